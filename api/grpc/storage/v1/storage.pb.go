@@ -7,11 +7,12 @@
 package storagepb
 
 import (
-	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
-	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
+
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 )
 
 const (
@@ -23,11 +24,13 @@ const (
 
 // UPLOAD FILE
 type UploadFileRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	FileName      string                 `protobuf:"bytes,2,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
-	FileSize      int64                  `protobuf:"varint,3,opt,name=file_size,json=fileSize,proto3" json:"file_size,omitempty"`
-	Content       []byte                 `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	UserId   string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	FileName string                 `protobuf:"bytes,2,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	FileSize int64                  `protobuf:"varint,3,opt,name=file_size,json=fileSize,proto3" json:"file_size,omitempty"`
+	Content  []byte                 `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
+	// Empty uploads to the top level.
+	FolderId      string `protobuf:"bytes,5,opt,name=folder_id,json=folderId,proto3" json:"folder_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -90,6 +93,13 @@ func (x *UploadFileRequest) GetContent() []byte {
 	return nil
 }
 
+func (x *UploadFileRequest) GetFolderId() string {
+	if x != nil {
+		return x.FolderId
+	}
+	return ""
+}
+
 type UploadFileResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
@@ -142,22 +152,6039 @@ func (x *UploadFileResponse) GetFileName() string {
 	return ""
 }
 
-var File_api_grpc_storage_v1_storage_proto protoreflect.FileDescriptor
+// DOWNLOAD FILE
+type DownloadFileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	FileId        string                 `protobuf:"bytes,2,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_api_grpc_storage_v1_storage_proto_rawDesc = "" +
-	"\n" +
-	"!api/grpc/storage/v1/storage.proto\x12\astorage\"\x80\x01\n" +
-	"\x11UploadFileRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
-	"\tfile_name\x18\x02 \x01(\tR\bfileName\x12\x1b\n" +
-	"\tfile_size\x18\x03 \x01(\x03R\bfileSize\x12\x18\n" +
-	"\acontent\x18\x04 \x01(\fR\acontent\"J\n" +
-	"\x12UploadFileResponse\x12\x17\n" +
-	"\afile_id\x18\x01 \x01(\tR\x06fileId\x12\x1b\n" +
-	"\tfile_name\x18\x02 \x01(\tR\bfileName2W\n" +
-	"\x0eStorageService\x12E\n" +
+func (x *DownloadFileRequest) Reset() {
+	*x = DownloadFileRequest{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadFileRequest) ProtoMessage() {}
+
+func (x *DownloadFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadFileRequest.ProtoReflect.Descriptor instead.
+func (*DownloadFileRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *DownloadFileRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *DownloadFileRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+// The first message of the stream carries the file metadata and no content;
+// every following message carries the next chunk of content.
+type DownloadFileResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileName      string                 `protobuf:"bytes,1,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	FileSize      int64                  `protobuf:"varint,2,opt,name=file_size,json=fileSize,proto3" json:"file_size,omitempty"`
+	Chunk         []byte                 `protobuf:"bytes,3,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadFileResponse) Reset() {
+	*x = DownloadFileResponse{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadFileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadFileResponse) ProtoMessage() {}
+
+func (x *DownloadFileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadFileResponse.ProtoReflect.Descriptor instead.
+func (*DownloadFileResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DownloadFileResponse) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *DownloadFileResponse) GetFileSize() int64 {
+	if x != nil {
+		return x.FileSize
+	}
+	return 0
+}
+
+func (x *DownloadFileResponse) GetChunk() []byte {
+	if x != nil {
+		return x.Chunk
+	}
+	return nil
+}
+
+// FOLDERS
+type Folder struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	FolderId string                 `protobuf:"bytes,1,opt,name=folder_id,json=folderId,proto3" json:"folder_id,omitempty"`
+	Name     string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Empty for top-level folders.
+	ParentId      string `protobuf:"bytes,3,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Folder) Reset() {
+	*x = Folder{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Folder) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Folder) ProtoMessage() {}
+
+func (x *Folder) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Folder.ProtoReflect.Descriptor instead.
+func (*Folder) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Folder) GetFolderId() string {
+	if x != nil {
+		return x.FolderId
+	}
+	return ""
+}
+
+func (x *Folder) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Folder) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+type FileInfo struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	FileId   string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	FileName string                 `protobuf:"bytes,2,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	FileSize int64                  `protobuf:"varint,3,opt,name=file_size,json=fileSize,proto3" json:"file_size,omitempty"`
+	// Empty for top-level files.
+	FolderId      string            `protobuf:"bytes,4,opt,name=folder_id,json=folderId,proto3" json:"folder_id,omitempty"`
+	ContentType   string            `protobuf:"bytes,5,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Tags          []string          `protobuf:"bytes,6,rep,name=tags,proto3" json:"tags,omitempty"`
+	Metadata      map[string]string `protobuf:"bytes,7,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	CreatedAtUnix int64             `protobuf:"varint,8,opt,name=created_at_unix,json=createdAtUnix,proto3" json:"created_at_unix,omitempty"`
+	UpdatedAtUnix int64             `protobuf:"varint,9,opt,name=updated_at_unix,json=updatedAtUnix,proto3" json:"updated_at_unix,omitempty"`
+	// Zero unless the file is in the trash.
+	DeletedAtUnix int64 `protobuf:"varint,10,opt,name=deleted_at_unix,json=deletedAtUnix,proto3" json:"deleted_at_unix,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileInfo) Reset() {
+	*x = FileInfo{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileInfo) ProtoMessage() {}
+
+func (x *FileInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileInfo.ProtoReflect.Descriptor instead.
+func (*FileInfo) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *FileInfo) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *FileInfo) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *FileInfo) GetFileSize() int64 {
+	if x != nil {
+		return x.FileSize
+	}
+	return 0
+}
+
+func (x *FileInfo) GetFolderId() string {
+	if x != nil {
+		return x.FolderId
+	}
+	return ""
+}
+
+func (x *FileInfo) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *FileInfo) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *FileInfo) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *FileInfo) GetCreatedAtUnix() int64 {
+	if x != nil {
+		return x.CreatedAtUnix
+	}
+	return 0
+}
+
+func (x *FileInfo) GetUpdatedAtUnix() int64 {
+	if x != nil {
+		return x.UpdatedAtUnix
+	}
+	return 0
+}
+
+func (x *FileInfo) GetDeletedAtUnix() int64 {
+	if x != nil {
+		return x.DeletedAtUnix
+	}
+	return 0
+}
+
+type CreateFolderRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name   string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Empty creates a top-level folder.
+	ParentId      string `protobuf:"bytes,3,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateFolderRequest) Reset() {
+	*x = CreateFolderRequest{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateFolderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateFolderRequest) ProtoMessage() {}
+
+func (x *CreateFolderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateFolderRequest.ProtoReflect.Descriptor instead.
+func (*CreateFolderRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CreateFolderRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CreateFolderRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateFolderRequest) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+type CreateFolderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Folder        *Folder                `protobuf:"bytes,1,opt,name=folder,proto3" json:"folder,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateFolderResponse) Reset() {
+	*x = CreateFolderResponse{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateFolderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateFolderResponse) ProtoMessage() {}
+
+func (x *CreateFolderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateFolderResponse.ProtoReflect.Descriptor instead.
+func (*CreateFolderResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CreateFolderResponse) GetFolder() *Folder {
+	if x != nil {
+		return x.Folder
+	}
+	return nil
+}
+
+type RenameFolderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	FolderId      string                 `protobuf:"bytes,2,opt,name=folder_id,json=folderId,proto3" json:"folder_id,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenameFolderRequest) Reset() {
+	*x = RenameFolderRequest{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenameFolderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenameFolderRequest) ProtoMessage() {}
+
+func (x *RenameFolderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenameFolderRequest.ProtoReflect.Descriptor instead.
+func (*RenameFolderRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RenameFolderRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RenameFolderRequest) GetFolderId() string {
+	if x != nil {
+		return x.FolderId
+	}
+	return ""
+}
+
+func (x *RenameFolderRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type RenameFolderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Folder        *Folder                `protobuf:"bytes,1,opt,name=folder,proto3" json:"folder,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenameFolderResponse) Reset() {
+	*x = RenameFolderResponse{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenameFolderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenameFolderResponse) ProtoMessage() {}
+
+func (x *RenameFolderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenameFolderResponse.ProtoReflect.Descriptor instead.
+func (*RenameFolderResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RenameFolderResponse) GetFolder() *Folder {
+	if x != nil {
+		return x.Folder
+	}
+	return nil
+}
+
+type MoveFolderRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	UserId   string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	FolderId string                 `protobuf:"bytes,2,opt,name=folder_id,json=folderId,proto3" json:"folder_id,omitempty"`
+	// Empty moves the folder to the top level.
+	ParentId      string `protobuf:"bytes,3,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveFolderRequest) Reset() {
+	*x = MoveFolderRequest{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveFolderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveFolderRequest) ProtoMessage() {}
+
+func (x *MoveFolderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveFolderRequest.ProtoReflect.Descriptor instead.
+func (*MoveFolderRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *MoveFolderRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *MoveFolderRequest) GetFolderId() string {
+	if x != nil {
+		return x.FolderId
+	}
+	return ""
+}
+
+func (x *MoveFolderRequest) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+type MoveFolderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Folder        *Folder                `protobuf:"bytes,1,opt,name=folder,proto3" json:"folder,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveFolderResponse) Reset() {
+	*x = MoveFolderResponse{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveFolderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveFolderResponse) ProtoMessage() {}
+
+func (x *MoveFolderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveFolderResponse.ProtoReflect.Descriptor instead.
+func (*MoveFolderResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *MoveFolderResponse) GetFolder() *Folder {
+	if x != nil {
+		return x.Folder
+	}
+	return nil
+}
+
+type DeleteFolderRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	UserId   string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	FolderId string                 `protobuf:"bytes,2,opt,name=folder_id,json=folderId,proto3" json:"folder_id,omitempty"`
+	// A folder that still holds folders or files is only deleted, along with
+	// everything it holds, when recursive is set.
+	Recursive     bool `protobuf:"varint,3,opt,name=recursive,proto3" json:"recursive,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteFolderRequest) Reset() {
+	*x = DeleteFolderRequest{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteFolderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteFolderRequest) ProtoMessage() {}
+
+func (x *DeleteFolderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteFolderRequest.ProtoReflect.Descriptor instead.
+func (*DeleteFolderRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DeleteFolderRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *DeleteFolderRequest) GetFolderId() string {
+	if x != nil {
+		return x.FolderId
+	}
+	return ""
+}
+
+func (x *DeleteFolderRequest) GetRecursive() bool {
+	if x != nil {
+		return x.Recursive
+	}
+	return false
+}
+
+type DeleteFolderResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	DeletedFolders int32                  `protobuf:"varint,1,opt,name=deleted_folders,json=deletedFolders,proto3" json:"deleted_folders,omitempty"`
+	DeletedFiles   int32                  `protobuf:"varint,2,opt,name=deleted_files,json=deletedFiles,proto3" json:"deleted_files,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DeleteFolderResponse) Reset() {
+	*x = DeleteFolderResponse{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteFolderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteFolderResponse) ProtoMessage() {}
+
+func (x *DeleteFolderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteFolderResponse.ProtoReflect.Descriptor instead.
+func (*DeleteFolderResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *DeleteFolderResponse) GetDeletedFolders() int32 {
+	if x != nil {
+		return x.DeletedFolders
+	}
+	return 0
+}
+
+func (x *DeleteFolderResponse) GetDeletedFiles() int32 {
+	if x != nil {
+		return x.DeletedFiles
+	}
+	return 0
+}
+
+type ListFolderRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Empty lists the top level.
+	FolderId      string `protobuf:"bytes,2,opt,name=folder_id,json=folderId,proto3" json:"folder_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFolderRequest) Reset() {
+	*x = ListFolderRequest{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFolderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFolderRequest) ProtoMessage() {}
+
+func (x *ListFolderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFolderRequest.ProtoReflect.Descriptor instead.
+func (*ListFolderRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListFolderRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListFolderRequest) GetFolderId() string {
+	if x != nil {
+		return x.FolderId
+	}
+	return ""
+}
+
+type ListFolderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Folders       []*Folder              `protobuf:"bytes,1,rep,name=folders,proto3" json:"folders,omitempty"`
+	Files         []*FileInfo            `protobuf:"bytes,2,rep,name=files,proto3" json:"files,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFolderResponse) Reset() {
+	*x = ListFolderResponse{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFolderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFolderResponse) ProtoMessage() {}
+
+func (x *ListFolderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFolderResponse.ProtoReflect.Descriptor instead.
+func (*ListFolderResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListFolderResponse) GetFolders() []*Folder {
+	if x != nil {
+		return x.Folders
+	}
+	return nil
+}
+
+func (x *ListFolderResponse) GetFiles() []*FileInfo {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+type MoveFileRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	FileId string                 `protobuf:"bytes,2,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	// Empty moves the file to the top level.
+	FolderId      string `protobuf:"bytes,3,opt,name=folder_id,json=folderId,proto3" json:"folder_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveFileRequest) Reset() {
+	*x = MoveFileRequest{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveFileRequest) ProtoMessage() {}
+
+func (x *MoveFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveFileRequest.ProtoReflect.Descriptor instead.
+func (*MoveFileRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *MoveFileRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *MoveFileRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *MoveFileRequest) GetFolderId() string {
+	if x != nil {
+		return x.FolderId
+	}
+	return ""
+}
+
+type MoveFileResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	File          *FileInfo              `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveFileResponse) Reset() {
+	*x = MoveFileResponse{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveFileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveFileResponse) ProtoMessage() {}
+
+func (x *MoveFileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveFileResponse.ProtoReflect.Descriptor instead.
+func (*MoveFileResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *MoveFileResponse) GetFile() *FileInfo {
+	if x != nil {
+		return x.File
+	}
+	return nil
+}
+
+// TAGS AND METADATA
+type AddFileTagsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	FileId        string                 `protobuf:"bytes,2,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	Tags          []string               `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddFileTagsRequest) Reset() {
+	*x = AddFileTagsRequest{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddFileTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddFileTagsRequest) ProtoMessage() {}
+
+func (x *AddFileTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddFileTagsRequest.ProtoReflect.Descriptor instead.
+func (*AddFileTagsRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *AddFileTagsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AddFileTagsRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *AddFileTagsRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type AddFileTagsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	File          *FileInfo              `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddFileTagsResponse) Reset() {
+	*x = AddFileTagsResponse{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddFileTagsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddFileTagsResponse) ProtoMessage() {}
+
+func (x *AddFileTagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddFileTagsResponse.ProtoReflect.Descriptor instead.
+func (*AddFileTagsResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *AddFileTagsResponse) GetFile() *FileInfo {
+	if x != nil {
+		return x.File
+	}
+	return nil
+}
+
+type RemoveFileTagsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	FileId        string                 `protobuf:"bytes,2,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	Tags          []string               `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveFileTagsRequest) Reset() {
+	*x = RemoveFileTagsRequest{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveFileTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveFileTagsRequest) ProtoMessage() {}
+
+func (x *RemoveFileTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveFileTagsRequest.ProtoReflect.Descriptor instead.
+func (*RemoveFileTagsRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RemoveFileTagsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RemoveFileTagsRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *RemoveFileTagsRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type RemoveFileTagsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	File          *FileInfo              `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveFileTagsResponse) Reset() {
+	*x = RemoveFileTagsResponse{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveFileTagsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveFileTagsResponse) ProtoMessage() {}
+
+func (x *RemoveFileTagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveFileTagsResponse.ProtoReflect.Descriptor instead.
+func (*RemoveFileTagsResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *RemoveFileTagsResponse) GetFile() *FileInfo {
+	if x != nil {
+		return x.File
+	}
+	return nil
+}
+
+type SetFileMetadataRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	FileId string                 `protobuf:"bytes,2,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	// Merged into the metadata of the file, replacing the values of existing
+	// keys.
+	Metadata      map[string]string `protobuf:"bytes,3,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetFileMetadataRequest) Reset() {
+	*x = SetFileMetadataRequest{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetFileMetadataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetFileMetadataRequest) ProtoMessage() {}
+
+func (x *SetFileMetadataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetFileMetadataRequest.ProtoReflect.Descriptor instead.
+func (*SetFileMetadataRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *SetFileMetadataRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SetFileMetadataRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *SetFileMetadataRequest) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type SetFileMetadataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	File          *FileInfo              `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetFileMetadataResponse) Reset() {
+	*x = SetFileMetadataResponse{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetFileMetadataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetFileMetadataResponse) ProtoMessage() {}
+
+func (x *SetFileMetadataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetFileMetadataResponse.ProtoReflect.Descriptor instead.
+func (*SetFileMetadataResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *SetFileMetadataResponse) GetFile() *FileInfo {
+	if x != nil {
+		return x.File
+	}
+	return nil
+}
+
+type RemoveFileMetadataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	FileId        string                 `protobuf:"bytes,2,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	Keys          []string               `protobuf:"bytes,3,rep,name=keys,proto3" json:"keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveFileMetadataRequest) Reset() {
+	*x = RemoveFileMetadataRequest{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveFileMetadataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveFileMetadataRequest) ProtoMessage() {}
+
+func (x *RemoveFileMetadataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveFileMetadataRequest.ProtoReflect.Descriptor instead.
+func (*RemoveFileMetadataRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *RemoveFileMetadataRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RemoveFileMetadataRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *RemoveFileMetadataRequest) GetKeys() []string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+type RemoveFileMetadataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	File          *FileInfo              `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveFileMetadataResponse) Reset() {
+	*x = RemoveFileMetadataResponse{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveFileMetadataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveFileMetadataResponse) ProtoMessage() {}
+
+func (x *RemoveFileMetadataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveFileMetadataResponse.ProtoReflect.Descriptor instead.
+func (*RemoveFileMetadataResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *RemoveFileMetadataResponse) GetFile() *FileInfo {
+	if x != nil {
+		return x.File
+	}
+	return nil
+}
+
+// LIST FILES
+// Every set filter must match. Unset filters match every file.
+type ListFilesRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Restricts the listing to the files directly inside the folder. Empty
+	// lists the files of every folder.
+	FolderId string `protobuf:"bytes,2,opt,name=folder_id,json=folderId,proto3" json:"folder_id,omitempty"`
+	// Files must carry all of the tags.
+	Tags []string `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
+	// Files must hold all of the keys with the given values.
+	Metadata map[string]string `protobuf:"bytes,4,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// An exact type such as "application/pdf" or a wildcard such as "image/*".
+	ContentType string `protobuf:"bytes,5,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	// Size bounds in bytes, both inclusive.
+	MinSize *int64 `protobuf:"varint,6,opt,name=min_size,json=minSize,proto3,oneof" json:"min_size,omitempty"`
+	MaxSize *int64 `protobuf:"varint,7,opt,name=max_size,json=maxSize,proto3,oneof" json:"max_size,omitempty"`
+	// Creation time bounds in unix seconds, both inclusive.
+	CreatedAfterUnix  *int64 `protobuf:"varint,8,opt,name=created_after_unix,json=createdAfterUnix,proto3,oneof" json:"created_after_unix,omitempty"`
+	CreatedBeforeUnix *int64 `protobuf:"varint,9,opt,name=created_before_unix,json=createdBeforeUnix,proto3,oneof" json:"created_before_unix,omitempty"`
+	// Maximum number of files of the page. Zero asks for the default size and
+	// larger sizes than the maximum are lowered to it.
+	PageSize int32 `protobuf:"varint,10,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// The next_page_token of the previous page, empty for the first page. It
+	// is only valid with the same sort and descending values.
+	PageToken string `protobuf:"bytes,11,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// One of "name" (the default), "size", "created" or "updated".
+	Sort          string `protobuf:"bytes,12,opt,name=sort,proto3" json:"sort,omitempty"`
+	Descending    bool   `protobuf:"varint,13,opt,name=descending,proto3" json:"descending,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFilesRequest) Reset() {
+	*x = ListFilesRequest{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFilesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFilesRequest) ProtoMessage() {}
+
+func (x *ListFilesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFilesRequest.ProtoReflect.Descriptor instead.
+func (*ListFilesRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListFilesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListFilesRequest) GetFolderId() string {
+	if x != nil {
+		return x.FolderId
+	}
+	return ""
+}
+
+func (x *ListFilesRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *ListFilesRequest) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *ListFilesRequest) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *ListFilesRequest) GetMinSize() int64 {
+	if x != nil && x.MinSize != nil {
+		return *x.MinSize
+	}
+	return 0
+}
+
+func (x *ListFilesRequest) GetMaxSize() int64 {
+	if x != nil && x.MaxSize != nil {
+		return *x.MaxSize
+	}
+	return 0
+}
+
+func (x *ListFilesRequest) GetCreatedAfterUnix() int64 {
+	if x != nil && x.CreatedAfterUnix != nil {
+		return *x.CreatedAfterUnix
+	}
+	return 0
+}
+
+func (x *ListFilesRequest) GetCreatedBeforeUnix() int64 {
+	if x != nil && x.CreatedBeforeUnix != nil {
+		return *x.CreatedBeforeUnix
+	}
+	return 0
+}
+
+func (x *ListFilesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListFilesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListFilesRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListFilesRequest) GetDescending() bool {
+	if x != nil {
+		return x.Descending
+	}
+	return false
+}
+
+type ListFilesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Files []*FileInfo            `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty"`
+	// Empty on the last page.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFilesResponse) Reset() {
+	*x = ListFilesResponse{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFilesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFilesResponse) ProtoMessage() {}
+
+func (x *ListFilesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFilesResponse.ProtoReflect.Descriptor instead.
+func (*ListFilesResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ListFilesResponse) GetFiles() []*FileInfo {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+func (x *ListFilesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// Moves a file into the trash.
+type DeleteFileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	FileId        string                 `protobuf:"bytes,2,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteFileRequest) Reset() {
+	*x = DeleteFileRequest{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteFileRequest) ProtoMessage() {}
+
+func (x *DeleteFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteFileRequest.ProtoReflect.Descriptor instead.
+func (*DeleteFileRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *DeleteFileRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *DeleteFileRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+type DeleteFileResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	File          *FileInfo              `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteFileResponse) Reset() {
+	*x = DeleteFileResponse{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteFileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteFileResponse) ProtoMessage() {}
+
+func (x *DeleteFileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteFileResponse.ProtoReflect.Descriptor instead.
+func (*DeleteFileResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *DeleteFileResponse) GetFile() *FileInfo {
+	if x != nil {
+		return x.File
+	}
+	return nil
+}
+
+// Lists the trash, most recently deleted first.
+type ListTrashRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTrashRequest) Reset() {
+	*x = ListTrashRequest{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTrashRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTrashRequest) ProtoMessage() {}
+
+func (x *ListTrashRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTrashRequest.ProtoReflect.Descriptor instead.
+func (*ListTrashRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ListTrashRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListTrashRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListTrashRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListTrashResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Files         []*FileInfo            `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTrashResponse) Reset() {
+	*x = ListTrashResponse{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTrashResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTrashResponse) ProtoMessage() {}
+
+func (x *ListTrashResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTrashResponse.ProtoReflect.Descriptor instead.
+func (*ListTrashResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ListTrashResponse) GetFiles() []*FileInfo {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+func (x *ListTrashResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// Takes a file out of the trash, back into its folder or to the top level
+// when the folder was deleted since.
+type RestoreFileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	FileId        string                 `protobuf:"bytes,2,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreFileRequest) Reset() {
+	*x = RestoreFileRequest{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreFileRequest) ProtoMessage() {}
+
+func (x *RestoreFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreFileRequest.ProtoReflect.Descriptor instead.
+func (*RestoreFileRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *RestoreFileRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RestoreFileRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+type RestoreFileResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	File          *FileInfo              `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreFileResponse) Reset() {
+	*x = RestoreFileResponse{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreFileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreFileResponse) ProtoMessage() {}
+
+func (x *RestoreFileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreFileResponse.ProtoReflect.Descriptor instead.
+func (*RestoreFileResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *RestoreFileResponse) GetFile() *FileInfo {
+	if x != nil {
+		return x.File
+	}
+	return nil
+}
+
+// Deletes every file of the trash for good, along with its content.
+type EmptyTrashRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmptyTrashRequest) Reset() {
+	*x = EmptyTrashRequest{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmptyTrashRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmptyTrashRequest) ProtoMessage() {}
+
+func (x *EmptyTrashRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmptyTrashRequest.ProtoReflect.Descriptor instead.
+func (*EmptyTrashRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *EmptyTrashRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type EmptyTrashResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PurgedFiles   int32                  `protobuf:"varint,1,opt,name=purged_files,json=purgedFiles,proto3" json:"purged_files,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmptyTrashResponse) Reset() {
+	*x = EmptyTrashResponse{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmptyTrashResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmptyTrashResponse) ProtoMessage() {}
+
+func (x *EmptyTrashResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmptyTrashResponse.ProtoReflect.Descriptor instead.
+func (*EmptyTrashResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *EmptyTrashResponse) GetPurgedFiles() int32 {
+	if x != nil {
+		return x.PurgedFiles
+	}
+	return 0
+}
+
+// A role granted to a user on a file or folder of another user.
+type ShareInfo struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// "file" or "folder".
+	ResourceType string `protobuf:"bytes,1,opt,name=resource_type,json=resourceType,proto3" json:"resource_type,omitempty"`
+	ResourceId   string `protobuf:"bytes,2,opt,name=resource_id,json=resourceId,proto3" json:"resource_id,omitempty"`
+	OwnerId      string `protobuf:"bytes,3,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	UserId       string `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// "viewer", "commenter" or "editor".
+	Role          string `protobuf:"bytes,5,opt,name=role,proto3" json:"role,omitempty"`
+	CreatedAtUnix int64  `protobuf:"varint,6,opt,name=created_at_unix,json=createdAtUnix,proto3" json:"created_at_unix,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShareInfo) Reset() {
+	*x = ShareInfo{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShareInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShareInfo) ProtoMessage() {}
+
+func (x *ShareInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShareInfo.ProtoReflect.Descriptor instead.
+func (*ShareInfo) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ShareInfo) GetResourceType() string {
+	if x != nil {
+		return x.ResourceType
+	}
+	return ""
+}
+
+func (x *ShareInfo) GetResourceId() string {
+	if x != nil {
+		return x.ResourceId
+	}
+	return ""
+}
+
+func (x *ShareInfo) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+func (x *ShareInfo) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ShareInfo) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *ShareInfo) GetCreatedAtUnix() int64 {
+	if x != nil {
+		return x.CreatedAtUnix
+	}
+	return 0
+}
+
+// Grants grantee_id a role on a file or folder of user_id, replacing the
+// role the grantee had on it. A role on a folder extends to everything the
+// folder holds.
+type ShareRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ResourceType  string                 `protobuf:"bytes,2,opt,name=resource_type,json=resourceType,proto3" json:"resource_type,omitempty"`
+	ResourceId    string                 `protobuf:"bytes,3,opt,name=resource_id,json=resourceId,proto3" json:"resource_id,omitempty"`
+	GranteeId     string                 `protobuf:"bytes,4,opt,name=grantee_id,json=granteeId,proto3" json:"grantee_id,omitempty"`
+	Role          string                 `protobuf:"bytes,5,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShareRequest) Reset() {
+	*x = ShareRequest{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShareRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShareRequest) ProtoMessage() {}
+
+func (x *ShareRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShareRequest.ProtoReflect.Descriptor instead.
+func (*ShareRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ShareRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ShareRequest) GetResourceType() string {
+	if x != nil {
+		return x.ResourceType
+	}
+	return ""
+}
+
+func (x *ShareRequest) GetResourceId() string {
+	if x != nil {
+		return x.ResourceId
+	}
+	return ""
+}
+
+func (x *ShareRequest) GetGranteeId() string {
+	if x != nil {
+		return x.GranteeId
+	}
+	return ""
+}
+
+func (x *ShareRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type ShareResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Share         *ShareInfo             `protobuf:"bytes,1,opt,name=share,proto3" json:"share,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShareResponse) Reset() {
+	*x = ShareResponse{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShareResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShareResponse) ProtoMessage() {}
+
+func (x *ShareResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShareResponse.ProtoReflect.Descriptor instead.
+func (*ShareResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ShareResponse) GetShare() *ShareInfo {
+	if x != nil {
+		return x.Share
+	}
+	return nil
+}
+
+type UnshareRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ResourceType  string                 `protobuf:"bytes,2,opt,name=resource_type,json=resourceType,proto3" json:"resource_type,omitempty"`
+	ResourceId    string                 `protobuf:"bytes,3,opt,name=resource_id,json=resourceId,proto3" json:"resource_id,omitempty"`
+	GranteeId     string                 `protobuf:"bytes,4,opt,name=grantee_id,json=granteeId,proto3" json:"grantee_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnshareRequest) Reset() {
+	*x = UnshareRequest{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnshareRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnshareRequest) ProtoMessage() {}
+
+func (x *UnshareRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnshareRequest.ProtoReflect.Descriptor instead.
+func (*UnshareRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *UnshareRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UnshareRequest) GetResourceType() string {
+	if x != nil {
+		return x.ResourceType
+	}
+	return ""
+}
+
+func (x *UnshareRequest) GetResourceId() string {
+	if x != nil {
+		return x.ResourceId
+	}
+	return ""
+}
+
+func (x *UnshareRequest) GetGranteeId() string {
+	if x != nil {
+		return x.GranteeId
+	}
+	return ""
+}
+
+type UnshareResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnshareResponse) Reset() {
+	*x = UnshareResponse{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnshareResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnshareResponse) ProtoMessage() {}
+
+func (x *UnshareResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnshareResponse.ProtoReflect.Descriptor instead.
+func (*UnshareResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{40}
+}
+
+// Lists the roles granted on a file or folder of user_id.
+type ListSharesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ResourceType  string                 `protobuf:"bytes,2,opt,name=resource_type,json=resourceType,proto3" json:"resource_type,omitempty"`
+	ResourceId    string                 `protobuf:"bytes,3,opt,name=resource_id,json=resourceId,proto3" json:"resource_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSharesRequest) Reset() {
+	*x = ListSharesRequest{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSharesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSharesRequest) ProtoMessage() {}
+
+func (x *ListSharesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSharesRequest.ProtoReflect.Descriptor instead.
+func (*ListSharesRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ListSharesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListSharesRequest) GetResourceType() string {
+	if x != nil {
+		return x.ResourceType
+	}
+	return ""
+}
+
+func (x *ListSharesRequest) GetResourceId() string {
+	if x != nil {
+		return x.ResourceId
+	}
+	return ""
+}
+
+type ListSharesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Shares        []*ShareInfo           `protobuf:"bytes,1,rep,name=shares,proto3" json:"shares,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSharesResponse) Reset() {
+	*x = ListSharesResponse{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSharesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSharesResponse) ProtoMessage() {}
+
+func (x *ListSharesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSharesResponse.ProtoReflect.Descriptor instead.
+func (*ListSharesResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ListSharesResponse) GetShares() []*ShareInfo {
+	if x != nil {
+		return x.Shares
+	}
+	return nil
+}
+
+// Lists the files and folders shared with user_id, most recently shared
+// first.
+type ListSharedWithMeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSharedWithMeRequest) Reset() {
+	*x = ListSharedWithMeRequest{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSharedWithMeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSharedWithMeRequest) ProtoMessage() {}
+
+func (x *ListSharedWithMeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSharedWithMeRequest.ProtoReflect.Descriptor instead.
+func (*ListSharedWithMeRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ListSharedWithMeRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// A file or folder shared with a user. Exactly one of file and folder is
+// set.
+type SharedItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Share         *ShareInfo             `protobuf:"bytes,1,opt,name=share,proto3" json:"share,omitempty"`
+	File          *FileInfo              `protobuf:"bytes,2,opt,name=file,proto3" json:"file,omitempty"`
+	Folder        *Folder                `protobuf:"bytes,3,opt,name=folder,proto3" json:"folder,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SharedItem) Reset() {
+	*x = SharedItem{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SharedItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedItem) ProtoMessage() {}
+
+func (x *SharedItem) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedItem.ProtoReflect.Descriptor instead.
+func (*SharedItem) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *SharedItem) GetShare() *ShareInfo {
+	if x != nil {
+		return x.Share
+	}
+	return nil
+}
+
+func (x *SharedItem) GetFile() *FileInfo {
+	if x != nil {
+		return x.File
+	}
+	return nil
+}
+
+func (x *SharedItem) GetFolder() *Folder {
+	if x != nil {
+		return x.Folder
+	}
+	return nil
+}
+
+type ListSharedWithMeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*SharedItem          `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSharedWithMeResponse) Reset() {
+	*x = ListSharedWithMeResponse{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSharedWithMeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSharedWithMeResponse) ProtoMessage() {}
+
+func (x *ListSharedWithMeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSharedWithMeResponse.ProtoReflect.Descriptor instead.
+func (*ListSharedWithMeResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ListSharedWithMeResponse) GetItems() []*SharedItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+// A public link to a file, usable without an account.
+type ShareLinkInfo struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	LinkId      string                 `protobuf:"bytes,1,opt,name=link_id,json=linkId,proto3" json:"link_id,omitempty"`
+	FileId      string                 `protobuf:"bytes,2,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	OwnerId     string                 `protobuf:"bytes,3,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	HasPassword bool                   `protobuf:"varint,4,opt,name=has_password,json=hasPassword,proto3" json:"has_password,omitempty"`
+	// Zero when the link does not expire.
+	ExpiresAtUnix int64 `protobuf:"varint,5,opt,name=expires_at_unix,json=expiresAtUnix,proto3" json:"expires_at_unix,omitempty"`
+	// Zero when downloads are not limited.
+	MaxDownloads  int32 `protobuf:"varint,6,opt,name=max_downloads,json=maxDownloads,proto3" json:"max_downloads,omitempty"`
+	DownloadCount int32 `protobuf:"varint,7,opt,name=download_count,json=downloadCount,proto3" json:"download_count,omitempty"`
+	// Zero unless the link was revoked.
+	RevokedAtUnix int64 `protobuf:"varint,8,opt,name=revoked_at_unix,json=revokedAtUnix,proto3" json:"revoked_at_unix,omitempty"`
+	CreatedAtUnix int64 `protobuf:"varint,9,opt,name=created_at_unix,json=createdAtUnix,proto3" json:"created_at_unix,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShareLinkInfo) Reset() {
+	*x = ShareLinkInfo{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShareLinkInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShareLinkInfo) ProtoMessage() {}
+
+func (x *ShareLinkInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShareLinkInfo.ProtoReflect.Descriptor instead.
+func (*ShareLinkInfo) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ShareLinkInfo) GetLinkId() string {
+	if x != nil {
+		return x.LinkId
+	}
+	return ""
+}
+
+func (x *ShareLinkInfo) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *ShareLinkInfo) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
+func (x *ShareLinkInfo) GetHasPassword() bool {
+	if x != nil {
+		return x.HasPassword
+	}
+	return false
+}
+
+func (x *ShareLinkInfo) GetExpiresAtUnix() int64 {
+	if x != nil {
+		return x.ExpiresAtUnix
+	}
+	return 0
+}
+
+func (x *ShareLinkInfo) GetMaxDownloads() int32 {
+	if x != nil {
+		return x.MaxDownloads
+	}
+	return 0
+}
+
+func (x *ShareLinkInfo) GetDownloadCount() int32 {
+	if x != nil {
+		return x.DownloadCount
+	}
+	return 0
+}
+
+func (x *ShareLinkInfo) GetRevokedAtUnix() int64 {
+	if x != nil {
+		return x.RevokedAtUnix
+	}
+	return 0
+}
+
+func (x *ShareLinkInfo) GetCreatedAtUnix() int64 {
+	if x != nil {
+		return x.CreatedAtUnix
+	}
+	return 0
+}
+
+// Creates a link to a file of user_id. The token of the link is only ever
+// returned here.
+type CreateShareLinkRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	FileId string                 `protobuf:"bytes,2,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	// Empty for a link without password.
+	Password      string `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"`
+	ExpiresAtUnix int64  `protobuf:"varint,4,opt,name=expires_at_unix,json=expiresAtUnix,proto3" json:"expires_at_unix,omitempty"`
+	MaxDownloads  int32  `protobuf:"varint,5,opt,name=max_downloads,json=maxDownloads,proto3" json:"max_downloads,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateShareLinkRequest) Reset() {
+	*x = CreateShareLinkRequest{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateShareLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateShareLinkRequest) ProtoMessage() {}
+
+func (x *CreateShareLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateShareLinkRequest.ProtoReflect.Descriptor instead.
+func (*CreateShareLinkRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *CreateShareLinkRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CreateShareLinkRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *CreateShareLinkRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *CreateShareLinkRequest) GetExpiresAtUnix() int64 {
+	if x != nil {
+		return x.ExpiresAtUnix
+	}
+	return 0
+}
+
+func (x *CreateShareLinkRequest) GetMaxDownloads() int32 {
+	if x != nil {
+		return x.MaxDownloads
+	}
+	return 0
+}
+
+type CreateShareLinkResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Link          *ShareLinkInfo         `protobuf:"bytes,1,opt,name=link,proto3" json:"link,omitempty"`
+	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateShareLinkResponse) Reset() {
+	*x = CreateShareLinkResponse{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateShareLinkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateShareLinkResponse) ProtoMessage() {}
+
+func (x *CreateShareLinkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateShareLinkResponse.ProtoReflect.Descriptor instead.
+func (*CreateShareLinkResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *CreateShareLinkResponse) GetLink() *ShareLinkInfo {
+	if x != nil {
+		return x.Link
+	}
+	return nil
+}
+
+func (x *CreateShareLinkResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+// Lists the links to a file of user_id, newest first.
+type ListShareLinksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	FileId        string                 `protobuf:"bytes,2,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListShareLinksRequest) Reset() {
+	*x = ListShareLinksRequest{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListShareLinksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListShareLinksRequest) ProtoMessage() {}
+
+func (x *ListShareLinksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListShareLinksRequest.ProtoReflect.Descriptor instead.
+func (*ListShareLinksRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *ListShareLinksRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListShareLinksRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+type ListShareLinksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Links         []*ShareLinkInfo       `protobuf:"bytes,1,rep,name=links,proto3" json:"links,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListShareLinksResponse) Reset() {
+	*x = ListShareLinksResponse{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListShareLinksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListShareLinksResponse) ProtoMessage() {}
+
+func (x *ListShareLinksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListShareLinksResponse.ProtoReflect.Descriptor instead.
+func (*ListShareLinksResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *ListShareLinksResponse) GetLinks() []*ShareLinkInfo {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+type RevokeShareLinkRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	LinkId        string                 `protobuf:"bytes,2,opt,name=link_id,json=linkId,proto3" json:"link_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeShareLinkRequest) Reset() {
+	*x = RevokeShareLinkRequest{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeShareLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeShareLinkRequest) ProtoMessage() {}
+
+func (x *RevokeShareLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeShareLinkRequest.ProtoReflect.Descriptor instead.
+func (*RevokeShareLinkRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *RevokeShareLinkRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RevokeShareLinkRequest) GetLinkId() string {
+	if x != nil {
+		return x.LinkId
+	}
+	return ""
+}
+
+type RevokeShareLinkResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Link          *ShareLinkInfo         `protobuf:"bytes,1,opt,name=link,proto3" json:"link,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeShareLinkResponse) Reset() {
+	*x = RevokeShareLinkResponse{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeShareLinkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeShareLinkResponse) ProtoMessage() {}
+
+func (x *RevokeShareLinkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeShareLinkResponse.ProtoReflect.Descriptor instead.
+func (*RevokeShareLinkResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *RevokeShareLinkResponse) GetLink() *ShareLinkInfo {
+	if x != nil {
+		return x.Link
+	}
+	return nil
+}
+
+// Downloads the file of a share link. The response stream is the same as
+// the one of DownloadFile. remote_addr and user_agent describe the client
+// for the record of the access.
+type DownloadSharedFileRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	RemoteAddr    string                 `protobuf:"bytes,3,opt,name=remote_addr,json=remoteAddr,proto3" json:"remote_addr,omitempty"`
+	UserAgent     string                 `protobuf:"bytes,4,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadSharedFileRequest) Reset() {
+	*x = DownloadSharedFileRequest{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadSharedFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadSharedFileRequest) ProtoMessage() {}
+
+func (x *DownloadSharedFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadSharedFileRequest.ProtoReflect.Descriptor instead.
+func (*DownloadSharedFileRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *DownloadSharedFileRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *DownloadSharedFileRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *DownloadSharedFileRequest) GetRemoteAddr() string {
+	if x != nil {
+		return x.RemoteAddr
+	}
+	return ""
+}
+
+func (x *DownloadSharedFileRequest) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+// Searches the contents of the documents user_id can read. Results are
+// ordered by rank, and the snippet marks the matched terms with <mark>.
+// A limit of zero returns the default number of results.
+type SearchDocumentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Query         string                 `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchDocumentsRequest) Reset() {
+	*x = SearchDocumentsRequest{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchDocumentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchDocumentsRequest) ProtoMessage() {}
+
+func (x *SearchDocumentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchDocumentsRequest.ProtoReflect.Descriptor instead.
+func (*SearchDocumentsRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *SearchDocumentsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SearchDocumentsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchDocumentsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type SearchHit struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	File          *FileInfo              `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	Rank          float64                `protobuf:"fixed64,2,opt,name=rank,proto3" json:"rank,omitempty"`
+	Snippet       string                 `protobuf:"bytes,3,opt,name=snippet,proto3" json:"snippet,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchHit) Reset() {
+	*x = SearchHit{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchHit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchHit) ProtoMessage() {}
+
+func (x *SearchHit) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchHit.ProtoReflect.Descriptor instead.
+func (*SearchHit) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *SearchHit) GetFile() *FileInfo {
+	if x != nil {
+		return x.File
+	}
+	return nil
+}
+
+func (x *SearchHit) GetRank() float64 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *SearchHit) GetSnippet() string {
+	if x != nil {
+		return x.Snippet
+	}
+	return ""
+}
+
+type SearchDocumentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hits          []*SearchHit           `protobuf:"bytes,1,rep,name=hits,proto3" json:"hits,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchDocumentsResponse) Reset() {
+	*x = SearchDocumentsResponse{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchDocumentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchDocumentsResponse) ProtoMessage() {}
+
+func (x *SearchDocumentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchDocumentsResponse.ProtoReflect.Descriptor instead.
+func (*SearchDocumentsResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *SearchDocumentsResponse) GetHits() []*SearchHit {
+	if x != nil {
+		return x.Hits
+	}
+	return nil
+}
+
+// Returns the structure of a document: its outline, statistics, images,
+// tables, language, fonts and properties. Only DOCX, ODT and Markdown
+// documents can be analyzed.
+type AnalyzeDocumentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	FileId        string                 `protobuf:"bytes,2,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnalyzeDocumentRequest) Reset() {
+	*x = AnalyzeDocumentRequest{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnalyzeDocumentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnalyzeDocumentRequest) ProtoMessage() {}
+
+func (x *AnalyzeDocumentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnalyzeDocumentRequest.ProtoReflect.Descriptor instead.
+func (*AnalyzeDocumentRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *AnalyzeDocumentRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AnalyzeDocumentRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+type Heading struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Level         int32                  `protobuf:"varint,1,opt,name=level,proto3" json:"level,omitempty"`
+	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	Children      []*Heading             `protobuf:"bytes,3,rep,name=children,proto3" json:"children,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Heading) Reset() {
+	*x = Heading{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Heading) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Heading) ProtoMessage() {}
+
+func (x *Heading) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Heading.ProtoReflect.Descriptor instead.
+func (*Heading) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *Heading) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *Heading) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Heading) GetChildren() []*Heading {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+// Statistics of the text of a document. pages is estimated from the words
+// when the document does not record its page count.
+type DocumentStatistics struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Words              int64                  `protobuf:"varint,1,opt,name=words,proto3" json:"words,omitempty"`
+	Characters         int64                  `protobuf:"varint,2,opt,name=characters,proto3" json:"characters,omitempty"`
+	CharactersNoSpaces int64                  `protobuf:"varint,3,opt,name=characters_no_spaces,json=charactersNoSpaces,proto3" json:"characters_no_spaces,omitempty"`
+	Paragraphs         int64                  `protobuf:"varint,4,opt,name=paragraphs,proto3" json:"paragraphs,omitempty"`
+	Pages              int64                  `protobuf:"varint,5,opt,name=pages,proto3" json:"pages,omitempty"`
+	PagesEstimated     bool                   `protobuf:"varint,6,opt,name=pages_estimated,json=pagesEstimated,proto3" json:"pages_estimated,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *DocumentStatistics) Reset() {
+	*x = DocumentStatistics{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DocumentStatistics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DocumentStatistics) ProtoMessage() {}
+
+func (x *DocumentStatistics) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DocumentStatistics.ProtoReflect.Descriptor instead.
+func (*DocumentStatistics) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *DocumentStatistics) GetWords() int64 {
+	if x != nil {
+		return x.Words
+	}
+	return 0
+}
+
+func (x *DocumentStatistics) GetCharacters() int64 {
+	if x != nil {
+		return x.Characters
+	}
+	return 0
+}
+
+func (x *DocumentStatistics) GetCharactersNoSpaces() int64 {
+	if x != nil {
+		return x.CharactersNoSpaces
+	}
+	return 0
+}
+
+func (x *DocumentStatistics) GetParagraphs() int64 {
+	if x != nil {
+		return x.Paragraphs
+	}
+	return 0
+}
+
+func (x *DocumentStatistics) GetPages() int64 {
+	if x != nil {
+		return x.Pages
+	}
+	return 0
+}
+
+func (x *DocumentStatistics) GetPagesEstimated() bool {
+	if x != nil {
+		return x.PagesEstimated
+	}
+	return false
+}
+
+type DocumentImage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Size          int64                  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DocumentImage) Reset() {
+	*x = DocumentImage{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DocumentImage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DocumentImage) ProtoMessage() {}
+
+func (x *DocumentImage) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DocumentImage.ProtoReflect.Descriptor instead.
+func (*DocumentImage) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *DocumentImage) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DocumentImage) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *DocumentImage) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *DocumentImage) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type DocumentTable struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rows          int32                  `protobuf:"varint,1,opt,name=rows,proto3" json:"rows,omitempty"`
+	Columns       int32                  `protobuf:"varint,2,opt,name=columns,proto3" json:"columns,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DocumentTable) Reset() {
+	*x = DocumentTable{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DocumentTable) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DocumentTable) ProtoMessage() {}
+
+func (x *DocumentTable) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DocumentTable.ProtoReflect.Descriptor instead.
+func (*DocumentTable) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *DocumentTable) GetRows() int32 {
+	if x != nil {
+		return x.Rows
+	}
+	return 0
+}
+
+func (x *DocumentTable) GetColumns() int32 {
+	if x != nil {
+		return x.Columns
+	}
+	return 0
+}
+
+// Core properties of a document. Unknown dates are zero.
+type DocumentProperties struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Title          string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Subject        string                 `protobuf:"bytes,2,opt,name=subject,proto3" json:"subject,omitempty"`
+	Description    string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Author         string                 `protobuf:"bytes,4,opt,name=author,proto3" json:"author,omitempty"`
+	LastModifiedBy string                 `protobuf:"bytes,5,opt,name=last_modified_by,json=lastModifiedBy,proto3" json:"last_modified_by,omitempty"`
+	Keywords       []string               `protobuf:"bytes,6,rep,name=keywords,proto3" json:"keywords,omitempty"`
+	CreatedAtUnix  int64                  `protobuf:"varint,7,opt,name=created_at_unix,json=createdAtUnix,proto3" json:"created_at_unix,omitempty"`
+	ModifiedAtUnix int64                  `protobuf:"varint,8,opt,name=modified_at_unix,json=modifiedAtUnix,proto3" json:"modified_at_unix,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DocumentProperties) Reset() {
+	*x = DocumentProperties{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DocumentProperties) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DocumentProperties) ProtoMessage() {}
+
+func (x *DocumentProperties) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DocumentProperties.ProtoReflect.Descriptor instead.
+func (*DocumentProperties) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *DocumentProperties) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *DocumentProperties) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *DocumentProperties) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *DocumentProperties) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *DocumentProperties) GetLastModifiedBy() string {
+	if x != nil {
+		return x.LastModifiedBy
+	}
+	return ""
+}
+
+func (x *DocumentProperties) GetKeywords() []string {
+	if x != nil {
+		return x.Keywords
+	}
+	return nil
+}
+
+func (x *DocumentProperties) GetCreatedAtUnix() int64 {
+	if x != nil {
+		return x.CreatedAtUnix
+	}
+	return 0
+}
+
+func (x *DocumentProperties) GetModifiedAtUnix() int64 {
+	if x != nil {
+		return x.ModifiedAtUnix
+	}
+	return 0
+}
+
+type AnalyzeDocumentResponse struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	FileId     string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	Outline    []*Heading             `protobuf:"bytes,2,rep,name=outline,proto3" json:"outline,omitempty"`
+	Statistics *DocumentStatistics    `protobuf:"bytes,3,opt,name=statistics,proto3" json:"statistics,omitempty"`
+	Images     []*DocumentImage       `protobuf:"bytes,4,rep,name=images,proto3" json:"images,omitempty"`
+	Tables     []*DocumentTable       `protobuf:"bytes,5,rep,name=tables,proto3" json:"tables,omitempty"`
+	// ISO 639-1 code, empty when the language could not be told.
+	Language       string              `protobuf:"bytes,6,opt,name=language,proto3" json:"language,omitempty"`
+	Fonts          []string            `protobuf:"bytes,7,rep,name=fonts,proto3" json:"fonts,omitempty"`
+	Properties     *DocumentProperties `protobuf:"bytes,8,opt,name=properties,proto3" json:"properties,omitempty"`
+	AnalyzedAtUnix int64               `protobuf:"varint,9,opt,name=analyzed_at_unix,json=analyzedAtUnix,proto3" json:"analyzed_at_unix,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AnalyzeDocumentResponse) Reset() {
+	*x = AnalyzeDocumentResponse{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnalyzeDocumentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnalyzeDocumentResponse) ProtoMessage() {}
+
+func (x *AnalyzeDocumentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnalyzeDocumentResponse.ProtoReflect.Descriptor instead.
+func (*AnalyzeDocumentResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *AnalyzeDocumentResponse) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *AnalyzeDocumentResponse) GetOutline() []*Heading {
+	if x != nil {
+		return x.Outline
+	}
+	return nil
+}
+
+func (x *AnalyzeDocumentResponse) GetStatistics() *DocumentStatistics {
+	if x != nil {
+		return x.Statistics
+	}
+	return nil
+}
+
+func (x *AnalyzeDocumentResponse) GetImages() []*DocumentImage {
+	if x != nil {
+		return x.Images
+	}
+	return nil
+}
+
+func (x *AnalyzeDocumentResponse) GetTables() []*DocumentTable {
+	if x != nil {
+		return x.Tables
+	}
+	return nil
+}
+
+func (x *AnalyzeDocumentResponse) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *AnalyzeDocumentResponse) GetFonts() []string {
+	if x != nil {
+		return x.Fonts
+	}
+	return nil
+}
+
+func (x *AnalyzeDocumentResponse) GetProperties() *DocumentProperties {
+	if x != nil {
+		return x.Properties
+	}
+	return nil
+}
+
+func (x *AnalyzeDocumentResponse) GetAnalyzedAtUnix() int64 {
+	if x != nil {
+		return x.AnalyzedAtUnix
+	}
+	return 0
+}
+
+type GetThumbnailRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	FileId        string                 `protobuf:"bytes,2,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetThumbnailRequest) Reset() {
+	*x = GetThumbnailRequest{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetThumbnailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetThumbnailRequest) ProtoMessage() {}
+
+func (x *GetThumbnailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetThumbnailRequest.ProtoReflect.Descriptor instead.
+func (*GetThumbnailRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *GetThumbnailRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetThumbnailRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+// PNG preview of the first page of a document.
+type GetThumbnailResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Image         []byte                 `protobuf:"bytes,3,opt,name=image,proto3" json:"image,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetThumbnailResponse) Reset() {
+	*x = GetThumbnailResponse{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetThumbnailResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetThumbnailResponse) ProtoMessage() {}
+
+func (x *GetThumbnailResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetThumbnailResponse.ProtoReflect.Descriptor instead.
+func (*GetThumbnailResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *GetThumbnailResponse) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *GetThumbnailResponse) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *GetThumbnailResponse) GetImage() []byte {
+	if x != nil {
+		return x.Image
+	}
+	return nil
+}
+
+// Compares two documents, typically two versions of one. context_lines
+// defaults to 3 when unset.
+type DiffDocumentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	OldFileId     string                 `protobuf:"bytes,2,opt,name=old_file_id,json=oldFileId,proto3" json:"old_file_id,omitempty"`
+	NewFileId     string                 `protobuf:"bytes,3,opt,name=new_file_id,json=newFileId,proto3" json:"new_file_id,omitempty"`
+	ContextLines  *int32                 `protobuf:"varint,4,opt,name=context_lines,json=contextLines,proto3,oneof" json:"context_lines,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiffDocumentsRequest) Reset() {
+	*x = DiffDocumentsRequest{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffDocumentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffDocumentsRequest) ProtoMessage() {}
+
+func (x *DiffDocumentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffDocumentsRequest.ProtoReflect.Descriptor instead.
+func (*DiffDocumentsRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *DiffDocumentsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *DiffDocumentsRequest) GetOldFileId() string {
+	if x != nil {
+		return x.OldFileId
+	}
+	return ""
+}
+
+func (x *DiffDocumentsRequest) GetNewFileId() string {
+	if x != nil {
+		return x.NewFileId
+	}
+	return ""
+}
+
+func (x *DiffDocumentsRequest) GetContextLines() int32 {
+	if x != nil && x.ContextLines != nil {
+		return *x.ContextLines
+	}
+	return 0
+}
+
+type DiffTableRow struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cells         []string               `protobuf:"bytes,1,rep,name=cells,proto3" json:"cells,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiffTableRow) Reset() {
+	*x = DiffTableRow{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffTableRow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffTableRow) ProtoMessage() {}
+
+func (x *DiffTableRow) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffTableRow.ProtoReflect.Descriptor instead.
+func (*DiffTableRow) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *DiffTableRow) GetCells() []string {
+	if x != nil {
+		return x.Cells
+	}
+	return nil
+}
+
+// A paragraph, heading, list item, quote, code block or table of a
+// document. index is the position of the block in its document.
+type DiffBlock struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Kind          string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Level         int32                  `protobuf:"varint,3,opt,name=level,proto3" json:"level,omitempty"`
+	Style         string                 `protobuf:"bytes,4,opt,name=style,proto3" json:"style,omitempty"`
+	Text          string                 `protobuf:"bytes,5,opt,name=text,proto3" json:"text,omitempty"`
+	Rows          []*DiffTableRow        `protobuf:"bytes,6,rep,name=rows,proto3" json:"rows,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiffBlock) Reset() {
+	*x = DiffBlock{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffBlock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffBlock) ProtoMessage() {}
+
+func (x *DiffBlock) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffBlock.ProtoReflect.Descriptor instead.
+func (*DiffBlock) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *DiffBlock) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *DiffBlock) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *DiffBlock) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *DiffBlock) GetStyle() string {
+	if x != nil {
+		return x.Style
+	}
+	return ""
+}
+
+func (x *DiffBlock) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *DiffBlock) GetRows() []*DiffTableRow {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+// op is insert, delete or modify. fields lists what a modification changed:
+// text, kind, level or style.
+type StructuralChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Op            string                 `protobuf:"bytes,1,opt,name=op,proto3" json:"op,omitempty"`
+	Old           *DiffBlock             `protobuf:"bytes,2,opt,name=old,proto3" json:"old,omitempty"`
+	New           *DiffBlock             `protobuf:"bytes,3,opt,name=new,proto3" json:"new,omitempty"`
+	Fields        []string               `protobuf:"bytes,4,rep,name=fields,proto3" json:"fields,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StructuralChange) Reset() {
+	*x = StructuralChange{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StructuralChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StructuralChange) ProtoMessage() {}
+
+func (x *StructuralChange) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StructuralChange.ProtoReflect.Descriptor instead.
+func (*StructuralChange) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *StructuralChange) GetOp() string {
+	if x != nil {
+		return x.Op
+	}
+	return ""
+}
+
+func (x *StructuralChange) GetOld() *DiffBlock {
+	if x != nil {
+		return x.Old
+	}
+	return nil
+}
+
+func (x *StructuralChange) GetNew() *DiffBlock {
+	if x != nil {
+		return x.New
+	}
+	return nil
+}
+
+func (x *StructuralChange) GetFields() []string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+// op is equal, insert or delete.
+type TextLine struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Op            string                 `protobuf:"bytes,1,opt,name=op,proto3" json:"op,omitempty"`
+	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TextLine) Reset() {
+	*x = TextLine{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TextLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TextLine) ProtoMessage() {}
+
+func (x *TextLine) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TextLine.ProtoReflect.Descriptor instead.
+func (*TextLine) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *TextLine) GetOp() string {
+	if x != nil {
+		return x.Op
+	}
+	return ""
+}
+
+func (x *TextLine) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+// A hunk of the line diff of the texts, with 1-based line numbers.
+type TextHunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OldStart      int32                  `protobuf:"varint,1,opt,name=old_start,json=oldStart,proto3" json:"old_start,omitempty"`
+	OldLines      int32                  `protobuf:"varint,2,opt,name=old_lines,json=oldLines,proto3" json:"old_lines,omitempty"`
+	NewStart      int32                  `protobuf:"varint,3,opt,name=new_start,json=newStart,proto3" json:"new_start,omitempty"`
+	NewLines      int32                  `protobuf:"varint,4,opt,name=new_lines,json=newLines,proto3" json:"new_lines,omitempty"`
+	Lines         []*TextLine            `protobuf:"bytes,5,rep,name=lines,proto3" json:"lines,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TextHunk) Reset() {
+	*x = TextHunk{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TextHunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TextHunk) ProtoMessage() {}
+
+func (x *TextHunk) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TextHunk.ProtoReflect.Descriptor instead.
+func (*TextHunk) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *TextHunk) GetOldStart() int32 {
+	if x != nil {
+		return x.OldStart
+	}
+	return 0
+}
+
+func (x *TextHunk) GetOldLines() int32 {
+	if x != nil {
+		return x.OldLines
+	}
+	return 0
+}
+
+func (x *TextHunk) GetNewStart() int32 {
+	if x != nil {
+		return x.NewStart
+	}
+	return 0
+}
+
+func (x *TextHunk) GetNewLines() int32 {
+	if x != nil {
+		return x.NewLines
+	}
+	return 0
+}
+
+func (x *TextHunk) GetLines() []*TextLine {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+type DiffSummary struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Inserted      int32                  `protobuf:"varint,1,opt,name=inserted,proto3" json:"inserted,omitempty"`
+	Deleted       int32                  `protobuf:"varint,2,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	Modified      int32                  `protobuf:"varint,3,opt,name=modified,proto3" json:"modified,omitempty"`
+	LinesAdded    int32                  `protobuf:"varint,4,opt,name=lines_added,json=linesAdded,proto3" json:"lines_added,omitempty"`
+	LinesRemoved  int32                  `protobuf:"varint,5,opt,name=lines_removed,json=linesRemoved,proto3" json:"lines_removed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiffSummary) Reset() {
+	*x = DiffSummary{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffSummary) ProtoMessage() {}
+
+func (x *DiffSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffSummary.ProtoReflect.Descriptor instead.
+func (*DiffSummary) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *DiffSummary) GetInserted() int32 {
+	if x != nil {
+		return x.Inserted
+	}
+	return 0
+}
+
+func (x *DiffSummary) GetDeleted() int32 {
+	if x != nil {
+		return x.Deleted
+	}
+	return 0
+}
+
+func (x *DiffSummary) GetModified() int32 {
+	if x != nil {
+		return x.Modified
+	}
+	return 0
+}
+
+func (x *DiffSummary) GetLinesAdded() int32 {
+	if x != nil {
+		return x.LinesAdded
+	}
+	return 0
+}
+
+func (x *DiffSummary) GetLinesRemoved() int32 {
+	if x != nil {
+		return x.LinesRemoved
+	}
+	return 0
+}
+
+type DiffDocumentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OldFileId     string                 `protobuf:"bytes,1,opt,name=old_file_id,json=oldFileId,proto3" json:"old_file_id,omitempty"`
+	NewFileId     string                 `protobuf:"bytes,2,opt,name=new_file_id,json=newFileId,proto3" json:"new_file_id,omitempty"`
+	Changes       []*StructuralChange    `protobuf:"bytes,3,rep,name=changes,proto3" json:"changes,omitempty"`
+	Hunks         []*TextHunk            `protobuf:"bytes,4,rep,name=hunks,proto3" json:"hunks,omitempty"`
+	Summary       *DiffSummary           `protobuf:"bytes,5,opt,name=summary,proto3" json:"summary,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiffDocumentsResponse) Reset() {
+	*x = DiffDocumentsResponse{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffDocumentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffDocumentsResponse) ProtoMessage() {}
+
+func (x *DiffDocumentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffDocumentsResponse.ProtoReflect.Descriptor instead.
+func (*DiffDocumentsResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *DiffDocumentsResponse) GetOldFileId() string {
+	if x != nil {
+		return x.OldFileId
+	}
+	return ""
+}
+
+func (x *DiffDocumentsResponse) GetNewFileId() string {
+	if x != nil {
+		return x.NewFileId
+	}
+	return ""
+}
+
+func (x *DiffDocumentsResponse) GetChanges() []*StructuralChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *DiffDocumentsResponse) GetHunks() []*TextHunk {
+	if x != nil {
+		return x.Hunks
+	}
+	return nil
+}
+
+func (x *DiffDocumentsResponse) GetSummary() *DiffSummary {
+	if x != nil {
+		return x.Summary
+	}
+	return nil
+}
+
+// Renders the changes from the old document to the new one as a DOCX with
+// tracked changes.
+type RedlineDocumentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	OldFileId     string                 `protobuf:"bytes,2,opt,name=old_file_id,json=oldFileId,proto3" json:"old_file_id,omitempty"`
+	NewFileId     string                 `protobuf:"bytes,3,opt,name=new_file_id,json=newFileId,proto3" json:"new_file_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RedlineDocumentsRequest) Reset() {
+	*x = RedlineDocumentsRequest{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RedlineDocumentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RedlineDocumentsRequest) ProtoMessage() {}
+
+func (x *RedlineDocumentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RedlineDocumentsRequest.ProtoReflect.Descriptor instead.
+func (*RedlineDocumentsRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *RedlineDocumentsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RedlineDocumentsRequest) GetOldFileId() string {
+	if x != nil {
+		return x.OldFileId
+	}
+	return ""
+}
+
+func (x *RedlineDocumentsRequest) GetNewFileId() string {
+	if x != nil {
+		return x.NewFileId
+	}
+	return ""
+}
+
+// Makes a file of the content of source files of the same format, in order.
+type MergeDocumentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	SourceFileIds []string               `protobuf:"bytes,2,rep,name=source_file_ids,json=sourceFileIds,proto3" json:"source_file_ids,omitempty"`
+	// Empty derives the name from the first source.
+	FileName string `protobuf:"bytes,3,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	// Empty puts the file at the top level.
+	FolderId string `protobuf:"bytes,4,opt,name=folder_id,json=folderId,proto3" json:"folder_id,omitempty"`
+	// Starts every source after the first on a new page.
+	PageBreaks    bool `protobuf:"varint,5,opt,name=page_breaks,json=pageBreaks,proto3" json:"page_breaks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergeDocumentsRequest) Reset() {
+	*x = MergeDocumentsRequest{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeDocumentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeDocumentsRequest) ProtoMessage() {}
+
+func (x *MergeDocumentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeDocumentsRequest.ProtoReflect.Descriptor instead.
+func (*MergeDocumentsRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *MergeDocumentsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *MergeDocumentsRequest) GetSourceFileIds() []string {
+	if x != nil {
+		return x.SourceFileIds
+	}
+	return nil
+}
+
+func (x *MergeDocumentsRequest) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *MergeDocumentsRequest) GetFolderId() string {
+	if x != nil {
+		return x.FolderId
+	}
+	return ""
+}
+
+func (x *MergeDocumentsRequest) GetPageBreaks() bool {
+	if x != nil {
+		return x.PageBreaks
+	}
+	return false
+}
+
+type MergeDocumentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	File          *FileInfo              `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergeDocumentsResponse) Reset() {
+	*x = MergeDocumentsResponse{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeDocumentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeDocumentsResponse) ProtoMessage() {}
+
+func (x *MergeDocumentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeDocumentsResponse.ProtoReflect.Descriptor instead.
+func (*MergeDocumentsResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *MergeDocumentsResponse) GetFile() *FileInfo {
+	if x != nil {
+		return x.File
+	}
+	return nil
+}
+
+// Cuts a file before each of its headings of heading_level or above, 1 being
+// the top level, into new files.
+type SplitDocumentRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	UserId       string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	FileId       string                 `protobuf:"bytes,2,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	HeadingLevel int32                  `protobuf:"varint,3,opt,name=heading_level,json=headingLevel,proto3" json:"heading_level,omitempty"`
+	// Empty puts the files at the top level.
+	FolderId      string `protobuf:"bytes,4,opt,name=folder_id,json=folderId,proto3" json:"folder_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SplitDocumentRequest) Reset() {
+	*x = SplitDocumentRequest{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SplitDocumentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SplitDocumentRequest) ProtoMessage() {}
+
+func (x *SplitDocumentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SplitDocumentRequest.ProtoReflect.Descriptor instead.
+func (*SplitDocumentRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *SplitDocumentRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SplitDocumentRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *SplitDocumentRequest) GetHeadingLevel() int32 {
+	if x != nil {
+		return x.HeadingLevel
+	}
+	return 0
+}
+
+func (x *SplitDocumentRequest) GetFolderId() string {
+	if x != nil {
+		return x.FolderId
+	}
+	return ""
+}
+
+type SplitDocumentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Files         []*FileInfo            `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SplitDocumentResponse) Reset() {
+	*x = SplitDocumentResponse{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SplitDocumentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SplitDocumentResponse) ProtoMessage() {}
+
+func (x *SplitDocumentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SplitDocumentResponse.ProtoReflect.Descriptor instead.
+func (*SplitDocumentResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *SplitDocumentResponse) GetFiles() []*FileInfo {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+type GetProvenanceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	FileId        string                 `protobuf:"bytes,2,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProvenanceRequest) Reset() {
+	*x = GetProvenanceRequest{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProvenanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProvenanceRequest) ProtoMessage() {}
+
+func (x *GetProvenanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProvenanceRequest.ProtoReflect.Descriptor instead.
+func (*GetProvenanceRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *GetProvenanceRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetProvenanceRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+// A file a file was made from, or made from it, by operation "merge",
+// "split" or "format". position is the place of the source among those merged, or of
+// the part among those of the split, from 0.
+type ProvenanceLink struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	File          *FileInfo              `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	Operation     string                 `protobuf:"bytes,2,opt,name=operation,proto3" json:"operation,omitempty"`
+	Position      int32                  `protobuf:"varint,3,opt,name=position,proto3" json:"position,omitempty"`
+	CreatedAtUnix int64                  `protobuf:"varint,4,opt,name=created_at_unix,json=createdAtUnix,proto3" json:"created_at_unix,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProvenanceLink) Reset() {
+	*x = ProvenanceLink{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProvenanceLink) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProvenanceLink) ProtoMessage() {}
+
+func (x *ProvenanceLink) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProvenanceLink.ProtoReflect.Descriptor instead.
+func (*ProvenanceLink) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *ProvenanceLink) GetFile() *FileInfo {
+	if x != nil {
+		return x.File
+	}
+	return nil
+}
+
+func (x *ProvenanceLink) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *ProvenanceLink) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *ProvenanceLink) GetCreatedAtUnix() int64 {
+	if x != nil {
+		return x.CreatedAtUnix
+	}
+	return 0
+}
+
+type GetProvenanceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sources       []*ProvenanceLink      `protobuf:"bytes,1,rep,name=sources,proto3" json:"sources,omitempty"`
+	Derived       []*ProvenanceLink      `protobuf:"bytes,2,rep,name=derived,proto3" json:"derived,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProvenanceResponse) Reset() {
+	*x = GetProvenanceResponse{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProvenanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProvenanceResponse) ProtoMessage() {}
+
+func (x *GetProvenanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProvenanceResponse.ProtoReflect.Descriptor instead.
+func (*GetProvenanceResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *GetProvenanceResponse) GetSources() []*ProvenanceLink {
+	if x != nil {
+		return x.Sources
+	}
+	return nil
+}
+
+func (x *GetProvenanceResponse) GetDerived() []*ProvenanceLink {
+	if x != nil {
+		return x.Derived
+	}
+	return nil
+}
+
+// Returns a copy of a DOCX or PDF file with a watermark, header or footer
+// drawn on every page. Their text may hold the placeholders {name},
+// {owner}, {user}, {version} and {date}; user_label is shown for {user},
+// and for {owner} when the user owns the file.
+type StampFileRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	UserId    string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	FileId    string                 `protobuf:"bytes,2,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	Watermark string                 `protobuf:"bytes,3,opt,name=watermark,proto3" json:"watermark,omitempty"`
+	// A PNG or JPEG image set in the middle of pages.
+	Image  []byte `protobuf:"bytes,4,opt,name=image,proto3" json:"image,omitempty"`
+	Header string `protobuf:"bytes,5,opt,name=header,proto3" json:"header,omitempty"`
+	Footer string `protobuf:"bytes,6,opt,name=footer,proto3" json:"footer,omitempty"`
+	// Opacity of the watermark, from 0 to 1. Zero uses the default.
+	Opacity       float64 `protobuf:"fixed64,7,opt,name=opacity,proto3" json:"opacity,omitempty"`
+	UserLabel     string  `protobuf:"bytes,8,opt,name=user_label,json=userLabel,proto3" json:"user_label,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StampFileRequest) Reset() {
+	*x = StampFileRequest{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StampFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StampFileRequest) ProtoMessage() {}
+
+func (x *StampFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StampFileRequest.ProtoReflect.Descriptor instead.
+func (*StampFileRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *StampFileRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *StampFileRequest) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *StampFileRequest) GetWatermark() string {
+	if x != nil {
+		return x.Watermark
+	}
+	return ""
+}
+
+func (x *StampFileRequest) GetImage() []byte {
+	if x != nil {
+		return x.Image
+	}
+	return nil
+}
+
+func (x *StampFileRequest) GetHeader() string {
+	if x != nil {
+		return x.Header
+	}
+	return ""
+}
+
+func (x *StampFileRequest) GetFooter() string {
+	if x != nil {
+		return x.Footer
+	}
+	return ""
+}
+
+func (x *StampFileRequest) GetOpacity() float64 {
+	if x != nil {
+		return x.Opacity
+	}
+	return 0
+}
+
+func (x *StampFileRequest) GetUserLabel() string {
+	if x != nil {
+		return x.UserLabel
+	}
+	return ""
+}
+
+// A house style applied to documents. Zero values leave the matching
+// formatting as it is.
+type StyleProfile struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	FontFamily string                 `protobuf:"bytes,1,opt,name=font_family,json=fontFamily,proto3" json:"font_family,omitempty"`
+	// In points.
+	FontSize          float64 `protobuf:"fixed64,2,opt,name=font_size,json=fontSize,proto3" json:"font_size,omitempty"`
+	HeadingFontFamily string  `protobuf:"bytes,3,opt,name=heading_font_family,json=headingFontFamily,proto3" json:"heading_font_family,omitempty"`
+	// In lines.
+	LineSpacing float64 `protobuf:"fixed64,4,opt,name=line_spacing,json=lineSpacing,proto3" json:"line_spacing,omitempty"`
+	// Of every side of pages, in points.
+	Margin float64 `protobuf:"fixed64,5,opt,name=margin,proto3" json:"margin,omitempty"`
+	// Drops the fonts and sizes set on runs of text, so that the profile
+	// reaches them.
+	ClearDirectFormatting bool `protobuf:"varint,6,opt,name=clear_direct_formatting,json=clearDirectFormatting,proto3" json:"clear_direct_formatting,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *StyleProfile) Reset() {
+	*x = StyleProfile{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StyleProfile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StyleProfile) ProtoMessage() {}
+
+func (x *StyleProfile) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StyleProfile.ProtoReflect.Descriptor instead.
+func (*StyleProfile) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *StyleProfile) GetFontFamily() string {
+	if x != nil {
+		return x.FontFamily
+	}
+	return ""
+}
+
+func (x *StyleProfile) GetFontSize() float64 {
+	if x != nil {
+		return x.FontSize
+	}
+	return 0
+}
+
+func (x *StyleProfile) GetHeadingFontFamily() string {
+	if x != nil {
+		return x.HeadingFontFamily
+	}
+	return ""
+}
+
+func (x *StyleProfile) GetLineSpacing() float64 {
+	if x != nil {
+		return x.LineSpacing
+	}
+	return 0
+}
+
+func (x *StyleProfile) GetMargin() float64 {
+	if x != nil {
+		return x.Margin
+	}
+	return 0
+}
+
+func (x *StyleProfile) GetClearDirectFormatting() bool {
+	if x != nil {
+		return x.ClearDirectFormatting
+	}
+	return false
+}
+
+// Formats the DOCX files of a folder, of a tag or of a list with one
+// profile, in the background. Exactly one of folder_id, tag and file_ids is
+// set.
+type CreateBatchJobRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	UserId   string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	FolderId string                 `protobuf:"bytes,2,opt,name=folder_id,json=folderId,proto3" json:"folder_id,omitempty"`
+	// Includes the files of the subfolders of folder_id.
+	Recursive bool          `protobuf:"varint,3,opt,name=recursive,proto3" json:"recursive,omitempty"`
+	Tag       string        `protobuf:"bytes,4,opt,name=tag,proto3" json:"tag,omitempty"`
+	FileIds   []string      `protobuf:"bytes,5,rep,name=file_ids,json=fileIds,proto3" json:"file_ids,omitempty"`
+	Profile   *StyleProfile `protobuf:"bytes,6,opt,name=profile,proto3" json:"profile,omitempty"`
+	// Empty puts the formatted files in folder_id, or at the top level for
+	// other targets.
+	OutputFolderId string `protobuf:"bytes,7,opt,name=output_folder_id,json=outputFolderId,proto3" json:"output_folder_id,omitempty"`
+	// Builds a ZIP archive of the formatted files as well.
+	Archive       bool `protobuf:"varint,8,opt,name=archive,proto3" json:"archive,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateBatchJobRequest) Reset() {
+	*x = CreateBatchJobRequest{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateBatchJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateBatchJobRequest) ProtoMessage() {}
+
+func (x *CreateBatchJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateBatchJobRequest.ProtoReflect.Descriptor instead.
+func (*CreateBatchJobRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *CreateBatchJobRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CreateBatchJobRequest) GetFolderId() string {
+	if x != nil {
+		return x.FolderId
+	}
+	return ""
+}
+
+func (x *CreateBatchJobRequest) GetRecursive() bool {
+	if x != nil {
+		return x.Recursive
+	}
+	return false
+}
+
+func (x *CreateBatchJobRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *CreateBatchJobRequest) GetFileIds() []string {
+	if x != nil {
+		return x.FileIds
+	}
+	return nil
+}
+
+func (x *CreateBatchJobRequest) GetProfile() *StyleProfile {
+	if x != nil {
+		return x.Profile
+	}
+	return nil
+}
+
+func (x *CreateBatchJobRequest) GetOutputFolderId() string {
+	if x != nil {
+		return x.OutputFolderId
+	}
+	return ""
+}
+
+func (x *CreateBatchJobRequest) GetArchive() bool {
+	if x != nil {
+		return x.Archive
+	}
+	return false
+}
+
+type CreateBatchJobResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Job           *BatchJob              `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateBatchJobResponse) Reset() {
+	*x = CreateBatchJobResponse{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateBatchJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateBatchJobResponse) ProtoMessage() {}
+
+func (x *CreateBatchJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateBatchJobResponse.ProtoReflect.Descriptor instead.
+func (*CreateBatchJobResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *CreateBatchJobResponse) GetJob() *BatchJob {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+type GetBatchJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	JobId         string                 `protobuf:"bytes,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBatchJobRequest) Reset() {
+	*x = GetBatchJobRequest{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBatchJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBatchJobRequest) ProtoMessage() {}
+
+func (x *GetBatchJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBatchJobRequest.ProtoReflect.Descriptor instead.
+func (*GetBatchJobRequest) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *GetBatchJobRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetBatchJobRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+type GetBatchJobResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Job           *BatchJob              `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBatchJobResponse) Reset() {
+	*x = GetBatchJobResponse{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBatchJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBatchJobResponse) ProtoMessage() {}
+
+func (x *GetBatchJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[87]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBatchJobResponse.ProtoReflect.Descriptor instead.
+func (*GetBatchJobResponse) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *GetBatchJobResponse) GetJob() *BatchJob {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+// A file of a batch job, with status "pending", "running", "succeeded" or
+// "failed".
+type BatchJobItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FileId        string                 `protobuf:"bytes,1,opt,name=file_id,json=fileId,proto3" json:"file_id,omitempty"`
+	FileName      string                 `protobuf:"bytes,2,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	OutputFileId  string                 `protobuf:"bytes,4,opt,name=output_file_id,json=outputFileId,proto3" json:"output_file_id,omitempty"`
+	Error         string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	Warnings      []string               `protobuf:"bytes,6,rep,name=warnings,proto3" json:"warnings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchJobItem) Reset() {
+	*x = BatchJobItem{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchJobItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchJobItem) ProtoMessage() {}
+
+func (x *BatchJobItem) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[88]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchJobItem.ProtoReflect.Descriptor instead.
+func (*BatchJobItem) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{88}
+}
+
+func (x *BatchJobItem) GetFileId() string {
+	if x != nil {
+		return x.FileId
+	}
+	return ""
+}
+
+func (x *BatchJobItem) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *BatchJobItem) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *BatchJobItem) GetOutputFileId() string {
+	if x != nil {
+		return x.OutputFileId
+	}
+	return ""
+}
+
+func (x *BatchJobItem) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *BatchJobItem) GetWarnings() []string {
+	if x != nil {
+		return x.Warnings
+	}
+	return nil
+}
+
+// A batch job, with status "running" or "completed". error tells why a
+// completed job has no archive when one was asked for.
+type BatchJob struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status         string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Profile        *StyleProfile          `protobuf:"bytes,3,opt,name=profile,proto3" json:"profile,omitempty"`
+	OutputFolderId string                 `protobuf:"bytes,4,opt,name=output_folder_id,json=outputFolderId,proto3" json:"output_folder_id,omitempty"`
+	Archive        bool                   `protobuf:"varint,5,opt,name=archive,proto3" json:"archive,omitempty"`
+	Total          int32                  `protobuf:"varint,6,opt,name=total,proto3" json:"total,omitempty"`
+	Pending        int32                  `protobuf:"varint,7,opt,name=pending,proto3" json:"pending,omitempty"`
+	Running        int32                  `protobuf:"varint,8,opt,name=running,proto3" json:"running,omitempty"`
+	Succeeded      int32                  `protobuf:"varint,9,opt,name=succeeded,proto3" json:"succeeded,omitempty"`
+	Failed         int32                  `protobuf:"varint,10,opt,name=failed,proto3" json:"failed,omitempty"`
+	Items          []*BatchJobItem        `protobuf:"bytes,11,rep,name=items,proto3" json:"items,omitempty"`
+	ArchiveFileId  string                 `protobuf:"bytes,12,opt,name=archive_file_id,json=archiveFileId,proto3" json:"archive_file_id,omitempty"`
+	Error          string                 `protobuf:"bytes,13,opt,name=error,proto3" json:"error,omitempty"`
+	CreatedAtUnix  int64                  `protobuf:"varint,14,opt,name=created_at_unix,json=createdAtUnix,proto3" json:"created_at_unix,omitempty"`
+	FinishedAtUnix int64                  `protobuf:"varint,15,opt,name=finished_at_unix,json=finishedAtUnix,proto3" json:"finished_at_unix,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *BatchJob) Reset() {
+	*x = BatchJob{}
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[89]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchJob) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchJob) ProtoMessage() {}
+
+func (x *BatchJob) ProtoReflect() protoreflect.Message {
+	mi := &file_api_grpc_storage_v1_storage_proto_msgTypes[89]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchJob.ProtoReflect.Descriptor instead.
+func (*BatchJob) Descriptor() ([]byte, []int) {
+	return file_api_grpc_storage_v1_storage_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *BatchJob) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *BatchJob) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *BatchJob) GetProfile() *StyleProfile {
+	if x != nil {
+		return x.Profile
+	}
+	return nil
+}
+
+func (x *BatchJob) GetOutputFolderId() string {
+	if x != nil {
+		return x.OutputFolderId
+	}
+	return ""
+}
+
+func (x *BatchJob) GetArchive() bool {
+	if x != nil {
+		return x.Archive
+	}
+	return false
+}
+
+func (x *BatchJob) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *BatchJob) GetPending() int32 {
+	if x != nil {
+		return x.Pending
+	}
+	return 0
+}
+
+func (x *BatchJob) GetRunning() int32 {
+	if x != nil {
+		return x.Running
+	}
+	return 0
+}
+
+func (x *BatchJob) GetSucceeded() int32 {
+	if x != nil {
+		return x.Succeeded
+	}
+	return 0
+}
+
+func (x *BatchJob) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *BatchJob) GetItems() []*BatchJobItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *BatchJob) GetArchiveFileId() string {
+	if x != nil {
+		return x.ArchiveFileId
+	}
+	return ""
+}
+
+func (x *BatchJob) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *BatchJob) GetCreatedAtUnix() int64 {
+	if x != nil {
+		return x.CreatedAtUnix
+	}
+	return 0
+}
+
+func (x *BatchJob) GetFinishedAtUnix() int64 {
+	if x != nil {
+		return x.FinishedAtUnix
+	}
+	return 0
+}
+
+var File_api_grpc_storage_v1_storage_proto protoreflect.FileDescriptor
+
+const file_api_grpc_storage_v1_storage_proto_rawDesc = "" +
+	"\n" +
+	"!api/grpc/storage/v1/storage.proto\x12\astorage\"\x9d\x01\n" +
+	"\x11UploadFileRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tfile_name\x18\x02 \x01(\tR\bfileName\x12\x1b\n" +
+	"\tfile_size\x18\x03 \x01(\x03R\bfileSize\x12\x18\n" +
+	"\acontent\x18\x04 \x01(\fR\acontent\x12\x1b\n" +
+	"\tfolder_id\x18\x05 \x01(\tR\bfolderId\"J\n" +
+	"\x12UploadFileResponse\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\tR\x06fileId\x12\x1b\n" +
+	"\tfile_name\x18\x02 \x01(\tR\bfileName\"G\n" +
+	"\x13DownloadFileRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\afile_id\x18\x02 \x01(\tR\x06fileId\"f\n" +
+	"\x14DownloadFileResponse\x12\x1b\n" +
+	"\tfile_name\x18\x01 \x01(\tR\bfileName\x12\x1b\n" +
+	"\tfile_size\x18\x02 \x01(\x03R\bfileSize\x12\x14\n" +
+	"\x05chunk\x18\x03 \x01(\fR\x05chunk\"V\n" +
+	"\x06Folder\x12\x1b\n" +
+	"\tfolder_id\x18\x01 \x01(\tR\bfolderId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1b\n" +
+	"\tparent_id\x18\x03 \x01(\tR\bparentId\"\xa3\x03\n" +
+	"\bFileInfo\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\tR\x06fileId\x12\x1b\n" +
+	"\tfile_name\x18\x02 \x01(\tR\bfileName\x12\x1b\n" +
+	"\tfile_size\x18\x03 \x01(\x03R\bfileSize\x12\x1b\n" +
+	"\tfolder_id\x18\x04 \x01(\tR\bfolderId\x12!\n" +
+	"\fcontent_type\x18\x05 \x01(\tR\vcontentType\x12\x12\n" +
+	"\x04tags\x18\x06 \x03(\tR\x04tags\x12;\n" +
+	"\bmetadata\x18\a \x03(\v2\x1f.storage.FileInfo.MetadataEntryR\bmetadata\x12&\n" +
+	"\x0fcreated_at_unix\x18\b \x01(\x03R\rcreatedAtUnix\x12&\n" +
+	"\x0fupdated_at_unix\x18\t \x01(\x03R\rupdatedAtUnix\x12&\n" +
+	"\x0fdeleted_at_unix\x18\n" +
+	" \x01(\x03R\rdeletedAtUnix\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"_\n" +
+	"\x13CreateFolderRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1b\n" +
+	"\tparent_id\x18\x03 \x01(\tR\bparentId\"?\n" +
+	"\x14CreateFolderResponse\x12'\n" +
+	"\x06folder\x18\x01 \x01(\v2\x0f.storage.FolderR\x06folder\"_\n" +
+	"\x13RenameFolderRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tfolder_id\x18\x02 \x01(\tR\bfolderId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\"?\n" +
+	"\x14RenameFolderResponse\x12'\n" +
+	"\x06folder\x18\x01 \x01(\v2\x0f.storage.FolderR\x06folder\"f\n" +
+	"\x11MoveFolderRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tfolder_id\x18\x02 \x01(\tR\bfolderId\x12\x1b\n" +
+	"\tparent_id\x18\x03 \x01(\tR\bparentId\"=\n" +
+	"\x12MoveFolderResponse\x12'\n" +
+	"\x06folder\x18\x01 \x01(\v2\x0f.storage.FolderR\x06folder\"i\n" +
+	"\x13DeleteFolderRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tfolder_id\x18\x02 \x01(\tR\bfolderId\x12\x1c\n" +
+	"\trecursive\x18\x03 \x01(\bR\trecursive\"d\n" +
+	"\x14DeleteFolderResponse\x12'\n" +
+	"\x0fdeleted_folders\x18\x01 \x01(\x05R\x0edeletedFolders\x12#\n" +
+	"\rdeleted_files\x18\x02 \x01(\x05R\fdeletedFiles\"I\n" +
+	"\x11ListFolderRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tfolder_id\x18\x02 \x01(\tR\bfolderId\"h\n" +
+	"\x12ListFolderResponse\x12)\n" +
+	"\afolders\x18\x01 \x03(\v2\x0f.storage.FolderR\afolders\x12'\n" +
+	"\x05files\x18\x02 \x03(\v2\x11.storage.FileInfoR\x05files\"`\n" +
+	"\x0fMoveFileRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\afile_id\x18\x02 \x01(\tR\x06fileId\x12\x1b\n" +
+	"\tfolder_id\x18\x03 \x01(\tR\bfolderId\"9\n" +
+	"\x10MoveFileResponse\x12%\n" +
+	"\x04file\x18\x01 \x01(\v2\x11.storage.FileInfoR\x04file\"Z\n" +
+	"\x12AddFileTagsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\afile_id\x18\x02 \x01(\tR\x06fileId\x12\x12\n" +
+	"\x04tags\x18\x03 \x03(\tR\x04tags\"<\n" +
+	"\x13AddFileTagsResponse\x12%\n" +
+	"\x04file\x18\x01 \x01(\v2\x11.storage.FileInfoR\x04file\"]\n" +
+	"\x15RemoveFileTagsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\afile_id\x18\x02 \x01(\tR\x06fileId\x12\x12\n" +
+	"\x04tags\x18\x03 \x03(\tR\x04tags\"?\n" +
+	"\x16RemoveFileTagsResponse\x12%\n" +
+	"\x04file\x18\x01 \x01(\v2\x11.storage.FileInfoR\x04file\"\xd2\x01\n" +
+	"\x16SetFileMetadataRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\afile_id\x18\x02 \x01(\tR\x06fileId\x12I\n" +
+	"\bmetadata\x18\x03 \x03(\v2-.storage.SetFileMetadataRequest.MetadataEntryR\bmetadata\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"@\n" +
+	"\x17SetFileMetadataResponse\x12%\n" +
+	"\x04file\x18\x01 \x01(\v2\x11.storage.FileInfoR\x04file\"a\n" +
+	"\x19RemoveFileMetadataRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\afile_id\x18\x02 \x01(\tR\x06fileId\x12\x12\n" +
+	"\x04keys\x18\x03 \x03(\tR\x04keys\"C\n" +
+	"\x1aRemoveFileMetadataResponse\x12%\n" +
+	"\x04file\x18\x01 \x01(\v2\x11.storage.FileInfoR\x04file\"\xe2\x04\n" +
+	"\x10ListFilesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tfolder_id\x18\x02 \x01(\tR\bfolderId\x12\x12\n" +
+	"\x04tags\x18\x03 \x03(\tR\x04tags\x12C\n" +
+	"\bmetadata\x18\x04 \x03(\v2'.storage.ListFilesRequest.MetadataEntryR\bmetadata\x12!\n" +
+	"\fcontent_type\x18\x05 \x01(\tR\vcontentType\x12\x1e\n" +
+	"\bmin_size\x18\x06 \x01(\x03H\x00R\aminSize\x88\x01\x01\x12\x1e\n" +
+	"\bmax_size\x18\a \x01(\x03H\x01R\amaxSize\x88\x01\x01\x121\n" +
+	"\x12created_after_unix\x18\b \x01(\x03H\x02R\x10createdAfterUnix\x88\x01\x01\x123\n" +
+	"\x13created_before_unix\x18\t \x01(\x03H\x03R\x11createdBeforeUnix\x88\x01\x01\x12\x1b\n" +
+	"\tpage_size\x18\n" +
+	" \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\v \x01(\tR\tpageToken\x12\x12\n" +
+	"\x04sort\x18\f \x01(\tR\x04sort\x12\x1e\n" +
+	"\n" +
+	"descending\x18\r \x01(\bR\n" +
+	"descending\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\v\n" +
+	"\t_min_sizeB\v\n" +
+	"\t_max_sizeB\x15\n" +
+	"\x13_created_after_unixB\x16\n" +
+	"\x14_created_before_unix\"d\n" +
+	"\x11ListFilesResponse\x12'\n" +
+	"\x05files\x18\x01 \x03(\v2\x11.storage.FileInfoR\x05files\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"E\n" +
+	"\x11DeleteFileRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\afile_id\x18\x02 \x01(\tR\x06fileId\";\n" +
+	"\x12DeleteFileResponse\x12%\n" +
+	"\x04file\x18\x01 \x01(\v2\x11.storage.FileInfoR\x04file\"g\n" +
+	"\x10ListTrashRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"d\n" +
+	"\x11ListTrashResponse\x12'\n" +
+	"\x05files\x18\x01 \x03(\v2\x11.storage.FileInfoR\x05files\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"F\n" +
+	"\x12RestoreFileRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\afile_id\x18\x02 \x01(\tR\x06fileId\"<\n" +
+	"\x13RestoreFileResponse\x12%\n" +
+	"\x04file\x18\x01 \x01(\v2\x11.storage.FileInfoR\x04file\",\n" +
+	"\x11EmptyTrashRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"7\n" +
+	"\x12EmptyTrashResponse\x12!\n" +
+	"\fpurged_files\x18\x01 \x01(\x05R\vpurgedFiles\"\xc1\x01\n" +
+	"\tShareInfo\x12#\n" +
+	"\rresource_type\x18\x01 \x01(\tR\fresourceType\x12\x1f\n" +
+	"\vresource_id\x18\x02 \x01(\tR\n" +
+	"resourceId\x12\x19\n" +
+	"\bowner_id\x18\x03 \x01(\tR\aownerId\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04role\x18\x05 \x01(\tR\x04role\x12&\n" +
+	"\x0fcreated_at_unix\x18\x06 \x01(\x03R\rcreatedAtUnix\"\xa0\x01\n" +
+	"\fShareRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12#\n" +
+	"\rresource_type\x18\x02 \x01(\tR\fresourceType\x12\x1f\n" +
+	"\vresource_id\x18\x03 \x01(\tR\n" +
+	"resourceId\x12\x1d\n" +
+	"\n" +
+	"grantee_id\x18\x04 \x01(\tR\tgranteeId\x12\x12\n" +
+	"\x04role\x18\x05 \x01(\tR\x04role\"9\n" +
+	"\rShareResponse\x12(\n" +
+	"\x05share\x18\x01 \x01(\v2\x12.storage.ShareInfoR\x05share\"\x8e\x01\n" +
+	"\x0eUnshareRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12#\n" +
+	"\rresource_type\x18\x02 \x01(\tR\fresourceType\x12\x1f\n" +
+	"\vresource_id\x18\x03 \x01(\tR\n" +
+	"resourceId\x12\x1d\n" +
+	"\n" +
+	"grantee_id\x18\x04 \x01(\tR\tgranteeId\"\x11\n" +
+	"\x0fUnshareResponse\"r\n" +
+	"\x11ListSharesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12#\n" +
+	"\rresource_type\x18\x02 \x01(\tR\fresourceType\x12\x1f\n" +
+	"\vresource_id\x18\x03 \x01(\tR\n" +
+	"resourceId\"@\n" +
+	"\x12ListSharesResponse\x12*\n" +
+	"\x06shares\x18\x01 \x03(\v2\x12.storage.ShareInfoR\x06shares\"2\n" +
+	"\x17ListSharedWithMeRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\x86\x01\n" +
+	"\n" +
+	"SharedItem\x12(\n" +
+	"\x05share\x18\x01 \x01(\v2\x12.storage.ShareInfoR\x05share\x12%\n" +
+	"\x04file\x18\x02 \x01(\v2\x11.storage.FileInfoR\x04file\x12'\n" +
+	"\x06folder\x18\x03 \x01(\v2\x0f.storage.FolderR\x06folder\"E\n" +
+	"\x18ListSharedWithMeResponse\x12)\n" +
+	"\x05items\x18\x01 \x03(\v2\x13.storage.SharedItemR\x05items\"\xc3\x02\n" +
+	"\rShareLinkInfo\x12\x17\n" +
+	"\alink_id\x18\x01 \x01(\tR\x06linkId\x12\x17\n" +
+	"\afile_id\x18\x02 \x01(\tR\x06fileId\x12\x19\n" +
+	"\bowner_id\x18\x03 \x01(\tR\aownerId\x12!\n" +
+	"\fhas_password\x18\x04 \x01(\bR\vhasPassword\x12&\n" +
+	"\x0fexpires_at_unix\x18\x05 \x01(\x03R\rexpiresAtUnix\x12#\n" +
+	"\rmax_downloads\x18\x06 \x01(\x05R\fmaxDownloads\x12%\n" +
+	"\x0edownload_count\x18\a \x01(\x05R\rdownloadCount\x12&\n" +
+	"\x0frevoked_at_unix\x18\b \x01(\x03R\rrevokedAtUnix\x12&\n" +
+	"\x0fcreated_at_unix\x18\t \x01(\x03R\rcreatedAtUnix\"\xb3\x01\n" +
+	"\x16CreateShareLinkRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\afile_id\x18\x02 \x01(\tR\x06fileId\x12\x1a\n" +
+	"\bpassword\x18\x03 \x01(\tR\bpassword\x12&\n" +
+	"\x0fexpires_at_unix\x18\x04 \x01(\x03R\rexpiresAtUnix\x12#\n" +
+	"\rmax_downloads\x18\x05 \x01(\x05R\fmaxDownloads\"[\n" +
+	"\x17CreateShareLinkResponse\x12*\n" +
+	"\x04link\x18\x01 \x01(\v2\x16.storage.ShareLinkInfoR\x04link\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\"I\n" +
+	"\x15ListShareLinksRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\afile_id\x18\x02 \x01(\tR\x06fileId\"F\n" +
+	"\x16ListShareLinksResponse\x12,\n" +
+	"\x05links\x18\x01 \x03(\v2\x16.storage.ShareLinkInfoR\x05links\"J\n" +
+	"\x16RevokeShareLinkRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\alink_id\x18\x02 \x01(\tR\x06linkId\"E\n" +
+	"\x17RevokeShareLinkResponse\x12*\n" +
+	"\x04link\x18\x01 \x01(\v2\x16.storage.ShareLinkInfoR\x04link\"\x8d\x01\n" +
+	"\x19DownloadSharedFileRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x1f\n" +
+	"\vremote_addr\x18\x03 \x01(\tR\n" +
+	"remoteAddr\x12\x1d\n" +
+	"\n" +
+	"user_agent\x18\x04 \x01(\tR\tuserAgent\"]\n" +
+	"\x16SearchDocumentsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05query\x18\x02 \x01(\tR\x05query\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"`\n" +
+	"\tSearchHit\x12%\n" +
+	"\x04file\x18\x01 \x01(\v2\x11.storage.FileInfoR\x04file\x12\x12\n" +
+	"\x04rank\x18\x02 \x01(\x01R\x04rank\x12\x18\n" +
+	"\asnippet\x18\x03 \x01(\tR\asnippet\"A\n" +
+	"\x17SearchDocumentsResponse\x12&\n" +
+	"\x04hits\x18\x01 \x03(\v2\x12.storage.SearchHitR\x04hits\"J\n" +
+	"\x16AnalyzeDocumentRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\afile_id\x18\x02 \x01(\tR\x06fileId\"a\n" +
+	"\aHeading\x12\x14\n" +
+	"\x05level\x18\x01 \x01(\x05R\x05level\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12,\n" +
+	"\bchildren\x18\x03 \x03(\v2\x10.storage.HeadingR\bchildren\"\xdb\x01\n" +
+	"\x12DocumentStatistics\x12\x14\n" +
+	"\x05words\x18\x01 \x01(\x03R\x05words\x12\x1e\n" +
+	"\n" +
+	"characters\x18\x02 \x01(\x03R\n" +
+	"characters\x120\n" +
+	"\x14characters_no_spaces\x18\x03 \x01(\x03R\x12charactersNoSpaces\x12\x1e\n" +
+	"\n" +
+	"paragraphs\x18\x04 \x01(\x03R\n" +
+	"paragraphs\x12\x14\n" +
+	"\x05pages\x18\x05 \x01(\x03R\x05pages\x12'\n" +
+	"\x0fpages_estimated\x18\x06 \x01(\bR\x0epagesEstimated\"|\n" +
+	"\rDocumentImage\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\x03R\x04size\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\"=\n" +
+	"\rDocumentTable\x12\x12\n" +
+	"\x04rows\x18\x01 \x01(\x05R\x04rows\x12\x18\n" +
+	"\acolumns\x18\x02 \x01(\x05R\acolumns\"\x96\x02\n" +
+	"\x12DocumentProperties\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12\x18\n" +
+	"\asubject\x18\x02 \x01(\tR\asubject\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x16\n" +
+	"\x06author\x18\x04 \x01(\tR\x06author\x12(\n" +
+	"\x10last_modified_by\x18\x05 \x01(\tR\x0elastModifiedBy\x12\x1a\n" +
+	"\bkeywords\x18\x06 \x03(\tR\bkeywords\x12&\n" +
+	"\x0fcreated_at_unix\x18\a \x01(\x03R\rcreatedAtUnix\x12(\n" +
+	"\x10modified_at_unix\x18\b \x01(\x03R\x0emodifiedAtUnix\"\x94\x03\n" +
+	"\x17AnalyzeDocumentResponse\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\tR\x06fileId\x12*\n" +
+	"\aoutline\x18\x02 \x03(\v2\x10.storage.HeadingR\aoutline\x12;\n" +
+	"\n" +
+	"statistics\x18\x03 \x01(\v2\x1b.storage.DocumentStatisticsR\n" +
+	"statistics\x12.\n" +
+	"\x06images\x18\x04 \x03(\v2\x16.storage.DocumentImageR\x06images\x12.\n" +
+	"\x06tables\x18\x05 \x03(\v2\x16.storage.DocumentTableR\x06tables\x12\x1a\n" +
+	"\blanguage\x18\x06 \x01(\tR\blanguage\x12\x14\n" +
+	"\x05fonts\x18\a \x03(\tR\x05fonts\x12;\n" +
+	"\n" +
+	"properties\x18\b \x01(\v2\x1b.storage.DocumentPropertiesR\n" +
+	"properties\x12(\n" +
+	"\x10analyzed_at_unix\x18\t \x01(\x03R\x0eanalyzedAtUnix\"G\n" +
+	"\x13GetThumbnailRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\afile_id\x18\x02 \x01(\tR\x06fileId\"h\n" +
+	"\x14GetThumbnailResponse\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\tR\x06fileId\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12\x14\n" +
+	"\x05image\x18\x03 \x01(\fR\x05image\"\xab\x01\n" +
+	"\x14DiffDocumentsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1e\n" +
+	"\vold_file_id\x18\x02 \x01(\tR\toldFileId\x12\x1e\n" +
+	"\vnew_file_id\x18\x03 \x01(\tR\tnewFileId\x12(\n" +
+	"\rcontext_lines\x18\x04 \x01(\x05H\x00R\fcontextLines\x88\x01\x01B\x10\n" +
+	"\x0e_context_lines\"$\n" +
+	"\fDiffTableRow\x12\x14\n" +
+	"\x05cells\x18\x01 \x03(\tR\x05cells\"\xa0\x01\n" +
+	"\tDiffBlock\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x14\n" +
+	"\x05level\x18\x03 \x01(\x05R\x05level\x12\x14\n" +
+	"\x05style\x18\x04 \x01(\tR\x05style\x12\x12\n" +
+	"\x04text\x18\x05 \x01(\tR\x04text\x12)\n" +
+	"\x04rows\x18\x06 \x03(\v2\x15.storage.DiffTableRowR\x04rows\"\x86\x01\n" +
+	"\x10StructuralChange\x12\x0e\n" +
+	"\x02op\x18\x01 \x01(\tR\x02op\x12$\n" +
+	"\x03old\x18\x02 \x01(\v2\x12.storage.DiffBlockR\x03old\x12$\n" +
+	"\x03new\x18\x03 \x01(\v2\x12.storage.DiffBlockR\x03new\x12\x16\n" +
+	"\x06fields\x18\x04 \x03(\tR\x06fields\".\n" +
+	"\bTextLine\x12\x0e\n" +
+	"\x02op\x18\x01 \x01(\tR\x02op\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\"\xa7\x01\n" +
+	"\bTextHunk\x12\x1b\n" +
+	"\told_start\x18\x01 \x01(\x05R\boldStart\x12\x1b\n" +
+	"\told_lines\x18\x02 \x01(\x05R\boldLines\x12\x1b\n" +
+	"\tnew_start\x18\x03 \x01(\x05R\bnewStart\x12\x1b\n" +
+	"\tnew_lines\x18\x04 \x01(\x05R\bnewLines\x12'\n" +
+	"\x05lines\x18\x05 \x03(\v2\x11.storage.TextLineR\x05lines\"\xa5\x01\n" +
+	"\vDiffSummary\x12\x1a\n" +
+	"\binserted\x18\x01 \x01(\x05R\binserted\x12\x18\n" +
+	"\adeleted\x18\x02 \x01(\x05R\adeleted\x12\x1a\n" +
+	"\bmodified\x18\x03 \x01(\x05R\bmodified\x12\x1f\n" +
+	"\vlines_added\x18\x04 \x01(\x05R\n" +
+	"linesAdded\x12#\n" +
+	"\rlines_removed\x18\x05 \x01(\x05R\flinesRemoved\"\xe5\x01\n" +
+	"\x15DiffDocumentsResponse\x12\x1e\n" +
+	"\vold_file_id\x18\x01 \x01(\tR\toldFileId\x12\x1e\n" +
+	"\vnew_file_id\x18\x02 \x01(\tR\tnewFileId\x123\n" +
+	"\achanges\x18\x03 \x03(\v2\x19.storage.StructuralChangeR\achanges\x12'\n" +
+	"\x05hunks\x18\x04 \x03(\v2\x11.storage.TextHunkR\x05hunks\x12.\n" +
+	"\asummary\x18\x05 \x01(\v2\x14.storage.DiffSummaryR\asummary\"r\n" +
+	"\x17RedlineDocumentsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1e\n" +
+	"\vold_file_id\x18\x02 \x01(\tR\toldFileId\x12\x1e\n" +
+	"\vnew_file_id\x18\x03 \x01(\tR\tnewFileId\"\xb3\x01\n" +
+	"\x15MergeDocumentsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12&\n" +
+	"\x0fsource_file_ids\x18\x02 \x03(\tR\rsourceFileIds\x12\x1b\n" +
+	"\tfile_name\x18\x03 \x01(\tR\bfileName\x12\x1b\n" +
+	"\tfolder_id\x18\x04 \x01(\tR\bfolderId\x12\x1f\n" +
+	"\vpage_breaks\x18\x05 \x01(\bR\n" +
+	"pageBreaks\"?\n" +
+	"\x16MergeDocumentsResponse\x12%\n" +
+	"\x04file\x18\x01 \x01(\v2\x11.storage.FileInfoR\x04file\"\x8a\x01\n" +
+	"\x14SplitDocumentRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\afile_id\x18\x02 \x01(\tR\x06fileId\x12#\n" +
+	"\rheading_level\x18\x03 \x01(\x05R\fheadingLevel\x12\x1b\n" +
+	"\tfolder_id\x18\x04 \x01(\tR\bfolderId\"@\n" +
+	"\x15SplitDocumentResponse\x12'\n" +
+	"\x05files\x18\x01 \x03(\v2\x11.storage.FileInfoR\x05files\"H\n" +
+	"\x14GetProvenanceRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\afile_id\x18\x02 \x01(\tR\x06fileId\"\x99\x01\n" +
+	"\x0eProvenanceLink\x12%\n" +
+	"\x04file\x18\x01 \x01(\v2\x11.storage.FileInfoR\x04file\x12\x1c\n" +
+	"\toperation\x18\x02 \x01(\tR\toperation\x12\x1a\n" +
+	"\bposition\x18\x03 \x01(\x05R\bposition\x12&\n" +
+	"\x0fcreated_at_unix\x18\x04 \x01(\x03R\rcreatedAtUnix\"}\n" +
+	"\x15GetProvenanceResponse\x121\n" +
+	"\asources\x18\x01 \x03(\v2\x17.storage.ProvenanceLinkR\asources\x121\n" +
+	"\aderived\x18\x02 \x03(\v2\x17.storage.ProvenanceLinkR\aderived\"\xe1\x01\n" +
+	"\x10StampFileRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x17\n" +
+	"\afile_id\x18\x02 \x01(\tR\x06fileId\x12\x1c\n" +
+	"\twatermark\x18\x03 \x01(\tR\twatermark\x12\x14\n" +
+	"\x05image\x18\x04 \x01(\fR\x05image\x12\x16\n" +
+	"\x06header\x18\x05 \x01(\tR\x06header\x12\x16\n" +
+	"\x06footer\x18\x06 \x01(\tR\x06footer\x12\x18\n" +
+	"\aopacity\x18\a \x01(\x01R\aopacity\x12\x1d\n" +
+	"\n" +
+	"user_label\x18\b \x01(\tR\tuserLabel\"\xef\x01\n" +
+	"\fStyleProfile\x12\x1f\n" +
+	"\vfont_family\x18\x01 \x01(\tR\n" +
+	"fontFamily\x12\x1b\n" +
+	"\tfont_size\x18\x02 \x01(\x01R\bfontSize\x12.\n" +
+	"\x13heading_font_family\x18\x03 \x01(\tR\x11headingFontFamily\x12!\n" +
+	"\fline_spacing\x18\x04 \x01(\x01R\vlineSpacing\x12\x16\n" +
+	"\x06margin\x18\x05 \x01(\x01R\x06margin\x126\n" +
+	"\x17clear_direct_formatting\x18\x06 \x01(\bR\x15clearDirectFormatting\"\x8d\x02\n" +
+	"\x15CreateBatchJobRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tfolder_id\x18\x02 \x01(\tR\bfolderId\x12\x1c\n" +
+	"\trecursive\x18\x03 \x01(\bR\trecursive\x12\x10\n" +
+	"\x03tag\x18\x04 \x01(\tR\x03tag\x12\x19\n" +
+	"\bfile_ids\x18\x05 \x03(\tR\afileIds\x12/\n" +
+	"\aprofile\x18\x06 \x01(\v2\x15.storage.StyleProfileR\aprofile\x12(\n" +
+	"\x10output_folder_id\x18\a \x01(\tR\x0eoutputFolderId\x12\x18\n" +
+	"\aarchive\x18\b \x01(\bR\aarchive\"=\n" +
+	"\x16CreateBatchJobResponse\x12#\n" +
+	"\x03job\x18\x01 \x01(\v2\x11.storage.BatchJobR\x03job\"D\n" +
+	"\x12GetBatchJobRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x15\n" +
+	"\x06job_id\x18\x02 \x01(\tR\x05jobId\":\n" +
+	"\x13GetBatchJobResponse\x12#\n" +
+	"\x03job\x18\x01 \x01(\v2\x11.storage.BatchJobR\x03job\"\xb4\x01\n" +
+	"\fBatchJobItem\x12\x17\n" +
+	"\afile_id\x18\x01 \x01(\tR\x06fileId\x12\x1b\n" +
+	"\tfile_name\x18\x02 \x01(\tR\bfileName\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12$\n" +
+	"\x0eoutput_file_id\x18\x04 \x01(\tR\foutputFileId\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\x12\x1a\n" +
+	"\bwarnings\x18\x06 \x03(\tR\bwarnings\"\xe4\x03\n" +
+	"\bBatchJob\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12/\n" +
+	"\aprofile\x18\x03 \x01(\v2\x15.storage.StyleProfileR\aprofile\x12(\n" +
+	"\x10output_folder_id\x18\x04 \x01(\tR\x0eoutputFolderId\x12\x18\n" +
+	"\aarchive\x18\x05 \x01(\bR\aarchive\x12\x14\n" +
+	"\x05total\x18\x06 \x01(\x05R\x05total\x12\x18\n" +
+	"\apending\x18\a \x01(\x05R\apending\x12\x18\n" +
+	"\arunning\x18\b \x01(\x05R\arunning\x12\x1c\n" +
+	"\tsucceeded\x18\t \x01(\x05R\tsucceeded\x12\x16\n" +
+	"\x06failed\x18\n" +
+	" \x01(\x05R\x06failed\x12+\n" +
+	"\x05items\x18\v \x03(\v2\x15.storage.BatchJobItemR\x05items\x12&\n" +
+	"\x0farchive_file_id\x18\f \x01(\tR\rarchiveFileId\x12\x14\n" +
+	"\x05error\x18\r \x01(\tR\x05error\x12&\n" +
+	"\x0fcreated_at_unix\x18\x0e \x01(\x03R\rcreatedAtUnix\x12(\n" +
+	"\x10finished_at_unix\x18\x0f \x01(\x03R\x0efinishedAtUnix2\xf7\x15\n" +
+	"\x0eStorageService\x12E\n" +
+	"\n" +
+	"UploadFile\x12\x1a.storage.UploadFileRequest\x1a\x1b.storage.UploadFileResponse\x12M\n" +
+	"\fDownloadFile\x12\x1c.storage.DownloadFileRequest\x1a\x1d.storage.DownloadFileResponse0\x01\x12?\n" +
+	"\bMoveFile\x12\x18.storage.MoveFileRequest\x1a\x19.storage.MoveFileResponse\x12K\n" +
+	"\fCreateFolder\x12\x1c.storage.CreateFolderRequest\x1a\x1d.storage.CreateFolderResponse\x12K\n" +
+	"\fRenameFolder\x12\x1c.storage.RenameFolderRequest\x1a\x1d.storage.RenameFolderResponse\x12E\n" +
+	"\n" +
+	"MoveFolder\x12\x1a.storage.MoveFolderRequest\x1a\x1b.storage.MoveFolderResponse\x12K\n" +
+	"\fDeleteFolder\x12\x1c.storage.DeleteFolderRequest\x1a\x1d.storage.DeleteFolderResponse\x12E\n" +
+	"\n" +
+	"ListFolder\x12\x1a.storage.ListFolderRequest\x1a\x1b.storage.ListFolderResponse\x12H\n" +
+	"\vAddFileTags\x12\x1b.storage.AddFileTagsRequest\x1a\x1c.storage.AddFileTagsResponse\x12Q\n" +
+	"\x0eRemoveFileTags\x12\x1e.storage.RemoveFileTagsRequest\x1a\x1f.storage.RemoveFileTagsResponse\x12T\n" +
+	"\x0fSetFileMetadata\x12\x1f.storage.SetFileMetadataRequest\x1a .storage.SetFileMetadataResponse\x12]\n" +
+	"\x12RemoveFileMetadata\x12\".storage.RemoveFileMetadataRequest\x1a#.storage.RemoveFileMetadataResponse\x12B\n" +
+	"\tListFiles\x12\x19.storage.ListFilesRequest\x1a\x1a.storage.ListFilesResponse\x12E\n" +
+	"\n" +
+	"DeleteFile\x12\x1a.storage.DeleteFileRequest\x1a\x1b.storage.DeleteFileResponse\x12B\n" +
+	"\tListTrash\x12\x19.storage.ListTrashRequest\x1a\x1a.storage.ListTrashResponse\x12H\n" +
+	"\vRestoreFile\x12\x1b.storage.RestoreFileRequest\x1a\x1c.storage.RestoreFileResponse\x12E\n" +
+	"\n" +
+	"EmptyTrash\x12\x1a.storage.EmptyTrashRequest\x1a\x1b.storage.EmptyTrashResponse\x126\n" +
+	"\x05Share\x12\x15.storage.ShareRequest\x1a\x16.storage.ShareResponse\x12<\n" +
+	"\aUnshare\x12\x17.storage.UnshareRequest\x1a\x18.storage.UnshareResponse\x12E\n" +
 	"\n" +
-	"UploadFile\x12\x1a.storage.UploadFileRequest\x1a\x1b.storage.UploadFileResponseB<Z:github.com/a1y/doc-formatter/api/grpc/storage/v1;storagepbb\x06proto3"
+	"ListShares\x12\x1a.storage.ListSharesRequest\x1a\x1b.storage.ListSharesResponse\x12W\n" +
+	"\x10ListSharedWithMe\x12 .storage.ListSharedWithMeRequest\x1a!.storage.ListSharedWithMeResponse\x12T\n" +
+	"\x0fCreateShareLink\x12\x1f.storage.CreateShareLinkRequest\x1a .storage.CreateShareLinkResponse\x12Q\n" +
+	"\x0eListShareLinks\x12\x1e.storage.ListShareLinksRequest\x1a\x1f.storage.ListShareLinksResponse\x12T\n" +
+	"\x0fRevokeShareLink\x12\x1f.storage.RevokeShareLinkRequest\x1a .storage.RevokeShareLinkResponse\x12Y\n" +
+	"\x12DownloadSharedFile\x12\".storage.DownloadSharedFileRequest\x1a\x1d.storage.DownloadFileResponse0\x01\x12T\n" +
+	"\x0fSearchDocuments\x12\x1f.storage.SearchDocumentsRequest\x1a .storage.SearchDocumentsResponse\x12T\n" +
+	"\x0fAnalyzeDocument\x12\x1f.storage.AnalyzeDocumentRequest\x1a .storage.AnalyzeDocumentResponse\x12K\n" +
+	"\fGetThumbnail\x12\x1c.storage.GetThumbnailRequest\x1a\x1d.storage.GetThumbnailResponse\x12N\n" +
+	"\rDiffDocuments\x12\x1d.storage.DiffDocumentsRequest\x1a\x1e.storage.DiffDocumentsResponse\x12U\n" +
+	"\x10RedlineDocuments\x12 .storage.RedlineDocumentsRequest\x1a\x1d.storage.DownloadFileResponse0\x01\x12Q\n" +
+	"\x0eMergeDocuments\x12\x1e.storage.MergeDocumentsRequest\x1a\x1f.storage.MergeDocumentsResponse\x12N\n" +
+	"\rSplitDocument\x12\x1d.storage.SplitDocumentRequest\x1a\x1e.storage.SplitDocumentResponse\x12N\n" +
+	"\rGetProvenance\x12\x1d.storage.GetProvenanceRequest\x1a\x1e.storage.GetProvenanceResponse\x12G\n" +
+	"\tStampFile\x12\x19.storage.StampFileRequest\x1a\x1d.storage.DownloadFileResponse0\x01\x12Q\n" +
+	"\x0eCreateBatchJob\x12\x1e.storage.CreateBatchJobRequest\x1a\x1f.storage.CreateBatchJobResponse\x12H\n" +
+	"\vGetBatchJob\x12\x1b.storage.GetBatchJobRequest\x1a\x1c.storage.GetBatchJobResponseB<Z:github.com/a1y/doc-formatter/api/grpc/storage/v1;storagepbb\x06proto3"
 
 var (
 	file_api_grpc_storage_v1_storage_proto_rawDescOnce sync.Once
@@ -171,19 +6198,231 @@ func file_api_grpc_storage_v1_storage_proto_rawDescGZIP() []byte {
 	return file_api_grpc_storage_v1_storage_proto_rawDescData
 }
 
-var file_api_grpc_storage_v1_storage_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_api_grpc_storage_v1_storage_proto_msgTypes = make([]protoimpl.MessageInfo, 93)
 var file_api_grpc_storage_v1_storage_proto_goTypes = []any{
-	(*UploadFileRequest)(nil),  // 0: storage.UploadFileRequest
-	(*UploadFileResponse)(nil), // 1: storage.UploadFileResponse
+	(*UploadFileRequest)(nil),          // 0: storage.UploadFileRequest
+	(*UploadFileResponse)(nil),         // 1: storage.UploadFileResponse
+	(*DownloadFileRequest)(nil),        // 2: storage.DownloadFileRequest
+	(*DownloadFileResponse)(nil),       // 3: storage.DownloadFileResponse
+	(*Folder)(nil),                     // 4: storage.Folder
+	(*FileInfo)(nil),                   // 5: storage.FileInfo
+	(*CreateFolderRequest)(nil),        // 6: storage.CreateFolderRequest
+	(*CreateFolderResponse)(nil),       // 7: storage.CreateFolderResponse
+	(*RenameFolderRequest)(nil),        // 8: storage.RenameFolderRequest
+	(*RenameFolderResponse)(nil),       // 9: storage.RenameFolderResponse
+	(*MoveFolderRequest)(nil),          // 10: storage.MoveFolderRequest
+	(*MoveFolderResponse)(nil),         // 11: storage.MoveFolderResponse
+	(*DeleteFolderRequest)(nil),        // 12: storage.DeleteFolderRequest
+	(*DeleteFolderResponse)(nil),       // 13: storage.DeleteFolderResponse
+	(*ListFolderRequest)(nil),          // 14: storage.ListFolderRequest
+	(*ListFolderResponse)(nil),         // 15: storage.ListFolderResponse
+	(*MoveFileRequest)(nil),            // 16: storage.MoveFileRequest
+	(*MoveFileResponse)(nil),           // 17: storage.MoveFileResponse
+	(*AddFileTagsRequest)(nil),         // 18: storage.AddFileTagsRequest
+	(*AddFileTagsResponse)(nil),        // 19: storage.AddFileTagsResponse
+	(*RemoveFileTagsRequest)(nil),      // 20: storage.RemoveFileTagsRequest
+	(*RemoveFileTagsResponse)(nil),     // 21: storage.RemoveFileTagsResponse
+	(*SetFileMetadataRequest)(nil),     // 22: storage.SetFileMetadataRequest
+	(*SetFileMetadataResponse)(nil),    // 23: storage.SetFileMetadataResponse
+	(*RemoveFileMetadataRequest)(nil),  // 24: storage.RemoveFileMetadataRequest
+	(*RemoveFileMetadataResponse)(nil), // 25: storage.RemoveFileMetadataResponse
+	(*ListFilesRequest)(nil),           // 26: storage.ListFilesRequest
+	(*ListFilesResponse)(nil),          // 27: storage.ListFilesResponse
+	(*DeleteFileRequest)(nil),          // 28: storage.DeleteFileRequest
+	(*DeleteFileResponse)(nil),         // 29: storage.DeleteFileResponse
+	(*ListTrashRequest)(nil),           // 30: storage.ListTrashRequest
+	(*ListTrashResponse)(nil),          // 31: storage.ListTrashResponse
+	(*RestoreFileRequest)(nil),         // 32: storage.RestoreFileRequest
+	(*RestoreFileResponse)(nil),        // 33: storage.RestoreFileResponse
+	(*EmptyTrashRequest)(nil),          // 34: storage.EmptyTrashRequest
+	(*EmptyTrashResponse)(nil),         // 35: storage.EmptyTrashResponse
+	(*ShareInfo)(nil),                  // 36: storage.ShareInfo
+	(*ShareRequest)(nil),               // 37: storage.ShareRequest
+	(*ShareResponse)(nil),              // 38: storage.ShareResponse
+	(*UnshareRequest)(nil),             // 39: storage.UnshareRequest
+	(*UnshareResponse)(nil),            // 40: storage.UnshareResponse
+	(*ListSharesRequest)(nil),          // 41: storage.ListSharesRequest
+	(*ListSharesResponse)(nil),         // 42: storage.ListSharesResponse
+	(*ListSharedWithMeRequest)(nil),    // 43: storage.ListSharedWithMeRequest
+	(*SharedItem)(nil),                 // 44: storage.SharedItem
+	(*ListSharedWithMeResponse)(nil),   // 45: storage.ListSharedWithMeResponse
+	(*ShareLinkInfo)(nil),              // 46: storage.ShareLinkInfo
+	(*CreateShareLinkRequest)(nil),     // 47: storage.CreateShareLinkRequest
+	(*CreateShareLinkResponse)(nil),    // 48: storage.CreateShareLinkResponse
+	(*ListShareLinksRequest)(nil),      // 49: storage.ListShareLinksRequest
+	(*ListShareLinksResponse)(nil),     // 50: storage.ListShareLinksResponse
+	(*RevokeShareLinkRequest)(nil),     // 51: storage.RevokeShareLinkRequest
+	(*RevokeShareLinkResponse)(nil),    // 52: storage.RevokeShareLinkResponse
+	(*DownloadSharedFileRequest)(nil),  // 53: storage.DownloadSharedFileRequest
+	(*SearchDocumentsRequest)(nil),     // 54: storage.SearchDocumentsRequest
+	(*SearchHit)(nil),                  // 55: storage.SearchHit
+	(*SearchDocumentsResponse)(nil),    // 56: storage.SearchDocumentsResponse
+	(*AnalyzeDocumentRequest)(nil),     // 57: storage.AnalyzeDocumentRequest
+	(*Heading)(nil),                    // 58: storage.Heading
+	(*DocumentStatistics)(nil),         // 59: storage.DocumentStatistics
+	(*DocumentImage)(nil),              // 60: storage.DocumentImage
+	(*DocumentTable)(nil),              // 61: storage.DocumentTable
+	(*DocumentProperties)(nil),         // 62: storage.DocumentProperties
+	(*AnalyzeDocumentResponse)(nil),    // 63: storage.AnalyzeDocumentResponse
+	(*GetThumbnailRequest)(nil),        // 64: storage.GetThumbnailRequest
+	(*GetThumbnailResponse)(nil),       // 65: storage.GetThumbnailResponse
+	(*DiffDocumentsRequest)(nil),       // 66: storage.DiffDocumentsRequest
+	(*DiffTableRow)(nil),               // 67: storage.DiffTableRow
+	(*DiffBlock)(nil),                  // 68: storage.DiffBlock
+	(*StructuralChange)(nil),           // 69: storage.StructuralChange
+	(*TextLine)(nil),                   // 70: storage.TextLine
+	(*TextHunk)(nil),                   // 71: storage.TextHunk
+	(*DiffSummary)(nil),                // 72: storage.DiffSummary
+	(*DiffDocumentsResponse)(nil),      // 73: storage.DiffDocumentsResponse
+	(*RedlineDocumentsRequest)(nil),    // 74: storage.RedlineDocumentsRequest
+	(*MergeDocumentsRequest)(nil),      // 75: storage.MergeDocumentsRequest
+	(*MergeDocumentsResponse)(nil),     // 76: storage.MergeDocumentsResponse
+	(*SplitDocumentRequest)(nil),       // 77: storage.SplitDocumentRequest
+	(*SplitDocumentResponse)(nil),      // 78: storage.SplitDocumentResponse
+	(*GetProvenanceRequest)(nil),       // 79: storage.GetProvenanceRequest
+	(*ProvenanceLink)(nil),             // 80: storage.ProvenanceLink
+	(*GetProvenanceResponse)(nil),      // 81: storage.GetProvenanceResponse
+	(*StampFileRequest)(nil),           // 82: storage.StampFileRequest
+	(*StyleProfile)(nil),               // 83: storage.StyleProfile
+	(*CreateBatchJobRequest)(nil),      // 84: storage.CreateBatchJobRequest
+	(*CreateBatchJobResponse)(nil),     // 85: storage.CreateBatchJobResponse
+	(*GetBatchJobRequest)(nil),         // 86: storage.GetBatchJobRequest
+	(*GetBatchJobResponse)(nil),        // 87: storage.GetBatchJobResponse
+	(*BatchJobItem)(nil),               // 88: storage.BatchJobItem
+	(*BatchJob)(nil),                   // 89: storage.BatchJob
+	nil,                                // 90: storage.FileInfo.MetadataEntry
+	nil,                                // 91: storage.SetFileMetadataRequest.MetadataEntry
+	nil,                                // 92: storage.ListFilesRequest.MetadataEntry
 }
 var file_api_grpc_storage_v1_storage_proto_depIdxs = []int32{
-	0, // 0: storage.StorageService.UploadFile:input_type -> storage.UploadFileRequest
-	1, // 1: storage.StorageService.UploadFile:output_type -> storage.UploadFileResponse
-	1, // [1:2] is the sub-list for method output_type
-	0, // [0:1] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	90, // 0: storage.FileInfo.metadata:type_name -> storage.FileInfo.MetadataEntry
+	4,  // 1: storage.CreateFolderResponse.folder:type_name -> storage.Folder
+	4,  // 2: storage.RenameFolderResponse.folder:type_name -> storage.Folder
+	4,  // 3: storage.MoveFolderResponse.folder:type_name -> storage.Folder
+	4,  // 4: storage.ListFolderResponse.folders:type_name -> storage.Folder
+	5,  // 5: storage.ListFolderResponse.files:type_name -> storage.FileInfo
+	5,  // 6: storage.MoveFileResponse.file:type_name -> storage.FileInfo
+	5,  // 7: storage.AddFileTagsResponse.file:type_name -> storage.FileInfo
+	5,  // 8: storage.RemoveFileTagsResponse.file:type_name -> storage.FileInfo
+	91, // 9: storage.SetFileMetadataRequest.metadata:type_name -> storage.SetFileMetadataRequest.MetadataEntry
+	5,  // 10: storage.SetFileMetadataResponse.file:type_name -> storage.FileInfo
+	5,  // 11: storage.RemoveFileMetadataResponse.file:type_name -> storage.FileInfo
+	92, // 12: storage.ListFilesRequest.metadata:type_name -> storage.ListFilesRequest.MetadataEntry
+	5,  // 13: storage.ListFilesResponse.files:type_name -> storage.FileInfo
+	5,  // 14: storage.DeleteFileResponse.file:type_name -> storage.FileInfo
+	5,  // 15: storage.ListTrashResponse.files:type_name -> storage.FileInfo
+	5,  // 16: storage.RestoreFileResponse.file:type_name -> storage.FileInfo
+	36, // 17: storage.ShareResponse.share:type_name -> storage.ShareInfo
+	36, // 18: storage.ListSharesResponse.shares:type_name -> storage.ShareInfo
+	36, // 19: storage.SharedItem.share:type_name -> storage.ShareInfo
+	5,  // 20: storage.SharedItem.file:type_name -> storage.FileInfo
+	4,  // 21: storage.SharedItem.folder:type_name -> storage.Folder
+	44, // 22: storage.ListSharedWithMeResponse.items:type_name -> storage.SharedItem
+	46, // 23: storage.CreateShareLinkResponse.link:type_name -> storage.ShareLinkInfo
+	46, // 24: storage.ListShareLinksResponse.links:type_name -> storage.ShareLinkInfo
+	46, // 25: storage.RevokeShareLinkResponse.link:type_name -> storage.ShareLinkInfo
+	5,  // 26: storage.SearchHit.file:type_name -> storage.FileInfo
+	55, // 27: storage.SearchDocumentsResponse.hits:type_name -> storage.SearchHit
+	58, // 28: storage.Heading.children:type_name -> storage.Heading
+	58, // 29: storage.AnalyzeDocumentResponse.outline:type_name -> storage.Heading
+	59, // 30: storage.AnalyzeDocumentResponse.statistics:type_name -> storage.DocumentStatistics
+	60, // 31: storage.AnalyzeDocumentResponse.images:type_name -> storage.DocumentImage
+	61, // 32: storage.AnalyzeDocumentResponse.tables:type_name -> storage.DocumentTable
+	62, // 33: storage.AnalyzeDocumentResponse.properties:type_name -> storage.DocumentProperties
+	67, // 34: storage.DiffBlock.rows:type_name -> storage.DiffTableRow
+	68, // 35: storage.StructuralChange.old:type_name -> storage.DiffBlock
+	68, // 36: storage.StructuralChange.new:type_name -> storage.DiffBlock
+	70, // 37: storage.TextHunk.lines:type_name -> storage.TextLine
+	69, // 38: storage.DiffDocumentsResponse.changes:type_name -> storage.StructuralChange
+	71, // 39: storage.DiffDocumentsResponse.hunks:type_name -> storage.TextHunk
+	72, // 40: storage.DiffDocumentsResponse.summary:type_name -> storage.DiffSummary
+	5,  // 41: storage.MergeDocumentsResponse.file:type_name -> storage.FileInfo
+	5,  // 42: storage.SplitDocumentResponse.files:type_name -> storage.FileInfo
+	5,  // 43: storage.ProvenanceLink.file:type_name -> storage.FileInfo
+	80, // 44: storage.GetProvenanceResponse.sources:type_name -> storage.ProvenanceLink
+	80, // 45: storage.GetProvenanceResponse.derived:type_name -> storage.ProvenanceLink
+	83, // 46: storage.CreateBatchJobRequest.profile:type_name -> storage.StyleProfile
+	89, // 47: storage.CreateBatchJobResponse.job:type_name -> storage.BatchJob
+	89, // 48: storage.GetBatchJobResponse.job:type_name -> storage.BatchJob
+	83, // 49: storage.BatchJob.profile:type_name -> storage.StyleProfile
+	88, // 50: storage.BatchJob.items:type_name -> storage.BatchJobItem
+	0,  // 51: storage.StorageService.UploadFile:input_type -> storage.UploadFileRequest
+	2,  // 52: storage.StorageService.DownloadFile:input_type -> storage.DownloadFileRequest
+	16, // 53: storage.StorageService.MoveFile:input_type -> storage.MoveFileRequest
+	6,  // 54: storage.StorageService.CreateFolder:input_type -> storage.CreateFolderRequest
+	8,  // 55: storage.StorageService.RenameFolder:input_type -> storage.RenameFolderRequest
+	10, // 56: storage.StorageService.MoveFolder:input_type -> storage.MoveFolderRequest
+	12, // 57: storage.StorageService.DeleteFolder:input_type -> storage.DeleteFolderRequest
+	14, // 58: storage.StorageService.ListFolder:input_type -> storage.ListFolderRequest
+	18, // 59: storage.StorageService.AddFileTags:input_type -> storage.AddFileTagsRequest
+	20, // 60: storage.StorageService.RemoveFileTags:input_type -> storage.RemoveFileTagsRequest
+	22, // 61: storage.StorageService.SetFileMetadata:input_type -> storage.SetFileMetadataRequest
+	24, // 62: storage.StorageService.RemoveFileMetadata:input_type -> storage.RemoveFileMetadataRequest
+	26, // 63: storage.StorageService.ListFiles:input_type -> storage.ListFilesRequest
+	28, // 64: storage.StorageService.DeleteFile:input_type -> storage.DeleteFileRequest
+	30, // 65: storage.StorageService.ListTrash:input_type -> storage.ListTrashRequest
+	32, // 66: storage.StorageService.RestoreFile:input_type -> storage.RestoreFileRequest
+	34, // 67: storage.StorageService.EmptyTrash:input_type -> storage.EmptyTrashRequest
+	37, // 68: storage.StorageService.Share:input_type -> storage.ShareRequest
+	39, // 69: storage.StorageService.Unshare:input_type -> storage.UnshareRequest
+	41, // 70: storage.StorageService.ListShares:input_type -> storage.ListSharesRequest
+	43, // 71: storage.StorageService.ListSharedWithMe:input_type -> storage.ListSharedWithMeRequest
+	47, // 72: storage.StorageService.CreateShareLink:input_type -> storage.CreateShareLinkRequest
+	49, // 73: storage.StorageService.ListShareLinks:input_type -> storage.ListShareLinksRequest
+	51, // 74: storage.StorageService.RevokeShareLink:input_type -> storage.RevokeShareLinkRequest
+	53, // 75: storage.StorageService.DownloadSharedFile:input_type -> storage.DownloadSharedFileRequest
+	54, // 76: storage.StorageService.SearchDocuments:input_type -> storage.SearchDocumentsRequest
+	57, // 77: storage.StorageService.AnalyzeDocument:input_type -> storage.AnalyzeDocumentRequest
+	64, // 78: storage.StorageService.GetThumbnail:input_type -> storage.GetThumbnailRequest
+	66, // 79: storage.StorageService.DiffDocuments:input_type -> storage.DiffDocumentsRequest
+	74, // 80: storage.StorageService.RedlineDocuments:input_type -> storage.RedlineDocumentsRequest
+	75, // 81: storage.StorageService.MergeDocuments:input_type -> storage.MergeDocumentsRequest
+	77, // 82: storage.StorageService.SplitDocument:input_type -> storage.SplitDocumentRequest
+	79, // 83: storage.StorageService.GetProvenance:input_type -> storage.GetProvenanceRequest
+	82, // 84: storage.StorageService.StampFile:input_type -> storage.StampFileRequest
+	84, // 85: storage.StorageService.CreateBatchJob:input_type -> storage.CreateBatchJobRequest
+	86, // 86: storage.StorageService.GetBatchJob:input_type -> storage.GetBatchJobRequest
+	1,  // 87: storage.StorageService.UploadFile:output_type -> storage.UploadFileResponse
+	3,  // 88: storage.StorageService.DownloadFile:output_type -> storage.DownloadFileResponse
+	17, // 89: storage.StorageService.MoveFile:output_type -> storage.MoveFileResponse
+	7,  // 90: storage.StorageService.CreateFolder:output_type -> storage.CreateFolderResponse
+	9,  // 91: storage.StorageService.RenameFolder:output_type -> storage.RenameFolderResponse
+	11, // 92: storage.StorageService.MoveFolder:output_type -> storage.MoveFolderResponse
+	13, // 93: storage.StorageService.DeleteFolder:output_type -> storage.DeleteFolderResponse
+	15, // 94: storage.StorageService.ListFolder:output_type -> storage.ListFolderResponse
+	19, // 95: storage.StorageService.AddFileTags:output_type -> storage.AddFileTagsResponse
+	21, // 96: storage.StorageService.RemoveFileTags:output_type -> storage.RemoveFileTagsResponse
+	23, // 97: storage.StorageService.SetFileMetadata:output_type -> storage.SetFileMetadataResponse
+	25, // 98: storage.StorageService.RemoveFileMetadata:output_type -> storage.RemoveFileMetadataResponse
+	27, // 99: storage.StorageService.ListFiles:output_type -> storage.ListFilesResponse
+	29, // 100: storage.StorageService.DeleteFile:output_type -> storage.DeleteFileResponse
+	31, // 101: storage.StorageService.ListTrash:output_type -> storage.ListTrashResponse
+	33, // 102: storage.StorageService.RestoreFile:output_type -> storage.RestoreFileResponse
+	35, // 103: storage.StorageService.EmptyTrash:output_type -> storage.EmptyTrashResponse
+	38, // 104: storage.StorageService.Share:output_type -> storage.ShareResponse
+	40, // 105: storage.StorageService.Unshare:output_type -> storage.UnshareResponse
+	42, // 106: storage.StorageService.ListShares:output_type -> storage.ListSharesResponse
+	45, // 107: storage.StorageService.ListSharedWithMe:output_type -> storage.ListSharedWithMeResponse
+	48, // 108: storage.StorageService.CreateShareLink:output_type -> storage.CreateShareLinkResponse
+	50, // 109: storage.StorageService.ListShareLinks:output_type -> storage.ListShareLinksResponse
+	52, // 110: storage.StorageService.RevokeShareLink:output_type -> storage.RevokeShareLinkResponse
+	3,  // 111: storage.StorageService.DownloadSharedFile:output_type -> storage.DownloadFileResponse
+	56, // 112: storage.StorageService.SearchDocuments:output_type -> storage.SearchDocumentsResponse
+	63, // 113: storage.StorageService.AnalyzeDocument:output_type -> storage.AnalyzeDocumentResponse
+	65, // 114: storage.StorageService.GetThumbnail:output_type -> storage.GetThumbnailResponse
+	73, // 115: storage.StorageService.DiffDocuments:output_type -> storage.DiffDocumentsResponse
+	3,  // 116: storage.StorageService.RedlineDocuments:output_type -> storage.DownloadFileResponse
+	76, // 117: storage.StorageService.MergeDocuments:output_type -> storage.MergeDocumentsResponse
+	78, // 118: storage.StorageService.SplitDocument:output_type -> storage.SplitDocumentResponse
+	81, // 119: storage.StorageService.GetProvenance:output_type -> storage.GetProvenanceResponse
+	3,  // 120: storage.StorageService.StampFile:output_type -> storage.DownloadFileResponse
+	85, // 121: storage.StorageService.CreateBatchJob:output_type -> storage.CreateBatchJobResponse
+	87, // 122: storage.StorageService.GetBatchJob:output_type -> storage.GetBatchJobResponse
+	87, // [87:123] is the sub-list for method output_type
+	51, // [51:87] is the sub-list for method input_type
+	51, // [51:51] is the sub-list for extension type_name
+	51, // [51:51] is the sub-list for extension extendee
+	0,  // [0:51] is the sub-list for field type_name
 }
 
 func init() { file_api_grpc_storage_v1_storage_proto_init() }
@@ -191,13 +6430,15 @@ func file_api_grpc_storage_v1_storage_proto_init() {
 	if File_api_grpc_storage_v1_storage_proto != nil {
 		return
 	}
+	file_api_grpc_storage_v1_storage_proto_msgTypes[26].OneofWrappers = []any{}
+	file_api_grpc_storage_v1_storage_proto_msgTypes[66].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_grpc_storage_v1_storage_proto_rawDesc), len(file_api_grpc_storage_v1_storage_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   93,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
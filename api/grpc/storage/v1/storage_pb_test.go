@@ -37,3 +37,33 @@ func TestUploadFileResponse_GettersAndString(t *testing.T) {
 	require.NotEmpty(t, resp.String())
 	require.NotNil(t, resp.ProtoReflect())
 }
+
+func TestDownloadFileRequest_GettersAndString(t *testing.T) {
+	t.Parallel()
+
+	req := &DownloadFileRequest{
+		UserId: "user-1",
+		FileId: "id-1",
+	}
+
+	require.Equal(t, "user-1", req.GetUserId())
+	require.Equal(t, "id-1", req.GetFileId())
+	require.NotEmpty(t, req.String())
+	require.NotNil(t, req.ProtoReflect())
+}
+
+func TestDownloadFileResponse_GettersAndString(t *testing.T) {
+	t.Parallel()
+
+	resp := &DownloadFileResponse{
+		FileName: "file.txt",
+		FileSize: 42,
+		Chunk:    []byte("data"),
+	}
+
+	require.Equal(t, "file.txt", resp.GetFileName())
+	require.EqualValues(t, 42, resp.GetFileSize())
+	require.Equal(t, []byte("data"), resp.GetChunk())
+	require.NotEmpty(t, resp.String())
+	require.NotNil(t, resp.ProtoReflect())
+}
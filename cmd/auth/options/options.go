@@ -1,25 +1,91 @@
 package options
 
 import (
+	"context"
+	"fmt"
 	"net"
+	"net/mail"
+	"net/url"
 	"strconv"
+	"strings"
+	"time"
 
 	authpb "github.com/a1y/doc-formatter/api/grpc/auth/v1"
+	"github.com/a1y/doc-formatter/cmd/auth/util"
 	"github.com/a1y/doc-formatter/internal/auth"
+	"github.com/a1y/doc-formatter/internal/auth/domain/repository"
 	"github.com/a1y/doc-formatter/internal/auth/handler"
+	"github.com/a1y/doc-formatter/internal/auth/infra/memory"
 	"github.com/a1y/doc-formatter/internal/auth/infra/persistence"
 	"github.com/a1y/doc-formatter/internal/auth/manager/user"
 	jwtutil "github.com/a1y/doc-formatter/internal/auth/util/jwt"
+	"github.com/a1y/doc-formatter/internal/auth/util/mailer"
+	"github.com/a1y/doc-formatter/internal/auth/util/oidc"
+	"github.com/a1y/doc-formatter/internal/auth/util/passwordpolicy"
+	"github.com/a1y/doc-formatter/internal/auth/util/secretbox"
+	"github.com/a1y/doc-formatter/pkg/credentials"
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"google.golang.org/grpc"
 	"k8s.io/kubectl/pkg/util/i18n"
 )
 
+var (
+	ErrSMTPHostNotSpecified     = errors.New("--smtp-host must be specified for the smtp mail driver")
+	ErrMailDirNotSpecified      = errors.New("--mail-dir must be specified for the file mail driver")
+	ErrNegativeVerificationTTL  = errors.New("--verification-ttl must not be negative")
+	ErrInvalidMailFrom          = errors.New("--mail-from must be a valid email address")
+	ErrInvalidVerificationURL   = errors.New("--verification-url must be an absolute URL")
+	ErrNegativePasswordResetTTL = errors.New("--password-reset-ttl must not be negative")
+	ErrInvalidPasswordResetURL  = errors.New("--password-reset-url must be an absolute URL")
+	ErrInvalidArgon2Time        = errors.New("--argon2-time must be at least 1")
+	ErrInvalidArgon2Threads     = errors.New("--argon2-threads must be at least 1")
+	ErrInvalidArgon2Memory      = errors.New("--argon2-memory must be at least 8 KiB per thread")
+	ErrNegativeLoginFailures    = errors.New("--login-max-account-failures and --login-max-ip-failures must not be negative")
+	ErrInvalidLoginLockout      = errors.New("--login-lockout must be positive and not above --login-max-lockout")
+	ErrInvalidLoginWindow       = errors.New("--login-failure-window must be positive")
+	ErrMFAIssuerNotSpecified    = errors.New("--mfa-issuer must not be empty")
+)
+
 type AuthOptions struct {
 	Port              int
 	Database          DatabaseOptions
 	JWTPrivateKeyPath string
+
+	MailDriver           string
+	MailFrom             string
+	MailDir              string
+	SMTP                 mailer.SMTPConfig
+	VerificationTTL      time.Duration
+	VerificationURL      string
+	RequireVerifiedLogin bool
+	PasswordResetTTL     time.Duration
+	PasswordResetURL     string
+
+	Argon2Time    uint32
+	Argon2Memory  uint32
+	Argon2Threads uint8
+
+	PasswordMinLength     int
+	PasswordMaxLength     int
+	PasswordClasses       []string
+	PasswordRejectEmail   bool
+	BreachedPasswordsFile string
+
+	LoginThrottleStore      string
+	LoginMaxAccountFailures int
+	LoginMaxIPFailures      int
+	LoginLockout            time.Duration
+	LoginMaxLockout         time.Duration
+	LoginFailureWindow      time.Duration
+
+	MFAKeyFile string
+	MFAIssuer  string
+
+	OIDCProvidersFile string
+
+	AdminEmails []string
 }
 
 func NewAuthOptions() *AuthOptions {
@@ -27,12 +93,101 @@ func NewAuthOptions() *AuthOptions {
 		Port:              DefaultPort,
 		Database:          DatabaseOptions{},
 		JWTPrivateKeyPath: JWTPrivateKeyPathEnv,
+		MailDriver:        auth.DefaultMailDriver,
+		MailFrom:          DefaultMailFrom,
+		SMTP:              mailer.SMTPConfig{Port: mailer.DefaultSMTPPort},
+		VerificationTTL:   auth.DefaultVerificationTTL,
+		PasswordResetTTL:  auth.DefaultPasswordResetTTL,
+		Argon2Time:        auth.DefaultArgon2Time,
+		Argon2Memory:      auth.DefaultArgon2Memory,
+		Argon2Threads:     auth.DefaultArgon2Threads,
+
+		PasswordMinLength:   auth.DefaultPasswordMinLength,
+		PasswordMaxLength:   auth.DefaultPasswordMaxLength,
+		PasswordRejectEmail: auth.DefaultPasswordRejectEmail,
+
+		LoginThrottleStore:      auth.DefaultLoginThrottleStore,
+		LoginMaxAccountFailures: auth.DefaultLoginMaxAccountFailures,
+		LoginMaxIPFailures:      auth.DefaultLoginMaxIPFailures,
+		LoginLockout:            auth.DefaultLoginLockout,
+		LoginMaxLockout:         auth.DefaultLoginMaxLockout,
+		LoginFailureWindow:      auth.DefaultLoginFailureWindow,
+
+		MFAIssuer: auth.DefaultMFAIssuer,
 	}
 }
 
 func (o *AuthOptions) Complete(args []string) {}
 
 func (o *AuthOptions) Validate() error {
+	var errs []error
+	switch o.MailDriver {
+	case mailer.DriverLog, "":
+	case mailer.DriverSMTP:
+		if len(o.SMTP.Host) == 0 {
+			errs = append(errs, ErrSMTPHostNotSpecified)
+		}
+	case mailer.DriverFile:
+		if len(o.MailDir) == 0 {
+			errs = append(errs, ErrMailDirNotSpecified)
+		}
+	default:
+		errs = append(errs, errors.Errorf("--mail-driver must be one of %s, %s or %s, got %q",
+			mailer.DriverSMTP, mailer.DriverFile, mailer.DriverLog, o.MailDriver))
+	}
+	if o.MailDriver != mailer.DriverLog && o.MailDriver != "" {
+		if _, err := mail.ParseAddress(o.MailFrom); err != nil {
+			errs = append(errs, ErrInvalidMailFrom)
+		}
+	}
+	if o.VerificationTTL < 0 {
+		errs = append(errs, ErrNegativeVerificationTTL)
+	}
+	if o.VerificationURL != "" {
+		if u, err := url.Parse(o.VerificationURL); err != nil || !u.IsAbs() {
+			errs = append(errs, ErrInvalidVerificationURL)
+		}
+	}
+	if o.PasswordResetTTL < 0 {
+		errs = append(errs, ErrNegativePasswordResetTTL)
+	}
+	if o.PasswordResetURL != "" {
+		if u, err := url.Parse(o.PasswordResetURL); err != nil || !u.IsAbs() {
+			errs = append(errs, ErrInvalidPasswordResetURL)
+		}
+	}
+	if o.Argon2Time < 1 {
+		errs = append(errs, ErrInvalidArgon2Time)
+	}
+	if o.Argon2Threads < 1 {
+		errs = append(errs, ErrInvalidArgon2Threads)
+	} else if o.Argon2Memory < 8*uint32(o.Argon2Threads) {
+		errs = append(errs, ErrInvalidArgon2Memory)
+	}
+	if err := o.passwordPolicy().Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	switch o.LoginThrottleStore {
+	case auth.LoginThrottleDatabase, auth.LoginThrottleMemory, auth.LoginThrottleOff:
+	default:
+		errs = append(errs, errors.Errorf("--login-throttle-store must be one of %s, %s or %s, got %q",
+			auth.LoginThrottleDatabase, auth.LoginThrottleMemory, auth.LoginThrottleOff, o.LoginThrottleStore))
+	}
+	if o.LoginMaxAccountFailures < 0 || o.LoginMaxIPFailures < 0 {
+		errs = append(errs, ErrNegativeLoginFailures)
+	}
+	if o.LoginLockout <= 0 || o.LoginLockout > o.LoginMaxLockout {
+		errs = append(errs, ErrInvalidLoginLockout)
+	}
+	if o.LoginFailureWindow <= 0 {
+		errs = append(errs, ErrInvalidLoginWindow)
+	}
+	if strings.TrimSpace(o.MFAIssuer) == "" {
+		errs = append(errs, ErrMFAIssuerNotSpecified)
+	}
+	if errs != nil {
+		return util.AggregateError(errs)
+	}
 	return nil
 }
 
@@ -42,9 +197,110 @@ func (o *AuthOptions) Config() (*auth.Config, error) {
 		return nil, err
 	}
 	cfg.Port = o.Port
+	cfg.MailDriver = o.MailDriver
+	cfg.MailFrom = o.MailFrom
+	cfg.MailDir = o.MailDir
+	cfg.SMTP = o.SMTP
+	cfg.SMTP.From = o.MailFrom
+	cfg.VerificationTTL = o.VerificationTTL
+	cfg.VerificationURL = o.VerificationURL
+	cfg.RequireVerifiedLogin = o.RequireVerifiedLogin
+	cfg.PasswordResetTTL = o.PasswordResetTTL
+	cfg.PasswordResetURL = o.PasswordResetURL
+	cfg.Argon2Time = o.Argon2Time
+	cfg.Argon2Memory = o.Argon2Memory
+	cfg.Argon2Threads = o.Argon2Threads
+
+	policy, err := o.loadPasswordPolicy()
+	if err != nil {
+		return nil, err
+	}
+	cfg.PasswordPolicy = policy
+
+	cfg.LoginThrottleStore = o.LoginThrottleStore
+	cfg.LoginMaxAccountFailures = o.LoginMaxAccountFailures
+	cfg.LoginMaxIPFailures = o.LoginMaxIPFailures
+	cfg.LoginLockout = o.LoginLockout
+	cfg.LoginMaxLockout = o.LoginMaxLockout
+	cfg.LoginFailureWindow = o.LoginFailureWindow
+
+	box, err := o.loadMFABox()
+	if err != nil {
+		return nil, err
+	}
+	cfg.MFABox = box
+	cfg.MFAIssuer = o.MFAIssuer
+
+	providers, err := o.loadOIDCProviders()
+	if err != nil {
+		return nil, err
+	}
+	cfg.OIDCProviders = providers
+	cfg.AdminEmails = o.AdminEmails
 	return cfg, nil
 }
 
+// loadOIDCProviders builds the identity providers configured in
+// --oidc-providers-file. Users cannot log in with any provider without it.
+func (o *AuthOptions) loadOIDCProviders() ([]*oidc.Provider, error) {
+	if o.OIDCProvidersFile == "" {
+		return nil, nil
+	}
+	configs, err := oidc.LoadConfig(o.OIDCProvidersFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load identity providers")
+	}
+	providers := make([]*oidc.Provider, 0, len(configs))
+	for _, c := range configs {
+		p, err := oidc.NewProvider(c, nil)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load identity providers")
+		}
+		providers = append(providers, p)
+		logrus.Infof("Users can log in with identity provider %s at %s", c.Name, c.Issuer)
+	}
+	return providers, nil
+}
+
+// loadMFABox builds the box TOTP secrets are sealed with from --mfa-key-file,
+// nil when it is not set.
+func (o *AuthOptions) loadMFABox() (*secretbox.Box, error) {
+	if o.MFAKeyFile == "" {
+		logrus.Warn("No --mfa-key-file, two-factor authentication is unavailable")
+		return nil, nil
+	}
+	box, err := secretbox.Load(o.MFAKeyFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load MFA key")
+	}
+	return box, nil
+}
+
+func (o *AuthOptions) passwordPolicy() *passwordpolicy.Policy {
+	return &passwordpolicy.Policy{
+		MinLength:   o.PasswordMinLength,
+		MaxLength:   o.PasswordMaxLength,
+		Classes:     o.PasswordClasses,
+		RejectEmail: o.PasswordRejectEmail,
+	}
+}
+
+// loadPasswordPolicy builds the password policy, with the breached passwords
+// of --breached-passwords-file when it is set.
+func (o *AuthOptions) loadPasswordPolicy() (*passwordpolicy.Policy, error) {
+	policy := o.passwordPolicy()
+	if o.BreachedPasswordsFile == "" {
+		return policy, nil
+	}
+	breached, err := passwordpolicy.LoadBreachedList(o.BreachedPasswordsFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load breached passwords")
+	}
+	logrus.Infof("Loaded %d breached password hashes", breached.Len())
+	policy.Breached = breached
+	return policy, nil
+}
+
 func (o *AuthOptions) AddFlags(cmd *cobra.Command) {
 	port, err := strconv.Atoi(PortEnv)
 	if err != nil {
@@ -54,6 +310,156 @@ func (o *AuthOptions) AddFlags(cmd *cobra.Command) {
 		i18n.T("specify the port for the auth service to listen on"))
 	cmd.Flags().StringVar(&o.JWTPrivateKeyPath, "jwt-private-key-path", JWTPrivateKeyPathEnv,
 		i18n.T("specify the path to the JWT private key file"))
+
+	mailDriver := MailDriverEnv
+	if mailDriver == "" {
+		mailDriver = auth.DefaultMailDriver
+	}
+	cmd.Flags().StringVar(&o.MailDriver, "mail-driver", mailDriver,
+		i18n.T("specify how emails are sent: smtp, file or log"))
+	mailFrom := MailFromEnv
+	if mailFrom == "" {
+		mailFrom = DefaultMailFrom
+	}
+	cmd.Flags().StringVar(&o.MailFrom, "mail-from", mailFrom,
+		i18n.T("specify the sender address of emails"))
+	cmd.Flags().StringVar(&o.MailDir, "mail-dir", MailDirEnv,
+		i18n.T("specify the directory the file mail driver writes emails to"))
+	cmd.Flags().StringVar(&o.SMTP.Host, "smtp-host", SMTPHostEnv,
+		i18n.T("specify the host of the SMTP server used by the smtp mail driver"))
+	smtpPort, err := strconv.Atoi(SMTPPortEnv)
+	if err != nil {
+		smtpPort = mailer.DefaultSMTPPort
+	}
+	cmd.Flags().IntVar(&o.SMTP.Port, "smtp-port", smtpPort,
+		i18n.T("specify the port of the SMTP server used by the smtp mail driver"))
+	cmd.Flags().StringVar(&o.SMTP.Username, "smtp-username", SMTPUsernameEnv,
+		i18n.T("specify the SMTP user name, authentication is skipped when empty"))
+	cmd.Flags().StringVar(&o.SMTP.Password, "smtp-password", SMTPPasswordEnv,
+		i18n.T("specify the SMTP password"))
+
+	verificationTTL, err := time.ParseDuration(VerificationTTLEnv)
+	if err != nil {
+		verificationTTL = auth.DefaultVerificationTTL
+	}
+	cmd.Flags().DurationVar(&o.VerificationTTL, "verification-ttl", verificationTTL,
+		i18n.T("specify how long email verification tokens are valid"))
+	cmd.Flags().StringVar(&o.VerificationURL, "verification-url", VerificationURLEnv,
+		i18n.T("specify the page email verification links point to, emails carry the bare token when empty"))
+	requireVerifiedLogin, err := strconv.ParseBool(RequireVerifiedLoginEnv)
+	if err != nil {
+		requireVerifiedLogin = false
+	}
+	cmd.Flags().BoolVar(&o.RequireVerifiedLogin, "require-verified-login", requireVerifiedLogin,
+		i18n.T("refuse to log in users who have not verified their email address"))
+	passwordResetTTL, err := time.ParseDuration(PasswordResetTTLEnv)
+	if err != nil {
+		passwordResetTTL = auth.DefaultPasswordResetTTL
+	}
+	cmd.Flags().DurationVar(&o.PasswordResetTTL, "password-reset-ttl", passwordResetTTL,
+		i18n.T("specify how long password reset tokens are valid"))
+	cmd.Flags().StringVar(&o.PasswordResetURL, "password-reset-url", PasswordResetURLEnv,
+		i18n.T("specify the page password reset links point to, emails carry the bare token when empty"))
+
+	argon2Time, err := strconv.ParseUint(Argon2TimeEnv, 10, 32)
+	if err != nil {
+		argon2Time = auth.DefaultArgon2Time
+	}
+	cmd.Flags().Uint32Var(&o.Argon2Time, "argon2-time", uint32(argon2Time),
+		i18n.T("specify the number of Argon2id iterations passwords are hashed with"))
+	argon2Memory, err := strconv.ParseUint(Argon2MemoryEnv, 10, 32)
+	if err != nil {
+		argon2Memory = auth.DefaultArgon2Memory
+	}
+	cmd.Flags().Uint32Var(&o.Argon2Memory, "argon2-memory", uint32(argon2Memory),
+		i18n.T("specify the memory in KiB passwords are hashed with"))
+	argon2Threads, err := strconv.ParseUint(Argon2ThreadsEnv, 10, 8)
+	if err != nil {
+		argon2Threads = auth.DefaultArgon2Threads
+	}
+	cmd.Flags().Uint8Var(&o.Argon2Threads, "argon2-threads", uint8(argon2Threads),
+		i18n.T("specify the number of threads passwords are hashed with, passwords hashed with fewer iterations or less memory are rehashed on login"))
+
+	passwordMinLength, err := strconv.Atoi(PasswordMinLengthEnv)
+	if err != nil {
+		passwordMinLength = auth.DefaultPasswordMinLength
+	}
+	cmd.Flags().IntVar(&o.PasswordMinLength, "password-min-length", passwordMinLength,
+		i18n.T("specify the minimum number of characters of passwords"))
+	passwordMaxLength, err := strconv.Atoi(PasswordMaxLengthEnv)
+	if err != nil {
+		passwordMaxLength = auth.DefaultPasswordMaxLength
+	}
+	cmd.Flags().IntVar(&o.PasswordMaxLength, "password-max-length", passwordMaxLength,
+		i18n.T("specify the maximum number of characters of passwords, which bounds the cost of hashing them, 0 for no maximum"))
+	var passwordClasses []string
+	if PasswordClassesEnv != "" {
+		passwordClasses = strings.Split(PasswordClassesEnv, ",")
+	}
+	cmd.Flags().StringSliceVar(&o.PasswordClasses, "password-classes", passwordClasses,
+		i18n.T("specify the character classes passwords must contain: lowercase, uppercase, digit or symbol"))
+	passwordRejectEmail, err := strconv.ParseBool(PasswordRejectEmailEnv)
+	if err != nil {
+		passwordRejectEmail = auth.DefaultPasswordRejectEmail
+	}
+	cmd.Flags().BoolVar(&o.PasswordRejectEmail, "password-reject-email", passwordRejectEmail,
+		i18n.T("refuse passwords containing the email address of their user"))
+	cmd.Flags().StringVar(&o.BreachedPasswordsFile, "breached-passwords-file", BreachedPasswordsEnv,
+		i18n.T("specify a file of SHA-1 hashes of breached passwords to refuse, one HASH[:COUNT] per line"))
+
+	loginThrottleStore := LoginThrottleStoreEnv
+	if loginThrottleStore == "" {
+		loginThrottleStore = auth.DefaultLoginThrottleStore
+	}
+	cmd.Flags().StringVar(&o.LoginThrottleStore, "login-throttle-store", loginThrottleStore,
+		i18n.T("specify where failed logins are counted: database, memory for a single instance, or off"))
+	loginMaxAccountFailures, err := strconv.Atoi(LoginMaxAccountFailuresEnv)
+	if err != nil {
+		loginMaxAccountFailures = auth.DefaultLoginMaxAccountFailures
+	}
+	cmd.Flags().IntVar(&o.LoginMaxAccountFailures, "login-max-account-failures", loginMaxAccountFailures,
+		i18n.T("specify the number of failed logins after which an account is locked, 0 to never lock accounts"))
+	loginMaxIPFailures, err := strconv.Atoi(LoginMaxIPFailuresEnv)
+	if err != nil {
+		loginMaxIPFailures = auth.DefaultLoginMaxIPFailures
+	}
+	cmd.Flags().IntVar(&o.LoginMaxIPFailures, "login-max-ip-failures", loginMaxIPFailures,
+		i18n.T("specify the number of failed logins after which a client address is locked, 0 to never lock addresses"))
+	loginLockout, err := time.ParseDuration(LoginLockoutEnv)
+	if err != nil {
+		loginLockout = auth.DefaultLoginLockout
+	}
+	cmd.Flags().DurationVar(&o.LoginLockout, "login-lockout", loginLockout,
+		i18n.T("specify how long the first lockout lasts, every further failed login doubles it"))
+	loginMaxLockout, err := time.ParseDuration(LoginMaxLockoutEnv)
+	if err != nil {
+		loginMaxLockout = auth.DefaultLoginMaxLockout
+	}
+	cmd.Flags().DurationVar(&o.LoginMaxLockout, "login-max-lockout", loginMaxLockout,
+		i18n.T("specify the longest a lockout lasts"))
+	loginFailureWindow, err := time.ParseDuration(LoginFailureWindowEnv)
+	if err != nil {
+		loginFailureWindow = auth.DefaultLoginFailureWindow
+	}
+	cmd.Flags().DurationVar(&o.LoginFailureWindow, "login-failure-window", loginFailureWindow,
+		i18n.T("specify how long failed logins are counted for, counts start over after this long without failures"))
+
+	cmd.Flags().StringVar(&o.MFAKeyFile, "mfa-key-file", MFAKeyFileEnv,
+		i18n.T("specify a file with the base64 encoded 32 byte key TOTP secrets are encrypted with, two-factor authentication is unavailable without it"))
+	mfaIssuer := MFAIssuerEnv
+	if mfaIssuer == "" {
+		mfaIssuer = auth.DefaultMFAIssuer
+	}
+	cmd.Flags().StringVar(&o.MFAIssuer, "mfa-issuer", mfaIssuer,
+		i18n.T("specify the name authenticator apps show accounts under"))
+	cmd.Flags().StringVar(&o.OIDCProvidersFile, "oidc-providers-file", OIDCProvidersFileEnv,
+		i18n.T("specify a YAML file of the OpenID Connect providers users can log in with"))
+	var adminEmails []string
+	if AdminEmailsEnv != "" {
+		adminEmails = strings.Split(AdminEmailsEnv, ",")
+	}
+	cmd.Flags().StringSliceVar(&o.AdminEmails, "admin-emails", adminEmails,
+		i18n.T("specify the email addresses of verified users to make admins on start"))
 	o.Database.AddFlags(cmd.Flags())
 }
 
@@ -63,8 +469,43 @@ func (o *AuthOptions) Run() error {
 		return err
 	}
 
+	m, err := newMailer(config)
+	if err != nil {
+		return err
+	}
+
 	userRepository := persistence.NewUserRepository(config.DB)
 	userManager := user.NewUserManager(userRepository, *jwtutil.NewTokenClaim(o.JWTPrivateKeyPath))
+	userManager.SetMailer(m)
+	userManager.SetVerification(user.VerificationConfig{
+		TokenTTL:         config.VerificationTTL,
+		URL:              config.VerificationURL,
+		RequiredForLogin: config.RequireVerifiedLogin,
+	})
+	userManager.SetPasswordReset(persistence.NewPasswordResetRepository(config.DB), user.PasswordResetConfig{
+		TokenTTL: config.PasswordResetTTL,
+		URL:      config.PasswordResetURL,
+	})
+	userManager.SetAuditRepository(persistence.NewAuditRepository(config.DB))
+	userManager.SetPasswordHasher(credentials.NewArgon2idHash(config.Argon2Time, config.Argon2Memory, config.Argon2Threads,
+		credentials.DefaultKeyLen, credentials.DefaultSaltLen))
+	userManager.SetPasswordPolicy(config.PasswordPolicy)
+	userManager.SetLoginThrottle(newLoginAttemptRepository(config), user.LoginThrottleConfig{
+		MaxAccountFailures: config.LoginMaxAccountFailures,
+		MaxIPFailures:      config.LoginMaxIPFailures,
+		Lockout:            config.LoginLockout,
+		MaxLockout:         config.LoginMaxLockout,
+		Window:             config.LoginFailureWindow,
+	})
+	userManager.SetMFA(persistence.NewMFARepository(config.DB), config.MFABox, user.MFAConfig{
+		Issuer: config.MFAIssuer,
+	})
+	userManager.SetOIDC(persistence.NewIdentityRepository(config.DB), config.OIDCProviders)
+	userManager.SetAPIKeyRepository(persistence.NewAPIKeyRepository(config.DB))
+	userManager.SetRoleRepository(persistence.NewRoleRepository(config.DB))
+	if err := userManager.PromoteAdmins(context.Background(), config.AdminEmails); err != nil {
+		return errors.Wrap(err, "failed to make admins")
+	}
 	authHandler, err := handler.NewHandler(userManager)
 	if err != nil {
 		return err
@@ -83,3 +524,33 @@ func (o *AuthOptions) Run() error {
 
 	return nil
 }
+
+// newLoginAttemptRepository builds the store selected by
+// config.LoginThrottleStore, nil when throttling is off.
+func newLoginAttemptRepository(config *auth.Config) repository.LoginAttemptRepository {
+	switch config.LoginThrottleStore {
+	case auth.LoginThrottleMemory:
+		logrus.Warn("Counting failed logins in memory, run a single instance of the auth service")
+		return memory.NewLoginAttemptRepository()
+	case auth.LoginThrottleOff:
+		logrus.Warn("Login throttling is off")
+		return nil
+	default:
+		return persistence.NewLoginAttemptRepository(config.DB)
+	}
+}
+
+// newMailer builds the mail driver selected by config.MailDriver.
+func newMailer(config *auth.Config) (mailer.Mailer, error) {
+	switch config.MailDriver {
+	case mailer.DriverSMTP:
+		return mailer.NewSMTPMailer(config.SMTP)
+	case mailer.DriverFile:
+		return mailer.NewFileMailer(config.MailDir, config.MailFrom)
+	case mailer.DriverLog, "":
+		logrus.Warn("Using the log mail driver, emails are logged instead of sent")
+		return mailer.NewLogMailer(config.MailFrom), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", mailer.ErrUnknownDriver, config.MailDriver)
+	}
+}
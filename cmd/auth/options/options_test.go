@@ -1,10 +1,19 @@
 package options
 
 import (
+	"encoding/base64"
+	"errors"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
+
+	"github.com/a1y/doc-formatter/internal/auth"
+	"github.com/a1y/doc-formatter/internal/auth/util/mailer"
 
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewAuthOptions(t *testing.T) {
@@ -20,6 +29,89 @@ func TestAuthOptions_Validate(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestAuthOptions_Validate_Mail(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(o *AuthOptions)
+		wantErr error
+	}{
+		{name: "log driver", mutate: func(o *AuthOptions) {}},
+		{name: "empty driver", mutate: func(o *AuthOptions) { o.MailDriver = "" }},
+		{name: "smtp driver with host", mutate: func(o *AuthOptions) {
+			o.MailDriver = mailer.DriverSMTP
+			o.SMTP.Host = "smtp.example.com"
+		}},
+		{name: "file driver with dir", mutate: func(o *AuthOptions) {
+			o.MailDriver = mailer.DriverFile
+			o.MailDir = "/tmp/mail"
+		}},
+		{name: "smtp driver without host", mutate: func(o *AuthOptions) {
+			o.MailDriver = mailer.DriverSMTP
+		}, wantErr: ErrSMTPHostNotSpecified},
+		{name: "file driver without dir", mutate: func(o *AuthOptions) {
+			o.MailDriver = mailer.DriverFile
+		}, wantErr: ErrMailDirNotSpecified},
+		{name: "invalid sender", mutate: func(o *AuthOptions) {
+			o.MailDriver = mailer.DriverFile
+			o.MailDir = "/tmp/mail"
+			o.MailFrom = "not an address"
+		}, wantErr: ErrInvalidMailFrom},
+		{name: "unknown driver", mutate: func(o *AuthOptions) { o.MailDriver = "pigeon" }, wantErr: errors.New(`--mail-driver must be one of smtp, file or log, got "pigeon"`)},
+		{name: "negative ttl", mutate: func(o *AuthOptions) { o.VerificationTTL = -time.Hour }, wantErr: ErrNegativeVerificationTTL},
+		{name: "relative url", mutate: func(o *AuthOptions) { o.VerificationURL = "/verify" }, wantErr: ErrInvalidVerificationURL},
+		{name: "absolute url", mutate: func(o *AuthOptions) { o.VerificationURL = "https://docs.example.com/verify" }},
+		{name: "negative reset ttl", mutate: func(o *AuthOptions) { o.PasswordResetTTL = -time.Hour }, wantErr: ErrNegativePasswordResetTTL},
+		{name: "relative reset url", mutate: func(o *AuthOptions) { o.PasswordResetURL = "reset" }, wantErr: ErrInvalidPasswordResetURL},
+		{name: "no argon2 iterations", mutate: func(o *AuthOptions) { o.Argon2Time = 0 }, wantErr: ErrInvalidArgon2Time},
+		{name: "no argon2 threads", mutate: func(o *AuthOptions) { o.Argon2Threads = 0 }, wantErr: ErrInvalidArgon2Threads},
+		{name: "too little argon2 memory", mutate: func(o *AuthOptions) { o.Argon2Memory = 16 }, wantErr: ErrInvalidArgon2Memory},
+		{name: "password classes", mutate: func(o *AuthOptions) { o.PasswordClasses = []string{"digit", "symbol"} }},
+		{name: "unknown password class", mutate: func(o *AuthOptions) { o.PasswordClasses = []string{"emoji"} }, wantErr: errors.New(`invalid password policy: unknown character class "emoji"`)},
+		{name: "password max below min", mutate: func(o *AuthOptions) { o.PasswordMaxLength = 4 }, wantErr: errors.New("invalid password policy: maximum length 4 is below minimum length 8")},
+		{name: "no password maximum", mutate: func(o *AuthOptions) { o.PasswordMaxLength = 0 }},
+		{name: "memory throttle store", mutate: func(o *AuthOptions) { o.LoginThrottleStore = auth.LoginThrottleMemory }},
+		{name: "throttling off", mutate: func(o *AuthOptions) { o.LoginThrottleStore = auth.LoginThrottleOff }},
+		{name: "unknown throttle store", mutate: func(o *AuthOptions) { o.LoginThrottleStore = "redis" }, wantErr: errors.New(`--login-throttle-store must be one of database, memory or off, got "redis"`)},
+		{name: "negative login failures", mutate: func(o *AuthOptions) { o.LoginMaxIPFailures = -1 }, wantErr: ErrNegativeLoginFailures},
+		{name: "no account lockout", mutate: func(o *AuthOptions) { o.LoginMaxAccountFailures = 0 }},
+		{name: "no lockout", mutate: func(o *AuthOptions) { o.LoginLockout = 0 }, wantErr: ErrInvalidLoginLockout},
+		{name: "lockout above maximum", mutate: func(o *AuthOptions) { o.LoginMaxLockout = time.Second }, wantErr: ErrInvalidLoginLockout},
+		{name: "no failure window", mutate: func(o *AuthOptions) { o.LoginFailureWindow = 0 }, wantErr: ErrInvalidLoginWindow},
+		{name: "no mfa issuer", mutate: func(o *AuthOptions) { o.MFAIssuer = " " }, wantErr: ErrMFAIssuerNotSpecified},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := NewAuthOptions()
+			tt.mutate(opts)
+			err := opts.Validate()
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNewMailer(t *testing.T) {
+	m, err := newMailer(&auth.Config{MailDriver: mailer.DriverLog})
+	assert.NoError(t, err)
+	assert.NotNil(t, m)
+
+	m, err = newMailer(&auth.Config{MailDriver: mailer.DriverFile, MailDir: t.TempDir(), MailFrom: DefaultMailFrom})
+	assert.NoError(t, err)
+	assert.NotNil(t, m)
+
+	_, err = newMailer(&auth.Config{MailDriver: "pigeon"})
+	assert.ErrorIs(t, err, mailer.ErrUnknownDriver)
+}
+
+func TestNewLoginAttemptRepository(t *testing.T) {
+	assert.NotNil(t, newLoginAttemptRepository(&auth.Config{LoginThrottleStore: auth.LoginThrottleMemory}))
+	assert.NotNil(t, newLoginAttemptRepository(&auth.Config{LoginThrottleStore: auth.LoginThrottleDatabase}))
+	assert.Nil(t, newLoginAttemptRepository(&auth.Config{LoginThrottleStore: auth.LoginThrottleOff}))
+}
+
 func TestAuthOptions_Complete(t *testing.T) {
 	opts := NewAuthOptions()
 	assert.NotPanics(t, func() {
@@ -87,6 +179,65 @@ func TestAuthOptions_Config(t *testing.T) {
 	}
 }
 
+func TestAuthOptions_LoadPasswordPolicy(t *testing.T) {
+	opts := NewAuthOptions()
+	policy, err := opts.loadPasswordPolicy()
+	require.NoError(t, err)
+	assert.Equal(t, auth.DefaultPasswordMinLength, policy.MinLength)
+	assert.Equal(t, auth.DefaultPasswordMaxLength, policy.MaxLength)
+	assert.True(t, policy.RejectEmail)
+	assert.Nil(t, policy.Breached)
+
+	opts.BreachedPasswordsFile = filepath.Join(t.TempDir(), "breached.txt")
+	_, err = opts.loadPasswordPolicy()
+	assert.ErrorContains(t, err, "failed to load breached passwords")
+
+	require.NoError(t, os.WriteFile(opts.BreachedPasswordsFile, []byte("5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8:3861493\n"), 0o600))
+	policy, err = opts.loadPasswordPolicy()
+	require.NoError(t, err)
+	assert.True(t, policy.Breached.Contains("password"))
+}
+
+func TestAuthOptions_LoadMFABox(t *testing.T) {
+	opts := NewAuthOptions()
+	box, err := opts.loadMFABox()
+	require.NoError(t, err)
+	assert.Nil(t, box)
+
+	opts.MFAKeyFile = filepath.Join(t.TempDir(), "mfa.key")
+	_, err = opts.loadMFABox()
+	assert.ErrorContains(t, err, "failed to load MFA key")
+
+	require.NoError(t, os.WriteFile(opts.MFAKeyFile, []byte(base64.StdEncoding.EncodeToString(make([]byte, 32))), 0o600))
+	box, err = opts.loadMFABox()
+	require.NoError(t, err)
+	assert.NotNil(t, box)
+}
+
+func TestAuthOptions_LoadOIDCProviders(t *testing.T) {
+	opts := NewAuthOptions()
+	providers, err := opts.loadOIDCProviders()
+	require.NoError(t, err)
+	assert.Empty(t, providers)
+
+	opts.OIDCProvidersFile = filepath.Join(t.TempDir(), "providers.yaml")
+	_, err = opts.loadOIDCProviders()
+	assert.ErrorContains(t, err, "failed to load identity providers")
+
+	require.NoError(t, os.WriteFile(opts.OIDCProvidersFile, []byte(`
+providers:
+  - name: corp
+    issuer: https://idp.example.com
+    client_id: client
+    client_secret: secret
+    redirect_url: https://gateway.example.com/api/v1/auth/oidc/corp/callback
+`), 0o600))
+	providers, err = opts.loadOIDCProviders()
+	require.NoError(t, err)
+	require.Len(t, providers, 1)
+	assert.Equal(t, "corp", providers[0].Name())
+}
+
 func TestAuthOptions_AddFlags(t *testing.T) {
 	opts := NewAuthOptions()
 	cmd := &cobra.Command{}
@@ -98,6 +249,20 @@ func TestAuthOptions_AddFlags(t *testing.T) {
 
 	assert.NotNil(t, cmd.Flags().Lookup("db-name"))
 	assert.NotNil(t, cmd.Flags().Lookup("db-host"))
+	assert.NotNil(t, cmd.Flags().Lookup("mail-driver"))
+	assert.NotNil(t, cmd.Flags().Lookup("require-verified-login"))
+	assert.Equal(t, "3", cmd.Flags().Lookup("argon2-time").DefValue)
+	assert.Equal(t, "65536", cmd.Flags().Lookup("argon2-memory").DefValue)
+	assert.Equal(t, "4", cmd.Flags().Lookup("argon2-threads").DefValue)
+	assert.Equal(t, "8", cmd.Flags().Lookup("password-min-length").DefValue)
+	assert.Equal(t, "true", cmd.Flags().Lookup("password-reject-email").DefValue)
+	assert.NotNil(t, cmd.Flags().Lookup("breached-passwords-file"))
+	assert.Equal(t, "database", cmd.Flags().Lookup("login-throttle-store").DefValue)
+	assert.Equal(t, "5", cmd.Flags().Lookup("login-max-account-failures").DefValue)
+	assert.Equal(t, "1m0s", cmd.Flags().Lookup("login-lockout").DefValue)
+	assert.NotNil(t, cmd.Flags().Lookup("mfa-key-file"))
+	assert.Equal(t, auth.DefaultMFAIssuer, cmd.Flags().Lookup("mfa-issuer").DefValue)
+	assert.NotNil(t, cmd.Flags().Lookup("oidc-providers-file"))
 }
 
 func TestAuthOptions_Run(t *testing.T) {
@@ -16,6 +16,8 @@ type Options interface {
 const (
 	DefaultDBPort = 5432
 	DefaultPort   = 8081
+
+	DefaultMailFrom = "Doc Formatter <noreply@localhost>"
 )
 
 var (
@@ -27,4 +29,35 @@ var (
 	PortEnv              = os.Getenv("AUTH_PORT")
 	AutoMigrateEnv       = os.Getenv("AUTH_AUTO_MIGRATE")
 	JWTPrivateKeyPathEnv = os.Getenv("AUTH_JWT_PRIVATE_KEY_PATH")
+
+	MailDriverEnv              = os.Getenv("AUTH_MAIL_DRIVER")
+	MailFromEnv                = os.Getenv("AUTH_MAIL_FROM")
+	MailDirEnv                 = os.Getenv("AUTH_MAIL_DIR")
+	SMTPHostEnv                = os.Getenv("AUTH_SMTP_HOST")
+	SMTPPortEnv                = os.Getenv("AUTH_SMTP_PORT")
+	SMTPUsernameEnv            = os.Getenv("AUTH_SMTP_USERNAME")
+	SMTPPasswordEnv            = os.Getenv("AUTH_SMTP_PASSWORD")
+	VerificationTTLEnv         = os.Getenv("AUTH_VERIFICATION_TTL")
+	VerificationURLEnv         = os.Getenv("AUTH_VERIFICATION_URL")
+	RequireVerifiedLoginEnv    = os.Getenv("AUTH_REQUIRE_VERIFIED_LOGIN")
+	PasswordResetTTLEnv        = os.Getenv("AUTH_PASSWORD_RESET_TTL")
+	PasswordResetURLEnv        = os.Getenv("AUTH_PASSWORD_RESET_URL")
+	Argon2TimeEnv              = os.Getenv("AUTH_ARGON2_TIME")
+	Argon2MemoryEnv            = os.Getenv("AUTH_ARGON2_MEMORY")
+	Argon2ThreadsEnv           = os.Getenv("AUTH_ARGON2_THREADS")
+	PasswordMinLengthEnv       = os.Getenv("AUTH_PASSWORD_MIN_LENGTH")
+	PasswordMaxLengthEnv       = os.Getenv("AUTH_PASSWORD_MAX_LENGTH")
+	PasswordClassesEnv         = os.Getenv("AUTH_PASSWORD_CLASSES")
+	PasswordRejectEmailEnv     = os.Getenv("AUTH_PASSWORD_REJECT_EMAIL")
+	BreachedPasswordsEnv       = os.Getenv("AUTH_BREACHED_PASSWORDS_FILE")
+	LoginThrottleStoreEnv      = os.Getenv("AUTH_LOGIN_THROTTLE_STORE")
+	LoginMaxAccountFailuresEnv = os.Getenv("AUTH_LOGIN_MAX_ACCOUNT_FAILURES")
+	LoginMaxIPFailuresEnv      = os.Getenv("AUTH_LOGIN_MAX_IP_FAILURES")
+	LoginLockoutEnv            = os.Getenv("AUTH_LOGIN_LOCKOUT")
+	LoginMaxLockoutEnv         = os.Getenv("AUTH_LOGIN_MAX_LOCKOUT")
+	LoginFailureWindowEnv      = os.Getenv("AUTH_LOGIN_FAILURE_WINDOW")
+	MFAKeyFileEnv              = os.Getenv("AUTH_MFA_KEY_FILE")
+	MFAIssuerEnv               = os.Getenv("AUTH_MFA_ISSUER")
+	OIDCProvidersFileEnv       = os.Getenv("AUTH_OIDC_PROVIDERS_FILE")
+	AdminEmailsEnv             = os.Getenv("AUTH_ADMIN_EMAILS")
 )
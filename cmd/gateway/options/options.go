@@ -20,6 +20,10 @@ type Options struct {
 	LogCompress    bool
 	LogEnvironment string
 	LogSample      bool
+
+	// RequireVerifiedUploads refuses uploads from users who have not
+	// verified their email address.
+	RequireVerifiedUploads bool
 }
 
 func NewOptions() *Options {
@@ -31,6 +35,7 @@ func (o *Options) Config() (*gateway.Config, error) {
 	cfg.Address = o.Address
 	cfg.AuthService = o.AuthService
 	cfg.StorageService = o.StorageService
+	cfg.RequireVerifiedUploads = o.RequireVerifiedUploads
 	cfg.Logging.Level = o.LogLevel
 	cfg.Logging.Format = o.LogFormat
 	cfg.Logging.FilePath = o.LogFilePath
@@ -47,6 +52,7 @@ func (o *Options) AddFlags(cmd *cobra.Command) {
 	cmd.Flags().StringVar(&o.Address, "bind-address", ":8080", i18n.T("the address to bind the gateway to"))
 	cmd.Flags().StringVar(&o.AuthService, "auth-service", ":8081", i18n.T("the address of the authentication service"))
 	cmd.Flags().StringVar(&o.StorageService, "storage-service", ":8082", i18n.T("the address of the storage service"))
+	cmd.Flags().BoolVar(&o.RequireVerifiedUploads, "require-verified-uploads", false, i18n.T("refuse uploads from users who have not verified their email address"))
 
 	cmd.Flags().StringVar(&o.LogLevel, "log-level", "info", i18n.T("log level: debug, info, warn, error"))
 	cmd.Flags().StringVar(&o.LogFormat, "log-format", "json", i18n.T("log format: json or console"))
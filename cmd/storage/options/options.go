@@ -2,26 +2,79 @@ package options
 
 import (
 	"context"
+	"fmt"
 	"net"
+	"os"
 	"strconv"
+	"time"
 
+	authpb "github.com/a1y/doc-formatter/api/grpc/auth/v1"
 	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/a1y/doc-formatter/cmd/auth/util"
 	"github.com/a1y/doc-formatter/internal/storage"
+	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
 	"github.com/a1y/doc-formatter/internal/storage/handler"
+	"github.com/a1y/doc-formatter/internal/storage/infra/directory"
 	storagepersistence "github.com/a1y/doc-formatter/internal/storage/infra/persistence"
 	"github.com/a1y/doc-formatter/internal/storage/manager/document"
+	"github.com/a1y/doc-formatter/internal/storage/manager/folder"
+	"github.com/a1y/doc-formatter/internal/storage/manager/share"
+	"github.com/a1y/doc-formatter/internal/storage/util/envelope"
+	"github.com/a1y/doc-formatter/internal/storage/util/objectstore"
+	"github.com/a1y/doc-formatter/internal/storage/util/objectstore/local"
+	"github.com/a1y/doc-formatter/internal/storage/util/objectstore/memory"
 	storages3 "github.com/a1y/doc-formatter/internal/storage/util/s3"
+	"github.com/a1y/doc-formatter/internal/storage/util/stamp"
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 	"k8s.io/kubectl/pkg/util/i18n"
 )
 
+var (
+	ErrLocalRootNotSpecified      = errors.New("--storage-local-root must be specified for the local storage backend")
+	ErrNegativeTrashRetention     = errors.New("--trash-retention must not be negative")
+	ErrNegativeTrashPurgeInterval = errors.New("--trash-purge-interval must not be negative")
+	ErrNegativeSearchInterval     = errors.New("--search-index-interval must not be negative")
+	ErrNegativeBatchInterval      = errors.New("--batch-poll-interval must not be negative")
+	ErrNegativeBatchConcurrency   = errors.New("--batch-concurrency must not be negative")
+	ErrNegativeLinkFailures       = errors.New("--share-link-max-failures and --share-link-max-ip-failures must not be negative")
+	ErrInvalidLinkLockout         = errors.New("--share-link-lockout must not be negative nor above --share-link-max-lockout")
+)
+
 type StorageOptions struct {
 	Port int
 
 	Database DatabaseOptions
 
+	Backend   string
+	LocalRoot string
+
+	EncryptionKeyring string
+
+	TrashRetention     time.Duration
+	TrashPurgeInterval time.Duration
+
+	SearchIndexInterval time.Duration
+	SearchLanguage      string
+
+	BatchPollInterval time.Duration
+	BatchConcurrency  int
+
+	ShareLinkWatermark      string
+	ShareLinkWatermarkImage string
+	ShareLinkHeader         string
+	ShareLinkFooter         string
+
+	ShareLinkMaxFailures   int
+	ShareLinkMaxIPFailures int
+	ShareLinkLockout       time.Duration
+	ShareLinkMaxLockout    time.Duration
+
+	AuthService string
+
 	S3Endpoint        string
 	S3Region          string
 	S3AccessKeyID     string
@@ -32,15 +85,77 @@ type StorageOptions struct {
 
 func NewStorageOptions() *StorageOptions {
 	return &StorageOptions{
-		Port:     DefaultPort,
-		Database: DatabaseOptions{},
+		Port:                DefaultPort,
+		Database:            DatabaseOptions{},
+		Backend:             storage.BackendS3,
+		TrashRetention:      storage.DefaultTrashRetention,
+		TrashPurgeInterval:  storage.DefaultTrashPurgeInterval,
+		SearchIndexInterval: storage.DefaultSearchIndexInterval,
+		SearchLanguage:      storage.DefaultSearchLanguage,
+		BatchPollInterval:   storage.DefaultBatchPollInterval,
+		BatchConcurrency:    storage.DefaultBatchConcurrency,
+
+		ShareLinkMaxFailures:   storage.DefaultShareLinkMaxFailures,
+		ShareLinkMaxIPFailures: storage.DefaultShareLinkMaxIPFailures,
+		ShareLinkLockout:       storage.DefaultShareLinkLockout,
+		ShareLinkMaxLockout:    storage.DefaultShareLinkMaxLockout,
 	}
 }
 
 func (o *StorageOptions) Complete(args []string) {}
 
 func (o *StorageOptions) Validate() error {
-	return o.Database.Validate()
+	var errs []error
+	if err := o.Database.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	switch o.Backend {
+	case storage.BackendS3, storage.BackendMemory, "":
+	case storage.BackendLocal:
+		if len(o.LocalRoot) == 0 {
+			errs = append(errs, ErrLocalRootNotSpecified)
+		}
+	default:
+		errs = append(errs, errors.Errorf("--storage-backend must be one of %s, %s or %s, got %q",
+			storage.BackendS3, storage.BackendLocal, storage.BackendMemory, o.Backend))
+	}
+	if o.TrashRetention < 0 {
+		errs = append(errs, ErrNegativeTrashRetention)
+	}
+	if o.TrashPurgeInterval < 0 {
+		errs = append(errs, ErrNegativeTrashPurgeInterval)
+	}
+	if o.SearchIndexInterval < 0 {
+		errs = append(errs, ErrNegativeSearchInterval)
+	}
+	if _, ok := entity.TextSearchConfig(o.SearchLanguage); !ok && o.SearchLanguage != "" {
+		errs = append(errs, errors.Errorf("--search-language must be a supported language, got %q", o.SearchLanguage))
+	}
+	if o.BatchPollInterval < 0 {
+		errs = append(errs, ErrNegativeBatchInterval)
+	}
+	if o.BatchConcurrency < 0 {
+		errs = append(errs, ErrNegativeBatchConcurrency)
+	}
+	for _, text := range []struct{ flag, value string }{
+		{"--share-link-watermark", o.ShareLinkWatermark},
+		{"--share-link-header", o.ShareLinkHeader},
+		{"--share-link-footer", o.ShareLinkFooter},
+	} {
+		if len(text.value) > stamp.MaxTextLength {
+			errs = append(errs, errors.Errorf("%s must be at most %d bytes", text.flag, stamp.MaxTextLength))
+		}
+	}
+	if o.ShareLinkMaxFailures < 0 || o.ShareLinkMaxIPFailures < 0 {
+		errs = append(errs, ErrNegativeLinkFailures)
+	}
+	if o.ShareLinkLockout < 0 || o.ShareLinkMaxLockout < 0 || (o.ShareLinkMaxLockout > 0 && o.ShareLinkLockout > o.ShareLinkMaxLockout) {
+		errs = append(errs, ErrInvalidLinkLockout)
+	}
+	if errs != nil {
+		return util.AggregateError(errs)
+	}
+	return nil
 }
 
 func (o *StorageOptions) Config() (*storage.Config, error) {
@@ -50,6 +165,28 @@ func (o *StorageOptions) Config() (*storage.Config, error) {
 	}
 
 	cfg.Port = o.Port
+	cfg.Backend = o.Backend
+	cfg.LocalRoot = o.LocalRoot
+	cfg.EncryptionKeyring = o.EncryptionKeyring
+	cfg.TrashRetention = o.TrashRetention
+	cfg.TrashPurgeInterval = o.TrashPurgeInterval
+	cfg.SearchIndexInterval = o.SearchIndexInterval
+	if language, ok := entity.TextSearchConfig(o.SearchLanguage); ok {
+		cfg.SearchLanguage = language
+	}
+	cfg.BatchPollInterval = o.BatchPollInterval
+	if o.BatchConcurrency > 0 {
+		cfg.BatchConcurrency = o.BatchConcurrency
+	}
+	cfg.ShareLinkWatermark = o.ShareLinkWatermark
+	cfg.ShareLinkWatermarkImage = o.ShareLinkWatermarkImage
+	cfg.ShareLinkHeader = o.ShareLinkHeader
+	cfg.ShareLinkFooter = o.ShareLinkFooter
+	cfg.ShareLinkMaxFailures = o.ShareLinkMaxFailures
+	cfg.ShareLinkMaxIPFailures = o.ShareLinkMaxIPFailures
+	cfg.ShareLinkLockout = o.ShareLinkLockout
+	cfg.ShareLinkMaxLockout = o.ShareLinkMaxLockout
+	cfg.AuthService = o.AuthService
 	cfg.EndPoint = o.S3Endpoint
 	cfg.Region = o.S3Region
 	cfg.AccessKeyID = o.S3AccessKeyID
@@ -68,6 +205,97 @@ func (o *StorageOptions) AddFlags(cmd *cobra.Command) {
 	cmd.Flags().IntVarP(&o.Port, "port", "p", port,
 		i18n.T("specify the port for the storage service to listen on"))
 
+	backend := BackendEnv
+	if backend == "" {
+		backend = storage.BackendS3
+	}
+	cmd.Flags().StringVar(&o.Backend, "storage-backend", backend,
+		i18n.T("specify the object storage backend for document content: s3, local or memory"))
+	cmd.Flags().StringVar(&o.LocalRoot, "storage-local-root", LocalRootEnv,
+		i18n.T("specify the root directory used by the local storage backend"))
+	cmd.Flags().StringVar(&o.EncryptionKeyring, "encryption-keyring", KeyringEnv,
+		i18n.T("specify the master keyring file used to encrypt document content, encryption is disabled when empty"))
+
+	retention, err := time.ParseDuration(TrashRetentionEnv)
+	if err != nil {
+		retention = storage.DefaultTrashRetention
+	}
+	cmd.Flags().DurationVar(&o.TrashRetention, "trash-retention", retention,
+		i18n.T("specify how long trashed documents are kept before being purged"))
+	purgeInterval, err := time.ParseDuration(TrashPurgeIntervalEnv)
+	if err != nil {
+		purgeInterval = storage.DefaultTrashPurgeInterval
+	}
+	cmd.Flags().DurationVar(&o.TrashPurgeInterval, "trash-purge-interval", purgeInterval,
+		i18n.T("specify how often expired documents are purged from the trash, the purger is disabled when zero"))
+
+	searchInterval, err := time.ParseDuration(SearchIntervalEnv)
+	if err != nil {
+		searchInterval = storage.DefaultSearchIndexInterval
+	}
+	cmd.Flags().DurationVar(&o.SearchIndexInterval, "search-index-interval", searchInterval,
+		i18n.T("specify how often the text of new documents is indexed for search, the indexer is disabled when zero"))
+	searchLanguage := SearchLanguageEnv
+	if searchLanguage == "" {
+		searchLanguage = storage.DefaultSearchLanguage
+	}
+	cmd.Flags().StringVar(&o.SearchLanguage, "search-language", searchLanguage,
+		i18n.T("specify the language documents are indexed in when their metadata sets none, as an ISO 639-1 code or a PostgreSQL text search configuration"))
+
+	batchInterval, err := time.ParseDuration(BatchIntervalEnv)
+	if err != nil {
+		batchInterval = storage.DefaultBatchPollInterval
+	}
+	cmd.Flags().DurationVar(&o.BatchPollInterval, "batch-poll-interval", batchInterval,
+		i18n.T("specify how often batch formatting jobs are looked for, batch jobs do not run when zero"))
+	batchConcurrency, err := strconv.Atoi(BatchConcurrencyEnv)
+	if err != nil {
+		batchConcurrency = storage.DefaultBatchConcurrency
+	}
+	cmd.Flags().IntVar(&o.BatchConcurrency, "batch-concurrency", batchConcurrency,
+		i18n.T("specify the number of documents of batch jobs formatted at a time, the default is used when zero"))
+
+	cmd.Flags().StringVar(&o.ShareLinkWatermark, "share-link-watermark", LinkWatermarkEnv,
+		i18n.T("specify the watermark text stamped on DOCX and PDF documents downloaded through share links, with the placeholders {name}, {owner}, {user}, {version} and {date}"))
+	cmd.Flags().StringVar(&o.ShareLinkWatermarkImage, "share-link-watermark-image", LinkWatermarkImageEnv,
+		i18n.T("specify a PNG or JPEG image stamped on DOCX and PDF documents downloaded through share links"))
+	cmd.Flags().StringVar(&o.ShareLinkHeader, "share-link-header", LinkHeaderEnv,
+		i18n.T("specify the header text stamped on DOCX and PDF documents downloaded through share links"))
+	cmd.Flags().StringVar(&o.ShareLinkFooter, "share-link-footer", LinkFooterEnv,
+		i18n.T("specify the footer text stamped on DOCX and PDF documents downloaded through share links"))
+
+	linkMaxFailures, err := strconv.Atoi(LinkMaxFailuresEnv)
+	if err != nil {
+		linkMaxFailures = storage.DefaultShareLinkMaxFailures
+	}
+	cmd.Flags().IntVar(&o.ShareLinkMaxFailures, "share-link-max-failures", linkMaxFailures,
+		i18n.T("specify the number of wrong passwords after which a share link is locked, 0 to never lock links"))
+	linkMaxIPFailures, err := strconv.Atoi(LinkMaxIPFailuresEnv)
+	if err != nil {
+		linkMaxIPFailures = storage.DefaultShareLinkMaxIPFailures
+	}
+	cmd.Flags().IntVar(&o.ShareLinkMaxIPFailures, "share-link-max-ip-failures", linkMaxIPFailures,
+		i18n.T("specify the number of wrong share link passwords after which a client address is locked, 0 to never lock addresses"))
+	linkLockout, err := time.ParseDuration(LinkLockoutEnv)
+	if err != nil {
+		linkLockout = storage.DefaultShareLinkLockout
+	}
+	cmd.Flags().DurationVar(&o.ShareLinkLockout, "share-link-lockout", linkLockout,
+		i18n.T("specify how long the first share link lockout lasts, every further wrong password doubles it"))
+	linkMaxLockout, err := time.ParseDuration(LinkMaxLockoutEnv)
+	if err != nil {
+		linkMaxLockout = storage.DefaultShareLinkMaxLockout
+	}
+	cmd.Flags().DurationVar(&o.ShareLinkMaxLockout, "share-link-max-lockout", linkMaxLockout,
+		i18n.T("specify the longest a share link lockout lasts"))
+
+	authService := AuthServiceEnv
+	if authService == "" {
+		authService = DefaultAuthService
+	}
+	cmd.Flags().StringVar(&o.AuthService, "auth-service", authService,
+		i18n.T("specify the address of the authentication service, through which stamps name the owners of documents"))
+
 	cmd.Flags().StringVar(&o.S3Endpoint, "s3-endpoint", S3EndpointEnv,
 		i18n.T("specify the S3 endpoint for the storage service"))
 	cmd.Flags().StringVar(&o.S3Region, "s3-region", S3RegionEnv,
@@ -91,25 +319,98 @@ func (o *StorageOptions) Run() error {
 	}
 
 	documentRepository := storagepersistence.NewDocumentRepository(config.DB)
+	folderRepository := storagepersistence.NewFolderRepository(config.DB)
+	aclRepository := storagepersistence.NewACLRepository(config.DB)
+	shareLinkRepository := storagepersistence.NewShareLinkRepository(config.DB)
+	shareLinkAttemptRepository := storagepersistence.NewShareLinkAttemptRepository(config.DB)
+	documentTextRepository := storagepersistence.NewDocumentTextRepository(config.DB)
+	documentAnalysisRepository := storagepersistence.NewDocumentAnalysisRepository(config.DB)
+	documentSourceRepository := storagepersistence.NewDocumentSourceRepository(config.DB)
+	batchJobRepository := storagepersistence.NewBatchJobRepository(config.DB)
 
 	ctx := context.Background()
-	s3Storage, err := storages3.NewS3Storage(ctx, config)
+	objectStore, err := newObjectStore(ctx, config)
+	if err != nil {
+		return err
+	}
+
+	keyring, err := newKeyring(config.EncryptionKeyring)
 	if err != nil {
 		return err
 	}
 
-	documentManager := document.NewDocumentManager(documentRepository, s3Storage)
-	storageHandler, err := handler.NewHandler(documentManager)
+	documentManager := document.NewDocumentManager(documentRepository, folderRepository, aclRepository, objectStore)
+	documentManager.SetShareLinkRepository(shareLinkRepository)
+	documentManager.SetTextRepository(documentTextRepository)
+	documentManager.SetAnalysisRepository(documentAnalysisRepository)
+	documentManager.SetSourceRepository(documentSourceRepository)
+	documentManager.SetBatchJobRepository(batchJobRepository)
+	documentManager.SetKeyring(keyring)
+	linkStamp, err := newShareLinkStamp(config)
+	if err != nil {
+		return err
+	}
+	if err := documentManager.SetShareLinkStamp(linkStamp); err != nil {
+		return errors.Wrap(err, "invalid share link stamp")
+	}
+	documentManager.SetLinkThrottle(shareLinkAttemptRepository, document.LinkThrottleConfig{
+		MaxLinkFailures: config.ShareLinkMaxFailures,
+		MaxIPFailures:   config.ShareLinkMaxIPFailures,
+		Lockout:         config.ShareLinkLockout,
+		MaxLockout:      config.ShareLinkMaxLockout,
+	})
+	if len(config.AuthService) > 0 {
+		conn, err := grpc.NewClient(config.AuthService, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			return errors.Wrap(err, "failed to connect to the authentication service")
+		}
+		defer conn.Close()
+		documentManager.SetUserDirectory(directory.NewAuthDirectory(authpb.NewAuthServiceClient(conn)))
+	} else {
+		logrus.Warn("No authentication service configured, stamps will show the owners of documents by id")
+	}
+	folderManager := folder.NewFolderManager(folderRepository, documentRepository, aclRepository)
+	shareManager := share.NewShareManager(aclRepository, documentRepository, folderRepository)
+	storageHandler, err := handler.NewHandler(documentManager, folderManager, shareManager)
 	if err != nil {
 		return err
 	}
 
+	go documentManager.RenderThumbnails(ctx)
+
+	if config.TrashPurgeInterval > 0 {
+		locker := storagepersistence.NewLocker(config.DB)
+		purger := document.NewTrashPurger(documentManager, locker, config.TrashRetention, config.TrashPurgeInterval)
+		go purger.Run(ctx)
+	} else {
+		logrus.Warn("Trash purger disabled, trashed documents will be kept until the trash is emptied")
+	}
+
+	if config.SearchIndexInterval > 0 {
+		locker := storagepersistence.NewLocker(config.DB)
+		indexer := document.NewTextIndexer(documentManager, locker, config.SearchLanguage, config.SearchIndexInterval)
+		go indexer.Run(ctx)
+	} else {
+		logrus.Warn("Text indexer disabled, new documents will not be searchable")
+	}
+
+	if config.BatchPollInterval > 0 {
+		locker := storagepersistence.NewLocker(config.DB)
+		runner := document.NewBatchRunner(documentManager, locker, config.BatchConcurrency, config.BatchPollInterval)
+		go runner.Run(ctx)
+	} else {
+		logrus.Warn("Batch runner disabled, batch formatting jobs will stay pending")
+	}
+
 	lis, err := net.Listen("tcp", ":"+strconv.Itoa(config.Port))
 	if err != nil {
 		return err
 	}
 
-	server := grpc.NewServer()
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(handler.RecoveryUnaryInterceptor),
+		grpc.ChainStreamInterceptor(handler.RecoveryStreamInterceptor),
+	)
 	storagepb.RegisterStorageServiceServer(server, storageHandler)
 
 	logrus.Infof("Storage service running at :%d", config.Port)
@@ -120,3 +421,54 @@ func (o *StorageOptions) Run() error {
 
 	return nil
 }
+
+// newObjectStore builds the object storage driver selected by config.Backend.
+func newObjectStore(ctx context.Context, config *storage.Config) (objectstore.ObjectStore, error) {
+	switch config.Backend {
+	case storage.BackendS3, "":
+		return storages3.NewS3Storage(ctx, config)
+	case storage.BackendLocal:
+		return local.NewLocalStorage(config.LocalRoot)
+	case storage.BackendMemory:
+		logrus.Warn("Using in-memory object storage, documents will be lost on restart")
+		return memory.NewMemoryStorage(), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", objectstore.ErrUnknownStorageBackend, config.Backend)
+	}
+}
+
+// newKeyring loads the master keyring at path, or returns nil when path is
+// empty and encryption is disabled.
+func newKeyring(path string) (*envelope.Keyring, error) {
+	if len(path) == 0 {
+		logrus.Warn("No encryption keyring configured, documents will be stored unencrypted")
+		return nil, nil
+	}
+	keyring, err := envelope.LoadKeyring(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load encryption keyring")
+	}
+	logrus.Infof("Encrypting documents with master key %s", keyring.PrimaryKeyID())
+	return keyring, nil
+}
+
+// newShareLinkStamp returns the stamp of share-link downloads set by config,
+// reading its image.
+func newShareLinkStamp(config *storage.Config) (stamp.Stamp, error) {
+	s := stamp.Stamp{
+		Watermark: config.ShareLinkWatermark,
+		Header:    config.ShareLinkHeader,
+		Footer:    config.ShareLinkFooter,
+	}
+	if len(config.ShareLinkWatermarkImage) > 0 {
+		image, err := os.ReadFile(config.ShareLinkWatermarkImage)
+		if err != nil {
+			return stamp.Stamp{}, errors.Wrap(err, "failed to read share link watermark image")
+		}
+		s.Image = image
+	}
+	if !s.Empty() {
+		logrus.Info("Stamping documents downloaded through share links")
+	}
+	return s, nil
+}
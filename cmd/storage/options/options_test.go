@@ -1,8 +1,16 @@
 package options
 
 import (
+	"context"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/a1y/doc-formatter/internal/storage"
+	"github.com/a1y/doc-formatter/internal/storage/util/objectstore"
+	"github.com/a1y/doc-formatter/internal/storage/util/objectstore/local"
+	"github.com/a1y/doc-formatter/internal/storage/util/objectstore/memory"
+	"github.com/a1y/doc-formatter/internal/storage/util/stamp"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 )
@@ -110,6 +118,13 @@ func TestStorageOptions_AddFlags(t *testing.T) {
 	assert.NotNil(t, cmd.Flags().Lookup("s3-bucket"))
 	assert.NotNil(t, cmd.Flags().Lookup("s3-force-path-style"))
 
+	assert.NotNil(t, cmd.Flags().Lookup("trash-retention"))
+	assert.NotNil(t, cmd.Flags().Lookup("trash-purge-interval"))
+
+	authFlag := cmd.Flags().Lookup("auth-service")
+	assert.NotNil(t, authFlag)
+	assert.Equal(t, DefaultAuthService, authFlag.DefValue)
+
 	assert.NotNil(t, cmd.Flags().Lookup("db-name"))
 	assert.NotNil(t, cmd.Flags().Lookup("db-host"))
 }
@@ -154,3 +169,211 @@ func TestStorageOptions_Run(t *testing.T) {
 		})
 	}
 }
+
+func TestStorageOptions_Validate_Backend(t *testing.T) {
+	validDB := DatabaseOptions{
+		DBHost: "localhost",
+		DBName: "testdb",
+		DBUser: "user",
+		DBPort: 5432,
+	}
+
+	tests := []struct {
+		name      string
+		backend   string
+		localRoot string
+		wantErr   bool
+	}{
+		{name: "s3 backend", backend: storage.BackendS3},
+		{name: "memory backend", backend: storage.BackendMemory},
+		{name: "local backend with root", backend: storage.BackendLocal, localRoot: "/tmp/objects"},
+		{name: "local backend without root", backend: storage.BackendLocal, wantErr: true},
+		{name: "unknown backend", backend: "ftp", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := &StorageOptions{
+				Database:  validDB,
+				Backend:   tt.backend,
+				LocalRoot: tt.localRoot,
+			}
+			err := opts.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestStorageOptions_AddFlags_Backend(t *testing.T) {
+	opts := NewStorageOptions()
+	cmd := &cobra.Command{}
+	opts.AddFlags(cmd)
+
+	backendFlag := cmd.Flags().Lookup("storage-backend")
+	assert.NotNil(t, backendFlag)
+	assert.Equal(t, storage.BackendS3, backendFlag.DefValue)
+	assert.NotNil(t, cmd.Flags().Lookup("storage-local-root"))
+}
+
+func TestNewObjectStore(t *testing.T) {
+	ctx := context.Background()
+
+	memoryStore, err := newObjectStore(ctx, &storage.Config{Backend: storage.BackendMemory})
+	assert.NoError(t, err)
+	assert.IsType(t, &memory.MemoryStorage{}, memoryStore)
+
+	localStore, err := newObjectStore(ctx, &storage.Config{Backend: storage.BackendLocal, LocalRoot: t.TempDir()})
+	assert.NoError(t, err)
+	assert.IsType(t, &local.LocalStorage{}, localStore)
+
+	unknownStore, err := newObjectStore(ctx, &storage.Config{Backend: "ftp"})
+	assert.ErrorIs(t, err, objectstore.ErrUnknownStorageBackend)
+	assert.Nil(t, unknownStore)
+}
+
+func TestStorageOptions_AddFlags_EncryptionKeyring(t *testing.T) {
+	opts := NewStorageOptions()
+	cmd := &cobra.Command{}
+	opts.AddFlags(cmd)
+
+	assert.NotNil(t, cmd.Flags().Lookup("encryption-keyring"))
+	assert.NoError(t, cmd.Flags().Set("encryption-keyring", "/etc/doc-formatter/keyring.yaml"))
+	assert.Equal(t, "/etc/doc-formatter/keyring.yaml", opts.EncryptionKeyring)
+}
+
+func TestNewKeyring(t *testing.T) {
+	keyring, err := newKeyring("")
+	assert.NoError(t, err)
+	assert.Nil(t, keyring)
+
+	keyring, err = newKeyring(t.TempDir() + "/missing.yaml")
+	assert.ErrorContains(t, err, "failed to load encryption keyring")
+	assert.Nil(t, keyring)
+}
+
+func TestStorageOptions_Validate_Trash(t *testing.T) {
+	validDB := DatabaseOptions{
+		DBHost: "localhost",
+		DBName: "testdb",
+		DBUser: "user",
+		DBPort: 5432,
+	}
+
+	opts := NewStorageOptions()
+	opts.Database = validDB
+	assert.NoError(t, opts.Validate())
+
+	opts.TrashPurgeInterval = 0
+	assert.NoError(t, opts.Validate())
+
+	opts.TrashRetention = -time.Hour
+	assert.ErrorContains(t, opts.Validate(), "--trash-retention")
+
+	opts.TrashRetention = time.Hour
+	opts.TrashPurgeInterval = -time.Minute
+	assert.ErrorContains(t, opts.Validate(), "--trash-purge-interval")
+}
+
+func TestStorageOptions_Validate_Search(t *testing.T) {
+	opts := NewStorageOptions()
+	opts.Database = DatabaseOptions{
+		DBHost: "localhost",
+		DBName: "testdb",
+		DBUser: "user",
+		DBPort: 5432,
+	}
+	assert.NoError(t, opts.Validate())
+
+	opts.SearchIndexInterval = 0
+	opts.SearchLanguage = "fr"
+	assert.NoError(t, opts.Validate())
+
+	opts.SearchIndexInterval = -time.Second
+	assert.ErrorContains(t, opts.Validate(), "--search-index-interval")
+
+	opts.SearchIndexInterval = time.Minute
+	opts.SearchLanguage = "klingon"
+	assert.ErrorContains(t, opts.Validate(), "--search-language")
+}
+
+func TestStorageOptions_Validate_Batch(t *testing.T) {
+	opts := NewStorageOptions()
+	opts.Database = DatabaseOptions{
+		DBHost: "localhost",
+		DBName: "testdb",
+		DBUser: "user",
+		DBPort: 5432,
+	}
+	assert.NoError(t, opts.Validate())
+
+	opts.BatchPollInterval = 0
+	assert.NoError(t, opts.Validate())
+
+	opts.BatchPollInterval = -time.Second
+	assert.ErrorContains(t, opts.Validate(), "--batch-poll-interval")
+
+	opts.BatchPollInterval = time.Second
+	opts.BatchConcurrency = 0
+	assert.NoError(t, opts.Validate())
+
+	opts.BatchConcurrency = -1
+	assert.ErrorContains(t, opts.Validate(), "--batch-concurrency")
+}
+
+func TestStorageOptions_Validate_ShareLinkStamp(t *testing.T) {
+	opts := NewStorageOptions()
+	opts.Database = DatabaseOptions{
+		DBHost: "localhost",
+		DBName: "testdb",
+		DBUser: "user",
+		DBPort: 5432,
+	}
+	opts.ShareLinkWatermark = "Shared with {user} on {date}"
+	assert.NoError(t, opts.Validate())
+
+	opts.ShareLinkFooter = strings.Repeat("a", stamp.MaxTextLength+1)
+	assert.ErrorContains(t, opts.Validate(), "--share-link-footer")
+}
+
+func TestStorageOptions_Validate_ShareLinkThrottle(t *testing.T) {
+	opts := NewStorageOptions()
+	opts.Database = DatabaseOptions{
+		DBHost: "localhost",
+		DBName: "testdb",
+		DBUser: "user",
+		DBPort: 5432,
+	}
+	assert.NoError(t, opts.Validate())
+	assert.Equal(t, storage.DefaultShareLinkMaxFailures, opts.ShareLinkMaxFailures)
+
+	opts.ShareLinkMaxFailures = 0
+	assert.NoError(t, opts.Validate())
+
+	opts.ShareLinkMaxIPFailures = -1
+	assert.ErrorContains(t, opts.Validate(), "--share-link-max-ip-failures")
+
+	opts.ShareLinkMaxIPFailures = 0
+	opts.ShareLinkLockout = 2 * time.Hour
+	assert.ErrorContains(t, opts.Validate(), "--share-link-lockout")
+}
+
+func TestNewShareLinkStamp(t *testing.T) {
+	cfg := storage.NewConfig()
+	s, err := newShareLinkStamp(cfg)
+	assert.NoError(t, err)
+	assert.True(t, s.Empty())
+
+	cfg.ShareLinkHeader = "Shared copy"
+	cfg.ShareLinkWatermarkImage = t.TempDir() + "/missing.png"
+	_, err = newShareLinkStamp(cfg)
+	assert.ErrorContains(t, err, "failed to read share link watermark image")
+
+	cfg.ShareLinkWatermarkImage = ""
+	s, err = newShareLinkStamp(cfg)
+	assert.NoError(t, err)
+	assert.Equal(t, "Shared copy", s.Header)
+}
@@ -0,0 +1,78 @@
+package options
+
+import (
+	"context"
+
+	"github.com/a1y/doc-formatter/cmd/auth/util"
+	storagepersistence "github.com/a1y/doc-formatter/internal/storage/infra/persistence"
+	"github.com/a1y/doc-formatter/internal/storage/manager/document"
+	"github.com/a1y/doc-formatter/internal/storage/util/envelope"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+	"k8s.io/kubectl/pkg/util/i18n"
+)
+
+var ErrKeyringNotSpecified = errors.New("--encryption-keyring must be specified")
+
+// RewrapKeysOptions holds the options of the command re-wrapping document
+// data keys with the primary master key.
+type RewrapKeysOptions struct {
+	Database DatabaseOptions
+
+	EncryptionKeyring string
+}
+
+func NewRewrapKeysOptions() *RewrapKeysOptions {
+	return &RewrapKeysOptions{
+		Database: DatabaseOptions{},
+	}
+}
+
+func (o *RewrapKeysOptions) Complete(args []string) {}
+
+func (o *RewrapKeysOptions) Validate() error {
+	var errs []error
+	if err := o.Database.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	if len(o.EncryptionKeyring) == 0 {
+		errs = append(errs, ErrKeyringNotSpecified)
+	}
+	if errs != nil {
+		return util.AggregateError(errs)
+	}
+	return nil
+}
+
+func (o *RewrapKeysOptions) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&o.EncryptionKeyring, "encryption-keyring", KeyringEnv,
+		i18n.T("specify the master keyring file, data keys are re-wrapped with its primary key"))
+
+	o.Database.AddFlags(cmd.Flags())
+}
+
+func (o *RewrapKeysOptions) Run() error {
+	keyring, err := envelope.LoadKeyring(o.EncryptionKeyring)
+	if err != nil {
+		return errors.Wrap(err, "failed to load encryption keyring")
+	}
+
+	var db *gorm.DB
+	if err := o.Database.ApplyTo(&db); err != nil {
+		return err
+	}
+
+	// Re-wrapping only rewrites the documents table, the stored content is
+	// encrypted with the data keys and does not change.
+	documentManager := document.NewDocumentManager(storagepersistence.NewDocumentRepository(db), nil, nil, nil)
+	documentManager.SetKeyring(keyring)
+	count, err := documentManager.RewrapDataKeys(context.Background())
+	if err != nil {
+		return errors.Wrapf(err, "re-wrapped %d data keys before failing", count)
+	}
+
+	logrus.Infof("Re-wrapped %d data keys with master key %s", count, keyring.PrimaryKeyID())
+	return nil
+}
@@ -0,0 +1,64 @@
+package options
+
+import (
+	"bytes"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/a1y/doc-formatter/internal/storage/util/envelope"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRewrapKeysOptions_Validate(t *testing.T) {
+	validDB := DatabaseOptions{
+		DBHost: "localhost",
+		DBName: "testdb",
+		DBUser: "user",
+		DBPort: 5432,
+	}
+
+	opts := NewRewrapKeysOptions()
+	opts.Database = validDB
+	assert.ErrorContains(t, opts.Validate(), ErrKeyringNotSpecified.Error())
+
+	opts.EncryptionKeyring = "/etc/doc-formatter/keyring.yaml"
+	assert.NoError(t, opts.Validate())
+
+	opts.Database = DatabaseOptions{}
+	assert.Error(t, opts.Validate())
+}
+
+func TestRewrapKeysOptions_Complete(t *testing.T) {
+	opts := NewRewrapKeysOptions()
+	assert.NotPanics(t, func() {
+		opts.Complete([]string{})
+	})
+}
+
+func TestRewrapKeysOptions_AddFlags(t *testing.T) {
+	opts := NewRewrapKeysOptions()
+	cmd := &cobra.Command{}
+	opts.AddFlags(cmd)
+
+	assert.NotNil(t, cmd.Flags().Lookup("encryption-keyring"))
+	assert.NotNil(t, cmd.Flags().Lookup("db-host"))
+	assert.NotNil(t, cmd.Flags().Lookup("db-name"))
+}
+
+func TestRewrapKeysOptions_Run(t *testing.T) {
+	opts := NewRewrapKeysOptions()
+	opts.EncryptionKeyring = filepath.Join(t.TempDir(), "missing.yaml")
+	assert.ErrorContains(t, opts.Run(), "failed to load encryption keyring")
+
+	keyringPath := filepath.Join(t.TempDir(), "keyring.yaml")
+	key := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, envelope.KeySize))
+	assert.NoError(t, os.WriteFile(keyringPath, []byte("primary: k1\nkeys:\n  - id: k1\n    key: "+key+"\n"), 0o600))
+
+	// The database is unreachable, so the run fails after loading the keyring.
+	opts.EncryptionKeyring = keyringPath
+	opts.Database = DatabaseOptions{DBHost: "127.0.0.1", DBPort: 1, DBName: "testdb", DBUser: "user"}
+	assert.Error(t, opts.Run())
+}
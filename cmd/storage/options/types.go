@@ -5,22 +5,41 @@ import (
 )
 
 const (
-	DefaultDBPort = 5432
-	DefaultPort   = 8082
+	DefaultDBPort      = 5432
+	DefaultPort        = 8082
+	DefaultAuthService = ":8081"
 )
 
 var (
-	DBHostEnv      = os.Getenv("STORAGE_DB_HOST")
-	DBPortEnv      = os.Getenv("STORAGE_DB_PORT")
-	DBUserEnv      = os.Getenv("STORAGE_DB_USER")
-	DBPassEnv      = os.Getenv("STORAGE_DB_PASS")
-	DBNameEnv      = os.Getenv("STORAGE_DB_NAME")
-	PortEnv        = os.Getenv("STORAGE_PORT")
-	AutoMigrateEnv = os.Getenv("STORAGE_AUTO_MIGRATE")
-	S3EndpointEnv  = os.Getenv("STORAGE_S3_ENDPOINT")
-	S3RegionEnv    = os.Getenv("STORAGE_S3_REGION")
-	S3AccessIDEnv  = os.Getenv("STORAGE_S3_ACCESS_KEY_ID")
-	S3AccessKeyEnv = os.Getenv("STORAGE_S3_ACCESS_KEY_SECRET")
-	S3BucketEnv    = os.Getenv("STORAGE_S3_BUCKET")
-	S3ForcePathEnv = os.Getenv("STORAGE_S3_FORCE_PATH_STYLE")
+	DBHostEnv             = os.Getenv("STORAGE_DB_HOST")
+	DBPortEnv             = os.Getenv("STORAGE_DB_PORT")
+	DBUserEnv             = os.Getenv("STORAGE_DB_USER")
+	DBPassEnv             = os.Getenv("STORAGE_DB_PASS")
+	DBNameEnv             = os.Getenv("STORAGE_DB_NAME")
+	PortEnv               = os.Getenv("STORAGE_PORT")
+	AutoMigrateEnv        = os.Getenv("STORAGE_AUTO_MIGRATE")
+	BackendEnv            = os.Getenv("STORAGE_BACKEND")
+	LocalRootEnv          = os.Getenv("STORAGE_LOCAL_ROOT")
+	KeyringEnv            = os.Getenv("STORAGE_ENCRYPTION_KEYRING")
+	TrashRetentionEnv     = os.Getenv("STORAGE_TRASH_RETENTION")
+	TrashPurgeIntervalEnv = os.Getenv("STORAGE_TRASH_PURGE_INTERVAL")
+	SearchIntervalEnv     = os.Getenv("STORAGE_SEARCH_INDEX_INTERVAL")
+	SearchLanguageEnv     = os.Getenv("STORAGE_SEARCH_LANGUAGE")
+	BatchIntervalEnv      = os.Getenv("STORAGE_BATCH_POLL_INTERVAL")
+	BatchConcurrencyEnv   = os.Getenv("STORAGE_BATCH_CONCURRENCY")
+	LinkWatermarkEnv      = os.Getenv("STORAGE_SHARE_LINK_WATERMARK")
+	LinkWatermarkImageEnv = os.Getenv("STORAGE_SHARE_LINK_WATERMARK_IMAGE")
+	LinkHeaderEnv         = os.Getenv("STORAGE_SHARE_LINK_HEADER")
+	LinkFooterEnv         = os.Getenv("STORAGE_SHARE_LINK_FOOTER")
+	LinkMaxFailuresEnv    = os.Getenv("STORAGE_SHARE_LINK_MAX_FAILURES")
+	LinkMaxIPFailuresEnv  = os.Getenv("STORAGE_SHARE_LINK_MAX_IP_FAILURES")
+	LinkLockoutEnv        = os.Getenv("STORAGE_SHARE_LINK_LOCKOUT")
+	LinkMaxLockoutEnv     = os.Getenv("STORAGE_SHARE_LINK_MAX_LOCKOUT")
+	AuthServiceEnv        = os.Getenv("STORAGE_AUTH_SERVICE")
+	S3EndpointEnv         = os.Getenv("STORAGE_S3_ENDPOINT")
+	S3RegionEnv           = os.Getenv("STORAGE_S3_REGION")
+	S3AccessIDEnv         = os.Getenv("STORAGE_S3_ACCESS_KEY_ID")
+	S3AccessKeyEnv        = os.Getenv("STORAGE_S3_ACCESS_KEY_SECRET")
+	S3BucketEnv           = os.Getenv("STORAGE_S3_BUCKET")
+	S3ForcePathEnv        = os.Getenv("STORAGE_S3_FORCE_PATH_STYLE")
 )
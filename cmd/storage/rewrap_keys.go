@@ -0,0 +1,46 @@
+package storage
+
+import (
+	"github.com/a1y/doc-formatter/cmd/storage/options"
+	"github.com/a1y/doc-formatter/cmd/util"
+	"github.com/spf13/cobra"
+
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+func NewCmdRewrapKeys() *cobra.Command {
+	var (
+		rewrapShort = i18n.T(`Re-wrap document data keys with the primary master key.`)
+
+		rewrapLong = i18n.T(`
+		Re-wrap the data key of every encrypted document with the primary key of the keyring.
+
+		Run it after rotating the master key: add the new key to the keyring, make it primary and
+		restart the storage service, then re-wrap. Once it completes the old key can be removed from
+		the keyring. Document content is not re-encrypted.`)
+
+		rewrapExample = i18n.T(`
+		# Re-wrap all data keys after a master key rotation
+		storage rewrap-keys --db-host localhost --db-port 5432 --db-name storage --db-user root --db-pass 123456 --encryption-keyring /etc/doc-formatter/keyring.yaml`)
+	)
+
+	o := options.NewRewrapKeysOptions()
+	cmd := &cobra.Command{
+		Use:     "rewrap-keys",
+		Short:   rewrapShort,
+		Long:    templates.LongDesc(rewrapLong),
+		Example: templates.Examples(rewrapExample),
+		RunE: func(_ *cobra.Command, args []string) (err error) {
+			defer util.RecoverErr(&err)
+			o.Complete(args)
+			util.CheckErr(o.Validate())
+			util.CheckErr(o.Run())
+			return
+		},
+	}
+
+	o.AddFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,26 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCmdRewrapKeys(t *testing.T) {
+	cmd := NewCmdRewrapKeys()
+
+	assert.NotNil(t, cmd)
+	assert.Equal(t, "rewrap-keys", cmd.Use)
+	assert.NotEmpty(t, cmd.Short)
+	assert.NotEmpty(t, cmd.Long)
+	assert.NotEmpty(t, cmd.Example)
+	assert.NotNil(t, cmd.Flags().Lookup("encryption-keyring"))
+	assert.NotNil(t, cmd.Flags().Lookup("db-host"))
+}
+
+func TestNewCmdRewrapKeys_RunE_Validation(t *testing.T) {
+	cmd := NewCmdRewrapKeys()
+
+	err := cmd.RunE(cmd, []string{})
+	assert.Error(t, err)
+}
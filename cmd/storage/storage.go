@@ -17,7 +17,13 @@ func NewCmdStorage() *cobra.Command {
 
 		serverExample = i18n.T(`
 		# Start storage service
-		storage --db-host localhost --db-port 5432 --db-name storage --db-user root --db-pass 123456 --s3-endpoint http://localhost:9000 --s3-bucket my-bucket`)
+		storage --db-host localhost --db-port 5432 --db-name storage --db-user root --db-pass 123456 --s3-endpoint http://localhost:9000 --s3-bucket my-bucket
+
+		# Start storage service keeping documents on the local filesystem
+		storage --db-host localhost --db-port 5432 --db-name storage --db-user root --db-pass 123456 --storage-backend local --storage-local-root /var/lib/doc-formatter
+
+		# Start storage service encrypting documents with the keys of a keyring file
+		storage --db-host localhost --db-port 5432 --db-name storage --db-user root --db-pass 123456 --s3-endpoint http://localhost:9000 --s3-bucket my-bucket --encryption-keyring /etc/doc-formatter/keyring.yaml`)
 	)
 
 	o := options.NewStorageOptions()
@@ -37,5 +43,7 @@ func NewCmdStorage() *cobra.Command {
 
 	o.AddFlags(cmd)
 
+	cmd.AddCommand(NewCmdRewrapKeys())
+
 	return cmd
 }
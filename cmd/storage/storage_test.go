@@ -21,6 +21,10 @@ func TestNewCmdStorage(t *testing.T) {
 	assert.NotNil(t, cmd.Flags().Lookup("db-user"))
 	assert.NotNil(t, cmd.Flags().Lookup("db-pass"))
 
+	assert.NotNil(t, cmd.Flags().Lookup("storage-backend"))
+	assert.NotNil(t, cmd.Flags().Lookup("storage-local-root"))
+	assert.NotNil(t, cmd.Flags().Lookup("encryption-keyring"))
+
 	assert.NotNil(t, cmd.Flags().Lookup("s3-endpoint"))
 	assert.NotNil(t, cmd.Flags().Lookup("s3-region"))
 	assert.NotNil(t, cmd.Flags().Lookup("s3-access-key-id"))
@@ -29,6 +33,14 @@ func TestNewCmdStorage(t *testing.T) {
 	assert.NotNil(t, cmd.Flags().Lookup("s3-force-path-style"))
 }
 
+func TestNewCmdStorage_HasRewrapKeysSubcommand(t *testing.T) {
+	cmd := NewCmdStorage()
+
+	sub, _, err := cmd.Find([]string{"rewrap-keys"})
+	assert.NoError(t, err)
+	assert.Equal(t, "rewrap-keys", sub.Use)
+}
+
 func TestNewCmdStorage_RunE_Validation(t *testing.T) {
 	cmd := NewCmdStorage()
 
@@ -12,6 +12,16 @@ import (
 // @version		1.0
 // @description	API for AI Doc Formatter
 // @BasePath		/
+//
+// @securityDefinitions.apikey	BearerAuth
+// @in							header
+// @name						Authorization
+// @description				Access token from /api/v1/auth/login, as "Bearer <token>".
+//
+// @securityDefinitions.apikey	ApiKeyAuth
+// @in							header
+// @name						Authorization
+// @description				Personal API key from /api/v1/auth/me/api-keys, as "ApiKey <key>".
 func main() {
 	rand.New(rand.NewSource(time.Now().UnixNano()))
 
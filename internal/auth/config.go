@@ -1,10 +1,129 @@
 package auth
 
-import "gorm.io/gorm"
+import (
+	"time"
+
+	"github.com/a1y/doc-formatter/internal/auth/util/mailer"
+	"github.com/a1y/doc-formatter/internal/auth/util/oidc"
+	"github.com/a1y/doc-formatter/internal/auth/util/passwordpolicy"
+	"github.com/a1y/doc-formatter/internal/auth/util/secretbox"
+	"gorm.io/gorm"
+)
+
+// Verification defaults: verification emails are only logged, and their
+// tokens are valid for a day. Password reset tokens are valid for an hour.
+const (
+	DefaultMailDriver       = mailer.DriverLog
+	DefaultVerificationTTL  = 24 * time.Hour
+	DefaultPasswordResetTTL = time.Hour
+)
+
+// Password hashing defaults: Argon2id with 3 iterations over 64 MiB on 4
+// threads. Raising them rehashes passwords as their users log in.
+const (
+	DefaultArgon2Time    = 3
+	DefaultArgon2Memory  = 64 * 1024
+	DefaultArgon2Threads = 4
+)
+
+// Password policy defaults: passwords of 8 to 128 characters that do not
+// contain the email address of their user.
+const (
+	DefaultPasswordMinLength   = 8
+	DefaultPasswordMaxLength   = 128
+	DefaultPasswordRejectEmail = true
+)
+
+// Stores failed logins are counted in: the database, shared by every
+// instance of the auth service, process memory, for a single instance, or
+// none, which turns throttling off.
+const (
+	LoginThrottleDatabase = "database"
+	LoginThrottleMemory   = "memory"
+	LoginThrottleOff      = "off"
+)
+
+// Login throttling defaults: accounts are locked after 5 failed logins and
+// client addresses after 50, for a minute that doubles with every further
+// failure up to an hour. Failures are counted until a day has passed without
+// any.
+const (
+	DefaultLoginThrottleStore      = LoginThrottleDatabase
+	DefaultLoginMaxAccountFailures = 5
+	DefaultLoginMaxIPFailures      = 50
+	DefaultLoginLockout            = time.Minute
+	DefaultLoginMaxLockout         = time.Hour
+	DefaultLoginFailureWindow      = 24 * time.Hour
+)
+
+// DefaultMFAIssuer is the name authenticator apps show accounts under.
+const DefaultMFAIssuer = "Doc Formatter"
 
 type Config struct {
 	DB   *gorm.DB
 	Port int
+
+	// MailDriver selects how emails are sent: smtp, file or log.
+	MailDriver string
+	// MailFrom is the sender address of emails.
+	MailFrom string
+	// SMTP is the server used by the smtp mail driver.
+	SMTP mailer.SMTPConfig
+	// MailDir is the directory the file mail driver writes emails to.
+	MailDir string
+	// VerificationTTL is how long email verification tokens are valid.
+	VerificationTTL time.Duration
+	// VerificationURL is the page verification links point to. Emails carry
+	// the bare token when it is empty.
+	VerificationURL string
+	// RequireVerifiedLogin refuses to log in users who have not verified
+	// their email address.
+	RequireVerifiedLogin bool
+	// PasswordResetTTL is how long password reset tokens are valid.
+	PasswordResetTTL time.Duration
+	// PasswordResetURL is the page password reset links point to. Emails
+	// carry the bare token when it is empty.
+	PasswordResetURL string
+
+	// Argon2Time is the number of Argon2id iterations passwords are hashed
+	// with.
+	Argon2Time uint32
+	// Argon2Memory is the memory passwords are hashed with, in KiB.
+	Argon2Memory uint32
+	// Argon2Threads is the number of threads passwords are hashed with.
+	Argon2Threads uint8
+
+	// PasswordPolicy is the policy new passwords must follow.
+	PasswordPolicy *passwordpolicy.Policy
+
+	// LoginThrottleStore selects where failed logins are counted: database,
+	// memory or off.
+	LoginThrottleStore string
+	// LoginMaxAccountFailures is the number of failed logins to an account
+	// after which it is locked.
+	LoginMaxAccountFailures int
+	// LoginMaxIPFailures is the number of failed logins from a client
+	// address after which it is locked.
+	LoginMaxIPFailures int
+	// LoginLockout is how long the first lockout lasts.
+	LoginLockout time.Duration
+	// LoginMaxLockout caps how long lockouts last.
+	LoginMaxLockout time.Duration
+	// LoginFailureWindow is how long failed logins are counted for.
+	LoginFailureWindow time.Duration
+
+	// MFABox seals the TOTP secrets of two-factor authentication. It is
+	// unavailable when MFABox is nil.
+	MFABox *secretbox.Box
+	// MFAIssuer is the name authenticator apps show accounts under.
+	MFAIssuer string
+
+	// OIDCProviders are the OpenID Connect providers users can log in with.
+	OIDCProviders []*oidc.Provider
+
+	// AdminEmails are the email addresses of the verified users made admins
+	// on start.
+	AdminEmails []string
 }
 
 func NewConfig() *Config {
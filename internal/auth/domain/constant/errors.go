@@ -5,4 +5,43 @@ import "errors"
 var (
 	ErrInvalidCredentials = errors.New("invalid email or password")
 	ErrEmailExists        = errors.New("email already exists")
+	ErrUserNotFound       = errors.New("user not found")
+
+	ErrInvalidVerificationToken = errors.New("invalid or expired verification token")
+	ErrEmailNotVerified         = errors.New("email address not verified")
+	ErrInvalidResetToken        = errors.New("invalid or expired password reset token")
+	ErrPasswordRequired         = errors.New("password is required")
+	ErrInvalidAccessToken       = errors.New("invalid or expired access token")
+	ErrIncorrectPassword        = errors.New("current password is incorrect")
+	ErrEmailRequired            = errors.New("email is required")
+	ErrEmailUnchanged           = errors.New("new email is the current email")
+	ErrMailUnavailable          = errors.New("email cannot be sent")
+	ErrTooManyLoginAttempts     = errors.New("too many failed login attempts")
+
+	ErrMFARequired       = errors.New("two-factor authentication required")
+	ErrMFAUnavailable    = errors.New("two-factor authentication is not available")
+	ErrMFAAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	ErrMFANotEnrolled    = errors.New("two-factor authentication enrollment was not started")
+	ErrInvalidMFACode    = errors.New("invalid two-factor authentication code")
+	ErrInvalidMFAToken   = errors.New("invalid or expired two-factor authentication token")
+
+	ErrOIDCProviderNotFound   = errors.New("identity provider not found")
+	ErrInvalidOIDCLogin       = errors.New("invalid or expired identity provider login")
+	ErrOIDCEmailNotVerified   = errors.New("identity provider did not verify the email address")
+	ErrOIDCAccountNotVerified = errors.New("an account with this email address exists but its email address is not verified")
+
+	ErrAPIKeysUnavailable = errors.New("api keys are not available")
+	ErrAPIKeyNotFound     = errors.New("api key not found")
+	ErrInvalidAPIKey      = errors.New("invalid or revoked api key")
+	ErrInvalidAPIKeyScope = errors.New("invalid api key scope")
+
+	ErrAccountDisabled   = errors.New("account is disabled")
+	ErrPermissionDenied  = errors.New("permission denied")
+	ErrRoleNotFound      = errors.New("role not found")
+	ErrRoleExists        = errors.New("role already exists")
+	ErrInvalidRole       = errors.New("invalid role")
+	ErrRolesUnavailable  = errors.New("custom roles are not available")
+	ErrInvalidPageToken  = errors.New("invalid page token")
+	ErrCannotActOnSelf   = errors.New("administrators cannot do this to their own account")
+	ErrCannotImpersonate = errors.New("users with permissions cannot be impersonated")
 )
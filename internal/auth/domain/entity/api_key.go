@@ -0,0 +1,58 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Scopes API keys can be restricted to. A key without scopes has them all.
+const (
+	APIKeyScopeRead   = "read"
+	APIKeyScopeUpload = "upload"
+	APIKeyScopeFormat = "format"
+)
+
+// APIKeyScopes are the scopes API keys can be restricted to.
+var APIKeyScopes = []string{APIKeyScopeRead, APIKeyScopeUpload, APIKeyScopeFormat}
+
+// APIKey lets scripts act as a user without their password. Only a hash of
+// the key is stored, and its prefix to look it up by.
+type APIKey struct {
+	ID     uuid.UUID `yaml:"id" json:"id"`
+	UserID uuid.UUID `yaml:"user_id" json:"user_id"`
+	// Name tells the keys of a user apart.
+	Name    string `yaml:"name" json:"name"`
+	Prefix  string `yaml:"prefix" json:"prefix"`
+	KeyHash string `yaml:"key_hash" json:"key_hash"`
+	// Scopes restrict what the key can be used for, none meaning no
+	// restriction.
+	Scopes     []string   `yaml:"scopes" json:"scopes"`
+	LastUsedAt *time.Time `yaml:"last_used_at" json:"last_used_at"`
+	RevokedAt  *time.Time `yaml:"revoked_at" json:"revoked_at"`
+	CreatedAt  time.Time  `yaml:"created_at" json:"created_at"`
+}
+
+func (k *APIKey) Validate() error {
+	if k.UserID == uuid.Nil {
+		return errors.New("user id is required")
+	}
+	if k.Name == "" {
+		return errors.New("name is required")
+	}
+	if k.Prefix == "" {
+		return errors.New("prefix is required")
+	}
+	if k.KeyHash == "" {
+		return errors.New("key hash is required")
+	}
+	for _, scope := range k.Scopes {
+		if !slices.Contains(APIKeyScopes, scope) {
+			return fmt.Errorf("unknown scope %q", scope)
+		}
+	}
+	return nil
+}
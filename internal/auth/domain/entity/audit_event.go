@@ -0,0 +1,52 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Audited actions on accounts.
+const (
+	AuditPasswordChanged      = "password_changed"
+	AuditPasswordReset        = "password_reset"
+	AuditEmailChangeRequested = "email_change_requested"
+	AuditEmailChanged         = "email_changed"
+	AuditMFAEnabled           = "mfa_enabled"
+	AuditRecoveryCodeUsed     = "recovery_code_used"
+	AuditIdentityLinked       = "identity_linked"
+	AuditAPIKeyCreated        = "api_key_created"
+	AuditAPIKeyRevoked        = "api_key_revoked"
+	AuditUsersListed          = "users_listed"
+	AuditUserDisabled         = "user_disabled"
+	AuditUserEnabled          = "user_enabled"
+	AuditUserVerified         = "user_verified"
+	AuditMFAReset             = "mfa_reset"
+	AuditImpersonated         = "impersonated"
+	AuditRoleChanged          = "role_changed"
+	AuditRoleCreated          = "role_created"
+)
+
+// AuditEvent records a change made to the credentials of a user, or an
+// action of an administrator.
+type AuditEvent struct {
+	ID     uuid.UUID `yaml:"id" json:"id"`
+	UserID uuid.UUID `yaml:"user_id" json:"user_id"`
+	// ActorID is the administrator who acted on the user, uuid.Nil when the
+	// user acted themselves.
+	ActorID   uuid.UUID `yaml:"actor_id" json:"actor_id"`
+	Action    string    `yaml:"action" json:"action"`
+	Detail    string    `yaml:"detail" json:"detail"`
+	CreatedAt time.Time `yaml:"created_at" json:"created_at"`
+}
+
+func (e *AuditEvent) Validate() error {
+	if e.UserID == uuid.Nil {
+		return errors.New("user id is required")
+	}
+	if e.Action == "" {
+		return errors.New("action is required")
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Identity links the account of a user at an external identity provider to
+// their user, so that they can log in with it.
+type Identity struct {
+	ID     uuid.UUID `yaml:"id" json:"id"`
+	UserID uuid.UUID `yaml:"user_id" json:"user_id"`
+	// Provider is the name of the identity provider.
+	Provider string `yaml:"provider" json:"provider"`
+	// Subject identifies the user at the provider.
+	Subject string `yaml:"subject" json:"subject"`
+	// Email is the address the provider knew the user by when the identity
+	// was linked.
+	Email     string    `yaml:"email" json:"email"`
+	CreatedAt time.Time `yaml:"created_at" json:"created_at"`
+}
+
+func (i *Identity) Validate() error {
+	if i.UserID == uuid.Nil {
+		return errors.New("user id is required")
+	}
+	if i.Provider == "" {
+		return errors.New("provider is required")
+	}
+	if i.Subject == "" {
+		return errors.New("subject is required")
+	}
+	return nil
+}
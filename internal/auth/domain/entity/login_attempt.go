@@ -0,0 +1,13 @@
+package entity
+
+import "github.com/a1y/doc-formatter/pkg/throttle"
+
+// Prefixes of the keys failed logins are counted by.
+const (
+	LoginKeyAccount = "account:"
+	LoginKeyIP      = "ip:"
+)
+
+// LoginAttempt counts the failed logins for a key, an account or a client
+// address, and how long logins for it are locked.
+type LoginAttempt = throttle.Attempt
@@ -0,0 +1,32 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PasswordReset lets the holder of its token set a new password for a user
+// once, until it expires. Only a hash of the token is stored.
+type PasswordReset struct {
+	ID        uuid.UUID  `yaml:"id" json:"id"`
+	UserID    uuid.UUID  `yaml:"user_id" json:"user_id"`
+	TokenHash string     `yaml:"token_hash" json:"token_hash"`
+	ExpiresAt time.Time  `yaml:"expires_at" json:"expires_at"`
+	UsedAt    *time.Time `yaml:"used_at" json:"used_at"`
+	CreatedAt time.Time  `yaml:"created_at" json:"created_at"`
+}
+
+func (r *PasswordReset) Validate() error {
+	if r.UserID == uuid.Nil {
+		return errors.New("user id is required")
+	}
+	if r.TokenHash == "" {
+		return errors.New("token hash is required")
+	}
+	if r.ExpiresAt.IsZero() {
+		return errors.New("expiry is required")
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Built-in roles. Every user has a role, RoleUser unless they were given
+// another one.
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
+// Permissions roles grant to administer the service.
+const (
+	// PermissionUsersRead lists and searches users.
+	PermissionUsersRead = "users:read"
+	// PermissionUsersManage disables and enables users, verifies their email
+	// address and resets their two-factor authentication.
+	PermissionUsersManage = "users:manage"
+	// PermissionUsersImpersonate acts as users to support them.
+	PermissionUsersImpersonate = "users:impersonate"
+	// PermissionRolesManage creates roles and gives them to users.
+	PermissionRolesManage = "roles:manage"
+)
+
+// Permissions are the permissions roles can grant.
+var Permissions = []string{
+	PermissionUsersRead, PermissionUsersManage, PermissionUsersImpersonate, PermissionRolesManage,
+}
+
+// BuiltInRoles are the roles that always exist and cannot be changed.
+var BuiltInRoles = []*Role{
+	{Name: RoleUser, Description: "Uses the service", BuiltIn: true},
+	{Name: RoleAdmin, Description: "Administers the service", Permissions: Permissions, BuiltIn: true},
+}
+
+// BuiltInRole returns the built-in role with name, nil when there is none.
+func BuiltInRole(name string) *Role {
+	for _, r := range BuiltInRoles {
+		if r.Name == name {
+			return r
+		}
+	}
+	return nil
+}
+
+var roleNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]{0,31}$`)
+
+// Role grants its users a set of permissions.
+type Role struct {
+	ID          uuid.UUID `yaml:"id" json:"id"`
+	Name        string    `yaml:"name" json:"name"`
+	Description string    `yaml:"description" json:"description"`
+	Permissions []string  `yaml:"permissions" json:"permissions"`
+	// BuiltIn is set for RoleUser and RoleAdmin, which are not stored.
+	BuiltIn   bool      `yaml:"built_in" json:"built_in"`
+	CreatedAt time.Time `yaml:"created_at" json:"created_at"`
+}
+
+// Can reports whether the role grants permission.
+func (r *Role) Can(permission string) bool {
+	return slices.Contains(r.Permissions, permission)
+}
+
+func (r *Role) Validate() error {
+	if !roleNamePattern.MatchString(r.Name) {
+		return errors.New("name must be 1 to 32 lowercase letters, digits, dashes or underscores, starting with a letter")
+	}
+	for _, permission := range r.Permissions {
+		if !slices.Contains(Permissions, permission) {
+			return fmt.Errorf("unknown permission %q", permission)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRole_Validate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, (&Role{Name: "support", Permissions: []string{PermissionUsersRead}}).Validate())
+	require.NoError(t, (&Role{Name: "read-only_2"}).Validate())
+
+	for _, name := range []string{"", "Support", "2fa", "support team", "a23456789012345678901234567890123"} {
+		assert.Error(t, (&Role{Name: name}).Validate(), name)
+	}
+	err := (&Role{Name: "support", Permissions: []string{"users:delete"}}).Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown permission "users:delete"`)
+}
+
+func TestBuiltInRole(t *testing.T) {
+	t.Parallel()
+
+	admin := BuiltInRole(RoleAdmin)
+	require.NotNil(t, admin)
+	for _, permission := range Permissions {
+		assert.True(t, admin.Can(permission), permission)
+	}
+
+	user := BuiltInRole(RoleUser)
+	require.NotNil(t, user)
+	assert.False(t, user.Can(PermissionUsersRead))
+
+	assert.Nil(t, BuiltInRole("support"))
+}
@@ -11,6 +11,26 @@ type User struct {
 	Email      string    `yaml:"email" json:"email"`
 	Password   string    `yaml:"password" json:"password"`
 	IsVerified bool      `yaml:"is_verified" json:"is_verified"`
+	// SessionVersion is embedded in access tokens and bumped to revoke every
+	// token issued before.
+	SessionVersion int `yaml:"session_version" json:"session_version"`
+	// PendingEmail is the address the user asked to change to, which takes
+	// effect once it is verified.
+	PendingEmail string `yaml:"pending_email" json:"pending_email"`
+	// TOTPSecret is the sealed TOTP secret of the user, set once they start
+	// enrolling in two-factor authentication.
+	TOTPSecret []byte `yaml:"totp_secret" json:"totp_secret"`
+	// MFAEnabled requires a TOTP or recovery code on top of the password to
+	// log in.
+	MFAEnabled bool `yaml:"mfa_enabled" json:"mfa_enabled"`
+	// TOTPLastStep is the time step of the last TOTP code used, so that no
+	// code can be used twice.
+	TOTPLastStep int64 `yaml:"totp_last_step" json:"totp_last_step"`
+	// Role is the name of the role of the user, RoleUser unless they were
+	// given another one.
+	Role string `yaml:"role" json:"role"`
+	// Disabled users cannot log in or use their tokens and API keys.
+	Disabled bool `yaml:"disabled" json:"disabled"`
 }
 
 func (u *User) Validate() error {
@@ -22,3 +42,22 @@ func (u *User) Validate() error {
 	}
 	return nil
 }
+
+// Sizes of pages of users.
+const (
+	DefaultUserPageSize = 50
+	MaxUserPageSize     = 200
+)
+
+// UserFilter selects a page of users, ordered by email address.
+type UserFilter struct {
+	// Query matches the users whose email address contains it, ignoring
+	// case. Every user matches when it is empty.
+	Query string
+	// Role matches the users with the role, any role when it is empty.
+	Role string
+	// AfterEmail starts the page after the user with this email address,
+	// from the first user when it is empty.
+	AfterEmail string
+	Limit      int
+}
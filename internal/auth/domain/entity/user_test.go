@@ -2,6 +2,7 @@ package entity
 
 import (
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
@@ -42,3 +43,27 @@ func TestUser_Validate_MissingPassword(t *testing.T) {
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "password is required")
 }
+
+func TestPasswordReset_Validate(t *testing.T) {
+	t.Parallel()
+
+	r := &PasswordReset{
+		UserID:    uuid.New(),
+		TokenHash: "hash",
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	require.NoError(t, r.Validate())
+
+	require.Error(t, (&PasswordReset{TokenHash: "hash", ExpiresAt: time.Now()}).Validate())
+	require.Error(t, (&PasswordReset{UserID: uuid.New(), ExpiresAt: time.Now()}).Validate())
+	require.Error(t, (&PasswordReset{UserID: uuid.New(), TokenHash: "hash"}).Validate())
+}
+
+func TestAuditEvent_Validate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, (&AuditEvent{UserID: uuid.New(), Action: AuditPasswordChanged}).Validate())
+
+	require.Error(t, (&AuditEvent{Action: AuditPasswordChanged}).Validate())
+	require.Error(t, (&AuditEvent{UserID: uuid.New()}).Validate())
+}
@@ -2,11 +2,112 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/a1y/doc-formatter/internal/auth/domain/entity"
+	"github.com/a1y/doc-formatter/pkg/throttle"
+	"github.com/google/uuid"
 )
 
 type UserRepository interface {
 	Create(ctx context.Context, u *entity.User) error
 	GetByEmail(ctx context.Context, email string) (*entity.User, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.User, error)
+	// MarkVerified marks the email address of the user verified, as long as
+	// it is still email.
+	MarkVerified(ctx context.Context, id uuid.UUID, email string) error
+	// UpdatePassword sets the password hash of the user and revokes their
+	// sessions.
+	UpdatePassword(ctx context.Context, id uuid.UUID, passwordHash string) error
+	// RehashPassword replaces the password hash of the user with a hash of
+	// the same password, as long as it is still oldHash. Sessions are kept.
+	RehashPassword(ctx context.Context, id uuid.UUID, oldHash, newHash string) error
+	// SetPendingEmail records the address the user asked to change to.
+	SetPendingEmail(ctx context.Context, id uuid.UUID, email string) error
+	// ConfirmEmail makes the pending address of the user their verified
+	// email address, as long as it is still email.
+	ConfirmEmail(ctx context.Context, id uuid.UUID, email string) error
+	// List returns the users selected by filter, ordered by email address.
+	List(ctx context.Context, filter entity.UserFilter) ([]*entity.User, error)
+	// SetDisabled disables or enables the user. Disabling revokes their
+	// sessions.
+	SetDisabled(ctx context.Context, id uuid.UUID, disabled bool) error
+	// SetRole gives the user the role with name.
+	SetRole(ctx context.Context, id uuid.UUID, role string) error
+}
+
+type PasswordResetRepository interface {
+	Create(ctx context.Context, r *entity.PasswordReset) error
+	// GetValid returns the unused, unexpired reset with tokenHash. It returns
+	// gorm.ErrRecordNotFound when there is no such reset.
+	GetValid(ctx context.Context, tokenHash string, now time.Time) (*entity.PasswordReset, error)
+	// ResetPassword uses the unused, unexpired reset with tokenHash to set
+	// the password hash of its user, revoking their sessions and any other
+	// reset. It returns gorm.ErrRecordNotFound when there is no such reset.
+	ResetPassword(ctx context.Context, tokenHash, passwordHash string, now time.Time) (uuid.UUID, error)
+}
+
+type AuditRepository interface {
+	Create(ctx context.Context, e *entity.AuditEvent) error
+}
+
+// LoginAttemptRepository counts failed logins by account and client
+// address.
+type LoginAttemptRepository = throttle.Store
+
+type MFARepository interface {
+	// SetTOTPSecret stores the sealed TOTP secret of the user, as long as
+	// two-factor authentication is not enabled for them.
+	SetTOTPSecret(ctx context.Context, userID uuid.UUID, secret []byte) error
+	// EnableTOTP enables two-factor authentication for the user, as long as
+	// it is not enabled yet, with step as the last TOTP code used. The
+	// recovery codes of the user are replaced by codeHashes.
+	EnableTOTP(ctx context.Context, userID uuid.UUID, step int64, codeHashes []string) error
+	// UseTOTPStep records step as the last TOTP code of the user used, as
+	// long as it is later than the last one. It returns
+	// gorm.ErrRecordNotFound when it is not.
+	UseTOTPStep(ctx context.Context, userID uuid.UUID, step int64) error
+	// UseRecoveryCode marks the unused recovery code of the user with
+	// codeHash used. It returns gorm.ErrRecordNotFound when there is no such
+	// code.
+	UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string, now time.Time) error
+	// ResetTOTP disables two-factor authentication for the user and forgets
+	// their TOTP secret and recovery codes, so that they can enroll again.
+	ResetTOTP(ctx context.Context, userID uuid.UUID) error
+}
+
+type IdentityRepository interface {
+	// Get returns the identity of subject at provider. It returns
+	// gorm.ErrRecordNotFound when there is no such identity.
+	Get(ctx context.Context, provider, subject string) (*entity.Identity, error)
+	// Create links an identity to its user.
+	Create(ctx context.Context, i *entity.Identity) error
+	// CreateWithUser creates a user and links an identity to them at once.
+	CreateWithUser(ctx context.Context, u *entity.User, i *entity.Identity) error
+}
+
+type APIKeyRepository interface {
+	Create(ctx context.Context, k *entity.APIKey) error
+	// GetByPrefix returns the unrevoked key with prefix. It returns
+	// gorm.ErrRecordNotFound when there is no such key.
+	GetByPrefix(ctx context.Context, prefix string) (*entity.APIKey, error)
+	// ListByUser returns the unrevoked keys of the user, newest first.
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*entity.APIKey, error)
+	// Revoke revokes the unrevoked key of the user with id. It returns
+	// gorm.ErrRecordNotFound when there is no such key.
+	Revoke(ctx context.Context, userID, id uuid.UUID, now time.Time) error
+	// RevokeAllByUser revokes every unrevoked key of the user.
+	RevokeAllByUser(ctx context.Context, userID uuid.UUID, now time.Time) error
+	// MarkUsed records that the key with id was used at now.
+	MarkUsed(ctx context.Context, id uuid.UUID, now time.Time) error
+}
+
+// RoleRepository keeps the custom roles. The built-in roles are not stored.
+type RoleRepository interface {
+	Create(ctx context.Context, r *entity.Role) error
+	// GetByName returns the role with name. It returns
+	// gorm.ErrRecordNotFound when there is no such role.
+	GetByName(ctx context.Context, name string) (*entity.Role, error)
+	// List returns the roles ordered by name.
+	List(ctx context.Context) ([]*entity.Role, error)
 }
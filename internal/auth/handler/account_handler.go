@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	authpb "github.com/a1y/doc-formatter/api/grpc/auth/v1"
+	"github.com/a1y/doc-formatter/internal/auth/domain/constant"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (h *Handler) ChangePassword(ctx context.Context, req *authpb.ChangePasswordRequest) (*authpb.ChangePasswordResponse, error) {
+	id, err := uuid.Parse(strings.TrimSpace(req.GetUserId()))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user id")
+	}
+	token, exp, err := h.userManager.ChangePassword(ctx, id, req.GetCurrentPassword(), req.GetNewPassword())
+	if err != nil {
+		return nil, accountError(err)
+	}
+	return &authpb.ChangePasswordResponse{
+		AccessToken: *token,
+		ExpiryUnix:  exp,
+	}, nil
+}
+
+func (h *Handler) ChangeEmail(ctx context.Context, req *authpb.ChangeEmailRequest) (*authpb.ChangeEmailResponse, error) {
+	id, err := uuid.Parse(strings.TrimSpace(req.GetUserId()))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user id")
+	}
+	err = h.userManager.ChangeEmail(ctx, id, req.GetCurrentPassword(), strings.TrimSpace(req.GetNewEmail()))
+	if err != nil {
+		return nil, accountError(err)
+	}
+	return &authpb.ChangeEmailResponse{}, nil
+}
+
+// accountError maps the errors of changing credentials to gRPC statuses.
+func accountError(err error) error {
+	if policyErr := passwordPolicyError(err, "new_password"); policyErr != nil {
+		return policyErr
+	}
+	switch {
+	case errors.Is(err, constant.ErrPasswordRequired),
+		errors.Is(err, constant.ErrEmailRequired),
+		errors.Is(err, constant.ErrEmailUnchanged):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, constant.ErrIncorrectPassword):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, constant.ErrUserNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, constant.ErrEmailExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, constant.ErrMailUnavailable):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return err
+	}
+}
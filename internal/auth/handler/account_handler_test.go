@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	authpb "github.com/a1y/doc-formatter/api/grpc/auth/v1"
+	"github.com/a1y/doc-formatter/internal/auth/infra/persistence"
+	"github.com/a1y/doc-formatter/internal/auth/manager/user"
+	jwtutil "github.com/a1y/doc-formatter/internal/auth/util/jwt"
+	"github.com/a1y/doc-formatter/pkg/credentials"
+	testpersistence "github.com/a1y/doc-formatter/pkg/persistence"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestHandler_ChangePassword(t *testing.T) {
+	_, tokenPath := setupTestPrivateKey(t)
+
+	db, mock, err := testpersistence.GetMockDB()
+	assert.NoError(t, err)
+
+	userManager := user.NewUserManager(persistence.NewUserRepository(db), jwtutil.TokenClaim{TokenPath: tokenPath})
+	userManager.SetAuditRepository(persistence.NewAuditRepository(db))
+	h, err := NewHandler(userManager)
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	hash, err := credentials.NewDefaultArgon2idHash().HashPassword("old-password", nil)
+	assert.NoError(t, err)
+
+	query := regexp.QuoteMeta(`SELECT * FROM "users" WHERE id = $1 AND "users"."deleted_at" IS NULL ORDER BY "users"."id" LIMIT $2`)
+	columns := []string{"id", "created_at", "updated_at", "deleted_at", "description", "name", "username", "email", "password", "is_verified", "session_version"}
+	mock.ExpectQuery(query).WithArgs(userID, 1).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(userID.String(), nil, nil, nil, "", "", "", "test@example.com", hash, true, 0))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "users" SET "password"=$1`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), userID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "audit_events"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+	mock.ExpectQuery(query).WithArgs(userID, 1).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(userID.String(), nil, nil, nil, "", "", "", "test@example.com", hash, true, 0))
+	mock.ExpectClose()
+
+	resp, err := h.ChangePassword(ctx, &authpb.ChangePasswordRequest{
+		UserId:          userID.String(),
+		CurrentPassword: "old-password",
+		NewPassword:     "new-password",
+	})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, resp.GetAccessToken())
+
+	_, err = h.ChangePassword(ctx, &authpb.ChangePasswordRequest{
+		UserId:          userID.String(),
+		CurrentPassword: "wrong-password",
+		NewPassword:     "new-password",
+	})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = h.ChangePassword(ctx, &authpb.ChangePasswordRequest{UserId: "not-a-uuid", NewPassword: "new-password"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	testpersistence.CloseDB(t, db)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestHandler_ChangeEmail(t *testing.T) {
+	db, mock, err := testpersistence.GetMockDB()
+	assert.NoError(t, err)
+
+	h, err := NewHandler(user.NewUserManager(persistence.NewUserRepository(db), jwtutil.TokenClaim{}))
+	assert.NoError(t, err)
+	mock.ExpectClose()
+
+	// No mailer is configured, so the new address could never be confirmed.
+	_, err = h.ChangeEmail(context.Background(), &authpb.ChangeEmailRequest{
+		UserId:          uuid.NewString(),
+		CurrentPassword: "password",
+		NewEmail:        "new@example.com",
+	})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	_, err = h.ChangeEmail(context.Background(), &authpb.ChangeEmailRequest{UserId: uuid.NewString(), NewEmail: " "})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	testpersistence.CloseDB(t, db)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,192 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	authpb "github.com/a1y/doc-formatter/api/grpc/auth/v1"
+	"github.com/a1y/doc-formatter/internal/auth/domain/constant"
+	"github.com/a1y/doc-formatter/internal/auth/domain/entity"
+	"github.com/a1y/doc-formatter/internal/auth/manager/user"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (h *Handler) ListUsers(ctx context.Context, req *authpb.ListUsersRequest) (*authpb.ListUsersResponse, error) {
+	adminID, err := uuid.Parse(strings.TrimSpace(req.GetAdminId()))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid admin id")
+	}
+	users, nextPageToken, err := h.userManager.ListUsers(ctx, adminID, user.UserPageRequest{
+		Query: req.GetQuery(),
+		Role:  req.GetRole(),
+		Size:  int(req.GetPageSize()),
+		Token: req.GetPageToken(),
+	})
+	if err != nil {
+		return nil, adminError(err)
+	}
+	resp := &authpb.ListUsersResponse{
+		Users:         make([]*authpb.UserInfo, len(users)),
+		NextPageToken: nextPageToken,
+	}
+	for i, u := range users {
+		resp.Users[i] = toUserInfo(u)
+	}
+	return resp, nil
+}
+
+func (h *Handler) SetUserDisabled(ctx context.Context, req *authpb.SetUserDisabledRequest) (*authpb.SetUserDisabledResponse, error) {
+	adminID, userID, err := adminTarget(req.GetAdminId(), req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	u, err := h.userManager.SetUserDisabled(ctx, adminID, userID, req.GetDisabled())
+	if err != nil {
+		return nil, adminError(err)
+	}
+	return &authpb.SetUserDisabledResponse{User: toUserInfo(u)}, nil
+}
+
+func (h *Handler) VerifyUser(ctx context.Context, req *authpb.VerifyUserRequest) (*authpb.VerifyUserResponse, error) {
+	adminID, userID, err := adminTarget(req.GetAdminId(), req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	u, err := h.userManager.VerifyUser(ctx, adminID, userID)
+	if err != nil {
+		return nil, adminError(err)
+	}
+	return &authpb.VerifyUserResponse{User: toUserInfo(u)}, nil
+}
+
+func (h *Handler) ResetUserMFA(ctx context.Context, req *authpb.ResetUserMFARequest) (*authpb.ResetUserMFAResponse, error) {
+	adminID, userID, err := adminTarget(req.GetAdminId(), req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	u, err := h.userManager.ResetUserMFA(ctx, adminID, userID)
+	if err != nil {
+		return nil, adminError(err)
+	}
+	return &authpb.ResetUserMFAResponse{User: toUserInfo(u)}, nil
+}
+
+func (h *Handler) ImpersonateUser(ctx context.Context, req *authpb.ImpersonateUserRequest) (*authpb.ImpersonateUserResponse, error) {
+	adminID, userID, err := adminTarget(req.GetAdminId(), req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	token, exp, err := h.userManager.ImpersonateUser(ctx, adminID, userID)
+	if err != nil {
+		return nil, adminError(err)
+	}
+	return &authpb.ImpersonateUserResponse{
+		AccessToken: *token,
+		ExpiryUnix:  exp,
+	}, nil
+}
+
+func (h *Handler) ListRoles(ctx context.Context, req *authpb.ListRolesRequest) (*authpb.ListRolesResponse, error) {
+	adminID, err := uuid.Parse(strings.TrimSpace(req.GetAdminId()))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid admin id")
+	}
+	roles, err := h.userManager.ListRoles(ctx, adminID)
+	if err != nil {
+		return nil, adminError(err)
+	}
+	resp := &authpb.ListRolesResponse{Roles: make([]*authpb.RoleInfo, len(roles))}
+	for i, role := range roles {
+		resp.Roles[i] = toRoleInfo(role)
+	}
+	return resp, nil
+}
+
+func (h *Handler) CreateRole(ctx context.Context, req *authpb.CreateRoleRequest) (*authpb.CreateRoleResponse, error) {
+	adminID, err := uuid.Parse(strings.TrimSpace(req.GetAdminId()))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid admin id")
+	}
+	role, err := h.userManager.CreateRole(ctx, adminID, &entity.Role{
+		Name:        req.GetName(),
+		Description: req.GetDescription(),
+		Permissions: req.GetPermissions(),
+	})
+	if err != nil {
+		return nil, adminError(err)
+	}
+	return &authpb.CreateRoleResponse{Role: toRoleInfo(role)}, nil
+}
+
+func (h *Handler) SetUserRole(ctx context.Context, req *authpb.SetUserRoleRequest) (*authpb.SetUserRoleResponse, error) {
+	adminID, userID, err := adminTarget(req.GetAdminId(), req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	u, err := h.userManager.SetUserRole(ctx, adminID, userID, req.GetRole())
+	if err != nil {
+		return nil, adminError(err)
+	}
+	return &authpb.SetUserRoleResponse{User: toUserInfo(u)}, nil
+}
+
+// adminTarget parses the IDs of an administrator and the user they act on.
+func adminTarget(adminID, userID string) (uuid.UUID, uuid.UUID, error) {
+	admin, err := uuid.Parse(strings.TrimSpace(adminID))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, status.Error(codes.InvalidArgument, "invalid admin id")
+	}
+	target, err := uuid.Parse(strings.TrimSpace(userID))
+	if err != nil {
+		return uuid.Nil, uuid.Nil, status.Error(codes.InvalidArgument, "invalid user id")
+	}
+	return admin, target, nil
+}
+
+// adminError maps the errors of administering users and roles to gRPC
+// statuses.
+func adminError(err error) error {
+	switch {
+	case errors.Is(err, constant.ErrPermissionDenied):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, constant.ErrUserNotFound),
+		errors.Is(err, constant.ErrRoleNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, constant.ErrRoleExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, constant.ErrInvalidRole),
+		errors.Is(err, constant.ErrInvalidPageToken):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, constant.ErrCannotActOnSelf),
+		errors.Is(err, constant.ErrCannotImpersonate),
+		errors.Is(err, constant.ErrAccountDisabled),
+		errors.Is(err, constant.ErrMFAUnavailable),
+		errors.Is(err, constant.ErrRolesUnavailable):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return err
+	}
+}
+
+func toUserInfo(u *entity.User) *authpb.UserInfo {
+	return &authpb.UserInfo{
+		UserId:     u.ID.String(),
+		Email:      u.Email,
+		IsVerified: u.IsVerified,
+		Role:       u.Role,
+		Disabled:   u.Disabled,
+		MfaEnabled: u.MFAEnabled,
+	}
+}
+
+func toRoleInfo(r *entity.Role) *authpb.RoleInfo {
+	return &authpb.RoleInfo{
+		Name:        r.Name,
+		Description: r.Description,
+		Permissions: r.Permissions,
+		BuiltIn:     r.BuiltIn,
+	}
+}
@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	authpb "github.com/a1y/doc-formatter/api/grpc/auth/v1"
+	"github.com/a1y/doc-formatter/internal/auth/infra/persistence"
+	"github.com/a1y/doc-formatter/internal/auth/manager/user"
+	jwtutil "github.com/a1y/doc-formatter/internal/auth/util/jwt"
+	testpersistence "github.com/a1y/doc-formatter/pkg/persistence"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestHandler_SetUserDisabled(t *testing.T) {
+	db, mock, err := testpersistence.GetMockDB()
+	assert.NoError(t, err)
+
+	h, err := NewHandler(user.NewUserManager(persistence.NewUserRepository(db), jwtutil.TokenClaim{}))
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+	adminID, userID := uuid.New(), uuid.New()
+	query := regexp.QuoteMeta(`SELECT * FROM "users" WHERE id = $1`)
+	columns := []string{"id", "email", "is_verified", "role", "disabled"}
+	adminRows := func() *sqlmock.Rows {
+		return sqlmock.NewRows(columns).AddRow(adminID.String(), "admin@example.com", true, "admin", false)
+	}
+
+	mock.ExpectQuery(query).WithArgs(adminID, 1).WillReturnRows(adminRows())
+	mock.ExpectQuery(query).WithArgs(userID, 1).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(userID.String(), "test@example.com", true, "user", false))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "users" SET "disabled"=$1,"session_version"=COALESCE(session_version, 0) + 1`)).
+		WithArgs(true, sqlmock.AnyArg(), userID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	resp, err := h.SetUserDisabled(ctx, &authpb.SetUserDisabledRequest{AdminId: adminID.String(), UserId: userID.String(), Disabled: true})
+	require.NoError(t, err)
+	assert.Equal(t, &authpb.UserInfo{UserId: userID.String(), Email: "test@example.com", IsVerified: true, Role: "user", Disabled: true}, resp.GetUser())
+
+	// Administrators cannot disable themselves.
+	mock.ExpectQuery(query).WithArgs(adminID, 1).WillReturnRows(adminRows())
+	_, err = h.SetUserDisabled(ctx, &authpb.SetUserDisabledRequest{AdminId: adminID.String(), UserId: adminID.String(), Disabled: true})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	// Users cannot administer others.
+	mock.ExpectQuery(query).WithArgs(userID, 1).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(userID.String(), "test@example.com", true, "user", false))
+	mock.ExpectClose()
+	_, err = h.SetUserDisabled(ctx, &authpb.SetUserDisabledRequest{AdminId: userID.String(), UserId: adminID.String(), Disabled: true})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	testpersistence.CloseDB(t, db)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestHandler_Admin_Invalid(t *testing.T) {
+	h, err := NewHandler(user.NewUserManager(nil, jwtutil.TokenClaim{}))
+	assert.NoError(t, err)
+	ctx := context.Background()
+	id := uuid.New().String()
+
+	_, err = h.ListUsers(ctx, &authpb.ListUsersRequest{AdminId: "not-a-uuid"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = h.VerifyUser(ctx, &authpb.VerifyUserRequest{AdminId: id, UserId: "not-a-uuid"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = h.ImpersonateUser(ctx, &authpb.ImpersonateUserRequest{AdminId: "", UserId: id})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = h.SetUserRole(ctx, &authpb.SetUserRoleRequest{AdminId: id, UserId: "not-a-uuid", Role: "admin"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
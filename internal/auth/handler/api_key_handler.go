@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	authpb "github.com/a1y/doc-formatter/api/grpc/auth/v1"
+	"github.com/a1y/doc-formatter/internal/auth/domain/constant"
+	"github.com/a1y/doc-formatter/internal/auth/domain/entity"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (h *Handler) CreateAPIKey(ctx context.Context, req *authpb.CreateAPIKeyRequest) (*authpb.CreateAPIKeyResponse, error) {
+	id, err := uuid.Parse(strings.TrimSpace(req.GetUserId()))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user id")
+	}
+	if strings.TrimSpace(req.GetName()) == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	apiKey, key, err := h.userManager.CreateAPIKey(ctx, id, req.GetName(), req.GetScopes())
+	if err != nil {
+		return nil, apiKeyError(err)
+	}
+	return &authpb.CreateAPIKeyResponse{
+		ApiKey: toAPIKeyInfo(apiKey),
+		Key:    key,
+	}, nil
+}
+
+func (h *Handler) ListAPIKeys(ctx context.Context, req *authpb.ListAPIKeysRequest) (*authpb.ListAPIKeysResponse, error) {
+	id, err := uuid.Parse(strings.TrimSpace(req.GetUserId()))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user id")
+	}
+	keys, err := h.userManager.ListAPIKeys(ctx, id)
+	if err != nil {
+		return nil, apiKeyError(err)
+	}
+	resp := &authpb.ListAPIKeysResponse{ApiKeys: make([]*authpb.APIKeyInfo, len(keys))}
+	for i, key := range keys {
+		resp.ApiKeys[i] = toAPIKeyInfo(key)
+	}
+	return resp, nil
+}
+
+func (h *Handler) RevokeAPIKey(ctx context.Context, req *authpb.RevokeAPIKeyRequest) (*authpb.RevokeAPIKeyResponse, error) {
+	id, err := uuid.Parse(strings.TrimSpace(req.GetUserId()))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user id")
+	}
+	keyID, err := uuid.Parse(strings.TrimSpace(req.GetKeyId()))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid key id")
+	}
+	var actorID uuid.UUID
+	if impersonatorID := strings.TrimSpace(req.GetImpersonatorId()); impersonatorID != "" {
+		actorID, err = uuid.Parse(impersonatorID)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid impersonator id")
+		}
+	}
+	if err := h.userManager.RevokeAPIKey(ctx, id, keyID, actorID); err != nil {
+		return nil, apiKeyError(err)
+	}
+	return &authpb.RevokeAPIKeyResponse{}, nil
+}
+
+func (h *Handler) ValidateAPIKey(ctx context.Context, req *authpb.ValidateAPIKeyRequest) (*authpb.ValidateAPIKeyResponse, error) {
+	user, apiKey, err := h.userManager.ValidateAPIKey(ctx, strings.TrimSpace(req.GetKey()))
+	if errors.Is(err, constant.ErrInvalidAPIKey) {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &authpb.ValidateAPIKeyResponse{
+		UserId:     user.ID.String(),
+		Email:      user.Email,
+		IsVerified: user.IsVerified,
+		Scopes:     apiKey.Scopes,
+	}, nil
+}
+
+// apiKeyError maps the errors of managing API keys to gRPC statuses.
+func apiKeyError(err error) error {
+	switch {
+	case errors.Is(err, constant.ErrUserNotFound),
+		errors.Is(err, constant.ErrAPIKeyNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, constant.ErrInvalidAPIKeyScope):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, constant.ErrAPIKeysUnavailable):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return err
+	}
+}
+
+func toAPIKeyInfo(k *entity.APIKey) *authpb.APIKeyInfo {
+	info := &authpb.APIKeyInfo{
+		KeyId:         k.ID.String(),
+		Name:          k.Name,
+		Prefix:        k.Prefix,
+		Scopes:        k.Scopes,
+		CreatedAtUnix: k.CreatedAt.Unix(),
+	}
+	if k.LastUsedAt != nil {
+		info.LastUsedAtUnix = k.LastUsedAt.Unix()
+	}
+	return info
+}
@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"context"
+	"database/sql/driver"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	authpb "github.com/a1y/doc-formatter/api/grpc/auth/v1"
+	"github.com/a1y/doc-formatter/internal/auth/infra/persistence"
+	"github.com/a1y/doc-formatter/internal/auth/manager/user"
+	jwtutil "github.com/a1y/doc-formatter/internal/auth/util/jwt"
+	testpersistence "github.com/a1y/doc-formatter/pkg/persistence"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestHandler_APIKeys(t *testing.T) {
+	db, mock, err := testpersistence.GetMockDB()
+	assert.NoError(t, err)
+
+	userManager := user.NewUserManager(persistence.NewUserRepository(db), jwtutil.TokenClaim{})
+	userManager.SetAPIKeyRepository(persistence.NewAPIKeyRepository(db))
+	h, err := NewHandler(userManager)
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+	userID, keyID := uuid.New(), uuid.New()
+	userRows := func() *sqlmock.Rows {
+		return sqlmock.NewRows([]string{"id", "email", "password", "is_verified"}).
+			AddRow(userID.String(), "test@example.com", "hash", true)
+	}
+
+	// Create
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE id = $1`)).WithArgs(userID, 1).WillReturnRows(userRows())
+	var keyHash string
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "api_keys"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), userID, "ci", sqlmock.AnyArg(), capture(&keyHash), "read", nil, nil).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(keyID))
+
+	created, err := h.CreateAPIKey(ctx, &authpb.CreateAPIKeyRequest{UserId: userID.String(), Name: "ci", Scopes: []string{"read"}})
+	require.NoError(t, err)
+	key, info := created.GetKey(), created.GetApiKey()
+	assert.Equal(t, keyID.String(), info.GetKeyId())
+	assert.Equal(t, []string{"read"}, info.GetScopes())
+	assert.Regexp(t, `^dfk_[0-9a-f]{12}$`, info.GetPrefix())
+	assert.NotContains(t, keyHash, key)
+
+	// Validate
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "api_keys" WHERE (prefix = $1 AND revoked_at IS NULL)`)).
+		WithArgs(info.GetPrefix(), 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "prefix", "key_hash", "scopes"}).
+			AddRow(keyID.String(), userID.String(), "ci", info.GetPrefix(), keyHash, "read"))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE id = $1`)).WithArgs(userID, 1).WillReturnRows(userRows())
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "api_keys" SET "last_used_at"=$1`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), keyID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	validated, err := h.ValidateAPIKey(ctx, &authpb.ValidateAPIKeyRequest{Key: key})
+	require.NoError(t, err)
+	assert.Equal(t, &authpb.ValidateAPIKeyResponse{UserId: userID.String(), Email: "test@example.com", IsVerified: true, Scopes: []string{"read"}}, validated)
+
+	// List
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "api_keys" WHERE (user_id = $1 AND revoked_at IS NULL)`)).
+		WithArgs(userID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "prefix", "key_hash", "scopes"}).
+			AddRow(keyID.String(), userID.String(), "ci", info.GetPrefix(), keyHash, "read"))
+
+	listed, err := h.ListAPIKeys(ctx, &authpb.ListAPIKeysRequest{UserId: userID.String()})
+	require.NoError(t, err)
+	require.Len(t, listed.GetApiKeys(), 1)
+	assert.Equal(t, info.GetPrefix(), listed.GetApiKeys()[0].GetPrefix())
+
+	// Revoke, which the key does not survive.
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "api_keys" SET "revoked_at"=$1`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), keyID, userID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "api_keys" SET "revoked_at"=$1`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), keyID, userID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "api_keys" WHERE (prefix = $1 AND revoked_at IS NULL)`)).
+		WithArgs(info.GetPrefix(), 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectClose()
+
+	_, err = h.RevokeAPIKey(ctx, &authpb.RevokeAPIKeyRequest{UserId: userID.String(), KeyId: keyID.String()})
+	require.NoError(t, err)
+	_, err = h.RevokeAPIKey(ctx, &authpb.RevokeAPIKeyRequest{UserId: userID.String(), KeyId: keyID.String()})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	_, err = h.ValidateAPIKey(ctx, &authpb.ValidateAPIKeyRequest{Key: key})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	testpersistence.CloseDB(t, db)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestHandler_CreateAPIKey_Invalid(t *testing.T) {
+	userManager := user.NewUserManager(nil, jwtutil.TokenClaim{})
+	userManager.SetAPIKeyRepository(persistence.NewAPIKeyRepository(nil))
+	h, err := NewHandler(userManager)
+	assert.NoError(t, err)
+	ctx := context.Background()
+	userID := uuid.New().String()
+
+	_, err = h.CreateAPIKey(ctx, &authpb.CreateAPIKeyRequest{UserId: "not-a-uuid", Name: "ci"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = h.CreateAPIKey(ctx, &authpb.CreateAPIKeyRequest{UserId: userID, Name: " "})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = h.CreateAPIKey(ctx, &authpb.CreateAPIKeyRequest{UserId: userID, Name: "ci", Scopes: []string{"admin"}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = h.RevokeAPIKey(ctx, &authpb.RevokeAPIKeyRequest{UserId: userID, KeyId: "not-a-uuid"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = h.RevokeAPIKey(ctx, &authpb.RevokeAPIKeyRequest{UserId: userID, KeyId: uuid.New().String(), ImpersonatorId: "not-a-uuid"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// Without a repository to keep them in, API keys are unavailable.
+	h, err = NewHandler(user.NewUserManager(nil, jwtutil.TokenClaim{}))
+	assert.NoError(t, err)
+	_, err = h.ListAPIKeys(ctx, &authpb.ListAPIKeysRequest{UserId: userID})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	_, err = h.ValidateAPIKey(ctx, &authpb.ValidateAPIKeyRequest{Key: "dfk_0123456789ab_secret"})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+// capture is an argument matcher that records the string it is given.
+type captureArg struct{ value *string }
+
+func capture(value *string) sqlmock.Argument {
+	return captureArg{value}
+}
+
+func (c captureArg) Match(v driver.Value) bool {
+	s, ok := v.(string)
+	*c.value = s
+	return ok
+}
@@ -2,9 +2,18 @@ package handler
 
 import (
 	"context"
+	"errors"
+	"strings"
 
 	authpb "github.com/a1y/doc-formatter/api/grpc/auth/v1"
+	"github.com/a1y/doc-formatter/internal/auth/domain/constant"
 	"github.com/a1y/doc-formatter/internal/auth/domain/entity"
+	"github.com/a1y/doc-formatter/internal/auth/manager/user"
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 func (h *Handler) Signup(ctx context.Context, req *authpb.SignupRequest) (*authpb.SignupResponse, error) {
@@ -13,6 +22,9 @@ func (h *Handler) Signup(ctx context.Context, req *authpb.SignupRequest) (*authp
 		Password: req.Password,
 	}
 	userResponse, err := h.userManager.CreateUser(ctx, &userEntity)
+	if policyErr := passwordPolicyError(err, "password"); policyErr != nil {
+		return nil, policyErr
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -22,7 +34,33 @@ func (h *Handler) Signup(ctx context.Context, req *authpb.SignupRequest) (*authp
 }
 
 func (h *Handler) Login(ctx context.Context, req *authpb.LoginRequest) (*authpb.LoginResponse, error) {
-	token, exp, err := h.userManager.LoginUser(ctx, &entity.User{Email: req.Email, Password: req.Password})
+	token, exp, err := h.userManager.LoginUser(ctx, &entity.User{Email: req.Email, Password: req.Password}, strings.TrimSpace(req.GetRemoteAddr()))
+	return loginResponse(token, exp, err)
+}
+
+// loginResponse maps the outcome of a login to its response: an access token,
+// or the token to complete the login with a second factor.
+func loginResponse(token *string, exp int64, err error) (*authpb.LoginResponse, error) {
+	if errors.Is(err, constant.ErrEmailNotVerified) || errors.Is(err, constant.ErrAccountDisabled) {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+	var lockedErr *user.LoginLockedError
+	if errors.As(err, &lockedErr) {
+		return nil, loginLockedError(lockedErr)
+	}
+	var mfaErr *user.MFARequiredError
+	if errors.As(err, &mfaErr) {
+		return &authpb.LoginResponse{
+			MfaRequired: true,
+			MfaToken:    mfaErr.Token,
+		}, nil
+	}
+	if errors.Is(err, constant.ErrMFAUnavailable) {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if errors.Is(err, constant.ErrInvalidCredentials) {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
 	if err != nil || token == nil {
 		return nil, err
 	}
@@ -31,3 +69,51 @@ func (h *Handler) Login(ctx context.Context, req *authpb.LoginRequest) (*authpb.
 		ExpiryUnix:  exp,
 	}, nil
 }
+
+// loginLockedError maps a login lockout to a ResourceExhausted status with
+// the time to wait before retrying as its retry info.
+func loginLockedError(err *user.LoginLockedError) error {
+	st, detailsErr := status.New(codes.ResourceExhausted, err.Error()).
+		WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(err.RetryAfter)})
+	if detailsErr != nil {
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+	return st.Err()
+}
+
+func (h *Handler) LookupUser(ctx context.Context, req *authpb.LookupUserRequest) (*authpb.LookupUserResponse, error) {
+	email := strings.TrimSpace(req.GetEmail())
+	if email == "" {
+		return nil, status.Error(codes.InvalidArgument, "email is required")
+	}
+	user, err := h.userManager.GetUserByEmail(ctx, email)
+	if errors.Is(err, constant.ErrUserNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &authpb.LookupUserResponse{
+		UserId: user.ID.String(),
+		Email:  user.Email,
+	}, nil
+}
+
+func (h *Handler) GetUser(ctx context.Context, req *authpb.GetUserRequest) (*authpb.GetUserResponse, error) {
+	id, err := uuid.Parse(strings.TrimSpace(req.GetUserId()))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user id")
+	}
+	user, err := h.userManager.GetUserByID(ctx, id)
+	if errors.Is(err, constant.ErrUserNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &authpb.GetUserResponse{
+		UserId:     user.ID.String(),
+		Email:      user.Email,
+		IsVerified: user.IsVerified,
+	}, nil
+}
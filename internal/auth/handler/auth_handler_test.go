@@ -9,16 +9,24 @@ import (
 	"os"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	authpb "github.com/a1y/doc-formatter/api/grpc/auth/v1"
+	"github.com/a1y/doc-formatter/internal/auth/infra/memory"
 	"github.com/a1y/doc-formatter/internal/auth/infra/persistence"
 	"github.com/a1y/doc-formatter/internal/auth/manager/user"
 	jwtutil "github.com/a1y/doc-formatter/internal/auth/util/jwt"
+	"github.com/a1y/doc-formatter/internal/auth/util/passwordpolicy"
 	"github.com/a1y/doc-formatter/pkg/credentials"
 	testpersistence "github.com/a1y/doc-formatter/pkg/persistence"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
 )
 
 func setupTestPrivateKey(t *testing.T) (*rsa.PrivateKey, string) {
@@ -76,7 +84,7 @@ func TestHandler_Signup(t *testing.T) {
 
 	mock.ExpectBegin()
 	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "users"`)).
-		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), req.Email, sqlmock.AnyArg(), false).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), req.Email, sqlmock.AnyArg(), false, 0, "", []byte(nil), false, 0, "user", false).
 		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
 	mock.ExpectCommit()
 	mock.ExpectClose()
@@ -90,6 +98,36 @@ func TestHandler_Signup(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestHandler_Signup_PasswordPolicy(t *testing.T) {
+	userManager := user.NewUserManager(nil, jwtutil.TokenClaim{})
+	userManager.SetPasswordPolicy(&passwordpolicy.Policy{
+		MinLength:   8,
+		Classes:     []string{passwordpolicy.ClassDigit},
+		RejectEmail: true,
+	})
+	h, err := NewHandler(userManager)
+	assert.NoError(t, err)
+
+	_, err = h.Signup(context.Background(), &authpb.SignupRequest{
+		Email:    "alice@example.com",
+		Password: "alice",
+	})
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+
+	require.Len(t, st.Details(), 1)
+	badRequest, ok := st.Details()[0].(*errdetails.BadRequest)
+	require.True(t, ok)
+	var reasons []string
+	for _, v := range badRequest.GetFieldViolations() {
+		assert.Equal(t, "password", v.GetField())
+		assert.NotEmpty(t, v.GetDescription())
+		reasons = append(reasons, v.GetReason())
+	}
+	assert.Equal(t, []string{passwordpolicy.RuleMinLength, passwordpolicy.RuleDigit, passwordpolicy.RuleContainsEmail}, reasons)
+}
+
 func TestHandler_Login(t *testing.T) {
 	_, tokenPath := setupTestPrivateKey(t)
 
@@ -133,3 +171,100 @@ func TestHandler_Login(t *testing.T) {
 	testpersistence.CloseDB(t, db)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestHandler_Login_Locked(t *testing.T) {
+	attempts := memory.NewLoginAttemptRepository()
+	userManager := user.NewUserManager(nil, jwtutil.TokenClaim{})
+	userManager.SetLoginThrottle(attempts, user.LoginThrottleConfig{MaxIPFailures: 1})
+	h, err := NewHandler(userManager)
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+	_, err = attempts.RecordFailure(ctx, "ip:192.0.2.1", time.Now(), time.Hour)
+	require.NoError(t, err)
+	require.NoError(t, attempts.Lock(ctx, "ip:192.0.2.1", time.Now().Add(90*time.Second)))
+
+	_, err = h.Login(ctx, &authpb.LoginRequest{
+		Email:      "test@example.com",
+		Password:   "password123",
+		RemoteAddr: "192.0.2.1",
+	})
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.ResourceExhausted, st.Code())
+
+	require.Len(t, st.Details(), 1)
+	retryInfo, ok := st.Details()[0].(*errdetails.RetryInfo)
+	require.True(t, ok)
+	assert.InDelta(t, 90*time.Second, retryInfo.GetRetryDelay().AsDuration(), float64(time.Second))
+}
+
+func TestHandler_LookupUser(t *testing.T) {
+	db, mock, err := testpersistence.GetMockDB()
+	assert.NoError(t, err)
+
+	userRepo := persistence.NewUserRepository(db)
+	userManager := user.NewUserManager(userRepo, jwtutil.TokenClaim{})
+	h, err := NewHandler(userManager)
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	email := "test@example.com"
+	query := regexp.QuoteMeta(`SELECT * FROM "users" WHERE email = $1 AND "users"."deleted_at" IS NULL ORDER BY "users"."id" LIMIT $2`)
+
+	rows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "deleted_at", "description", "name", "username", "email", "password", "is_verified"}).
+		AddRow(userID.String(), nil, nil, nil, "", "", "", email, "hash", false)
+	mock.ExpectQuery(query).WithArgs(email, 1).WillReturnRows(rows)
+	mock.ExpectQuery(query).WithArgs("missing@example.com", 1).WillReturnError(gorm.ErrRecordNotFound)
+	mock.ExpectClose()
+
+	resp, err := h.LookupUser(ctx, &authpb.LookupUserRequest{Email: " " + email + " "})
+	assert.NoError(t, err)
+	assert.Equal(t, userID.String(), resp.GetUserId())
+	assert.Equal(t, email, resp.GetEmail())
+
+	_, err = h.LookupUser(ctx, &authpb.LookupUserRequest{Email: "missing@example.com"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = h.LookupUser(ctx, &authpb.LookupUserRequest{Email: "  "})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	testpersistence.CloseDB(t, db)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestHandler_GetUser(t *testing.T) {
+	db, mock, err := testpersistence.GetMockDB()
+	assert.NoError(t, err)
+
+	userRepo := persistence.NewUserRepository(db)
+	userManager := user.NewUserManager(userRepo, jwtutil.TokenClaim{})
+	h, err := NewHandler(userManager)
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+	userID, missingID := uuid.New(), uuid.New()
+	email := "test@example.com"
+	query := regexp.QuoteMeta(`SELECT * FROM "users" WHERE id = $1 AND "users"."deleted_at" IS NULL ORDER BY "users"."id" LIMIT $2`)
+
+	rows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "deleted_at", "description", "name", "username", "email", "password", "is_verified"}).
+		AddRow(userID.String(), nil, nil, nil, "", "", "", email, "hash", false)
+	mock.ExpectQuery(query).WithArgs(userID, 1).WillReturnRows(rows)
+	mock.ExpectQuery(query).WithArgs(missingID, 1).WillReturnError(gorm.ErrRecordNotFound)
+	mock.ExpectClose()
+
+	resp, err := h.GetUser(ctx, &authpb.GetUserRequest{UserId: userID.String()})
+	assert.NoError(t, err)
+	assert.Equal(t, userID.String(), resp.GetUserId())
+	assert.Equal(t, email, resp.GetEmail())
+
+	_, err = h.GetUser(ctx, &authpb.GetUserRequest{UserId: missingID.String()})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = h.GetUser(ctx, &authpb.GetUserRequest{UserId: "not-a-uuid"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	testpersistence.CloseDB(t, db)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	authpb "github.com/a1y/doc-formatter/api/grpc/auth/v1"
+	"github.com/a1y/doc-formatter/internal/auth/domain/constant"
+	"github.com/a1y/doc-formatter/internal/auth/manager/user"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (h *Handler) EnrollTOTP(ctx context.Context, req *authpb.EnrollTOTPRequest) (*authpb.EnrollTOTPResponse, error) {
+	id, err := uuid.Parse(strings.TrimSpace(req.GetUserId()))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user id")
+	}
+	secret, uri, err := h.userManager.EnrollTOTP(ctx, id)
+	if err != nil {
+		return nil, mfaError(err)
+	}
+	return &authpb.EnrollTOTPResponse{
+		Secret:     secret,
+		OtpauthUri: uri,
+	}, nil
+}
+
+func (h *Handler) ConfirmTOTP(ctx context.Context, req *authpb.ConfirmTOTPRequest) (*authpb.ConfirmTOTPResponse, error) {
+	id, err := uuid.Parse(strings.TrimSpace(req.GetUserId()))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user id")
+	}
+	recoveryCodes, err := h.userManager.ConfirmTOTP(ctx, id, req.GetCode())
+	if errors.Is(err, constant.ErrInvalidMFACode) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return nil, mfaError(err)
+	}
+	return &authpb.ConfirmTOTPResponse{
+		RecoveryCodes: recoveryCodes,
+	}, nil
+}
+
+func (h *Handler) VerifyMFA(ctx context.Context, req *authpb.VerifyMFARequest) (*authpb.VerifyMFAResponse, error) {
+	mfaToken := strings.TrimSpace(req.GetMfaToken())
+	if mfaToken == "" {
+		return nil, status.Error(codes.InvalidArgument, "mfa token is required")
+	}
+	token, exp, err := h.userManager.VerifyMFA(ctx, mfaToken, req.GetCode(), strings.TrimSpace(req.GetRemoteAddr()))
+	var lockedErr *user.LoginLockedError
+	if errors.As(err, &lockedErr) {
+		return nil, loginLockedError(lockedErr)
+	}
+	if errors.Is(err, constant.ErrInvalidMFACode) || errors.Is(err, constant.ErrInvalidMFAToken) {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if errors.Is(err, constant.ErrAccountDisabled) {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+	if err != nil {
+		return nil, mfaError(err)
+	}
+	return &authpb.VerifyMFAResponse{
+		AccessToken: *token,
+		ExpiryUnix:  exp,
+	}, nil
+}
+
+// mfaError maps the errors of two-factor authentication to gRPC statuses.
+func mfaError(err error) error {
+	switch {
+	case errors.Is(err, constant.ErrUserNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, constant.ErrMFAAlreadyEnabled):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, constant.ErrMFANotEnrolled),
+		errors.Is(err, constant.ErrMFAUnavailable):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return err
+	}
+}
@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	authpb "github.com/a1y/doc-formatter/api/grpc/auth/v1"
+	"github.com/a1y/doc-formatter/internal/auth/infra/persistence"
+	"github.com/a1y/doc-formatter/internal/auth/manager/user"
+	jwtutil "github.com/a1y/doc-formatter/internal/auth/util/jwt"
+	"github.com/a1y/doc-formatter/internal/auth/util/secretbox"
+	"github.com/a1y/doc-formatter/internal/auth/util/totp"
+	"github.com/a1y/doc-formatter/pkg/credentials"
+	testpersistence "github.com/a1y/doc-formatter/pkg/persistence"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var mfaUserColumns = []string{"id", "email", "password", "is_verified", "session_version", "totp_secret", "mfa_enabled", "totp_last_step"}
+
+func TestHandler_EnrollTOTP(t *testing.T) {
+	db, mock, err := testpersistence.GetMockDB()
+	assert.NoError(t, err)
+
+	box, err := secretbox.New(bytes.Repeat([]byte{1}, secretbox.KeySize))
+	require.NoError(t, err)
+	userManager := user.NewUserManager(persistence.NewUserRepository(db), jwtutil.TokenClaim{})
+	userManager.SetMFA(persistence.NewMFARepository(db), box, user.MFAConfig{})
+	h, err := NewHandler(userManager)
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+	userID := uuid.New()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE id = $1`)).WithArgs(userID, 1).
+		WillReturnRows(sqlmock.NewRows(mfaUserColumns).AddRow(userID.String(), "test@example.com", "hash", true, 0, nil, false, 0))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "users" SET "totp_secret"=$1`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), userID, false).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectClose()
+
+	resp, err := h.EnrollTOTP(ctx, &authpb.EnrollTOTPRequest{UserId: userID.String()})
+	require.NoError(t, err)
+	assert.Len(t, resp.GetSecret(), 32)
+	assert.Contains(t, resp.GetOtpauthUri(), "otpauth://totp/Doc%20Formatter:test@example.com?")
+
+	_, err = h.EnrollTOTP(ctx, &authpb.EnrollTOTPRequest{UserId: "not-a-uuid"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	testpersistence.CloseDB(t, db)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	// Without a key to seal secrets with, two-factor authentication is
+	// unavailable.
+	h, err = NewHandler(user.NewUserManager(nil, jwtutil.TokenClaim{}))
+	assert.NoError(t, err)
+	_, err = h.EnrollTOTP(ctx, &authpb.EnrollTOTPRequest{UserId: userID.String()})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestHandler_LoginWithMFA(t *testing.T) {
+	_, tokenPath := setupTestPrivateKey(t)
+
+	db, mock, err := testpersistence.GetMockDB()
+	assert.NoError(t, err)
+
+	box, err := secretbox.New(bytes.Repeat([]byte{1}, secretbox.KeySize))
+	require.NoError(t, err)
+	userManager := user.NewUserManager(persistence.NewUserRepository(db), jwtutil.TokenClaim{TokenPath: tokenPath})
+	userManager.SetMFA(persistence.NewMFARepository(db), box, user.MFAConfig{})
+	h, err := NewHandler(userManager)
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	email := "test@example.com"
+	hash, err := credentials.NewDefaultArgon2idHash().HashPassword("password123", nil)
+	require.NoError(t, err)
+	secret, err := totp.GenerateSecret()
+	require.NoError(t, err)
+	sealed, err := box.Seal(secret, userID[:])
+	require.NoError(t, err)
+	row := func() *sqlmock.Rows {
+		return sqlmock.NewRows(mfaUserColumns).AddRow(userID.String(), email, hash, true, 0, sealed, true, 0)
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE email = $1`)).WithArgs(email, 1).WillReturnRows(row())
+
+	loginResp, err := h.Login(ctx, &authpb.LoginRequest{Email: email, Password: "password123"})
+	require.NoError(t, err)
+	assert.True(t, loginResp.GetMfaRequired())
+	assert.Empty(t, loginResp.GetAccessToken())
+	require.NotEmpty(t, loginResp.GetMfaToken())
+
+	selectByID := regexp.QuoteMeta(`SELECT * FROM "users" WHERE id = $1`)
+	mock.ExpectQuery(selectByID).WithArgs(userID, 1).WillReturnRows(row())
+
+	_, err = h.VerifyMFA(ctx, &authpb.VerifyMFARequest{MfaToken: loginResp.GetMfaToken(), Code: totp.Code(secret, time.Now().Add(-time.Hour))})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	mock.ExpectQuery(selectByID).WithArgs(userID, 1).WillReturnRows(row())
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "users" SET "totp_last_step"=$1`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), userID, true, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectClose()
+
+	verifyResp, err := h.VerifyMFA(ctx, &authpb.VerifyMFARequest{MfaToken: loginResp.GetMfaToken(), Code: totp.Code(secret, time.Now())})
+	require.NoError(t, err)
+	assert.NotEmpty(t, verifyResp.GetAccessToken())
+	assert.NotZero(t, verifyResp.GetExpiryUnix())
+
+	_, err = h.VerifyMFA(ctx, &authpb.VerifyMFARequest{MfaToken: "not-a-token", Code: "123456"})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	testpersistence.CloseDB(t, db)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	authpb "github.com/a1y/doc-formatter/api/grpc/auth/v1"
+	"github.com/a1y/doc-formatter/internal/auth/domain/constant"
+	"github.com/a1y/doc-formatter/internal/auth/util/oidc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (h *Handler) StartOIDCLogin(ctx context.Context, req *authpb.StartOIDCLoginRequest) (*authpb.StartOIDCLoginResponse, error) {
+	authURL, loginToken, exp, err := h.userManager.StartOIDCLogin(ctx, strings.TrimSpace(req.GetProvider()))
+	if err != nil {
+		return nil, oidcError(err)
+	}
+	return &authpb.StartOIDCLoginResponse{
+		AuthorizationUrl: authURL,
+		LoginToken:       loginToken,
+		ExpiryUnix:       exp,
+	}, nil
+}
+
+func (h *Handler) FinishOIDCLogin(ctx context.Context, req *authpb.FinishOIDCLoginRequest) (*authpb.LoginResponse, error) {
+	if req.GetCode() == "" || req.GetState() == "" || req.GetLoginToken() == "" {
+		return nil, status.Error(codes.InvalidArgument, "code, state and login token are required")
+	}
+	token, exp, err := h.userManager.FinishOIDCLogin(ctx, strings.TrimSpace(req.GetProvider()), req.GetCode(), req.GetState(), req.GetLoginToken())
+	return loginResponse(token, exp, oidcError(err))
+}
+
+// oidcError maps the errors of logins with identity providers to statuses,
+// and returns other errors as they are.
+func oidcError(err error) error {
+	switch {
+	case errors.Is(err, constant.ErrOIDCProviderNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, constant.ErrInvalidOIDCLogin):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, constant.ErrOIDCEmailNotVerified), errors.Is(err, constant.ErrOIDCAccountNotVerified):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, oidc.ErrDiscovery):
+		return status.Error(codes.Unavailable, "identity provider is unavailable")
+	default:
+		return err
+	}
+}
@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	authpb "github.com/a1y/doc-formatter/api/grpc/auth/v1"
+	"github.com/a1y/doc-formatter/internal/auth/infra/persistence"
+	"github.com/a1y/doc-formatter/internal/auth/manager/user"
+	jwtutil "github.com/a1y/doc-formatter/internal/auth/util/jwt"
+	"github.com/a1y/doc-formatter/internal/auth/util/oidc"
+	"github.com/a1y/doc-formatter/internal/auth/util/oidc/oidctest"
+	testpersistence "github.com/a1y/doc-formatter/pkg/persistence"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestHandler_OIDCLogin(t *testing.T) {
+	_, tokenPath := setupTestPrivateKey(t)
+
+	db, mock, err := testpersistence.GetMockDB()
+	assert.NoError(t, err)
+
+	idp := oidctest.NewServer("client", "secret")
+	defer idp.Close()
+	idp.SetUser(oidctest.User{Subject: "sub-1", Email: "test@example.com", EmailVerified: true})
+	provider, err := oidc.NewProvider(oidc.ProviderConfig{
+		Name:         "corp",
+		Issuer:       idp.Issuer(),
+		ClientID:     "client",
+		ClientSecret: "secret",
+		RedirectURL:  "https://gateway.example.com/api/v1/auth/oidc/corp/callback",
+	}, nil)
+	require.NoError(t, err)
+
+	userManager := user.NewUserManager(persistence.NewUserRepository(db), jwtutil.TokenClaim{TokenPath: tokenPath})
+	userManager.SetOIDC(persistence.NewIdentityRepository(db), []*oidc.Provider{provider})
+	h, err := NewHandler(userManager)
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	_, err = h.StartOIDCLogin(ctx, &authpb.StartOIDCLoginRequest{Provider: "other"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	startResp, err := h.StartOIDCLogin(ctx, &authpb.StartOIDCLoginRequest{Provider: "corp"})
+	require.NoError(t, err)
+	assert.NotEmpty(t, startResp.GetLoginToken())
+	assert.NotZero(t, startResp.GetExpiryUnix())
+
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	resp, err := client.Get(startResp.GetAuthorizationUrl())
+	require.NoError(t, err)
+	resp.Body.Close()
+	location, err := url.Parse(resp.Header.Get("Location"))
+	require.NoError(t, err)
+	finishReq := &authpb.FinishOIDCLoginRequest{
+		Provider:   "corp",
+		Code:       location.Query().Get("code"),
+		State:      location.Query().Get("state"),
+		LoginToken: startResp.GetLoginToken(),
+	}
+
+	_, err = h.FinishOIDCLogin(ctx, &authpb.FinishOIDCLoginRequest{Provider: "corp", Code: finishReq.Code, State: "other-state", LoginToken: finishReq.LoginToken})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = h.FinishOIDCLogin(ctx, &authpb.FinishOIDCLoginRequest{Provider: "corp", Code: finishReq.Code})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// The first login creates a user for the identity.
+	userID := uuid.New()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "user_identities" WHERE (provider = $1 AND subject = $2)`)).
+		WithArgs("corp", "sub-1", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE email = $1`)).
+		WithArgs("test@example.com", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "users"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), "test@example.com", sqlmock.AnyArg(), true, 0, "", []byte(nil), false, 0, "user", false).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(userID))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "user_identities"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), userID, "corp", "sub-1", "test@example.com").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+	mock.ExpectCommit()
+	mock.ExpectClose()
+
+	loginResp, err := h.FinishOIDCLogin(ctx, finishReq)
+	require.NoError(t, err)
+	assert.NotEmpty(t, loginResp.GetAccessToken())
+	assert.False(t, loginResp.GetMfaRequired())
+
+	testpersistence.CloseDB(t, db)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
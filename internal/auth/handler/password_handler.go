@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	authpb "github.com/a1y/doc-formatter/api/grpc/auth/v1"
+	"github.com/a1y/doc-formatter/internal/auth/domain/constant"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (h *Handler) RequestPasswordReset(ctx context.Context, req *authpb.RequestPasswordResetRequest) (*authpb.RequestPasswordResetResponse, error) {
+	email := strings.TrimSpace(req.GetEmail())
+	if email == "" {
+		return nil, status.Error(codes.InvalidArgument, "email is required")
+	}
+	if err := h.userManager.RequestPasswordReset(ctx, email); err != nil {
+		return nil, err
+	}
+	return &authpb.RequestPasswordResetResponse{}, nil
+}
+
+func (h *Handler) ResetPassword(ctx context.Context, req *authpb.ResetPasswordRequest) (*authpb.ResetPasswordResponse, error) {
+	err := h.userManager.ResetPassword(ctx, strings.TrimSpace(req.GetToken()), req.GetNewPassword())
+	if policyErr := passwordPolicyError(err, "new_password"); policyErr != nil {
+		return nil, policyErr
+	}
+	if errors.Is(err, constant.ErrInvalidResetToken) || errors.Is(err, constant.ErrPasswordRequired) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &authpb.ResetPasswordResponse{}, nil
+}
+
+func (h *Handler) ValidateToken(ctx context.Context, req *authpb.ValidateTokenRequest) (*authpb.ValidateTokenResponse, error) {
+	session, err := h.userManager.ValidateAccessToken(ctx, strings.TrimSpace(req.GetAccessToken()))
+	if errors.Is(err, constant.ErrInvalidAccessToken) {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	resp := &authpb.ValidateTokenResponse{
+		UserId:      session.User.ID.String(),
+		Email:       session.User.Email,
+		IsVerified:  session.User.IsVerified,
+		Role:        session.Role.Name,
+		Permissions: session.Role.Permissions,
+	}
+	if session.ImpersonatorID != uuid.Nil {
+		resp.ImpersonatorId = session.ImpersonatorID.String()
+	}
+	return resp, nil
+}
@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	authpb "github.com/a1y/doc-formatter/api/grpc/auth/v1"
+	"github.com/a1y/doc-formatter/internal/auth/infra/persistence"
+	"github.com/a1y/doc-formatter/internal/auth/manager/user"
+	jwtutil "github.com/a1y/doc-formatter/internal/auth/util/jwt"
+	testpersistence "github.com/a1y/doc-formatter/pkg/persistence"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+func TestHandler_RequestPasswordReset(t *testing.T) {
+	db, mock, err := testpersistence.GetMockDB()
+	assert.NoError(t, err)
+
+	userManager := user.NewUserManager(persistence.NewUserRepository(db), jwtutil.TokenClaim{})
+	userManager.SetPasswordReset(persistence.NewPasswordResetRepository(db), user.PasswordResetConfig{})
+	h, err := NewHandler(userManager)
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE email = $1 AND "users"."deleted_at" IS NULL ORDER BY "users"."id" LIMIT $2`)).
+		WithArgs("missing@example.com", 1).
+		WillReturnError(gorm.ErrRecordNotFound)
+	mock.ExpectClose()
+
+	resp, err := h.RequestPasswordReset(ctx, &authpb.RequestPasswordResetRequest{Email: "missing@example.com"})
+	assert.NoError(t, err)
+	assert.NotNil(t, resp)
+
+	_, err = h.RequestPasswordReset(ctx, &authpb.RequestPasswordResetRequest{Email: " "})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	testpersistence.CloseDB(t, db)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestHandler_ResetPassword(t *testing.T) {
+	db, mock, err := testpersistence.GetMockDB()
+	assert.NoError(t, err)
+
+	userManager := user.NewUserManager(persistence.NewUserRepository(db), jwtutil.TokenClaim{})
+	userManager.SetPasswordReset(persistence.NewPasswordResetRepository(db), user.PasswordResetConfig{})
+	h, err := NewHandler(userManager)
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "password_resets"`)).
+		WillReturnError(gorm.ErrRecordNotFound)
+	mock.ExpectRollback()
+	mock.ExpectClose()
+
+	_, err = h.ResetPassword(ctx, &authpb.ResetPasswordRequest{Token: "used", NewPassword: "new-password"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = h.ResetPassword(ctx, &authpb.ResetPasswordRequest{Token: "token"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	testpersistence.CloseDB(t, db)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestHandler_ValidateToken(t *testing.T) {
+	_, tokenPath := setupTestPrivateKey(t)
+
+	db, mock, err := testpersistence.GetMockDB()
+	assert.NoError(t, err)
+
+	claims := jwtutil.TokenClaim{TokenPath: tokenPath}
+	h, err := NewHandler(user.NewUserManager(persistence.NewUserRepository(db), claims))
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	email := "test@example.com"
+	query := regexp.QuoteMeta(`SELECT * FROM "users" WHERE id = $1 AND "users"."deleted_at" IS NULL ORDER BY "users"."id" LIMIT $2`)
+	columns := []string{"id", "created_at", "updated_at", "deleted_at", "description", "name", "username", "email", "password", "is_verified", "session_version"}
+	mock.ExpectQuery(query).WithArgs(userID, 1).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(userID.String(), nil, nil, nil, "", "", "", email, "hash", true, 1))
+	mock.ExpectQuery(query).WithArgs(userID, 1).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(userID.String(), nil, nil, nil, "", "", "", email, "hash", true, 2))
+	mock.ExpectClose()
+
+	token, _, err := claims.GenerateSessionToken(userID, email, 1, time.Minute)
+	assert.NoError(t, err)
+
+	resp, err := h.ValidateToken(ctx, &authpb.ValidateTokenRequest{AccessToken: token})
+	assert.NoError(t, err)
+	assert.Equal(t, userID.String(), resp.GetUserId())
+	assert.True(t, resp.GetIsVerified())
+
+	// The password was reset since the token was issued.
+	_, err = h.ValidateToken(ctx, &authpb.ValidateTokenRequest{AccessToken: token})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = h.ValidateToken(ctx, &authpb.ValidateTokenRequest{AccessToken: "not-a-token"})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	testpersistence.CloseDB(t, db)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
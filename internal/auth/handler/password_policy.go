@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/a1y/doc-formatter/internal/auth/util/passwordpolicy"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// passwordPolicyError maps a password policy error to an InvalidArgument
+// status with a field violation on field for every failed rule, the rule
+// being the reason of the violation. It returns nil for other errors.
+func passwordPolicyError(err error, field string) error {
+	var policyErr *passwordpolicy.Error
+	if !errors.As(err, &policyErr) {
+		return nil
+	}
+	violations := make([]*errdetails.BadRequest_FieldViolation, 0, len(policyErr.Violations))
+	for _, v := range policyErr.Violations {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       field,
+			Reason:      v.Rule,
+			Description: v.Description,
+		})
+	}
+	st, detailsErr := status.New(codes.InvalidArgument, policyErr.Error()).
+		WithDetails(&errdetails.BadRequest{FieldViolations: violations})
+	if detailsErr != nil {
+		return status.Error(codes.InvalidArgument, policyErr.Error())
+	}
+	return st.Err()
+}
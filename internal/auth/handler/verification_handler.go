@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	authpb "github.com/a1y/doc-formatter/api/grpc/auth/v1"
+	"github.com/a1y/doc-formatter/internal/auth/domain/constant"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (h *Handler) VerifyEmail(ctx context.Context, req *authpb.VerifyEmailRequest) (*authpb.VerifyEmailResponse, error) {
+	token := strings.TrimSpace(req.GetToken())
+	if token == "" {
+		return nil, status.Error(codes.InvalidArgument, "token is required")
+	}
+	user, err := h.userManager.VerifyEmail(ctx, token)
+	if errors.Is(err, constant.ErrInvalidVerificationToken) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if errors.Is(err, constant.ErrEmailExists) {
+		return nil, status.Error(codes.AlreadyExists, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &authpb.VerifyEmailResponse{
+		UserId: user.ID.String(),
+		Email:  user.Email,
+	}, nil
+}
+
+func (h *Handler) ResendVerification(ctx context.Context, req *authpb.ResendVerificationRequest) (*authpb.ResendVerificationResponse, error) {
+	email := strings.TrimSpace(req.GetEmail())
+	if email == "" {
+		return nil, status.Error(codes.InvalidArgument, "email is required")
+	}
+	if err := h.userManager.ResendVerification(ctx, email); err != nil {
+		return nil, err
+	}
+	return &authpb.ResendVerificationResponse{}, nil
+}
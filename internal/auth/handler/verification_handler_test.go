@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	authpb "github.com/a1y/doc-formatter/api/grpc/auth/v1"
+	"github.com/a1y/doc-formatter/internal/auth/infra/persistence"
+	"github.com/a1y/doc-formatter/internal/auth/manager/user"
+	jwtutil "github.com/a1y/doc-formatter/internal/auth/util/jwt"
+	"github.com/a1y/doc-formatter/pkg/credentials"
+	testpersistence "github.com/a1y/doc-formatter/pkg/persistence"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+func TestHandler_VerifyEmail(t *testing.T) {
+	_, tokenPath := setupTestPrivateKey(t)
+
+	db, mock, err := testpersistence.GetMockDB()
+	assert.NoError(t, err)
+
+	claims := jwtutil.TokenClaim{TokenPath: tokenPath}
+	userManager := user.NewUserManager(persistence.NewUserRepository(db), claims)
+	h, err := NewHandler(userManager)
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	email := "test@example.com"
+	token, _, err := claims.GeneratePurposeToken(userID, email, jwtutil.PurposeVerifyEmail, time.Hour)
+	assert.NoError(t, err)
+
+	rows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "deleted_at", "description", "name", "username", "email", "password", "is_verified"}).
+		AddRow(userID.String(), nil, nil, nil, "", "", "", email, "hash", false)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE id = $1 AND "users"."deleted_at" IS NULL ORDER BY "users"."id" LIMIT $2`)).
+		WithArgs(userID, 1).
+		WillReturnRows(rows)
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "users" SET "is_verified"=$1`)).
+		WithArgs(true, sqlmock.AnyArg(), userID, email).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectClose()
+
+	resp, err := h.VerifyEmail(ctx, &authpb.VerifyEmailRequest{Token: token})
+	assert.NoError(t, err)
+	assert.Equal(t, userID.String(), resp.GetUserId())
+	assert.Equal(t, email, resp.GetEmail())
+
+	_, err = h.VerifyEmail(ctx, &authpb.VerifyEmailRequest{Token: "not-a-token"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = h.VerifyEmail(ctx, &authpb.VerifyEmailRequest{Token: " "})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	testpersistence.CloseDB(t, db)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestHandler_ResendVerification(t *testing.T) {
+	db, mock, err := testpersistence.GetMockDB()
+	assert.NoError(t, err)
+
+	userManager := user.NewUserManager(persistence.NewUserRepository(db), jwtutil.TokenClaim{})
+	h, err := NewHandler(userManager)
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE email = $1 AND "users"."deleted_at" IS NULL ORDER BY "users"."id" LIMIT $2`)).
+		WithArgs("missing@example.com", 1).
+		WillReturnError(gorm.ErrRecordNotFound)
+	mock.ExpectClose()
+
+	resp, err := h.ResendVerification(ctx, &authpb.ResendVerificationRequest{Email: "missing@example.com"})
+	assert.NoError(t, err)
+	assert.NotNil(t, resp)
+
+	_, err = h.ResendVerification(ctx, &authpb.ResendVerificationRequest{Email: ""})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	testpersistence.CloseDB(t, db)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestHandler_Login_Unverified(t *testing.T) {
+	_, tokenPath := setupTestPrivateKey(t)
+
+	db, mock, err := testpersistence.GetMockDB()
+	assert.NoError(t, err)
+
+	userManager := user.NewUserManager(persistence.NewUserRepository(db), jwtutil.TokenClaim{TokenPath: tokenPath})
+	userManager.SetVerification(user.VerificationConfig{RequiredForLogin: true})
+	h, err := NewHandler(userManager)
+	assert.NoError(t, err)
+
+	password := "password123"
+	hashedPassword, err := credentials.NewDefaultArgon2idHash().HashPassword(password, nil)
+	assert.NoError(t, err)
+	email := "test@example.com"
+
+	rows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "deleted_at", "description", "name", "username", "email", "password", "is_verified"}).
+		AddRow(uuid.New().String(), nil, nil, nil, "", "", "", email, hashedPassword, false)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE email = $1 AND "users"."deleted_at" IS NULL ORDER BY "users"."id" LIMIT $2`)).
+		WithArgs(email, 1).
+		WillReturnRows(rows)
+	mock.ExpectClose()
+
+	_, err = h.Login(context.Background(), &authpb.LoginRequest{Email: email, Password: password})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	testpersistence.CloseDB(t, db)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -11,7 +11,7 @@ import (
 )
 
 func main() {
-	stmts, err := gormschema.New("postgres").Load(&persistence.UserModel{})
+	stmts, err := gormschema.New("postgres").Load(&persistence.UserModel{}, &persistence.PasswordResetModel{}, &persistence.AuditEventModel{}, &persistence.LoginAttemptModel{}, &persistence.RecoveryCodeModel{}, &persistence.IdentityModel{}, &persistence.APIKeyModel{}, &persistence.RoleModel{})
 	if err != nil {
 		logrus.Errorf("failed to load gorm schema: %v\n", err)
 		os.Exit(1)
@@ -0,0 +1,12 @@
+// Package memory keeps auth state in process memory, for running a single
+// instance of the auth service without sharing state through the database.
+package memory
+
+import (
+	"github.com/a1y/doc-formatter/internal/auth/domain/repository"
+	"github.com/a1y/doc-formatter/pkg/throttle"
+)
+
+func NewLoginAttemptRepository() repository.LoginAttemptRepository {
+	return throttle.NewMemoryStore()
+}
@@ -0,0 +1,94 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"github.com/a1y/doc-formatter/internal/auth/domain/entity"
+	"github.com/a1y/doc-formatter/internal/auth/domain/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var _ repository.APIKeyRepository = &apiKeyRepository{}
+
+type apiKeyRepository struct {
+	db *gorm.DB
+}
+
+func NewAPIKeyRepository(db *gorm.DB) repository.APIKeyRepository {
+	return &apiKeyRepository{
+		db: db,
+	}
+}
+
+func (r *apiKeyRepository) Create(ctx context.Context, dataEntity *entity.APIKey) error {
+	if err := dataEntity.Validate(); err != nil {
+		return err
+	}
+	var dataModel APIKeyModel
+	if err := dataModel.FromEntity(dataEntity); err != nil {
+		return err
+	}
+	if err := r.db.WithContext(ctx).Create(&dataModel).Error; err != nil {
+		return err
+	}
+	dataEntity.ID = dataModel.ID
+	dataEntity.CreatedAt = dataModel.CreatedAt
+	return nil
+}
+
+func (r *apiKeyRepository) GetByPrefix(ctx context.Context, prefix string) (*entity.APIKey, error) {
+	var dataModel APIKeyModel
+	if err := r.db.WithContext(ctx).
+		Where("prefix = ? AND revoked_at IS NULL", prefix).
+		First(&dataModel).Error; err != nil {
+		return nil, err
+	}
+	return dataModel.ToEntity()
+}
+
+func (r *apiKeyRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*entity.APIKey, error) {
+	var models []APIKeyModel
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Order("created_at DESC, id DESC").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+	entities := make([]*entity.APIKey, len(models))
+	for i, model := range models {
+		entity, err := model.ToEntity()
+		if err != nil {
+			return nil, err
+		}
+		entities[i] = entity
+	}
+	return entities, nil
+}
+
+func (r *apiKeyRepository) Revoke(ctx context.Context, userID, id uuid.UUID, now time.Time) error {
+	result := r.db.WithContext(ctx).Model(&APIKeyModel{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", id, userID).
+		Update("revoked_at", now)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *apiKeyRepository) RevokeAllByUser(ctx context.Context, userID uuid.UUID, now time.Time) error {
+	return r.db.WithContext(ctx).Model(&APIKeyModel{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", now).Error
+}
+
+func (r *apiKeyRepository) MarkUsed(ctx context.Context, id uuid.UUID, now time.Time) error {
+	return r.db.WithContext(ctx).Model(&APIKeyModel{}).
+		Where("id = ?", id).
+		Update("last_used_at", now).Error
+}
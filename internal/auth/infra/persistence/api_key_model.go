@@ -0,0 +1,53 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/a1y/doc-formatter/internal/auth/domain/entity"
+	"github.com/google/uuid"
+)
+
+type APIKeyModel struct {
+	BaseModel
+	UserID     uuid.UUID `gorm:"index"`
+	Name       string
+	Prefix     string `gorm:"uniqueIndex"`
+	KeyHash    string
+	Scopes     MultiString
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+}
+
+func (k *APIKeyModel) TableName() string {
+	return "api_keys"
+}
+
+func (k *APIKeyModel) ToEntity() (*entity.APIKey, error) {
+	return &entity.APIKey{
+		ID:         k.ID,
+		UserID:     k.UserID,
+		Name:       k.Name,
+		Prefix:     k.Prefix,
+		KeyHash:    k.KeyHash,
+		Scopes:     k.Scopes,
+		LastUsedAt: k.LastUsedAt,
+		RevokedAt:  k.RevokedAt,
+		CreatedAt:  k.CreatedAt,
+	}, nil
+}
+
+func (k *APIKeyModel) FromEntity(e *entity.APIKey) error {
+	k.ID = e.ID
+	k.UserID = e.UserID
+	k.Name = e.Name
+	k.Prefix = e.Prefix
+	k.KeyHash = e.KeyHash
+	// Keys without scopes are stored as NULL, which scans back to no scopes
+	// where an empty string would scan to one empty scope.
+	if len(e.Scopes) > 0 {
+		k.Scopes = e.Scopes
+	}
+	k.LastUsedAt = e.LastUsedAt
+	k.RevokedAt = e.RevokedAt
+	return nil
+}
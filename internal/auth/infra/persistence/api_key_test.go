@@ -0,0 +1,162 @@
+package persistence
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/a1y/doc-formatter/internal/auth/domain/entity"
+	testpersistence "github.com/a1y/doc-formatter/pkg/persistence"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestAPIKeyRepository_Create(t *testing.T) {
+	db, mock, err := testpersistence.GetMockDB()
+	assert.NoError(t, err)
+
+	repo := NewAPIKeyRepository(db)
+	ctx := context.Background()
+	userID := uuid.New()
+	insert := regexp.QuoteMeta(`INSERT INTO "api_keys"`)
+
+	scoped := &entity.APIKey{UserID: userID, Name: "ci", Prefix: "dfk_0123456789ab", KeyHash: "hash", Scopes: []string{"read", "upload"}}
+	mock.ExpectQuery(insert).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), userID, "ci", "dfk_0123456789ab", "hash", "read,upload", nil, nil).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+	assert.NoError(t, repo.Create(ctx, scoped))
+	assert.NotEqual(t, uuid.Nil, scoped.ID)
+
+	// Keys without scopes are stored without any.
+	unscoped := &entity.APIKey{UserID: userID, Name: "ci", Prefix: "dfk_ba9876543210", KeyHash: "hash", Scopes: []string{}}
+	mock.ExpectQuery(insert).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), userID, "ci", "dfk_ba9876543210", "hash", nil, nil, nil).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+	assert.NoError(t, repo.Create(ctx, unscoped))
+
+	assert.Error(t, repo.Create(ctx, &entity.APIKey{UserID: userID, Name: "ci", Prefix: "dfk_x", KeyHash: "hash", Scopes: []string{"admin"}}))
+
+	mock.ExpectClose()
+	testpersistence.CloseDB(t, db)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAPIKeyRepository_GetByPrefix(t *testing.T) {
+	db, mock, err := testpersistence.GetMockDB()
+	assert.NoError(t, err)
+
+	repo := NewAPIKeyRepository(db)
+	ctx := context.Background()
+	id, userID := uuid.New(), uuid.New()
+	query := regexp.QuoteMeta(`SELECT * FROM "api_keys" WHERE (prefix = $1 AND revoked_at IS NULL) AND "api_keys"."deleted_at" IS NULL ORDER BY "api_keys"."id" LIMIT $2`)
+
+	mock.ExpectQuery(query).
+		WithArgs("dfk_0123456789ab", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "prefix", "key_hash", "scopes"}).
+			AddRow(id.String(), userID.String(), "ci", "dfk_0123456789ab", "hash", "format,read"))
+	apiKey, err := repo.GetByPrefix(ctx, "dfk_0123456789ab")
+	assert.NoError(t, err)
+	assert.Equal(t, &entity.APIKey{ID: id, UserID: userID, Name: "ci", Prefix: "dfk_0123456789ab", KeyHash: "hash", Scopes: []string{"format", "read"}}, apiKey)
+
+	mock.ExpectQuery(query).
+		WithArgs("dfk_ba9876543210", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	_, err = repo.GetByPrefix(ctx, "dfk_ba9876543210")
+	assert.Equal(t, gorm.ErrRecordNotFound, err)
+
+	mock.ExpectClose()
+	testpersistence.CloseDB(t, db)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAPIKeyRepository_ListByUser(t *testing.T) {
+	db, mock, err := testpersistence.GetMockDB()
+	assert.NoError(t, err)
+
+	repo := NewAPIKeyRepository(db)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "api_keys" WHERE (user_id = $1 AND revoked_at IS NULL) AND "api_keys"."deleted_at" IS NULL ORDER BY created_at DESC, id DESC`)).
+		WithArgs(userID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "scopes"}).
+			AddRow(uuid.New().String(), userID.String(), "deploy", nil).
+			AddRow(uuid.New().String(), userID.String(), "ci", "read"))
+	keys, err := repo.ListByUser(ctx, userID)
+	assert.NoError(t, err)
+	if assert.Len(t, keys, 2) {
+		assert.Equal(t, "deploy", keys[0].Name)
+		assert.Empty(t, keys[0].Scopes)
+		assert.Equal(t, []string{"read"}, keys[1].Scopes)
+	}
+
+	mock.ExpectClose()
+	testpersistence.CloseDB(t, db)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAPIKeyRepository_Revoke(t *testing.T) {
+	db, mock, err := testpersistence.GetMockDB()
+	assert.NoError(t, err)
+
+	repo := NewAPIKeyRepository(db)
+	ctx := context.Background()
+	id, userID := uuid.New(), uuid.New()
+	now := time.Now()
+	query := regexp.QuoteMeta(`UPDATE "api_keys" SET "revoked_at"=$1,"updated_at"=$2 WHERE (id = $3 AND user_id = $4 AND revoked_at IS NULL) AND "api_keys"."deleted_at" IS NULL`)
+
+	mock.ExpectExec(query).
+		WithArgs(now, sqlmock.AnyArg(), id, userID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, repo.Revoke(ctx, userID, id, now))
+
+	mock.ExpectExec(query).
+		WithArgs(now, sqlmock.AnyArg(), id, userID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	assert.Equal(t, gorm.ErrRecordNotFound, repo.Revoke(ctx, userID, id, now))
+
+	mock.ExpectClose()
+	testpersistence.CloseDB(t, db)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAPIKeyRepository_RevokeAllByUser(t *testing.T) {
+	db, mock, err := testpersistence.GetMockDB()
+	assert.NoError(t, err)
+
+	repo := NewAPIKeyRepository(db)
+	ctx := context.Background()
+	userID := uuid.New()
+	now := time.Now()
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "api_keys" SET "revoked_at"=$1,"updated_at"=$2 WHERE (user_id = $3 AND revoked_at IS NULL) AND "api_keys"."deleted_at" IS NULL`)).
+		WithArgs(now, sqlmock.AnyArg(), userID).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	assert.NoError(t, repo.RevokeAllByUser(ctx, userID, now))
+
+	mock.ExpectClose()
+	testpersistence.CloseDB(t, db)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAPIKeyRepository_MarkUsed(t *testing.T) {
+	db, mock, err := testpersistence.GetMockDB()
+	assert.NoError(t, err)
+
+	repo := NewAPIKeyRepository(db)
+	ctx := context.Background()
+	id := uuid.New()
+	now := time.Now()
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "api_keys" SET "last_used_at"=$1,"updated_at"=$2 WHERE id = $3 AND "api_keys"."deleted_at" IS NULL`)).
+		WithArgs(now, sqlmock.AnyArg(), id).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, repo.MarkUsed(ctx, id, now))
+
+	mock.ExpectClose()
+	testpersistence.CloseDB(t, db)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
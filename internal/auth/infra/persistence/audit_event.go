@@ -0,0 +1,37 @@
+package persistence
+
+import (
+	"context"
+
+	"github.com/a1y/doc-formatter/internal/auth/domain/entity"
+	"github.com/a1y/doc-formatter/internal/auth/domain/repository"
+	"gorm.io/gorm"
+)
+
+var _ repository.AuditRepository = &auditRepository{}
+
+type auditRepository struct {
+	db *gorm.DB
+}
+
+func NewAuditRepository(db *gorm.DB) repository.AuditRepository {
+	return &auditRepository{
+		db: db,
+	}
+}
+
+func (r *auditRepository) Create(ctx context.Context, dataEntity *entity.AuditEvent) error {
+	if err := dataEntity.Validate(); err != nil {
+		return err
+	}
+	var dataModel AuditEventModel
+	if err := dataModel.FromEntity(dataEntity); err != nil {
+		return err
+	}
+	if err := r.db.WithContext(ctx).Create(&dataModel).Error; err != nil {
+		return err
+	}
+	dataEntity.ID = dataModel.ID
+	dataEntity.CreatedAt = dataModel.CreatedAt
+	return nil
+}
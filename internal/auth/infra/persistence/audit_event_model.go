@@ -0,0 +1,47 @@
+package persistence
+
+import (
+	"github.com/a1y/doc-formatter/internal/auth/domain/entity"
+	"github.com/google/uuid"
+)
+
+type AuditEventModel struct {
+	BaseModel
+	UserID uuid.UUID `gorm:"index"`
+	// ActorID is the administrator who acted on the user, NULL when the
+	// user acted themselves.
+	ActorID *uuid.UUID `gorm:"index"`
+	Action  string
+	Detail  string
+}
+
+func (e *AuditEventModel) TableName() string {
+	return "audit_events"
+}
+
+func (e *AuditEventModel) ToEntity() (*entity.AuditEvent, error) {
+	var actorID uuid.UUID
+	if e.ActorID != nil {
+		actorID = *e.ActorID
+	}
+	return &entity.AuditEvent{
+		ID:        e.ID,
+		UserID:    e.UserID,
+		ActorID:   actorID,
+		Action:    e.Action,
+		Detail:    e.Detail,
+		CreatedAt: e.CreatedAt,
+	}, nil
+}
+
+func (e *AuditEventModel) FromEntity(ev *entity.AuditEvent) error {
+	e.ID = ev.ID
+	e.UserID = ev.UserID
+	e.ActorID = nil
+	if ev.ActorID != uuid.Nil {
+		e.ActorID = &ev.ActorID
+	}
+	e.Action = ev.Action
+	e.Detail = ev.Detail
+	return nil
+}
@@ -0,0 +1,41 @@
+package persistence
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/a1y/doc-formatter/internal/auth/domain/entity"
+	testpersistence "github.com/a1y/doc-formatter/pkg/persistence"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditRepository_Create(t *testing.T) {
+	db, mock, err := testpersistence.GetMockDB()
+	assert.NoError(t, err)
+
+	repo := NewAuditRepository(db)
+	ctx := context.Background()
+
+	event := &entity.AuditEvent{
+		UserID: uuid.New(),
+		Action: entity.AuditEmailChanged,
+		Detail: "old@example.com -> new@example.com",
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "audit_events"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), event.UserID, nil, event.Action, event.Detail).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+	mock.ExpectClose()
+
+	err = repo.Create(ctx, event)
+	assert.NoError(t, err)
+	assert.NotEqual(t, uuid.Nil, event.ID)
+
+	assert.Error(t, repo.Create(ctx, &entity.AuditEvent{UserID: uuid.New()}))
+
+	testpersistence.CloseDB(t, db)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,72 @@
+package persistence
+
+import (
+	"context"
+
+	"github.com/a1y/doc-formatter/internal/auth/domain/entity"
+	"github.com/a1y/doc-formatter/internal/auth/domain/repository"
+	"gorm.io/gorm"
+)
+
+var _ repository.IdentityRepository = &identityRepository{}
+
+type identityRepository struct {
+	db *gorm.DB
+}
+
+func NewIdentityRepository(db *gorm.DB) repository.IdentityRepository {
+	return &identityRepository{
+		db: db,
+	}
+}
+
+func (r *identityRepository) Get(ctx context.Context, provider, subject string) (*entity.Identity, error) {
+	var dataModel IdentityModel
+	if err := r.db.WithContext(ctx).
+		Where("provider = ? AND subject = ?", provider, subject).
+		First(&dataModel).Error; err != nil {
+		return nil, err
+	}
+	return dataModel.ToEntity()
+}
+
+func (r *identityRepository) Create(ctx context.Context, dataEntity *entity.Identity) error {
+	return createIdentity(r.db.WithContext(ctx), dataEntity)
+}
+
+func (r *identityRepository) CreateWithUser(ctx context.Context, user *entity.User, identity *entity.Identity) error {
+	if err := user.Validate(); err != nil {
+		return err
+	}
+	var userModel UserModel
+	if err := userModel.FromEntity(user); err != nil {
+		return err
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&userModel).Error; err != nil {
+			return err
+		}
+		identity.UserID = userModel.ID
+		if err := createIdentity(tx, identity); err != nil {
+			return err
+		}
+		user.ID = userModel.ID
+		return nil
+	})
+}
+
+func createIdentity(db *gorm.DB, dataEntity *entity.Identity) error {
+	if err := dataEntity.Validate(); err != nil {
+		return err
+	}
+	var dataModel IdentityModel
+	if err := dataModel.FromEntity(dataEntity); err != nil {
+		return err
+	}
+	if err := db.Create(&dataModel).Error; err != nil {
+		return err
+	}
+	dataEntity.ID = dataModel.ID
+	dataEntity.CreatedAt = dataModel.CreatedAt
+	return nil
+}
@@ -0,0 +1,40 @@
+package persistence
+
+import (
+	"github.com/a1y/doc-formatter/internal/auth/domain/entity"
+	"github.com/google/uuid"
+)
+
+// IdentityModel maps the subject of a user at an identity provider to their
+// user.
+type IdentityModel struct {
+	BaseModel
+	UserID   uuid.UUID `gorm:"index"`
+	Provider string    `gorm:"index:unique_identity,unique"`
+	Subject  string    `gorm:"index:unique_identity,unique"`
+	Email    string
+}
+
+func (i *IdentityModel) TableName() string {
+	return "user_identities"
+}
+
+func (i *IdentityModel) ToEntity() (*entity.Identity, error) {
+	return &entity.Identity{
+		ID:        i.ID,
+		UserID:    i.UserID,
+		Provider:  i.Provider,
+		Subject:   i.Subject,
+		Email:     i.Email,
+		CreatedAt: i.CreatedAt,
+	}, nil
+}
+
+func (i *IdentityModel) FromEntity(e *entity.Identity) error {
+	i.ID = e.ID
+	i.UserID = e.UserID
+	i.Provider = e.Provider
+	i.Subject = e.Subject
+	i.Email = e.Email
+	return nil
+}
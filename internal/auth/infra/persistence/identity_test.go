@@ -0,0 +1,106 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/a1y/doc-formatter/internal/auth/domain/entity"
+	testpersistence "github.com/a1y/doc-formatter/pkg/persistence"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestIdentityRepository_Get(t *testing.T) {
+	db, mock, err := testpersistence.GetMockDB()
+	assert.NoError(t, err)
+
+	repo := NewIdentityRepository(db)
+	ctx := context.Background()
+	id, userID := uuid.New(), uuid.New()
+	query := regexp.QuoteMeta(`SELECT * FROM "user_identities" WHERE (provider = $1 AND subject = $2) AND "user_identities"."deleted_at" IS NULL ORDER BY "user_identities"."id" LIMIT $3`)
+
+	mock.ExpectQuery(query).
+		WithArgs("corp", "sub-1", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "provider", "subject", "email"}).
+			AddRow(id.String(), userID.String(), "corp", "sub-1", "test@example.com"))
+	identity, err := repo.Get(ctx, "corp", "sub-1")
+	assert.NoError(t, err)
+	assert.Equal(t, &entity.Identity{ID: id, UserID: userID, Provider: "corp", Subject: "sub-1", Email: "test@example.com"}, identity)
+
+	mock.ExpectQuery(query).
+		WithArgs("corp", "sub-2", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	_, err = repo.Get(ctx, "corp", "sub-2")
+	assert.Equal(t, gorm.ErrRecordNotFound, err)
+
+	mock.ExpectClose()
+	testpersistence.CloseDB(t, db)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIdentityRepository_Create(t *testing.T) {
+	db, mock, err := testpersistence.GetMockDB()
+	assert.NoError(t, err)
+
+	repo := NewIdentityRepository(db)
+	ctx := context.Background()
+	identity := &entity.Identity{UserID: uuid.New(), Provider: "corp", Subject: "sub-1", Email: "test@example.com"}
+
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "user_identities"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), identity.UserID, "corp", "sub-1", "test@example.com").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+	mock.ExpectClose()
+
+	assert.NoError(t, repo.Create(ctx, identity))
+	assert.NotEqual(t, uuid.Nil, identity.ID)
+
+	assert.Error(t, repo.Create(ctx, &entity.Identity{UserID: uuid.New(), Provider: "corp"}))
+
+	testpersistence.CloseDB(t, db)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIdentityRepository_CreateWithUser(t *testing.T) {
+	db, mock, err := testpersistence.GetMockDB()
+	assert.NoError(t, err)
+
+	repo := NewIdentityRepository(db)
+	ctx := context.Background()
+	userID := uuid.New()
+	user := &entity.User{Email: "test@example.com", Password: "hash", IsVerified: true}
+	identity := &entity.Identity{Provider: "corp", Subject: "sub-1", Email: "test@example.com"}
+	insertUser := regexp.QuoteMeta(`INSERT INTO "users"`)
+	insertIdentity := regexp.QuoteMeta(`INSERT INTO "user_identities"`)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(insertUser).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(userID))
+	mock.ExpectQuery(insertIdentity).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), userID, "corp", "sub-1", "test@example.com").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+	mock.ExpectCommit()
+
+	assert.NoError(t, repo.CreateWithUser(ctx, user, identity))
+	assert.Equal(t, userID, user.ID)
+	assert.Equal(t, userID, identity.UserID)
+
+	// The user is not created when the identity cannot be linked.
+	mock.ExpectBegin()
+	mock.ExpectQuery(insertUser).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+	mock.ExpectQuery(insertIdentity).
+		WillReturnError(errors.New("duplicate key value violates unique constraint"))
+	mock.ExpectRollback()
+
+	user = &entity.User{Email: "other@example.com", Password: "hash"}
+	assert.Error(t, repo.CreateWithUser(ctx, user, &entity.Identity{Provider: "corp", Subject: "sub-1"}))
+	assert.Equal(t, uuid.Nil, user.ID)
+
+	mock.ExpectClose()
+	testpersistence.CloseDB(t, db)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,86 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"github.com/a1y/doc-formatter/internal/auth/domain/entity"
+	"github.com/a1y/doc-formatter/internal/auth/domain/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var _ repository.LoginAttemptRepository = &loginAttemptRepository{}
+
+type loginAttemptRepository struct {
+	db *gorm.DB
+}
+
+func NewLoginAttemptRepository(db *gorm.DB) repository.LoginAttemptRepository {
+	return &loginAttemptRepository{
+		db: db,
+	}
+}
+
+func (r *loginAttemptRepository) Get(ctx context.Context, keys []string) ([]*entity.LoginAttempt, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	var dataModels []LoginAttemptModel
+	if err := r.db.WithContext(ctx).Where("key IN ?", keys).Find(&dataModels).Error; err != nil {
+		return nil, err
+	}
+	attempts := make([]*entity.LoginAttempt, 0, len(dataModels))
+	for i := range dataModels {
+		attempt, err := dataModels[i].ToEntity()
+		if err != nil {
+			return nil, err
+		}
+		attempts = append(attempts, attempt)
+	}
+	return attempts, nil
+}
+
+func (r *loginAttemptRepository) RecordFailure(ctx context.Context, key string, now time.Time, window time.Duration) (*entity.LoginAttempt, error) {
+	var attempt *entity.LoginAttempt
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Counting in the upsert keeps failures made at once from being lost.
+		dataModel := LoginAttemptModel{Key: key, Failures: 1, LastFailureAt: now}
+		err := tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "key"}},
+			DoUpdates: clause.Assignments(map[string]any{
+				"failures":        gorm.Expr(`CASE WHEN "login_attempts"."last_failure_at" > ? THEN "login_attempts"."failures" + 1 ELSE 1 END`, now.Add(-window)),
+				"last_failure_at": now,
+				"updated_at":      now,
+			}),
+		}).Create(&dataModel).Error
+		if err != nil {
+			return err
+		}
+
+		var stored LoginAttemptModel
+		if err := tx.Where("key = ?", key).First(&stored).Error; err != nil {
+			return err
+		}
+		attempt, err = stored.ToEntity()
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return attempt, nil
+}
+
+func (r *loginAttemptRepository) Lock(ctx context.Context, key string, until time.Time) error {
+	return r.db.WithContext(ctx).Model(&LoginAttemptModel{}).
+		Where("key = ?", key).
+		Update("locked_until", until).Error
+}
+
+// Reset removes the counts for good, so that the key can be counted again
+// from scratch.
+func (r *loginAttemptRepository) Reset(ctx context.Context, key string) error {
+	return r.db.WithContext(ctx).Unscoped().
+		Where("key = ?", key).
+		Delete(&LoginAttemptModel{}).Error
+}
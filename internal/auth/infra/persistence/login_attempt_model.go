@@ -0,0 +1,39 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/a1y/doc-formatter/internal/auth/domain/entity"
+)
+
+type LoginAttemptModel struct {
+	BaseModel
+	Key           string `gorm:"uniqueIndex"`
+	Failures      int
+	LastFailureAt time.Time
+	LockedUntil   *time.Time
+}
+
+func (a *LoginAttemptModel) TableName() string {
+	return "login_attempts"
+}
+
+func (a *LoginAttemptModel) ToEntity() (*entity.LoginAttempt, error) {
+	return &entity.LoginAttempt{
+		ID:            a.ID,
+		Key:           a.Key,
+		Failures:      a.Failures,
+		LastFailureAt: a.LastFailureAt,
+		LockedUntil:   a.LockedUntil,
+		CreatedAt:     a.CreatedAt,
+	}, nil
+}
+
+func (a *LoginAttemptModel) FromEntity(e *entity.LoginAttempt) error {
+	a.ID = e.ID
+	a.Key = e.Key
+	a.Failures = e.Failures
+	a.LastFailureAt = e.LastFailureAt
+	a.LockedUntil = e.LockedUntil
+	return nil
+}
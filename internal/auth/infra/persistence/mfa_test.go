@@ -0,0 +1,158 @@
+package persistence
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	testpersistence "github.com/a1y/doc-formatter/pkg/persistence"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestMFARepository_SetTOTPSecret(t *testing.T) {
+	db, mock, err := testpersistence.GetMockDB()
+	assert.NoError(t, err)
+
+	repo := NewMFARepository(db)
+	ctx := context.Background()
+	userID := uuid.New()
+	query := regexp.QuoteMeta(`UPDATE "users" SET "totp_secret"=$1,"updated_at"=$2 WHERE (id = $3 AND mfa_enabled = $4) AND "users"."deleted_at" IS NULL`)
+
+	mock.ExpectExec(query).
+		WithArgs([]byte("sealed"), sqlmock.AnyArg(), userID, false).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, repo.SetTOTPSecret(ctx, userID, []byte("sealed")))
+
+	mock.ExpectExec(query).
+		WithArgs([]byte("sealed"), sqlmock.AnyArg(), userID, false).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	assert.Equal(t, gorm.ErrRecordNotFound, repo.SetTOTPSecret(ctx, userID, []byte("sealed")))
+
+	mock.ExpectClose()
+	testpersistence.CloseDB(t, db)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMFARepository_EnableTOTP(t *testing.T) {
+	db, mock, err := testpersistence.GetMockDB()
+	assert.NoError(t, err)
+
+	repo := NewMFARepository(db)
+	ctx := context.Background()
+	userID := uuid.New()
+	updateQuery := regexp.QuoteMeta(`UPDATE "users" SET "mfa_enabled"=$1,"totp_last_step"=$2,"updated_at"=$3 WHERE (id = $4 AND mfa_enabled = $5) AND "users"."deleted_at" IS NULL`)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(updateQuery).
+		WithArgs(true, int64(42), sqlmock.AnyArg(), userID, false).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "recovery_codes" SET "deleted_at"=$1 WHERE user_id = $2 AND "recovery_codes"."deleted_at" IS NULL`)).
+		WithArgs(sqlmock.AnyArg(), userID).
+		WillReturnResult(sqlmock.NewResult(0, 10))
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "recovery_codes"`)).
+		WithArgs(
+			sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), userID, "hash-1", nil,
+			sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), userID, "hash-2", nil,
+		).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()).AddRow(uuid.New()))
+	mock.ExpectCommit()
+
+	assert.NoError(t, repo.EnableTOTP(ctx, userID, 42, []string{"hash-1", "hash-2"}))
+
+	mock.ExpectBegin()
+	mock.ExpectExec(updateQuery).
+		WithArgs(true, int64(42), sqlmock.AnyArg(), userID, false).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	assert.Equal(t, gorm.ErrRecordNotFound, repo.EnableTOTP(ctx, userID, 42, []string{"hash-1"}))
+
+	mock.ExpectClose()
+	testpersistence.CloseDB(t, db)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMFARepository_UseTOTPStep(t *testing.T) {
+	db, mock, err := testpersistence.GetMockDB()
+	assert.NoError(t, err)
+
+	repo := NewMFARepository(db)
+	ctx := context.Background()
+	userID := uuid.New()
+	query := regexp.QuoteMeta(`UPDATE "users" SET "totp_last_step"=$1,"updated_at"=$2 WHERE (id = $3 AND mfa_enabled = $4 AND totp_last_step < $5) AND "users"."deleted_at" IS NULL`)
+
+	mock.ExpectExec(query).
+		WithArgs(int64(43), sqlmock.AnyArg(), userID, true, int64(43)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, repo.UseTOTPStep(ctx, userID, 43))
+
+	// The code was used already.
+	mock.ExpectExec(query).
+		WithArgs(int64(43), sqlmock.AnyArg(), userID, true, int64(43)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	assert.Equal(t, gorm.ErrRecordNotFound, repo.UseTOTPStep(ctx, userID, 43))
+
+	mock.ExpectClose()
+	testpersistence.CloseDB(t, db)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMFARepository_UseRecoveryCode(t *testing.T) {
+	db, mock, err := testpersistence.GetMockDB()
+	assert.NoError(t, err)
+
+	repo := NewMFARepository(db)
+	ctx := context.Background()
+	userID := uuid.New()
+	now := time.Now()
+	query := regexp.QuoteMeta(`UPDATE "recovery_codes" SET "used_at"=$1,"updated_at"=$2 WHERE (user_id = $3 AND code_hash = $4 AND used_at IS NULL) AND "recovery_codes"."deleted_at" IS NULL`)
+
+	mock.ExpectExec(query).
+		WithArgs(now, sqlmock.AnyArg(), userID, "hash").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, repo.UseRecoveryCode(ctx, userID, "hash", now))
+
+	mock.ExpectExec(query).
+		WithArgs(now, sqlmock.AnyArg(), userID, "hash").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	assert.Equal(t, gorm.ErrRecordNotFound, repo.UseRecoveryCode(ctx, userID, "hash", now))
+
+	mock.ExpectClose()
+	testpersistence.CloseDB(t, db)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMFARepository_ResetTOTP(t *testing.T) {
+	db, mock, err := testpersistence.GetMockDB()
+	assert.NoError(t, err)
+
+	repo := NewMFARepository(db)
+	ctx := context.Background()
+	userID := uuid.New()
+	update := regexp.QuoteMeta(`UPDATE "users" SET "mfa_enabled"=$1,"totp_last_step"=$2,"totp_secret"=$3,"updated_at"=$4 WHERE id = $5 AND "users"."deleted_at" IS NULL`)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(update).
+		WithArgs(false, 0, nil, sqlmock.AnyArg(), userID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "recovery_codes" SET "deleted_at"=$1 WHERE user_id = $2 AND "recovery_codes"."deleted_at" IS NULL`)).
+		WithArgs(sqlmock.AnyArg(), userID).
+		WillReturnResult(sqlmock.NewResult(0, 10))
+	mock.ExpectCommit()
+	assert.NoError(t, repo.ResetTOTP(ctx, userID))
+
+	mock.ExpectBegin()
+	mock.ExpectExec(update).
+		WithArgs(false, 0, nil, sqlmock.AnyArg(), userID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+	assert.Equal(t, gorm.ErrRecordNotFound, repo.ResetTOTP(ctx, userID))
+
+	mock.ExpectClose()
+	testpersistence.CloseDB(t, db)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,21 @@
+-- Modify "users" table
+ALTER TABLE "public"."users" ADD COLUMN "session_version" bigint NULL;
+-- Create "password_resets" table
+CREATE TABLE "public"."password_resets" (
+  "id" uuid NOT NULL,
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "deleted_at" timestamptz NULL,
+  "description" text NULL,
+  "user_id" uuid NULL,
+  "token_hash" text NULL,
+  "expires_at" timestamptz NULL,
+  "used_at" timestamptz NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_password_resets_deleted_at" to table: "password_resets"
+CREATE INDEX "idx_password_resets_deleted_at" ON "public"."password_resets" ("deleted_at");
+-- Create index "idx_password_resets_token_hash" to table: "password_resets"
+CREATE UNIQUE INDEX "idx_password_resets_token_hash" ON "public"."password_resets" ("token_hash");
+-- Create index "idx_password_resets_user_id" to table: "password_resets"
+CREATE INDEX "idx_password_resets_user_id" ON "public"."password_resets" ("user_id");
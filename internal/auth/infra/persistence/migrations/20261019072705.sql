@@ -0,0 +1,18 @@
+-- Modify "users" table
+ALTER TABLE "public"."users" ADD COLUMN "pending_email" text NULL;
+-- Create "audit_events" table
+CREATE TABLE "public"."audit_events" (
+  "id" uuid NOT NULL,
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "deleted_at" timestamptz NULL,
+  "description" text NULL,
+  "user_id" uuid NULL,
+  "action" text NULL,
+  "detail" text NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_audit_events_deleted_at" to table: "audit_events"
+CREATE INDEX "idx_audit_events_deleted_at" ON "public"."audit_events" ("deleted_at");
+-- Create index "idx_audit_events_user_id" to table: "audit_events"
+CREATE INDEX "idx_audit_events_user_id" ON "public"."audit_events" ("user_id");
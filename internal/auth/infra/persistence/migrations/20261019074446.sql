@@ -0,0 +1,17 @@
+-- Create "login_attempts" table
+CREATE TABLE "public"."login_attempts" (
+  "id" uuid NOT NULL,
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "deleted_at" timestamptz NULL,
+  "description" text NULL,
+  "key" text NULL,
+  "failures" bigint NULL,
+  "last_failure_at" timestamptz NULL,
+  "locked_until" timestamptz NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_login_attempts_deleted_at" to table: "login_attempts"
+CREATE INDEX "idx_login_attempts_deleted_at" ON "public"."login_attempts" ("deleted_at");
+-- Create index "idx_login_attempts_key" to table: "login_attempts"
+CREATE UNIQUE INDEX "idx_login_attempts_key" ON "public"."login_attempts" ("key");
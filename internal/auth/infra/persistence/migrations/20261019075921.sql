@@ -0,0 +1,18 @@
+-- Modify "users" table
+ALTER TABLE "public"."users" ADD COLUMN "totp_secret" bytea NULL, ADD COLUMN "mfa_enabled" boolean NULL, ADD COLUMN "totp_last_step" bigint NULL;
+-- Create "recovery_codes" table
+CREATE TABLE "public"."recovery_codes" (
+  "id" uuid NOT NULL,
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "deleted_at" timestamptz NULL,
+  "description" text NULL,
+  "user_id" uuid NULL,
+  "code_hash" text NULL,
+  "used_at" timestamptz NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_recovery_codes_deleted_at" to table: "recovery_codes"
+CREATE INDEX "idx_recovery_codes_deleted_at" ON "public"."recovery_codes" ("deleted_at");
+-- Create index "idx_recovery_codes_user_id" to table: "recovery_codes"
+CREATE INDEX "idx_recovery_codes_user_id" ON "public"."recovery_codes" ("user_id");
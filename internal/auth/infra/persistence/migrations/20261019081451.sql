@@ -0,0 +1,19 @@
+-- Create "user_identities" table
+CREATE TABLE "public"."user_identities" (
+  "id" uuid NOT NULL,
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "deleted_at" timestamptz NULL,
+  "description" text NULL,
+  "user_id" uuid NULL,
+  "provider" text NULL,
+  "subject" text NULL,
+  "email" text NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_user_identities_deleted_at" to table: "user_identities"
+CREATE INDEX "idx_user_identities_deleted_at" ON "public"."user_identities" ("deleted_at");
+-- Create index "idx_user_identities_user_id" to table: "user_identities"
+CREATE INDEX "idx_user_identities_user_id" ON "public"."user_identities" ("user_id");
+-- Create index "unique_identity" to table: "user_identities"
+CREATE UNIQUE INDEX "unique_identity" ON "public"."user_identities" ("provider", "subject");
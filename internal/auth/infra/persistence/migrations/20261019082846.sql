@@ -0,0 +1,22 @@
+-- Create "api_keys" table
+CREATE TABLE "public"."api_keys" (
+  "id" uuid NOT NULL,
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "deleted_at" timestamptz NULL,
+  "description" text NULL,
+  "user_id" uuid NULL,
+  "name" text NULL,
+  "prefix" text NULL,
+  "key_hash" text NULL,
+  "scopes" text NULL,
+  "last_used_at" timestamptz NULL,
+  "revoked_at" timestamptz NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_api_keys_deleted_at" to table: "api_keys"
+CREATE INDEX "idx_api_keys_deleted_at" ON "public"."api_keys" ("deleted_at");
+-- Create index "idx_api_keys_prefix" to table: "api_keys"
+CREATE UNIQUE INDEX "idx_api_keys_prefix" ON "public"."api_keys" ("prefix");
+-- Create index "idx_api_keys_user_id" to table: "api_keys"
+CREATE INDEX "idx_api_keys_user_id" ON "public"."api_keys" ("user_id");
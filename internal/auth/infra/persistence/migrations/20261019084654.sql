@@ -0,0 +1,23 @@
+-- Modify "audit_events" table
+ALTER TABLE "public"."audit_events" ADD COLUMN "actor_id" uuid NULL;
+-- Create index "idx_audit_events_actor_id" to table: "audit_events"
+CREATE INDEX "idx_audit_events_actor_id" ON "public"."audit_events" ("actor_id");
+-- Modify "users" table
+ALTER TABLE "public"."users" ADD COLUMN "role" text NULL DEFAULT 'user', ADD COLUMN "disabled" boolean NULL;
+-- Create index "idx_users_role" to table: "users"
+CREATE INDEX "idx_users_role" ON "public"."users" ("role");
+-- Create "roles" table
+CREATE TABLE "public"."roles" (
+  "id" uuid NOT NULL,
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "deleted_at" timestamptz NULL,
+  "description" text NULL,
+  "name" text NULL,
+  "permissions" text NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_roles_deleted_at" to table: "roles"
+CREATE INDEX "idx_roles_deleted_at" ON "public"."roles" ("deleted_at");
+-- Create index "idx_roles_name" to table: "roles"
+CREATE UNIQUE INDEX "idx_roles_name" ON "public"."roles" ("name");
@@ -0,0 +1,98 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"github.com/a1y/doc-formatter/internal/auth/domain/entity"
+	"github.com/a1y/doc-formatter/internal/auth/domain/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var _ repository.PasswordResetRepository = &passwordResetRepository{}
+
+type passwordResetRepository struct {
+	db *gorm.DB
+}
+
+func NewPasswordResetRepository(db *gorm.DB) repository.PasswordResetRepository {
+	return &passwordResetRepository{
+		db: db,
+	}
+}
+
+func (r *passwordResetRepository) Create(ctx context.Context, dataEntity *entity.PasswordReset) error {
+	if err := dataEntity.Validate(); err != nil {
+		return err
+	}
+	var dataModel PasswordResetModel
+	if err := dataModel.FromEntity(dataEntity); err != nil {
+		return err
+	}
+	if err := r.db.WithContext(ctx).Create(&dataModel).Error; err != nil {
+		return err
+	}
+	dataEntity.ID = dataModel.ID
+	dataEntity.CreatedAt = dataModel.CreatedAt
+	return nil
+}
+
+func (r *passwordResetRepository) GetValid(ctx context.Context, tokenHash string, now time.Time) (*entity.PasswordReset, error) {
+	var dataModel PasswordResetModel
+	if err := r.db.WithContext(ctx).
+		Where("token_hash = ? AND used_at IS NULL AND expires_at > ?", tokenHash, now).
+		First(&dataModel).Error; err != nil {
+		return nil, err
+	}
+	return dataModel.ToEntity()
+}
+
+func (r *passwordResetRepository) ResetPassword(ctx context.Context, tokenHash, passwordHash string, now time.Time) (uuid.UUID, error) {
+	var userID uuid.UUID
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var reset PasswordResetModel
+		if err := tx.Where("token_hash = ? AND used_at IS NULL AND expires_at > ?", tokenHash, now).
+			First(&reset).Error; err != nil {
+			return err
+		}
+		// Using the reset only when it is still unused keeps it single-use
+		// when it is presented twice at once.
+		result := tx.Model(&PasswordResetModel{}).
+			Where("id = ? AND used_at IS NULL", reset.ID).
+			Update("used_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		result = tx.Model(&UserModel{}).
+			Where("id = ?", reset.UserID).
+			Updates(map[string]any{
+				"password":        passwordHash,
+				"session_version": gorm.Expr("COALESCE(session_version, 0) + 1"),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		// The other resets of the user were sent for the old password.
+		if err := tx.Model(&PasswordResetModel{}).
+			Where("user_id = ? AND used_at IS NULL", reset.UserID).
+			Update("used_at", now).Error; err != nil {
+			return err
+		}
+
+		userID = reset.UserID
+		return nil
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return userID, nil
+}
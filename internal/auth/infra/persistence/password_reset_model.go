@@ -0,0 +1,40 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/a1y/doc-formatter/internal/auth/domain/entity"
+	"github.com/google/uuid"
+)
+
+type PasswordResetModel struct {
+	BaseModel
+	UserID    uuid.UUID `gorm:"index"`
+	TokenHash string    `gorm:"uniqueIndex"`
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+}
+
+func (r *PasswordResetModel) TableName() string {
+	return "password_resets"
+}
+
+func (r *PasswordResetModel) ToEntity() (*entity.PasswordReset, error) {
+	return &entity.PasswordReset{
+		ID:        r.ID,
+		UserID:    r.UserID,
+		TokenHash: r.TokenHash,
+		ExpiresAt: r.ExpiresAt,
+		UsedAt:    r.UsedAt,
+		CreatedAt: r.CreatedAt,
+	}, nil
+}
+
+func (r *PasswordResetModel) FromEntity(e *entity.PasswordReset) error {
+	r.ID = e.ID
+	r.UserID = e.UserID
+	r.TokenHash = e.TokenHash
+	r.ExpiresAt = e.ExpiresAt
+	r.UsedAt = e.UsedAt
+	return nil
+}
@@ -0,0 +1,129 @@
+package persistence
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/a1y/doc-formatter/internal/auth/domain/entity"
+	testpersistence "github.com/a1y/doc-formatter/pkg/persistence"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestPasswordResetRepository_Create(t *testing.T) {
+	db, mock, err := testpersistence.GetMockDB()
+	assert.NoError(t, err)
+
+	repo := NewPasswordResetRepository(db)
+	ctx := context.Background()
+
+	reset := &entity.PasswordReset{
+		UserID:    uuid.New(),
+		TokenHash: "hash",
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "password_resets"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), reset.UserID, reset.TokenHash, reset.ExpiresAt, nil).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+	mock.ExpectClose()
+
+	err = repo.Create(ctx, reset)
+	assert.NoError(t, err)
+	assert.NotEqual(t, uuid.Nil, reset.ID)
+
+	assert.Error(t, repo.Create(ctx, &entity.PasswordReset{TokenHash: "hash"}))
+
+	testpersistence.CloseDB(t, db)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPasswordResetRepository_GetValid(t *testing.T) {
+	db, mock, err := testpersistence.GetMockDB()
+	assert.NoError(t, err)
+
+	repo := NewPasswordResetRepository(db)
+	ctx := context.Background()
+	now := time.Now()
+	resetID, userID := uuid.New(), uuid.New()
+	query := regexp.QuoteMeta(`SELECT * FROM "password_resets" WHERE (token_hash = $1 AND used_at IS NULL AND expires_at > $2) AND "password_resets"."deleted_at" IS NULL ORDER BY "password_resets"."id" LIMIT $3`)
+
+	mock.ExpectQuery(query).
+		WithArgs("hash", now, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "token_hash"}).AddRow(resetID, userID, "hash"))
+
+	reset, err := repo.GetValid(ctx, "hash", now)
+	assert.NoError(t, err)
+	assert.Equal(t, resetID, reset.ID)
+	assert.Equal(t, userID, reset.UserID)
+
+	mock.ExpectQuery(query).
+		WithArgs("used", now, 1).
+		WillReturnError(gorm.ErrRecordNotFound)
+
+	_, err = repo.GetValid(ctx, "used", now)
+	assert.Equal(t, gorm.ErrRecordNotFound, err)
+
+	mock.ExpectClose()
+	testpersistence.CloseDB(t, db)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPasswordResetRepository_ResetPassword(t *testing.T) {
+	db, mock, err := testpersistence.GetMockDB()
+	assert.NoError(t, err)
+
+	repo := NewPasswordResetRepository(db)
+	ctx := context.Background()
+	now := time.Now()
+	resetID, userID := uuid.New(), uuid.New()
+	selectQuery := regexp.QuoteMeta(`SELECT * FROM "password_resets" WHERE (token_hash = $1 AND used_at IS NULL AND expires_at > $2) AND "password_resets"."deleted_at" IS NULL ORDER BY "password_resets"."id" LIMIT $3`)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(selectQuery).
+		WithArgs("hash", now, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "token_hash"}).AddRow(resetID, userID, "hash"))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "password_resets" SET "used_at"=$1,"updated_at"=$2 WHERE (id = $3 AND used_at IS NULL) AND "password_resets"."deleted_at" IS NULL`)).
+		WithArgs(now, sqlmock.AnyArg(), resetID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "users" SET "password"=$1,"session_version"=COALESCE(session_version, 0) + 1,"updated_at"=$2 WHERE id = $3 AND "users"."deleted_at" IS NULL`)).
+		WithArgs("new-hash", sqlmock.AnyArg(), userID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "password_resets" SET "used_at"=$1,"updated_at"=$2 WHERE (user_id = $3 AND used_at IS NULL) AND "password_resets"."deleted_at" IS NULL`)).
+		WithArgs(now, sqlmock.AnyArg(), userID).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	gotUserID, err := repo.ResetPassword(ctx, "hash", "new-hash", now)
+	assert.NoError(t, err)
+	assert.Equal(t, userID, gotUserID)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(selectQuery).
+		WithArgs("used", now, 1).
+		WillReturnError(gorm.ErrRecordNotFound)
+	mock.ExpectRollback()
+
+	_, err = repo.ResetPassword(ctx, "used", "new-hash", now)
+	assert.Equal(t, gorm.ErrRecordNotFound, err)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(selectQuery).
+		WithArgs("raced", now, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "token_hash"}).AddRow(resetID, userID, "raced"))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "password_resets" SET "used_at"=$1`)).
+		WithArgs(now, sqlmock.AnyArg(), resetID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	_, err = repo.ResetPassword(ctx, "raced", "new-hash", now)
+	assert.Equal(t, gorm.ErrRecordNotFound, err)
+
+	mock.ExpectClose()
+	testpersistence.CloseDB(t, db)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,20 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RecoveryCodeModel is a single-use code that stands in for a TOTP code when
+// its user has lost their authenticator. Only a hash of the code is stored.
+type RecoveryCodeModel struct {
+	BaseModel
+	UserID   uuid.UUID `gorm:"index"`
+	CodeHash string
+	UsedAt   *time.Time
+}
+
+func (c *RecoveryCodeModel) TableName() string {
+	return "recovery_codes"
+}
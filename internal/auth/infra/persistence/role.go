@@ -0,0 +1,61 @@
+package persistence
+
+import (
+	"context"
+
+	"github.com/a1y/doc-formatter/internal/auth/domain/entity"
+	"github.com/a1y/doc-formatter/internal/auth/domain/repository"
+	"gorm.io/gorm"
+)
+
+var _ repository.RoleRepository = &roleRepository{}
+
+type roleRepository struct {
+	db *gorm.DB
+}
+
+func NewRoleRepository(db *gorm.DB) repository.RoleRepository {
+	return &roleRepository{
+		db: db,
+	}
+}
+
+func (r *roleRepository) Create(ctx context.Context, dataEntity *entity.Role) error {
+	if err := dataEntity.Validate(); err != nil {
+		return err
+	}
+	var dataModel RoleModel
+	if err := dataModel.FromEntity(dataEntity); err != nil {
+		return err
+	}
+	if err := r.db.WithContext(ctx).Create(&dataModel).Error; err != nil {
+		return err
+	}
+	dataEntity.ID = dataModel.ID
+	dataEntity.CreatedAt = dataModel.CreatedAt
+	return nil
+}
+
+func (r *roleRepository) GetByName(ctx context.Context, name string) (*entity.Role, error) {
+	var dataModel RoleModel
+	if err := r.db.WithContext(ctx).Where("name = ?", name).First(&dataModel).Error; err != nil {
+		return nil, err
+	}
+	return dataModel.ToEntity()
+}
+
+func (r *roleRepository) List(ctx context.Context) ([]*entity.Role, error) {
+	var models []RoleModel
+	if err := r.db.WithContext(ctx).Order("name").Find(&models).Error; err != nil {
+		return nil, err
+	}
+	entities := make([]*entity.Role, len(models))
+	for i, model := range models {
+		entity, err := model.ToEntity()
+		if err != nil {
+			return nil, err
+		}
+		entities[i] = entity
+	}
+	return entities, nil
+}
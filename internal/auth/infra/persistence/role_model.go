@@ -0,0 +1,37 @@
+package persistence
+
+import (
+	"github.com/a1y/doc-formatter/internal/auth/domain/entity"
+)
+
+type RoleModel struct {
+	BaseModel
+	Name        string `gorm:"uniqueIndex"`
+	Permissions MultiString
+}
+
+func (r *RoleModel) TableName() string {
+	return "roles"
+}
+
+func (r *RoleModel) ToEntity() (*entity.Role, error) {
+	return &entity.Role{
+		ID:          r.ID,
+		Name:        r.Name,
+		Description: r.Description,
+		Permissions: r.Permissions,
+		CreatedAt:   r.CreatedAt,
+	}, nil
+}
+
+func (r *RoleModel) FromEntity(e *entity.Role) error {
+	r.ID = e.ID
+	r.Name = e.Name
+	r.Description = e.Description
+	// Roles without permissions are stored as NULL, which scans back to no
+	// permissions where an empty string would scan to one empty permission.
+	if len(e.Permissions) > 0 {
+		r.Permissions = e.Permissions
+	}
+	return nil
+}
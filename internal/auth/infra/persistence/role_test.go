@@ -0,0 +1,99 @@
+package persistence
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/a1y/doc-formatter/internal/auth/domain/entity"
+	testpersistence "github.com/a1y/doc-formatter/pkg/persistence"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestRoleRepository_Create(t *testing.T) {
+	db, mock, err := testpersistence.GetMockDB()
+	assert.NoError(t, err)
+
+	repo := NewRoleRepository(db)
+	ctx := context.Background()
+	insert := regexp.QuoteMeta(`INSERT INTO "roles"`)
+
+	support := &entity.Role{Name: "support", Description: "Helps users", Permissions: []string{"users:read", "users:impersonate"}}
+	mock.ExpectQuery(insert).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), "Helps users", "support", "users:read,users:impersonate").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+	assert.NoError(t, repo.Create(ctx, support))
+	assert.NotEqual(t, uuid.Nil, support.ID)
+
+	// Roles without permissions are stored without any.
+	mock.ExpectQuery(insert).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), "", "viewer", nil).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+	assert.NoError(t, repo.Create(ctx, &entity.Role{Name: "viewer", Permissions: []string{}}))
+
+	assert.Error(t, repo.Create(ctx, &entity.Role{Name: "Support Team"}))
+	assert.Error(t, repo.Create(ctx, &entity.Role{Name: "root", Permissions: []string{"everything"}}))
+
+	mock.ExpectClose()
+	testpersistence.CloseDB(t, db)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRoleRepository_GetByName(t *testing.T) {
+	db, mock, err := testpersistence.GetMockDB()
+	assert.NoError(t, err)
+
+	repo := NewRoleRepository(db)
+	ctx := context.Background()
+	id := uuid.New()
+	query := regexp.QuoteMeta(`SELECT * FROM "roles" WHERE name = $1 AND "roles"."deleted_at" IS NULL ORDER BY "roles"."id" LIMIT $2`)
+
+	mock.ExpectQuery(query).
+		WithArgs("support", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "description", "permissions"}).
+			AddRow(id.String(), "support", "Helps users", "users:read,users:impersonate"))
+	role, err := repo.GetByName(ctx, "support")
+	assert.NoError(t, err)
+	assert.Equal(t, &entity.Role{
+		ID:          id,
+		Name:        "support",
+		Description: "Helps users",
+		Permissions: []string{"users:read", "users:impersonate"},
+	}, role)
+
+	mock.ExpectQuery(query).
+		WithArgs("missing", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	_, err = repo.GetByName(ctx, "missing")
+	assert.Equal(t, gorm.ErrRecordNotFound, err)
+
+	mock.ExpectClose()
+	testpersistence.CloseDB(t, db)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRoleRepository_List(t *testing.T) {
+	db, mock, err := testpersistence.GetMockDB()
+	assert.NoError(t, err)
+
+	repo := NewRoleRepository(db)
+	ctx := context.Background()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "roles" WHERE "roles"."deleted_at" IS NULL ORDER BY name`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "permissions"}).
+			AddRow(uuid.New().String(), "auditor", "users:read").
+			AddRow(uuid.New().String(), "viewer", nil))
+	roles, err := repo.List(ctx)
+	assert.NoError(t, err)
+	if assert.Len(t, roles, 2) {
+		assert.Equal(t, []string{"users:read"}, roles[0].Permissions)
+		assert.Empty(t, roles[1].Permissions)
+	}
+
+	mock.ExpectClose()
+	testpersistence.CloseDB(t, db)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -2,9 +2,11 @@ package persistence
 
 import (
 	"context"
+	"strings"
 
 	"github.com/a1y/doc-formatter/internal/auth/domain/entity"
 	"github.com/a1y/doc-formatter/internal/auth/domain/repository"
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
@@ -28,6 +30,14 @@ func (r *userRepository) GetByEmail(ctx context.Context, email string) (*entity.
 	return model.ToEntity()
 }
 
+func (r *userRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.User, error) {
+	var model UserModel
+	if err := r.db.Where("id = ?", id).First(&model).Error; err != nil {
+		return nil, err
+	}
+	return model.ToEntity()
+}
+
 func (r *userRepository) Create(ctx context.Context, dataEntity *entity.User) error {
 	err := dataEntity.Validate()
 	if err != nil {
@@ -48,3 +58,134 @@ func (r *userRepository) Create(ctx context.Context, dataEntity *entity.User) er
 		return nil
 	})
 }
+
+func (r *userRepository) MarkVerified(ctx context.Context, id uuid.UUID, email string) error {
+	result := r.db.WithContext(ctx).Model(&UserModel{}).
+		Where("id = ? AND email = ?", id, email).
+		Update("is_verified", true)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *userRepository) UpdatePassword(ctx context.Context, id uuid.UUID, passwordHash string) error {
+	result := r.db.WithContext(ctx).Model(&UserModel{}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"password":        passwordHash,
+			"session_version": gorm.Expr("COALESCE(session_version, 0) + 1"),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *userRepository) SetPendingEmail(ctx context.Context, id uuid.UUID, email string) error {
+	result := r.db.WithContext(ctx).Model(&UserModel{}).
+		Where("id = ?", id).
+		Update("pending_email", email)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *userRepository) ConfirmEmail(ctx context.Context, id uuid.UUID, email string) error {
+	result := r.db.WithContext(ctx).Model(&UserModel{}).
+		Where("id = ? AND pending_email = ?", id, email).
+		Updates(map[string]any{
+			"email":         email,
+			"pending_email": "",
+			"is_verified":   true,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *userRepository) RehashPassword(ctx context.Context, id uuid.UUID, oldHash, newHash string) error {
+	result := r.db.WithContext(ctx).Model(&UserModel{}).
+		Where("id = ? AND password = ?", id, oldHash).
+		Update("password", newHash)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *userRepository) List(ctx context.Context, filter entity.UserFilter) ([]*entity.User, error) {
+	query := r.db.WithContext(ctx).Model(&UserModel{})
+	if filter.Query != "" {
+		query = query.Where(`email ILIKE ? ESCAPE '\'`, "%"+likeEscaper.Replace(filter.Query)+"%")
+	}
+	if filter.Role != "" {
+		query = query.Where("role = ?", filter.Role)
+	}
+	if filter.AfterEmail != "" {
+		query = query.Where("email > ?", filter.AfterEmail)
+	}
+	var models []UserModel
+	if err := query.Order("email").Limit(filter.Limit).Find(&models).Error; err != nil {
+		return nil, err
+	}
+	entities := make([]*entity.User, len(models))
+	for i, model := range models {
+		entity, err := model.ToEntity()
+		if err != nil {
+			return nil, err
+		}
+		entities[i] = entity
+	}
+	return entities, nil
+}
+
+func (r *userRepository) SetDisabled(ctx context.Context, id uuid.UUID, disabled bool) error {
+	updates := map[string]any{"disabled": disabled}
+	if disabled {
+		updates["session_version"] = gorm.Expr("COALESCE(session_version, 0) + 1")
+	}
+	result := r.db.WithContext(ctx).Model(&UserModel{}).
+		Where("id = ?", id).
+		Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *userRepository) SetRole(ctx context.Context, id uuid.UUID, role string) error {
+	result := r.db.WithContext(ctx).Model(&UserModel{}).
+		Where("id = ?", id).
+		Update("role", role)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// likeEscaper escapes the wildcards of a LIKE pattern.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
@@ -11,6 +11,20 @@ type UserModel struct {
 	Email      string `gorm:"index:unique_user,unique"`
 	Password   string `json:"-"`
 	IsVerified bool
+	// SessionVersion is bumped to revoke every access token issued before.
+	SessionVersion int
+	// PendingEmail is the unverified address the user asked to change to.
+	PendingEmail string
+	// TOTPSecret is the sealed TOTP secret of the user.
+	TOTPSecret []byte `json:"-"`
+	// MFAEnabled requires a TOTP or recovery code to log in.
+	MFAEnabled bool
+	// TOTPLastStep is the time step of the last TOTP code used.
+	TOTPLastStep int64
+	// Role is the name of the role of the user.
+	Role string `gorm:"index;default:user"`
+	// Disabled users cannot log in or use their tokens and API keys.
+	Disabled bool
 }
 
 func (u *UserModel) TableName() string {
@@ -19,10 +33,17 @@ func (u *UserModel) TableName() string {
 
 func (u *UserModel) ToEntity() (*entity.User, error) {
 	return &entity.User{
-		ID:         u.ID,
-		Email:      u.Email,
-		Password:   u.Password,
-		IsVerified: u.IsVerified,
+		ID:             u.ID,
+		Email:          u.Email,
+		Password:       u.Password,
+		IsVerified:     u.IsVerified,
+		SessionVersion: u.SessionVersion,
+		PendingEmail:   u.PendingEmail,
+		TOTPSecret:     u.TOTPSecret,
+		MFAEnabled:     u.MFAEnabled,
+		TOTPLastStep:   u.TOTPLastStep,
+		Role:           u.Role,
+		Disabled:       u.Disabled,
 	}, nil
 }
 
@@ -31,5 +52,15 @@ func (u *UserModel) FromEntity(e *entity.User) error {
 	u.Email = e.Email
 	u.Password = e.Password
 	u.IsVerified = e.IsVerified
+	u.SessionVersion = e.SessionVersion
+	u.PendingEmail = e.PendingEmail
+	u.TOTPSecret = e.TOTPSecret
+	u.MFAEnabled = e.MFAEnabled
+	u.TOTPLastStep = e.TOTPLastStep
+	u.Role = e.Role
+	if u.Role == "" {
+		u.Role = entity.RoleUser
+	}
+	u.Disabled = e.Disabled
 	return nil
 }
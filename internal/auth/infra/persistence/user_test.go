@@ -29,7 +29,7 @@ func TestUserRepository_Create(t *testing.T) {
 
 	mock.ExpectBegin()
 	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "users"`)).
-		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), user.Email, user.Password, user.IsVerified).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), user.Email, user.Password, user.IsVerified, user.SessionVersion, user.PendingEmail, user.TOTPSecret, user.MFAEnabled, user.TOTPLastStep, entity.RoleUser, user.Disabled).
 		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(user.ID))
 	mock.ExpectCommit()
 	mock.ExpectClose()
@@ -82,3 +82,248 @@ func TestUserRepository_GetByEmail(t *testing.T) {
 
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestUserRepository_GetByID(t *testing.T) {
+	db, mock, err := testpersistence.GetMockDB()
+	assert.NoError(t, err)
+
+	repo := NewUserRepository(db)
+	ctx := context.Background()
+
+	id := uuid.New()
+	rows := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "deleted_at", "description", "name", "username", "email", "password", "is_verified"}).
+		AddRow(id.String(), nil, nil, nil, "", "", "", "existing@example.com", "hashedpassword", false)
+	query := regexp.QuoteMeta(`SELECT * FROM "users" WHERE id = $1 AND "users"."deleted_at" IS NULL ORDER BY "users"."id" LIMIT $2`)
+	mock.ExpectQuery(query).WithArgs(id, 1).WillReturnRows(rows)
+
+	foundUser, err := repo.GetByID(ctx, id)
+	assert.NoError(t, err)
+	assert.Equal(t, id, foundUser.ID)
+	assert.Equal(t, "existing@example.com", foundUser.Email)
+
+	missing := uuid.New()
+	mock.ExpectQuery(query).WithArgs(missing, 1).WillReturnError(gorm.ErrRecordNotFound)
+
+	_, err = repo.GetByID(ctx, missing)
+	assert.Equal(t, gorm.ErrRecordNotFound, err)
+
+	mock.ExpectClose()
+	testpersistence.CloseDB(t, db)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepository_MarkVerified(t *testing.T) {
+	db, mock, err := testpersistence.GetMockDB()
+	assert.NoError(t, err)
+
+	repo := NewUserRepository(db)
+	ctx := context.Background()
+
+	id := uuid.New()
+	query := regexp.QuoteMeta(`UPDATE "users" SET "is_verified"=$1,"updated_at"=$2 WHERE (id = $3 AND email = $4) AND "users"."deleted_at" IS NULL`)
+	mock.ExpectExec(query).
+		WithArgs(true, sqlmock.AnyArg(), id, "user@example.com").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.MarkVerified(ctx, id, "user@example.com")
+	assert.NoError(t, err)
+
+	mock.ExpectExec(query).
+		WithArgs(true, sqlmock.AnyArg(), id, "old@example.com").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = repo.MarkVerified(ctx, id, "old@example.com")
+	assert.Equal(t, gorm.ErrRecordNotFound, err)
+
+	mock.ExpectClose()
+	testpersistence.CloseDB(t, db)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepository_UpdatePassword(t *testing.T) {
+	db, mock, err := testpersistence.GetMockDB()
+	assert.NoError(t, err)
+
+	repo := NewUserRepository(db)
+	ctx := context.Background()
+
+	id := uuid.New()
+	query := regexp.QuoteMeta(`UPDATE "users" SET "password"=$1,"session_version"=COALESCE(session_version, 0) + 1,"updated_at"=$2 WHERE id = $3 AND "users"."deleted_at" IS NULL`)
+	mock.ExpectExec(query).
+		WithArgs("new-hash", sqlmock.AnyArg(), id).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.UpdatePassword(ctx, id, "new-hash")
+	assert.NoError(t, err)
+
+	mock.ExpectExec(query).
+		WithArgs("new-hash", sqlmock.AnyArg(), id).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = repo.UpdatePassword(ctx, id, "new-hash")
+	assert.Equal(t, gorm.ErrRecordNotFound, err)
+
+	mock.ExpectClose()
+	testpersistence.CloseDB(t, db)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepository_ChangeEmail(t *testing.T) {
+	db, mock, err := testpersistence.GetMockDB()
+	assert.NoError(t, err)
+
+	repo := NewUserRepository(db)
+	ctx := context.Background()
+
+	id := uuid.New()
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "users" SET "pending_email"=$1,"updated_at"=$2 WHERE id = $3 AND "users"."deleted_at" IS NULL`)).
+		WithArgs("new@example.com", sqlmock.AnyArg(), id).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.SetPendingEmail(ctx, id, "new@example.com")
+	assert.NoError(t, err)
+
+	query := regexp.QuoteMeta(`UPDATE "users" SET "email"=$1,"is_verified"=$2,"pending_email"=$3,"updated_at"=$4 WHERE (id = $5 AND pending_email = $6) AND "users"."deleted_at" IS NULL`)
+	mock.ExpectExec(query).
+		WithArgs("new@example.com", true, "", sqlmock.AnyArg(), id, "new@example.com").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.ConfirmEmail(ctx, id, "new@example.com")
+	assert.NoError(t, err)
+
+	mock.ExpectExec(query).
+		WithArgs("stale@example.com", true, "", sqlmock.AnyArg(), id, "stale@example.com").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = repo.ConfirmEmail(ctx, id, "stale@example.com")
+	assert.Equal(t, gorm.ErrRecordNotFound, err)
+
+	mock.ExpectClose()
+	testpersistence.CloseDB(t, db)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepository_RehashPassword(t *testing.T) {
+	db, mock, err := testpersistence.GetMockDB()
+	assert.NoError(t, err)
+
+	repo := NewUserRepository(db)
+	ctx := context.Background()
+
+	id := uuid.New()
+	query := regexp.QuoteMeta(`UPDATE "users" SET "password"=$1,"updated_at"=$2 WHERE (id = $3 AND password = $4) AND "users"."deleted_at" IS NULL`)
+	mock.ExpectExec(query).
+		WithArgs("new-hash", sqlmock.AnyArg(), id, "old-hash").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.RehashPassword(ctx, id, "old-hash", "new-hash")
+	assert.NoError(t, err)
+
+	// The password was changed in the meantime.
+	mock.ExpectExec(query).
+		WithArgs("new-hash", sqlmock.AnyArg(), id, "old-hash").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = repo.RehashPassword(ctx, id, "old-hash", "new-hash")
+	assert.Equal(t, gorm.ErrRecordNotFound, err)
+
+	mock.ExpectClose()
+	testpersistence.CloseDB(t, db)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepository_List(t *testing.T) {
+	db, mock, err := testpersistence.GetMockDB()
+	assert.NoError(t, err)
+
+	repo := NewUserRepository(db)
+	ctx := context.Background()
+	id := uuid.New()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE email ILIKE $1 ESCAPE '\' AND role = $2 AND email > $3 AND "users"."deleted_at" IS NULL ORDER BY email LIMIT $4`)).
+		WithArgs(`%50\%\_off%`, "admin", "a@example.com", 3).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email", "role", "disabled"}).
+			AddRow(id.String(), "b@example.com", "admin", true))
+
+	users, err := repo.List(ctx, entity.UserFilter{Query: "50%_off", Role: "admin", AfterEmail: "a@example.com", Limit: 3})
+	assert.NoError(t, err)
+	if assert.Len(t, users, 1) {
+		assert.Equal(t, id, users[0].ID)
+		assert.Equal(t, "admin", users[0].Role)
+		assert.True(t, users[0].Disabled)
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE "users"."deleted_at" IS NULL ORDER BY email LIMIT $1`)).
+		WithArgs(51).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	users, err = repo.List(ctx, entity.UserFilter{Limit: 51})
+	assert.NoError(t, err)
+	assert.Empty(t, users)
+
+	mock.ExpectClose()
+	testpersistence.CloseDB(t, db)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepository_SetDisabled(t *testing.T) {
+	db, mock, err := testpersistence.GetMockDB()
+	assert.NoError(t, err)
+
+	repo := NewUserRepository(db)
+	ctx := context.Background()
+	id := uuid.New()
+
+	// Disabling revokes the sessions of the user.
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "users" SET "disabled"=$1,"session_version"=COALESCE(session_version, 0) + 1,"updated_at"=$2 WHERE id = $3 AND "users"."deleted_at" IS NULL`)).
+		WithArgs(true, sqlmock.AnyArg(), id).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, repo.SetDisabled(ctx, id, true))
+
+	enable := regexp.QuoteMeta(`UPDATE "users" SET "disabled"=$1,"updated_at"=$2 WHERE id = $3 AND "users"."deleted_at" IS NULL`)
+	mock.ExpectExec(enable).
+		WithArgs(false, sqlmock.AnyArg(), id).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, repo.SetDisabled(ctx, id, false))
+
+	mock.ExpectExec(enable).
+		WithArgs(false, sqlmock.AnyArg(), id).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	assert.Equal(t, gorm.ErrRecordNotFound, repo.SetDisabled(ctx, id, false))
+
+	mock.ExpectClose()
+	testpersistence.CloseDB(t, db)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepository_SetRole(t *testing.T) {
+	db, mock, err := testpersistence.GetMockDB()
+	assert.NoError(t, err)
+
+	repo := NewUserRepository(db)
+	ctx := context.Background()
+	id := uuid.New()
+	query := regexp.QuoteMeta(`UPDATE "users" SET "role"=$1,"updated_at"=$2 WHERE id = $3 AND "users"."deleted_at" IS NULL`)
+
+	mock.ExpectExec(query).
+		WithArgs("admin", sqlmock.AnyArg(), id).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, repo.SetRole(ctx, id, "admin"))
+
+	mock.ExpectExec(query).
+		WithArgs("admin", sqlmock.AnyArg(), id).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	assert.Equal(t, gorm.ErrRecordNotFound, repo.SetRole(ctx, id, "admin"))
+
+	mock.ExpectClose()
+	testpersistence.CloseDB(t, db)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
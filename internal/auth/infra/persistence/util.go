@@ -10,7 +10,7 @@ import (
 )
 
 func AutoMigrate(db *gorm.DB) error {
-	if err := db.AutoMigrate(&UserModel{}); err != nil {
+	if err := db.AutoMigrate(&UserModel{}, &PasswordResetModel{}, &AuditEventModel{}, &LoginAttemptModel{}, &RecoveryCodeModel{}, &IdentityModel{}, &APIKeyModel{}, &RoleModel{}); err != nil {
 		return err
 	}
 	return nil
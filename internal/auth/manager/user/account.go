@@ -0,0 +1,211 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/a1y/doc-formatter/internal/auth/domain/constant"
+	"github.com/a1y/doc-formatter/internal/auth/domain/entity"
+	jwtutil "github.com/a1y/doc-formatter/internal/auth/util/jwt"
+	"github.com/a1y/doc-formatter/internal/auth/util/mailer"
+	"github.com/a1y/doc-formatter/pkg/credentials"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// ChangePassword sets a new password for a user who knows their current one.
+// Every session and API key of the user is revoked, so it returns a new
+// access token for the session the password was changed from.
+func (u *UserManager) ChangePassword(ctx context.Context, userID uuid.UUID, currentPassword, newPassword string) (*string, int64, error) {
+	if newPassword == "" {
+		return nil, 0, constant.ErrPasswordRequired
+	}
+	user, err := u.checkPassword(ctx, userID, currentPassword)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := u.checkPasswordPolicy(newPassword, user.Email); err != nil {
+		return nil, 0, err
+	}
+
+	hashedPassword, err := u.passwordHasher().HashPassword(newPassword, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	err = u.userRepo.UpdatePassword(ctx, user.ID, hashedPassword)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, 0, constant.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := u.revokeAPIKeys(ctx, user.ID); err != nil {
+		return nil, 0, err
+	}
+	u.recordAudit(ctx, user.ID, entity.AuditPasswordChanged, "")
+
+	// Changing the password bumped the session version.
+	user.SessionVersion++
+	return u.accessToken(user)
+}
+
+// ChangeEmail starts changing the email address of a user who knows their
+// password. The new address is emailed a token and only replaces the current
+// one once that token is verified.
+func (u *UserManager) ChangeEmail(ctx context.Context, userID uuid.UUID, currentPassword, newEmail string) error {
+	if newEmail == "" {
+		return constant.ErrEmailRequired
+	}
+	if u.mailer == nil {
+		return constant.ErrMailUnavailable
+	}
+	user, err := u.checkPassword(ctx, userID, currentPassword)
+	if err != nil {
+		return err
+	}
+	if strings.EqualFold(user.Email, newEmail) {
+		return constant.ErrEmailUnchanged
+	}
+	if err := u.checkEmailAvailable(ctx, newEmail); err != nil {
+		return err
+	}
+
+	err = u.userRepo.SetPendingEmail(ctx, user.ID, newEmail)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return constant.ErrUserNotFound
+	}
+	if err != nil {
+		return err
+	}
+	u.recordAudit(ctx, user.ID, entity.AuditEmailChangeRequested, newEmail)
+
+	ttl := u.verification.TokenTTL
+	if ttl <= 0 {
+		ttl = DefaultVerificationTokenTTL
+	}
+	token, _, err := u.jwtClaims.GeneratePurposeToken(user.ID, newEmail, jwtutil.PurposeChangeEmail, ttl)
+	if err != nil {
+		return err
+	}
+	body, err := emailChangeBody(u.verification.URL, token, ttl)
+	if err != nil {
+		return err
+	}
+	return u.mailer.Send(ctx, &mailer.Message{
+		To:      newEmail,
+		Subject: "Confirm your new email address",
+		Body:    body,
+	})
+}
+
+// confirmEmailChange makes email the verified address of the user, as long
+// as it is the last address they asked to change to.
+func (u *UserManager) confirmEmailChange(ctx context.Context, userID uuid.UUID, email string) (*entity.User, error) {
+	user, err := u.userRepo.GetByID(ctx, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, constant.ErrInvalidVerificationToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	if user.PendingEmail != email {
+		return nil, constant.ErrInvalidVerificationToken
+	}
+	// The address may have been taken since the change was asked for.
+	if err := u.checkEmailAvailable(ctx, email); err != nil {
+		return nil, err
+	}
+
+	err = u.userRepo.ConfirmEmail(ctx, user.ID, email)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, constant.ErrInvalidVerificationToken
+	}
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return nil, constant.ErrEmailExists
+	}
+	if err != nil {
+		return nil, err
+	}
+	u.recordAudit(ctx, user.ID, entity.AuditEmailChanged, fmt.Sprintf("%s -> %s", user.Email, email))
+
+	user.Email = email
+	user.PendingEmail = ""
+	user.IsVerified = true
+	return user, nil
+}
+
+// checkPassword returns the user with userID when password is their current
+// password.
+func (u *UserManager) checkPassword(ctx context.Context, userID uuid.UUID, password string) (*entity.User, error) {
+	user, err := u.userRepo.GetByID(ctx, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, constant.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	ok, err := credentials.Compare(password, user.Password)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, constant.ErrIncorrectPassword
+	}
+	return user, nil
+}
+
+func (u *UserManager) checkEmailAvailable(ctx context.Context, email string) error {
+	_, err := u.userRepo.GetByEmail(ctx, email)
+	if err == nil {
+		return constant.ErrEmailExists
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	return err
+}
+
+// recordAudit records action on the account of userID. Failing to record it
+// does not fail the action, which has already been taken.
+func (u *UserManager) recordAudit(ctx context.Context, userID uuid.UUID, action, detail string) {
+	u.recordAuditBy(ctx, uuid.Nil, userID, action, detail)
+}
+
+// recordAuditBy records action taken by the administrator with actorID on
+// the user.
+func (u *UserManager) recordAuditBy(ctx context.Context, actorID, userID uuid.UUID, action, detail string) {
+	if u.auditRepo == nil {
+		return
+	}
+	event := &entity.AuditEvent{
+		UserID:  userID,
+		ActorID: actorID,
+		Action:  action,
+		Detail:  detail,
+	}
+	if err := u.auditRepo.Create(ctx, event); err != nil {
+		logrus.Warnf("Failed to record %s for user %s: %v", action, userID, err)
+	}
+}
+
+func emailChangeBody(link, token string, ttl time.Duration) (string, error) {
+	var b strings.Builder
+	b.WriteString("Someone asked to change the email address of a Doc Formatter account to this address.\n\n")
+	if link == "" {
+		b.WriteString("To confirm the change, use this verification token:\n\n")
+		b.WriteString(token + "\n\n")
+	} else {
+		tokenURL, err := tokenLink(link, token)
+		if err != nil {
+			return "", fmt.Errorf("invalid verification url: %w", err)
+		}
+		b.WriteString("To confirm the change, open this link:\n\n")
+		b.WriteString(tokenURL + "\n\n")
+	}
+	fmt.Fprintf(&b, "It expires in %s. Until then the account keeps its current address. If you did not ask for it, you can ignore this email.\n", formatTTL(ttl))
+	return b.String(), nil
+}
@@ -0,0 +1,198 @@
+package user
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/a1y/doc-formatter/internal/auth/domain/constant"
+	"github.com/a1y/doc-formatter/internal/auth/domain/entity"
+	jwtutil "github.com/a1y/doc-formatter/internal/auth/util/jwt"
+	"github.com/a1y/doc-formatter/internal/auth/util/passwordpolicy"
+	"github.com/a1y/doc-formatter/pkg/credentials"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type MockAuditRepository struct {
+	mock.Mock
+}
+
+func (m *MockAuditRepository) Create(ctx context.Context, e *entity.AuditEvent) error {
+	args := m.Called(ctx, e)
+	return args.Error(0)
+}
+
+// auditAction matches audit events recording action.
+func auditAction(action string) any {
+	return mock.MatchedBy(func(e *entity.AuditEvent) bool { return e.Action == action })
+}
+
+func newAccountManager(t *testing.T, m *recordingMailer) (*UserManager, *MockUserRepository, *MockAuditRepository) {
+	userManager, mockRepo := newVerificationManager(t, m, false)
+	if m == nil {
+		userManager.SetMailer(nil)
+	}
+	auditRepo := new(MockAuditRepository)
+	userManager.SetAuditRepository(auditRepo)
+	return userManager, mockRepo, auditRepo
+}
+
+func hashedUser(t *testing.T, id uuid.UUID, email, password string) *entity.User {
+	hash, err := credentials.NewDefaultArgon2idHash().HashPassword(password, nil)
+	require.NoError(t, err)
+	return &entity.User{ID: id, Email: email, Password: hash, IsVerified: true, SessionVersion: 4}
+}
+
+func TestChangePassword(t *testing.T) {
+	userID := uuid.New()
+	email := "test@example.com"
+
+	t.Run("Success", func(t *testing.T) {
+		userManager, mockRepo, auditRepo := newAccountManager(t, nil)
+		mockRepo.On("GetByID", mock.Anything, userID).Return(hashedUser(t, userID, email, "old-password"), nil)
+		var stored string
+		mockRepo.On("UpdatePassword", mock.Anything, userID, mock.Anything).Run(func(args mock.Arguments) {
+			stored = args.String(2)
+		}).Return(nil)
+		auditRepo.On("Create", mock.Anything, auditAction(entity.AuditPasswordChanged)).Return(nil)
+		keyRepo := new(MockAPIKeyRepository)
+		keyRepo.On("RevokeAllByUser", mock.Anything, userID, mock.Anything).Return(nil).Once()
+		userManager.SetAPIKeyRepository(keyRepo)
+
+		token, exp, err := userManager.ChangePassword(context.Background(), userID, "old-password", "new-password")
+		require.NoError(t, err)
+		keyRepo.AssertExpectations(t)
+		assert.Greater(t, exp, time.Now().Unix())
+
+		ok, err := credentials.Compare("new-password", stored)
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		// The new token carries the session version bumped by the change.
+		gotID, _, sessionVersion, err := userManager.jwtClaims.ParseAccessToken(*token)
+		require.NoError(t, err)
+		assert.Equal(t, userID, gotID)
+		assert.Equal(t, 5, sessionVersion)
+		auditRepo.AssertExpectations(t)
+	})
+
+	t.Run("IncorrectPassword", func(t *testing.T) {
+		userManager, mockRepo, _ := newAccountManager(t, nil)
+		mockRepo.On("GetByID", mock.Anything, userID).Return(hashedUser(t, userID, email, "old-password"), nil)
+
+		_, _, err := userManager.ChangePassword(context.Background(), userID, "wrong-password", "new-password")
+		assert.Equal(t, constant.ErrIncorrectPassword, err)
+		mockRepo.AssertNotCalled(t, "UpdatePassword", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("EmptyPassword", func(t *testing.T) {
+		userManager, _, _ := newAccountManager(t, nil)
+
+		_, _, err := userManager.ChangePassword(context.Background(), userID, "old-password", "")
+		assert.Equal(t, constant.ErrPasswordRequired, err)
+	})
+
+	t.Run("UserNotFound", func(t *testing.T) {
+		userManager, mockRepo, _ := newAccountManager(t, nil)
+		mockRepo.On("GetByID", mock.Anything, userID).Return(nil, gorm.ErrRecordNotFound)
+
+		_, _, err := userManager.ChangePassword(context.Background(), userID, "old-password", "new-password")
+		assert.Equal(t, constant.ErrUserNotFound, err)
+	})
+
+	t.Run("PolicyViolation", func(t *testing.T) {
+		userManager, mockRepo, _ := newAccountManager(t, nil)
+		userManager.SetPasswordPolicy(&passwordpolicy.Policy{Classes: []string{passwordpolicy.ClassDigit}})
+		mockRepo.On("GetByID", mock.Anything, userID).Return(hashedUser(t, userID, email, "old-password"), nil)
+
+		_, _, err := userManager.ChangePassword(context.Background(), userID, "old-password", "new-password")
+		var policyErr *passwordpolicy.Error
+		require.ErrorAs(t, err, &policyErr)
+		assert.Equal(t, passwordpolicy.RuleDigit, policyErr.Violations[0].Rule)
+		mockRepo.AssertNotCalled(t, "UpdatePassword", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestChangeEmail(t *testing.T) {
+	userID := uuid.New()
+	email := "old@example.com"
+	newEmail := "new@example.com"
+
+	t.Run("Confirmed", func(t *testing.T) {
+		m := &recordingMailer{}
+		userManager, mockRepo, auditRepo := newAccountManager(t, m)
+		user := hashedUser(t, userID, email, "password")
+		mockRepo.On("GetByID", mock.Anything, userID).Return(user, nil).Once()
+		mockRepo.On("GetByEmail", mock.Anything, newEmail).Return(nil, gorm.ErrRecordNotFound)
+		mockRepo.On("SetPendingEmail", mock.Anything, userID, newEmail).Return(nil)
+		auditRepo.On("Create", mock.Anything, auditAction(entity.AuditEmailChangeRequested)).Return(nil)
+
+		require.NoError(t, userManager.ChangeEmail(context.Background(), userID, "password", newEmail))
+		require.Len(t, m.messages, 1)
+		assert.Equal(t, newEmail, m.messages[0].To)
+
+		pending := *user
+		pending.PendingEmail = newEmail
+		mockRepo.On("GetByID", mock.Anything, userID).Return(&pending, nil)
+		mockRepo.On("ConfirmEmail", mock.Anything, userID, newEmail).Return(nil)
+		auditRepo.On("Create", mock.Anything, auditAction(entity.AuditEmailChanged)).Return(nil)
+
+		confirmed, err := userManager.VerifyEmail(context.Background(), tokenFrom(t, m.messages[0]))
+		require.NoError(t, err)
+		assert.Equal(t, newEmail, confirmed.Email)
+		assert.Empty(t, confirmed.PendingEmail)
+		assert.True(t, confirmed.IsVerified)
+		auditRepo.AssertExpectations(t)
+	})
+
+	t.Run("SupersededToken", func(t *testing.T) {
+		userManager, mockRepo, _ := newAccountManager(t, &recordingMailer{})
+		token, _, err := userManager.jwtClaims.GeneratePurposeToken(userID, newEmail, jwtutil.PurposeChangeEmail, time.Minute)
+		require.NoError(t, err)
+		user := hashedUser(t, userID, email, "password")
+		user.PendingEmail = "other@example.com"
+		mockRepo.On("GetByID", mock.Anything, userID).Return(user, nil)
+
+		_, err = userManager.VerifyEmail(context.Background(), token)
+		assert.Equal(t, constant.ErrInvalidVerificationToken, err)
+		mockRepo.AssertNotCalled(t, "ConfirmEmail", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Taken", func(t *testing.T) {
+		m := &recordingMailer{}
+		userManager, mockRepo, _ := newAccountManager(t, m)
+		mockRepo.On("GetByID", mock.Anything, userID).Return(hashedUser(t, userID, email, "password"), nil)
+		mockRepo.On("GetByEmail", mock.Anything, newEmail).Return(&entity.User{ID: uuid.New(), Email: newEmail}, nil)
+
+		err := userManager.ChangeEmail(context.Background(), userID, "password", newEmail)
+		assert.Equal(t, constant.ErrEmailExists, err)
+		assert.Empty(t, m.messages)
+	})
+
+	t.Run("Unchanged", func(t *testing.T) {
+		userManager, mockRepo, _ := newAccountManager(t, &recordingMailer{})
+		mockRepo.On("GetByID", mock.Anything, userID).Return(hashedUser(t, userID, email, "password"), nil)
+
+		err := userManager.ChangeEmail(context.Background(), userID, "password", "OLD@example.com")
+		assert.Equal(t, constant.ErrEmailUnchanged, err)
+	})
+
+	t.Run("IncorrectPassword", func(t *testing.T) {
+		userManager, mockRepo, _ := newAccountManager(t, &recordingMailer{})
+		mockRepo.On("GetByID", mock.Anything, userID).Return(hashedUser(t, userID, email, "password"), nil)
+
+		err := userManager.ChangeEmail(context.Background(), userID, "wrong", newEmail)
+		assert.Equal(t, constant.ErrIncorrectPassword, err)
+	})
+
+	t.Run("NoMailer", func(t *testing.T) {
+		userManager, _, _ := newAccountManager(t, nil)
+
+		err := userManager.ChangeEmail(context.Background(), userID, "password", newEmail)
+		assert.Equal(t, constant.ErrMailUnavailable, err)
+	})
+}
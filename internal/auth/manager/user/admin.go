@@ -0,0 +1,234 @@
+package user
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/a1y/doc-formatter/internal/auth/domain/constant"
+	"github.com/a1y/doc-formatter/internal/auth/domain/entity"
+	jwtutil "github.com/a1y/doc-formatter/internal/auth/util/jwt"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// UserPageRequest asks for a page of the users, ordered by email address.
+type UserPageRequest struct {
+	// Query matches the users whose email address contains it, ignoring
+	// case.
+	Query string
+	// Role matches the users with the role.
+	Role string
+	// Size is the maximum number of users of the page. Zero asks for
+	// entity.DefaultUserPageSize and larger sizes are lowered to
+	// entity.MaxUserPageSize.
+	Size int
+	// Token is the next page token of the previous page, empty for the
+	// first page.
+	Token string
+}
+
+// ListUsers returns a page of the users along with the token of the next
+// page, which is empty on the last page, for an administrator who can read
+// users.
+func (u *UserManager) ListUsers(ctx context.Context, actorID uuid.UUID, req UserPageRequest) ([]*entity.User, string, error) {
+	actor, err := u.authorize(ctx, actorID, entity.PermissionUsersRead)
+	if err != nil {
+		return nil, "", err
+	}
+	size := min(req.Size, entity.MaxUserPageSize)
+	if size <= 0 {
+		size = entity.DefaultUserPageSize
+	}
+	filter := entity.UserFilter{
+		Query: strings.TrimSpace(req.Query),
+		Role:  strings.TrimSpace(req.Role),
+		// One more user than asked for tells whether a next page exists.
+		Limit: size + 1,
+	}
+	if req.Token != "" {
+		after, err := base64.RawURLEncoding.DecodeString(req.Token)
+		if err != nil || len(after) == 0 {
+			return nil, "", constant.ErrInvalidPageToken
+		}
+		filter.AfterEmail = string(after)
+	}
+	users, err := u.userRepo.List(ctx, filter)
+	if err != nil {
+		return nil, "", err
+	}
+	u.recordAuditBy(ctx, actor.ID, actor.ID, entity.AuditUsersListed,
+		fmt.Sprintf("query=%q role=%q", filter.Query, filter.Role))
+	if len(users) <= size {
+		return users, "", nil
+	}
+	users = users[:size]
+	return users, base64.RawURLEncoding.EncodeToString([]byte(users[size-1].Email)), nil
+}
+
+// SetUserDisabled disables or enables a user, for an administrator who can
+// manage users. Disabled users cannot log in, and their sessions and API
+// keys stop working. Administrators cannot disable themselves.
+func (u *UserManager) SetUserDisabled(ctx context.Context, actorID, userID uuid.UUID, disabled bool) (*entity.User, error) {
+	actor, err := u.authorize(ctx, actorID, entity.PermissionUsersManage)
+	if err != nil {
+		return nil, err
+	}
+	if actorID == userID {
+		return nil, constant.ErrCannotActOnSelf
+	}
+	user, err := u.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.Disabled == disabled {
+		return user, nil
+	}
+	if err := u.userRepo.SetDisabled(ctx, user.ID, disabled); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constant.ErrUserNotFound
+		}
+		return nil, err
+	}
+	user.Disabled = disabled
+	action := entity.AuditUserEnabled
+	if disabled {
+		action = entity.AuditUserDisabled
+		user.SessionVersion++
+	}
+	u.recordAuditBy(ctx, actor.ID, user.ID, action, "")
+	return user, nil
+}
+
+// VerifyUser marks the email address of a user verified without a
+// verification token, for an administrator who can manage users.
+func (u *UserManager) VerifyUser(ctx context.Context, actorID, userID uuid.UUID) (*entity.User, error) {
+	actor, err := u.authorize(ctx, actorID, entity.PermissionUsersManage)
+	if err != nil {
+		return nil, err
+	}
+	user, err := u.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.IsVerified {
+		return user, nil
+	}
+	if err := u.userRepo.MarkVerified(ctx, user.ID, user.Email); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// The email address changed since the user was read.
+			return nil, constant.ErrUserNotFound
+		}
+		return nil, err
+	}
+	user.IsVerified = true
+	u.recordAuditBy(ctx, actor.ID, user.ID, entity.AuditUserVerified, user.Email)
+	return user, nil
+}
+
+// ResetUserMFA disables the two-factor authentication of a user who lost
+// their authenticator and recovery codes, for an administrator who can
+// manage users. The user can enroll again after logging in with their
+// password.
+func (u *UserManager) ResetUserMFA(ctx context.Context, actorID, userID uuid.UUID) (*entity.User, error) {
+	actor, err := u.authorize(ctx, actorID, entity.PermissionUsersManage)
+	if err != nil {
+		return nil, err
+	}
+	if u.mfaRepo == nil {
+		return nil, constant.ErrMFAUnavailable
+	}
+	user, err := u.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.mfaRepo.ResetTOTP(ctx, user.ID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constant.ErrUserNotFound
+		}
+		return nil, err
+	}
+	user.MFAEnabled = false
+	user.TOTPSecret = nil
+	user.TOTPLastStep = 0
+	u.recordAuditBy(ctx, actor.ID, user.ID, entity.AuditMFAReset, "")
+	return user, nil
+}
+
+// ImpersonateUser issues an access token to act as a user, for an
+// administrator who can impersonate users to support them. The token names
+// the administrator and stops working once they can no longer impersonate.
+// Users whose role grants permissions cannot be impersonated, so that
+// impersonating cannot gain permissions.
+func (u *UserManager) ImpersonateUser(ctx context.Context, actorID, userID uuid.UUID) (*string, int64, error) {
+	actor, err := u.authorize(ctx, actorID, entity.PermissionUsersImpersonate)
+	if err != nil {
+		return nil, 0, err
+	}
+	if actorID == userID {
+		return nil, 0, constant.ErrCannotActOnSelf
+	}
+	user, err := u.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if user.Disabled {
+		return nil, 0, constant.ErrAccountDisabled
+	}
+	role, err := u.roleOf(ctx, user)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(role.Permissions) > 0 {
+		return nil, 0, constant.ErrCannotImpersonate
+	}
+
+	tokenString, exp, err := u.jwtClaims.GenerateAccessToken(jwtutil.AccessClaims{
+		UserID:         user.ID,
+		Email:          user.Email,
+		SessionVersion: user.SessionVersion,
+		Role:           role.Name,
+		ImpersonatorID: actor.ID,
+	}, accessTokenTTL)
+	if err != nil {
+		return nil, 0, err
+	}
+	u.recordAuditBy(ctx, actor.ID, user.ID, entity.AuditImpersonated, "")
+	return &tokenString, exp, nil
+}
+
+// PromoteAdmins gives the admin role to the verified users with the given
+// email addresses, so that a new deployment has someone to administer it.
+// Addresses without a verified user are skipped with a warning.
+func (u *UserManager) PromoteAdmins(ctx context.Context, emails []string) error {
+	for _, email := range emails {
+		email = strings.TrimSpace(email)
+		if email == "" {
+			continue
+		}
+		user, err := u.userRepo.GetByEmail(ctx, email)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logrus.Warnf("No user with email %s to make admin", email)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if !user.IsVerified {
+			logrus.Warnf("Not making %s admin until they verify their email address", email)
+			continue
+		}
+		if userRole(user) == entity.RoleAdmin {
+			continue
+		}
+		if err := u.userRepo.SetRole(ctx, user.ID, entity.RoleAdmin); err != nil {
+			return err
+		}
+		u.recordAudit(ctx, user.ID, entity.AuditRoleChanged, fmt.Sprintf("%s -> %s", userRole(user), entity.RoleAdmin))
+		logrus.Infof("Made %s admin", email)
+	}
+	return nil
+}
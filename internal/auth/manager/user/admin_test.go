@@ -0,0 +1,444 @@
+package user
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/a1y/doc-formatter/internal/auth/domain/constant"
+	"github.com/a1y/doc-formatter/internal/auth/domain/entity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type MockRoleRepository struct {
+	mock.Mock
+}
+
+func (m *MockRoleRepository) Create(ctx context.Context, r *entity.Role) error {
+	args := m.Called(ctx, r)
+	return args.Error(0)
+}
+
+func (m *MockRoleRepository) GetByName(ctx context.Context, name string) (*entity.Role, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Role), args.Error(1)
+}
+
+func (m *MockRoleRepository) List(ctx context.Context) ([]*entity.Role, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Role), args.Error(1)
+}
+
+func newAdminManager(t *testing.T) (*UserManager, *MockUserRepository, *MockRoleRepository, *MockAuditRepository) {
+	userManager, mockRepo, auditRepo := newAccountManager(t, nil)
+	roleRepo := new(MockRoleRepository)
+	userManager.SetRoleRepository(roleRepo)
+	return userManager, mockRepo, roleRepo, auditRepo
+}
+
+// adminAudit matches audit events recording action taken by actorID.
+func adminAudit(action string, actorID uuid.UUID) any {
+	return mock.MatchedBy(func(e *entity.AuditEvent) bool { return e.Action == action && e.ActorID == actorID })
+}
+
+func TestListUsers(t *testing.T) {
+	adminID := uuid.New()
+	admin := &entity.User{ID: adminID, Email: "admin@example.com", Role: entity.RoleAdmin}
+	ctx := context.Background()
+
+	t.Run("Pages", func(t *testing.T) {
+		userManager, mockRepo, _, auditRepo := newAdminManager(t)
+		mockRepo.On("GetByID", mock.Anything, adminID).Return(admin, nil)
+		mockRepo.On("List", mock.Anything, entity.UserFilter{Query: "example", Limit: 3}).Return([]*entity.User{
+			{Email: "a@example.com"}, {Email: "b@example.com"}, {Email: "c@example.com"},
+		}, nil).Once()
+		auditRepo.On("Create", mock.Anything, adminAudit(entity.AuditUsersListed, adminID)).Return(nil)
+
+		users, next, err := userManager.ListUsers(ctx, adminID, UserPageRequest{Query: " example ", Size: 2})
+		require.NoError(t, err)
+		assert.Len(t, users, 2)
+		require.NotEmpty(t, next)
+
+		mockRepo.On("List", mock.Anything, entity.UserFilter{Query: "example", AfterEmail: "b@example.com", Limit: 3}).
+			Return([]*entity.User{{Email: "c@example.com"}}, nil).Once()
+		users, next, err = userManager.ListUsers(ctx, adminID, UserPageRequest{Query: "example", Size: 2, Token: next})
+		require.NoError(t, err)
+		assert.Len(t, users, 1)
+		assert.Empty(t, next)
+		auditRepo.AssertNumberOfCalls(t, "Create", 2)
+	})
+
+	t.Run("DefaultSize", func(t *testing.T) {
+		userManager, mockRepo, _, auditRepo := newAdminManager(t)
+		mockRepo.On("GetByID", mock.Anything, adminID).Return(admin, nil)
+		mockRepo.On("List", mock.Anything, entity.UserFilter{Limit: entity.DefaultUserPageSize + 1}).Return([]*entity.User{}, nil)
+		auditRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+		_, _, err := userManager.ListUsers(ctx, adminID, UserPageRequest{})
+		require.NoError(t, err)
+	})
+
+	t.Run("InvalidPageToken", func(t *testing.T) {
+		userManager, mockRepo, _, _ := newAdminManager(t)
+		mockRepo.On("GetByID", mock.Anything, adminID).Return(admin, nil)
+
+		_, _, err := userManager.ListUsers(ctx, adminID, UserPageRequest{Token: "not base64!"})
+		assert.ErrorIs(t, err, constant.ErrInvalidPageToken)
+	})
+
+	t.Run("NotAdmin", func(t *testing.T) {
+		userManager, mockRepo, _, _ := newAdminManager(t)
+		mockRepo.On("GetByID", mock.Anything, adminID).Return(&entity.User{ID: adminID, Role: entity.RoleUser}, nil)
+
+		_, _, err := userManager.ListUsers(ctx, adminID, UserPageRequest{})
+		assert.ErrorIs(t, err, constant.ErrPermissionDenied)
+		mockRepo.AssertNotCalled(t, "List", mock.Anything, mock.Anything)
+	})
+
+	t.Run("DisabledAdmin", func(t *testing.T) {
+		userManager, mockRepo, _, _ := newAdminManager(t)
+		mockRepo.On("GetByID", mock.Anything, adminID).Return(&entity.User{ID: adminID, Role: entity.RoleAdmin, Disabled: true}, nil)
+
+		_, _, err := userManager.ListUsers(ctx, adminID, UserPageRequest{})
+		assert.ErrorIs(t, err, constant.ErrPermissionDenied)
+	})
+
+	t.Run("CustomRole", func(t *testing.T) {
+		userManager, mockRepo, roleRepo, auditRepo := newAdminManager(t)
+		mockRepo.On("GetByID", mock.Anything, adminID).Return(&entity.User{ID: adminID, Role: "support"}, nil)
+		roleRepo.On("GetByName", mock.Anything, "support").
+			Return(&entity.Role{Name: "support", Permissions: []string{entity.PermissionUsersRead}}, nil)
+		mockRepo.On("List", mock.Anything, mock.Anything).Return([]*entity.User{}, nil)
+		auditRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+		_, _, err := userManager.ListUsers(ctx, adminID, UserPageRequest{})
+		require.NoError(t, err)
+	})
+}
+
+func TestSetUserDisabled(t *testing.T) {
+	adminID, userID := uuid.New(), uuid.New()
+	admin := &entity.User{ID: adminID, Role: entity.RoleAdmin}
+	ctx := context.Background()
+
+	t.Run("Disable", func(t *testing.T) {
+		userManager, mockRepo, _, auditRepo := newAdminManager(t)
+		mockRepo.On("GetByID", mock.Anything, adminID).Return(admin, nil)
+		mockRepo.On("GetByID", mock.Anything, userID).Return(&entity.User{ID: userID, SessionVersion: 1}, nil)
+		mockRepo.On("SetDisabled", mock.Anything, userID, true).Return(nil)
+		auditRepo.On("Create", mock.Anything, adminAudit(entity.AuditUserDisabled, adminID)).Return(nil)
+
+		user, err := userManager.SetUserDisabled(ctx, adminID, userID, true)
+		require.NoError(t, err)
+		assert.True(t, user.Disabled)
+		assert.Equal(t, 2, user.SessionVersion)
+		auditRepo.AssertExpectations(t)
+	})
+
+	t.Run("AlreadyEnabled", func(t *testing.T) {
+		userManager, mockRepo, _, auditRepo := newAdminManager(t)
+		mockRepo.On("GetByID", mock.Anything, adminID).Return(admin, nil)
+		mockRepo.On("GetByID", mock.Anything, userID).Return(&entity.User{ID: userID}, nil)
+
+		user, err := userManager.SetUserDisabled(ctx, adminID, userID, false)
+		require.NoError(t, err)
+		assert.False(t, user.Disabled)
+		mockRepo.AssertNotCalled(t, "SetDisabled", mock.Anything, mock.Anything, mock.Anything)
+		auditRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Self", func(t *testing.T) {
+		userManager, mockRepo, _, _ := newAdminManager(t)
+		mockRepo.On("GetByID", mock.Anything, adminID).Return(admin, nil)
+
+		_, err := userManager.SetUserDisabled(ctx, adminID, adminID, true)
+		assert.ErrorIs(t, err, constant.ErrCannotActOnSelf)
+	})
+
+	t.Run("UserNotFound", func(t *testing.T) {
+		userManager, mockRepo, _, _ := newAdminManager(t)
+		mockRepo.On("GetByID", mock.Anything, adminID).Return(admin, nil)
+		mockRepo.On("GetByID", mock.Anything, userID).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := userManager.SetUserDisabled(ctx, adminID, userID, true)
+		assert.ErrorIs(t, err, constant.ErrUserNotFound)
+	})
+}
+
+func TestVerifyUser(t *testing.T) {
+	adminID, userID := uuid.New(), uuid.New()
+	ctx := context.Background()
+
+	userManager, mockRepo, _, auditRepo := newAdminManager(t)
+	mockRepo.On("GetByID", mock.Anything, adminID).Return(&entity.User{ID: adminID, Role: entity.RoleAdmin}, nil)
+	mockRepo.On("GetByID", mock.Anything, userID).Return(&entity.User{ID: userID, Email: "test@example.com"}, nil)
+	mockRepo.On("MarkVerified", mock.Anything, userID, "test@example.com").Return(nil)
+	auditRepo.On("Create", mock.Anything, adminAudit(entity.AuditUserVerified, adminID)).Return(nil)
+
+	user, err := userManager.VerifyUser(ctx, adminID, userID)
+	require.NoError(t, err)
+	assert.True(t, user.IsVerified)
+	auditRepo.AssertExpectations(t)
+}
+
+func TestResetUserMFA(t *testing.T) {
+	adminID, userID := uuid.New(), uuid.New()
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		userManager, mockRepo, _, auditRepo := newAdminManager(t)
+		mfaRepo := new(MockMFARepository)
+		userManager.SetMFA(mfaRepo, nil, MFAConfig{})
+		mockRepo.On("GetByID", mock.Anything, adminID).Return(&entity.User{ID: adminID, Role: entity.RoleAdmin}, nil)
+		mockRepo.On("GetByID", mock.Anything, userID).Return(&entity.User{ID: userID, MFAEnabled: true, TOTPSecret: []byte("sealed")}, nil)
+		mfaRepo.On("ResetTOTP", mock.Anything, userID).Return(nil)
+		auditRepo.On("Create", mock.Anything, adminAudit(entity.AuditMFAReset, adminID)).Return(nil)
+
+		user, err := userManager.ResetUserMFA(ctx, adminID, userID)
+		require.NoError(t, err)
+		assert.False(t, user.MFAEnabled)
+		assert.Nil(t, user.TOTPSecret)
+		auditRepo.AssertExpectations(t)
+	})
+
+	t.Run("Unavailable", func(t *testing.T) {
+		userManager, mockRepo, _, _ := newAdminManager(t)
+		mockRepo.On("GetByID", mock.Anything, adminID).Return(&entity.User{ID: adminID, Role: entity.RoleAdmin}, nil)
+
+		_, err := userManager.ResetUserMFA(ctx, adminID, userID)
+		assert.ErrorIs(t, err, constant.ErrMFAUnavailable)
+	})
+}
+
+func TestImpersonateUser(t *testing.T) {
+	adminID, userID := uuid.New(), uuid.New()
+	admin := &entity.User{ID: adminID, Role: entity.RoleAdmin}
+	target := &entity.User{ID: userID, Email: "test@example.com", Role: entity.RoleUser, SessionVersion: 3}
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		userManager, mockRepo, _, auditRepo := newAdminManager(t)
+		mockRepo.On("GetByID", mock.Anything, adminID).Return(admin, nil)
+		mockRepo.On("GetByID", mock.Anything, userID).Return(target, nil)
+		auditRepo.On("Create", mock.Anything, adminAudit(entity.AuditImpersonated, adminID)).Return(nil)
+
+		token, _, err := userManager.ImpersonateUser(ctx, adminID, userID)
+		require.NoError(t, err)
+		auditRepo.AssertExpectations(t)
+
+		session, err := userManager.ValidateAccessToken(ctx, *token)
+		require.NoError(t, err)
+		assert.Equal(t, userID, session.User.ID)
+		assert.Equal(t, adminID, session.ImpersonatorID)
+		assert.Empty(t, session.Role.Permissions)
+	})
+
+	t.Run("ImpersonatorDemoted", func(t *testing.T) {
+		userManager, mockRepo, _, auditRepo := newAdminManager(t)
+		mockRepo.On("GetByID", mock.Anything, adminID).Return(admin, nil).Once()
+		mockRepo.On("GetByID", mock.Anything, userID).Return(target, nil)
+		auditRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+		token, _, err := userManager.ImpersonateUser(ctx, adminID, userID)
+		require.NoError(t, err)
+
+		mockRepo.On("GetByID", mock.Anything, adminID).Return(&entity.User{ID: adminID, Role: entity.RoleUser}, nil)
+		_, err = userManager.ValidateAccessToken(ctx, *token)
+		assert.Equal(t, constant.ErrInvalidAccessToken, err)
+	})
+
+	t.Run("Admin", func(t *testing.T) {
+		userManager, mockRepo, _, _ := newAdminManager(t)
+		otherAdmin := &entity.User{ID: userID, Role: entity.RoleAdmin}
+		mockRepo.On("GetByID", mock.Anything, adminID).Return(admin, nil)
+		mockRepo.On("GetByID", mock.Anything, userID).Return(otherAdmin, nil)
+
+		_, _, err := userManager.ImpersonateUser(ctx, adminID, userID)
+		assert.ErrorIs(t, err, constant.ErrCannotImpersonate)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		userManager, mockRepo, _, _ := newAdminManager(t)
+		mockRepo.On("GetByID", mock.Anything, adminID).Return(admin, nil)
+		mockRepo.On("GetByID", mock.Anything, userID).Return(&entity.User{ID: userID, Disabled: true}, nil)
+
+		_, _, err := userManager.ImpersonateUser(ctx, adminID, userID)
+		assert.ErrorIs(t, err, constant.ErrAccountDisabled)
+	})
+
+	t.Run("WithoutPermission", func(t *testing.T) {
+		userManager, mockRepo, roleRepo, _ := newAdminManager(t)
+		mockRepo.On("GetByID", mock.Anything, adminID).Return(&entity.User{ID: adminID, Role: "auditor"}, nil)
+		roleRepo.On("GetByName", mock.Anything, "auditor").
+			Return(&entity.Role{Name: "auditor", Permissions: []string{entity.PermissionUsersRead}}, nil)
+
+		_, _, err := userManager.ImpersonateUser(ctx, adminID, userID)
+		assert.ErrorIs(t, err, constant.ErrPermissionDenied)
+	})
+}
+
+func TestCreateRole(t *testing.T) {
+	adminID := uuid.New()
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		userManager, mockRepo, roleRepo, auditRepo := newAdminManager(t)
+		mockRepo.On("GetByID", mock.Anything, adminID).Return(&entity.User{ID: adminID, Role: entity.RoleAdmin}, nil)
+		roleRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+		auditRepo.On("Create", mock.Anything, adminAudit(entity.AuditRoleCreated, adminID)).Return(nil)
+
+		role, err := userManager.CreateRole(ctx, adminID, &entity.Role{
+			Name:        " Support ",
+			Permissions: []string{entity.PermissionUsersRead, entity.PermissionUsersImpersonate, entity.PermissionUsersRead},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "support", role.Name)
+		assert.Equal(t, []string{entity.PermissionUsersImpersonate, entity.PermissionUsersRead}, role.Permissions)
+		auditRepo.AssertExpectations(t)
+	})
+
+	t.Run("BuiltIn", func(t *testing.T) {
+		userManager, mockRepo, roleRepo, _ := newAdminManager(t)
+		mockRepo.On("GetByID", mock.Anything, adminID).Return(&entity.User{ID: adminID, Role: entity.RoleAdmin}, nil)
+
+		_, err := userManager.CreateRole(ctx, adminID, &entity.Role{Name: entity.RoleAdmin})
+		assert.ErrorIs(t, err, constant.ErrRoleExists)
+		roleRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Duplicate", func(t *testing.T) {
+		userManager, mockRepo, roleRepo, _ := newAdminManager(t)
+		mockRepo.On("GetByID", mock.Anything, adminID).Return(&entity.User{ID: adminID, Role: entity.RoleAdmin}, nil)
+		roleRepo.On("Create", mock.Anything, mock.Anything).Return(gorm.ErrDuplicatedKey)
+
+		_, err := userManager.CreateRole(ctx, adminID, &entity.Role{Name: "support"})
+		assert.ErrorIs(t, err, constant.ErrRoleExists)
+	})
+
+	t.Run("UnknownPermission", func(t *testing.T) {
+		userManager, mockRepo, _, _ := newAdminManager(t)
+		mockRepo.On("GetByID", mock.Anything, adminID).Return(&entity.User{ID: adminID, Role: entity.RoleAdmin}, nil)
+
+		_, err := userManager.CreateRole(ctx, adminID, &entity.Role{Name: "support", Permissions: []string{"users:delete"}})
+		assert.ErrorIs(t, err, constant.ErrInvalidRole)
+	})
+
+	t.Run("Unavailable", func(t *testing.T) {
+		userManager, mockRepo, _, _ := newAdminManager(t)
+		userManager.SetRoleRepository(nil)
+		mockRepo.On("GetByID", mock.Anything, adminID).Return(&entity.User{ID: adminID, Role: entity.RoleAdmin}, nil)
+
+		_, err := userManager.CreateRole(ctx, adminID, &entity.Role{Name: "support"})
+		assert.ErrorIs(t, err, constant.ErrRolesUnavailable)
+	})
+}
+
+func TestListRoles(t *testing.T) {
+	adminID := uuid.New()
+	ctx := context.Background()
+
+	userManager, mockRepo, roleRepo, _ := newAdminManager(t)
+	mockRepo.On("GetByID", mock.Anything, adminID).Return(&entity.User{ID: adminID, Role: entity.RoleAdmin}, nil)
+	roleRepo.On("List", mock.Anything).Return([]*entity.Role{{Name: "support"}}, nil)
+
+	roles, err := userManager.ListRoles(ctx, adminID)
+	require.NoError(t, err)
+	names := make([]string, len(roles))
+	for i, role := range roles {
+		names[i] = role.Name
+	}
+	assert.Equal(t, []string{entity.RoleUser, entity.RoleAdmin, "support"}, names)
+}
+
+func TestSetUserRole(t *testing.T) {
+	adminID, userID := uuid.New(), uuid.New()
+	admin := &entity.User{ID: adminID, Role: entity.RoleAdmin}
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		userManager, mockRepo, roleRepo, auditRepo := newAdminManager(t)
+		mockRepo.On("GetByID", mock.Anything, adminID).Return(admin, nil)
+		mockRepo.On("GetByID", mock.Anything, userID).Return(&entity.User{ID: userID, Role: entity.RoleUser}, nil)
+		roleRepo.On("GetByName", mock.Anything, "support").Return(&entity.Role{Name: "support"}, nil)
+		mockRepo.On("SetRole", mock.Anything, userID, "support").Return(nil)
+		auditRepo.On("Create", mock.Anything, mock.MatchedBy(func(e *entity.AuditEvent) bool {
+			return e.Action == entity.AuditRoleChanged && e.ActorID == adminID && e.Detail == "user -> support"
+		})).Return(nil)
+
+		user, err := userManager.SetUserRole(ctx, adminID, userID, "support")
+		require.NoError(t, err)
+		assert.Equal(t, "support", user.Role)
+		auditRepo.AssertExpectations(t)
+	})
+
+	t.Run("RoleNotFound", func(t *testing.T) {
+		userManager, mockRepo, roleRepo, _ := newAdminManager(t)
+		mockRepo.On("GetByID", mock.Anything, adminID).Return(admin, nil)
+		mockRepo.On("GetByID", mock.Anything, userID).Return(&entity.User{ID: userID}, nil)
+		roleRepo.On("GetByName", mock.Anything, "missing").Return(nil, gorm.ErrRecordNotFound)
+
+		_, err := userManager.SetUserRole(ctx, adminID, userID, "missing")
+		assert.ErrorIs(t, err, constant.ErrRoleNotFound)
+	})
+
+	t.Run("Self", func(t *testing.T) {
+		userManager, mockRepo, _, _ := newAdminManager(t)
+		mockRepo.On("GetByID", mock.Anything, adminID).Return(admin, nil)
+
+		_, err := userManager.SetUserRole(ctx, adminID, adminID, entity.RoleUser)
+		assert.ErrorIs(t, err, constant.ErrCannotActOnSelf)
+	})
+}
+
+func TestPromoteAdmins(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	userManager, mockRepo, _, auditRepo := newAdminManager(t)
+	mockRepo.On("GetByEmail", mock.Anything, "admin@example.com").Return(&entity.User{ID: userID, IsVerified: true}, nil)
+	mockRepo.On("GetByEmail", mock.Anything, "unverified@example.com").Return(&entity.User{ID: uuid.New()}, nil)
+	mockRepo.On("GetByEmail", mock.Anything, "missing@example.com").Return(nil, gorm.ErrRecordNotFound)
+	mockRepo.On("SetRole", mock.Anything, userID, entity.RoleAdmin).Return(nil).Once()
+	auditRepo.On("Create", mock.Anything, auditAction(entity.AuditRoleChanged)).Return(nil)
+
+	require.NoError(t, userManager.PromoteAdmins(ctx, []string{"admin@example.com", "unverified@example.com", " ", "missing@example.com"}))
+	mockRepo.AssertExpectations(t)
+}
+
+func TestLoginUser_Disabled(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	userManager, mockRepo, _, _ := newAdminManager(t)
+	user := hashedUser(t, userID, "test@example.com", "password123")
+	user.Disabled = true
+	mockRepo.On("GetByEmail", mock.Anything, "test@example.com").Return(user, nil)
+
+	_, _, err := userManager.LoginUser(ctx, &entity.User{Email: "test@example.com", Password: "password123"}, "")
+	assert.ErrorIs(t, err, constant.ErrAccountDisabled)
+}
+
+func TestListUsers_PageToken(t *testing.T) {
+	// Page tokens are opaque to clients but carry the last email address.
+	token := base64.RawURLEncoding.EncodeToString([]byte("b@example.com"))
+	adminID := uuid.New()
+
+	userManager, mockRepo, _, auditRepo := newAdminManager(t)
+	mockRepo.On("GetByID", mock.Anything, adminID).Return(&entity.User{ID: adminID, Role: entity.RoleAdmin}, nil)
+	mockRepo.On("List", mock.Anything, mock.MatchedBy(func(f entity.UserFilter) bool { return f.AfterEmail == "b@example.com" })).
+		Return([]*entity.User{}, nil)
+	auditRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	_, _, err := userManager.ListUsers(context.Background(), adminID, UserPageRequest{Token: token})
+	require.NoError(t, err)
+}
@@ -0,0 +1,190 @@
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/a1y/doc-formatter/internal/auth/domain/constant"
+	"github.com/a1y/doc-formatter/internal/auth/domain/entity"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// apiKeyMarker starts every API key, so that leaked keys are easy to spot.
+const apiKeyMarker = "dfk_"
+
+// apiKeyLookupSize is the number of random bytes of the part of an API key
+// it is looked up by.
+const apiKeyLookupSize = 6
+
+// apiKeyUsageInterval is how often the use of an API key is recorded at
+// most, so that scripts calling often do not write on every request.
+const apiKeyUsageInterval = time.Minute
+
+// CreateAPIKey creates an API key of a user restricted to scopes, none
+// meaning no restriction, and returns it with the key. The key is not stored
+// and cannot be retrieved later.
+func (u *UserManager) CreateAPIKey(ctx context.Context, userID uuid.UUID, name string, scopes []string) (*entity.APIKey, string, error) {
+	if u.apiKeyRepo == nil {
+		return nil, "", constant.ErrAPIKeysUnavailable
+	}
+	scopes, err := normalizeScopes(scopes)
+	if err != nil {
+		return nil, "", err
+	}
+	user, err := u.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	prefix, key, err := newAPIKey()
+	if err != nil {
+		return nil, "", err
+	}
+	apiKey := &entity.APIKey{
+		UserID:  user.ID,
+		Name:    strings.TrimSpace(name),
+		Prefix:  prefix,
+		KeyHash: hashAPIKey(key),
+		Scopes:  scopes,
+	}
+	if err := u.apiKeyRepo.Create(ctx, apiKey); err != nil {
+		return nil, "", err
+	}
+	u.recordAudit(ctx, user.ID, entity.AuditAPIKeyCreated, apiKey.Prefix)
+	return apiKey, key, nil
+}
+
+// ListAPIKeys returns the unrevoked API keys of a user, newest first.
+func (u *UserManager) ListAPIKeys(ctx context.Context, userID uuid.UUID) ([]*entity.APIKey, error) {
+	if u.apiKeyRepo == nil {
+		return nil, constant.ErrAPIKeysUnavailable
+	}
+	return u.apiKeyRepo.ListByUser(ctx, userID)
+}
+
+// RevokeAPIKey stops an API key of a user from working. actorID is the
+// administrator revoking it while impersonating the user, uuid.Nil when the
+// user revokes it themselves.
+func (u *UserManager) RevokeAPIKey(ctx context.Context, userID, keyID, actorID uuid.UUID) error {
+	if u.apiKeyRepo == nil {
+		return constant.ErrAPIKeysUnavailable
+	}
+	err := u.apiKeyRepo.Revoke(ctx, userID, keyID, time.Now())
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return constant.ErrAPIKeyNotFound
+	}
+	if err != nil {
+		return err
+	}
+	u.recordAuditBy(ctx, actorID, userID, entity.AuditAPIKeyRevoked, keyID.String())
+	return nil
+}
+
+// revokeAPIKeys revokes every API key of a user, for when their password
+// changes: keys made by whoever knew the old password must stop working too.
+func (u *UserManager) revokeAPIKeys(ctx context.Context, userID uuid.UUID) error {
+	if u.apiKeyRepo == nil {
+		return nil
+	}
+	return u.apiKeyRepo.RevokeAllByUser(ctx, userID, time.Now())
+}
+
+// ValidateAPIKey returns the user an unrevoked API key belongs to, and the
+// key. Its use is recorded. Keys of disabled users are refused.
+func (u *UserManager) ValidateAPIKey(ctx context.Context, key string) (*entity.User, *entity.APIKey, error) {
+	if u.apiKeyRepo == nil {
+		return nil, nil, constant.ErrInvalidAPIKey
+	}
+	prefix, ok := apiKeyPrefix(key)
+	if !ok {
+		return nil, nil, constant.ErrInvalidAPIKey
+	}
+	apiKey, err := u.apiKeyRepo.GetByPrefix(ctx, prefix)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil, constant.ErrInvalidAPIKey
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if subtle.ConstantTimeCompare([]byte(apiKey.KeyHash), []byte(hashAPIKey(key))) != 1 {
+		return nil, nil, constant.ErrInvalidAPIKey
+	}
+	user, err := u.userRepo.GetByID(ctx, apiKey.UserID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil, constant.ErrInvalidAPIKey
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if user.Disabled {
+		return nil, nil, constant.ErrInvalidAPIKey
+	}
+
+	now := time.Now()
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) >= apiKeyUsageInterval {
+		if err := u.apiKeyRepo.MarkUsed(ctx, apiKey.ID, now); err != nil {
+			logrus.Warnf("Failed to record use of api key %s: %v", apiKey.Prefix, err)
+		} else {
+			apiKey.LastUsedAt = &now
+		}
+	}
+	return user, apiKey, nil
+}
+
+// normalizeScopes sorts scopes and drops duplicates. It returns
+// constant.ErrInvalidAPIKeyScope for unknown scopes.
+func normalizeScopes(scopes []string) ([]string, error) {
+	var normalized []string
+	for _, scope := range scopes {
+		scope = strings.ToLower(strings.TrimSpace(scope))
+		if !slices.Contains(entity.APIKeyScopes, scope) {
+			return nil, fmt.Errorf("%w: %q", constant.ErrInvalidAPIKeyScope, scope)
+		}
+		if !slices.Contains(normalized, scope) {
+			normalized = append(normalized, scope)
+		}
+	}
+	slices.Sort(normalized)
+	return normalized, nil
+}
+
+// newAPIKey returns a new API key and the prefix it is looked up by. Keys
+// look like dfk_<lookup>_<secret>, the prefix being dfk_<lookup>.
+func newAPIKey() (string, string, error) {
+	b := make([]byte, apiKeyLookupSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	prefix := apiKeyMarker + hex.EncodeToString(b)
+	return prefix, prefix + "_" + rand.Text(), nil
+}
+
+// apiKeyPrefix returns the prefix of a key, when it looks like an API key.
+func apiKeyPrefix(key string) (string, bool) {
+	rest, ok := strings.CutPrefix(key, apiKeyMarker)
+	if !ok {
+		return "", false
+	}
+	lookup, secret, ok := strings.Cut(rest, "_")
+	if !ok || len(lookup) != hex.EncodedLen(apiKeyLookupSize) || secret == "" {
+		return "", false
+	}
+	return apiKeyMarker + lookup, true
+}
+
+// hashAPIKey hashes a key for storage. Keys are random and long, so a fast
+// unsalted hash is enough.
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,214 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/a1y/doc-formatter/internal/auth/domain/constant"
+	"github.com/a1y/doc-formatter/internal/auth/domain/entity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type MockAPIKeyRepository struct {
+	mock.Mock
+}
+
+func (m *MockAPIKeyRepository) Create(ctx context.Context, k *entity.APIKey) error {
+	args := m.Called(ctx, k)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyRepository) GetByPrefix(ctx context.Context, prefix string) (*entity.APIKey, error) {
+	args := m.Called(ctx, prefix)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*entity.APIKey, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) Revoke(ctx context.Context, userID, id uuid.UUID, now time.Time) error {
+	args := m.Called(ctx, userID, id, now)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyRepository) RevokeAllByUser(ctx context.Context, userID uuid.UUID, now time.Time) error {
+	args := m.Called(ctx, userID, now)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyRepository) MarkUsed(ctx context.Context, id uuid.UUID, now time.Time) error {
+	args := m.Called(ctx, id, now)
+	return args.Error(0)
+}
+
+func newAPIKeyManager(t *testing.T) (*UserManager, *MockUserRepository, *MockAPIKeyRepository, *MockAuditRepository) {
+	userManager, mockRepo, auditRepo := newAccountManager(t, nil)
+	keyRepo := new(MockAPIKeyRepository)
+	userManager.SetAPIKeyRepository(keyRepo)
+	return userManager, mockRepo, keyRepo, auditRepo
+}
+
+func TestCreateAPIKey(t *testing.T) {
+	userID := uuid.New()
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		userManager, mockRepo, keyRepo, auditRepo := newAPIKeyManager(t)
+		mockRepo.On("GetByID", mock.Anything, userID).Return(&entity.User{ID: userID, Email: "test@example.com"}, nil)
+		var stored *entity.APIKey
+		keyRepo.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			stored = args.Get(1).(*entity.APIKey)
+		}).Return(nil)
+		auditRepo.On("Create", mock.Anything, auditAction(entity.AuditAPIKeyCreated)).Return(nil)
+
+		apiKey, key, err := userManager.CreateAPIKey(ctx, userID, " ci ", []string{"upload", "Read", "upload"})
+		require.NoError(t, err)
+		assert.Same(t, stored, apiKey)
+		assert.Equal(t, "ci", apiKey.Name)
+		assert.Equal(t, []string{"read", "upload"}, apiKey.Scopes)
+
+		// Only a hash of the key is stored, and the prefix it starts with.
+		assert.True(t, strings.HasPrefix(key, apiKey.Prefix+"_"))
+		assert.True(t, strings.HasPrefix(key, "dfk_"))
+		assert.NotContains(t, apiKey.KeyHash, key)
+		assert.Equal(t, hashAPIKey(key), apiKey.KeyHash)
+		auditRepo.AssertExpectations(t)
+	})
+
+	t.Run("UnknownScope", func(t *testing.T) {
+		userManager, _, keyRepo, _ := newAPIKeyManager(t)
+
+		_, _, err := userManager.CreateAPIKey(ctx, userID, "ci", []string{"admin"})
+		assert.ErrorIs(t, err, constant.ErrInvalidAPIKeyScope)
+		keyRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("UserNotFound", func(t *testing.T) {
+		userManager, mockRepo, _, _ := newAPIKeyManager(t)
+		mockRepo.On("GetByID", mock.Anything, userID).Return(nil, gorm.ErrRecordNotFound)
+
+		_, _, err := userManager.CreateAPIKey(ctx, userID, "ci", nil)
+		assert.ErrorIs(t, err, constant.ErrUserNotFound)
+	})
+
+	t.Run("Unavailable", func(t *testing.T) {
+		userManager, _, _, _ := newAPIKeyManager(t)
+		userManager.SetAPIKeyRepository(nil)
+
+		_, _, err := userManager.CreateAPIKey(ctx, userID, "ci", nil)
+		assert.ErrorIs(t, err, constant.ErrAPIKeysUnavailable)
+	})
+}
+
+func TestRevokeAPIKey(t *testing.T) {
+	userID, keyID := uuid.New(), uuid.New()
+	ctx := context.Background()
+
+	userManager, _, keyRepo, auditRepo := newAPIKeyManager(t)
+	keyRepo.On("Revoke", mock.Anything, userID, keyID, mock.Anything).Return(nil).Once()
+	auditRepo.On("Create", mock.Anything, adminAudit(entity.AuditAPIKeyRevoked, uuid.Nil)).Return(nil).Once()
+	require.NoError(t, userManager.RevokeAPIKey(ctx, userID, keyID, uuid.Nil))
+	auditRepo.AssertExpectations(t)
+
+	// An administrator impersonating the user is recorded as the actor.
+	adminID := uuid.New()
+	keyRepo.On("Revoke", mock.Anything, userID, keyID, mock.Anything).Return(nil).Once()
+	auditRepo.On("Create", mock.Anything, adminAudit(entity.AuditAPIKeyRevoked, adminID)).Return(nil).Once()
+	require.NoError(t, userManager.RevokeAPIKey(ctx, userID, keyID, adminID))
+	auditRepo.AssertExpectations(t)
+
+	keyRepo.On("Revoke", mock.Anything, userID, keyID, mock.Anything).Return(gorm.ErrRecordNotFound)
+	assert.ErrorIs(t, userManager.RevokeAPIKey(ctx, userID, keyID, uuid.Nil), constant.ErrAPIKeyNotFound)
+}
+
+func TestValidateAPIKey(t *testing.T) {
+	userID := uuid.New()
+	ctx := context.Background()
+	prefix, key, err := newAPIKey()
+	require.NoError(t, err)
+	storedKey := func(lastUsedAt *time.Time) *entity.APIKey {
+		return &entity.APIKey{ID: uuid.New(), UserID: userID, Name: "ci", Prefix: prefix, KeyHash: hashAPIKey(key), Scopes: []string{"read"}, LastUsedAt: lastUsedAt}
+	}
+	user := &entity.User{ID: userID, Email: "test@example.com", IsVerified: true}
+
+	t.Run("Success", func(t *testing.T) {
+		userManager, mockRepo, keyRepo, _ := newAPIKeyManager(t)
+		apiKey := storedKey(nil)
+		keyRepo.On("GetByPrefix", mock.Anything, prefix).Return(apiKey, nil)
+		mockRepo.On("GetByID", mock.Anything, userID).Return(user, nil)
+		keyRepo.On("MarkUsed", mock.Anything, apiKey.ID, mock.Anything).Return(nil)
+
+		gotUser, gotKey, err := userManager.ValidateAPIKey(ctx, key)
+		require.NoError(t, err)
+		assert.Equal(t, user, gotUser)
+		assert.Equal(t, []string{"read"}, gotKey.Scopes)
+		assert.NotNil(t, gotKey.LastUsedAt)
+		keyRepo.AssertExpectations(t)
+	})
+
+	t.Run("RecentlyUsed", func(t *testing.T) {
+		userManager, mockRepo, keyRepo, _ := newAPIKeyManager(t)
+		lastUsedAt := time.Now().Add(-10 * time.Second)
+		keyRepo.On("GetByPrefix", mock.Anything, prefix).Return(storedKey(&lastUsedAt), nil)
+		mockRepo.On("GetByID", mock.Anything, userID).Return(user, nil)
+
+		_, _, err := userManager.ValidateAPIKey(ctx, key)
+		require.NoError(t, err)
+		keyRepo.AssertNotCalled(t, "MarkUsed", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("MarkUsedFails", func(t *testing.T) {
+		userManager, mockRepo, keyRepo, _ := newAPIKeyManager(t)
+		keyRepo.On("GetByPrefix", mock.Anything, prefix).Return(storedKey(nil), nil)
+		mockRepo.On("GetByID", mock.Anything, userID).Return(user, nil)
+		keyRepo.On("MarkUsed", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("db down"))
+
+		_, _, err := userManager.ValidateAPIKey(ctx, key)
+		assert.NoError(t, err, "the key works even when its use cannot be recorded")
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		userManager, mockRepo, keyRepo, _ := newAPIKeyManager(t)
+		keyRepo.On("GetByPrefix", mock.Anything, prefix).Return(storedKey(nil), nil)
+		otherPrefix, otherKey, err := newAPIKey()
+		require.NoError(t, err)
+		keyRepo.On("GetByPrefix", mock.Anything, otherPrefix).Return(nil, gorm.ErrRecordNotFound)
+
+		for name, key := range map[string]string{
+			"WrongSecret":  prefix + "_WRONG",
+			"UnknownKey":   otherKey,
+			"Malformed":    "not-a-key",
+			"NoSecret":     prefix + "_",
+			"ShortLookup":  "dfk_abc_secret",
+			"BearerTokens": "eyJhbGciOiJSUzI1NiJ9.e30.sig",
+		} {
+			_, _, err := userManager.ValidateAPIKey(ctx, key)
+			assert.ErrorIs(t, err, constant.ErrInvalidAPIKey, name)
+		}
+		mockRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("UserDeleted", func(t *testing.T) {
+		userManager, mockRepo, keyRepo, _ := newAPIKeyManager(t)
+		keyRepo.On("GetByPrefix", mock.Anything, prefix).Return(storedKey(nil), nil)
+		mockRepo.On("GetByID", mock.Anything, userID).Return(nil, gorm.ErrRecordNotFound)
+
+		_, _, err := userManager.ValidateAPIKey(ctx, key)
+		assert.ErrorIs(t, err, constant.ErrInvalidAPIKey)
+	})
+}
@@ -0,0 +1,39 @@
+package user
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// tokenLink adds token to the query of link.
+func tokenLink(link, token string) (string, error) {
+	u, err := url.Parse(link)
+	if err != nil {
+		return "", err
+	}
+	query := u.Query()
+	query.Set("token", token)
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+// formatTTL spells out ttl in the largest whole unit it is a multiple of.
+func formatTTL(ttl time.Duration) string {
+	plural := func(n int64, unit string) string {
+		if n == 1 {
+			return fmt.Sprintf("1 %s", unit)
+		}
+		return fmt.Sprintf("%d %ss", n, unit)
+	}
+	switch {
+	case ttl >= 24*time.Hour && ttl%(24*time.Hour) == 0:
+		return plural(int64(ttl/(24*time.Hour)), "day")
+	case ttl >= time.Hour && ttl%time.Hour == 0:
+		return plural(int64(ttl/time.Hour), "hour")
+	case ttl >= time.Minute && ttl%time.Minute == 0:
+		return plural(int64(ttl/time.Minute), "minute")
+	default:
+		return ttl.String()
+	}
+}
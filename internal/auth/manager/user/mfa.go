@@ -0,0 +1,280 @@
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/a1y/doc-formatter/internal/auth/domain/constant"
+	"github.com/a1y/doc-formatter/internal/auth/domain/entity"
+	jwtutil "github.com/a1y/doc-formatter/internal/auth/util/jwt"
+	"github.com/a1y/doc-formatter/internal/auth/util/totp"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// DefaultMFAIssuer is the name authenticator apps show accounts under when
+// the MFA config sets none.
+const DefaultMFAIssuer = "Doc Formatter"
+
+// mfaTokenTTL is how long the token a password login returns for the second
+// step is valid.
+const mfaTokenTTL = 5 * time.Minute
+
+// Recovery codes are issued in sets of 10, of 10 random bytes each.
+const (
+	recoveryCodeCount = 10
+	recoveryCodeSize  = 10
+)
+
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// MFARequiredError is returned when the password of a user with two-factor
+// authentication is right. The login is completed by VerifyMFA.
+type MFARequiredError struct {
+	// Token identifies the login to VerifyMFA.
+	Token string
+	// ExpiresAt is when Token expires, in Unix seconds.
+	ExpiresAt int64
+}
+
+func (e *MFARequiredError) Error() string {
+	return constant.ErrMFARequired.Error()
+}
+
+func (e *MFARequiredError) Unwrap() error {
+	return constant.ErrMFARequired
+}
+
+// EnrollTOTP starts enrolling a user in two-factor authentication with a new
+// TOTP secret. It returns the secret and the otpauth URI to set up
+// authenticator apps with. Enrolling again replaces the secret, until
+// ConfirmTOTP enables it.
+func (u *UserManager) EnrollTOTP(ctx context.Context, userID uuid.UUID) (string, string, error) {
+	if u.mfaRepo == nil || u.mfaBox == nil {
+		return "", "", constant.ErrMFAUnavailable
+	}
+	user, err := u.GetUserByID(ctx, userID)
+	if err != nil {
+		return "", "", err
+	}
+	if user.MFAEnabled {
+		return "", "", constant.ErrMFAAlreadyEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return "", "", err
+	}
+	sealed, err := u.mfaBox.Seal(secret, user.ID[:])
+	if err != nil {
+		return "", "", err
+	}
+	err = u.mfaRepo.SetTOTPSecret(ctx, user.ID, sealed)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// It was enabled since it was checked.
+		return "", "", constant.ErrMFAAlreadyEnabled
+	}
+	if err != nil {
+		return "", "", err
+	}
+
+	issuer := u.mfa.Issuer
+	if issuer == "" {
+		issuer = DefaultMFAIssuer
+	}
+	return totp.EncodeSecret(secret), totp.URI(issuer, user.Email, secret), nil
+}
+
+// ConfirmTOTP enables two-factor authentication for a user once they show a
+// code of the secret they enrolled with. It returns their recovery codes,
+// which are not stored and cannot be shown again.
+func (u *UserManager) ConfirmTOTP(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	if u.mfaRepo == nil || u.mfaBox == nil {
+		return nil, constant.ErrMFAUnavailable
+	}
+	user, err := u.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.MFAEnabled {
+		return nil, constant.ErrMFAAlreadyEnabled
+	}
+	if len(user.TOTPSecret) == 0 {
+		return nil, constant.ErrMFANotEnrolled
+	}
+	secret, err := u.mfaBox.Open(user.TOTPSecret, user.ID[:])
+	if err != nil {
+		return nil, err
+	}
+	step, err := totp.Validate(secret, code, time.Now())
+	if err != nil {
+		return nil, constant.ErrInvalidMFACode
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	err = u.mfaRepo.EnableTOTP(ctx, user.ID, step, hashes)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, constant.ErrMFAAlreadyEnabled
+	}
+	if err != nil {
+		return nil, err
+	}
+	logrus.Infof("Two-factor authentication enabled for user %s", user.ID)
+	u.recordAudit(ctx, user.ID, entity.AuditMFAEnabled, "totp")
+	return codes, nil
+}
+
+// VerifyMFA completes a login with the token LoginUser returned in its
+// *MFARequiredError and a TOTP or recovery code of the user. Every code can
+// only be used once. Wrong codes count as failed logins.
+func (u *UserManager) VerifyMFA(ctx context.Context, token, code, remoteAddr string) (*string, int64, error) {
+	if u.mfaRepo == nil || u.mfaBox == nil {
+		return nil, 0, constant.ErrMFAUnavailable
+	}
+	userID, email, err := u.jwtClaims.ParsePurposeToken(token, jwtutil.PurposeMFA)
+	if errors.Is(err, jwtutil.ErrInvalidToken) {
+		return nil, 0, constant.ErrInvalidMFAToken
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+
+	now := time.Now()
+	accountKey, ipKey := loginKeys(email, remoteAddr)
+	if err := u.checkLoginLocked(ctx, now, accountKey, ipKey); err != nil {
+		return nil, 0, err
+	}
+	user, err := u.userRepo.GetByID(ctx, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, 0, constant.ErrInvalidMFAToken
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	if !user.MFAEnabled {
+		// Two-factor authentication was reset since the password was
+		// checked.
+		return nil, 0, constant.ErrInvalidMFAToken
+	}
+	if user.Disabled {
+		return nil, 0, constant.ErrAccountDisabled
+	}
+
+	err = u.useMFACode(ctx, user, code, now)
+	if errors.Is(err, constant.ErrInvalidMFACode) {
+		u.recordLoginFailure(ctx, now, accountKey, ipKey)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	u.resetLoginFailures(ctx, accountKey)
+
+	return u.accessToken(user)
+}
+
+// mfaChallenge returns the *MFARequiredError for a user whose password was
+// right.
+func (u *UserManager) mfaChallenge(user *entity.User) error {
+	if u.mfaRepo == nil || u.mfaBox == nil {
+		// Letting the user in on their password alone would silently
+		// downgrade their account.
+		return constant.ErrMFAUnavailable
+	}
+	token, exp, err := u.jwtClaims.GeneratePurposeToken(user.ID, user.Email, jwtutil.PurposeMFA, mfaTokenTTL)
+	if err != nil {
+		return err
+	}
+	return &MFARequiredError{Token: token, ExpiresAt: exp}
+}
+
+// useMFACode uses code, a TOTP code or a recovery code of user. It returns
+// constant.ErrInvalidMFACode when the code is wrong or was used before.
+func (u *UserManager) useMFACode(ctx context.Context, user *entity.User, code string, now time.Time) error {
+	code = strings.ReplaceAll(strings.TrimSpace(code), " ", "")
+	if code == "" {
+		return constant.ErrInvalidMFACode
+	}
+	if !isTOTPCode(code) {
+		return u.useRecoveryCode(ctx, user, code, now)
+	}
+
+	secret, err := u.mfaBox.Open(user.TOTPSecret, user.ID[:])
+	if err != nil {
+		return err
+	}
+	step, err := totp.Validate(secret, code, now)
+	if err != nil || step <= user.TOTPLastStep {
+		return constant.ErrInvalidMFACode
+	}
+	// Using the step only when it is later than the last one keeps codes
+	// single-use when they are presented twice at once.
+	err = u.mfaRepo.UseTOTPStep(ctx, user.ID, step)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return constant.ErrInvalidMFACode
+	}
+	return err
+}
+
+func (u *UserManager) useRecoveryCode(ctx context.Context, user *entity.User, code string, now time.Time) error {
+	err := u.mfaRepo.UseRecoveryCode(ctx, user.ID, hashRecoveryCode(code), now)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return constant.ErrInvalidMFACode
+	}
+	if err != nil {
+		return err
+	}
+	logrus.Infof("User %s logged in with a recovery code", user.ID)
+	u.recordAudit(ctx, user.ID, entity.AuditRecoveryCodeUsed, "")
+	return nil
+}
+
+func isTOTPCode(code string) bool {
+	if len(code) != totp.Digits {
+		return false
+	}
+	for _, r := range code {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// generateRecoveryCodes returns a new set of recovery codes, formatted in
+// groups of four characters, and the hashes to store of them.
+func generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+	for range recoveryCodeCount {
+		b := make([]byte, recoveryCodeSize)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, err
+		}
+		raw := strings.ToLower(recoveryCodeEncoding.EncodeToString(b))
+		var groups []string
+		for i := 0; i < len(raw); i += 4 {
+			groups = append(groups, raw[i:min(i+4, len(raw))])
+		}
+		codes = append(codes, strings.Join(groups, "-"))
+		hashes = append(hashes, hashRecoveryCode(raw))
+	}
+	return codes, hashes, nil
+}
+
+// hashRecoveryCode hashes a recovery code however it was typed: in any case,
+// with or without dashes.
+func hashRecoveryCode(code string) string {
+	code = strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(code))
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,305 @@
+package user
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/a1y/doc-formatter/internal/auth/domain/constant"
+	"github.com/a1y/doc-formatter/internal/auth/domain/entity"
+	"github.com/a1y/doc-formatter/internal/auth/infra/memory"
+	jwtutil "github.com/a1y/doc-formatter/internal/auth/util/jwt"
+	"github.com/a1y/doc-formatter/internal/auth/util/secretbox"
+	"github.com/a1y/doc-formatter/internal/auth/util/totp"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type MockMFARepository struct {
+	mock.Mock
+}
+
+func (m *MockMFARepository) SetTOTPSecret(ctx context.Context, userID uuid.UUID, secret []byte) error {
+	args := m.Called(ctx, userID, secret)
+	return args.Error(0)
+}
+
+func (m *MockMFARepository) EnableTOTP(ctx context.Context, userID uuid.UUID, step int64, codeHashes []string) error {
+	args := m.Called(ctx, userID, step, codeHashes)
+	return args.Error(0)
+}
+
+func (m *MockMFARepository) UseTOTPStep(ctx context.Context, userID uuid.UUID, step int64) error {
+	args := m.Called(ctx, userID, step)
+	return args.Error(0)
+}
+
+func (m *MockMFARepository) UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string, now time.Time) error {
+	args := m.Called(ctx, userID, codeHash, now)
+	return args.Error(0)
+}
+
+func (m *MockMFARepository) ResetTOTP(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func newMFAManager(t *testing.T) (*UserManager, *MockUserRepository, *MockMFARepository, *MockAuditRepository) {
+	userManager, mockRepo, auditRepo := newAccountManager(t, nil)
+	mfaRepo := new(MockMFARepository)
+	userManager.SetMFA(mfaRepo, testBox(t), MFAConfig{Issuer: "Test"})
+	return userManager, mockRepo, mfaRepo, auditRepo
+}
+
+func testBox(t *testing.T) *secretbox.Box {
+	box, err := secretbox.New(bytes.Repeat([]byte{7}, secretbox.KeySize))
+	require.NoError(t, err)
+	return box
+}
+
+// mfaUser returns a user with two-factor authentication enabled, and their
+// TOTP secret.
+func mfaUser(t *testing.T, id uuid.UUID, email, password string) (*entity.User, []byte) {
+	user := hashedUser(t, id, email, password)
+	secret, err := totp.GenerateSecret()
+	require.NoError(t, err)
+	user.TOTPSecret, err = testBox(t).Seal(secret, id[:])
+	require.NoError(t, err)
+	user.MFAEnabled = true
+	return user, secret
+}
+
+func TestEnrollTOTP(t *testing.T) {
+	userID := uuid.New()
+	email := "test@example.com"
+
+	t.Run("Success", func(t *testing.T) {
+		userManager, mockRepo, mfaRepo, _ := newMFAManager(t)
+		mockRepo.On("GetByID", mock.Anything, userID).Return(hashedUser(t, userID, email, "password123"), nil)
+		var sealed []byte
+		mfaRepo.On("SetTOTPSecret", mock.Anything, userID, mock.Anything).Run(func(args mock.Arguments) {
+			sealed = args.Get(2).([]byte)
+		}).Return(nil)
+
+		secret, uri, err := userManager.EnrollTOTP(context.Background(), userID)
+		require.NoError(t, err)
+		assert.Contains(t, uri, "otpauth://totp/Test:test@example.com?")
+		assert.Contains(t, uri, "secret="+secret)
+
+		// The secret is stored sealed, bound to the user.
+		assert.NotContains(t, string(sealed), secret)
+		opened, err := testBox(t).Open(sealed, userID[:])
+		require.NoError(t, err)
+		assert.Equal(t, secret, totp.EncodeSecret(opened))
+	})
+
+	t.Run("AlreadyEnabled", func(t *testing.T) {
+		userManager, mockRepo, mfaRepo, _ := newMFAManager(t)
+		user, _ := mfaUser(t, userID, email, "password123")
+		mockRepo.On("GetByID", mock.Anything, userID).Return(user, nil)
+
+		_, _, err := userManager.EnrollTOTP(context.Background(), userID)
+		assert.ErrorIs(t, err, constant.ErrMFAAlreadyEnabled)
+		mfaRepo.AssertNotCalled(t, "SetTOTPSecret", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Unavailable", func(t *testing.T) {
+		userManager, _, _ := newAccountManager(t, nil)
+
+		_, _, err := userManager.EnrollTOTP(context.Background(), userID)
+		assert.ErrorIs(t, err, constant.ErrMFAUnavailable)
+	})
+}
+
+func TestConfirmTOTP(t *testing.T) {
+	userID := uuid.New()
+	email := "test@example.com"
+	enrolled := func(t *testing.T) (*entity.User, []byte) {
+		user, secret := mfaUser(t, userID, email, "password123")
+		user.MFAEnabled = false
+		return user, secret
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		userManager, mockRepo, mfaRepo, auditRepo := newMFAManager(t)
+		user, secret := enrolled(t)
+		mockRepo.On("GetByID", mock.Anything, userID).Return(user, nil)
+		var hashes []string
+		mfaRepo.On("EnableTOTP", mock.Anything, userID, totp.Step(time.Now()), mock.Anything).Run(func(args mock.Arguments) {
+			hashes = args.Get(3).([]string)
+		}).Return(nil)
+		auditRepo.On("Create", mock.Anything, auditAction(entity.AuditMFAEnabled)).Return(nil)
+
+		codes, err := userManager.ConfirmTOTP(context.Background(), userID, totp.Code(secret, time.Now()))
+		require.NoError(t, err)
+		require.Len(t, codes, recoveryCodeCount)
+		assert.Regexp(t, `^[a-z2-7]{4}-[a-z2-7]{4}-[a-z2-7]{4}-[a-z2-7]{4}$`, codes[0])
+		// Only hashes of the codes are stored.
+		require.Len(t, hashes, recoveryCodeCount)
+		assert.NotContains(t, hashes, codes[0])
+		assert.Contains(t, hashes, hashRecoveryCode(codes[0]))
+		auditRepo.AssertExpectations(t)
+	})
+
+	t.Run("InvalidCode", func(t *testing.T) {
+		userManager, mockRepo, mfaRepo, _ := newMFAManager(t)
+		user, secret := enrolled(t)
+		mockRepo.On("GetByID", mock.Anything, userID).Return(user, nil)
+
+		_, err := userManager.ConfirmTOTP(context.Background(), userID, totp.Code(secret, time.Now().Add(-time.Hour)))
+		assert.ErrorIs(t, err, constant.ErrInvalidMFACode)
+		mfaRepo.AssertNotCalled(t, "EnableTOTP", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("NotEnrolled", func(t *testing.T) {
+		userManager, mockRepo, _, _ := newMFAManager(t)
+		mockRepo.On("GetByID", mock.Anything, userID).Return(hashedUser(t, userID, email, "password123"), nil)
+
+		_, err := userManager.ConfirmTOTP(context.Background(), userID, "123456")
+		assert.ErrorIs(t, err, constant.ErrMFANotEnrolled)
+	})
+
+	t.Run("Raced", func(t *testing.T) {
+		userManager, mockRepo, mfaRepo, _ := newMFAManager(t)
+		user, secret := enrolled(t)
+		mockRepo.On("GetByID", mock.Anything, userID).Return(user, nil)
+		mfaRepo.On("EnableTOTP", mock.Anything, userID, mock.Anything, mock.Anything).Return(gorm.ErrRecordNotFound)
+
+		_, err := userManager.ConfirmTOTP(context.Background(), userID, totp.Code(secret, time.Now()))
+		assert.ErrorIs(t, err, constant.ErrMFAAlreadyEnabled)
+	})
+}
+
+func TestLoginUser_MFA(t *testing.T) {
+	userID := uuid.New()
+	email := "test@example.com"
+
+	t.Run("Challenge", func(t *testing.T) {
+		userManager, mockRepo, _, _ := newMFAManager(t)
+		user, _ := mfaUser(t, userID, email, "password123")
+		mockRepo.On("GetByEmail", mock.Anything, email).Return(user, nil)
+
+		token, _, err := userManager.LoginUser(context.Background(), &entity.User{Email: email, Password: "password123"}, "")
+		assert.Nil(t, token)
+		var mfaErr *MFARequiredError
+		require.ErrorAs(t, err, &mfaErr)
+		assert.ErrorIs(t, err, constant.ErrMFARequired)
+		assert.Greater(t, mfaErr.ExpiresAt, time.Now().Unix())
+
+		// The challenge token is no access token.
+		gotID, _, err := userManager.jwtClaims.ParsePurposeToken(mfaErr.Token, jwtutil.PurposeMFA)
+		require.NoError(t, err)
+		assert.Equal(t, userID, gotID)
+		_, _, _, err = userManager.jwtClaims.ParseAccessToken(mfaErr.Token)
+		assert.Error(t, err)
+	})
+
+	t.Run("Unavailable", func(t *testing.T) {
+		userManager, mockRepo, _ := newAccountManager(t, nil)
+		user, _ := mfaUser(t, userID, email, "password123")
+		mockRepo.On("GetByEmail", mock.Anything, email).Return(user, nil)
+
+		token, _, err := userManager.LoginUser(context.Background(), &entity.User{Email: email, Password: "password123"}, "")
+		assert.Nil(t, token)
+		assert.ErrorIs(t, err, constant.ErrMFAUnavailable)
+	})
+}
+
+func TestVerifyMFA(t *testing.T) {
+	userID := uuid.New()
+	email := "test@example.com"
+	challenge := func(t *testing.T, userManager *UserManager) string {
+		token, _, err := userManager.jwtClaims.GeneratePurposeToken(userID, email, jwtutil.PurposeMFA, time.Minute)
+		require.NoError(t, err)
+		return token
+	}
+
+	t.Run("TOTPCode", func(t *testing.T) {
+		userManager, mockRepo, mfaRepo, _ := newMFAManager(t)
+		user, secret := mfaUser(t, userID, email, "password123")
+		mockRepo.On("GetByID", mock.Anything, userID).Return(user, nil)
+		mfaRepo.On("UseTOTPStep", mock.Anything, userID, totp.Step(time.Now())).Return(nil)
+
+		token, exp, err := userManager.VerifyMFA(context.Background(), challenge(t, userManager), totp.Code(secret, time.Now()), "")
+		require.NoError(t, err)
+		require.NotNil(t, token)
+		assert.Greater(t, exp, time.Now().Unix())
+		gotID, _, sessionVersion, err := userManager.jwtClaims.ParseAccessToken(*token)
+		require.NoError(t, err)
+		assert.Equal(t, userID, gotID)
+		assert.Equal(t, user.SessionVersion, sessionVersion)
+	})
+
+	t.Run("UsedTOTPCode", func(t *testing.T) {
+		userManager, mockRepo, mfaRepo, _ := newMFAManager(t)
+		user, secret := mfaUser(t, userID, email, "password123")
+		user.TOTPLastStep = totp.Step(time.Now()) + totp.Skew
+		mockRepo.On("GetByID", mock.Anything, userID).Return(user, nil)
+
+		_, _, err := userManager.VerifyMFA(context.Background(), challenge(t, userManager), totp.Code(secret, time.Now()), "")
+		assert.ErrorIs(t, err, constant.ErrInvalidMFACode)
+		mfaRepo.AssertNotCalled(t, "UseTOTPStep", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("RecoveryCode", func(t *testing.T) {
+		userManager, mockRepo, mfaRepo, auditRepo := newMFAManager(t)
+		user, _ := mfaUser(t, userID, email, "password123")
+		mockRepo.On("GetByID", mock.Anything, userID).Return(user, nil)
+		mfaRepo.On("UseRecoveryCode", mock.Anything, userID, hashRecoveryCode("abcd-efgh-ijkl-mnop"), mock.Anything).Return(nil)
+		auditRepo.On("Create", mock.Anything, auditAction(entity.AuditRecoveryCodeUsed)).Return(nil)
+
+		token, _, err := userManager.VerifyMFA(context.Background(), challenge(t, userManager), "ABCDEFGH IJKLMNOP", "")
+		require.NoError(t, err)
+		assert.NotNil(t, token)
+		auditRepo.AssertExpectations(t)
+	})
+
+	t.Run("UnknownRecoveryCode", func(t *testing.T) {
+		userManager, mockRepo, mfaRepo, _ := newMFAManager(t)
+		user, _ := mfaUser(t, userID, email, "password123")
+		mockRepo.On("GetByID", mock.Anything, userID).Return(user, nil)
+		mfaRepo.On("UseRecoveryCode", mock.Anything, userID, mock.Anything, mock.Anything).Return(gorm.ErrRecordNotFound)
+
+		_, _, err := userManager.VerifyMFA(context.Background(), challenge(t, userManager), "abcd-efgh-ijkl-mnop", "")
+		assert.ErrorIs(t, err, constant.ErrInvalidMFACode)
+	})
+
+	t.Run("InvalidToken", func(t *testing.T) {
+		userManager, _, _, _ := newMFAManager(t)
+		accessToken, _, err := userManager.jwtClaims.GenerateSessionToken(userID, email, 4, time.Minute)
+		require.NoError(t, err)
+
+		_, _, err = userManager.VerifyMFA(context.Background(), accessToken, "123456", "")
+		assert.ErrorIs(t, err, constant.ErrInvalidMFAToken)
+	})
+
+	t.Run("Throttled", func(t *testing.T) {
+		userManager, mockRepo, _, _ := newMFAManager(t)
+		userManager.SetLoginThrottle(memory.NewLoginAttemptRepository(), LoginThrottleConfig{MaxAccountFailures: 3})
+		user, secret := mfaUser(t, userID, email, "password123")
+		user.TOTPLastStep = totp.Step(time.Now()) + totp.Skew
+		mockRepo.On("GetByID", mock.Anything, userID).Return(user, nil)
+		mockRepo.On("GetByEmail", mock.Anything, email).Return(user, nil)
+		token := challenge(t, userManager)
+
+		wrongCode := func() {
+			_, _, err := userManager.VerifyMFA(context.Background(), token, totp.Code(secret, time.Now()), "")
+			require.ErrorIs(t, err, constant.ErrInvalidMFACode)
+		}
+		wrongCode()
+		wrongCode()
+
+		// Logging in with the password again does not lift throttling of
+		// guesses at codes.
+		_, _, err := userManager.LoginUser(context.Background(), &entity.User{Email: email, Password: "password123"}, "")
+		require.ErrorIs(t, err, constant.ErrMFARequired)
+		wrongCode()
+
+		_, _, err = userManager.VerifyMFA(context.Background(), token, totp.Code(secret, time.Now()), "")
+		assert.ErrorIs(t, err, constant.ErrTooManyLoginAttempts)
+	})
+}
@@ -0,0 +1,167 @@
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"time"
+
+	"github.com/a1y/doc-formatter/internal/auth/domain/constant"
+	"github.com/a1y/doc-formatter/internal/auth/domain/entity"
+	jwtutil "github.com/a1y/doc-formatter/internal/auth/util/jwt"
+	"github.com/a1y/doc-formatter/internal/auth/util/oidc"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// oidcLoginTTL is how long users have to log in at an identity provider.
+const oidcLoginTTL = 10 * time.Minute
+
+// StartOIDCLogin starts a login with the identity provider named provider. It
+// returns the URL of the provider to send the user to, and a token to finish
+// the login with, along with its expiry. The token must only be kept by the
+// browser of the user, as it carries the PKCE code verifier of the login.
+func (u *UserManager) StartOIDCLogin(ctx context.Context, provider string) (string, string, int64, error) {
+	p, err := u.oidcProvider(provider)
+	if err != nil {
+		return "", "", 0, err
+	}
+	login := jwtutil.OIDCLogin{
+		Provider: p.Name(),
+		State:    rand.Text(),
+		Nonce:    rand.Text(),
+		Verifier: oidc.NewVerifier(),
+	}
+	authURL, err := p.AuthCodeURL(ctx, login.State, login.Nonce, login.Verifier)
+	if err != nil {
+		return "", "", 0, err
+	}
+	token, exp, err := u.jwtClaims.GenerateOIDCLoginToken(login, oidcLoginTTL)
+	if err != nil {
+		return "", "", 0, err
+	}
+	return authURL, token, exp, nil
+}
+
+// FinishOIDCLogin finishes a login with an identity provider with the
+// authorization code and state the provider sent the user back with, and the
+// token StartOIDCLogin returned. Users are found by their identity at the
+// provider. Identities not seen before are linked to the user with the same
+// email address, or to a new user when there is none, as long as the
+// provider verified the address. Users with two-factor authentication get a
+// *MFARequiredError instead of an access token, to complete the login with
+// VerifyMFA.
+func (u *UserManager) FinishOIDCLogin(ctx context.Context, provider, code, state, loginToken string) (*string, int64, error) {
+	p, err := u.oidcProvider(provider)
+	if err != nil {
+		return nil, 0, err
+	}
+	login, err := u.jwtClaims.ParseOIDCLoginToken(loginToken)
+	if errors.Is(err, jwtutil.ErrInvalidToken) {
+		return nil, 0, constant.ErrInvalidOIDCLogin
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	// The state binds the redirect to the browser the login started in, so
+	// that nobody can log a user in to their own account.
+	if login.Provider != p.Name() || subtle.ConstantTimeCompare([]byte(login.State), []byte(state)) != 1 {
+		return nil, 0, constant.ErrInvalidOIDCLogin
+	}
+
+	claims, err := p.Exchange(ctx, code, login.Verifier, login.Nonce)
+	if errors.Is(err, oidc.ErrInvalidCode) || errors.Is(err, oidc.ErrInvalidIDToken) {
+		logrus.Warnf("Login with identity provider %s refused: %v", p.Name(), err)
+		return nil, 0, constant.ErrInvalidOIDCLogin
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	user, err := u.userForIdentity(ctx, p.Name(), claims)
+	if err != nil {
+		return nil, 0, err
+	}
+	if user.Disabled {
+		return nil, 0, constant.ErrAccountDisabled
+	}
+
+	if u.verification.RequiredForLogin && !user.IsVerified {
+		return nil, 0, constant.ErrEmailNotVerified
+	}
+	if user.MFAEnabled {
+		return nil, 0, u.mfaChallenge(user)
+	}
+	return u.accessToken(user)
+}
+
+func (u *UserManager) oidcProvider(name string) (*oidc.Provider, error) {
+	p, ok := u.oidcProviders[name]
+	if !ok || u.identityRepo == nil {
+		return nil, constant.ErrOIDCProviderNotFound
+	}
+	return p, nil
+}
+
+// userForIdentity returns the user the identity with claims at provider is
+// linked to, linking it first when it is not.
+func (u *UserManager) userForIdentity(ctx context.Context, provider string, claims *oidc.Claims) (*entity.User, error) {
+	identity, err := u.identityRepo.Get(ctx, provider, claims.Subject)
+	if err == nil {
+		return u.GetUserByID(ctx, identity.UserID)
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	if claims.Email == "" || !claims.EmailVerified {
+		return nil, constant.ErrOIDCEmailNotVerified
+	}
+	identity = &entity.Identity{
+		Provider: provider,
+		Subject:  claims.Subject,
+		Email:    claims.Email,
+	}
+	user, err := u.userRepo.GetByEmail(ctx, claims.Email)
+	switch {
+	case err == nil:
+		// Whoever signed up with an address they have not verified may not
+		// own it, and would keep access through their password.
+		if !user.IsVerified {
+			return nil, constant.ErrOIDCAccountNotVerified
+		}
+		identity.UserID = user.ID
+		if err := u.identityRepo.Create(ctx, identity); err != nil {
+			return nil, err
+		}
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		user, err = u.newOIDCUser(claims.Email)
+		if err != nil {
+			return nil, err
+		}
+		if err := u.identityRepo.CreateWithUser(ctx, user, identity); err != nil {
+			return nil, err
+		}
+		logrus.Infof("Created user %s for identity provider %s", user.ID, provider)
+	default:
+		return nil, err
+	}
+	logrus.Infof("Linked identity at %s to user %s", provider, user.ID)
+	u.recordAudit(ctx, user.ID, entity.AuditIdentityLinked, provider)
+	return user, nil
+}
+
+// newOIDCUser returns a user with email, verified by an identity provider.
+// Their password is random and never shown: they log in with the provider,
+// or set a password by resetting it.
+func (u *UserManager) newOIDCUser(email string) (*entity.User, error) {
+	hashedPassword, err := u.passwordHasher().HashPassword(rand.Text(), nil)
+	if err != nil {
+		return nil, err
+	}
+	return &entity.User{
+		Email:      email,
+		Password:   hashedPassword,
+		IsVerified: true,
+	}, nil
+}
@@ -0,0 +1,222 @@
+package user
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/a1y/doc-formatter/internal/auth/domain/constant"
+	"github.com/a1y/doc-formatter/internal/auth/domain/entity"
+	"github.com/a1y/doc-formatter/internal/auth/util/oidc"
+	"github.com/a1y/doc-formatter/internal/auth/util/oidc/oidctest"
+	"github.com/a1y/doc-formatter/pkg/credentials"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type MockIdentityRepository struct {
+	mock.Mock
+}
+
+func (m *MockIdentityRepository) Get(ctx context.Context, provider, subject string) (*entity.Identity, error) {
+	args := m.Called(ctx, provider, subject)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Identity), args.Error(1)
+}
+
+func (m *MockIdentityRepository) Create(ctx context.Context, i *entity.Identity) error {
+	args := m.Called(ctx, i)
+	return args.Error(0)
+}
+
+func (m *MockIdentityRepository) CreateWithUser(ctx context.Context, u *entity.User, i *entity.Identity) error {
+	args := m.Called(ctx, u, i)
+	return args.Error(0)
+}
+
+func newOIDCManager(t *testing.T) (*UserManager, *MockUserRepository, *MockIdentityRepository, *MockAuditRepository, *oidctest.Server) {
+	userManager, mockRepo, auditRepo := newAccountManager(t, nil)
+	idp := oidctest.NewServer("client", "secret")
+	t.Cleanup(idp.Close)
+	p, err := oidc.NewProvider(oidc.ProviderConfig{
+		Name:         "corp",
+		Issuer:       idp.Issuer(),
+		ClientID:     "client",
+		ClientSecret: "secret",
+		RedirectURL:  "https://gateway.example.com/api/v1/auth/oidc/corp/callback",
+	}, nil)
+	require.NoError(t, err)
+	identityRepo := new(MockIdentityRepository)
+	userManager.SetOIDC(identityRepo, []*oidc.Provider{p})
+	return userManager, mockRepo, identityRepo, auditRepo, idp
+}
+
+// loginAt starts a login with the provider as user and returns the code and
+// state the provider redirects back with, and the login token.
+func loginAt(t *testing.T, userManager *UserManager, idp *oidctest.Server, user oidctest.User) (string, string, string) {
+	t.Helper()
+	idp.SetUser(user)
+	authURL, loginToken, exp, err := userManager.StartOIDCLogin(context.Background(), "corp")
+	require.NoError(t, err)
+	assert.NotZero(t, exp)
+
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	resp, err := client.Get(authURL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	location, err := url.Parse(resp.Header.Get("Location"))
+	require.NoError(t, err)
+	return location.Query().Get("code"), location.Query().Get("state"), loginToken
+}
+
+func TestStartOIDCLogin(t *testing.T) {
+	userManager, _, _, _, idp := newOIDCManager(t)
+
+	authURL, loginToken, _, err := userManager.StartOIDCLogin(context.Background(), "corp")
+	require.NoError(t, err)
+	assert.Contains(t, authURL, idp.URL+"/authorize?")
+
+	// The verifier is kept in the login token, not sent to the provider.
+	login, err := userManager.jwtClaims.ParseOIDCLoginToken(loginToken)
+	require.NoError(t, err)
+	assert.Equal(t, "corp", login.Provider)
+	assert.Contains(t, authURL, "state="+login.State)
+	assert.Contains(t, authURL, "nonce="+login.Nonce)
+	assert.NotContains(t, authURL, login.Verifier)
+
+	_, _, _, err = userManager.StartOIDCLogin(context.Background(), "other")
+	assert.ErrorIs(t, err, constant.ErrOIDCProviderNotFound)
+
+	// Without a repository to link identities in, no provider is available.
+	userManager.SetOIDC(nil, nil)
+	_, _, _, err = userManager.StartOIDCLogin(context.Background(), "corp")
+	assert.ErrorIs(t, err, constant.ErrOIDCProviderNotFound)
+}
+
+func TestFinishOIDCLogin(t *testing.T) {
+	userID := uuid.New()
+	email := "test@example.com"
+	idpUser := oidctest.User{Subject: "sub-1", Email: email, EmailVerified: true}
+	ctx := context.Background()
+
+	t.Run("LinkedIdentity", func(t *testing.T) {
+		userManager, mockRepo, identityRepo, _, idp := newOIDCManager(t)
+		code, state, loginToken := loginAt(t, userManager, idp, idpUser)
+		identityRepo.On("Get", mock.Anything, "corp", "sub-1").Return(&entity.Identity{UserID: userID, Provider: "corp", Subject: "sub-1"}, nil)
+		mockRepo.On("GetByID", mock.Anything, userID).Return(hashedUser(t, userID, email, "password123"), nil)
+
+		token, _, err := userManager.FinishOIDCLogin(ctx, "corp", code, state, loginToken)
+		require.NoError(t, err)
+		gotID, gotEmail, sessionVersion, err := userManager.jwtClaims.ParseAccessToken(*token)
+		require.NoError(t, err)
+		assert.Equal(t, userID, gotID)
+		assert.Equal(t, email, gotEmail)
+		assert.Equal(t, 4, sessionVersion)
+		identityRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("LinkByVerifiedEmail", func(t *testing.T) {
+		userManager, mockRepo, identityRepo, auditRepo, idp := newOIDCManager(t)
+		code, state, loginToken := loginAt(t, userManager, idp, idpUser)
+		identityRepo.On("Get", mock.Anything, "corp", "sub-1").Return(nil, gorm.ErrRecordNotFound)
+		mockRepo.On("GetByEmail", mock.Anything, email).Return(hashedUser(t, userID, email, "password123"), nil)
+		identityRepo.On("Create", mock.Anything, &entity.Identity{UserID: userID, Provider: "corp", Subject: "sub-1", Email: email}).Return(nil)
+		auditRepo.On("Create", mock.Anything, auditAction(entity.AuditIdentityLinked)).Return(nil)
+
+		token, _, err := userManager.FinishOIDCLogin(ctx, "corp", code, state, loginToken)
+		require.NoError(t, err)
+		assert.NotEmpty(t, *token)
+		identityRepo.AssertExpectations(t)
+		auditRepo.AssertExpectations(t)
+	})
+
+	t.Run("NewUser", func(t *testing.T) {
+		userManager, mockRepo, identityRepo, auditRepo, idp := newOIDCManager(t)
+		code, state, loginToken := loginAt(t, userManager, idp, idpUser)
+		identityRepo.On("Get", mock.Anything, "corp", "sub-1").Return(nil, gorm.ErrRecordNotFound)
+		mockRepo.On("GetByEmail", mock.Anything, email).Return(nil, gorm.ErrRecordNotFound)
+		var created *entity.User
+		identityRepo.On("CreateWithUser", mock.Anything, mock.Anything, &entity.Identity{Provider: "corp", Subject: "sub-1", Email: email}).Run(func(args mock.Arguments) {
+			created = args.Get(1).(*entity.User)
+			created.ID = userID
+		}).Return(nil)
+		auditRepo.On("Create", mock.Anything, auditAction(entity.AuditIdentityLinked)).Return(nil)
+
+		token, _, err := userManager.FinishOIDCLogin(ctx, "corp", code, state, loginToken)
+		require.NoError(t, err)
+		gotID, _, _, err := userManager.jwtClaims.ParseAccessToken(*token)
+		require.NoError(t, err)
+		assert.Equal(t, userID, gotID)
+
+		// New users are verified by the provider, and have a password
+		// nobody knows.
+		require.NotNil(t, created)
+		assert.Equal(t, email, created.Email)
+		assert.True(t, created.IsVerified)
+		ok, err := credentials.Compare("", created.Password)
+		require.NoError(t, err)
+		assert.False(t, ok)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("EmailNotVerifiedByProvider", func(t *testing.T) {
+		userManager, mockRepo, identityRepo, _, idp := newOIDCManager(t)
+		code, state, loginToken := loginAt(t, userManager, idp, oidctest.User{Subject: "sub-1", Email: email})
+		identityRepo.On("Get", mock.Anything, "corp", "sub-1").Return(nil, gorm.ErrRecordNotFound)
+
+		_, _, err := userManager.FinishOIDCLogin(ctx, "corp", code, state, loginToken)
+		assert.ErrorIs(t, err, constant.ErrOIDCEmailNotVerified)
+		mockRepo.AssertNotCalled(t, "GetByEmail", mock.Anything, mock.Anything)
+	})
+
+	t.Run("UnverifiedAccount", func(t *testing.T) {
+		userManager, mockRepo, identityRepo, _, idp := newOIDCManager(t)
+		code, state, loginToken := loginAt(t, userManager, idp, idpUser)
+		identityRepo.On("Get", mock.Anything, "corp", "sub-1").Return(nil, gorm.ErrRecordNotFound)
+		user := hashedUser(t, userID, email, "password123")
+		user.IsVerified = false
+		mockRepo.On("GetByEmail", mock.Anything, email).Return(user, nil)
+
+		_, _, err := userManager.FinishOIDCLogin(ctx, "corp", code, state, loginToken)
+		assert.ErrorIs(t, err, constant.ErrOIDCAccountNotVerified)
+		identityRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("MFA", func(t *testing.T) {
+		userManager, mockRepo, identityRepo, _, idp := newOIDCManager(t)
+		userManager.SetMFA(new(MockMFARepository), testBox(t), MFAConfig{})
+		code, state, loginToken := loginAt(t, userManager, idp, idpUser)
+		identityRepo.On("Get", mock.Anything, "corp", "sub-1").Return(&entity.Identity{UserID: userID}, nil)
+		user, _ := mfaUser(t, userID, email, "password123")
+		mockRepo.On("GetByID", mock.Anything, userID).Return(user, nil)
+
+		token, _, err := userManager.FinishOIDCLogin(ctx, "corp", code, state, loginToken)
+		assert.Nil(t, token)
+		var mfaErr *MFARequiredError
+		require.ErrorAs(t, err, &mfaErr)
+		assert.NotEmpty(t, mfaErr.Token)
+	})
+
+	t.Run("InvalidLogin", func(t *testing.T) {
+		userManager, _, identityRepo, _, idp := newOIDCManager(t)
+		code, state, loginToken := loginAt(t, userManager, idp, idpUser)
+
+		_, _, err := userManager.FinishOIDCLogin(ctx, "corp", code, "other-state", loginToken)
+		assert.ErrorIs(t, err, constant.ErrInvalidOIDCLogin, "state of another login")
+		_, _, err = userManager.FinishOIDCLogin(ctx, "corp", code, state, "not-a-token")
+		assert.ErrorIs(t, err, constant.ErrInvalidOIDCLogin, "invalid login token")
+		_, _, err = userManager.FinishOIDCLogin(ctx, "corp", "not-a-code", state, loginToken)
+		assert.ErrorIs(t, err, constant.ErrInvalidOIDCLogin, "invalid code")
+		_, _, err = userManager.FinishOIDCLogin(ctx, "other", code, state, loginToken)
+		assert.ErrorIs(t, err, constant.ErrOIDCProviderNotFound)
+		identityRepo.AssertNotCalled(t, "Get", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,164 @@
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/a1y/doc-formatter/internal/auth/domain/constant"
+	"github.com/a1y/doc-formatter/internal/auth/domain/entity"
+	"github.com/a1y/doc-formatter/internal/auth/util/mailer"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// DefaultPasswordResetTokenTTL is how long password reset tokens are valid
+// when the password reset config sets no TTL.
+const DefaultPasswordResetTokenTTL = time.Hour
+
+// resetTokenSize is the number of random bytes of a password reset token.
+const resetTokenSize = 32
+
+// RequestPasswordReset emails a password reset token to email. It answers
+// the same whether or not email belongs to an account, and whether or not
+// the email could be sent, so that it cannot be used to find out who has an
+// account.
+func (u *UserManager) RequestPasswordReset(ctx context.Context, email string) error {
+	user, err := u.userRepo.GetByEmail(ctx, email)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := u.sendPasswordReset(ctx, user); err != nil {
+		logrus.Warnf("Failed to send password reset email to user %s: %v", user.ID, err)
+	}
+	return nil
+}
+
+// ResetPassword sets the password of the user a password reset token was
+// sent to, and revokes every session and API key of the user. Tokens can
+// only be used once.
+func (u *UserManager) ResetPassword(ctx context.Context, token, password string) error {
+	if password == "" {
+		return constant.ErrPasswordRequired
+	}
+	if u.resetRepo == nil || token == "" {
+		return constant.ErrInvalidResetToken
+	}
+	if err := u.checkResetPasswordPolicy(ctx, token, password); err != nil {
+		return err
+	}
+	hashedPassword, err := u.passwordHasher().HashPassword(password, nil)
+	if err != nil {
+		return err
+	}
+	userID, err := u.resetRepo.ResetPassword(ctx, hashResetToken(token), hashedPassword, time.Now())
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return constant.ErrInvalidResetToken
+	}
+	if err != nil {
+		return err
+	}
+	if err := u.revokeAPIKeys(ctx, userID); err != nil {
+		return err
+	}
+	logrus.Infof("Password of user %s reset, sessions and API keys revoked", userID)
+	u.recordAudit(ctx, userID, entity.AuditPasswordReset, "")
+	return nil
+}
+
+// checkResetPasswordPolicy checks password against the password policy for
+// the user the reset token was sent to. The token is checked again when it
+// is used.
+func (u *UserManager) checkResetPasswordPolicy(ctx context.Context, token, password string) error {
+	if u.policy == nil {
+		return nil
+	}
+	reset, err := u.resetRepo.GetValid(ctx, hashResetToken(token), time.Now())
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return constant.ErrInvalidResetToken
+	}
+	if err != nil {
+		return err
+	}
+	user, err := u.userRepo.GetByID(ctx, reset.UserID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return constant.ErrInvalidResetToken
+	}
+	if err != nil {
+		return err
+	}
+	return u.checkPasswordPolicy(password, user.Email)
+}
+
+// sendPasswordReset stores a new password reset token for user and emails it.
+func (u *UserManager) sendPasswordReset(ctx context.Context, user *entity.User) error {
+	if u.resetRepo == nil || u.mailer == nil {
+		return nil
+	}
+	ttl := u.passwordReset.TokenTTL
+	if ttl <= 0 {
+		ttl = DefaultPasswordResetTokenTTL
+	}
+	token, err := newResetToken()
+	if err != nil {
+		return err
+	}
+	if err := u.resetRepo.Create(ctx, &entity.PasswordReset{
+		UserID:    user.ID,
+		TokenHash: hashResetToken(token),
+		ExpiresAt: time.Now().Add(ttl),
+	}); err != nil {
+		return err
+	}
+	body, err := passwordResetBody(u.passwordReset.URL, token, ttl)
+	if err != nil {
+		return err
+	}
+	return u.mailer.Send(ctx, &mailer.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body:    body,
+	})
+}
+
+func passwordResetBody(link, token string, ttl time.Duration) (string, error) {
+	var b strings.Builder
+	b.WriteString("Someone asked to reset the password of your Doc Formatter account.\n\n")
+	if link == "" {
+		b.WriteString("To choose a new password, use this password reset token:\n\n")
+		b.WriteString(token + "\n\n")
+	} else {
+		tokenURL, err := tokenLink(link, token)
+		if err != nil {
+			return "", fmt.Errorf("invalid password reset url: %w", err)
+		}
+		b.WriteString("To choose a new password, open this link:\n\n")
+		b.WriteString(tokenURL + "\n\n")
+	}
+	fmt.Fprintf(&b, "It expires in %s and can be used once. Resetting your password signs you out everywhere. If you did not ask for it, you can ignore this email.\n", formatTTL(ttl))
+	return b.String(), nil
+}
+
+func newResetToken() (string, error) {
+	b := make([]byte, resetTokenSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashResetToken hashes a token for storage. Tokens are random and long, so
+// a fast unsalted hash is enough and lets resets be looked up by token.
+func hashResetToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,244 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/a1y/doc-formatter/internal/auth/domain/constant"
+	"github.com/a1y/doc-formatter/internal/auth/domain/entity"
+	jwtutil "github.com/a1y/doc-formatter/internal/auth/util/jwt"
+	"github.com/a1y/doc-formatter/internal/auth/util/passwordpolicy"
+	"github.com/a1y/doc-formatter/pkg/credentials"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type MockPasswordResetRepository struct {
+	mock.Mock
+}
+
+func (m *MockPasswordResetRepository) Create(ctx context.Context, r *entity.PasswordReset) error {
+	args := m.Called(ctx, r)
+	return args.Error(0)
+}
+
+func (m *MockPasswordResetRepository) GetValid(ctx context.Context, tokenHash string, now time.Time) (*entity.PasswordReset, error) {
+	args := m.Called(ctx, tokenHash, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.PasswordReset), args.Error(1)
+}
+
+func (m *MockPasswordResetRepository) ResetPassword(ctx context.Context, tokenHash, passwordHash string, now time.Time) (uuid.UUID, error) {
+	args := m.Called(ctx, tokenHash, passwordHash, now)
+	return args.Get(0).(uuid.UUID), args.Error(1)
+}
+
+func newResetManager(m *recordingMailer) (*UserManager, *MockUserRepository, *MockPasswordResetRepository) {
+	mockRepo := new(MockUserRepository)
+	resetRepo := new(MockPasswordResetRepository)
+	userManager := NewUserManager(mockRepo, jwtutil.TokenClaim{})
+	userManager.SetMailer(m)
+	userManager.SetPasswordReset(resetRepo, PasswordResetConfig{
+		TokenTTL: 30 * time.Minute,
+		URL:      "https://docs.example.com/reset",
+	})
+	return userManager, mockRepo, resetRepo
+}
+
+func TestRequestPasswordReset(t *testing.T) {
+	email := "test@example.com"
+
+	t.Run("Sent", func(t *testing.T) {
+		m := &recordingMailer{}
+		userManager, mockRepo, resetRepo := newResetManager(m)
+		userID := uuid.New()
+		mockRepo.On("GetByEmail", mock.Anything, email).Return(&entity.User{ID: userID, Email: email}, nil)
+		var stored *entity.PasswordReset
+		resetRepo.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			stored = args.Get(1).(*entity.PasswordReset)
+		}).Return(nil)
+
+		require.NoError(t, userManager.RequestPasswordReset(context.Background(), email))
+
+		require.Len(t, m.messages, 1)
+		assert.Equal(t, email, m.messages[0].To)
+		assert.Contains(t, m.messages[0].Body, "30 minutes")
+		token := tokenFrom(t, m.messages[0])
+		require.NotEmpty(t, token)
+		require.NotNil(t, stored)
+		assert.Equal(t, userID, stored.UserID)
+		assert.Equal(t, hashResetToken(token), stored.TokenHash)
+		assert.NotEqual(t, token, stored.TokenHash)
+		assert.WithinDuration(t, time.Now().Add(30*time.Minute), stored.ExpiresAt, time.Minute)
+	})
+
+	t.Run("UnknownEmail", func(t *testing.T) {
+		m := &recordingMailer{}
+		userManager, mockRepo, resetRepo := newResetManager(m)
+		mockRepo.On("GetByEmail", mock.Anything, email).Return(nil, gorm.ErrRecordNotFound)
+
+		assert.NoError(t, userManager.RequestPasswordReset(context.Background(), email))
+		assert.Empty(t, m.messages)
+		resetRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("MailerErrorHidden", func(t *testing.T) {
+		m := &recordingMailer{err: errors.New("smtp down")}
+		userManager, mockRepo, resetRepo := newResetManager(m)
+		mockRepo.On("GetByEmail", mock.Anything, email).Return(&entity.User{ID: uuid.New(), Email: email}, nil)
+		resetRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+		assert.NoError(t, userManager.RequestPasswordReset(context.Background(), email))
+	})
+}
+
+func TestResetPassword(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		userManager, _, resetRepo := newResetManager(&recordingMailer{})
+		userID := uuid.New()
+		var passwordHash string
+		resetRepo.On("ResetPassword", mock.Anything, hashResetToken("token"), mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				passwordHash = args.String(2)
+			}).
+			Return(userID, nil)
+		keyRepo := new(MockAPIKeyRepository)
+		keyRepo.On("RevokeAllByUser", mock.Anything, userID, mock.Anything).Return(nil).Once()
+		userManager.SetAPIKeyRepository(keyRepo)
+
+		require.NoError(t, userManager.ResetPassword(context.Background(), "token", "new-password"))
+		keyRepo.AssertExpectations(t)
+
+		ok, err := credentials.Compare("new-password", passwordHash)
+		require.NoError(t, err)
+		assert.True(t, ok)
+	})
+
+	t.Run("InvalidToken", func(t *testing.T) {
+		userManager, _, resetRepo := newResetManager(&recordingMailer{})
+		resetRepo.On("ResetPassword", mock.Anything, hashResetToken("used"), mock.Anything, mock.Anything).
+			Return(uuid.Nil, gorm.ErrRecordNotFound)
+
+		err := userManager.ResetPassword(context.Background(), "used", "new-password")
+		assert.Equal(t, constant.ErrInvalidResetToken, err)
+	})
+
+	t.Run("EmptyPassword", func(t *testing.T) {
+		userManager, _, _ := newResetManager(&recordingMailer{})
+
+		err := userManager.ResetPassword(context.Background(), "token", "")
+		assert.Equal(t, constant.ErrPasswordRequired, err)
+	})
+
+	t.Run("NotConfigured", func(t *testing.T) {
+		userManager := NewUserManager(new(MockUserRepository), jwtutil.TokenClaim{})
+
+		err := userManager.ResetPassword(context.Background(), "token", "new-password")
+		assert.Equal(t, constant.ErrInvalidResetToken, err)
+	})
+
+	t.Run("PolicyViolation", func(t *testing.T) {
+		userManager, mockRepo, resetRepo := newResetManager(&recordingMailer{})
+		userManager.SetPasswordPolicy(&passwordpolicy.Policy{MinLength: 8, RejectEmail: true})
+		userID := uuid.New()
+		resetRepo.On("GetValid", mock.Anything, hashResetToken("token"), mock.Anything).
+			Return(&entity.PasswordReset{UserID: userID}, nil)
+		mockRepo.On("GetByID", mock.Anything, userID).Return(&entity.User{ID: userID, Email: "alice@example.com"}, nil)
+
+		err := userManager.ResetPassword(context.Background(), "token", "alice-1")
+		var policyErr *passwordpolicy.Error
+		require.ErrorAs(t, err, &policyErr)
+		assert.Len(t, policyErr.Violations, 2)
+		resetRepo.AssertNotCalled(t, "ResetPassword", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("PolicyInvalidToken", func(t *testing.T) {
+		userManager, _, resetRepo := newResetManager(&recordingMailer{})
+		userManager.SetPasswordPolicy(&passwordpolicy.Policy{MinLength: 8})
+		resetRepo.On("GetValid", mock.Anything, hashResetToken("used"), mock.Anything).
+			Return(nil, gorm.ErrRecordNotFound)
+
+		err := userManager.ResetPassword(context.Background(), "used", "new-password")
+		assert.Equal(t, constant.ErrInvalidResetToken, err)
+	})
+}
+
+func TestValidateAccessToken(t *testing.T) {
+	_, tokenPath := setupTestPrivateKey(t)
+	claims := jwtutil.TokenClaim{TokenPath: tokenPath}
+	userID := uuid.New()
+
+	t.Run("Valid", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		userManager := NewUserManager(mockRepo, claims)
+		token, _, err := claims.GenerateSessionToken(userID, "test@example.com", 2, time.Minute)
+		require.NoError(t, err)
+		mockRepo.On("GetByID", mock.Anything, userID).Return(&entity.User{ID: userID, SessionVersion: 2}, nil)
+
+		session, err := userManager.ValidateAccessToken(context.Background(), token)
+		require.NoError(t, err)
+		assert.Equal(t, userID, session.User.ID)
+		assert.Equal(t, entity.RoleUser, session.Role.Name)
+		assert.Empty(t, session.Role.Permissions)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		userManager := NewUserManager(mockRepo, claims)
+		token, _, err := claims.GenerateSessionToken(userID, "test@example.com", 2, time.Minute)
+		require.NoError(t, err)
+		mockRepo.On("GetByID", mock.Anything, userID).Return(&entity.User{ID: userID, SessionVersion: 2, Disabled: true}, nil)
+
+		_, err = userManager.ValidateAccessToken(context.Background(), token)
+		assert.Equal(t, constant.ErrInvalidAccessToken, err)
+	})
+
+	t.Run("RoleChanged", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		userManager := NewUserManager(mockRepo, claims)
+		token, _, err := claims.GenerateAccessToken(jwtutil.AccessClaims{
+			UserID: userID, Email: "test@example.com", SessionVersion: 2, Role: entity.RoleAdmin,
+		}, time.Minute)
+		require.NoError(t, err)
+		mockRepo.On("GetByID", mock.Anything, userID).Return(&entity.User{ID: userID, SessionVersion: 2, Role: entity.RoleUser}, nil)
+
+		_, err = userManager.ValidateAccessToken(context.Background(), token)
+		assert.Equal(t, constant.ErrInvalidAccessToken, err, "tokens issued for a previous role are refused")
+	})
+
+	t.Run("Revoked", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		userManager := NewUserManager(mockRepo, claims)
+		token, _, err := claims.GenerateSessionToken(userID, "test@example.com", 2, time.Minute)
+		require.NoError(t, err)
+		mockRepo.On("GetByID", mock.Anything, userID).Return(&entity.User{ID: userID, SessionVersion: 3}, nil)
+
+		_, err = userManager.ValidateAccessToken(context.Background(), token)
+		assert.Equal(t, constant.ErrInvalidAccessToken, err)
+	})
+
+	t.Run("UserDeleted", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		userManager := NewUserManager(mockRepo, claims)
+		token, _, err := claims.GenerateSessionToken(userID, "test@example.com", 0, time.Minute)
+		require.NoError(t, err)
+		mockRepo.On("GetByID", mock.Anything, userID).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err = userManager.ValidateAccessToken(context.Background(), token)
+		assert.Equal(t, constant.ErrInvalidAccessToken, err)
+	})
+
+	t.Run("Malformed", func(t *testing.T) {
+		userManager := NewUserManager(new(MockUserRepository), claims)
+
+		_, err := userManager.ValidateAccessToken(context.Background(), "not-a-token")
+		assert.Equal(t, constant.ErrInvalidAccessToken, err)
+	})
+}
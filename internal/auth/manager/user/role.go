@@ -0,0 +1,147 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/a1y/doc-formatter/internal/auth/domain/constant"
+	"github.com/a1y/doc-formatter/internal/auth/domain/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ListRoles returns the built-in roles followed by the custom ones, for an
+// administrator who can read users.
+func (u *UserManager) ListRoles(ctx context.Context, actorID uuid.UUID) ([]*entity.Role, error) {
+	if _, err := u.authorize(ctx, actorID, entity.PermissionUsersRead); err != nil {
+		return nil, err
+	}
+	roles := slices.Clone(entity.BuiltInRoles)
+	if u.roleRepo == nil {
+		return roles, nil
+	}
+	custom, err := u.roleRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return append(roles, custom...), nil
+}
+
+// CreateRole creates a custom role, for an administrator who can manage
+// roles.
+func (u *UserManager) CreateRole(ctx context.Context, actorID uuid.UUID, role *entity.Role) (*entity.Role, error) {
+	actor, err := u.authorize(ctx, actorID, entity.PermissionRolesManage)
+	if err != nil {
+		return nil, err
+	}
+	if u.roleRepo == nil {
+		return nil, constant.ErrRolesUnavailable
+	}
+	created := &entity.Role{
+		Name:        strings.ToLower(strings.TrimSpace(role.Name)),
+		Description: strings.TrimSpace(role.Description),
+	}
+	for _, permission := range role.Permissions {
+		if !slices.Contains(created.Permissions, permission) {
+			created.Permissions = append(created.Permissions, permission)
+		}
+	}
+	slices.Sort(created.Permissions)
+	if err := created.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", constant.ErrInvalidRole, err)
+	}
+	if entity.BuiltInRole(created.Name) != nil {
+		return nil, constant.ErrRoleExists
+	}
+	err = u.roleRepo.Create(ctx, created)
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return nil, constant.ErrRoleExists
+	}
+	if err != nil {
+		return nil, err
+	}
+	u.recordAuditBy(ctx, actor.ID, actor.ID, entity.AuditRoleCreated,
+		fmt.Sprintf("%s: %s", created.Name, strings.Join(created.Permissions, ",")))
+	return created, nil
+}
+
+// SetUserRole gives a user the role with name, for an administrator who can
+// manage roles. Administrators cannot change their own role, so that they
+// cannot lock everyone out of administering the service. The sessions of the
+// user issued for their previous role are no longer valid.
+func (u *UserManager) SetUserRole(ctx context.Context, actorID, userID uuid.UUID, name string) (*entity.User, error) {
+	actor, err := u.authorize(ctx, actorID, entity.PermissionRolesManage)
+	if err != nil {
+		return nil, err
+	}
+	if actorID == userID {
+		return nil, constant.ErrCannotActOnSelf
+	}
+	user, err := u.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	role, err := u.role(ctx, strings.TrimSpace(name))
+	if err != nil {
+		return nil, err
+	}
+	previous := userRole(user)
+	if previous == role.Name {
+		return user, nil
+	}
+	if err := u.userRepo.SetRole(ctx, user.ID, role.Name); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constant.ErrUserNotFound
+		}
+		return nil, err
+	}
+	user.Role = role.Name
+	u.recordAuditBy(ctx, actor.ID, user.ID, entity.AuditRoleChanged, fmt.Sprintf("%s -> %s", previous, role.Name))
+	return user, nil
+}
+
+// role returns the role with name, built-in or custom. It returns
+// constant.ErrRoleNotFound when there is no such role.
+func (u *UserManager) role(ctx context.Context, name string) (*entity.Role, error) {
+	if role := entity.BuiltInRole(name); role != nil {
+		return role, nil
+	}
+	if u.roleRepo == nil {
+		return nil, constant.ErrRoleNotFound
+	}
+	role, err := u.roleRepo.GetByName(ctx, name)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, constant.ErrRoleNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+// authorize returns the administrator with actorID, as long as they are not
+// disabled and their role grants permission. It returns
+// constant.ErrPermissionDenied otherwise.
+func (u *UserManager) authorize(ctx context.Context, actorID uuid.UUID, permission string) (*entity.User, error) {
+	actor, err := u.userRepo.GetByID(ctx, actorID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, constant.ErrPermissionDenied
+	}
+	if err != nil {
+		return nil, err
+	}
+	if actor.Disabled {
+		return nil, constant.ErrPermissionDenied
+	}
+	role, err := u.roleOf(ctx, actor)
+	if err != nil {
+		return nil, err
+	}
+	if !role.Can(permission) {
+		return nil, constant.ErrPermissionDenied
+	}
+	return actor, nil
+}
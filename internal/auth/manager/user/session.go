@@ -0,0 +1,108 @@
+package user
+
+import (
+	"context"
+	"errors"
+
+	"github.com/a1y/doc-formatter/internal/auth/domain/constant"
+	"github.com/a1y/doc-formatter/internal/auth/domain/entity"
+	jwtutil "github.com/a1y/doc-formatter/internal/auth/util/jwt"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// Session is what a valid access token lets its bearer do.
+type Session struct {
+	User *entity.User
+	// Role is the role of the user, which grants the permissions of the
+	// session.
+	Role *entity.Role
+	// ImpersonatorID is the administrator acting as the user, uuid.Nil
+	// unless the token was issued by ImpersonateUser.
+	ImpersonatorID uuid.UUID
+}
+
+// ValidateAccessToken returns the session of an access token, as long as the
+// token has not expired, the sessions of the user have not been revoked and
+// their role has not changed since, and the user is not disabled.
+// Impersonation tokens are also refused once their administrator can no
+// longer impersonate.
+func (u *UserManager) ValidateAccessToken(ctx context.Context, token string) (*Session, error) {
+	claims, err := u.jwtClaims.ParseAccessClaims(token)
+	if errors.Is(err, jwtutil.ErrInvalidToken) {
+		return nil, constant.ErrInvalidAccessToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	user, err := u.userRepo.GetByID(ctx, claims.UserID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, constant.ErrInvalidAccessToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	if user.SessionVersion != claims.SessionVersion || user.Disabled {
+		return nil, constant.ErrInvalidAccessToken
+	}
+	// Tokens issued before users had roles carry none, which is the role
+	// every user had then.
+	tokenRole := claims.Role
+	if tokenRole == "" {
+		tokenRole = entity.RoleUser
+	}
+	if tokenRole != userRole(user) {
+		return nil, constant.ErrInvalidAccessToken
+	}
+	role, err := u.roleOf(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	if claims.ImpersonatorID != uuid.Nil {
+		_, err := u.authorize(ctx, claims.ImpersonatorID, entity.PermissionUsersImpersonate)
+		if errors.Is(err, constant.ErrPermissionDenied) {
+			return nil, constant.ErrInvalidAccessToken
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &Session{User: user, Role: role, ImpersonatorID: claims.ImpersonatorID}, nil
+}
+
+// accessToken issues an access token to user for their current session
+// version and role.
+func (u *UserManager) accessToken(user *entity.User) (*string, int64, error) {
+	tokenString, exp, err := u.jwtClaims.GenerateAccessToken(jwtutil.AccessClaims{
+		UserID:         user.ID,
+		Email:          user.Email,
+		SessionVersion: user.SessionVersion,
+		Role:           userRole(user),
+	}, accessTokenTTL)
+	if err != nil {
+		return nil, 0, err
+	}
+	return &tokenString, exp, nil
+}
+
+// roleOf returns the role of user. Users whose custom role cannot be found
+// get a role without permissions, so that they can keep using the service.
+func (u *UserManager) roleOf(ctx context.Context, user *entity.User) (*entity.Role, error) {
+	name := userRole(user)
+	role, err := u.role(ctx, name)
+	if errors.Is(err, constant.ErrRoleNotFound) {
+		logrus.Warnf("Role %s of user %s not found, granting no permissions", name, user.ID)
+		return &entity.Role{Name: name}, nil
+	}
+	return role, err
+}
+
+// userRole returns the name of the role of user, which is entity.RoleUser
+// for users who were never given one.
+func userRole(user *entity.User) string {
+	if user.Role == "" {
+		return entity.RoleUser
+	}
+	return user.Role
+}
@@ -0,0 +1,64 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/a1y/doc-formatter/internal/auth/domain/constant"
+	"github.com/a1y/doc-formatter/internal/auth/domain/entity"
+	"github.com/a1y/doc-formatter/pkg/throttle"
+)
+
+// LoginLockedError is returned when logins are locked after too many failed
+// attempts.
+type LoginLockedError struct {
+	// RetryAfter is how long logins stay locked.
+	RetryAfter time.Duration
+}
+
+func (e *LoginLockedError) Error() string {
+	return fmt.Sprintf("%s, retry in %s", constant.ErrTooManyLoginAttempts, e.RetryAfter.Round(time.Second))
+}
+
+func (e *LoginLockedError) Unwrap() error {
+	return constant.ErrTooManyLoginAttempts
+}
+
+// loginKeys returns the keys failed logins to email from remoteAddr are
+// counted by. The address key is empty when remoteAddr is unknown.
+func loginKeys(email, remoteAddr string) (account, ip string) {
+	account = entity.LoginKeyAccount + strings.ToLower(strings.TrimSpace(email))
+	if remoteAddr != "" {
+		ip = entity.LoginKeyIP + remoteAddr
+	}
+	return account, ip
+}
+
+// checkLoginLocked returns a *LoginLockedError when logins for any of keys
+// are locked.
+func (u *UserManager) checkLoginLocked(ctx context.Context, now time.Time, keys ...string) error {
+	err := u.loginThrottle.Check(ctx, now, keys...)
+	var lockedErr *throttle.LockedError
+	if errors.As(err, &lockedErr) {
+		return &LoginLockedError{RetryAfter: lockedErr.RetryAfter}
+	}
+	return err
+}
+
+// recordLoginFailure counts a failed login for the account and client
+// address keys, locking the keys that reached their maximum number of
+// failures. Failing to count is logged, so that logins do not depend on it.
+func (u *UserManager) recordLoginFailure(ctx context.Context, now time.Time, accountKey, ipKey string) {
+	u.loginThrottle.RecordFailure(ctx, now, accountKey, u.loginThrottleConfig.MaxAccountFailures)
+	u.loginThrottle.RecordFailure(ctx, now, ipKey, u.loginThrottleConfig.MaxIPFailures)
+}
+
+// resetLoginFailures forgets the failed logins to an account after it was
+// logged in to. Failures from the client address are kept, so that logging
+// in to one account does not lift throttling of guesses at others.
+func (u *UserManager) resetLoginFailures(ctx context.Context, accountKey string) {
+	u.loginThrottle.Reset(ctx, accountKey)
+}
@@ -0,0 +1,98 @@
+package user
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/a1y/doc-formatter/internal/auth/domain/constant"
+	"github.com/a1y/doc-formatter/internal/auth/domain/entity"
+	"github.com/a1y/doc-formatter/internal/auth/infra/memory"
+	jwtutil "github.com/a1y/doc-formatter/internal/auth/util/jwt"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func newThrottledManager(t *testing.T, config LoginThrottleConfig) (*UserManager, *MockUserRepository) {
+	_, tokenPath := setupTestPrivateKey(t)
+	mockRepo := new(MockUserRepository)
+	userManager := NewUserManager(mockRepo, jwtutil.TokenClaim{TokenPath: tokenPath})
+	userManager.SetLoginThrottle(memory.NewLoginAttemptRepository(), config)
+	return userManager, mockRepo
+}
+
+func TestLoginUser_Throttled(t *testing.T) {
+	email := "test@example.com"
+	login := func(password string) *entity.User { return &entity.User{Email: email, Password: password} }
+
+	t.Run("AccountLocked", func(t *testing.T) {
+		userManager, mockRepo := newThrottledManager(t, LoginThrottleConfig{MaxAccountFailures: 3, Lockout: time.Minute})
+		mockRepo.On("GetByEmail", mock.Anything, email).Return(hashedUser(t, uuid.New(), email, "password123"), nil)
+
+		for range 3 {
+			_, _, err := userManager.LoginUser(context.Background(), login("wrong"), "192.0.2.1")
+			require.Error(t, err)
+			assert.NotErrorIs(t, err, constant.ErrTooManyLoginAttempts)
+		}
+
+		// Even the right password is refused while the account is locked,
+		// from any address.
+		_, _, err := userManager.LoginUser(context.Background(), login("password123"), "198.51.100.7")
+		var lockedErr *LoginLockedError
+		require.ErrorAs(t, err, &lockedErr)
+		assert.ErrorIs(t, err, constant.ErrTooManyLoginAttempts)
+		assert.InDelta(t, time.Minute, lockedErr.RetryAfter, float64(time.Second))
+	})
+
+	t.Run("UnknownAccount", func(t *testing.T) {
+		userManager, mockRepo := newThrottledManager(t, LoginThrottleConfig{MaxAccountFailures: 2})
+		mockRepo.On("GetByEmail", mock.Anything, email).Return(nil, gorm.ErrRecordNotFound)
+
+		for range 2 {
+			_, _, err := userManager.LoginUser(context.Background(), login("password123"), "")
+			assert.ErrorIs(t, err, constant.ErrInvalidCredentials)
+		}
+
+		_, _, err := userManager.LoginUser(context.Background(), login("password123"), "")
+		assert.ErrorIs(t, err, constant.ErrTooManyLoginAttempts)
+	})
+
+	t.Run("SuccessResetsAccount", func(t *testing.T) {
+		userManager, mockRepo := newThrottledManager(t, LoginThrottleConfig{MaxAccountFailures: 3})
+		mockRepo.On("GetByEmail", mock.Anything, email).Return(hashedUser(t, uuid.New(), email, "password123"), nil)
+
+		for range 2 {
+			_, _, err := userManager.LoginUser(context.Background(), login("wrong"), "")
+			require.Error(t, err)
+		}
+		_, _, err := userManager.LoginUser(context.Background(), login("password123"), "")
+		require.NoError(t, err)
+
+		for range 2 {
+			_, _, err := userManager.LoginUser(context.Background(), login("wrong"), "")
+			assert.NotErrorIs(t, err, constant.ErrTooManyLoginAttempts)
+		}
+		_, _, err = userManager.LoginUser(context.Background(), login("password123"), "")
+		assert.NoError(t, err)
+	})
+
+	t.Run("AddressLocked", func(t *testing.T) {
+		userManager, mockRepo := newThrottledManager(t, LoginThrottleConfig{MaxAccountFailures: 10, MaxIPFailures: 3})
+		for i := range 3 {
+			other := uuid.NewString() + "@example.com"
+			mockRepo.On("GetByEmail", mock.Anything, other).Return(nil, gorm.ErrRecordNotFound)
+			_, _, err := userManager.LoginUser(context.Background(), &entity.User{Email: other, Password: "guess"}, "192.0.2.1")
+			require.ErrorIs(t, err, constant.ErrInvalidCredentials, "attempt %d", i)
+		}
+
+		_, _, err := userManager.LoginUser(context.Background(), login("password123"), "192.0.2.1")
+		assert.ErrorIs(t, err, constant.ErrTooManyLoginAttempts)
+
+		mockRepo.On("GetByEmail", mock.Anything, email).Return(hashedUser(t, uuid.New(), email, "password123"), nil)
+		_, _, err = userManager.LoginUser(context.Background(), login("password123"), "198.51.100.7")
+		assert.NoError(t, err)
+	})
+}
@@ -1,13 +1,80 @@
 package user
 
 import (
+	"sync"
+	"time"
+
 	"github.com/a1y/doc-formatter/internal/auth/domain/repository"
 	jwtutil "github.com/a1y/doc-formatter/internal/auth/util/jwt"
+	"github.com/a1y/doc-formatter/internal/auth/util/mailer"
+	"github.com/a1y/doc-formatter/internal/auth/util/oidc"
+	"github.com/a1y/doc-formatter/internal/auth/util/passwordpolicy"
+	"github.com/a1y/doc-formatter/internal/auth/util/secretbox"
+	"github.com/a1y/doc-formatter/pkg/credentials"
+	"github.com/a1y/doc-formatter/pkg/throttle"
 )
 
 type UserManager struct {
 	userRepo  repository.UserRepository
 	jwtClaims jwtutil.TokenClaim
+
+	mailer              mailer.Mailer
+	verification        VerificationConfig
+	resetRepo           repository.PasswordResetRepository
+	passwordReset       PasswordResetConfig
+	auditRepo           repository.AuditRepository
+	hasher              *credentials.Argon2idHash
+	dummyHashOnce       sync.Once
+	dummyHash           string
+	policy              *passwordpolicy.Policy
+	loginThrottle       *throttle.Throttle
+	loginThrottleConfig LoginThrottleConfig
+	mfaRepo             repository.MFARepository
+	mfaBox              *secretbox.Box
+	mfa                 MFAConfig
+	identityRepo        repository.IdentityRepository
+	oidcProviders       map[string]*oidc.Provider
+	apiKeyRepo          repository.APIKeyRepository
+	roleRepo            repository.RoleRepository
+}
+
+// VerificationConfig controls how email addresses are verified.
+type VerificationConfig struct {
+	// TokenTTL is how long verification tokens are valid.
+	TokenTTL time.Duration
+	// URL is the page verification links point to, with the token in the
+	// token query parameter. Emails carry the bare token when it is empty.
+	URL string
+	// RequiredForLogin refuses to log in users who have not verified their
+	// email address.
+	RequiredForLogin bool
+}
+
+// LoginThrottleConfig controls how failed logins are throttled.
+type LoginThrottleConfig struct {
+	// MaxAccountFailures is the number of failed logins to an account after
+	// which logins to it are locked. Accounts are not locked when it is zero.
+	MaxAccountFailures int
+	// MaxIPFailures is the number of failed logins from a client address
+	// after which logins from it are locked. Addresses are not locked when it
+	// is zero.
+	MaxIPFailures int
+	// Lockout is how long the first lockout lasts. Every further failure
+	// doubles it.
+	Lockout time.Duration
+	// MaxLockout caps how long lockouts last. They are not capped when it is
+	// zero.
+	MaxLockout time.Duration
+	// Window is how long failures are counted for. Counts start over after
+	// a window without failures.
+	Window time.Duration
+}
+
+// MFAConfig controls two-factor authentication.
+type MFAConfig struct {
+	// Issuer is the name authenticator apps show accounts under.
+	// DefaultMFAIssuer is used when it is empty.
+	Issuer string
 }
 
 func NewUserManager(userRepo repository.UserRepository, jwtClaims jwtutil.TokenClaim) *UserManager {
@@ -16,3 +83,108 @@ func NewUserManager(userRepo repository.UserRepository, jwtClaims jwtutil.TokenC
 		jwtClaims: jwtClaims,
 	}
 }
+
+// PasswordResetConfig controls how passwords are reset.
+type PasswordResetConfig struct {
+	// TokenTTL is how long password reset tokens are valid.
+	TokenTTL time.Duration
+	// URL is the page password reset links point to, with the token in the
+	// token query parameter. Emails carry the bare token when it is empty.
+	URL string
+}
+
+// SetMailer sets the mailer verification and password reset emails are sent
+// with. No emails are sent when m is nil.
+func (u *UserManager) SetMailer(m mailer.Mailer) {
+	u.mailer = m
+}
+
+// SetVerification sets how email addresses are verified.
+func (u *UserManager) SetVerification(config VerificationConfig) {
+	u.verification = config
+}
+
+// SetPasswordReset sets the repository password reset tokens are kept in and
+// how passwords are reset. Passwords cannot be reset when repo is nil.
+func (u *UserManager) SetPasswordReset(repo repository.PasswordResetRepository, config PasswordResetConfig) {
+	u.resetRepo = repo
+	u.passwordReset = config
+}
+
+// SetAuditRepository sets the repository changes to credentials are recorded
+// in. Nothing is recorded when repo is nil.
+func (u *UserManager) SetAuditRepository(repo repository.AuditRepository) {
+	u.auditRepo = repo
+}
+
+// SetPasswordHasher sets the parameters passwords are hashed with. Passwords
+// hashed with weaker parameters are rehashed when their users log in. The
+// default parameters are used when hasher is nil.
+func (u *UserManager) SetPasswordHasher(hasher *credentials.Argon2idHash) {
+	u.hasher = hasher
+}
+
+func (u *UserManager) passwordHasher() *credentials.Argon2idHash {
+	if u.hasher == nil {
+		return credentials.NewDefaultArgon2idHash()
+	}
+	return u.hasher
+}
+
+// SetLoginThrottle sets the repository failed logins are counted in and how
+// they are throttled. Logins are not throttled when repo is nil.
+func (u *UserManager) SetLoginThrottle(repo repository.LoginAttemptRepository, config LoginThrottleConfig) {
+	u.loginThrottle = throttle.New(repo, throttle.Policy{
+		Lockout:    config.Lockout,
+		MaxLockout: config.MaxLockout,
+		Window:     config.Window,
+	})
+	u.loginThrottleConfig = config
+}
+
+// SetMFA sets the repository two-factor authentication is kept in, the box
+// TOTP secrets are sealed with and how two-factor authentication works. It is
+// unavailable when repo or box is nil.
+func (u *UserManager) SetMFA(repo repository.MFARepository, box *secretbox.Box, config MFAConfig) {
+	u.mfaRepo = repo
+	u.mfaBox = box
+	u.mfa = config
+}
+
+// SetOIDC sets the repository identities at external identity providers are
+// linked in and the providers users can log in with. Users cannot log in with
+// any provider when repo is nil.
+func (u *UserManager) SetOIDC(repo repository.IdentityRepository, providers []*oidc.Provider) {
+	u.identityRepo = repo
+	u.oidcProviders = make(map[string]*oidc.Provider, len(providers))
+	for _, p := range providers {
+		u.oidcProviders[p.Name()] = p
+	}
+}
+
+// SetAPIKeyRepository sets the repository API keys are kept in. API keys
+// are unavailable when repo is nil.
+func (u *UserManager) SetAPIKeyRepository(repo repository.APIKeyRepository) {
+	u.apiKeyRepo = repo
+}
+
+// SetRoleRepository sets the repository custom roles are kept in. Only the
+// built-in roles exist when repo is nil.
+func (u *UserManager) SetRoleRepository(repo repository.RoleRepository) {
+	u.roleRepo = repo
+}
+
+// SetPasswordPolicy sets the policy new passwords must follow. Any password
+// is accepted when policy is nil.
+func (u *UserManager) SetPasswordPolicy(policy *passwordpolicy.Policy) {
+	u.policy = policy
+}
+
+// checkPasswordPolicy returns a *passwordpolicy.Error when password does not
+// follow the password policy for the user with email.
+func (u *UserManager) checkPasswordPolicy(password, email string) error {
+	if u.policy == nil {
+		return nil
+	}
+	return u.policy.Check(password, email)
+}
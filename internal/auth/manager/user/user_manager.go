@@ -5,18 +5,27 @@ import (
 	"errors"
 	"time"
 
+	"github.com/a1y/doc-formatter/internal/auth/domain/constant"
 	"github.com/a1y/doc-formatter/internal/auth/domain/entity"
 	"github.com/a1y/doc-formatter/pkg/credentials"
+	"github.com/google/uuid"
 	"github.com/jinzhu/copier"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
 )
 
+// accessTokenTTL is how long access tokens are valid.
+const accessTokenTTL = 15 * time.Minute
+
 func (u *UserManager) CreateUser(ctx context.Context, user *entity.User) (*entity.User, error) {
 	var createdEntity entity.User
 	if err := copier.Copy(&createdEntity, &user); err != nil {
 		return nil, err
 	}
-	argon2iHash := credentials.NewDefaultArgon2idHash()
-	hashedPassword, err := argon2iHash.HashPassword(createdEntity.Password, nil)
+	if err := u.checkPasswordPolicy(createdEntity.Password, createdEntity.Email); err != nil {
+		return nil, err
+	}
+	hashedPassword, err := u.passwordHasher().HashPassword(createdEntity.Password, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -25,11 +34,30 @@ func (u *UserManager) CreateUser(ctx context.Context, user *entity.User) (*entit
 	if err := u.userRepo.Create(ctx, &createdEntity); err != nil {
 		return nil, err
 	}
+	u.sendVerificationOnSignup(ctx, &createdEntity)
 	return &createdEntity, nil
 }
 
-func (u *UserManager) LoginUser(ctx context.Context, userEntity *entity.User) (*string, int64, error) {
+// LoginUser logs a user in with their email and password. remoteAddr is the
+// address the login comes from, empty when it is unknown. Failed logins are
+// throttled per account and per address when a login throttle is set. Users
+// with two-factor authentication get a *MFARequiredError instead of an access
+// token, to complete the login with VerifyMFA.
+func (u *UserManager) LoginUser(ctx context.Context, userEntity *entity.User, remoteAddr string) (*string, int64, error) {
+	now := time.Now()
+	accountKey, ipKey := loginKeys(userEntity.Email, remoteAddr)
+	if err := u.checkLoginLocked(ctx, now, accountKey, ipKey); err != nil {
+		return nil, 0, err
+	}
+
 	user, err := u.userRepo.GetByEmail(ctx, userEntity.Email)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// Unknown email addresses are refused as slowly as wrong passwords,
+		// so that timing does not tell which addresses have accounts.
+		_, _ = credentials.Compare(userEntity.Password, u.dummyPasswordHash())
+		u.recordLoginFailure(ctx, now, accountKey, ipKey)
+		return nil, 0, constant.ErrInvalidCredentials
+	}
 	if err != nil {
 		return nil, 0, err
 	}
@@ -38,13 +66,94 @@ func (u *UserManager) LoginUser(ctx context.Context, userEntity *entity.User) (*
 		return nil, 0, err
 	}
 	if !ok {
-		return nil, 0, errors.New("invalid credentials")
+		u.recordLoginFailure(ctx, now, accountKey, ipKey)
+		return nil, 0, constant.ErrInvalidCredentials
+	}
+	if !user.MFAEnabled {
+		// Failures to accounts with two-factor authentication are reset by
+		// VerifyMFA, so that knowing the password does not lift throttling
+		// of guesses at codes.
+		u.resetLoginFailures(ctx, accountKey)
 	}
+	u.upgradePasswordHash(ctx, user, userEntity.Password)
+	if user.Disabled {
+		return nil, 0, constant.ErrAccountDisabled
+	}
+	if u.verification.RequiredForLogin && !user.IsVerified {
+		return nil, 0, constant.ErrEmailNotVerified
+	}
+	if user.MFAEnabled {
+		return nil, 0, u.mfaChallenge(user)
+	}
+
+	return u.accessToken(user)
+}
+
+// dummyPasswordHash returns a hash of a random password, made once with the
+// parameters passwords are hashed with, to compare passwords of logins to
+// unknown accounts against.
+func (u *UserManager) dummyPasswordHash() string {
+	u.dummyHashOnce.Do(func() {
+		hash, err := u.passwordHasher().HashPassword(uuid.NewString(), nil)
+		if err != nil {
+			logrus.WithError(err).Warn("failed to hash dummy password")
+			return
+		}
+		u.dummyHash = hash
+	})
+	return u.dummyHash
+}
 
-	tokenString, exp, err := u.jwtClaims.GenerateToken(user.ID, user.Email, 15*time.Minute)
+// upgradePasswordHash rehashes password, the password of user, when its
+// stored hash was made with weaker parameters than passwords are hashed with
+// now. Logging in does not fail when the hash cannot be upgraded, it is
+// tried again on the next login.
+func (u *UserManager) upgradePasswordHash(ctx context.Context, user *entity.User, password string) {
+	hasher := u.passwordHasher()
+	weaker, err := hasher.NeedsRehash(user.Password)
+	if err != nil || !weaker {
+		return
+	}
+	hashedPassword, err := hasher.HashPassword(password, nil)
 	if err != nil {
-		return nil, 0, err
+		logrus.Warnf("Failed to rehash password of user %s: %v", user.ID, err)
+		return
+	}
+	err = u.userRepo.RehashPassword(ctx, user.ID, user.Password, hashedPassword)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// The password was changed since it was checked.
+		return
+	}
+	if err != nil {
+		logrus.Warnf("Failed to store rehashed password of user %s: %v", user.ID, err)
+		return
 	}
+	user.Password = hashedPassword
+	logrus.Infof("Upgraded password hash of user %s", user.ID)
+}
 
-	return &tokenString, exp, nil
+// GetUserByID resolves a registered user by id, so that other services can
+// show the people they know by id.
+func (u *UserManager) GetUserByID(ctx context.Context, id uuid.UUID) (*entity.User, error) {
+	user, err := u.userRepo.GetByID(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, constant.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// GetUserByEmail resolves a registered user, so that other services can
+// refer to people by the email address they know them by.
+func (u *UserManager) GetUserByEmail(ctx context.Context, email string) (*entity.User, error) {
+	user, err := u.userRepo.GetByEmail(ctx, email)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, constant.ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
 }
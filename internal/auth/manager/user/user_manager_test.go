@@ -10,12 +10,16 @@ import (
 	"os"
 	"testing"
 
+	"github.com/a1y/doc-formatter/internal/auth/domain/constant"
 	"github.com/a1y/doc-formatter/internal/auth/domain/entity"
 	jwtutil "github.com/a1y/doc-formatter/internal/auth/util/jwt"
+	"github.com/a1y/doc-formatter/internal/auth/util/passwordpolicy"
 	"github.com/a1y/doc-formatter/pkg/credentials"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
 )
 
 func setupTestPrivateKey(t *testing.T) (*rsa.PrivateKey, string) {
@@ -73,6 +77,57 @@ func (m *MockUserRepository) GetByEmail(ctx context.Context, email string) (*ent
 	return args.Get(0).(*entity.User), args.Error(1)
 }
 
+func (m *MockUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.User), args.Error(1)
+}
+
+func (m *MockUserRepository) MarkVerified(ctx context.Context, id uuid.UUID, email string) error {
+	args := m.Called(ctx, id, email)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) UpdatePassword(ctx context.Context, id uuid.UUID, passwordHash string) error {
+	args := m.Called(ctx, id, passwordHash)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) RehashPassword(ctx context.Context, id uuid.UUID, oldHash, newHash string) error {
+	args := m.Called(ctx, id, oldHash, newHash)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) SetPendingEmail(ctx context.Context, id uuid.UUID, email string) error {
+	args := m.Called(ctx, id, email)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) ConfirmEmail(ctx context.Context, id uuid.UUID, email string) error {
+	args := m.Called(ctx, id, email)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) List(ctx context.Context, filter entity.UserFilter) ([]*entity.User, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.User), args.Error(1)
+}
+
+func (m *MockUserRepository) SetDisabled(ctx context.Context, id uuid.UUID, disabled bool) error {
+	args := m.Called(ctx, id, disabled)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) SetRole(ctx context.Context, id uuid.UUID, role string) error {
+	args := m.Called(ctx, id, role)
+	return args.Error(0)
+}
+
 func TestCreateUser(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		mockRepo := new(MockUserRepository)
@@ -111,6 +166,24 @@ func TestCreateUser(t *testing.T) {
 		assert.Nil(t, createdUser)
 		mockRepo.AssertExpectations(t)
 	})
+
+	t.Run("PolicyViolation", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		userManager := NewUserManager(mockRepo, jwtutil.TokenClaim{TokenPath: "/tmp/test-private-key.pem"})
+		userManager.SetPasswordPolicy(&passwordpolicy.Policy{MinLength: 8, RejectEmail: true})
+		user := &entity.User{
+			Email:    "test@example.com",
+			Password: "test123",
+		}
+
+		createdUser, err := userManager.CreateUser(context.Background(), user)
+
+		var policyErr *passwordpolicy.Error
+		require.ErrorAs(t, err, &policyErr)
+		assert.Len(t, policyErr.Violations, 2)
+		assert.Nil(t, createdUser)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
 }
 
 func TestLoginUser(t *testing.T) {
@@ -136,7 +209,7 @@ func TestLoginUser(t *testing.T) {
 
 		mockRepo.On("GetByEmail", mock.Anything, userEntity.Email).Return(storedUser, nil)
 
-		token, exp, err := userManager.LoginUser(context.Background(), userEntity)
+		token, exp, err := userManager.LoginUser(context.Background(), userEntity, "")
 
 		assert.NoError(t, err)
 		assert.NotNil(t, token)
@@ -156,7 +229,7 @@ func TestLoginUser(t *testing.T) {
 
 		mockRepo.On("GetByEmail", mock.Anything, userEntity.Email).Return(nil, errors.New("user not found"))
 
-		token, exp, err := userManager.LoginUser(context.Background(), userEntity)
+		token, exp, err := userManager.LoginUser(context.Background(), userEntity, "")
 
 		assert.Error(t, err)
 		assert.Nil(t, token)
@@ -182,11 +255,30 @@ func TestLoginUser(t *testing.T) {
 
 		mockRepo.On("GetByEmail", mock.Anything, userEntity.Email).Return(storedUser, nil)
 
-		token, exp, err := userManager.LoginUser(context.Background(), userEntity)
+		token, exp, err := userManager.LoginUser(context.Background(), userEntity, "")
 
-		assert.Error(t, err)
+		assert.ErrorIs(t, err, constant.ErrInvalidCredentials)
+		assert.Nil(t, token)
+		assert.Equal(t, int64(0), exp)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("UnknownEmail", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		userManager := NewUserManager(mockRepo, jwtutil.TokenClaim{})
+		userEntity := &entity.User{
+			Email:    "missing@example.com",
+			Password: password,
+		}
+
+		mockRepo.On("GetByEmail", mock.Anything, userEntity.Email).Return(nil, gorm.ErrRecordNotFound)
+
+		token, exp, err := userManager.LoginUser(context.Background(), userEntity, "")
+
+		assert.ErrorIs(t, err, constant.ErrInvalidCredentials)
 		assert.Nil(t, token)
 		assert.Equal(t, int64(0), exp)
+		assert.NotEmpty(t, userManager.dummyHash)
 		mockRepo.AssertExpectations(t)
 	})
 
@@ -208,7 +300,7 @@ func TestLoginUser(t *testing.T) {
 
 		mockRepo.On("GetByEmail", mock.Anything, userEntity.Email).Return(storedUser, nil)
 
-		token, exp, err := userManager.LoginUser(context.Background(), userEntity)
+		token, exp, err := userManager.LoginUser(context.Background(), userEntity, "")
 
 		assert.Error(t, err)
 		assert.Nil(t, token)
@@ -216,3 +308,151 @@ func TestLoginUser(t *testing.T) {
 		mockRepo.AssertExpectations(t)
 	})
 }
+
+func TestLoginUser_UpgradesPasswordHash(t *testing.T) {
+	password := "password123"
+	weak := credentials.NewArgon2idHash(1, 8*1024, 1, 32, 16)
+	current := credentials.NewArgon2idHash(2, 8*1024, 1, 32, 16)
+	weakHash, err := weak.HashPassword(password, nil)
+	require.NoError(t, err)
+	currentHash, err := current.HashPassword(password, nil)
+	require.NoError(t, err)
+	userID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+
+	newManager := func(t *testing.T) (*UserManager, *MockUserRepository) {
+		_, tokenPath := setupTestPrivateKey(t)
+		mockRepo := new(MockUserRepository)
+		userManager := NewUserManager(mockRepo, jwtutil.TokenClaim{TokenPath: tokenPath})
+		userManager.SetPasswordHasher(current)
+		return userManager, mockRepo
+	}
+	login := &entity.User{Email: "test@example.com", Password: password}
+
+	t.Run("Weaker", func(t *testing.T) {
+		userManager, mockRepo := newManager(t)
+		mockRepo.On("GetByEmail", mock.Anything, login.Email).
+			Return(&entity.User{ID: userID, Email: login.Email, Password: weakHash}, nil)
+		var rehashed string
+		mockRepo.On("RehashPassword", mock.Anything, userID, weakHash, mock.Anything).Run(func(args mock.Arguments) {
+			rehashed = args.String(3)
+		}).Return(nil)
+
+		token, _, err := userManager.LoginUser(context.Background(), login, "")
+		require.NoError(t, err)
+		assert.NotNil(t, token)
+
+		needsRehash, err := current.NeedsRehash(rehashed)
+		require.NoError(t, err)
+		assert.False(t, needsRehash)
+		ok, err := credentials.Compare(password, rehashed)
+		require.NoError(t, err)
+		assert.True(t, ok)
+	})
+
+	t.Run("Current", func(t *testing.T) {
+		userManager, mockRepo := newManager(t)
+		mockRepo.On("GetByEmail", mock.Anything, login.Email).
+			Return(&entity.User{ID: userID, Email: login.Email, Password: currentHash}, nil)
+
+		_, _, err := userManager.LoginUser(context.Background(), login, "")
+		require.NoError(t, err)
+		mockRepo.AssertNotCalled(t, "RehashPassword", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("StoreFails", func(t *testing.T) {
+		userManager, mockRepo := newManager(t)
+		mockRepo.On("GetByEmail", mock.Anything, login.Email).
+			Return(&entity.User{ID: userID, Email: login.Email, Password: weakHash}, nil)
+		mockRepo.On("RehashPassword", mock.Anything, userID, weakHash, mock.Anything).Return(errors.New("db down"))
+
+		token, _, err := userManager.LoginUser(context.Background(), login, "")
+		require.NoError(t, err)
+		assert.NotNil(t, token)
+	})
+
+	t.Run("WrongPassword", func(t *testing.T) {
+		userManager, mockRepo := newManager(t)
+		mockRepo.On("GetByEmail", mock.Anything, login.Email).
+			Return(&entity.User{ID: userID, Email: login.Email, Password: weakHash}, nil)
+
+		_, _, err := userManager.LoginUser(context.Background(), &entity.User{Email: login.Email, Password: "wrong"}, "")
+		assert.Error(t, err)
+		mockRepo.AssertNotCalled(t, "RehashPassword", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestGetUserByEmail(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		userManager := NewUserManager(mockRepo, jwtutil.TokenClaim{})
+		storedUser := &entity.User{
+			ID:    uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+			Email: "test@example.com",
+		}
+
+		mockRepo.On("GetByEmail", mock.Anything, "test@example.com").Return(storedUser, nil)
+
+		user, err := userManager.GetUserByEmail(context.Background(), "test@example.com")
+
+		assert.NoError(t, err)
+		assert.Equal(t, storedUser, user)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		userManager := NewUserManager(mockRepo, jwtutil.TokenClaim{})
+
+		mockRepo.On("GetByEmail", mock.Anything, "missing@example.com").Return(nil, gorm.ErrRecordNotFound)
+
+		user, err := userManager.GetUserByEmail(context.Background(), "missing@example.com")
+
+		assert.ErrorIs(t, err, constant.ErrUserNotFound)
+		assert.Nil(t, user)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("RepoError", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		userManager := NewUserManager(mockRepo, jwtutil.TokenClaim{})
+
+		mockRepo.On("GetByEmail", mock.Anything, "test@example.com").Return(nil, errors.New("db error"))
+
+		user, err := userManager.GetUserByEmail(context.Background(), "test@example.com")
+
+		assert.EqualError(t, err, "db error")
+		assert.Nil(t, user)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestGetUserByID(t *testing.T) {
+	id := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		userManager := NewUserManager(mockRepo, jwtutil.TokenClaim{})
+		storedUser := &entity.User{ID: id, Email: "test@example.com"}
+
+		mockRepo.On("GetByID", mock.Anything, id).Return(storedUser, nil)
+
+		user, err := userManager.GetUserByID(context.Background(), id)
+
+		assert.NoError(t, err)
+		assert.Equal(t, storedUser, user)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		userManager := NewUserManager(mockRepo, jwtutil.TokenClaim{})
+
+		mockRepo.On("GetByID", mock.Anything, id).Return(nil, gorm.ErrRecordNotFound)
+
+		user, err := userManager.GetUserByID(context.Background(), id)
+
+		assert.ErrorIs(t, err, constant.ErrUserNotFound)
+		assert.Nil(t, user)
+		mockRepo.AssertExpectations(t)
+	})
+}
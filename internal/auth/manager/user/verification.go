@@ -0,0 +1,128 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/a1y/doc-formatter/internal/auth/domain/constant"
+	"github.com/a1y/doc-formatter/internal/auth/domain/entity"
+	jwtutil "github.com/a1y/doc-formatter/internal/auth/util/jwt"
+	"github.com/a1y/doc-formatter/internal/auth/util/mailer"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// DefaultVerificationTokenTTL is how long verification tokens are valid when
+// the verification config sets no TTL.
+const DefaultVerificationTokenTTL = 24 * time.Hour
+
+// VerifyEmail marks the email address a verification token was sent to
+// verified. Verifying an address twice is not an error, but a token sent to
+// an address the user has since changed is refused. Tokens sent to confirm
+// a change of email address are verified here as well.
+func (u *UserManager) VerifyEmail(ctx context.Context, token string) (*entity.User, error) {
+	userID, email, err := u.jwtClaims.ParsePurposeToken(token, jwtutil.PurposeVerifyEmail)
+	if errors.Is(err, jwtutil.ErrInvalidToken) {
+		if userID, email, err := u.jwtClaims.ParsePurposeToken(token, jwtutil.PurposeChangeEmail); err == nil {
+			return u.confirmEmailChange(ctx, userID, email)
+		}
+		return nil, constant.ErrInvalidVerificationToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := u.userRepo.GetByID(ctx, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, constant.ErrInvalidVerificationToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	if user.Email != email {
+		return nil, constant.ErrInvalidVerificationToken
+	}
+	if user.IsVerified {
+		return user, nil
+	}
+
+	err = u.userRepo.MarkVerified(ctx, user.ID, email)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, constant.ErrInvalidVerificationToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	user.IsVerified = true
+	return user, nil
+}
+
+// ResendVerification sends a new verification email to email. It does
+// nothing for unknown or already verified addresses, so that it cannot be
+// used to find out who has an account.
+func (u *UserManager) ResendVerification(ctx context.Context, email string) error {
+	user, err := u.userRepo.GetByEmail(ctx, email)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if user.IsVerified {
+		return nil
+	}
+	return u.sendVerification(ctx, user)
+}
+
+// sendVerification emails user a verification token.
+func (u *UserManager) sendVerification(ctx context.Context, user *entity.User) error {
+	if u.mailer == nil {
+		return nil
+	}
+	ttl := u.verification.TokenTTL
+	if ttl <= 0 {
+		ttl = DefaultVerificationTokenTTL
+	}
+	token, _, err := u.jwtClaims.GeneratePurposeToken(user.ID, user.Email, jwtutil.PurposeVerifyEmail, ttl)
+	if err != nil {
+		return err
+	}
+	body, err := verificationBody(u.verification.URL, token, ttl)
+	if err != nil {
+		return err
+	}
+	return u.mailer.Send(ctx, &mailer.Message{
+		To:      user.Email,
+		Subject: "Verify your email address",
+		Body:    body,
+	})
+}
+
+// sendVerificationOnSignup emails a new user a verification token. Signing
+// up does not fail when the email cannot be sent, since it can be resent.
+func (u *UserManager) sendVerificationOnSignup(ctx context.Context, user *entity.User) {
+	if err := u.sendVerification(ctx, user); err != nil {
+		logrus.Warnf("Failed to send verification email to user %s: %v", user.ID, err)
+	}
+}
+
+func verificationBody(link, token string, ttl time.Duration) (string, error) {
+	var b strings.Builder
+	b.WriteString("Welcome to Doc Formatter!\n\n")
+	if link == "" {
+		b.WriteString("To verify your email address, use this verification token:\n\n")
+		b.WriteString(token + "\n\n")
+	} else {
+		tokenURL, err := tokenLink(link, token)
+		if err != nil {
+			return "", fmt.Errorf("invalid verification url: %w", err)
+		}
+		b.WriteString("To verify your email address, open this link:\n\n")
+		b.WriteString(tokenURL + "\n\n")
+	}
+	fmt.Fprintf(&b, "It expires in %s. If you did not sign up, you can ignore this email.\n", formatTTL(ttl))
+	return b.String(), nil
+}
@@ -0,0 +1,247 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/a1y/doc-formatter/internal/auth/domain/constant"
+	"github.com/a1y/doc-formatter/internal/auth/domain/entity"
+	jwtutil "github.com/a1y/doc-formatter/internal/auth/util/jwt"
+	"github.com/a1y/doc-formatter/internal/auth/util/mailer"
+	"github.com/a1y/doc-formatter/pkg/credentials"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// recordingMailer keeps the messages it is asked to send.
+type recordingMailer struct {
+	mu       sync.Mutex
+	messages []*mailer.Message
+	err      error
+}
+
+func (m *recordingMailer) Send(ctx context.Context, msg *mailer.Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.messages = append(m.messages, msg)
+	return m.err
+}
+
+// tokenFrom returns the token of the verification link in msg.
+func tokenFrom(t *testing.T, msg *mailer.Message) string {
+	t.Helper()
+	for _, line := range strings.Split(msg.Body, "\n") {
+		if strings.HasPrefix(line, "https://") {
+			u, err := url.Parse(line)
+			require.NoError(t, err)
+			return u.Query().Get("token")
+		}
+	}
+	t.Fatalf("no verification link in %q", msg.Body)
+	return ""
+}
+
+func newVerificationManager(t *testing.T, m mailer.Mailer, required bool) (*UserManager, *MockUserRepository) {
+	_, tokenPath := setupTestPrivateKey(t)
+	mockRepo := new(MockUserRepository)
+	userManager := NewUserManager(mockRepo, jwtutil.TokenClaim{TokenPath: tokenPath})
+	userManager.SetMailer(m)
+	userManager.SetVerification(VerificationConfig{
+		TokenTTL:         time.Hour,
+		URL:              "https://docs.example.com/verify?lang=en",
+		RequiredForLogin: required,
+	})
+	return userManager, mockRepo
+}
+
+func TestCreateUser_SendsVerification(t *testing.T) {
+	t.Run("Sent", func(t *testing.T) {
+		m := &recordingMailer{}
+		userManager, mockRepo := newVerificationManager(t, m, false)
+		id := uuid.New()
+		mockRepo.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			args.Get(1).(*entity.User).ID = id
+		}).Return(nil)
+
+		_, err := userManager.CreateUser(context.Background(), &entity.User{Email: "test@example.com", Password: "password123"})
+		require.NoError(t, err)
+
+		require.Len(t, m.messages, 1)
+		assert.Equal(t, "test@example.com", m.messages[0].To)
+		assert.Contains(t, m.messages[0].Body, "https://docs.example.com/verify?lang=en&token=")
+		assert.Contains(t, m.messages[0].Body, "1 hour")
+
+		gotID, gotEmail, err := userManager.jwtClaims.ParsePurposeToken(tokenFrom(t, m.messages[0]), jwtutil.PurposeVerifyEmail)
+		require.NoError(t, err)
+		assert.Equal(t, id, gotID)
+		assert.Equal(t, "test@example.com", gotEmail)
+	})
+
+	t.Run("MailerErrorIgnored", func(t *testing.T) {
+		m := &recordingMailer{err: errors.New("smtp down")}
+		userManager, mockRepo := newVerificationManager(t, m, false)
+		mockRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+		createdUser, err := userManager.CreateUser(context.Background(), &entity.User{Email: "test@example.com", Password: "password123"})
+		assert.NoError(t, err)
+		assert.NotNil(t, createdUser)
+		assert.Len(t, m.messages, 1)
+	})
+}
+
+func TestVerifyEmail(t *testing.T) {
+	id := uuid.New()
+	email := "test@example.com"
+
+	t.Run("Success", func(t *testing.T) {
+		userManager, mockRepo := newVerificationManager(t, nil, false)
+		token, _, err := userManager.jwtClaims.GeneratePurposeToken(id, email, jwtutil.PurposeVerifyEmail, time.Hour)
+		require.NoError(t, err)
+		mockRepo.On("GetByID", mock.Anything, id).Return(&entity.User{ID: id, Email: email}, nil)
+		mockRepo.On("MarkVerified", mock.Anything, id, email).Return(nil)
+
+		user, err := userManager.VerifyEmail(context.Background(), token)
+		require.NoError(t, err)
+		assert.True(t, user.IsVerified)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("AlreadyVerified", func(t *testing.T) {
+		userManager, mockRepo := newVerificationManager(t, nil, false)
+		token, _, err := userManager.jwtClaims.GeneratePurposeToken(id, email, jwtutil.PurposeVerifyEmail, time.Hour)
+		require.NoError(t, err)
+		mockRepo.On("GetByID", mock.Anything, id).Return(&entity.User{ID: id, Email: email, IsVerified: true}, nil)
+
+		user, err := userManager.VerifyEmail(context.Background(), token)
+		require.NoError(t, err)
+		assert.True(t, user.IsVerified)
+		mockRepo.AssertNotCalled(t, "MarkVerified", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("EmailChanged", func(t *testing.T) {
+		userManager, mockRepo := newVerificationManager(t, nil, false)
+		token, _, err := userManager.jwtClaims.GeneratePurposeToken(id, email, jwtutil.PurposeVerifyEmail, time.Hour)
+		require.NoError(t, err)
+		mockRepo.On("GetByID", mock.Anything, id).Return(&entity.User{ID: id, Email: "new@example.com"}, nil)
+
+		_, err = userManager.VerifyEmail(context.Background(), token)
+		assert.Equal(t, constant.ErrInvalidVerificationToken, err)
+	})
+
+	t.Run("UserDeleted", func(t *testing.T) {
+		userManager, mockRepo := newVerificationManager(t, nil, false)
+		token, _, err := userManager.jwtClaims.GeneratePurposeToken(id, email, jwtutil.PurposeVerifyEmail, time.Hour)
+		require.NoError(t, err)
+		mockRepo.On("GetByID", mock.Anything, id).Return(nil, gorm.ErrRecordNotFound)
+
+		_, err = userManager.VerifyEmail(context.Background(), token)
+		assert.Equal(t, constant.ErrInvalidVerificationToken, err)
+	})
+
+	t.Run("AccessToken", func(t *testing.T) {
+		userManager, _ := newVerificationManager(t, nil, false)
+		token, _, err := userManager.jwtClaims.GenerateToken(id, email, time.Hour)
+		require.NoError(t, err)
+
+		_, err = userManager.VerifyEmail(context.Background(), token)
+		assert.Equal(t, constant.ErrInvalidVerificationToken, err)
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		userManager, _ := newVerificationManager(t, nil, false)
+		token, _, err := userManager.jwtClaims.GeneratePurposeToken(id, email, jwtutil.PurposeVerifyEmail, -time.Minute)
+		require.NoError(t, err)
+
+		_, err = userManager.VerifyEmail(context.Background(), token)
+		assert.Equal(t, constant.ErrInvalidVerificationToken, err)
+	})
+}
+
+func TestResendVerification(t *testing.T) {
+	email := "test@example.com"
+
+	t.Run("Unverified", func(t *testing.T) {
+		m := &recordingMailer{}
+		userManager, mockRepo := newVerificationManager(t, m, false)
+		mockRepo.On("GetByEmail", mock.Anything, email).Return(&entity.User{ID: uuid.New(), Email: email}, nil)
+
+		assert.NoError(t, userManager.ResendVerification(context.Background(), email))
+		assert.Len(t, m.messages, 1)
+	})
+
+	t.Run("AlreadyVerified", func(t *testing.T) {
+		m := &recordingMailer{}
+		userManager, mockRepo := newVerificationManager(t, m, false)
+		mockRepo.On("GetByEmail", mock.Anything, email).Return(&entity.User{ID: uuid.New(), Email: email, IsVerified: true}, nil)
+
+		assert.NoError(t, userManager.ResendVerification(context.Background(), email))
+		assert.Empty(t, m.messages)
+	})
+
+	t.Run("UnknownEmail", func(t *testing.T) {
+		m := &recordingMailer{}
+		userManager, mockRepo := newVerificationManager(t, m, false)
+		mockRepo.On("GetByEmail", mock.Anything, email).Return(nil, gorm.ErrRecordNotFound)
+
+		assert.NoError(t, userManager.ResendVerification(context.Background(), email))
+		assert.Empty(t, m.messages)
+	})
+
+	t.Run("MailerError", func(t *testing.T) {
+		m := &recordingMailer{err: errors.New("smtp down")}
+		userManager, mockRepo := newVerificationManager(t, m, false)
+		mockRepo.On("GetByEmail", mock.Anything, email).Return(&entity.User{ID: uuid.New(), Email: email}, nil)
+
+		assert.Error(t, userManager.ResendVerification(context.Background(), email))
+	})
+}
+
+func TestLoginUser_RequiresVerification(t *testing.T) {
+	password := "password123"
+	hashedPassword, _ := credentials.NewDefaultArgon2idHash().HashPassword(password, nil)
+
+	t.Run("Unverified", func(t *testing.T) {
+		userManager, mockRepo := newVerificationManager(t, nil, true)
+		mockRepo.On("GetByEmail", mock.Anything, "test@example.com").
+			Return(&entity.User{ID: uuid.New(), Email: "test@example.com", Password: hashedPassword}, nil)
+
+		_, _, err := userManager.LoginUser(context.Background(), &entity.User{Email: "test@example.com", Password: password}, "")
+		assert.Equal(t, constant.ErrEmailNotVerified, err)
+	})
+
+	t.Run("WrongPasswordFirst", func(t *testing.T) {
+		userManager, mockRepo := newVerificationManager(t, nil, true)
+		mockRepo.On("GetByEmail", mock.Anything, "test@example.com").
+			Return(&entity.User{ID: uuid.New(), Email: "test@example.com", Password: hashedPassword}, nil)
+
+		_, _, err := userManager.LoginUser(context.Background(), &entity.User{Email: "test@example.com", Password: "wrong"}, "")
+		assert.Error(t, err)
+		assert.NotEqual(t, constant.ErrEmailNotVerified, err)
+	})
+
+	t.Run("Verified", func(t *testing.T) {
+		userManager, mockRepo := newVerificationManager(t, nil, true)
+		mockRepo.On("GetByEmail", mock.Anything, "test@example.com").
+			Return(&entity.User{ID: uuid.New(), Email: "test@example.com", Password: hashedPassword, IsVerified: true}, nil)
+
+		token, _, err := userManager.LoginUser(context.Background(), &entity.User{Email: "test@example.com", Password: password}, "")
+		assert.NoError(t, err)
+		assert.NotNil(t, token)
+	})
+}
+
+func TestFormatTTL(t *testing.T) {
+	assert.Equal(t, "1 day", formatTTL(24*time.Hour))
+	assert.Equal(t, "2 days", formatTTL(48*time.Hour))
+	assert.Equal(t, "36 hours", formatTTL(36*time.Hour))
+	assert.Equal(t, "30 minutes", formatTTL(30*time.Minute))
+	assert.Equal(t, "45s", formatTTL(45*time.Second))
+}
@@ -0,0 +1,74 @@
+package jwt
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// OIDCLogin is what a login with an OpenID Connect provider remembers
+// between sending the user to the provider and their return.
+type OIDCLogin struct {
+	// Provider is the name of the provider.
+	Provider string
+	// State is echoed back by the provider on the redirect.
+	State string
+	// Nonce is echoed back by the provider in the ID token.
+	Nonce string
+	// Verifier is the PKCE code verifier of the authorization code.
+	Verifier string
+}
+
+// GenerateOIDCLoginToken generates a token carrying login, valid for
+// expirationDuration, that is only accepted by ParseOIDCLoginToken. Returns
+// the token string and expiration timestamp.
+func (t *TokenClaim) GenerateOIDCLoginToken(login OIDCLogin, expirationDuration time.Duration) (string, int64, error) {
+	exp := time.Now().Add(expirationDuration).Unix()
+	privateKey, err := loadRSAPrivateKeyFromFile(t.TokenPath)
+	if err != nil {
+		return "", 0, err
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"purpose":  PurposeOIDCLogin,
+		"provider": login.Provider,
+		"state":    login.State,
+		"nonce":    login.Nonce,
+		"verifier": login.Verifier,
+		"exp":      exp,
+	})
+
+	tokenString, err := token.SignedString(privateKey)
+	if err != nil {
+		return "", 0, fmt.Errorf("sign token: %w", err)
+	}
+
+	return tokenString, exp, nil
+}
+
+// ParseOIDCLoginToken checks a token generated by GenerateOIDCLoginToken and
+// returns the login it carries.
+func (t *TokenClaim) ParseOIDCLoginToken(tokenString string) (*OIDCLogin, error) {
+	claims, err := t.parseClaims(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	if claims["purpose"] != PurposeOIDCLogin {
+		return nil, fmt.Errorf("%w: not made for %s", ErrInvalidToken, PurposeOIDCLogin)
+	}
+	var login OIDCLogin
+	for key, value := range map[string]*string{
+		"provider": &login.Provider,
+		"state":    &login.State,
+		"nonce":    &login.Nonce,
+		"verifier": &login.Verifier,
+	} {
+		s, ok := claims[key].(string)
+		if !ok || s == "" {
+			return nil, fmt.Errorf("%w: missing %s", ErrInvalidToken, key)
+		}
+		*value = s
+	}
+	return &login, nil
+}
@@ -0,0 +1,56 @@
+package jwt
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOIDCLoginToken(t *testing.T) {
+	filePath, _ := setupTestPrivateKeyFile(t)
+	tc := NewTokenClaim(filePath)
+	login := OIDCLogin{Provider: "corp", State: "state", Nonce: "nonce", Verifier: "verifier"}
+
+	t.Run("Success", func(t *testing.T) {
+		token, exp, err := tc.GenerateOIDCLoginToken(login, time.Minute)
+		require.NoError(t, err)
+		assert.Greater(t, exp, time.Now().Unix())
+
+		got, err := tc.ParseOIDCLoginToken(token)
+		require.NoError(t, err)
+		assert.Equal(t, &login, got)
+
+		// It is neither an access token nor a purpose token.
+		_, _, _, err = tc.ParseAccessToken(token)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+		_, _, err = tc.ParsePurposeToken(token, PurposeOIDCLogin)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("PurposeToken", func(t *testing.T) {
+		token, _, err := tc.GeneratePurposeToken(uuid.New(), "test@example.com", PurposeMFA, time.Minute)
+		require.NoError(t, err)
+
+		_, err = tc.ParseOIDCLoginToken(token)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("MissingClaim", func(t *testing.T) {
+		token, _, err := tc.GenerateOIDCLoginToken(OIDCLogin{Provider: "corp", State: "state", Nonce: "nonce"}, time.Minute)
+		require.NoError(t, err)
+
+		_, err = tc.ParseOIDCLoginToken(token)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		token, _, err := tc.GenerateOIDCLoginToken(login, -time.Minute)
+		require.NoError(t, err)
+
+		_, err = tc.ParseOIDCLoginToken(token)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+}
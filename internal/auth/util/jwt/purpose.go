@@ -0,0 +1,96 @@
+package jwt
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// Token purposes. A token made for one purpose is refused for any other, and
+// access tokens, which have none, are refused for all of them.
+const (
+	PurposeVerifyEmail = "verify_email"
+	PurposeChangeEmail = "change_email"
+	PurposeMFA         = "mfa"
+	PurposeOIDCLogin   = "oidc_login"
+)
+
+// ErrInvalidToken is returned for tokens that are malformed, expired, not
+// signed with the key or made for another purpose.
+var ErrInvalidToken = errors.New("invalid token")
+
+// GeneratePurposeToken generates a token for the given user ID and email,
+// valid for expirationDuration, that is only accepted by ParsePurposeToken
+// for purpose. Returns the token string and expiration timestamp.
+func (t *TokenClaim) GeneratePurposeToken(userID uuid.UUID, email, purpose string, expirationDuration time.Duration) (string, int64, error) {
+	exp := time.Now().Add(expirationDuration).Unix()
+	privateKey, err := loadRSAPrivateKeyFromFile(t.TokenPath)
+	if err != nil {
+		return "", 0, err
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"sub":     userID.String(),
+		"email":   email,
+		"purpose": purpose,
+		"exp":     exp,
+	})
+
+	tokenString, err := token.SignedString(privateKey)
+	if err != nil {
+		return "", 0, fmt.Errorf("sign token: %w", err)
+	}
+
+	return tokenString, exp, nil
+}
+
+// ParsePurposeToken checks a token generated by GeneratePurposeToken for
+// purpose, and returns the user ID and email it was generated for.
+func (t *TokenClaim) ParsePurposeToken(tokenString, purpose string) (uuid.UUID, string, error) {
+	claims, err := t.parseClaims(tokenString)
+	if err != nil {
+		return uuid.Nil, "", err
+	}
+	if claims["purpose"] != purpose {
+		return uuid.Nil, "", fmt.Errorf("%w: not made for %s", ErrInvalidToken, purpose)
+	}
+	return subjectOf(claims)
+}
+
+// parseClaims checks the signature and expiry of a token and returns its
+// claims.
+func (t *TokenClaim) parseClaims(tokenString string) (jwt.MapClaims, error) {
+	privateKey, err := loadRSAPrivateKeyFromFile(t.TokenPath)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := jwt.MapClaims{}
+	_, err = jwt.ParseWithClaims(tokenString, claims, func(*jwt.Token) (any, error) {
+		return &privateKey.PublicKey, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	return claims, nil
+}
+
+// subjectOf returns the user ID and email a token was generated for.
+func subjectOf(claims jwt.MapClaims) (uuid.UUID, string, error) {
+	subject, err := claims.GetSubject()
+	if err != nil {
+		return uuid.Nil, "", fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	userID, err := uuid.Parse(subject)
+	if err != nil {
+		return uuid.Nil, "", fmt.Errorf("%w: invalid subject", ErrInvalidToken)
+	}
+	email, ok := claims["email"].(string)
+	if !ok {
+		return uuid.Nil, "", fmt.Errorf("%w: missing email", ErrInvalidToken)
+	}
+	return userID, email, nil
+}
@@ -0,0 +1,66 @@
+package jwt
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPurposeToken(t *testing.T) {
+	filePath, _ := setupTestPrivateKeyFile(t)
+	tc := NewTokenClaim(filePath)
+	userID := uuid.New()
+	email := "test@example.com"
+
+	t.Run("Success", func(t *testing.T) {
+		token, exp, err := tc.GeneratePurposeToken(userID, email, PurposeVerifyEmail, time.Hour)
+		require.NoError(t, err)
+		assert.Greater(t, exp, time.Now().Unix())
+
+		gotID, gotEmail, err := tc.ParsePurposeToken(token, PurposeVerifyEmail)
+		require.NoError(t, err)
+		assert.Equal(t, userID, gotID)
+		assert.Equal(t, email, gotEmail)
+	})
+
+	t.Run("OtherPurpose", func(t *testing.T) {
+		token, _, err := tc.GeneratePurposeToken(userID, email, "other", time.Hour)
+		require.NoError(t, err)
+
+		_, _, err = tc.ParsePurposeToken(token, PurposeVerifyEmail)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("AccessToken", func(t *testing.T) {
+		token, _, err := tc.GenerateToken(userID, email, time.Hour)
+		require.NoError(t, err)
+
+		_, _, err = tc.ParsePurposeToken(token, PurposeVerifyEmail)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		token, _, err := tc.GeneratePurposeToken(userID, email, PurposeVerifyEmail, -time.Minute)
+		require.NoError(t, err)
+
+		_, _, err = tc.ParsePurposeToken(token, PurposeVerifyEmail)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("OtherKey", func(t *testing.T) {
+		otherPath, _ := setupTestPrivateKeyFile(t)
+		token, _, err := NewTokenClaim(otherPath).GeneratePurposeToken(userID, email, PurposeVerifyEmail, time.Hour)
+		require.NoError(t, err)
+
+		_, _, err = tc.ParsePurposeToken(token, PurposeVerifyEmail)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("Malformed", func(t *testing.T) {
+		_, _, err := tc.ParsePurposeToken("not-a-token", PurposeVerifyEmail)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+}
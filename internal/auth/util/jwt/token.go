@@ -44,17 +44,51 @@ func loadRSAPrivateKeyFromFile(tokenPath string) (*rsa.PrivateKey, error) {
 // GenerateToken generates a JWT token for the given user ID and email.
 // Returns the token string, expiration timestamp, and any error that occurred.
 func (t *TokenClaim) GenerateToken(userID uuid.UUID, email string, expirationDuration time.Duration) (string, int64, error) {
+	return t.GenerateSessionToken(userID, email, 0, expirationDuration)
+}
+
+// GenerateSessionToken generates an access token like GenerateToken, bound to
+// the session version of the user so that it can be revoked by bumping it.
+func (t *TokenClaim) GenerateSessionToken(userID uuid.UUID, email string, sessionVersion int, expirationDuration time.Duration) (string, int64, error) {
+	return t.GenerateAccessToken(AccessClaims{UserID: userID, Email: email, SessionVersion: sessionVersion}, expirationDuration)
+}
+
+// AccessClaims are what an access token is issued for.
+type AccessClaims struct {
+	UserID         uuid.UUID
+	Email          string
+	SessionVersion int
+	// Role is the name of the role of the user when the token was issued,
+	// empty for tokens issued before users had roles.
+	Role string
+	// ImpersonatorID is the administrator the token was issued to, to act
+	// as the user. It is uuid.Nil for tokens the user logged in for.
+	ImpersonatorID uuid.UUID
+}
+
+// GenerateAccessToken generates an access token carrying claims, valid for
+// expirationDuration. Returns the token string and expiration timestamp.
+func (t *TokenClaim) GenerateAccessToken(claims AccessClaims, expirationDuration time.Duration) (string, int64, error) {
 	exp := time.Now().Add(expirationDuration).Unix()
 	privateKey, err := loadRSAPrivateKeyFromFile(t.TokenPath)
 	if err != nil {
 		return "", 0, err
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
-		"sub":   userID.String(),
-		"email": email,
+	mapClaims := jwt.MapClaims{
+		"sub":   claims.UserID.String(),
+		"email": claims.Email,
+		"sv":    claims.SessionVersion,
 		"exp":   exp,
-	})
+	}
+	if claims.Role != "" {
+		mapClaims["role"] = claims.Role
+	}
+	if claims.ImpersonatorID != uuid.Nil {
+		// The actor claim of RFC 8693 names who acts as the subject.
+		mapClaims["act"] = map[string]any{"sub": claims.ImpersonatorID.String()}
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, mapClaims)
 
 	tokenString, err := token.SignedString(privateKey)
 	if err != nil {
@@ -63,3 +97,58 @@ func (t *TokenClaim) GenerateToken(userID uuid.UUID, email string, expirationDur
 
 	return tokenString, exp, nil
 }
+
+// ParseAccessToken checks an access token and returns the user ID, email and
+// session version it was generated for. Tokens generated for a purpose are
+// refused.
+func (t *TokenClaim) ParseAccessToken(tokenString string) (uuid.UUID, string, int, error) {
+	claims, err := t.ParseAccessClaims(tokenString)
+	if err != nil {
+		return uuid.Nil, "", 0, err
+	}
+	return claims.UserID, claims.Email, claims.SessionVersion, nil
+}
+
+// ParseAccessClaims checks an access token like ParseAccessToken and returns
+// all of its claims.
+func (t *TokenClaim) ParseAccessClaims(tokenString string) (*AccessClaims, error) {
+	claims, err := t.parseClaims(tokenString)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := claims["purpose"]; ok {
+		return nil, fmt.Errorf("%w: not an access token", ErrInvalidToken)
+	}
+	userID, email, err := subjectOf(claims)
+	if err != nil {
+		return nil, err
+	}
+	access := &AccessClaims{UserID: userID, Email: email}
+	// Tokens generated before session versions carry none, which is the
+	// version of users who never revoked their sessions.
+	if sv, ok := claims["sv"]; ok {
+		f, ok := sv.(float64)
+		if !ok {
+			return nil, fmt.Errorf("%w: invalid session version", ErrInvalidToken)
+		}
+		access.SessionVersion = int(f)
+	}
+	if role, ok := claims["role"]; ok {
+		access.Role, ok = role.(string)
+		if !ok {
+			return nil, fmt.Errorf("%w: invalid role", ErrInvalidToken)
+		}
+	}
+	if act, ok := claims["act"]; ok {
+		actor, ok := act.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("%w: invalid actor", ErrInvalidToken)
+		}
+		sub, _ := actor["sub"].(string)
+		access.ImpersonatorID, err = uuid.Parse(sub)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid actor", ErrInvalidToken)
+		}
+	}
+	return access, nil
+}
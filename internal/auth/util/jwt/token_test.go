@@ -194,6 +194,78 @@ func TestGenerateToken(t *testing.T) {
 	})
 }
 
+func TestParseAccessToken(t *testing.T) {
+	filePath, _ := setupTestPrivateKeyFile(t)
+	tokenClaim := TokenClaim{TokenPath: filePath}
+	userID := uuid.New()
+	email := "test@example.com"
+
+	t.Run("Success", func(t *testing.T) {
+		token, _, err := tokenClaim.GenerateSessionToken(userID, email, 3, time.Minute)
+		require.NoError(t, err)
+
+		gotID, gotEmail, sessionVersion, err := tokenClaim.ParseAccessToken(token)
+		require.NoError(t, err)
+		assert.Equal(t, userID, gotID)
+		assert.Equal(t, email, gotEmail)
+		assert.Equal(t, 3, sessionVersion)
+	})
+
+	t.Run("PurposeToken", func(t *testing.T) {
+		token, _, err := tokenClaim.GeneratePurposeToken(userID, email, PurposeVerifyEmail, time.Minute)
+		require.NoError(t, err)
+
+		_, _, _, err = tokenClaim.ParseAccessToken(token)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		token, _, err := tokenClaim.GenerateToken(userID, email, -time.Minute)
+		require.NoError(t, err)
+
+		_, _, _, err = tokenClaim.ParseAccessToken(token)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+}
+
+func TestParseAccessClaims(t *testing.T) {
+	filePath, _ := setupTestPrivateKeyFile(t)
+	tokenClaim := TokenClaim{TokenPath: filePath}
+	userID := uuid.New()
+	adminID := uuid.New()
+
+	t.Run("Impersonation", func(t *testing.T) {
+		token, _, err := tokenClaim.GenerateAccessToken(AccessClaims{
+			UserID:         userID,
+			Email:          "test@example.com",
+			SessionVersion: 2,
+			Role:           "user",
+			ImpersonatorID: adminID,
+		}, time.Minute)
+		require.NoError(t, err)
+
+		claims, err := tokenClaim.ParseAccessClaims(token)
+		require.NoError(t, err)
+		assert.Equal(t, &AccessClaims{
+			UserID:         userID,
+			Email:          "test@example.com",
+			SessionVersion: 2,
+			Role:           "user",
+			ImpersonatorID: adminID,
+		}, claims)
+	})
+
+	t.Run("WithoutRole", func(t *testing.T) {
+		token, _, err := tokenClaim.GenerateSessionToken(userID, "test@example.com", 1, time.Minute)
+		require.NoError(t, err)
+
+		claims, err := tokenClaim.ParseAccessClaims(token)
+		require.NoError(t, err)
+		assert.Empty(t, claims.Role)
+		assert.Equal(t, uuid.Nil, claims.ImpersonatorID)
+	})
+}
+
 func TestNewTokenClaim(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,52 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var _ Mailer = &fileMailer{}
+
+type fileMailer struct {
+	dir  string
+	from *mail.Address
+	now  func() time.Time
+}
+
+// NewFileMailer returns a Mailer writing every email to its own .eml file in
+// dir instead of sending it, for local development.
+func NewFileMailer(dir, from string) (Mailer, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("mail directory must be specified")
+	}
+	sender, err := mail.ParseAddress(from)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sender address: %w", err)
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, err
+	}
+	return &fileMailer{dir: dir, from: sender, now: time.Now}, nil
+}
+
+func (m *fileMailer) Send(ctx context.Context, msg *Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := msg.Validate(); err != nil {
+		return err
+	}
+	now := m.now()
+	data, err := compose(m.from.String(), msg, now)
+	if err != nil {
+		return err
+	}
+	name := fmt.Sprintf("%s-%s.eml", now.UTC().Format("20060102T150405Z"), uuid.NewString())
+	return os.WriteFile(filepath.Join(m.dir, name), data, 0o640)
+}
@@ -0,0 +1,31 @@
+package mailer
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+var _ Mailer = &logMailer{}
+
+type logMailer struct {
+	from string
+}
+
+// NewLogMailer returns a Mailer that only logs emails, for local
+// development. Emails carry tokens, so it must not be used in production.
+func NewLogMailer(from string) Mailer {
+	return &logMailer{from: from}
+}
+
+func (m *logMailer) Send(ctx context.Context, msg *Message) error {
+	if err := msg.Validate(); err != nil {
+		return err
+	}
+	logrus.WithFields(logrus.Fields{
+		"from":    m.from,
+		"to":      msg.To,
+		"subject": msg.Subject,
+	}).Infof("Email not sent, log mail driver in use:\n%s", msg.Body)
+	return nil
+}
@@ -0,0 +1,208 @@
+package mailer
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"net"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessage_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		msg     Message
+		wantErr bool
+	}{
+		{name: "valid", msg: Message{To: "user@example.com", Subject: "Hello"}},
+		{name: "named recipient", msg: Message{To: "User <user@example.com>", Subject: "Hello"}},
+		{name: "invalid recipient", msg: Message{To: "not an address", Subject: "Hello"}, wantErr: true},
+		{name: "header injection in recipient", msg: Message{To: "user@example.com\r\nBcc: x@example.com"}, wantErr: true},
+		{name: "header injection in subject", msg: Message{To: "user@example.com", Subject: "Hi\r\nBcc: x@example.com"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.msg.Validate()
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidMessage)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCompose(t *testing.T) {
+	msg := &Message{To: "user@example.com", Subject: "Vérifiez", Body: "line one\nline two é"}
+	data, err := compose("Doc Formatter <noreply@example.com>", msg, time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC))
+	require.NoError(t, err)
+
+	parsed, err := mail.ReadMessage(strings.NewReader(string(data)))
+	require.NoError(t, err)
+	assert.Equal(t, "Doc Formatter <noreply@example.com>", parsed.Header.Get("From"))
+	assert.Equal(t, "<user@example.com>", parsed.Header.Get("To"))
+	assert.Equal(t, "=?utf-8?q?V=C3=A9rifiez?=", parsed.Header.Get("Subject"))
+	assert.Equal(t, "quoted-printable", parsed.Header.Get("Content-Transfer-Encoding"))
+	assert.Contains(t, string(data), "line one\r\nline two =C3=A9")
+	assert.NotContains(t, strings.ReplaceAll(string(data), "\r\n", ""), "\n")
+}
+
+func TestFileMailer(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "mail")
+	m, err := NewFileMailer(dir, "noreply@example.com")
+	require.NoError(t, err)
+
+	require.NoError(t, m.Send(context.Background(), &Message{To: "user@example.com", Subject: "Hello", Body: "Hi"}))
+	require.Error(t, m.Send(context.Background(), &Message{To: "invalid"}))
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.True(t, strings.HasSuffix(entries[0].Name(), ".eml"))
+	data, err := os.ReadFile(filepath.Join(dir, entries[0].Name()))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "Subject: Hello\r\n")
+
+	_, err = NewFileMailer("", "noreply@example.com")
+	assert.Error(t, err)
+}
+
+func TestLogMailer(t *testing.T) {
+	m := NewLogMailer("noreply@example.com")
+	assert.NoError(t, m.Send(context.Background(), &Message{To: "user@example.com", Subject: "Hello"}))
+	assert.ErrorIs(t, m.Send(context.Background(), &Message{To: "invalid"}), ErrInvalidMessage)
+}
+
+// fakeSMTPServer accepts one session and records what it was sent.
+type fakeSMTPServer struct {
+	addr string
+	wg   sync.WaitGroup
+
+	mu       sync.Mutex
+	auth     string
+	mailFrom string
+	rcptTo   string
+	data     string
+}
+
+func startFakeSMTPServer(t *testing.T) *fakeSMTPServer {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { lis.Close() })
+
+	s := &fakeSMTPServer{addr: lis.Addr().String()}
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		conn, err := lis.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		s.serve(conn)
+	}()
+	return s
+}
+
+func (s *fakeSMTPServer) serve(conn net.Conn) {
+	r := bufio.NewReader(conn)
+	reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+	reply("220 localhost ESMTP")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		cmd := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+		s.mu.Lock()
+		switch cmd {
+		case "EHLO":
+			reply("250-localhost")
+			reply("250 AUTH PLAIN")
+		case "AUTH":
+			decoded, _ := base64.StdEncoding.DecodeString(strings.TrimPrefix(line, "AUTH PLAIN "))
+			s.auth = string(decoded)
+			reply("235 OK")
+		case "MAIL":
+			s.mailFrom = line
+			reply("250 OK")
+		case "RCPT":
+			s.rcptTo = line
+			reply("250 OK")
+		case "DATA":
+			reply("354 Go ahead")
+			var data strings.Builder
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil || l == ".\r\n" {
+					break
+				}
+				data.WriteString(l)
+			}
+			s.data = data.String()
+			reply("250 OK")
+		case "QUIT":
+			reply("221 Bye")
+			s.mu.Unlock()
+			return
+		default:
+			reply("250 OK")
+		}
+		s.mu.Unlock()
+	}
+}
+
+func TestSMTPMailer_Send(t *testing.T) {
+	server := startFakeSMTPServer(t)
+	host, portStr, err := net.SplitHostPort(server.addr)
+	require.NoError(t, err)
+	port, err := strconv.Atoi(portStr)
+	require.NoError(t, err)
+
+	m, err := NewSMTPMailer(SMTPConfig{
+		Host:     host,
+		Port:     port,
+		Username: "mailer",
+		Password: "secret",
+		From:     "Doc Formatter <noreply@example.com>",
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, m.Send(ctx, &Message{To: "user@example.com", Subject: "Hello", Body: "Hi there"}))
+	server.wg.Wait()
+
+	assert.Equal(t, "\x00mailer\x00secret", server.auth)
+	assert.Equal(t, "MAIL FROM:<noreply@example.com>", server.mailFrom)
+	assert.Equal(t, "RCPT TO:<user@example.com>", server.rcptTo)
+	assert.Contains(t, server.data, "Subject: Hello\r\n")
+	assert.Contains(t, server.data, "Hi there")
+}
+
+func TestSMTPMailer_Errors(t *testing.T) {
+	_, err := NewSMTPMailer(SMTPConfig{From: "noreply@example.com"})
+	assert.Error(t, err)
+	_, err = NewSMTPMailer(SMTPConfig{Host: "localhost", From: "invalid"})
+	assert.Error(t, err)
+
+	m, err := NewSMTPMailer(SMTPConfig{Host: "localhost", From: "noreply@example.com"})
+	require.NoError(t, err)
+	assert.ErrorIs(t, m.Send(context.Background(), &Message{To: "invalid"}), ErrInvalidMessage)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Error(t, m.Send(ctx, &Message{To: "user@example.com"}))
+}
@@ -0,0 +1,109 @@
+package mailer
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"time"
+)
+
+// DefaultSMTPPort is the submission port.
+const DefaultSMTPPort = 587
+
+// SMTPConfig is the server and account used by the SMTP driver.
+type SMTPConfig struct {
+	Host string `yaml:"host" json:"host"`
+	Port int    `yaml:"port" json:"port"`
+	// Username and Password are used for PLAIN authentication, which is
+	// skipped when Username is empty.
+	Username string `yaml:"username" json:"username"`
+	Password string `yaml:"password" json:"-"`
+	// From is the sender address of every email.
+	From string `yaml:"from" json:"from"`
+}
+
+var _ Mailer = &smtpMailer{}
+
+type smtpMailer struct {
+	config SMTPConfig
+	from   *mail.Address
+	now    func() time.Time
+}
+
+// NewSMTPMailer returns a Mailer delivering through the SMTP server of
+// config, upgrading the connection with STARTTLS when the server offers it.
+func NewSMTPMailer(config SMTPConfig) (Mailer, error) {
+	if config.Host == "" {
+		return nil, fmt.Errorf("smtp host must be specified")
+	}
+	from, err := mail.ParseAddress(config.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sender address: %w", err)
+	}
+	if config.Port == 0 {
+		config.Port = DefaultSMTPPort
+	}
+	return &smtpMailer{config: config, from: from, now: time.Now}, nil
+}
+
+func (m *smtpMailer) Send(ctx context.Context, msg *Message) error {
+	if err := msg.Validate(); err != nil {
+		return err
+	}
+	data, err := compose(m.from.String(), msg, m.now())
+	if err != nil {
+		return err
+	}
+	to, _ := mail.ParseAddress(msg.To)
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(m.config.Host, strconv.Itoa(m.config.Port)))
+	if err != nil {
+		return fmt.Errorf("dial smtp server: %w", err)
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return err
+		}
+	}
+
+	client, err := smtp.NewClient(conn, m.config.Host)
+	if err != nil {
+		return fmt.Errorf("smtp greeting: %w", err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: m.config.Host}); err != nil {
+			return fmt.Errorf("smtp starttls: %w", err)
+		}
+	}
+	if m.config.Username != "" {
+		auth := smtp.PlainAuth("", m.config.Username, m.config.Password, m.config.Host)
+		if err := client.Auth(auth); err != nil {
+			return fmt.Errorf("smtp auth: %w", err)
+		}
+	}
+	if err := client.Mail(m.from.Address); err != nil {
+		return fmt.Errorf("smtp mail from: %w", err)
+	}
+	if err := client.Rcpt(to.Address); err != nil {
+		return fmt.Errorf("smtp rcpt to: %w", err)
+	}
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("smtp data: %w", err)
+	}
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("smtp data: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("smtp data: %w", err)
+	}
+	return client.Quit()
+}
@@ -0,0 +1,47 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+)
+
+// Supported mail drivers.
+const (
+	DriverSMTP = "smtp"
+	DriverFile = "file"
+	DriverLog  = "log"
+)
+
+var (
+	ErrUnknownDriver  = errors.New("unknown mail driver")
+	ErrInvalidMessage = errors.New("invalid mail message")
+)
+
+// Message is a plain text email to a single recipient.
+type Message struct {
+	To      string `yaml:"to" json:"to"`
+	Subject string `yaml:"subject" json:"subject"`
+	Body    string `yaml:"body" json:"body"`
+}
+
+// Validate checks that the message can be put in an email without letting
+// its fields add headers of their own.
+func (m *Message) Validate() error {
+	if _, err := mail.ParseAddress(m.To); err != nil {
+		return fmt.Errorf("%w: recipient: %v", ErrInvalidMessage, err)
+	}
+	if strings.ContainsAny(m.Subject, "\r\n") {
+		return fmt.Errorf("%w: subject contains a line break", ErrInvalidMessage)
+	}
+	return nil
+}
+
+// Mailer sends the emails of the auth service. Implementations must be safe
+// for concurrent use.
+type Mailer interface {
+	// Send delivers msg, or fails once ctx is done.
+	Send(ctx context.Context, msg *Message) error
+}
@@ -0,0 +1,38 @@
+package oidc
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// File is the layout of the file identity providers are configured in.
+type File struct {
+	Providers []ProviderConfig `yaml:"providers"`
+}
+
+// LoadConfig reads the providers configured in the YAML file at path and
+// validates them.
+func LoadConfig(path string) ([]ProviderConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read identity provider file %q: %w", path, err)
+	}
+	var file File
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("parse identity provider file %q: %w", path, err)
+	}
+	names := make(map[string]struct{}, len(file.Providers))
+	for i := range file.Providers {
+		config := &file.Providers[i]
+		if err := config.Validate(); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		if _, ok := names[config.Name]; ok {
+			return nil, fmt.Errorf("%s: %w: duplicate name %q", path, ErrInvalidConfig, config.Name)
+		}
+		names[config.Name] = struct{}{}
+	}
+	return file.Providers, nil
+}
@@ -0,0 +1,67 @@
+package oidc
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "providers.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadConfig(t *testing.T) {
+	path := writeConfig(t, `
+providers:
+  - name: corp
+    issuer: https://idp.example.com
+    client_id: client
+    client_secret: secret
+    redirect_url: https://gateway.example.com/api/v1/auth/oidc/corp/callback
+    scopes: [email]
+`)
+	providers, err := LoadConfig(path)
+	require.NoError(t, err)
+	assert.Equal(t, []ProviderConfig{{
+		Name:         "corp",
+		Issuer:       "https://idp.example.com",
+		ClientID:     "client",
+		ClientSecret: "secret",
+		RedirectURL:  "https://gateway.example.com/api/v1/auth/oidc/corp/callback",
+		Scopes:       []string{"email"},
+	}}, providers)
+}
+
+func TestLoadConfig_Invalid(t *testing.T) {
+	provider := `
+  - name: corp
+    issuer: https://idp.example.com
+    client_id: client
+    redirect_url: https://gateway.example.com/callback
+`
+	tests := map[string]string{
+		"Duplicate":      "providers:" + provider + provider,
+		"InvalidName":    "providers:\n  - name: Corp IdP\n    issuer: https://idp.example.com\n    client_id: client\n    redirect_url: https://gateway.example.com/callback\n",
+		"NoIssuer":       "providers:\n  - name: corp\n    client_id: client\n    redirect_url: https://gateway.example.com/callback\n",
+		"NoClientID":     "providers:\n  - name: corp\n    issuer: https://idp.example.com\n    redirect_url: https://gateway.example.com/callback\n",
+		"NoRedirectURL":  "providers:\n  - name: corp\n    issuer: https://idp.example.com\n    client_id: client\n",
+		"RelativeIssuer": "providers:\n  - name: corp\n    issuer: idp.example.com\n    client_id: client\n    redirect_url: https://gateway.example.com/callback\n",
+	}
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := LoadConfig(writeConfig(t, content))
+			assert.ErrorIs(t, err, ErrInvalidConfig)
+		})
+	}
+
+	_, err := LoadConfig(writeConfig(t, "providers: ["))
+	assert.Error(t, err)
+	_, err = LoadConfig(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,37 @@
+package oidc
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJWK_PublicKey(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	point, err := key.PublicKey.Bytes()
+	require.NoError(t, err)
+	ec := jwk{
+		Kty: "EC",
+		Crv: "P-256",
+		X:   base64.RawURLEncoding.EncodeToString(point[1:33]),
+		Y:   base64.RawURLEncoding.EncodeToString(point[33:]),
+	}
+	pub, err := ec.publicKey()
+	require.NoError(t, err)
+	assert.True(t, key.PublicKey.Equal(pub))
+
+	ec.Crv = "P-384"
+	_, err = ec.publicKey()
+	assert.Error(t, err, "coordinates of another curve")
+
+	_, err = (&jwk{Kty: "RSA", N: "AQAB", E: "AQ"}).publicKey()
+	assert.Error(t, err, "exponent 1")
+	_, err = (&jwk{Kty: "oct"}).publicKey()
+	assert.Error(t, err)
+}
@@ -0,0 +1,223 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/oauth2"
+)
+
+// httpTimeout bounds every request to a provider.
+const httpTimeout = 10 * time.Second
+
+// idTokenLeeway is the clock skew allowed when checking ID tokens.
+const idTokenLeeway = time.Minute
+
+// signingMethods are the algorithms ID tokens are accepted signed with.
+// Unsigned tokens and tokens signed with the client secret are refused.
+var signingMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}
+
+// Provider logs users in with an OpenID Connect provider with the
+// authorization code flow and PKCE. Its discovery document is fetched the
+// first time it is used, so that the auth service starts while the provider
+// is down.
+type Provider struct {
+	config ProviderConfig
+	client *http.Client
+
+	mu        sync.Mutex
+	discovery *discovery
+	keys      *keySet
+}
+
+// NewProvider builds a provider from config. Requests to the provider are
+// sent with client, or with a client timing out after 10 seconds when it is
+// nil.
+func NewProvider(config ProviderConfig, client *http.Client) (*Provider, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if client == nil {
+		client = &http.Client{Timeout: httpTimeout}
+	}
+	return &Provider{config: config, client: client}, nil
+}
+
+// NewVerifier returns a new PKCE code verifier.
+func NewVerifier() string {
+	return oauth2.GenerateVerifier()
+}
+
+// Name returns the name of the provider.
+func (p *Provider) Name() string {
+	return p.config.Name
+}
+
+// AuthCodeURL returns the URL of the provider to send users to. state is
+// echoed back on the redirect URL, nonce is echoed back in the ID token and
+// verifier is the PKCE code verifier Exchange must be given again.
+func (p *Provider) AuthCodeURL(ctx context.Context, state, nonce, verifier string) (string, error) {
+	oauthConfig, err := p.oauthConfig(ctx)
+	if err != nil {
+		return "", err
+	}
+	return oauthConfig.AuthCodeURL(state,
+		oauth2.S256ChallengeOption(verifier),
+		oauth2.SetAuthURLParam("nonce", nonce),
+	), nil
+}
+
+// Exchange redeems an authorization code with the PKCE code verifier it was
+// asked for with, and returns the claims of the verified ID token the
+// provider issued for it. The ID token must carry nonce.
+func (p *Provider) Exchange(ctx context.Context, code, verifier, nonce string) (*Claims, error) {
+	oauthConfig, err := p.oauthConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	token, err := oauthConfig.Exchange(context.WithValue(ctx, oauth2.HTTPClient, p.client), code, oauth2.VerifierOption(verifier))
+	var retrieveErr *oauth2.RetrieveError
+	if errors.As(err, &retrieveErr) && retrieveErr.Response != nil && retrieveErr.Response.StatusCode < http.StatusInternalServerError {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCode, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("exchange authorization code: %w", err)
+	}
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok || rawIDToken == "" {
+		return nil, fmt.Errorf("%w: token response carries none", ErrInvalidIDToken)
+	}
+	return p.VerifyIDToken(ctx, rawIDToken, nonce)
+}
+
+// VerifyIDToken checks the signature of an ID token against the keys of the
+// provider, that it was issued by the provider for the client and has not
+// expired, and that it carries nonce. It returns the claims of the token.
+func (p *Provider) VerifyIDToken(ctx context.Context, rawIDToken, nonce string) (*Claims, error) {
+	d, keys, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := jwt.MapClaims{}
+	_, err = jwt.ParseWithClaims(rawIDToken, claims, func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+		return keys.key(ctx, kid)
+	},
+		jwt.WithValidMethods(signingMethods),
+		jwt.WithIssuer(d.Issuer),
+		jwt.WithAudience(p.config.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(idTokenLeeway),
+	)
+	if err != nil {
+		if errors.Is(err, ErrInvalidIDToken) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %v", ErrInvalidIDToken, err)
+	}
+	// A token issued to several clients must name the client it was issued
+	// for.
+	if azp, ok := claims["azp"]; ok && azp != p.config.ClientID {
+		return nil, fmt.Errorf("%w: issued for another client", ErrInvalidIDToken)
+	}
+	if claims["nonce"] != nonce {
+		return nil, fmt.Errorf("%w: nonce mismatch", ErrInvalidIDToken)
+	}
+
+	subject, err := claims.GetSubject()
+	if err != nil || subject == "" {
+		return nil, fmt.Errorf("%w: missing subject", ErrInvalidIDToken)
+	}
+	email, _ := claims["email"].(string)
+	return &Claims{
+		Subject:       subject,
+		Email:         strings.TrimSpace(email),
+		EmailVerified: emailVerified(claims["email_verified"]),
+	}, nil
+}
+
+// emailVerified reads the email_verified claim, which some providers send as
+// a string.
+func emailVerified(claim any) bool {
+	switch v := claim.(type) {
+	case bool:
+		return v
+	case string:
+		return strings.EqualFold(v, "true")
+	default:
+		return false
+	}
+}
+
+func (p *Provider) oauthConfig(ctx context.Context) (*oauth2.Config, error) {
+	d, _, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	scopes := p.config.Scopes
+	if len(scopes) == 0 {
+		scopes = DefaultScopes
+	}
+	if !slices.Contains(scopes, ScopeOpenID) {
+		scopes = append([]string{ScopeOpenID}, scopes...)
+	}
+	return &oauth2.Config{
+		ClientID:     p.config.ClientID,
+		ClientSecret: p.config.ClientSecret,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  d.AuthorizationEndpoint,
+			TokenURL: d.TokenEndpoint,
+		},
+		RedirectURL: p.config.RedirectURL,
+		Scopes:      scopes,
+	}, nil
+}
+
+// discover fetches the discovery document of the provider once it fetched
+// it successfully.
+func (p *Provider) discover(ctx context.Context) (*discovery, *keySet, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.discovery != nil {
+		return p.discovery, p.keys, nil
+	}
+
+	issuer := strings.TrimSuffix(p.config.Issuer, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, issuer+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %s: %v", ErrDiscovery, p.config.Name, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("%w: %s: %s", ErrDiscovery, p.config.Name, resp.Status)
+	}
+	var d discovery
+	if err := json.NewDecoder(resp.Body).Decode(&d); err != nil {
+		return nil, nil, fmt.Errorf("%w: %s: %v", ErrDiscovery, p.config.Name, err)
+	}
+	// The issuer must be the one configured, so that a provider cannot
+	// issue tokens in the name of another.
+	if strings.TrimSuffix(d.Issuer, "/") != issuer {
+		return nil, nil, fmt.Errorf("%w: %s: issuer %q does not match %q", ErrDiscovery, p.config.Name, d.Issuer, p.config.Issuer)
+	}
+	if d.AuthorizationEndpoint == "" || d.TokenEndpoint == "" || d.JWKSURI == "" {
+		return nil, nil, fmt.Errorf("%w: %s: missing endpoints", ErrDiscovery, p.config.Name)
+	}
+
+	p.discovery = &d
+	p.keys = &keySet{uri: d.JWKSURI, client: p.client}
+	return p.discovery, p.keys, nil
+}
@@ -0,0 +1,159 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/a1y/doc-formatter/internal/auth/util/oidc/oidctest"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+const testRedirectURL = "https://gateway.example.com/api/v1/auth/oidc/corp/callback"
+
+func newTestProvider(t *testing.T) (*Provider, *oidctest.Server) {
+	t.Helper()
+	idp := oidctest.NewServer("client", "secret")
+	t.Cleanup(idp.Close)
+	p, err := NewProvider(ProviderConfig{
+		Name:         "corp",
+		Issuer:       idp.Issuer(),
+		ClientID:     "client",
+		ClientSecret: "secret",
+		RedirectURL:  testRedirectURL,
+	}, nil)
+	require.NoError(t, err)
+	return p, idp
+}
+
+// authorize follows authURL to the mock provider and returns the code and
+// state it redirects back with.
+func authorize(t *testing.T, authURL string) (string, string) {
+	t.Helper()
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	resp, err := client.Get(authURL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusFound, resp.StatusCode)
+	location, err := url.Parse(resp.Header.Get("Location"))
+	require.NoError(t, err)
+	return location.Query().Get("code"), location.Query().Get("state")
+}
+
+func TestProvider_Login(t *testing.T) {
+	p, idp := newTestProvider(t)
+	idp.SetUser(oidctest.User{Subject: "sub-1", Email: "test@example.com", EmailVerified: true})
+	ctx := context.Background()
+	verifier := oauth2.GenerateVerifier()
+
+	authURL, err := p.AuthCodeURL(ctx, "state", "nonce", verifier)
+	require.NoError(t, err)
+	params, err := url.Parse(authURL)
+	require.NoError(t, err)
+	assert.Equal(t, "openid email profile", params.Query().Get("scope"))
+	assert.Equal(t, testRedirectURL, params.Query().Get("redirect_uri"))
+	assert.Equal(t, oauth2.S256ChallengeFromVerifier(verifier), params.Query().Get("code_challenge"))
+
+	code, state := authorize(t, authURL)
+	assert.Equal(t, "state", state)
+
+	claims, err := p.Exchange(ctx, code, verifier, "nonce")
+	require.NoError(t, err)
+	assert.Equal(t, &Claims{Subject: "sub-1", Email: "test@example.com", EmailVerified: true}, claims)
+
+	// Codes are single-use.
+	_, err = p.Exchange(ctx, code, verifier, "nonce")
+	assert.ErrorIs(t, err, ErrInvalidCode)
+}
+
+func TestProvider_Exchange_Invalid(t *testing.T) {
+	p, idp := newTestProvider(t)
+	idp.SetUser(oidctest.User{Subject: "sub-1"})
+	ctx := context.Background()
+	verifier := oauth2.GenerateVerifier()
+
+	authURL, err := p.AuthCodeURL(ctx, "state", "nonce", verifier)
+	require.NoError(t, err)
+	code, _ := authorize(t, authURL)
+	_, err = p.Exchange(ctx, code, oauth2.GenerateVerifier(), "nonce")
+	assert.ErrorIs(t, err, ErrInvalidCode, "wrong verifier")
+
+	code, _ = authorize(t, authURL)
+	_, err = p.Exchange(ctx, code, verifier, "other-nonce")
+	assert.ErrorIs(t, err, ErrInvalidIDToken, "wrong nonce")
+}
+
+func TestProvider_VerifyIDToken(t *testing.T) {
+	p, idp := newTestProvider(t)
+	ctx := context.Background()
+	user := oidctest.User{Subject: "sub-1", Email: "test@example.com"}
+
+	claims, err := p.VerifyIDToken(ctx, idp.SignIDToken(idp.IDTokenClaims(user, "nonce")), "nonce")
+	require.NoError(t, err)
+	assert.Equal(t, "sub-1", claims.Subject)
+	assert.False(t, claims.EmailVerified)
+
+	withClaim := func(key string, value any) string {
+		c := idp.IDTokenClaims(user, "nonce")
+		if value == nil {
+			delete(c, key)
+		} else {
+			c[key] = value
+		}
+		return idp.SignIDToken(c)
+	}
+	tests := map[string]string{
+		"OtherIssuer":   withClaim("iss", "https://idp.example.com"),
+		"OtherAudience": withClaim("aud", "other-client"),
+		"OtherAZP":      withClaim("azp", "other-client"),
+		"Expired":       withClaim("exp", time.Now().Add(-time.Hour).Unix()),
+		"NoExpiry":      withClaim("exp", nil),
+		"NoSubject":     withClaim("sub", nil),
+		"NoNonce":       withClaim("nonce", nil),
+		"Unsigned":      unsigned(t, idp.IDTokenClaims(user, "nonce")),
+		"Malformed":     "not-a-token",
+	}
+	for name, token := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := p.VerifyIDToken(ctx, token, "nonce")
+			assert.ErrorIs(t, err, ErrInvalidIDToken)
+		})
+	}
+}
+
+func unsigned(t *testing.T, claims jwt.MapClaims) string {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodNone, claims).SignedString(jwt.UnsafeAllowNoneSignatureType)
+	require.NoError(t, err)
+	return token
+}
+
+func TestProvider_Discovery(t *testing.T) {
+	idp := oidctest.NewServer("client", "secret")
+	defer idp.Close()
+
+	// Discovery documents of another issuer are refused.
+	p, err := NewProvider(ProviderConfig{
+		Name:        "corp",
+		Issuer:      idp.Issuer() + "/tenant",
+		ClientID:    "client",
+		RedirectURL: testRedirectURL,
+	}, nil)
+	require.NoError(t, err)
+	_, err = p.AuthCodeURL(context.Background(), "state", "nonce", "verifier")
+	assert.ErrorIs(t, err, ErrDiscovery)
+}
+
+func TestEmailVerified(t *testing.T) {
+	assert.True(t, emailVerified(true))
+	assert.True(t, emailVerified("true"))
+	assert.False(t, emailVerified(false))
+	assert.False(t, emailVerified("false"))
+	assert.False(t, emailVerified(nil))
+}
@@ -0,0 +1,80 @@
+package oidc
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+)
+
+// ScopeOpenID is the scope every authentication request asks for.
+const ScopeOpenID = "openid"
+
+// DefaultScopes are asked for when a provider sets none: the subject and the
+// email address of the user.
+var DefaultScopes = []string{ScopeOpenID, "email", "profile"}
+
+var (
+	ErrInvalidConfig  = errors.New("invalid identity provider config")
+	ErrDiscovery      = errors.New("identity provider discovery failed")
+	ErrInvalidCode    = errors.New("invalid or expired authorization code")
+	ErrInvalidIDToken = errors.New("invalid ID token")
+)
+
+var providerNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// ProviderConfig is an OpenID Connect provider users can log in with, and
+// the client the auth service is registered as there.
+type ProviderConfig struct {
+	// Name identifies the provider in login routes, like "corp". It is
+	// lowercase letters, digits, dashes and underscores.
+	Name string `yaml:"name" json:"name"`
+	// Issuer is the issuer URL of the provider, where its discovery document
+	// is found under /.well-known/openid-configuration.
+	Issuer string `yaml:"issuer" json:"issuer"`
+	// ClientID and ClientSecret are the credentials of the client.
+	ClientID     string `yaml:"client_id" json:"client_id"`
+	ClientSecret string `yaml:"client_secret" json:"-"`
+	// RedirectURL is the callback route of the gateway registered for the
+	// client, which the provider sends users back to.
+	RedirectURL string `yaml:"redirect_url" json:"redirect_url"`
+	// Scopes are asked for on top of openid. DefaultScopes are used when it
+	// is empty.
+	Scopes []string `yaml:"scopes" json:"scopes"`
+}
+
+// Validate checks that the config names a provider and a client.
+func (c *ProviderConfig) Validate() error {
+	if !providerNamePattern.MatchString(c.Name) {
+		return fmt.Errorf("%w: invalid name %q", ErrInvalidConfig, c.Name)
+	}
+	if u, err := url.Parse(c.Issuer); err != nil || u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("%w: %s: invalid issuer %q", ErrInvalidConfig, c.Name, c.Issuer)
+	}
+	if c.ClientID == "" {
+		return fmt.Errorf("%w: %s: client id is required", ErrInvalidConfig, c.Name)
+	}
+	if u, err := url.Parse(c.RedirectURL); err != nil || u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("%w: %s: invalid redirect url %q", ErrInvalidConfig, c.Name, c.RedirectURL)
+	}
+	return nil
+}
+
+// Claims are the claims of a verified ID token the auth service uses.
+type Claims struct {
+	// Subject identifies the user at the provider. It never changes, unlike
+	// their email address.
+	Subject string
+	Email   string
+	// EmailVerified is set when the provider verified that the user owns
+	// Email.
+	EmailVerified bool
+}
+
+// discovery is the part of a discovery document the auth service uses.
+type discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
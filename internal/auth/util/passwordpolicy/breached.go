@@ -0,0 +1,92 @@
+package passwordpolicy
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// prefixLength is the number of hex digits of a SHA-1 hash breached hashes
+// are grouped by, as in the k-anonymity range API of Pwned Passwords.
+const prefixLength = 5
+
+// BreachedList holds the SHA-1 hashes of passwords known from breaches,
+// grouped by hash prefix. It is loaded from a local file, so passwords are
+// never sent anywhere to be checked.
+type BreachedList struct {
+	ranges map[string]map[string]struct{}
+	size   int
+}
+
+// LoadBreachedList reads a breached password list from the file at path,
+// see ReadBreachedList.
+func LoadBreachedList(path string) (*BreachedList, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	list, err := ReadBreachedList(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return list, nil
+}
+
+// ReadBreachedList reads a breached password list with one hex SHA-1 hash per
+// line, optionally followed by a colon and the number of times it was seen,
+// as in the files of the Pwned Passwords downloader. Empty lines and lines
+// starting with # are skipped.
+func ReadBreachedList(r io.Reader) (*BreachedList, error) {
+	list := &BreachedList{ranges: make(map[string]map[string]struct{})}
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		hash, _, _ := strings.Cut(text, ":")
+		hash = strings.ToUpper(strings.TrimSpace(hash))
+		if len(hash) != sha1.Size*2 {
+			return nil, fmt.Errorf("line %d: not a SHA-1 hash", line)
+		}
+		if _, err := hex.DecodeString(hash); err != nil {
+			return nil, fmt.Errorf("line %d: not a SHA-1 hash", line)
+		}
+		list.add(hash)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (b *BreachedList) add(hash string) {
+	prefix, suffix := hash[:prefixLength], hash[prefixLength:]
+	suffixes, ok := b.ranges[prefix]
+	if !ok {
+		suffixes = make(map[string]struct{})
+		b.ranges[prefix] = suffixes
+	}
+	if _, ok := suffixes[suffix]; !ok {
+		suffixes[suffix] = struct{}{}
+		b.size++
+	}
+}
+
+// Contains reports whether password is on the list.
+func (b *BreachedList) Contains(password string) bool {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	_, ok := b.ranges[hash[:prefixLength]][hash[prefixLength:]]
+	return ok
+}
+
+// Len returns the number of hashes on the list.
+func (b *BreachedList) Len() int {
+	return b.size
+}
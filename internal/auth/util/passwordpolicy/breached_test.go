@@ -0,0 +1,57 @@
+package passwordpolicy
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadBreachedList(t *testing.T) {
+	input := strings.Join([]string{
+		"# SHA-1 hashes of breached passwords",
+		"5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8:3861493",
+		"",
+		"7c4a8d09ca3762af61e59520943dc26494f8941b",
+		"7C4A8D09CA3762AF61E59520943DC26494F8941B:37359195",
+	}, "\n")
+
+	list, err := ReadBreachedList(strings.NewReader(input))
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, list.Len())
+	assert.True(t, list.Contains("password"))
+	assert.True(t, list.Contains("123456"))
+	assert.False(t, list.Contains("P@ssw0rd"))
+}
+
+func TestReadBreachedList_InvalidLine(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "short hash", input: "5BAA61E4C9B93F3F"},
+		{name: "not hex", input: "ZBAA61E4C9B93F3F0682250B6CF8331B7EE68FD8"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadBreachedList(strings.NewReader("# header\n" + tt.input))
+			assert.ErrorContains(t, err, "line 2")
+		})
+	}
+}
+
+func TestLoadBreachedList(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "breached.txt")
+	require.NoError(t, os.WriteFile(path, []byte("5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8:1\n"), 0o600))
+
+	list, err := LoadBreachedList(path)
+	require.NoError(t, err)
+	assert.True(t, list.Contains("password"))
+
+	_, err = LoadBreachedList(filepath.Join(t.TempDir(), "missing.txt"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,81 @@
+package passwordpolicy
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// minEmailPartLength is the shortest local part of an email address that
+// passwords are checked for, so that short ones do not rule out most
+// passwords.
+const minEmailPartLength = 3
+
+// Check returns an *Error listing every rule of the policy password does not
+// follow, or nil when it follows them all. email is the address of the user
+// the password is for.
+func (p *Policy) Check(password, email string) error {
+	var violations []Violation
+	add := func(rule, format string, args ...any) {
+		violations = append(violations, Violation{Rule: rule, Description: fmt.Sprintf(format, args...)})
+	}
+
+	length := utf8.RuneCountInString(password)
+	if length < p.MinLength {
+		add(RuleMinLength, "must be at least %d characters long", p.MinLength)
+	}
+	if p.MaxLength > 0 && length > p.MaxLength {
+		add(RuleMaxLength, "must be at most %d characters long", p.MaxLength)
+	}
+
+	for _, class := range p.Classes {
+		switch class {
+		case ClassLowercase:
+			if !strings.ContainsFunc(password, unicode.IsLower) {
+				add(RuleLowercase, "must contain a lowercase letter")
+			}
+		case ClassUppercase:
+			if !strings.ContainsFunc(password, unicode.IsUpper) {
+				add(RuleUppercase, "must contain an uppercase letter")
+			}
+		case ClassDigit:
+			if !strings.ContainsFunc(password, unicode.IsDigit) {
+				add(RuleDigit, "must contain a digit")
+			}
+		case ClassSymbol:
+			if !strings.ContainsFunc(password, isSymbol) {
+				add(RuleSymbol, "must contain a symbol")
+			}
+		}
+	}
+
+	if p.RejectEmail && containsEmail(password, email) {
+		add(RuleContainsEmail, "must not contain your email address")
+	}
+	if p.Breached != nil && p.Breached.Contains(password) {
+		add(RuleBreached, "appears in a known data breach")
+	}
+
+	if violations != nil {
+		return &Error{Violations: violations}
+	}
+	return nil
+}
+
+func isSymbol(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.IsSpace(r)
+}
+
+func containsEmail(password, email string) bool {
+	password = strings.ToLower(password)
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" {
+		return false
+	}
+	if strings.Contains(password, email) {
+		return true
+	}
+	local, _, _ := strings.Cut(email, "@")
+	return utf8.RuneCountInString(local) >= minEmailPartLength && strings.Contains(password, local)
+}
@@ -0,0 +1,85 @@
+package passwordpolicy
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func rules(err *Error) []string {
+	var out []string
+	for _, v := range err.Violations {
+		out = append(out, v.Rule)
+	}
+	return out
+}
+
+func TestPolicy_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  Policy
+		wantErr bool
+	}{
+		{name: "valid", policy: Policy{MinLength: 8, MaxLength: 128, Classes: []string{ClassDigit, ClassSymbol}}},
+		{name: "no maximum", policy: Policy{MinLength: 8}},
+		{name: "negative minimum", policy: Policy{MinLength: -1}, wantErr: true},
+		{name: "negative maximum", policy: Policy{MaxLength: -1}, wantErr: true},
+		{name: "maximum below minimum", policy: Policy{MinLength: 10, MaxLength: 8}, wantErr: true},
+		{name: "unknown class", policy: Policy{Classes: []string{"emoji"}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Validate()
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidPolicy)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPolicy_Check(t *testing.T) {
+	breached, err := ReadBreachedList(strings.NewReader("5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8:3861493\n"))
+	require.NoError(t, err)
+
+	policy := &Policy{
+		MinLength:   8,
+		MaxLength:   16,
+		Classes:     []string{ClassLowercase, ClassUppercase, ClassDigit, ClassSymbol},
+		RejectEmail: true,
+		Breached:    breached,
+	}
+
+	tests := []struct {
+		name      string
+		password  string
+		email     string
+		wantRules []string
+	}{
+		{name: "valid", password: "Correct-h0rse", email: "user@example.com"},
+		{name: "too short", password: "Ab1!", wantRules: []string{RuleMinLength}},
+		{name: "too long", password: "Correct-h0rse-battery", wantRules: []string{RuleMaxLength}},
+		{name: "length counts characters", password: "Äöüäöüä1!", wantRules: nil},
+		{name: "missing classes", password: "abcdefghij", wantRules: []string{RuleUppercase, RuleDigit, RuleSymbol}},
+		{name: "contains email", password: "X1!Me@Ex.io", email: "me@ex.io", wantRules: []string{RuleContainsEmail}},
+		{name: "contains local part", password: "Alice-2024!", email: "alice@example.com", wantRules: []string{RuleContainsEmail}},
+		{name: "short local part is ignored", password: "Bob-is-2024!", email: "bo@example.com"},
+		{name: "breached", password: "password", wantRules: []string{RuleUppercase, RuleDigit, RuleSymbol, RuleBreached}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.Check(tt.password, tt.email)
+			if tt.wantRules == nil {
+				assert.NoError(t, err)
+				return
+			}
+			var perr *Error
+			require.ErrorAs(t, err, &perr)
+			assert.Equal(t, tt.wantRules, rules(perr))
+			assert.Contains(t, err.Error(), "password does not meet the password policy")
+		})
+	}
+}
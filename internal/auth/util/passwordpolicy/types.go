@@ -0,0 +1,85 @@
+package passwordpolicy
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Rules a password can violate.
+const (
+	RuleMinLength     = "min_length"
+	RuleMaxLength     = "max_length"
+	RuleLowercase     = "lowercase"
+	RuleUppercase     = "uppercase"
+	RuleDigit         = "digit"
+	RuleSymbol        = "symbol"
+	RuleContainsEmail = "contains_email"
+	RuleBreached      = "breached"
+)
+
+// Character classes a policy can require.
+const (
+	ClassLowercase = "lowercase"
+	ClassUppercase = "uppercase"
+	ClassDigit     = "digit"
+	ClassSymbol    = "symbol"
+)
+
+var ErrInvalidPolicy = errors.New("invalid password policy")
+
+// Policy is the set of rules passwords must follow.
+type Policy struct {
+	// MinLength is the minimum number of characters.
+	MinLength int
+	// MaxLength is the maximum number of characters, which bounds the cost
+	// of hashing. There is no maximum when it is zero.
+	MaxLength int
+	// Classes lists the character classes passwords must contain.
+	Classes []string
+	// RejectEmail refuses passwords containing the email address of the
+	// user, or its local part.
+	RejectEmail bool
+	// Breached refuses passwords known from breaches when it is set.
+	Breached *BreachedList
+}
+
+// Validate checks that the rules of the policy can be followed.
+func (p *Policy) Validate() error {
+	if p.MinLength < 0 {
+		return fmt.Errorf("%w: negative minimum length", ErrInvalidPolicy)
+	}
+	if p.MaxLength < 0 {
+		return fmt.Errorf("%w: negative maximum length", ErrInvalidPolicy)
+	}
+	if p.MaxLength > 0 && p.MaxLength < p.MinLength {
+		return fmt.Errorf("%w: maximum length %d is below minimum length %d", ErrInvalidPolicy, p.MaxLength, p.MinLength)
+	}
+	for _, class := range p.Classes {
+		switch class {
+		case ClassLowercase, ClassUppercase, ClassDigit, ClassSymbol:
+		default:
+			return fmt.Errorf("%w: unknown character class %q", ErrInvalidPolicy, class)
+		}
+	}
+	return nil
+}
+
+// Violation is a rule a password does not follow.
+type Violation struct {
+	Rule        string `yaml:"rule" json:"rule"`
+	Description string `yaml:"description" json:"description"`
+}
+
+// Error lists every rule a password does not follow.
+type Error struct {
+	Violations []Violation
+}
+
+func (e *Error) Error() string {
+	descriptions := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		descriptions = append(descriptions, v.Description)
+	}
+	return "password does not meet the password policy: " + strings.Join(descriptions, "; ")
+}
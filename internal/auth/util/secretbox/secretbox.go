@@ -0,0 +1,89 @@
+package secretbox
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// KeySize is the size in bytes of keys (AES-256).
+const KeySize = 32
+
+var (
+	ErrInvalidKeySize = errors.New("invalid key size")
+	ErrInvalidSealed  = errors.New("sealed secret is corrupted or was sealed with another key")
+)
+
+// Box seals small secrets, like TOTP secrets, so that they can be stored in
+// the database without being readable from it.
+type Box struct {
+	aead cipher.AEAD
+}
+
+// New builds a box sealing with key.
+func New(key []byte) (*Box, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("%w: got %d bytes, want %d", ErrInvalidKeySize, len(key), KeySize)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &Box{aead: aead}, nil
+}
+
+// Load builds a box sealing with the base64 encoded key in a local file.
+func Load(path string) (*Box, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("stat key file %q: %w", path, err)
+	}
+	if info.Mode().Perm()&0o077 != 0 {
+		logrus.Warnf("Key file %s is accessible by other users, consider chmod 600", path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read key file %q: %w", path, err)
+	}
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil {
+		return nil, fmt.Errorf("decode key file %q: %w", path, err)
+	}
+	return New(key)
+}
+
+// Seal encrypts secret, bound to additionalData, which must be given again
+// to open it. Binding a secret to the ID of the row it is stored in stops it
+// from being copied to another row.
+func (b *Box) Seal(secret, additionalData []byte) ([]byte, error) {
+	nonce := make([]byte, b.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return b.aead.Seal(nonce, nonce, secret, additionalData), nil
+}
+
+// Open decrypts a secret sealed by Seal with the same additionalData.
+func (b *Box) Open(sealed, additionalData []byte) ([]byte, error) {
+	if len(sealed) < b.aead.NonceSize()+b.aead.Overhead() {
+		return nil, ErrInvalidSealed
+	}
+	nonce, ciphertext := sealed[:b.aead.NonceSize()], sealed[b.aead.NonceSize():]
+	secret, err := b.aead.Open(nil, nonce, ciphertext, additionalData)
+	if err != nil {
+		return nil, ErrInvalidSealed
+	}
+	return secret, nil
+}
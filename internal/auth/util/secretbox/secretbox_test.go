@@ -0,0 +1,70 @@
+package secretbox
+
+import (
+	"bytes"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testKey(b byte) []byte {
+	return bytes.Repeat([]byte{b}, KeySize)
+}
+
+func TestBox_SealOpen(t *testing.T) {
+	box, err := New(testKey(1))
+	require.NoError(t, err)
+
+	sealed, err := box.Seal([]byte("secret"), []byte("user-1"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(sealed), "secret")
+
+	secret, err := box.Open(sealed, []byte("user-1"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("secret"), secret)
+
+	t.Run("OtherAdditionalData", func(t *testing.T) {
+		_, err := box.Open(sealed, []byte("user-2"))
+		assert.ErrorIs(t, err, ErrInvalidSealed)
+	})
+
+	t.Run("OtherKey", func(t *testing.T) {
+		other, err := New(testKey(2))
+		require.NoError(t, err)
+		_, err = other.Open(sealed, []byte("user-1"))
+		assert.ErrorIs(t, err, ErrInvalidSealed)
+	})
+
+	t.Run("Truncated", func(t *testing.T) {
+		_, err := box.Open(sealed[:10], []byte("user-1"))
+		assert.ErrorIs(t, err, ErrInvalidSealed)
+	})
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, "mfa.key")
+	require.NoError(t, os.WriteFile(path, []byte(base64.StdEncoding.EncodeToString(testKey(1))+"\n"), 0o600))
+	box, err := Load(path)
+	require.NoError(t, err)
+	sealed, err := box.Seal([]byte("secret"), nil)
+	require.NoError(t, err)
+
+	same, err := New(testKey(1))
+	require.NoError(t, err)
+	_, err = same.Open(sealed, nil)
+	assert.NoError(t, err)
+
+	short := filepath.Join(dir, "short.key")
+	require.NoError(t, os.WriteFile(short, []byte(base64.StdEncoding.EncodeToString([]byte("short"))), 0o600))
+	_, err = Load(short)
+	assert.ErrorIs(t, err, ErrInvalidKeySize)
+
+	_, err = Load(filepath.Join(dir, "missing.key"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,95 @@
+package totp
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rfcSecret is the SHA-1 secret of the RFC 4226 and RFC 6238 test vectors.
+var rfcSecret = []byte("12345678901234567890")
+
+func TestHOTP(t *testing.T) {
+	// RFC 4226, appendix D.
+	want := []string{"755224", "287082", "359152", "969429", "338314", "254676", "287922", "162583", "399871", "520489"}
+	for counter, code := range want {
+		assert.Equal(t, code, hotp(rfcSecret, uint64(counter), 6), "counter %d", counter)
+	}
+}
+
+func TestTOTP(t *testing.T) {
+	// RFC 6238, appendix B, SHA-1.
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{unix: 59, want: "94287082"},
+		{unix: 1111111109, want: "07081804"},
+		{unix: 1111111111, want: "14050471"},
+		{unix: 1234567890, want: "89005924"},
+		{unix: 2000000000, want: "69279037"},
+		{unix: 20000000000, want: "65353130"},
+	}
+	for _, tt := range tests {
+		at := time.Unix(tt.unix, 0)
+		assert.Equal(t, tt.want, hotp(rfcSecret, uint64(Step(at)), 8), "time %d", tt.unix)
+		assert.Equal(t, tt.want[2:], Code(rfcSecret, at), "time %d", tt.unix)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	secret, err := GenerateSecret()
+	require.NoError(t, err)
+	now := time.Unix(1700000000, 0)
+
+	t.Run("Current", func(t *testing.T) {
+		step, err := Validate(secret, Code(secret, now), now)
+		require.NoError(t, err)
+		assert.Equal(t, Step(now), step)
+	})
+
+	t.Run("WithinSkew", func(t *testing.T) {
+		step, err := Validate(secret, Code(secret, now.Add(-Period)), now)
+		require.NoError(t, err)
+		assert.Equal(t, Step(now)-1, step)
+
+		step, err = Validate(secret, Code(secret, now.Add(Period)), now)
+		require.NoError(t, err)
+		assert.Equal(t, Step(now)+1, step)
+	})
+
+	t.Run("Spaces", func(t *testing.T) {
+		code := Code(secret, now)
+		_, err := Validate(secret, " "+code[:3]+" "+code[3:], now)
+		assert.NoError(t, err)
+	})
+
+	t.Run("OutsideSkew", func(t *testing.T) {
+		_, err := Validate(secret, Code(secret, now.Add(-2*Period)), now)
+		assert.ErrorIs(t, err, ErrInvalidCode)
+	})
+
+	t.Run("Malformed", func(t *testing.T) {
+		for _, code := range []string{"", "12345", "1234567", "abcdef"} {
+			_, err := Validate(secret, code, now)
+			assert.ErrorIs(t, err, ErrInvalidCode, "code %q", code)
+		}
+	})
+}
+
+func TestURI(t *testing.T) {
+	uri := URI("Doc Formatter", "test@example.com", rfcSecret)
+
+	u, err := url.Parse(uri)
+	require.NoError(t, err)
+	assert.Equal(t, "otpauth", u.Scheme)
+	assert.Equal(t, "totp", u.Host)
+	assert.Equal(t, "/Doc Formatter:test@example.com", u.Path)
+	assert.Equal(t, "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ", u.Query().Get("secret"))
+	assert.Equal(t, "Doc Formatter", u.Query().Get("issuer"))
+	assert.Equal(t, "6", u.Query().Get("digits"))
+	assert.Equal(t, "30", u.Query().Get("period"))
+}
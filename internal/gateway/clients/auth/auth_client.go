@@ -22,13 +22,218 @@ func (a *authClient) Signup(ctx context.Context, email, password string) (*respo
 	return &response.SignUpResponse{UserID: resp.GetUserId()}, nil
 }
 
-func (a *authClient) Login(ctx context.Context, email, password string) (*response.LoginResponse, error) {
+func (a *authClient) Login(ctx context.Context, email, password, remoteAddr string) (*response.LoginResponse, error) {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	resp, err := a.client.Login(ctx, &authpb.LoginRequest{
-		Email:    email,
-		Password: password,
+		Email:      email,
+		Password:   password,
+		RemoteAddr: remoteAddr,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &response.LoginResponse{
+		AccessToken: resp.GetAccessToken(),
+		ExpiryUnix:  resp.GetExpiryUnix(),
+		MFARequired: resp.GetMfaRequired(),
+		MFAToken:    resp.GetMfaToken(),
+	}, nil
+}
+
+func (a *authClient) LookupUser(ctx context.Context, email string) (*response.UserResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := a.client.LookupUser(ctx, &authpb.LookupUserRequest{Email: email})
+	if err != nil {
+		return nil, err
+	}
+	return &response.UserResponse{
+		UserID: resp.GetUserId(),
+		Email:  resp.GetEmail(),
+	}, nil
+}
+
+func (a *authClient) GetUser(ctx context.Context, userID string) (*response.UserResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := a.client.GetUser(ctx, &authpb.GetUserRequest{UserId: userID})
+	if err != nil {
+		return nil, err
+	}
+	return &response.UserResponse{
+		UserID:     resp.GetUserId(),
+		Email:      resp.GetEmail(),
+		IsVerified: resp.GetIsVerified(),
+	}, nil
+}
+
+func (a *authClient) VerifyEmail(ctx context.Context, token string) (*response.UserResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := a.client.VerifyEmail(ctx, &authpb.VerifyEmailRequest{Token: token})
+	if err != nil {
+		return nil, err
+	}
+	return &response.UserResponse{
+		UserID:     resp.GetUserId(),
+		Email:      resp.GetEmail(),
+		IsVerified: true,
+	}, nil
+}
+
+func (a *authClient) ResendVerification(ctx context.Context, email string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := a.client.ResendVerification(ctx, &authpb.ResendVerificationRequest{Email: email})
+	return err
+}
+
+func (a *authClient) RequestPasswordReset(ctx context.Context, email string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := a.client.RequestPasswordReset(ctx, &authpb.RequestPasswordResetRequest{Email: email})
+	return err
+}
+
+func (a *authClient) ResetPassword(ctx context.Context, token, newPassword string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := a.client.ResetPassword(ctx, &authpb.ResetPasswordRequest{Token: token, NewPassword: newPassword})
+	return err
+}
+
+func (a *authClient) ValidateToken(ctx context.Context, accessToken string) (*response.UserResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := a.client.ValidateToken(ctx, &authpb.ValidateTokenRequest{AccessToken: accessToken})
+	if err != nil {
+		return nil, err
+	}
+	return &response.UserResponse{
+		UserID:         resp.GetUserId(),
+		Email:          resp.GetEmail(),
+		IsVerified:     resp.GetIsVerified(),
+		Role:           resp.GetRole(),
+		Permissions:    resp.GetPermissions(),
+		ImpersonatorID: resp.GetImpersonatorId(),
+	}, nil
+}
+
+func (a *authClient) ChangePassword(ctx context.Context, userID, currentPassword, newPassword string) (*response.LoginResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := a.client.ChangePassword(ctx, &authpb.ChangePasswordRequest{
+		UserId:          userID,
+		CurrentPassword: currentPassword,
+		NewPassword:     newPassword,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &response.LoginResponse{
+		AccessToken: resp.GetAccessToken(),
+		ExpiryUnix:  resp.GetExpiryUnix(),
+	}, nil
+}
+
+func (a *authClient) ChangeEmail(ctx context.Context, userID, currentPassword, newEmail string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := a.client.ChangeEmail(ctx, &authpb.ChangeEmailRequest{
+		UserId:          userID,
+		CurrentPassword: currentPassword,
+		NewEmail:        newEmail,
+	})
+	return err
+}
+
+func (a *authClient) EnrollTOTP(ctx context.Context, userID string) (*response.EnrollTOTPResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := a.client.EnrollTOTP(ctx, &authpb.EnrollTOTPRequest{UserId: userID})
+	if err != nil {
+		return nil, err
+	}
+	return &response.EnrollTOTPResponse{
+		Secret:     resp.GetSecret(),
+		OtpauthURI: resp.GetOtpauthUri(),
+	}, nil
+}
+
+func (a *authClient) ConfirmTOTP(ctx context.Context, userID, code string) (*response.RecoveryCodesResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := a.client.ConfirmTOTP(ctx, &authpb.ConfirmTOTPRequest{
+		UserId: userID,
+		Code:   code,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &response.RecoveryCodesResponse{RecoveryCodes: resp.GetRecoveryCodes()}, nil
+}
+
+func (a *authClient) VerifyMFA(ctx context.Context, mfaToken, code, remoteAddr string) (*response.LoginResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := a.client.VerifyMFA(ctx, &authpb.VerifyMFARequest{
+		MfaToken:   mfaToken,
+		Code:       code,
+		RemoteAddr: remoteAddr,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &response.LoginResponse{
+		AccessToken: resp.GetAccessToken(),
+		ExpiryUnix:  resp.GetExpiryUnix(),
+	}, nil
+}
+
+// oidcLoginTimeout bounds calls that make the auth service talk to an
+// identity provider, which take longer than others.
+const oidcLoginTimeout = 30 * time.Second
+
+func (a *authClient) StartOIDCLogin(ctx context.Context, provider string) (*response.StartOIDCLoginResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, oidcLoginTimeout)
+	defer cancel()
+
+	resp, err := a.client.StartOIDCLogin(ctx, &authpb.StartOIDCLoginRequest{
+		Provider: provider,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &response.StartOIDCLoginResponse{
+		AuthorizationURL: resp.GetAuthorizationUrl(),
+		LoginToken:       resp.GetLoginToken(),
+		ExpiryUnix:       resp.GetExpiryUnix(),
+	}, nil
+}
+
+func (a *authClient) FinishOIDCLogin(ctx context.Context, provider, code, state, loginToken string) (*response.LoginResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, oidcLoginTimeout)
+	defer cancel()
+
+	resp, err := a.client.FinishOIDCLogin(ctx, &authpb.FinishOIDCLoginRequest{
+		Provider:   provider,
+		Code:       code,
+		State:      state,
+		LoginToken: loginToken,
 	})
 	if err != nil {
 		return nil, err
@@ -36,5 +241,243 @@ func (a *authClient) Login(ctx context.Context, email, password string) (*respon
 	return &response.LoginResponse{
 		AccessToken: resp.GetAccessToken(),
 		ExpiryUnix:  resp.GetExpiryUnix(),
+		MFARequired: resp.GetMfaRequired(),
+		MFAToken:    resp.GetMfaToken(),
+	}, nil
+}
+
+func (a *authClient) CreateAPIKey(ctx context.Context, userID, name string, scopes []string) (*response.APIKeyResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := a.client.CreateAPIKey(ctx, &authpb.CreateAPIKeyRequest{
+		UserId: userID,
+		Name:   name,
+		Scopes: scopes,
+	})
+	if err != nil {
+		return nil, err
+	}
+	apiKey := toAPIKeyResponse(resp.GetApiKey())
+	apiKey.Key = resp.GetKey()
+	return &apiKey, nil
+}
+
+func (a *authClient) ListAPIKeys(ctx context.Context, userID string) (*response.ListAPIKeysResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := a.client.ListAPIKeys(ctx, &authpb.ListAPIKeysRequest{UserId: userID})
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]response.APIKeyResponse, len(resp.GetApiKeys()))
+	for i, k := range resp.GetApiKeys() {
+		keys[i] = toAPIKeyResponse(k)
+	}
+	return &response.ListAPIKeysResponse{APIKeys: keys}, nil
+}
+
+func (a *authClient) RevokeAPIKey(ctx context.Context, userID, keyID, impersonatorID string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := a.client.RevokeAPIKey(ctx, &authpb.RevokeAPIKeyRequest{
+		UserId:         userID,
+		KeyId:          keyID,
+		ImpersonatorId: impersonatorID,
+	})
+	return err
+}
+
+func (a *authClient) ValidateAPIKey(ctx context.Context, key string) (*response.UserResponse, []string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := a.client.ValidateAPIKey(ctx, &authpb.ValidateAPIKeyRequest{Key: key})
+	if err != nil {
+		return nil, nil, err
+	}
+	return &response.UserResponse{
+		UserID:     resp.GetUserId(),
+		Email:      resp.GetEmail(),
+		IsVerified: resp.GetIsVerified(),
+	}, resp.GetScopes(), nil
+}
+
+func (a *authClient) ListUsers(ctx context.Context, adminID, query, role string, pageSize int32, pageToken string) (*response.ListUsersResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := a.client.ListUsers(ctx, &authpb.ListUsersRequest{
+		AdminId:   adminID,
+		Query:     query,
+		Role:      role,
+		PageSize:  pageSize,
+		PageToken: pageToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+	users := make([]response.AdminUserResponse, len(resp.GetUsers()))
+	for i, u := range resp.GetUsers() {
+		users[i] = toAdminUserResponse(u)
+	}
+	return &response.ListUsersResponse{
+		Users:         users,
+		NextPageToken: resp.GetNextPageToken(),
 	}, nil
 }
+
+func (a *authClient) SetUserDisabled(ctx context.Context, adminID, userID string, disabled bool) (*response.AdminUserResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := a.client.SetUserDisabled(ctx, &authpb.SetUserDisabledRequest{
+		AdminId:  adminID,
+		UserId:   userID,
+		Disabled: disabled,
+	})
+	if err != nil {
+		return nil, err
+	}
+	user := toAdminUserResponse(resp.GetUser())
+	return &user, nil
+}
+
+func (a *authClient) VerifyUser(ctx context.Context, adminID, userID string) (*response.AdminUserResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := a.client.VerifyUser(ctx, &authpb.VerifyUserRequest{
+		AdminId: adminID,
+		UserId:  userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	user := toAdminUserResponse(resp.GetUser())
+	return &user, nil
+}
+
+func (a *authClient) ResetUserMFA(ctx context.Context, adminID, userID string) (*response.AdminUserResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := a.client.ResetUserMFA(ctx, &authpb.ResetUserMFARequest{
+		AdminId: adminID,
+		UserId:  userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	user := toAdminUserResponse(resp.GetUser())
+	return &user, nil
+}
+
+func (a *authClient) ImpersonateUser(ctx context.Context, adminID, userID string) (*response.LoginResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := a.client.ImpersonateUser(ctx, &authpb.ImpersonateUserRequest{
+		AdminId: adminID,
+		UserId:  userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &response.LoginResponse{
+		AccessToken: resp.GetAccessToken(),
+		ExpiryUnix:  resp.GetExpiryUnix(),
+	}, nil
+}
+
+func (a *authClient) ListRoles(ctx context.Context, adminID string) (*response.ListRolesResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := a.client.ListRoles(ctx, &authpb.ListRolesRequest{AdminId: adminID})
+	if err != nil {
+		return nil, err
+	}
+	roles := make([]response.RoleResponse, len(resp.GetRoles()))
+	for i, r := range resp.GetRoles() {
+		roles[i] = toRoleResponse(r)
+	}
+	return &response.ListRolesResponse{Roles: roles}, nil
+}
+
+func (a *authClient) CreateRole(ctx context.Context, adminID, name, description string, permissions []string) (*response.RoleResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := a.client.CreateRole(ctx, &authpb.CreateRoleRequest{
+		AdminId:     adminID,
+		Name:        name,
+		Description: description,
+		Permissions: permissions,
+	})
+	if err != nil {
+		return nil, err
+	}
+	role := toRoleResponse(resp.GetRole())
+	return &role, nil
+}
+
+func (a *authClient) SetUserRole(ctx context.Context, adminID, userID, role string) (*response.AdminUserResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := a.client.SetUserRole(ctx, &authpb.SetUserRoleRequest{
+		AdminId: adminID,
+		UserId:  userID,
+		Role:    role,
+	})
+	if err != nil {
+		return nil, err
+	}
+	user := toAdminUserResponse(resp.GetUser())
+	return &user, nil
+}
+
+func toAdminUserResponse(u *authpb.UserInfo) response.AdminUserResponse {
+	return response.AdminUserResponse{
+		UserID:     u.GetUserId(),
+		Email:      u.GetEmail(),
+		IsVerified: u.GetIsVerified(),
+		Role:       u.GetRole(),
+		Disabled:   u.GetDisabled(),
+		MFAEnabled: u.GetMfaEnabled(),
+	}
+}
+
+func toRoleResponse(r *authpb.RoleInfo) response.RoleResponse {
+	role := response.RoleResponse{
+		Name:        r.GetName(),
+		Description: r.GetDescription(),
+		Permissions: r.GetPermissions(),
+		BuiltIn:     r.GetBuiltIn(),
+	}
+	if role.Permissions == nil {
+		role.Permissions = []string{}
+	}
+	return role
+}
+
+func toAPIKeyResponse(k *authpb.APIKeyInfo) response.APIKeyResponse {
+	apiKey := response.APIKeyResponse{
+		KeyID:     k.GetKeyId(),
+		Name:      k.GetName(),
+		Prefix:    k.GetPrefix(),
+		Scopes:    k.GetScopes(),
+		CreatedAt: time.Unix(k.GetCreatedAtUnix(), 0).UTC(),
+	}
+	if apiKey.Scopes == nil {
+		apiKey.Scopes = []string{}
+	}
+	if sec := k.GetLastUsedAtUnix(); sec != 0 {
+		lastUsedAt := time.Unix(sec, 0).UTC()
+		apiKey.LastUsedAt = &lastUsedAt
+	}
+	return apiKey
+}
@@ -35,6 +35,214 @@ func (m *MockAuthServiceClient) Login(ctx context.Context, in *authpb.LoginReque
 	return args.Get(0).(*authpb.LoginResponse), args.Error(1)
 }
 
+func (m *MockAuthServiceClient) LookupUser(ctx context.Context, in *authpb.LookupUserRequest, opts ...grpc.CallOption) (*authpb.LookupUserResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*authpb.LookupUserResponse), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) GetUser(ctx context.Context, in *authpb.GetUserRequest, opts ...grpc.CallOption) (*authpb.GetUserResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*authpb.GetUserResponse), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) VerifyEmail(ctx context.Context, in *authpb.VerifyEmailRequest, opts ...grpc.CallOption) (*authpb.VerifyEmailResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*authpb.VerifyEmailResponse), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) ResendVerification(ctx context.Context, in *authpb.ResendVerificationRequest, opts ...grpc.CallOption) (*authpb.ResendVerificationResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*authpb.ResendVerificationResponse), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) RequestPasswordReset(ctx context.Context, in *authpb.RequestPasswordResetRequest, opts ...grpc.CallOption) (*authpb.RequestPasswordResetResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*authpb.RequestPasswordResetResponse), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) ResetPassword(ctx context.Context, in *authpb.ResetPasswordRequest, opts ...grpc.CallOption) (*authpb.ResetPasswordResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*authpb.ResetPasswordResponse), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) ValidateToken(ctx context.Context, in *authpb.ValidateTokenRequest, opts ...grpc.CallOption) (*authpb.ValidateTokenResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*authpb.ValidateTokenResponse), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) ChangePassword(ctx context.Context, in *authpb.ChangePasswordRequest, opts ...grpc.CallOption) (*authpb.ChangePasswordResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*authpb.ChangePasswordResponse), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) ChangeEmail(ctx context.Context, in *authpb.ChangeEmailRequest, opts ...grpc.CallOption) (*authpb.ChangeEmailResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*authpb.ChangeEmailResponse), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) EnrollTOTP(ctx context.Context, in *authpb.EnrollTOTPRequest, opts ...grpc.CallOption) (*authpb.EnrollTOTPResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*authpb.EnrollTOTPResponse), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) ConfirmTOTP(ctx context.Context, in *authpb.ConfirmTOTPRequest, opts ...grpc.CallOption) (*authpb.ConfirmTOTPResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*authpb.ConfirmTOTPResponse), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) VerifyMFA(ctx context.Context, in *authpb.VerifyMFARequest, opts ...grpc.CallOption) (*authpb.VerifyMFAResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*authpb.VerifyMFAResponse), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) StartOIDCLogin(ctx context.Context, in *authpb.StartOIDCLoginRequest, opts ...grpc.CallOption) (*authpb.StartOIDCLoginResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*authpb.StartOIDCLoginResponse), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) FinishOIDCLogin(ctx context.Context, in *authpb.FinishOIDCLoginRequest, opts ...grpc.CallOption) (*authpb.LoginResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*authpb.LoginResponse), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) CreateAPIKey(ctx context.Context, in *authpb.CreateAPIKeyRequest, opts ...grpc.CallOption) (*authpb.CreateAPIKeyResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*authpb.CreateAPIKeyResponse), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) ListAPIKeys(ctx context.Context, in *authpb.ListAPIKeysRequest, opts ...grpc.CallOption) (*authpb.ListAPIKeysResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*authpb.ListAPIKeysResponse), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) RevokeAPIKey(ctx context.Context, in *authpb.RevokeAPIKeyRequest, opts ...grpc.CallOption) (*authpb.RevokeAPIKeyResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*authpb.RevokeAPIKeyResponse), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) ValidateAPIKey(ctx context.Context, in *authpb.ValidateAPIKeyRequest, opts ...grpc.CallOption) (*authpb.ValidateAPIKeyResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*authpb.ValidateAPIKeyResponse), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) ListUsers(ctx context.Context, in *authpb.ListUsersRequest, opts ...grpc.CallOption) (*authpb.ListUsersResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*authpb.ListUsersResponse), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) SetUserDisabled(ctx context.Context, in *authpb.SetUserDisabledRequest, opts ...grpc.CallOption) (*authpb.SetUserDisabledResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*authpb.SetUserDisabledResponse), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) VerifyUser(ctx context.Context, in *authpb.VerifyUserRequest, opts ...grpc.CallOption) (*authpb.VerifyUserResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*authpb.VerifyUserResponse), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) ResetUserMFA(ctx context.Context, in *authpb.ResetUserMFARequest, opts ...grpc.CallOption) (*authpb.ResetUserMFAResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*authpb.ResetUserMFAResponse), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) ImpersonateUser(ctx context.Context, in *authpb.ImpersonateUserRequest, opts ...grpc.CallOption) (*authpb.ImpersonateUserResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*authpb.ImpersonateUserResponse), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) ListRoles(ctx context.Context, in *authpb.ListRolesRequest, opts ...grpc.CallOption) (*authpb.ListRolesResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*authpb.ListRolesResponse), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) CreateRole(ctx context.Context, in *authpb.CreateRoleRequest, opts ...grpc.CallOption) (*authpb.CreateRoleResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*authpb.CreateRoleResponse), args.Error(1)
+}
+
+func (m *MockAuthServiceClient) SetUserRole(ctx context.Context, in *authpb.SetUserRoleRequest, opts ...grpc.CallOption) (*authpb.SetUserRoleResponse, error) {
+	args := m.Called(ctx, in, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*authpb.SetUserRoleResponse), args.Error(1)
+}
+
 func TestAuthClient_Signup(t *testing.T) {
 	email := "test@example.com"
 	password := "password123"
@@ -92,14 +300,15 @@ func TestAuthClient_Login(t *testing.T) {
 		}
 
 		mockClient.On("Login", mock.Anything, &authpb.LoginRequest{
-			Email:    email,
-			Password: password,
+			Email:      email,
+			Password:   password,
+			RemoteAddr: "192.0.2.1",
 		}, mock.Anything).Return(&authpb.LoginResponse{
 			AccessToken: accessToken,
 			ExpiryUnix:  expiryUnix,
 		}, nil)
 
-		resp, err := client.Login(context.Background(), email, password)
+		resp, err := client.Login(context.Background(), email, password, "192.0.2.1")
 
 		assert.NoError(t, err)
 		assert.Equal(t, &response.LoginResponse{
@@ -121,7 +330,7 @@ func TestAuthClient_Login(t *testing.T) {
 			Password: password,
 		}, mock.Anything).Return(nil, expectedErr)
 
-		resp, err := client.Login(context.Background(), email, password)
+		resp, err := client.Login(context.Background(), email, password, "")
 
 		assert.Error(t, err)
 		assert.Nil(t, resp)
@@ -130,6 +339,446 @@ func TestAuthClient_Login(t *testing.T) {
 	})
 }
 
+func TestAuthClient_LookupUser(t *testing.T) {
+	email := "test@example.com"
+
+	t.Run("Success", func(t *testing.T) {
+		mockClient := new(MockAuthServiceClient)
+		client := &authClient{
+			client: mockClient,
+		}
+
+		mockClient.On("LookupUser", mock.Anything, &authpb.LookupUserRequest{Email: email}, mock.Anything).
+			Return(&authpb.LookupUserResponse{UserId: "123", Email: email}, nil)
+
+		resp, err := client.LookupUser(context.Background(), email)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &response.UserResponse{UserID: "123", Email: email}, resp)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		mockClient := new(MockAuthServiceClient)
+		client := &authClient{
+			client: mockClient,
+		}
+
+		expectedErr := errors.New("lookup failed")
+		mockClient.On("LookupUser", mock.Anything, &authpb.LookupUserRequest{Email: email}, mock.Anything).
+			Return(nil, expectedErr)
+
+		resp, err := client.LookupUser(context.Background(), email)
+
+		assert.Nil(t, resp)
+		assert.Equal(t, expectedErr, err)
+		mockClient.AssertExpectations(t)
+	})
+}
+
+func TestAuthClient_GetUser(t *testing.T) {
+	userID := "123"
+
+	t.Run("Success", func(t *testing.T) {
+		mockClient := new(MockAuthServiceClient)
+		client := &authClient{
+			client: mockClient,
+		}
+
+		mockClient.On("GetUser", mock.Anything, &authpb.GetUserRequest{UserId: userID}, mock.Anything).
+			Return(&authpb.GetUserResponse{UserId: userID, Email: "test@example.com"}, nil)
+
+		resp, err := client.GetUser(context.Background(), userID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &response.UserResponse{UserID: userID, Email: "test@example.com"}, resp)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		mockClient := new(MockAuthServiceClient)
+		client := &authClient{
+			client: mockClient,
+		}
+
+		expectedErr := errors.New("get user failed")
+		mockClient.On("GetUser", mock.Anything, &authpb.GetUserRequest{UserId: userID}, mock.Anything).
+			Return(nil, expectedErr)
+
+		resp, err := client.GetUser(context.Background(), userID)
+
+		assert.Nil(t, resp)
+		assert.Equal(t, expectedErr, err)
+		mockClient.AssertExpectations(t)
+	})
+}
+
+func TestAuthClient_VerifyEmail(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockClient := new(MockAuthServiceClient)
+		client := &authClient{
+			client: mockClient,
+		}
+
+		mockClient.On("VerifyEmail", mock.Anything, &authpb.VerifyEmailRequest{Token: "token"}, mock.Anything).
+			Return(&authpb.VerifyEmailResponse{UserId: "user-1", Email: "test@example.com"}, nil)
+
+		resp, err := client.VerifyEmail(context.Background(), "token")
+
+		assert.NoError(t, err)
+		assert.Equal(t, &response.UserResponse{UserID: "user-1", Email: "test@example.com", IsVerified: true}, resp)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		mockClient := new(MockAuthServiceClient)
+		client := &authClient{
+			client: mockClient,
+		}
+
+		expectedErr := errors.New("verify failed")
+		mockClient.On("VerifyEmail", mock.Anything, &authpb.VerifyEmailRequest{Token: "token"}, mock.Anything).
+			Return(nil, expectedErr)
+
+		resp, err := client.VerifyEmail(context.Background(), "token")
+
+		assert.Nil(t, resp)
+		assert.Equal(t, expectedErr, err)
+		mockClient.AssertExpectations(t)
+	})
+}
+
+func TestAuthClient_ResendVerification(t *testing.T) {
+	mockClient := new(MockAuthServiceClient)
+	client := &authClient{
+		client: mockClient,
+	}
+
+	mockClient.On("ResendVerification", mock.Anything, &authpb.ResendVerificationRequest{Email: "test@example.com"}, mock.Anything).
+		Return(&authpb.ResendVerificationResponse{}, nil)
+
+	assert.NoError(t, client.ResendVerification(context.Background(), "test@example.com"))
+	mockClient.AssertExpectations(t)
+}
+
+func TestAuthClient_RequestPasswordReset(t *testing.T) {
+	mockClient := new(MockAuthServiceClient)
+	client := &authClient{
+		client: mockClient,
+	}
+
+	mockClient.On("RequestPasswordReset", mock.Anything, &authpb.RequestPasswordResetRequest{Email: "test@example.com"}, mock.Anything).
+		Return(&authpb.RequestPasswordResetResponse{}, nil)
+
+	assert.NoError(t, client.RequestPasswordReset(context.Background(), "test@example.com"))
+	mockClient.AssertExpectations(t)
+}
+
+func TestAuthClient_ResetPassword(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockClient := new(MockAuthServiceClient)
+		client := &authClient{
+			client: mockClient,
+		}
+
+		mockClient.On("ResetPassword", mock.Anything, &authpb.ResetPasswordRequest{Token: "token", NewPassword: "new-password"}, mock.Anything).
+			Return(&authpb.ResetPasswordResponse{}, nil)
+
+		assert.NoError(t, client.ResetPassword(context.Background(), "token", "new-password"))
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		mockClient := new(MockAuthServiceClient)
+		client := &authClient{
+			client: mockClient,
+		}
+
+		expectedErr := errors.New("reset failed")
+		mockClient.On("ResetPassword", mock.Anything, &authpb.ResetPasswordRequest{Token: "token", NewPassword: "new-password"}, mock.Anything).
+			Return(nil, expectedErr)
+
+		assert.Equal(t, expectedErr, client.ResetPassword(context.Background(), "token", "new-password"))
+		mockClient.AssertExpectations(t)
+	})
+}
+
+func TestAuthClient_ValidateToken(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockClient := new(MockAuthServiceClient)
+		client := &authClient{
+			client: mockClient,
+		}
+
+		mockClient.On("ValidateToken", mock.Anything, &authpb.ValidateTokenRequest{AccessToken: "token"}, mock.Anything).
+			Return(&authpb.ValidateTokenResponse{
+				UserId:         "user-1",
+				Email:          "test@example.com",
+				IsVerified:     true,
+				Role:           "admin",
+				Permissions:    []string{"users:read"},
+				ImpersonatorId: "admin-1",
+			}, nil)
+
+		resp, err := client.ValidateToken(context.Background(), "token")
+
+		assert.NoError(t, err)
+		assert.Equal(t, &response.UserResponse{
+			UserID:         "user-1",
+			Email:          "test@example.com",
+			IsVerified:     true,
+			Role:           "admin",
+			Permissions:    []string{"users:read"},
+			ImpersonatorID: "admin-1",
+		}, resp)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		mockClient := new(MockAuthServiceClient)
+		client := &authClient{
+			client: mockClient,
+		}
+
+		expectedErr := errors.New("token expired")
+		mockClient.On("ValidateToken", mock.Anything, &authpb.ValidateTokenRequest{AccessToken: "token"}, mock.Anything).
+			Return(nil, expectedErr)
+
+		resp, err := client.ValidateToken(context.Background(), "token")
+
+		assert.Nil(t, resp)
+		assert.Equal(t, expectedErr, err)
+		mockClient.AssertExpectations(t)
+	})
+}
+
+func TestAuthClient_ChangePassword(t *testing.T) {
+	mockClient := new(MockAuthServiceClient)
+	client := &authClient{
+		client: mockClient,
+	}
+
+	mockClient.On("ChangePassword", mock.Anything, &authpb.ChangePasswordRequest{
+		UserId:          "user-1",
+		CurrentPassword: "old-password",
+		NewPassword:     "new-password",
+	}, mock.Anything).Return(&authpb.ChangePasswordResponse{AccessToken: "token", ExpiryUnix: 42}, nil)
+
+	resp, err := client.ChangePassword(context.Background(), "user-1", "old-password", "new-password")
+
+	assert.NoError(t, err)
+	assert.Equal(t, &response.LoginResponse{AccessToken: "token", ExpiryUnix: 42}, resp)
+	mockClient.AssertExpectations(t)
+}
+
+func TestAuthClient_ChangeEmail(t *testing.T) {
+	mockClient := new(MockAuthServiceClient)
+	client := &authClient{
+		client: mockClient,
+	}
+
+	mockClient.On("ChangeEmail", mock.Anything, &authpb.ChangeEmailRequest{
+		UserId:          "user-1",
+		CurrentPassword: "password",
+		NewEmail:        "new@example.com",
+	}, mock.Anything).Return(&authpb.ChangeEmailResponse{}, nil)
+
+	assert.NoError(t, client.ChangeEmail(context.Background(), "user-1", "password", "new@example.com"))
+	mockClient.AssertExpectations(t)
+}
+
+func TestAuthClient_Login_MFARequired(t *testing.T) {
+	mockClient := new(MockAuthServiceClient)
+	client := &authClient{
+		client: mockClient,
+	}
+
+	mockClient.On("Login", mock.Anything, &authpb.LoginRequest{
+		Email:    "test@example.com",
+		Password: "password123",
+	}, mock.Anything).Return(&authpb.LoginResponse{MfaRequired: true, MfaToken: "mfa-token"}, nil)
+
+	resp, err := client.Login(context.Background(), "test@example.com", "password123", "")
+	assert.NoError(t, err)
+	assert.Equal(t, &response.LoginResponse{MFARequired: true, MFAToken: "mfa-token"}, resp)
+	mockClient.AssertExpectations(t)
+}
+
+func TestAuthClient_EnrollTOTP(t *testing.T) {
+	mockClient := new(MockAuthServiceClient)
+	client := &authClient{
+		client: mockClient,
+	}
+
+	mockClient.On("EnrollTOTP", mock.Anything, &authpb.EnrollTOTPRequest{UserId: "user-1"}, mock.Anything).
+		Return(&authpb.EnrollTOTPResponse{Secret: "SECRET", OtpauthUri: "otpauth://totp/x"}, nil)
+
+	resp, err := client.EnrollTOTP(context.Background(), "user-1")
+	assert.NoError(t, err)
+	assert.Equal(t, &response.EnrollTOTPResponse{Secret: "SECRET", OtpauthURI: "otpauth://totp/x"}, resp)
+	mockClient.AssertExpectations(t)
+}
+
+func TestAuthClient_ConfirmTOTP(t *testing.T) {
+	mockClient := new(MockAuthServiceClient)
+	client := &authClient{
+		client: mockClient,
+	}
+
+	mockClient.On("ConfirmTOTP", mock.Anything, &authpb.ConfirmTOTPRequest{UserId: "user-1", Code: "123456"}, mock.Anything).
+		Return(&authpb.ConfirmTOTPResponse{RecoveryCodes: []string{"abcd-efgh-ijkl-mnop"}}, nil)
+
+	resp, err := client.ConfirmTOTP(context.Background(), "user-1", "123456")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"abcd-efgh-ijkl-mnop"}, resp.RecoveryCodes)
+
+	mockClient.On("ConfirmTOTP", mock.Anything, &authpb.ConfirmTOTPRequest{UserId: "user-1", Code: "000000"}, mock.Anything).
+		Return(nil, errors.New("invalid code"))
+
+	_, err = client.ConfirmTOTP(context.Background(), "user-1", "000000")
+	assert.Error(t, err)
+	mockClient.AssertExpectations(t)
+}
+
+func TestAuthClient_VerifyMFA(t *testing.T) {
+	mockClient := new(MockAuthServiceClient)
+	client := &authClient{
+		client: mockClient,
+	}
+
+	mockClient.On("VerifyMFA", mock.Anything, &authpb.VerifyMFARequest{
+		MfaToken:   "mfa-token",
+		Code:       "123456",
+		RemoteAddr: "192.0.2.1",
+	}, mock.Anything).Return(&authpb.VerifyMFAResponse{AccessToken: "token", ExpiryUnix: 42}, nil)
+
+	resp, err := client.VerifyMFA(context.Background(), "mfa-token", "123456", "192.0.2.1")
+	assert.NoError(t, err)
+	assert.Equal(t, &response.LoginResponse{AccessToken: "token", ExpiryUnix: 42}, resp)
+	mockClient.AssertExpectations(t)
+}
+
+func TestAuthClient_StartOIDCLogin(t *testing.T) {
+	mockClient := new(MockAuthServiceClient)
+	client := &authClient{
+		client: mockClient,
+	}
+
+	mockClient.On("StartOIDCLogin", mock.Anything, &authpb.StartOIDCLoginRequest{Provider: "corp"}, mock.Anything).
+		Return(&authpb.StartOIDCLoginResponse{AuthorizationUrl: "https://idp.example.com/authorize", LoginToken: "login-token", ExpiryUnix: 42}, nil)
+
+	resp, err := client.StartOIDCLogin(context.Background(), "corp")
+	assert.NoError(t, err)
+	assert.Equal(t, &response.StartOIDCLoginResponse{AuthorizationURL: "https://idp.example.com/authorize", LoginToken: "login-token", ExpiryUnix: 42}, resp)
+
+	mockClient.On("StartOIDCLogin", mock.Anything, &authpb.StartOIDCLoginRequest{Provider: "other"}, mock.Anything).
+		Return(nil, errors.New("identity provider not found"))
+
+	_, err = client.StartOIDCLogin(context.Background(), "other")
+	assert.Error(t, err)
+	mockClient.AssertExpectations(t)
+}
+
+func TestAuthClient_FinishOIDCLogin(t *testing.T) {
+	mockClient := new(MockAuthServiceClient)
+	client := &authClient{
+		client: mockClient,
+	}
+
+	mockClient.On("FinishOIDCLogin", mock.Anything, &authpb.FinishOIDCLoginRequest{
+		Provider:   "corp",
+		Code:       "code",
+		State:      "state",
+		LoginToken: "login-token",
+	}, mock.Anything).Return(&authpb.LoginResponse{MfaRequired: true, MfaToken: "mfa-token"}, nil)
+
+	resp, err := client.FinishOIDCLogin(context.Background(), "corp", "code", "state", "login-token")
+	assert.NoError(t, err)
+	assert.Equal(t, &response.LoginResponse{MFARequired: true, MFAToken: "mfa-token"}, resp)
+	mockClient.AssertExpectations(t)
+}
+
+func TestAuthClient_CreateAPIKey(t *testing.T) {
+	mockClient := new(MockAuthServiceClient)
+	client := &authClient{
+		client: mockClient,
+	}
+
+	mockClient.On("CreateAPIKey", mock.Anything, &authpb.CreateAPIKeyRequest{UserId: "user-1", Name: "ci", Scopes: []string{"read"}}, mock.Anything).
+		Return(&authpb.CreateAPIKeyResponse{
+			ApiKey: &authpb.APIKeyInfo{KeyId: "key-1", Name: "ci", Prefix: "dfk_0123456789ab", Scopes: []string{"read"}, CreatedAtUnix: 1700000000},
+			Key:    "dfk_0123456789ab_SECRET",
+		}, nil)
+
+	resp, err := client.CreateAPIKey(context.Background(), "user-1", "ci", []string{"read"})
+	assert.NoError(t, err)
+	assert.Equal(t, &response.APIKeyResponse{
+		KeyID:     "key-1",
+		Name:      "ci",
+		Prefix:    "dfk_0123456789ab",
+		Key:       "dfk_0123456789ab_SECRET",
+		Scopes:    []string{"read"},
+		CreatedAt: time.Unix(1700000000, 0).UTC(),
+	}, resp)
+	mockClient.AssertExpectations(t)
+}
+
+func TestAuthClient_ListAPIKeys(t *testing.T) {
+	mockClient := new(MockAuthServiceClient)
+	client := &authClient{
+		client: mockClient,
+	}
+
+	mockClient.On("ListAPIKeys", mock.Anything, &authpb.ListAPIKeysRequest{UserId: "user-1"}, mock.Anything).
+		Return(&authpb.ListAPIKeysResponse{ApiKeys: []*authpb.APIKeyInfo{
+			{KeyId: "key-2", Name: "deploy", CreatedAtUnix: 1700000100, LastUsedAtUnix: 1700000200},
+			{KeyId: "key-1", Name: "ci", Scopes: []string{"read"}, CreatedAtUnix: 1700000000},
+		}}, nil)
+
+	resp, err := client.ListAPIKeys(context.Background(), "user-1")
+	assert.NoError(t, err)
+	require.Len(t, resp.APIKeys, 2)
+	assert.Equal(t, []string{}, resp.APIKeys[0].Scopes)
+	lastUsedAt := time.Unix(1700000200, 0).UTC()
+	assert.Equal(t, &lastUsedAt, resp.APIKeys[0].LastUsedAt)
+	assert.Nil(t, resp.APIKeys[1].LastUsedAt)
+	assert.Empty(t, resp.APIKeys[1].Key)
+	mockClient.AssertExpectations(t)
+}
+
+func TestAuthClient_RevokeAPIKey(t *testing.T) {
+	mockClient := new(MockAuthServiceClient)
+	client := &authClient{
+		client: mockClient,
+	}
+
+	mockClient.On("RevokeAPIKey", mock.Anything, &authpb.RevokeAPIKeyRequest{UserId: "user-1", KeyId: "key-1", ImpersonatorId: "admin-1"}, mock.Anything).
+		Return(&authpb.RevokeAPIKeyResponse{}, nil)
+
+	assert.NoError(t, client.RevokeAPIKey(context.Background(), "user-1", "key-1", "admin-1"))
+	mockClient.AssertExpectations(t)
+}
+
+func TestAuthClient_ValidateAPIKey(t *testing.T) {
+	mockClient := new(MockAuthServiceClient)
+	client := &authClient{
+		client: mockClient,
+	}
+
+	mockClient.On("ValidateAPIKey", mock.Anything, &authpb.ValidateAPIKeyRequest{Key: "dfk_0123456789ab_SECRET"}, mock.Anything).
+		Return(&authpb.ValidateAPIKeyResponse{UserId: "user-1", Email: "test@example.com", IsVerified: true, Scopes: []string{"upload"}}, nil)
+
+	user, scopes, err := client.ValidateAPIKey(context.Background(), "dfk_0123456789ab_SECRET")
+	assert.NoError(t, err)
+	assert.Equal(t, &response.UserResponse{UserID: "user-1", Email: "test@example.com", IsVerified: true}, user)
+	assert.Equal(t, []string{"upload"}, scopes)
+
+	mockClient.On("ValidateAPIKey", mock.Anything, &authpb.ValidateAPIKeyRequest{Key: "bad"}, mock.Anything).
+		Return(nil, errors.New("invalid or revoked api key"))
+	_, _, err = client.ValidateAPIKey(context.Background(), "bad")
+	assert.Error(t, err)
+}
+
 type fakeAuthServiceServer struct {
 	authpb.UnimplementedAuthServiceServer
 }
@@ -170,8 +819,112 @@ func TestNewAuthClient(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "srv-user@example.com", resp.UserID)
 
-	loginResp, err := client.Login(context.Background(), "user@example.com", "secret")
+	loginResp, err := client.Login(context.Background(), "user@example.com", "secret", "")
 	assert.NoError(t, err)
 	assert.Contains(t, loginResp.AccessToken, "token-user@example.com")
 	assert.NotZero(t, loginResp.ExpiryUnix)
 }
+
+func TestAuthClient_ListUsers(t *testing.T) {
+	mockClient := new(MockAuthServiceClient)
+	client := &authClient{
+		client: mockClient,
+	}
+
+	mockClient.On("ListUsers", mock.Anything, &authpb.ListUsersRequest{AdminId: "admin-1", Query: "example", Role: "user", PageSize: 10, PageToken: "next"}, mock.Anything).
+		Return(&authpb.ListUsersResponse{
+			Users:         []*authpb.UserInfo{{UserId: "user-1", Email: "test@example.com", Role: "user", Disabled: true, MfaEnabled: true}},
+			NextPageToken: "after",
+		}, nil)
+
+	resp, err := client.ListUsers(context.Background(), "admin-1", "example", "user", 10, "next")
+
+	assert.NoError(t, err)
+	assert.Equal(t, &response.ListUsersResponse{
+		Users:         []response.AdminUserResponse{{UserID: "user-1", Email: "test@example.com", Role: "user", Disabled: true, MFAEnabled: true}},
+		NextPageToken: "after",
+	}, resp)
+	mockClient.AssertExpectations(t)
+}
+
+func TestAuthClient_SetUserDisabled(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockClient := new(MockAuthServiceClient)
+		client := &authClient{
+			client: mockClient,
+		}
+
+		mockClient.On("SetUserDisabled", mock.Anything, &authpb.SetUserDisabledRequest{AdminId: "admin-1", UserId: "user-1", Disabled: true}, mock.Anything).
+			Return(&authpb.SetUserDisabledResponse{User: &authpb.UserInfo{UserId: "user-1", Role: "user", Disabled: true}}, nil)
+
+		resp, err := client.SetUserDisabled(context.Background(), "admin-1", "user-1", true)
+
+		assert.NoError(t, err)
+		assert.Equal(t, &response.AdminUserResponse{UserID: "user-1", Role: "user", Disabled: true}, resp)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		mockClient := new(MockAuthServiceClient)
+		client := &authClient{
+			client: mockClient,
+		}
+
+		expectedErr := errors.New("permission denied")
+		mockClient.On("SetUserDisabled", mock.Anything, mock.Anything, mock.Anything).Return(nil, expectedErr)
+
+		resp, err := client.SetUserDisabled(context.Background(), "admin-1", "user-1", true)
+
+		assert.Nil(t, resp)
+		assert.Equal(t, expectedErr, err)
+	})
+}
+
+func TestAuthClient_ImpersonateUser(t *testing.T) {
+	mockClient := new(MockAuthServiceClient)
+	client := &authClient{
+		client: mockClient,
+	}
+
+	mockClient.On("ImpersonateUser", mock.Anything, &authpb.ImpersonateUserRequest{AdminId: "admin-1", UserId: "user-1"}, mock.Anything).
+		Return(&authpb.ImpersonateUserResponse{AccessToken: "token", ExpiryUnix: 42}, nil)
+
+	resp, err := client.ImpersonateUser(context.Background(), "admin-1", "user-1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, &response.LoginResponse{AccessToken: "token", ExpiryUnix: 42}, resp)
+	mockClient.AssertExpectations(t)
+}
+
+func TestAuthClient_Roles(t *testing.T) {
+	mockClient := new(MockAuthServiceClient)
+	client := &authClient{
+		client: mockClient,
+	}
+
+	mockClient.On("ListRoles", mock.Anything, &authpb.ListRolesRequest{AdminId: "admin-1"}, mock.Anything).
+		Return(&authpb.ListRolesResponse{Roles: []*authpb.RoleInfo{
+			{Name: "user", BuiltIn: true},
+			{Name: "support", Description: "Support staff", Permissions: []string{"users:read"}},
+		}}, nil)
+	mockClient.On("CreateRole", mock.Anything, &authpb.CreateRoleRequest{AdminId: "admin-1", Name: "support", Permissions: []string{"users:read"}}, mock.Anything).
+		Return(&authpb.CreateRoleResponse{Role: &authpb.RoleInfo{Name: "support", Permissions: []string{"users:read"}}}, nil)
+	mockClient.On("SetUserRole", mock.Anything, &authpb.SetUserRoleRequest{AdminId: "admin-1", UserId: "user-1", Role: "support"}, mock.Anything).
+		Return(&authpb.SetUserRoleResponse{User: &authpb.UserInfo{UserId: "user-1", Role: "support"}}, nil)
+
+	roles, err := client.ListRoles(context.Background(), "admin-1")
+	assert.NoError(t, err)
+	assert.Equal(t, &response.ListRolesResponse{Roles: []response.RoleResponse{
+		{Name: "user", Permissions: []string{}, BuiltIn: true},
+		{Name: "support", Description: "Support staff", Permissions: []string{"users:read"}},
+	}}, roles)
+
+	role, err := client.CreateRole(context.Background(), "admin-1", "support", "", []string{"users:read"})
+	assert.NoError(t, err)
+	assert.Equal(t, &response.RoleResponse{Name: "support", Permissions: []string{"users:read"}}, role)
+
+	user, err := client.SetUserRole(context.Background(), "admin-1", "user-1", "support")
+	assert.NoError(t, err)
+	assert.Equal(t, &response.AdminUserResponse{UserID: "user-1", Role: "support"}, user)
+	mockClient.AssertExpectations(t)
+}
@@ -12,7 +12,39 @@ import (
 
 type AuthClient interface {
 	Signup(ctx context.Context, email, password string) (*response.SignUpResponse, error)
-	Login(ctx context.Context, email, password string) (*response.LoginResponse, error)
+	Login(ctx context.Context, email, password, remoteAddr string) (*response.LoginResponse, error)
+	LookupUser(ctx context.Context, email string) (*response.UserResponse, error)
+	GetUser(ctx context.Context, userID string) (*response.UserResponse, error)
+	VerifyEmail(ctx context.Context, token string) (*response.UserResponse, error)
+	ResendVerification(ctx context.Context, email string) error
+	RequestPasswordReset(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token, newPassword string) error
+	ValidateToken(ctx context.Context, accessToken string) (*response.UserResponse, error)
+	ChangePassword(ctx context.Context, userID, currentPassword, newPassword string) (*response.LoginResponse, error)
+	ChangeEmail(ctx context.Context, userID, currentPassword, newEmail string) error
+	EnrollTOTP(ctx context.Context, userID string) (*response.EnrollTOTPResponse, error)
+	ConfirmTOTP(ctx context.Context, userID, code string) (*response.RecoveryCodesResponse, error)
+	VerifyMFA(ctx context.Context, mfaToken, code, remoteAddr string) (*response.LoginResponse, error)
+	StartOIDCLogin(ctx context.Context, provider string) (*response.StartOIDCLoginResponse, error)
+	FinishOIDCLogin(ctx context.Context, provider, code, state, loginToken string) (*response.LoginResponse, error)
+	CreateAPIKey(ctx context.Context, userID, name string, scopes []string) (*response.APIKeyResponse, error)
+	ListAPIKeys(ctx context.Context, userID string) (*response.ListAPIKeysResponse, error)
+	// RevokeAPIKey audits the administrator with impersonatorID as the
+	// actor, an empty id meaning the user revoked the key themselves.
+	RevokeAPIKey(ctx context.Context, userID, keyID, impersonatorID string) error
+	// ValidateAPIKey resolves the user an API key belongs to, and the scopes
+	// the key is restricted to.
+	ValidateAPIKey(ctx context.Context, key string) (*response.UserResponse, []string, error)
+	// The admin methods act as the administrator with adminID, whose role
+	// must grant the permission each of them needs.
+	ListUsers(ctx context.Context, adminID, query, role string, pageSize int32, pageToken string) (*response.ListUsersResponse, error)
+	SetUserDisabled(ctx context.Context, adminID, userID string, disabled bool) (*response.AdminUserResponse, error)
+	VerifyUser(ctx context.Context, adminID, userID string) (*response.AdminUserResponse, error)
+	ResetUserMFA(ctx context.Context, adminID, userID string) (*response.AdminUserResponse, error)
+	ImpersonateUser(ctx context.Context, adminID, userID string) (*response.LoginResponse, error)
+	ListRoles(ctx context.Context, adminID string) (*response.ListRolesResponse, error)
+	CreateRole(ctx context.Context, adminID, name, description string, permissions []string) (*response.RoleResponse, error)
+	SetUserRole(ctx context.Context, adminID, userID, role string) (*response.AdminUserResponse, error)
 }
 
 var _ AuthClient = &authClient{}
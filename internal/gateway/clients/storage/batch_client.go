@@ -0,0 +1,22 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+)
+
+// CreateBatchJob only lists the documents of the job, which are formatted in
+// the background.
+func (s *storageClient) CreateBatchJob(ctx context.Context, req *storagepb.CreateBatchJobRequest) (*storagepb.CreateBatchJobResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	return s.client.CreateBatchJob(ctx, req)
+}
+
+func (s *storageClient) GetBatchJob(ctx context.Context, req *storagepb.GetBatchJobRequest) (*storagepb.GetBatchJobResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	return s.client.GetBatchJob(ctx, req)
+}
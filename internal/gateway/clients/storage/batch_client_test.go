@@ -0,0 +1,55 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStorageClientBatchCallsUseTimeoutAndForwardRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		req  any
+	}{
+		{
+			name: "create batch job",
+			req: &storagepb.CreateBatchJobRequest{
+				UserId:   "user-123",
+				FolderId: "folder-id",
+				Profile:  &storagepb.StyleProfile{FontFamily: "Georgia"},
+				Archive:  true,
+			},
+		},
+		{
+			name: "get batch job",
+			req:  &storagepb.GetBatchJobRequest{UserId: "user-123", JobId: "job-id"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockStorageServiceClient{}
+			client := &storageClient{client: mockClient}
+			ctx := context.Background()
+
+			var err error
+			switch req := tt.req.(type) {
+			case *storagepb.CreateBatchJobRequest:
+				_, err = client.CreateBatchJob(ctx, req)
+			case *storagepb.GetBatchJobRequest:
+				_, err = client.GetBatchJob(ctx, req)
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.req, mockClient.lastFolderReq)
+
+			deadline, ok := mockClient.lastCtx.Deadline()
+			assert.True(t, ok, "expected context to have a deadline")
+			remaining := time.Until(deadline)
+			assert.Greater(t, remaining, time.Duration(0))
+			assert.LessOrEqual(t, remaining, 5*time.Second)
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"google.golang.org/grpc"
+)
+
+func (s *storageClient) DiffDocuments(ctx context.Context, req *storagepb.DiffDocumentsRequest) (*storagepb.DiffDocumentsResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	return s.client.DiffDocuments(ctx, req)
+}
+
+// RedlineDocuments opens the stream of the redline DOCX of two files. Like
+// DownloadFile, the stream lives as long as ctx.
+func (s *storageClient) RedlineDocuments(ctx context.Context, req *storagepb.RedlineDocumentsRequest) (grpc.ServerStreamingClient[storagepb.DownloadFileResponse], error) {
+	return s.client.RedlineDocuments(ctx, req)
+}
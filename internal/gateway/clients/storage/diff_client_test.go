@@ -0,0 +1,42 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStorageClientDiffDocumentsUsesTimeoutAndForwardsRequest(t *testing.T) {
+	mockClient := &mockStorageServiceClient{}
+	client := &storageClient{client: mockClient}
+
+	contextLines := int32(5)
+	req := &storagepb.DiffDocumentsRequest{UserId: "user-123", OldFileId: "old-id", NewFileId: "new-id", ContextLines: &contextLines}
+
+	_, err := client.DiffDocuments(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, req, mockClient.lastFolderReq)
+
+	deadline, ok := mockClient.lastCtx.Deadline()
+	assert.True(t, ok, "expected context to have a deadline")
+	remaining := time.Until(deadline)
+	assert.Greater(t, remaining, time.Duration(0))
+	assert.LessOrEqual(t, remaining, 5*time.Second)
+}
+
+func TestStorageClientRedlineDocumentsForwardsRequestWithoutTimeout(t *testing.T) {
+	mockClient := &mockStorageServiceClient{}
+	client := &storageClient{client: mockClient}
+
+	req := &storagepb.RedlineDocumentsRequest{UserId: "user-123", OldFileId: "old-id", NewFileId: "new-id"}
+
+	_, err := client.RedlineDocuments(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, req, mockClient.lastFolderReq)
+
+	_, ok := mockClient.lastCtx.Deadline()
+	assert.False(t, ok, "expected redline stream to have no deadline")
+}
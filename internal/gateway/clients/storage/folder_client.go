@@ -0,0 +1,40 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+)
+
+func (s *storageClient) CreateFolder(ctx context.Context, req *storagepb.CreateFolderRequest) (*storagepb.CreateFolderResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	return s.client.CreateFolder(ctx, req)
+}
+
+func (s *storageClient) RenameFolder(ctx context.Context, req *storagepb.RenameFolderRequest) (*storagepb.RenameFolderResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	return s.client.RenameFolder(ctx, req)
+}
+
+func (s *storageClient) MoveFolder(ctx context.Context, req *storagepb.MoveFolderRequest) (*storagepb.MoveFolderResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	return s.client.MoveFolder(ctx, req)
+}
+
+// DeleteFolder allows as much time as an upload since a recursive delete
+// moves every file of the folder tree into the trash.
+func (s *storageClient) DeleteFolder(ctx context.Context, req *storagepb.DeleteFolderRequest) (*storagepb.DeleteFolderResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	return s.client.DeleteFolder(ctx, req)
+}
+
+func (s *storageClient) ListFolder(ctx context.Context, req *storagepb.ListFolderRequest) (*storagepb.ListFolderResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	return s.client.ListFolder(ctx, req)
+}
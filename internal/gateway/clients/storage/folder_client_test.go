@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStorageClientFolderCallsUseTimeoutAndForwardRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		timeout time.Duration
+		req     any
+	}{
+		{
+			name:    "move file",
+			timeout: 5 * time.Second,
+			req:     &storagepb.MoveFileRequest{UserId: "user-123", FileId: "file-id"},
+		},
+		{
+			name:    "create folder",
+			timeout: 5 * time.Second,
+			req:     &storagepb.CreateFolderRequest{UserId: "user-123", Name: "Clients"},
+		},
+		{
+			name:    "rename folder",
+			timeout: 5 * time.Second,
+			req:     &storagepb.RenameFolderRequest{UserId: "user-123", FolderId: "folder-id", Name: "Acme"},
+		},
+		{
+			name:    "move folder",
+			timeout: 5 * time.Second,
+			req:     &storagepb.MoveFolderRequest{UserId: "user-123", FolderId: "folder-id"},
+		},
+		{
+			name:    "delete folder",
+			timeout: 30 * time.Second,
+			req:     &storagepb.DeleteFolderRequest{UserId: "user-123", FolderId: "folder-id", Recursive: true},
+		},
+		{
+			name:    "list folder",
+			timeout: 5 * time.Second,
+			req:     &storagepb.ListFolderRequest{UserId: "user-123"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockStorageServiceClient{}
+			client := &storageClient{client: mockClient}
+			ctx := context.Background()
+
+			var err error
+			switch req := tt.req.(type) {
+			case *storagepb.MoveFileRequest:
+				_, err = client.MoveFile(ctx, req)
+			case *storagepb.CreateFolderRequest:
+				_, err = client.CreateFolder(ctx, req)
+			case *storagepb.RenameFolderRequest:
+				_, err = client.RenameFolder(ctx, req)
+			case *storagepb.MoveFolderRequest:
+				_, err = client.MoveFolder(ctx, req)
+			case *storagepb.DeleteFolderRequest:
+				_, err = client.DeleteFolder(ctx, req)
+			case *storagepb.ListFolderRequest:
+				_, err = client.ListFolder(ctx, req)
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.req, mockClient.lastFolderReq)
+
+			deadline, ok := mockClient.lastCtx.Deadline()
+			assert.True(t, ok, "expected context to have a deadline")
+			remaining := time.Until(deadline)
+			assert.Greater(t, remaining, time.Duration(0))
+			assert.LessOrEqual(t, remaining, tt.timeout)
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+)
+
+func (s *storageClient) AddFileTags(ctx context.Context, req *storagepb.AddFileTagsRequest) (*storagepb.AddFileTagsResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	return s.client.AddFileTags(ctx, req)
+}
+
+func (s *storageClient) RemoveFileTags(ctx context.Context, req *storagepb.RemoveFileTagsRequest) (*storagepb.RemoveFileTagsResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	return s.client.RemoveFileTags(ctx, req)
+}
+
+func (s *storageClient) SetFileMetadata(ctx context.Context, req *storagepb.SetFileMetadataRequest) (*storagepb.SetFileMetadataResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	return s.client.SetFileMetadata(ctx, req)
+}
+
+func (s *storageClient) RemoveFileMetadata(ctx context.Context, req *storagepb.RemoveFileMetadataRequest) (*storagepb.RemoveFileMetadataResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	return s.client.RemoveFileMetadata(ctx, req)
+}
+
+func (s *storageClient) ListFiles(ctx context.Context, req *storagepb.ListFilesRequest) (*storagepb.ListFilesResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	return s.client.ListFiles(ctx, req)
+}
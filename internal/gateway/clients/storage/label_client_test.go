@@ -0,0 +1,68 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStorageClientLabelCallsUseTimeoutAndForwardRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		req  any
+	}{
+		{
+			name: "add file tags",
+			req:  &storagepb.AddFileTagsRequest{UserId: "user-123", FileId: "file-id", Tags: []string{"client:acme"}},
+		},
+		{
+			name: "remove file tags",
+			req:  &storagepb.RemoveFileTagsRequest{UserId: "user-123", FileId: "file-id", Tags: []string{"client:acme"}},
+		},
+		{
+			name: "set file metadata",
+			req:  &storagepb.SetFileMetadataRequest{UserId: "user-123", FileId: "file-id", Metadata: map[string]string{"owner": "legal"}},
+		},
+		{
+			name: "remove file metadata",
+			req:  &storagepb.RemoveFileMetadataRequest{UserId: "user-123", FileId: "file-id", Keys: []string{"owner"}},
+		},
+		{
+			name: "list files",
+			req:  &storagepb.ListFilesRequest{UserId: "user-123", Tags: []string{"status:draft"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockStorageServiceClient{}
+			client := &storageClient{client: mockClient}
+			ctx := context.Background()
+
+			var err error
+			switch req := tt.req.(type) {
+			case *storagepb.AddFileTagsRequest:
+				_, err = client.AddFileTags(ctx, req)
+			case *storagepb.RemoveFileTagsRequest:
+				_, err = client.RemoveFileTags(ctx, req)
+			case *storagepb.SetFileMetadataRequest:
+				_, err = client.SetFileMetadata(ctx, req)
+			case *storagepb.RemoveFileMetadataRequest:
+				_, err = client.RemoveFileMetadata(ctx, req)
+			case *storagepb.ListFilesRequest:
+				_, err = client.ListFiles(ctx, req)
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.req, mockClient.lastFolderReq)
+
+			deadline, ok := mockClient.lastCtx.Deadline()
+			assert.True(t, ok, "expected context to have a deadline")
+			remaining := time.Until(deadline)
+			assert.Greater(t, remaining, time.Duration(0))
+			assert.LessOrEqual(t, remaining, 5*time.Second)
+		})
+	}
+}
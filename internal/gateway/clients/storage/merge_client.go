@@ -0,0 +1,29 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+)
+
+// MergeDocuments allows as much time as an upload since it reads every source
+// and stores the merged file.
+func (s *storageClient) MergeDocuments(ctx context.Context, req *storagepb.MergeDocumentsRequest) (*storagepb.MergeDocumentsResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	return s.client.MergeDocuments(ctx, req)
+}
+
+// SplitDocument allows as much time as an upload since it stores every part.
+func (s *storageClient) SplitDocument(ctx context.Context, req *storagepb.SplitDocumentRequest) (*storagepb.SplitDocumentResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	return s.client.SplitDocument(ctx, req)
+}
+
+func (s *storageClient) GetProvenance(ctx context.Context, req *storagepb.GetProvenanceRequest) (*storagepb.GetProvenanceResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	return s.client.GetProvenance(ctx, req)
+}
@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStorageClientMergeCallsUseTimeoutAndForwardRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		timeout time.Duration
+		req     any
+	}{
+		{
+			name:    "merge documents",
+			timeout: 30 * time.Second,
+			req:     &storagepb.MergeDocumentsRequest{UserId: "user-123", SourceFileIds: []string{"a", "b"}, FileName: "all.md"},
+		},
+		{
+			name:    "split document",
+			timeout: 30 * time.Second,
+			req:     &storagepb.SplitDocumentRequest{UserId: "user-123", FileId: "file-id", HeadingLevel: 2},
+		},
+		{
+			name:    "get provenance",
+			timeout: 5 * time.Second,
+			req:     &storagepb.GetProvenanceRequest{UserId: "user-123", FileId: "file-id"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockStorageServiceClient{}
+			client := &storageClient{client: mockClient}
+			ctx := context.Background()
+
+			var err error
+			switch req := tt.req.(type) {
+			case *storagepb.MergeDocumentsRequest:
+				_, err = client.MergeDocuments(ctx, req)
+			case *storagepb.SplitDocumentRequest:
+				_, err = client.SplitDocument(ctx, req)
+			case *storagepb.GetProvenanceRequest:
+				_, err = client.GetProvenance(ctx, req)
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.req, mockClient.lastFolderReq)
+
+			deadline, ok := mockClient.lastCtx.Deadline()
+			assert.True(t, ok, "expected context to have a deadline")
+			remaining := time.Until(deadline)
+			assert.Greater(t, remaining, time.Duration(0))
+			assert.LessOrEqual(t, remaining, tt.timeout)
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+)
+
+func (s *storageClient) SearchDocuments(ctx context.Context, req *storagepb.SearchDocumentsRequest) (*storagepb.SearchDocumentsResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	return s.client.SearchDocuments(ctx, req)
+}
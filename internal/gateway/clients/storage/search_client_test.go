@@ -0,0 +1,27 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStorageClientSearchDocumentsUsesTimeoutAndForwardsRequest(t *testing.T) {
+	mockClient := &mockStorageServiceClient{}
+	client := &storageClient{client: mockClient}
+
+	req := &storagepb.SearchDocumentsRequest{UserId: "user-123", Query: "quarterly report", Limit: 10}
+
+	_, err := client.SearchDocuments(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, req, mockClient.lastFolderReq)
+
+	deadline, ok := mockClient.lastCtx.Deadline()
+	assert.True(t, ok, "expected context to have a deadline")
+	remaining := time.Until(deadline)
+	assert.Greater(t, remaining, time.Duration(0))
+	assert.LessOrEqual(t, remaining, 5*time.Second)
+}
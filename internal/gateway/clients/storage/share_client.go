@@ -0,0 +1,32 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+)
+
+func (s *storageClient) Share(ctx context.Context, req *storagepb.ShareRequest) (*storagepb.ShareResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	return s.client.Share(ctx, req)
+}
+
+func (s *storageClient) Unshare(ctx context.Context, req *storagepb.UnshareRequest) (*storagepb.UnshareResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	return s.client.Unshare(ctx, req)
+}
+
+func (s *storageClient) ListShares(ctx context.Context, req *storagepb.ListSharesRequest) (*storagepb.ListSharesResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	return s.client.ListShares(ctx, req)
+}
+
+func (s *storageClient) ListSharedWithMe(ctx context.Context, req *storagepb.ListSharedWithMeRequest) (*storagepb.ListSharedWithMeResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	return s.client.ListSharedWithMe(ctx, req)
+}
@@ -0,0 +1,62 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStorageClientShareCallsUseTimeoutAndForwardRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		req  any
+	}{
+		{
+			name: "share",
+			req:  &storagepb.ShareRequest{UserId: "user-123", ResourceType: "file", ResourceId: "file-id", GranteeId: "user-456", Role: "viewer"},
+		},
+		{
+			name: "unshare",
+			req:  &storagepb.UnshareRequest{UserId: "user-123", ResourceType: "folder", ResourceId: "folder-id", GranteeId: "user-456"},
+		},
+		{
+			name: "list shares",
+			req:  &storagepb.ListSharesRequest{UserId: "user-123", ResourceType: "file", ResourceId: "file-id"},
+		},
+		{
+			name: "list shared with me",
+			req:  &storagepb.ListSharedWithMeRequest{UserId: "user-456"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockStorageServiceClient{}
+			client := &storageClient{client: mockClient}
+			ctx := context.Background()
+
+			var err error
+			switch req := tt.req.(type) {
+			case *storagepb.ShareRequest:
+				_, err = client.Share(ctx, req)
+			case *storagepb.UnshareRequest:
+				_, err = client.Unshare(ctx, req)
+			case *storagepb.ListSharesRequest:
+				_, err = client.ListShares(ctx, req)
+			case *storagepb.ListSharedWithMeRequest:
+				_, err = client.ListSharedWithMe(ctx, req)
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.req, mockClient.lastFolderReq)
+
+			deadline, ok := mockClient.lastCtx.Deadline()
+			assert.True(t, ok, "expected context to have a deadline")
+			remaining := time.Until(deadline)
+			assert.Greater(t, remaining, time.Duration(0))
+			assert.LessOrEqual(t, remaining, 5*time.Second)
+		})
+	}
+}
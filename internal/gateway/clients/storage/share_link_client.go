@@ -0,0 +1,33 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"google.golang.org/grpc"
+)
+
+func (s *storageClient) CreateShareLink(ctx context.Context, req *storagepb.CreateShareLinkRequest) (*storagepb.CreateShareLinkResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	return s.client.CreateShareLink(ctx, req)
+}
+
+func (s *storageClient) ListShareLinks(ctx context.Context, req *storagepb.ListShareLinksRequest) (*storagepb.ListShareLinksResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	return s.client.ListShareLinks(ctx, req)
+}
+
+func (s *storageClient) RevokeShareLink(ctx context.Context, req *storagepb.RevokeShareLinkRequest) (*storagepb.RevokeShareLinkResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	return s.client.RevokeShareLink(ctx, req)
+}
+
+// DownloadSharedFile opens the content stream of a file behind a share link.
+// Like DownloadFile, the stream lives as long as ctx.
+func (s *storageClient) DownloadSharedFile(ctx context.Context, req *storagepb.DownloadSharedFileRequest) (grpc.ServerStreamingClient[storagepb.DownloadFileResponse], error) {
+	return s.client.DownloadSharedFile(ctx, req)
+}
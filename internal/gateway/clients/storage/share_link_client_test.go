@@ -0,0 +1,75 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStorageClientShareLinkCallsUseTimeoutAndForwardRequest(t *testing.T) {
+	tests := []struct {
+		name string
+		req  any
+	}{
+		{
+			name: "create share link",
+			req:  &storagepb.CreateShareLinkRequest{UserId: "user-123", FileId: "file-id", Password: "secret", MaxDownloads: 3},
+		},
+		{
+			name: "list share links",
+			req:  &storagepb.ListShareLinksRequest{UserId: "user-123", FileId: "file-id"},
+		},
+		{
+			name: "revoke share link",
+			req:  &storagepb.RevokeShareLinkRequest{UserId: "user-123", LinkId: "link-id"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockStorageServiceClient{}
+			client := &storageClient{client: mockClient}
+			ctx := context.Background()
+
+			var err error
+			switch req := tt.req.(type) {
+			case *storagepb.CreateShareLinkRequest:
+				_, err = client.CreateShareLink(ctx, req)
+			case *storagepb.ListShareLinksRequest:
+				_, err = client.ListShareLinks(ctx, req)
+			case *storagepb.RevokeShareLinkRequest:
+				_, err = client.RevokeShareLink(ctx, req)
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.req, mockClient.lastFolderReq)
+
+			deadline, ok := mockClient.lastCtx.Deadline()
+			assert.True(t, ok, "expected context to have a deadline")
+			remaining := time.Until(deadline)
+			assert.Greater(t, remaining, time.Duration(0))
+			assert.LessOrEqual(t, remaining, 5*time.Second)
+		})
+	}
+}
+
+func TestStorageClientDownloadSharedFileForwardsRequestWithoutTimeout(t *testing.T) {
+	mockClient := &mockStorageServiceClient{}
+	client := &storageClient{client: mockClient}
+
+	req := &storagepb.DownloadSharedFileRequest{
+		Token:      "token",
+		Password:   "secret",
+		RemoteAddr: "203.0.113.7",
+		UserAgent:  "curl/8.0",
+	}
+
+	_, err := client.DownloadSharedFile(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, req, mockClient.lastFolderReq)
+
+	_, ok := mockClient.lastCtx.Deadline()
+	assert.False(t, ok, "expected download stream to have no deadline")
+}
@@ -0,0 +1,14 @@
+package storage
+
+import (
+	"context"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"google.golang.org/grpc"
+)
+
+// StampFile opens the stream of a stamped copy of a file. Like DownloadFile,
+// the stream lives as long as ctx.
+func (s *storageClient) StampFile(ctx context.Context, req *storagepb.StampFileRequest) (grpc.ServerStreamingClient[storagepb.DownloadFileResponse], error) {
+	return s.client.StampFile(ctx, req)
+}
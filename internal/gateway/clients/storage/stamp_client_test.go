@@ -0,0 +1,23 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStorageClientStampFileForwardsRequestWithoutTimeout(t *testing.T) {
+	mockClient := &mockStorageServiceClient{}
+	client := &storageClient{client: mockClient}
+
+	req := &storagepb.StampFileRequest{UserId: "user-123", FileId: "file-id", Watermark: "DRAFT", UserLabel: "ann@example.com"}
+
+	_, err := client.StampFile(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, req, mockClient.lastFolderReq)
+
+	_, ok := mockClient.lastCtx.Deadline()
+	assert.False(t, ok, "expected stamp stream to have no deadline")
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"google.golang.org/grpc"
 )
 
 func (s *storageClient) UploadFile(ctx context.Context, req *storagepb.UploadFileRequest) (*storagepb.UploadFileResponse, error) {
@@ -12,3 +13,15 @@ func (s *storageClient) UploadFile(ctx context.Context, req *storagepb.UploadFil
 	defer cancel()
 	return s.client.UploadFile(ctx, req)
 }
+
+func (s *storageClient) MoveFile(ctx context.Context, req *storagepb.MoveFileRequest) (*storagepb.MoveFileResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	return s.client.MoveFile(ctx, req)
+}
+
+// DownloadFile opens the content stream of a file. The stream lives as long as
+// ctx, so no timeout is applied; large files may take a while to transfer.
+func (s *storageClient) DownloadFile(ctx context.Context, req *storagepb.DownloadFileRequest) (grpc.ServerStreamingClient[storagepb.DownloadFileResponse], error) {
+	return s.client.DownloadFile(ctx, req)
+}
@@ -2,6 +2,7 @@ package storage
 
 import (
 	"context"
+	"io"
 	"net"
 	"testing"
 	"time"
@@ -17,6 +18,106 @@ type mockStorageServiceClient struct {
 
 	resp *storagepb.UploadFileResponse
 	err  error
+
+	lastDownloadReq *storagepb.DownloadFileRequest
+	// lastFolderReq records the request of the file, folder and label
+	// management calls.
+	lastFolderReq any
+}
+
+func (m *mockStorageServiceClient) MoveFile(ctx context.Context, in *storagepb.MoveFileRequest, opts ...grpc.CallOption) (*storagepb.MoveFileResponse, error) {
+	m.lastCtx, m.lastFolderReq = ctx, in
+	return &storagepb.MoveFileResponse{}, m.err
+}
+
+func (m *mockStorageServiceClient) CreateFolder(ctx context.Context, in *storagepb.CreateFolderRequest, opts ...grpc.CallOption) (*storagepb.CreateFolderResponse, error) {
+	m.lastCtx, m.lastFolderReq = ctx, in
+	return &storagepb.CreateFolderResponse{}, m.err
+}
+
+func (m *mockStorageServiceClient) RenameFolder(ctx context.Context, in *storagepb.RenameFolderRequest, opts ...grpc.CallOption) (*storagepb.RenameFolderResponse, error) {
+	m.lastCtx, m.lastFolderReq = ctx, in
+	return &storagepb.RenameFolderResponse{}, m.err
+}
+
+func (m *mockStorageServiceClient) MoveFolder(ctx context.Context, in *storagepb.MoveFolderRequest, opts ...grpc.CallOption) (*storagepb.MoveFolderResponse, error) {
+	m.lastCtx, m.lastFolderReq = ctx, in
+	return &storagepb.MoveFolderResponse{}, m.err
+}
+
+func (m *mockStorageServiceClient) DeleteFolder(ctx context.Context, in *storagepb.DeleteFolderRequest, opts ...grpc.CallOption) (*storagepb.DeleteFolderResponse, error) {
+	m.lastCtx, m.lastFolderReq = ctx, in
+	return &storagepb.DeleteFolderResponse{}, m.err
+}
+
+func (m *mockStorageServiceClient) ListFolder(ctx context.Context, in *storagepb.ListFolderRequest, opts ...grpc.CallOption) (*storagepb.ListFolderResponse, error) {
+	m.lastCtx, m.lastFolderReq = ctx, in
+	return &storagepb.ListFolderResponse{}, m.err
+}
+
+func (m *mockStorageServiceClient) AddFileTags(ctx context.Context, in *storagepb.AddFileTagsRequest, opts ...grpc.CallOption) (*storagepb.AddFileTagsResponse, error) {
+	m.lastCtx, m.lastFolderReq = ctx, in
+	return &storagepb.AddFileTagsResponse{}, m.err
+}
+
+func (m *mockStorageServiceClient) RemoveFileTags(ctx context.Context, in *storagepb.RemoveFileTagsRequest, opts ...grpc.CallOption) (*storagepb.RemoveFileTagsResponse, error) {
+	m.lastCtx, m.lastFolderReq = ctx, in
+	return &storagepb.RemoveFileTagsResponse{}, m.err
+}
+
+func (m *mockStorageServiceClient) SetFileMetadata(ctx context.Context, in *storagepb.SetFileMetadataRequest, opts ...grpc.CallOption) (*storagepb.SetFileMetadataResponse, error) {
+	m.lastCtx, m.lastFolderReq = ctx, in
+	return &storagepb.SetFileMetadataResponse{}, m.err
+}
+
+func (m *mockStorageServiceClient) RemoveFileMetadata(ctx context.Context, in *storagepb.RemoveFileMetadataRequest, opts ...grpc.CallOption) (*storagepb.RemoveFileMetadataResponse, error) {
+	m.lastCtx, m.lastFolderReq = ctx, in
+	return &storagepb.RemoveFileMetadataResponse{}, m.err
+}
+
+func (m *mockStorageServiceClient) ListFiles(ctx context.Context, in *storagepb.ListFilesRequest, opts ...grpc.CallOption) (*storagepb.ListFilesResponse, error) {
+	m.lastCtx, m.lastFolderReq = ctx, in
+	return &storagepb.ListFilesResponse{}, m.err
+}
+
+func (m *mockStorageServiceClient) DeleteFile(ctx context.Context, in *storagepb.DeleteFileRequest, opts ...grpc.CallOption) (*storagepb.DeleteFileResponse, error) {
+	m.lastCtx, m.lastFolderReq = ctx, in
+	return &storagepb.DeleteFileResponse{}, m.err
+}
+
+func (m *mockStorageServiceClient) ListTrash(ctx context.Context, in *storagepb.ListTrashRequest, opts ...grpc.CallOption) (*storagepb.ListTrashResponse, error) {
+	m.lastCtx, m.lastFolderReq = ctx, in
+	return &storagepb.ListTrashResponse{}, m.err
+}
+
+func (m *mockStorageServiceClient) RestoreFile(ctx context.Context, in *storagepb.RestoreFileRequest, opts ...grpc.CallOption) (*storagepb.RestoreFileResponse, error) {
+	m.lastCtx, m.lastFolderReq = ctx, in
+	return &storagepb.RestoreFileResponse{}, m.err
+}
+
+func (m *mockStorageServiceClient) EmptyTrash(ctx context.Context, in *storagepb.EmptyTrashRequest, opts ...grpc.CallOption) (*storagepb.EmptyTrashResponse, error) {
+	m.lastCtx, m.lastFolderReq = ctx, in
+	return &storagepb.EmptyTrashResponse{}, m.err
+}
+
+func (m *mockStorageServiceClient) Share(ctx context.Context, in *storagepb.ShareRequest, opts ...grpc.CallOption) (*storagepb.ShareResponse, error) {
+	m.lastCtx, m.lastFolderReq = ctx, in
+	return &storagepb.ShareResponse{}, m.err
+}
+
+func (m *mockStorageServiceClient) Unshare(ctx context.Context, in *storagepb.UnshareRequest, opts ...grpc.CallOption) (*storagepb.UnshareResponse, error) {
+	m.lastCtx, m.lastFolderReq = ctx, in
+	return &storagepb.UnshareResponse{}, m.err
+}
+
+func (m *mockStorageServiceClient) ListShares(ctx context.Context, in *storagepb.ListSharesRequest, opts ...grpc.CallOption) (*storagepb.ListSharesResponse, error) {
+	m.lastCtx, m.lastFolderReq = ctx, in
+	return &storagepb.ListSharesResponse{}, m.err
+}
+
+func (m *mockStorageServiceClient) ListSharedWithMe(ctx context.Context, in *storagepb.ListSharedWithMeRequest, opts ...grpc.CallOption) (*storagepb.ListSharedWithMeResponse, error) {
+	m.lastCtx, m.lastFolderReq = ctx, in
+	return &storagepb.ListSharedWithMeResponse{}, m.err
 }
 
 func (m *mockStorageServiceClient) UploadFile(ctx context.Context, in *storagepb.UploadFileRequest, opts ...grpc.CallOption) (*storagepb.UploadFileResponse, error) {
@@ -58,6 +159,31 @@ func TestStorageClientUploadFileUsesTimeoutAndForwardsRequest(t *testing.T) {
 	assert.LessOrEqual(t, remaining, 30*time.Second)
 }
 
+func (m *mockStorageServiceClient) DownloadFile(ctx context.Context, in *storagepb.DownloadFileRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[storagepb.DownloadFileResponse], error) {
+	m.lastCtx = ctx
+	m.lastDownloadReq = in
+	return nil, m.err
+}
+
+func TestStorageClientDownloadFileForwardsRequestWithoutTimeout(t *testing.T) {
+	mockClient := &mockStorageServiceClient{}
+	client := &storageClient{
+		client: mockClient,
+	}
+
+	req := &storagepb.DownloadFileRequest{
+		UserId: "user-123",
+		FileId: "file-id",
+	}
+
+	_, err := client.DownloadFile(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, req, mockClient.lastDownloadReq)
+
+	_, ok := mockClient.lastCtx.Deadline()
+	assert.False(t, ok, "expected download stream to have no deadline")
+}
+
 type testStorageServer struct {
 	storagepb.UnimplementedStorageServiceServer
 }
@@ -97,3 +223,118 @@ func TestNewStorageClientConnectsToServerAndUploads(t *testing.T) {
 	assert.Equal(t, "uploaded.txt", resp.FileName)
 	assert.NotEmpty(t, resp.FileId)
 }
+
+func (s *testStorageServer) DownloadFile(req *storagepb.DownloadFileRequest, stream grpc.ServerStreamingServer[storagepb.DownloadFileResponse]) error {
+	if err := stream.Send(&storagepb.DownloadFileResponse{FileName: "downloaded.txt", FileSize: 5}); err != nil {
+		return err
+	}
+	return stream.Send(&storagepb.DownloadFileResponse{Chunk: []byte("hello")})
+}
+
+func TestNewStorageClientConnectsToServerAndDownloads(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+
+	grpcServer := grpc.NewServer()
+	storagepb.RegisterStorageServiceServer(grpcServer, &testStorageServer{})
+
+	go grpcServer.Serve(lis)
+	t.Cleanup(func() {
+		grpcServer.Stop()
+		_ = lis.Close()
+	})
+
+	client := NewStorageClient(lis.Addr().String())
+
+	stream, err := client.DownloadFile(context.Background(), &storagepb.DownloadFileRequest{
+		UserId: "user-123",
+		FileId: "file-id",
+	})
+	assert.NoError(t, err)
+
+	header, err := stream.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, "downloaded.txt", header.GetFileName())
+
+	chunk, err := stream.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("hello"), chunk.GetChunk())
+
+	_, err = stream.Recv()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func (m *mockStorageServiceClient) CreateShareLink(ctx context.Context, in *storagepb.CreateShareLinkRequest, opts ...grpc.CallOption) (*storagepb.CreateShareLinkResponse, error) {
+	m.lastCtx, m.lastFolderReq = ctx, in
+	return &storagepb.CreateShareLinkResponse{}, m.err
+}
+
+func (m *mockStorageServiceClient) ListShareLinks(ctx context.Context, in *storagepb.ListShareLinksRequest, opts ...grpc.CallOption) (*storagepb.ListShareLinksResponse, error) {
+	m.lastCtx, m.lastFolderReq = ctx, in
+	return &storagepb.ListShareLinksResponse{}, m.err
+}
+
+func (m *mockStorageServiceClient) RevokeShareLink(ctx context.Context, in *storagepb.RevokeShareLinkRequest, opts ...grpc.CallOption) (*storagepb.RevokeShareLinkResponse, error) {
+	m.lastCtx, m.lastFolderReq = ctx, in
+	return &storagepb.RevokeShareLinkResponse{}, m.err
+}
+
+func (m *mockStorageServiceClient) DownloadSharedFile(ctx context.Context, in *storagepb.DownloadSharedFileRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[storagepb.DownloadFileResponse], error) {
+	m.lastCtx, m.lastFolderReq = ctx, in
+	return nil, m.err
+}
+
+func (m *mockStorageServiceClient) SearchDocuments(ctx context.Context, in *storagepb.SearchDocumentsRequest, opts ...grpc.CallOption) (*storagepb.SearchDocumentsResponse, error) {
+	m.lastCtx, m.lastFolderReq = ctx, in
+	return &storagepb.SearchDocumentsResponse{}, m.err
+}
+
+func (m *mockStorageServiceClient) AnalyzeDocument(ctx context.Context, in *storagepb.AnalyzeDocumentRequest, opts ...grpc.CallOption) (*storagepb.AnalyzeDocumentResponse, error) {
+	m.lastCtx, m.lastFolderReq = ctx, in
+	return &storagepb.AnalyzeDocumentResponse{}, m.err
+}
+
+func (m *mockStorageServiceClient) GetThumbnail(ctx context.Context, in *storagepb.GetThumbnailRequest, opts ...grpc.CallOption) (*storagepb.GetThumbnailResponse, error) {
+	m.lastCtx, m.lastFolderReq = ctx, in
+	return &storagepb.GetThumbnailResponse{}, m.err
+}
+
+func (m *mockStorageServiceClient) DiffDocuments(ctx context.Context, in *storagepb.DiffDocumentsRequest, opts ...grpc.CallOption) (*storagepb.DiffDocumentsResponse, error) {
+	m.lastCtx, m.lastFolderReq = ctx, in
+	return &storagepb.DiffDocumentsResponse{}, m.err
+}
+
+func (m *mockStorageServiceClient) RedlineDocuments(ctx context.Context, in *storagepb.RedlineDocumentsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[storagepb.DownloadFileResponse], error) {
+	m.lastCtx, m.lastFolderReq = ctx, in
+	return nil, m.err
+}
+
+func (m *mockStorageServiceClient) StampFile(ctx context.Context, in *storagepb.StampFileRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[storagepb.DownloadFileResponse], error) {
+	m.lastCtx, m.lastFolderReq = ctx, in
+	return nil, m.err
+}
+
+func (m *mockStorageServiceClient) MergeDocuments(ctx context.Context, in *storagepb.MergeDocumentsRequest, opts ...grpc.CallOption) (*storagepb.MergeDocumentsResponse, error) {
+	m.lastCtx, m.lastFolderReq = ctx, in
+	return &storagepb.MergeDocumentsResponse{}, m.err
+}
+
+func (m *mockStorageServiceClient) SplitDocument(ctx context.Context, in *storagepb.SplitDocumentRequest, opts ...grpc.CallOption) (*storagepb.SplitDocumentResponse, error) {
+	m.lastCtx, m.lastFolderReq = ctx, in
+	return &storagepb.SplitDocumentResponse{}, m.err
+}
+
+func (m *mockStorageServiceClient) GetProvenance(ctx context.Context, in *storagepb.GetProvenanceRequest, opts ...grpc.CallOption) (*storagepb.GetProvenanceResponse, error) {
+	m.lastCtx, m.lastFolderReq = ctx, in
+	return &storagepb.GetProvenanceResponse{}, m.err
+}
+
+func (m *mockStorageServiceClient) CreateBatchJob(ctx context.Context, in *storagepb.CreateBatchJobRequest, opts ...grpc.CallOption) (*storagepb.CreateBatchJobResponse, error) {
+	m.lastCtx, m.lastFolderReq = ctx, in
+	return &storagepb.CreateBatchJobResponse{}, m.err
+}
+
+func (m *mockStorageServiceClient) GetBatchJob(ctx context.Context, in *storagepb.GetBatchJobRequest, opts ...grpc.CallOption) (*storagepb.GetBatchJobResponse, error) {
+	m.lastCtx, m.lastFolderReq = ctx, in
+	return &storagepb.GetBatchJobResponse{}, m.err
+}
@@ -0,0 +1,34 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+)
+
+func (s *storageClient) DeleteFile(ctx context.Context, req *storagepb.DeleteFileRequest) (*storagepb.DeleteFileResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	return s.client.DeleteFile(ctx, req)
+}
+
+func (s *storageClient) ListTrash(ctx context.Context, req *storagepb.ListTrashRequest) (*storagepb.ListTrashResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	return s.client.ListTrash(ctx, req)
+}
+
+func (s *storageClient) RestoreFile(ctx context.Context, req *storagepb.RestoreFileRequest) (*storagepb.RestoreFileResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	return s.client.RestoreFile(ctx, req)
+}
+
+// EmptyTrash allows as much time as an upload since it removes the stored
+// content of every file in the trash.
+func (s *storageClient) EmptyTrash(ctx context.Context, req *storagepb.EmptyTrashRequest) (*storagepb.EmptyTrashResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	return s.client.EmptyTrash(ctx, req)
+}
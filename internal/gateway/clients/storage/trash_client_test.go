@@ -0,0 +1,67 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStorageClientTrashCallsUseTimeoutAndForwardRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		timeout time.Duration
+		req     any
+	}{
+		{
+			name:    "delete file",
+			timeout: 5 * time.Second,
+			req:     &storagepb.DeleteFileRequest{UserId: "user-123", FileId: "file-id"},
+		},
+		{
+			name:    "list trash",
+			timeout: 5 * time.Second,
+			req:     &storagepb.ListTrashRequest{UserId: "user-123", PageSize: 10},
+		},
+		{
+			name:    "restore file",
+			timeout: 5 * time.Second,
+			req:     &storagepb.RestoreFileRequest{UserId: "user-123", FileId: "file-id"},
+		},
+		{
+			name:    "empty trash",
+			timeout: 30 * time.Second,
+			req:     &storagepb.EmptyTrashRequest{UserId: "user-123"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &mockStorageServiceClient{}
+			client := &storageClient{client: mockClient}
+			ctx := context.Background()
+
+			var err error
+			switch req := tt.req.(type) {
+			case *storagepb.DeleteFileRequest:
+				_, err = client.DeleteFile(ctx, req)
+			case *storagepb.ListTrashRequest:
+				_, err = client.ListTrash(ctx, req)
+			case *storagepb.RestoreFileRequest:
+				_, err = client.RestoreFile(ctx, req)
+			case *storagepb.EmptyTrashRequest:
+				_, err = client.EmptyTrash(ctx, req)
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.req, mockClient.lastFolderReq)
+
+			deadline, ok := mockClient.lastCtx.Deadline()
+			assert.True(t, ok, "expected context to have a deadline")
+			remaining := time.Until(deadline)
+			assert.Greater(t, remaining, time.Duration(0))
+			assert.LessOrEqual(t, remaining, tt.timeout)
+		})
+	}
+}
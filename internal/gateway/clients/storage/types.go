@@ -11,6 +11,41 @@ import (
 
 type StorageClient interface {
 	UploadFile(ctx context.Context, req *storagepb.UploadFileRequest) (*storagepb.UploadFileResponse, error)
+	DownloadFile(ctx context.Context, req *storagepb.DownloadFileRequest) (grpc.ServerStreamingClient[storagepb.DownloadFileResponse], error)
+	MoveFile(ctx context.Context, req *storagepb.MoveFileRequest) (*storagepb.MoveFileResponse, error)
+	CreateFolder(ctx context.Context, req *storagepb.CreateFolderRequest) (*storagepb.CreateFolderResponse, error)
+	RenameFolder(ctx context.Context, req *storagepb.RenameFolderRequest) (*storagepb.RenameFolderResponse, error)
+	MoveFolder(ctx context.Context, req *storagepb.MoveFolderRequest) (*storagepb.MoveFolderResponse, error)
+	DeleteFolder(ctx context.Context, req *storagepb.DeleteFolderRequest) (*storagepb.DeleteFolderResponse, error)
+	ListFolder(ctx context.Context, req *storagepb.ListFolderRequest) (*storagepb.ListFolderResponse, error)
+	AddFileTags(ctx context.Context, req *storagepb.AddFileTagsRequest) (*storagepb.AddFileTagsResponse, error)
+	RemoveFileTags(ctx context.Context, req *storagepb.RemoveFileTagsRequest) (*storagepb.RemoveFileTagsResponse, error)
+	SetFileMetadata(ctx context.Context, req *storagepb.SetFileMetadataRequest) (*storagepb.SetFileMetadataResponse, error)
+	RemoveFileMetadata(ctx context.Context, req *storagepb.RemoveFileMetadataRequest) (*storagepb.RemoveFileMetadataResponse, error)
+	ListFiles(ctx context.Context, req *storagepb.ListFilesRequest) (*storagepb.ListFilesResponse, error)
+	DeleteFile(ctx context.Context, req *storagepb.DeleteFileRequest) (*storagepb.DeleteFileResponse, error)
+	ListTrash(ctx context.Context, req *storagepb.ListTrashRequest) (*storagepb.ListTrashResponse, error)
+	RestoreFile(ctx context.Context, req *storagepb.RestoreFileRequest) (*storagepb.RestoreFileResponse, error)
+	EmptyTrash(ctx context.Context, req *storagepb.EmptyTrashRequest) (*storagepb.EmptyTrashResponse, error)
+	Share(ctx context.Context, req *storagepb.ShareRequest) (*storagepb.ShareResponse, error)
+	Unshare(ctx context.Context, req *storagepb.UnshareRequest) (*storagepb.UnshareResponse, error)
+	ListShares(ctx context.Context, req *storagepb.ListSharesRequest) (*storagepb.ListSharesResponse, error)
+	ListSharedWithMe(ctx context.Context, req *storagepb.ListSharedWithMeRequest) (*storagepb.ListSharedWithMeResponse, error)
+	CreateShareLink(ctx context.Context, req *storagepb.CreateShareLinkRequest) (*storagepb.CreateShareLinkResponse, error)
+	ListShareLinks(ctx context.Context, req *storagepb.ListShareLinksRequest) (*storagepb.ListShareLinksResponse, error)
+	RevokeShareLink(ctx context.Context, req *storagepb.RevokeShareLinkRequest) (*storagepb.RevokeShareLinkResponse, error)
+	DownloadSharedFile(ctx context.Context, req *storagepb.DownloadSharedFileRequest) (grpc.ServerStreamingClient[storagepb.DownloadFileResponse], error)
+	SearchDocuments(ctx context.Context, req *storagepb.SearchDocumentsRequest) (*storagepb.SearchDocumentsResponse, error)
+	AnalyzeDocument(ctx context.Context, req *storagepb.AnalyzeDocumentRequest) (*storagepb.AnalyzeDocumentResponse, error)
+	GetThumbnail(ctx context.Context, req *storagepb.GetThumbnailRequest) (*storagepb.GetThumbnailResponse, error)
+	DiffDocuments(ctx context.Context, req *storagepb.DiffDocumentsRequest) (*storagepb.DiffDocumentsResponse, error)
+	RedlineDocuments(ctx context.Context, req *storagepb.RedlineDocumentsRequest) (grpc.ServerStreamingClient[storagepb.DownloadFileResponse], error)
+	MergeDocuments(ctx context.Context, req *storagepb.MergeDocumentsRequest) (*storagepb.MergeDocumentsResponse, error)
+	SplitDocument(ctx context.Context, req *storagepb.SplitDocumentRequest) (*storagepb.SplitDocumentResponse, error)
+	GetProvenance(ctx context.Context, req *storagepb.GetProvenanceRequest) (*storagepb.GetProvenanceResponse, error)
+	StampFile(ctx context.Context, req *storagepb.StampFileRequest) (grpc.ServerStreamingClient[storagepb.DownloadFileResponse], error)
+	CreateBatchJob(ctx context.Context, req *storagepb.CreateBatchJobRequest) (*storagepb.CreateBatchJobResponse, error)
+	GetBatchJob(ctx context.Context, req *storagepb.GetBatchJobRequest) (*storagepb.GetBatchJobResponse, error)
 }
 
 var _ StorageClient = &storageClient{}
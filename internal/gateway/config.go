@@ -5,6 +5,10 @@ type Config struct {
 	AuthService    string
 	StorageService string
 	Logging        LoggingConfig
+
+	// RequireVerifiedUploads refuses uploads from users who have not
+	// verified their email address.
+	RequireVerifiedUploads bool
 }
 
 // LoggingConfig holds structured logging configuration for the gateway.
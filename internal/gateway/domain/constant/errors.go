@@ -6,4 +6,9 @@ var (
 	ErrEmptyEmail         = errors.New("email cannot be empty")
 	ErrEmptyPassword      = errors.New("password cannot be empty")
 	ErrInvalidCredentials = errors.New("invalid email or password")
+	ErrEmptyToken         = errors.New("token cannot be empty")
+	ErrEmptyCode          = errors.New("code cannot be empty")
+	ErrEmptyState         = errors.New("state cannot be empty")
+	ErrEmptyName          = errors.New("name cannot be empty")
+	ErrEmailNotVerified   = errors.New("email address not verified")
 )
@@ -33,3 +33,166 @@ func (r *LoginRequest) Validate() error {
 	}
 	return nil
 }
+
+type VerifyEmailRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+func (r *VerifyEmailRequest) Validate() error {
+	if r.Token == "" {
+		return constant.ErrEmptyToken
+	}
+	return nil
+}
+
+type ResendVerificationRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+func (r *ResendVerificationRequest) Validate() error {
+	if r.Email == "" {
+		return constant.ErrEmptyEmail
+	}
+	return nil
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+func (r *ForgotPasswordRequest) Validate() error {
+	if r.Email == "" {
+		return constant.ErrEmptyEmail
+	}
+	return nil
+}
+
+type ResetPasswordRequest struct {
+	Token       string `json:"token" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required,min=6"`
+}
+
+func (r *ResetPasswordRequest) Validate() error {
+	if r.Token == "" {
+		return constant.ErrEmptyToken
+	}
+	if r.NewPassword == "" {
+		return constant.ErrEmptyPassword
+	}
+	return nil
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required,min=6"`
+}
+
+func (r *ChangePasswordRequest) Validate() error {
+	if r.CurrentPassword == "" || r.NewPassword == "" {
+		return constant.ErrEmptyPassword
+	}
+	return nil
+}
+
+type ChangeEmailRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewEmail        string `json:"new_email" binding:"required,email"`
+}
+
+func (r *ChangeEmailRequest) Validate() error {
+	if r.CurrentPassword == "" {
+		return constant.ErrEmptyPassword
+	}
+	if r.NewEmail == "" {
+		return constant.ErrEmptyEmail
+	}
+	return nil
+}
+
+type ConfirmTOTPRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+func (r *ConfirmTOTPRequest) Validate() error {
+	if r.Code == "" {
+		return constant.ErrEmptyCode
+	}
+	return nil
+}
+
+type VerifyMFARequest struct {
+	MFAToken string `json:"mfa_token" binding:"required"`
+	// Code is a TOTP code or a recovery code.
+	Code string `json:"code" binding:"required"`
+}
+
+func (r *VerifyMFARequest) Validate() error {
+	if r.MFAToken == "" {
+		return constant.ErrEmptyToken
+	}
+	if r.Code == "" {
+		return constant.ErrEmptyCode
+	}
+	return nil
+}
+
+// OIDCCallbackRequest is the query an identity provider redirects users back
+// to the gateway with: an authorization code and the state of the login, or
+// an error when the login failed at the provider.
+type OIDCCallbackRequest struct {
+	Code             string `form:"code"`
+	State            string `form:"state"`
+	Error            string `form:"error"`
+	ErrorDescription string `form:"error_description"`
+}
+
+func (r *OIDCCallbackRequest) Validate() error {
+	if r.Code == "" {
+		return constant.ErrEmptyCode
+	}
+	if r.State == "" {
+		return constant.ErrEmptyState
+	}
+	return nil
+}
+
+// CreateAPIKeyRequest names a new API key and restricts it to scopes. A key
+// without scopes can do anything its user can, except manage credentials.
+type CreateAPIKeyRequest struct {
+	Name   string   `json:"name" binding:"required,max=100"`
+	Scopes []string `json:"scopes" binding:"omitempty,dive,oneof=read upload format"`
+}
+
+func (r *CreateAPIKeyRequest) Validate() error {
+	if r.Name == "" {
+		return constant.ErrEmptyName
+	}
+	return nil
+}
+
+// ListUsersRequest binds the filters of the user listing of administrators.
+type ListUsersRequest struct {
+	// Query matches the users whose email address contains it.
+	Query     string `form:"q"`
+	Role      string `form:"role"`
+	PageSize  int32  `form:"page_size" binding:"omitempty,min=0"`
+	PageToken string `form:"page_token"`
+}
+
+// CreateRoleRequest names a custom role and the permissions it grants.
+type CreateRoleRequest struct {
+	Name        string   `json:"name" binding:"required,max=32"`
+	Description string   `json:"description" binding:"max=200"`
+	Permissions []string `json:"permissions" binding:"omitempty,dive,oneof=users:read users:manage users:impersonate roles:manage"`
+}
+
+func (r *CreateRoleRequest) Validate() error {
+	if r.Name == "" {
+		return constant.ErrEmptyName
+	}
+	return nil
+}
+
+type SetUserRoleRequest struct {
+	Role string `json:"role" binding:"required"`
+}
@@ -82,3 +82,58 @@ func TestLoginRequestValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestVerifyEmailRequestValidate(t *testing.T) {
+	assert.NoError(t, (&VerifyEmailRequest{Token: "token"}).Validate())
+	assert.Equal(t, constant.ErrEmptyToken, (&VerifyEmailRequest{}).Validate())
+}
+
+func TestResendVerificationRequestValidate(t *testing.T) {
+	assert.NoError(t, (&ResendVerificationRequest{Email: "user@example.com"}).Validate())
+	assert.Equal(t, constant.ErrEmptyEmail, (&ResendVerificationRequest{}).Validate())
+}
+
+func TestForgotPasswordRequestValidate(t *testing.T) {
+	assert.NoError(t, (&ForgotPasswordRequest{Email: "user@example.com"}).Validate())
+	assert.Equal(t, constant.ErrEmptyEmail, (&ForgotPasswordRequest{}).Validate())
+}
+
+func TestResetPasswordRequestValidate(t *testing.T) {
+	assert.NoError(t, (&ResetPasswordRequest{Token: "token", NewPassword: "secret1"}).Validate())
+	assert.Equal(t, constant.ErrEmptyToken, (&ResetPasswordRequest{NewPassword: "secret1"}).Validate())
+	assert.Equal(t, constant.ErrEmptyPassword, (&ResetPasswordRequest{Token: "token"}).Validate())
+}
+
+func TestChangePasswordRequestValidate(t *testing.T) {
+	assert.NoError(t, (&ChangePasswordRequest{CurrentPassword: "secret1", NewPassword: "secret2"}).Validate())
+	assert.Equal(t, constant.ErrEmptyPassword, (&ChangePasswordRequest{NewPassword: "secret2"}).Validate())
+	assert.Equal(t, constant.ErrEmptyPassword, (&ChangePasswordRequest{CurrentPassword: "secret1"}).Validate())
+}
+
+func TestChangeEmailRequestValidate(t *testing.T) {
+	assert.NoError(t, (&ChangeEmailRequest{CurrentPassword: "secret1", NewEmail: "user@example.com"}).Validate())
+	assert.Equal(t, constant.ErrEmptyPassword, (&ChangeEmailRequest{NewEmail: "user@example.com"}).Validate())
+	assert.Equal(t, constant.ErrEmptyEmail, (&ChangeEmailRequest{CurrentPassword: "secret1"}).Validate())
+}
+
+func TestConfirmTOTPRequestValidate(t *testing.T) {
+	assert.NoError(t, (&ConfirmTOTPRequest{Code: "123456"}).Validate())
+	assert.Equal(t, constant.ErrEmptyCode, (&ConfirmTOTPRequest{}).Validate())
+}
+
+func TestVerifyMFARequestValidate(t *testing.T) {
+	assert.NoError(t, (&VerifyMFARequest{MFAToken: "token", Code: "123456"}).Validate())
+	assert.Equal(t, constant.ErrEmptyToken, (&VerifyMFARequest{Code: "123456"}).Validate())
+	assert.Equal(t, constant.ErrEmptyCode, (&VerifyMFARequest{MFAToken: "token"}).Validate())
+}
+
+func TestOIDCCallbackRequestValidate(t *testing.T) {
+	assert.NoError(t, (&OIDCCallbackRequest{Code: "code", State: "state"}).Validate())
+	assert.Equal(t, constant.ErrEmptyCode, (&OIDCCallbackRequest{State: "state"}).Validate())
+	assert.Equal(t, constant.ErrEmptyState, (&OIDCCallbackRequest{Code: "code"}).Validate())
+}
+
+func TestCreateAPIKeyRequestValidate(t *testing.T) {
+	assert.NoError(t, (&CreateAPIKeyRequest{Name: "ci", Scopes: []string{"read"}}).Validate())
+	assert.Equal(t, constant.ErrEmptyName, (&CreateAPIKeyRequest{}).Validate())
+}
@@ -1,5 +1,188 @@
 package request
 
+import "time"
+
 type UploadFileRequest struct {
-	UserID string `form:"user_id" binding:"required,uuid4"`
+	FolderID string `form:"folder_id" binding:"omitempty,uuid"`
+}
+
+// FileURI binds the file id of routes such as /storage/files/:id.
+type FileURI struct {
+	FileID string `uri:"id" binding:"required,uuid"`
+}
+
+type MoveFileRequest struct {
+	FolderID string `json:"folder_id" binding:"omitempty,uuid"`
+}
+
+// FolderURI binds the folder id of routes such as /storage/folders/:id.
+type FolderURI struct {
+	FolderID string `uri:"id" binding:"required,uuid"`
+}
+
+type CreateFolderRequest struct {
+	Name     string `json:"name" binding:"required"`
+	ParentID string `json:"parent_id" binding:"omitempty,uuid"`
+}
+
+type RenameFolderRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+type MoveFolderRequest struct {
+	ParentID string `json:"parent_id" binding:"omitempty,uuid"`
+}
+
+type DeleteFolderRequest struct {
+	Recursive bool `form:"recursive"`
+}
+
+type ListFolderRequest struct {
+	FolderID string `form:"folder_id" binding:"omitempty,uuid"`
+}
+
+type AddFileTagsRequest struct {
+	Tags []string `json:"tags" binding:"required,min=1"`
+}
+
+type RemoveFileTagsRequest struct {
+	Tags []string `form:"tag" binding:"required,min=1"`
+}
+
+type SetFileMetadataRequest struct {
+	Metadata map[string]string `json:"metadata" binding:"required,min=1"`
+}
+
+type RemoveFileMetadataRequest struct {
+	Keys []string `form:"key" binding:"required,min=1"`
+}
+
+// ListFilesRequest binds the filters of a file listing. Metadata is bound
+// separately from query parameters such as metadata[owner]=legal.
+type ListFilesRequest struct {
+	FolderID      string            `form:"folder_id" binding:"omitempty,uuid"`
+	Tags          []string          `form:"tag"`
+	Metadata      map[string]string `form:"-"`
+	ContentType   string            `form:"content_type"`
+	MinSize       *int64            `form:"min_size" binding:"omitempty,min=0"`
+	MaxSize       *int64            `form:"max_size" binding:"omitempty,min=0"`
+	CreatedAfter  *time.Time        `form:"created_after" time_format:"2006-01-02T15:04:05Z07:00"`
+	CreatedBefore *time.Time        `form:"created_before" time_format:"2006-01-02T15:04:05Z07:00"`
+	PageSize      int32             `form:"page_size" binding:"omitempty,min=0"`
+	PageToken     string            `form:"page_token"`
+	Sort          string            `form:"sort" binding:"omitempty,oneof=name size created updated"`
+	Order         string            `form:"order" binding:"omitempty,oneof=asc desc"`
+}
+
+type ListTrashRequest struct {
+	PageSize  int32  `form:"page_size" binding:"omitempty,min=0"`
+	PageToken string `form:"page_token"`
+}
+
+type ShareRequest struct {
+	Email string `json:"email" binding:"required,email"`
+	Role  string `json:"role" binding:"required,oneof=viewer commenter editor"`
+}
+
+type UnshareRequest struct {
+	Email string `form:"email" binding:"required,email"`
+}
+
+// CreateShareLinkRequest describes a public link to a file. An empty password,
+// a nil expiry and a zero download limit each leave the link unrestricted.
+type CreateShareLinkRequest struct {
+	Password     string     `json:"password"`
+	ExpiresAt    *time.Time `json:"expires_at"`
+	MaxDownloads int32      `json:"max_downloads" binding:"omitempty,min=0"`
+}
+
+// ShareLinkURI binds the link id of routes such as /storage/links/:id.
+type ShareLinkURI struct {
+	LinkID string `uri:"id" binding:"required,uuid"`
+}
+
+// SharedFileURI binds the token of the public route /s/:token.
+type SharedFileURI struct {
+	Token string `uri:"token" binding:"required"`
+}
+
+// SearchRequest binds a full-text search over the documents the user can
+// read. A zero limit returns the default number of results.
+type SearchRequest struct {
+	Query string `form:"q" binding:"required,max=256"`
+	Limit int32  `form:"limit" binding:"omitempty,min=0,max=100"`
+}
+
+// DiffDocumentsRequest binds a comparison of two files, typically two
+// versions of a document. A nil Context shows the default number of
+// unchanged lines around the changes of the text diff.
+type DiffDocumentsRequest struct {
+	OldFileID string `form:"old_file_id" binding:"required,uuid"`
+	NewFileID string `form:"new_file_id" binding:"required,uuid"`
+	Context   *int32 `form:"context" binding:"omitempty,min=0,max=20"`
+}
+
+type RedlineDocumentsRequest struct {
+	OldFileID string `form:"old_file_id" binding:"required,uuid"`
+	NewFileID string `form:"new_file_id" binding:"required,uuid"`
+}
+
+// MergeDocumentsRequest binds the merge of files of the same format, in the
+// order given, into a new file. An empty FileName is derived from the first
+// source and an empty FolderID puts the file at the top level.
+type MergeDocumentsRequest struct {
+	SourceFileIDs []string `json:"source_file_ids" binding:"required,min=2,max=100,dive,uuid"`
+	FileName      string   `json:"file_name"`
+	FolderID      string   `json:"folder_id" binding:"omitempty,uuid"`
+	PageBreaks    bool     `json:"page_breaks"`
+}
+
+// SplitDocumentRequest binds the split of a file before each of its headings
+// of HeadingLevel or above, 1 being the top level.
+type SplitDocumentRequest struct {
+	HeadingLevel int32  `json:"heading_level" binding:"required,min=1,max=9"`
+	FolderID     string `json:"folder_id" binding:"omitempty,uuid"`
+}
+
+// StampFileRequest binds the stamping of a copy of a DOCX or PDF file. The
+// text may hold the placeholders {name}, {owner}, {user}, {version} and
+// {date}. Image is a PNG or JPEG image, base64-encoded in JSON, and a zero
+// Opacity uses the default.
+type StampFileRequest struct {
+	Watermark string  `json:"watermark" binding:"max=500"`
+	Image     []byte  `json:"image" swaggertype:"string" format:"base64"`
+	Header    string  `json:"header" binding:"max=500"`
+	Footer    string  `json:"footer" binding:"max=500"`
+	Opacity   float64 `json:"opacity" binding:"min=0,max=1"`
+}
+
+// StyleProfileRequest binds a house style applied to DOCX files. Zero values
+// leave the matching formatting as it is. Sizes and margins are in points,
+// line spacing in lines.
+type StyleProfileRequest struct {
+	FontFamily            string  `json:"font_family" binding:"max=100"`
+	FontSize              float64 `json:"font_size" binding:"omitempty,min=1,max=1638"`
+	HeadingFontFamily     string  `json:"heading_font_family" binding:"max=100"`
+	LineSpacing           float64 `json:"line_spacing" binding:"omitempty,min=0.5,max=10"`
+	Margin                float64 `json:"margin" binding:"min=0,max=720"`
+	ClearDirectFormatting bool    `json:"clear_direct_formatting"`
+}
+
+// CreateBatchJobRequest binds a job formatting with one profile the files of
+// a folder, those carrying a tag, or a list of files. Exactly one of
+// FolderID, Tag and FileIDs is set. An empty OutputFolderID puts the
+// formatted files in FolderID, or at the top level for other targets.
+type CreateBatchJobRequest struct {
+	FolderID       string              `json:"folder_id" binding:"omitempty,uuid"`
+	Recursive      bool                `json:"recursive"`
+	Tag            string              `json:"tag"`
+	FileIDs        []string            `json:"file_ids" binding:"max=1000,dive,uuid"`
+	Profile        StyleProfileRequest `json:"profile"`
+	OutputFolderID string              `json:"output_folder_id" binding:"omitempty,uuid"`
+	Archive        bool                `json:"archive"`
+}
+
+// JobURI binds the job id of routes such as /jobs/batch/:id.
+type JobURI struct {
+	JobID string `uri:"id" binding:"required,uuid"`
 }
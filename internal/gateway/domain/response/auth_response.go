@@ -1,10 +1,108 @@
 package response
 
+import "time"
+
 type SignUpResponse struct {
 	UserID string `json:"user_id"`
 }
 
+// LoginResponse carries the access token of a session. Logins of users with
+// two-factor authentication carry no access token but MFAToken, which
+// completes the login with a code.
 type LoginResponse struct {
-	AccessToken string `json:"access_token"`
-	ExpiryUnix  int64  `json:"expiry_unix"`
+	AccessToken string `json:"access_token,omitempty"`
+	ExpiryUnix  int64  `json:"expiry_unix,omitempty"`
+	MFARequired bool   `json:"mfa_required,omitempty"`
+	MFAToken    string `json:"mfa_token,omitempty"`
+}
+
+// StartOIDCLoginResponse is where to send a user to log in with an identity
+// provider, and the token that finishes the login, which the gateway keeps in
+// a cookie.
+type StartOIDCLoginResponse struct {
+	AuthorizationURL string `json:"authorization_url"`
+	LoginToken       string `json:"-"`
+	ExpiryUnix       int64  `json:"-"`
+}
+
+type EnrollTOTPResponse struct {
+	Secret     string `json:"secret"`
+	OtpauthURI string `json:"otpauth_uri"`
+}
+
+// RecoveryCodesResponse lists recovery codes, which are only shown once.
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// APIKeyResponse describes a personal API key. Key is only returned when the
+// key is created; the auth service keeps a hash only.
+type APIKeyResponse struct {
+	KeyID      string     `json:"key_id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Key        string     `json:"key,omitempty"`
+	Scopes     []string   `json:"scopes"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+}
+
+type ListAPIKeysResponse struct {
+	APIKeys []APIKeyResponse `json:"api_keys"`
+}
+
+type UserResponse struct {
+	UserID     string `json:"user_id"`
+	Email      string `json:"email"`
+	IsVerified bool   `json:"is_verified"`
+	// Role and Permissions are only known for users authenticated with an
+	// access token.
+	Role        string   `json:"role,omitempty"`
+	Permissions []string `json:"permissions,omitempty"`
+	// ImpersonatorID is the administrator acting as the user, empty unless
+	// the access token was issued to impersonate them.
+	ImpersonatorID string `json:"impersonator_id,omitempty"`
+}
+
+// AdminUserResponse describes a user to administrators.
+type AdminUserResponse struct {
+	UserID     string `json:"user_id"`
+	Email      string `json:"email"`
+	IsVerified bool   `json:"is_verified"`
+	Role       string `json:"role"`
+	Disabled   bool   `json:"disabled"`
+	MFAEnabled bool   `json:"mfa_enabled"`
+}
+
+// ListUsersResponse holds a page of the users, ordered by email address.
+type ListUsersResponse struct {
+	Users []AdminUserResponse `json:"users"`
+	// NextPageToken is empty on the last page.
+	NextPageToken string `json:"next_page_token,omitempty"`
+}
+
+// RoleResponse describes a role and the permissions it grants. Built-in
+// roles cannot be changed.
+type RoleResponse struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Permissions []string `json:"permissions"`
+	BuiltIn     bool     `json:"built_in"`
+}
+
+type ListRolesResponse struct {
+	Roles []RoleResponse `json:"roles"`
+}
+
+// ValidationErrorResponse is the error returned when fields of a request are
+// invalid, with the rules each of them breaks.
+type ValidationErrorResponse struct {
+	Error      string           `json:"error"`
+	Violations []FieldViolation `json:"violations,omitempty"`
+}
+
+type FieldViolation struct {
+	Field       string `json:"field"`
+	Rule        string `json:"rule"`
+	Description string `json:"description"`
 }
@@ -1,6 +1,310 @@
 package response
 
+import (
+	"io"
+	"time"
+)
+
 type UploadFileResponse struct {
 	FileID   string `json:"file_id"`
 	FileName string `json:"file_name"`
 }
+
+// DownloadFileResponse describes a file being downloaded. Content streams the
+// file from the storage service and is not serialized.
+type DownloadFileResponse struct {
+	FileName string    `json:"file_name"`
+	FileSize int64     `json:"file_size"`
+	Content  io.Reader `json:"-"`
+}
+
+// ThumbnailResponse is a preview image of the first page of a file, served as
+// the image itself.
+type ThumbnailResponse struct {
+	FileID      string
+	ContentType string
+	Image       []byte
+}
+
+type FolderResponse struct {
+	FolderID string `json:"folder_id"`
+	Name     string `json:"name"`
+	ParentID string `json:"parent_id,omitempty"`
+}
+
+type FileInfoResponse struct {
+	FileID      string            `json:"file_id"`
+	FileName    string            `json:"file_name"`
+	FileSize    int64             `json:"file_size"`
+	FolderID    string            `json:"folder_id,omitempty"`
+	ContentType string            `json:"content_type,omitempty"`
+	Tags        []string          `json:"tags,omitempty"`
+	Metadata    map[string]string `json:"metadata,omitempty"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+	// DeletedAt is only set for files in the trash.
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+type DeleteFolderResponse struct {
+	DeletedFolders int32 `json:"deleted_folders"`
+	DeletedFiles   int32 `json:"deleted_files"`
+}
+
+// ListFolderResponse holds the direct children of a folder.
+type ListFolderResponse struct {
+	Folders []FolderResponse   `json:"folders"`
+	Files   []FileInfoResponse `json:"files"`
+}
+
+type ListFilesResponse struct {
+	Files []FileInfoResponse `json:"files"`
+	// NextPageToken is empty on the last page.
+	NextPageToken string `json:"next_page_token,omitempty"`
+}
+
+// ListTrashResponse holds a page of the trash, most recently deleted first.
+type ListTrashResponse struct {
+	Files []FileInfoResponse `json:"files"`
+	// NextPageToken is empty on the last page.
+	NextPageToken string `json:"next_page_token,omitempty"`
+}
+
+type EmptyTrashResponse struct {
+	PurgedFiles int32 `json:"purged_files"`
+}
+
+// ShareResponse describes the role a user was granted on a file or folder.
+type ShareResponse struct {
+	ResourceType string    `json:"resource_type"`
+	ResourceID   string    `json:"resource_id"`
+	OwnerID      string    `json:"owner_id"`
+	UserID       string    `json:"user_id"`
+	Role         string    `json:"role"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type ListSharesResponse struct {
+	Shares []ShareResponse `json:"shares"`
+}
+
+// SharedItemResponse holds a share together with the file or folder it
+// grants access to.
+type SharedItemResponse struct {
+	Share  ShareResponse     `json:"share"`
+	File   *FileInfoResponse `json:"file,omitempty"`
+	Folder *FolderResponse   `json:"folder,omitempty"`
+}
+
+type ListSharedWithMeResponse struct {
+	Items []SharedItemResponse `json:"items"`
+}
+
+// ShareLinkResponse describes a public link to a file. Token and URL are only
+// returned when the link is created; the storage service keeps a hash only.
+type ShareLinkResponse struct {
+	LinkID        string     `json:"link_id"`
+	FileID        string     `json:"file_id"`
+	OwnerID       string     `json:"owner_id"`
+	Token         string     `json:"token,omitempty"`
+	URL           string     `json:"url,omitempty"`
+	HasPassword   bool       `json:"has_password"`
+	ExpiresAt     *time.Time `json:"expires_at,omitempty"`
+	MaxDownloads  int32      `json:"max_downloads"`
+	DownloadCount int32      `json:"download_count"`
+	RevokedAt     *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+type ListShareLinksResponse struct {
+	Links []ShareLinkResponse `json:"links"`
+}
+
+// SearchResultResponse is a document matching a search. Snippet is an excerpt
+// of the document text in which the matched terms are wrapped in <mark> tags.
+type SearchResultResponse struct {
+	File    FileInfoResponse `json:"file"`
+	Rank    float64          `json:"rank"`
+	Snippet string           `json:"snippet"`
+}
+
+// SearchResponse lists the matching documents, best matches first.
+type SearchResponse struct {
+	Results []SearchResultResponse `json:"results"`
+}
+
+type HeadingResponse struct {
+	Level    int32             `json:"level"`
+	Text     string            `json:"text"`
+	Children []HeadingResponse `json:"children,omitempty"`
+}
+
+// DocumentStatisticsResponse counts the text of a document. Pages is an
+// estimate from the words when PagesEstimated is set.
+type DocumentStatisticsResponse struct {
+	Words              int64 `json:"words"`
+	Characters         int64 `json:"characters"`
+	CharactersNoSpaces int64 `json:"characters_no_spaces"`
+	Paragraphs         int64 `json:"paragraphs"`
+	Pages              int64 `json:"pages"`
+	PagesEstimated     bool  `json:"pages_estimated"`
+}
+
+type DocumentImageResponse struct {
+	Name        string `json:"name"`
+	ContentType string `json:"content_type,omitempty"`
+	Size        int64  `json:"size,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+type DocumentTableResponse struct {
+	Rows    int32 `json:"rows"`
+	Columns int32 `json:"columns"`
+}
+
+type DocumentPropertiesResponse struct {
+	Title          string     `json:"title,omitempty"`
+	Subject        string     `json:"subject,omitempty"`
+	Description    string     `json:"description,omitempty"`
+	Author         string     `json:"author,omitempty"`
+	LastModifiedBy string     `json:"last_modified_by,omitempty"`
+	Keywords       []string   `json:"keywords,omitempty"`
+	CreatedAt      *time.Time `json:"created_at,omitempty"`
+	ModifiedAt     *time.Time `json:"modified_at,omitempty"`
+}
+
+// DocumentAnalysisResponse describes the structure of a document. Language
+// is an ISO 639-1 code, absent when it could not be told.
+type DocumentAnalysisResponse struct {
+	FileID     string                     `json:"file_id"`
+	Outline    []HeadingResponse          `json:"outline"`
+	Statistics DocumentStatisticsResponse `json:"statistics"`
+	Images     []DocumentImageResponse    `json:"images"`
+	Tables     []DocumentTableResponse    `json:"tables"`
+	Language   string                     `json:"language,omitempty"`
+	Fonts      []string                   `json:"fonts"`
+	Properties DocumentPropertiesResponse `json:"properties"`
+	AnalyzedAt time.Time                  `json:"analyzed_at"`
+}
+
+// DiffBlockResponse is a paragraph, heading, list item, quote, code block or
+// table of a compared document. Rows holds the cells of tables.
+type DiffBlockResponse struct {
+	Index int32      `json:"index"`
+	Kind  string     `json:"kind"`
+	Level int32      `json:"level,omitempty"`
+	Style string     `json:"style,omitempty"`
+	Text  string     `json:"text"`
+	Rows  [][]string `json:"rows,omitempty"`
+}
+
+// StructuralChangeResponse is an inserted, deleted or modified block. Fields
+// lists what a modification changed: text, kind, level or style.
+type StructuralChangeResponse struct {
+	Op     string             `json:"op"`
+	Old    *DiffBlockResponse `json:"old,omitempty"`
+	New    *DiffBlockResponse `json:"new,omitempty"`
+	Fields []string           `json:"fields,omitempty"`
+}
+
+type TextLineResponse struct {
+	Op   string `json:"op"`
+	Text string `json:"text"`
+}
+
+// TextHunkResponse is a hunk of the line diff of the texts, with 1-based line
+// numbers.
+type TextHunkResponse struct {
+	OldStart int32              `json:"old_start"`
+	OldLines int32              `json:"old_lines"`
+	NewStart int32              `json:"new_start"`
+	NewLines int32              `json:"new_lines"`
+	Lines    []TextLineResponse `json:"lines"`
+}
+
+type DiffSummaryResponse struct {
+	Inserted     int32 `json:"inserted"`
+	Deleted      int32 `json:"deleted"`
+	Modified     int32 `json:"modified"`
+	LinesAdded   int32 `json:"lines_added"`
+	LinesRemoved int32 `json:"lines_removed"`
+}
+
+// DocumentDiffResponse holds the structural changes between two files and
+// the hunks of the diff of their texts.
+type DocumentDiffResponse struct {
+	OldFileID string                     `json:"old_file_id"`
+	NewFileID string                     `json:"new_file_id"`
+	Changes   []StructuralChangeResponse `json:"changes"`
+	Hunks     []TextHunkResponse         `json:"hunks"`
+	Summary   DiffSummaryResponse        `json:"summary"`
+}
+
+type SplitDocumentResponse struct {
+	Files []FileInfoResponse `json:"files"`
+}
+
+// ProvenanceLinkResponse is a file a file was made from, or made from it, by
+// the operation merge, split or format. Position is the place of the source among those merged, or of the part
+// among those of the split, from 0.
+type ProvenanceLinkResponse struct {
+	File      FileInfoResponse `json:"file"`
+	Operation string           `json:"operation"`
+	Position  int32            `json:"position"`
+	CreatedAt time.Time        `json:"created_at"`
+}
+
+// ProvenanceResponse lists the files a file was made from and those made
+// from it, among the files the user can view.
+type ProvenanceResponse struct {
+	Sources []ProvenanceLinkResponse `json:"sources"`
+	Derived []ProvenanceLinkResponse `json:"derived"`
+}
+
+type StyleProfileResponse struct {
+	FontFamily            string  `json:"font_family,omitempty"`
+	FontSize              float64 `json:"font_size,omitempty"`
+	HeadingFontFamily     string  `json:"heading_font_family,omitempty"`
+	LineSpacing           float64 `json:"line_spacing,omitempty"`
+	Margin                float64 `json:"margin,omitempty"`
+	ClearDirectFormatting bool    `json:"clear_direct_formatting,omitempty"`
+}
+
+// BatchProgressResponse counts the files of a batch job by status.
+type BatchProgressResponse struct {
+	Total     int32 `json:"total"`
+	Pending   int32 `json:"pending"`
+	Running   int32 `json:"running"`
+	Succeeded int32 `json:"succeeded"`
+	Failed    int32 `json:"failed"`
+}
+
+// BatchJobItemResponse is a file of a batch job, with status pending,
+// running, succeeded or failed. OutputFileID is the formatted file, and
+// Warnings tell the parts of the file the profile could not reach.
+type BatchJobItemResponse struct {
+	FileID       string   `json:"file_id"`
+	FileName     string   `json:"file_name"`
+	Status       string   `json:"status"`
+	OutputFileID string   `json:"output_file_id,omitempty"`
+	Error        string   `json:"error,omitempty"`
+	Warnings     []string `json:"warnings,omitempty"`
+}
+
+// BatchJobResponse is a batch job, with status running or completed, and the
+// report of its files. Error tells why a completed job has no archive when
+// one was asked for.
+type BatchJobResponse struct {
+	JobID          string                 `json:"job_id"`
+	Status         string                 `json:"status"`
+	Profile        StyleProfileResponse   `json:"profile"`
+	OutputFolderID string                 `json:"output_folder_id,omitempty"`
+	Archive        bool                   `json:"archive"`
+	Progress       BatchProgressResponse  `json:"progress"`
+	Items          []BatchJobItemResponse `json:"items"`
+	ArchiveFileID  string                 `json:"archive_file_id,omitempty"`
+	Error          string                 `json:"error,omitempty"`
+	CreatedAt      time.Time              `json:"created_at"`
+	// FinishedAt is only set for completed jobs.
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
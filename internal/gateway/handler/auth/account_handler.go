@@ -0,0 +1,105 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/a1y/doc-formatter/internal/gateway/domain/request"
+	"github.com/a1y/doc-formatter/internal/gateway/middleware"
+	"github.com/a1y/doc-formatter/internal/gateway/util/grpcstatus"
+	"github.com/gin-gonic/gin"
+)
+
+// Me godoc
+//
+//	@Summary		Current user
+//	@Description	Get the user the access token or API key was issued to
+//	@Tags			Auth
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+//	@Success		200	{object}	response.UserResponse
+//	@Failure		401	{object}	map[string]string
+//	@Router			/api/v1/auth/me [get]
+func (h *AuthHandler) Me(c *gin.Context) {
+	user, ok := middleware.AuthUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "not authenticated"})
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// ChangePassword godoc
+//
+//	@Summary		Change password
+//	@Description	Change the password of the current user. Every other session of the user is signed out, and a new access token is returned for this one.
+//	@Tags			Auth
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			body	body		request.ChangePasswordRequest	true	"Password change payload"
+//	@Success		200		{object}	response.LoginResponse
+//	@Failure		400		{object}	response.ValidationErrorResponse
+//	@Failure		401		{object}	map[string]string
+//	@Failure		403		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/api/v1/auth/me/password [put]
+func (h *AuthHandler) ChangePassword(c *gin.Context) {
+	user, ok := middleware.AuthUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "not authenticated"})
+		return
+	}
+
+	var req request.ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.authManager.ChangePassword(c.Request.Context(), user.UserID, req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// ChangeEmail godoc
+//
+//	@Summary		Change email
+//	@Description	Ask to change the email address of the current user. A verification token is sent to the new address, which replaces the current one once it is verified with /api/v1/auth/verify.
+//	@Tags			Auth
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			body	body	request.ChangeEmailRequest	true	"Email change payload"
+//	@Success		202
+//	@Failure		400	{object}	map[string]string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		409	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/api/v1/auth/me/email [put]
+func (h *AuthHandler) ChangeEmail(c *gin.Context) {
+	user, ok := middleware.AuthUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "not authenticated"})
+		return
+	}
+
+	var req request.ChangeEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.authManager.ChangeEmail(c.Request.Context(), user.UserID, req); err != nil {
+		c.JSON(grpcstatus.HTTPStatus(err), gin.H{"error": grpcstatus.Message(err)})
+		return
+	}
+
+	c.Status(http.StatusAccepted)
+}
@@ -0,0 +1,151 @@
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/a1y/doc-formatter/internal/gateway/domain/request"
+	"github.com/a1y/doc-formatter/internal/gateway/domain/response"
+	"github.com/a1y/doc-formatter/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var testUser = &response.UserResponse{UserID: "123", Email: "test@example.com", IsVerified: true}
+
+func TestAuthHandler_Me(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		r, mockClient := setupRouter()
+		mockClient.On("ValidateToken", mock.Anything, "token").Return(testUser, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/api/auth/me", nil)
+		req.Header.Set("Authorization", "Bearer token")
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var resp response.UserResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, *testUser, resp)
+	})
+
+	t.Run("Unauthenticated", func(t *testing.T) {
+		r, mockClient := setupRouter()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/api/auth/me", nil)
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		mockClient.AssertNotCalled(t, "ValidateToken", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAuthHandler_ChangePassword(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		r, mockClient := setupRouter()
+		mockClient.On("ValidateToken", mock.Anything, "token").Return(testUser, nil)
+		mockClient.On("ChangePassword", mock.Anything, "123", "old-password", "new-password").
+			Return(&response.LoginResponse{AccessToken: "new-token", ExpiryUnix: 42}, nil)
+
+		w := httptest.NewRecorder()
+		req := testutil.NewJSONRequest(t, http.MethodPut, "/api/auth/me/password", request.ChangePasswordRequest{CurrentPassword: "old-password", NewPassword: "new-password"})
+		req.Header.Set("Authorization", "Bearer token")
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var resp response.LoginResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, response.LoginResponse{AccessToken: "new-token", ExpiryUnix: 42}, resp)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("IncorrectPassword", func(t *testing.T) {
+		r, mockClient := setupRouter()
+		mockClient.On("ValidateToken", mock.Anything, "token").Return(testUser, nil)
+		mockClient.On("ChangePassword", mock.Anything, "123", "wrong-password", "new-password").
+			Return(nil, status.Error(codes.PermissionDenied, "current password is incorrect"))
+
+		w := httptest.NewRecorder()
+		req := testutil.NewJSONRequest(t, http.MethodPut, "/api/auth/me/password", request.ChangePasswordRequest{CurrentPassword: "wrong-password", NewPassword: "new-password"})
+		req.Header.Set("Authorization", "Bearer token")
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.JSONEq(t, `{"error":"current password is incorrect"}`, w.Body.String())
+	})
+
+	t.Run("ShortPassword", func(t *testing.T) {
+		r, mockClient := setupRouter()
+		mockClient.On("ValidateToken", mock.Anything, "token").Return(testUser, nil)
+
+		w := httptest.NewRecorder()
+		req := testutil.NewJSONRequest(t, http.MethodPut, "/api/auth/me/password", request.ChangePasswordRequest{CurrentPassword: "old-password", NewPassword: "abc"})
+		req.Header.Set("Authorization", "Bearer token")
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Impersonated", func(t *testing.T) {
+		r, mockClient := setupRouter()
+		impersonated := &response.UserResponse{UserID: "123", Email: "test@example.com", IsVerified: true, ImpersonatorID: "admin-1"}
+		mockClient.On("ValidateToken", mock.Anything, "token").Return(impersonated, nil)
+
+		w := httptest.NewRecorder()
+		req := testutil.NewJSONRequest(t, http.MethodPut, "/api/auth/me/password", request.ChangePasswordRequest{CurrentPassword: "old-password", NewPassword: "new-password"})
+		req.Header.Set("Authorization", "Bearer token")
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		mockClient.AssertNotCalled(t, "ChangePassword", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestAuthHandler_ChangeEmail(t *testing.T) {
+	t.Run("Accepted", func(t *testing.T) {
+		r, mockClient := setupRouter()
+		mockClient.On("ValidateToken", mock.Anything, "token").Return(testUser, nil)
+		mockClient.On("ChangeEmail", mock.Anything, "123", "password", "new@example.com").Return(nil)
+
+		w := httptest.NewRecorder()
+		req := testutil.NewJSONRequest(t, http.MethodPut, "/api/auth/me/email", request.ChangeEmailRequest{CurrentPassword: "password", NewEmail: "new@example.com"})
+		req.Header.Set("Authorization", "Bearer token")
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("Taken", func(t *testing.T) {
+		r, mockClient := setupRouter()
+		mockClient.On("ValidateToken", mock.Anything, "token").Return(testUser, nil)
+		mockClient.On("ChangeEmail", mock.Anything, "123", "password", "taken@example.com").
+			Return(status.Error(codes.AlreadyExists, "email already exists"))
+
+		w := httptest.NewRecorder()
+		req := testutil.NewJSONRequest(t, http.MethodPut, "/api/auth/me/email", request.ChangeEmailRequest{CurrentPassword: "password", NewEmail: "taken@example.com"})
+		req.Header.Set("Authorization", "Bearer token")
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("ExpiredToken", func(t *testing.T) {
+		r, mockClient := setupRouter()
+		mockClient.On("ValidateToken", mock.Anything, "expired").
+			Return(nil, status.Error(codes.Unauthenticated, "invalid or expired access token"))
+
+		w := httptest.NewRecorder()
+		req := testutil.NewJSONRequest(t, http.MethodPut, "/api/auth/me/email", request.ChangeEmailRequest{CurrentPassword: "password", NewEmail: "new@example.com"})
+		req.Header.Set("Authorization", "Bearer expired")
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		mockClient.AssertNotCalled(t, "ChangeEmail", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,305 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/a1y/doc-formatter/internal/gateway/domain/request"
+	"github.com/a1y/doc-formatter/internal/gateway/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// ListUsers godoc
+//
+//	@Summary		List users
+//	@Description	List the users, ordered by email address, a page at a time. Needs the users:read permission.
+//	@Tags			Admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			q			query		string	false	"Only users whose email address contains it"
+//	@Param			role		query		string	false	"Only users with the role"
+//	@Param			page_size	query		int		false	"Maximum number of users, 50 by default and at most 200"
+//	@Param			page_token	query		string	false	"Next page token of the previous page"
+//	@Success		200			{object}	response.ListUsersResponse
+//	@Failure		400			{object}	map[string]string
+//	@Failure		401			{object}	map[string]string
+//	@Failure		403			{object}	map[string]string
+//	@Failure		500			{object}	map[string]string
+//	@Router			/api/v1/admin/users [get]
+func (h *AuthHandler) ListUsers(c *gin.Context) {
+	admin, ok := middleware.AuthUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "not authenticated"})
+		return
+	}
+
+	var req request.ListUsersRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.authManager.ListUsers(c.Request.Context(), admin.UserID, req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// DisableUser godoc
+//
+//	@Summary		Disable user
+//	@Description	Disable a user, who can no longer log in, and whose sessions and API keys stop working at once. Administrators cannot disable themselves. Needs the users:manage permission.
+//	@Tags			Admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string	true	"User ID"
+//	@Success		200	{object}	response.AdminUserResponse
+//	@Failure		400	{object}	map[string]string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		412	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/api/v1/admin/users/{id}/disable [post]
+func (h *AuthHandler) DisableUser(c *gin.Context) {
+	h.setUserDisabled(c, true)
+}
+
+// EnableUser godoc
+//
+//	@Summary		Enable user
+//	@Description	Enable a disabled user, who can log in again. Needs the users:manage permission.
+//	@Tags			Admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string	true	"User ID"
+//	@Success		200	{object}	response.AdminUserResponse
+//	@Failure		400	{object}	map[string]string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		412	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/api/v1/admin/users/{id}/enable [post]
+func (h *AuthHandler) EnableUser(c *gin.Context) {
+	h.setUserDisabled(c, false)
+}
+
+func (h *AuthHandler) setUserDisabled(c *gin.Context, disabled bool) {
+	admin, ok := middleware.AuthUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "not authenticated"})
+		return
+	}
+
+	resp, err := h.authManager.SetUserDisabled(c.Request.Context(), admin.UserID, c.Param("id"), disabled)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// VerifyUser godoc
+//
+//	@Summary		Verify user
+//	@Description	Mark the email address of a user verified, for users who cannot receive the verification email. Needs the users:manage permission.
+//	@Tags			Admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string	true	"User ID"
+//	@Success		200	{object}	response.AdminUserResponse
+//	@Failure		400	{object}	map[string]string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/api/v1/admin/users/{id}/verify [post]
+func (h *AuthHandler) VerifyUser(c *gin.Context) {
+	admin, ok := middleware.AuthUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "not authenticated"})
+		return
+	}
+
+	resp, err := h.authManager.VerifyUser(c.Request.Context(), admin.UserID, c.Param("id"))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// ResetUserMFA godoc
+//
+//	@Summary		Reset two-factor authentication
+//	@Description	Turn off the two-factor authentication of a user who lost their authenticator and recovery codes. They can enroll again after logging in with their password. Needs the users:manage permission.
+//	@Tags			Admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string	true	"User ID"
+//	@Success		200	{object}	response.AdminUserResponse
+//	@Failure		400	{object}	map[string]string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		412	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/api/v1/admin/users/{id}/mfa [delete]
+func (h *AuthHandler) ResetUserMFA(c *gin.Context) {
+	admin, ok := middleware.AuthUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "not authenticated"})
+		return
+	}
+
+	resp, err := h.authManager.ResetUserMFA(c.Request.Context(), admin.UserID, c.Param("id"))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// ImpersonateUser godoc
+//
+//	@Summary		Impersonate user
+//	@Description	Issue an access token to act as a user while supporting them. The token names the administrator, see impersonator_id of /api/v1/auth/me, and stops working once they can no longer impersonate. It cannot change the credentials of the user. Users whose role grants permissions cannot be impersonated. Needs the users:impersonate permission.
+//	@Tags			Admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string	true	"User ID"
+//	@Success		200	{object}	response.LoginResponse
+//	@Failure		400	{object}	map[string]string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		412	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/api/v1/admin/users/{id}/impersonate [post]
+func (h *AuthHandler) ImpersonateUser(c *gin.Context) {
+	admin, ok := middleware.AuthUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "not authenticated"})
+		return
+	}
+
+	resp, err := h.authManager.ImpersonateUser(c.Request.Context(), admin.UserID, c.Param("id"))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// SetUserRole godoc
+//
+//	@Summary		Set user role
+//	@Description	Give a user a role. Their sessions issued for their previous role stop working. Administrators cannot change their own role. Needs the roles:manage permission.
+//	@Tags			Admin
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string						true	"User ID"
+//	@Param			body	body		request.SetUserRoleRequest	true	"Role payload"
+//	@Success		200		{object}	response.AdminUserResponse
+//	@Failure		400		{object}	map[string]string
+//	@Failure		401		{object}	map[string]string
+//	@Failure		403		{object}	map[string]string
+//	@Failure		404		{object}	map[string]string
+//	@Failure		412		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/api/v1/admin/users/{id}/role [put]
+func (h *AuthHandler) SetUserRole(c *gin.Context) {
+	admin, ok := middleware.AuthUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "not authenticated"})
+		return
+	}
+
+	var req request.SetUserRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.authManager.SetUserRole(c.Request.Context(), admin.UserID, c.Param("id"), req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// ListRoles godoc
+//
+//	@Summary		List roles
+//	@Description	List the built-in roles, user and admin, followed by the custom ones. Needs the users:read permission.
+//	@Tags			Admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	response.ListRolesResponse
+//	@Failure		401	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/api/v1/admin/roles [get]
+func (h *AuthHandler) ListRoles(c *gin.Context) {
+	admin, ok := middleware.AuthUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "not authenticated"})
+		return
+	}
+
+	resp, err := h.authManager.ListRoles(c.Request.Context(), admin.UserID)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// CreateRole godoc
+//
+//	@Summary		Create role
+//	@Description	Create a custom role granting a set of permissions: users:read, users:manage, users:impersonate and roles:manage. Needs the roles:manage permission.
+//	@Tags			Admin
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			body	body		request.CreateRoleRequest	true	"Role payload"
+//	@Success		201		{object}	response.RoleResponse
+//	@Failure		400		{object}	map[string]string
+//	@Failure		401		{object}	map[string]string
+//	@Failure		403		{object}	map[string]string
+//	@Failure		409		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/api/v1/admin/roles [post]
+func (h *AuthHandler) CreateRole(c *gin.Context) {
+	admin, ok := middleware.AuthUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "not authenticated"})
+		return
+	}
+
+	var req request.CreateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.authManager.CreateRole(c.Request.Context(), admin.UserID, req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
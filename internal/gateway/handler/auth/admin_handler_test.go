@@ -0,0 +1,258 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/a1y/doc-formatter/internal/gateway/domain/request"
+	"github.com/a1y/doc-formatter/internal/gateway/domain/response"
+	"github.com/a1y/doc-formatter/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var testAdmin = &response.UserResponse{
+	UserID:      "admin-1",
+	Email:       "admin@example.com",
+	IsVerified:  true,
+	Role:        "admin",
+	Permissions: []string{"roles:manage", "users:impersonate", "users:manage", "users:read"},
+}
+
+func TestAuthHandler_ListUsers(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		r, mockClient := setupRouter()
+		mockClient.On("ValidateToken", mock.Anything, "token").Return(testAdmin, nil)
+		mockClient.On("ListUsers", mock.Anything, "admin-1", "example", "user", int32(10), "next").
+			Return(&response.ListUsersResponse{
+				Users:         []response.AdminUserResponse{{UserID: "123", Email: "test@example.com", IsVerified: true, Role: "user"}},
+				NextPageToken: "after",
+			}, nil)
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/api/admin/users?q=example&role=user&page_size=10&page_token=next", nil)
+		req.Header.Set("Authorization", "Bearer token")
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"users":[{"user_id":"123","email":"test@example.com","is_verified":true,"role":"user","disabled":false,"mfa_enabled":false}],"next_page_token":"after"}`, w.Body.String())
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("NotAdmin", func(t *testing.T) {
+		r, mockClient := setupRouter()
+		mockClient.On("ValidateToken", mock.Anything, "token").Return(testUser, nil)
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/api/admin/users", nil)
+		req.Header.Set("Authorization", "Bearer token")
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		mockClient.AssertNotCalled(t, "ListUsers", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("WithAPIKey", func(t *testing.T) {
+		r, mockClient := setupRouter()
+		mockClient.On("ValidateAPIKey", mock.Anything, "dfk_0123456789ab_SECRET").Return(testAdmin, []string{}, nil)
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/api/admin/users", nil)
+		req.Header.Set("Authorization", "ApiKey dfk_0123456789ab_SECRET")
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code, "keys cannot administer users")
+	})
+}
+
+func TestAuthHandler_DisableUser(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		r, mockClient := setupRouter()
+		mockClient.On("ValidateToken", mock.Anything, "token").Return(testAdmin, nil)
+		mockClient.On("SetUserDisabled", mock.Anything, "admin-1", "123", true).
+			Return(&response.AdminUserResponse{UserID: "123", Email: "test@example.com", Role: "user", Disabled: true}, nil)
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/api/admin/users/123/disable", nil)
+		req.Header.Set("Authorization", "Bearer token")
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"disabled":true`)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("Self", func(t *testing.T) {
+		r, mockClient := setupRouter()
+		mockClient.On("ValidateToken", mock.Anything, "token").Return(testAdmin, nil)
+		mockClient.On("SetUserDisabled", mock.Anything, "admin-1", "admin-1", true).
+			Return(nil, status.Error(codes.FailedPrecondition, "administrators cannot do this to their own account"))
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/api/admin/users/admin-1/disable", nil)
+		req.Header.Set("Authorization", "Bearer token")
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+	})
+}
+
+func TestAuthHandler_EnableUser(t *testing.T) {
+	r, mockClient := setupRouter()
+	mockClient.On("ValidateToken", mock.Anything, "token").Return(testAdmin, nil)
+	mockClient.On("SetUserDisabled", mock.Anything, "admin-1", "123", false).
+		Return(&response.AdminUserResponse{UserID: "123", Role: "user"}, nil)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/users/123/enable", nil)
+	req.Header.Set("Authorization", "Bearer token")
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockClient.AssertExpectations(t)
+}
+
+func TestAuthHandler_VerifyUser(t *testing.T) {
+	r, mockClient := setupRouter()
+	mockClient.On("ValidateToken", mock.Anything, "token").Return(testAdmin, nil)
+	mockClient.On("VerifyUser", mock.Anything, "admin-1", "123").
+		Return(nil, status.Error(codes.NotFound, "user not found"))
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/users/123/verify", nil)
+	req.Header.Set("Authorization", "Bearer token")
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockClient.AssertExpectations(t)
+}
+
+func TestAuthHandler_ResetUserMFA(t *testing.T) {
+	r, mockClient := setupRouter()
+	mockClient.On("ValidateToken", mock.Anything, "token").Return(testAdmin, nil)
+	mockClient.On("ResetUserMFA", mock.Anything, "admin-1", "123").
+		Return(&response.AdminUserResponse{UserID: "123", Role: "user"}, nil)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodDelete, "/api/admin/users/123/mfa", nil)
+	req.Header.Set("Authorization", "Bearer token")
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"mfa_enabled":false`)
+	mockClient.AssertExpectations(t)
+}
+
+func TestAuthHandler_ImpersonateUser(t *testing.T) {
+	r, mockClient := setupRouter()
+	mockClient.On("ValidateToken", mock.Anything, "token").Return(testAdmin, nil)
+	mockClient.On("ImpersonateUser", mock.Anything, "admin-1", "123").
+		Return(&response.LoginResponse{AccessToken: "impersonation-token", ExpiryUnix: 42}, nil)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/users/123/impersonate", nil)
+	req.Header.Set("Authorization", "Bearer token")
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"access_token":"impersonation-token","expiry_unix":42}`, w.Body.String())
+	mockClient.AssertExpectations(t)
+}
+
+func TestAuthHandler_SetUserRole(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		r, mockClient := setupRouter()
+		mockClient.On("ValidateToken", mock.Anything, "token").Return(testAdmin, nil)
+		mockClient.On("SetUserRole", mock.Anything, "admin-1", "123", "support").
+			Return(&response.AdminUserResponse{UserID: "123", Role: "support"}, nil)
+
+		w := httptest.NewRecorder()
+		req := testutil.NewJSONRequest(t, http.MethodPut, "/api/admin/users/123/role", request.SetUserRoleRequest{Role: "support"})
+		req.Header.Set("Authorization", "Bearer token")
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"role":"support"`)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("MissingRole", func(t *testing.T) {
+		r, mockClient := setupRouter()
+		mockClient.On("ValidateToken", mock.Anything, "token").Return(testAdmin, nil)
+
+		w := httptest.NewRecorder()
+		req := testutil.NewJSONRequest(t, http.MethodPut, "/api/admin/users/123/role", request.SetUserRoleRequest{})
+		req.Header.Set("Authorization", "Bearer token")
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockClient.AssertNotCalled(t, "SetUserRole", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestAuthHandler_ListRoles(t *testing.T) {
+	r, mockClient := setupRouter()
+	mockClient.On("ValidateToken", mock.Anything, "token").Return(testAdmin, nil)
+	mockClient.On("ListRoles", mock.Anything, "admin-1").
+		Return(&response.ListRolesResponse{Roles: []response.RoleResponse{{Name: "user", Permissions: []string{}, BuiltIn: true}}}, nil)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/roles", nil)
+	req.Header.Set("Authorization", "Bearer token")
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"roles":[{"name":"user","permissions":[],"built_in":true}]}`, w.Body.String())
+	mockClient.AssertExpectations(t)
+}
+
+func TestAuthHandler_CreateRole(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		r, mockClient := setupRouter()
+		mockClient.On("ValidateToken", mock.Anything, "token").Return(testAdmin, nil)
+		mockClient.On("CreateRole", mock.Anything, "admin-1", "support", "Support staff", []string{"users:read", "users:impersonate"}).
+			Return(&response.RoleResponse{Name: "support", Description: "Support staff", Permissions: []string{"users:impersonate", "users:read"}}, nil)
+
+		w := httptest.NewRecorder()
+		req := testutil.NewJSONRequest(t, http.MethodPost, "/api/admin/roles", request.CreateRoleRequest{
+			Name:        "support",
+			Description: "Support staff",
+			Permissions: []string{"users:read", "users:impersonate"},
+		})
+		req.Header.Set("Authorization", "Bearer token")
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("UnknownPermission", func(t *testing.T) {
+		r, mockClient := setupRouter()
+		mockClient.On("ValidateToken", mock.Anything, "token").Return(testAdmin, nil)
+
+		w := httptest.NewRecorder()
+		req := testutil.NewJSONRequest(t, http.MethodPost, "/api/admin/roles", request.CreateRoleRequest{Name: "support", Permissions: []string{"users:delete"}})
+		req.Header.Set("Authorization", "Bearer token")
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockClient.AssertNotCalled(t, "CreateRole", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Exists", func(t *testing.T) {
+		r, mockClient := setupRouter()
+		mockClient.On("ValidateToken", mock.Anything, "token").Return(testAdmin, nil)
+		mockClient.On("CreateRole", mock.Anything, "admin-1", "admin", "", []string(nil)).
+			Return(nil, status.Error(codes.AlreadyExists, "role already exists"))
+
+		w := httptest.NewRecorder()
+		req := testutil.NewJSONRequest(t, http.MethodPost, "/api/admin/roles", request.CreateRoleRequest{Name: "admin"})
+		req.Header.Set("Authorization", "Bearer token")
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+}
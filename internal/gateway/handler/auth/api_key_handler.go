@@ -0,0 +1,103 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/a1y/doc-formatter/internal/gateway/domain/request"
+	"github.com/a1y/doc-formatter/internal/gateway/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// CreateAPIKey godoc
+//
+//	@Summary		Create API key
+//	@Description	Create a personal API key for scripts, sent as "Authorization: ApiKey <key>". The key is only returned here. Keys can be restricted to scopes: read to download and list, upload to upload, format to run formatting jobs and merge, split or stamp documents. Keys without scopes can do anything their user can, except manage credentials.
+//	@Tags			Auth
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			body	body		request.CreateAPIKeyRequest	true	"API key payload"
+//	@Success		201		{object}	response.APIKeyResponse
+//	@Failure		400		{object}	map[string]string
+//	@Failure		401		{object}	map[string]string
+//	@Failure		403		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/api/v1/auth/me/api-keys [post]
+func (h *AuthHandler) CreateAPIKey(c *gin.Context) {
+	user, ok := middleware.AuthUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "not authenticated"})
+		return
+	}
+
+	var req request.CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.authManager.CreateAPIKey(c.Request.Context(), user.UserID, req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+// ListAPIKeys godoc
+//
+//	@Summary		List API keys
+//	@Description	List the API keys of the current user that are not revoked, newest first
+//	@Tags			Auth
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	response.ListAPIKeysResponse
+//	@Failure		401	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/api/v1/auth/me/api-keys [get]
+func (h *AuthHandler) ListAPIKeys(c *gin.Context) {
+	user, ok := middleware.AuthUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "not authenticated"})
+		return
+	}
+
+	resp, err := h.authManager.ListAPIKeys(c.Request.Context(), user.UserID)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// RevokeAPIKey godoc
+//
+//	@Summary		Revoke API key
+//	@Description	Revoke an API key of the current user, which stops working at once
+//	@Tags			Auth
+//	@Security		BearerAuth
+//	@Param			id	path	string	true	"API key ID"
+//	@Success		204
+//	@Failure		400	{object}	map[string]string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/api/v1/auth/me/api-keys/{id} [delete]
+func (h *AuthHandler) RevokeAPIKey(c *gin.Context) {
+	user, ok := middleware.AuthUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "not authenticated"})
+		return
+	}
+
+	if err := h.authManager.RevokeAPIKey(c.Request.Context(), user.UserID, c.Param("id"), user.ImpersonatorID); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,136 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/a1y/doc-formatter/internal/gateway/domain/request"
+	"github.com/a1y/doc-formatter/internal/gateway/domain/response"
+	"github.com/a1y/doc-formatter/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestAuthHandler_CreateAPIKey(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		r, mockClient := setupRouter()
+		mockClient.On("ValidateToken", mock.Anything, "token").Return(testUser, nil)
+		mockClient.On("CreateAPIKey", mock.Anything, "123", "ci", []string{"read", "upload"}).
+			Return(&response.APIKeyResponse{
+				KeyID:     "key-1",
+				Name:      "ci",
+				Prefix:    "dfk_0123456789ab",
+				Key:       "dfk_0123456789ab_SECRET",
+				Scopes:    []string{"read", "upload"},
+				CreatedAt: time.Date(2026, 10, 26, 9, 0, 0, 0, time.UTC),
+			}, nil)
+
+		w := httptest.NewRecorder()
+		req := testutil.NewJSONRequest(t, http.MethodPost, "/api/auth/me/api-keys", request.CreateAPIKeyRequest{Name: "ci", Scopes: []string{"read", "upload"}})
+		req.Header.Set("Authorization", "Bearer token")
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.JSONEq(t, `{"key_id":"key-1","name":"ci","prefix":"dfk_0123456789ab","key":"dfk_0123456789ab_SECRET","scopes":["read","upload"],"created_at":"2026-10-26T09:00:00Z"}`, w.Body.String())
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("UnknownScope", func(t *testing.T) {
+		r, mockClient := setupRouter()
+		mockClient.On("ValidateToken", mock.Anything, "token").Return(testUser, nil)
+
+		w := httptest.NewRecorder()
+		req := testutil.NewJSONRequest(t, http.MethodPost, "/api/auth/me/api-keys", request.CreateAPIKeyRequest{Name: "ci", Scopes: []string{"admin"}})
+		req.Header.Set("Authorization", "Bearer token")
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockClient.AssertNotCalled(t, "CreateAPIKey", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("WithAPIKey", func(t *testing.T) {
+		r, mockClient := setupRouter()
+		mockClient.On("ValidateAPIKey", mock.Anything, "dfk_0123456789ab_SECRET").Return(testUser, []string{}, nil)
+
+		w := httptest.NewRecorder()
+		req := testutil.NewJSONRequest(t, http.MethodPost, "/api/auth/me/api-keys", request.CreateAPIKeyRequest{Name: "ci"})
+		req.Header.Set("Authorization", "ApiKey dfk_0123456789ab_SECRET")
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code, "keys cannot create keys")
+		mockClient.AssertNotCalled(t, "CreateAPIKey", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestAuthHandler_ListAPIKeys(t *testing.T) {
+	r, mockClient := setupRouter()
+	mockClient.On("ValidateToken", mock.Anything, "token").Return(testUser, nil)
+	lastUsedAt := time.Date(2026, 10, 26, 10, 0, 0, 0, time.UTC)
+	mockClient.On("ListAPIKeys", mock.Anything, "123").
+		Return(&response.ListAPIKeysResponse{APIKeys: []response.APIKeyResponse{{
+			KeyID:      "key-1",
+			Name:       "ci",
+			Prefix:     "dfk_0123456789ab",
+			Scopes:     []string{},
+			CreatedAt:  time.Date(2026, 10, 26, 9, 0, 0, 0, time.UTC),
+			LastUsedAt: &lastUsedAt,
+		}}}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/auth/me/api-keys", nil)
+	req.Header.Set("Authorization", "Bearer token")
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"api_keys":[{"key_id":"key-1","name":"ci","prefix":"dfk_0123456789ab","scopes":[],"created_at":"2026-10-26T09:00:00Z","last_used_at":"2026-10-26T10:00:00Z"}]}`, w.Body.String())
+}
+
+func TestAuthHandler_RevokeAPIKey(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		r, mockClient := setupRouter()
+		mockClient.On("ValidateToken", mock.Anything, "token").Return(testUser, nil)
+		mockClient.On("RevokeAPIKey", mock.Anything, "123", "key-1", "").Return(nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodDelete, "/api/auth/me/api-keys/key-1", nil)
+		req.Header.Set("Authorization", "Bearer token")
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("Impersonated", func(t *testing.T) {
+		r, mockClient := setupRouter()
+		impersonated := &response.UserResponse{UserID: "123", Email: "test@example.com", IsVerified: true, ImpersonatorID: "admin-1"}
+		mockClient.On("ValidateToken", mock.Anything, "token").Return(impersonated, nil)
+		mockClient.On("RevokeAPIKey", mock.Anything, "123", "key-1", "admin-1").Return(nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodDelete, "/api/auth/me/api-keys/key-1", nil)
+		req.Header.Set("Authorization", "Bearer token")
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		r, mockClient := setupRouter()
+		mockClient.On("ValidateToken", mock.Anything, "token").Return(testUser, nil)
+		mockClient.On("RevokeAPIKey", mock.Anything, "123", "key-2", "").
+			Return(status.Error(codes.NotFound, "api key not found"))
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodDelete, "/api/auth/me/api-keys/key-2", nil)
+		req.Header.Set("Authorization", "Bearer token")
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.JSONEq(t, `{"error":"api key not found"}`, w.Body.String())
+	})
+}
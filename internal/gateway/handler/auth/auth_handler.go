@@ -4,7 +4,10 @@ import (
 	"net/http"
 
 	"github.com/a1y/doc-formatter/internal/gateway/domain/request"
+	"github.com/a1y/doc-formatter/internal/gateway/util/grpcstatus"
 	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // Signup godoc
@@ -16,7 +19,7 @@ import (
 //	@Produce		json
 //	@Param			body	body		request.SignupRequest	true	"Signup payload"
 //	@Success		201		{object}	response.SignUpResponse
-//	@Failure		400		{object}	map[string]string
+//	@Failure		400		{object}	response.ValidationErrorResponse
 //	@Failure		500		{object}	map[string]string
 //	@Router			/api/v1/auth/signup [post]
 func (h *AuthHandler) Signup(c *gin.Context) {
@@ -28,7 +31,7 @@ func (h *AuthHandler) Signup(c *gin.Context) {
 
 	resp, err := h.authManager.Signup(c.Request.Context(), req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		writeError(c, err)
 		return
 	}
 
@@ -40,7 +43,7 @@ func (h *AuthHandler) Signup(c *gin.Context) {
 // Login godoc
 //
 //	@Summary		Login
-//	@Description	Login user and return JWT token
+//	@Description	Login user and return JWT token. Users with two-factor authentication get mfa_required and an mfa_token instead, to complete the login with /api/v1/auth/login/mfa. Logins are locked for a while after too many failed attempts to an account or from an address.
 //	@Tags			Auth
 //	@Accept			json
 //	@Produce		json
@@ -48,6 +51,9 @@ func (h *AuthHandler) Signup(c *gin.Context) {
 //	@Success		200		{object}	response.LoginResponse
 //	@Failure		400		{object}	map[string]string
 //	@Failure		401		{object}	map[string]string
+//	@Failure		403		{object}	map[string]string
+//	@Failure		429		{object}	map[string]string
+//	@Header			429		{integer}	Retry-After	"Seconds to wait before logging in again"
 //	@Failure		500		{object}	map[string]string
 //	@Router			/api/v1/auth/login [post]
 func (h *AuthHandler) Login(c *gin.Context) {
@@ -57,11 +63,26 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	resp, err := h.authManager.Login(c.Request.Context(), req)
+	resp, err := h.authManager.Login(c.Request.Context(), req, c.ClientIP())
+	if status.Code(err) == codes.ResourceExhausted {
+		writeError(c, err)
+		return
+	}
+	if status.Code(err) == codes.PermissionDenied {
+		c.JSON(http.StatusForbidden, gin.H{"error": grpcstatus.Message(err)})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 		return
 	}
+	if resp.MFARequired {
+		c.JSON(http.StatusOK, gin.H{
+			"mfa_required": true,
+			"mfa_token":    resp.MFAToken,
+		})
+		return
+	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"access_token": resp.AccessToken,
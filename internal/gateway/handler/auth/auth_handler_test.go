@@ -8,14 +8,20 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/a1y/doc-formatter/internal/gateway/domain/request"
 	"github.com/a1y/doc-formatter/internal/gateway/domain/response"
 	manager "github.com/a1y/doc-formatter/internal/gateway/manager/auth"
+	"github.com/a1y/doc-formatter/internal/gateway/middleware"
 	"github.com/a1y/doc-formatter/internal/testutil"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 type MockAuthClient struct {
@@ -30,14 +36,207 @@ func (m *MockAuthClient) Signup(ctx context.Context, email, password string) (*r
 	return args.Get(0).(*response.SignUpResponse), args.Error(1)
 }
 
-func (m *MockAuthClient) Login(ctx context.Context, email, password string) (*response.LoginResponse, error) {
-	args := m.Called(ctx, email, password)
+func (m *MockAuthClient) Login(ctx context.Context, email, password, remoteAddr string) (*response.LoginResponse, error) {
+	args := m.Called(ctx, email, password, remoteAddr)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*response.LoginResponse), args.Error(1)
+}
+
+func (m *MockAuthClient) LookupUser(ctx context.Context, email string) (*response.UserResponse, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*response.UserResponse), args.Error(1)
+}
+
+func (m *MockAuthClient) GetUser(ctx context.Context, userID string) (*response.UserResponse, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*response.UserResponse), args.Error(1)
+}
+
+func (m *MockAuthClient) VerifyEmail(ctx context.Context, token string) (*response.UserResponse, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*response.UserResponse), args.Error(1)
+}
+
+func (m *MockAuthClient) ResendVerification(ctx context.Context, email string) error {
+	args := m.Called(ctx, email)
+	return args.Error(0)
+}
+
+func (m *MockAuthClient) RequestPasswordReset(ctx context.Context, email string) error {
+	args := m.Called(ctx, email)
+	return args.Error(0)
+}
+
+func (m *MockAuthClient) ResetPassword(ctx context.Context, token, newPassword string) error {
+	args := m.Called(ctx, token, newPassword)
+	return args.Error(0)
+}
+
+func (m *MockAuthClient) ValidateToken(ctx context.Context, accessToken string) (*response.UserResponse, error) {
+	args := m.Called(ctx, accessToken)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*response.UserResponse), args.Error(1)
+}
+
+func (m *MockAuthClient) ChangePassword(ctx context.Context, userID, currentPassword, newPassword string) (*response.LoginResponse, error) {
+	args := m.Called(ctx, userID, currentPassword, newPassword)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*response.LoginResponse), args.Error(1)
+}
+
+func (m *MockAuthClient) ChangeEmail(ctx context.Context, userID, currentPassword, newEmail string) error {
+	args := m.Called(ctx, userID, currentPassword, newEmail)
+	return args.Error(0)
+}
+
+func (m *MockAuthClient) EnrollTOTP(ctx context.Context, userID string) (*response.EnrollTOTPResponse, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*response.EnrollTOTPResponse), args.Error(1)
+}
+
+func (m *MockAuthClient) ConfirmTOTP(ctx context.Context, userID, code string) (*response.RecoveryCodesResponse, error) {
+	args := m.Called(ctx, userID, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*response.RecoveryCodesResponse), args.Error(1)
+}
+
+func (m *MockAuthClient) VerifyMFA(ctx context.Context, mfaToken, code, remoteAddr string) (*response.LoginResponse, error) {
+	args := m.Called(ctx, mfaToken, code, remoteAddr)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*response.LoginResponse), args.Error(1)
+}
+
+func (m *MockAuthClient) StartOIDCLogin(ctx context.Context, provider string) (*response.StartOIDCLoginResponse, error) {
+	args := m.Called(ctx, provider)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*response.StartOIDCLoginResponse), args.Error(1)
+}
+
+func (m *MockAuthClient) FinishOIDCLogin(ctx context.Context, provider, code, state, loginToken string) (*response.LoginResponse, error) {
+	args := m.Called(ctx, provider, code, state, loginToken)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*response.LoginResponse), args.Error(1)
 }
 
+func (m *MockAuthClient) CreateAPIKey(ctx context.Context, userID, name string, scopes []string) (*response.APIKeyResponse, error) {
+	args := m.Called(ctx, userID, name, scopes)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*response.APIKeyResponse), args.Error(1)
+}
+
+func (m *MockAuthClient) ListAPIKeys(ctx context.Context, userID string) (*response.ListAPIKeysResponse, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*response.ListAPIKeysResponse), args.Error(1)
+}
+
+func (m *MockAuthClient) RevokeAPIKey(ctx context.Context, userID, keyID, impersonatorID string) error {
+	args := m.Called(ctx, userID, keyID, impersonatorID)
+	return args.Error(0)
+}
+
+func (m *MockAuthClient) ValidateAPIKey(ctx context.Context, key string) (*response.UserResponse, []string, error) {
+	args := m.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*response.UserResponse), args.Get(1).([]string), args.Error(2)
+}
+
+func (m *MockAuthClient) ListUsers(ctx context.Context, adminID, query, role string, pageSize int32, pageToken string) (*response.ListUsersResponse, error) {
+	args := m.Called(ctx, adminID, query, role, pageSize, pageToken)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*response.ListUsersResponse), args.Error(1)
+}
+
+func (m *MockAuthClient) SetUserDisabled(ctx context.Context, adminID, userID string, disabled bool) (*response.AdminUserResponse, error) {
+	args := m.Called(ctx, adminID, userID, disabled)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*response.AdminUserResponse), args.Error(1)
+}
+
+func (m *MockAuthClient) VerifyUser(ctx context.Context, adminID, userID string) (*response.AdminUserResponse, error) {
+	args := m.Called(ctx, adminID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*response.AdminUserResponse), args.Error(1)
+}
+
+func (m *MockAuthClient) ResetUserMFA(ctx context.Context, adminID, userID string) (*response.AdminUserResponse, error) {
+	args := m.Called(ctx, adminID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*response.AdminUserResponse), args.Error(1)
+}
+
+func (m *MockAuthClient) ImpersonateUser(ctx context.Context, adminID, userID string) (*response.LoginResponse, error) {
+	args := m.Called(ctx, adminID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*response.LoginResponse), args.Error(1)
+}
+
+func (m *MockAuthClient) ListRoles(ctx context.Context, adminID string) (*response.ListRolesResponse, error) {
+	args := m.Called(ctx, adminID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*response.ListRolesResponse), args.Error(1)
+}
+
+func (m *MockAuthClient) CreateRole(ctx context.Context, adminID, name, description string, permissions []string) (*response.RoleResponse, error) {
+	args := m.Called(ctx, adminID, name, description, permissions)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*response.RoleResponse), args.Error(1)
+}
+
+func (m *MockAuthClient) SetUserRole(ctx context.Context, adminID, userID, role string) (*response.AdminUserResponse, error) {
+	args := m.Called(ctx, adminID, userID, role)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*response.AdminUserResponse), args.Error(1)
+}
+
 func setupRouter() (*gin.Engine, *MockAuthClient) {
 	r := testutil.NewGinEngine()
 	mockClient := new(MockAuthClient)
@@ -46,6 +245,32 @@ func setupRouter() (*gin.Engine, *MockAuthClient) {
 
 	r.POST("/api/auth/signup", authHandler.Signup)
 	r.POST("/api/auth/login", authHandler.Login)
+	r.POST("/api/auth/login/mfa", authHandler.VerifyMFA)
+	r.POST("/api/auth/verify", authHandler.VerifyEmail)
+	r.POST("/api/auth/verify/resend", authHandler.ResendVerification)
+	r.POST("/api/auth/password/forgot", authHandler.ForgotPassword)
+	r.POST("/api/auth/password/reset", authHandler.ResetPassword)
+	r.GET("/api/auth/oidc/:provider/login", authHandler.OIDCLogin)
+	r.GET("/api/auth/oidc/:provider/callback", authHandler.OIDCCallback)
+	me := r.Group("/api/auth/me", middleware.AuthMiddleware(mockClient))
+	me.GET("", authHandler.Me)
+	me.PUT("/password", middleware.RequireSession(), authHandler.ChangePassword)
+	me.PUT("/email", middleware.RequireSession(), authHandler.ChangeEmail)
+	me.POST("/mfa/totp", middleware.RequireSession(), authHandler.EnrollTOTP)
+	me.POST("/mfa/totp/confirm", middleware.RequireSession(), authHandler.ConfirmTOTP)
+	me.POST("/api-keys", middleware.RequireSession(), authHandler.CreateAPIKey)
+	me.GET("/api-keys", middleware.RequireAccessToken(), authHandler.ListAPIKeys)
+	me.DELETE("/api-keys/:id", middleware.RequireAccessToken(), authHandler.RevokeAPIKey)
+	admin := r.Group("/api/admin", middleware.AuthMiddleware(mockClient), middleware.RequireSession())
+	admin.GET("/users", middleware.RequirePermission(middleware.PermissionUsersRead), authHandler.ListUsers)
+	admin.POST("/users/:id/disable", middleware.RequirePermission(middleware.PermissionUsersManage), authHandler.DisableUser)
+	admin.POST("/users/:id/enable", middleware.RequirePermission(middleware.PermissionUsersManage), authHandler.EnableUser)
+	admin.POST("/users/:id/verify", middleware.RequirePermission(middleware.PermissionUsersManage), authHandler.VerifyUser)
+	admin.DELETE("/users/:id/mfa", middleware.RequirePermission(middleware.PermissionUsersManage), authHandler.ResetUserMFA)
+	admin.POST("/users/:id/impersonate", middleware.RequirePermission(middleware.PermissionUsersImpersonate), authHandler.ImpersonateUser)
+	admin.PUT("/users/:id/role", middleware.RequirePermission(middleware.PermissionRolesManage), authHandler.SetUserRole)
+	admin.GET("/roles", middleware.RequirePermission(middleware.PermissionUsersRead), authHandler.ListRoles)
+	admin.POST("/roles", middleware.RequirePermission(middleware.PermissionRolesManage), authHandler.CreateRole)
 
 	return r, mockClient
 }
@@ -116,6 +341,38 @@ func TestAuthHandler_Signup(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 		mockClient.AssertExpectations(t)
 	})
+
+	t.Run("PasswordPolicyViolated", func(t *testing.T) {
+		r, mockClient := setupRouter()
+		reqBody := request.SignupRequest{
+			Email:    "test@example.com",
+			Password: "password",
+		}
+		st, err := status.New(codes.InvalidArgument, "password does not meet the password policy").WithDetails(&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{
+				{Field: "password", Reason: "digit", Description: "must contain a digit"},
+				{Field: "password", Reason: "breached", Description: "appears in a known data breach"},
+			},
+		})
+		assert.NoError(t, err)
+
+		mockClient.On("Signup", mock.Anything, reqBody.Email, reqBody.Password).
+			Return(nil, st.Err())
+
+		w := httptest.NewRecorder()
+		req := testutil.NewJSONRequest(t, http.MethodPost, "/api/auth/signup", reqBody)
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, `{
+			"error": "password does not meet the password policy",
+			"violations": [
+				{"field": "password", "rule": "digit", "description": "must contain a digit"},
+				{"field": "password", "rule": "breached", "description": "appears in a known data breach"}
+			]
+		}`, w.Body.String())
+		mockClient.AssertExpectations(t)
+	})
 }
 
 func TestAuthHandler_Login(t *testing.T) {
@@ -126,7 +383,7 @@ func TestAuthHandler_Login(t *testing.T) {
 			Password: "password123",
 		}
 
-		mockClient.On("Login", mock.Anything, reqBody.Email, reqBody.Password).
+		mockClient.On("Login", mock.Anything, reqBody.Email, reqBody.Password, mock.Anything).
 			Return(&response.LoginResponse{
 				AccessToken: "token",
 				ExpiryUnix:  1234567890,
@@ -165,7 +422,7 @@ func TestAuthHandler_Login(t *testing.T) {
 		}
 		jsonBody, _ := json.Marshal(reqBody)
 
-		mockClient.On("Login", mock.Anything, reqBody.Email, reqBody.Password).
+		mockClient.On("Login", mock.Anything, reqBody.Email, reqBody.Password, mock.Anything).
 			Return(nil, errors.New("invalid credentials"))
 
 		w := httptest.NewRecorder()
@@ -175,4 +432,46 @@ func TestAuthHandler_Login(t *testing.T) {
 		assert.Equal(t, http.StatusUnauthorized, w.Code)
 		mockClient.AssertExpectations(t)
 	})
+
+	t.Run("Unverified", func(t *testing.T) {
+		r, mockClient := setupRouter()
+		reqBody := request.LoginRequest{
+			Email:    "test@example.com",
+			Password: "password123",
+		}
+
+		mockClient.On("Login", mock.Anything, reqBody.Email, reqBody.Password, mock.Anything).
+			Return(nil, status.Error(codes.PermissionDenied, "email address not verified"))
+
+		w := httptest.NewRecorder()
+		req := testutil.NewJSONRequest(t, http.MethodPost, "/api/auth/login", reqBody)
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.JSONEq(t, `{"error":"email address not verified"}`, w.Body.String())
+		mockClient.AssertExpectations(t)
+	})
+	t.Run("Locked", func(t *testing.T) {
+		r, mockClient := setupRouter()
+		reqBody := request.LoginRequest{
+			Email:    "test@example.com",
+			Password: "password123",
+		}
+		st, err := status.New(codes.ResourceExhausted, "too many failed login attempts, retry in 1m30s").
+			WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(89500 * time.Millisecond)})
+		assert.NoError(t, err)
+
+		mockClient.On("Login", mock.Anything, reqBody.Email, reqBody.Password, "192.0.2.1").
+			Return(nil, st.Err())
+
+		w := httptest.NewRecorder()
+		req := testutil.NewJSONRequest(t, http.MethodPost, "/api/auth/login", reqBody)
+		req.RemoteAddr = "192.0.2.1:54321"
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.Equal(t, "90", w.Header().Get("Retry-After"))
+		assert.JSONEq(t, `{"error":"too many failed login attempts, retry in 1m30s"}`, w.Body.String())
+		mockClient.AssertExpectations(t)
+	})
 }
@@ -0,0 +1,28 @@
+package auth
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/a1y/doc-formatter/internal/gateway/domain/response"
+	"github.com/a1y/doc-formatter/internal/gateway/util/grpcstatus"
+	"github.com/gin-gonic/gin"
+)
+
+// writeError writes the HTTP error matching the gRPC status of err, listing
+// the rules broken by each invalid field when the status has any, and
+// passing its retry delay on as the Retry-After header.
+func writeError(c *gin.Context, err error) {
+	if retryAfter, ok := grpcstatus.RetryAfter(err); ok {
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	}
+	resp := response.ValidationErrorResponse{Error: grpcstatus.Message(err)}
+	for _, v := range grpcstatus.FieldViolations(err) {
+		resp.Violations = append(resp.Violations, response.FieldViolation{
+			Field:       v.GetField(),
+			Rule:        v.GetReason(),
+			Description: v.GetDescription(),
+		})
+	}
+	c.JSON(grpcstatus.HTTPStatus(err), resp)
+}
@@ -0,0 +1,107 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/a1y/doc-formatter/internal/gateway/domain/request"
+	"github.com/a1y/doc-formatter/internal/gateway/middleware"
+	"github.com/gin-gonic/gin"
+)
+
+// VerifyMFA godoc
+//
+//	@Summary		Complete login with a code
+//	@Description	Complete the login of a user with two-factor authentication with the mfa_token their login returned and a code of their authenticator app or a recovery code. Every code can only be used once, and wrong codes count as failed logins.
+//	@Tags			Auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		request.VerifyMFARequest	true	"Two-factor authentication payload"
+//	@Success		200		{object}	response.LoginResponse
+//	@Failure		400		{object}	map[string]string
+//	@Failure		401		{object}	map[string]string
+//	@Failure		429		{object}	map[string]string
+//	@Header			429		{integer}	Retry-After	"Seconds to wait before logging in again"
+//	@Failure		500		{object}	map[string]string
+//	@Router			/api/v1/auth/login/mfa [post]
+func (h *AuthHandler) VerifyMFA(c *gin.Context) {
+	var req request.VerifyMFARequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.authManager.VerifyMFA(c.Request.Context(), req, c.ClientIP())
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// EnrollTOTP godoc
+//
+//	@Summary		Enroll in two-factor authentication
+//	@Description	Start enrolling the current user in two-factor authentication with a new TOTP secret. The otpauth URI sets up authenticator apps, usually shown as a QR code. Two-factor authentication is enabled once a code is confirmed with /api/v1/auth/me/mfa/totp/confirm.
+//	@Tags			Auth
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	response.EnrollTOTPResponse
+//	@Failure		401	{object}	map[string]string
+//	@Failure		409	{object}	map[string]string
+//	@Failure		412	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/api/v1/auth/me/mfa/totp [post]
+func (h *AuthHandler) EnrollTOTP(c *gin.Context) {
+	user, ok := middleware.AuthUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "not authenticated"})
+		return
+	}
+
+	resp, err := h.authManager.EnrollTOTP(c.Request.Context(), user.UserID)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// ConfirmTOTP godoc
+//
+//	@Summary		Confirm two-factor authentication
+//	@Description	Enable two-factor authentication for the current user with a code of the secret they enrolled with. Returns recovery codes that each stand in for a code once, which are not shown again.
+//	@Tags			Auth
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			body	body		request.ConfirmTOTPRequest	true	"TOTP confirmation payload"
+//	@Success		200		{object}	response.RecoveryCodesResponse
+//	@Failure		400		{object}	map[string]string
+//	@Failure		401		{object}	map[string]string
+//	@Failure		409		{object}	map[string]string
+//	@Failure		412		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/api/v1/auth/me/mfa/totp/confirm [post]
+func (h *AuthHandler) ConfirmTOTP(c *gin.Context) {
+	user, ok := middleware.AuthUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "not authenticated"})
+		return
+	}
+
+	var req request.ConfirmTOTPRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.authManager.ConfirmTOTP(c.Request.Context(), user.UserID, req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,155 @@
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/a1y/doc-formatter/internal/gateway/domain/request"
+	"github.com/a1y/doc-formatter/internal/gateway/domain/response"
+	"github.com/a1y/doc-formatter/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestAuthHandler_Login_MFARequired(t *testing.T) {
+	r, mockClient := setupRouter()
+	mockClient.On("Login", mock.Anything, "test@example.com", "password123", mock.Anything).
+		Return(&response.LoginResponse{MFARequired: true, MFAToken: "mfa-token"}, nil)
+
+	w := httptest.NewRecorder()
+	req := testutil.NewJSONRequest(t, http.MethodPost, "/api/auth/login", request.LoginRequest{Email: "test@example.com", Password: "password123"})
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"mfa_required":true,"mfa_token":"mfa-token"}`, w.Body.String())
+	mockClient.AssertExpectations(t)
+}
+
+func TestAuthHandler_VerifyMFA(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		r, mockClient := setupRouter()
+		mockClient.On("VerifyMFA", mock.Anything, "mfa-token", "123456", "192.0.2.1").
+			Return(&response.LoginResponse{AccessToken: "token", ExpiryUnix: 42}, nil)
+
+		w := httptest.NewRecorder()
+		req := testutil.NewJSONRequest(t, http.MethodPost, "/api/auth/login/mfa", request.VerifyMFARequest{MFAToken: "mfa-token", Code: "123456"})
+		req.RemoteAddr = "192.0.2.1:54321"
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var resp response.LoginResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, response.LoginResponse{AccessToken: "token", ExpiryUnix: 42}, resp)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("MissingCode", func(t *testing.T) {
+		r, mockClient := setupRouter()
+
+		w := httptest.NewRecorder()
+		req := testutil.NewJSONRequest(t, http.MethodPost, "/api/auth/login/mfa", request.VerifyMFARequest{MFAToken: "mfa-token"})
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockClient.AssertNotCalled(t, "VerifyMFA", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("InvalidCode", func(t *testing.T) {
+		r, mockClient := setupRouter()
+		mockClient.On("VerifyMFA", mock.Anything, "mfa-token", "000000", mock.Anything).
+			Return(nil, status.Error(codes.Unauthenticated, "invalid two-factor authentication code"))
+
+		w := httptest.NewRecorder()
+		req := testutil.NewJSONRequest(t, http.MethodPost, "/api/auth/login/mfa", request.VerifyMFARequest{MFAToken: "mfa-token", Code: "000000"})
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.JSONEq(t, `{"error":"invalid two-factor authentication code"}`, w.Body.String())
+	})
+
+	t.Run("Locked", func(t *testing.T) {
+		r, mockClient := setupRouter()
+		st, err := status.New(codes.ResourceExhausted, "too many failed login attempts, retry in 1m").
+			WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(time.Minute)})
+		assert.NoError(t, err)
+		mockClient.On("VerifyMFA", mock.Anything, "mfa-token", "000000", mock.Anything).Return(nil, st.Err())
+
+		w := httptest.NewRecorder()
+		req := testutil.NewJSONRequest(t, http.MethodPost, "/api/auth/login/mfa", request.VerifyMFARequest{MFAToken: "mfa-token", Code: "000000"})
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.Equal(t, "60", w.Header().Get("Retry-After"))
+	})
+}
+
+func TestAuthHandler_EnrollTOTP(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		r, mockClient := setupRouter()
+		mockClient.On("ValidateToken", mock.Anything, "token").Return(testUser, nil)
+		mockClient.On("EnrollTOTP", mock.Anything, "123").
+			Return(&response.EnrollTOTPResponse{Secret: "SECRET", OtpauthURI: "otpauth://totp/x"}, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/api/auth/me/mfa/totp", nil)
+		req.Header.Set("Authorization", "Bearer token")
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"secret":"SECRET","otpauth_uri":"otpauth://totp/x"}`, w.Body.String())
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("Unavailable", func(t *testing.T) {
+		r, mockClient := setupRouter()
+		mockClient.On("ValidateToken", mock.Anything, "token").Return(testUser, nil)
+		mockClient.On("EnrollTOTP", mock.Anything, "123").
+			Return(nil, status.Error(codes.FailedPrecondition, "two-factor authentication is unavailable"))
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/api/auth/me/mfa/totp", nil)
+		req.Header.Set("Authorization", "Bearer token")
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+	})
+}
+
+func TestAuthHandler_ConfirmTOTP(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		r, mockClient := setupRouter()
+		mockClient.On("ValidateToken", mock.Anything, "token").Return(testUser, nil)
+		mockClient.On("ConfirmTOTP", mock.Anything, "123", "123456").
+			Return(&response.RecoveryCodesResponse{RecoveryCodes: []string{"abcd-efgh-ijkl-mnop"}}, nil)
+
+		w := httptest.NewRecorder()
+		req := testutil.NewJSONRequest(t, http.MethodPost, "/api/auth/me/mfa/totp/confirm", request.ConfirmTOTPRequest{Code: "123456"})
+		req.Header.Set("Authorization", "Bearer token")
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"recovery_codes":["abcd-efgh-ijkl-mnop"]}`, w.Body.String())
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("InvalidCode", func(t *testing.T) {
+		r, mockClient := setupRouter()
+		mockClient.On("ValidateToken", mock.Anything, "token").Return(testUser, nil)
+		mockClient.On("ConfirmTOTP", mock.Anything, "123", "000000").
+			Return(nil, status.Error(codes.InvalidArgument, "invalid two-factor authentication code"))
+
+		w := httptest.NewRecorder()
+		req := testutil.NewJSONRequest(t, http.MethodPost, "/api/auth/me/mfa/totp/confirm", request.ConfirmTOTPRequest{Code: "000000"})
+		req.Header.Set("Authorization", "Bearer token")
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
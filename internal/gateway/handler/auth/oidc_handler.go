@@ -0,0 +1,101 @@
+package auth
+
+import (
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/a1y/doc-formatter/internal/gateway/domain/request"
+	"github.com/gin-gonic/gin"
+)
+
+// oidcLoginCookie keeps the token of a login with an identity provider in
+// the browser it started in, scoped to the routes of the provider.
+const oidcLoginCookie = "oidc_login"
+
+// OIDCLogin godoc
+//
+//	@Summary		Log in with an identity provider
+//	@Description	Redirect to an OpenID Connect provider to log in with it. The provider redirects back to /api/v1/auth/oidc/{provider}/callback, which must be opened in the same browser.
+//	@Tags			Auth
+//	@Param			provider	path	string	true	"Name of the identity provider"
+//	@Success		302
+//	@Header			302	{string}	Location	"Authorization URL of the provider"
+//	@Failure		404	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Failure		503	{object}	map[string]string
+//	@Router			/api/v1/auth/oidc/{provider}/login [get]
+func (h *AuthHandler) OIDCLogin(c *gin.Context) {
+	resp, err := h.authManager.StartOIDCLogin(c.Request.Context(), c.Param("provider"))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	setOIDCLoginCookie(c, resp.LoginToken, int(time.Until(time.Unix(resp.ExpiryUnix, 0)).Seconds()))
+	c.Redirect(http.StatusFound, resp.AuthorizationURL)
+}
+
+// OIDCCallback godoc
+//
+//	@Summary		Finish logging in with an identity provider
+//	@Description	Finish a login with an OpenID Connect provider it redirected back from, and return a JWT token. Users are linked to their identity at the provider by the email address it verified, or created. Users with two-factor authentication get mfa_required and an mfa_token instead, to complete the login with /api/v1/auth/login/mfa.
+//	@Tags			Auth
+//	@Produce		json
+//	@Param			provider	path		string	true	"Name of the identity provider"
+//	@Param			code		query		string	false	"Authorization code"
+//	@Param			state		query		string	false	"State of the login"
+//	@Param			error		query		string	false	"Error of the provider"
+//	@Success		200			{object}	response.LoginResponse
+//	@Failure		400			{object}	map[string]string
+//	@Failure		401			{object}	map[string]string
+//	@Failure		404			{object}	map[string]string
+//	@Failure		412			{object}	map[string]string
+//	@Failure		500			{object}	map[string]string
+//	@Failure		503			{object}	map[string]string
+//	@Router			/api/v1/auth/oidc/{provider}/callback [get]
+func (h *AuthHandler) OIDCCallback(c *gin.Context) {
+	var req request.OIDCCallbackRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	// The login can only be finished once, whatever happens to it.
+	loginToken, cookieErr := c.Cookie(oidcLoginCookie)
+	setOIDCLoginCookie(c, "", -1)
+
+	if req.Error != "" {
+		message := "identity provider login failed: " + req.Error
+		if req.ErrorDescription != "" {
+			message += ": " + req.ErrorDescription
+		}
+		c.JSON(http.StatusUnauthorized, gin.H{"error": message})
+		return
+	}
+	if err := req.Validate(); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if cookieErr != nil || loginToken == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "login was not started in this browser or has expired"})
+		return
+	}
+
+	resp, err := h.authManager.FinishOIDCLogin(c.Request.Context(), c.Param("provider"), req, loginToken)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// setOIDCLoginCookie sets the login cookie for the routes of the provider of
+// the request, for maxAge seconds. It is deleted when maxAge is negative.
+func setOIDCLoginCookie(c *gin.Context, value string, maxAge int) {
+	secure := c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https"
+	// Lax cookies are sent along with the top-level redirect back from the
+	// provider.
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(oidcLoginCookie, value, maxAge, path.Dir(c.Request.URL.Path), "", secure, true)
+}
@@ -0,0 +1,147 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/a1y/doc-formatter/internal/gateway/domain/response"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestAuthHandler_OIDCLogin(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		r, mockClient := setupRouter()
+		mockClient.On("StartOIDCLogin", mock.Anything, "corp").Return(&response.StartOIDCLoginResponse{
+			AuthorizationURL: "https://idp.example.com/authorize?state=state",
+			LoginToken:       "login-token",
+			ExpiryUnix:       time.Now().Add(10 * time.Minute).Unix(),
+		}, nil)
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/api/auth/oidc/corp/login", nil)
+		req.Header.Set("X-Forwarded-Proto", "https")
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusFound, w.Code)
+		assert.Equal(t, "https://idp.example.com/authorize?state=state", w.Header().Get("Location"))
+		cookies := w.Result().Cookies()
+		require.Len(t, cookies, 1)
+		cookie := cookies[0]
+		assert.Equal(t, oidcLoginCookie, cookie.Name)
+		assert.Equal(t, "login-token", cookie.Value)
+		assert.Equal(t, "/api/auth/oidc/corp", cookie.Path)
+		assert.True(t, cookie.HttpOnly)
+		assert.True(t, cookie.Secure)
+		assert.Equal(t, http.SameSiteLaxMode, cookie.SameSite)
+		assert.InDelta(t, 600, cookie.MaxAge, 5)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("UnknownProvider", func(t *testing.T) {
+		r, mockClient := setupRouter()
+		mockClient.On("StartOIDCLogin", mock.Anything, "other").
+			Return(nil, status.Error(codes.NotFound, "identity provider not found"))
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/auth/oidc/other/login", nil))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.JSONEq(t, `{"error":"identity provider not found"}`, w.Body.String())
+		assert.Empty(t, w.Result().Cookies())
+	})
+}
+
+func TestAuthHandler_OIDCCallback(t *testing.T) {
+	callback := func(query string, withCookie bool) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/api/auth/oidc/corp/callback?"+query, nil)
+		if withCookie {
+			req.AddCookie(&http.Cookie{Name: oidcLoginCookie, Value: "login-token"})
+		}
+		return req
+	}
+	// assertCookieCleared checks the login cannot be finished again.
+	assertCookieCleared := func(t *testing.T, w *httptest.ResponseRecorder) {
+		t.Helper()
+		cookies := w.Result().Cookies()
+		require.Len(t, cookies, 1)
+		assert.Equal(t, oidcLoginCookie, cookies[0].Name)
+		assert.Empty(t, cookies[0].Value)
+		assert.Negative(t, cookies[0].MaxAge)
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		r, mockClient := setupRouter()
+		mockClient.On("FinishOIDCLogin", mock.Anything, "corp", "code", "state", "login-token").
+			Return(&response.LoginResponse{AccessToken: "token", ExpiryUnix: 42}, nil)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, callback("code=code&state=state", true))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"access_token":"token","expiry_unix":42}`, w.Body.String())
+		assertCookieCleared(t, w)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("MFARequired", func(t *testing.T) {
+		r, mockClient := setupRouter()
+		mockClient.On("FinishOIDCLogin", mock.Anything, "corp", "code", "state", "login-token").
+			Return(&response.LoginResponse{MFARequired: true, MFAToken: "mfa-token"}, nil)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, callback("code=code&state=state", true))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"mfa_required":true,"mfa_token":"mfa-token"}`, w.Body.String())
+	})
+
+	t.Run("ProviderError", func(t *testing.T) {
+		r, mockClient := setupRouter()
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, callback("error=access_denied&error_description=User+cancelled&state=state", true))
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.JSONEq(t, `{"error":"identity provider login failed: access_denied: User cancelled"}`, w.Body.String())
+		assertCookieCleared(t, w)
+		mockClient.AssertNotCalled(t, "FinishOIDCLogin", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("MissingCode", func(t *testing.T) {
+		r, mockClient := setupRouter()
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, callback("state=state", true))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockClient.AssertNotCalled(t, "FinishOIDCLogin", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("MissingCookie", func(t *testing.T) {
+		r, mockClient := setupRouter()
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, callback("code=code&state=state", false))
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		mockClient.AssertNotCalled(t, "FinishOIDCLogin", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("InvalidLogin", func(t *testing.T) {
+		r, mockClient := setupRouter()
+		mockClient.On("FinishOIDCLogin", mock.Anything, "corp", "code", "state", "login-token").
+			Return(nil, status.Error(codes.Unauthenticated, "invalid or expired identity provider login"))
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, callback("code=code&state=state", true))
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.JSONEq(t, `{"error":"invalid or expired identity provider login"}`, w.Body.String())
+	})
+}
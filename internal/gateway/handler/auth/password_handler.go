@@ -0,0 +1,63 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/a1y/doc-formatter/internal/gateway/domain/request"
+	"github.com/a1y/doc-formatter/internal/gateway/util/grpcstatus"
+	"github.com/gin-gonic/gin"
+)
+
+// ForgotPassword godoc
+//
+//	@Summary		Request a password reset
+//	@Description	Email a one-time password reset link. The response is the same whether or not the address belongs to an account.
+//	@Tags			Auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body	request.ForgotPasswordRequest	true	"Password reset request"
+//	@Success		202
+//	@Failure		400	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/api/v1/auth/password/forgot [post]
+func (h *AuthHandler) ForgotPassword(c *gin.Context) {
+	var req request.ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.authManager.RequestPasswordReset(c.Request.Context(), req); err != nil {
+		c.JSON(grpcstatus.HTTPStatus(err), gin.H{"error": grpcstatus.Message(err)})
+		return
+	}
+
+	c.Status(http.StatusAccepted)
+}
+
+// ResetPassword godoc
+//
+//	@Summary		Reset password
+//	@Description	Set a new password with a reset token. The token can only be used once and every existing session of the account is revoked.
+//	@Tags			Auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body	request.ResetPasswordRequest	true	"Password reset payload"
+//	@Success		204
+//	@Failure		400	{object}	response.ValidationErrorResponse
+//	@Failure		500	{object}	map[string]string
+//	@Router			/api/v1/auth/password/reset [post]
+func (h *AuthHandler) ResetPassword(c *gin.Context) {
+	var req request.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.authManager.ResetPassword(c.Request.Context(), req); err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,75 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/a1y/doc-formatter/internal/gateway/domain/request"
+	"github.com/a1y/doc-formatter/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestAuthHandler_ForgotPassword(t *testing.T) {
+	t.Run("Accepted", func(t *testing.T) {
+		r, mockClient := setupRouter()
+		mockClient.On("RequestPasswordReset", mock.Anything, "test@example.com").Return(nil)
+
+		w := httptest.NewRecorder()
+		req := testutil.NewJSONRequest(t, http.MethodPost, "/api/auth/password/forgot", request.ForgotPasswordRequest{Email: "test@example.com"})
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("InvalidEmail", func(t *testing.T) {
+		r, _ := setupRouter()
+
+		w := httptest.NewRecorder()
+		req := testutil.NewJSONRequest(t, http.MethodPost, "/api/auth/password/forgot", request.ForgotPasswordRequest{Email: "not-an-email"})
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestAuthHandler_ResetPassword(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		r, mockClient := setupRouter()
+		mockClient.On("ResetPassword", mock.Anything, "token", "new-password").Return(nil)
+
+		w := httptest.NewRecorder()
+		req := testutil.NewJSONRequest(t, http.MethodPost, "/api/auth/password/reset", request.ResetPasswordRequest{Token: "token", NewPassword: "new-password"})
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("InvalidToken", func(t *testing.T) {
+		r, mockClient := setupRouter()
+		mockClient.On("ResetPassword", mock.Anything, "used", "new-password").
+			Return(status.Error(codes.InvalidArgument, "invalid or expired password reset token"))
+
+		w := httptest.NewRecorder()
+		req := testutil.NewJSONRequest(t, http.MethodPost, "/api/auth/password/reset", request.ResetPasswordRequest{Token: "used", NewPassword: "new-password"})
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, `{"error":"invalid or expired password reset token"}`, w.Body.String())
+	})
+
+	t.Run("ShortPassword", func(t *testing.T) {
+		r, _ := setupRouter()
+
+		w := httptest.NewRecorder()
+		req := testutil.NewJSONRequest(t, http.MethodPost, "/api/auth/password/reset", request.ResetPasswordRequest{Token: "token", NewPassword: "abc"})
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -0,0 +1,64 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/a1y/doc-formatter/internal/gateway/domain/request"
+	"github.com/a1y/doc-formatter/internal/gateway/util/grpcstatus"
+	"github.com/gin-gonic/gin"
+)
+
+// VerifyEmail godoc
+//
+//	@Summary		Verify email
+//	@Description	Verify the email address a verification token was sent to
+//	@Tags			Auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		request.VerifyEmailRequest	true	"Verification payload"
+//	@Success		200		{object}	response.UserResponse
+//	@Failure		400		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/api/v1/auth/verify [post]
+func (h *AuthHandler) VerifyEmail(c *gin.Context) {
+	var req request.VerifyEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.authManager.VerifyEmail(c.Request.Context(), req)
+	if err != nil {
+		c.JSON(grpcstatus.HTTPStatus(err), gin.H{"error": grpcstatus.Message(err)})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// ResendVerification godoc
+//
+//	@Summary		Resend verification email
+//	@Description	Send a new verification email to an unverified address. Unknown and already verified addresses are accepted without sending anything.
+//	@Tags			Auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body	request.ResendVerificationRequest	true	"Resend payload"
+//	@Success		202
+//	@Failure		400	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/api/v1/auth/verify/resend [post]
+func (h *AuthHandler) ResendVerification(c *gin.Context) {
+	var req request.ResendVerificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.authManager.ResendVerification(c.Request.Context(), req); err != nil {
+		c.JSON(grpcstatus.HTTPStatus(err), gin.H{"error": grpcstatus.Message(err)})
+		return
+	}
+
+	c.Status(http.StatusAccepted)
+}
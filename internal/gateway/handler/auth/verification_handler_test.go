@@ -0,0 +1,94 @@
+package auth
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/a1y/doc-formatter/internal/gateway/domain/request"
+	"github.com/a1y/doc-formatter/internal/gateway/domain/response"
+	"github.com/a1y/doc-formatter/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestAuthHandler_VerifyEmail(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		r, mockClient := setupRouter()
+		mockClient.On("VerifyEmail", mock.Anything, "token").
+			Return(&response.UserResponse{UserID: "123", Email: "test@example.com", IsVerified: true}, nil)
+
+		w := httptest.NewRecorder()
+		req := testutil.NewJSONRequest(t, http.MethodPost, "/api/auth/verify", request.VerifyEmailRequest{Token: "token"})
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var resp response.UserResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, response.UserResponse{UserID: "123", Email: "test@example.com", IsVerified: true}, resp)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("InvalidToken", func(t *testing.T) {
+		r, mockClient := setupRouter()
+		mockClient.On("VerifyEmail", mock.Anything, "expired").
+			Return(nil, status.Error(codes.InvalidArgument, "invalid or expired verification token"))
+
+		w := httptest.NewRecorder()
+		req := testutil.NewJSONRequest(t, http.MethodPost, "/api/auth/verify", request.VerifyEmailRequest{Token: "expired"})
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, `{"error":"invalid or expired verification token"}`, w.Body.String())
+	})
+
+	t.Run("MissingToken", func(t *testing.T) {
+		r, _ := setupRouter()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/api/auth/verify", bytes.NewBufferString(`{}`))
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestAuthHandler_ResendVerification(t *testing.T) {
+	t.Run("Accepted", func(t *testing.T) {
+		r, mockClient := setupRouter()
+		mockClient.On("ResendVerification", mock.Anything, "test@example.com").Return(nil)
+
+		w := httptest.NewRecorder()
+		req := testutil.NewJSONRequest(t, http.MethodPost, "/api/auth/verify/resend", request.ResendVerificationRequest{Email: "test@example.com"})
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("InvalidEmail", func(t *testing.T) {
+		r, _ := setupRouter()
+
+		w := httptest.NewRecorder()
+		req := testutil.NewJSONRequest(t, http.MethodPost, "/api/auth/verify/resend", request.ResendVerificationRequest{Email: "not-an-email"})
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Unavailable", func(t *testing.T) {
+		r, mockClient := setupRouter()
+		mockClient.On("ResendVerification", mock.Anything, "test@example.com").
+			Return(status.Error(codes.Unavailable, "auth service unavailable"))
+
+		w := httptest.NewRecorder()
+		req := testutil.NewJSONRequest(t, http.MethodPost, "/api/auth/verify/resend", request.ResendVerificationRequest{Email: "test@example.com"})
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	})
+}
@@ -0,0 +1,57 @@
+package storage
+
+import (
+	"net/http"
+
+	"github.com/a1y/doc-formatter/internal/gateway/domain/request"
+	"github.com/a1y/doc-formatter/internal/gateway/util/grpcstatus"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AnalyzeDocument godoc
+//
+//	@Summary		Analyze document
+//	@Description	Return the structure of a DOCX, ODT or Markdown file: its heading tree, word, character and page counts, embedded images and tables, detected language, fonts in use and core properties. The analysis is computed once per file content and then served from cache.
+//	@Tags			Storage
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+//	@Param			id	path		string	true	"File ID (UUID)"
+//	@Success		200	{object}	response.DocumentAnalysisResponse
+//	@Failure		400	{object}	map[string]string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		422	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/api/v1/storage/files/{id}/analysis [get]
+func (h *StorageHandler) AnalyzeDocument(c *gin.Context) {
+	userID, ok := authUserID(c)
+	if !ok {
+		return
+	}
+
+	var uri request.FileURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.storageManager.AnalyzeDocument(c.Request.Context(), userID, uri.FileID)
+	if err != nil {
+		c.JSON(documentStatus(err), gin.H{"error": grpcstatus.Message(err)})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// documentStatus reports files whose format is not supported or whose
+// content cannot be read as unprocessable.
+func documentStatus(err error) int {
+	if status.Code(err) == codes.FailedPrecondition {
+		return http.StatusUnprocessableEntity
+	}
+	return grpcstatus.HTTPStatus(err)
+}
@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	storagemgr "github.com/a1y/doc-formatter/internal/gateway/manager/storage"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type mockAnalysisClient struct {
+	mockStorageClient
+
+	analysisErr  error
+	lastAnalysis *storagepb.AnalyzeDocumentRequest
+}
+
+func (m *mockAnalysisClient) AnalyzeDocument(_ context.Context, req *storagepb.AnalyzeDocumentRequest) (*storagepb.AnalyzeDocumentResponse, error) {
+	m.lastAnalysis = req
+	if m.analysisErr != nil {
+		return nil, m.analysisErr
+	}
+	return &storagepb.AnalyzeDocumentResponse{
+		FileId:         testFileID,
+		Outline:        []*storagepb.Heading{{Level: 1, Text: "Intro"}},
+		Statistics:     &storagepb.DocumentStatistics{Words: 3, Characters: 15, CharactersNoSpaces: 13, Paragraphs: 1, Pages: 1, PagesEstimated: true},
+		Language:       "en",
+		Properties:     &storagepb.DocumentProperties{Title: "Report"},
+		AnalyzedAtUnix: 1767225600,
+	}, nil
+}
+
+func setupAnalysisRouter(t *testing.T, mockClient *mockAnalysisClient) *gin.Engine {
+	t.Helper()
+
+	h, err := NewStorageHandler(storagemgr.NewStorageManager(mockClient, nil))
+	assert.NoError(t, err)
+	r := setupRouter(h)
+	r.GET("/api/v1/storage/files/:id/analysis", h.AnalyzeDocument)
+	return r
+}
+
+func TestStorageHandler_AnalyzeDocument(t *testing.T) {
+	mockClient := &mockAnalysisClient{}
+	r := setupAnalysisRouter(t, mockClient)
+
+	w := serve(r, http.MethodGet, "/api/v1/storage/files/"+testFileID+"/analysis", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{
+		"file_id":"`+testFileID+`",
+		"outline":[{"level":1,"text":"Intro"}],
+		"statistics":{"words":3,"characters":15,"characters_no_spaces":13,"paragraphs":1,"pages":1,"pages_estimated":true},
+		"images":[],"tables":[],"language":"en","fonts":[],
+		"properties":{"title":"Report"},
+		"analyzed_at":"2026-01-01T00:00:00Z"
+	}`, w.Body.String())
+	assert.Equal(t, &storagepb.AnalyzeDocumentRequest{UserId: testUserID, FileId: testFileID}, mockClient.lastAnalysis)
+}
+
+func TestStorageHandler_AnalyzeDocument_Errors(t *testing.T) {
+	mockClient := &mockAnalysisClient{}
+	r := setupAnalysisRouter(t, mockClient)
+
+	w := serve(r, http.MethodGet, "/api/v1/storage/files/not-a-uuid/analysis", "")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Nil(t, mockClient.lastAnalysis)
+
+	mockClient.analysisErr = status.Error(codes.FailedPrecondition, "operation not supported for this document format: application/pdf")
+	w = serve(r, http.MethodGet, "/api/v1/storage/files/"+testFileID+"/analysis", "")
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Contains(t, w.Body.String(), "application/pdf")
+
+	mockClient.analysisErr = status.Error(codes.NotFound, "document not found")
+	w = serve(r, http.MethodGet, "/api/v1/storage/files/"+testFileID+"/analysis", "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,82 @@
+package storage
+
+import (
+	"net/http"
+
+	"github.com/a1y/doc-formatter/internal/gateway/domain/request"
+	"github.com/a1y/doc-formatter/internal/gateway/util/grpcstatus"
+	"github.com/gin-gonic/gin"
+)
+
+// CreateBatchJob godoc
+//
+//	@Summary		Create batch formatting job
+//	@Description	Format with one style profile the DOCX files of a folder (with its subfolders when recursive is set), the files carrying a tag, or a list of files, up to 1000 of them. Exactly one of folder_id, tag and file_ids is set. The files are formatted in the background, each into a new file named after it in the output folder; poll the job for its progress and its report of successes, failures and warnings. When archive is set, a ZIP archive of the formatted files is stored in the output folder once the job completes.
+//	@Tags			Jobs
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+//	@Param			request	body		request.CreateBatchJobRequest	true	"Files to format and style profile"
+//	@Success		202		{object}	response.BatchJobResponse
+//	@Failure		400		{object}	map[string]string
+//	@Failure		401		{object}	map[string]string
+//	@Failure		404		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/api/v1/jobs/batch [post]
+func (h *StorageHandler) CreateBatchJob(c *gin.Context) {
+	userID, ok := authUserID(c)
+	if !ok {
+		return
+	}
+
+	var req request.CreateBatchJobRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.storageManager.CreateBatchJob(c.Request.Context(), userID, &req)
+	if err != nil {
+		c.JSON(grpcstatus.HTTPStatus(err), gin.H{"error": grpcstatus.Message(err)})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, resp)
+}
+
+// GetBatchJob godoc
+//
+//	@Summary		Get batch formatting job
+//	@Description	Get the progress of a batch formatting job and the report of its files: the formatted file of each success, the error of each failure and the warnings about parts of files the profile could not reach.
+//	@Tags			Jobs
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+//	@Param			id	path		string	true	"Job ID (UUID)"
+//	@Success		200	{object}	response.BatchJobResponse
+//	@Failure		400	{object}	map[string]string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/api/v1/jobs/batch/{id} [get]
+func (h *StorageHandler) GetBatchJob(c *gin.Context) {
+	userID, ok := authUserID(c)
+	if !ok {
+		return
+	}
+
+	var uri request.JobURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.storageManager.GetBatchJob(c.Request.Context(), userID, uri.JobID)
+	if err != nil {
+		c.JSON(grpcstatus.HTTPStatus(err), gin.H{"error": grpcstatus.Message(err)})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
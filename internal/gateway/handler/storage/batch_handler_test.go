@@ -0,0 +1,160 @@
+package storage
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	storagemgr "github.com/a1y/doc-formatter/internal/gateway/manager/storage"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const testJobID = "0a7c6e3e-1f5b-4b7e-9d3a-5c2f8e9b1d40"
+
+type mockBatchClient struct {
+	mockStorageClient
+
+	batchErr  error
+	lastBatch any
+}
+
+func batchJob() *storagepb.BatchJob {
+	return &storagepb.BatchJob{
+		Id:      testJobID,
+		Status:  "running",
+		Profile: &storagepb.StyleProfile{FontFamily: "Georgia"},
+		Total:   1,
+		Pending: 1,
+		Items: []*storagepb.BatchJobItem{
+			{FileId: testFileID, FileName: "report.docx", Status: "pending"},
+		},
+		CreatedAtUnix: 1767225600,
+	}
+}
+
+func (m *mockBatchClient) CreateBatchJob(_ context.Context, req *storagepb.CreateBatchJobRequest) (*storagepb.CreateBatchJobResponse, error) {
+	m.lastBatch = req
+	if m.batchErr != nil {
+		return nil, m.batchErr
+	}
+	return &storagepb.CreateBatchJobResponse{Job: batchJob()}, nil
+}
+
+func (m *mockBatchClient) GetBatchJob(_ context.Context, req *storagepb.GetBatchJobRequest) (*storagepb.GetBatchJobResponse, error) {
+	m.lastBatch = req
+	if m.batchErr != nil {
+		return nil, m.batchErr
+	}
+	return &storagepb.GetBatchJobResponse{Job: batchJob()}, nil
+}
+
+func setupBatchRouter(t *testing.T, mockClient *mockBatchClient) *gin.Engine {
+	t.Helper()
+
+	h, err := NewStorageHandler(storagemgr.NewStorageManager(mockClient, nil))
+	assert.NoError(t, err)
+	r := setupRouter(h)
+	r.POST("/api/v1/jobs/batch", h.CreateBatchJob)
+	r.GET("/api/v1/jobs/batch/:id", h.GetBatchJob)
+	return r
+}
+
+const testBatchJobJSON = `{
+	"job_id":"` + testJobID + `",
+	"status":"running",
+	"profile":{"font_family":"Georgia"},
+	"archive":false,
+	"progress":{"total":1,"pending":1,"running":0,"succeeded":0,"failed":0},
+	"items":[{"file_id":"` + testFileID + `","file_name":"report.docx","status":"pending"}],
+	"created_at":"2026-01-01T00:00:00Z"
+}`
+
+func TestStorageHandler_BatchJobs(t *testing.T) {
+	mockClient := &mockBatchClient{}
+	r := setupBatchRouter(t, mockClient)
+
+	w := serve(r, http.MethodPost, "/api/v1/jobs/batch",
+		`{"tag":"legal","profile":{"font_family":"Georgia","margin":54},"archive":true}`)
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	assert.JSONEq(t, testBatchJobJSON, w.Body.String())
+	assert.Equal(t, &storagepb.CreateBatchJobRequest{
+		UserId:  testUserID,
+		Tag:     "legal",
+		Profile: &storagepb.StyleProfile{FontFamily: "Georgia", Margin: 54},
+		Archive: true,
+	}, mockClient.lastBatch)
+
+	w = serve(r, http.MethodGet, "/api/v1/jobs/batch/"+testJobID+"", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, testBatchJobJSON, w.Body.String())
+	assert.Equal(t, &storagepb.GetBatchJobRequest{UserId: testUserID, JobId: testJobID}, mockClient.lastBatch)
+}
+
+func TestStorageHandler_BatchJobErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		err    error
+		want   int
+	}{
+		{
+			name:   "create with invalid file id",
+			method: http.MethodPost,
+			path:   "/api/v1/jobs/batch",
+			body:   `{"file_ids":["not-a-uuid"],"profile":{"font_family":"Georgia"}}`,
+			want:   http.StatusBadRequest,
+		},
+		{
+			name:   "create with font size out of range",
+			method: http.MethodPost,
+			path:   "/api/v1/jobs/batch",
+			body:   `{"tag":"legal","profile":{"font_size":2000}}`,
+			want:   http.StatusBadRequest,
+		},
+		{
+			name:   "create without target",
+			method: http.MethodPost,
+			path:   "/api/v1/jobs/batch",
+			body:   `{"profile":{"font_family":"Georgia"}}`,
+			err:    status.Error(codes.InvalidArgument, "invalid batch job: a folder, a tag or a list of documents is required"),
+			want:   http.StatusBadRequest,
+		},
+		{
+			name:   "create in missing folder",
+			method: http.MethodPost,
+			path:   "/api/v1/jobs/batch",
+			body:   `{"folder_id":"` + testFileID + `","profile":{"font_family":"Georgia"}}`,
+			err:    status.Error(codes.NotFound, "folder not found"),
+			want:   http.StatusNotFound,
+		},
+		{
+			name:   "get with invalid job id",
+			method: http.MethodGet,
+			path:   "/api/v1/jobs/batch/not-a-uuid",
+			want:   http.StatusBadRequest,
+		},
+		{
+			name:   "get missing job",
+			method: http.MethodGet,
+			path:   "/api/v1/jobs/batch/" + testJobID + "",
+			err:    status.Error(codes.NotFound, "batch job not found"),
+			want:   http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := setupBatchRouter(t, &mockBatchClient{batchErr: tt.err})
+
+			w := serve(r, tt.method, tt.path, tt.body)
+
+			assert.Equal(t, tt.want, w.Code)
+		})
+	}
+}
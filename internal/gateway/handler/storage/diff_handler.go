@@ -0,0 +1,86 @@
+package storage
+
+import (
+	"net/http"
+
+	"github.com/a1y/doc-formatter/internal/gateway/domain/request"
+	"github.com/a1y/doc-formatter/internal/gateway/util/grpcstatus"
+	"github.com/gin-gonic/gin"
+)
+
+// DiffDocuments godoc
+//
+//	@Summary		Compare files
+//	@Description	Compare two DOCX, ODT, Markdown, HTML or text files, typically two versions of a document. The structural diff lists the paragraphs, headings, list items, quotes, code blocks and tables inserted, deleted or modified, with the fields a modification changed (text, kind, level or style). The text diff gives unified hunks of the lines of the texts.
+//	@Tags			Storage
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+//	@Param			old_file_id	query		string	true	"File ID of the old version (UUID)"
+//	@Param			new_file_id	query		string	true	"File ID of the new version (UUID)"
+//	@Param			context		query		int		false	"Unchanged lines around the changes of text hunks, 3 by default"	minimum(0)	maximum(20)
+//	@Success		200			{object}	response.DocumentDiffResponse
+//	@Failure		400			{object}	map[string]string
+//	@Failure		401			{object}	map[string]string
+//	@Failure		404			{object}	map[string]string
+//	@Failure		422			{object}	map[string]string
+//	@Failure		500			{object}	map[string]string
+//	@Router			/api/v1/storage/diff [get]
+func (h *StorageHandler) DiffDocuments(c *gin.Context) {
+	userID, ok := authUserID(c)
+	if !ok {
+		return
+	}
+
+	var req request.DiffDocumentsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.storageManager.DiffDocuments(c.Request.Context(), userID, &req)
+	if err != nil {
+		c.JSON(documentStatus(err), gin.H{"error": grpcstatus.Message(err)})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// RedlineDocuments godoc
+//
+//	@Summary		Download redline
+//	@Description	Download a DOCX of the new file showing its changes from the old one as tracked changes, which word processors can review, accept or reject.
+//	@Tags			Storage
+//	@Produce		octet-stream
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+//	@Param			old_file_id	query		string	true	"File ID of the old version (UUID)"
+//	@Param			new_file_id	query		string	true	"File ID of the new version (UUID)"
+//	@Success		200			{file}		binary
+//	@Failure		400			{object}	map[string]string
+//	@Failure		401			{object}	map[string]string
+//	@Failure		404			{object}	map[string]string
+//	@Failure		422			{object}	map[string]string
+//	@Failure		500			{object}	map[string]string
+//	@Router			/api/v1/storage/diff/redline [get]
+func (h *StorageHandler) RedlineDocuments(c *gin.Context) {
+	userID, ok := authUserID(c)
+	if !ok {
+		return
+	}
+
+	var req request.RedlineDocumentsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.storageManager.RedlineDocuments(c.Request.Context(), userID, &req)
+	if err != nil {
+		c.JSON(documentStatus(err), gin.H{"error": grpcstatus.Message(err)})
+		return
+	}
+
+	sendFile(c, resp)
+}
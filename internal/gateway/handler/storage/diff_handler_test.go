@@ -0,0 +1,130 @@
+package storage
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	storagemgr "github.com/a1y/doc-formatter/internal/gateway/manager/storage"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const testNewFileID = "9b2e4c1d-7a3f-4e8b-a6d5-0c1f2e3d4b5a"
+
+type mockDiffClient struct {
+	mockStorageClient
+
+	diffErr     error
+	lastDiff    *storagepb.DiffDocumentsRequest
+	lastRedline *storagepb.RedlineDocumentsRequest
+}
+
+func (m *mockDiffClient) DiffDocuments(_ context.Context, req *storagepb.DiffDocumentsRequest) (*storagepb.DiffDocumentsResponse, error) {
+	m.lastDiff = req
+	if m.diffErr != nil {
+		return nil, m.diffErr
+	}
+	return &storagepb.DiffDocumentsResponse{
+		OldFileId: req.OldFileId,
+		NewFileId: req.NewFileId,
+		Changes: []*storagepb.StructuralChange{{
+			Op:     "modify",
+			Old:    &storagepb.DiffBlock{Index: 0, Kind: "heading", Level: 1, Text: "Notes"},
+			New:    &storagepb.DiffBlock{Index: 0, Kind: "heading", Level: 2, Text: "Notes"},
+			Fields: []string{"level"},
+		}},
+		Summary: &storagepb.DiffSummary{Modified: 1},
+	}, nil
+}
+
+func (m *mockDiffClient) RedlineDocuments(_ context.Context, req *storagepb.RedlineDocumentsRequest) (grpc.ServerStreamingClient[storagepb.DownloadFileResponse], error) {
+	m.lastRedline = req
+	return &mockDownloadStream{msgs: m.downloadMsgs, err: m.diffErr}, nil
+}
+
+func setupDiffRouter(t *testing.T, mockClient *mockDiffClient) *gin.Engine {
+	t.Helper()
+
+	h, err := NewStorageHandler(storagemgr.NewStorageManager(mockClient, nil))
+	assert.NoError(t, err)
+	r := setupRouter(h)
+	r.GET("/api/v1/storage/diff", h.DiffDocuments)
+	r.GET("/api/v1/storage/diff/redline", h.RedlineDocuments)
+	return r
+}
+
+func TestStorageHandler_DiffDocuments(t *testing.T) {
+	mockClient := &mockDiffClient{}
+	r := setupDiffRouter(t, mockClient)
+	query := "?old_file_id=" + testFileID + "&new_file_id=" + testNewFileID
+
+	w := serve(r, http.MethodGet, "/api/v1/storage/diff"+query+"&context=0", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{
+		"old_file_id":"`+testFileID+`","new_file_id":"`+testNewFileID+`",
+		"changes":[{
+			"op":"modify",
+			"old":{"index":0,"kind":"heading","level":1,"text":"Notes"},
+			"new":{"index":0,"kind":"heading","level":2,"text":"Notes"},
+			"fields":["level"]
+		}],
+		"hunks":[],
+		"summary":{"inserted":0,"deleted":0,"modified":1,"lines_added":0,"lines_removed":0}
+	}`, w.Body.String())
+	contextLines := int32(0)
+	assert.Equal(t, &storagepb.DiffDocumentsRequest{
+		UserId:       testUserID,
+		OldFileId:    testFileID,
+		NewFileId:    testNewFileID,
+		ContextLines: &contextLines,
+	}, mockClient.lastDiff)
+
+	w = serve(r, http.MethodGet, "/api/v1/storage/diff"+query, "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Nil(t, mockClient.lastDiff.ContextLines)
+}
+
+func TestStorageHandler_RedlineDocuments(t *testing.T) {
+	mockClient := &mockDiffClient{}
+	mockClient.downloadMsgs = []*storagepb.DownloadFileResponse{
+		{FileName: "notes-redline.docx", FileSize: 4},
+		{Chunk: []byte("PK\x03\x04")},
+	}
+	r := setupDiffRouter(t, mockClient)
+
+	w := serve(r, http.MethodGet, "/api/v1/storage/diff/redline?old_file_id="+testFileID+"&new_file_id="+testNewFileID, "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "PK\x03\x04", w.Body.String())
+	assert.Equal(t, `attachment; filename=notes-redline.docx`, w.Header().Get("Content-Disposition"))
+	assert.Equal(t, &storagepb.RedlineDocumentsRequest{UserId: testUserID, OldFileId: testFileID, NewFileId: testNewFileID}, mockClient.lastRedline)
+}
+
+func TestStorageHandler_DiffDocuments_Errors(t *testing.T) {
+	mockClient := &mockDiffClient{}
+	r := setupDiffRouter(t, mockClient)
+	query := "?old_file_id=" + testFileID + "&new_file_id=" + testNewFileID
+
+	w := serve(r, http.MethodGet, "/api/v1/storage/diff?old_file_id="+testFileID, "")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = serve(r, http.MethodGet, "/api/v1/storage/diff"+query+"&context=21", "")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = serve(r, http.MethodGet, "/api/v1/storage/diff/redline?old_file_id=x&new_file_id="+testNewFileID, "")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Nil(t, mockClient.lastDiff)
+	assert.Nil(t, mockClient.lastRedline)
+
+	mockClient.diffErr = status.Error(codes.FailedPrecondition, "operation not supported for this document format: application/pdf")
+	w = serve(r, http.MethodGet, "/api/v1/storage/diff"+query, "")
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	w = serve(r, http.MethodGet, "/api/v1/storage/diff/redline"+query, "")
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+
+	mockClient.diffErr = status.Error(codes.NotFound, "document not found")
+	w = serve(r, http.MethodGet, "/api/v1/storage/diff"+query, "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
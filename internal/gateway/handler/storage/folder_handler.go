@@ -0,0 +1,266 @@
+package storage
+
+import (
+	"net/http"
+
+	"github.com/a1y/doc-formatter/internal/gateway/domain/request"
+	"github.com/a1y/doc-formatter/internal/gateway/util/grpcstatus"
+	"github.com/gin-gonic/gin"
+)
+
+// MoveFile godoc
+//
+//	@Summary		Move file
+//	@Description	Move a file into a folder, or to the top level when folder_id is omitted
+//	@Tags			Storage
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+//	@Param			id		path		string					true	"File ID (UUID)"
+//	@Param			request	body		request.MoveFileRequest	true	"Target folder"
+//	@Success		200		{object}	response.FileInfoResponse
+//	@Failure		400		{object}	map[string]string
+//	@Failure		401		{object}	map[string]string
+//	@Failure		403		{object}	map[string]string
+//	@Failure		404		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/api/v1/storage/files/{id}/folder [put]
+func (h *StorageHandler) MoveFile(c *gin.Context) {
+	userID, ok := authUserID(c)
+	if !ok {
+		return
+	}
+
+	var uri request.FileURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req request.MoveFileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.storageManager.MoveFile(c.Request.Context(), userID, uri.FileID, req.FolderID)
+	if err != nil {
+		c.JSON(grpcstatus.HTTPStatus(err), gin.H{"error": grpcstatus.Message(err)})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// CreateFolder godoc
+//
+//	@Summary		Create folder
+//	@Description	Create a folder, at the top level when parent_id is omitted
+//	@Tags			Storage
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+//	@Param			request	body		request.CreateFolderRequest	true	"Folder to create"
+//	@Success		201		{object}	response.FolderResponse
+//	@Failure		400		{object}	map[string]string
+//	@Failure		401		{object}	map[string]string
+//	@Failure		403		{object}	map[string]string
+//	@Failure		404		{object}	map[string]string
+//	@Failure		409		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/api/v1/storage/folders [post]
+func (h *StorageHandler) CreateFolder(c *gin.Context) {
+	userID, ok := authUserID(c)
+	if !ok {
+		return
+	}
+
+	var req request.CreateFolderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.storageManager.CreateFolder(c.Request.Context(), userID, req.Name, req.ParentID)
+	if err != nil {
+		c.JSON(grpcstatus.HTTPStatus(err), gin.H{"error": grpcstatus.Message(err)})
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+// RenameFolder godoc
+//
+//	@Summary		Rename folder
+//	@Description	Rename a folder owned by a user
+//	@Tags			Storage
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+//	@Param			id		path		string						true	"Folder ID (UUID)"
+//	@Param			request	body		request.RenameFolderRequest	true	"New name"
+//	@Success		200		{object}	response.FolderResponse
+//	@Failure		400		{object}	map[string]string
+//	@Failure		401		{object}	map[string]string
+//	@Failure		403		{object}	map[string]string
+//	@Failure		404		{object}	map[string]string
+//	@Failure		409		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/api/v1/storage/folders/{id}/name [put]
+func (h *StorageHandler) RenameFolder(c *gin.Context) {
+	userID, ok := authUserID(c)
+	if !ok {
+		return
+	}
+
+	var uri request.FolderURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req request.RenameFolderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.storageManager.RenameFolder(c.Request.Context(), userID, uri.FolderID, req.Name)
+	if err != nil {
+		c.JSON(grpcstatus.HTTPStatus(err), gin.H{"error": grpcstatus.Message(err)})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// MoveFolder godoc
+//
+//	@Summary		Move folder
+//	@Description	Move a folder under another folder, or to the top level when parent_id is omitted
+//	@Tags			Storage
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+//	@Param			id		path		string						true	"Folder ID (UUID)"
+//	@Param			request	body		request.MoveFolderRequest	true	"New parent folder"
+//	@Success		200		{object}	response.FolderResponse
+//	@Failure		400		{object}	map[string]string
+//	@Failure		401		{object}	map[string]string
+//	@Failure		403		{object}	map[string]string
+//	@Failure		404		{object}	map[string]string
+//	@Failure		409		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/api/v1/storage/folders/{id}/parent [put]
+func (h *StorageHandler) MoveFolder(c *gin.Context) {
+	userID, ok := authUserID(c)
+	if !ok {
+		return
+	}
+
+	var uri request.FolderURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req request.MoveFolderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.storageManager.MoveFolder(c.Request.Context(), userID, uri.FolderID, req.ParentID)
+	if err != nil {
+		c.JSON(grpcstatus.HTTPStatus(err), gin.H{"error": grpcstatus.Message(err)})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// DeleteFolder godoc
+//
+//	@Summary		Delete folder
+//	@Description	Delete a folder. A folder that is not empty is only deleted when recursive is true, in which case its subfolders are deleted and its files moved into the trash.
+//	@Tags			Storage
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+//	@Param			id			path		string	true	"Folder ID (UUID)"
+//	@Param			recursive	query		bool	false	"Delete the subfolders and trash the files too"
+//	@Success		200			{object}	response.DeleteFolderResponse
+//	@Failure		400			{object}	map[string]string
+//	@Failure		401			{object}	map[string]string
+//	@Failure		403			{object}	map[string]string
+//	@Failure		404			{object}	map[string]string
+//	@Failure		412			{object}	map[string]string
+//	@Failure		500			{object}	map[string]string
+//	@Router			/api/v1/storage/folders/{id} [delete]
+func (h *StorageHandler) DeleteFolder(c *gin.Context) {
+	userID, ok := authUserID(c)
+	if !ok {
+		return
+	}
+
+	var uri request.FolderURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req request.DeleteFolderRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.storageManager.DeleteFolder(c.Request.Context(), userID, uri.FolderID, req.Recursive)
+	if err != nil {
+		c.JSON(grpcstatus.HTTPStatus(err), gin.H{"error": grpcstatus.Message(err)})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// ListFolder godoc
+//
+//	@Summary		List folder
+//	@Description	List the folders and files directly inside a folder, or at the top level when folder_id is omitted
+//	@Tags			Storage
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+//	@Param			folder_id	query		string	false	"Folder ID (UUID)"
+//	@Success		200			{object}	response.ListFolderResponse
+//	@Failure		400			{object}	map[string]string
+//	@Failure		401			{object}	map[string]string
+//	@Failure		404			{object}	map[string]string
+//	@Failure		500			{object}	map[string]string
+//	@Router			/api/v1/storage/list [get]
+func (h *StorageHandler) ListFolder(c *gin.Context) {
+	userID, ok := authUserID(c)
+	if !ok {
+		return
+	}
+
+	var req request.ListFolderRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.storageManager.ListFolder(c.Request.Context(), userID, req.FolderID)
+	if err != nil {
+		c.JSON(grpcstatus.HTTPStatus(err), gin.H{"error": grpcstatus.Message(err)})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,236 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/a1y/doc-formatter/internal/gateway/domain/response"
+	storagemgr "github.com/a1y/doc-formatter/internal/gateway/manager/storage"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	testUserID   = "550e8400-e29b-41d4-a716-446655440000"
+	testFolderID = "6ba7b811-9dad-11d1-80b4-00c04fd430c8"
+	testFileID   = "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+)
+
+type mockFolderClient struct {
+	mockStorageClient
+
+	folderErr  error
+	lastFolder any
+}
+
+func (m *mockFolderClient) MoveFile(_ context.Context, req *storagepb.MoveFileRequest) (*storagepb.MoveFileResponse, error) {
+	m.lastFolder = req
+	if m.folderErr != nil {
+		return nil, m.folderErr
+	}
+	return &storagepb.MoveFileResponse{File: &storagepb.FileInfo{FileId: req.GetFileId(), FileName: "a.txt", FolderId: req.GetFolderId()}}, nil
+}
+
+func (m *mockFolderClient) CreateFolder(_ context.Context, req *storagepb.CreateFolderRequest) (*storagepb.CreateFolderResponse, error) {
+	m.lastFolder = req
+	if m.folderErr != nil {
+		return nil, m.folderErr
+	}
+	return &storagepb.CreateFolderResponse{Folder: &storagepb.Folder{FolderId: testFolderID, Name: req.GetName(), ParentId: req.GetParentId()}}, nil
+}
+
+func (m *mockFolderClient) RenameFolder(_ context.Context, req *storagepb.RenameFolderRequest) (*storagepb.RenameFolderResponse, error) {
+	m.lastFolder = req
+	if m.folderErr != nil {
+		return nil, m.folderErr
+	}
+	return &storagepb.RenameFolderResponse{Folder: &storagepb.Folder{FolderId: req.GetFolderId(), Name: req.GetName()}}, nil
+}
+
+func (m *mockFolderClient) MoveFolder(_ context.Context, req *storagepb.MoveFolderRequest) (*storagepb.MoveFolderResponse, error) {
+	m.lastFolder = req
+	if m.folderErr != nil {
+		return nil, m.folderErr
+	}
+	return &storagepb.MoveFolderResponse{Folder: &storagepb.Folder{FolderId: req.GetFolderId(), Name: "reports", ParentId: req.GetParentId()}}, nil
+}
+
+func (m *mockFolderClient) DeleteFolder(_ context.Context, req *storagepb.DeleteFolderRequest) (*storagepb.DeleteFolderResponse, error) {
+	m.lastFolder = req
+	if m.folderErr != nil {
+		return nil, m.folderErr
+	}
+	return &storagepb.DeleteFolderResponse{DeletedFolders: 1, DeletedFiles: 4}, nil
+}
+
+func (m *mockFolderClient) ListFolder(_ context.Context, req *storagepb.ListFolderRequest) (*storagepb.ListFolderResponse, error) {
+	m.lastFolder = req
+	if m.folderErr != nil {
+		return nil, m.folderErr
+	}
+	return &storagepb.ListFolderResponse{
+		Folders: []*storagepb.Folder{{FolderId: testFolderID, Name: "reports"}},
+		Files:   []*storagepb.FileInfo{{FileId: testFileID, FileName: "a.txt", FileSize: 3, CreatedAtUnix: 1767225600, UpdatedAtUnix: 1767225600}},
+	}, nil
+}
+
+func setupFolderRouter(t *testing.T, mockClient *mockFolderClient) *gin.Engine {
+	t.Helper()
+
+	h, err := NewStorageHandler(storagemgr.NewStorageManager(mockClient, nil))
+	assert.NoError(t, err)
+	r := setupRouter(h)
+	r.PUT("/api/v1/storage/files/:id/folder", h.MoveFile)
+	r.GET("/api/v1/storage/list", h.ListFolder)
+	r.POST("/api/v1/storage/folders", h.CreateFolder)
+	r.PUT("/api/v1/storage/folders/:id/name", h.RenameFolder)
+	r.PUT("/api/v1/storage/folders/:id/parent", h.MoveFolder)
+	r.DELETE("/api/v1/storage/folders/:id", h.DeleteFolder)
+	return r
+}
+
+func serve(r *gin.Engine, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestStorageHandler_CreateFolder(t *testing.T) {
+	mockClient := &mockFolderClient{}
+	r := setupFolderRouter(t, mockClient)
+
+	w := serve(r, http.MethodPost, "/api/v1/storage/folders", `{"name":"reports"}`)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	var resp response.FolderResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, response.FolderResponse{FolderID: testFolderID, Name: "reports"}, resp)
+	assert.Equal(t, &storagepb.CreateFolderRequest{UserId: testUserID, Name: "reports"}, mockClient.lastFolder)
+}
+
+func TestStorageHandler_FolderUpdates(t *testing.T) {
+	mockClient := &mockFolderClient{}
+	r := setupFolderRouter(t, mockClient)
+
+	w := serve(r, http.MethodPut, "/api/v1/storage/folders/"+testFolderID+"/name", `{"name":"archive"}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, &storagepb.RenameFolderRequest{UserId: testUserID, FolderId: testFolderID, Name: "archive"}, mockClient.lastFolder)
+
+	w = serve(r, http.MethodPut, "/api/v1/storage/folders/"+testFolderID+"/parent", `{}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, &storagepb.MoveFolderRequest{UserId: testUserID, FolderId: testFolderID}, mockClient.lastFolder)
+
+	w = serve(r, http.MethodPut, "/api/v1/storage/files/"+testFileID+"/folder", `{"folder_id":"`+testFolderID+`"}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, &storagepb.MoveFileRequest{UserId: testUserID, FileId: testFileID, FolderId: testFolderID}, mockClient.lastFolder)
+}
+
+func TestStorageHandler_DeleteFolder(t *testing.T) {
+	mockClient := &mockFolderClient{}
+	r := setupFolderRouter(t, mockClient)
+
+	w := serve(r, http.MethodDelete, "/api/v1/storage/folders/"+testFolderID+"?recursive=true", "")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"deleted_folders":1,"deleted_files":4}`, w.Body.String())
+	assert.Equal(t, &storagepb.DeleteFolderRequest{UserId: testUserID, FolderId: testFolderID, Recursive: true}, mockClient.lastFolder)
+}
+
+func TestStorageHandler_ListFolder(t *testing.T) {
+	mockClient := &mockFolderClient{}
+	r := setupFolderRouter(t, mockClient)
+
+	w := serve(r, http.MethodGet, "/api/v1/storage/list?folder_id="+testFolderID, "")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{
+		"folders": [{"folder_id":"`+testFolderID+`","name":"reports"}],
+		"files": [{"file_id":"`+testFileID+`","file_name":"a.txt","file_size":3,"created_at":"2026-01-01T00:00:00Z","updated_at":"2026-01-01T00:00:00Z"}]
+	}`, w.Body.String())
+	assert.Equal(t, &storagepb.ListFolderRequest{UserId: testUserID, FolderId: testFolderID}, mockClient.lastFolder)
+}
+
+func TestStorageHandler_FolderErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		err    error
+		want   int
+	}{
+		{
+			name:   "missing folder name",
+			method: http.MethodPost,
+			path:   "/api/v1/storage/folders",
+			body:   `{}`,
+			want:   http.StatusBadRequest,
+		},
+		{
+			name:   "invalid parent id",
+			method: http.MethodPost,
+			path:   "/api/v1/storage/folders",
+			body:   `{"name":"reports","parent_id":"nope"}`,
+			want:   http.StatusBadRequest,
+		},
+		{
+			name:   "name conflict",
+			method: http.MethodPost,
+			path:   "/api/v1/storage/folders",
+			body:   `{"name":"reports"}`,
+			err:    status.Error(codes.AlreadyExists, "folder name already in use"),
+			want:   http.StatusConflict,
+		},
+		{
+			name:   "invalid folder id",
+			method: http.MethodPut,
+			path:   "/api/v1/storage/folders/not-a-uuid/name",
+			body:   `{"name":"reports"}`,
+			want:   http.StatusBadRequest,
+		},
+		{
+			name:   "move into own subfolder",
+			method: http.MethodPut,
+			path:   "/api/v1/storage/folders/" + testFolderID + "/parent",
+			body:   `{"parent_id":"` + testFolderID + `"}`,
+			err:    status.Error(codes.InvalidArgument, "folder cannot be moved into itself"),
+			want:   http.StatusBadRequest,
+		},
+		{
+			name:   "file not found",
+			method: http.MethodPut,
+			path:   "/api/v1/storage/files/" + testFileID + "/folder",
+			body:   `{}`,
+			err:    status.Error(codes.NotFound, "document not found"),
+			want:   http.StatusNotFound,
+		},
+		{
+			name:   "delete non-empty folder",
+			method: http.MethodDelete,
+			path:   "/api/v1/storage/folders/" + testFolderID,
+			err:    status.Error(codes.FailedPrecondition, "folder is not empty"),
+			want:   http.StatusPreconditionFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := setupFolderRouter(t, &mockFolderClient{folderErr: tt.err})
+
+			w := serve(r, tt.method, tt.path, tt.body)
+
+			assert.Equal(t, tt.want, w.Code)
+		})
+	}
+}
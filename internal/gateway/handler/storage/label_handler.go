@@ -0,0 +1,251 @@
+package storage
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/a1y/doc-formatter/internal/gateway/domain/request"
+	"github.com/a1y/doc-formatter/internal/gateway/util/grpcstatus"
+	"github.com/gin-gonic/gin"
+)
+
+// AddFileTags godoc
+//
+//	@Summary		Add file tags
+//	@Description	Add tags to a file. Tags the file already has are kept once.
+//	@Tags			Storage
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+//	@Param			id		path		string						true	"File ID (UUID)"
+//	@Param			request	body		request.AddFileTagsRequest	true	"Tags to add"
+//	@Success		200		{object}	response.FileInfoResponse
+//	@Failure		400		{object}	map[string]string
+//	@Failure		401		{object}	map[string]string
+//	@Failure		403		{object}	map[string]string
+//	@Failure		404		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/api/v1/storage/files/{id}/tags [post]
+func (h *StorageHandler) AddFileTags(c *gin.Context) {
+	userID, ok := authUserID(c)
+	if !ok {
+		return
+	}
+
+	var uri request.FileURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req request.AddFileTagsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.storageManager.AddFileTags(c.Request.Context(), userID, uri.FileID, req.Tags)
+	if err != nil {
+		c.JSON(grpcstatus.HTTPStatus(err), gin.H{"error": grpcstatus.Message(err)})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// RemoveFileTags godoc
+//
+//	@Summary		Remove file tags
+//	@Description	Remove tags from a file. Tags the file does not have are ignored.
+//	@Tags			Storage
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+//	@Param			id	path		string		true	"File ID (UUID)"
+//	@Param			tag	query		[]string	true	"Tags to remove"	collectionFormat(multi)
+//	@Success		200	{object}	response.FileInfoResponse
+//	@Failure		400	{object}	map[string]string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/api/v1/storage/files/{id}/tags [delete]
+func (h *StorageHandler) RemoveFileTags(c *gin.Context) {
+	userID, ok := authUserID(c)
+	if !ok {
+		return
+	}
+
+	var uri request.FileURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req request.RemoveFileTagsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.storageManager.RemoveFileTags(c.Request.Context(), userID, uri.FileID, req.Tags)
+	if err != nil {
+		c.JSON(grpcstatus.HTTPStatus(err), gin.H{"error": grpcstatus.Message(err)})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// SetFileMetadata godoc
+//
+//	@Summary		Set file metadata
+//	@Description	Merge key/value pairs into the metadata of a file, overwriting keys that already exist
+//	@Tags			Storage
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+//	@Param			id		path		string							true	"File ID (UUID)"
+//	@Param			request	body		request.SetFileMetadataRequest	true	"Metadata to set"
+//	@Success		200		{object}	response.FileInfoResponse
+//	@Failure		400		{object}	map[string]string
+//	@Failure		401		{object}	map[string]string
+//	@Failure		403		{object}	map[string]string
+//	@Failure		404		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/api/v1/storage/files/{id}/metadata [put]
+func (h *StorageHandler) SetFileMetadata(c *gin.Context) {
+	userID, ok := authUserID(c)
+	if !ok {
+		return
+	}
+
+	var uri request.FileURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req request.SetFileMetadataRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.storageManager.SetFileMetadata(c.Request.Context(), userID, uri.FileID, req.Metadata)
+	if err != nil {
+		c.JSON(grpcstatus.HTTPStatus(err), gin.H{"error": grpcstatus.Message(err)})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// RemoveFileMetadata godoc
+//
+//	@Summary		Remove file metadata
+//	@Description	Remove metadata keys from a file. Keys the file does not have are ignored.
+//	@Tags			Storage
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+//	@Param			id	path		string		true	"File ID (UUID)"
+//	@Param			key	query		[]string	true	"Metadata keys to remove"	collectionFormat(multi)
+//	@Success		200	{object}	response.FileInfoResponse
+//	@Failure		400	{object}	map[string]string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/api/v1/storage/files/{id}/metadata [delete]
+func (h *StorageHandler) RemoveFileMetadata(c *gin.Context) {
+	userID, ok := authUserID(c)
+	if !ok {
+		return
+	}
+
+	var uri request.FileURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req request.RemoveFileMetadataRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.storageManager.RemoveFileMetadata(c.Request.Context(), userID, uri.FileID, req.Keys)
+	if err != nil {
+		c.JSON(grpcstatus.HTTPStatus(err), gin.H{"error": grpcstatus.Message(err)})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// ListFiles godoc
+//
+//	@Summary		List files
+//	@Description	List a page of the files of a user matching all given filters. Files in every folder are listed when folder_id is omitted. Metadata filters are passed as metadata[key]=value. The next page is fetched by passing next_page_token as page_token, with the same sort and order; its URL is also given by the Link header.
+//	@Tags			Storage
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+//	@Param			folder_id		query		string		false	"Folder ID (UUID)"
+//	@Param			tag				query		[]string	false	"Tags the files must all have"	collectionFormat(multi)
+//	@Param			content_type	query		string		false	"Content type, exact or a wildcard such as image/*"
+//	@Param			min_size		query		int			false	"Minimum file size in bytes"
+//	@Param			max_size		query		int			false	"Maximum file size in bytes"
+//	@Param			created_after	query		string		false	"Only files created at or after this time (RFC 3339)"
+//	@Param			created_before	query		string		false	"Only files created at or before this time (RFC 3339)"
+//	@Param			page_size		query		int			false	"Maximum number of files of the page, 100 by default and at most 1000"
+//	@Param			page_token		query		string		false	"Token of the page to list, from next_page_token"
+//	@Param			sort			query		string		false	"Field to sort by, name by default"	Enums(name, size, created, updated)
+//	@Param			order			query		string		false	"Sort order, asc by default"		Enums(asc, desc)
+//	@Success		200				{object}	response.ListFilesResponse
+//	@Header			200				{string}	Link	"URL of the next page with rel=next, absent on the last page"
+//	@Failure		400				{object}	map[string]string
+//	@Failure		401				{object}	map[string]string
+//	@Failure		404				{object}	map[string]string
+//	@Failure		500				{object}	map[string]string
+//	@Router			/api/v1/storage/files [get]
+func (h *StorageHandler) ListFiles(c *gin.Context) {
+	userID, ok := authUserID(c)
+	if !ok {
+		return
+	}
+
+	var req request.ListFilesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if metadata := c.QueryMap("metadata"); len(metadata) > 0 {
+		req.Metadata = metadata
+	}
+
+	resp, err := h.storageManager.ListFiles(c.Request.Context(), userID, &req)
+	if err != nil {
+		c.JSON(grpcstatus.HTTPStatus(err), gin.H{"error": grpcstatus.Message(err)})
+		return
+	}
+
+	if resp.NextPageToken != "" {
+		c.Header("Link", nextPageLink(c.Request.URL, resp.NextPageToken))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// nextPageLink returns a Link header value pointing at the request URL u
+// with its page token replaced by token.
+func nextPageLink(u *url.URL, token string) string {
+	query := u.Query()
+	query.Set("page_token", token)
+	next := url.URL{Path: u.Path, RawQuery: query.Encode()}
+	return fmt.Sprintf(`<%s>; rel="next"`, next.String())
+}
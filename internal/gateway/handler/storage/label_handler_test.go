@@ -0,0 +1,267 @@
+package storage
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	storagemgr "github.com/a1y/doc-formatter/internal/gateway/manager/storage"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+type mockLabelClient struct {
+	mockStorageClient
+
+	labelErr      error
+	lastLabel     any
+	nextPageToken string
+}
+
+func labeledFile(fileID string) *storagepb.FileInfo {
+	return &storagepb.FileInfo{
+		FileId:        fileID,
+		FileName:      "report.pdf",
+		FileSize:      42,
+		ContentType:   "application/pdf",
+		Tags:          []string{"finance"},
+		Metadata:      map[string]string{"owner": "legal"},
+		CreatedAtUnix: 1767225600,
+		UpdatedAtUnix: 1767225600,
+	}
+}
+
+func (m *mockLabelClient) AddFileTags(_ context.Context, req *storagepb.AddFileTagsRequest) (*storagepb.AddFileTagsResponse, error) {
+	m.lastLabel = req
+	if m.labelErr != nil {
+		return nil, m.labelErr
+	}
+	return &storagepb.AddFileTagsResponse{File: labeledFile(req.GetFileId())}, nil
+}
+
+func (m *mockLabelClient) RemoveFileTags(_ context.Context, req *storagepb.RemoveFileTagsRequest) (*storagepb.RemoveFileTagsResponse, error) {
+	m.lastLabel = req
+	if m.labelErr != nil {
+		return nil, m.labelErr
+	}
+	return &storagepb.RemoveFileTagsResponse{File: labeledFile(req.GetFileId())}, nil
+}
+
+func (m *mockLabelClient) SetFileMetadata(_ context.Context, req *storagepb.SetFileMetadataRequest) (*storagepb.SetFileMetadataResponse, error) {
+	m.lastLabel = req
+	if m.labelErr != nil {
+		return nil, m.labelErr
+	}
+	return &storagepb.SetFileMetadataResponse{File: labeledFile(req.GetFileId())}, nil
+}
+
+func (m *mockLabelClient) RemoveFileMetadata(_ context.Context, req *storagepb.RemoveFileMetadataRequest) (*storagepb.RemoveFileMetadataResponse, error) {
+	m.lastLabel = req
+	if m.labelErr != nil {
+		return nil, m.labelErr
+	}
+	return &storagepb.RemoveFileMetadataResponse{File: labeledFile(req.GetFileId())}, nil
+}
+
+func (m *mockLabelClient) ListFiles(_ context.Context, req *storagepb.ListFilesRequest) (*storagepb.ListFilesResponse, error) {
+	m.lastLabel = req
+	if m.labelErr != nil {
+		return nil, m.labelErr
+	}
+	return &storagepb.ListFilesResponse{Files: []*storagepb.FileInfo{labeledFile(testFileID)}, NextPageToken: m.nextPageToken}, nil
+}
+
+func setupLabelRouter(t *testing.T, mockClient *mockLabelClient) *gin.Engine {
+	t.Helper()
+
+	h, err := NewStorageHandler(storagemgr.NewStorageManager(mockClient, nil))
+	assert.NoError(t, err)
+	r := setupRouter(h)
+	r.GET("/api/v1/storage/files", h.ListFiles)
+	r.POST("/api/v1/storage/files/:id/tags", h.AddFileTags)
+	r.DELETE("/api/v1/storage/files/:id/tags", h.RemoveFileTags)
+	r.PUT("/api/v1/storage/files/:id/metadata", h.SetFileMetadata)
+	r.DELETE("/api/v1/storage/files/:id/metadata", h.RemoveFileMetadata)
+	return r
+}
+
+const testLabeledFileJSON = `{
+	"file_id":"` + testFileID + `",
+	"file_name":"report.pdf",
+	"file_size":42,
+	"content_type":"application/pdf",
+	"tags":["finance"],
+	"metadata":{"owner":"legal"},
+	"created_at":"2026-01-01T00:00:00Z","updated_at":"2026-01-01T00:00:00Z"
+}`
+
+func TestStorageHandler_FileLabels(t *testing.T) {
+	mockClient := &mockLabelClient{}
+	r := setupLabelRouter(t, mockClient)
+
+	w := serve(r, http.MethodPost, "/api/v1/storage/files/"+testFileID+"/tags", `{"tags":["finance"]}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, testLabeledFileJSON, w.Body.String())
+	assert.Equal(t, &storagepb.AddFileTagsRequest{UserId: testUserID, FileId: testFileID, Tags: []string{"finance"}}, mockClient.lastLabel)
+
+	w = serve(r, http.MethodDelete, "/api/v1/storage/files/"+testFileID+"/tags?tag=draft&tag=old", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, &storagepb.RemoveFileTagsRequest{UserId: testUserID, FileId: testFileID, Tags: []string{"draft", "old"}}, mockClient.lastLabel)
+
+	w = serve(r, http.MethodPut, "/api/v1/storage/files/"+testFileID+"/metadata", `{"metadata":{"owner":"legal"}}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, &storagepb.SetFileMetadataRequest{UserId: testUserID, FileId: testFileID, Metadata: map[string]string{"owner": "legal"}}, mockClient.lastLabel)
+
+	w = serve(r, http.MethodDelete, "/api/v1/storage/files/"+testFileID+"/metadata?key=status", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, &storagepb.RemoveFileMetadataRequest{UserId: testUserID, FileId: testFileID, Keys: []string{"status"}}, mockClient.lastLabel)
+}
+
+func TestStorageHandler_ListFiles(t *testing.T) {
+	mockClient := &mockLabelClient{}
+	r := setupLabelRouter(t, mockClient)
+
+	w := serve(r, http.MethodGet, "/api/v1/storage/files?tag=finance&tag=2026&metadata[owner]=legal&content_type=image/*"+
+		"&min_size=1&max_size=1024&created_after=2026-01-01T00:00:00Z&created_before=2026-02-01T00:00:00Z", "")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"files":[`+testLabeledFileJSON+`]}`, w.Body.String())
+	assert.Empty(t, w.Header().Get("Link"))
+	assert.Equal(t, &storagepb.ListFilesRequest{
+		UserId:            testUserID,
+		Tags:              []string{"finance", "2026"},
+		Metadata:          map[string]string{"owner": "legal"},
+		ContentType:       "image/*",
+		MinSize:           proto.Int64(1),
+		MaxSize:           proto.Int64(1024),
+		CreatedAfterUnix:  proto.Int64(1767225600),
+		CreatedBeforeUnix: proto.Int64(1769904000),
+	}, mockClient.lastLabel)
+}
+
+func TestStorageHandler_ListFiles_Pages(t *testing.T) {
+	mockClient := &mockLabelClient{nextPageToken: "next-token"}
+	r := setupLabelRouter(t, mockClient)
+
+	w := serve(r, http.MethodGet, "/api/v1/storage/files?tag=finance&page_size=1&page_token=first&sort=created&order=desc", "")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"files":[`+testLabeledFileJSON+`],"next_page_token":"next-token"}`, w.Body.String())
+	assert.Equal(t,
+		`</api/v1/storage/files?order=desc&page_size=1&page_token=next-token&sort=created&tag=finance>; rel="next"`,
+		w.Header().Get("Link"))
+	assert.Equal(t, &storagepb.ListFilesRequest{
+		UserId:     testUserID,
+		Tags:       []string{"finance"},
+		PageSize:   1,
+		PageToken:  "first",
+		Sort:       "created",
+		Descending: true,
+	}, mockClient.lastLabel)
+}
+
+func TestStorageHandler_LabelErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		err    error
+		want   int
+	}{
+		{
+			name:   "add without tags",
+			method: http.MethodPost,
+			path:   "/api/v1/storage/files/" + testFileID + "/tags",
+			body:   `{"tags":[]}`,
+			want:   http.StatusBadRequest,
+		},
+		{
+			name:   "invalid file id",
+			method: http.MethodPost,
+			path:   "/api/v1/storage/files/not-a-uuid/tags",
+			body:   `{"tags":["finance"]}`,
+			want:   http.StatusBadRequest,
+		},
+		{
+			name:   "invalid tag",
+			method: http.MethodPost,
+			path:   "/api/v1/storage/files/" + testFileID + "/tags",
+			body:   `{"tags":[" "]}`,
+			err:    status.Error(codes.InvalidArgument, "invalid tags"),
+			want:   http.StatusBadRequest,
+		},
+		{
+			name:   "remove tags without tag",
+			method: http.MethodDelete,
+			path:   "/api/v1/storage/files/" + testFileID + "/tags",
+			want:   http.StatusBadRequest,
+		},
+		{
+			name:   "set metadata without entries",
+			method: http.MethodPut,
+			path:   "/api/v1/storage/files/" + testFileID + "/metadata",
+			body:   `{"metadata":{}}`,
+			want:   http.StatusBadRequest,
+		},
+		{
+			name:   "file not found",
+			method: http.MethodDelete,
+			path:   "/api/v1/storage/files/" + testFileID + "/metadata?key=status",
+			err:    status.Error(codes.NotFound, "document not found"),
+			want:   http.StatusNotFound,
+		},
+		{
+			name:   "list with negative size",
+			method: http.MethodGet,
+			path:   "/api/v1/storage/files?min_size=-1",
+			want:   http.StatusBadRequest,
+		},
+		{
+			name:   "list with invalid time",
+			method: http.MethodGet,
+			path:   "/api/v1/storage/files?created_after=yesterday",
+			want:   http.StatusBadRequest,
+		},
+		{
+			name:   "list with unknown sort",
+			method: http.MethodGet,
+			path:   "/api/v1/storage/files?sort=rating",
+			want:   http.StatusBadRequest,
+		},
+		{
+			name:   "list with unknown order",
+			method: http.MethodGet,
+			path:   "/api/v1/storage/files?order=up",
+			want:   http.StatusBadRequest,
+		},
+		{
+			name:   "list with invalid page token",
+			method: http.MethodGet,
+			path:   "/api/v1/storage/files?page_token=garbage",
+			err:    status.Error(codes.InvalidArgument, "invalid page token"),
+			want:   http.StatusBadRequest,
+		},
+		{
+			name:   "list with invalid filter",
+			method: http.MethodGet,
+			path:   "/api/v1/storage/files?content_type=*/*",
+			err:    status.Error(codes.InvalidArgument, "invalid filter"),
+			want:   http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := setupLabelRouter(t, &mockLabelClient{labelErr: tt.err})
+
+			w := serve(r, tt.method, tt.path, tt.body)
+
+			assert.Equal(t, tt.want, w.Code)
+		})
+	}
+}
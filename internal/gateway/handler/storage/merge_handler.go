@@ -0,0 +1,128 @@
+package storage
+
+import (
+	"net/http"
+
+	"github.com/a1y/doc-formatter/internal/gateway/domain/request"
+	"github.com/a1y/doc-formatter/internal/gateway/util/grpcstatus"
+	"github.com/gin-gonic/gin"
+)
+
+// MergeDocuments godoc
+//
+//	@Summary		Merge files
+//	@Description	Make a new file of the content of 2 to 100 DOCX or Markdown files of the same format, in the order given. The page setup, headers and styles of the first file win; styles the others use that it lacks are copied, and lists and images are carried over. Markdown keeps the front matter of the first file only. The new file records the files it was made from, see the provenance route.
+//	@Tags			Storage
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+//	@Param			request	body		request.MergeDocumentsRequest	true	"Files to merge"
+//	@Success		201		{object}	response.FileInfoResponse
+//	@Failure		400		{object}	map[string]string
+//	@Failure		401		{object}	map[string]string
+//	@Failure		404		{object}	map[string]string
+//	@Failure		422		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/api/v1/storage/merge [post]
+func (h *StorageHandler) MergeDocuments(c *gin.Context) {
+	userID, ok := authUserID(c)
+	if !ok {
+		return
+	}
+
+	var req request.MergeDocumentsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.storageManager.MergeDocuments(c.Request.Context(), userID, &req)
+	if err != nil {
+		c.JSON(documentStatus(err), gin.H{"error": grpcstatus.Message(err)})
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+// SplitDocument godoc
+//
+//	@Summary		Split file
+//	@Description	Cut a DOCX or Markdown file before each of its headings of heading_level or above into new files, named after their number and heading. Content before the first heading makes a file named after the source. Each new file records the file it was cut from.
+//	@Tags			Storage
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+//	@Param			id		path		string							true	"File ID (UUID)"
+//	@Param			request	body		request.SplitDocumentRequest	true	"Heading level and target folder"
+//	@Success		201		{object}	response.SplitDocumentResponse
+//	@Failure		400		{object}	map[string]string
+//	@Failure		401		{object}	map[string]string
+//	@Failure		404		{object}	map[string]string
+//	@Failure		422		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/api/v1/storage/files/{id}/split [post]
+func (h *StorageHandler) SplitDocument(c *gin.Context) {
+	userID, ok := authUserID(c)
+	if !ok {
+		return
+	}
+
+	var uri request.FileURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req request.SplitDocumentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.storageManager.SplitDocument(c.Request.Context(), userID, uri.FileID, &req)
+	if err != nil {
+		c.JSON(documentStatus(err), gin.H{"error": grpcstatus.Message(err)})
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+// GetProvenance godoc
+//
+//	@Summary		Get file provenance
+//	@Description	List the files a file was merged or split from, and the files merged or split from it. Files in the trash or that the user cannot view are left out.
+//	@Tags			Storage
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+//	@Param			id	path		string	true	"File ID (UUID)"
+//	@Success		200	{object}	response.ProvenanceResponse
+//	@Failure		400	{object}	map[string]string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/api/v1/storage/files/{id}/provenance [get]
+func (h *StorageHandler) GetProvenance(c *gin.Context) {
+	userID, ok := authUserID(c)
+	if !ok {
+		return
+	}
+
+	var uri request.FileURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.storageManager.GetProvenance(c.Request.Context(), userID, uri.FileID)
+	if err != nil {
+		c.JSON(grpcstatus.HTTPStatus(err), gin.H{"error": grpcstatus.Message(err)})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
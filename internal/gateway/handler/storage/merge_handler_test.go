@@ -0,0 +1,181 @@
+package storage
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	storagemgr "github.com/a1y/doc-formatter/internal/gateway/manager/storage"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type mockMergeClient struct {
+	mockStorageClient
+
+	mergeErr  error
+	lastMerge any
+}
+
+func mergedFile(fileID string) *storagepb.FileInfo {
+	return &storagepb.FileInfo{
+		FileId:        fileID,
+		FileName:      "report.md",
+		FileSize:      42,
+		ContentType:   "text/markdown",
+		CreatedAtUnix: 1767225600,
+		UpdatedAtUnix: 1767225600,
+	}
+}
+
+func (m *mockMergeClient) MergeDocuments(_ context.Context, req *storagepb.MergeDocumentsRequest) (*storagepb.MergeDocumentsResponse, error) {
+	m.lastMerge = req
+	if m.mergeErr != nil {
+		return nil, m.mergeErr
+	}
+	return &storagepb.MergeDocumentsResponse{File: mergedFile(testFileID)}, nil
+}
+
+func (m *mockMergeClient) SplitDocument(_ context.Context, req *storagepb.SplitDocumentRequest) (*storagepb.SplitDocumentResponse, error) {
+	m.lastMerge = req
+	if m.mergeErr != nil {
+		return nil, m.mergeErr
+	}
+	return &storagepb.SplitDocumentResponse{Files: []*storagepb.FileInfo{mergedFile(testFileID)}}, nil
+}
+
+func (m *mockMergeClient) GetProvenance(_ context.Context, req *storagepb.GetProvenanceRequest) (*storagepb.GetProvenanceResponse, error) {
+	m.lastMerge = req
+	if m.mergeErr != nil {
+		return nil, m.mergeErr
+	}
+	return &storagepb.GetProvenanceResponse{
+		Derived: []*storagepb.ProvenanceLink{{File: mergedFile(testFileID), Operation: "split", Position: 2, CreatedAtUnix: 1767225600}},
+	}, nil
+}
+
+func setupMergeRouter(t *testing.T, mockClient *mockMergeClient) *gin.Engine {
+	t.Helper()
+
+	h, err := NewStorageHandler(storagemgr.NewStorageManager(mockClient, nil))
+	assert.NoError(t, err)
+	r := setupRouter(h)
+	r.POST("/api/v1/storage/merge", h.MergeDocuments)
+	r.POST("/api/v1/storage/files/:id/split", h.SplitDocument)
+	r.GET("/api/v1/storage/files/:id/provenance", h.GetProvenance)
+	return r
+}
+
+const testMergedFileJSON = `{
+	"file_id":"` + testFileID + `",
+	"file_name":"report.md",
+	"file_size":42,
+	"content_type":"text/markdown",
+	"created_at":"2026-01-01T00:00:00Z","updated_at":"2026-01-01T00:00:00Z"
+}`
+
+func TestStorageHandler_MergeAndSplit(t *testing.T) {
+	mockClient := &mockMergeClient{}
+	r := setupMergeRouter(t, mockClient)
+	otherID := "8f14e45f-ceea-467a-9af0-2f1e3f0c6a71"
+
+	w := serve(r, http.MethodPost, "/api/v1/storage/merge",
+		`{"source_file_ids":["`+testFileID+`","`+otherID+`"],"file_name":"report.md","page_breaks":true}`)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.JSONEq(t, testMergedFileJSON, w.Body.String())
+	assert.Equal(t, &storagepb.MergeDocumentsRequest{
+		UserId:        testUserID,
+		SourceFileIds: []string{testFileID, otherID},
+		FileName:      "report.md",
+		PageBreaks:    true,
+	}, mockClient.lastMerge)
+
+	w = serve(r, http.MethodPost, "/api/v1/storage/files/"+testFileID+"/split", `{"heading_level":2}`)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.JSONEq(t, `{"files":[`+testMergedFileJSON+`]}`, w.Body.String())
+	assert.Equal(t, &storagepb.SplitDocumentRequest{UserId: testUserID, FileId: testFileID, HeadingLevel: 2}, mockClient.lastMerge)
+
+	w = serve(r, http.MethodGet, "/api/v1/storage/files/"+testFileID+"/provenance", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{
+		"sources":[],
+		"derived":[{"file":`+testMergedFileJSON+`,"operation":"split","position":2,"created_at":"2026-01-01T00:00:00Z"}]
+	}`, w.Body.String())
+	assert.Equal(t, &storagepb.GetProvenanceRequest{UserId: testUserID, FileId: testFileID}, mockClient.lastMerge)
+}
+
+func TestStorageHandler_MergeErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		err    error
+		want   int
+	}{
+		{
+			name:   "merge a single file",
+			method: http.MethodPost,
+			path:   "/api/v1/storage/merge",
+			body:   `{"source_file_ids":["` + testFileID + `"]}`,
+			want:   http.StatusBadRequest,
+		},
+		{
+			name:   "merge invalid file id",
+			method: http.MethodPost,
+			path:   "/api/v1/storage/merge",
+			body:   `{"source_file_ids":["` + testFileID + `","not-a-uuid"]}`,
+			want:   http.StatusBadRequest,
+		},
+		{
+			name:   "merge unsupported format",
+			method: http.MethodPost,
+			path:   "/api/v1/storage/merge",
+			body:   `{"source_file_ids":["` + testFileID + `","` + testFileID + `"]}`,
+			err:    status.Error(codes.FailedPrecondition, "operation not supported for this document format"),
+			want:   http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "split without heading level",
+			method: http.MethodPost,
+			path:   "/api/v1/storage/files/" + testFileID + "/split",
+			body:   `{}`,
+			want:   http.StatusBadRequest,
+		},
+		{
+			name:   "split too deep",
+			method: http.MethodPost,
+			path:   "/api/v1/storage/files/" + testFileID + "/split",
+			body:   `{"heading_level":10}`,
+			want:   http.StatusBadRequest,
+		},
+		{
+			name:   "split without headings",
+			method: http.MethodPost,
+			path:   "/api/v1/storage/files/" + testFileID + "/split",
+			body:   `{"heading_level":1}`,
+			err:    status.Error(codes.InvalidArgument, "invalid split request: document has no headings to split at"),
+			want:   http.StatusBadRequest,
+		},
+		{
+			name:   "provenance of missing file",
+			method: http.MethodGet,
+			path:   "/api/v1/storage/files/" + testFileID + "/provenance",
+			err:    status.Error(codes.NotFound, "document not found"),
+			want:   http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := setupMergeRouter(t, &mockMergeClient{mergeErr: tt.err})
+
+			w := serve(r, tt.method, tt.path, tt.body)
+
+			assert.Equal(t, tt.want, w.Code)
+		})
+	}
+}
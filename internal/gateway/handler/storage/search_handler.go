@@ -0,0 +1,45 @@
+package storage
+
+import (
+	"net/http"
+
+	"github.com/a1y/doc-formatter/internal/gateway/domain/request"
+	"github.com/a1y/doc-formatter/internal/gateway/util/grpcstatus"
+	"github.com/gin-gonic/gin"
+)
+
+// SearchDocuments godoc
+//
+//	@Summary		Search documents
+//	@Description	Search the text and file names of the documents a user owns or that are shared with them, best matches first. Each result carries a snippet of the text in which the matched terms are wrapped in <mark> tags. Newly uploaded documents become searchable once their text has been indexed.
+//	@Tags			Storage
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+//	@Param			q		query		string	true	"Search query; quoted phrases, OR and -word are supported"
+//	@Param			limit	query		int		false	"Maximum number of results, 20 by default and at most 100"
+//	@Success		200		{object}	response.SearchResponse
+//	@Failure		400		{object}	map[string]string
+//	@Failure		401		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/api/v1/search [get]
+func (h *StorageHandler) SearchDocuments(c *gin.Context) {
+	userID, ok := authUserID(c)
+	if !ok {
+		return
+	}
+
+	var req request.SearchRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.storageManager.SearchDocuments(c.Request.Context(), userID, &req)
+	if err != nil {
+		c.JSON(grpcstatus.HTTPStatus(err), gin.H{"error": grpcstatus.Message(err)})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	storagemgr "github.com/a1y/doc-formatter/internal/gateway/manager/storage"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type mockSearchClient struct {
+	mockStorageClient
+
+	searchErr  error
+	lastSearch *storagepb.SearchDocumentsRequest
+}
+
+func (m *mockSearchClient) SearchDocuments(_ context.Context, req *storagepb.SearchDocumentsRequest) (*storagepb.SearchDocumentsResponse, error) {
+	m.lastSearch = req
+	if m.searchErr != nil {
+		return nil, m.searchErr
+	}
+	return &storagepb.SearchDocumentsResponse{Hits: []*storagepb.SearchHit{{
+		File: &storagepb.FileInfo{
+			FileId:        testFileID,
+			FileName:      "report.docx",
+			FileSize:      42,
+			CreatedAtUnix: 1767225600,
+			UpdatedAtUnix: 1767225600,
+		},
+		Rank:    0.5,
+		Snippet: "the <mark>quarterly</mark> report",
+	}}}, nil
+}
+
+func setupSearchRouter(t *testing.T, mockClient *mockSearchClient) *gin.Engine {
+	t.Helper()
+
+	h, err := NewStorageHandler(storagemgr.NewStorageManager(mockClient, nil))
+	assert.NoError(t, err)
+	r := setupRouter(h)
+	r.GET("/api/v1/search", h.SearchDocuments)
+	return r
+}
+
+func TestStorageHandler_SearchDocuments(t *testing.T) {
+	mockClient := &mockSearchClient{}
+	r := setupSearchRouter(t, mockClient)
+
+	query := url.Values{"q": {`"quarterly report"`}, "limit": {"5"}}
+	w := serve(r, http.MethodGet, "/api/v1/search?"+query.Encode(), "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"results":[{
+		"file":{"file_id":"`+testFileID+`","file_name":"report.docx","file_size":42,
+			"created_at":"2026-01-01T00:00:00Z","updated_at":"2026-01-01T00:00:00Z"},
+		"rank":0.5,"snippet":"the <mark>quarterly</mark> report"
+	}]}`, w.Body.String())
+	assert.Equal(t, &storagepb.SearchDocumentsRequest{UserId: testUserID, Query: `"quarterly report"`, Limit: 5}, mockClient.lastSearch)
+}
+
+func TestStorageHandler_SearchDocuments_Errors(t *testing.T) {
+	mockClient := &mockSearchClient{}
+	r := setupSearchRouter(t, mockClient)
+
+	for _, target := range []string{
+		"/api/v1/search",
+		"/api/v1/search?q=report&limit=101",
+	} {
+		w := serve(r, http.MethodGet, target, "")
+		assert.Equal(t, http.StatusBadRequest, w.Code, target)
+	}
+	assert.Nil(t, mockClient.lastSearch)
+
+	mockClient.searchErr = status.Error(codes.InvalidArgument, "invalid search: query is empty")
+	w := serve(r, http.MethodGet, "/api/v1/search?q=%20", "")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "invalid search")
+}
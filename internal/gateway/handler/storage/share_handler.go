@@ -0,0 +1,250 @@
+package storage
+
+import (
+	"net/http"
+
+	"github.com/a1y/doc-formatter/internal/gateway/domain/request"
+	storagemgr "github.com/a1y/doc-formatter/internal/gateway/manager/storage"
+	"github.com/a1y/doc-formatter/internal/gateway/util/grpcstatus"
+	"github.com/gin-gonic/gin"
+)
+
+// ShareFile godoc
+//
+//	@Summary		Share file
+//	@Description	Grant the user registered under an email address the viewer, commenter or editor role on a file. Sharing again with the same user changes the role.
+//	@Tags			Storage
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+//	@Param			id		path		string					true	"File ID (UUID)"
+//	@Param			request	body		request.ShareRequest	true	"Email of the user to share with and role"
+//	@Success		200		{object}	response.ShareResponse
+//	@Failure		400		{object}	map[string]string
+//	@Failure		401		{object}	map[string]string
+//	@Failure		403		{object}	map[string]string
+//	@Failure		404		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/api/v1/storage/files/{id}/shares [post]
+func (h *StorageHandler) ShareFile(c *gin.Context) {
+	var uri request.FileURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	h.share(c, storagemgr.ResourceFile, uri.FileID)
+}
+
+// UnshareFile godoc
+//
+//	@Summary		Unshare file
+//	@Description	Revoke the role the user registered under an email address holds on a file.
+//	@Tags			Storage
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+//	@Param			id		path	string	true	"File ID (UUID)"
+//	@Param			email	query	string	true	"Email of the user to unshare with"
+//	@Success		204
+//	@Failure		400	{object}	map[string]string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/api/v1/storage/files/{id}/shares [delete]
+func (h *StorageHandler) UnshareFile(c *gin.Context) {
+	var uri request.FileURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	h.unshare(c, storagemgr.ResourceFile, uri.FileID)
+}
+
+// ListFileShares godoc
+//
+//	@Summary		List file shares
+//	@Description	List the users a file is shared with and their roles.
+//	@Tags			Storage
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+//	@Param			id	path		string	true	"File ID (UUID)"
+//	@Success		200	{object}	response.ListSharesResponse
+//	@Failure		400	{object}	map[string]string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/api/v1/storage/files/{id}/shares [get]
+func (h *StorageHandler) ListFileShares(c *gin.Context) {
+	var uri request.FileURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	h.listShares(c, storagemgr.ResourceFile, uri.FileID)
+}
+
+// ShareFolder godoc
+//
+//	@Summary		Share folder
+//	@Description	Grant the user registered under an email address the viewer, commenter or editor role on a folder. Sharing again with the same user changes the role. Sharing a folder grants the role on everything it contains.
+//	@Tags			Storage
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+//	@Param			id		path		string					true	"Folder ID (UUID)"
+//	@Param			request	body		request.ShareRequest	true	"Email of the user to share with and role"
+//	@Success		200		{object}	response.ShareResponse
+//	@Failure		400		{object}	map[string]string
+//	@Failure		401		{object}	map[string]string
+//	@Failure		403		{object}	map[string]string
+//	@Failure		404		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/api/v1/storage/folders/{id}/shares [post]
+func (h *StorageHandler) ShareFolder(c *gin.Context) {
+	var uri request.FolderURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	h.share(c, storagemgr.ResourceFolder, uri.FolderID)
+}
+
+// UnshareFolder godoc
+//
+//	@Summary		Unshare folder
+//	@Description	Revoke the role the user registered under an email address holds on a folder.
+//	@Tags			Storage
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+//	@Param			id		path	string	true	"Folder ID (UUID)"
+//	@Param			email	query	string	true	"Email of the user to unshare with"
+//	@Success		204
+//	@Failure		400	{object}	map[string]string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/api/v1/storage/folders/{id}/shares [delete]
+func (h *StorageHandler) UnshareFolder(c *gin.Context) {
+	var uri request.FolderURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	h.unshare(c, storagemgr.ResourceFolder, uri.FolderID)
+}
+
+// ListFolderShares godoc
+//
+//	@Summary		List folder shares
+//	@Description	List the users a folder is shared with and their roles.
+//	@Tags			Storage
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+//	@Param			id	path		string	true	"Folder ID (UUID)"
+//	@Success		200	{object}	response.ListSharesResponse
+//	@Failure		400	{object}	map[string]string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/api/v1/storage/folders/{id}/shares [get]
+func (h *StorageHandler) ListFolderShares(c *gin.Context) {
+	var uri request.FolderURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	h.listShares(c, storagemgr.ResourceFolder, uri.FolderID)
+}
+
+// ListSharedWithMe godoc
+//
+//	@Summary		List shared with me
+//	@Description	List the files and folders other users shared with a user, most recently shared first.
+//	@Tags			Storage
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+//	@Success		200	{object}	response.ListSharedWithMeResponse
+//	@Failure		400	{object}	map[string]string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/api/v1/storage/shared [get]
+func (h *StorageHandler) ListSharedWithMe(c *gin.Context) {
+	userID, ok := authUserID(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.storageManager.ListSharedWithMe(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(grpcstatus.HTTPStatus(err), gin.H{"error": grpcstatus.Message(err)})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *StorageHandler) share(c *gin.Context, resourceType string, resourceID string) {
+	userID, ok := authUserID(c)
+	if !ok {
+		return
+	}
+
+	var req request.ShareRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.storageManager.Share(c.Request.Context(), userID, resourceType, resourceID, &req)
+	if err != nil {
+		c.JSON(grpcstatus.HTTPStatus(err), gin.H{"error": grpcstatus.Message(err)})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h *StorageHandler) unshare(c *gin.Context, resourceType string, resourceID string) {
+	userID, ok := authUserID(c)
+	if !ok {
+		return
+	}
+
+	var req request.UnshareRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.storageManager.Unshare(c.Request.Context(), userID, resourceType, resourceID, &req); err != nil {
+		c.JSON(grpcstatus.HTTPStatus(err), gin.H{"error": grpcstatus.Message(err)})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *StorageHandler) listShares(c *gin.Context, resourceType string, resourceID string) {
+	userID, ok := authUserID(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.storageManager.ListShares(c.Request.Context(), userID, resourceType, resourceID)
+	if err != nil {
+		c.JSON(grpcstatus.HTTPStatus(err), gin.H{"error": grpcstatus.Message(err)})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,223 @@
+package storage
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/a1y/doc-formatter/internal/gateway/clients/auth"
+	"github.com/a1y/doc-formatter/internal/gateway/domain/response"
+	storagemgr "github.com/a1y/doc-formatter/internal/gateway/manager/storage"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const testGranteeID = "9b2f4c1e-3d5a-4e6b-8c7d-1a2b3c4d5e6f"
+
+type mockShareClient struct {
+	mockStorageClient
+
+	shareErr  error
+	lastShare any
+}
+
+func testShare(resourceType, resourceID, role string) *storagepb.ShareInfo {
+	return &storagepb.ShareInfo{
+		ResourceType:  resourceType,
+		ResourceId:    resourceID,
+		OwnerId:       testUserID,
+		UserId:        testGranteeID,
+		Role:          role,
+		CreatedAtUnix: 1767225600,
+	}
+}
+
+func (m *mockShareClient) Share(_ context.Context, req *storagepb.ShareRequest) (*storagepb.ShareResponse, error) {
+	m.lastShare = req
+	if m.shareErr != nil {
+		return nil, m.shareErr
+	}
+	return &storagepb.ShareResponse{Share: testShare(req.GetResourceType(), req.GetResourceId(), req.GetRole())}, nil
+}
+
+func (m *mockShareClient) Unshare(_ context.Context, req *storagepb.UnshareRequest) (*storagepb.UnshareResponse, error) {
+	m.lastShare = req
+	if m.shareErr != nil {
+		return nil, m.shareErr
+	}
+	return &storagepb.UnshareResponse{}, nil
+}
+
+func (m *mockShareClient) ListShares(_ context.Context, req *storagepb.ListSharesRequest) (*storagepb.ListSharesResponse, error) {
+	m.lastShare = req
+	if m.shareErr != nil {
+		return nil, m.shareErr
+	}
+	return &storagepb.ListSharesResponse{Shares: []*storagepb.ShareInfo{testShare(req.GetResourceType(), req.GetResourceId(), "editor")}}, nil
+}
+
+func (m *mockShareClient) ListSharedWithMe(_ context.Context, req *storagepb.ListSharedWithMeRequest) (*storagepb.ListSharedWithMeResponse, error) {
+	m.lastShare = req
+	if m.shareErr != nil {
+		return nil, m.shareErr
+	}
+	return &storagepb.ListSharedWithMeResponse{Items: []*storagepb.SharedItem{
+		{Share: testShare("folder", testFolderID, "viewer"), Folder: &storagepb.Folder{FolderId: testFolderID, Name: "reports"}},
+	}}, nil
+}
+
+type mockLookupClient struct {
+	auth.AuthClient
+}
+
+func (m *mockLookupClient) LookupUser(_ context.Context, email string) (*response.UserResponse, error) {
+	if email != "bob@example.com" {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	return &response.UserResponse{UserID: testGranteeID, Email: email}, nil
+}
+
+func setupShareRouter(t *testing.T, mockClient *mockShareClient) *gin.Engine {
+	t.Helper()
+
+	h, err := NewStorageHandler(storagemgr.NewStorageManager(mockClient, &mockLookupClient{}))
+	assert.NoError(t, err)
+	r := setupRouter(h)
+	r.POST("/api/v1/storage/files/:id/shares", h.ShareFile)
+	r.GET("/api/v1/storage/files/:id/shares", h.ListFileShares)
+	r.DELETE("/api/v1/storage/files/:id/shares", h.UnshareFile)
+	r.POST("/api/v1/storage/folders/:id/shares", h.ShareFolder)
+	r.GET("/api/v1/storage/folders/:id/shares", h.ListFolderShares)
+	r.DELETE("/api/v1/storage/folders/:id/shares", h.UnshareFolder)
+	r.GET("/api/v1/storage/shared", h.ListSharedWithMe)
+	return r
+}
+
+func TestStorageHandler_Shares(t *testing.T) {
+	mockClient := &mockShareClient{}
+	r := setupShareRouter(t, mockClient)
+
+	w := serve(r, http.MethodPost, "/api/v1/storage/files/"+testFileID+"/shares",
+		`{"email":"bob@example.com","role":"editor"}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{
+		"resource_type":"file","resource_id":"`+testFileID+`",
+		"owner_id":"`+testUserID+`","user_id":"`+testGranteeID+`",
+		"role":"editor","created_at":"2026-01-01T00:00:00Z"
+	}`, w.Body.String())
+	assert.Equal(t, &storagepb.ShareRequest{
+		UserId:       testUserID,
+		ResourceType: "file",
+		ResourceId:   testFileID,
+		GranteeId:    testGranteeID,
+		Role:         "editor",
+	}, mockClient.lastShare)
+
+	w = serve(r, http.MethodPost, "/api/v1/storage/folders/"+testFolderID+"/shares",
+		`{"email":"bob@example.com","role":"viewer"}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "folder", mockClient.lastShare.(*storagepb.ShareRequest).GetResourceType())
+
+	w = serve(r, http.MethodGet, "/api/v1/storage/folders/"+testFolderID+"/shares", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"role":"editor"`)
+	assert.Equal(t, &storagepb.ListSharesRequest{UserId: testUserID, ResourceType: "folder", ResourceId: testFolderID}, mockClient.lastShare)
+
+	w = serve(r, http.MethodDelete, "/api/v1/storage/files/"+testFileID+"/shares?email=bob@example.com", "")
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Empty(t, w.Body.String())
+	assert.Equal(t, &storagepb.UnshareRequest{UserId: testUserID, ResourceType: "file", ResourceId: testFileID, GranteeId: testGranteeID}, mockClient.lastShare)
+
+	w = serve(r, http.MethodGet, "/api/v1/storage/shared", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"items":[{
+		"share":{
+			"resource_type":"folder","resource_id":"`+testFolderID+`",
+			"owner_id":"`+testUserID+`","user_id":"`+testGranteeID+`",
+			"role":"viewer","created_at":"2026-01-01T00:00:00Z"
+		},
+		"folder":{"folder_id":"`+testFolderID+`","name":"reports"}
+	}]}`, w.Body.String())
+	assert.Equal(t, &storagepb.ListSharedWithMeRequest{UserId: testUserID}, mockClient.lastShare)
+}
+
+func TestStorageHandler_ShareErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		err    error
+		want   int
+	}{
+		{
+			name:   "share with invalid file id",
+			method: http.MethodPost,
+			path:   "/api/v1/storage/files/not-a-uuid/shares",
+			body:   `{"email":"bob@example.com","role":"viewer"}`,
+			want:   http.StatusBadRequest,
+		},
+		{
+			name:   "share with owner role",
+			method: http.MethodPost,
+			path:   "/api/v1/storage/files/" + testFileID + "/shares",
+			body:   `{"email":"bob@example.com","role":"owner"}`,
+			want:   http.StatusBadRequest,
+		},
+		{
+			name:   "share with invalid email",
+			method: http.MethodPost,
+			path:   "/api/v1/storage/folders/" + testFolderID + "/shares",
+			body:   `{"email":"bob","role":"viewer"}`,
+			want:   http.StatusBadRequest,
+		},
+		{
+			name:   "share with unknown user",
+			method: http.MethodPost,
+			path:   "/api/v1/storage/files/" + testFileID + "/shares",
+			body:   `{"email":"eve@example.com","role":"viewer"}`,
+			want:   http.StatusNotFound,
+		},
+		{
+			name:   "share a file of someone else",
+			method: http.MethodPost,
+			path:   "/api/v1/storage/files/" + testFileID + "/shares",
+			body:   `{"email":"bob@example.com","role":"viewer"}`,
+			err:    status.Error(codes.PermissionDenied, "permission denied"),
+			want:   http.StatusForbidden,
+		},
+		{
+			name:   "unshare without email",
+			method: http.MethodDelete,
+			path:   "/api/v1/storage/folders/" + testFolderID + "/shares",
+			want:   http.StatusBadRequest,
+		},
+		{
+			name:   "unshare a missing share",
+			method: http.MethodDelete,
+			path:   "/api/v1/storage/files/" + testFileID + "/shares?email=bob@example.com",
+			err:    status.Error(codes.NotFound, "share not found"),
+			want:   http.StatusNotFound,
+		},
+		{
+			name:   "list shared with me fails",
+			method: http.MethodGet,
+			path:   "/api/v1/storage/shared",
+			err:    status.Error(codes.Internal, "internal error"),
+			want:   http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := setupShareRouter(t, &mockShareClient{shareErr: tt.err})
+
+			w := serve(r, tt.method, tt.path, tt.body)
+
+			assert.Equal(t, tt.want, w.Code)
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package storage
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/a1y/doc-formatter/internal/gateway/domain/request"
+	"github.com/a1y/doc-formatter/internal/gateway/util/grpcstatus"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// SharePasswordHeader carries the password of a protected share link.
+const SharePasswordHeader = "X-Share-Password"
+
+// CreateShareLink godoc
+//
+//	@Summary		Create share link
+//	@Description	Create a public link to a file, optionally protected by a password, expiring at a given time or limited to a number of downloads. The token is only returned once.
+//	@Tags			Storage
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+//	@Param			id		path		string							true	"File ID (UUID)"
+//	@Param			request	body		request.CreateShareLinkRequest	true	"Link restrictions"
+//	@Success		201		{object}	response.ShareLinkResponse
+//	@Failure		400		{object}	map[string]string
+//	@Failure		401		{object}	map[string]string
+//	@Failure		403		{object}	map[string]string
+//	@Failure		404		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/api/v1/storage/files/{id}/links [post]
+func (h *StorageHandler) CreateShareLink(c *gin.Context) {
+	userID, ok := authUserID(c)
+	if !ok {
+		return
+	}
+
+	var uri request.FileURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req request.CreateShareLinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.storageManager.CreateShareLink(c.Request.Context(), userID, uri.FileID, &req)
+	if err != nil {
+		c.JSON(grpcstatus.HTTPStatus(err), gin.H{"error": grpcstatus.Message(err)})
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+// ListShareLinks godoc
+//
+//	@Summary		List share links
+//	@Description	List the public links of a file, newest first, including revoked and expired ones.
+//	@Tags			Storage
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+//	@Param			id	path		string	true	"File ID (UUID)"
+//	@Success		200	{object}	response.ListShareLinksResponse
+//	@Failure		400	{object}	map[string]string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/api/v1/storage/files/{id}/links [get]
+func (h *StorageHandler) ListShareLinks(c *gin.Context) {
+	userID, ok := authUserID(c)
+	if !ok {
+		return
+	}
+
+	var uri request.FileURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.storageManager.ListShareLinks(c.Request.Context(), userID, uri.FileID)
+	if err != nil {
+		c.JSON(grpcstatus.HTTPStatus(err), gin.H{"error": grpcstatus.Message(err)})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// RevokeShareLink godoc
+//
+//	@Summary		Revoke share link
+//	@Description	Revoke a public link so it can no longer be used. Revoking a revoked link is a no-op.
+//	@Tags			Storage
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+//	@Param			id	path		string	true	"Link ID (UUID)"
+//	@Success		200	{object}	response.ShareLinkResponse
+//	@Failure		400	{object}	map[string]string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/api/v1/storage/links/{id} [delete]
+func (h *StorageHandler) RevokeShareLink(c *gin.Context) {
+	userID, ok := authUserID(c)
+	if !ok {
+		return
+	}
+
+	var uri request.ShareLinkURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.storageManager.RevokeShareLink(c.Request.Context(), userID, uri.LinkID)
+	if err != nil {
+		c.JSON(grpcstatus.HTTPStatus(err), gin.H{"error": grpcstatus.Message(err)})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// DownloadSharedFile godoc
+//
+//	@Summary		Download shared file
+//	@Description	Download the file behind a share link. No account is needed; protected links take their password from the X-Share-Password header. Every attempt is recorded. After too many wrong passwords to a link or from an address, passwords are refused for a while.
+//	@Tags			Storage
+//	@Produce		octet-stream
+//	@Param			token				path		string	true	"Share link token"
+//	@Param			X-Share-Password	header		string	false	"Password of a protected link"
+//	@Success		200					{file}		binary
+//	@Failure		401					{object}	map[string]string
+//	@Failure		404					{object}	map[string]string
+//	@Failure		410					{object}	map[string]string
+//	@Failure		429					{object}	map[string]string
+//	@Header			429					{integer}	Retry-After	"Seconds to wait before trying a password again"
+//	@Failure		500					{object}	map[string]string
+//	@Router			/s/{token} [get]
+func (h *StorageHandler) DownloadSharedFile(c *gin.Context) {
+	var uri request.SharedFileURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.storageManager.DownloadSharedFile(c.Request.Context(), uri.Token, c.GetHeader(SharePasswordHeader), c.ClientIP(), c.Request.UserAgent())
+	if err != nil {
+		if retryAfter, ok := grpcstatus.RetryAfter(err); ok {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+		}
+		c.JSON(sharedFileStatus(err), gin.H{"error": grpcstatus.Message(err)})
+		return
+	}
+
+	sendFile(c, resp)
+}
+
+// sharedFileStatus reports expired, revoked and exhausted links as gone rather
+// than as a failed precondition, which means little to an anonymous client.
+func sharedFileStatus(err error) int {
+	if status.Code(err) == codes.FailedPrecondition {
+		return http.StatusGone
+	}
+	return grpcstatus.HTTPStatus(err)
+}
@@ -0,0 +1,227 @@
+package storage
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	storagemgr "github.com/a1y/doc-formatter/internal/gateway/manager/storage"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+const testLinkID = "3f1c2b7a-8d4e-4f6a-9b0c-5e7d1a2c3b4f"
+
+type mockShareLinkClient struct {
+	mockStorageClient
+
+	linkErr  error
+	lastLink any
+}
+
+func testShareLink() *storagepb.ShareLinkInfo {
+	return &storagepb.ShareLinkInfo{
+		LinkId:        testLinkID,
+		FileId:        testFileID,
+		OwnerId:       testUserID,
+		HasPassword:   true,
+		MaxDownloads:  3,
+		CreatedAtUnix: 1767225600,
+	}
+}
+
+func (m *mockShareLinkClient) CreateShareLink(_ context.Context, req *storagepb.CreateShareLinkRequest) (*storagepb.CreateShareLinkResponse, error) {
+	m.lastLink = req
+	if m.linkErr != nil {
+		return nil, m.linkErr
+	}
+	return &storagepb.CreateShareLinkResponse{Link: testShareLink(), Token: "abc123"}, nil
+}
+
+func (m *mockShareLinkClient) ListShareLinks(_ context.Context, req *storagepb.ListShareLinksRequest) (*storagepb.ListShareLinksResponse, error) {
+	m.lastLink = req
+	if m.linkErr != nil {
+		return nil, m.linkErr
+	}
+	return &storagepb.ListShareLinksResponse{Links: []*storagepb.ShareLinkInfo{testShareLink()}}, nil
+}
+
+func (m *mockShareLinkClient) RevokeShareLink(_ context.Context, req *storagepb.RevokeShareLinkRequest) (*storagepb.RevokeShareLinkResponse, error) {
+	m.lastLink = req
+	if m.linkErr != nil {
+		return nil, m.linkErr
+	}
+	link := testShareLink()
+	link.RevokedAtUnix = 1767229200
+	return &storagepb.RevokeShareLinkResponse{Link: link}, nil
+}
+
+func (m *mockShareLinkClient) DownloadSharedFile(_ context.Context, req *storagepb.DownloadSharedFileRequest) (grpc.ServerStreamingClient[storagepb.DownloadFileResponse], error) {
+	m.lastLink = req
+	return &mockDownloadStream{msgs: m.downloadMsgs, err: m.linkErr}, nil
+}
+
+func setupShareLinkRouter(t *testing.T, mockClient *mockShareLinkClient) *gin.Engine {
+	t.Helper()
+
+	h, err := NewStorageHandler(storagemgr.NewStorageManager(mockClient, nil))
+	assert.NoError(t, err)
+	r := setupRouter(h)
+	r.POST("/api/v1/storage/files/:id/links", h.CreateShareLink)
+	r.GET("/api/v1/storage/files/:id/links", h.ListShareLinks)
+	r.DELETE("/api/v1/storage/links/:id", h.RevokeShareLink)
+	r.GET("/s/:token", h.DownloadSharedFile)
+	return r
+}
+
+func TestStorageHandler_ShareLinks(t *testing.T) {
+	mockClient := &mockShareLinkClient{}
+	r := setupShareLinkRouter(t, mockClient)
+
+	w := serve(r, http.MethodPost, "/api/v1/storage/files/"+testFileID+"/links",
+		`{"password":"hunter2","expires_at":"2026-02-01T00:00:00Z","max_downloads":3}`)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.JSONEq(t, `{
+		"link_id":"`+testLinkID+`","file_id":"`+testFileID+`","owner_id":"`+testUserID+`",
+		"token":"abc123","url":"/s/abc123","has_password":true,
+		"max_downloads":3,"download_count":0,"created_at":"2026-01-01T00:00:00Z"
+	}`, w.Body.String())
+	assert.Equal(t, &storagepb.CreateShareLinkRequest{
+		UserId:        testUserID,
+		FileId:        testFileID,
+		Password:      "hunter2",
+		ExpiresAtUnix: 1769904000,
+		MaxDownloads:  3,
+	}, mockClient.lastLink)
+
+	w = serve(r, http.MethodGet, "/api/v1/storage/files/"+testFileID+"/links", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"link_id":"`+testLinkID+`"`)
+	assert.NotContains(t, w.Body.String(), `"token"`)
+	assert.Equal(t, &storagepb.ListShareLinksRequest{UserId: testUserID, FileId: testFileID}, mockClient.lastLink)
+
+	w = serve(r, http.MethodDelete, "/api/v1/storage/links/"+testLinkID+"", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"revoked_at":"2026-01-01T01:00:00Z"`)
+	assert.Equal(t, &storagepb.RevokeShareLinkRequest{UserId: testUserID, LinkId: testLinkID}, mockClient.lastLink)
+}
+
+func TestStorageHandler_DownloadSharedFile(t *testing.T) {
+	mockClient := &mockShareLinkClient{}
+	mockClient.downloadMsgs = []*storagepb.DownloadFileResponse{
+		{FileName: "report.pdf", FileSize: 5},
+		{Chunk: []byte("hello")},
+	}
+	r := setupShareLinkRouter(t, mockClient)
+
+	req := httptest.NewRequest(http.MethodGet, "/s/abc123", nil)
+	req.Header.Set(SharePasswordHeader, "hunter2")
+	req.Header.Set("User-Agent", "curl/8.0")
+	req.RemoteAddr = "203.0.113.7:54321"
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "hello", w.Body.String())
+	assert.Equal(t, "application/pdf", w.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename=report.pdf`, w.Header().Get("Content-Disposition"))
+	assert.Equal(t, &storagepb.DownloadSharedFileRequest{
+		Token:      "abc123",
+		Password:   "hunter2",
+		RemoteAddr: "203.0.113.7",
+		UserAgent:  "curl/8.0",
+	}, mockClient.lastLink)
+}
+
+func TestStorageHandler_ShareLinkErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		err    error
+		want   int
+	}{
+		{
+			name:   "create with negative download limit",
+			method: http.MethodPost,
+			path:   "/api/v1/storage/files/" + testFileID + "/links",
+			body:   `{"max_downloads":-1}`,
+			want:   http.StatusBadRequest,
+		},
+		{
+			name:   "create on a file of someone else",
+			method: http.MethodPost,
+			path:   "/api/v1/storage/files/" + testFileID + "/links",
+			body:   `{}`,
+			err:    status.Error(codes.PermissionDenied, "permission denied"),
+			want:   http.StatusForbidden,
+		},
+		{
+			name:   "revoke with invalid link id",
+			method: http.MethodDelete,
+			path:   "/api/v1/storage/links/not-a-uuid",
+			want:   http.StatusBadRequest,
+		},
+		{
+			name:   "revoke a missing link",
+			method: http.MethodDelete,
+			path:   "/api/v1/storage/links/" + testLinkID + "",
+			err:    status.Error(codes.NotFound, "share link not found"),
+			want:   http.StatusNotFound,
+		},
+		{
+			name:   "download with unknown token",
+			method: http.MethodGet,
+			path:   "/s/unknown",
+			err:    status.Error(codes.NotFound, "share link not found"),
+			want:   http.StatusNotFound,
+		},
+		{
+			name:   "download with wrong password",
+			method: http.MethodGet,
+			path:   "/s/abc123",
+			err:    status.Error(codes.Unauthenticated, "share link password is missing or wrong"),
+			want:   http.StatusUnauthorized,
+		},
+		{
+			name:   "download an expired link",
+			method: http.MethodGet,
+			path:   "/s/abc123",
+			err:    status.Error(codes.FailedPrecondition, "share link has expired"),
+			want:   http.StatusGone,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := setupShareLinkRouter(t, &mockShareLinkClient{linkErr: tt.err})
+
+			w := serve(r, tt.method, tt.path, tt.body)
+
+			assert.Equal(t, tt.want, w.Code)
+		})
+	}
+}
+
+func TestStorageHandler_DownloadSharedFile_Throttled(t *testing.T) {
+	st, err := status.New(codes.ResourceExhausted, "too many failed attempts, retry in 1m30s").
+		WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(89500 * time.Millisecond)})
+	require.NoError(t, err)
+	r := setupShareLinkRouter(t, &mockShareLinkClient{linkErr: st.Err()})
+
+	w := serve(r, http.MethodGet, "/s/abc123", "")
+
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "90", w.Header().Get("Retry-After"))
+	assert.JSONEq(t, `{"error":"too many failed attempts, retry in 1m30s"}`, w.Body.String())
+}
@@ -0,0 +1,54 @@
+package storage
+
+import (
+	"net/http"
+
+	"github.com/a1y/doc-formatter/internal/gateway/domain/request"
+	"github.com/a1y/doc-formatter/internal/gateway/util/grpcstatus"
+	"github.com/gin-gonic/gin"
+)
+
+// StampFile godoc
+//
+//	@Summary		Download stamped file
+//	@Description	Download a copy of a DOCX or PDF file with a watermark, an image, a header or a footer drawn on every page. Their text may hold the placeholders {name}, {owner}, {user}, {version} and {date}, the owner and the user being shown by email address. PDF files are stamped by an incremental update, which keeps existing signatures valid for the revision they signed.
+//	@Tags			Storage
+//	@Accept			json
+//	@Produce		octet-stream
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+//	@Param			id		path		string						true	"File ID (UUID)"
+//	@Param			request	body		request.StampFileRequest	true	"Stamp"
+//	@Success		200		{file}		binary
+//	@Failure		400		{object}	map[string]string
+//	@Failure		401		{object}	map[string]string
+//	@Failure		404		{object}	map[string]string
+//	@Failure		422		{object}	map[string]string
+//	@Failure		500		{object}	map[string]string
+//	@Router			/api/v1/storage/files/{id}/stamp [post]
+func (h *StorageHandler) StampFile(c *gin.Context) {
+	userID, ok := authUserID(c)
+	if !ok {
+		return
+	}
+
+	var uri request.FileURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req request.StampFileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.storageManager.StampFile(c.Request.Context(), userID, uri.FileID, &req)
+	if err != nil {
+		c.JSON(documentStatus(err), gin.H{"error": grpcstatus.Message(err)})
+		return
+	}
+
+	sendFile(c, resp)
+}
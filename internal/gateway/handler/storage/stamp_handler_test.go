@@ -0,0 +1,95 @@
+package storage
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/a1y/doc-formatter/internal/gateway/clients/auth"
+	"github.com/a1y/doc-formatter/internal/gateway/domain/response"
+	storagemgr "github.com/a1y/doc-formatter/internal/gateway/manager/storage"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type mockStampClient struct {
+	mockStorageClient
+
+	stampErr  error
+	lastStamp *storagepb.StampFileRequest
+}
+
+func (m *mockStampClient) StampFile(_ context.Context, req *storagepb.StampFileRequest) (grpc.ServerStreamingClient[storagepb.DownloadFileResponse], error) {
+	m.lastStamp = req
+	return &mockDownloadStream{msgs: m.downloadMsgs, err: m.stampErr}, nil
+}
+
+type mockUserClient struct {
+	auth.AuthClient
+}
+
+func (m *mockUserClient) GetUser(_ context.Context, userID string) (*response.UserResponse, error) {
+	return &response.UserResponse{UserID: userID, Email: "ann@example.com"}, nil
+}
+
+func setupStampRouter(t *testing.T, mockClient *mockStampClient) *gin.Engine {
+	t.Helper()
+
+	h, err := NewStorageHandler(storagemgr.NewStorageManager(mockClient, &mockUserClient{}))
+	assert.NoError(t, err)
+	r := setupRouter(h)
+	r.POST("/api/v1/storage/files/:id/stamp", h.StampFile)
+	return r
+}
+
+func TestStorageHandler_StampFile(t *testing.T) {
+	mockClient := &mockStampClient{}
+	mockClient.downloadMsgs = []*storagepb.DownloadFileResponse{
+		{FileName: "offer.pdf", FileSize: 5},
+		{Chunk: []byte("%PDF-")},
+	}
+	r := setupStampRouter(t, mockClient)
+
+	w := serve(r, http.MethodPost, "/api/v1/storage/files/"+testFileID+"/stamp",
+		`{"watermark":"DRAFT for {user}","image":"cG5n","header":"{name}","opacity":0.5}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "%PDF-", w.Body.String())
+	assert.Equal(t, `attachment; filename=offer.pdf`, w.Header().Get("Content-Disposition"))
+	assert.Equal(t, &storagepb.StampFileRequest{
+		UserId:    testUserID,
+		FileId:    testFileID,
+		Watermark: "DRAFT for {user}",
+		Image:     []byte("png"),
+		Header:    "{name}",
+		Opacity:   0.5,
+		UserLabel: "ann@example.com",
+	}, mockClient.lastStamp)
+}
+
+func TestStorageHandler_StampFile_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		fileID   string
+		body     string
+		stampErr error
+		code     int
+	}{
+		{name: "bad file id", fileID: "bad", body: `{"watermark":"DRAFT"}`, code: http.StatusBadRequest},
+		{name: "bad opacity", fileID: testFileID, body: `{"watermark":"DRAFT","opacity":2}`, code: http.StatusBadRequest},
+		{name: "invalid stamp", fileID: testFileID, body: `{}`, stampErr: status.Error(codes.InvalidArgument, "invalid stamp"), code: http.StatusBadRequest},
+		{name: "unsupported format", fileID: testFileID, body: `{"watermark":"DRAFT"}`, stampErr: status.Error(codes.FailedPrecondition, "operation not supported for this document format"), code: http.StatusUnprocessableEntity},
+		{name: "not found", fileID: testFileID, body: `{"watermark":"DRAFT"}`, stampErr: status.Error(codes.NotFound, "document not found"), code: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := setupStampRouter(t, &mockStampClient{stampErr: tt.stampErr})
+
+			w := serve(r, http.MethodPost, "/api/v1/storage/files/"+tt.fileID+"/stamp", tt.body)
+			assert.Equal(t, tt.code, w.Code)
+		})
+	}
+}
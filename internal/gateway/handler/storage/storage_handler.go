@@ -2,9 +2,14 @@ package storage
 
 import (
 	"io"
+	"mime"
 	"net/http"
+	"path/filepath"
 
 	"github.com/a1y/doc-formatter/internal/gateway/domain/request"
+	"github.com/a1y/doc-formatter/internal/gateway/domain/response"
+	"github.com/a1y/doc-formatter/internal/gateway/middleware"
+	"github.com/a1y/doc-formatter/internal/gateway/util/grpcstatus"
 	"github.com/gin-gonic/gin"
 )
 
@@ -15,13 +20,23 @@ import (
 //	@Tags			Storage
 //	@Accept			multipart/form-data
 //	@Produce		json
-//	@Param			user_id	formData	string	true	"User ID (UUID)"
-//	@Param			file	formData	file	true	"File to upload"
-//	@Success		201		{object}	response.UploadFileResponse
-//	@Failure		400		{object}	map[string]string
-//	@Failure		500		{object}	map[string]string
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+//	@Param			folder_id	formData	string	false	"Folder ID (UUID), top level when omitted"
+//	@Param			file		formData	file	true	"File to upload"
+//	@Success		201			{object}	response.UploadFileResponse
+//	@Failure		400			{object}	map[string]string
+//	@Failure		401			{object}	map[string]string
+//	@Failure		403			{object}	map[string]string
+//	@Failure		404			{object}	map[string]string
+//	@Failure		500			{object}	map[string]string
 //	@Router			/api/v1/storage/upload [post]
 func (h *StorageHandler) UploadFile(c *gin.Context) {
+	user, ok := authUser(c)
+	if !ok {
+		return
+	}
+
 	var req request.UploadFileRequest
 	if err := c.ShouldBind(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -41,9 +56,9 @@ func (h *StorageHandler) UploadFile(c *gin.Context) {
 		return
 	}
 
-	resp, err := h.storageManager.UploadFile(c.Request.Context(), req.UserID, header.Filename, int64(len(fileBytes)), fileBytes)
+	resp, err := h.storageManager.UploadFile(c.Request.Context(), user, req.FolderID, header.Filename, int64(len(fileBytes)), fileBytes)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(grpcstatus.HTTPStatus(err), gin.H{"error": grpcstatus.Message(err)})
 		return
 	}
 
@@ -52,3 +67,72 @@ func (h *StorageHandler) UploadFile(c *gin.Context) {
 		"file_name": resp.FileName,
 	})
 }
+
+// DownloadFile godoc
+//
+//	@Summary		Download file
+//	@Description	Download the content of a file owned by a user
+//	@Tags			Storage
+//	@Produce		octet-stream
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+//	@Param			id	path		string	true	"File ID (UUID)"
+//	@Success		200	{file}		binary
+//	@Failure		400	{object}	map[string]string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/api/v1/storage/files/{id}/download [get]
+func (h *StorageHandler) DownloadFile(c *gin.Context) {
+	userID, ok := authUserID(c)
+	if !ok {
+		return
+	}
+
+	var uri request.FileURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.storageManager.DownloadFile(c.Request.Context(), userID, uri.FileID)
+	if err != nil {
+		c.JSON(grpcstatus.HTTPStatus(err), gin.H{"error": grpcstatus.Message(err)})
+		return
+	}
+
+	sendFile(c, resp)
+}
+
+// authUser returns the user the request was authenticated as. Requests that
+// reach a storage route unauthenticated are answered with 401.
+func authUser(c *gin.Context) (*response.UserResponse, bool) {
+	user, ok := middleware.AuthUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "not authenticated"})
+		return nil, false
+	}
+	return user, true
+}
+
+// authUserID returns the id of the user the request was authenticated as, see
+// authUser.
+func authUserID(c *gin.Context) (string, bool) {
+	user, ok := authUser(c)
+	if !ok {
+		return "", false
+	}
+	return user.UserID, true
+}
+
+// sendFile streams a downloaded file as an attachment, guessing its content
+// type from the file name.
+func sendFile(c *gin.Context, resp *response.DownloadFileResponse) {
+	contentType := mime.TypeByExtension(filepath.Ext(resp.FileName))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	c.DataFromReader(http.StatusOK, resp.FileSize, contentType, resp.Content, map[string]string{
+		"Content-Disposition": mime.FormatMediaType("attachment", map[string]string{"filename": resp.FileName}),
+	})
+}
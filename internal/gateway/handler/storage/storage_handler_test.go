@@ -13,10 +13,15 @@ import (
 
 	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
 	clientstorage "github.com/a1y/doc-formatter/internal/gateway/clients/storage"
+	"github.com/a1y/doc-formatter/internal/gateway/domain/response"
 	storagemgr "github.com/a1y/doc-formatter/internal/gateway/manager/storage"
+	"github.com/a1y/doc-formatter/internal/gateway/middleware"
 	"github.com/a1y/doc-formatter/internal/testutil"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type mockStorageClient struct {
@@ -26,6 +31,9 @@ type mockStorageClient struct {
 	err  error
 
 	lastReq *storagepb.UploadFileRequest
+
+	downloadMsgs    []*storagepb.DownloadFileResponse
+	lastDownloadReq *storagepb.DownloadFileRequest
 }
 
 func (m *mockStorageClient) UploadFile(_ context.Context, req *storagepb.UploadFileRequest) (*storagepb.UploadFileResponse, error) {
@@ -33,32 +41,60 @@ func (m *mockStorageClient) UploadFile(_ context.Context, req *storagepb.UploadF
 	return m.resp, m.err
 }
 
+func (m *mockStorageClient) DownloadFile(_ context.Context, req *storagepb.DownloadFileRequest) (grpc.ServerStreamingClient[storagepb.DownloadFileResponse], error) {
+	m.lastDownloadReq = req
+	return &mockDownloadStream{msgs: m.downloadMsgs, err: m.err}, nil
+}
+
+type mockDownloadStream struct {
+	grpc.ClientStream
+
+	msgs []*storagepb.DownloadFileResponse
+	err  error
+}
+
+func (s *mockDownloadStream) Recv() (*storagepb.DownloadFileResponse, error) {
+	if len(s.msgs) == 0 {
+		if s.err != nil {
+			return nil, s.err
+		}
+		return nil, io.EOF
+	}
+	msg := s.msgs[0]
+	s.msgs = s.msgs[1:]
+	return msg, nil
+}
+
 func newTestHandler(t *testing.T, mockClient *mockStorageClient) *StorageHandler {
 	t.Helper()
 
-	manager := storagemgr.NewStorageManager(mockClient)
+	manager := storagemgr.NewStorageManager(mockClient, nil)
 	h, err := NewStorageHandler(manager)
 	assert.NoError(t, err)
 	return h
 }
 
+// authenticated stands in for middleware.AuthMiddleware and authenticates
+// every request as testUserID.
+func authenticated(c *gin.Context) {
+	c.Set(middleware.AuthUserKey, &response.UserResponse{UserID: testUserID, IsVerified: true})
+	c.Next()
+}
+
 func setupRouter(h *StorageHandler) *gin.Engine {
 	r := testutil.NewGinEngine()
+	r.Use(authenticated)
 	r.POST("/api/v1/storage/upload", h.UploadFile)
+	r.GET("/api/v1/storage/files/:id/download", h.DownloadFile)
 	return r
 }
 
-func createMultipartRequest(t *testing.T, userID string, includeFile bool) *http.Request {
+func createMultipartRequest(t *testing.T, includeFile bool) *http.Request {
 	t.Helper()
 
 	body := &bytes.Buffer{}
 	writer := multipart.NewWriter(body)
 
-	if userID != "" {
-		err := writer.WriteField("user_id", userID)
-		assert.NoError(t, err)
-	}
-
 	if includeFile {
 		fileWriter, err := writer.CreateFormFile("file", "test.txt")
 		assert.NoError(t, err)
@@ -86,8 +122,7 @@ func TestStorageHandler_UploadFileSuccess(t *testing.T) {
 	h := newTestHandler(t, mockClient)
 	router := setupRouter(h)
 
-	userID := "550e8400-e29b-41d4-a716-446655440000"
-	req := createMultipartRequest(t, userID, true)
+	req := createMultipartRequest(t, true)
 
 	w := httptest.NewRecorder()
 	router.ServeHTTP(w, req)
@@ -101,7 +136,7 @@ func TestStorageHandler_UploadFileSuccess(t *testing.T) {
 	assert.Equal(t, "test.txt", respBody["file_name"])
 
 	if assert.NotNil(t, mockClient.lastReq) {
-		assert.Equal(t, userID, mockClient.lastReq.GetUserId())
+		assert.Equal(t, testUserID, mockClient.lastReq.GetUserId())
 		assert.Equal(t, "test.txt", mockClient.lastReq.GetFileName())
 		assert.Equal(t, int64(len("hello world")), mockClient.lastReq.GetFileSize())
 		assert.Equal(t, []byte("hello world"), mockClient.lastReq.GetContent())
@@ -115,6 +150,7 @@ func TestStorageHandler_UploadFileBindError(t *testing.T) {
 
 	body := &bytes.Buffer{}
 	writer := multipart.NewWriter(body)
+	assert.NoError(t, writer.WriteField("folder_id", "not-a-uuid"))
 	fileWriter, err := writer.CreateFormFile("file", "test.txt")
 	assert.NoError(t, err)
 	_, err = io.Copy(fileWriter, bytes.NewReader([]byte("hello world")))
@@ -135,7 +171,7 @@ func TestStorageHandler_UploadFileFileMissing(t *testing.T) {
 	h := newTestHandler(t, mockClient)
 	router := setupRouter(h)
 
-	req := createMultipartRequest(t, "550e8400-e29b-41d4-a716-446655440000", false)
+	req := createMultipartRequest(t, false)
 
 	w := httptest.NewRecorder()
 	router.ServeHTTP(w, req)
@@ -151,10 +187,124 @@ func TestStorageHandler_UploadFileManagerError(t *testing.T) {
 	h := newTestHandler(t, mockClient)
 	router := setupRouter(h)
 
-	req := createMultipartRequest(t, "550e8400-e29b-41d4-a716-446655440000", true)
+	req := createMultipartRequest(t, true)
 
 	w := httptest.NewRecorder()
 	router.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
 }
+
+func TestStorageHandler_DownloadFileSuccess(t *testing.T) {
+	mockClient := &mockStorageClient{
+		downloadMsgs: []*storagepb.DownloadFileResponse{
+			{FileName: "contract.pdf", FileSize: 11},
+			{Chunk: []byte("hello ")},
+			{Chunk: []byte("world")},
+		},
+	}
+
+	h := newTestHandler(t, mockClient)
+	router := setupRouter(h)
+
+	fileID := "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/storage/files/"+fileID+"/download", nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "hello world", w.Body.String())
+	assert.Equal(t, "application/pdf", w.Header().Get("Content-Type"))
+	assert.Equal(t, "11", w.Header().Get("Content-Length"))
+	assert.Equal(t, "attachment; filename=contract.pdf", w.Header().Get("Content-Disposition"))
+
+	if assert.NotNil(t, mockClient.lastDownloadReq) {
+		assert.Equal(t, testUserID, mockClient.lastDownloadReq.GetUserId())
+		assert.Equal(t, fileID, mockClient.lastDownloadReq.GetFileId())
+	}
+}
+
+func TestStorageHandler_DownloadFileErrors(t *testing.T) {
+	fileID := "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+
+	tests := []struct {
+		name string
+		path string
+		err  error
+		want int
+	}{
+		{
+			name: "invalid file id",
+			path: "/api/v1/storage/files/not-a-uuid/download",
+			want: http.StatusBadRequest,
+		},
+		{
+			name: "file not found",
+			path: "/api/v1/storage/files/" + fileID + "/download",
+			err:  status.Error(codes.NotFound, "document not found"),
+			want: http.StatusNotFound,
+		},
+		{
+			name: "storage failure",
+			path: "/api/v1/storage/files/" + fileID + "/download",
+			err:  errors.New("download failed"),
+			want: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newTestHandler(t, &mockStorageClient{err: tt.err})
+			router := setupRouter(h)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			assert.Equal(t, tt.want, w.Code)
+		})
+	}
+}
+
+func TestStorageHandler_Unauthenticated(t *testing.T) {
+	mockClient := &mockStorageClient{}
+	h := newTestHandler(t, mockClient)
+
+	router := testutil.NewGinEngine()
+	router.POST("/api/v1/storage/upload", h.UploadFile)
+	router.GET("/api/v1/storage/files/:id/download", h.DownloadFile)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, createMultipartRequest(t, true))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Nil(t, mockClient.lastReq)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/storage/files/6ba7b810-9dad-11d1-80b4-00c04fd430c8/download", nil))
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Nil(t, mockClient.lastDownloadReq)
+}
+
+func TestStorageHandler_UploadFileRequiresVerifiedUser(t *testing.T) {
+	mockClient := &mockStorageClient{resp: &storagepb.UploadFileResponse{FileId: "file-id-123", FileName: "test.txt"}}
+	manager := storagemgr.NewStorageManager(mockClient, nil)
+	manager.SetRequireVerifiedUploads(true)
+	h, err := NewStorageHandler(manager)
+	assert.NoError(t, err)
+
+	router := testutil.NewGinEngine()
+	router.Use(func(c *gin.Context) {
+		c.Set(middleware.AuthUserKey, &response.UserResponse{UserID: testUserID})
+		c.Next()
+	})
+	router.POST("/api/v1/storage/upload", h.UploadFile)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, createMultipartRequest(t, true))
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Nil(t, mockClient.lastReq)
+
+	w = httptest.NewRecorder()
+	setupRouter(h).ServeHTTP(w, createMultipartRequest(t, true))
+	assert.Equal(t, http.StatusCreated, w.Code)
+}
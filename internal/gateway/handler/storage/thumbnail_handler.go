@@ -0,0 +1,46 @@
+package storage
+
+import (
+	"net/http"
+
+	"github.com/a1y/doc-formatter/internal/gateway/domain/request"
+	"github.com/a1y/doc-formatter/internal/gateway/util/grpcstatus"
+	"github.com/gin-gonic/gin"
+)
+
+// GetThumbnail godoc
+//
+//	@Summary		Get file thumbnail
+//	@Description	Return a PNG preview of the first page of a file. The thumbnail is rendered from the text of the file when a new version is uploaded, so styling and images are not shown.
+//	@Tags			Storage
+//	@Produce		png
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+//	@Param			id	path		string	true	"File ID (UUID)"
+//	@Success		200	{file}		binary
+//	@Failure		400	{object}	map[string]string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		422	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/api/v1/storage/files/{id}/thumbnail [get]
+func (h *StorageHandler) GetThumbnail(c *gin.Context) {
+	userID, ok := authUserID(c)
+	if !ok {
+		return
+	}
+
+	var uri request.FileURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.storageManager.GetThumbnail(c.Request.Context(), userID, uri.FileID)
+	if err != nil {
+		c.JSON(documentStatus(err), gin.H{"error": grpcstatus.Message(err)})
+		return
+	}
+
+	c.Data(http.StatusOK, resp.ContentType, resp.Image)
+}
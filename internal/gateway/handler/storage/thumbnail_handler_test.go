@@ -0,0 +1,68 @@
+package storage
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	storagemgr "github.com/a1y/doc-formatter/internal/gateway/manager/storage"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type mockThumbnailClient struct {
+	mockStorageClient
+
+	thumbnailErr  error
+	lastThumbnail *storagepb.GetThumbnailRequest
+}
+
+func (m *mockThumbnailClient) GetThumbnail(_ context.Context, req *storagepb.GetThumbnailRequest) (*storagepb.GetThumbnailResponse, error) {
+	m.lastThumbnail = req
+	if m.thumbnailErr != nil {
+		return nil, m.thumbnailErr
+	}
+	return &storagepb.GetThumbnailResponse{FileId: testFileID, ContentType: "image/png", Image: []byte("\x89PNG")}, nil
+}
+
+func setupThumbnailRouter(t *testing.T, mockClient *mockThumbnailClient) *gin.Engine {
+	t.Helper()
+
+	h, err := NewStorageHandler(storagemgr.NewStorageManager(mockClient, nil))
+	assert.NoError(t, err)
+	r := setupRouter(h)
+	r.GET("/api/v1/storage/files/:id/thumbnail", h.GetThumbnail)
+	return r
+}
+
+func TestStorageHandler_GetThumbnail(t *testing.T) {
+	mockClient := &mockThumbnailClient{}
+	r := setupThumbnailRouter(t, mockClient)
+
+	w := serve(r, http.MethodGet, "/api/v1/storage/files/"+testFileID+"/thumbnail", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "image/png", w.Header().Get("Content-Type"))
+	assert.Equal(t, "\x89PNG", w.Body.String())
+	assert.Equal(t, &storagepb.GetThumbnailRequest{UserId: testUserID, FileId: testFileID}, mockClient.lastThumbnail)
+}
+
+func TestStorageHandler_GetThumbnail_Errors(t *testing.T) {
+	mockClient := &mockThumbnailClient{}
+	r := setupThumbnailRouter(t, mockClient)
+
+	w := serve(r, http.MethodGet, "/api/v1/storage/files/not-a-uuid/thumbnail", "")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Nil(t, mockClient.lastThumbnail)
+
+	mockClient.thumbnailErr = status.Error(codes.FailedPrecondition, "operation not supported for this document format: application/pdf")
+	w = serve(r, http.MethodGet, "/api/v1/storage/files/"+testFileID+"/thumbnail", "")
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Contains(t, w.Body.String(), "application/pdf")
+
+	mockClient.thumbnailErr = status.Error(codes.NotFound, "document not found")
+	w = serve(r, http.MethodGet, "/api/v1/storage/files/"+testFileID+"/thumbnail", "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,150 @@
+package storage
+
+import (
+	"net/http"
+
+	"github.com/a1y/doc-formatter/internal/gateway/domain/request"
+	"github.com/a1y/doc-formatter/internal/gateway/util/grpcstatus"
+	"github.com/gin-gonic/gin"
+)
+
+// DeleteFile godoc
+//
+//	@Summary		Delete file
+//	@Description	Move a file into the trash. It can be restored until the trash is emptied or the retention period of the trash has passed.
+//	@Tags			Storage
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+//	@Param			id	path		string	true	"File ID (UUID)"
+//	@Success		200	{object}	response.FileInfoResponse
+//	@Failure		400	{object}	map[string]string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		403	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/api/v1/storage/files/{id} [delete]
+func (h *StorageHandler) DeleteFile(c *gin.Context) {
+	userID, ok := authUserID(c)
+	if !ok {
+		return
+	}
+
+	var uri request.FileURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.storageManager.DeleteFile(c.Request.Context(), userID, uri.FileID)
+	if err != nil {
+		c.JSON(grpcstatus.HTTPStatus(err), gin.H{"error": grpcstatus.Message(err)})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// ListTrash godoc
+//
+//	@Summary		List trash
+//	@Description	List a page of the files in the trash of a user, most recently deleted first. The next page is fetched by passing next_page_token as page_token; its URL is also given by the Link header.
+//	@Tags			Storage
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+//	@Param			page_size	query		int		false	"Maximum number of files of the page, 100 by default and at most 1000"
+//	@Param			page_token	query		string	false	"Token of the page to list, from next_page_token"
+//	@Success		200			{object}	response.ListTrashResponse
+//	@Header			200			{string}	Link	"URL of the next page with rel=next, absent on the last page"
+//	@Failure		400			{object}	map[string]string
+//	@Failure		401			{object}	map[string]string
+//	@Failure		500			{object}	map[string]string
+//	@Router			/api/v1/storage/trash [get]
+func (h *StorageHandler) ListTrash(c *gin.Context) {
+	userID, ok := authUserID(c)
+	if !ok {
+		return
+	}
+
+	var req request.ListTrashRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.storageManager.ListTrash(c.Request.Context(), userID, &req)
+	if err != nil {
+		c.JSON(grpcstatus.HTTPStatus(err), gin.H{"error": grpcstatus.Message(err)})
+		return
+	}
+
+	if resp.NextPageToken != "" {
+		c.Header("Link", nextPageLink(c.Request.URL, resp.NextPageToken))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// RestoreFile godoc
+//
+//	@Summary		Restore file
+//	@Description	Take a file out of the trash. It goes back into its folder, or to the top level when that folder was deleted since.
+//	@Tags			Storage
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+//	@Param			id	path		string	true	"File ID (UUID)"
+//	@Success		200	{object}	response.FileInfoResponse
+//	@Failure		400	{object}	map[string]string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		404	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/api/v1/storage/trash/{id}/restore [post]
+func (h *StorageHandler) RestoreFile(c *gin.Context) {
+	userID, ok := authUserID(c)
+	if !ok {
+		return
+	}
+
+	var uri request.FileURI
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.storageManager.RestoreFile(c.Request.Context(), userID, uri.FileID)
+	if err != nil {
+		c.JSON(grpcstatus.HTTPStatus(err), gin.H{"error": grpcstatus.Message(err)})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// EmptyTrash godoc
+//
+//	@Summary		Empty trash
+//	@Description	Delete every file in the trash of a user for good, along with its content.
+//	@Tags			Storage
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+//	@Success		200	{object}	response.EmptyTrashResponse
+//	@Failure		400	{object}	map[string]string
+//	@Failure		401	{object}	map[string]string
+//	@Failure		500	{object}	map[string]string
+//	@Router			/api/v1/storage/trash [delete]
+func (h *StorageHandler) EmptyTrash(c *gin.Context) {
+	userID, ok := authUserID(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.storageManager.EmptyTrash(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(grpcstatus.HTTPStatus(err), gin.H{"error": grpcstatus.Message(err)})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
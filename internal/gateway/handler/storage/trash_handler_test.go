@@ -0,0 +1,184 @@
+package storage
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	storagemgr "github.com/a1y/doc-formatter/internal/gateway/manager/storage"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type mockTrashClient struct {
+	mockStorageClient
+
+	trashErr      error
+	lastTrash     any
+	nextPageToken string
+}
+
+func trashedFile(fileID string) *storagepb.FileInfo {
+	return &storagepb.FileInfo{
+		FileId:        fileID,
+		FileName:      "report.pdf",
+		FileSize:      42,
+		CreatedAtUnix: 1767225600,
+		UpdatedAtUnix: 1767225600,
+		DeletedAtUnix: 1769904000,
+	}
+}
+
+func (m *mockTrashClient) DeleteFile(_ context.Context, req *storagepb.DeleteFileRequest) (*storagepb.DeleteFileResponse, error) {
+	m.lastTrash = req
+	if m.trashErr != nil {
+		return nil, m.trashErr
+	}
+	return &storagepb.DeleteFileResponse{File: trashedFile(req.GetFileId())}, nil
+}
+
+func (m *mockTrashClient) ListTrash(_ context.Context, req *storagepb.ListTrashRequest) (*storagepb.ListTrashResponse, error) {
+	m.lastTrash = req
+	if m.trashErr != nil {
+		return nil, m.trashErr
+	}
+	return &storagepb.ListTrashResponse{Files: []*storagepb.FileInfo{trashedFile(testFileID)}, NextPageToken: m.nextPageToken}, nil
+}
+
+func (m *mockTrashClient) RestoreFile(_ context.Context, req *storagepb.RestoreFileRequest) (*storagepb.RestoreFileResponse, error) {
+	m.lastTrash = req
+	if m.trashErr != nil {
+		return nil, m.trashErr
+	}
+	file := trashedFile(req.GetFileId())
+	file.DeletedAtUnix = 0
+	return &storagepb.RestoreFileResponse{File: file}, nil
+}
+
+func (m *mockTrashClient) EmptyTrash(_ context.Context, req *storagepb.EmptyTrashRequest) (*storagepb.EmptyTrashResponse, error) {
+	m.lastTrash = req
+	if m.trashErr != nil {
+		return nil, m.trashErr
+	}
+	return &storagepb.EmptyTrashResponse{PurgedFiles: 2}, nil
+}
+
+func setupTrashRouter(t *testing.T, mockClient *mockTrashClient) *gin.Engine {
+	t.Helper()
+
+	h, err := NewStorageHandler(storagemgr.NewStorageManager(mockClient, nil))
+	assert.NoError(t, err)
+	r := setupRouter(h)
+	r.DELETE("/api/v1/storage/files/:id", h.DeleteFile)
+	r.GET("/api/v1/storage/trash", h.ListTrash)
+	r.POST("/api/v1/storage/trash/:id/restore", h.RestoreFile)
+	r.DELETE("/api/v1/storage/trash", h.EmptyTrash)
+	return r
+}
+
+const testTrashedFileJSON = `{
+	"file_id":"` + testFileID + `",
+	"file_name":"report.pdf",
+	"file_size":42,
+	"created_at":"2026-01-01T00:00:00Z","updated_at":"2026-01-01T00:00:00Z",
+	"deleted_at":"2026-02-01T00:00:00Z"
+}`
+
+func TestStorageHandler_Trash(t *testing.T) {
+	mockClient := &mockTrashClient{}
+	r := setupTrashRouter(t, mockClient)
+
+	w := serve(r, http.MethodDelete, "/api/v1/storage/files/"+testFileID, "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, testTrashedFileJSON, w.Body.String())
+	assert.Equal(t, &storagepb.DeleteFileRequest{UserId: testUserID, FileId: testFileID}, mockClient.lastTrash)
+
+	w = serve(r, http.MethodGet, "/api/v1/storage/trash", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"files":[`+testTrashedFileJSON+`]}`, w.Body.String())
+	assert.Empty(t, w.Header().Get("Link"))
+	assert.Equal(t, &storagepb.ListTrashRequest{UserId: testUserID}, mockClient.lastTrash)
+
+	w = serve(r, http.MethodPost, "/api/v1/storage/trash/"+testFileID+"/restore", `{}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), "deleted_at")
+	assert.Equal(t, &storagepb.RestoreFileRequest{UserId: testUserID, FileId: testFileID}, mockClient.lastTrash)
+
+	w = serve(r, http.MethodDelete, "/api/v1/storage/trash", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"purged_files":2}`, w.Body.String())
+	assert.Equal(t, &storagepb.EmptyTrashRequest{UserId: testUserID}, mockClient.lastTrash)
+}
+
+func TestStorageHandler_ListTrash_Pages(t *testing.T) {
+	mockClient := &mockTrashClient{nextPageToken: "next-token"}
+	r := setupTrashRouter(t, mockClient)
+
+	w := serve(r, http.MethodGet, "/api/v1/storage/trash?page_size=1&page_token=first", "")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"files":[`+testTrashedFileJSON+`],"next_page_token":"next-token"}`, w.Body.String())
+	assert.Equal(t,
+		`</api/v1/storage/trash?page_size=1&page_token=next-token>; rel="next"`,
+		w.Header().Get("Link"))
+	assert.Equal(t, &storagepb.ListTrashRequest{UserId: testUserID, PageSize: 1, PageToken: "first"}, mockClient.lastTrash)
+}
+
+func TestStorageHandler_TrashErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		err    error
+		want   int
+	}{
+		{
+			name:   "delete with invalid file id",
+			method: http.MethodDelete,
+			path:   "/api/v1/storage/files/not-a-uuid",
+			want:   http.StatusBadRequest,
+		},
+		{
+			name:   "delete missing file",
+			method: http.MethodDelete,
+			path:   "/api/v1/storage/files/" + testFileID,
+			err:    status.Error(codes.NotFound, "document not found"),
+			want:   http.StatusNotFound,
+		},
+		{
+			name:   "list with negative page size",
+			method: http.MethodGet,
+			path:   "/api/v1/storage/trash?page_size=-1",
+			want:   http.StatusBadRequest,
+		},
+		{
+			name:   "restore file not in trash",
+			method: http.MethodPost,
+			path:   "/api/v1/storage/trash/" + testFileID + "/restore",
+			body:   `{}`,
+			err:    status.Error(codes.NotFound, "document not found"),
+			want:   http.StatusNotFound,
+		},
+		{
+			name:   "empty trash fails",
+			method: http.MethodDelete,
+			path:   "/api/v1/storage/trash",
+			err:    status.Error(codes.Internal, "internal error"),
+			want:   http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := setupTrashRouter(t, &mockTrashClient{trashErr: tt.err})
+
+			w := serve(r, tt.method, tt.path, tt.body)
+
+			assert.Equal(t, tt.want, w.Code)
+		})
+	}
+}
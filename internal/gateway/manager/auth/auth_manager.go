@@ -11,6 +11,94 @@ func (m *AuthManager) Signup(ctx context.Context, request request.SignupRequest)
 	return m.authClient.Signup(ctx, request.Email, request.Password)
 }
 
-func (m *AuthManager) Login(ctx context.Context, request request.LoginRequest) (*response.LoginResponse, error) {
-	return m.authClient.Login(ctx, request.Email, request.Password)
+func (m *AuthManager) Login(ctx context.Context, request request.LoginRequest, remoteAddr string) (*response.LoginResponse, error) {
+	return m.authClient.Login(ctx, request.Email, request.Password, remoteAddr)
+}
+
+func (m *AuthManager) VerifyEmail(ctx context.Context, request request.VerifyEmailRequest) (*response.UserResponse, error) {
+	return m.authClient.VerifyEmail(ctx, request.Token)
+}
+
+func (m *AuthManager) ResendVerification(ctx context.Context, request request.ResendVerificationRequest) error {
+	return m.authClient.ResendVerification(ctx, request.Email)
+}
+
+func (m *AuthManager) RequestPasswordReset(ctx context.Context, request request.ForgotPasswordRequest) error {
+	return m.authClient.RequestPasswordReset(ctx, request.Email)
+}
+
+func (m *AuthManager) ResetPassword(ctx context.Context, request request.ResetPasswordRequest) error {
+	return m.authClient.ResetPassword(ctx, request.Token, request.NewPassword)
+}
+
+func (m *AuthManager) ChangePassword(ctx context.Context, userID string, request request.ChangePasswordRequest) (*response.LoginResponse, error) {
+	return m.authClient.ChangePassword(ctx, userID, request.CurrentPassword, request.NewPassword)
+}
+
+func (m *AuthManager) ChangeEmail(ctx context.Context, userID string, request request.ChangeEmailRequest) error {
+	return m.authClient.ChangeEmail(ctx, userID, request.CurrentPassword, request.NewEmail)
+}
+
+func (m *AuthManager) VerifyMFA(ctx context.Context, request request.VerifyMFARequest, remoteAddr string) (*response.LoginResponse, error) {
+	return m.authClient.VerifyMFA(ctx, request.MFAToken, request.Code, remoteAddr)
+}
+
+func (m *AuthManager) EnrollTOTP(ctx context.Context, userID string) (*response.EnrollTOTPResponse, error) {
+	return m.authClient.EnrollTOTP(ctx, userID)
+}
+
+func (m *AuthManager) ConfirmTOTP(ctx context.Context, userID string, request request.ConfirmTOTPRequest) (*response.RecoveryCodesResponse, error) {
+	return m.authClient.ConfirmTOTP(ctx, userID, request.Code)
+}
+
+func (m *AuthManager) StartOIDCLogin(ctx context.Context, provider string) (*response.StartOIDCLoginResponse, error) {
+	return m.authClient.StartOIDCLogin(ctx, provider)
+}
+
+func (m *AuthManager) FinishOIDCLogin(ctx context.Context, provider string, request request.OIDCCallbackRequest, loginToken string) (*response.LoginResponse, error) {
+	return m.authClient.FinishOIDCLogin(ctx, provider, request.Code, request.State, loginToken)
+}
+
+func (m *AuthManager) CreateAPIKey(ctx context.Context, userID string, request request.CreateAPIKeyRequest) (*response.APIKeyResponse, error) {
+	return m.authClient.CreateAPIKey(ctx, userID, request.Name, request.Scopes)
+}
+
+func (m *AuthManager) ListAPIKeys(ctx context.Context, userID string) (*response.ListAPIKeysResponse, error) {
+	return m.authClient.ListAPIKeys(ctx, userID)
+}
+
+func (m *AuthManager) RevokeAPIKey(ctx context.Context, userID, keyID, impersonatorID string) error {
+	return m.authClient.RevokeAPIKey(ctx, userID, keyID, impersonatorID)
+}
+
+func (m *AuthManager) ListUsers(ctx context.Context, adminID string, request request.ListUsersRequest) (*response.ListUsersResponse, error) {
+	return m.authClient.ListUsers(ctx, adminID, request.Query, request.Role, request.PageSize, request.PageToken)
+}
+
+func (m *AuthManager) SetUserDisabled(ctx context.Context, adminID, userID string, disabled bool) (*response.AdminUserResponse, error) {
+	return m.authClient.SetUserDisabled(ctx, adminID, userID, disabled)
+}
+
+func (m *AuthManager) VerifyUser(ctx context.Context, adminID, userID string) (*response.AdminUserResponse, error) {
+	return m.authClient.VerifyUser(ctx, adminID, userID)
+}
+
+func (m *AuthManager) ResetUserMFA(ctx context.Context, adminID, userID string) (*response.AdminUserResponse, error) {
+	return m.authClient.ResetUserMFA(ctx, adminID, userID)
+}
+
+func (m *AuthManager) ImpersonateUser(ctx context.Context, adminID, userID string) (*response.LoginResponse, error) {
+	return m.authClient.ImpersonateUser(ctx, adminID, userID)
+}
+
+func (m *AuthManager) ListRoles(ctx context.Context, adminID string) (*response.ListRolesResponse, error) {
+	return m.authClient.ListRoles(ctx, adminID)
+}
+
+func (m *AuthManager) CreateRole(ctx context.Context, adminID string, request request.CreateRoleRequest) (*response.RoleResponse, error) {
+	return m.authClient.CreateRole(ctx, adminID, request.Name, request.Description, request.Permissions)
+}
+
+func (m *AuthManager) SetUserRole(ctx context.Context, adminID, userID string, request request.SetUserRoleRequest) (*response.AdminUserResponse, error) {
+	return m.authClient.SetUserRole(ctx, adminID, userID, request.Role)
 }
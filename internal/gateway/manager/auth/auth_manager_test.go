@@ -11,16 +11,146 @@ import (
 )
 
 type mockAuthClient struct {
-	signupFunc func(ctx context.Context, email, password string) (*response.SignUpResponse, error)
-	loginFunc  func(ctx context.Context, email, password string) (*response.LoginResponse, error)
+	signupFunc          func(ctx context.Context, email, password string) (*response.SignUpResponse, error)
+	loginFunc           func(ctx context.Context, email, password, remoteAddr string) (*response.LoginResponse, error)
+	lookupFunc          func(ctx context.Context, email string) (*response.UserResponse, error)
+	getFunc             func(ctx context.Context, userID string) (*response.UserResponse, error)
+	verifyFunc          func(ctx context.Context, token string) (*response.UserResponse, error)
+	resendFunc          func(ctx context.Context, email string) error
+	forgotFunc          func(ctx context.Context, email string) error
+	resetFunc           func(ctx context.Context, token, newPassword string) error
+	tokenFunc           func(ctx context.Context, accessToken string) (*response.UserResponse, error)
+	changePasswordFunc  func(ctx context.Context, userID, currentPassword, newPassword string) (*response.LoginResponse, error)
+	changeEmailFunc     func(ctx context.Context, userID, currentPassword, newEmail string) error
+	enrollTOTPFunc      func(ctx context.Context, userID string) (*response.EnrollTOTPResponse, error)
+	confirmTOTPFunc     func(ctx context.Context, userID, code string) (*response.RecoveryCodesResponse, error)
+	verifyMFAFunc       func(ctx context.Context, mfaToken, code, remoteAddr string) (*response.LoginResponse, error)
+	startOIDCLoginFunc  func(ctx context.Context, provider string) (*response.StartOIDCLoginResponse, error)
+	finishOIDCLoginFunc func(ctx context.Context, provider, code, state, loginToken string) (*response.LoginResponse, error)
+	createAPIKeyFunc    func(ctx context.Context, userID, name string, scopes []string) (*response.APIKeyResponse, error)
+	listAPIKeysFunc     func(ctx context.Context, userID string) (*response.ListAPIKeysResponse, error)
+	revokeAPIKeyFunc    func(ctx context.Context, userID, keyID, impersonatorID string) error
+	validateAPIKeyFunc  func(ctx context.Context, key string) (*response.UserResponse, []string, error)
+	listUsersFunc       func(ctx context.Context, adminID, query, role string, pageSize int32, pageToken string) (*response.ListUsersResponse, error)
+	setUserDisabledFunc func(ctx context.Context, adminID, userID string, disabled bool) (*response.AdminUserResponse, error)
+	verifyUserFunc      func(ctx context.Context, adminID, userID string) (*response.AdminUserResponse, error)
+	resetUserMFAFunc    func(ctx context.Context, adminID, userID string) (*response.AdminUserResponse, error)
+	impersonateUserFunc func(ctx context.Context, adminID, userID string) (*response.LoginResponse, error)
+	listRolesFunc       func(ctx context.Context, adminID string) (*response.ListRolesResponse, error)
+	createRoleFunc      func(ctx context.Context, adminID, name, description string, permissions []string) (*response.RoleResponse, error)
+	setUserRoleFunc     func(ctx context.Context, adminID, userID, role string) (*response.AdminUserResponse, error)
 }
 
 func (m *mockAuthClient) Signup(ctx context.Context, email, password string) (*response.SignUpResponse, error) {
 	return m.signupFunc(ctx, email, password)
 }
 
-func (m *mockAuthClient) Login(ctx context.Context, email, password string) (*response.LoginResponse, error) {
-	return m.loginFunc(ctx, email, password)
+func (m *mockAuthClient) Login(ctx context.Context, email, password, remoteAddr string) (*response.LoginResponse, error) {
+	return m.loginFunc(ctx, email, password, remoteAddr)
+}
+
+func (m *mockAuthClient) LookupUser(ctx context.Context, email string) (*response.UserResponse, error) {
+	return m.lookupFunc(ctx, email)
+}
+
+func (m *mockAuthClient) GetUser(ctx context.Context, userID string) (*response.UserResponse, error) {
+	return m.getFunc(ctx, userID)
+}
+
+func (m *mockAuthClient) VerifyEmail(ctx context.Context, token string) (*response.UserResponse, error) {
+	return m.verifyFunc(ctx, token)
+}
+
+func (m *mockAuthClient) ResendVerification(ctx context.Context, email string) error {
+	return m.resendFunc(ctx, email)
+}
+
+func (m *mockAuthClient) RequestPasswordReset(ctx context.Context, email string) error {
+	return m.forgotFunc(ctx, email)
+}
+
+func (m *mockAuthClient) ResetPassword(ctx context.Context, token, newPassword string) error {
+	return m.resetFunc(ctx, token, newPassword)
+}
+
+func (m *mockAuthClient) ValidateToken(ctx context.Context, accessToken string) (*response.UserResponse, error) {
+	return m.tokenFunc(ctx, accessToken)
+}
+
+func (m *mockAuthClient) ChangePassword(ctx context.Context, userID, currentPassword, newPassword string) (*response.LoginResponse, error) {
+	return m.changePasswordFunc(ctx, userID, currentPassword, newPassword)
+}
+
+func (m *mockAuthClient) ChangeEmail(ctx context.Context, userID, currentPassword, newEmail string) error {
+	return m.changeEmailFunc(ctx, userID, currentPassword, newEmail)
+}
+
+func (m *mockAuthClient) EnrollTOTP(ctx context.Context, userID string) (*response.EnrollTOTPResponse, error) {
+	return m.enrollTOTPFunc(ctx, userID)
+}
+
+func (m *mockAuthClient) ConfirmTOTP(ctx context.Context, userID, code string) (*response.RecoveryCodesResponse, error) {
+	return m.confirmTOTPFunc(ctx, userID, code)
+}
+
+func (m *mockAuthClient) VerifyMFA(ctx context.Context, mfaToken, code, remoteAddr string) (*response.LoginResponse, error) {
+	return m.verifyMFAFunc(ctx, mfaToken, code, remoteAddr)
+}
+
+func (m *mockAuthClient) StartOIDCLogin(ctx context.Context, provider string) (*response.StartOIDCLoginResponse, error) {
+	return m.startOIDCLoginFunc(ctx, provider)
+}
+
+func (m *mockAuthClient) FinishOIDCLogin(ctx context.Context, provider, code, state, loginToken string) (*response.LoginResponse, error) {
+	return m.finishOIDCLoginFunc(ctx, provider, code, state, loginToken)
+}
+
+func (m *mockAuthClient) CreateAPIKey(ctx context.Context, userID, name string, scopes []string) (*response.APIKeyResponse, error) {
+	return m.createAPIKeyFunc(ctx, userID, name, scopes)
+}
+
+func (m *mockAuthClient) ListAPIKeys(ctx context.Context, userID string) (*response.ListAPIKeysResponse, error) {
+	return m.listAPIKeysFunc(ctx, userID)
+}
+
+func (m *mockAuthClient) RevokeAPIKey(ctx context.Context, userID, keyID, impersonatorID string) error {
+	return m.revokeAPIKeyFunc(ctx, userID, keyID, impersonatorID)
+}
+
+func (m *mockAuthClient) ValidateAPIKey(ctx context.Context, key string) (*response.UserResponse, []string, error) {
+	return m.validateAPIKeyFunc(ctx, key)
+}
+
+func (m *mockAuthClient) ListUsers(ctx context.Context, adminID, query, role string, pageSize int32, pageToken string) (*response.ListUsersResponse, error) {
+	return m.listUsersFunc(ctx, adminID, query, role, pageSize, pageToken)
+}
+
+func (m *mockAuthClient) SetUserDisabled(ctx context.Context, adminID, userID string, disabled bool) (*response.AdminUserResponse, error) {
+	return m.setUserDisabledFunc(ctx, adminID, userID, disabled)
+}
+
+func (m *mockAuthClient) VerifyUser(ctx context.Context, adminID, userID string) (*response.AdminUserResponse, error) {
+	return m.verifyUserFunc(ctx, adminID, userID)
+}
+
+func (m *mockAuthClient) ResetUserMFA(ctx context.Context, adminID, userID string) (*response.AdminUserResponse, error) {
+	return m.resetUserMFAFunc(ctx, adminID, userID)
+}
+
+func (m *mockAuthClient) ImpersonateUser(ctx context.Context, adminID, userID string) (*response.LoginResponse, error) {
+	return m.impersonateUserFunc(ctx, adminID, userID)
+}
+
+func (m *mockAuthClient) ListRoles(ctx context.Context, adminID string) (*response.ListRolesResponse, error) {
+	return m.listRolesFunc(ctx, adminID)
+}
+
+func (m *mockAuthClient) CreateRole(ctx context.Context, adminID, name, description string, permissions []string) (*response.RoleResponse, error) {
+	return m.createRoleFunc(ctx, adminID, name, description, permissions)
+}
+
+func (m *mockAuthClient) SetUserRole(ctx context.Context, adminID, userID, role string) (*response.AdminUserResponse, error) {
+	return m.setUserRoleFunc(ctx, adminID, userID, role)
 }
 
 var _ auth.AuthClient = (*mockAuthClient)(nil)
@@ -35,7 +165,7 @@ func TestAuthManager_Signup_DelegatesToClient(t *testing.T) {
 			assert.Equal(t, "password", password)
 			return expected, nil
 		},
-		loginFunc: func(ctx context.Context, email, password string) (*response.LoginResponse, error) {
+		loginFunc: func(ctx context.Context, email, password, remoteAddr string) (*response.LoginResponse, error) {
 			t.Fatalf("unexpected call to Login")
 			return nil, nil
 		},
@@ -64,9 +194,10 @@ func TestAuthManager_Login_DelegatesToClient(t *testing.T) {
 			t.Fatalf("unexpected call to Signup")
 			return nil, nil
 		},
-		loginFunc: func(ctx context.Context, email, password string) (*response.LoginResponse, error) {
+		loginFunc: func(ctx context.Context, email, password, remoteAddr string) (*response.LoginResponse, error) {
 			assert.Equal(t, "test@example.com", email)
 			assert.Equal(t, "password", password)
+			assert.Equal(t, "192.0.2.1", remoteAddr)
 			return expected, nil
 		},
 	}
@@ -76,7 +207,302 @@ func TestAuthManager_Login_DelegatesToClient(t *testing.T) {
 	resp, err := manager.Login(context.Background(), request.LoginRequest{
 		Email:    "test@example.com",
 		Password: "password",
-	})
+	}, "192.0.2.1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, resp)
+}
+
+func TestAuthManager_VerifyEmail_DelegatesToClient(t *testing.T) {
+	t.Parallel()
+
+	expected := &response.UserResponse{UserID: "user-123", Email: "test@example.com", IsVerified: true}
+	mockClient := &mockAuthClient{
+		verifyFunc: func(ctx context.Context, token string) (*response.UserResponse, error) {
+			assert.Equal(t, "token-123", token)
+			return expected, nil
+		},
+	}
+
+	manager := NewAuthManager(mockClient)
+
+	resp, err := manager.VerifyEmail(context.Background(), request.VerifyEmailRequest{Token: "token-123"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, resp)
+}
+
+func TestAuthManager_ResendVerification_DelegatesToClient(t *testing.T) {
+	t.Parallel()
+
+	mockClient := &mockAuthClient{
+		resendFunc: func(ctx context.Context, email string) error {
+			assert.Equal(t, "test@example.com", email)
+			return nil
+		},
+	}
+
+	manager := NewAuthManager(mockClient)
+
+	err := manager.ResendVerification(context.Background(), request.ResendVerificationRequest{Email: "test@example.com"})
+
+	assert.NoError(t, err)
+}
+
+func TestAuthManager_RequestPasswordReset_DelegatesToClient(t *testing.T) {
+	t.Parallel()
+
+	mockClient := &mockAuthClient{
+		forgotFunc: func(ctx context.Context, email string) error {
+			assert.Equal(t, "test@example.com", email)
+			return nil
+		},
+	}
+
+	manager := NewAuthManager(mockClient)
+
+	err := manager.RequestPasswordReset(context.Background(), request.ForgotPasswordRequest{Email: "test@example.com"})
+
+	assert.NoError(t, err)
+}
+
+func TestAuthManager_ResetPassword_DelegatesToClient(t *testing.T) {
+	t.Parallel()
+
+	mockClient := &mockAuthClient{
+		resetFunc: func(ctx context.Context, token, newPassword string) error {
+			assert.Equal(t, "token-123", token)
+			assert.Equal(t, "new-password", newPassword)
+			return nil
+		},
+	}
+
+	manager := NewAuthManager(mockClient)
+
+	err := manager.ResetPassword(context.Background(), request.ResetPasswordRequest{Token: "token-123", NewPassword: "new-password"})
+
+	assert.NoError(t, err)
+}
+
+func TestAuthManager_ChangePassword_DelegatesToClient(t *testing.T) {
+	t.Parallel()
+
+	expected := &response.LoginResponse{AccessToken: "token", ExpiryUnix: 42}
+	mockClient := &mockAuthClient{
+		changePasswordFunc: func(ctx context.Context, userID, currentPassword, newPassword string) (*response.LoginResponse, error) {
+			assert.Equal(t, "user-123", userID)
+			assert.Equal(t, "old-password", currentPassword)
+			assert.Equal(t, "new-password", newPassword)
+			return expected, nil
+		},
+	}
+
+	manager := NewAuthManager(mockClient)
+
+	resp, err := manager.ChangePassword(context.Background(), "user-123", request.ChangePasswordRequest{CurrentPassword: "old-password", NewPassword: "new-password"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, resp)
+}
+
+func TestAuthManager_ChangeEmail_DelegatesToClient(t *testing.T) {
+	t.Parallel()
+
+	mockClient := &mockAuthClient{
+		changeEmailFunc: func(ctx context.Context, userID, currentPassword, newEmail string) error {
+			assert.Equal(t, "user-123", userID)
+			assert.Equal(t, "password", currentPassword)
+			assert.Equal(t, "new@example.com", newEmail)
+			return nil
+		},
+	}
+
+	manager := NewAuthManager(mockClient)
+
+	err := manager.ChangeEmail(context.Background(), "user-123", request.ChangeEmailRequest{CurrentPassword: "password", NewEmail: "new@example.com"})
+
+	assert.NoError(t, err)
+}
+
+func TestAuthManager_EnrollTOTP_DelegatesToClient(t *testing.T) {
+	t.Parallel()
+
+	expected := &response.EnrollTOTPResponse{Secret: "SECRET", OtpauthURI: "otpauth://totp/x"}
+	mockClient := &mockAuthClient{
+		enrollTOTPFunc: func(ctx context.Context, userID string) (*response.EnrollTOTPResponse, error) {
+			assert.Equal(t, "user-123", userID)
+			return expected, nil
+		},
+	}
+
+	manager := NewAuthManager(mockClient)
+
+	resp, err := manager.EnrollTOTP(context.Background(), "user-123")
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, resp)
+}
+
+func TestAuthManager_ConfirmTOTP_DelegatesToClient(t *testing.T) {
+	t.Parallel()
+
+	expected := &response.RecoveryCodesResponse{RecoveryCodes: []string{"abcd-efgh-ijkl-mnop"}}
+	mockClient := &mockAuthClient{
+		confirmTOTPFunc: func(ctx context.Context, userID, code string) (*response.RecoveryCodesResponse, error) {
+			assert.Equal(t, "user-123", userID)
+			assert.Equal(t, "123456", code)
+			return expected, nil
+		},
+	}
+
+	manager := NewAuthManager(mockClient)
+
+	resp, err := manager.ConfirmTOTP(context.Background(), "user-123", request.ConfirmTOTPRequest{Code: "123456"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, resp)
+}
+
+func TestAuthManager_VerifyMFA_DelegatesToClient(t *testing.T) {
+	t.Parallel()
+
+	expected := &response.LoginResponse{AccessToken: "token", ExpiryUnix: 42}
+	mockClient := &mockAuthClient{
+		verifyMFAFunc: func(ctx context.Context, mfaToken, code, remoteAddr string) (*response.LoginResponse, error) {
+			assert.Equal(t, "mfa-token", mfaToken)
+			assert.Equal(t, "123456", code)
+			assert.Equal(t, "192.0.2.1", remoteAddr)
+			return expected, nil
+		},
+	}
+
+	manager := NewAuthManager(mockClient)
+
+	resp, err := manager.VerifyMFA(context.Background(), request.VerifyMFARequest{MFAToken: "mfa-token", Code: "123456"}, "192.0.2.1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, resp)
+}
+
+func TestAuthManager_StartOIDCLogin_DelegatesToClient(t *testing.T) {
+	t.Parallel()
+
+	expected := &response.StartOIDCLoginResponse{AuthorizationURL: "https://idp.example.com/authorize", LoginToken: "login-token"}
+	mockClient := &mockAuthClient{
+		startOIDCLoginFunc: func(ctx context.Context, provider string) (*response.StartOIDCLoginResponse, error) {
+			assert.Equal(t, "corp", provider)
+			return expected, nil
+		},
+	}
+
+	manager := NewAuthManager(mockClient)
+
+	resp, err := manager.StartOIDCLogin(context.Background(), "corp")
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, resp)
+}
+
+func TestAuthManager_FinishOIDCLogin_DelegatesToClient(t *testing.T) {
+	t.Parallel()
+
+	expected := &response.LoginResponse{AccessToken: "token", ExpiryUnix: 42}
+	mockClient := &mockAuthClient{
+		finishOIDCLoginFunc: func(ctx context.Context, provider, code, state, loginToken string) (*response.LoginResponse, error) {
+			assert.Equal(t, "corp", provider)
+			assert.Equal(t, "code", code)
+			assert.Equal(t, "state", state)
+			assert.Equal(t, "login-token", loginToken)
+			return expected, nil
+		},
+	}
+
+	manager := NewAuthManager(mockClient)
+
+	resp, err := manager.FinishOIDCLogin(context.Background(), "corp", request.OIDCCallbackRequest{Code: "code", State: "state"}, "login-token")
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, resp)
+}
+
+func TestAuthManager_CreateAPIKey_DelegatesToClient(t *testing.T) {
+	t.Parallel()
+
+	expected := &response.APIKeyResponse{KeyID: "key-1", Key: "dfk_0123456789ab_SECRET"}
+	mockClient := &mockAuthClient{
+		createAPIKeyFunc: func(ctx context.Context, userID, name string, scopes []string) (*response.APIKeyResponse, error) {
+			assert.Equal(t, "user-1", userID)
+			assert.Equal(t, "ci", name)
+			assert.Equal(t, []string{"read"}, scopes)
+			return expected, nil
+		},
+	}
+
+	manager := NewAuthManager(mockClient)
+
+	resp, err := manager.CreateAPIKey(context.Background(), "user-1", request.CreateAPIKeyRequest{Name: "ci", Scopes: []string{"read"}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, resp)
+}
+
+func TestAuthManager_RevokeAPIKey_DelegatesToClient(t *testing.T) {
+	t.Parallel()
+
+	mockClient := &mockAuthClient{
+		revokeAPIKeyFunc: func(ctx context.Context, userID, keyID, impersonatorID string) error {
+			assert.Equal(t, "user-1", userID)
+			assert.Equal(t, "key-1", keyID)
+			assert.Equal(t, "admin-1", impersonatorID)
+			return nil
+		},
+	}
+
+	manager := NewAuthManager(mockClient)
+
+	assert.NoError(t, manager.RevokeAPIKey(context.Background(), "user-1", "key-1", "admin-1"))
+}
+
+func TestAuthManager_ListUsers_DelegatesToClient(t *testing.T) {
+	t.Parallel()
+
+	expected := &response.ListUsersResponse{Users: []response.AdminUserResponse{{UserID: "user-1"}}, NextPageToken: "next"}
+	mockClient := &mockAuthClient{
+		listUsersFunc: func(ctx context.Context, adminID, query, role string, pageSize int32, pageToken string) (*response.ListUsersResponse, error) {
+			assert.Equal(t, "admin-1", adminID)
+			assert.Equal(t, "example", query)
+			assert.Equal(t, "user", role)
+			assert.Equal(t, int32(10), pageSize)
+			assert.Equal(t, "token", pageToken)
+			return expected, nil
+		},
+	}
+
+	manager := NewAuthManager(mockClient)
+
+	resp, err := manager.ListUsers(context.Background(), "admin-1", request.ListUsersRequest{Query: "example", Role: "user", PageSize: 10, PageToken: "token"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, resp)
+}
+
+func TestAuthManager_CreateRole_DelegatesToClient(t *testing.T) {
+	t.Parallel()
+
+	expected := &response.RoleResponse{Name: "support", Permissions: []string{"users:read"}}
+	mockClient := &mockAuthClient{
+		createRoleFunc: func(ctx context.Context, adminID, name, description string, permissions []string) (*response.RoleResponse, error) {
+			assert.Equal(t, "admin-1", adminID)
+			assert.Equal(t, "support", name)
+			assert.Equal(t, "Support staff", description)
+			assert.Equal(t, []string{"users:read"}, permissions)
+			return expected, nil
+		},
+	}
+
+	manager := NewAuthManager(mockClient)
+
+	resp, err := manager.CreateRole(context.Background(), "admin-1", request.CreateRoleRequest{Name: "support", Description: "Support staff", Permissions: []string{"users:read"}})
 
 	assert.NoError(t, err)
 	assert.Equal(t, expected, resp)
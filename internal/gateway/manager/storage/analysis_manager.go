@@ -0,0 +1,77 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/a1y/doc-formatter/internal/gateway/domain/response"
+)
+
+// AnalyzeDocument returns the outline, statistics and properties of a file.
+func (m *StorageManager) AnalyzeDocument(ctx context.Context, userID string, fileID string) (*response.DocumentAnalysisResponse, error) {
+	resp, err := m.client.AnalyzeDocument(ctx, &storagepb.AnalyzeDocumentRequest{
+		UserId: userID,
+		FileId: fileID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stats := resp.GetStatistics()
+	props := resp.GetProperties()
+	out := &response.DocumentAnalysisResponse{
+		FileID:  resp.GetFileId(),
+		Outline: toHeadingResponses(resp.GetOutline()),
+		Statistics: response.DocumentStatisticsResponse{
+			Words:              stats.GetWords(),
+			Characters:         stats.GetCharacters(),
+			CharactersNoSpaces: stats.GetCharactersNoSpaces(),
+			Paragraphs:         stats.GetParagraphs(),
+			Pages:              stats.GetPages(),
+			PagesEstimated:     stats.GetPagesEstimated(),
+		},
+		Images:   make([]response.DocumentImageResponse, 0, len(resp.GetImages())),
+		Tables:   make([]response.DocumentTableResponse, 0, len(resp.GetTables())),
+		Language: resp.GetLanguage(),
+		Fonts:    resp.GetFonts(),
+		Properties: response.DocumentPropertiesResponse{
+			Title:          props.GetTitle(),
+			Subject:        props.GetSubject(),
+			Description:    props.GetDescription(),
+			Author:         props.GetAuthor(),
+			LastModifiedBy: props.GetLastModifiedBy(),
+			Keywords:       props.GetKeywords(),
+			CreatedAt:      unixOrNil(props.GetCreatedAtUnix()),
+			ModifiedAt:     unixOrNil(props.GetModifiedAtUnix()),
+		},
+		AnalyzedAt: time.Unix(resp.GetAnalyzedAtUnix(), 0).UTC(),
+	}
+	if out.Fonts == nil {
+		out.Fonts = []string{}
+	}
+	for _, image := range resp.GetImages() {
+		out.Images = append(out.Images, response.DocumentImageResponse{
+			Name:        image.GetName(),
+			ContentType: image.GetContentType(),
+			Size:        image.GetSize(),
+			Description: image.GetDescription(),
+		})
+	}
+	for _, table := range resp.GetTables() {
+		out.Tables = append(out.Tables, response.DocumentTableResponse{Rows: table.GetRows(), Columns: table.GetColumns()})
+	}
+	return out, nil
+}
+
+func toHeadingResponses(headings []*storagepb.Heading) []response.HeadingResponse {
+	out := make([]response.HeadingResponse, 0, len(headings))
+	for _, heading := range headings {
+		h := response.HeadingResponse{Level: heading.GetLevel(), Text: heading.GetText()}
+		if len(heading.GetChildren()) > 0 {
+			h.Children = toHeadingResponses(heading.GetChildren())
+		}
+		out = append(out, h)
+	}
+	return out
+}
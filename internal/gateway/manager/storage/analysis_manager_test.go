@@ -0,0 +1,87 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/a1y/doc-formatter/internal/gateway/clients/storage"
+	"github.com/a1y/doc-formatter/internal/gateway/domain/response"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type stubAnalysisClient struct {
+	storage.StorageClient
+
+	resp *storagepb.AnalyzeDocumentResponse
+	err  error
+
+	lastReq *storagepb.AnalyzeDocumentRequest
+}
+
+func (s *stubAnalysisClient) AnalyzeDocument(_ context.Context, req *storagepb.AnalyzeDocumentRequest) (*storagepb.AnalyzeDocumentResponse, error) {
+	s.lastReq = req
+	return s.resp, s.err
+}
+
+func TestStorageManager_AnalyzeDocument(t *testing.T) {
+	t.Parallel()
+
+	created := time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC)
+	analyzed := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	client := &stubAnalysisClient{resp: &storagepb.AnalyzeDocumentResponse{
+		FileId: "file-id",
+		Outline: []*storagepb.Heading{
+			{Level: 1, Text: "Intro", Children: []*storagepb.Heading{{Level: 2, Text: "Scope"}}},
+		},
+		Statistics: &storagepb.DocumentStatistics{Words: 120, Characters: 700, CharactersNoSpaces: 580, Paragraphs: 8, Pages: 1, PagesEstimated: true},
+		Images:     []*storagepb.DocumentImage{{Name: "word/media/image1.png", ContentType: "image/png", Size: 512}},
+		Tables:     []*storagepb.DocumentTable{{Rows: 3, Columns: 2}},
+		Language:   "en",
+		Fonts:      []string{"Georgia"},
+		Properties: &storagepb.DocumentProperties{
+			Title:         "Report",
+			Author:        "Ada",
+			Keywords:      []string{"finance"},
+			CreatedAtUnix: created.Unix(),
+		},
+		AnalyzedAtUnix: analyzed.Unix(),
+	}}
+	mgr := NewStorageManager(client, nil)
+
+	got, err := mgr.AnalyzeDocument(context.Background(), "user-id", "file-id")
+	require.NoError(t, err)
+	require.Equal(t, &storagepb.AnalyzeDocumentRequest{UserId: "user-id", FileId: "file-id"}, client.lastReq)
+	require.Equal(t, &response.DocumentAnalysisResponse{
+		FileID: "file-id",
+		Outline: []response.HeadingResponse{
+			{Level: 1, Text: "Intro", Children: []response.HeadingResponse{{Level: 2, Text: "Scope"}}},
+		},
+		Statistics: response.DocumentStatisticsResponse{Words: 120, Characters: 700, CharactersNoSpaces: 580, Paragraphs: 8, Pages: 1, PagesEstimated: true},
+		Images:     []response.DocumentImageResponse{{Name: "word/media/image1.png", ContentType: "image/png", Size: 512}},
+		Tables:     []response.DocumentTableResponse{{Rows: 3, Columns: 2}},
+		Language:   "en",
+		Fonts:      []string{"Georgia"},
+		Properties: response.DocumentPropertiesResponse{
+			Title:     "Report",
+			Author:    "Ada",
+			Keywords:  []string{"finance"},
+			CreatedAt: &created,
+		},
+		AnalyzedAt: analyzed,
+	}, got)
+}
+
+func TestStorageManager_AnalyzeDocument_Error(t *testing.T) {
+	t.Parallel()
+
+	client := &stubAnalysisClient{err: status.Error(codes.FailedPrecondition, "operation not supported for this document format")}
+	mgr := NewStorageManager(client, nil)
+
+	got, err := mgr.AnalyzeDocument(context.Background(), "user-id", "file-id")
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	require.Nil(t, got)
+}
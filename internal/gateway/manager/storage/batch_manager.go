@@ -0,0 +1,91 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/a1y/doc-formatter/internal/gateway/domain/request"
+	"github.com/a1y/doc-formatter/internal/gateway/domain/response"
+)
+
+// CreateBatchJob starts formatting the files of a folder, of a tag or of a
+// list with one profile, in the background.
+func (m *StorageManager) CreateBatchJob(ctx context.Context, userID string, req *request.CreateBatchJobRequest) (*response.BatchJobResponse, error) {
+	resp, err := m.client.CreateBatchJob(ctx, &storagepb.CreateBatchJobRequest{
+		UserId:    userID,
+		FolderId:  req.FolderID,
+		Recursive: req.Recursive,
+		Tag:       req.Tag,
+		FileIds:   req.FileIDs,
+		Profile: &storagepb.StyleProfile{
+			FontFamily:            req.Profile.FontFamily,
+			FontSize:              req.Profile.FontSize,
+			HeadingFontFamily:     req.Profile.HeadingFontFamily,
+			LineSpacing:           req.Profile.LineSpacing,
+			Margin:                req.Profile.Margin,
+			ClearDirectFormatting: req.Profile.ClearDirectFormatting,
+		},
+		OutputFolderId: req.OutputFolderID,
+		Archive:        req.Archive,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toBatchJobResponse(resp.GetJob()), nil
+}
+
+func (m *StorageManager) GetBatchJob(ctx context.Context, userID string, jobID string) (*response.BatchJobResponse, error) {
+	resp, err := m.client.GetBatchJob(ctx, &storagepb.GetBatchJobRequest{
+		UserId: userID,
+		JobId:  jobID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toBatchJobResponse(resp.GetJob()), nil
+}
+
+func toBatchJobResponse(job *storagepb.BatchJob) *response.BatchJobResponse {
+	profile := job.GetProfile()
+	out := &response.BatchJobResponse{
+		JobID:  job.GetId(),
+		Status: job.GetStatus(),
+		Profile: response.StyleProfileResponse{
+			FontFamily:            profile.GetFontFamily(),
+			FontSize:              profile.GetFontSize(),
+			HeadingFontFamily:     profile.GetHeadingFontFamily(),
+			LineSpacing:           profile.GetLineSpacing(),
+			Margin:                profile.GetMargin(),
+			ClearDirectFormatting: profile.GetClearDirectFormatting(),
+		},
+		OutputFolderID: job.GetOutputFolderId(),
+		Archive:        job.GetArchive(),
+		Progress: response.BatchProgressResponse{
+			Total:     job.GetTotal(),
+			Pending:   job.GetPending(),
+			Running:   job.GetRunning(),
+			Succeeded: job.GetSucceeded(),
+			Failed:    job.GetFailed(),
+		},
+		Items:         make([]response.BatchJobItemResponse, len(job.GetItems())),
+		ArchiveFileID: job.GetArchiveFileId(),
+		Error:         job.GetError(),
+		CreatedAt:     time.Unix(job.GetCreatedAtUnix(), 0).UTC(),
+	}
+	if job.GetFinishedAtUnix() != 0 {
+		finishedAt := time.Unix(job.GetFinishedAtUnix(), 0).UTC()
+		out.FinishedAt = &finishedAt
+	}
+	for i, item := range job.GetItems() {
+		out.Items[i] = response.BatchJobItemResponse{
+			FileID:       item.GetFileId(),
+			FileName:     item.GetFileName(),
+			Status:       item.GetStatus(),
+			OutputFileID: item.GetOutputFileId(),
+			Error:        item.GetError(),
+			Warnings:     item.GetWarnings(),
+		}
+	}
+	return out
+}
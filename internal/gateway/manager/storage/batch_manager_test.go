@@ -0,0 +1,104 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/a1y/doc-formatter/internal/gateway/clients/storage"
+	"github.com/a1y/doc-formatter/internal/gateway/domain/request"
+	"github.com/a1y/doc-formatter/internal/gateway/domain/response"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type stubBatchClient struct {
+	storage.StorageClient
+
+	job *storagepb.BatchJob
+	err error
+
+	lastReq any
+}
+
+func (s *stubBatchClient) CreateBatchJob(_ context.Context, req *storagepb.CreateBatchJobRequest) (*storagepb.CreateBatchJobResponse, error) {
+	s.lastReq = req
+	return &storagepb.CreateBatchJobResponse{Job: s.job}, s.err
+}
+
+func (s *stubBatchClient) GetBatchJob(_ context.Context, req *storagepb.GetBatchJobRequest) (*storagepb.GetBatchJobResponse, error) {
+	s.lastReq = req
+	return &storagepb.GetBatchJobResponse{Job: s.job}, s.err
+}
+
+func TestStorageManager_BatchCalls(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	client := &stubBatchClient{job: &storagepb.BatchJob{
+		Id:        "job-id",
+		Status:    "completed",
+		Profile:   &storagepb.StyleProfile{FontFamily: "Georgia", FontSize: 11},
+		Archive:   true,
+		Total:     2,
+		Succeeded: 1,
+		Failed:    1,
+		Items: []*storagepb.BatchJobItem{
+			{FileId: "a", FileName: "a.docx", Status: "succeeded", OutputFileId: "a2", Warnings: []string{"kept fonts"}},
+			{FileId: "b", FileName: "b.md", Status: "failed", Error: "unsupported"},
+		},
+		ArchiveFileId:  "zip-id",
+		CreatedAtUnix:  at.Unix(),
+		FinishedAtUnix: at.Unix(),
+	}}
+	mgr := NewStorageManager(client, nil)
+	ctx := context.Background()
+
+	want := &response.BatchJobResponse{
+		JobID:    "job-id",
+		Status:   "completed",
+		Profile:  response.StyleProfileResponse{FontFamily: "Georgia", FontSize: 11},
+		Archive:  true,
+		Progress: response.BatchProgressResponse{Total: 2, Succeeded: 1, Failed: 1},
+		Items: []response.BatchJobItemResponse{
+			{FileID: "a", FileName: "a.docx", Status: "succeeded", OutputFileID: "a2", Warnings: []string{"kept fonts"}},
+			{FileID: "b", FileName: "b.md", Status: "failed", Error: "unsupported"},
+		},
+		ArchiveFileID: "zip-id",
+		CreatedAt:     at,
+		FinishedAt:    &at,
+	}
+
+	job, err := mgr.CreateBatchJob(ctx, "user-id", &request.CreateBatchJobRequest{
+		FolderID:  "folder-id",
+		Recursive: true,
+		Profile:   request.StyleProfileRequest{FontFamily: "Georgia", FontSize: 11, ClearDirectFormatting: true},
+		Archive:   true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, want, job)
+	require.Equal(t, &storagepb.CreateBatchJobRequest{
+		UserId:    "user-id",
+		FolderId:  "folder-id",
+		Recursive: true,
+		Profile:   &storagepb.StyleProfile{FontFamily: "Georgia", FontSize: 11, ClearDirectFormatting: true},
+		Archive:   true,
+	}, client.lastReq)
+
+	job, err = mgr.GetBatchJob(ctx, "user-id", "job-id")
+	require.NoError(t, err)
+	require.Equal(t, want, job)
+	require.Equal(t, &storagepb.GetBatchJobRequest{UserId: "user-id", JobId: "job-id"}, client.lastReq)
+
+	client.job = &storagepb.BatchJob{Id: "job-id", Status: "running", CreatedAtUnix: at.Unix()}
+	job, err = mgr.GetBatchJob(ctx, "user-id", "job-id")
+	require.NoError(t, err)
+	require.Nil(t, job.FinishedAt)
+	require.Empty(t, job.Items)
+
+	client.err = status.Error(codes.NotFound, "batch job not found")
+	_, err = mgr.GetBatchJob(ctx, "user-id", "job-id")
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
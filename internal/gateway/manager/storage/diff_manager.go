@@ -0,0 +1,91 @@
+package storage
+
+import (
+	"context"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/a1y/doc-formatter/internal/gateway/domain/request"
+	"github.com/a1y/doc-formatter/internal/gateway/domain/response"
+)
+
+// DiffDocuments compares two files block by block and line by line.
+func (m *StorageManager) DiffDocuments(ctx context.Context, userID string, req *request.DiffDocumentsRequest) (*response.DocumentDiffResponse, error) {
+	resp, err := m.client.DiffDocuments(ctx, &storagepb.DiffDocumentsRequest{
+		UserId:       userID,
+		OldFileId:    req.OldFileID,
+		NewFileId:    req.NewFileID,
+		ContextLines: req.Context,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	summary := resp.GetSummary()
+	out := &response.DocumentDiffResponse{
+		OldFileID: resp.GetOldFileId(),
+		NewFileID: resp.GetNewFileId(),
+		Changes:   make([]response.StructuralChangeResponse, len(resp.GetChanges())),
+		Hunks:     make([]response.TextHunkResponse, len(resp.GetHunks())),
+		Summary: response.DiffSummaryResponse{
+			Inserted:     summary.GetInserted(),
+			Deleted:      summary.GetDeleted(),
+			Modified:     summary.GetModified(),
+			LinesAdded:   summary.GetLinesAdded(),
+			LinesRemoved: summary.GetLinesRemoved(),
+		},
+	}
+	for i, change := range resp.GetChanges() {
+		out.Changes[i] = response.StructuralChangeResponse{
+			Op:     change.GetOp(),
+			Old:    toDiffBlockResponse(change.GetOld()),
+			New:    toDiffBlockResponse(change.GetNew()),
+			Fields: change.GetFields(),
+		}
+	}
+	for i, hunk := range resp.GetHunks() {
+		lines := make([]response.TextLineResponse, len(hunk.GetLines()))
+		for j, line := range hunk.GetLines() {
+			lines[j] = response.TextLineResponse{Op: line.GetOp(), Text: line.GetText()}
+		}
+		out.Hunks[i] = response.TextHunkResponse{
+			OldStart: hunk.GetOldStart(),
+			OldLines: hunk.GetOldLines(),
+			NewStart: hunk.GetNewStart(),
+			NewLines: hunk.GetNewLines(),
+			Lines:    lines,
+		}
+	}
+	return out, nil
+}
+
+// RedlineDocuments opens a DOCX of the new file showing its changes from the
+// old one as tracked changes. Like DownloadFile, the content reads from the
+// stream until ctx is done.
+func (m *StorageManager) RedlineDocuments(ctx context.Context, userID string, req *request.RedlineDocumentsRequest) (*response.DownloadFileResponse, error) {
+	stream, err := m.client.RedlineDocuments(ctx, &storagepb.RedlineDocumentsRequest{
+		UserId:    userID,
+		OldFileId: req.OldFileID,
+		NewFileId: req.NewFileID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return openDownload(stream)
+}
+
+func toDiffBlockResponse(b *storagepb.DiffBlock) *response.DiffBlockResponse {
+	if b == nil {
+		return nil
+	}
+	out := &response.DiffBlockResponse{
+		Index: b.GetIndex(),
+		Kind:  b.GetKind(),
+		Level: b.GetLevel(),
+		Style: b.GetStyle(),
+		Text:  b.GetText(),
+	}
+	for _, row := range b.GetRows() {
+		out.Rows = append(out.Rows, row.GetCells())
+	}
+	return out
+}
@@ -0,0 +1,132 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/a1y/doc-formatter/internal/gateway/clients/storage"
+	"github.com/a1y/doc-formatter/internal/gateway/domain/request"
+	"github.com/a1y/doc-formatter/internal/gateway/domain/response"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type stubDiffClient struct {
+	storage.StorageClient
+
+	resp   *storagepb.DiffDocumentsResponse
+	stream *stubDownloadStream
+	err    error
+
+	lastReq any
+}
+
+func (s *stubDiffClient) DiffDocuments(_ context.Context, req *storagepb.DiffDocumentsRequest) (*storagepb.DiffDocumentsResponse, error) {
+	s.lastReq = req
+	return s.resp, s.err
+}
+
+func (s *stubDiffClient) RedlineDocuments(_ context.Context, req *storagepb.RedlineDocumentsRequest) (grpc.ServerStreamingClient[storagepb.DownloadFileResponse], error) {
+	s.lastReq = req
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.stream, nil
+}
+
+func TestStorageManager_DiffDocuments(t *testing.T) {
+	t.Parallel()
+
+	client := &stubDiffClient{resp: &storagepb.DiffDocumentsResponse{
+		OldFileId: "old-id",
+		NewFileId: "new-id",
+		Changes: []*storagepb.StructuralChange{
+			{
+				Op:     "modify",
+				Old:    &storagepb.DiffBlock{Index: 1, Kind: "paragraph", Text: "Buy milk."},
+				New:    &storagepb.DiffBlock{Index: 1, Kind: "paragraph", Text: "Buy bread."},
+				Fields: []string{"text"},
+			},
+			{
+				Op:  "insert",
+				New: &storagepb.DiffBlock{Index: 2, Kind: "table", Text: "a\tb", Rows: []*storagepb.DiffTableRow{{Cells: []string{"a", "b"}}}},
+			},
+		},
+		Hunks: []*storagepb.TextHunk{{
+			OldStart: 3, OldLines: 1, NewStart: 3, NewLines: 1,
+			Lines: []*storagepb.TextLine{{Op: "delete", Text: "Buy milk."}, {Op: "insert", Text: "Buy bread."}},
+		}},
+		Summary: &storagepb.DiffSummary{Inserted: 1, Modified: 1, LinesAdded: 1, LinesRemoved: 1},
+	}}
+	mgr := NewStorageManager(client, nil)
+
+	contextLines := int32(1)
+	got, err := mgr.DiffDocuments(context.Background(), "user-id", &request.DiffDocumentsRequest{
+		OldFileID: "old-id",
+		NewFileID: "new-id",
+		Context:   &contextLines,
+	})
+	require.NoError(t, err)
+	require.Equal(t, &storagepb.DiffDocumentsRequest{UserId: "user-id", OldFileId: "old-id", NewFileId: "new-id", ContextLines: &contextLines}, client.lastReq)
+	require.Equal(t, &response.DocumentDiffResponse{
+		OldFileID: "old-id",
+		NewFileID: "new-id",
+		Changes: []response.StructuralChangeResponse{
+			{
+				Op:     "modify",
+				Old:    &response.DiffBlockResponse{Index: 1, Kind: "paragraph", Text: "Buy milk."},
+				New:    &response.DiffBlockResponse{Index: 1, Kind: "paragraph", Text: "Buy bread."},
+				Fields: []string{"text"},
+			},
+			{
+				Op:  "insert",
+				New: &response.DiffBlockResponse{Index: 2, Kind: "table", Text: "a\tb", Rows: [][]string{{"a", "b"}}},
+			},
+		},
+		Hunks: []response.TextHunkResponse{{
+			OldStart: 3, OldLines: 1, NewStart: 3, NewLines: 1,
+			Lines: []response.TextLineResponse{{Op: "delete", Text: "Buy milk."}, {Op: "insert", Text: "Buy bread."}},
+		}},
+		Summary: response.DiffSummaryResponse{Inserted: 1, Modified: 1, LinesAdded: 1, LinesRemoved: 1},
+	}, got)
+}
+
+func TestStorageManager_RedlineDocuments(t *testing.T) {
+	t.Parallel()
+
+	client := &stubDiffClient{
+		stream: &stubDownloadStream{msgs: []*storagepb.DownloadFileResponse{
+			{FileName: "notes-redline.docx", FileSize: 4},
+			{Chunk: []byte("PK\x03\x04")},
+		}},
+	}
+	mgr := NewStorageManager(client, nil)
+
+	resp, err := mgr.RedlineDocuments(context.Background(), "user-id", &request.RedlineDocumentsRequest{OldFileID: "old-id", NewFileID: "new-id"})
+	require.NoError(t, err)
+	require.Equal(t, &storagepb.RedlineDocumentsRequest{UserId: "user-id", OldFileId: "old-id", NewFileId: "new-id"}, client.lastReq)
+	require.Equal(t, "notes-redline.docx", resp.FileName)
+	content, err := io.ReadAll(resp.Content)
+	require.NoError(t, err)
+	require.Equal(t, "PK\x03\x04", string(content))
+}
+
+func TestStorageManager_DiffDocuments_Error(t *testing.T) {
+	t.Parallel()
+
+	client := &stubDiffClient{err: status.Error(codes.FailedPrecondition, "unsupported document format")}
+	mgr := NewStorageManager(client, nil)
+	ctx := context.Background()
+
+	diff, err := mgr.DiffDocuments(ctx, "user-id", &request.DiffDocumentsRequest{OldFileID: "old-id", NewFileID: "new-id"})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	require.Nil(t, diff)
+
+	redline, err := mgr.RedlineDocuments(ctx, "user-id", &request.RedlineDocumentsRequest{OldFileID: "old-id", NewFileID: "new-id"})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	require.Nil(t, redline)
+}
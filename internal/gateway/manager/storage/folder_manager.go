@@ -0,0 +1,132 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/a1y/doc-formatter/internal/gateway/domain/response"
+)
+
+// MoveFile moves a file into folderID, or to the top level when folderID is
+// empty.
+func (m *StorageManager) MoveFile(ctx context.Context, userID string, fileID string, folderID string) (*response.FileInfoResponse, error) {
+	resp, err := m.client.MoveFile(ctx, &storagepb.MoveFileRequest{
+		UserId:   userID,
+		FileId:   fileID,
+		FolderId: folderID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	file := toFileInfoResponse(resp.GetFile())
+	return &file, nil
+}
+
+func (m *StorageManager) CreateFolder(ctx context.Context, userID string, name string, parentID string) (*response.FolderResponse, error) {
+	resp, err := m.client.CreateFolder(ctx, &storagepb.CreateFolderRequest{
+		UserId:   userID,
+		Name:     name,
+		ParentId: parentID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	folder := toFolderResponse(resp.GetFolder())
+	return &folder, nil
+}
+
+func (m *StorageManager) RenameFolder(ctx context.Context, userID string, folderID string, name string) (*response.FolderResponse, error) {
+	resp, err := m.client.RenameFolder(ctx, &storagepb.RenameFolderRequest{
+		UserId:   userID,
+		FolderId: folderID,
+		Name:     name,
+	})
+	if err != nil {
+		return nil, err
+	}
+	folder := toFolderResponse(resp.GetFolder())
+	return &folder, nil
+}
+
+// MoveFolder moves a folder under parentID, or to the top level when
+// parentID is empty.
+func (m *StorageManager) MoveFolder(ctx context.Context, userID string, folderID string, parentID string) (*response.FolderResponse, error) {
+	resp, err := m.client.MoveFolder(ctx, &storagepb.MoveFolderRequest{
+		UserId:   userID,
+		FolderId: folderID,
+		ParentId: parentID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	folder := toFolderResponse(resp.GetFolder())
+	return &folder, nil
+}
+
+func (m *StorageManager) DeleteFolder(ctx context.Context, userID string, folderID string, recursive bool) (*response.DeleteFolderResponse, error) {
+	resp, err := m.client.DeleteFolder(ctx, &storagepb.DeleteFolderRequest{
+		UserId:    userID,
+		FolderId:  folderID,
+		Recursive: recursive,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &response.DeleteFolderResponse{
+		DeletedFolders: resp.GetDeletedFolders(),
+		DeletedFiles:   resp.GetDeletedFiles(),
+	}, nil
+}
+
+// ListFolder lists the folders and files directly inside folderID, or at the
+// top level when folderID is empty.
+func (m *StorageManager) ListFolder(ctx context.Context, userID string, folderID string) (*response.ListFolderResponse, error) {
+	resp, err := m.client.ListFolder(ctx, &storagepb.ListFolderRequest{
+		UserId:   userID,
+		FolderId: folderID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := &response.ListFolderResponse{
+		Folders: make([]response.FolderResponse, 0, len(resp.GetFolders())),
+		Files:   make([]response.FileInfoResponse, 0, len(resp.GetFiles())),
+	}
+	for _, f := range resp.GetFolders() {
+		out.Folders = append(out.Folders, toFolderResponse(f))
+	}
+	for _, f := range resp.GetFiles() {
+		out.Files = append(out.Files, toFileInfoResponse(f))
+	}
+	return out, nil
+}
+
+func toFolderResponse(f *storagepb.Folder) response.FolderResponse {
+	return response.FolderResponse{
+		FolderID: f.GetFolderId(),
+		Name:     f.GetName(),
+		ParentID: f.GetParentId(),
+	}
+}
+
+func toFileInfoResponse(f *storagepb.FileInfo) response.FileInfoResponse {
+	var deletedAt *time.Time
+	if f.GetDeletedAtUnix() != 0 {
+		t := time.Unix(f.GetDeletedAtUnix(), 0).UTC()
+		deletedAt = &t
+	}
+	return response.FileInfoResponse{
+		FileID:   f.GetFileId(),
+		FileName: f.GetFileName(),
+		FileSize: f.GetFileSize(),
+		FolderID: f.GetFolderId(),
+
+		ContentType: f.GetContentType(),
+		Tags:        f.GetTags(),
+		Metadata:    f.GetMetadata(),
+		CreatedAt:   time.Unix(f.GetCreatedAtUnix(), 0).UTC(),
+		UpdatedAt:   time.Unix(f.GetUpdatedAtUnix(), 0).UTC(),
+		DeletedAt:   deletedAt,
+	}
+}
@@ -0,0 +1,171 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/a1y/doc-formatter/internal/gateway/clients/storage"
+	"github.com/a1y/doc-formatter/internal/gateway/domain/response"
+	"github.com/stretchr/testify/require"
+)
+
+type stubFolderClient struct {
+	storage.StorageClient
+
+	folder *storagepb.Folder
+	list   *storagepb.ListFolderResponse
+	err    error
+
+	lastReq any
+}
+
+func (s *stubFolderClient) MoveFile(_ context.Context, req *storagepb.MoveFileRequest) (*storagepb.MoveFileResponse, error) {
+	s.lastReq = req
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &storagepb.MoveFileResponse{File: &storagepb.FileInfo{
+		FileId:   req.GetFileId(),
+		FileName: "file.txt",
+		FileSize: 7,
+		FolderId: req.GetFolderId(),
+
+		CreatedAtUnix: 1767225600,
+		UpdatedAtUnix: 1767225600,
+	}}, nil
+}
+
+func (s *stubFolderClient) CreateFolder(_ context.Context, req *storagepb.CreateFolderRequest) (*storagepb.CreateFolderResponse, error) {
+	s.lastReq = req
+	return &storagepb.CreateFolderResponse{Folder: s.folder}, s.err
+}
+
+func (s *stubFolderClient) RenameFolder(_ context.Context, req *storagepb.RenameFolderRequest) (*storagepb.RenameFolderResponse, error) {
+	s.lastReq = req
+	return &storagepb.RenameFolderResponse{Folder: s.folder}, s.err
+}
+
+func (s *stubFolderClient) MoveFolder(_ context.Context, req *storagepb.MoveFolderRequest) (*storagepb.MoveFolderResponse, error) {
+	s.lastReq = req
+	return &storagepb.MoveFolderResponse{Folder: s.folder}, s.err
+}
+
+func (s *stubFolderClient) DeleteFolder(_ context.Context, req *storagepb.DeleteFolderRequest) (*storagepb.DeleteFolderResponse, error) {
+	s.lastReq = req
+	return &storagepb.DeleteFolderResponse{DeletedFolders: 2, DeletedFiles: 3}, s.err
+}
+
+func (s *stubFolderClient) ListFolder(_ context.Context, req *storagepb.ListFolderRequest) (*storagepb.ListFolderResponse, error) {
+	s.lastReq = req
+	return s.list, s.err
+}
+
+func TestStorageManager_FolderCalls(t *testing.T) {
+	t.Parallel()
+
+	folder := &storagepb.Folder{FolderId: "folder-id", Name: "reports", ParentId: "parent-id"}
+	want := &response.FolderResponse{FolderID: "folder-id", Name: "reports", ParentID: "parent-id"}
+	client := &stubFolderClient{folder: folder}
+	mgr := NewStorageManager(client, nil)
+	ctx := context.Background()
+
+	created, err := mgr.CreateFolder(ctx, "user-id", "reports", "parent-id")
+	require.NoError(t, err)
+	require.Equal(t, want, created)
+	require.Equal(t, &storagepb.CreateFolderRequest{UserId: "user-id", Name: "reports", ParentId: "parent-id"}, client.lastReq)
+
+	renamed, err := mgr.RenameFolder(ctx, "user-id", "folder-id", "reports")
+	require.NoError(t, err)
+	require.Equal(t, want, renamed)
+	require.Equal(t, &storagepb.RenameFolderRequest{UserId: "user-id", FolderId: "folder-id", Name: "reports"}, client.lastReq)
+
+	moved, err := mgr.MoveFolder(ctx, "user-id", "folder-id", "parent-id")
+	require.NoError(t, err)
+	require.Equal(t, want, moved)
+	require.Equal(t, &storagepb.MoveFolderRequest{UserId: "user-id", FolderId: "folder-id", ParentId: "parent-id"}, client.lastReq)
+
+	deleted, err := mgr.DeleteFolder(ctx, "user-id", "folder-id", true)
+	require.NoError(t, err)
+	require.Equal(t, &response.DeleteFolderResponse{DeletedFolders: 2, DeletedFiles: 3}, deleted)
+	require.Equal(t, &storagepb.DeleteFolderRequest{UserId: "user-id", FolderId: "folder-id", Recursive: true}, client.lastReq)
+
+	file, err := mgr.MoveFile(ctx, "user-id", "file-id", "folder-id")
+	require.NoError(t, err)
+	require.Equal(t, &response.FileInfoResponse{
+		FileID:    "file-id",
+		FileName:  "file.txt",
+		FileSize:  7,
+		FolderID:  "folder-id",
+		CreatedAt: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		UpdatedAt: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+	}, file)
+}
+
+func TestStorageManager_ListFolder(t *testing.T) {
+	t.Parallel()
+
+	client := &stubFolderClient{list: &storagepb.ListFolderResponse{
+		Folders: []*storagepb.Folder{{FolderId: "sub-id", Name: "drafts", ParentId: "folder-id"}},
+		Files: []*storagepb.FileInfo{{
+			FileId:        "file-id",
+			FileName:      "a.txt",
+			FileSize:      1,
+			FolderId:      "folder-id",
+			ContentType:   "text/plain",
+			Tags:          []string{"status:draft"},
+			Metadata:      map[string]string{"owner": "legal"},
+			CreatedAtUnix: 1767225600,
+			UpdatedAtUnix: 1767225600,
+		}},
+	}}
+	mgr := NewStorageManager(client, nil)
+
+	resp, err := mgr.ListFolder(context.Background(), "user-id", "folder-id")
+	require.NoError(t, err)
+	require.Equal(t, &response.ListFolderResponse{
+		Folders: []response.FolderResponse{{FolderID: "sub-id", Name: "drafts", ParentID: "folder-id"}},
+		Files: []response.FileInfoResponse{{
+			FileID:      "file-id",
+			FileName:    "a.txt",
+			FileSize:    1,
+			FolderID:    "folder-id",
+			ContentType: "text/plain",
+			Tags:        []string{"status:draft"},
+			Metadata:    map[string]string{"owner": "legal"},
+			CreatedAt:   time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			UpdatedAt:   time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		}},
+	}, resp)
+	require.Equal(t, &storagepb.ListFolderRequest{UserId: "user-id", FolderId: "folder-id"}, client.lastReq)
+
+	// An empty folder lists as empty arrays rather than null.
+	client.list = &storagepb.ListFolderResponse{}
+	resp, err = mgr.ListFolder(context.Background(), "user-id", "")
+	require.NoError(t, err)
+	require.NotNil(t, resp.Folders)
+	require.NotNil(t, resp.Files)
+}
+
+func TestStorageManager_FolderCalls_PropagateErrors(t *testing.T) {
+	t.Parallel()
+
+	expectedErr := errors.New("storage unavailable")
+	mgr := NewStorageManager(&stubFolderClient{err: expectedErr}, nil)
+	ctx := context.Background()
+
+	_, err := mgr.CreateFolder(ctx, "user-id", "reports", "")
+	require.Equal(t, expectedErr, err)
+	_, err = mgr.RenameFolder(ctx, "user-id", "folder-id", "reports")
+	require.Equal(t, expectedErr, err)
+	_, err = mgr.MoveFolder(ctx, "user-id", "folder-id", "")
+	require.Equal(t, expectedErr, err)
+	_, err = mgr.DeleteFolder(ctx, "user-id", "folder-id", false)
+	require.Equal(t, expectedErr, err)
+	_, err = mgr.ListFolder(ctx, "user-id", "")
+	require.Equal(t, expectedErr, err)
+	_, err = mgr.MoveFile(ctx, "user-id", "file-id", "")
+	require.Equal(t, expectedErr, err)
+}
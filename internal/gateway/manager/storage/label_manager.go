@@ -0,0 +1,100 @@
+package storage
+
+import (
+	"context"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/a1y/doc-formatter/internal/gateway/domain/request"
+	"github.com/a1y/doc-formatter/internal/gateway/domain/response"
+)
+
+func (m *StorageManager) AddFileTags(ctx context.Context, userID string, fileID string, tags []string) (*response.FileInfoResponse, error) {
+	resp, err := m.client.AddFileTags(ctx, &storagepb.AddFileTagsRequest{
+		UserId: userID,
+		FileId: fileID,
+		Tags:   tags,
+	})
+	if err != nil {
+		return nil, err
+	}
+	file := toFileInfoResponse(resp.GetFile())
+	return &file, nil
+}
+
+func (m *StorageManager) RemoveFileTags(ctx context.Context, userID string, fileID string, tags []string) (*response.FileInfoResponse, error) {
+	resp, err := m.client.RemoveFileTags(ctx, &storagepb.RemoveFileTagsRequest{
+		UserId: userID,
+		FileId: fileID,
+		Tags:   tags,
+	})
+	if err != nil {
+		return nil, err
+	}
+	file := toFileInfoResponse(resp.GetFile())
+	return &file, nil
+}
+
+// SetFileMetadata merges metadata into the metadata of a file.
+func (m *StorageManager) SetFileMetadata(ctx context.Context, userID string, fileID string, metadata map[string]string) (*response.FileInfoResponse, error) {
+	resp, err := m.client.SetFileMetadata(ctx, &storagepb.SetFileMetadataRequest{
+		UserId:   userID,
+		FileId:   fileID,
+		Metadata: metadata,
+	})
+	if err != nil {
+		return nil, err
+	}
+	file := toFileInfoResponse(resp.GetFile())
+	return &file, nil
+}
+
+func (m *StorageManager) RemoveFileMetadata(ctx context.Context, userID string, fileID string, keys []string) (*response.FileInfoResponse, error) {
+	resp, err := m.client.RemoveFileMetadata(ctx, &storagepb.RemoveFileMetadataRequest{
+		UserId: userID,
+		FileId: fileID,
+		Keys:   keys,
+	})
+	if err != nil {
+		return nil, err
+	}
+	file := toFileInfoResponse(resp.GetFile())
+	return &file, nil
+}
+
+// ListFiles lists the files of a user matching the filters of req.
+func (m *StorageManager) ListFiles(ctx context.Context, userID string, req *request.ListFilesRequest) (*response.ListFilesResponse, error) {
+	listReq := &storagepb.ListFilesRequest{
+		UserId:      userID,
+		FolderId:    req.FolderID,
+		Tags:        req.Tags,
+		Metadata:    req.Metadata,
+		ContentType: req.ContentType,
+		MinSize:     req.MinSize,
+		MaxSize:     req.MaxSize,
+		PageSize:    req.PageSize,
+		PageToken:   req.PageToken,
+		Sort:        req.Sort,
+		Descending:  req.Order == "desc",
+	}
+	if req.CreatedAfter != nil {
+		after := req.CreatedAfter.Unix()
+		listReq.CreatedAfterUnix = &after
+	}
+	if req.CreatedBefore != nil {
+		before := req.CreatedBefore.Unix()
+		listReq.CreatedBeforeUnix = &before
+	}
+
+	resp, err := m.client.ListFiles(ctx, listReq)
+	if err != nil {
+		return nil, err
+	}
+	out := &response.ListFilesResponse{
+		Files:         make([]response.FileInfoResponse, 0, len(resp.GetFiles())),
+		NextPageToken: resp.GetNextPageToken(),
+	}
+	for _, f := range resp.GetFiles() {
+		out.Files = append(out.Files, toFileInfoResponse(f))
+	}
+	return out, nil
+}
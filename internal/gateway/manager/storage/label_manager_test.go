@@ -0,0 +1,169 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/a1y/doc-formatter/internal/gateway/clients/storage"
+	"github.com/a1y/doc-formatter/internal/gateway/domain/request"
+	"github.com/a1y/doc-formatter/internal/gateway/domain/response"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+type stubLabelClient struct {
+	storage.StorageClient
+
+	file  *storagepb.FileInfo
+	files []*storagepb.FileInfo
+	next  string
+	err   error
+
+	lastReq any
+}
+
+func (s *stubLabelClient) AddFileTags(_ context.Context, req *storagepb.AddFileTagsRequest) (*storagepb.AddFileTagsResponse, error) {
+	s.lastReq = req
+	return &storagepb.AddFileTagsResponse{File: s.file}, s.err
+}
+
+func (s *stubLabelClient) RemoveFileTags(_ context.Context, req *storagepb.RemoveFileTagsRequest) (*storagepb.RemoveFileTagsResponse, error) {
+	s.lastReq = req
+	return &storagepb.RemoveFileTagsResponse{File: s.file}, s.err
+}
+
+func (s *stubLabelClient) SetFileMetadata(_ context.Context, req *storagepb.SetFileMetadataRequest) (*storagepb.SetFileMetadataResponse, error) {
+	s.lastReq = req
+	return &storagepb.SetFileMetadataResponse{File: s.file}, s.err
+}
+
+func (s *stubLabelClient) RemoveFileMetadata(_ context.Context, req *storagepb.RemoveFileMetadataRequest) (*storagepb.RemoveFileMetadataResponse, error) {
+	s.lastReq = req
+	return &storagepb.RemoveFileMetadataResponse{File: s.file}, s.err
+}
+
+func (s *stubLabelClient) ListFiles(_ context.Context, req *storagepb.ListFilesRequest) (*storagepb.ListFilesResponse, error) {
+	s.lastReq = req
+	return &storagepb.ListFilesResponse{Files: s.files, NextPageToken: s.next}, s.err
+}
+
+func TestStorageManager_LabelCalls(t *testing.T) {
+	t.Parallel()
+
+	file := &storagepb.FileInfo{
+		FileId:        "file-id",
+		FileName:      "report.pdf",
+		FileSize:      42,
+		ContentType:   "application/pdf",
+		Tags:          []string{"finance"},
+		Metadata:      map[string]string{"owner": "legal"},
+		CreatedAtUnix: 1767225600,
+		UpdatedAtUnix: 1767225600,
+	}
+	want := &response.FileInfoResponse{
+		FileID:      "file-id",
+		FileName:    "report.pdf",
+		FileSize:    42,
+		ContentType: "application/pdf",
+		Tags:        []string{"finance"},
+		Metadata:    map[string]string{"owner": "legal"},
+		CreatedAt:   time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		UpdatedAt:   time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	client := &stubLabelClient{file: file}
+	mgr := NewStorageManager(client, nil)
+	ctx := context.Background()
+
+	got, err := mgr.AddFileTags(ctx, "user-id", "file-id", []string{"finance"})
+	require.NoError(t, err)
+	require.Equal(t, want, got)
+	require.Equal(t, &storagepb.AddFileTagsRequest{UserId: "user-id", FileId: "file-id", Tags: []string{"finance"}}, client.lastReq)
+
+	got, err = mgr.RemoveFileTags(ctx, "user-id", "file-id", []string{"draft"})
+	require.NoError(t, err)
+	require.Equal(t, want, got)
+	require.Equal(t, &storagepb.RemoveFileTagsRequest{UserId: "user-id", FileId: "file-id", Tags: []string{"draft"}}, client.lastReq)
+
+	got, err = mgr.SetFileMetadata(ctx, "user-id", "file-id", map[string]string{"owner": "legal"})
+	require.NoError(t, err)
+	require.Equal(t, want, got)
+	require.Equal(t, &storagepb.SetFileMetadataRequest{UserId: "user-id", FileId: "file-id", Metadata: map[string]string{"owner": "legal"}}, client.lastReq)
+
+	got, err = mgr.RemoveFileMetadata(ctx, "user-id", "file-id", []string{"status"})
+	require.NoError(t, err)
+	require.Equal(t, want, got)
+	require.Equal(t, &storagepb.RemoveFileMetadataRequest{UserId: "user-id", FileId: "file-id", Keys: []string{"status"}}, client.lastReq)
+}
+
+func TestStorageManager_LabelCalls_Error(t *testing.T) {
+	t.Parallel()
+
+	client := &stubLabelClient{err: status.Error(codes.InvalidArgument, "invalid tags")}
+	mgr := NewStorageManager(client, nil)
+
+	got, err := mgr.AddFileTags(context.Background(), "user-id", "file-id", []string{""})
+	require.Error(t, err)
+	require.Nil(t, got)
+}
+
+func TestStorageManager_ListFiles(t *testing.T) {
+	t.Parallel()
+
+	after := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	before := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	client := &stubLabelClient{
+		files: []*storagepb.FileInfo{{FileId: "file-id", FileName: "a.txt", CreatedAtUnix: 1767225600, UpdatedAtUnix: 1767225600}},
+		next:  "next-token",
+	}
+	mgr := NewStorageManager(client, nil)
+
+	got, err := mgr.ListFiles(context.Background(), "user-id", &request.ListFilesRequest{
+		FolderID:      "folder-id",
+		Tags:          []string{"finance"},
+		Metadata:      map[string]string{"owner": "legal"},
+		ContentType:   "image/*",
+		MinSize:       proto.Int64(1),
+		MaxSize:       proto.Int64(1024),
+		CreatedAfter:  &after,
+		CreatedBefore: &before,
+		PageSize:      20,
+		PageToken:     "page-token",
+		Sort:          "size",
+		Order:         "desc",
+	})
+	require.NoError(t, err)
+	require.Equal(t, &response.ListFilesResponse{
+		Files:         []response.FileInfoResponse{{FileID: "file-id", FileName: "a.txt", CreatedAt: after, UpdatedAt: after}},
+		NextPageToken: "next-token",
+	}, got)
+	require.Equal(t, &storagepb.ListFilesRequest{
+		UserId:            "user-id",
+		FolderId:          "folder-id",
+		Tags:              []string{"finance"},
+		Metadata:          map[string]string{"owner": "legal"},
+		ContentType:       "image/*",
+		MinSize:           proto.Int64(1),
+		MaxSize:           proto.Int64(1024),
+		CreatedAfterUnix:  proto.Int64(after.Unix()),
+		CreatedBeforeUnix: proto.Int64(before.Unix()),
+		PageSize:          20,
+		PageToken:         "page-token",
+		Sort:              "size",
+		Descending:        true,
+	}, client.lastReq)
+}
+
+func TestStorageManager_ListFiles_Empty(t *testing.T) {
+	t.Parallel()
+
+	mgr := NewStorageManager(&stubLabelClient{}, nil)
+
+	got, err := mgr.ListFiles(context.Background(), "user-id", &request.ListFilesRequest{})
+	require.NoError(t, err)
+	require.NotNil(t, got.Files)
+	require.Empty(t, got.Files)
+}
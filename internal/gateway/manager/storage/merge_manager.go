@@ -0,0 +1,71 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/a1y/doc-formatter/internal/gateway/domain/request"
+	"github.com/a1y/doc-formatter/internal/gateway/domain/response"
+)
+
+// MergeDocuments makes a new file of the content of several files, in order.
+func (m *StorageManager) MergeDocuments(ctx context.Context, userID string, req *request.MergeDocumentsRequest) (*response.FileInfoResponse, error) {
+	resp, err := m.client.MergeDocuments(ctx, &storagepb.MergeDocumentsRequest{
+		UserId:        userID,
+		SourceFileIds: req.SourceFileIDs,
+		FileName:      req.FileName,
+		FolderId:      req.FolderID,
+		PageBreaks:    req.PageBreaks,
+	})
+	if err != nil {
+		return nil, err
+	}
+	file := toFileInfoResponse(resp.GetFile())
+	return &file, nil
+}
+
+// SplitDocument cuts a file at its headings into new files.
+func (m *StorageManager) SplitDocument(ctx context.Context, userID string, fileID string, req *request.SplitDocumentRequest) (*response.SplitDocumentResponse, error) {
+	resp, err := m.client.SplitDocument(ctx, &storagepb.SplitDocumentRequest{
+		UserId:       userID,
+		FileId:       fileID,
+		HeadingLevel: req.HeadingLevel,
+		FolderId:     req.FolderID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := &response.SplitDocumentResponse{Files: make([]response.FileInfoResponse, len(resp.GetFiles()))}
+	for i, file := range resp.GetFiles() {
+		out.Files[i] = toFileInfoResponse(file)
+	}
+	return out, nil
+}
+
+func (m *StorageManager) GetProvenance(ctx context.Context, userID string, fileID string) (*response.ProvenanceResponse, error) {
+	resp, err := m.client.GetProvenance(ctx, &storagepb.GetProvenanceRequest{
+		UserId: userID,
+		FileId: fileID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &response.ProvenanceResponse{
+		Sources: toProvenanceLinkResponses(resp.GetSources()),
+		Derived: toProvenanceLinkResponses(resp.GetDerived()),
+	}, nil
+}
+
+func toProvenanceLinkResponses(links []*storagepb.ProvenanceLink) []response.ProvenanceLinkResponse {
+	out := make([]response.ProvenanceLinkResponse, len(links))
+	for i, link := range links {
+		out[i] = response.ProvenanceLinkResponse{
+			File:      toFileInfoResponse(link.GetFile()),
+			Operation: link.GetOperation(),
+			Position:  link.GetPosition(),
+			CreatedAt: time.Unix(link.GetCreatedAtUnix(), 0).UTC(),
+		}
+	}
+	return out
+}
@@ -0,0 +1,100 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/a1y/doc-formatter/internal/gateway/clients/storage"
+	"github.com/a1y/doc-formatter/internal/gateway/domain/request"
+	"github.com/a1y/doc-formatter/internal/gateway/domain/response"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type stubMergeClient struct {
+	storage.StorageClient
+
+	file       *storagepb.FileInfo
+	provenance *storagepb.GetProvenanceResponse
+	err        error
+
+	lastReq any
+}
+
+func (s *stubMergeClient) MergeDocuments(_ context.Context, req *storagepb.MergeDocumentsRequest) (*storagepb.MergeDocumentsResponse, error) {
+	s.lastReq = req
+	return &storagepb.MergeDocumentsResponse{File: s.file}, s.err
+}
+
+func (s *stubMergeClient) SplitDocument(_ context.Context, req *storagepb.SplitDocumentRequest) (*storagepb.SplitDocumentResponse, error) {
+	s.lastReq = req
+	return &storagepb.SplitDocumentResponse{Files: []*storagepb.FileInfo{s.file}}, s.err
+}
+
+func (s *stubMergeClient) GetProvenance(_ context.Context, req *storagepb.GetProvenanceRequest) (*storagepb.GetProvenanceResponse, error) {
+	s.lastReq = req
+	return s.provenance, s.err
+}
+
+func TestStorageManager_MergeCalls(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	file := &storagepb.FileInfo{FileId: "file-id", FileName: "report.md", CreatedAtUnix: at.Unix(), UpdatedAtUnix: at.Unix()}
+	want := response.FileInfoResponse{FileID: "file-id", FileName: "report.md", CreatedAt: at, UpdatedAt: at}
+	client := &stubMergeClient{
+		file: file,
+		provenance: &storagepb.GetProvenanceResponse{
+			Sources: []*storagepb.ProvenanceLink{{File: file, Operation: "merge", Position: 1, CreatedAtUnix: at.Unix()}},
+		},
+	}
+	mgr := NewStorageManager(client, nil)
+	ctx := context.Background()
+
+	merged, err := mgr.MergeDocuments(ctx, "user-id", &request.MergeDocumentsRequest{
+		SourceFileIDs: []string{"a", "b"},
+		FileName:      "report.md",
+		FolderID:      "folder-id",
+		PageBreaks:    true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, &want, merged)
+	require.Equal(t, &storagepb.MergeDocumentsRequest{
+		UserId:        "user-id",
+		SourceFileIds: []string{"a", "b"},
+		FileName:      "report.md",
+		FolderId:      "folder-id",
+		PageBreaks:    true,
+	}, client.lastReq)
+
+	split, err := mgr.SplitDocument(ctx, "user-id", "file-id", &request.SplitDocumentRequest{HeadingLevel: 2})
+	require.NoError(t, err)
+	require.Equal(t, &response.SplitDocumentResponse{Files: []response.FileInfoResponse{want}}, split)
+	require.Equal(t, &storagepb.SplitDocumentRequest{UserId: "user-id", FileId: "file-id", HeadingLevel: 2}, client.lastReq)
+
+	provenance, err := mgr.GetProvenance(ctx, "user-id", "file-id")
+	require.NoError(t, err)
+	require.Equal(t, &response.ProvenanceResponse{
+		Sources: []response.ProvenanceLinkResponse{{File: want, Operation: "merge", Position: 1, CreatedAt: at}},
+		Derived: []response.ProvenanceLinkResponse{},
+	}, provenance)
+	require.Equal(t, &storagepb.GetProvenanceRequest{UserId: "user-id", FileId: "file-id"}, client.lastReq)
+}
+
+func TestStorageManager_MergeCalls_Error(t *testing.T) {
+	t.Parallel()
+
+	client := &stubMergeClient{err: status.Error(codes.InvalidArgument, "invalid merge request")}
+	mgr := NewStorageManager(client, nil)
+
+	merged, err := mgr.MergeDocuments(context.Background(), "user-id", &request.MergeDocumentsRequest{})
+	require.Error(t, err)
+	require.Nil(t, merged)
+
+	split, err := mgr.SplitDocument(context.Background(), "user-id", "file-id", &request.SplitDocumentRequest{})
+	require.Error(t, err)
+	require.Nil(t, split)
+}
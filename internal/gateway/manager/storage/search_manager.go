@@ -0,0 +1,30 @@
+package storage
+
+import (
+	"context"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/a1y/doc-formatter/internal/gateway/domain/request"
+	"github.com/a1y/doc-formatter/internal/gateway/domain/response"
+)
+
+// SearchDocuments searches the text of the documents the user can read.
+func (m *StorageManager) SearchDocuments(ctx context.Context, userID string, req *request.SearchRequest) (*response.SearchResponse, error) {
+	resp, err := m.client.SearchDocuments(ctx, &storagepb.SearchDocumentsRequest{
+		UserId: userID,
+		Query:  req.Query,
+		Limit:  req.Limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := &response.SearchResponse{Results: make([]response.SearchResultResponse, 0, len(resp.GetHits()))}
+	for _, hit := range resp.GetHits() {
+		out.Results = append(out.Results, response.SearchResultResponse{
+			File:    toFileInfoResponse(hit.GetFile()),
+			Rank:    hit.GetRank(),
+			Snippet: hit.GetSnippet(),
+		})
+	}
+	return out, nil
+}
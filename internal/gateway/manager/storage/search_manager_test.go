@@ -0,0 +1,67 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/a1y/doc-formatter/internal/gateway/clients/storage"
+	"github.com/a1y/doc-formatter/internal/gateway/domain/request"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type stubSearchClient struct {
+	storage.StorageClient
+
+	hits []*storagepb.SearchHit
+	err  error
+
+	lastReq *storagepb.SearchDocumentsRequest
+}
+
+func (s *stubSearchClient) SearchDocuments(_ context.Context, req *storagepb.SearchDocumentsRequest) (*storagepb.SearchDocumentsResponse, error) {
+	s.lastReq = req
+	return &storagepb.SearchDocumentsResponse{Hits: s.hits}, s.err
+}
+
+func TestStorageManager_SearchDocuments(t *testing.T) {
+	t.Parallel()
+
+	client := &stubSearchClient{hits: []*storagepb.SearchHit{
+		{
+			File:    &storagepb.FileInfo{FileId: "file-1", FileName: "report.docx", FileSize: 42},
+			Rank:    0.75,
+			Snippet: "the <mark>quarterly</mark> report",
+		},
+		{
+			File: &storagepb.FileInfo{FileId: "file-2", FileName: "notes.md"},
+			Rank: 0.25,
+		},
+	}}
+	mgr := NewStorageManager(client, nil)
+
+	got, err := mgr.SearchDocuments(context.Background(), "user-id", &request.SearchRequest{Query: "quarterly", Limit: 10})
+	require.NoError(t, err)
+	require.Equal(t, &storagepb.SearchDocumentsRequest{UserId: "user-id", Query: "quarterly", Limit: 10}, client.lastReq)
+	require.Len(t, got.Results, 2)
+	require.Equal(t, "file-1", got.Results[0].File.FileID)
+	require.Equal(t, "report.docx", got.Results[0].File.FileName)
+	require.EqualValues(t, 42, got.Results[0].File.FileSize)
+	require.Equal(t, 0.75, got.Results[0].Rank)
+	require.Equal(t, "the <mark>quarterly</mark> report", got.Results[0].Snippet)
+	require.Equal(t, "file-2", got.Results[1].File.FileID)
+	require.Empty(t, got.Results[1].Snippet)
+}
+
+func TestStorageManager_SearchDocuments_Error(t *testing.T) {
+	t.Parallel()
+
+	client := &stubSearchClient{err: status.Error(codes.InvalidArgument, "invalid search: query is empty")}
+	mgr := NewStorageManager(client, nil)
+
+	got, err := mgr.SearchDocuments(context.Background(), "user-id", &request.SearchRequest{Query: " "})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	require.Nil(t, got)
+}
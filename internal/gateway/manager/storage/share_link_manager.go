@@ -0,0 +1,102 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/a1y/doc-formatter/internal/gateway/domain/request"
+	"github.com/a1y/doc-formatter/internal/gateway/domain/response"
+)
+
+// SharedFilePath is the public path under which share link tokens are served.
+const SharedFilePath = "/s/"
+
+// CreateShareLink creates a public link to a file. The token is only returned
+// here; it cannot be recovered later.
+func (m *StorageManager) CreateShareLink(ctx context.Context, userID string, fileID string, req *request.CreateShareLinkRequest) (*response.ShareLinkResponse, error) {
+	var expiresAt int64
+	if req.ExpiresAt != nil {
+		expiresAt = req.ExpiresAt.Unix()
+	}
+	resp, err := m.client.CreateShareLink(ctx, &storagepb.CreateShareLinkRequest{
+		UserId:        userID,
+		FileId:        fileID,
+		Password:      req.Password,
+		ExpiresAtUnix: expiresAt,
+		MaxDownloads:  req.MaxDownloads,
+	})
+	if err != nil {
+		return nil, err
+	}
+	link := toShareLinkResponse(resp.GetLink())
+	link.Token = resp.GetToken()
+	link.URL = SharedFilePath + resp.GetToken()
+	return &link, nil
+}
+
+func (m *StorageManager) ListShareLinks(ctx context.Context, userID string, fileID string) (*response.ListShareLinksResponse, error) {
+	resp, err := m.client.ListShareLinks(ctx, &storagepb.ListShareLinksRequest{
+		UserId: userID,
+		FileId: fileID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := &response.ListShareLinksResponse{Links: make([]response.ShareLinkResponse, 0, len(resp.GetLinks()))}
+	for _, l := range resp.GetLinks() {
+		out.Links = append(out.Links, toShareLinkResponse(l))
+	}
+	return out, nil
+}
+
+func (m *StorageManager) RevokeShareLink(ctx context.Context, userID string, linkID string) (*response.ShareLinkResponse, error) {
+	resp, err := m.client.RevokeShareLink(ctx, &storagepb.RevokeShareLinkRequest{
+		UserId: userID,
+		LinkId: linkID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	link := toShareLinkResponse(resp.GetLink())
+	return &link, nil
+}
+
+// DownloadSharedFile opens the file behind a share link on behalf of an
+// anonymous client. Like DownloadFile, the content reads from the stream
+// until ctx is done.
+func (m *StorageManager) DownloadSharedFile(ctx context.Context, token string, password string, remoteAddr string, userAgent string) (*response.DownloadFileResponse, error) {
+	stream, err := m.client.DownloadSharedFile(ctx, &storagepb.DownloadSharedFileRequest{
+		Token:      token,
+		Password:   password,
+		RemoteAddr: remoteAddr,
+		UserAgent:  userAgent,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return openDownload(stream)
+}
+
+func toShareLinkResponse(l *storagepb.ShareLinkInfo) response.ShareLinkResponse {
+	return response.ShareLinkResponse{
+		LinkID:        l.GetLinkId(),
+		FileID:        l.GetFileId(),
+		OwnerID:       l.GetOwnerId(),
+		HasPassword:   l.GetHasPassword(),
+		ExpiresAt:     unixOrNil(l.GetExpiresAtUnix()),
+		MaxDownloads:  l.GetMaxDownloads(),
+		DownloadCount: l.GetDownloadCount(),
+		RevokedAt:     unixOrNil(l.GetRevokedAtUnix()),
+		CreatedAt:     time.Unix(l.GetCreatedAtUnix(), 0).UTC(),
+	}
+}
+
+// unixOrNil converts an optional unix timestamp, where zero means unset.
+func unixOrNil(sec int64) *time.Time {
+	if sec == 0 {
+		return nil
+	}
+	t := time.Unix(sec, 0).UTC()
+	return &t
+}
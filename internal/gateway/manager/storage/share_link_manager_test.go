@@ -0,0 +1,163 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/a1y/doc-formatter/internal/gateway/clients/storage"
+	"github.com/a1y/doc-formatter/internal/gateway/domain/request"
+	"github.com/a1y/doc-formatter/internal/gateway/domain/response"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type stubShareLinkClient struct {
+	storage.StorageClient
+
+	link   *storagepb.ShareLinkInfo
+	token  string
+	stream *stubDownloadStream
+	err    error
+
+	lastReq any
+}
+
+func (s *stubShareLinkClient) CreateShareLink(_ context.Context, req *storagepb.CreateShareLinkRequest) (*storagepb.CreateShareLinkResponse, error) {
+	s.lastReq = req
+	return &storagepb.CreateShareLinkResponse{Link: s.link, Token: s.token}, s.err
+}
+
+func (s *stubShareLinkClient) ListShareLinks(_ context.Context, req *storagepb.ListShareLinksRequest) (*storagepb.ListShareLinksResponse, error) {
+	s.lastReq = req
+	return &storagepb.ListShareLinksResponse{Links: []*storagepb.ShareLinkInfo{s.link}}, s.err
+}
+
+func (s *stubShareLinkClient) RevokeShareLink(_ context.Context, req *storagepb.RevokeShareLinkRequest) (*storagepb.RevokeShareLinkResponse, error) {
+	s.lastReq = req
+	return &storagepb.RevokeShareLinkResponse{Link: s.link}, s.err
+}
+
+func (s *stubShareLinkClient) DownloadSharedFile(_ context.Context, req *storagepb.DownloadSharedFileRequest) (grpc.ServerStreamingClient[storagepb.DownloadFileResponse], error) {
+	s.lastReq = req
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.stream, nil
+}
+
+func TestStorageManager_ShareLinkCalls(t *testing.T) {
+	t.Parallel()
+
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	expires := created.Add(24 * time.Hour)
+	client := &stubShareLinkClient{
+		link: &storagepb.ShareLinkInfo{
+			LinkId:        "link-id",
+			FileId:        "file-id",
+			OwnerId:       "owner-id",
+			HasPassword:   true,
+			ExpiresAtUnix: expires.Unix(),
+			MaxDownloads:  5,
+			DownloadCount: 2,
+			CreatedAtUnix: created.Unix(),
+		},
+		token: "secret-token",
+	}
+	want := response.ShareLinkResponse{
+		LinkID:        "link-id",
+		FileID:        "file-id",
+		OwnerID:       "owner-id",
+		HasPassword:   true,
+		ExpiresAt:     &expires,
+		MaxDownloads:  5,
+		DownloadCount: 2,
+		CreatedAt:     created,
+	}
+	mgr := NewStorageManager(client, nil)
+	ctx := context.Background()
+
+	got, err := mgr.CreateShareLink(ctx, "owner-id", "file-id", &request.CreateShareLinkRequest{
+		Password:     "hunter2",
+		ExpiresAt:    &expires,
+		MaxDownloads: 5,
+	})
+	require.NoError(t, err)
+	withToken := want
+	withToken.Token = "secret-token"
+	withToken.URL = "/s/secret-token"
+	require.Equal(t, &withToken, got)
+	require.Equal(t, &storagepb.CreateShareLinkRequest{
+		UserId:        "owner-id",
+		FileId:        "file-id",
+		Password:      "hunter2",
+		ExpiresAtUnix: expires.Unix(),
+		MaxDownloads:  5,
+	}, client.lastReq)
+
+	list, err := mgr.ListShareLinks(ctx, "owner-id", "file-id")
+	require.NoError(t, err)
+	require.Equal(t, &response.ListShareLinksResponse{Links: []response.ShareLinkResponse{want}}, list)
+	require.Equal(t, &storagepb.ListShareLinksRequest{UserId: "owner-id", FileId: "file-id"}, client.lastReq)
+
+	client.link.RevokedAtUnix = created.Add(time.Hour).Unix()
+	revoked, err := mgr.RevokeShareLink(ctx, "owner-id", "link-id")
+	require.NoError(t, err)
+	require.NotNil(t, revoked.RevokedAt)
+	require.Equal(t, created.Add(time.Hour), *revoked.RevokedAt)
+	require.Empty(t, revoked.Token)
+	require.Equal(t, &storagepb.RevokeShareLinkRequest{UserId: "owner-id", LinkId: "link-id"}, client.lastReq)
+}
+
+func TestStorageManager_DownloadSharedFile(t *testing.T) {
+	t.Parallel()
+
+	client := &stubShareLinkClient{
+		stream: &stubDownloadStream{msgs: []*storagepb.DownloadFileResponse{
+			{FileName: "report.pdf", FileSize: 5},
+			{Chunk: []byte("hello")},
+		}},
+	}
+	mgr := NewStorageManager(client, nil)
+
+	resp, err := mgr.DownloadSharedFile(context.Background(), "token", "pw", "203.0.113.7", "curl/8.0")
+	require.NoError(t, err)
+	require.Equal(t, "report.pdf", resp.FileName)
+	require.EqualValues(t, 5, resp.FileSize)
+	require.Equal(t, &storagepb.DownloadSharedFileRequest{
+		Token:      "token",
+		Password:   "pw",
+		RemoteAddr: "203.0.113.7",
+		UserAgent:  "curl/8.0",
+	}, client.lastReq)
+
+	content, err := io.ReadAll(resp.Content)
+	require.NoError(t, err)
+	require.Equal(t, "hello", string(content))
+}
+
+func TestStorageManager_ShareLinkCalls_Error(t *testing.T) {
+	t.Parallel()
+
+	client := &stubShareLinkClient{err: status.Error(codes.PermissionDenied, "permission denied")}
+	mgr := NewStorageManager(client, nil)
+	ctx := context.Background()
+
+	link, err := mgr.CreateShareLink(ctx, "user-id", "file-id", &request.CreateShareLinkRequest{})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	require.Nil(t, link)
+
+	list, err := mgr.ListShareLinks(ctx, "user-id", "file-id")
+	require.Error(t, err)
+	require.Nil(t, list)
+
+	expired := status.Error(codes.FailedPrecondition, "share link has expired")
+	mgr = NewStorageManager(&stubShareLinkClient{stream: &stubDownloadStream{err: expired}}, nil)
+	resp, err := mgr.DownloadSharedFile(ctx, "token", "", "", "")
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	require.Nil(t, resp)
+}
@@ -0,0 +1,101 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/a1y/doc-formatter/internal/gateway/domain/request"
+	"github.com/a1y/doc-formatter/internal/gateway/domain/response"
+)
+
+// Resource types understood by the sharing RPCs of the storage service.
+const (
+	ResourceFile   = "file"
+	ResourceFolder = "folder"
+)
+
+// Share grants the user registered under req.Email a role on a file or
+// folder of userID.
+func (m *StorageManager) Share(ctx context.Context, userID string, resourceType string, resourceID string, req *request.ShareRequest) (*response.ShareResponse, error) {
+	grantee, err := m.authClient.LookupUser(ctx, req.Email)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := m.client.Share(ctx, &storagepb.ShareRequest{
+		UserId:       userID,
+		ResourceType: resourceType,
+		ResourceId:   resourceID,
+		GranteeId:    grantee.UserID,
+		Role:         req.Role,
+	})
+	if err != nil {
+		return nil, err
+	}
+	share := toShareResponse(resp.GetShare())
+	return &share, nil
+}
+
+// Unshare revokes the role the user registered under req.Email holds on a
+// file or folder.
+func (m *StorageManager) Unshare(ctx context.Context, userID string, resourceType string, resourceID string, req *request.UnshareRequest) error {
+	grantee, err := m.authClient.LookupUser(ctx, req.Email)
+	if err != nil {
+		return err
+	}
+	_, err = m.client.Unshare(ctx, &storagepb.UnshareRequest{
+		UserId:       userID,
+		ResourceType: resourceType,
+		ResourceId:   resourceID,
+		GranteeId:    grantee.UserID,
+	})
+	return err
+}
+
+func (m *StorageManager) ListShares(ctx context.Context, userID string, resourceType string, resourceID string) (*response.ListSharesResponse, error) {
+	resp, err := m.client.ListShares(ctx, &storagepb.ListSharesRequest{
+		UserId:       userID,
+		ResourceType: resourceType,
+		ResourceId:   resourceID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := &response.ListSharesResponse{Shares: make([]response.ShareResponse, 0, len(resp.GetShares()))}
+	for _, s := range resp.GetShares() {
+		out.Shares = append(out.Shares, toShareResponse(s))
+	}
+	return out, nil
+}
+
+func (m *StorageManager) ListSharedWithMe(ctx context.Context, userID string) (*response.ListSharedWithMeResponse, error) {
+	resp, err := m.client.ListSharedWithMe(ctx, &storagepb.ListSharedWithMeRequest{UserId: userID})
+	if err != nil {
+		return nil, err
+	}
+	out := &response.ListSharedWithMeResponse{Items: make([]response.SharedItemResponse, 0, len(resp.GetItems()))}
+	for _, item := range resp.GetItems() {
+		shared := response.SharedItemResponse{Share: toShareResponse(item.GetShare())}
+		if item.GetFile() != nil {
+			file := toFileInfoResponse(item.GetFile())
+			shared.File = &file
+		}
+		if item.GetFolder() != nil {
+			folder := toFolderResponse(item.GetFolder())
+			shared.Folder = &folder
+		}
+		out.Items = append(out.Items, shared)
+	}
+	return out, nil
+}
+
+func toShareResponse(s *storagepb.ShareInfo) response.ShareResponse {
+	return response.ShareResponse{
+		ResourceType: s.GetResourceType(),
+		ResourceID:   s.GetResourceId(),
+		OwnerID:      s.GetOwnerId(),
+		UserID:       s.GetUserId(),
+		Role:         s.GetRole(),
+		CreatedAt:    time.Unix(s.GetCreatedAtUnix(), 0).UTC(),
+	}
+}
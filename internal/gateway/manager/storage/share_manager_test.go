@@ -0,0 +1,138 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/a1y/doc-formatter/internal/gateway/clients/auth"
+	"github.com/a1y/doc-formatter/internal/gateway/clients/storage"
+	"github.com/a1y/doc-formatter/internal/gateway/domain/request"
+	"github.com/a1y/doc-formatter/internal/gateway/domain/response"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type stubShareClient struct {
+	storage.StorageClient
+
+	share *storagepb.ShareInfo
+	items []*storagepb.SharedItem
+	err   error
+
+	lastReq any
+}
+
+func (s *stubShareClient) Share(_ context.Context, req *storagepb.ShareRequest) (*storagepb.ShareResponse, error) {
+	s.lastReq = req
+	return &storagepb.ShareResponse{Share: s.share}, s.err
+}
+
+func (s *stubShareClient) Unshare(_ context.Context, req *storagepb.UnshareRequest) (*storagepb.UnshareResponse, error) {
+	s.lastReq = req
+	return &storagepb.UnshareResponse{}, s.err
+}
+
+func (s *stubShareClient) ListShares(_ context.Context, req *storagepb.ListSharesRequest) (*storagepb.ListSharesResponse, error) {
+	s.lastReq = req
+	return &storagepb.ListSharesResponse{Shares: []*storagepb.ShareInfo{s.share}}, s.err
+}
+
+func (s *stubShareClient) ListSharedWithMe(_ context.Context, req *storagepb.ListSharedWithMeRequest) (*storagepb.ListSharedWithMeResponse, error) {
+	s.lastReq = req
+	return &storagepb.ListSharedWithMeResponse{Items: s.items}, s.err
+}
+
+type stubLookupClient struct {
+	auth.AuthClient
+
+	users map[string]string
+}
+
+func (s *stubLookupClient) LookupUser(_ context.Context, email string) (*response.UserResponse, error) {
+	userID, ok := s.users[email]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	return &response.UserResponse{UserID: userID, Email: email}, nil
+}
+
+func TestStorageManager_ShareCalls(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	share := &storagepb.ShareInfo{
+		ResourceType:  "folder",
+		ResourceId:    "folder-id",
+		OwnerId:       "owner-id",
+		UserId:        "grantee-id",
+		Role:          "viewer",
+		CreatedAtUnix: at.Unix(),
+	}
+	want := response.ShareResponse{
+		ResourceType: "folder",
+		ResourceID:   "folder-id",
+		OwnerID:      "owner-id",
+		UserID:       "grantee-id",
+		Role:         "viewer",
+		CreatedAt:    at,
+	}
+	client := &stubShareClient{
+		share: share,
+		items: []*storagepb.SharedItem{
+			{Share: share, Folder: &storagepb.Folder{FolderId: "folder-id", Name: "Clients"}},
+			{Share: share, File: &storagepb.FileInfo{FileId: "file-id", FileName: "a.txt", CreatedAtUnix: at.Unix(), UpdatedAtUnix: at.Unix()}},
+		},
+	}
+	mgr := NewStorageManager(client, &stubLookupClient{users: map[string]string{"bob@example.com": "grantee-id"}})
+	ctx := context.Background()
+
+	got, err := mgr.Share(ctx, "owner-id", ResourceFolder, "folder-id", &request.ShareRequest{Email: "bob@example.com", Role: "viewer"})
+	require.NoError(t, err)
+	require.Equal(t, &want, got)
+	require.Equal(t, &storagepb.ShareRequest{UserId: "owner-id", ResourceType: "folder", ResourceId: "folder-id", GranteeId: "grantee-id", Role: "viewer"}, client.lastReq)
+
+	err = mgr.Unshare(ctx, "owner-id", ResourceFolder, "folder-id", &request.UnshareRequest{Email: "bob@example.com"})
+	require.NoError(t, err)
+	require.Equal(t, &storagepb.UnshareRequest{UserId: "owner-id", ResourceType: "folder", ResourceId: "folder-id", GranteeId: "grantee-id"}, client.lastReq)
+
+	shares, err := mgr.ListShares(ctx, "owner-id", ResourceFile, "file-id")
+	require.NoError(t, err)
+	require.Equal(t, &response.ListSharesResponse{Shares: []response.ShareResponse{want}}, shares)
+	require.Equal(t, &storagepb.ListSharesRequest{UserId: "owner-id", ResourceType: "file", ResourceId: "file-id"}, client.lastReq)
+
+	withMe, err := mgr.ListSharedWithMe(ctx, "grantee-id")
+	require.NoError(t, err)
+	require.Len(t, withMe.Items, 2)
+	require.Equal(t, &response.FolderResponse{FolderID: "folder-id", Name: "Clients"}, withMe.Items[0].Folder)
+	require.Nil(t, withMe.Items[0].File)
+	require.Equal(t, "a.txt", withMe.Items[1].File.FileName)
+	require.Nil(t, withMe.Items[1].Folder)
+	require.Equal(t, &storagepb.ListSharedWithMeRequest{UserId: "grantee-id"}, client.lastReq)
+}
+
+func TestStorageManager_ShareCalls_Error(t *testing.T) {
+	t.Parallel()
+
+	client := &stubShareClient{err: status.Error(codes.PermissionDenied, "permission denied")}
+	mgr := NewStorageManager(client, &stubLookupClient{users: map[string]string{"bob@example.com": "grantee-id"}})
+	ctx := context.Background()
+
+	got, err := mgr.Share(ctx, "owner-id", ResourceFile, "file-id", &request.ShareRequest{Email: "eve@example.com", Role: "viewer"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+	require.Nil(t, got)
+	require.Nil(t, client.lastReq)
+
+	got, err = mgr.Share(ctx, "owner-id", ResourceFile, "file-id", &request.ShareRequest{Email: "bob@example.com", Role: "viewer"})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	require.Nil(t, got)
+
+	err = mgr.Unshare(ctx, "owner-id", ResourceFile, "file-id", &request.UnshareRequest{Email: "eve@example.com"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	withMe, err := mgr.ListSharedWithMe(ctx, "grantee-id")
+	require.Error(t, err)
+	require.Nil(t, withMe)
+}
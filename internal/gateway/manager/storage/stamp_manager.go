@@ -0,0 +1,33 @@
+package storage
+
+import (
+	"context"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/a1y/doc-formatter/internal/gateway/domain/request"
+	"github.com/a1y/doc-formatter/internal/gateway/domain/response"
+)
+
+// StampFile opens a copy of a file with a watermark, header or footer drawn
+// on every page. The user is shown by email address. Like DownloadFile, the
+// content reads from the stream until ctx is done.
+func (m *StorageManager) StampFile(ctx context.Context, userID string, fileID string, req *request.StampFileRequest) (*response.DownloadFileResponse, error) {
+	user, err := m.authClient.GetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	stream, err := m.client.StampFile(ctx, &storagepb.StampFileRequest{
+		UserId:    userID,
+		FileId:    fileID,
+		Watermark: req.Watermark,
+		Image:     req.Image,
+		Header:    req.Header,
+		Footer:    req.Footer,
+		Opacity:   req.Opacity,
+		UserLabel: user.Email,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return openDownload(stream)
+}
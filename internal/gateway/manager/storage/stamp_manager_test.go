@@ -0,0 +1,98 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/a1y/doc-formatter/internal/gateway/clients/auth"
+	"github.com/a1y/doc-formatter/internal/gateway/clients/storage"
+	"github.com/a1y/doc-formatter/internal/gateway/domain/request"
+	"github.com/a1y/doc-formatter/internal/gateway/domain/response"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type stubStampClient struct {
+	storage.StorageClient
+
+	stream *stubDownloadStream
+	err    error
+
+	lastReq *storagepb.StampFileRequest
+}
+
+func (s *stubStampClient) StampFile(_ context.Context, req *storagepb.StampFileRequest) (grpc.ServerStreamingClient[storagepb.DownloadFileResponse], error) {
+	s.lastReq = req
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.stream, nil
+}
+
+type stubUserClient struct {
+	auth.AuthClient
+
+	users map[string]string
+}
+
+func (s *stubUserClient) GetUser(_ context.Context, userID string) (*response.UserResponse, error) {
+	email, ok := s.users[userID]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	return &response.UserResponse{UserID: userID, Email: email}, nil
+}
+
+func TestStorageManager_StampFile(t *testing.T) {
+	t.Parallel()
+
+	client := &stubStampClient{
+		stream: &stubDownloadStream{msgs: []*storagepb.DownloadFileResponse{
+			{FileName: "offer.pdf", FileSize: 5},
+			{Chunk: []byte("%PDF-")},
+		}},
+	}
+	mgr := NewStorageManager(client, &stubUserClient{users: map[string]string{"user-id": "ann@example.com"}})
+
+	resp, err := mgr.StampFile(context.Background(), "user-id", "file-id", &request.StampFileRequest{
+		Watermark: "DRAFT for {user}",
+		Image:     []byte("png"),
+		Footer:    "{date}",
+		Opacity:   0.5,
+	})
+	require.NoError(t, err)
+	require.Equal(t, &storagepb.StampFileRequest{
+		UserId:    "user-id",
+		FileId:    "file-id",
+		Watermark: "DRAFT for {user}",
+		Image:     []byte("png"),
+		Footer:    "{date}",
+		Opacity:   0.5,
+		UserLabel: "ann@example.com",
+	}, client.lastReq)
+	require.Equal(t, "offer.pdf", resp.FileName)
+	content, err := io.ReadAll(resp.Content)
+	require.NoError(t, err)
+	require.Equal(t, "%PDF-", string(content))
+}
+
+func TestStorageManager_StampFile_Error(t *testing.T) {
+	t.Parallel()
+
+	client := &stubStampClient{err: status.Error(codes.FailedPrecondition, "operation not supported for this document format")}
+	mgr := NewStorageManager(client, &stubUserClient{users: map[string]string{"user-id": "ann@example.com"}})
+	ctx := context.Background()
+
+	resp, err := mgr.StampFile(ctx, "user-id", "file-id", &request.StampFileRequest{Watermark: "DRAFT"})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+	require.Nil(t, resp)
+
+	resp, err = mgr.StampFile(ctx, "unknown", "file-id", &request.StampFileRequest{Watermark: "DRAFT"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+	require.Nil(t, resp)
+	require.Equal(t, "user-id", client.lastReq.GetUserId())
+}
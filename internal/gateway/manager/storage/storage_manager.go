@@ -4,12 +4,22 @@ import (
 	"context"
 
 	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/a1y/doc-formatter/internal/gateway/domain/constant"
 	"github.com/a1y/doc-formatter/internal/gateway/domain/response"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
-func (m *StorageManager) UploadFile(ctx context.Context, userID string, fileName string, fileSize int64, content []byte) (*response.UploadFileResponse, error) {
+// UploadFile stores a file for user, the user the request was authenticated
+// as, whose verified claim gates uploads when verified uploads are required.
+func (m *StorageManager) UploadFile(ctx context.Context, user *response.UserResponse, folderID string, fileName string, fileSize int64, content []byte) (*response.UploadFileResponse, error) {
+	if m.requireVerifiedUploads && !user.IsVerified {
+		return nil, status.Error(codes.PermissionDenied, constant.ErrEmailNotVerified.Error())
+	}
 	req := &storagepb.UploadFileRequest{
-		UserId:   userID,
+		UserId:   user.UserID,
+		FolderId: folderID,
 		FileName: fileName,
 		FileSize: fileSize,
 		Content:  content,
@@ -23,3 +33,51 @@ func (m *StorageManager) UploadFile(ctx context.Context, userID string, fileName
 		FileName: resp.GetFileName(),
 	}, nil
 }
+
+// DownloadFile opens the download stream of a file and reads its metadata.
+// The returned content keeps reading from the stream, which is released once
+// ctx is done.
+func (m *StorageManager) DownloadFile(ctx context.Context, userID string, fileID string) (*response.DownloadFileResponse, error) {
+	stream, err := m.client.DownloadFile(ctx, &storagepb.DownloadFileRequest{
+		UserId: userID,
+		FileId: fileID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return openDownload(stream)
+}
+
+// openDownload reads the header of a download stream and wraps the remaining
+// chunks in a reader.
+func openDownload(stream grpc.ServerStreamingClient[storagepb.DownloadFileResponse]) (*response.DownloadFileResponse, error) {
+	// Errors such as a missing file only surface on the first receive.
+	header, err := stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+	return &response.DownloadFileResponse{
+		FileName: header.GetFileName(),
+		FileSize: header.GetFileSize(),
+		Content:  &streamReader{stream: stream},
+	}, nil
+}
+
+// streamReader exposes the chunks of a download stream as an io.Reader.
+type streamReader struct {
+	stream grpc.ServerStreamingClient[storagepb.DownloadFileResponse]
+	chunk  []byte
+}
+
+func (r *streamReader) Read(p []byte) (int, error) {
+	for len(r.chunk) == 0 {
+		msg, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		r.chunk = msg.GetChunk()
+	}
+	n := copy(p, r.chunk)
+	r.chunk = r.chunk[n:]
+	return n, nil
+}
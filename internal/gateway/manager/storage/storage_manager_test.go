@@ -3,27 +3,67 @@ package storage
 import (
 	"context"
 	"errors"
+	"io"
 	"testing"
 
 	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/a1y/doc-formatter/internal/gateway/clients/storage"
 	"github.com/a1y/doc-formatter/internal/gateway/domain/response"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type stubStorageClient struct {
-	resp *storagepb.UploadFileResponse
-	err  error
+	storage.StorageClient
+
+	resp      *storagepb.UploadFileResponse
+	err       error
+	lastUpReq *storagepb.UploadFileRequest
+
+	stream      *stubDownloadStream
+	lastDownReq *storagepb.DownloadFileRequest
 }
 
-func (s *stubStorageClient) UploadFile(_ context.Context, _ *storagepb.UploadFileRequest) (*storagepb.UploadFileResponse, error) {
+func (s *stubStorageClient) UploadFile(_ context.Context, req *storagepb.UploadFileRequest) (*storagepb.UploadFileResponse, error) {
+	s.lastUpReq = req
 	return s.resp, s.err
 }
 
+func (s *stubStorageClient) DownloadFile(_ context.Context, req *storagepb.DownloadFileRequest) (grpc.ServerStreamingClient[storagepb.DownloadFileResponse], error) {
+	s.lastDownReq = req
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.stream, nil
+}
+
+// stubDownloadStream replays msgs and then returns err, or io.EOF.
+type stubDownloadStream struct {
+	grpc.ClientStream
+
+	msgs []*storagepb.DownloadFileResponse
+	err  error
+}
+
+func (s *stubDownloadStream) Recv() (*storagepb.DownloadFileResponse, error) {
+	if len(s.msgs) == 0 {
+		if s.err != nil {
+			return nil, s.err
+		}
+		return nil, io.EOF
+	}
+	msg := s.msgs[0]
+	s.msgs = s.msgs[1:]
+	return msg, nil
+}
+
 func TestNewStorageManager_CreatesManager(t *testing.T) {
 	t.Parallel()
 
 	client := &stubStorageClient{}
-	mgr := NewStorageManager(client)
+	mgr := NewStorageManager(client, nil)
 
 	require.NotNil(t, mgr)
 	require.Equal(t, client, mgr.client)
@@ -38,10 +78,10 @@ func TestStorageManager_UploadFile_Success(t *testing.T) {
 			FileName: "file.txt",
 		},
 	}
-	mgr := NewStorageManager(client)
+	mgr := NewStorageManager(client, nil)
 
 	ctx := context.Background()
-	resp, err := mgr.UploadFile(ctx, "user-id", "file.txt", 123, []byte("content"))
+	resp, err := mgr.UploadFile(ctx, &response.UserResponse{UserID: "user-id"}, "", "file.txt", 123, []byte("content"))
 
 	require.NoError(t, err)
 	require.NotNil(t, resp)
@@ -56,12 +96,76 @@ func TestStorageManager_UploadFile_PropagatesError(t *testing.T) {
 
 	expectedErr := errors.New("upload failed")
 	client := &stubStorageClient{err: expectedErr}
-	mgr := NewStorageManager(client)
+	mgr := NewStorageManager(client, nil)
 
 	ctx := context.Background()
-	resp, err := mgr.UploadFile(ctx, "user-id", "file.txt", 123, []byte("content"))
+	resp, err := mgr.UploadFile(ctx, &response.UserResponse{UserID: "user-id"}, "", "file.txt", 123, []byte("content"))
 
 	require.Error(t, err)
 	require.Nil(t, resp)
 	require.Equal(t, expectedErr, err)
 }
+
+func TestStorageManager_UploadFile_RequiresVerifiedUser(t *testing.T) {
+	t.Parallel()
+
+	client := &stubStorageClient{
+		resp: &storagepb.UploadFileResponse{FileId: "file-id", FileName: "file.txt"},
+	}
+	// The auth client is not consulted: the verified claim comes with the
+	// authenticated user.
+	mgr := NewStorageManager(client, nil)
+	mgr.SetRequireVerifiedUploads(true)
+	ctx := context.Background()
+
+	resp, err := mgr.UploadFile(ctx, &response.UserResponse{UserID: "verified-id", IsVerified: true}, "", "file.txt", 123, []byte("content"))
+	require.NoError(t, err)
+	require.Equal(t, "file-id", resp.FileID)
+	require.Equal(t, "verified-id", client.lastUpReq.GetUserId())
+
+	resp, err = mgr.UploadFile(ctx, &response.UserResponse{UserID: "unverified-id"}, "", "file.txt", 123, []byte("content"))
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+	require.Nil(t, resp)
+}
+
+func TestStorageManager_DownloadFile_Success(t *testing.T) {
+	t.Parallel()
+
+	client := &stubStorageClient{
+		stream: &stubDownloadStream{msgs: []*storagepb.DownloadFileResponse{
+			{FileName: "file.txt", FileSize: 11},
+			{Chunk: []byte("hello ")},
+			{Chunk: []byte("world")},
+		}},
+	}
+	mgr := NewStorageManager(client, nil)
+
+	resp, err := mgr.DownloadFile(context.Background(), "user-id", "file-id")
+	require.NoError(t, err)
+	require.Equal(t, "file.txt", resp.FileName)
+	require.EqualValues(t, 11, resp.FileSize)
+	require.Equal(t, "user-id", client.lastDownReq.GetUserId())
+	require.Equal(t, "file-id", client.lastDownReq.GetFileId())
+
+	content, err := io.ReadAll(resp.Content)
+	require.NoError(t, err)
+	require.Equal(t, "hello world", string(content))
+}
+
+func TestStorageManager_DownloadFile_PropagatesErrors(t *testing.T) {
+	t.Parallel()
+
+	expectedErr := errors.New("download failed")
+	mgr := NewStorageManager(&stubStorageClient{err: expectedErr}, nil)
+
+	resp, err := mgr.DownloadFile(context.Background(), "user-id", "file-id")
+	require.Equal(t, expectedErr, err)
+	require.Nil(t, resp)
+
+	notFound := status.Error(codes.NotFound, "document not found")
+	mgr = NewStorageManager(&stubStorageClient{stream: &stubDownloadStream{err: notFound}}, nil)
+
+	resp, err = mgr.DownloadFile(context.Background(), "user-id", "file-id")
+	require.Equal(t, codes.NotFound, status.Code(err))
+	require.Nil(t, resp)
+}
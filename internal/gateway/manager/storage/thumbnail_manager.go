@@ -0,0 +1,24 @@
+package storage
+
+import (
+	"context"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/a1y/doc-formatter/internal/gateway/domain/response"
+)
+
+// GetThumbnail returns a preview image of the first page of a file.
+func (m *StorageManager) GetThumbnail(ctx context.Context, userID string, fileID string) (*response.ThumbnailResponse, error) {
+	resp, err := m.client.GetThumbnail(ctx, &storagepb.GetThumbnailRequest{
+		UserId: userID,
+		FileId: fileID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &response.ThumbnailResponse{
+		FileID:      resp.GetFileId(),
+		ContentType: resp.GetContentType(),
+		Image:       resp.GetImage(),
+	}, nil
+}
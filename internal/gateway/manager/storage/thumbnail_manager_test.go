@@ -0,0 +1,54 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/a1y/doc-formatter/internal/gateway/clients/storage"
+	"github.com/a1y/doc-formatter/internal/gateway/domain/response"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type stubThumbnailClient struct {
+	storage.StorageClient
+
+	resp *storagepb.GetThumbnailResponse
+	err  error
+
+	lastReq *storagepb.GetThumbnailRequest
+}
+
+func (s *stubThumbnailClient) GetThumbnail(_ context.Context, req *storagepb.GetThumbnailRequest) (*storagepb.GetThumbnailResponse, error) {
+	s.lastReq = req
+	return s.resp, s.err
+}
+
+func TestStorageManager_GetThumbnail(t *testing.T) {
+	t.Parallel()
+
+	client := &stubThumbnailClient{resp: &storagepb.GetThumbnailResponse{
+		FileId:      "file-id",
+		ContentType: "image/png",
+		Image:       []byte("\x89PNG"),
+	}}
+	mgr := NewStorageManager(client, nil)
+
+	got, err := mgr.GetThumbnail(context.Background(), "user-id", "file-id")
+	require.NoError(t, err)
+	require.Equal(t, &storagepb.GetThumbnailRequest{UserId: "user-id", FileId: "file-id"}, client.lastReq)
+	require.Equal(t, &response.ThumbnailResponse{FileID: "file-id", ContentType: "image/png", Image: []byte("\x89PNG")}, got)
+}
+
+func TestStorageManager_GetThumbnail_Error(t *testing.T) {
+	t.Parallel()
+
+	client := &stubThumbnailClient{err: status.Error(codes.NotFound, "document not found")}
+	mgr := NewStorageManager(client, nil)
+
+	got, err := mgr.GetThumbnail(context.Background(), "user-id", "file-id")
+	require.Equal(t, codes.NotFound, status.Code(err))
+	require.Nil(t, got)
+}
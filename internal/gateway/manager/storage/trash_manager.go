@@ -0,0 +1,62 @@
+package storage
+
+import (
+	"context"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/a1y/doc-formatter/internal/gateway/domain/request"
+	"github.com/a1y/doc-formatter/internal/gateway/domain/response"
+)
+
+// DeleteFile moves a file into the trash.
+func (m *StorageManager) DeleteFile(ctx context.Context, userID string, fileID string) (*response.FileInfoResponse, error) {
+	resp, err := m.client.DeleteFile(ctx, &storagepb.DeleteFileRequest{
+		UserId: userID,
+		FileId: fileID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	file := toFileInfoResponse(resp.GetFile())
+	return &file, nil
+}
+
+func (m *StorageManager) ListTrash(ctx context.Context, userID string, req *request.ListTrashRequest) (*response.ListTrashResponse, error) {
+	resp, err := m.client.ListTrash(ctx, &storagepb.ListTrashRequest{
+		UserId:    userID,
+		PageSize:  req.PageSize,
+		PageToken: req.PageToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := &response.ListTrashResponse{
+		Files:         make([]response.FileInfoResponse, 0, len(resp.GetFiles())),
+		NextPageToken: resp.GetNextPageToken(),
+	}
+	for _, f := range resp.GetFiles() {
+		out.Files = append(out.Files, toFileInfoResponse(f))
+	}
+	return out, nil
+}
+
+func (m *StorageManager) RestoreFile(ctx context.Context, userID string, fileID string) (*response.FileInfoResponse, error) {
+	resp, err := m.client.RestoreFile(ctx, &storagepb.RestoreFileRequest{
+		UserId: userID,
+		FileId: fileID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	file := toFileInfoResponse(resp.GetFile())
+	return &file, nil
+}
+
+// EmptyTrash deletes every file in the trash of a user for good.
+func (m *StorageManager) EmptyTrash(ctx context.Context, userID string) (*response.EmptyTrashResponse, error) {
+	resp, err := m.client.EmptyTrash(ctx, &storagepb.EmptyTrashRequest{UserId: userID})
+	if err != nil {
+		return nil, err
+	}
+	return &response.EmptyTrashResponse{PurgedFiles: resp.GetPurgedFiles()}, nil
+}
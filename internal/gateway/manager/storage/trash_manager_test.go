@@ -0,0 +1,106 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/a1y/doc-formatter/internal/gateway/clients/storage"
+	"github.com/a1y/doc-formatter/internal/gateway/domain/request"
+	"github.com/a1y/doc-formatter/internal/gateway/domain/response"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type stubTrashClient struct {
+	storage.StorageClient
+
+	file   *storagepb.FileInfo
+	files  []*storagepb.FileInfo
+	next   string
+	purged int32
+	err    error
+
+	lastReq any
+}
+
+func (s *stubTrashClient) DeleteFile(_ context.Context, req *storagepb.DeleteFileRequest) (*storagepb.DeleteFileResponse, error) {
+	s.lastReq = req
+	return &storagepb.DeleteFileResponse{File: s.file}, s.err
+}
+
+func (s *stubTrashClient) ListTrash(_ context.Context, req *storagepb.ListTrashRequest) (*storagepb.ListTrashResponse, error) {
+	s.lastReq = req
+	return &storagepb.ListTrashResponse{Files: s.files, NextPageToken: s.next}, s.err
+}
+
+func (s *stubTrashClient) RestoreFile(_ context.Context, req *storagepb.RestoreFileRequest) (*storagepb.RestoreFileResponse, error) {
+	s.lastReq = req
+	return &storagepb.RestoreFileResponse{File: s.file}, s.err
+}
+
+func (s *stubTrashClient) EmptyTrash(_ context.Context, req *storagepb.EmptyTrashRequest) (*storagepb.EmptyTrashResponse, error) {
+	s.lastReq = req
+	return &storagepb.EmptyTrashResponse{PurgedFiles: s.purged}, s.err
+}
+
+func TestStorageManager_TrashCalls(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	trashed := &storagepb.FileInfo{
+		FileId:        "file-id",
+		FileName:      "report.pdf",
+		CreatedAtUnix: at.Unix(),
+		UpdatedAtUnix: at.Unix(),
+		DeletedAtUnix: at.Unix(),
+	}
+	want := response.FileInfoResponse{
+		FileID:    "file-id",
+		FileName:  "report.pdf",
+		CreatedAt: at,
+		UpdatedAt: at,
+		DeletedAt: &at,
+	}
+	client := &stubTrashClient{file: trashed, files: []*storagepb.FileInfo{trashed}, next: "next-token", purged: 3}
+	mgr := NewStorageManager(client, nil)
+	ctx := context.Background()
+
+	got, err := mgr.DeleteFile(ctx, "user-id", "file-id")
+	require.NoError(t, err)
+	require.Equal(t, &want, got)
+	require.Equal(t, &storagepb.DeleteFileRequest{UserId: "user-id", FileId: "file-id"}, client.lastReq)
+
+	listing, err := mgr.ListTrash(ctx, "user-id", &request.ListTrashRequest{PageSize: 10, PageToken: "page-token"})
+	require.NoError(t, err)
+	require.Equal(t, &response.ListTrashResponse{Files: []response.FileInfoResponse{want}, NextPageToken: "next-token"}, listing)
+	require.Equal(t, &storagepb.ListTrashRequest{UserId: "user-id", PageSize: 10, PageToken: "page-token"}, client.lastReq)
+
+	client.file = &storagepb.FileInfo{FileId: "file-id", FileName: "report.pdf", CreatedAtUnix: at.Unix(), UpdatedAtUnix: at.Unix()}
+	got, err = mgr.RestoreFile(ctx, "user-id", "file-id")
+	require.NoError(t, err)
+	require.Nil(t, got.DeletedAt)
+	require.Equal(t, &storagepb.RestoreFileRequest{UserId: "user-id", FileId: "file-id"}, client.lastReq)
+
+	emptied, err := mgr.EmptyTrash(ctx, "user-id")
+	require.NoError(t, err)
+	require.Equal(t, &response.EmptyTrashResponse{PurgedFiles: 3}, emptied)
+	require.Equal(t, &storagepb.EmptyTrashRequest{UserId: "user-id"}, client.lastReq)
+}
+
+func TestStorageManager_TrashCalls_Error(t *testing.T) {
+	t.Parallel()
+
+	client := &stubTrashClient{err: status.Error(codes.NotFound, "document not found")}
+	mgr := NewStorageManager(client, nil)
+
+	got, err := mgr.RestoreFile(context.Background(), "user-id", "file-id")
+	require.Error(t, err)
+	require.Nil(t, got)
+
+	emptied, err := mgr.EmptyTrash(context.Background(), "user-id")
+	require.Error(t, err)
+	require.Nil(t, emptied)
+}
@@ -1,13 +1,25 @@
 package storage
 
 import (
+	"github.com/a1y/doc-formatter/internal/gateway/clients/auth"
 	"github.com/a1y/doc-formatter/internal/gateway/clients/storage"
 )
 
 type StorageManager struct {
-	client storage.StorageClient
+	client     storage.StorageClient
+	authClient auth.AuthClient
+
+	requireVerifiedUploads bool
+}
+
+// NewStorageManager creates a storage manager. The auth client resolves the
+// email addresses that files and folders are shared with.
+func NewStorageManager(client storage.StorageClient, authClient auth.AuthClient) *StorageManager {
+	return &StorageManager{client: client, authClient: authClient}
 }
 
-func NewStorageManager(client storage.StorageClient) *StorageManager {
-	return &StorageManager{client: client}
+// SetRequireVerifiedUploads makes UploadFile refuse users who have not
+// verified their email address.
+func (m *StorageManager) SetRequireVerifiedUploads(require bool) {
+	m.requireVerifiedUploads = require
 }
@@ -5,10 +5,14 @@ import (
 	"testing"
 
 	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/a1y/doc-formatter/internal/gateway/clients/storage"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
 )
 
-type fakeStorageClient struct{}
+type fakeStorageClient struct {
+	storage.StorageClient
+}
 
 func (f *fakeStorageClient) UploadFile(ctx context.Context, req *storagepb.UploadFileRequest) (*storagepb.UploadFileResponse, error) {
 	return &storagepb.UploadFileResponse{
@@ -17,12 +21,17 @@ func (f *fakeStorageClient) UploadFile(ctx context.Context, req *storagepb.Uploa
 	}, nil
 }
 
+func (f *fakeStorageClient) DownloadFile(ctx context.Context, req *storagepb.DownloadFileRequest) (grpc.ServerStreamingClient[storagepb.DownloadFileResponse], error) {
+	return nil, nil
+}
+
 func TestNewStorageManager_ReturnsManagerWithClient(t *testing.T) {
 	t.Parallel()
 
 	client := &fakeStorageClient{}
-	mgr := NewStorageManager(client)
+	mgr := NewStorageManager(client, nil)
 
 	require.NotNil(t, mgr)
 	require.Equal(t, client, mgr.client)
+	require.Nil(t, mgr.authClient)
 }
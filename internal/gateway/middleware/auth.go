@@ -0,0 +1,191 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/a1y/doc-formatter/internal/gateway/domain/response"
+	"github.com/a1y/doc-formatter/internal/gateway/util/grpcstatus"
+	"github.com/gin-gonic/gin"
+)
+
+// Scopes API keys can be restricted to.
+const (
+	ScopeRead   = "read"
+	ScopeUpload = "upload"
+	ScopeFormat = "format"
+)
+
+// Permissions roles can grant to administer the service.
+const (
+	PermissionUsersRead        = "users:read"
+	PermissionUsersManage      = "users:manage"
+	PermissionUsersImpersonate = "users:impersonate"
+	PermissionRolesManage      = "roles:manage"
+)
+
+// TokenValidator resolves the user an access token or API key was issued to.
+type TokenValidator interface {
+	ValidateToken(ctx context.Context, accessToken string) (*response.UserResponse, error)
+	// ValidateAPIKey also returns the scopes the key is restricted to.
+	ValidateAPIKey(ctx context.Context, key string) (*response.UserResponse, []string, error)
+}
+
+// AuthMiddleware only lets requests with a valid bearer access token or API
+// key through, and attaches the user the credential was issued to, see
+// AuthUser. API keys are sent as "ApiKey <key>".
+func AuthMiddleware(validator TokenValidator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		scheme, credential, ok := credentials(c.GetHeader("Authorization"))
+		if !ok {
+			c.Writer.Header().Add("WWW-Authenticate", "Bearer")
+			c.Writer.Header().Add("WWW-Authenticate", "ApiKey")
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "missing bearer token or api key"})
+			return
+		}
+		authenticate(c, validator, scheme, credential)
+	}
+}
+
+func authenticate(c *gin.Context, validator TokenValidator, scheme, credential string) {
+	var (
+		user   *response.UserResponse
+		scopes []string
+		err    error
+	)
+	if scheme == "Bearer" {
+		user, err = validator.ValidateToken(c.Request.Context(), credential)
+	} else {
+		user, scopes, err = validator.ValidateAPIKey(c.Request.Context(), credential)
+	}
+	if err != nil {
+		code := grpcstatus.HTTPStatus(err)
+		if code == http.StatusUnauthorized {
+			c.Header("WWW-Authenticate", scheme+` error="invalid_token"`)
+		}
+		c.AbortWithStatusJSON(code, gin.H{"error": grpcstatus.Message(err)})
+		return
+	}
+
+	c.Set(AuthUserKey, user)
+	if scheme == "ApiKey" {
+		// Keys without scopes are recorded too, so that they can be told
+		// apart from sessions.
+		c.Set(AuthScopesKey, append([]string{}, scopes...))
+	}
+	c.Next()
+}
+
+// AuthUser returns the user AuthMiddleware authenticated the request as.
+func AuthUser(c *gin.Context) (*response.UserResponse, bool) {
+	value, _ := c.Get(AuthUserKey)
+	user, ok := value.(*response.UserResponse)
+	return user, ok && user != nil
+}
+
+// AuthScopes returns the scopes of the API key AuthMiddleware authenticated
+// the request with, none meaning the key is not restricted. It returns false
+// when the request was not authenticated with an API key.
+func AuthScopes(c *gin.Context) ([]string, bool) {
+	value, ok := c.Get(AuthScopesKey)
+	if !ok {
+		return nil, false
+	}
+	scopes, ok := value.([]string)
+	return scopes, ok
+}
+
+// RequireScope only lets requests authenticated with an API key through when
+// the key is not restricted, or has scope.
+func RequireScope(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if scopes, ok := AuthScopes(c); ok && len(scopes) > 0 && !slices.Contains(scopes, scope) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": fmt.Sprintf("api key does not have the %s scope", scope)})
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequireFullAccess only lets requests authenticated with an API key through
+// when the key is not restricted to any scope.
+func RequireFullAccess() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if scopes, ok := AuthScopes(c); ok && len(scopes) > 0 {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "api key is restricted to " + strings.Join(scopes, ", ")})
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequireAccessToken does not let requests authenticated with an API key
+// through, so that keys cannot be used to manage the keys of their user.
+func RequireAccessToken() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := AuthScopes(c); ok {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "not available with an api key, log in instead"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequireSession only lets requests through that users make with their own
+// session. API keys and the access tokens administrators impersonate users
+// with are refused, so that neither can be used to take over an account by
+// changing its credentials.
+func RequireSession() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := AuthScopes(c); ok {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "not available with an api key, log in instead"})
+			return
+		}
+		if user, ok := AuthUser(c); ok && user.ImpersonatorID != "" {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "not available while impersonating a user"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequirePermission only lets requests through when the role of their user
+// grants permission. Only access tokens carry a role, so requests
+// authenticated with an API key are refused.
+func RequirePermission(permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, ok := AuthUser(c)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "not authenticated"})
+			return
+		}
+		if !slices.Contains(user.Permissions, permission) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": fmt.Sprintf("role does not have the %s permission", permission)})
+			return
+		}
+		c.Next()
+	}
+}
+
+// credentials returns the scheme, either Bearer or ApiKey, and the credential
+// of an Authorization header.
+func credentials(header string) (string, string, bool) {
+	scheme, credential, ok := strings.Cut(strings.TrimSpace(header), " ")
+	if !ok {
+		return "", "", false
+	}
+	credential = strings.TrimSpace(credential)
+	switch {
+	case credential == "":
+		return "", "", false
+	case strings.EqualFold(scheme, "Bearer"):
+		return "Bearer", credential, true
+	case strings.EqualFold(scheme, "ApiKey"):
+		return "ApiKey", credential, true
+	default:
+		return "", "", false
+	}
+}
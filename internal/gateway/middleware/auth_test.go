@@ -0,0 +1,229 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/a1y/doc-formatter/internal/gateway/domain/response"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type tokenValidatorFunc func(ctx context.Context, accessToken string) (*response.UserResponse, error)
+
+func (f tokenValidatorFunc) ValidateToken(ctx context.Context, accessToken string) (*response.UserResponse, error) {
+	return f(ctx, accessToken)
+}
+
+// ValidateAPIKey accepts the keys "full-key", without scopes, and
+// "read-key", with the read scope.
+func (f tokenValidatorFunc) ValidateAPIKey(ctx context.Context, key string) (*response.UserResponse, []string, error) {
+	switch key {
+	case "full-key":
+		return &response.UserResponse{UserID: "user-2"}, nil, nil
+	case "read-key":
+		return &response.UserResponse{UserID: "user-2"}, []string{ScopeRead}, nil
+	default:
+		return nil, nil, status.Error(codes.Unauthenticated, "invalid or revoked api key")
+	}
+}
+
+func newAuthRouter(validator TokenValidator) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/me", AuthMiddleware(validator), func(c *gin.Context) {
+		user, ok := AuthUser(c)
+		if !ok {
+			c.Status(http.StatusInternalServerError)
+			return
+		}
+		c.String(http.StatusOK, user.UserID)
+	})
+	return r
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	t.Parallel()
+
+	validator := tokenValidatorFunc(func(ctx context.Context, accessToken string) (*response.UserResponse, error) {
+		if accessToken != "good-token" {
+			return nil, status.Error(codes.Unauthenticated, "invalid or expired access token")
+		}
+		return &response.UserResponse{UserID: "user-1"}, nil
+	})
+	r := newAuthRouter(validator)
+
+	tests := []struct {
+		name          string
+		header        string
+		wantCode      int
+		wantBody      string
+		wantChallenge string
+	}{
+		{name: "valid", header: "Bearer good-token", wantCode: http.StatusOK, wantBody: "user-1"},
+		{name: "scheme is case insensitive", header: "bearer good-token", wantCode: http.StatusOK, wantBody: "user-1"},
+		{name: "missing", wantCode: http.StatusUnauthorized, wantChallenge: "Bearer"},
+		{name: "other scheme", header: "Basic dXNlcjpwYXNz", wantCode: http.StatusUnauthorized, wantChallenge: "Bearer"},
+		{name: "empty token", header: "Bearer ", wantCode: http.StatusUnauthorized, wantChallenge: "Bearer"},
+		{name: "invalid token", header: "Bearer bad-token", wantCode: http.StatusUnauthorized, wantChallenge: `Bearer error="invalid_token"`},
+		{name: "api key", header: "ApiKey full-key", wantCode: http.StatusOK, wantBody: "user-2"},
+		{name: "api key scheme is case insensitive", header: "apikey read-key", wantCode: http.StatusOK, wantBody: "user-2"},
+		{name: "invalid api key", header: "ApiKey bad-key", wantCode: http.StatusUnauthorized, wantChallenge: `ApiKey error="invalid_token"`},
+		{name: "empty api key", header: "ApiKey ", wantCode: http.StatusUnauthorized, wantChallenge: "Bearer"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodGet, "/me", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("expected status %d, got %d", tt.wantCode, w.Code)
+			}
+			if tt.wantBody != "" && w.Body.String() != tt.wantBody {
+				t.Fatalf("expected body %q, got %q", tt.wantBody, w.Body.String())
+			}
+			if got := w.Header().Get("WWW-Authenticate"); got != tt.wantChallenge {
+				t.Fatalf("expected challenge %q, got %q", tt.wantChallenge, got)
+			}
+		})
+	}
+}
+
+func TestAuthMiddleware_AuthServiceUnavailable(t *testing.T) {
+	t.Parallel()
+
+	r := newAuthRouter(tokenValidatorFunc(func(ctx context.Context, accessToken string) (*response.UserResponse, error) {
+		return nil, status.Error(codes.Unavailable, "auth service unavailable")
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/me", nil)
+	req.Header.Set("Authorization", "Bearer good-token")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected status %d, got %d", http.StatusServiceUnavailable, w.Code)
+	}
+}
+
+func TestAuthMiddleware_ChallengesBothSchemes(t *testing.T) {
+	t.Parallel()
+
+	r := newAuthRouter(tokenValidatorFunc(nil))
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/me", nil))
+
+	if got := w.Header().Values("WWW-Authenticate"); len(got) != 2 || got[0] != "Bearer" || got[1] != "ApiKey" {
+		t.Fatalf("expected Bearer and ApiKey challenges, got %q", got)
+	}
+}
+
+func TestRequireScope(t *testing.T) {
+	t.Parallel()
+
+	validator := tokenValidatorFunc(func(ctx context.Context, accessToken string) (*response.UserResponse, error) {
+		if accessToken == "impersonation-token" {
+			return &response.UserResponse{UserID: "user-1", ImpersonatorID: "admin-1"}, nil
+		}
+		return &response.UserResponse{UserID: "user-1"}, nil
+	})
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	r.GET("/read", AuthMiddleware(validator), RequireScope(ScopeRead), ok)
+	r.POST("/upload", AuthMiddleware(validator), RequireScope(ScopeUpload), ok)
+	r.DELETE("/file", AuthMiddleware(validator), RequireFullAccess(), ok)
+	r.PUT("/password", AuthMiddleware(validator), RequireSession(), ok)
+	r.DELETE("/api-key", AuthMiddleware(validator), RequireAccessToken(), ok)
+
+	tests := []struct {
+		method   string
+		path     string
+		header   string
+		wantCode int
+	}{
+		{method: http.MethodGet, path: "/read", header: "ApiKey read-key", wantCode: http.StatusOK},
+		{method: http.MethodPost, path: "/upload", header: "ApiKey read-key", wantCode: http.StatusForbidden},
+		{method: http.MethodPost, path: "/upload", header: "ApiKey full-key", wantCode: http.StatusOK},
+		{method: http.MethodPost, path: "/upload", header: "Bearer token", wantCode: http.StatusOK},
+		{method: http.MethodPost, path: "/upload", wantCode: http.StatusUnauthorized},
+		{method: http.MethodDelete, path: "/file", header: "ApiKey read-key", wantCode: http.StatusForbidden},
+		{method: http.MethodDelete, path: "/file", header: "ApiKey full-key", wantCode: http.StatusOK},
+		{method: http.MethodPut, path: "/password", header: "ApiKey full-key", wantCode: http.StatusForbidden},
+		{method: http.MethodPut, path: "/password", header: "Bearer token", wantCode: http.StatusOK},
+		{method: http.MethodPut, path: "/password", header: "Bearer impersonation-token", wantCode: http.StatusForbidden},
+		{method: http.MethodDelete, path: "/api-key", header: "ApiKey full-key", wantCode: http.StatusForbidden},
+		{method: http.MethodDelete, path: "/api-key", header: "Bearer impersonation-token", wantCode: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path+" "+tt.header, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("expected status %d, got %d", tt.wantCode, w.Code)
+			}
+		})
+	}
+}
+
+func TestRequirePermission(t *testing.T) {
+	t.Parallel()
+
+	validator := tokenValidatorFunc(func(ctx context.Context, accessToken string) (*response.UserResponse, error) {
+		if accessToken == "admin-token" {
+			return &response.UserResponse{UserID: "user-1", Role: "admin", Permissions: []string{PermissionUsersRead, PermissionUsersManage}}, nil
+		}
+		return &response.UserResponse{UserID: "user-1", Role: "user"}, nil
+	})
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	r.GET("/users", AuthMiddleware(validator), RequirePermission(PermissionUsersRead), ok)
+	r.PUT("/role", AuthMiddleware(validator), RequirePermission(PermissionRolesManage), ok)
+	r.GET("/unauthenticated", RequirePermission(PermissionUsersRead), ok)
+
+	tests := []struct {
+		method   string
+		path     string
+		header   string
+		wantCode int
+	}{
+		{method: http.MethodGet, path: "/users", header: "Bearer admin-token", wantCode: http.StatusOK},
+		{method: http.MethodGet, path: "/users", header: "Bearer token", wantCode: http.StatusForbidden},
+		{method: http.MethodGet, path: "/users", header: "ApiKey full-key", wantCode: http.StatusForbidden},
+		{method: http.MethodPut, path: "/role", header: "Bearer admin-token", wantCode: http.StatusForbidden},
+		{method: http.MethodGet, path: "/unauthenticated", wantCode: http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path+" "+tt.header, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("expected status %d, got %d", tt.wantCode, w.Code)
+			}
+		})
+	}
+}
@@ -8,4 +8,6 @@ var (
 	APILoggerKey       = &contextKey{"api-logger"}
 	RunLoggerKey       = &contextKey{"run-logger"}
 	RunLoggerBufferKey = &contextKey{"run-logger-buffer"}
+	AuthUserKey        = &contextKey{"auth-user"}
+	AuthScopesKey      = &contextKey{"auth-scopes"}
 )
@@ -48,7 +48,7 @@ func NewRouter(config *gateway.Config) (*gin.Engine, error) {
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
 	v1 := r.Group("/api/v1")
-	if err := setupAPIV1(v1, config); err != nil {
+	if err := setupAPIV1(v1, r, config); err != nil {
 		logger.Error("Failed to setup API v1...", zap.Error(err))
 		return nil, err
 	}
@@ -56,7 +56,9 @@ func NewRouter(config *gateway.Config) (*gin.Engine, error) {
 	return r, nil
 }
 
-func setupAPIV1(r gin.IRouter, config *gateway.Config) error {
+// setupAPIV1 registers the versioned API on r. Unauthenticated routes that live
+// outside the API prefix, such as share links, are registered on public.
+func setupAPIV1(r gin.IRouter, public gin.IRouter, config *gateway.Config) error {
 	logger := logutil.GetLogger(context.Background())
 	logger.Info("Setting up API v1...")
 
@@ -66,7 +68,8 @@ func setupAPIV1(r gin.IRouter, config *gateway.Config) error {
 
 	// Setup managers
 	authManager := authmanager.NewAuthManager(authClient)
-	storageManager := storagemanager.NewStorageManager(storageClient)
+	storageManager := storagemanager.NewStorageManager(storageClient, authClient)
+	storageManager.SetRequireVerifiedUploads(config.RequireVerifiedUploads)
 
 	// Setup handlers
 	authHandler, err := authhandler.NewAuthHandler(authManager)
@@ -85,12 +88,110 @@ func setupAPIV1(r gin.IRouter, config *gateway.Config) error {
 	{
 		authGroup.POST("/signup", authHandler.Signup)
 		authGroup.POST("/login", authHandler.Login)
+		authGroup.POST("/login/mfa", authHandler.VerifyMFA)
+		authGroup.POST("/verify", authHandler.VerifyEmail)
+		authGroup.POST("/verify/resend", authHandler.ResendVerification)
+		authGroup.POST("/password/forgot", authHandler.ForgotPassword)
+		authGroup.POST("/password/reset", authHandler.ResetPassword)
+		authGroup.GET("/oidc/:provider/login", authHandler.OIDCLogin)
+		authGroup.GET("/oidc/:provider/callback", authHandler.OIDCCallback)
 	}
 
-	storageGroup := r.Group("/storage")
+	// API keys cannot manage credentials, so that a leaked key cannot be
+	// turned into an account takeover. Administrators impersonating a user
+	// cannot change the credentials either, only list and revoke API keys.
+	session := middleware.RequireSession()
+	accessToken := middleware.RequireAccessToken()
+	meGroup := authGroup.Group("/me", middleware.AuthMiddleware(authClient))
 	{
-		storageGroup.POST("/upload", storageHandler.UploadFile)
+		meGroup.GET("", authHandler.Me)
+		meGroup.PUT("/password", session, authHandler.ChangePassword)
+		meGroup.PUT("/email", session, authHandler.ChangeEmail)
+		meGroup.POST("/mfa/totp", session, authHandler.EnrollTOTP)
+		meGroup.POST("/mfa/totp/confirm", session, authHandler.ConfirmTOTP)
+		meGroup.POST("/api-keys", session, authHandler.CreateAPIKey)
+		meGroup.GET("/api-keys", accessToken, authHandler.ListAPIKeys)
+		meGroup.DELETE("/api-keys/:id", accessToken, authHandler.RevokeAPIKey)
 	}
 
+	// Administrators act with their session only, and each route needs the
+	// permission its action calls for. The auth service checks the role of
+	// the administrator again and audits every action.
+	adminGroup := r.Group("/admin", middleware.AuthMiddleware(authClient), session)
+	{
+		readUsers := middleware.RequirePermission(middleware.PermissionUsersRead)
+		manageUsers := middleware.RequirePermission(middleware.PermissionUsersManage)
+		impersonate := middleware.RequirePermission(middleware.PermissionUsersImpersonate)
+		manageRoles := middleware.RequirePermission(middleware.PermissionRolesManage)
+
+		adminGroup.GET("/users", readUsers, authHandler.ListUsers)
+		adminGroup.POST("/users/:id/disable", manageUsers, authHandler.DisableUser)
+		adminGroup.POST("/users/:id/enable", manageUsers, authHandler.EnableUser)
+		adminGroup.POST("/users/:id/verify", manageUsers, authHandler.VerifyUser)
+		adminGroup.DELETE("/users/:id/mfa", manageUsers, authHandler.ResetUserMFA)
+		adminGroup.POST("/users/:id/impersonate", impersonate, authHandler.ImpersonateUser)
+		adminGroup.PUT("/users/:id/role", manageRoles, authHandler.SetUserRole)
+		adminGroup.GET("/roles", readUsers, authHandler.ListRoles)
+		adminGroup.POST("/roles", manageRoles, authHandler.CreateRole)
+	}
+
+	// Storage and job routes act for the user the access token or API key was
+	// issued to. Requests with an API key are restricted to the scopes of the
+	// key, and anything not covered by a scope needs a key without scopes.
+	authenticated := middleware.AuthMiddleware(authClient)
+	read := middleware.RequireScope(middleware.ScopeRead)
+	upload := middleware.RequireScope(middleware.ScopeUpload)
+	format := middleware.RequireScope(middleware.ScopeFormat)
+	full := middleware.RequireFullAccess()
+
+	storageGroup := r.Group("/storage", authenticated)
+	{
+		storageGroup.POST("/upload", upload, storageHandler.UploadFile)
+		storageGroup.GET("/files/:id/download", read, storageHandler.DownloadFile)
+		storageGroup.PUT("/files/:id/folder", full, storageHandler.MoveFile)
+		storageGroup.GET("/files", read, storageHandler.ListFiles)
+		storageGroup.POST("/files/:id/tags", full, storageHandler.AddFileTags)
+		storageGroup.DELETE("/files/:id/tags", full, storageHandler.RemoveFileTags)
+		storageGroup.PUT("/files/:id/metadata", full, storageHandler.SetFileMetadata)
+		storageGroup.DELETE("/files/:id/metadata", full, storageHandler.RemoveFileMetadata)
+		storageGroup.DELETE("/files/:id", full, storageHandler.DeleteFile)
+		storageGroup.GET("/files/:id/analysis", read, storageHandler.AnalyzeDocument)
+		storageGroup.GET("/files/:id/thumbnail", read, storageHandler.GetThumbnail)
+		storageGroup.GET("/trash", read, storageHandler.ListTrash)
+		storageGroup.POST("/trash/:id/restore", full, storageHandler.RestoreFile)
+		storageGroup.DELETE("/trash", full, storageHandler.EmptyTrash)
+		storageGroup.GET("/list", read, storageHandler.ListFolder)
+		storageGroup.POST("/folders", full, storageHandler.CreateFolder)
+		storageGroup.PUT("/folders/:id/name", full, storageHandler.RenameFolder)
+		storageGroup.PUT("/folders/:id/parent", full, storageHandler.MoveFolder)
+		storageGroup.DELETE("/folders/:id", full, storageHandler.DeleteFolder)
+		storageGroup.POST("/files/:id/shares", full, storageHandler.ShareFile)
+		storageGroup.GET("/files/:id/shares", read, storageHandler.ListFileShares)
+		storageGroup.DELETE("/files/:id/shares", full, storageHandler.UnshareFile)
+		storageGroup.POST("/folders/:id/shares", full, storageHandler.ShareFolder)
+		storageGroup.GET("/folders/:id/shares", read, storageHandler.ListFolderShares)
+		storageGroup.DELETE("/folders/:id/shares", full, storageHandler.UnshareFolder)
+		storageGroup.GET("/shared", read, storageHandler.ListSharedWithMe)
+		storageGroup.POST("/files/:id/links", full, storageHandler.CreateShareLink)
+		storageGroup.GET("/files/:id/links", read, storageHandler.ListShareLinks)
+		storageGroup.DELETE("/links/:id", full, storageHandler.RevokeShareLink)
+		storageGroup.GET("/diff", read, storageHandler.DiffDocuments)
+		storageGroup.GET("/diff/redline", read, storageHandler.RedlineDocuments)
+		storageGroup.POST("/merge", format, storageHandler.MergeDocuments)
+		storageGroup.POST("/files/:id/split", format, storageHandler.SplitDocument)
+		storageGroup.GET("/files/:id/provenance", read, storageHandler.GetProvenance)
+		storageGroup.POST("/files/:id/stamp", format, storageHandler.StampFile)
+	}
+
+	jobsGroup := r.Group("/jobs", authenticated)
+	{
+		jobsGroup.POST("/batch", format, storageHandler.CreateBatchJob)
+		jobsGroup.GET("/batch/:id", read, storageHandler.GetBatchJob)
+	}
+
+	r.GET("/search", authenticated, read, storageHandler.SearchDocuments)
+
+	public.GET(storagemanager.SharedFilePath+":token", storageHandler.DownloadSharedFile)
+
 	return nil
 }
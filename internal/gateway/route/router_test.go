@@ -1,11 +1,20 @@
 package route
 
 import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	authpb "github.com/a1y/doc-formatter/api/grpc/auth/v1"
 	"github.com/a1y/doc-formatter/internal/gateway"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestNewRouter(t *testing.T) {
@@ -22,19 +31,148 @@ func TestNewRouter(t *testing.T) {
 	assert.NotNil(t, r)
 
 	routes := r.Routes()
-	expectedRoutes := map[string]string{
-		"/api/v1/auth/signup":    "POST",
-		"/api/v1/auth/login":     "POST",
-		"/api/v1/storage/upload": "POST",
-		"/swagger/*any":          "GET",
+	expectedRoutes := map[string]bool{
+		"POST /api/v1/auth/signup":                  true,
+		"POST /api/v1/auth/login":                   true,
+		"POST /api/v1/auth/login/mfa":               true,
+		"GET /api/v1/auth/oidc/:provider/login":     true,
+		"GET /api/v1/auth/oidc/:provider/callback":  true,
+		"POST /api/v1/auth/verify":                  true,
+		"POST /api/v1/auth/verify/resend":           true,
+		"POST /api/v1/auth/password/forgot":         true,
+		"POST /api/v1/auth/password/reset":          true,
+		"GET /api/v1/auth/me":                       true,
+		"PUT /api/v1/auth/me/password":              true,
+		"PUT /api/v1/auth/me/email":                 true,
+		"POST /api/v1/auth/me/mfa/totp":             true,
+		"POST /api/v1/auth/me/mfa/totp/confirm":     true,
+		"POST /api/v1/auth/me/api-keys":             true,
+		"GET /api/v1/auth/me/api-keys":              true,
+		"DELETE /api/v1/auth/me/api-keys/:id":       true,
+		"GET /api/v1/admin/users":                   true,
+		"POST /api/v1/admin/users/:id/disable":      true,
+		"POST /api/v1/admin/users/:id/enable":       true,
+		"POST /api/v1/admin/users/:id/verify":       true,
+		"DELETE /api/v1/admin/users/:id/mfa":        true,
+		"POST /api/v1/admin/users/:id/impersonate":  true,
+		"PUT /api/v1/admin/users/:id/role":          true,
+		"GET /api/v1/admin/roles":                   true,
+		"POST /api/v1/admin/roles":                  true,
+		"POST /api/v1/storage/upload":               true,
+		"GET /api/v1/storage/files/:id/download":    true,
+		"PUT /api/v1/storage/files/:id/folder":      true,
+		"GET /api/v1/storage/files":                 true,
+		"POST /api/v1/storage/files/:id/tags":       true,
+		"DELETE /api/v1/storage/files/:id/tags":     true,
+		"PUT /api/v1/storage/files/:id/metadata":    true,
+		"DELETE /api/v1/storage/files/:id/metadata": true,
+		"DELETE /api/v1/storage/files/:id":          true,
+		"GET /api/v1/storage/trash":                 true,
+		"POST /api/v1/storage/trash/:id/restore":    true,
+		"DELETE /api/v1/storage/trash":              true,
+		"GET /api/v1/storage/list":                  true,
+		"POST /api/v1/storage/folders":              true,
+		"PUT /api/v1/storage/folders/:id/name":      true,
+		"PUT /api/v1/storage/folders/:id/parent":    true,
+		"DELETE /api/v1/storage/folders/:id":        true,
+		"POST /api/v1/storage/files/:id/shares":     true,
+		"GET /api/v1/storage/files/:id/shares":      true,
+		"DELETE /api/v1/storage/files/:id/shares":   true,
+		"POST /api/v1/storage/folders/:id/shares":   true,
+		"GET /api/v1/storage/folders/:id/shares":    true,
+		"DELETE /api/v1/storage/folders/:id/shares": true,
+		"GET /api/v1/storage/shared":                true,
+		"POST /api/v1/storage/files/:id/links":      true,
+		"GET /api/v1/storage/files/:id/links":       true,
+		"DELETE /api/v1/storage/links/:id":          true,
+		"GET /api/v1/search":                        true,
+		"GET /api/v1/storage/files/:id/analysis":    true,
+		"GET /api/v1/storage/files/:id/thumbnail":   true,
+		"GET /api/v1/storage/diff":                  true,
+		"GET /api/v1/storage/diff/redline":          true,
+		"POST /api/v1/storage/merge":                true,
+		"POST /api/v1/storage/files/:id/split":      true,
+		"GET /api/v1/storage/files/:id/provenance":  true,
+		"POST /api/v1/storage/files/:id/stamp":      true,
+		"POST /api/v1/jobs/batch":                   true,
+		"GET /api/v1/jobs/batch/:id":                true,
+		"GET /s/:token":                             true,
+		"GET /swagger/*any":                         true,
 	}
 
 	for _, route := range routes {
-		if method, ok := expectedRoutes[route.Path]; ok {
-			assert.Equal(t, method, route.Method)
-			delete(expectedRoutes, route.Path)
-		}
+		delete(expectedRoutes, route.Method+" "+route.Path)
 	}
 
 	assert.Empty(t, expectedRoutes, "Some expected routes were not found")
 }
+
+// fakeAuthServer only accepts the API key "read-key", restricted to the read
+// scope.
+type fakeAuthServer struct {
+	authpb.UnimplementedAuthServiceServer
+}
+
+func (fakeAuthServer) ValidateAPIKey(ctx context.Context, req *authpb.ValidateAPIKeyRequest) (*authpb.ValidateAPIKeyResponse, error) {
+	if req.GetKey() != "read-key" {
+		return nil, status.Error(codes.Unauthenticated, "invalid or revoked api key")
+	}
+	return &authpb.ValidateAPIKeyResponse{
+		UserId:     "550e8400-e29b-41d4-a716-446655440000",
+		IsVerified: true,
+		Scopes:     []string{"read"},
+	}, nil
+}
+
+func TestNewRouter_StorageRequiresAuthentication(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	grpcServer := grpc.NewServer()
+	authpb.RegisterAuthServiceServer(grpcServer, fakeAuthServer{})
+	go func() {
+		_ = grpcServer.Serve(lis)
+	}()
+	t.Cleanup(grpcServer.Stop)
+
+	// The storage service is never reached: every request below is turned
+	// away by the gateway.
+	r, err := NewRouter(&gateway.Config{
+		Address:        ":8080",
+		AuthService:    lis.Addr().String(),
+		StorageService: "127.0.0.1:1",
+	})
+	require.NoError(t, err)
+
+	const fileID = "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		header   string
+		wantCode int
+	}{
+		{name: "upload without credential", method: http.MethodPost, path: "/api/v1/storage/upload", wantCode: http.StatusUnauthorized},
+		{name: "download without credential", method: http.MethodGet, path: "/api/v1/storage/files/" + fileID + "/download", wantCode: http.StatusUnauthorized},
+		{name: "list files without credential", method: http.MethodGet, path: "/api/v1/storage/files?user_id=550e8400-e29b-41d4-a716-446655440000", wantCode: http.StatusUnauthorized},
+		{name: "batch job without credential", method: http.MethodGet, path: "/api/v1/jobs/batch/" + fileID, wantCode: http.StatusUnauthorized},
+		{name: "search without credential", method: http.MethodGet, path: "/api/v1/search?q=report", wantCode: http.StatusUnauthorized},
+		{name: "invalid api key", method: http.MethodGet, path: "/api/v1/storage/files", header: "ApiKey bad-key", wantCode: http.StatusUnauthorized},
+		{name: "upload with read key", method: http.MethodPost, path: "/api/v1/storage/upload", header: "ApiKey read-key", wantCode: http.StatusForbidden},
+		{name: "delete with read key", method: http.MethodDelete, path: "/api/v1/storage/files/" + fileID, header: "ApiKey read-key", wantCode: http.StatusForbidden},
+		{name: "batch job with read key", method: http.MethodPost, path: "/api/v1/jobs/batch", header: "ApiKey read-key", wantCode: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantCode, w.Code)
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package grpcstatus
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestHTTPStatus(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{err: nil, want: http.StatusOK},
+		{err: status.Error(codes.InvalidArgument, "bad"), want: http.StatusBadRequest},
+		{err: status.Error(codes.Unauthenticated, "who"), want: http.StatusUnauthorized},
+		{err: status.Error(codes.PermissionDenied, "no"), want: http.StatusForbidden},
+		{err: status.Error(codes.NotFound, "missing"), want: http.StatusNotFound},
+		{err: status.Error(codes.AlreadyExists, "dup"), want: http.StatusConflict},
+		{err: status.Error(codes.FailedPrecondition, "state"), want: http.StatusPreconditionFailed},
+		{err: status.Error(codes.ResourceExhausted, "slow down"), want: http.StatusTooManyRequests},
+		{err: status.Error(codes.Unimplemented, "todo"), want: http.StatusNotImplemented},
+		{err: status.Error(codes.Unavailable, "down"), want: http.StatusServiceUnavailable},
+		{err: status.Error(codes.DeadlineExceeded, "late"), want: http.StatusGatewayTimeout},
+		{err: status.Error(codes.Internal, "boom"), want: http.StatusInternalServerError},
+		{err: errors.New("plain"), want: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		require.Equal(t, tt.want, HTTPStatus(tt.err), "error %v", tt.err)
+	}
+}
+
+func TestMessage(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "document not found", Message(status.Error(codes.NotFound, "document not found")))
+	require.Equal(t, "plain", Message(errors.New("plain")))
+}
+
+func TestFieldViolations(t *testing.T) {
+	t.Parallel()
+
+	st, err := status.New(codes.InvalidArgument, "weak password").WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: "password", Reason: "min_length", Description: "must be at least 8 characters long"},
+			{Field: "password", Reason: "digit", Description: "must contain a digit"},
+		},
+	})
+	require.NoError(t, err)
+
+	violations := FieldViolations(st.Err())
+	require.Len(t, violations, 2)
+	require.Equal(t, "password", violations[0].GetField())
+	require.Equal(t, "digit", violations[1].GetReason())
+
+	require.Nil(t, FieldViolations(status.Error(codes.InvalidArgument, "bad")))
+	require.Nil(t, FieldViolations(errors.New("plain")))
+}
+
+func TestRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	st, err := status.New(codes.ResourceExhausted, "too many failed login attempts").
+		WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(90 * time.Second)})
+	require.NoError(t, err)
+
+	retryAfter, ok := RetryAfter(st.Err())
+	require.True(t, ok)
+	require.Equal(t, 90*time.Second, retryAfter)
+
+	_, ok = RetryAfter(status.Error(codes.ResourceExhausted, "slow down"))
+	require.False(t, ok)
+	_, ok = RetryAfter(errors.New("plain"))
+	require.False(t, ok)
+}
@@ -1,11 +1,102 @@
 package storage
 
-import "gorm.io/gorm"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Trash defaults: trashed documents are kept for 30 days and the trash is
+// purged hourly.
+const (
+	DefaultTrashRetention     = 30 * 24 * time.Hour
+	DefaultTrashPurgeInterval = time.Hour
+)
+
+// Search defaults: new documents are indexed every 30 seconds, in English
+// unless their metadata sets another language.
+const (
+	DefaultSearchIndexInterval = 30 * time.Second
+	DefaultSearchLanguage      = "english"
+)
+
+// Batch defaults: new batch jobs are looked for every 10 seconds and run
+// four documents at a time.
+const (
+	DefaultBatchPollInterval = 10 * time.Second
+	DefaultBatchConcurrency  = 4
+)
+
+// Share link throttling defaults: wrong passwords lock a link after 10
+// attempts and a client address after 50, for a minute that doubles with
+// every further failure up to an hour.
+const (
+	DefaultShareLinkMaxFailures   = 10
+	DefaultShareLinkMaxIPFailures = 50
+	DefaultShareLinkLockout       = time.Minute
+	DefaultShareLinkMaxLockout    = time.Hour
+)
+
+// Supported object storage backends.
+const (
+	BackendS3     = "s3"
+	BackendLocal  = "local"
+	BackendMemory = "memory"
+)
 
 type Config struct {
 	DB   *gorm.DB `yaml:"-" json:"-"`
 	Port int      `yaml:"port" json:"port"`
 
+	// Backend selects where document content is stored: s3, local or memory.
+	Backend string `yaml:"backend" json:"backend"`
+	// LocalRoot is the directory used by the local backend.
+	LocalRoot string `yaml:"localRoot" json:"localRoot"`
+	// EncryptionKeyring is the path of the master key file. Document content
+	// is stored unencrypted when it is empty.
+	EncryptionKeyring string `yaml:"encryptionKeyring" json:"encryptionKeyring"`
+	// TrashRetention is how long trashed documents, and their content, are
+	// kept before being purged.
+	TrashRetention time.Duration `yaml:"trashRetention" json:"trashRetention"`
+	// TrashPurgeInterval is how often the trash is purged. The purger does
+	// not run when it is zero.
+	TrashPurgeInterval time.Duration `yaml:"trashPurgeInterval" json:"trashPurgeInterval"`
+	// SearchIndexInterval is how often the text of new documents is
+	// extracted for full-text search. The indexer does not run when it is
+	// zero.
+	SearchIndexInterval time.Duration `yaml:"searchIndexInterval" json:"searchIndexInterval"`
+	// SearchLanguage is the PostgreSQL text search configuration of documents
+	// whose metadata sets no language.
+	SearchLanguage string `yaml:"searchLanguage" json:"searchLanguage"`
+	// BatchPollInterval is how often batch formatting jobs are looked for.
+	// Batch jobs do not run when it is zero.
+	BatchPollInterval time.Duration `yaml:"batchPollInterval" json:"batchPollInterval"`
+	// BatchConcurrency is the number of documents of batch jobs formatted at
+	// a time.
+	BatchConcurrency int `yaml:"batchConcurrency" json:"batchConcurrency"`
+	// ShareLinkWatermark, ShareLinkHeader and ShareLinkFooter are stamped on
+	// DOCX and PDF documents downloaded through share links, along with the
+	// PNG or JPEG image at ShareLinkWatermarkImage. Downloads are left as
+	// they are when all are empty.
+	ShareLinkWatermark      string `yaml:"shareLinkWatermark" json:"shareLinkWatermark"`
+	ShareLinkWatermarkImage string `yaml:"shareLinkWatermarkImage" json:"shareLinkWatermarkImage"`
+	ShareLinkHeader         string `yaml:"shareLinkHeader" json:"shareLinkHeader"`
+	ShareLinkFooter         string `yaml:"shareLinkFooter" json:"shareLinkFooter"`
+	// ShareLinkMaxFailures and ShareLinkMaxIPFailures are the numbers of wrong
+	// passwords to a share link and from a client address after which they
+	// are locked, zero to never lock them. Failures are counted in memory,
+	// by each instance of the storage service.
+	ShareLinkMaxFailures   int `yaml:"shareLinkMaxFailures" json:"shareLinkMaxFailures"`
+	ShareLinkMaxIPFailures int `yaml:"shareLinkMaxIPFailures" json:"shareLinkMaxIPFailures"`
+	// ShareLinkLockout is how long the first lockout lasts, capped by
+	// ShareLinkMaxLockout.
+	ShareLinkLockout    time.Duration `yaml:"shareLinkLockout" json:"shareLinkLockout"`
+	ShareLinkMaxLockout time.Duration `yaml:"shareLinkMaxLockout" json:"shareLinkMaxLockout"`
+	// AuthService is the address of the authentication service, through
+	// which stamps name the owners of documents. Owners are shown by id
+	// when it is empty.
+	AuthService string `yaml:"authService" json:"authService"`
+
 	EndPoint        string `yaml:"endpoint" json:"endpoint"`
 	Region          string `yaml:"region" json:"region"`
 	AccessKeyID     string `yaml:"accessKeyID" json:"accessKeyID"`
@@ -16,12 +107,26 @@ type Config struct {
 
 func NewConfig() *Config {
 	return &Config{
-		Port:            8082,
-		EndPoint:        "",
-		Region:          "us-east-1",
-		AccessKeyID:     "",
-		AccessKeySecret: "",
-		Bucket:          "",
-		ForcePathStyle:  false,
+		Port:                8082,
+		Backend:             BackendS3,
+		LocalRoot:           "",
+		EncryptionKeyring:   "",
+		TrashRetention:      DefaultTrashRetention,
+		TrashPurgeInterval:  DefaultTrashPurgeInterval,
+		SearchIndexInterval: DefaultSearchIndexInterval,
+		SearchLanguage:      DefaultSearchLanguage,
+		BatchPollInterval:   DefaultBatchPollInterval,
+		BatchConcurrency:    DefaultBatchConcurrency,
+		EndPoint:            "",
+		Region:              "us-east-1",
+		AccessKeyID:         "",
+		AccessKeySecret:     "",
+		Bucket:              "",
+		ForcePathStyle:      false,
+
+		ShareLinkMaxFailures:   DefaultShareLinkMaxFailures,
+		ShareLinkMaxIPFailures: DefaultShareLinkMaxIPFailures,
+		ShareLinkLockout:       DefaultShareLinkLockout,
+		ShareLinkMaxLockout:    DefaultShareLinkMaxLockout,
 	}
 }
@@ -2,6 +2,7 @@ package storage
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -14,6 +15,13 @@ func TestNewConfig_HasExpectedDefaults(t *testing.T) {
 
 	require.Nil(t, cfg.DB)
 	require.Equal(t, 8082, cfg.Port)
+	require.Equal(t, BackendS3, cfg.Backend)
+	require.Equal(t, "", cfg.LocalRoot)
+	require.Equal(t, "", cfg.EncryptionKeyring)
+	require.Equal(t, 30*24*time.Hour, cfg.TrashRetention)
+	require.Equal(t, time.Hour, cfg.TrashPurgeInterval)
+	require.Equal(t, 30*time.Second, cfg.SearchIndexInterval)
+	require.Equal(t, "english", cfg.SearchLanguage)
 	require.Equal(t, "", cfg.EndPoint)
 	require.Equal(t, "us-east-1", cfg.Region)
 	require.Equal(t, "", cfg.AccessKeyID)
@@ -0,0 +1,37 @@
+package constant
+
+import "errors"
+
+var (
+	ErrDocumentNotFound     = errors.New("document not found")
+	ErrKeyringNotConfigured = errors.New("no encryption keyring is configured")
+	ErrFolderNotFound       = errors.New("folder not found")
+	ErrInvalidFolderName    = errors.New("invalid folder name")
+	ErrFolderNotEmpty       = errors.New("folder is not empty, delete it recursively to remove its content")
+	ErrFolderNameConflict   = errors.New("a folder with this name already exists here")
+	ErrFolderCycle          = errors.New("a folder cannot be moved into itself or one of its subfolders")
+	ErrInvalidTags          = errors.New("invalid tags")
+	ErrInvalidMetadata      = errors.New("invalid metadata")
+	ErrInvalidFilter        = errors.New("invalid filter")
+	ErrInvalidPage          = errors.New("invalid page request")
+	ErrInvalidPageToken     = errors.New("invalid page token")
+	ErrInvalidShare         = errors.New("invalid share")
+	ErrShareNotFound        = errors.New("share not found")
+	ErrPermissionDenied     = errors.New("permission denied")
+	ErrInvalidShareLink     = errors.New("invalid share link")
+	ErrShareLinkNotFound    = errors.New("share link not found")
+	ErrShareLinkPassword    = errors.New("share link password is missing or wrong")
+	ErrShareLinkExpired     = errors.New("share link expired")
+	ErrShareLinkRevoked     = errors.New("share link was revoked")
+	ErrDownloadLimitReached = errors.New("download limit of the share link reached")
+	ErrInvalidSearch        = errors.New("invalid search")
+	ErrUnsupportedFormat    = errors.New("operation not supported for this document format")
+	ErrMalformedDocument    = errors.New("document is malformed")
+	ErrInvalidDiff          = errors.New("invalid diff request")
+	ErrInvalidMerge         = errors.New("invalid merge request")
+	ErrInvalidSplit         = errors.New("invalid split request")
+	ErrInvalidStamp         = errors.New("invalid stamp")
+	ErrEncryptedDocument    = errors.New("encrypted documents cannot be stamped")
+	ErrInvalidBatchJob      = errors.New("invalid batch job")
+	ErrBatchJobNotFound     = errors.New("batch job not found")
+)
@@ -0,0 +1,91 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Role is the access a user has to a document or folder. Each role allows
+// everything the roles before it allow.
+type Role string
+
+const (
+	RoleViewer    Role = "viewer"
+	RoleCommenter Role = "commenter"
+	RoleEditor    Role = "editor"
+	// RoleOwner is the role of the user a document or folder belongs to. It
+	// cannot be granted.
+	RoleOwner Role = "owner"
+)
+
+var roleRanks = map[Role]int{
+	RoleViewer:    1,
+	RoleCommenter: 2,
+	RoleEditor:    3,
+	RoleOwner:     4,
+}
+
+// Validate checks that r is a role that can be granted.
+func (r Role) Validate() error {
+	if r == RoleOwner {
+		return errors.New("the owner role cannot be granted")
+	}
+	if _, ok := roleRanks[r]; !ok {
+		return errors.New("unknown role")
+	}
+	return nil
+}
+
+// Allows reports whether r gives at least the access of required. No role
+// allows nothing.
+func (r Role) Allows(required Role) bool {
+	rank, ok := roleRanks[r]
+	return ok && rank >= roleRanks[required]
+}
+
+// ResourceType is the kind of resource an ACL entry grants access to.
+type ResourceType string
+
+const (
+	ResourceDocument ResourceType = "document"
+	// ResourceFolder grants access to the folder and everything it holds.
+	ResourceFolder ResourceType = "folder"
+)
+
+func (t ResourceType) Validate() error {
+	if t != ResourceDocument && t != ResourceFolder {
+		return errors.New("unknown resource type")
+	}
+	return nil
+}
+
+// ACLEntry grants a user a role on a document or folder of another user.
+type ACLEntry struct {
+	ID           uuid.UUID    `yaml:"id" json:"id"`
+	ResourceType ResourceType `yaml:"resourceType" json:"resourceType"`
+	ResourceID   uuid.UUID    `yaml:"resourceID" json:"resourceID"`
+	// OwnerID is the user the resource belongs to.
+	OwnerID uuid.UUID `yaml:"ownerID" json:"ownerID"`
+	// UserID is the user the role is granted to.
+	UserID    uuid.UUID `yaml:"userID" json:"userID"`
+	Role      Role      `yaml:"role" json:"role"`
+	CreatedAt time.Time `yaml:"createdAt" json:"createdAt"`
+}
+
+func (e *ACLEntry) Validate() error {
+	if err := e.ResourceType.Validate(); err != nil {
+		return err
+	}
+	if e.ResourceID == uuid.Nil {
+		return errors.New("resource id is required")
+	}
+	if e.OwnerID == uuid.Nil || e.UserID == uuid.Nil {
+		return errors.New("owner and user ids are required")
+	}
+	if e.OwnerID == e.UserID {
+		return errors.New("a resource cannot be shared with its owner")
+	}
+	return e.Role.Validate()
+}
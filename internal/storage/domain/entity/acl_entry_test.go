@@ -0,0 +1,60 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRole_Allows(t *testing.T) {
+	t.Parallel()
+
+	require.True(t, RoleEditor.Allows(RoleViewer))
+	require.True(t, RoleCommenter.Allows(RoleCommenter))
+	require.True(t, RoleOwner.Allows(RoleEditor))
+	require.False(t, RoleViewer.Allows(RoleCommenter))
+	require.False(t, RoleEditor.Allows(RoleOwner))
+	require.False(t, Role("").Allows(RoleViewer))
+	require.False(t, Role("admin").Allows(RoleViewer))
+}
+
+func TestRole_Validate(t *testing.T) {
+	t.Parallel()
+
+	for _, role := range []Role{RoleViewer, RoleCommenter, RoleEditor} {
+		require.NoError(t, role.Validate())
+	}
+	require.ErrorContains(t, RoleOwner.Validate(), "cannot be granted")
+	require.ErrorContains(t, Role("admin").Validate(), "unknown role")
+}
+
+func TestACLEntry_Validate(t *testing.T) {
+	t.Parallel()
+
+	ownerID := uuid.New()
+	valid := ACLEntry{
+		ResourceType: ResourceFolder,
+		ResourceID:   uuid.New(),
+		OwnerID:      ownerID,
+		UserID:       uuid.New(),
+		Role:         RoleEditor,
+	}
+	require.NoError(t, valid.Validate())
+
+	entry := valid
+	entry.ResourceType = "workspace"
+	require.ErrorContains(t, entry.Validate(), "unknown resource type")
+
+	entry = valid
+	entry.ResourceID = uuid.Nil
+	require.ErrorContains(t, entry.Validate(), "resource id is required")
+
+	entry = valid
+	entry.UserID = ownerID
+	require.ErrorContains(t, entry.Validate(), "shared with its owner")
+
+	entry = valid
+	entry.Role = RoleOwner
+	require.Error(t, entry.Validate())
+}
@@ -0,0 +1,151 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/a1y/doc-formatter/internal/storage/util/docstyle"
+	"github.com/google/uuid"
+)
+
+// MaxBatchDocuments is the number of documents a batch job formats at most.
+const MaxBatchDocuments = 1000
+
+// BatchJobStatus tells whether a batch job is done.
+type BatchJobStatus string
+
+const (
+	BatchJobRunning BatchJobStatus = "running"
+	// BatchJobCompleted jobs have tried every document, whether they
+	// succeeded or not, and built their archive when asked to.
+	BatchJobCompleted BatchJobStatus = "completed"
+)
+
+// BatchItemStatus tells where the formatting of a document of a batch job
+// stands.
+type BatchItemStatus string
+
+const (
+	BatchItemPending   BatchItemStatus = "pending"
+	BatchItemRunning   BatchItemStatus = "running"
+	BatchItemSucceeded BatchItemStatus = "succeeded"
+	BatchItemFailed    BatchItemStatus = "failed"
+)
+
+// BatchJob formats many documents of a user with one style profile. Each
+// document is an item of the job, formatted on its own into a new document.
+type BatchJob struct {
+	ID     uuid.UUID      `yaml:"id" json:"id"`
+	UserID uuid.UUID      `yaml:"userID" json:"userID"`
+	Status BatchJobStatus `yaml:"status" json:"status"`
+	// FolderID is the folder whose documents the job formats, including
+	// those of its subfolders when Recursive is set. Tag is the tag of the
+	// documents the job formats. A job with neither formats a list of
+	// documents.
+	FolderID  *uuid.UUID       `yaml:"folderID" json:"folderID"`
+	Recursive bool             `yaml:"recursive" json:"recursive"`
+	Tag       string           `yaml:"tag" json:"tag"`
+	Profile   docstyle.Profile `yaml:"profile" json:"profile"`
+	// OutputFolderID is the folder of the formatted documents, nil for the
+	// top level.
+	OutputFolderID *uuid.UUID `yaml:"outputFolderID" json:"outputFolderID"`
+	// Archive asks for a ZIP archive of the formatted documents, stored as
+	// the document ArchiveID once the job completes.
+	Archive   bool       `yaml:"archive" json:"archive"`
+	ArchiveID *uuid.UUID `yaml:"archiveID" json:"archiveID"`
+	// Error tells why a completed job asked for an archive has none.
+	Error      string     `yaml:"error" json:"error"`
+	CreatedAt  time.Time  `yaml:"createdAt" json:"createdAt"`
+	FinishedAt *time.Time `yaml:"finishedAt" json:"finishedAt"`
+}
+
+func (j *BatchJob) Validate() error {
+	if j.UserID == uuid.Nil {
+		return errors.New("user id is required")
+	}
+	if j.Status != BatchJobRunning && j.Status != BatchJobCompleted {
+		return fmt.Errorf("unknown status %q", j.Status)
+	}
+	if j.FolderID != nil && j.Tag != "" {
+		return errors.New("a job formats a folder or the documents of a tag, not both")
+	}
+	if j.Recursive && j.FolderID == nil {
+		return errors.New("only folders can be formatted recursively")
+	}
+	if j.Tag != "" {
+		if err := ValidateTag(j.Tag); err != nil {
+			return err
+		}
+	}
+	return j.Profile.Validate()
+}
+
+// BatchJobItem is a document of a batch job and the outcome of its
+// formatting.
+type BatchJobItem struct {
+	ID         uuid.UUID `yaml:"id" json:"id"`
+	JobID      uuid.UUID `yaml:"jobID" json:"jobID"`
+	DocumentID uuid.UUID `yaml:"documentID" json:"documentID"`
+	// FileName is the name of the document when the job was created, kept
+	// for the report should the document go.
+	FileName string `yaml:"fileName" json:"fileName"`
+	// Position is the place of the document in the job, from 0.
+	Position int             `yaml:"position" json:"position"`
+	Status   BatchItemStatus `yaml:"status" json:"status"`
+	// OutputID is the formatted document of a succeeded item.
+	OutputID *uuid.UUID `yaml:"outputID" json:"outputID"`
+	// Error tells why a failed item failed.
+	Error string `yaml:"error" json:"error"`
+	// Warnings tell what the formatting of a succeeded item could not
+	// change.
+	Warnings  []string  `yaml:"warnings" json:"warnings"`
+	UpdatedAt time.Time `yaml:"updatedAt" json:"updatedAt"`
+}
+
+func (i *BatchJobItem) Validate() error {
+	if i.DocumentID == uuid.Nil {
+		return errors.New("document id is required")
+	}
+	switch i.Status {
+	case BatchItemPending, BatchItemRunning, BatchItemSucceeded, BatchItemFailed:
+	default:
+		return fmt.Errorf("unknown status %q", i.Status)
+	}
+	if i.Position < 0 {
+		return errors.New("position must not be negative")
+	}
+	return nil
+}
+
+// Done reports whether the item succeeded or failed.
+func (i *BatchJobItem) Done() bool {
+	return i.Status == BatchItemSucceeded || i.Status == BatchItemFailed
+}
+
+// BatchProgress counts the items of a batch job by status.
+type BatchProgress struct {
+	Total     int `yaml:"total" json:"total"`
+	Pending   int `yaml:"pending" json:"pending"`
+	Running   int `yaml:"running" json:"running"`
+	Succeeded int `yaml:"succeeded" json:"succeeded"`
+	Failed    int `yaml:"failed" json:"failed"`
+}
+
+// NewBatchProgress returns the progress of a batch job of items.
+func NewBatchProgress(items []*BatchJobItem) BatchProgress {
+	progress := BatchProgress{Total: len(items)}
+	for _, item := range items {
+		switch item.Status {
+		case BatchItemPending:
+			progress.Pending++
+		case BatchItemRunning:
+			progress.Running++
+		case BatchItemSucceeded:
+			progress.Succeeded++
+		case BatchItemFailed:
+			progress.Failed++
+		}
+	}
+	return progress
+}
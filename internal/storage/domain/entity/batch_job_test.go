@@ -0,0 +1,62 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/a1y/doc-formatter/internal/storage/util/docstyle"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchJob_Validate(t *testing.T) {
+	valid := func() *BatchJob {
+		folderID := uuid.New()
+		return &BatchJob{UserID: uuid.New(), Status: BatchJobRunning, FolderID: &folderID, Recursive: true, Profile: docstyle.Profile{FontSize: 12}}
+	}
+	require.NoError(t, valid().Validate())
+
+	tests := map[string]func(j *BatchJob){
+		"no user":         func(j *BatchJob) { j.UserID = uuid.Nil },
+		"unknown status":  func(j *BatchJob) { j.Status = "done" },
+		"folder and tag":  func(j *BatchJob) { j.Tag = "draft" },
+		"recursive list":  func(j *BatchJob) { j.FolderID = nil },
+		"invalid tag":     func(j *BatchJob) { j.FolderID, j.Recursive, j.Tag = nil, false, " draft" },
+		"invalid profile": func(j *BatchJob) { j.Profile.Margin = -1 },
+	}
+	for name, mutate := range tests {
+		j := valid()
+		mutate(j)
+		require.Error(t, j.Validate(), name)
+	}
+}
+
+func TestBatchJobItem_Validate(t *testing.T) {
+	valid := func() *BatchJobItem {
+		return &BatchJobItem{DocumentID: uuid.New(), Status: BatchItemPending}
+	}
+	require.NoError(t, valid().Validate())
+
+	tests := map[string]func(i *BatchJobItem){
+		"no document":       func(i *BatchJobItem) { i.DocumentID = uuid.Nil },
+		"unknown status":    func(i *BatchJobItem) { i.Status = "skipped" },
+		"negative position": func(i *BatchJobItem) { i.Position = -1 },
+	}
+	for name, mutate := range tests {
+		i := valid()
+		mutate(i)
+		require.Error(t, i.Validate(), name)
+	}
+}
+
+func TestNewBatchProgress(t *testing.T) {
+	items := []*BatchJobItem{
+		{Status: BatchItemPending},
+		{Status: BatchItemRunning},
+		{Status: BatchItemSucceeded},
+		{Status: BatchItemSucceeded},
+		{Status: BatchItemFailed},
+	}
+	require.Equal(t, BatchProgress{Total: 5, Pending: 1, Running: 1, Succeeded: 2, Failed: 1}, NewBatchProgress(items))
+	require.True(t, items[2].Done())
+	require.False(t, items[1].Done())
+}
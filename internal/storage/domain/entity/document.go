@@ -2,16 +2,54 @@ package entity
 
 import (
 	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
 
 	"github.com/google/uuid"
 )
 
+const (
+	// MaxTags is the maximum number of tags of a document.
+	MaxTags = 50
+	// MaxTagLength is the maximum length in bytes of a tag.
+	MaxTagLength = 128
+	// MaxMetadataEntries is the maximum number of metadata keys of a
+	// document.
+	MaxMetadataEntries = 50
+	// MaxMetadataKeyLength and MaxMetadataValueLength are the maximum lengths
+	// in bytes of a metadata key and value.
+	MaxMetadataKeyLength   = 128
+	MaxMetadataValueLength = 1024
+)
+
 type Document struct {
 	ID        uuid.UUID `yaml:"id" json:"id"`
 	UserID    uuid.UUID `yaml:"userID" json:"userID"`
 	FileName  string    `yaml:"fileName" json:"fileName"`
 	FileSize  int64     `yaml:"fileSize" json:"fileSize"`
 	ObjectKey string    `yaml:"objectKey" json:"objectKey"`
+	// FolderID is nil for top-level documents.
+	FolderID    *uuid.UUID        `yaml:"folderID,omitempty" json:"folderID,omitempty"`
+	ContentType string            `yaml:"contentType" json:"contentType"`
+	Tags        []string          `yaml:"tags,omitempty" json:"tags,omitempty"`
+	Metadata    map[string]string `yaml:"metadata,omitempty" json:"metadata,omitempty"`
+	CreatedAt   time.Time         `yaml:"createdAt" json:"createdAt"`
+	UpdatedAt   time.Time         `yaml:"updatedAt" json:"updatedAt"`
+	// DeletedAt is set while the document is in the trash.
+	DeletedAt *time.Time `yaml:"deletedAt,omitempty" json:"deletedAt,omitempty"`
+	// MasterKeyID, WrappedKey and Nonce are set when the content is stored
+	// encrypted: the data key is sealed by the named master key and the nonce
+	// seeds the content encryption.
+	MasterKeyID string `yaml:"masterKeyID,omitempty" json:"-"`
+	WrappedKey  []byte `yaml:"-" json:"-"`
+	Nonce       []byte `yaml:"-" json:"-"`
+}
+
+// IsEncrypted reports whether the document content is stored encrypted.
+func (d *Document) IsEncrypted() bool {
+	return len(d.WrappedKey) > 0
 }
 
 func (d *Document) Validate() error {
@@ -24,5 +62,68 @@ func (d *Document) Validate() error {
 	if d.ObjectKey == "" {
 		return errors.New("object key is required")
 	}
+	if d.IsEncrypted() && (d.MasterKeyID == "" || len(d.Nonce) == 0) {
+		return errors.New("master key id and nonce are required for encrypted documents")
+	}
+	if err := ValidateTags(d.Tags); err != nil {
+		return err
+	}
+	return ValidateMetadata(d.Metadata)
+}
+
+// ValidateTags checks that tags can be set on a document.
+func ValidateTags(tags []string) error {
+	if len(tags) > MaxTags {
+		return fmt.Errorf("a document can have at most %d tags", MaxTags)
+	}
+	for _, tag := range tags {
+		if err := ValidateTag(tag); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateTag checks that tag can be used as a tag, such as "client:acme".
+func ValidateTag(tag string) error {
+	if tag == "" {
+		return errors.New("tag is required")
+	}
+	if len(tag) > MaxTagLength {
+		return fmt.Errorf("tag %q is too long", tag)
+	}
+	if strings.TrimSpace(tag) != tag || strings.IndexFunc(tag, unicode.IsControl) >= 0 {
+		return fmt.Errorf("tag %q must not start or end with spaces or contain control characters", tag)
+	}
+	return nil
+}
+
+// ValidateMetadata checks that metadata can be set on a document.
+func ValidateMetadata(metadata map[string]string) error {
+	if len(metadata) > MaxMetadataEntries {
+		return fmt.Errorf("a document can have at most %d metadata keys", MaxMetadataEntries)
+	}
+	for key, value := range metadata {
+		if err := ValidateMetadataKey(key); err != nil {
+			return err
+		}
+		if len(value) > MaxMetadataValueLength {
+			return fmt.Errorf("value of metadata key %q is too long", key)
+		}
+	}
+	return nil
+}
+
+// ValidateMetadataKey checks that key can be used as a metadata key.
+func ValidateMetadataKey(key string) error {
+	if key == "" {
+		return errors.New("metadata key is required")
+	}
+	if len(key) > MaxMetadataKeyLength {
+		return fmt.Errorf("metadata key %q is too long", key)
+	}
+	if strings.TrimSpace(key) != key || strings.IndexFunc(key, unicode.IsControl) >= 0 {
+		return fmt.Errorf("metadata key %q must not start or end with spaces or contain control characters", key)
+	}
 	return nil
 }
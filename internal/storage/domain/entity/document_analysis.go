@@ -0,0 +1,40 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"github.com/a1y/doc-formatter/internal/storage/util/docanalysis"
+	"github.com/google/uuid"
+)
+
+// DocumentAnalysis is the structure of a document, kept for the content it
+// was computed from. Content is never replaced in place, so the object key
+// identifies the version of the document.
+type DocumentAnalysis struct {
+	DocumentID uuid.UUID `yaml:"documentID" json:"documentID"`
+	ObjectKey  string    `yaml:"objectKey" json:"objectKey"`
+	// Version is the docanalysis.Version the analysis was made with.
+	Version    int                   `yaml:"version" json:"version"`
+	Analysis   *docanalysis.Analysis `yaml:"analysis" json:"analysis"`
+	AnalyzedAt time.Time             `yaml:"analyzedAt" json:"analyzedAt"`
+}
+
+func (a *DocumentAnalysis) Validate() error {
+	if a.DocumentID == uuid.Nil {
+		return errors.New("document id is required")
+	}
+	if a.ObjectKey == "" {
+		return errors.New("object key is required")
+	}
+	if a.Analysis == nil {
+		return errors.New("analysis is required")
+	}
+	return nil
+}
+
+// IsCurrent reports whether the analysis was made from the current content
+// of document by the current analyzer.
+func (a *DocumentAnalysis) IsCurrent(document *Document) bool {
+	return a.DocumentID == document.ID && a.ObjectKey == document.ObjectKey && a.Version == docanalysis.Version
+}
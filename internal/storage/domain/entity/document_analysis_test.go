@@ -0,0 +1,33 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/a1y/doc-formatter/internal/storage/util/docanalysis"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocumentAnalysis_Validate(t *testing.T) {
+	analysis := &DocumentAnalysis{DocumentID: uuid.New(), ObjectKey: "key", Analysis: &docanalysis.Analysis{}}
+	require.NoError(t, analysis.Validate())
+
+	analysis.ObjectKey = ""
+	require.Error(t, analysis.Validate())
+
+	analysis = &DocumentAnalysis{DocumentID: uuid.New(), ObjectKey: "key"}
+	require.Error(t, analysis.Validate())
+}
+
+func TestDocumentAnalysis_IsCurrent(t *testing.T) {
+	document := &Document{ID: uuid.New(), ObjectKey: "key"}
+	analysis := &DocumentAnalysis{DocumentID: document.ID, ObjectKey: "key", Version: docanalysis.Version}
+	require.True(t, analysis.IsCurrent(document))
+
+	analysis.Version--
+	require.False(t, analysis.IsCurrent(document))
+
+	analysis.Version = docanalysis.Version
+	document.ObjectKey = "other"
+	require.False(t, analysis.IsCurrent(document))
+}
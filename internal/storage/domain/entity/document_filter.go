@@ -0,0 +1,73 @@
+package entity
+
+import (
+	"errors"
+	"mime"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DocumentFilter narrows a listing of the documents of a user. Every set
+// field must match, unset fields match every document.
+type DocumentFilter struct {
+	// FolderID restricts the listing to the documents directly inside the
+	// folder. Documents of every folder are listed when nil.
+	FolderID *uuid.UUID
+	// Tags lists tags that documents must all carry.
+	Tags []string
+	// Metadata holds keys that documents must all hold with the given value.
+	Metadata map[string]string
+	// ContentType is an exact type such as "application/pdf" or a wildcard
+	// such as "image/*".
+	ContentType string
+	// MinSize and MaxSize bound the size in bytes, both inclusive.
+	MinSize *int64
+	MaxSize *int64
+	// CreatedAfter and CreatedBefore bound the creation time, both inclusive.
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+}
+
+func (f *DocumentFilter) Validate() error {
+	for _, tag := range f.Tags {
+		if err := ValidateTag(tag); err != nil {
+			return err
+		}
+	}
+	for key := range f.Metadata {
+		if err := ValidateMetadataKey(key); err != nil {
+			return err
+		}
+	}
+	if f.ContentType != "" {
+		if err := validateContentTypePattern(f.ContentType); err != nil {
+			return err
+		}
+	}
+	if (f.MinSize != nil && *f.MinSize < 0) || (f.MaxSize != nil && *f.MaxSize < 0) {
+		return errors.New("size bounds must not be negative")
+	}
+	if f.MinSize != nil && f.MaxSize != nil && *f.MinSize > *f.MaxSize {
+		return errors.New("minimum size is greater than maximum size")
+	}
+	if f.CreatedAfter != nil && f.CreatedBefore != nil && f.CreatedAfter.After(*f.CreatedBefore) {
+		return errors.New("created after is later than created before")
+	}
+	return nil
+}
+
+// validateContentTypePattern checks that pattern is a media type without
+// parameters, in lower case, where the subtype may be a "*" wildcard.
+func validateContentTypePattern(pattern string) error {
+	mediaType, params, err := mime.ParseMediaType(pattern)
+	if err != nil || len(params) > 0 || mediaType != pattern {
+		return errors.New("content type must be a media type such as application/pdf")
+	}
+	typ, subtype, ok := strings.Cut(mediaType, "/")
+	if !ok || typ == "*" || (strings.Contains(subtype, "*") && subtype != "*") {
+		return errors.New("content type must be a media type such as application/pdf or image/*")
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocumentFilter_Validate(t *testing.T) {
+	t.Parallel()
+
+	size := func(n int64) *int64 { return &n }
+	now := time.Now()
+	earlier := now.Add(-time.Hour)
+
+	valid := []DocumentFilter{
+		{},
+		{Tags: []string{"client:acme"}, Metadata: map[string]string{"owner": "legal"}},
+		{ContentType: "application/pdf"},
+		{ContentType: "image/*"},
+		{MinSize: size(0), MaxSize: size(0)},
+		{CreatedAfter: &earlier, CreatedBefore: &now},
+	}
+	for _, f := range valid {
+		require.NoError(t, f.Validate(), "filter %+v", f)
+	}
+
+	invalid := []struct {
+		filter DocumentFilter
+		msg    string
+	}{
+		{filter: DocumentFilter{Tags: []string{""}}, msg: "tag is required"},
+		{filter: DocumentFilter{Metadata: map[string]string{"": "v"}}, msg: "metadata key is required"},
+		{filter: DocumentFilter{ContentType: "pdf"}, msg: "media type"},
+		{filter: DocumentFilter{ContentType: "text/plain; charset=utf-8"}, msg: "media type"},
+		{filter: DocumentFilter{ContentType: "*/*"}, msg: "image/*"},
+		{filter: DocumentFilter{ContentType: "image/p*"}, msg: "image/*"},
+		{filter: DocumentFilter{MinSize: size(-1)}, msg: "must not be negative"},
+		{filter: DocumentFilter{MinSize: size(10), MaxSize: size(5)}, msg: "greater than maximum"},
+		{filter: DocumentFilter{CreatedAfter: &now, CreatedBefore: &earlier}, msg: "later than created before"},
+	}
+	for _, tt := range invalid {
+		require.ErrorContains(t, tt.filter.Validate(), tt.msg, "filter %+v", tt.filter)
+	}
+}
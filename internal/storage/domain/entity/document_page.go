@@ -0,0 +1,82 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultPageSize is the page size of a listing that does not ask for
+	// one.
+	DefaultPageSize = 100
+	// MaxPageSize is the largest page size of a listing.
+	MaxPageSize = 1000
+)
+
+// DocumentSort is the field a listing of documents is ordered by. Documents
+// with equal values are ordered by ID, so that the order is total.
+type DocumentSort string
+
+const (
+	SortByName    DocumentSort = "name"
+	SortBySize    DocumentSort = "size"
+	SortByCreated DocumentSort = "created"
+	SortByUpdated DocumentSort = "updated"
+	// SortByDeleted orders the trash, where every document has a deletion
+	// time.
+	SortByDeleted DocumentSort = "deleted"
+)
+
+func (s DocumentSort) Validate() error {
+	switch s {
+	case SortByName, SortBySize, SortByCreated, SortByUpdated, SortByDeleted:
+		return nil
+	default:
+		return fmt.Errorf("unknown sort field %q", string(s))
+	}
+}
+
+// Key returns the value of the sort field of d.
+func (s DocumentSort) Key(d *Document) any {
+	switch s {
+	case SortBySize:
+		return d.FileSize
+	case SortByCreated:
+		return d.CreatedAt
+	case SortByUpdated:
+		return d.UpdatedAt
+	case SortByDeleted:
+		if d.DeletedAt == nil {
+			return nil
+		}
+		return *d.DeletedAt
+	default:
+		return d.FileName
+	}
+}
+
+// DocumentPage selects a page of a listing of documents.
+type DocumentPage struct {
+	Sort       DocumentSort
+	Descending bool
+	// Size is the maximum number of documents of the page.
+	Size int
+	// After is the last document of the previous page, nil for the first
+	// page. Only its ID and the field named by Sort are read.
+	After *Document
+}
+
+func (p *DocumentPage) Validate() error {
+	if err := p.Sort.Validate(); err != nil {
+		return err
+	}
+	if p.Size < 1 || p.Size > MaxPageSize {
+		return fmt.Errorf("page size must be between 1 and %d", MaxPageSize)
+	}
+	if p.After != nil && p.After.ID == uuid.Nil {
+		return errors.New("page position has no document id")
+	}
+	return nil
+}
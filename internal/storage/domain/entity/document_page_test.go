@@ -0,0 +1,55 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocumentPage_Validate(t *testing.T) {
+	t.Parallel()
+
+	valid := []DocumentPage{
+		{Sort: SortByName, Size: 1},
+		{Sort: SortBySize, Size: MaxPageSize, Descending: true},
+		{Sort: SortByCreated, Size: 10, After: &Document{ID: uuid.New()}},
+		{Sort: SortByUpdated, Size: 10},
+		{Sort: SortByDeleted, Size: 10},
+	}
+	for _, p := range valid {
+		require.NoError(t, p.Validate(), "page %+v", p)
+	}
+
+	invalid := []struct {
+		page DocumentPage
+		msg  string
+	}{
+		{page: DocumentPage{Sort: "rating", Size: 10}, msg: "unknown sort field"},
+		{page: DocumentPage{Sort: SortByName}, msg: "page size"},
+		{page: DocumentPage{Sort: SortByName, Size: MaxPageSize + 1}, msg: "page size"},
+		{page: DocumentPage{Sort: SortByName, Size: 10, After: &Document{}}, msg: "document id"},
+	}
+	for _, tt := range invalid {
+		err := tt.page.Validate()
+		require.Error(t, err, "page %+v", tt.page)
+		require.Contains(t, err.Error(), tt.msg)
+	}
+}
+
+func TestDocumentSort_Key(t *testing.T) {
+	t.Parallel()
+
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	updated := created.Add(time.Hour)
+	d := &Document{FileName: "a.txt", FileSize: 42, CreatedAt: created, UpdatedAt: updated}
+	require.Nil(t, SortByDeleted.Key(d))
+	d.DeletedAt = &updated
+
+	require.Equal(t, "a.txt", SortByName.Key(d))
+	require.Equal(t, int64(42), SortBySize.Key(d))
+	require.Equal(t, created, SortByCreated.Key(d))
+	require.Equal(t, updated, SortByUpdated.Key(d))
+	require.Equal(t, updated, SortByDeleted.Key(d))
+}
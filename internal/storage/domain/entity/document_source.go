@@ -0,0 +1,70 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Operations a document can be made from other documents with.
+const (
+	// OperationMerge makes a document of the content of several.
+	OperationMerge = "merge"
+	// OperationSplit makes documents of the parts of one, cut at its
+	// headings.
+	OperationSplit = "split"
+	// OperationFormat makes a document of another formatted with a style
+	// profile.
+	OperationFormat = "format"
+)
+
+// DocumentSource records that a document was made from another by an
+// operation. Links are kept while either document is in the trash, and go
+// when one of them is purged.
+type DocumentSource struct {
+	ID         uuid.UUID `yaml:"id" json:"id"`
+	DocumentID uuid.UUID `yaml:"documentID" json:"documentID"`
+	SourceID   uuid.UUID `yaml:"sourceID" json:"sourceID"`
+	Operation  string    `yaml:"operation" json:"operation"`
+	// Position is the place of the source among those merged, or of the
+	// document among the parts its source was split into, from 0.
+	Position  int       `yaml:"position" json:"position"`
+	CreatedAt time.Time `yaml:"createdAt" json:"createdAt"`
+}
+
+func (s *DocumentSource) Validate() error {
+	if s.DocumentID == uuid.Nil {
+		return errors.New("document id is required")
+	}
+	if s.SourceID == uuid.Nil {
+		return errors.New("source id is required")
+	}
+	if s.DocumentID == s.SourceID {
+		return errors.New("a document cannot be its own source")
+	}
+	switch s.Operation {
+	case OperationMerge, OperationSplit, OperationFormat:
+	default:
+		return fmt.Errorf("unknown operation %q", s.Operation)
+	}
+	if s.Position < 0 {
+		return errors.New("position must not be negative")
+	}
+	return nil
+}
+
+// Provenance tells which documents a document was made from and which were
+// made from it.
+type Provenance struct {
+	Sources []*ProvenanceLink
+	Derived []*ProvenanceLink
+}
+
+// ProvenanceLink is a link of provenance along with the document at its
+// other end.
+type ProvenanceLink struct {
+	Link     *DocumentSource
+	Document *Document
+}
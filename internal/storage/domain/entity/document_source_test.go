@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocumentSource_Validate(t *testing.T) {
+	valid := func() *DocumentSource {
+		return &DocumentSource{DocumentID: uuid.New(), SourceID: uuid.New(), Operation: OperationMerge, Position: 1}
+	}
+	require.NoError(t, valid().Validate())
+
+	tests := map[string]func(s *DocumentSource){
+		"no document":       func(s *DocumentSource) { s.DocumentID = uuid.Nil },
+		"no source":         func(s *DocumentSource) { s.SourceID = uuid.Nil },
+		"own source":        func(s *DocumentSource) { s.SourceID = s.DocumentID },
+		"unknown operation": func(s *DocumentSource) { s.Operation = "copy" },
+		"negative position": func(s *DocumentSource) { s.Position = -1 },
+	}
+	for name, mutate := range tests {
+		s := valid()
+		mutate(s)
+		require.Error(t, s.Validate(), name)
+	}
+}
@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/google/uuid"
@@ -59,3 +60,74 @@ func TestDocument_Validate_MissingObjectKey(t *testing.T) {
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "object key is required")
 }
+
+func TestDocument_Validate_EncryptedRequiresKeyMaterial(t *testing.T) {
+	t.Parallel()
+
+	d := &Document{
+		UserID:     uuid.New(),
+		FileName:   "file.txt",
+		ObjectKey:  "file.txt",
+		WrappedKey: []byte("wrapped"),
+	}
+
+	require.True(t, d.IsEncrypted())
+	err := d.Validate()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "master key id and nonce are required")
+
+	d.MasterKeyID = "k1"
+	d.Nonce = []byte("nonce")
+	require.NoError(t, d.Validate())
+}
+
+func TestDocument_Validate_Labels(t *testing.T) {
+	t.Parallel()
+
+	d := &Document{
+		UserID:    uuid.New(),
+		FileName:  "file.txt",
+		ObjectKey: "file.txt",
+		Tags:      []string{"client:acme", "status:draft"},
+		Metadata:  map[string]string{"owner": "legal"},
+	}
+	require.NoError(t, d.Validate())
+
+	d.Tags = append(d.Tags, " padded")
+	require.ErrorContains(t, d.Validate(), "must not start or end with spaces")
+
+	d.Tags = nil
+	d.Metadata[""] = "value"
+	require.ErrorContains(t, d.Validate(), "metadata key is required")
+}
+
+func TestValidateTags(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, ValidateTags(nil))
+	require.NoError(t, ValidateTags([]string{"client:acme", "needs review"}))
+	require.ErrorContains(t, ValidateTags([]string{""}), "tag is required")
+	require.ErrorContains(t, ValidateTags([]string{strings.Repeat("a", MaxTagLength+1)}), "too long")
+	require.ErrorContains(t, ValidateTags([]string{"line\nbreak"}), "control characters")
+
+	tooMany := make([]string, MaxTags+1)
+	for i := range tooMany {
+		tooMany[i] = strings.Repeat("t", i+1)
+	}
+	require.ErrorContains(t, ValidateTags(tooMany), "at most")
+}
+
+func TestValidateMetadata(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, ValidateMetadata(map[string]string{"owner": "legal", "empty": ""}))
+	require.ErrorContains(t, ValidateMetadata(map[string]string{"owner ": "legal"}), "must not start or end with spaces")
+	require.ErrorContains(t, ValidateMetadata(map[string]string{strings.Repeat("k", MaxMetadataKeyLength+1): "v"}), "too long")
+	require.ErrorContains(t, ValidateMetadata(map[string]string{"owner": strings.Repeat("v", MaxMetadataValueLength+1)}), "too long")
+
+	tooMany := make(map[string]string, MaxMetadataEntries+1)
+	for i := 0; i <= MaxMetadataEntries; i++ {
+		tooMany[strings.Repeat("k", i+1)] = "v"
+	}
+	require.ErrorContains(t, ValidateMetadata(tooMany), "at most")
+}
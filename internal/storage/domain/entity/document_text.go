@@ -0,0 +1,126 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// LanguageMetadataKey is the metadata key setting the language a document is
+// indexed in, as an ISO 639-1 code such as "fr".
+const LanguageMetadataKey = "language"
+
+// SimpleTextSearch is the text search configuration of text in no particular
+// language: words are lowercased but not stemmed.
+const SimpleTextSearch = "simple"
+
+// TextSearchConfigs maps the languages documents can be indexed in, by ISO
+// 639-1 code, to the PostgreSQL text search configuration for them.
+var TextSearchConfigs = map[string]string{
+	"da": "danish",
+	"de": "german",
+	"en": "english",
+	"es": "spanish",
+	"fi": "finnish",
+	"fr": "french",
+	"hu": "hungarian",
+	"it": "italian",
+	"nl": "dutch",
+	"no": "norwegian",
+	"pt": "portuguese",
+	"ro": "romanian",
+	"ru": "russian",
+	"sv": "swedish",
+	"tr": "turkish",
+}
+
+// TextSearchConfig returns the text search configuration of language, given
+// either as an ISO 639-1 code or as the name of the configuration.
+func TextSearchConfig(language string) (string, bool) {
+	language = strings.ToLower(strings.TrimSpace(language))
+	if config, ok := TextSearchConfigs[language]; ok {
+		return config, true
+	}
+	if language == SimpleTextSearch {
+		return language, true
+	}
+	for _, config := range TextSearchConfigs {
+		if config == language {
+			return config, true
+		}
+	}
+	return "", false
+}
+
+// DocumentText is the text extracted from a document for full-text search.
+type DocumentText struct {
+	DocumentID uuid.UUID `yaml:"documentID" json:"documentID"`
+	// Language is the text search configuration the text is indexed with.
+	Language string `yaml:"language" json:"language"`
+	Content  string `yaml:"content" json:"content"`
+	// Error tells why no text could be extracted, empty when it was.
+	Error     string    `yaml:"error" json:"error"`
+	IndexedAt time.Time `yaml:"indexedAt" json:"indexedAt"`
+}
+
+func (t *DocumentText) Validate() error {
+	if t.DocumentID == uuid.Nil {
+		return errors.New("document id is required")
+	}
+	if config, ok := TextSearchConfig(t.Language); !ok || config != t.Language {
+		return fmt.Errorf("unknown text search configuration %q", t.Language)
+	}
+	return nil
+}
+
+const (
+	// DefaultSearchLimit is the number of results of a search that does not
+	// ask for a number.
+	DefaultSearchLimit = 20
+	// MaxSearchLimit is the largest number of results of a search.
+	MaxSearchLimit = 100
+	// MaxSearchLength is the length of the longest search text, in runes.
+	MaxSearchLength = 256
+)
+
+// SearchQuery is a full-text search over the documents a user can read: the
+// documents of UserID, those of DocumentIDs and those inside FolderIDs.
+type SearchQuery struct {
+	// Text is in web search syntax: quoted phrases, "or" and "-" to
+	// exclude a word.
+	Text        string
+	UserID      uuid.UUID
+	DocumentIDs []uuid.UUID
+	FolderIDs   []uuid.UUID
+	Limit       int
+}
+
+func (q *SearchQuery) Validate() error {
+	if strings.TrimSpace(q.Text) == "" {
+		return errors.New("search text is required")
+	}
+	if utf8.RuneCountInString(q.Text) > MaxSearchLength {
+		return fmt.Errorf("search text must not exceed %d characters", MaxSearchLength)
+	}
+	if q.UserID == uuid.Nil {
+		return errors.New("user id is required")
+	}
+	if q.Limit < 1 || q.Limit > MaxSearchLimit {
+		return fmt.Errorf("limit must be between 1 and %d", MaxSearchLimit)
+	}
+	return nil
+}
+
+// SearchHit is a document matching a search.
+type SearchHit struct {
+	Document *Document
+	Rank     float64
+	// Snippet is an excerpt of the text of the document in which the
+	// matching words are wrapped in <mark> tags. The rest of the excerpt is
+	// not escaped.
+	Snippet string
+}
@@ -0,0 +1,60 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTextSearchConfig(t *testing.T) {
+	tests := []struct {
+		language string
+		want     string
+		ok       bool
+	}{
+		{language: "fr", want: "french", ok: true},
+		{language: " EN ", want: "english", ok: true},
+		{language: "german", want: "german", ok: true},
+		{language: "simple", want: "simple", ok: true},
+		{language: "klingon", ok: false},
+		{language: "", ok: false},
+	}
+	for _, tt := range tests {
+		got, ok := TextSearchConfig(tt.language)
+		require.Equal(t, tt.ok, ok, tt.language)
+		require.Equal(t, tt.want, got, tt.language)
+	}
+}
+
+func TestDocumentText_Validate(t *testing.T) {
+	text := &DocumentText{DocumentID: uuid.New(), Language: "english"}
+	require.NoError(t, text.Validate())
+
+	text.Language = "en"
+	require.Error(t, text.Validate())
+
+	text = &DocumentText{Language: "simple"}
+	require.Error(t, text.Validate())
+}
+
+func TestSearchQuery_Validate(t *testing.T) {
+	valid := func() *SearchQuery {
+		return &SearchQuery{Text: "invoice", UserID: uuid.New(), Limit: DefaultSearchLimit}
+	}
+	require.NoError(t, valid().Validate())
+
+	tests := map[string]func(q *SearchQuery){
+		"blank text":    func(q *SearchQuery) { q.Text = "  " },
+		"long text":     func(q *SearchQuery) { q.Text = strings.Repeat("é", MaxSearchLength+1) },
+		"no user":       func(q *SearchQuery) { q.UserID = uuid.Nil },
+		"zero limit":    func(q *SearchQuery) { q.Limit = 0 },
+		"limit too big": func(q *SearchQuery) { q.Limit = MaxSearchLimit + 1 },
+	}
+	for name, mutate := range tests {
+		q := valid()
+		mutate(q)
+		require.Error(t, q.Validate(), name)
+	}
+}
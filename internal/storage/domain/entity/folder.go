@@ -0,0 +1,40 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// MaxFolderNameLength is the maximum length in bytes of a folder name.
+const MaxFolderNameLength = 255
+
+type Folder struct {
+	ID     uuid.UUID `yaml:"id" json:"id"`
+	UserID uuid.UUID `yaml:"userID" json:"userID"`
+	// ParentID is nil for top-level folders.
+	ParentID *uuid.UUID `yaml:"parentID,omitempty" json:"parentID,omitempty"`
+	Name     string     `yaml:"name" json:"name"`
+}
+
+func (f *Folder) Validate() error {
+	if f.UserID == uuid.Nil {
+		return errors.New("user id is required")
+	}
+	return ValidateFolderName(f.Name)
+}
+
+// ValidateFolderName checks that name can be used as a folder name.
+func ValidateFolderName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return errors.New("folder name is required")
+	}
+	if len(name) > MaxFolderNameLength {
+		return errors.New("folder name is too long")
+	}
+	if strings.ContainsAny(name, "/\\") || name == "." || name == ".." {
+		return errors.New("folder name must not contain path separators")
+	}
+	return nil
+}
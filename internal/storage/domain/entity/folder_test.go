@@ -0,0 +1,43 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFolder_Validate_Success(t *testing.T) {
+	t.Parallel()
+
+	parentID := uuid.New()
+	f := &Folder{
+		ID:       uuid.New(),
+		UserID:   uuid.New(),
+		ParentID: &parentID,
+		Name:     "Contracts",
+	}
+
+	require.NoError(t, f.Validate())
+}
+
+func TestFolder_Validate_MissingUserID(t *testing.T) {
+	t.Parallel()
+
+	f := &Folder{Name: "Contracts"}
+
+	err := f.Validate()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "user id is required")
+}
+
+func TestValidateFolderName(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, ValidateFolderName("2024 Q1"))
+	require.ErrorContains(t, ValidateFolderName("  "), "folder name is required")
+	require.ErrorContains(t, ValidateFolderName(strings.Repeat("a", MaxFolderNameLength+1)), "too long")
+	require.ErrorContains(t, ValidateFolderName("a/b"), "path separators")
+	require.ErrorContains(t, ValidateFolderName(".."), "path separators")
+}
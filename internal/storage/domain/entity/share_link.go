@@ -0,0 +1,74 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ShareLink gives anyone holding its token access to a document without an
+// account. Only a hash of the token is stored.
+type ShareLink struct {
+	ID         uuid.UUID `yaml:"id" json:"id"`
+	DocumentID uuid.UUID `yaml:"documentID" json:"documentID"`
+	OwnerID    uuid.UUID `yaml:"ownerID" json:"ownerID"`
+	TokenHash  string    `yaml:"tokenHash" json:"tokenHash"`
+	// PasswordHash is the argon2id hash of the password of the link, empty
+	// when the link has none.
+	PasswordHash string     `yaml:"passwordHash" json:"passwordHash"`
+	ExpiresAt    *time.Time `yaml:"expiresAt" json:"expiresAt"`
+	// MaxDownloads limits how often the document can be downloaded through
+	// the link, zero meaning no limit.
+	MaxDownloads  int        `yaml:"maxDownloads" json:"maxDownloads"`
+	DownloadCount int        `yaml:"downloadCount" json:"downloadCount"`
+	RevokedAt     *time.Time `yaml:"revokedAt" json:"revokedAt"`
+	CreatedAt     time.Time  `yaml:"createdAt" json:"createdAt"`
+}
+
+func (l *ShareLink) Validate() error {
+	if l.DocumentID == uuid.Nil {
+		return errors.New("document id is required")
+	}
+	if l.OwnerID == uuid.Nil {
+		return errors.New("owner id is required")
+	}
+	if l.TokenHash == "" {
+		return errors.New("token hash is required")
+	}
+	if l.MaxDownloads < 0 {
+		return errors.New("max downloads must not be negative")
+	}
+	return nil
+}
+
+func (l *ShareLink) HasPassword() bool {
+	return l.PasswordHash != ""
+}
+
+// Expired reports whether the link expired at the given time.
+func (l *ShareLink) Expired(at time.Time) bool {
+	return l.ExpiresAt != nil && !at.Before(*l.ExpiresAt)
+}
+
+// LinkAccessOutcome tells how an attempt to use a share link ended.
+type LinkAccessOutcome string
+
+const (
+	LinkAccessDownloaded      LinkAccessOutcome = "downloaded"
+	LinkAccessInvalidPassword LinkAccessOutcome = "invalid_password"
+	LinkAccessExpired         LinkAccessOutcome = "expired"
+	LinkAccessRevoked         LinkAccessOutcome = "revoked"
+	LinkAccessLimitReached    LinkAccessOutcome = "limit_reached"
+	LinkAccessThrottled       LinkAccessOutcome = "throttled"
+)
+
+// ShareLinkAccess records an attempt to use a share link.
+type ShareLinkAccess struct {
+	ID         uuid.UUID         `yaml:"id" json:"id"`
+	LinkID     uuid.UUID         `yaml:"linkID" json:"linkID"`
+	RemoteAddr string            `yaml:"remoteAddr" json:"remoteAddr"`
+	UserAgent  string            `yaml:"userAgent" json:"userAgent"`
+	Outcome    LinkAccessOutcome `yaml:"outcome" json:"outcome"`
+	CreatedAt  time.Time         `yaml:"createdAt" json:"createdAt"`
+}
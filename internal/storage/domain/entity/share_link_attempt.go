@@ -0,0 +1,7 @@
+package entity
+
+import "github.com/a1y/doc-formatter/pkg/throttle"
+
+// ShareLinkAttempt counts the wrong passwords for a key, a share link or a
+// client address, and how long passwords for it are not checked.
+type ShareLinkAttempt = throttle.Attempt
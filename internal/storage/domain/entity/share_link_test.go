@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShareLink_Validate(t *testing.T) {
+	t.Parallel()
+
+	valid := ShareLink{DocumentID: uuid.New(), OwnerID: uuid.New(), TokenHash: "hash"}
+	require.NoError(t, valid.Validate())
+
+	missingDocument := valid
+	missingDocument.DocumentID = uuid.Nil
+	require.Error(t, missingDocument.Validate())
+
+	missingToken := valid
+	missingToken.TokenHash = ""
+	require.Error(t, missingToken.Validate())
+
+	negativeLimit := valid
+	negativeLimit.MaxDownloads = -1
+	require.ErrorContains(t, negativeLimit.Validate(), "must not be negative")
+}
+
+func TestShareLink_Expired(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	link := ShareLink{}
+	require.False(t, link.Expired(now))
+
+	expiresAt := now.Add(time.Hour)
+	link.ExpiresAt = &expiresAt
+	require.False(t, link.Expired(now))
+	require.True(t, link.Expired(expiresAt))
+	require.True(t, link.Expired(expiresAt.Add(time.Second)))
+}
@@ -2,14 +2,162 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
+	"github.com/a1y/doc-formatter/pkg/throttle"
 	"github.com/google/uuid"
 )
 
 type DocumentRepository interface {
 	Create(ctx context.Context, d *entity.Document) error
-	ListByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.Document, error)
+	// ListByFolder returns the documents of userID directly inside folderID,
+	// or at the top level when folderID is nil.
+	ListByFolder(ctx context.Context, userID uuid.UUID, folderID *uuid.UUID) ([]*entity.Document, error)
+	// List returns the page of the documents of userID matching filter
+	// selected by page.
+	List(ctx context.Context, userID uuid.UUID, filter *entity.DocumentFilter, page *entity.DocumentPage) ([]*entity.Document, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*entity.Document, error)
+	// Delete moves a document into the trash. Trashed documents are left out
+	// of every lookup but the trash ones below.
 	Delete(ctx context.Context, id uuid.UUID) error
+	// GetTrashedByID returns a document of the trash.
+	GetTrashedByID(ctx context.Context, id uuid.UUID) (*entity.Document, error)
+	// ListTrash returns the page of the trash of userID selected by page.
+	ListTrash(ctx context.Context, userID uuid.UUID, page *entity.DocumentPage) ([]*entity.Document, error)
+	// ListTrashedBefore returns up to limit documents of every user, moved
+	// into the trash before deletedBefore, ordered by id and starting after
+	// afterID.
+	ListTrashedBefore(ctx context.Context, deletedBefore time.Time, afterID uuid.UUID, limit int) ([]*entity.Document, error)
+	// Restore takes a document out of the trash into folderID, or to the top
+	// level when folderID is nil.
+	Restore(ctx context.Context, id uuid.UUID, folderID *uuid.UUID) error
+	// Purge deletes trashed documents for good, along with their shares,
+	// share links, indexed text, analyses and provenance links, all or
+	// nothing.
+	Purge(ctx context.Context, ids []uuid.UUID) error
+	// ListWrappedByOtherKey returns up to limit encrypted documents, ordered by
+	// id and starting after afterID, whose data key is not wrapped by
+	// masterKeyID. Soft-deleted documents are included.
+	ListWrappedByOtherKey(ctx context.Context, masterKeyID string, afterID uuid.UUID, limit int) ([]*entity.Document, error)
+	UpdateWrappedKey(ctx context.Context, id uuid.UUID, masterKeyID string, wrappedKey []byte) error
+	UpdateFolder(ctx context.Context, id uuid.UUID, folderID *uuid.UUID) error
+	UpdateLabels(ctx context.Context, id uuid.UUID, tags []string, metadata map[string]string) error
+}
+
+type FolderRepository interface {
+	Create(ctx context.Context, f *entity.Folder) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.Folder, error)
+	// ListByParent returns the folders of userID directly inside parentID, or
+	// at the top level when parentID is nil.
+	ListByParent(ctx context.Context, userID uuid.UUID, parentID *uuid.UUID) ([]*entity.Folder, error)
+	Update(ctx context.Context, f *entity.Folder) error
+	Delete(ctx context.Context, ids []uuid.UUID) error
+	// DeleteTree deletes folderID of userID with the folders inside it and
+	// the roles granted on them, and moves the documents inside them into
+	// the trash, all or nothing. The folders are locked from being listed to
+	// being deleted, so that nothing is added to them meanwhile. Unless
+	// recursive, a folder that is not empty is left as it is and
+	// constant.ErrFolderNotEmpty returned. It returns the numbers of folders
+	// and documents deleted.
+	DeleteTree(ctx context.Context, userID, folderID uuid.UUID, recursive bool) (int, int, error)
+}
+
+type ACLRepository interface {
+	// Upsert grants the role of e, replacing the role the user had on the
+	// resource if any.
+	Upsert(ctx context.Context, e *entity.ACLEntry) error
+	// Delete revokes the role of userID on resourceID.
+	Delete(ctx context.Context, resourceID, userID uuid.UUID) error
+	// DeleteByResources revokes every role on resourceIDs.
+	DeleteByResources(ctx context.Context, resourceIDs []uuid.UUID) error
+	// ListByResource returns the entries of resourceID, oldest first.
+	ListByResource(ctx context.Context, resourceID uuid.UUID) ([]*entity.ACLEntry, error)
+	// ListByUser returns the entries granted to userID, newest first.
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*entity.ACLEntry, error)
+	// ListForUser returns the entries granted to userID on any of
+	// resourceIDs.
+	ListForUser(ctx context.Context, userID uuid.UUID, resourceIDs []uuid.UUID) ([]*entity.ACLEntry, error)
+}
+
+type ShareLinkRepository interface {
+	Create(ctx context.Context, l *entity.ShareLink) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.ShareLink, error)
+	GetByTokenHash(ctx context.Context, tokenHash string) (*entity.ShareLink, error)
+	// ListByDocument returns the links of documentID, newest first.
+	ListByDocument(ctx context.Context, documentID uuid.UUID) ([]*entity.ShareLink, error)
+	Revoke(ctx context.Context, id uuid.UUID, at time.Time) error
+	// IncrementDownloads counts a download through the link unless its
+	// download limit is reached, in which case false is returned.
+	IncrementDownloads(ctx context.Context, id uuid.UUID) (bool, error)
+	// DeleteByDocuments removes the links of documentIDs and their accesses.
+	DeleteByDocuments(ctx context.Context, documentIDs []uuid.UUID) error
+	RecordAccess(ctx context.Context, a *entity.ShareLinkAccess) error
+}
+
+// ShareLinkAttemptRepository counts wrong share link passwords by link and
+// client address.
+type ShareLinkAttemptRepository = throttle.Store
+
+type DocumentTextRepository interface {
+	// Save stores the text of a document, replacing the text it had.
+	Save(ctx context.Context, t *entity.DocumentText) error
+	// ListUnindexed returns up to limit documents of every user that have
+	// no text yet, oldest first. Documents in the trash are left out.
+	ListUnindexed(ctx context.Context, limit int) ([]*entity.Document, error)
+	// DeleteByDocuments removes the text of documentIDs, which are indexed
+	// again unless they are gone.
+	DeleteByDocuments(ctx context.Context, documentIDs []uuid.UUID) error
+	// Search returns the documents matching q, best matches first.
+	Search(ctx context.Context, q *entity.SearchQuery) ([]*entity.SearchHit, error)
+}
+
+type DocumentAnalysisRepository interface {
+	// GetByDocument returns the stored analysis of documentID.
+	GetByDocument(ctx context.Context, documentID uuid.UUID) (*entity.DocumentAnalysis, error)
+	// Save stores the analysis of a document, replacing the one it had.
+	Save(ctx context.Context, a *entity.DocumentAnalysis) error
+	DeleteByDocuments(ctx context.Context, documentIDs []uuid.UUID) error
+}
+
+type DocumentSourceRepository interface {
+	// Create records the links of a document made from others, all at once.
+	Create(ctx context.Context, sources []*entity.DocumentSource) error
+	// ListByDocument returns the sources documentID was made from, by
+	// position.
+	ListByDocument(ctx context.Context, documentID uuid.UUID) ([]*entity.DocumentSource, error)
+	// ListBySource returns the links of the documents made from sourceID,
+	// newest first.
+	ListBySource(ctx context.Context, sourceID uuid.UUID) ([]*entity.DocumentSource, error)
+	// DeleteByDocuments removes the links from and to documentIDs.
+	DeleteByDocuments(ctx context.Context, documentIDs []uuid.UUID) error
+}
+
+type BatchJobRepository interface {
+	// Create stores a job along with its items, all at once.
+	Create(ctx context.Context, j *entity.BatchJob, items []*entity.BatchJobItem) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.BatchJob, error)
+	// ListRunning returns the jobs of every user not completed yet, oldest
+	// first.
+	ListRunning(ctx context.Context) ([]*entity.BatchJob, error)
+	// Complete records that a job completed, along with its archive or the
+	// error that kept it from having one.
+	Complete(ctx context.Context, j *entity.BatchJob) error
+	// ListItems returns the items of jobID by position.
+	ListItems(ctx context.Context, jobID uuid.UUID) ([]*entity.BatchJobItem, error)
+	// ListPendingItems returns up to limit pending items of every job, those
+	// of the oldest jobs first.
+	ListPendingItems(ctx context.Context, limit int) ([]*entity.BatchJobItem, error)
+	// UpdateItem records the status and outcome of an item.
+	UpdateItem(ctx context.Context, i *entity.BatchJobItem) error
+	// ResetRunningItems makes the running items of every job pending again
+	// and returns how many there were.
+	ResetRunningItems(ctx context.Context) (int, error)
+}
+
+// Locker runs work that only one instance of the service may do at a time.
+type Locker interface {
+	// TryLock runs fn while holding the lock called name. When another
+	// instance holds the lock, fn is not run and false is returned.
+	TryLock(ctx context.Context, name string, fn func(ctx context.Context) error) (bool, error)
 }
@@ -0,0 +1,76 @@
+package handler
+
+import (
+	"context"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/a1y/doc-formatter/internal/storage/util/docanalysis"
+)
+
+func (h *Handler) AnalyzeDocument(ctx context.Context, req *storagepb.AnalyzeDocumentRequest) (*storagepb.AnalyzeDocumentResponse, error) {
+	userID, fileID, err := parseFileIDs(req.UserId, req.FileId)
+	if err != nil {
+		return nil, err
+	}
+
+	analysis, err := h.documentManager.AnalyzeDocument(ctx, userID, fileID)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	result := analysis.Analysis
+	resp := &storagepb.AnalyzeDocumentResponse{
+		FileId:  analysis.DocumentID.String(),
+		Outline: toHeadings(result.Outline),
+		Statistics: &storagepb.DocumentStatistics{
+			Words:              int64(result.Statistics.Words),
+			Characters:         int64(result.Statistics.Characters),
+			CharactersNoSpaces: int64(result.Statistics.CharactersNoSpaces),
+			Paragraphs:         int64(result.Statistics.Paragraphs),
+			Pages:              int64(result.Statistics.Pages),
+			PagesEstimated:     result.Statistics.PagesEstimated,
+		},
+		Images:   make([]*storagepb.DocumentImage, len(result.Images)),
+		Tables:   make([]*storagepb.DocumentTable, len(result.Tables)),
+		Language: result.Language,
+		Fonts:    result.Fonts,
+		Properties: &storagepb.DocumentProperties{
+			Title:          result.Properties.Title,
+			Subject:        result.Properties.Subject,
+			Description:    result.Properties.Description,
+			Author:         result.Properties.Author,
+			LastModifiedBy: result.Properties.LastModifiedBy,
+			Keywords:       result.Properties.Keywords,
+		},
+		AnalyzedAtUnix: analysis.AnalyzedAt.Unix(),
+	}
+	if created := result.Properties.Created; created != nil {
+		resp.Properties.CreatedAtUnix = created.Unix()
+	}
+	if modified := result.Properties.Modified; modified != nil {
+		resp.Properties.ModifiedAtUnix = modified.Unix()
+	}
+	for i, image := range result.Images {
+		resp.Images[i] = &storagepb.DocumentImage{
+			Name:        image.Name,
+			ContentType: image.ContentType,
+			Size:        image.Size,
+			Description: image.Description,
+		}
+	}
+	for i, table := range result.Tables {
+		resp.Tables[i] = &storagepb.DocumentTable{Rows: int32(table.Rows), Columns: int32(table.Columns)}
+	}
+	return resp, nil
+}
+
+func toHeadings(headings []docanalysis.Heading) []*storagepb.Heading {
+	out := make([]*storagepb.Heading, len(headings))
+	for i, heading := range headings {
+		out[i] = &storagepb.Heading{
+			Level:    int32(heading.Level),
+			Text:     heading.Text,
+			Children: toHeadings(heading.Children),
+		}
+	}
+	return out
+}
@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestHandler_AnalyzeDocument(t *testing.T) {
+	dm, fm, sm := newTestManagers(t)
+	client := newTestStorageClient(t, dm, fm, sm)
+	ctx := context.Background()
+	userID := uuid.NewString()
+
+	content := []byte("---\ntitle: Notes\ndate: 2025-01-02\n---\n# Notes\n\n## Week 1\n\n![Plan](plan.png)\n")
+	uploaded, err := client.UploadFile(ctx, &storagepb.UploadFileRequest{
+		UserId:   userID,
+		FileName: "notes.md",
+		FileSize: int64(len(content)),
+		Content:  content,
+	})
+	require.NoError(t, err)
+
+	resp, err := client.AnalyzeDocument(ctx, &storagepb.AnalyzeDocumentRequest{UserId: userID, FileId: uploaded.GetFileId()})
+	require.NoError(t, err)
+	require.Equal(t, uploaded.GetFileId(), resp.GetFileId())
+	require.Len(t, resp.GetOutline(), 1)
+	require.Equal(t, "Notes", resp.GetOutline()[0].GetText())
+	require.Equal(t, "Week 1", resp.GetOutline()[0].GetChildren()[0].GetText())
+	require.EqualValues(t, 2, resp.GetOutline()[0].GetChildren()[0].GetLevel())
+	require.Len(t, resp.GetImages(), 1)
+	require.Equal(t, "plan.png", resp.GetImages()[0].GetName())
+	require.Equal(t, "Notes", resp.GetProperties().GetTitle())
+	require.EqualValues(t, 1735776000, resp.GetProperties().GetCreatedAtUnix())
+	require.Zero(t, resp.GetProperties().GetModifiedAtUnix())
+	require.True(t, resp.GetStatistics().GetPagesEstimated())
+	require.NotZero(t, resp.GetAnalyzedAtUnix())
+
+	text := []byte("plain")
+	plain, err := client.UploadFile(ctx, &storagepb.UploadFileRequest{UserId: userID, FileName: "a.txt", FileSize: 5, Content: text})
+	require.NoError(t, err)
+	_, err = client.AnalyzeDocument(ctx, &storagepb.AnalyzeDocumentRequest{UserId: userID, FileId: plain.GetFileId()})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	_, err = client.AnalyzeDocument(ctx, &storagepb.AnalyzeDocumentRequest{UserId: uuid.NewString(), FileId: uploaded.GetFileId()})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = client.AnalyzeDocument(ctx, &storagepb.AnalyzeDocumentRequest{UserId: userID, FileId: "not-a-uuid"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"context"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
+	"github.com/a1y/doc-formatter/internal/storage/manager/document"
+	"github.com/a1y/doc-formatter/internal/storage/util/docstyle"
+	"github.com/google/uuid"
+)
+
+func (h *Handler) CreateBatchJob(ctx context.Context, req *storagepb.CreateBatchJobRequest) (*storagepb.CreateBatchJobResponse, error) {
+	userID, err := parseID("user id", req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	folderID, err := parseOptionalID("folder id", req.FolderId)
+	if err != nil {
+		return nil, err
+	}
+	documentIDs := make([]uuid.UUID, len(req.FileIds))
+	for i, id := range req.FileIds {
+		if documentIDs[i], err = parseID("file id", id); err != nil {
+			return nil, err
+		}
+	}
+	outputFolderID, err := parseOptionalID("output folder id", req.OutputFolderId)
+	if err != nil {
+		return nil, err
+	}
+
+	target := document.BatchTarget{FolderID: folderID, Recursive: req.Recursive, Tag: req.Tag, DocumentIDs: documentIDs}
+	opts := document.BatchOptions{OutputFolderID: outputFolderID, Archive: req.Archive}
+	job, items, err := h.documentManager.CreateBatchJob(ctx, userID, target, fromStyleProfile(req.Profile), opts)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &storagepb.CreateBatchJobResponse{Job: toBatchJob(job, items)}, nil
+}
+
+func (h *Handler) GetBatchJob(ctx context.Context, req *storagepb.GetBatchJobRequest) (*storagepb.GetBatchJobResponse, error) {
+	userID, err := parseID("user id", req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	jobID, err := parseID("job id", req.JobId)
+	if err != nil {
+		return nil, err
+	}
+
+	job, items, err := h.documentManager.GetBatchJob(ctx, userID, jobID)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &storagepb.GetBatchJobResponse{Job: toBatchJob(job, items)}, nil
+}
+
+func fromStyleProfile(p *storagepb.StyleProfile) docstyle.Profile {
+	return docstyle.Profile{
+		FontFamily:            p.GetFontFamily(),
+		FontSize:              p.GetFontSize(),
+		HeadingFontFamily:     p.GetHeadingFontFamily(),
+		LineSpacing:           p.GetLineSpacing(),
+		Margin:                p.GetMargin(),
+		ClearDirectFormatting: p.GetClearDirectFormatting(),
+	}
+}
+
+func toBatchJob(job *entity.BatchJob, items []*entity.BatchJobItem) *storagepb.BatchJob {
+	var finishedAtUnix int64
+	if job.FinishedAt != nil {
+		finishedAtUnix = job.FinishedAt.Unix()
+	}
+	progress := entity.NewBatchProgress(items)
+	out := &storagepb.BatchJob{
+		Id:     job.ID.String(),
+		Status: string(job.Status),
+		Profile: &storagepb.StyleProfile{
+			FontFamily:            job.Profile.FontFamily,
+			FontSize:              job.Profile.FontSize,
+			HeadingFontFamily:     job.Profile.HeadingFontFamily,
+			LineSpacing:           job.Profile.LineSpacing,
+			Margin:                job.Profile.Margin,
+			ClearDirectFormatting: job.Profile.ClearDirectFormatting,
+		},
+		OutputFolderId: formatOptionalID(job.OutputFolderID),
+		Archive:        job.Archive,
+		Total:          int32(progress.Total),
+		Pending:        int32(progress.Pending),
+		Running:        int32(progress.Running),
+		Succeeded:      int32(progress.Succeeded),
+		Failed:         int32(progress.Failed),
+		Items:          make([]*storagepb.BatchJobItem, len(items)),
+		ArchiveFileId:  formatOptionalID(job.ArchiveID),
+		Error:          job.Error,
+		CreatedAtUnix:  job.CreatedAt.Unix(),
+		FinishedAtUnix: finishedAtUnix,
+	}
+	for i, item := range items {
+		out.Items[i] = &storagepb.BatchJobItem{
+			FileId:       item.DocumentID.String(),
+			FileName:     item.FileName,
+			Status:       string(item.Status),
+			OutputFileId: formatOptionalID(item.OutputID),
+			Error:        item.Error,
+			Warnings:     item.Warnings,
+		}
+	}
+	return out
+}
@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestHandler_BatchJobs(t *testing.T) {
+	dm, fm, sm := newTestManagers(t)
+	client := newTestStorageClient(t, dm, fm, sm)
+	ctx := context.Background()
+	userID := uuid.NewString()
+
+	uploaded, err := client.UploadFile(ctx, &storagepb.UploadFileRequest{
+		UserId:   userID,
+		FileName: "notes.md",
+		FileSize: 8,
+		Content:  []byte("# Notes\n"),
+	})
+	require.NoError(t, err)
+
+	profile := &storagepb.StyleProfile{FontFamily: "Georgia", FontSize: 11}
+	created, err := client.CreateBatchJob(ctx, &storagepb.CreateBatchJobRequest{
+		UserId:  userID,
+		FileIds: []string{uploaded.GetFileId()},
+		Profile: profile,
+		Archive: true,
+	})
+	require.NoError(t, err)
+	job := created.GetJob()
+	require.Equal(t, "running", job.GetStatus())
+	require.Equal(t, "Georgia", job.GetProfile().GetFontFamily())
+	require.Equal(t, int32(1), job.GetTotal())
+	require.Equal(t, int32(1), job.GetPending())
+	require.Len(t, job.GetItems(), 1)
+	require.Equal(t, "notes.md", job.GetItems()[0].GetFileName())
+	require.NotZero(t, job.GetCreatedAtUnix())
+
+	formatted, err := dm.RunBatchJobs(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, 1, formatted)
+
+	got, err := client.GetBatchJob(ctx, &storagepb.GetBatchJobRequest{UserId: userID, JobId: job.GetId()})
+	require.NoError(t, err)
+	job = got.GetJob()
+	require.Equal(t, "completed", job.GetStatus())
+	require.Equal(t, int32(1), job.GetFailed())
+	require.Equal(t, "failed", job.GetItems()[0].GetStatus())
+	require.NotEmpty(t, job.GetItems()[0].GetError())
+	require.Empty(t, job.GetArchiveFileId())
+	require.NotEmpty(t, job.GetError())
+	require.NotZero(t, job.GetFinishedAtUnix())
+
+	_, err = client.GetBatchJob(ctx, &storagepb.GetBatchJobRequest{UserId: uuid.NewString(), JobId: job.GetId()})
+	require.Equal(t, codes.NotFound, status.Code(err))
+	_, err = client.GetBatchJob(ctx, &storagepb.GetBatchJobRequest{UserId: userID, JobId: "not-a-uuid"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = client.CreateBatchJob(ctx, &storagepb.CreateBatchJobRequest{UserId: userID, Profile: profile})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = client.CreateBatchJob(ctx, &storagepb.CreateBatchJobRequest{UserId: userID, FileIds: []string{uploaded.GetFileId()}})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = client.CreateBatchJob(ctx, &storagepb.CreateBatchJobRequest{UserId: userID, FolderId: uuid.NewString(), Profile: profile})
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/a1y/doc-formatter/internal/storage/util/docdiff"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+)
+
+func (h *Handler) DiffDocuments(ctx context.Context, req *storagepb.DiffDocumentsRequest) (*storagepb.DiffDocumentsResponse, error) {
+	userID, oldID, newID, err := parseDiffIDs(req.UserId, req.OldFileId, req.NewFileId)
+	if err != nil {
+		return nil, err
+	}
+	contextLines := docdiff.DefaultContextLines
+	if req.ContextLines != nil {
+		contextLines = int(req.GetContextLines())
+	}
+
+	diff, err := h.documentManager.DiffDocuments(ctx, userID, oldID, newID, contextLines)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	resp := &storagepb.DiffDocumentsResponse{
+		OldFileId: oldID.String(),
+		NewFileId: newID.String(),
+		Changes:   make([]*storagepb.StructuralChange, len(diff.Changes)),
+		Hunks:     make([]*storagepb.TextHunk, len(diff.Hunks)),
+		Summary: &storagepb.DiffSummary{
+			Inserted:     int32(diff.Summary.Inserted),
+			Deleted:      int32(diff.Summary.Deleted),
+			Modified:     int32(diff.Summary.Modified),
+			LinesAdded:   int32(diff.Summary.LinesAdded),
+			LinesRemoved: int32(diff.Summary.LinesRemoved),
+		},
+	}
+	for i, change := range diff.Changes {
+		resp.Changes[i] = &storagepb.StructuralChange{
+			Op:     string(change.Op),
+			Old:    toDiffBlock(change.Old),
+			New:    toDiffBlock(change.New),
+			Fields: change.Fields,
+		}
+	}
+	for i, hunk := range diff.Hunks {
+		lines := make([]*storagepb.TextLine, len(hunk.Lines))
+		for j, line := range hunk.Lines {
+			lines[j] = &storagepb.TextLine{Op: string(line.Op), Text: line.Text}
+		}
+		resp.Hunks[i] = &storagepb.TextHunk{
+			OldStart: int32(hunk.OldStart),
+			OldLines: int32(hunk.OldLines),
+			NewStart: int32(hunk.NewStart),
+			NewLines: int32(hunk.NewLines),
+			Lines:    lines,
+		}
+	}
+	return resp, nil
+}
+
+func (h *Handler) RedlineDocuments(req *storagepb.RedlineDocumentsRequest, stream grpc.ServerStreamingServer[storagepb.DownloadFileResponse]) error {
+	userID, oldID, newID, err := parseDiffIDs(req.UserId, req.OldFileId, req.NewFileId)
+	if err != nil {
+		return err
+	}
+
+	doc, content, err := h.documentManager.RedlineDocuments(stream.Context(), userID, oldID, newID)
+	if err != nil {
+		return toStatusError(err)
+	}
+	return sendDocument(stream, doc, bytes.NewReader(content))
+}
+
+func parseDiffIDs(userID, oldFileID, newFileID string) (uuid.UUID, uuid.UUID, uuid.UUID, error) {
+	user, oldID, err := parseFileIDs(userID, oldFileID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, uuid.Nil, err
+	}
+	newID, err := parseID("file id", newFileID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, uuid.Nil, err
+	}
+	return user, oldID, newID, nil
+}
+
+// toDiffBlock converts a block of a change, nil for the missing side of an
+// insertion or deletion.
+func toDiffBlock(block *docdiff.Block) *storagepb.DiffBlock {
+	if block == nil {
+		return nil
+	}
+	out := &storagepb.DiffBlock{
+		Index: int32(block.Index),
+		Kind:  block.Kind,
+		Level: int32(block.Level),
+		Style: block.Style,
+		Text:  block.Text,
+		Rows:  make([]*storagepb.DiffTableRow, len(block.Rows)),
+	}
+	for i, row := range block.Rows {
+		out.Rows[i] = &storagepb.DiffTableRow{Cells: row}
+	}
+	return out
+}
@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestHandler_DiffDocuments(t *testing.T) {
+	dm, fm, sm := newTestManagers(t)
+	client := newTestStorageClient(t, dm, fm, sm)
+	ctx := context.Background()
+	userID := uuid.NewString()
+
+	upload := func(content string) string {
+		resp, err := client.UploadFile(ctx, &storagepb.UploadFileRequest{
+			UserId:   userID,
+			FileName: "notes.md",
+			FileSize: int64(len(content)),
+			Content:  []byte(content),
+		})
+		require.NoError(t, err)
+		return resp.GetFileId()
+	}
+	oldID := upload("# Notes\n\nBuy milk.\n")
+	newID := upload("# Notes\n\nBuy bread.\n")
+
+	resp, err := client.DiffDocuments(ctx, &storagepb.DiffDocumentsRequest{UserId: userID, OldFileId: oldID, NewFileId: newID})
+	require.NoError(t, err)
+	require.Equal(t, oldID, resp.GetOldFileId())
+	require.Len(t, resp.GetChanges(), 1)
+	change := resp.GetChanges()[0]
+	require.Equal(t, "modify", change.GetOp())
+	require.Equal(t, []string{"text"}, change.GetFields())
+	require.Equal(t, "Buy milk.", change.GetOld().GetText())
+	require.Equal(t, "Buy bread.", change.GetNew().GetText())
+	require.Len(t, resp.GetHunks(), 1)
+	require.Len(t, resp.GetHunks()[0].GetLines(), 4)
+	require.EqualValues(t, 1, resp.GetSummary().GetModified())
+
+	resp, err = client.DiffDocuments(ctx, &storagepb.DiffDocumentsRequest{UserId: userID, OldFileId: oldID, NewFileId: newID, ContextLines: proto.Int32(0)})
+	require.NoError(t, err)
+	require.Len(t, resp.GetHunks()[0].GetLines(), 2)
+
+	_, err = client.DiffDocuments(ctx, &storagepb.DiffDocumentsRequest{UserId: userID, OldFileId: oldID, NewFileId: newID, ContextLines: proto.Int32(-1)})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = client.DiffDocuments(ctx, &storagepb.DiffDocumentsRequest{UserId: userID, OldFileId: oldID, NewFileId: "not-a-uuid"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = client.DiffDocuments(ctx, &storagepb.DiffDocumentsRequest{UserId: uuid.NewString(), OldFileId: oldID, NewFileId: newID})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	stream, err := client.RedlineDocuments(ctx, &storagepb.RedlineDocumentsRequest{UserId: userID, OldFileId: oldID, NewFileId: newID})
+	require.NoError(t, err)
+	header, content, err := receiveAll(stream)
+	require.NoError(t, err)
+	require.Equal(t, "notes-redline.docx", header.GetFileName())
+	require.EqualValues(t, len(content), header.GetFileSize())
+	require.Contains(t, string(content), "word/document.xml")
+}
@@ -10,18 +10,64 @@ import (
 )
 
 func (h *Handler) UploadFile(ctx context.Context, req *storagepb.UploadFileRequest) (*storagepb.UploadFileResponse, error) {
+	folderID, err := parseOptionalID("folder id", req.FolderId)
+	if err != nil {
+		return nil, err
+	}
 	reader := bytes.NewReader(req.Content)
 	documentEntity := entity.Document{
 		UserID:   uuid.MustParse(req.UserId),
 		FileName: req.FileName,
 		FileSize: req.FileSize,
+		FolderID: folderID,
 	}
 	documentResponse, err := h.documentManager.UploadDocument(ctx, &documentEntity, reader)
 	if err != nil {
-		return nil, err
+		return nil, toStatusError(err)
 	}
 	return &storagepb.UploadFileResponse{
 		FileId:   documentResponse.ID.String(),
 		FileName: documentResponse.FileName,
 	}, nil
 }
+
+func (h *Handler) MoveFile(ctx context.Context, req *storagepb.MoveFileRequest) (*storagepb.MoveFileResponse, error) {
+	userID, err := parseID("user id", req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	fileID, err := parseID("file id", req.FileId)
+	if err != nil {
+		return nil, err
+	}
+	folderID, err := parseOptionalID("folder id", req.FolderId)
+	if err != nil {
+		return nil, err
+	}
+
+	document, err := h.documentManager.MoveDocument(ctx, userID, fileID, folderID)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &storagepb.MoveFileResponse{File: toFileInfo(document)}, nil
+}
+
+func toFileInfo(document *entity.Document) *storagepb.FileInfo {
+	var deletedAtUnix int64
+	if document.DeletedAt != nil {
+		deletedAtUnix = document.DeletedAt.Unix()
+	}
+	return &storagepb.FileInfo{
+		FileId:   document.ID.String(),
+		FileName: document.FileName,
+		FileSize: document.FileSize,
+		FolderId: formatOptionalID(document.FolderID),
+
+		ContentType:   document.ContentType,
+		Tags:          document.Tags,
+		Metadata:      document.Metadata,
+		CreatedAtUnix: document.CreatedAt.Unix(),
+		UpdatedAtUnix: document.UpdatedAt.Unix(),
+		DeletedAtUnix: deletedAtUnix,
+	}
+}
@@ -6,6 +6,8 @@ import (
 
 	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
 	"github.com/a1y/doc-formatter/internal/storage/manager/document"
+	"github.com/a1y/doc-formatter/internal/storage/manager/folder"
+	"github.com/a1y/doc-formatter/internal/storage/manager/share"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 )
@@ -13,10 +15,16 @@ import (
 func TestNewHandler_ReturnsHandlerWithDocumentManager(t *testing.T) {
 	dm := &document.DocumentManager{}
 
-	h, err := NewHandler(dm)
+	fm := &folder.FolderManager{}
+
+	sm := &share.ShareManager{}
+
+	h, err := NewHandler(dm, fm, sm)
 	require.NoError(t, err)
 	require.NotNil(t, h)
 	require.Equal(t, dm, h.documentManager)
+	require.Equal(t, fm, h.folderManager)
+	require.Equal(t, sm, h.shareManager)
 }
 
 func TestHandler_UploadFile_InvalidUserID_Panics(t *testing.T) {
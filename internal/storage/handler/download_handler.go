@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"errors"
+	"io"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
+	"google.golang.org/grpc"
+)
+
+// DownloadChunkSize is the maximum amount of content sent per stream message.
+const DownloadChunkSize = 64 * 1024
+
+func (h *Handler) DownloadFile(req *storagepb.DownloadFileRequest, stream grpc.ServerStreamingServer[storagepb.DownloadFileResponse]) error {
+	userID, err := parseID("user id", req.UserId)
+	if err != nil {
+		return err
+	}
+	fileID, err := parseID("file id", req.FileId)
+	if err != nil {
+		return err
+	}
+
+	document, content, err := h.documentManager.DownloadDocument(stream.Context(), userID, fileID)
+	if err != nil {
+		return toStatusError(err)
+	}
+	defer content.Close()
+
+	return sendDocument(stream, document, content)
+}
+
+// sendDocument streams the metadata of document, then its content in chunks
+// of at most DownloadChunkSize.
+func sendDocument(stream grpc.ServerStreamingServer[storagepb.DownloadFileResponse], document *entity.Document, content io.Reader) error {
+	if err := stream.Send(&storagepb.DownloadFileResponse{
+		FileName: document.FileName,
+		FileSize: document.FileSize,
+	}); err != nil {
+		return err
+	}
+
+	buf := make([]byte, DownloadChunkSize)
+	for {
+		n, err := io.ReadFull(content, buf)
+		if n > 0 {
+			if err := stream.Send(&storagepb.DownloadFileResponse{Chunk: buf[:n]}); err != nil {
+				return err
+			}
+		}
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
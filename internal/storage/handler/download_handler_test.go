@@ -0,0 +1,157 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
+	"github.com/a1y/doc-formatter/internal/storage/infra/persistence"
+	"github.com/a1y/doc-formatter/internal/storage/manager/document"
+	"github.com/a1y/doc-formatter/internal/storage/manager/folder"
+	"github.com/a1y/doc-formatter/internal/storage/manager/share"
+	"github.com/a1y/doc-formatter/internal/storage/util/envelope"
+	"github.com/a1y/doc-formatter/internal/storage/util/objectstore/memory"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func newTestStorageClient(t *testing.T, dm *document.DocumentManager, fm *folder.FolderManager, sm *share.ShareManager) storagepb.StorageServiceClient {
+	t.Helper()
+
+	h, err := NewHandler(dm, fm, sm)
+	require.NoError(t, err)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := grpc.NewServer()
+	storagepb.RegisterStorageServiceServer(server, h)
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return storagepb.NewStorageServiceClient(conn)
+}
+
+// newTestManagers returns managers sharing an in-memory database and object
+// store, with encryption enabled.
+func newTestManagers(t *testing.T) (*document.DocumentManager, *folder.FolderManager, *share.ShareManager) {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, persistence.AutoMigrate(db))
+
+	keyring, err := envelope.NewKeyring("k1", map[string][]byte{"k1": bytes.Repeat([]byte{1}, envelope.KeySize)})
+	require.NoError(t, err)
+
+	documentRepo := persistence.NewDocumentRepository(db)
+	folderRepo := persistence.NewFolderRepository(db)
+	aclRepo := persistence.NewACLRepository(db)
+	documentManager := document.NewDocumentManager(documentRepo, folderRepo, aclRepo, memory.NewMemoryStorage())
+	documentManager.SetShareLinkRepository(persistence.NewShareLinkRepository(db))
+	documentManager.SetTextRepository(persistence.NewDocumentTextRepository(db))
+	documentManager.SetAnalysisRepository(persistence.NewDocumentAnalysisRepository(db))
+	documentManager.SetSourceRepository(persistence.NewDocumentSourceRepository(db))
+	documentManager.SetBatchJobRepository(persistence.NewBatchJobRepository(db))
+	documentManager.SetKeyring(keyring)
+	return documentManager,
+		folder.NewFolderManager(folderRepo, documentRepo, aclRepo),
+		share.NewShareManager(aclRepo, documentRepo, folderRepo)
+}
+
+func receiveAll(stream grpc.ServerStreamingClient[storagepb.DownloadFileResponse]) (*storagepb.DownloadFileResponse, []byte, error) {
+	header, err := stream.Recv()
+	if err != nil {
+		return nil, nil, err
+	}
+	var content []byte
+	for {
+		msg, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return header, content, nil
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		content = append(content, msg.GetChunk()...)
+	}
+}
+
+func TestHandler_DownloadFile_StreamsDecryptedContent(t *testing.T) {
+	dm, fm, sm := newTestManagers(t)
+	client := newTestStorageClient(t, dm, fm, sm)
+
+	userID := uuid.New()
+	content := bytes.Repeat([]byte("contract "), DownloadChunkSize/4)
+	created, err := dm.UploadDocument(context.Background(), &entity.Document{
+		UserID:   userID,
+		FileName: "contract.txt",
+		FileSize: int64(len(content)),
+	}, bytes.NewReader(content))
+	require.NoError(t, err)
+
+	stream, err := client.DownloadFile(context.Background(), &storagepb.DownloadFileRequest{
+		UserId: userID.String(),
+		FileId: created.ID.String(),
+	})
+	require.NoError(t, err)
+
+	header, received, err := receiveAll(stream)
+	require.NoError(t, err)
+	require.Equal(t, "contract.txt", header.GetFileName())
+	require.EqualValues(t, len(content), header.GetFileSize())
+	require.Empty(t, header.GetChunk())
+	require.Equal(t, content, received)
+}
+
+func TestHandler_DownloadFile_Errors(t *testing.T) {
+	dm, fm, sm := newTestManagers(t)
+	client := newTestStorageClient(t, dm, fm, sm)
+
+	tests := []struct {
+		name string
+		req  *storagepb.DownloadFileRequest
+		code codes.Code
+	}{
+		{
+			name: "invalid user id",
+			req:  &storagepb.DownloadFileRequest{UserId: "bad", FileId: uuid.NewString()},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "invalid file id",
+			req:  &storagepb.DownloadFileRequest{UserId: uuid.NewString(), FileId: "bad"},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "missing file",
+			req:  &storagepb.DownloadFileRequest{UserId: uuid.NewString(), FileId: uuid.NewString()},
+			code: codes.NotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream, err := client.DownloadFile(context.Background(), tt.req)
+			require.NoError(t, err)
+
+			_, err = stream.Recv()
+			require.Equal(t, tt.code, status.Code(err))
+		})
+	}
+}
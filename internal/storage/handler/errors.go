@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/a1y/doc-formatter/internal/storage/domain/constant"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// toStatusError converts domain errors into gRPC status errors so that the
+// gateway can answer with a matching HTTP status. Other errors are returned
+// unchanged.
+func toStatusError(err error) error {
+	switch {
+	case errors.Is(err, constant.ErrDocumentNotFound), errors.Is(err, constant.ErrFolderNotFound),
+		errors.Is(err, constant.ErrShareNotFound), errors.Is(err, constant.ErrShareLinkNotFound),
+		errors.Is(err, constant.ErrBatchJobNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, constant.ErrInvalidFolderName), errors.Is(err, constant.ErrFolderCycle),
+		errors.Is(err, constant.ErrInvalidTags), errors.Is(err, constant.ErrInvalidMetadata),
+		errors.Is(err, constant.ErrInvalidFilter), errors.Is(err, constant.ErrInvalidPage),
+		errors.Is(err, constant.ErrInvalidPageToken), errors.Is(err, constant.ErrInvalidShare),
+		errors.Is(err, constant.ErrInvalidShareLink), errors.Is(err, constant.ErrInvalidSearch),
+		errors.Is(err, constant.ErrInvalidDiff), errors.Is(err, constant.ErrInvalidMerge),
+		errors.Is(err, constant.ErrInvalidSplit), errors.Is(err, constant.ErrInvalidStamp),
+		errors.Is(err, constant.ErrInvalidBatchJob):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, constant.ErrPermissionDenied):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, constant.ErrFolderNameConflict):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, constant.ErrShareLinkPassword):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, constant.ErrFolderNotEmpty), errors.Is(err, constant.ErrShareLinkExpired),
+		errors.Is(err, constant.ErrShareLinkRevoked), errors.Is(err, constant.ErrDownloadLimitReached),
+		errors.Is(err, constant.ErrUnsupportedFormat), errors.Is(err, constant.ErrMalformedDocument),
+		errors.Is(err, constant.ErrEncryptedDocument):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return err
+	}
+}
+
+// parseID parses a required id field of a request.
+func parseID(field, value string) (uuid.UUID, error) {
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return uuid.Nil, status.Error(codes.InvalidArgument, "invalid "+field)
+	}
+	return id, nil
+}
+
+// parseOptionalID parses an id field of a request where empty means none.
+func parseOptionalID(field, value string) (*uuid.UUID, error) {
+	if value == "" {
+		return nil, nil
+	}
+	id, err := parseID(field, value)
+	if err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
+
+// formatOptionalID formats an optional id for a response, where none is
+// empty.
+func formatOptionalID(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
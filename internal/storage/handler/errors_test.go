@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/a1y/doc-formatter/internal/storage/domain/constant"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestToStatusError(t *testing.T) {
+	tests := []struct {
+		err  error
+		code codes.Code
+	}{
+		{err: constant.ErrDocumentNotFound, code: codes.NotFound},
+		{err: constant.ErrFolderNotFound, code: codes.NotFound},
+		{err: fmt.Errorf("%w: too long", constant.ErrInvalidFolderName), code: codes.InvalidArgument},
+		{err: constant.ErrFolderCycle, code: codes.InvalidArgument},
+		{err: constant.ErrFolderNameConflict, code: codes.AlreadyExists},
+		{err: constant.ErrFolderNotEmpty, code: codes.FailedPrecondition},
+		{err: fmt.Errorf("%w: too many", constant.ErrInvalidTags), code: codes.InvalidArgument},
+		{err: fmt.Errorf("%w: too long", constant.ErrInvalidMetadata), code: codes.InvalidArgument},
+		{err: fmt.Errorf("%w: bad range", constant.ErrInvalidFilter), code: codes.InvalidArgument},
+		{err: fmt.Errorf("%w: page size too large", constant.ErrInvalidPage), code: codes.InvalidArgument},
+		{err: constant.ErrInvalidPageToken, code: codes.InvalidArgument},
+		{err: fmt.Errorf("%w: unknown role", constant.ErrInvalidShare), code: codes.InvalidArgument},
+		{err: constant.ErrShareNotFound, code: codes.NotFound},
+		{err: constant.ErrPermissionDenied, code: codes.PermissionDenied},
+		{err: fmt.Errorf("%w: expiry must be in the future", constant.ErrInvalidShareLink), code: codes.InvalidArgument},
+		{err: constant.ErrShareLinkNotFound, code: codes.NotFound},
+		{err: constant.ErrBatchJobNotFound, code: codes.NotFound},
+		{err: constant.ErrShareLinkPassword, code: codes.Unauthenticated},
+		{err: constant.ErrShareLinkExpired, code: codes.FailedPrecondition},
+		{err: constant.ErrShareLinkRevoked, code: codes.FailedPrecondition},
+		{err: constant.ErrDownloadLimitReached, code: codes.FailedPrecondition},
+		{err: fmt.Errorf("%w: query is empty", constant.ErrInvalidSearch), code: codes.InvalidArgument},
+		{err: fmt.Errorf("%w: context lines out of range", constant.ErrInvalidDiff), code: codes.InvalidArgument},
+		{err: fmt.Errorf("%w: one document", constant.ErrInvalidMerge), code: codes.InvalidArgument},
+		{err: fmt.Errorf("%w: no headings", constant.ErrInvalidSplit), code: codes.InvalidArgument},
+		{err: fmt.Errorf("%w: application/pdf", constant.ErrUnsupportedFormat), code: codes.FailedPrecondition},
+		{err: fmt.Errorf("%w: missing content.xml", constant.ErrMalformedDocument), code: codes.FailedPrecondition},
+		{err: fmt.Errorf("%w: opacity must be between 0 and 1", constant.ErrInvalidStamp), code: codes.InvalidArgument},
+		{err: fmt.Errorf("%w: the target holds no documents", constant.ErrInvalidBatchJob), code: codes.InvalidArgument},
+		{err: constant.ErrEncryptedDocument, code: codes.FailedPrecondition},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.code, status.Code(toStatusError(tt.err)), "error %v", tt.err)
+	}
+
+	plain := errors.New("boom")
+	require.Equal(t, plain, toStatusError(plain))
+}
+
+func TestParseIDs(t *testing.T) {
+	id := uuid.New()
+
+	parsed, err := parseID("file id", id.String())
+	require.NoError(t, err)
+	require.Equal(t, id, parsed)
+
+	_, err = parseID("file id", "bad")
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	optional, err := parseOptionalID("folder id", "")
+	require.NoError(t, err)
+	require.Nil(t, optional)
+	require.Empty(t, formatOptionalID(optional))
+
+	optional, err = parseOptionalID("folder id", id.String())
+	require.NoError(t, err)
+	require.Equal(t, id.String(), formatOptionalID(optional))
+
+	_, err = parseOptionalID("folder id", "bad")
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"context"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
+)
+
+func (h *Handler) CreateFolder(ctx context.Context, req *storagepb.CreateFolderRequest) (*storagepb.CreateFolderResponse, error) {
+	userID, err := parseID("user id", req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	parentID, err := parseOptionalID("parent id", req.ParentId)
+	if err != nil {
+		return nil, err
+	}
+
+	folder, err := h.folderManager.CreateFolder(ctx, userID, parentID, req.Name)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &storagepb.CreateFolderResponse{Folder: toFolder(folder)}, nil
+}
+
+func (h *Handler) RenameFolder(ctx context.Context, req *storagepb.RenameFolderRequest) (*storagepb.RenameFolderResponse, error) {
+	userID, err := parseID("user id", req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	folderID, err := parseID("folder id", req.FolderId)
+	if err != nil {
+		return nil, err
+	}
+
+	folder, err := h.folderManager.RenameFolder(ctx, userID, folderID, req.Name)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &storagepb.RenameFolderResponse{Folder: toFolder(folder)}, nil
+}
+
+func (h *Handler) MoveFolder(ctx context.Context, req *storagepb.MoveFolderRequest) (*storagepb.MoveFolderResponse, error) {
+	userID, err := parseID("user id", req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	folderID, err := parseID("folder id", req.FolderId)
+	if err != nil {
+		return nil, err
+	}
+	parentID, err := parseOptionalID("parent id", req.ParentId)
+	if err != nil {
+		return nil, err
+	}
+
+	folder, err := h.folderManager.MoveFolder(ctx, userID, folderID, parentID)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &storagepb.MoveFolderResponse{Folder: toFolder(folder)}, nil
+}
+
+func (h *Handler) DeleteFolder(ctx context.Context, req *storagepb.DeleteFolderRequest) (*storagepb.DeleteFolderResponse, error) {
+	userID, err := parseID("user id", req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	folderID, err := parseID("folder id", req.FolderId)
+	if err != nil {
+		return nil, err
+	}
+
+	folders, files, err := h.folderManager.DeleteFolder(ctx, userID, folderID, req.Recursive)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &storagepb.DeleteFolderResponse{
+		DeletedFolders: int32(folders),
+		DeletedFiles:   int32(files),
+	}, nil
+}
+
+func (h *Handler) ListFolder(ctx context.Context, req *storagepb.ListFolderRequest) (*storagepb.ListFolderResponse, error) {
+	userID, err := parseID("user id", req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	folderID, err := parseOptionalID("folder id", req.FolderId)
+	if err != nil {
+		return nil, err
+	}
+
+	folders, documents, err := h.folderManager.ListFolder(ctx, userID, folderID)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	resp := &storagepb.ListFolderResponse{
+		Folders: make([]*storagepb.Folder, len(folders)),
+		Files:   make([]*storagepb.FileInfo, len(documents)),
+	}
+	for i, folder := range folders {
+		resp.Folders[i] = toFolder(folder)
+	}
+	for i, document := range documents {
+		resp.Files[i] = toFileInfo(document)
+	}
+	return resp, nil
+}
+
+func toFolder(folder *entity.Folder) *storagepb.Folder {
+	return &storagepb.Folder{
+		FolderId: folder.ID.String(),
+		Name:     folder.Name,
+		ParentId: formatOptionalID(folder.ParentID),
+	}
+}
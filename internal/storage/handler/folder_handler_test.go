@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestHandler_Folders(t *testing.T) {
+	dm, fm, sm := newTestManagers(t)
+	client := newTestStorageClient(t, dm, fm, sm)
+	ctx := context.Background()
+	userID := uuid.NewString()
+
+	clients, err := client.CreateFolder(ctx, &storagepb.CreateFolderRequest{UserId: userID, Name: "Clients"})
+	require.NoError(t, err)
+	require.Equal(t, "Clients", clients.GetFolder().GetName())
+	require.Empty(t, clients.GetFolder().GetParentId())
+
+	acme, err := client.CreateFolder(ctx, &storagepb.CreateFolderRequest{
+		UserId:   userID,
+		Name:     "Acme",
+		ParentId: clients.GetFolder().GetFolderId(),
+	})
+	require.NoError(t, err)
+	require.Equal(t, clients.GetFolder().GetFolderId(), acme.GetFolder().GetParentId())
+
+	_, err = client.CreateFolder(ctx, &storagepb.CreateFolderRequest{UserId: userID, Name: "Clients"})
+	require.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	uploaded, err := client.UploadFile(ctx, &storagepb.UploadFileRequest{
+		UserId:   userID,
+		FileName: "contract.docx",
+		FileSize: 4,
+		Content:  []byte("data"),
+		FolderId: acme.GetFolder().GetFolderId(),
+	})
+	require.NoError(t, err)
+
+	renamed, err := client.RenameFolder(ctx, &storagepb.RenameFolderRequest{
+		UserId:   userID,
+		FolderId: acme.GetFolder().GetFolderId(),
+		Name:     "Acme Corp",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Acme Corp", renamed.GetFolder().GetName())
+
+	listing, err := client.ListFolder(ctx, &storagepb.ListFolderRequest{UserId: userID, FolderId: acme.GetFolder().GetFolderId()})
+	require.NoError(t, err)
+	require.Empty(t, listing.GetFolders())
+	require.Len(t, listing.GetFiles(), 1)
+	require.Equal(t, uploaded.GetFileId(), listing.GetFiles()[0].GetFileId())
+	require.Equal(t, acme.GetFolder().GetFolderId(), listing.GetFiles()[0].GetFolderId())
+
+	moved, err := client.MoveFile(ctx, &storagepb.MoveFileRequest{UserId: userID, FileId: uploaded.GetFileId()})
+	require.NoError(t, err)
+	require.Empty(t, moved.GetFile().GetFolderId())
+
+	_, err = client.MoveFolder(ctx, &storagepb.MoveFolderRequest{
+		UserId:   userID,
+		FolderId: clients.GetFolder().GetFolderId(),
+		ParentId: acme.GetFolder().GetFolderId(),
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	movedFolder, err := client.MoveFolder(ctx, &storagepb.MoveFolderRequest{UserId: userID, FolderId: acme.GetFolder().GetFolderId()})
+	require.NoError(t, err)
+	require.Empty(t, movedFolder.GetFolder().GetParentId())
+
+	root, err := client.ListFolder(ctx, &storagepb.ListFolderRequest{UserId: userID})
+	require.NoError(t, err)
+	require.Len(t, root.GetFolders(), 2)
+	require.Len(t, root.GetFiles(), 1)
+
+	_, err = client.MoveFile(ctx, &storagepb.MoveFileRequest{
+		UserId:   userID,
+		FileId:   uploaded.GetFileId(),
+		FolderId: clients.GetFolder().GetFolderId(),
+	})
+	require.NoError(t, err)
+
+	_, err = client.DeleteFolder(ctx, &storagepb.DeleteFolderRequest{UserId: userID, FolderId: clients.GetFolder().GetFolderId()})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	deleted, err := client.DeleteFolder(ctx, &storagepb.DeleteFolderRequest{
+		UserId:    userID,
+		FolderId:  clients.GetFolder().GetFolderId(),
+		Recursive: true,
+	})
+	require.NoError(t, err)
+	require.EqualValues(t, 1, deleted.GetDeletedFolders())
+	require.EqualValues(t, 1, deleted.GetDeletedFiles())
+}
+
+func TestHandler_Folders_InvalidAndMissing(t *testing.T) {
+	dm, fm, sm := newTestManagers(t)
+	client := newTestStorageClient(t, dm, fm, sm)
+	ctx := context.Background()
+	userID := uuid.NewString()
+
+	_, err := client.CreateFolder(ctx, &storagepb.CreateFolderRequest{UserId: "bad", Name: "x"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.CreateFolder(ctx, &storagepb.CreateFolderRequest{UserId: userID, Name: "a/b"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.CreateFolder(ctx, &storagepb.CreateFolderRequest{UserId: userID, Name: "x", ParentId: "bad"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.RenameFolder(ctx, &storagepb.RenameFolderRequest{UserId: userID, FolderId: uuid.NewString(), Name: "x"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = client.ListFolder(ctx, &storagepb.ListFolderRequest{UserId: userID, FolderId: uuid.NewString()})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = client.DeleteFolder(ctx, &storagepb.DeleteFolderRequest{UserId: userID, FolderId: "bad"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.MoveFile(ctx, &storagepb.MoveFileRequest{UserId: userID, FileId: uuid.NewString()})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = client.UploadFile(ctx, &storagepb.UploadFileRequest{
+		UserId:   userID,
+		FileName: "file.txt",
+		FolderId: uuid.NewString(),
+	})
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"context"
+	"time"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
+	"github.com/a1y/doc-formatter/internal/storage/manager/document"
+	"github.com/google/uuid"
+)
+
+func (h *Handler) AddFileTags(ctx context.Context, req *storagepb.AddFileTagsRequest) (*storagepb.AddFileTagsResponse, error) {
+	userID, fileID, err := parseFileIDs(req.UserId, req.FileId)
+	if err != nil {
+		return nil, err
+	}
+
+	document, err := h.documentManager.AddTags(ctx, userID, fileID, req.Tags)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &storagepb.AddFileTagsResponse{File: toFileInfo(document)}, nil
+}
+
+func (h *Handler) RemoveFileTags(ctx context.Context, req *storagepb.RemoveFileTagsRequest) (*storagepb.RemoveFileTagsResponse, error) {
+	userID, fileID, err := parseFileIDs(req.UserId, req.FileId)
+	if err != nil {
+		return nil, err
+	}
+
+	document, err := h.documentManager.RemoveTags(ctx, userID, fileID, req.Tags)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &storagepb.RemoveFileTagsResponse{File: toFileInfo(document)}, nil
+}
+
+func (h *Handler) SetFileMetadata(ctx context.Context, req *storagepb.SetFileMetadataRequest) (*storagepb.SetFileMetadataResponse, error) {
+	userID, fileID, err := parseFileIDs(req.UserId, req.FileId)
+	if err != nil {
+		return nil, err
+	}
+
+	document, err := h.documentManager.SetMetadata(ctx, userID, fileID, req.Metadata)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &storagepb.SetFileMetadataResponse{File: toFileInfo(document)}, nil
+}
+
+func (h *Handler) RemoveFileMetadata(ctx context.Context, req *storagepb.RemoveFileMetadataRequest) (*storagepb.RemoveFileMetadataResponse, error) {
+	userID, fileID, err := parseFileIDs(req.UserId, req.FileId)
+	if err != nil {
+		return nil, err
+	}
+
+	document, err := h.documentManager.RemoveMetadata(ctx, userID, fileID, req.Keys)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &storagepb.RemoveFileMetadataResponse{File: toFileInfo(document)}, nil
+}
+
+func (h *Handler) ListFiles(ctx context.Context, req *storagepb.ListFilesRequest) (*storagepb.ListFilesResponse, error) {
+	userID, err := parseID("user id", req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	folderID, err := parseOptionalID("folder id", req.FolderId)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := entity.DocumentFilter{
+		FolderID:    folderID,
+		Tags:        req.Tags,
+		Metadata:    req.Metadata,
+		ContentType: req.ContentType,
+		MinSize:     req.MinSize,
+		MaxSize:     req.MaxSize,
+	}
+	if req.CreatedAfterUnix != nil {
+		after := time.Unix(*req.CreatedAfterUnix, 0)
+		filter.CreatedAfter = &after
+	}
+	if req.CreatedBeforeUnix != nil {
+		before := time.Unix(*req.CreatedBeforeUnix, 0)
+		filter.CreatedBefore = &before
+	}
+
+	page := document.PageRequest{
+		Size:       int(req.PageSize),
+		Token:      req.PageToken,
+		Sort:       entity.DocumentSort(req.Sort),
+		Descending: req.Descending,
+	}
+
+	documents, nextPageToken, err := h.documentManager.ListDocuments(ctx, userID, filter, page)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	resp := &storagepb.ListFilesResponse{
+		Files:         make([]*storagepb.FileInfo, len(documents)),
+		NextPageToken: nextPageToken,
+	}
+	for i, document := range documents {
+		resp.Files[i] = toFileInfo(document)
+	}
+	return resp, nil
+}
+
+// parseFileIDs parses the user and file ids of a request on a single file.
+func parseFileIDs(userID, fileID string) (uuid.UUID, uuid.UUID, error) {
+	user, err := parseID("user id", userID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+	file, err := parseID("file id", fileID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+	return user, file, nil
+}
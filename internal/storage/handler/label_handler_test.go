@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestHandler_Labels(t *testing.T) {
+	dm, fm, sm := newTestManagers(t)
+	client := newTestStorageClient(t, dm, fm, sm)
+	ctx := context.Background()
+	userID := uuid.NewString()
+
+	uploaded, err := client.UploadFile(ctx, &storagepb.UploadFileRequest{
+		UserId:   userID,
+		FileName: "contract.pdf",
+		FileSize: 4,
+		Content:  []byte("data"),
+	})
+	require.NoError(t, err)
+
+	tagged, err := client.AddFileTags(ctx, &storagepb.AddFileTagsRequest{
+		UserId: userID,
+		FileId: uploaded.GetFileId(),
+		Tags:   []string{"client:acme", "status:draft"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"client:acme", "status:draft"}, tagged.GetFile().GetTags())
+	require.Equal(t, "application/pdf", tagged.GetFile().GetContentType())
+	require.WithinDuration(t, time.Now(), time.Unix(tagged.GetFile().GetCreatedAtUnix(), 0), time.Minute)
+
+	untagged, err := client.RemoveFileTags(ctx, &storagepb.RemoveFileTagsRequest{
+		UserId: userID,
+		FileId: uploaded.GetFileId(),
+		Tags:   []string{"status:draft"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"client:acme"}, untagged.GetFile().GetTags())
+
+	withMetadata, err := client.SetFileMetadata(ctx, &storagepb.SetFileMetadataRequest{
+		UserId:   userID,
+		FileId:   uploaded.GetFileId(),
+		Metadata: map[string]string{"owner": "legal", "year": "2026"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"owner": "legal", "year": "2026"}, withMetadata.GetFile().GetMetadata())
+
+	withoutOwner, err := client.RemoveFileMetadata(ctx, &storagepb.RemoveFileMetadataRequest{
+		UserId: userID,
+		FileId: uploaded.GetFileId(),
+		Keys:   []string{"owner"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"year": "2026"}, withoutOwner.GetFile().GetMetadata())
+
+	_, err = client.AddFileTags(ctx, &storagepb.AddFileTagsRequest{UserId: userID, FileId: uploaded.GetFileId(), Tags: []string{""}})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.SetFileMetadata(ctx, &storagepb.SetFileMetadataRequest{UserId: userID, FileId: "not-a-uuid"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.RemoveFileTags(ctx, &storagepb.RemoveFileTagsRequest{UserId: uuid.NewString(), FileId: uploaded.GetFileId()})
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestHandler_ListFiles(t *testing.T) {
+	dm, fm, sm := newTestManagers(t)
+	client := newTestStorageClient(t, dm, fm, sm)
+	ctx := context.Background()
+	userID := uuid.NewString()
+
+	for _, name := range []string{"notes.txt", "scan.png"} {
+		_, err := client.UploadFile(ctx, &storagepb.UploadFileRequest{
+			UserId:   userID,
+			FileName: name,
+			FileSize: int64(len(name)),
+			Content:  []byte(name),
+		})
+		require.NoError(t, err)
+	}
+
+	listing, err := client.ListFiles(ctx, &storagepb.ListFilesRequest{UserId: userID})
+	require.NoError(t, err)
+	require.Len(t, listing.GetFiles(), 2)
+
+	listing, err = client.ListFiles(ctx, &storagepb.ListFilesRequest{
+		UserId:           userID,
+		ContentType:      "image/*",
+		MinSize:          proto.Int64(1),
+		CreatedAfterUnix: proto.Int64(time.Now().Add(-time.Hour).Unix()),
+	})
+	require.NoError(t, err)
+	require.Len(t, listing.GetFiles(), 1)
+	require.Equal(t, "scan.png", listing.GetFiles()[0].GetFileName())
+
+	listing, err = client.ListFiles(ctx, &storagepb.ListFilesRequest{
+		UserId:            userID,
+		CreatedBeforeUnix: proto.Int64(time.Now().Add(-time.Hour).Unix()),
+	})
+	require.NoError(t, err)
+	require.Empty(t, listing.GetFiles())
+
+	_, err = client.ListFiles(ctx, &storagepb.ListFilesRequest{UserId: userID, MinSize: proto.Int64(10), MaxSize: proto.Int64(1)})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.ListFiles(ctx, &storagepb.ListFilesRequest{UserId: userID, FolderId: uuid.NewString()})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	listing, err = client.ListFiles(ctx, &storagepb.ListFilesRequest{UserId: userID, PageSize: 1, Sort: "size", Descending: true})
+	require.NoError(t, err)
+	require.Len(t, listing.GetFiles(), 1)
+	require.Equal(t, "notes.txt", listing.GetFiles()[0].GetFileName())
+	require.NotEmpty(t, listing.GetNextPageToken())
+
+	listing, err = client.ListFiles(ctx, &storagepb.ListFilesRequest{
+		UserId:     userID,
+		PageSize:   1,
+		PageToken:  listing.GetNextPageToken(),
+		Sort:       "size",
+		Descending: true,
+	})
+	require.NoError(t, err)
+	require.Len(t, listing.GetFiles(), 1)
+	require.Equal(t, "scan.png", listing.GetFiles()[0].GetFileName())
+	require.Empty(t, listing.GetNextPageToken())
+
+	_, err = client.ListFiles(ctx, &storagepb.ListFilesRequest{UserId: userID, Sort: "rating"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.ListFiles(ctx, &storagepb.ListFilesRequest{UserId: userID, PageToken: "garbage"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"context"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
+	"github.com/google/uuid"
+)
+
+func (h *Handler) MergeDocuments(ctx context.Context, req *storagepb.MergeDocumentsRequest) (*storagepb.MergeDocumentsResponse, error) {
+	userID, err := parseID("user id", req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	sourceIDs := make([]uuid.UUID, len(req.SourceFileIds))
+	for i, id := range req.SourceFileIds {
+		if sourceIDs[i], err = parseID("source file id", id); err != nil {
+			return nil, err
+		}
+	}
+	folderID, err := parseOptionalID("folder id", req.FolderId)
+	if err != nil {
+		return nil, err
+	}
+
+	document, err := h.documentManager.MergeDocuments(ctx, userID, sourceIDs, req.FileName, folderID, req.PageBreaks)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &storagepb.MergeDocumentsResponse{File: toFileInfo(document)}, nil
+}
+
+func (h *Handler) SplitDocument(ctx context.Context, req *storagepb.SplitDocumentRequest) (*storagepb.SplitDocumentResponse, error) {
+	userID, fileID, err := parseFileIDs(req.UserId, req.FileId)
+	if err != nil {
+		return nil, err
+	}
+	folderID, err := parseOptionalID("folder id", req.FolderId)
+	if err != nil {
+		return nil, err
+	}
+
+	documents, err := h.documentManager.SplitDocument(ctx, userID, fileID, int(req.HeadingLevel), folderID)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	resp := &storagepb.SplitDocumentResponse{Files: make([]*storagepb.FileInfo, len(documents))}
+	for i, document := range documents {
+		resp.Files[i] = toFileInfo(document)
+	}
+	return resp, nil
+}
+
+func (h *Handler) GetProvenance(ctx context.Context, req *storagepb.GetProvenanceRequest) (*storagepb.GetProvenanceResponse, error) {
+	userID, fileID, err := parseFileIDs(req.UserId, req.FileId)
+	if err != nil {
+		return nil, err
+	}
+
+	provenance, err := h.documentManager.GetProvenance(ctx, userID, fileID)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &storagepb.GetProvenanceResponse{
+		Sources: toProvenanceLinks(provenance.Sources),
+		Derived: toProvenanceLinks(provenance.Derived),
+	}, nil
+}
+
+func toProvenanceLinks(links []*entity.ProvenanceLink) []*storagepb.ProvenanceLink {
+	out := make([]*storagepb.ProvenanceLink, len(links))
+	for i, link := range links {
+		out[i] = &storagepb.ProvenanceLink{
+			File:          toFileInfo(link.Document),
+			Operation:     link.Link.Operation,
+			Position:      int32(link.Link.Position),
+			CreatedAtUnix: link.Link.CreatedAt.Unix(),
+		}
+	}
+	return out
+}
@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestHandler_MergeAndSplitDocuments(t *testing.T) {
+	dm, fm, sm := newTestManagers(t)
+	client := newTestStorageClient(t, dm, fm, sm)
+	ctx := context.Background()
+	userID := uuid.NewString()
+
+	upload := func(name, content string) string {
+		resp, err := client.UploadFile(ctx, &storagepb.UploadFileRequest{
+			UserId:   userID,
+			FileName: name,
+			FileSize: int64(len(content)),
+			Content:  []byte(content),
+		})
+		require.NoError(t, err)
+		return resp.GetFileId()
+	}
+	introID := upload("intro.md", "# Intro\n\nHello.\n")
+	methodsID := upload("methods.md", "# Methods\n\nWe measured.\n")
+
+	merged, err := client.MergeDocuments(ctx, &storagepb.MergeDocumentsRequest{
+		UserId:        userID,
+		SourceFileIds: []string{introID, methodsID},
+		FileName:      "report.md",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "report.md", merged.GetFile().GetFileName())
+	require.Equal(t, "text/markdown", merged.GetFile().GetContentType())
+
+	split, err := client.SplitDocument(ctx, &storagepb.SplitDocumentRequest{UserId: userID, FileId: merged.GetFile().GetFileId(), HeadingLevel: 1})
+	require.NoError(t, err)
+	require.Len(t, split.GetFiles(), 2)
+	require.Equal(t, "01 Intro.md", split.GetFiles()[0].GetFileName())
+	require.Equal(t, "02 Methods.md", split.GetFiles()[1].GetFileName())
+
+	provenance, err := client.GetProvenance(ctx, &storagepb.GetProvenanceRequest{UserId: userID, FileId: merged.GetFile().GetFileId()})
+	require.NoError(t, err)
+	require.Len(t, provenance.GetSources(), 2)
+	require.Equal(t, introID, provenance.GetSources()[0].GetFile().GetFileId())
+	require.Equal(t, "merge", provenance.GetSources()[0].GetOperation())
+	require.Len(t, provenance.GetDerived(), 2)
+	require.Equal(t, "split", provenance.GetDerived()[0].GetOperation())
+	require.NotZero(t, provenance.GetDerived()[0].GetCreatedAtUnix())
+
+	_, err = client.MergeDocuments(ctx, &storagepb.MergeDocumentsRequest{UserId: userID, SourceFileIds: []string{introID}})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = client.MergeDocuments(ctx, &storagepb.MergeDocumentsRequest{UserId: userID, SourceFileIds: []string{introID, "not-a-uuid"}})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = client.SplitDocument(ctx, &storagepb.SplitDocumentRequest{UserId: userID, FileId: introID, HeadingLevel: 10})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = client.SplitDocument(ctx, &storagepb.SplitDocumentRequest{UserId: userID, FileId: introID, HeadingLevel: 1, FolderId: uuid.NewString()})
+	require.Equal(t, codes.NotFound, status.Code(err))
+	_, err = client.GetProvenance(ctx, &storagepb.GetProvenanceRequest{UserId: uuid.NewString(), FileId: introID})
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"context"
+	"runtime/debug"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RecoveryUnaryInterceptor turns a panic while handling a call into an
+// internal error, so that one bad document does not take the service down.
+func RecoveryUnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	defer recoverCall(info.FullMethod, &err)
+	return handler(ctx, req)
+}
+
+// RecoveryStreamInterceptor is RecoveryUnaryInterceptor for streaming calls.
+func RecoveryStreamInterceptor(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer recoverCall(info.FullMethod, &err)
+	return handler(srv, stream)
+}
+
+// recoverCall recovers from a panic of method and sets err to an internal
+// error in its place.
+func recoverCall(method string, err *error) {
+	if r := recover(); r != nil {
+		logrus.Errorf("Panic in %s: %v\n%s", method, r, debug.Stack())
+		*err = status.Error(codes.Internal, "internal error")
+	}
+}
@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRecoveryUnaryInterceptor(t *testing.T) {
+	t.Parallel()
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/storage.StorageService/StampFile"}
+	resp, err := RecoveryUnaryInterceptor(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+		panic("index out of range")
+	})
+	require.Nil(t, resp)
+	require.Equal(t, codes.Internal, status.Code(err))
+
+	resp, err = RecoveryUnaryInterceptor(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+		return "ok", nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, "ok", resp)
+}
+
+func TestRecoveryStreamInterceptor(t *testing.T) {
+	t.Parallel()
+
+	info := &grpc.StreamServerInfo{FullMethod: "/storage.StorageService/StampFile"}
+	err := RecoveryStreamInterceptor(nil, nil, info, func(srv any, stream grpc.ServerStream) error {
+		panic("index out of range")
+	})
+	require.Equal(t, codes.Internal, status.Code(err))
+}
@@ -0,0 +1,28 @@
+package handler
+
+import (
+	"context"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+)
+
+func (h *Handler) SearchDocuments(ctx context.Context, req *storagepb.SearchDocumentsRequest) (*storagepb.SearchDocumentsResponse, error) {
+	userID, err := parseID("user id", req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	hits, err := h.documentManager.SearchDocuments(ctx, userID, req.Query, int(req.Limit))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	resp := &storagepb.SearchDocumentsResponse{Hits: make([]*storagepb.SearchHit, len(hits))}
+	for i, hit := range hits {
+		resp.Hits[i] = &storagepb.SearchHit{
+			File:    toFileInfo(hit.Document),
+			Rank:    hit.Rank,
+			Snippet: hit.Snippet,
+		}
+	}
+	return resp, nil
+}
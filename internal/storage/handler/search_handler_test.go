@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestHandler_SearchDocuments_InvalidArguments(t *testing.T) {
+	dm, fm, sm := newTestManagers(t)
+	client := newTestStorageClient(t, dm, fm, sm)
+	ctx := context.Background()
+
+	_, err := client.SearchDocuments(ctx, &storagepb.SearchDocumentsRequest{UserId: "not-a-uuid", Query: "report"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.SearchDocuments(ctx, &storagepb.SearchDocumentsRequest{UserId: uuid.NewString(), Query: "  "})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.SearchDocuments(ctx, &storagepb.SearchDocumentsRequest{UserId: uuid.NewString(), Query: strings.Repeat("a", 257)})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.SearchDocuments(ctx, &storagepb.SearchDocumentsRequest{UserId: uuid.NewString(), Query: "report", Limit: -1})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
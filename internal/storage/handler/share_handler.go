@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"context"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
+	"github.com/a1y/doc-formatter/internal/storage/manager/share"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Documents are called files in the API.
+const (
+	resourceTypeFile   = "file"
+	resourceTypeFolder = "folder"
+)
+
+func (h *Handler) Share(ctx context.Context, req *storagepb.ShareRequest) (*storagepb.ShareResponse, error) {
+	userID, err := parseID("user id", req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	resourceType, resourceID, err := parseResource(req.ResourceType, req.ResourceId)
+	if err != nil {
+		return nil, err
+	}
+	granteeID, err := parseID("grantee id", req.GranteeId)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := h.shareManager.Share(ctx, userID, resourceType, resourceID, granteeID, entity.Role(req.Role))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &storagepb.ShareResponse{Share: toShareInfo(entry)}, nil
+}
+
+func (h *Handler) Unshare(ctx context.Context, req *storagepb.UnshareRequest) (*storagepb.UnshareResponse, error) {
+	userID, err := parseID("user id", req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	resourceType, resourceID, err := parseResource(req.ResourceType, req.ResourceId)
+	if err != nil {
+		return nil, err
+	}
+	granteeID, err := parseID("grantee id", req.GranteeId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.shareManager.Unshare(ctx, userID, resourceType, resourceID, granteeID); err != nil {
+		return nil, toStatusError(err)
+	}
+	return &storagepb.UnshareResponse{}, nil
+}
+
+func (h *Handler) ListShares(ctx context.Context, req *storagepb.ListSharesRequest) (*storagepb.ListSharesResponse, error) {
+	userID, err := parseID("user id", req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	resourceType, resourceID, err := parseResource(req.ResourceType, req.ResourceId)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := h.shareManager.ListShares(ctx, userID, resourceType, resourceID)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	resp := &storagepb.ListSharesResponse{Shares: make([]*storagepb.ShareInfo, len(entries))}
+	for i, entry := range entries {
+		resp.Shares[i] = toShareInfo(entry)
+	}
+	return resp, nil
+}
+
+func (h *Handler) ListSharedWithMe(ctx context.Context, req *storagepb.ListSharedWithMeRequest) (*storagepb.ListSharedWithMeResponse, error) {
+	userID, err := parseID("user id", req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	shared, err := h.shareManager.ListSharedWithMe(ctx, userID)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	resp := &storagepb.ListSharedWithMeResponse{Items: make([]*storagepb.SharedItem, len(shared))}
+	for i, resource := range shared {
+		resp.Items[i] = toSharedItem(resource)
+	}
+	return resp, nil
+}
+
+// parseResource parses the resource a share request is about.
+func parseResource(resourceType, resourceID string) (entity.ResourceType, uuid.UUID, error) {
+	var parsed entity.ResourceType
+	switch resourceType {
+	case resourceTypeFile:
+		parsed = entity.ResourceDocument
+	case resourceTypeFolder:
+		parsed = entity.ResourceFolder
+	default:
+		return "", uuid.Nil, status.Error(codes.InvalidArgument, "invalid resource type")
+	}
+	id, err := parseID("resource id", resourceID)
+	if err != nil {
+		return "", uuid.Nil, err
+	}
+	return parsed, id, nil
+}
+
+func toShareInfo(entry *entity.ACLEntry) *storagepb.ShareInfo {
+	resourceType := resourceTypeFolder
+	if entry.ResourceType == entity.ResourceDocument {
+		resourceType = resourceTypeFile
+	}
+	return &storagepb.ShareInfo{
+		ResourceType:  resourceType,
+		ResourceId:    entry.ResourceID.String(),
+		OwnerId:       entry.OwnerID.String(),
+		UserId:        entry.UserID.String(),
+		Role:          string(entry.Role),
+		CreatedAtUnix: entry.CreatedAt.Unix(),
+	}
+}
+
+func toSharedItem(resource *share.SharedResource) *storagepb.SharedItem {
+	item := &storagepb.SharedItem{Share: toShareInfo(resource.Entry)}
+	if resource.Document != nil {
+		item.File = toFileInfo(resource.Document)
+	}
+	if resource.Folder != nil {
+		item.Folder = toFolder(resource.Folder)
+	}
+	return item
+}
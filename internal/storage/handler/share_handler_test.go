@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestHandler_Share(t *testing.T) {
+	dm, fm, sm := newTestManagers(t)
+	client := newTestStorageClient(t, dm, fm, sm)
+	ctx := context.Background()
+	ownerID, granteeID := uuid.NewString(), uuid.NewString()
+
+	folder, err := client.CreateFolder(ctx, &storagepb.CreateFolderRequest{UserId: ownerID, Name: "Clients"})
+	require.NoError(t, err)
+	uploaded, err := client.UploadFile(ctx, &storagepb.UploadFileRequest{
+		UserId:   ownerID,
+		FolderId: folder.GetFolder().GetFolderId(),
+		FileName: "contract.txt",
+		FileSize: 4,
+		Content:  []byte("data"),
+	})
+	require.NoError(t, err)
+
+	shared, err := client.Share(ctx, &storagepb.ShareRequest{
+		UserId:       ownerID,
+		ResourceType: "folder",
+		ResourceId:   folder.GetFolder().GetFolderId(),
+		GranteeId:    granteeID,
+		Role:         "viewer",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "folder", shared.GetShare().GetResourceType())
+	require.Equal(t, ownerID, shared.GetShare().GetOwnerId())
+	require.Equal(t, "viewer", shared.GetShare().GetRole())
+
+	// The grantee can read what the folder holds, but not edit it.
+	stream, err := client.DownloadFile(ctx, &storagepb.DownloadFileRequest{UserId: granteeID, FileId: uploaded.GetFileId()})
+	require.NoError(t, err)
+	_, content, err := receiveAll(stream)
+	require.NoError(t, err)
+	require.Equal(t, []byte("data"), content)
+
+	listing, err := client.ListFolder(ctx, &storagepb.ListFolderRequest{UserId: granteeID, FolderId: folder.GetFolder().GetFolderId()})
+	require.NoError(t, err)
+	require.Len(t, listing.GetFiles(), 1)
+
+	_, err = client.AddFileTags(ctx, &storagepb.AddFileTagsRequest{UserId: granteeID, FileId: uploaded.GetFileId(), Tags: []string{"signed"}})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = client.Share(ctx, &storagepb.ShareRequest{
+		UserId:       ownerID,
+		ResourceType: "file",
+		ResourceId:   uploaded.GetFileId(),
+		GranteeId:    granteeID,
+		Role:         "editor",
+	})
+	require.NoError(t, err)
+	_, err = client.AddFileTags(ctx, &storagepb.AddFileTagsRequest{UserId: granteeID, FileId: uploaded.GetFileId(), Tags: []string{"signed"}})
+	require.NoError(t, err)
+
+	shares, err := client.ListShares(ctx, &storagepb.ListSharesRequest{UserId: ownerID, ResourceType: "file", ResourceId: uploaded.GetFileId()})
+	require.NoError(t, err)
+	require.Len(t, shares.GetShares(), 1)
+	require.Equal(t, granteeID, shares.GetShares()[0].GetUserId())
+
+	withMe, err := client.ListSharedWithMe(ctx, &storagepb.ListSharedWithMeRequest{UserId: granteeID})
+	require.NoError(t, err)
+	require.Len(t, withMe.GetItems(), 2)
+	require.Equal(t, "contract.txt", withMe.GetItems()[0].GetFile().GetFileName())
+	require.Nil(t, withMe.GetItems()[0].GetFolder())
+	require.Equal(t, "Clients", withMe.GetItems()[1].GetFolder().GetName())
+
+	_, err = client.Unshare(ctx, &storagepb.UnshareRequest{
+		UserId:       ownerID,
+		ResourceType: "folder",
+		ResourceId:   folder.GetFolder().GetFolderId(),
+		GranteeId:    granteeID,
+	})
+	require.NoError(t, err)
+	_, err = client.Unshare(ctx, &storagepb.UnshareRequest{
+		UserId:       ownerID,
+		ResourceType: "folder",
+		ResourceId:   folder.GetFolder().GetFolderId(),
+		GranteeId:    granteeID,
+	})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = client.ListFolder(ctx, &storagepb.ListFolderRequest{UserId: granteeID, FolderId: folder.GetFolder().GetFolderId()})
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestHandler_Share_Errors(t *testing.T) {
+	dm, fm, sm := newTestManagers(t)
+	client := newTestStorageClient(t, dm, fm, sm)
+	ctx := context.Background()
+	ownerID := uuid.NewString()
+
+	uploaded, err := client.UploadFile(ctx, &storagepb.UploadFileRequest{UserId: ownerID, FileName: "a.txt", Content: []byte("a")})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		req  *storagepb.ShareRequest
+		code codes.Code
+	}{
+		{
+			name: "unknown resource type",
+			req:  &storagepb.ShareRequest{UserId: ownerID, ResourceType: "drive", ResourceId: uploaded.GetFileId(), GranteeId: uuid.NewString(), Role: "viewer"},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "invalid grantee",
+			req:  &storagepb.ShareRequest{UserId: ownerID, ResourceType: "file", ResourceId: uploaded.GetFileId(), GranteeId: "bob", Role: "viewer"},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "unknown role",
+			req:  &storagepb.ShareRequest{UserId: ownerID, ResourceType: "file", ResourceId: uploaded.GetFileId(), GranteeId: uuid.NewString(), Role: "admin"},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "shared with the owner",
+			req:  &storagepb.ShareRequest{UserId: ownerID, ResourceType: "file", ResourceId: uploaded.GetFileId(), GranteeId: ownerID, Role: "viewer"},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "file of another user",
+			req:  &storagepb.ShareRequest{UserId: uuid.NewString(), ResourceType: "file", ResourceId: uploaded.GetFileId(), GranteeId: uuid.NewString(), Role: "viewer"},
+			code: codes.NotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.Share(ctx, tt.req)
+			require.Equal(t, tt.code, status.Code(err))
+		})
+	}
+
+	_, err = client.ListSharedWithMe(ctx, &storagepb.ListSharedWithMeRequest{UserId: "not-a-uuid"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
+	"github.com/a1y/doc-formatter/internal/storage/manager/document"
+	"github.com/a1y/doc-formatter/pkg/throttle"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func (h *Handler) CreateShareLink(ctx context.Context, req *storagepb.CreateShareLinkRequest) (*storagepb.CreateShareLinkResponse, error) {
+	userID, fileID, err := parseFileIDs(req.UserId, req.FileId)
+	if err != nil {
+		return nil, err
+	}
+	if req.MaxDownloads < 0 {
+		return nil, status.Error(codes.InvalidArgument, "max downloads must not be negative")
+	}
+
+	opts := document.ShareLinkOptions{
+		Password:     req.Password,
+		MaxDownloads: int(req.MaxDownloads),
+	}
+	if req.ExpiresAtUnix != 0 {
+		expiresAt := time.Unix(req.ExpiresAtUnix, 0)
+		opts.ExpiresAt = &expiresAt
+	}
+	link, token, err := h.documentManager.CreateShareLink(ctx, userID, fileID, opts)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &storagepb.CreateShareLinkResponse{Link: toShareLinkInfo(link), Token: token}, nil
+}
+
+func (h *Handler) ListShareLinks(ctx context.Context, req *storagepb.ListShareLinksRequest) (*storagepb.ListShareLinksResponse, error) {
+	userID, fileID, err := parseFileIDs(req.UserId, req.FileId)
+	if err != nil {
+		return nil, err
+	}
+
+	links, err := h.documentManager.ListShareLinks(ctx, userID, fileID)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	resp := &storagepb.ListShareLinksResponse{Links: make([]*storagepb.ShareLinkInfo, len(links))}
+	for i, link := range links {
+		resp.Links[i] = toShareLinkInfo(link)
+	}
+	return resp, nil
+}
+
+func (h *Handler) RevokeShareLink(ctx context.Context, req *storagepb.RevokeShareLinkRequest) (*storagepb.RevokeShareLinkResponse, error) {
+	userID, err := parseID("user id", req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	linkID, err := parseID("link id", req.LinkId)
+	if err != nil {
+		return nil, err
+	}
+
+	link, err := h.documentManager.RevokeShareLink(ctx, userID, linkID)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &storagepb.RevokeShareLinkResponse{Link: toShareLinkInfo(link)}, nil
+}
+
+func (h *Handler) DownloadSharedFile(req *storagepb.DownloadSharedFileRequest, stream grpc.ServerStreamingServer[storagepb.DownloadFileResponse]) error {
+	if req.Token == "" {
+		return status.Error(codes.InvalidArgument, "token is required")
+	}
+
+	doc, content, err := h.documentManager.DownloadSharedDocument(stream.Context(), document.LinkDownload{
+		Token:      req.Token,
+		Password:   req.Password,
+		RemoteAddr: req.RemoteAddr,
+		UserAgent:  req.UserAgent,
+	})
+	var lockedErr *throttle.LockedError
+	if errors.As(err, &lockedErr) {
+		return lockedError(lockedErr)
+	}
+	if err != nil {
+		return toStatusError(err)
+	}
+	defer content.Close()
+
+	return sendDocument(stream, doc, content)
+}
+
+// lockedError maps a share link lockout to a ResourceExhausted status with
+// the time to wait before retrying as its retry info.
+func lockedError(err *throttle.LockedError) error {
+	st, detailsErr := status.New(codes.ResourceExhausted, err.Error()).
+		WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(err.RetryAfter)})
+	if detailsErr != nil {
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+	return st.Err()
+}
+
+func toShareLinkInfo(link *entity.ShareLink) *storagepb.ShareLinkInfo {
+	info := &storagepb.ShareLinkInfo{
+		LinkId:        link.ID.String(),
+		FileId:        link.DocumentID.String(),
+		OwnerId:       link.OwnerID.String(),
+		HasPassword:   link.HasPassword(),
+		MaxDownloads:  int32(link.MaxDownloads),
+		DownloadCount: int32(link.DownloadCount),
+		CreatedAtUnix: link.CreatedAt.Unix(),
+	}
+	if link.ExpiresAt != nil {
+		info.ExpiresAtUnix = link.ExpiresAt.Unix()
+	}
+	if link.RevokedAt != nil {
+		info.RevokedAtUnix = link.RevokedAt.Unix()
+	}
+	return info
+}
@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/a1y/doc-formatter/internal/storage/manager/document"
+	"github.com/a1y/doc-formatter/pkg/throttle"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestHandler_ShareLinks(t *testing.T) {
+	dm, fm, sm := newTestManagers(t)
+	client := newTestStorageClient(t, dm, fm, sm)
+	ctx := context.Background()
+	userID := uuid.NewString()
+
+	uploaded, err := client.UploadFile(ctx, &storagepb.UploadFileRequest{
+		UserId:   userID,
+		FileName: "offer.pdf",
+		FileSize: 5,
+		Content:  []byte("offer"),
+	})
+	require.NoError(t, err)
+
+	expiresAt := time.Now().Add(time.Hour).Unix()
+	created, err := client.CreateShareLink(ctx, &storagepb.CreateShareLinkRequest{
+		UserId:        userID,
+		FileId:        uploaded.GetFileId(),
+		Password:      "s3cret",
+		ExpiresAtUnix: expiresAt,
+		MaxDownloads:  1,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, created.GetToken())
+	require.True(t, created.GetLink().GetHasPassword())
+	require.Equal(t, expiresAt, created.GetLink().GetExpiresAtUnix())
+	require.EqualValues(t, 1, created.GetLink().GetMaxDownloads())
+
+	stream, err := client.DownloadSharedFile(ctx, &storagepb.DownloadSharedFileRequest{Token: created.GetToken(), Password: "wrong"})
+	require.NoError(t, err)
+	_, _, err = receiveAll(stream)
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	stream, err = client.DownloadSharedFile(ctx, &storagepb.DownloadSharedFileRequest{
+		Token:      created.GetToken(),
+		Password:   "s3cret",
+		RemoteAddr: "192.0.2.1",
+		UserAgent:  "curl",
+	})
+	require.NoError(t, err)
+	header, content, err := receiveAll(stream)
+	require.NoError(t, err)
+	require.Equal(t, "offer.pdf", header.GetFileName())
+	require.Equal(t, []byte("offer"), content)
+
+	stream, err = client.DownloadSharedFile(ctx, &storagepb.DownloadSharedFileRequest{Token: created.GetToken(), Password: "s3cret"})
+	require.NoError(t, err)
+	_, _, err = receiveAll(stream)
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	listed, err := client.ListShareLinks(ctx, &storagepb.ListShareLinksRequest{UserId: userID, FileId: uploaded.GetFileId()})
+	require.NoError(t, err)
+	require.Len(t, listed.GetLinks(), 1)
+	require.EqualValues(t, 1, listed.GetLinks()[0].GetDownloadCount())
+
+	revoked, err := client.RevokeShareLink(ctx, &storagepb.RevokeShareLinkRequest{UserId: userID, LinkId: created.GetLink().GetLinkId()})
+	require.NoError(t, err)
+	require.NotZero(t, revoked.GetLink().GetRevokedAtUnix())
+
+	stream, err = client.DownloadSharedFile(ctx, &storagepb.DownloadSharedFileRequest{Token: "unknown"})
+	require.NoError(t, err)
+	_, _, err = receiveAll(stream)
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = client.CreateShareLink(ctx, &storagepb.CreateShareLinkRequest{UserId: userID, FileId: uploaded.GetFileId(), MaxDownloads: -1})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.CreateShareLink(ctx, &storagepb.CreateShareLinkRequest{UserId: userID, FileId: uploaded.GetFileId(), ExpiresAtUnix: 1})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.RevokeShareLink(ctx, &storagepb.RevokeShareLinkRequest{UserId: uuid.NewString(), LinkId: created.GetLink().GetLinkId()})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = client.RevokeShareLink(ctx, &storagepb.RevokeShareLinkRequest{UserId: userID, LinkId: "not-a-uuid"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestHandler_DownloadSharedFile_Throttled(t *testing.T) {
+	dm, fm, sm := newTestManagers(t)
+	dm.SetLinkThrottle(throttle.NewMemoryStore(), document.LinkThrottleConfig{MaxLinkFailures: 1, Lockout: time.Minute})
+	client := newTestStorageClient(t, dm, fm, sm)
+	ctx := context.Background()
+	userID := uuid.NewString()
+
+	uploaded, err := client.UploadFile(ctx, &storagepb.UploadFileRequest{
+		UserId:   userID,
+		FileName: "offer.pdf",
+		FileSize: 5,
+		Content:  []byte("offer"),
+	})
+	require.NoError(t, err)
+	created, err := client.CreateShareLink(ctx, &storagepb.CreateShareLinkRequest{UserId: userID, FileId: uploaded.GetFileId(), Password: "s3cret"})
+	require.NoError(t, err)
+
+	stream, err := client.DownloadSharedFile(ctx, &storagepb.DownloadSharedFileRequest{Token: created.GetToken(), Password: "wrong"})
+	require.NoError(t, err)
+	_, _, err = receiveAll(stream)
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	stream, err = client.DownloadSharedFile(ctx, &storagepb.DownloadSharedFileRequest{Token: created.GetToken(), Password: "s3cret"})
+	require.NoError(t, err)
+	_, _, err = receiveAll(stream)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	details := status.Convert(err).Details()
+	require.Len(t, details, 1)
+	retryInfo, ok := details[0].(*errdetails.RetryInfo)
+	require.True(t, ok)
+	require.InDelta(t, time.Minute, retryInfo.GetRetryDelay().AsDuration(), float64(time.Second))
+}
@@ -0,0 +1,28 @@
+package handler
+
+import (
+	"bytes"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/a1y/doc-formatter/internal/storage/util/stamp"
+	"google.golang.org/grpc"
+)
+
+func (h *Handler) StampFile(req *storagepb.StampFileRequest, stream grpc.ServerStreamingServer[storagepb.DownloadFileResponse]) error {
+	userID, fileID, err := parseFileIDs(req.UserId, req.FileId)
+	if err != nil {
+		return err
+	}
+
+	doc, content, err := h.documentManager.StampDocument(stream.Context(), userID, fileID, stamp.Stamp{
+		Watermark: req.Watermark,
+		Image:     req.Image,
+		Header:    req.Header,
+		Footer:    req.Footer,
+		Opacity:   req.Opacity,
+	}, req.UserLabel)
+	if err != nil {
+		return toStatusError(err)
+	}
+	return sendDocument(stream, doc, bytes.NewReader(content))
+}
@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const testStampPDF = "%PDF-1.4\n" +
+	"1 0 obj\n<< /Type /Catalog /Pages 2 0 R >>\nendobj\n" +
+	"2 0 obj\n<< /Type /Pages /Kids [3 0 R] /Count 1 >>\nendobj\n" +
+	"3 0 obj\n<< /Type /Page /Parent 2 0 R /MediaBox [0 0 200 200] >>\nendobj\n" +
+	"trailer\n<< /Root 1 0 R >>\n%%EOF\n"
+
+func TestHandler_StampFile(t *testing.T) {
+	dm, fm, sm := newTestManagers(t)
+	client := newTestStorageClient(t, dm, fm, sm)
+	ctx := context.Background()
+	userID := uuid.NewString()
+
+	upload := func(name, content string) string {
+		resp, err := client.UploadFile(ctx, &storagepb.UploadFileRequest{
+			UserId:   userID,
+			FileName: name,
+			FileSize: int64(len(content)),
+			Content:  []byte(content),
+		})
+		require.NoError(t, err)
+		return resp.GetFileId()
+	}
+	pdfID := upload("offer.pdf", testStampPDF)
+	notesID := upload("notes.md", "# Notes\n")
+
+	stream, err := client.StampFile(ctx, &storagepb.StampFileRequest{UserId: userID, FileId: pdfID, Watermark: "DRAFT for {user}", UserLabel: "ann@example.com"})
+	require.NoError(t, err)
+	header, content, err := receiveAll(stream)
+	require.NoError(t, err)
+	require.Equal(t, "offer.pdf", header.GetFileName())
+	require.EqualValues(t, len(content), header.GetFileSize())
+	require.Contains(t, string(content), "<"+hex.EncodeToString([]byte("DRAFT for ann@example.com"))+">")
+
+	tests := []struct {
+		req  *storagepb.StampFileRequest
+		code codes.Code
+	}{
+		{req: &storagepb.StampFileRequest{UserId: userID, FileId: pdfID}, code: codes.InvalidArgument},
+		{req: &storagepb.StampFileRequest{UserId: userID, FileId: pdfID, Watermark: "DRAFT", Opacity: 2}, code: codes.InvalidArgument},
+		{req: &storagepb.StampFileRequest{UserId: userID, FileId: pdfID, Image: []byte("not an image")}, code: codes.InvalidArgument},
+		{req: &storagepb.StampFileRequest{UserId: userID, FileId: "not-a-uuid", Watermark: "DRAFT"}, code: codes.InvalidArgument},
+		{req: &storagepb.StampFileRequest{UserId: userID, FileId: notesID, Watermark: "DRAFT"}, code: codes.FailedPrecondition},
+		{req: &storagepb.StampFileRequest{UserId: uuid.NewString(), FileId: pdfID, Watermark: "DRAFT"}, code: codes.NotFound},
+	}
+	for _, tt := range tests {
+		stream, err := client.StampFile(ctx, tt.req)
+		require.NoError(t, err)
+		_, _, err = receiveAll(stream)
+		require.Equal(t, tt.code, status.Code(err), "%v", tt.req)
+	}
+}
@@ -0,0 +1,25 @@
+package handler
+
+import (
+	"context"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/a1y/doc-formatter/internal/storage/util/thumbnail"
+)
+
+func (h *Handler) GetThumbnail(ctx context.Context, req *storagepb.GetThumbnailRequest) (*storagepb.GetThumbnailResponse, error) {
+	userID, fileID, err := parseFileIDs(req.UserId, req.FileId)
+	if err != nil {
+		return nil, err
+	}
+
+	image, err := h.documentManager.GetThumbnail(ctx, userID, fileID)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &storagepb.GetThumbnailResponse{
+		FileId:      fileID.String(),
+		ContentType: thumbnail.ContentType,
+		Image:       image,
+	}, nil
+}
@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"image/png"
+	"testing"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestHandler_GetThumbnail(t *testing.T) {
+	dm, fm, sm := newTestManagers(t)
+	client := newTestStorageClient(t, dm, fm, sm)
+	ctx := context.Background()
+	userID := uuid.NewString()
+
+	content := []byte("# Notes\n\nBuy milk.\n")
+	uploaded, err := client.UploadFile(ctx, &storagepb.UploadFileRequest{
+		UserId:   userID,
+		FileName: "notes.md",
+		FileSize: int64(len(content)),
+		Content:  content,
+	})
+	require.NoError(t, err)
+
+	resp, err := client.GetThumbnail(ctx, &storagepb.GetThumbnailRequest{UserId: userID, FileId: uploaded.GetFileId()})
+	require.NoError(t, err)
+	require.Equal(t, uploaded.GetFileId(), resp.GetFileId())
+	require.Equal(t, "image/png", resp.GetContentType())
+	_, err = png.Decode(bytes.NewReader(resp.GetImage()))
+	require.NoError(t, err)
+
+	pdf, err := client.UploadFile(ctx, &storagepb.UploadFileRequest{UserId: userID, FileName: "scan.pdf", FileSize: 4, Content: []byte("%PDF")})
+	require.NoError(t, err)
+	_, err = client.GetThumbnail(ctx, &storagepb.GetThumbnailRequest{UserId: userID, FileId: pdf.GetFileId()})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	_, err = client.GetThumbnail(ctx, &storagepb.GetThumbnailRequest{UserId: uuid.NewString(), FileId: uploaded.GetFileId()})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = client.GetThumbnail(ctx, &storagepb.GetThumbnailRequest{UserId: userID, FileId: "not-a-uuid"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"context"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+)
+
+func (h *Handler) DeleteFile(ctx context.Context, req *storagepb.DeleteFileRequest) (*storagepb.DeleteFileResponse, error) {
+	userID, fileID, err := parseFileIDs(req.UserId, req.FileId)
+	if err != nil {
+		return nil, err
+	}
+
+	document, err := h.documentManager.TrashDocument(ctx, userID, fileID)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &storagepb.DeleteFileResponse{File: toFileInfo(document)}, nil
+}
+
+func (h *Handler) ListTrash(ctx context.Context, req *storagepb.ListTrashRequest) (*storagepb.ListTrashResponse, error) {
+	userID, err := parseID("user id", req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	documents, nextPageToken, err := h.documentManager.ListTrash(ctx, userID, int(req.PageSize), req.PageToken)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	resp := &storagepb.ListTrashResponse{
+		Files:         make([]*storagepb.FileInfo, len(documents)),
+		NextPageToken: nextPageToken,
+	}
+	for i, document := range documents {
+		resp.Files[i] = toFileInfo(document)
+	}
+	return resp, nil
+}
+
+func (h *Handler) RestoreFile(ctx context.Context, req *storagepb.RestoreFileRequest) (*storagepb.RestoreFileResponse, error) {
+	userID, fileID, err := parseFileIDs(req.UserId, req.FileId)
+	if err != nil {
+		return nil, err
+	}
+
+	document, err := h.documentManager.RestoreDocument(ctx, userID, fileID)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &storagepb.RestoreFileResponse{File: toFileInfo(document)}, nil
+}
+
+func (h *Handler) EmptyTrash(ctx context.Context, req *storagepb.EmptyTrashRequest) (*storagepb.EmptyTrashResponse, error) {
+	userID, err := parseID("user id", req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	purged, err := h.documentManager.EmptyTrash(ctx, userID)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &storagepb.EmptyTrashResponse{PurgedFiles: int32(purged)}, nil
+}
@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestHandler_Trash(t *testing.T) {
+	dm, fm, sm := newTestManagers(t)
+	client := newTestStorageClient(t, dm, fm, sm)
+	ctx := context.Background()
+	userID := uuid.NewString()
+
+	var fileIDs []string
+	for _, name := range []string{"draft.txt", "old.txt"} {
+		uploaded, err := client.UploadFile(ctx, &storagepb.UploadFileRequest{
+			UserId:   userID,
+			FileName: name,
+			FileSize: int64(len(name)),
+			Content:  []byte(name),
+		})
+		require.NoError(t, err)
+		fileIDs = append(fileIDs, uploaded.GetFileId())
+	}
+
+	for _, fileID := range fileIDs {
+		deleted, err := client.DeleteFile(ctx, &storagepb.DeleteFileRequest{UserId: userID, FileId: fileID})
+		require.NoError(t, err)
+		require.NotZero(t, deleted.GetFile().GetDeletedAtUnix())
+	}
+
+	listing, err := client.ListFiles(ctx, &storagepb.ListFilesRequest{UserId: userID})
+	require.NoError(t, err)
+	require.Empty(t, listing.GetFiles())
+
+	trash, err := client.ListTrash(ctx, &storagepb.ListTrashRequest{UserId: userID, PageSize: 1})
+	require.NoError(t, err)
+	require.Len(t, trash.GetFiles(), 1)
+	require.NotEmpty(t, trash.GetNextPageToken())
+
+	trash, err = client.ListTrash(ctx, &storagepb.ListTrashRequest{UserId: userID, PageSize: 1, PageToken: trash.GetNextPageToken()})
+	require.NoError(t, err)
+	require.Len(t, trash.GetFiles(), 1)
+	require.Empty(t, trash.GetNextPageToken())
+
+	restored, err := client.RestoreFile(ctx, &storagepb.RestoreFileRequest{UserId: userID, FileId: fileIDs[0]})
+	require.NoError(t, err)
+	require.Equal(t, "draft.txt", restored.GetFile().GetFileName())
+	require.Zero(t, restored.GetFile().GetDeletedAtUnix())
+
+	_, err = client.RestoreFile(ctx, &storagepb.RestoreFileRequest{UserId: userID, FileId: fileIDs[0]})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	emptied, err := client.EmptyTrash(ctx, &storagepb.EmptyTrashRequest{UserId: userID})
+	require.NoError(t, err)
+	require.EqualValues(t, 1, emptied.GetPurgedFiles())
+
+	_, err = client.RestoreFile(ctx, &storagepb.RestoreFileRequest{UserId: userID, FileId: fileIDs[1]})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = client.DeleteFile(ctx, &storagepb.DeleteFileRequest{UserId: uuid.NewString(), FileId: fileIDs[0]})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = client.ListTrash(ctx, &storagepb.ListTrashRequest{UserId: "not-a-uuid"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -3,13 +3,21 @@ package handler
 import (
 	storagepb "github.com/a1y/doc-formatter/api/grpc/storage/v1"
 	"github.com/a1y/doc-formatter/internal/storage/manager/document"
+	"github.com/a1y/doc-formatter/internal/storage/manager/folder"
+	"github.com/a1y/doc-formatter/internal/storage/manager/share"
 )
 
-func NewHandler(documentManager *document.DocumentManager) (*Handler, error) {
-	return &Handler{documentManager: documentManager}, nil
+func NewHandler(
+	documentManager *document.DocumentManager,
+	folderManager *folder.FolderManager,
+	shareManager *share.ShareManager,
+) (*Handler, error) {
+	return &Handler{documentManager: documentManager, folderManager: folderManager, shareManager: shareManager}, nil
 }
 
 type Handler struct {
 	storagepb.UnimplementedStorageServiceServer
 	documentManager *document.DocumentManager
+	folderManager   *folder.FolderManager
+	shareManager    *share.ShareManager
 }
@@ -0,0 +1,30 @@
+package directory
+
+import (
+	"context"
+	"time"
+
+	authpb "github.com/a1y/doc-formatter/api/grpc/auth/v1"
+	"github.com/google/uuid"
+)
+
+// AuthDirectory names users by the email address the authentication
+// service holds for them.
+type AuthDirectory struct {
+	client authpb.AuthServiceClient
+}
+
+func NewAuthDirectory(client authpb.AuthServiceClient) *AuthDirectory {
+	return &AuthDirectory{client: client}
+}
+
+func (d *AuthDirectory) UserLabel(ctx context.Context, userID uuid.UUID) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := d.client.GetUser(ctx, &authpb.GetUserRequest{UserId: userID.String()})
+	if err != nil {
+		return "", err
+	}
+	return resp.GetEmail(), nil
+}
@@ -0,0 +1,41 @@
+package directory
+
+import (
+	"context"
+	"testing"
+
+	authpb "github.com/a1y/doc-formatter/api/grpc/auth/v1"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeAuthClient knows the users of a map.
+type fakeAuthClient struct {
+	authpb.AuthServiceClient
+	emails map[string]string
+}
+
+func (c *fakeAuthClient) GetUser(ctx context.Context, req *authpb.GetUserRequest, opts ...grpc.CallOption) (*authpb.GetUserResponse, error) {
+	email, ok := c.emails[req.GetUserId()]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	return &authpb.GetUserResponse{UserId: req.GetUserId(), Email: email}, nil
+}
+
+func TestAuthDirectory_UserLabel(t *testing.T) {
+	t.Parallel()
+
+	userID := uuid.New()
+	directory := NewAuthDirectory(&fakeAuthClient{emails: map[string]string{userID.String(): "owner@example.com"}})
+
+	label, err := directory.UserLabel(context.Background(), userID)
+	require.NoError(t, err)
+	require.Equal(t, "owner@example.com", label)
+
+	_, err = directory.UserLabel(context.Background(), uuid.New())
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
@@ -11,11 +11,14 @@ import (
 )
 
 func main() {
-	stmts, err := gormschema.New("postgres").Load(&persistence.DocumentModel{})
+	stmts, err := gormschema.New("postgres").Load(&persistence.DocumentModel{}, &persistence.FolderModel{}, &persistence.ACLEntryModel{}, &persistence.ShareLinkModel{}, &persistence.ShareLinkAccessModel{}, &persistence.ShareLinkAttemptModel{}, &persistence.DocumentTextModel{}, &persistence.DocumentAnalysisModel{}, &persistence.DocumentSourceModel{}, &persistence.BatchJobModel{}, &persistence.BatchJobItemModel{})
 	if err != nil {
 		logrus.Errorf("failed to load gorm schema: %v\n", err)
 		os.Exit(1)
 	}
+	for _, stmt := range persistence.PostgresIndexes {
+		stmts += stmt + ";\n"
+	}
 	_, err = io.WriteString(os.Stdout, stmts)
 	if err != nil {
 		os.Exit(1)
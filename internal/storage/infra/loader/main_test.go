@@ -15,7 +15,7 @@ func TestMain_GeneratesStorageSchema(t *testing.T) {
 	t.Parallel()
 
 	// Pre-check to avoid triggering os.Exit on environments where gormschema fails.
-	stmts, err := gormschema.New("postgres").Load(&persistence.DocumentModel{})
+	stmts, err := gormschema.New("postgres").Load(&persistence.DocumentModel{}, &persistence.FolderModel{}, &persistence.ACLEntryModel{}, &persistence.ShareLinkModel{}, &persistence.ShareLinkAccessModel{}, &persistence.DocumentTextModel{}, &persistence.DocumentAnalysisModel{}, &persistence.DocumentSourceModel{}, &persistence.BatchJobModel{}, &persistence.BatchJobItemModel{})
 	if err != nil {
 		t.Skipf("skipping storage loader main test due to gormschema error: %v", err)
 	}
@@ -0,0 +1,113 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
+	"github.com/a1y/doc-formatter/internal/storage/domain/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var _ repository.ACLRepository = &aclRepository{}
+
+type aclRepository struct {
+	db *gorm.DB
+}
+
+func NewACLRepository(db *gorm.DB) repository.ACLRepository {
+	return &aclRepository{
+		db: db,
+	}
+}
+
+func (r *aclRepository) Upsert(ctx context.Context, dataEntity *entity.ACLEntry) error {
+	err := dataEntity.Validate()
+	if err != nil {
+		return err
+	}
+
+	var dataModel ACLEntryModel
+	if err := dataModel.FromEntity(dataEntity); err != nil {
+		return err
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err = tx.WithContext(ctx).Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "resource_id"}, {Name: "user_id"}},
+			DoUpdates: clause.Assignments(map[string]any{"role": dataModel.Role, "updated_at": time.Now()}),
+		}).Create(&dataModel).Error
+		if err != nil {
+			return err
+		}
+
+		// The id and creation time are those of the first grant when the
+		// role is replaced.
+		var stored ACLEntryModel
+		err = tx.WithContext(ctx).
+			Where("resource_id = ? AND user_id = ?", dataModel.ResourceID, dataModel.UserID).
+			First(&stored).Error
+		if err != nil {
+			return err
+		}
+		dataEntity.ID = stored.ID
+		dataEntity.CreatedAt = stored.CreatedAt
+
+		return nil
+	})
+}
+
+// Delete removes the entry for good, so that the resource can be shared with
+// the user again.
+func (r *aclRepository) Delete(ctx context.Context, resourceID, userID uuid.UUID) error {
+	result := r.db.WithContext(ctx).Unscoped().
+		Where("resource_id = ? AND user_id = ?", resourceID, userID).
+		Delete(&ACLEntryModel{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *aclRepository) DeleteByResources(ctx context.Context, resourceIDs []uuid.UUID) error {
+	if len(resourceIDs) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Unscoped().Where("resource_id IN ?", resourceIDs).Delete(&ACLEntryModel{}).Error
+}
+
+func (r *aclRepository) ListByResource(ctx context.Context, resourceID uuid.UUID) ([]*entity.ACLEntry, error) {
+	return r.find(r.db.WithContext(ctx).Where("resource_id = ?", resourceID).Order("created_at, id"))
+}
+
+func (r *aclRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*entity.ACLEntry, error) {
+	return r.find(r.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at DESC, id DESC"))
+}
+
+func (r *aclRepository) ListForUser(ctx context.Context, userID uuid.UUID, resourceIDs []uuid.UUID) ([]*entity.ACLEntry, error) {
+	if len(resourceIDs) == 0 {
+		return nil, nil
+	}
+	return r.find(r.db.WithContext(ctx).Where("user_id = ? AND resource_id IN ?", userID, resourceIDs))
+}
+
+func (r *aclRepository) find(query *gorm.DB) ([]*entity.ACLEntry, error) {
+	var models []ACLEntryModel
+	if err := query.Find(&models).Error; err != nil {
+		return nil, err
+	}
+	entities := make([]*entity.ACLEntry, len(models))
+	for i, model := range models {
+		entity, err := model.ToEntity()
+		if err != nil {
+			return nil, err
+		}
+		entities[i] = entity
+	}
+	return entities, nil
+}
@@ -0,0 +1,42 @@
+package persistence
+
+import (
+	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
+	"github.com/google/uuid"
+)
+
+type ACLEntryModel struct {
+	BaseModel
+	ResourceType string
+	// A user holds at most one role on a resource.
+	ResourceID uuid.UUID `gorm:"type:uuid;uniqueIndex:idx_acl_entries_resource_id_user_id"`
+	OwnerID    uuid.UUID `gorm:"type:uuid"`
+	UserID     uuid.UUID `gorm:"type:uuid;uniqueIndex:idx_acl_entries_resource_id_user_id;index"`
+	Role       string
+}
+
+func (a *ACLEntryModel) TableName() string {
+	return "acl_entries"
+}
+
+func (a *ACLEntryModel) ToEntity() (*entity.ACLEntry, error) {
+	return &entity.ACLEntry{
+		ID:           a.ID,
+		ResourceType: entity.ResourceType(a.ResourceType),
+		ResourceID:   a.ResourceID,
+		OwnerID:      a.OwnerID,
+		UserID:       a.UserID,
+		Role:         entity.Role(a.Role),
+		CreatedAt:    a.CreatedAt,
+	}, nil
+}
+
+func (a *ACLEntryModel) FromEntity(e *entity.ACLEntry) error {
+	a.ID = e.ID
+	a.ResourceType = string(e.ResourceType)
+	a.ResourceID = e.ResourceID
+	a.OwnerID = e.OwnerID
+	a.UserID = e.UserID
+	a.Role = string(e.Role)
+	return nil
+}
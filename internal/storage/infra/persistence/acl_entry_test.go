@@ -0,0 +1,152 @@
+package persistence
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
+	testpersistence "github.com/a1y/doc-formatter/pkg/persistence"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestACLRepository_Upsert_Invalid(t *testing.T) {
+	db, mock, err := testpersistence.GetMockDB()
+	assert.NoError(t, err)
+
+	repo := NewACLRepository(db)
+	ownerID := uuid.New()
+
+	err = repo.Upsert(context.Background(), &entity.ACLEntry{
+		ResourceType: entity.ResourceDocument,
+		ResourceID:   uuid.New(),
+		OwnerID:      ownerID,
+		UserID:       ownerID,
+		Role:         entity.RoleViewer,
+	})
+	assert.Error(t, err)
+
+	mock.ExpectClose()
+	testpersistence.CloseDB(t, db)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestACLRepository_Delete_NotFound(t *testing.T) {
+	db, mock, err := testpersistence.GetMockDB()
+	assert.NoError(t, err)
+
+	repo := NewACLRepository(db)
+
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "acl_entries" WHERE resource_id = $1 AND user_id = $2`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectClose()
+
+	err = repo.Delete(context.Background(), uuid.New(), uuid.New())
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+	testpersistence.CloseDB(t, db)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestACLRepository_ListForUser_NoResources(t *testing.T) {
+	db, mock, err := testpersistence.GetMockDB()
+	assert.NoError(t, err)
+
+	repo := NewACLRepository(db)
+
+	entries, err := repo.ListForUser(context.Background(), uuid.New(), nil)
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+	assert.NoError(t, repo.DeleteByResources(context.Background(), nil))
+
+	mock.ExpectClose()
+	testpersistence.CloseDB(t, db)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestACLRepository_SQLiteIntegration(t *testing.T) {
+	t.Parallel()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, AutoMigrate(db))
+
+	repo := NewACLRepository(db)
+	ctx := context.Background()
+
+	ownerID, aliceID, bobID := uuid.New(), uuid.New(), uuid.New()
+	documentID, folderID := uuid.New(), uuid.New()
+
+	first := &entity.ACLEntry{
+		ResourceType: entity.ResourceDocument,
+		ResourceID:   documentID,
+		OwnerID:      ownerID,
+		UserID:       aliceID,
+		Role:         entity.RoleViewer,
+	}
+	require.NoError(t, repo.Upsert(ctx, first))
+	require.NotEqual(t, uuid.Nil, first.ID)
+
+	// Sharing again replaces the role and keeps the entry.
+	again := &entity.ACLEntry{
+		ResourceType: entity.ResourceDocument,
+		ResourceID:   documentID,
+		OwnerID:      ownerID,
+		UserID:       aliceID,
+		Role:         entity.RoleEditor,
+	}
+	require.NoError(t, repo.Upsert(ctx, again))
+	require.Equal(t, first.ID, again.ID)
+
+	require.NoError(t, repo.Upsert(ctx, &entity.ACLEntry{
+		ResourceType: entity.ResourceFolder,
+		ResourceID:   folderID,
+		OwnerID:      ownerID,
+		UserID:       aliceID,
+		Role:         entity.RoleCommenter,
+	}))
+	require.NoError(t, repo.Upsert(ctx, &entity.ACLEntry{
+		ResourceType: entity.ResourceDocument,
+		ResourceID:   documentID,
+		OwnerID:      ownerID,
+		UserID:       bobID,
+		Role:         entity.RoleViewer,
+	}))
+
+	entries, err := repo.ListByResource(ctx, documentID)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, aliceID, entries[0].UserID)
+	require.Equal(t, entity.RoleEditor, entries[0].Role)
+
+	entries, err = repo.ListByUser(ctx, aliceID)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	entries, err = repo.ListForUser(ctx, bobID, []uuid.UUID{documentID, folderID})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, entity.RoleViewer, entries[0].Role)
+
+	require.NoError(t, repo.Delete(ctx, documentID, bobID))
+	require.ErrorIs(t, repo.Delete(ctx, documentID, bobID), gorm.ErrRecordNotFound)
+
+	// A revoked role can be granted again.
+	require.NoError(t, repo.Upsert(ctx, &entity.ACLEntry{
+		ResourceType: entity.ResourceDocument,
+		ResourceID:   documentID,
+		OwnerID:      ownerID,
+		UserID:       bobID,
+		Role:         entity.RoleCommenter,
+	}))
+
+	require.NoError(t, repo.DeleteByResources(ctx, []uuid.UUID{documentID, folderID}))
+	entries, err = repo.ListByUser(ctx, aliceID)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
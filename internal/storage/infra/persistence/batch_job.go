@@ -0,0 +1,154 @@
+package persistence
+
+import (
+	"context"
+
+	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
+	"github.com/a1y/doc-formatter/internal/storage/domain/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var _ repository.BatchJobRepository = &batchJobRepository{}
+
+type batchJobRepository struct {
+	db *gorm.DB
+}
+
+func NewBatchJobRepository(db *gorm.DB) repository.BatchJobRepository {
+	return &batchJobRepository{
+		db: db,
+	}
+}
+
+func (r *batchJobRepository) Create(ctx context.Context, job *entity.BatchJob, items []*entity.BatchJobItem) error {
+	if err := job.Validate(); err != nil {
+		return err
+	}
+	var jobModel BatchJobModel
+	if err := jobModel.FromEntity(job); err != nil {
+		return err
+	}
+	itemModels := make([]BatchJobItemModel, len(items))
+	for i, item := range items {
+		if err := item.Validate(); err != nil {
+			return err
+		}
+		if err := itemModels[i].FromEntity(item); err != nil {
+			return err
+		}
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&jobModel).Error; err != nil {
+			return err
+		}
+		if len(itemModels) == 0 {
+			return nil
+		}
+		for i := range itemModels {
+			itemModels[i].JobID = jobModel.ID
+		}
+		return tx.Create(&itemModels).Error
+	})
+	if err != nil {
+		return err
+	}
+	job.ID = jobModel.ID
+	job.CreatedAt = jobModel.CreatedAt
+	for i, item := range items {
+		item.ID = itemModels[i].ID
+		item.JobID = jobModel.ID
+		item.UpdatedAt = itemModels[i].UpdatedAt
+	}
+	return nil
+}
+
+func (r *batchJobRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.BatchJob, error) {
+	var dataModel BatchJobModel
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&dataModel).Error; err != nil {
+		return nil, err
+	}
+	return dataModel.ToEntity()
+}
+
+func (r *batchJobRepository) ListRunning(ctx context.Context) ([]*entity.BatchJob, error) {
+	var models []BatchJobModel
+	err := r.db.WithContext(ctx).
+		Where("status = ?", entity.BatchJobRunning).
+		Order("created_at, id").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+	entities := make([]*entity.BatchJob, len(models))
+	for i, model := range models {
+		entity, err := model.ToEntity()
+		if err != nil {
+			return nil, err
+		}
+		entities[i] = entity
+	}
+	return entities, nil
+}
+
+func (r *batchJobRepository) Complete(ctx context.Context, job *entity.BatchJob) error {
+	return r.db.WithContext(ctx).Model(&BatchJobModel{}).
+		Where("id = ?", job.ID).
+		Updates(map[string]any{
+			"status":      entity.BatchJobCompleted,
+			"archive_id":  job.ArchiveID,
+			"error":       job.Error,
+			"finished_at": job.FinishedAt,
+		}).Error
+}
+
+func (r *batchJobRepository) ListItems(ctx context.Context, jobID uuid.UUID) ([]*entity.BatchJobItem, error) {
+	return r.findItems(r.db.WithContext(ctx).Where("job_id = ?", jobID).Order("position, id"))
+}
+
+// ListPendingItems takes the items in the order they were created, which is
+// that of their jobs.
+func (r *batchJobRepository) ListPendingItems(ctx context.Context, limit int) ([]*entity.BatchJobItem, error) {
+	return r.findItems(r.db.WithContext(ctx).
+		Where("status = ?", entity.BatchItemPending).
+		Order("created_at, job_id, position").
+		Limit(limit))
+}
+
+func (r *batchJobRepository) UpdateItem(ctx context.Context, item *entity.BatchJobItem) error {
+	if err := item.Validate(); err != nil {
+		return err
+	}
+	return r.db.WithContext(ctx).Model(&BatchJobItemModel{}).
+		Where("id = ?", item.ID).
+		Updates(map[string]any{
+			"status":    item.Status,
+			"output_id": item.OutputID,
+			"error":     item.Error,
+			"warnings":  StringList(item.Warnings),
+		}).Error
+}
+
+func (r *batchJobRepository) ResetRunningItems(ctx context.Context) (int, error) {
+	result := r.db.WithContext(ctx).Model(&BatchJobItemModel{}).
+		Where("status = ?", entity.BatchItemRunning).
+		Update("status", entity.BatchItemPending)
+	return int(result.RowsAffected), result.Error
+}
+
+func (r *batchJobRepository) findItems(query *gorm.DB) ([]*entity.BatchJobItem, error) {
+	var models []BatchJobItemModel
+	if err := query.Find(&models).Error; err != nil {
+		return nil, err
+	}
+	entities := make([]*entity.BatchJobItem, len(models))
+	for i, model := range models {
+		entity, err := model.ToEntity()
+		if err != nil {
+			return nil, err
+		}
+		entities[i] = entity
+	}
+	return entities, nil
+}
@@ -0,0 +1,114 @@
+package persistence
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
+	"github.com/google/uuid"
+)
+
+type BatchJobModel struct {
+	BaseModel
+	UserID    uuid.UUID  `gorm:"type:uuid;index"`
+	Status    string     `gorm:"index"`
+	FolderID  *uuid.UUID `gorm:"type:uuid"`
+	Recursive bool
+	Tag       string
+	// Profile is the docstyle.Profile of the job as JSON.
+	Profile        string     `gorm:"type:jsonb"`
+	OutputFolderID *uuid.UUID `gorm:"type:uuid"`
+	Archive        bool
+	ArchiveID      *uuid.UUID `gorm:"type:uuid"`
+	Error          string
+	FinishedAt     *time.Time
+}
+
+func (j *BatchJobModel) TableName() string {
+	return "batch_jobs"
+}
+
+func (j *BatchJobModel) ToEntity() (*entity.BatchJob, error) {
+	job := &entity.BatchJob{
+		ID:             j.ID,
+		UserID:         j.UserID,
+		Status:         entity.BatchJobStatus(j.Status),
+		FolderID:       j.FolderID,
+		Recursive:      j.Recursive,
+		Tag:            j.Tag,
+		OutputFolderID: j.OutputFolderID,
+		Archive:        j.Archive,
+		ArchiveID:      j.ArchiveID,
+		Error:          j.Error,
+		CreatedAt:      j.CreatedAt,
+		FinishedAt:     j.FinishedAt,
+	}
+	if err := json.Unmarshal([]byte(j.Profile), &job.Profile); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+func (j *BatchJobModel) FromEntity(e *entity.BatchJob) error {
+	profile, err := json.Marshal(e.Profile)
+	if err != nil {
+		return err
+	}
+	j.UserID = e.UserID
+	j.Status = string(e.Status)
+	j.FolderID = e.FolderID
+	j.Recursive = e.Recursive
+	j.Tag = e.Tag
+	j.Profile = string(profile)
+	j.OutputFolderID = e.OutputFolderID
+	j.Archive = e.Archive
+	j.ArchiveID = e.ArchiveID
+	j.Error = e.Error
+	j.FinishedAt = e.FinishedAt
+	return nil
+}
+
+type BatchJobItemModel struct {
+	BaseModel
+	JobID      uuid.UUID `gorm:"type:uuid;index"`
+	DocumentID uuid.UUID `gorm:"type:uuid"`
+	FileName   string
+	Position   int
+	// Status is indexed so that the pending items of every job are found
+	// without reading the others.
+	Status   string     `gorm:"index"`
+	OutputID *uuid.UUID `gorm:"type:uuid"`
+	Error    string
+	Warnings StringList `gorm:"type:jsonb"`
+}
+
+func (i *BatchJobItemModel) TableName() string {
+	return "batch_job_items"
+}
+
+func (i *BatchJobItemModel) ToEntity() (*entity.BatchJobItem, error) {
+	return &entity.BatchJobItem{
+		ID:         i.ID,
+		JobID:      i.JobID,
+		DocumentID: i.DocumentID,
+		FileName:   i.FileName,
+		Position:   i.Position,
+		Status:     entity.BatchItemStatus(i.Status),
+		OutputID:   i.OutputID,
+		Error:      i.Error,
+		Warnings:   i.Warnings,
+		UpdatedAt:  i.UpdatedAt,
+	}, nil
+}
+
+func (i *BatchJobItemModel) FromEntity(e *entity.BatchJobItem) error {
+	i.JobID = e.JobID
+	i.DocumentID = e.DocumentID
+	i.FileName = e.FileName
+	i.Position = e.Position
+	i.Status = string(e.Status)
+	i.OutputID = e.OutputID
+	i.Error = e.Error
+	i.Warnings = e.Warnings
+	return nil
+}
@@ -0,0 +1,96 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+
+	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
+	"github.com/a1y/doc-formatter/internal/storage/util/docstyle"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestBatchJobRepository_SQLiteIntegration(t *testing.T) {
+	t.Parallel()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, AutoMigrate(db))
+
+	repo := NewBatchJobRepository(db)
+	ctx := context.Background()
+	folderID := uuid.New()
+
+	job := &entity.BatchJob{
+		UserID:   uuid.New(),
+		Status:   entity.BatchJobRunning,
+		FolderID: &folderID,
+		Profile:  docstyle.Profile{FontFamily: "Georgia", FontSize: 11},
+		Archive:  true,
+	}
+	items := []*entity.BatchJobItem{
+		{DocumentID: uuid.New(), FileName: "b.docx", Position: 1, Status: entity.BatchItemPending},
+		{DocumentID: uuid.New(), FileName: "a.docx", Position: 0, Status: entity.BatchItemPending},
+	}
+	require.NoError(t, repo.Create(ctx, job, items))
+	assert.NotEqual(t, uuid.Nil, job.ID)
+	assert.Equal(t, job.ID, items[0].JobID)
+
+	stored, err := repo.GetByID(ctx, job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, job.Profile, stored.Profile)
+	assert.Equal(t, &folderID, stored.FolderID)
+
+	listed, err := repo.ListItems(ctx, job.ID)
+	require.NoError(t, err)
+	require.Len(t, listed, 2)
+	assert.Equal(t, "a.docx", listed[0].FileName)
+
+	// Items are taken in order, and running ones are made pending again.
+	pending, err := repo.ListPendingItems(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, "a.docx", pending[0].FileName)
+	pending[0].Status = entity.BatchItemRunning
+	require.NoError(t, repo.UpdateItem(ctx, pending[0]))
+	pending, err = repo.ListPendingItems(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, "b.docx", pending[0].FileName)
+	reset, err := repo.ResetRunningItems(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, reset)
+
+	outputID := uuid.New()
+	pending[0].Status = entity.BatchItemSucceeded
+	pending[0].OutputID = &outputID
+	pending[0].Warnings = []string{"kept fonts"}
+	require.NoError(t, repo.UpdateItem(ctx, pending[0]))
+	listed, err = repo.ListItems(ctx, job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.BatchItemPending, listed[0].Status)
+	assert.Equal(t, &outputID, listed[1].OutputID)
+	assert.Equal(t, []string{"kept fonts"}, listed[1].Warnings)
+	pending[0].Status = "skipped"
+	require.Error(t, repo.UpdateItem(ctx, pending[0]))
+
+	running, err := repo.ListRunning(ctx)
+	require.NoError(t, err)
+	require.Len(t, running, 1)
+	job.Error = "no document succeeded"
+	require.NoError(t, repo.Complete(ctx, job))
+	stored, err = repo.GetByID(ctx, job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.BatchJobCompleted, stored.Status)
+	assert.Equal(t, "no document succeeded", stored.Error)
+	running, err = repo.ListRunning(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, running)
+
+	// Invalid jobs are not stored.
+	err = repo.Create(ctx, &entity.BatchJob{UserID: uuid.New(), Status: entity.BatchJobRunning, Tag: "draft", FolderID: &folderID}, nil)
+	require.Error(t, err)
+}
@@ -2,6 +2,9 @@ package persistence
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
 	"github.com/a1y/doc-formatter/internal/storage/domain/repository"
@@ -33,6 +36,9 @@ func (r *documentRepository) Create(ctx context.Context, dataEntity *entity.Docu
 	}
 
 	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockFolder(tx.WithContext(ctx), dataEntity.FolderID); err != nil {
+			return err
+		}
 		// Create new record in the store
 		err = tx.WithContext(ctx).Create(&dataModel).Error
 		if err != nil {
@@ -40,14 +46,23 @@ func (r *documentRepository) Create(ctx context.Context, dataEntity *entity.Docu
 		}
 
 		dataEntity.ID = dataModel.ID
+		dataEntity.CreatedAt = dataModel.CreatedAt
+		dataEntity.UpdatedAt = dataModel.UpdatedAt
 
 		return nil
 	})
 }
 
-func (r *documentRepository) ListByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.Document, error) {
+func (r *documentRepository) ListByFolder(ctx context.Context, userID uuid.UUID, folderID *uuid.UUID) ([]*entity.Document, error) {
+	query := r.db.WithContext(ctx).Where("user_id = ?", userID)
+	if folderID == nil {
+		query = query.Where("folder_id IS NULL")
+	} else {
+		query = query.Where("folder_id = ?", *folderID)
+	}
+
 	var models []DocumentModel
-	if err := r.db.Where("user_id = ?", userID).Find(&models).Error; err != nil && err != gorm.ErrRecordNotFound {
+	if err := query.Order("file_name").Find(&models).Error; err != nil {
 		return nil, err
 	}
 	entities := make([]*entity.Document, len(models))
@@ -61,6 +76,82 @@ func (r *documentRepository) ListByUserID(ctx context.Context, userID uuid.UUID)
 	return entities, nil
 }
 
+func (r *documentRepository) List(
+	ctx context.Context, userID uuid.UUID, filter *entity.DocumentFilter, page *entity.DocumentPage,
+) ([]*entity.Document, error) {
+	query := r.db.WithContext(ctx).Where("user_id = ?", userID)
+	if filter.FolderID != nil {
+		query = query.Where("folder_id = ?", *filter.FolderID)
+	}
+	// Containment is what the GIN indexes on tags and metadata serve.
+	if len(filter.Tags) > 0 {
+		query = query.Where("tags @> ?", StringList(filter.Tags))
+	}
+	if len(filter.Metadata) > 0 {
+		query = query.Where("metadata @> ?", StringMap(filter.Metadata))
+	}
+	if filter.ContentType != "" {
+		if typ, ok := strings.CutSuffix(filter.ContentType, "/*"); ok {
+			query = query.Where(`content_type LIKE ? ESCAPE '\'`, likeEscaper.Replace(typ)+"/%")
+		} else {
+			query = query.Where("content_type = ?", filter.ContentType)
+		}
+	}
+	if filter.MinSize != nil {
+		query = query.Where("file_size >= ?", *filter.MinSize)
+	}
+	if filter.MaxSize != nil {
+		query = query.Where("file_size <= ?", *filter.MaxSize)
+	}
+	if filter.CreatedAfter != nil {
+		query = query.Where("created_at >= ?", *filter.CreatedAfter)
+	}
+	if filter.CreatedBefore != nil {
+		query = query.Where("created_at <= ?", *filter.CreatedBefore)
+	}
+
+	var models []DocumentModel
+	if err := paginate(query, page).Find(&models).Error; err != nil {
+		return nil, err
+	}
+	entities := make([]*entity.Document, len(models))
+	for i, model := range models {
+		entity, err := model.ToEntity()
+		if err != nil {
+			return nil, err
+		}
+		entities[i] = entity
+	}
+	return entities, nil
+}
+
+// sortColumns maps the sort fields of a listing to their column.
+var sortColumns = map[entity.DocumentSort]string{
+	entity.SortByName:    "file_name",
+	entity.SortBySize:    "file_size",
+	entity.SortByCreated: "created_at",
+	entity.SortByUpdated: "updated_at",
+	entity.SortByDeleted: "deleted_at",
+}
+
+// paginate restricts query to page. Pages are keyset paginated on the sort
+// column and the id, which breaks ties, so that a page starts right after the
+// previous one however many documents precede it.
+func paginate(query *gorm.DB, page *entity.DocumentPage) *gorm.DB {
+	column := sortColumns[page.Sort]
+	op, direction := ">", "ASC"
+	if page.Descending {
+		op, direction = "<", "DESC"
+	}
+	if page.After != nil {
+		query = query.Where(fmt.Sprintf("(%s, id) %s (?, ?)", column, op), page.Sort.Key(page.After), page.After.ID)
+	}
+	return query.Order(column + " " + direction).Order("id " + direction).Limit(page.Size)
+}
+
+// likeEscaper escapes the wildcards of a LIKE pattern.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
 func (r *documentRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Document, error) {
 	var model DocumentModel
 	if err := r.db.Where("id = ?", id).First(&model).Error; err != nil {
@@ -72,3 +163,145 @@ func (r *documentRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity
 func (r *documentRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return r.db.Where("id = ?", id).Delete(&DocumentModel{}).Error
 }
+
+func (r *documentRepository) GetTrashedByID(ctx context.Context, id uuid.UUID) (*entity.Document, error) {
+	var model DocumentModel
+	if err := r.db.WithContext(ctx).Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).First(&model).Error; err != nil {
+		return nil, err
+	}
+	return model.ToEntity()
+}
+
+func (r *documentRepository) ListTrash(ctx context.Context, userID uuid.UUID, page *entity.DocumentPage) ([]*entity.Document, error) {
+	query := r.db.WithContext(ctx).Unscoped().Where("user_id = ? AND deleted_at IS NOT NULL", userID)
+
+	var models []DocumentModel
+	if err := paginate(query, page).Find(&models).Error; err != nil {
+		return nil, err
+	}
+	entities := make([]*entity.Document, len(models))
+	for i, model := range models {
+		entity, err := model.ToEntity()
+		if err != nil {
+			return nil, err
+		}
+		entities[i] = entity
+	}
+	return entities, nil
+}
+
+func (r *documentRepository) ListTrashedBefore(
+	ctx context.Context, deletedBefore time.Time, afterID uuid.UUID, limit int,
+) ([]*entity.Document, error) {
+	var models []DocumentModel
+	err := r.db.WithContext(ctx).Unscoped().
+		Where("deleted_at IS NOT NULL AND deleted_at < ? AND id > ?", deletedBefore, afterID).
+		Order("id").Limit(limit).
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+	entities := make([]*entity.Document, len(models))
+	for i, model := range models {
+		entity, err := model.ToEntity()
+		if err != nil {
+			return nil, err
+		}
+		entities[i] = entity
+	}
+	return entities, nil
+}
+
+func (r *documentRepository) Restore(ctx context.Context, id uuid.UUID, folderID *uuid.UUID) error {
+	return r.db.WithContext(ctx).Unscoped().Model(&DocumentModel{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Updates(map[string]any{"deleted_at": nil, "folder_id": folderID}).Error
+}
+
+func (r *documentRepository) Purge(ctx context.Context, ids []uuid.UUID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Rows referring to documents outside the trash are kept.
+		var trashed []uuid.UUID
+		err := tx.Unscoped().Model(&DocumentModel{}).
+			Where("id IN ? AND deleted_at IS NOT NULL", ids).
+			Pluck("id", &trashed).Error
+		if err != nil || len(trashed) == 0 {
+			return err
+		}
+
+		var linkIDs []uuid.UUID
+		err = tx.Unscoped().Model(&ShareLinkModel{}).
+			Where("document_id IN ?", trashed).
+			Pluck("id", &linkIDs).Error
+		if err != nil {
+			return err
+		}
+		if len(linkIDs) > 0 {
+			if err := tx.Unscoped().Where("link_id IN ?", linkIDs).Delete(&ShareLinkAccessModel{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Unscoped().Where("id IN ?", linkIDs).Delete(&ShareLinkModel{}).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Unscoped().Where("resource_id IN ?", trashed).Delete(&ACLEntryModel{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("document_id IN ?", trashed).Delete(&DocumentTextModel{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("document_id IN ?", trashed).Delete(&DocumentAnalysisModel{}).Error; err != nil {
+			return err
+		}
+		err = tx.Unscoped().
+			Where("document_id IN ? OR source_id IN ?", trashed, trashed).
+			Delete(&DocumentSourceModel{}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Unscoped().Where("id IN ?", trashed).Delete(&DocumentModel{}).Error
+	})
+}
+
+func (r *documentRepository) ListWrappedByOtherKey(
+	ctx context.Context, masterKeyID string, afterID uuid.UUID, limit int,
+) ([]*entity.Document, error) {
+	var models []DocumentModel
+	err := r.db.WithContext(ctx).Unscoped().
+		Where("wrapped_key IS NOT NULL AND master_key_id <> ? AND id > ?", masterKeyID, afterID).
+		Order("id").Limit(limit).
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+	entities := make([]*entity.Document, len(models))
+	for i, model := range models {
+		entity, err := model.ToEntity()
+		if err != nil {
+			return nil, err
+		}
+		entities[i] = entity
+	}
+	return entities, nil
+}
+
+func (r *documentRepository) UpdateWrappedKey(ctx context.Context, id uuid.UUID, masterKeyID string, wrappedKey []byte) error {
+	return r.db.WithContext(ctx).Unscoped().Model(&DocumentModel{}).
+		Where("id = ?", id).
+		Updates(map[string]any{"master_key_id": masterKeyID, "wrapped_key": wrappedKey}).Error
+}
+
+func (r *documentRepository) UpdateFolder(ctx context.Context, id uuid.UUID, folderID *uuid.UUID) error {
+	return r.db.WithContext(ctx).Model(&DocumentModel{}).
+		Where("id = ?", id).
+		Updates(map[string]any{"folder_id": folderID}).Error
+}
+
+func (r *documentRepository) UpdateLabels(ctx context.Context, id uuid.UUID, tags []string, metadata map[string]string) error {
+	return r.db.WithContext(ctx).Model(&DocumentModel{}).
+		Where("id = ?", id).
+		Updates(map[string]any{"tags": StringList(tags), "metadata": StringMap(metadata)}).Error
+}
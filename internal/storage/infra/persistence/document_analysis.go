@@ -0,0 +1,62 @@
+package persistence
+
+import (
+	"context"
+
+	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
+	"github.com/a1y/doc-formatter/internal/storage/domain/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var _ repository.DocumentAnalysisRepository = &documentAnalysisRepository{}
+
+// documentAnalysisRepository caches the analyses of documents, one per
+// document.
+type documentAnalysisRepository struct {
+	db *gorm.DB
+}
+
+func NewDocumentAnalysisRepository(db *gorm.DB) repository.DocumentAnalysisRepository {
+	return &documentAnalysisRepository{
+		db: db,
+	}
+}
+
+func (r *documentAnalysisRepository) GetByDocument(ctx context.Context, documentID uuid.UUID) (*entity.DocumentAnalysis, error) {
+	var dataModel DocumentAnalysisModel
+	if err := r.db.WithContext(ctx).Where("document_id = ?", documentID).First(&dataModel).Error; err != nil {
+		return nil, err
+	}
+	return dataModel.ToEntity()
+}
+
+func (r *documentAnalysisRepository) Save(ctx context.Context, dataEntity *entity.DocumentAnalysis) error {
+	err := dataEntity.Validate()
+	if err != nil {
+		return err
+	}
+
+	var dataModel DocumentAnalysisModel
+	if err := dataModel.FromEntity(dataEntity); err != nil {
+		return err
+	}
+
+	err = r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "document_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"object_key", "version", "analysis", "updated_at"}),
+	}).Create(&dataModel).Error
+	if err != nil {
+		return err
+	}
+	dataEntity.AnalyzedAt = dataModel.UpdatedAt
+	return nil
+}
+
+func (r *documentAnalysisRepository) DeleteByDocuments(ctx context.Context, documentIDs []uuid.UUID) error {
+	if len(documentIDs) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Unscoped().Where("document_id IN ?", documentIDs).Delete(&DocumentAnalysisModel{}).Error
+}
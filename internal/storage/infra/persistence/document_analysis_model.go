@@ -0,0 +1,48 @@
+package persistence
+
+import (
+	"encoding/json"
+
+	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
+	"github.com/a1y/doc-formatter/internal/storage/util/docanalysis"
+	"github.com/google/uuid"
+)
+
+type DocumentAnalysisModel struct {
+	BaseModel
+	DocumentID uuid.UUID `gorm:"type:uuid;uniqueIndex"`
+	ObjectKey  string
+	Version    int
+	// Analysis is the docanalysis.Analysis of the document as JSON.
+	Analysis string `gorm:"type:jsonb"`
+}
+
+func (a *DocumentAnalysisModel) TableName() string {
+	return "document_analyses"
+}
+
+func (a *DocumentAnalysisModel) ToEntity() (*entity.DocumentAnalysis, error) {
+	var analysis docanalysis.Analysis
+	if err := json.Unmarshal([]byte(a.Analysis), &analysis); err != nil {
+		return nil, err
+	}
+	return &entity.DocumentAnalysis{
+		DocumentID: a.DocumentID,
+		ObjectKey:  a.ObjectKey,
+		Version:    a.Version,
+		Analysis:   &analysis,
+		AnalyzedAt: a.UpdatedAt,
+	}, nil
+}
+
+func (a *DocumentAnalysisModel) FromEntity(e *entity.DocumentAnalysis) error {
+	data, err := json.Marshal(e.Analysis)
+	if err != nil {
+		return err
+	}
+	a.DocumentID = e.DocumentID
+	a.ObjectKey = e.ObjectKey
+	a.Version = e.Version
+	a.Analysis = string(data)
+	return nil
+}
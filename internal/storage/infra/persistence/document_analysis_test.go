@@ -0,0 +1,66 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+
+	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
+	"github.com/a1y/doc-formatter/internal/storage/util/docanalysis"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestDocumentAnalysisRepository_SQLiteIntegration(t *testing.T) {
+	t.Parallel()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, AutoMigrate(db))
+
+	repo := NewDocumentAnalysisRepository(db)
+	ctx := context.Background()
+	documentID := uuid.New()
+
+	_, err = repo.GetByDocument(ctx, documentID)
+	require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+	analysis := &entity.DocumentAnalysis{
+		DocumentID: documentID,
+		ObjectKey:  "v1",
+		Version:    docanalysis.Version,
+		Analysis: &docanalysis.Analysis{
+			Outline:  []docanalysis.Heading{{Level: 1, Text: "Intro", Children: []docanalysis.Heading{{Level: 2, Text: "Scope"}}}},
+			Language: "en",
+			Fonts:    []string{"Georgia"},
+		},
+	}
+	require.NoError(t, repo.Save(ctx, analysis))
+	assert.False(t, analysis.AnalyzedAt.IsZero())
+
+	stored, err := repo.GetByDocument(ctx, documentID)
+	require.NoError(t, err)
+	assert.Equal(t, "v1", stored.ObjectKey)
+	assert.Equal(t, analysis.Analysis, stored.Analysis)
+
+	// Saving again replaces the analysis.
+	analysis.ObjectKey = "v2"
+	analysis.Analysis = &docanalysis.Analysis{Language: "fr"}
+	require.NoError(t, repo.Save(ctx, analysis))
+	stored, err = repo.GetByDocument(ctx, documentID)
+	require.NoError(t, err)
+	assert.Equal(t, "v2", stored.ObjectKey)
+	assert.Equal(t, "fr", stored.Analysis.Language)
+	var count int64
+	require.NoError(t, db.Model(&DocumentAnalysisModel{}).Count(&count).Error)
+	assert.EqualValues(t, 1, count)
+
+	assert.Error(t, repo.Save(ctx, &entity.DocumentAnalysis{DocumentID: documentID}))
+
+	require.NoError(t, repo.DeleteByDocuments(ctx, []uuid.UUID{documentID}))
+	_, err = repo.GetByDocument(ctx, documentID)
+	require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	require.NoError(t, repo.DeleteByDocuments(ctx, nil))
+}
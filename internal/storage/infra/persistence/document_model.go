@@ -1,6 +1,8 @@
 package persistence
 
 import (
+	"time"
+
 	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
 	"github.com/google/uuid"
 )
@@ -11,6 +13,17 @@ type DocumentModel struct {
 	FileName  string
 	FileSize  int64
 	ObjectKey string
+	FolderID  *uuid.UUID `gorm:"type:uuid;index"`
+	// ContentType is the media type of the content, without parameters.
+	ContentType string
+	// Tags and Metadata are GIN indexed, see PostgresIndexes.
+	Tags     StringList `gorm:"type:jsonb"`
+	Metadata StringMap  `gorm:"type:jsonb"`
+	// MasterKeyID is indexed so re-wrapping after a key rotation can find the
+	// documents still sealed by a retired master key.
+	MasterKeyID string `gorm:"index"`
+	WrappedKey  []byte
+	Nonce       []byte
 }
 
 func (d *DocumentModel) TableName() string {
@@ -18,12 +31,28 @@ func (d *DocumentModel) TableName() string {
 }
 
 func (d *DocumentModel) ToEntity() (*entity.Document, error) {
+	var deletedAt *time.Time
+	if d.DeletedAt.Valid {
+		deletedAt = &d.DeletedAt.Time
+	}
 	return &entity.Document{
 		ID:        d.ID,
 		UserID:    d.UserID,
 		FileName:  d.FileName,
 		FileSize:  d.FileSize,
 		ObjectKey: d.ObjectKey,
+		FolderID:  d.FolderID,
+
+		ContentType: d.ContentType,
+		Tags:        d.Tags,
+		Metadata:    d.Metadata,
+		CreatedAt:   d.CreatedAt,
+		UpdatedAt:   d.UpdatedAt,
+		DeletedAt:   deletedAt,
+
+		MasterKeyID: d.MasterKeyID,
+		WrappedKey:  d.WrappedKey,
+		Nonce:       d.Nonce,
 	}, nil
 }
 
@@ -33,5 +62,12 @@ func (d *DocumentModel) FromEntity(e *entity.Document) error {
 	d.FileName = e.FileName
 	d.FileSize = e.FileSize
 	d.ObjectKey = e.ObjectKey
+	d.FolderID = e.FolderID
+	d.ContentType = e.ContentType
+	d.Tags = e.Tags
+	d.Metadata = e.Metadata
+	d.MasterKeyID = e.MasterKeyID
+	d.WrappedKey = e.WrappedKey
+	d.Nonce = e.Nonce
 	return nil
 }
@@ -0,0 +1,79 @@
+package persistence
+
+import (
+	"context"
+
+	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
+	"github.com/a1y/doc-formatter/internal/storage/domain/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var _ repository.DocumentSourceRepository = &documentSourceRepository{}
+
+type documentSourceRepository struct {
+	db *gorm.DB
+}
+
+func NewDocumentSourceRepository(db *gorm.DB) repository.DocumentSourceRepository {
+	return &documentSourceRepository{
+		db: db,
+	}
+}
+
+func (r *documentSourceRepository) Create(ctx context.Context, dataEntities []*entity.DocumentSource) error {
+	if len(dataEntities) == 0 {
+		return nil
+	}
+	dataModels := make([]DocumentSourceModel, len(dataEntities))
+	for i, dataEntity := range dataEntities {
+		if err := dataEntity.Validate(); err != nil {
+			return err
+		}
+		if err := dataModels[i].FromEntity(dataEntity); err != nil {
+			return err
+		}
+	}
+
+	if err := r.db.WithContext(ctx).Create(&dataModels).Error; err != nil {
+		return err
+	}
+	for i, dataEntity := range dataEntities {
+		dataEntity.ID = dataModels[i].ID
+		dataEntity.CreatedAt = dataModels[i].CreatedAt
+	}
+	return nil
+}
+
+func (r *documentSourceRepository) ListByDocument(ctx context.Context, documentID uuid.UUID) ([]*entity.DocumentSource, error) {
+	return r.find(r.db.WithContext(ctx).Where("document_id = ?", documentID).Order("position, id"))
+}
+
+func (r *documentSourceRepository) ListBySource(ctx context.Context, sourceID uuid.UUID) ([]*entity.DocumentSource, error) {
+	return r.find(r.db.WithContext(ctx).Where("source_id = ?", sourceID).Order("created_at DESC, position, id"))
+}
+
+func (r *documentSourceRepository) DeleteByDocuments(ctx context.Context, documentIDs []uuid.UUID) error {
+	if len(documentIDs) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Unscoped().
+		Where("document_id IN ? OR source_id IN ?", documentIDs, documentIDs).
+		Delete(&DocumentSourceModel{}).Error
+}
+
+func (r *documentSourceRepository) find(query *gorm.DB) ([]*entity.DocumentSource, error) {
+	var models []DocumentSourceModel
+	if err := query.Find(&models).Error; err != nil {
+		return nil, err
+	}
+	entities := make([]*entity.DocumentSource, len(models))
+	for i, model := range models {
+		entity, err := model.ToEntity()
+		if err != nil {
+			return nil, err
+		}
+		entities[i] = entity
+	}
+	return entities, nil
+}
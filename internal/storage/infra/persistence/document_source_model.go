@@ -0,0 +1,37 @@
+package persistence
+
+import (
+	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
+	"github.com/google/uuid"
+)
+
+type DocumentSourceModel struct {
+	BaseModel
+	DocumentID uuid.UUID `gorm:"type:uuid;index"`
+	SourceID   uuid.UUID `gorm:"type:uuid;index"`
+	Operation  string
+	Position   int
+}
+
+func (s *DocumentSourceModel) TableName() string {
+	return "document_sources"
+}
+
+func (s *DocumentSourceModel) ToEntity() (*entity.DocumentSource, error) {
+	return &entity.DocumentSource{
+		ID:         s.ID,
+		DocumentID: s.DocumentID,
+		SourceID:   s.SourceID,
+		Operation:  s.Operation,
+		Position:   s.Position,
+		CreatedAt:  s.CreatedAt,
+	}, nil
+}
+
+func (s *DocumentSourceModel) FromEntity(e *entity.DocumentSource) error {
+	s.DocumentID = e.DocumentID
+	s.SourceID = e.SourceID
+	s.Operation = e.Operation
+	s.Position = e.Position
+	return nil
+}
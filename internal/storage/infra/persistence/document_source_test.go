@@ -0,0 +1,64 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+
+	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestDocumentSourceRepository_SQLiteIntegration(t *testing.T) {
+	t.Parallel()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, AutoMigrate(db))
+
+	repo := NewDocumentSourceRepository(db)
+	ctx := context.Background()
+	merged, first, second := uuid.New(), uuid.New(), uuid.New()
+
+	sources := []*entity.DocumentSource{
+		{DocumentID: merged, SourceID: second, Operation: entity.OperationMerge, Position: 1},
+		{DocumentID: merged, SourceID: first, Operation: entity.OperationMerge, Position: 0},
+	}
+	require.NoError(t, repo.Create(ctx, sources))
+	assert.NotEqual(t, uuid.Nil, sources[0].ID)
+	assert.False(t, sources[0].CreatedAt.IsZero())
+	part := &entity.DocumentSource{DocumentID: uuid.New(), SourceID: first, Operation: entity.OperationSplit}
+	require.NoError(t, repo.Create(ctx, []*entity.DocumentSource{part}))
+
+	stored, err := repo.ListByDocument(ctx, merged)
+	require.NoError(t, err)
+	require.Len(t, stored, 2)
+	assert.Equal(t, first, stored[0].SourceID)
+	assert.Equal(t, second, stored[1].SourceID)
+
+	derived, err := repo.ListBySource(ctx, first)
+	require.NoError(t, err)
+	require.Len(t, derived, 2)
+
+	// Invalid links are not recorded, even along valid ones.
+	err = repo.Create(ctx, []*entity.DocumentSource{
+		{DocumentID: uuid.New(), SourceID: first, Operation: entity.OperationMerge},
+		{DocumentID: uuid.New(), SourceID: first, Operation: "copy"},
+	})
+	require.Error(t, err)
+	require.NoError(t, repo.Create(ctx, nil))
+
+	// Deleting removes the links in both directions.
+	require.NoError(t, repo.DeleteByDocuments(ctx, []uuid.UUID{first}))
+	stored, err = repo.ListByDocument(ctx, merged)
+	require.NoError(t, err)
+	require.Len(t, stored, 1)
+	assert.Equal(t, second, stored[0].SourceID)
+	derived, err = repo.ListBySource(ctx, first)
+	require.NoError(t, err)
+	assert.Empty(t, derived)
+	require.NoError(t, repo.DeleteByDocuments(ctx, nil))
+}
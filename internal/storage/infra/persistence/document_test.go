@@ -2,8 +2,10 @@ package persistence
 
 import (
 	"context"
+	"fmt"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
@@ -43,7 +45,7 @@ func TestDocumentRepository_Create(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
-func TestDocumentRepository_ListByUserID(t *testing.T) {
+func TestDocumentRepository_List_Page(t *testing.T) {
 	db, mock, err := testpersistence.GetMockDB()
 	assert.NoError(t, err)
 
@@ -61,26 +63,31 @@ func TestDocumentRepository_ListByUserID(t *testing.T) {
 		userID, "test.txt", int64(123), userID.String()+"/test.txt",
 	)
 
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "documents" WHERE user_id = $1 AND "documents"."deleted_at" IS NULL`)).
-		WithArgs(userID).
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "documents" WHERE user_id = $1 AND "documents"."deleted_at" IS NULL `+
+		`ORDER BY file_name ASC,id ASC LIMIT $2`)).
+		WithArgs(userID, 10).
 		WillReturnRows(rows)
 
-	docs, err := repo.ListByUserID(ctx, userID)
+	docs, err := repo.List(ctx, userID, &entity.DocumentFilter{}, &entity.DocumentPage{Sort: entity.SortByName, Size: 10})
 	assert.NoError(t, err)
 	assert.Len(t, docs, 1)
 	assert.Equal(t, userID, docs[0].UserID)
 	assert.Equal(t, "test.txt", docs[0].FileName)
 
-	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "documents" WHERE user_id = $1 AND "documents"."deleted_at" IS NULL`)).
-		WithArgs(sqlmock.AnyArg()).
-		WillReturnRows(sqlmock.NewRows([]string{
-			"id", "created_at", "updated_at", "deleted_at", "description",
-			"user_id", "file_name", "file_size", "object_key",
-		}))
-
-	emptyDocs, err := repo.ListByUserID(ctx, uuid.New())
+	createdAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "documents" WHERE user_id = $1 AND (created_at, id) < ($2, $3) `+
+		`AND "documents"."deleted_at" IS NULL ORDER BY created_at DESC,id DESC LIMIT $4`)).
+		WithArgs(userID, createdAt, docID, 5).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	docs, err = repo.List(ctx, userID, &entity.DocumentFilter{}, &entity.DocumentPage{
+		Sort:       entity.SortByCreated,
+		Descending: true,
+		Size:       5,
+		After:      &entity.Document{ID: docID, CreatedAt: createdAt},
+	})
 	assert.NoError(t, err)
-	assert.Len(t, emptyDocs, 0)
+	assert.Len(t, docs, 0)
 
 	mock.ExpectClose()
 	testpersistence.CloseDB(t, db)
@@ -173,7 +180,7 @@ func TestDocumentRepository_SQLiteIntegration(t *testing.T) {
 	require.NotEqual(t, uuid.Nil, doc.ID)
 
 	// List by user
-	docs, err := repo.ListByUserID(ctx, userID)
+	docs, err := repo.List(ctx, userID, &entity.DocumentFilter{}, &entity.DocumentPage{Sort: entity.SortByName, Size: 10})
 	require.NoError(t, err)
 	require.Len(t, docs, 1)
 
@@ -187,3 +194,262 @@ func TestDocumentRepository_SQLiteIntegration(t *testing.T) {
 	// Delete
 	require.NoError(t, repo.Delete(ctx, fetched.ID))
 }
+
+func TestDocumentRepository_RewrapSQLiteIntegration(t *testing.T) {
+	t.Parallel()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	require.NoError(t, AutoMigrate(db))
+
+	repo := NewDocumentRepository(db)
+	ctx := context.Background()
+
+	userID := uuid.New()
+	newDoc := func(name, masterKeyID string, wrappedKey []byte) *entity.Document {
+		doc := &entity.Document{
+			UserID:      userID,
+			FileName:    name,
+			ObjectKey:   userID.String() + "/" + name,
+			MasterKeyID: masterKeyID,
+			WrappedKey:  wrappedKey,
+		}
+		if len(wrappedKey) > 0 {
+			doc.Nonce = []byte("nonce")
+		}
+		require.NoError(t, repo.Create(ctx, doc))
+		return doc
+	}
+
+	old := newDoc("old.txt", "k1", []byte("wrapped-by-k1"))
+	deleted := newDoc("deleted.txt", "k1", []byte("wrapped-by-k1"))
+	newDoc("current.txt", "k2", []byte("wrapped-by-k2"))
+	newDoc("plain.txt", "", nil)
+	require.NoError(t, repo.Delete(ctx, deleted.ID))
+
+	docs, err := repo.ListWrappedByOtherKey(ctx, "k2", uuid.Nil, 10)
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+	require.Less(t, docs[0].ID.String(), docs[1].ID.String())
+	require.ElementsMatch(t, []uuid.UUID{old.ID, deleted.ID}, []uuid.UUID{docs[0].ID, docs[1].ID})
+
+	page, err := repo.ListWrappedByOtherKey(ctx, "k2", docs[0].ID, 10)
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	require.Equal(t, docs[1].ID, page[0].ID)
+
+	require.NoError(t, repo.UpdateWrappedKey(ctx, old.ID, "k2", []byte("wrapped-by-k2")))
+	require.NoError(t, repo.UpdateWrappedKey(ctx, deleted.ID, "k2", []byte("wrapped-by-k2")))
+
+	docs, err = repo.ListWrappedByOtherKey(ctx, "k2", uuid.Nil, 10)
+	require.NoError(t, err)
+	require.Empty(t, docs)
+
+	fetched, err := repo.GetByID(ctx, old.ID)
+	require.NoError(t, err)
+	require.Equal(t, "k2", fetched.MasterKeyID)
+	require.Equal(t, []byte("wrapped-by-k2"), fetched.WrappedKey)
+	require.Equal(t, []byte("nonce"), fetched.Nonce)
+}
+
+func TestDocumentRepository_List_Filters(t *testing.T) {
+	db, mock, err := testpersistence.GetMockDB()
+	assert.NoError(t, err)
+
+	repo := NewDocumentRepository(db)
+	ctx := context.Background()
+
+	userID := uuid.New()
+	folderID := uuid.New()
+	minSize, maxSize := int64(10), int64(2048)
+	after := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	before := after.AddDate(0, 1, 0)
+	page := &entity.DocumentPage{Sort: entity.SortByName, Size: 10}
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "documents" WHERE user_id = $1 AND folder_id = $2 AND tags @> $3 AND metadata @> $4 `+
+		`AND content_type LIKE $5 ESCAPE '\' AND file_size >= $6 AND file_size <= $7 AND created_at >= $8 AND created_at <= $9 `+
+		`AND "documents"."deleted_at" IS NULL ORDER BY file_name ASC,id ASC LIMIT $10`)).
+		WithArgs(userID, folderID, `["client:acme","status:draft"]`, `{"owner":"legal"}`, `image/%`, minSize, maxSize, after, before, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "file_name", "tags", "metadata"}).
+			AddRow(uuid.New().String(), userID, "scan.png", `["client:acme","status:draft"]`, `{"owner":"legal"}`))
+
+	docs, err := repo.List(ctx, userID, &entity.DocumentFilter{
+		FolderID:      &folderID,
+		Tags:          []string{"client:acme", "status:draft"},
+		Metadata:      map[string]string{"owner": "legal"},
+		ContentType:   "image/*",
+		MinSize:       &minSize,
+		MaxSize:       &maxSize,
+		CreatedAfter:  &after,
+		CreatedBefore: &before,
+	}, page)
+	assert.NoError(t, err)
+	if assert.Len(t, docs, 1) {
+		assert.Equal(t, []string{"client:acme", "status:draft"}, docs[0].Tags)
+		assert.Equal(t, map[string]string{"owner": "legal"}, docs[0].Metadata)
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "documents" WHERE user_id = $1 AND content_type = $2 AND "documents"."deleted_at" IS NULL ORDER BY file_name ASC,id ASC LIMIT $3`)).
+		WithArgs(userID, "application/pdf", 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	docs, err = repo.List(ctx, userID, &entity.DocumentFilter{ContentType: "application/pdf"}, page)
+	assert.NoError(t, err)
+	assert.Empty(t, docs)
+
+	mock.ExpectClose()
+	testpersistence.CloseDB(t, db)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDocumentRepository_LabelsSQLiteIntegration(t *testing.T) {
+	t.Parallel()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	require.NoError(t, AutoMigrate(db))
+
+	repo := NewDocumentRepository(db)
+	ctx := context.Background()
+
+	userID := uuid.New()
+	doc := &entity.Document{
+		UserID:      userID,
+		FileName:    "report.pdf",
+		FileSize:    2048,
+		ObjectKey:   userID.String() + "/report.pdf",
+		ContentType: "application/pdf",
+		Tags:        []string{"client:acme"},
+	}
+	require.NoError(t, repo.Create(ctx, doc))
+	require.False(t, doc.CreatedAt.IsZero())
+
+	fetched, err := repo.GetByID(ctx, doc.ID)
+	require.NoError(t, err)
+	require.Equal(t, "application/pdf", fetched.ContentType)
+	require.Equal(t, []string{"client:acme"}, fetched.Tags)
+	require.Nil(t, fetched.Metadata)
+
+	require.NoError(t, repo.UpdateLabels(ctx, doc.ID, []string{"status:draft"}, map[string]string{"owner": "legal"}))
+
+	fetched, err = repo.GetByID(ctx, doc.ID)
+	require.NoError(t, err)
+	require.Equal(t, []string{"status:draft"}, fetched.Tags)
+	require.Equal(t, map[string]string{"owner": "legal"}, fetched.Metadata)
+
+	// Filters other than tags and metadata run on SQLite too.
+	small := int64(1024)
+	page := &entity.DocumentPage{Sort: entity.SortByName, Size: 10}
+	docs, err := repo.List(ctx, userID, &entity.DocumentFilter{MinSize: &small, ContentType: "application/*"}, page)
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+
+	docs, err = repo.List(ctx, userID, &entity.DocumentFilter{MaxSize: &small}, page)
+	require.NoError(t, err)
+	require.Empty(t, docs)
+}
+
+func TestDocumentRepository_TrashSQLiteIntegration(t *testing.T) {
+	t.Parallel()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	require.NoError(t, AutoMigrate(db))
+
+	repo := NewDocumentRepository(db)
+	ctx := context.Background()
+
+	userID := uuid.New()
+	folder := &entity.Folder{UserID: userID, Name: "Drafts"}
+	require.NoError(t, NewFolderRepository(db).Create(ctx, folder))
+	folderID := folder.ID
+	var docs []*entity.Document
+	for _, name := range []string{"a.txt", "b.txt", "c.txt"} {
+		doc := &entity.Document{UserID: userID, FileName: name, ObjectKey: userID.String() + "/" + name, FolderID: &folderID}
+		require.NoError(t, repo.Create(ctx, doc))
+		docs = append(docs, doc)
+	}
+
+	_, err = repo.GetTrashedByID(ctx, docs[0].ID)
+	require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+	for _, doc := range docs {
+		require.NoError(t, repo.Delete(ctx, doc.ID))
+	}
+	trashed, err := repo.GetTrashedByID(ctx, docs[0].ID)
+	require.NoError(t, err)
+	require.NotNil(t, trashed.DeletedAt)
+
+	page := &entity.DocumentPage{Sort: entity.SortByDeleted, Descending: true, Size: 2}
+	trash, err := repo.ListTrash(ctx, userID, page)
+	require.NoError(t, err)
+	require.Len(t, trash, 2)
+	page.After = trash[1]
+	trash, err = repo.ListTrash(ctx, userID, page)
+	require.NoError(t, err)
+	require.Len(t, trash, 1)
+
+	expired, err := repo.ListTrashedBefore(ctx, time.Now().Add(time.Minute), uuid.Nil, 10)
+	require.NoError(t, err)
+	require.Len(t, expired, 3)
+	expired, err = repo.ListTrashedBefore(ctx, time.Now().Add(-time.Minute), uuid.Nil, 10)
+	require.NoError(t, err)
+	require.Empty(t, expired)
+	expired, err = repo.ListTrashedBefore(ctx, time.Now().Add(time.Minute), uuid.Nil, 2)
+	require.NoError(t, err)
+	require.Len(t, expired, 2)
+	rest, err := repo.ListTrashedBefore(ctx, time.Now().Add(time.Minute), expired[1].ID, 2)
+	require.NoError(t, err)
+	require.Len(t, rest, 1)
+	require.Greater(t, rest[0].ID.String(), expired[1].ID.String())
+
+	require.NoError(t, repo.Restore(ctx, docs[0].ID, nil))
+	restored, err := repo.GetByID(ctx, docs[0].ID)
+	require.NoError(t, err)
+	require.Nil(t, restored.DeletedAt)
+	require.Nil(t, restored.FolderID)
+
+	aclRepo := NewACLRepository(db)
+	linkRepo := NewShareLinkRepository(db)
+	for i, doc := range docs[:2] {
+		require.NoError(t, aclRepo.Upsert(ctx, &entity.ACLEntry{
+			ResourceType: entity.ResourceDocument,
+			ResourceID:   doc.ID,
+			OwnerID:      userID,
+			UserID:       uuid.New(),
+			Role:         entity.RoleViewer,
+		}))
+		link := &entity.ShareLink{DocumentID: doc.ID, OwnerID: userID, TokenHash: fmt.Sprintf("token-%d", i)}
+		require.NoError(t, linkRepo.Create(ctx, link))
+		require.NoError(t, linkRepo.RecordAccess(ctx, &entity.ShareLinkAccess{LinkID: link.ID, Outcome: entity.LinkAccessDownloaded}))
+	}
+
+	// Purge only deletes documents of the trash, and the rows referring to
+	// them.
+	require.NoError(t, repo.Purge(ctx, []uuid.UUID{docs[0].ID, docs[1].ID}))
+	_, err = repo.GetByID(ctx, docs[0].ID)
+	require.NoError(t, err)
+	_, err = repo.GetTrashedByID(ctx, docs[1].ID)
+	require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	_, err = repo.GetTrashedByID(ctx, docs[2].ID)
+	require.NoError(t, err)
+
+	entries, err := aclRepo.ListByResource(ctx, docs[0].ID)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	entries, err = aclRepo.ListByResource(ctx, docs[1].ID)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+	links, err := linkRepo.ListByDocument(ctx, docs[0].ID)
+	require.NoError(t, err)
+	require.Len(t, links, 1)
+	links, err = linkRepo.ListByDocument(ctx, docs[1].ID)
+	require.NoError(t, err)
+	require.Empty(t, links)
+	var accesses int64
+	require.NoError(t, db.Model(&ShareLinkAccessModel{}).Count(&accesses).Error)
+	require.EqualValues(t, 1, accesses)
+}
@@ -0,0 +1,139 @@
+package persistence
+
+import (
+	"context"
+
+	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
+	"github.com/a1y/doc-formatter/internal/storage/domain/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var _ repository.DocumentTextRepository = &documentTextRepository{}
+
+// searchHeadline configures the snippets of search results: up to two
+// fragments of the text around the matches, which are wrapped in <mark>.
+const searchHeadline = "StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=20, MinWords=8, FragmentDelimiter=\" … \""
+
+// documentTextRepository keeps the text of documents for full-text search.
+// Searching relies on the text search of PostgreSQL; other databases, only
+// used in tests, store the text but leave the search vector empty.
+type documentTextRepository struct {
+	db *gorm.DB
+}
+
+func NewDocumentTextRepository(db *gorm.DB) repository.DocumentTextRepository {
+	return &documentTextRepository{
+		db: db,
+	}
+}
+
+func (r *documentTextRepository) Save(ctx context.Context, dataEntity *entity.DocumentText) error {
+	err := dataEntity.Validate()
+	if err != nil {
+		return err
+	}
+
+	var dataModel DocumentTextModel
+	if err := dataModel.FromEntity(dataEntity); err != nil {
+		return err
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "document_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"language", "content", "error", "updated_at"}),
+		}).Create(&dataModel).Error
+		if err != nil {
+			return err
+		}
+		dataEntity.IndexedAt = dataModel.UpdatedAt
+
+		if tx.Dialector.Name() != "postgres" {
+			return nil
+		}
+		// Matches in the file name rank above matches in the content.
+		return tx.Exec(`UPDATE "document_texts" SET "search_vector" = `+
+			`setweight(to_tsvector("language"::regconfig, coalesce((SELECT "file_name" FROM "documents" WHERE "documents"."id" = "document_texts"."document_id"), '')), 'A') || `+
+			`setweight(to_tsvector("language"::regconfig, "content"), 'B') `+
+			`WHERE "document_id" = ?`, dataEntity.DocumentID).Error
+	})
+}
+
+func (r *documentTextRepository) ListUnindexed(ctx context.Context, limit int) ([]*entity.Document, error) {
+	var models []DocumentModel
+	err := r.db.WithContext(ctx).
+		Joins(`LEFT JOIN "document_texts" ON "document_texts"."document_id" = "documents"."id"`).
+		Where(`"document_texts"."id" IS NULL`).
+		Order(`"documents"."created_at", "documents"."id"`).
+		Limit(limit).
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+	entities := make([]*entity.Document, len(models))
+	for i, model := range models {
+		entity, err := model.ToEntity()
+		if err != nil {
+			return nil, err
+		}
+		entities[i] = entity
+	}
+	return entities, nil
+}
+
+func (r *documentTextRepository) DeleteByDocuments(ctx context.Context, documentIDs []uuid.UUID) error {
+	if len(documentIDs) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Unscoped().Where("document_id IN ?", documentIDs).Delete(&DocumentTextModel{}).Error
+}
+
+// searchHitRow is a row of search results: a document with the rank and
+// snippet of its text.
+type searchHitRow struct {
+	DocumentModel
+	Rank    float64
+	Snippet string
+}
+
+func (r *documentTextRepository) Search(ctx context.Context, q *entity.SearchQuery) ([]*entity.SearchHit, error) {
+	if err := q.Validate(); err != nil {
+		return nil, err
+	}
+
+	// The query is parsed with the configuration of each document, so that
+	// its words are stemmed the way the text was.
+	access := r.db.Where(`"documents"."user_id" = ?`, q.UserID)
+	if len(q.DocumentIDs) > 0 {
+		access = access.Or(`"documents"."id" IN ?`, q.DocumentIDs)
+	}
+	if len(q.FolderIDs) > 0 {
+		access = access.Or(`"documents"."folder_id" IN ?`, q.FolderIDs)
+	}
+	var rows []searchHitRow
+	err := r.db.WithContext(ctx).Model(&DocumentModel{}).
+		Select(`"documents".*, ts_rank_cd("document_texts"."search_vector", "query") AS "rank", `+
+			`ts_headline("document_texts"."language"::regconfig, "document_texts"."content", "query", ?) AS "snippet"`, searchHeadline).
+		Joins(`JOIN "document_texts" ON "document_texts"."document_id" = "documents"."id"`).
+		Joins(`CROSS JOIN LATERAL websearch_to_tsquery("document_texts"."language"::regconfig, ?) AS "query"`, q.Text).
+		Where(`"document_texts"."search_vector" @@ "query"`).
+		Where(access).
+		Order(`"rank" DESC, "documents"."id"`).
+		Limit(q.Limit).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	hits := make([]*entity.SearchHit, len(rows))
+	for i, row := range rows {
+		document, err := row.ToEntity()
+		if err != nil {
+			return nil, err
+		}
+		hits[i] = &entity.SearchHit{Document: document, Rank: row.Rank, Snippet: row.Snippet}
+	}
+	return hits, nil
+}
@@ -0,0 +1,40 @@
+package persistence
+
+import (
+	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
+	"github.com/google/uuid"
+)
+
+type DocumentTextModel struct {
+	BaseModel
+	DocumentID uuid.UUID `gorm:"type:uuid;uniqueIndex"`
+	// Language is the text search configuration of the document.
+	Language string
+	Content  string
+	// SearchVector is computed by PostgreSQL from the file name and the
+	// content when the text is saved, and GIN indexed, see PostgresIndexes.
+	SearchVector string `gorm:"type:tsvector;->"`
+	Error        string
+}
+
+func (t *DocumentTextModel) TableName() string {
+	return "document_texts"
+}
+
+func (t *DocumentTextModel) ToEntity() (*entity.DocumentText, error) {
+	return &entity.DocumentText{
+		DocumentID: t.DocumentID,
+		Language:   t.Language,
+		Content:    t.Content,
+		Error:      t.Error,
+		IndexedAt:  t.UpdatedAt,
+	}, nil
+}
+
+func (t *DocumentTextModel) FromEntity(e *entity.DocumentText) error {
+	t.DocumentID = e.DocumentID
+	t.Language = e.Language
+	t.Content = e.Content
+	t.Error = e.Error
+	return nil
+}
@@ -0,0 +1,144 @@
+package persistence
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
+	testpersistence "github.com/a1y/doc-formatter/pkg/persistence"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestDocumentTextRepository_Save(t *testing.T) {
+	db, mock, err := testpersistence.GetMockDB()
+	assert.NoError(t, err)
+
+	repo := NewDocumentTextRepository(db)
+	documentID := uuid.New()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "document_texts"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "document_texts" SET "search_vector" = ` +
+		`setweight(to_tsvector("language"::regconfig, coalesce((SELECT "file_name" FROM "documents" WHERE "documents"."id" = "document_texts"."document_id"), '')), 'A') || ` +
+		`setweight(to_tsvector("language"::regconfig, "content"), 'B') WHERE "document_id" = $1`)).
+		WithArgs(documentID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	text := &entity.DocumentText{DocumentID: documentID, Language: "french", Content: "Bonjour"}
+	assert.NoError(t, repo.Save(context.Background(), text))
+	assert.False(t, text.IndexedAt.IsZero())
+
+	// Unknown configurations never reach the database.
+	assert.Error(t, repo.Save(context.Background(), &entity.DocumentText{DocumentID: documentID, Language: "klingon"}))
+
+	mock.ExpectClose()
+	testpersistence.CloseDB(t, db)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDocumentTextRepository_Search(t *testing.T) {
+	db, mock, err := testpersistence.GetMockDB()
+	assert.NoError(t, err)
+
+	repo := NewDocumentTextRepository(db)
+	userID, sharedID, folderID, hitID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT "documents".*, ts_rank_cd("document_texts"."search_vector", "query") AS "rank", `+
+		`ts_headline("document_texts"."language"::regconfig, "document_texts"."content", "query", $1) AS "snippet" `+
+		`FROM "documents" JOIN "document_texts" ON "document_texts"."document_id" = "documents"."id" `+
+		`CROSS JOIN LATERAL websearch_to_tsquery("document_texts"."language"::regconfig, $2) AS "query" `+
+		`WHERE "document_texts"."search_vector" @@ "query" `+
+		`AND ("documents"."user_id" = $3 OR "documents"."id" IN ($4) OR "documents"."folder_id" IN ($5)) `+
+		`AND "documents"."deleted_at" IS NULL ORDER BY "rank" DESC, "documents"."id" LIMIT $6`)).
+		WithArgs(searchHeadline, `"annual report" -draft`, userID, sharedID, folderID, 5).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "file_name", "rank", "snippet"}).
+			AddRow(hitID, userID, "report.docx", 0.42, "the <mark>annual</mark> <mark>report</mark>"))
+
+	hits, err := repo.Search(context.Background(), &entity.SearchQuery{
+		Text:        `"annual report" -draft`,
+		UserID:      userID,
+		DocumentIDs: []uuid.UUID{sharedID},
+		FolderIDs:   []uuid.UUID{folderID},
+		Limit:       5,
+	})
+	require.NoError(t, err)
+	require.Len(t, hits, 1)
+	assert.Equal(t, hitID, hits[0].Document.ID)
+	assert.Equal(t, "report.docx", hits[0].Document.FileName)
+	assert.InDelta(t, 0.42, hits[0].Rank, 1e-9)
+	assert.Equal(t, "the <mark>annual</mark> <mark>report</mark>", hits[0].Snippet)
+
+	// Without shares, only the documents of the user are searched.
+	mock.ExpectQuery(regexp.QuoteMeta(`WHERE "document_texts"."search_vector" @@ "query" AND "documents"."user_id" = $3 AND "documents"."deleted_at" IS NULL`)).
+		WithArgs(searchHeadline, "invoice", userID, 20).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	hits, err = repo.Search(context.Background(), &entity.SearchQuery{Text: "invoice", UserID: userID, Limit: 20})
+	require.NoError(t, err)
+	assert.Empty(t, hits)
+
+	_, err = repo.Search(context.Background(), &entity.SearchQuery{Text: " ", UserID: userID, Limit: 20})
+	assert.Error(t, err)
+
+	mock.ExpectClose()
+	testpersistence.CloseDB(t, db)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDocumentTextRepository_SQLiteIntegration(t *testing.T) {
+	t.Parallel()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, AutoMigrate(db))
+
+	documents := NewDocumentRepository(db)
+	repo := NewDocumentTextRepository(db)
+	ctx := context.Background()
+
+	userID := uuid.New()
+	var ids []uuid.UUID
+	for _, name := range []string{"a.md", "b.txt", "c.docx"} {
+		document := &entity.Document{UserID: userID, FileName: name, FileSize: 1, ObjectKey: name}
+		require.NoError(t, documents.Create(ctx, document))
+		ids = append(ids, document.ID)
+		// Distinct creation times keep the order of the listing stable.
+		time.Sleep(2 * time.Millisecond)
+	}
+	require.NoError(t, documents.Delete(ctx, ids[2]))
+
+	unindexed, err := repo.ListUnindexed(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, unindexed, 2)
+	assert.Equal(t, ids[0], unindexed[0].ID)
+	assert.Equal(t, ids[1], unindexed[1].ID)
+
+	require.NoError(t, repo.Save(ctx, &entity.DocumentText{DocumentID: ids[0], Language: "english", Content: "first"}))
+	// Saving again replaces the text.
+	require.NoError(t, repo.Save(ctx, &entity.DocumentText{DocumentID: ids[0], Language: "simple", Content: "second"}))
+	var stored DocumentTextModel
+	require.NoError(t, db.Where("document_id = ?", ids[0]).First(&stored).Error)
+	assert.Equal(t, "simple", stored.Language)
+	assert.Equal(t, "second", stored.Content)
+
+	unindexed, err = repo.ListUnindexed(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, unindexed, 1)
+	assert.Equal(t, ids[1], unindexed[0].ID)
+
+	require.NoError(t, repo.DeleteByDocuments(ctx, []uuid.UUID{ids[0]}))
+	require.NoError(t, repo.DeleteByDocuments(ctx, nil))
+	unindexed, err = repo.ListUnindexed(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, unindexed, 1)
+	assert.Equal(t, ids[0], unindexed[0].ID)
+}
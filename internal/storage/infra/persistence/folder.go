@@ -0,0 +1,160 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+
+	"github.com/a1y/doc-formatter/internal/storage/domain/constant"
+	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
+	"github.com/a1y/doc-formatter/internal/storage/domain/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var _ repository.FolderRepository = &folderRepository{}
+
+type folderRepository struct {
+	db *gorm.DB
+}
+
+func NewFolderRepository(db *gorm.DB) repository.FolderRepository {
+	return &folderRepository{
+		db: db,
+	}
+}
+
+func (r *folderRepository) Create(ctx context.Context, dataEntity *entity.Folder) error {
+	err := dataEntity.Validate()
+	if err != nil {
+		return err
+	}
+
+	var dataModel FolderModel
+	if err := dataModel.FromEntity(dataEntity); err != nil {
+		return err
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockFolder(tx.WithContext(ctx), dataEntity.ParentID); err != nil {
+			return err
+		}
+		err = tx.WithContext(ctx).Create(&dataModel).Error
+		if err != nil {
+			return err
+		}
+
+		dataEntity.ID = dataModel.ID
+
+		return nil
+	})
+}
+
+func (r *folderRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Folder, error) {
+	var model FolderModel
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&model).Error; err != nil {
+		return nil, err
+	}
+	return model.ToEntity()
+}
+
+func (r *folderRepository) ListByParent(ctx context.Context, userID uuid.UUID, parentID *uuid.UUID) ([]*entity.Folder, error) {
+	query := r.db.WithContext(ctx).Where("user_id = ?", userID)
+	if parentID == nil {
+		query = query.Where("parent_id IS NULL")
+	} else {
+		query = query.Where("parent_id = ?", *parentID)
+	}
+
+	var models []FolderModel
+	if err := query.Order("name").Find(&models).Error; err != nil {
+		return nil, err
+	}
+	entities := make([]*entity.Folder, len(models))
+	for i, model := range models {
+		entity, err := model.ToEntity()
+		if err != nil {
+			return nil, err
+		}
+		entities[i] = entity
+	}
+	return entities, nil
+}
+
+func (r *folderRepository) Update(ctx context.Context, dataEntity *entity.Folder) error {
+	err := dataEntity.Validate()
+	if err != nil {
+		return err
+	}
+	// A map is used so that a nil parent is written as NULL.
+	return r.db.WithContext(ctx).Model(&FolderModel{}).
+		Where("id = ?", dataEntity.ID).
+		Updates(map[string]any{"name": dataEntity.Name, "parent_id": dataEntity.ParentID}).Error
+}
+
+func (r *folderRepository) Delete(ctx context.Context, ids []uuid.UUID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Where("id IN ?", ids).Delete(&FolderModel{}).Error
+}
+
+func (r *folderRepository) DeleteTree(ctx context.Context, userID, folderID uuid.UUID, recursive bool) (int, int, error) {
+	var folderIDs, documentIDs []uuid.UUID
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		folderIDs, documentIDs = []uuid.UUID{folderID}, nil
+		for i := 0; i < len(folderIDs); i++ {
+			// Documents and folders are only added to folders they hold a
+			// lock on, see lockFolder.
+			var folder FolderModel
+			err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", folderIDs[i]).First(&folder).Error
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return constant.ErrFolderNotFound
+			}
+			if err != nil {
+				return err
+			}
+			var children, contained []uuid.UUID
+			if err := tx.Model(&FolderModel{}).Where("user_id = ? AND parent_id = ?", userID, folderIDs[i]).Pluck("id", &children).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(&DocumentModel{}).Where("user_id = ? AND folder_id = ?", userID, folderIDs[i]).Pluck("id", &contained).Error; err != nil {
+				return err
+			}
+			if !recursive && (len(children) > 0 || len(contained) > 0) {
+				return constant.ErrFolderNotEmpty
+			}
+			folderIDs = append(folderIDs, children...)
+			documentIDs = append(documentIDs, contained...)
+		}
+
+		if len(documentIDs) > 0 {
+			if err := tx.Where("id IN ?", documentIDs).Delete(&DocumentModel{}).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Where("id IN ?", folderIDs).Delete(&FolderModel{}).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Where("resource_id IN ?", folderIDs).Delete(&ACLEntryModel{}).Error
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	return len(folderIDs), len(documentIDs), nil
+}
+
+// lockFolder locks folderID, the folder a document or folder is added to in
+// tx, so that it cannot be deleted before tx commits. It returns
+// constant.ErrFolderNotFound when the folder was deleted in the meantime.
+func lockFolder(tx *gorm.DB, folderID *uuid.UUID) error {
+	if folderID == nil {
+		return nil
+	}
+	var folder FolderModel
+	err := tx.Clauses(clause.Locking{Strength: "SHARE"}).Where("id = ?", *folderID).First(&folder).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return constant.ErrFolderNotFound
+	}
+	return err
+}
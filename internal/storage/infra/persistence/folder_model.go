@@ -0,0 +1,34 @@
+package persistence
+
+import (
+	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
+	"github.com/google/uuid"
+)
+
+type FolderModel struct {
+	BaseModel
+	UserID   uuid.UUID  `gorm:"type:uuid;index:idx_folders_user_id_parent_id"`
+	ParentID *uuid.UUID `gorm:"type:uuid;index:idx_folders_user_id_parent_id"`
+	Name     string
+}
+
+func (f *FolderModel) TableName() string {
+	return "folders"
+}
+
+func (f *FolderModel) ToEntity() (*entity.Folder, error) {
+	return &entity.Folder{
+		ID:       f.ID,
+		UserID:   f.UserID,
+		ParentID: f.ParentID,
+		Name:     f.Name,
+	}, nil
+}
+
+func (f *FolderModel) FromEntity(e *entity.Folder) error {
+	f.ID = e.ID
+	f.UserID = e.UserID
+	f.ParentID = e.ParentID
+	f.Name = e.Name
+	return nil
+}
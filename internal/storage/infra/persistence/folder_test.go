@@ -0,0 +1,296 @@
+package persistence
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/a1y/doc-formatter/internal/storage/domain/constant"
+	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
+	testpersistence "github.com/a1y/doc-formatter/pkg/persistence"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestFolderRepository_Create(t *testing.T) {
+	db, mock, err := testpersistence.GetMockDB()
+	assert.NoError(t, err)
+
+	repo := NewFolderRepository(db)
+	ctx := context.Background()
+
+	folder := &entity.Folder{
+		UserID: uuid.New(),
+		Name:   "Contracts",
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "folders"`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+	mock.ExpectCommit()
+	mock.ExpectClose()
+
+	err = repo.Create(ctx, folder)
+	assert.NoError(t, err)
+
+	testpersistence.CloseDB(t, db)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFolderRepository_Create_Invalid(t *testing.T) {
+	db, mock, err := testpersistence.GetMockDB()
+	assert.NoError(t, err)
+
+	repo := NewFolderRepository(db)
+
+	err = repo.Create(context.Background(), &entity.Folder{UserID: uuid.New()})
+	assert.Error(t, err)
+
+	mock.ExpectClose()
+	testpersistence.CloseDB(t, db)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFolderRepository_ListByParent(t *testing.T) {
+	db, mock, err := testpersistence.GetMockDB()
+	assert.NoError(t, err)
+
+	repo := NewFolderRepository(db)
+	ctx := context.Background()
+
+	userID := uuid.New()
+	parentID := uuid.New()
+	columns := []string{
+		"id", "created_at", "updated_at", "deleted_at", "description",
+		"user_id", "parent_id", "name",
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "folders" WHERE user_id = $1 AND parent_id IS NULL AND "folders"."deleted_at" IS NULL ORDER BY name`)).
+		WithArgs(userID).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(uuid.New().String(), nil, nil, nil, "", userID, nil, "Contracts"))
+
+	folders, err := repo.ListByParent(ctx, userID, nil)
+	assert.NoError(t, err)
+	assert.Len(t, folders, 1)
+	assert.Equal(t, "Contracts", folders[0].Name)
+	assert.Nil(t, folders[0].ParentID)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "folders" WHERE user_id = $1 AND parent_id = $2 AND "folders"."deleted_at" IS NULL ORDER BY name`)).
+		WithArgs(userID, parentID).
+		WillReturnRows(sqlmock.NewRows(columns))
+
+	folders, err = repo.ListByParent(ctx, userID, &parentID)
+	assert.NoError(t, err)
+	assert.Empty(t, folders)
+
+	mock.ExpectClose()
+	testpersistence.CloseDB(t, db)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFolderRepository_Delete(t *testing.T) {
+	db, mock, err := testpersistence.GetMockDB()
+	assert.NoError(t, err)
+
+	repo := NewFolderRepository(db)
+	ctx := context.Background()
+
+	ids := []uuid.UUID{uuid.New(), uuid.New()}
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "folders" SET "deleted_at"=$1 WHERE id IN ($2,$3) AND "folders"."deleted_at" IS NULL`)).
+		WithArgs(sqlmock.AnyArg(), ids[0], ids[1]).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	assert.NoError(t, repo.Delete(ctx, ids))
+	assert.NoError(t, repo.Delete(ctx, nil))
+
+	mock.ExpectClose()
+	testpersistence.CloseDB(t, db)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFolderRepository_DeleteTree(t *testing.T) {
+	db, mock, err := testpersistence.GetMockDB()
+	assert.NoError(t, err)
+
+	repo := NewFolderRepository(db)
+	ctx := context.Background()
+
+	userID, folderID, childID, documentID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	expectLock := func(id uuid.UUID) {
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "folders" WHERE id = $1 AND "folders"."deleted_at" IS NULL ORDER BY "folders"."id" LIMIT $2 FOR UPDATE`)).
+			WithArgs(id, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(id))
+	}
+	expectContents := func(id uuid.UUID, children, documents []uuid.UUID) {
+		childRows := sqlmock.NewRows([]string{"id"})
+		for _, child := range children {
+			childRows.AddRow(child)
+		}
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id" FROM "folders" WHERE (user_id = $1 AND parent_id = $2) AND "folders"."deleted_at" IS NULL`)).
+			WithArgs(userID, id).
+			WillReturnRows(childRows)
+		documentRows := sqlmock.NewRows([]string{"id"})
+		for _, document := range documents {
+			documentRows.AddRow(document)
+		}
+		mock.ExpectQuery(regexp.QuoteMeta(`SELECT "id" FROM "documents" WHERE (user_id = $1 AND folder_id = $2) AND "documents"."deleted_at" IS NULL`)).
+			WithArgs(userID, id).
+			WillReturnRows(documentRows)
+	}
+
+	mock.ExpectBegin()
+	expectLock(folderID)
+	expectContents(folderID, []uuid.UUID{childID}, []uuid.UUID{documentID})
+	expectLock(childID)
+	expectContents(childID, nil, nil)
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "documents" SET "deleted_at"=$1 WHERE id IN ($2) AND "documents"."deleted_at" IS NULL`)).
+		WithArgs(sqlmock.AnyArg(), documentID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "folders" SET "deleted_at"=$1 WHERE id IN ($2,$3) AND "folders"."deleted_at" IS NULL`)).
+		WithArgs(sqlmock.AnyArg(), folderID, childID).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "acl_entries" WHERE resource_id IN ($1,$2)`)).
+		WithArgs(folderID, childID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	folders, documents, err := repo.DeleteTree(ctx, userID, folderID, true)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, folders)
+	assert.Equal(t, 1, documents)
+
+	// A folder that is not empty is kept unless recursive.
+	mock.ExpectBegin()
+	expectLock(folderID)
+	expectContents(folderID, nil, []uuid.UUID{documentID})
+	mock.ExpectRollback()
+
+	_, _, err = repo.DeleteTree(ctx, userID, folderID, false)
+	assert.ErrorIs(t, err, constant.ErrFolderNotEmpty)
+
+	// A folder deleted in the meantime is not found.
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "folders" WHERE id = $1 AND "folders"."deleted_at" IS NULL ORDER BY "folders"."id" LIMIT $2 FOR UPDATE`)).
+		WithArgs(folderID, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectRollback()
+
+	_, _, err = repo.DeleteTree(ctx, userID, folderID, true)
+	assert.ErrorIs(t, err, constant.ErrFolderNotFound)
+
+	// A failure leaves the documents out of the trash.
+	mock.ExpectBegin()
+	expectLock(folderID)
+	expectContents(folderID, nil, []uuid.UUID{documentID})
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "documents" SET "deleted_at"=$1 WHERE id IN ($2) AND "documents"."deleted_at" IS NULL`)).
+		WithArgs(sqlmock.AnyArg(), documentID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "folders" SET "deleted_at"=$1 WHERE id IN ($2) AND "folders"."deleted_at" IS NULL`)).
+		WithArgs(sqlmock.AnyArg(), folderID).
+		WillReturnError(gorm.ErrInvalidDB)
+	mock.ExpectRollback()
+
+	_, _, err = repo.DeleteTree(ctx, userID, folderID, true)
+	assert.ErrorIs(t, err, gorm.ErrInvalidDB)
+
+	mock.ExpectClose()
+	testpersistence.CloseDB(t, db)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFolderRepository_SQLiteIntegration(t *testing.T) {
+	t.Parallel()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	require.NoError(t, AutoMigrate(db))
+
+	repo := NewFolderRepository(db)
+	documents := NewDocumentRepository(db)
+	ctx := context.Background()
+
+	userID := uuid.New()
+	parent := &entity.Folder{UserID: userID, Name: "Clients"}
+	require.NoError(t, repo.Create(ctx, parent))
+	require.NotEqual(t, uuid.Nil, parent.ID)
+
+	child := &entity.Folder{UserID: userID, ParentID: &parent.ID, Name: "Acme"}
+	require.NoError(t, repo.Create(ctx, child))
+
+	// Listing
+	top, err := repo.ListByParent(ctx, userID, nil)
+	require.NoError(t, err)
+	require.Len(t, top, 1)
+	require.Equal(t, parent.ID, top[0].ID)
+
+	children, err := repo.ListByParent(ctx, userID, &parent.ID)
+	require.NoError(t, err)
+	require.Len(t, children, 1)
+	require.Equal(t, child.ID, children[0].ID)
+
+	// Rename and move to the top level
+	child.Name = "Acme Corp"
+	child.ParentID = nil
+	require.NoError(t, repo.Update(ctx, child))
+
+	fetched, err := repo.GetByID(ctx, child.ID)
+	require.NoError(t, err)
+	require.Equal(t, "Acme Corp", fetched.Name)
+	require.Nil(t, fetched.ParentID)
+
+	// Documents by folder
+	doc := &entity.Document{
+		UserID:    userID,
+		FileName:  "contract.docx",
+		ObjectKey: userID.String() + "/contract.docx",
+		FolderID:  &parent.ID,
+	}
+	require.NoError(t, documents.Create(ctx, doc))
+
+	inFolder, err := documents.ListByFolder(ctx, userID, &parent.ID)
+	require.NoError(t, err)
+	require.Len(t, inFolder, 1)
+	require.Equal(t, parent.ID, *inFolder[0].FolderID)
+
+	require.NoError(t, documents.UpdateFolder(ctx, doc.ID, nil))
+	atTop, err := documents.ListByFolder(ctx, userID, nil)
+	require.NoError(t, err)
+	require.Len(t, atTop, 1)
+	require.Nil(t, atTop[0].FolderID)
+
+	// Delete
+	require.NoError(t, repo.Delete(ctx, []uuid.UUID{parent.ID, child.ID}))
+	_, err = repo.GetByID(ctx, parent.ID)
+	require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+	// Nothing is added to a deleted folder.
+	require.ErrorIs(t, repo.Create(ctx, &entity.Folder{UserID: userID, ParentID: &parent.ID, Name: "Late"}), constant.ErrFolderNotFound)
+	late := &entity.Document{UserID: userID, FileName: "late.docx", ObjectKey: userID.String() + "/late.docx", FolderID: &parent.ID}
+	require.ErrorIs(t, documents.Create(ctx, late), constant.ErrFolderNotFound)
+
+	// Delete a tree
+	root := &entity.Folder{UserID: userID, Name: "Archive"}
+	require.NoError(t, repo.Create(ctx, root))
+	nested := &entity.Folder{UserID: userID, ParentID: &root.ID, Name: "2025"}
+	require.NoError(t, repo.Create(ctx, nested))
+	archived := &entity.Document{UserID: userID, FileName: "old.docx", ObjectKey: userID.String() + "/old.docx", FolderID: &nested.ID}
+	require.NoError(t, documents.Create(ctx, archived))
+
+	_, _, err = repo.DeleteTree(ctx, userID, root.ID, false)
+	require.ErrorIs(t, err, constant.ErrFolderNotEmpty)
+	folders, trashed, err := repo.DeleteTree(ctx, userID, root.ID, true)
+	require.NoError(t, err)
+	require.Equal(t, 2, folders)
+	require.Equal(t, 1, trashed)
+	_, err = repo.GetByID(ctx, nested.ID)
+	require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	_, err = documents.GetByID(ctx, archived.ID)
+	require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
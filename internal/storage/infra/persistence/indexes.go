@@ -0,0 +1,17 @@
+package persistence
+
+// PostgresIndexes creates the indexes that GORM cannot declare portably. The
+// GIN indexes make the containment filters on tags and metadata fast, but
+// SQLite, used in tests, has no such index method. The listing index serves
+// the keyset pagination of the documents of a user by creation time, and
+// spans a column of the shared BaseModel. The partial trash index serves the
+// trash listing and keeps live documents out of it. The GIN index on the
+// search vector of document texts serves full-text search. AutoMigrate runs
+// them on PostgreSQL and the Atlas loader adds them to the desired schema.
+var PostgresIndexes = []string{
+	`CREATE INDEX IF NOT EXISTS "idx_documents_tags" ON "documents" USING GIN ("tags")`,
+	`CREATE INDEX IF NOT EXISTS "idx_documents_metadata" ON "documents" USING GIN ("metadata")`,
+	`CREATE INDEX IF NOT EXISTS "idx_documents_user_created" ON "documents" ("user_id", "created_at", "id")`,
+	`CREATE INDEX IF NOT EXISTS "idx_documents_trash" ON "documents" ("user_id", "deleted_at", "id") WHERE "deleted_at" IS NOT NULL`,
+	`CREATE INDEX IF NOT EXISTS "idx_document_texts_search_vector" ON "document_texts" USING GIN ("search_vector")`,
+}
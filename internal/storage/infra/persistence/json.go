@@ -0,0 +1,56 @@
+package persistence
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// StringList is a list of strings stored as a JSON array.
+type StringList []string
+
+func (l StringList) Value() (driver.Value, error) {
+	if l == nil {
+		return nil, nil
+	}
+	return marshalJSON(l)
+}
+
+func (l *StringList) Scan(src any) error {
+	return unmarshalJSON(src, l)
+}
+
+// StringMap is a map of strings stored as a JSON object.
+type StringMap map[string]string
+
+func (m StringMap) Value() (driver.Value, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return marshalJSON(m)
+}
+
+func (m *StringMap) Scan(src any) error {
+	return unmarshalJSON(src, m)
+}
+
+func marshalJSON(v any) (driver.Value, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func unmarshalJSON(src any, dst any) error {
+	switch data := src.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return json.Unmarshal(data, dst)
+	case string:
+		return json.Unmarshal([]byte(data), dst)
+	default:
+		return fmt.Errorf("cannot scan %T into a JSON column", src)
+	}
+}
@@ -0,0 +1,33 @@
+package persistence
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONColumns(t *testing.T) {
+	t.Parallel()
+
+	value, err := StringList{"a", "b"}.Value()
+	require.NoError(t, err)
+	require.Equal(t, `["a","b"]`, value)
+
+	value, err = StringList(nil).Value()
+	require.NoError(t, err)
+	require.Nil(t, value)
+
+	var list StringList
+	require.NoError(t, list.Scan([]byte(`["a","b"]`)))
+	require.Equal(t, StringList{"a", "b"}, list)
+
+	value, err = StringMap{"k": "v"}.Value()
+	require.NoError(t, err)
+	require.Equal(t, `{"k":"v"}`, value)
+
+	var m StringMap
+	require.NoError(t, m.Scan(`{"k":"v"}`))
+	require.Equal(t, StringMap{"k": "v"}, m)
+	require.NoError(t, m.Scan(nil))
+	require.ErrorContains(t, m.Scan(42), "cannot scan int")
+}
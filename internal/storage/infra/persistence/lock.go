@@ -0,0 +1,50 @@
+package persistence
+
+import (
+	"context"
+	"sync"
+
+	"github.com/a1y/doc-formatter/internal/storage/domain/repository"
+	"gorm.io/gorm"
+)
+
+var _ repository.Locker = &locker{}
+
+// locker takes PostgreSQL advisory locks, which are held by a database
+// session and so shared by every instance of the service. The lock is scoped
+// to a transaction kept open while the work runs, so that it is released
+// with the connection should the instance die. Other databases, only used in
+// tests, are served by locks local to the process.
+type locker struct {
+	db    *gorm.DB
+	local sync.Map
+}
+
+func NewLocker(db *gorm.DB) repository.Locker {
+	return &locker{
+		db: db,
+	}
+}
+
+func (l *locker) TryLock(ctx context.Context, name string, fn func(ctx context.Context) error) (bool, error) {
+	if l.db.Dialector.Name() != "postgres" {
+		mu, _ := l.local.LoadOrStore(name, &sync.Mutex{})
+		if !mu.(*sync.Mutex).TryLock() {
+			return false, nil
+		}
+		defer mu.(*sync.Mutex).Unlock()
+		return true, fn(ctx)
+	}
+
+	acquired := false
+	err := l.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Raw("SELECT pg_try_advisory_xact_lock(hashtext(?))", name).Scan(&acquired).Error; err != nil {
+			return err
+		}
+		if !acquired {
+			return nil
+		}
+		return fn(ctx)
+	})
+	return acquired, err
+}
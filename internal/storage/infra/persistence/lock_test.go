@@ -0,0 +1,80 @@
+package persistence
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	testpersistence "github.com/a1y/doc-formatter/pkg/persistence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestLocker_Postgres(t *testing.T) {
+	db, mock, err := testpersistence.GetMockDB()
+	require.NoError(t, err)
+
+	locker := NewLocker(db)
+	ctx := context.Background()
+	lockQuery := regexp.QuoteMeta(`SELECT pg_try_advisory_xact_lock(hashtext($1))`)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(lockQuery).WithArgs("purge").
+		WillReturnRows(sqlmock.NewRows([]string{"pg_try_advisory_xact_lock"}).AddRow(true))
+	mock.ExpectCommit()
+
+	ran := false
+	acquired, err := locker.TryLock(ctx, "purge", func(ctx context.Context) error {
+		ran = true
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.True(t, acquired)
+	assert.True(t, ran)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(lockQuery).WithArgs("purge").
+		WillReturnRows(sqlmock.NewRows([]string{"pg_try_advisory_xact_lock"}).AddRow(false))
+	mock.ExpectCommit()
+
+	ran = false
+	acquired, err = locker.TryLock(ctx, "purge", func(ctx context.Context) error {
+		ran = true
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.False(t, acquired)
+	assert.False(t, ran)
+
+	mock.ExpectClose()
+	testpersistence.CloseDB(t, db)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLocker_Local(t *testing.T) {
+	t.Parallel()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	locker := NewLocker(db)
+	ctx := context.Background()
+
+	acquired, err := locker.TryLock(ctx, "purge", func(ctx context.Context) error {
+		nested, err := locker.TryLock(ctx, "purge", func(ctx context.Context) error {
+			t.Fatal("lock taken twice")
+			return nil
+		})
+		assert.False(t, nested)
+		assert.NoError(t, err)
+
+		other, err := locker.TryLock(ctx, "other", func(ctx context.Context) error { return nil })
+		assert.True(t, other)
+		return err
+	})
+	require.NoError(t, err)
+	require.True(t, acquired)
+}
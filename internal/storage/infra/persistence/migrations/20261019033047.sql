@@ -0,0 +1,4 @@
+-- Modify "documents" table
+ALTER TABLE "public"."documents" ADD COLUMN "master_key_id" text NULL, ADD COLUMN "wrapped_key" bytea NULL, ADD COLUMN "nonce" bytea NULL;
+-- Create index "idx_documents_master_key_id" to table: "documents"
+CREATE INDEX "idx_documents_master_key_id" ON "public"."documents" ("master_key_id");
@@ -0,0 +1,20 @@
+-- Modify "documents" table
+ALTER TABLE "public"."documents" ADD COLUMN "folder_id" uuid NULL;
+-- Create index "idx_documents_folder_id" to table: "documents"
+CREATE INDEX "idx_documents_folder_id" ON "public"."documents" ("folder_id");
+-- Create "folders" table
+CREATE TABLE "public"."folders" (
+  "id" uuid NOT NULL,
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "deleted_at" timestamptz NULL,
+  "description" text NULL,
+  "user_id" uuid NULL,
+  "parent_id" uuid NULL,
+  "name" text NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_folders_deleted_at" to table: "folders"
+CREATE INDEX "idx_folders_deleted_at" ON "public"."folders" ("deleted_at");
+-- Create index "idx_folders_user_id_parent_id" to table: "folders"
+CREATE INDEX "idx_folders_user_id_parent_id" ON "public"."folders" ("user_id", "parent_id");
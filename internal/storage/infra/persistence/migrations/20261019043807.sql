@@ -0,0 +1,6 @@
+-- Modify "documents" table
+ALTER TABLE "public"."documents" ADD COLUMN "content_type" text NULL, ADD COLUMN "tags" jsonb NULL, ADD COLUMN "metadata" jsonb NULL;
+-- Create index "idx_documents_metadata" to table: "documents"
+CREATE INDEX "idx_documents_metadata" ON "public"."documents" USING GIN ("metadata");
+-- Create index "idx_documents_tags" to table: "documents"
+CREATE INDEX "idx_documents_tags" ON "public"."documents" USING GIN ("tags");
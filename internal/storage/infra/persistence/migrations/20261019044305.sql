@@ -0,0 +1,2 @@
+-- Create index "idx_documents_user_created" to table: "documents"
+CREATE INDEX "idx_documents_user_created" ON "public"."documents" ("user_id", "created_at", "id");
@@ -0,0 +1,2 @@
+-- Create index "idx_documents_trash" to table: "documents"
+CREATE INDEX "idx_documents_trash" ON "public"."documents" ("user_id", "deleted_at", "id") WHERE (deleted_at IS NOT NULL);
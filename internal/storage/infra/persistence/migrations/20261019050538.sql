@@ -0,0 +1,20 @@
+-- Create "acl_entries" table
+CREATE TABLE "public"."acl_entries" (
+  "id" uuid NOT NULL,
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "deleted_at" timestamptz NULL,
+  "description" text NULL,
+  "resource_type" text NULL,
+  "resource_id" uuid NULL,
+  "owner_id" uuid NULL,
+  "user_id" uuid NULL,
+  "role" text NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_acl_entries_deleted_at" to table: "acl_entries"
+CREATE INDEX "idx_acl_entries_deleted_at" ON "public"."acl_entries" ("deleted_at");
+-- Create index "idx_acl_entries_resource_id_user_id" to table: "acl_entries"
+CREATE UNIQUE INDEX "idx_acl_entries_resource_id_user_id" ON "public"."acl_entries" ("resource_id", "user_id");
+-- Create index "idx_acl_entries_user_id" to table: "acl_entries"
+CREATE INDEX "idx_acl_entries_user_id" ON "public"."acl_entries" ("user_id");
@@ -0,0 +1,40 @@
+-- Create "share_links" table
+CREATE TABLE "public"."share_links" (
+  "id" uuid NOT NULL,
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "deleted_at" timestamptz NULL,
+  "description" text NULL,
+  "document_id" uuid NULL,
+  "owner_id" uuid NULL,
+  "token_hash" text NULL,
+  "password_hash" text NULL,
+  "expires_at" timestamptz NULL,
+  "max_downloads" bigint NULL,
+  "download_count" bigint NULL,
+  "revoked_at" timestamptz NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_share_links_deleted_at" to table: "share_links"
+CREATE INDEX "idx_share_links_deleted_at" ON "public"."share_links" ("deleted_at");
+-- Create index "idx_share_links_document_id" to table: "share_links"
+CREATE INDEX "idx_share_links_document_id" ON "public"."share_links" ("document_id");
+-- Create index "idx_share_links_token_hash" to table: "share_links"
+CREATE UNIQUE INDEX "idx_share_links_token_hash" ON "public"."share_links" ("token_hash");
+-- Create "share_link_accesses" table
+CREATE TABLE "public"."share_link_accesses" (
+  "id" uuid NOT NULL,
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "deleted_at" timestamptz NULL,
+  "description" text NULL,
+  "link_id" uuid NULL,
+  "remote_addr" text NULL,
+  "user_agent" text NULL,
+  "outcome" text NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_share_link_accesses_deleted_at" to table: "share_link_accesses"
+CREATE INDEX "idx_share_link_accesses_deleted_at" ON "public"."share_link_accesses" ("deleted_at");
+-- Create index "idx_share_link_accesses_link_id" to table: "share_link_accesses"
+CREATE INDEX "idx_share_link_accesses_link_id" ON "public"."share_link_accesses" ("link_id");
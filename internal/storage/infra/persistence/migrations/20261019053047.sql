@@ -0,0 +1,20 @@
+-- Create "document_texts" table
+CREATE TABLE "public"."document_texts" (
+  "id" uuid NOT NULL,
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "deleted_at" timestamptz NULL,
+  "description" text NULL,
+  "document_id" uuid NULL,
+  "language" text NULL,
+  "content" text NULL,
+  "search_vector" tsvector NULL,
+  "error" text NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_document_texts_deleted_at" to table: "document_texts"
+CREATE INDEX "idx_document_texts_deleted_at" ON "public"."document_texts" ("deleted_at");
+-- Create index "idx_document_texts_document_id" to table: "document_texts"
+CREATE UNIQUE INDEX "idx_document_texts_document_id" ON "public"."document_texts" ("document_id");
+-- Create index "idx_document_texts_search_vector" to table: "document_texts"
+CREATE INDEX "idx_document_texts_search_vector" ON "public"."document_texts" USING GIN ("search_vector");
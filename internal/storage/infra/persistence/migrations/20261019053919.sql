@@ -0,0 +1,17 @@
+-- Create "document_analyses" table
+CREATE TABLE "public"."document_analyses" (
+  "id" uuid NOT NULL,
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "deleted_at" timestamptz NULL,
+  "description" text NULL,
+  "document_id" uuid NULL,
+  "object_key" text NULL,
+  "version" bigint NULL,
+  "analysis" jsonb NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_document_analyses_deleted_at" to table: "document_analyses"
+CREATE INDEX "idx_document_analyses_deleted_at" ON "public"."document_analyses" ("deleted_at");
+-- Create index "idx_document_analyses_document_id" to table: "document_analyses"
+CREATE UNIQUE INDEX "idx_document_analyses_document_id" ON "public"."document_analyses" ("document_id");
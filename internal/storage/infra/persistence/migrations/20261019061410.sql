@@ -0,0 +1,19 @@
+-- Create "document_sources" table
+CREATE TABLE "public"."document_sources" (
+  "id" uuid NOT NULL,
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "deleted_at" timestamptz NULL,
+  "description" text NULL,
+  "document_id" uuid NULL,
+  "source_id" uuid NULL,
+  "operation" text NULL,
+  "position" bigint NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_document_sources_deleted_at" to table: "document_sources"
+CREATE INDEX "idx_document_sources_deleted_at" ON "public"."document_sources" ("deleted_at");
+-- Create index "idx_document_sources_document_id" to table: "document_sources"
+CREATE INDEX "idx_document_sources_document_id" ON "public"."document_sources" ("document_id");
+-- Create index "idx_document_sources_source_id" to table: "document_sources"
+CREATE INDEX "idx_document_sources_source_id" ON "public"."document_sources" ("source_id");
@@ -0,0 +1,49 @@
+-- Create "batch_jobs" table
+CREATE TABLE "public"."batch_jobs" (
+  "id" uuid NOT NULL,
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "deleted_at" timestamptz NULL,
+  "description" text NULL,
+  "user_id" uuid NULL,
+  "status" text NULL,
+  "folder_id" uuid NULL,
+  "recursive" boolean NULL,
+  "tag" text NULL,
+  "profile" jsonb NULL,
+  "output_folder_id" uuid NULL,
+  "archive" boolean NULL,
+  "archive_id" uuid NULL,
+  "error" text NULL,
+  "finished_at" timestamptz NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_batch_jobs_deleted_at" to table: "batch_jobs"
+CREATE INDEX "idx_batch_jobs_deleted_at" ON "public"."batch_jobs" ("deleted_at");
+-- Create index "idx_batch_jobs_status" to table: "batch_jobs"
+CREATE INDEX "idx_batch_jobs_status" ON "public"."batch_jobs" ("status");
+-- Create index "idx_batch_jobs_user_id" to table: "batch_jobs"
+CREATE INDEX "idx_batch_jobs_user_id" ON "public"."batch_jobs" ("user_id");
+-- Create "batch_job_items" table
+CREATE TABLE "public"."batch_job_items" (
+  "id" uuid NOT NULL,
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "deleted_at" timestamptz NULL,
+  "description" text NULL,
+  "job_id" uuid NULL,
+  "document_id" uuid NULL,
+  "file_name" text NULL,
+  "position" bigint NULL,
+  "status" text NULL,
+  "output_id" uuid NULL,
+  "error" text NULL,
+  "warnings" jsonb NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_batch_job_items_deleted_at" to table: "batch_job_items"
+CREATE INDEX "idx_batch_job_items_deleted_at" ON "public"."batch_job_items" ("deleted_at");
+-- Create index "idx_batch_job_items_job_id" to table: "batch_job_items"
+CREATE INDEX "idx_batch_job_items_job_id" ON "public"."batch_job_items" ("job_id");
+-- Create index "idx_batch_job_items_status" to table: "batch_job_items"
+CREATE INDEX "idx_batch_job_items_status" ON "public"."batch_job_items" ("status");
@@ -0,0 +1,17 @@
+-- Create "share_link_attempts" table
+CREATE TABLE "public"."share_link_attempts" (
+  "id" uuid NOT NULL,
+  "created_at" timestamptz NULL,
+  "updated_at" timestamptz NULL,
+  "deleted_at" timestamptz NULL,
+  "description" text NULL,
+  "key" text NULL,
+  "failures" bigint NULL,
+  "last_failure_at" timestamptz NULL,
+  "locked_until" timestamptz NULL,
+  PRIMARY KEY ("id")
+);
+-- Create index "idx_share_link_attempts_deleted_at" to table: "share_link_attempts"
+CREATE INDEX "idx_share_link_attempts_deleted_at" ON "public"."share_link_attempts" ("deleted_at");
+-- Create index "idx_share_link_attempts_key" to table: "share_link_attempts"
+CREATE UNIQUE INDEX "idx_share_link_attempts_key" ON "public"."share_link_attempts" ("key");
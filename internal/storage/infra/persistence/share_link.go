@@ -0,0 +1,131 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
+	"github.com/a1y/doc-formatter/internal/storage/domain/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var _ repository.ShareLinkRepository = &shareLinkRepository{}
+
+type shareLinkRepository struct {
+	db *gorm.DB
+}
+
+func NewShareLinkRepository(db *gorm.DB) repository.ShareLinkRepository {
+	return &shareLinkRepository{
+		db: db,
+	}
+}
+
+func (r *shareLinkRepository) Create(ctx context.Context, dataEntity *entity.ShareLink) error {
+	err := dataEntity.Validate()
+	if err != nil {
+		return err
+	}
+
+	var dataModel ShareLinkModel
+	if err := dataModel.FromEntity(dataEntity); err != nil {
+		return err
+	}
+
+	err = r.db.WithContext(ctx).Create(&dataModel).Error
+	if err != nil {
+		return err
+	}
+	dataEntity.ID = dataModel.ID
+	dataEntity.CreatedAt = dataModel.CreatedAt
+	return nil
+}
+
+func (r *shareLinkRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.ShareLink, error) {
+	var dataModel ShareLinkModel
+	if err := r.db.WithContext(ctx).Where("id = ?", id).First(&dataModel).Error; err != nil {
+		return nil, err
+	}
+	return dataModel.ToEntity()
+}
+
+func (r *shareLinkRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*entity.ShareLink, error) {
+	var dataModel ShareLinkModel
+	if err := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&dataModel).Error; err != nil {
+		return nil, err
+	}
+	return dataModel.ToEntity()
+}
+
+func (r *shareLinkRepository) ListByDocument(ctx context.Context, documentID uuid.UUID) ([]*entity.ShareLink, error) {
+	var models []ShareLinkModel
+	err := r.db.WithContext(ctx).
+		Where("document_id = ?", documentID).
+		Order("created_at DESC, id DESC").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+	entities := make([]*entity.ShareLink, len(models))
+	for i, model := range models {
+		entity, err := model.ToEntity()
+		if err != nil {
+			return nil, err
+		}
+		entities[i] = entity
+	}
+	return entities, nil
+}
+
+func (r *shareLinkRepository) Revoke(ctx context.Context, id uuid.UUID, at time.Time) error {
+	return r.db.WithContext(ctx).Model(&ShareLinkModel{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", at).Error
+}
+
+// IncrementDownloads checks the limit in the update itself so that concurrent
+// downloads cannot exceed it.
+func (r *shareLinkRepository) IncrementDownloads(ctx context.Context, id uuid.UUID) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&ShareLinkModel{}).
+		Where("id = ? AND (max_downloads = 0 OR download_count < max_downloads)", id).
+		Update("download_count", gorm.Expr("download_count + 1"))
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *shareLinkRepository) DeleteByDocuments(ctx context.Context, documentIDs []uuid.UUID) error {
+	if len(documentIDs) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var linkIDs []uuid.UUID
+		err := tx.Unscoped().Model(&ShareLinkModel{}).
+			Where("document_id IN ?", documentIDs).
+			Pluck("id", &linkIDs).Error
+		if err != nil || len(linkIDs) == 0 {
+			return err
+		}
+		if err := tx.Unscoped().Where("link_id IN ?", linkIDs).Delete(&ShareLinkAccessModel{}).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Where("id IN ?", linkIDs).Delete(&ShareLinkModel{}).Error
+	})
+}
+
+func (r *shareLinkRepository) RecordAccess(ctx context.Context, dataEntity *entity.ShareLinkAccess) error {
+	var dataModel ShareLinkAccessModel
+	if err := dataModel.FromEntity(dataEntity); err != nil {
+		return err
+	}
+
+	err := r.db.WithContext(ctx).Create(&dataModel).Error
+	if err != nil {
+		return err
+	}
+	dataEntity.ID = dataModel.ID
+	dataEntity.CreatedAt = dataModel.CreatedAt
+	return nil
+}
@@ -0,0 +1,87 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
+	"github.com/a1y/doc-formatter/internal/storage/domain/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var _ repository.ShareLinkAttemptRepository = &shareLinkAttemptRepository{}
+
+type shareLinkAttemptRepository struct {
+	db *gorm.DB
+}
+
+func NewShareLinkAttemptRepository(db *gorm.DB) repository.ShareLinkAttemptRepository {
+	return &shareLinkAttemptRepository{
+		db: db,
+	}
+}
+
+func (r *shareLinkAttemptRepository) Get(ctx context.Context, keys []string) ([]*entity.ShareLinkAttempt, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	var dataModels []ShareLinkAttemptModel
+	if err := r.db.WithContext(ctx).Where("key IN ?", keys).Find(&dataModels).Error; err != nil {
+		return nil, err
+	}
+	attempts := make([]*entity.ShareLinkAttempt, 0, len(dataModels))
+	for i := range dataModels {
+		attempt, err := dataModels[i].ToEntity()
+		if err != nil {
+			return nil, err
+		}
+		attempts = append(attempts, attempt)
+	}
+	return attempts, nil
+}
+
+func (r *shareLinkAttemptRepository) RecordFailure(ctx context.Context, key string, now time.Time, window time.Duration) (*entity.ShareLinkAttempt, error) {
+	var attempt *entity.ShareLinkAttempt
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// The count is raised by the upsert itself, so that wrong passwords
+		// sent at the same time are all counted.
+		dataModel := ShareLinkAttemptModel{Key: key, Failures: 1, LastFailureAt: now}
+		err := tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "key"}},
+			DoUpdates: clause.Assignments(map[string]any{
+				"failures":        gorm.Expr(`CASE WHEN "share_link_attempts"."last_failure_at" > ? THEN "share_link_attempts"."failures" + 1 ELSE 1 END`, now.Add(-window)),
+				"last_failure_at": now,
+				"updated_at":      now,
+			}),
+		}).Create(&dataModel).Error
+		if err != nil {
+			return err
+		}
+
+		var stored ShareLinkAttemptModel
+		if err := tx.Where("key = ?", key).First(&stored).Error; err != nil {
+			return err
+		}
+		attempt, err = stored.ToEntity()
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return attempt, nil
+}
+
+func (r *shareLinkAttemptRepository) Lock(ctx context.Context, key string, until time.Time) error {
+	return r.db.WithContext(ctx).Model(&ShareLinkAttemptModel{}).
+		Where("key = ?", key).
+		Update("locked_until", until).Error
+}
+
+// Reset removes the counts for good, so that the key can be counted again
+// from scratch.
+func (r *shareLinkAttemptRepository) Reset(ctx context.Context, key string) error {
+	return r.db.WithContext(ctx).Unscoped().
+		Where("key = ?", key).
+		Delete(&ShareLinkAttemptModel{}).Error
+}
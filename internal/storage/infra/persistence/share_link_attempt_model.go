@@ -0,0 +1,39 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
+)
+
+type ShareLinkAttemptModel struct {
+	BaseModel
+	Key           string `gorm:"uniqueIndex"`
+	Failures      int
+	LastFailureAt time.Time
+	LockedUntil   *time.Time
+}
+
+func (a *ShareLinkAttemptModel) TableName() string {
+	return "share_link_attempts"
+}
+
+func (a *ShareLinkAttemptModel) ToEntity() (*entity.ShareLinkAttempt, error) {
+	return &entity.ShareLinkAttempt{
+		ID:            a.ID,
+		Key:           a.Key,
+		Failures:      a.Failures,
+		LastFailureAt: a.LastFailureAt,
+		LockedUntil:   a.LockedUntil,
+		CreatedAt:     a.CreatedAt,
+	}, nil
+}
+
+func (a *ShareLinkAttemptModel) FromEntity(e *entity.ShareLinkAttempt) error {
+	a.ID = e.ID
+	a.Key = e.Key
+	a.Failures = e.Failures
+	a.LastFailureAt = e.LastFailureAt
+	a.LockedUntil = e.LockedUntil
+	return nil
+}
@@ -0,0 +1,92 @@
+package persistence
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	testpersistence "github.com/a1y/doc-formatter/pkg/persistence"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShareLinkAttemptRepository_Get(t *testing.T) {
+	db, mock, err := testpersistence.GetMockDB()
+	assert.NoError(t, err)
+
+	repo := NewShareLinkAttemptRepository(db)
+	ctx := context.Background()
+	lockedUntil := time.Now().Add(time.Minute)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "share_link_attempts" WHERE key IN ($1,$2) AND "share_link_attempts"."deleted_at" IS NULL`)).
+		WithArgs("link:0b5c8e02-0d3a-4b5e-9a53-0c6ad3f6a0b1", "ip:192.0.2.1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "key", "failures", "locked_until"}).
+			AddRow(uuid.New(), "link:0b5c8e02-0d3a-4b5e-9a53-0c6ad3f6a0b1", 5, lockedUntil))
+
+	attempts, err := repo.Get(ctx, []string{"link:0b5c8e02-0d3a-4b5e-9a53-0c6ad3f6a0b1", "ip:192.0.2.1"})
+	assert.NoError(t, err)
+	assert.Len(t, attempts, 1)
+	assert.Equal(t, 5, attempts[0].Failures)
+	assert.Equal(t, lockedUntil, *attempts[0].LockedUntil)
+
+	attempts, err = repo.Get(ctx, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, attempts)
+
+	mock.ExpectClose()
+	testpersistence.CloseDB(t, db)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestShareLinkAttemptRepository_RecordFailure(t *testing.T) {
+	db, mock, err := testpersistence.GetMockDB()
+	assert.NoError(t, err)
+
+	repo := NewShareLinkAttemptRepository(db)
+	ctx := context.Background()
+	now := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "share_link_attempts" ("id","created_at","updated_at","deleted_at","description","key","failures","last_failure_at","locked_until") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9) ON CONFLICT ("key") DO UPDATE SET "failures"=CASE WHEN "share_link_attempts"."last_failure_at" > $10 THEN "share_link_attempts"."failures" + 1 ELSE 1 END,"last_failure_at"=$11,"updated_at"=$12 RETURNING "id"`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), nil, "", "link:0b5c8e02-0d3a-4b5e-9a53-0c6ad3f6a0b1", 1, now, nil, now.Add(-time.Hour), now, now).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "share_link_attempts" WHERE key = $1 AND "share_link_attempts"."deleted_at" IS NULL ORDER BY "share_link_attempts"."id" LIMIT $2`)).
+		WithArgs("link:0b5c8e02-0d3a-4b5e-9a53-0c6ad3f6a0b1", 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "key", "failures", "last_failure_at"}).
+			AddRow(uuid.New(), "link:0b5c8e02-0d3a-4b5e-9a53-0c6ad3f6a0b1", 3, now))
+	mock.ExpectCommit()
+
+	attempt, err := repo.RecordFailure(ctx, "link:0b5c8e02-0d3a-4b5e-9a53-0c6ad3f6a0b1", now, time.Hour)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, attempt.Failures)
+	assert.Nil(t, attempt.LockedUntil)
+
+	mock.ExpectClose()
+	testpersistence.CloseDB(t, db)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestShareLinkAttemptRepository_LockAndReset(t *testing.T) {
+	db, mock, err := testpersistence.GetMockDB()
+	assert.NoError(t, err)
+
+	repo := NewShareLinkAttemptRepository(db)
+	ctx := context.Background()
+	until := time.Now().Add(time.Minute)
+
+	mock.ExpectExec(regexp.QuoteMeta(`UPDATE "share_link_attempts" SET "locked_until"=$1,"updated_at"=$2 WHERE key = $3 AND "share_link_attempts"."deleted_at" IS NULL`)).
+		WithArgs(until, sqlmock.AnyArg(), "ip:192.0.2.1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, repo.Lock(ctx, "ip:192.0.2.1", until))
+
+	mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "share_link_attempts" WHERE key = $1`)).
+		WithArgs("link:0b5c8e02-0d3a-4b5e-9a53-0c6ad3f6a0b1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, repo.Reset(ctx, "link:0b5c8e02-0d3a-4b5e-9a53-0c6ad3f6a0b1"))
+
+	mock.ExpectClose()
+	testpersistence.CloseDB(t, db)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
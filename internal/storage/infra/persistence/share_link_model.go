@@ -0,0 +1,84 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
+	"github.com/google/uuid"
+)
+
+type ShareLinkModel struct {
+	BaseModel
+	DocumentID    uuid.UUID `gorm:"type:uuid;index"`
+	OwnerID       uuid.UUID `gorm:"type:uuid"`
+	TokenHash     string    `gorm:"uniqueIndex"`
+	PasswordHash  string
+	ExpiresAt     *time.Time
+	MaxDownloads  int
+	DownloadCount int
+	RevokedAt     *time.Time
+}
+
+func (l *ShareLinkModel) TableName() string {
+	return "share_links"
+}
+
+func (l *ShareLinkModel) ToEntity() (*entity.ShareLink, error) {
+	return &entity.ShareLink{
+		ID:            l.ID,
+		DocumentID:    l.DocumentID,
+		OwnerID:       l.OwnerID,
+		TokenHash:     l.TokenHash,
+		PasswordHash:  l.PasswordHash,
+		ExpiresAt:     l.ExpiresAt,
+		MaxDownloads:  l.MaxDownloads,
+		DownloadCount: l.DownloadCount,
+		RevokedAt:     l.RevokedAt,
+		CreatedAt:     l.CreatedAt,
+	}, nil
+}
+
+func (l *ShareLinkModel) FromEntity(e *entity.ShareLink) error {
+	l.ID = e.ID
+	l.DocumentID = e.DocumentID
+	l.OwnerID = e.OwnerID
+	l.TokenHash = e.TokenHash
+	l.PasswordHash = e.PasswordHash
+	l.ExpiresAt = e.ExpiresAt
+	l.MaxDownloads = e.MaxDownloads
+	l.DownloadCount = e.DownloadCount
+	l.RevokedAt = e.RevokedAt
+	return nil
+}
+
+type ShareLinkAccessModel struct {
+	BaseModel
+	LinkID     uuid.UUID `gorm:"type:uuid;index"`
+	RemoteAddr string
+	UserAgent  string
+	Outcome    string
+}
+
+func (a *ShareLinkAccessModel) TableName() string {
+	return "share_link_accesses"
+}
+
+func (a *ShareLinkAccessModel) ToEntity() (*entity.ShareLinkAccess, error) {
+	return &entity.ShareLinkAccess{
+		ID:         a.ID,
+		LinkID:     a.LinkID,
+		RemoteAddr: a.RemoteAddr,
+		UserAgent:  a.UserAgent,
+		Outcome:    entity.LinkAccessOutcome(a.Outcome),
+		CreatedAt:  a.CreatedAt,
+	}, nil
+}
+
+func (a *ShareLinkAccessModel) FromEntity(e *entity.ShareLinkAccess) error {
+	a.ID = e.ID
+	a.LinkID = e.LinkID
+	a.RemoteAddr = e.RemoteAddr
+	a.UserAgent = e.UserAgent
+	a.Outcome = string(e.Outcome)
+	return nil
+}
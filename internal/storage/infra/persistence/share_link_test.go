@@ -0,0 +1,109 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
+	testpersistence "github.com/a1y/doc-formatter/pkg/persistence"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestShareLinkRepository_Create_Invalid(t *testing.T) {
+	db, mock, err := testpersistence.GetMockDB()
+	assert.NoError(t, err)
+
+	repo := NewShareLinkRepository(db)
+
+	err = repo.Create(context.Background(), &entity.ShareLink{DocumentID: uuid.New(), OwnerID: uuid.New()})
+	assert.Error(t, err)
+	assert.NoError(t, repo.DeleteByDocuments(context.Background(), nil))
+
+	mock.ExpectClose()
+	testpersistence.CloseDB(t, db)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestShareLinkRepository_SQLiteIntegration(t *testing.T) {
+	t.Parallel()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, AutoMigrate(db))
+
+	repo := NewShareLinkRepository(db)
+	ctx := context.Background()
+
+	documentID, ownerID := uuid.New(), uuid.New()
+	expiresAt := time.Now().Add(time.Hour).UTC()
+	limited := &entity.ShareLink{
+		DocumentID:   documentID,
+		OwnerID:      ownerID,
+		TokenHash:    "limited",
+		PasswordHash: "hash",
+		ExpiresAt:    &expiresAt,
+		MaxDownloads: 2,
+	}
+	require.NoError(t, repo.Create(ctx, limited))
+	require.NotEqual(t, uuid.Nil, limited.ID)
+	unlimited := &entity.ShareLink{DocumentID: documentID, OwnerID: ownerID, TokenHash: "unlimited"}
+	require.NoError(t, repo.Create(ctx, unlimited))
+
+	// Token hashes are unique.
+	require.Error(t, repo.Create(ctx, &entity.ShareLink{DocumentID: documentID, OwnerID: ownerID, TokenHash: "limited"}))
+
+	stored, err := repo.GetByTokenHash(ctx, "limited")
+	require.NoError(t, err)
+	require.Equal(t, limited.ID, stored.ID)
+	require.True(t, stored.HasPassword())
+	require.WithinDuration(t, expiresAt, *stored.ExpiresAt, time.Second)
+
+	_, err = repo.GetByTokenHash(ctx, "unknown")
+	require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+	for range 2 {
+		ok, err := repo.IncrementDownloads(ctx, limited.ID)
+		require.NoError(t, err)
+		require.True(t, ok)
+	}
+	ok, err := repo.IncrementDownloads(ctx, limited.ID)
+	require.NoError(t, err)
+	require.False(t, ok)
+	for range 3 {
+		ok, err := repo.IncrementDownloads(ctx, unlimited.ID)
+		require.NoError(t, err)
+		require.True(t, ok)
+	}
+
+	stored, err = repo.GetByID(ctx, limited.ID)
+	require.NoError(t, err)
+	require.Equal(t, 2, stored.DownloadCount)
+
+	revokedAt := time.Now().UTC()
+	require.NoError(t, repo.Revoke(ctx, unlimited.ID, revokedAt))
+	stored, err = repo.GetByID(ctx, unlimited.ID)
+	require.NoError(t, err)
+	require.Equal(t, 3, stored.DownloadCount)
+	require.WithinDuration(t, revokedAt, *stored.RevokedAt, time.Second)
+
+	links, err := repo.ListByDocument(ctx, documentID)
+	require.NoError(t, err)
+	require.Len(t, links, 2)
+
+	access := &entity.ShareLinkAccess{LinkID: limited.ID, RemoteAddr: "192.0.2.1", UserAgent: "curl", Outcome: entity.LinkAccessDownloaded}
+	require.NoError(t, repo.RecordAccess(ctx, access))
+	require.NotEqual(t, uuid.Nil, access.ID)
+
+	require.NoError(t, repo.DeleteByDocuments(ctx, []uuid.UUID{documentID}))
+	links, err = repo.ListByDocument(ctx, documentID)
+	require.NoError(t, err)
+	require.Empty(t, links)
+	var accesses int64
+	require.NoError(t, db.Model(&ShareLinkAccessModel{}).Count(&accesses).Error)
+	require.Zero(t, accesses)
+}
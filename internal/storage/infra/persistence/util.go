@@ -6,8 +6,16 @@ import (
 
 // AutoMigrate runs database migrations for the storage service.
 func AutoMigrate(db *gorm.DB) error {
-	if err := db.AutoMigrate(&DocumentModel{}); err != nil {
+	if err := db.AutoMigrate(&DocumentModel{}, &FolderModel{}, &ACLEntryModel{}, &ShareLinkModel{}, &ShareLinkAccessModel{}, &ShareLinkAttemptModel{}, &DocumentTextModel{}, &DocumentAnalysisModel{}, &DocumentSourceModel{}, &BatchJobModel{}, &BatchJobItemModel{}); err != nil {
 		return err
 	}
+	if db.Dialector.Name() != "postgres" {
+		return nil
+	}
+	for _, stmt := range PostgresIndexes {
+		if err := db.Exec(stmt).Error; err != nil {
+			return err
+		}
+	}
 	return nil
 }
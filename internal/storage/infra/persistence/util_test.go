@@ -18,3 +18,99 @@ func TestAutoMigrate_DocumentModel_WithSQLite(t *testing.T) {
 	err = AutoMigrate(db)
 	assert.NoError(t, err)
 }
+
+func TestAutoMigrate_FolderModel_WithSQLite(t *testing.T) {
+	t.Parallel()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	require.NoError(t, AutoMigrate(db))
+	assert.True(t, db.Migrator().HasTable(&FolderModel{}))
+	assert.True(t, db.Migrator().HasColumn(&DocumentModel{}, "folder_id"))
+}
+
+func TestAutoMigrate_DocumentLabels_WithSQLite(t *testing.T) {
+	t.Parallel()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	// The GIN indexes are PostgreSQL only and must not break SQLite.
+	require.NoError(t, AutoMigrate(db))
+	for _, column := range []string{"content_type", "tags", "metadata"} {
+		assert.True(t, db.Migrator().HasColumn(&DocumentModel{}, column), column)
+	}
+	assert.False(t, db.Migrator().HasIndex(&DocumentModel{}, "idx_documents_tags"))
+}
+
+func TestAutoMigrate_ACLEntryModel_WithSQLite(t *testing.T) {
+	t.Parallel()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	require.NoError(t, AutoMigrate(db))
+	assert.True(t, db.Migrator().HasTable("acl_entries"))
+	assert.True(t, db.Migrator().HasIndex(&ACLEntryModel{}, "idx_acl_entries_resource_id_user_id"))
+}
+
+func TestAutoMigrate_ShareLinkModels_WithSQLite(t *testing.T) {
+	t.Parallel()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	require.NoError(t, AutoMigrate(db))
+	assert.True(t, db.Migrator().HasTable("share_links"))
+	assert.True(t, db.Migrator().HasTable("share_link_accesses"))
+	assert.True(t, db.Migrator().HasIndex(&ShareLinkModel{}, "idx_share_links_token_hash"))
+}
+
+func TestAutoMigrate_DocumentTextModel_WithSQLite(t *testing.T) {
+	t.Parallel()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	require.NoError(t, AutoMigrate(db))
+	assert.True(t, db.Migrator().HasTable("document_texts"))
+	assert.True(t, db.Migrator().HasIndex(&DocumentTextModel{}, "idx_document_texts_document_id"))
+}
+
+func TestAutoMigrate_DocumentAnalysisModel_WithSQLite(t *testing.T) {
+	t.Parallel()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	require.NoError(t, AutoMigrate(db))
+	assert.True(t, db.Migrator().HasTable("document_analyses"))
+	assert.True(t, db.Migrator().HasIndex(&DocumentAnalysisModel{}, "idx_document_analyses_document_id"))
+}
+
+func TestAutoMigrate_DocumentSourceModel_WithSQLite(t *testing.T) {
+	t.Parallel()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	require.NoError(t, AutoMigrate(db))
+	assert.True(t, db.Migrator().HasTable("document_sources"))
+	assert.True(t, db.Migrator().HasIndex(&DocumentSourceModel{}, "idx_document_sources_document_id"))
+	assert.True(t, db.Migrator().HasIndex(&DocumentSourceModel{}, "idx_document_sources_source_id"))
+}
+
+func TestAutoMigrate_BatchJobModels_WithSQLite(t *testing.T) {
+	t.Parallel()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	require.NoError(t, AutoMigrate(db))
+	assert.True(t, db.Migrator().HasTable("batch_jobs"))
+	assert.True(t, db.Migrator().HasTable("batch_job_items"))
+	assert.True(t, db.Migrator().HasIndex(&BatchJobModel{}, "idx_batch_jobs_status"))
+	assert.True(t, db.Migrator().HasIndex(&BatchJobItemModel{}, "idx_batch_job_items_job_id"))
+	assert.True(t, db.Migrator().HasIndex(&BatchJobItemModel{}, "idx_batch_job_items_status"))
+}
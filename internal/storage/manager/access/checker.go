@@ -0,0 +1,120 @@
+package access
+
+import (
+	"context"
+	"errors"
+
+	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
+	"github.com/a1y/doc-formatter/internal/storage/domain/repository"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Checker resolves the role of a user on documents and folders. A role
+// granted on a folder extends to everything the folder holds, and the
+// highest of the roles that apply wins.
+type Checker struct {
+	aclRepo    repository.ACLRepository
+	folderRepo repository.FolderRepository
+}
+
+// NewChecker returns a checker. Without an ACL repository, users only have
+// access to what they own.
+func NewChecker(aclRepo repository.ACLRepository, folderRepo repository.FolderRepository) *Checker {
+	return &Checker{
+		aclRepo:    aclRepo,
+		folderRepo: folderRepo,
+	}
+}
+
+// DocumentRole returns the role of userID on document, or an empty role when
+// the user has no access to it.
+func (c *Checker) DocumentRole(ctx context.Context, userID uuid.UUID, document *entity.Document) (entity.Role, error) {
+	if document.UserID == userID {
+		return entity.RoleOwner, nil
+	}
+	return c.grantedRole(ctx, userID, document.ID, document.FolderID)
+}
+
+// FolderRole returns the role of userID on folder, or an empty role when the
+// user has no access to it.
+func (c *Checker) FolderRole(ctx context.Context, userID uuid.UUID, folder *entity.Folder) (entity.Role, error) {
+	if folder.UserID == userID {
+		return entity.RoleOwner, nil
+	}
+	return c.grantedRole(ctx, userID, folder.ID, folder.ParentID)
+}
+
+// grantedRole returns the highest role granted to userID on resourceID or
+// on one of the folders above it, starting at parentID.
+func (c *Checker) grantedRole(ctx context.Context, userID, resourceID uuid.UUID, parentID *uuid.UUID) (entity.Role, error) {
+	if c.aclRepo == nil {
+		return "", nil
+	}
+
+	resourceIDs := []uuid.UUID{resourceID}
+	for parentID != nil {
+		parent, err := c.folderRepo.GetByID(ctx, *parentID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				break
+			}
+			return "", err
+		}
+		resourceIDs = append(resourceIDs, parent.ID)
+		parentID = parent.ParentID
+	}
+
+	entries, err := c.aclRepo.ListForUser(ctx, userID, resourceIDs)
+	if err != nil {
+		return "", err
+	}
+	var role entity.Role
+	for _, entry := range entries {
+		if !role.Allows(entry.Role) {
+			role = entry.Role
+		}
+	}
+	return role, nil
+}
+
+// SharedWith returns the documents shared with userID and the folders whose
+// content userID can read through a share: the shared folders and every
+// folder below them.
+func (c *Checker) SharedWith(ctx context.Context, userID uuid.UUID) ([]uuid.UUID, []uuid.UUID, error) {
+	if c.aclRepo == nil {
+		return nil, nil, nil
+	}
+	entries, err := c.aclRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var documentIDs, folderIDs []uuid.UUID
+	seen := make(map[uuid.UUID]bool)
+	for _, entry := range entries {
+		switch entry.ResourceType {
+		case entity.ResourceDocument:
+			documentIDs = append(documentIDs, entry.ResourceID)
+		case entity.ResourceFolder:
+			pending := []uuid.UUID{entry.ResourceID}
+			for len(pending) > 0 {
+				folderID := pending[0]
+				pending = pending[1:]
+				if seen[folderID] {
+					continue
+				}
+				seen[folderID] = true
+				folderIDs = append(folderIDs, folderID)
+				children, err := c.folderRepo.ListByParent(ctx, entry.OwnerID, &folderID)
+				if err != nil {
+					return nil, nil, err
+				}
+				for _, child := range children {
+					pending = append(pending, child.ID)
+				}
+			}
+		}
+	}
+	return documentIDs, folderIDs, nil
+}
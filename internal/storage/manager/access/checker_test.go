@@ -0,0 +1,123 @@
+package access
+
+import (
+	"context"
+	"testing"
+
+	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
+	"github.com/a1y/doc-formatter/internal/storage/infra/persistence"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestChecker_Roles(t *testing.T) {
+	t.Parallel()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, persistence.AutoMigrate(db))
+
+	ctx := context.Background()
+	aclRepo := persistence.NewACLRepository(db)
+	folderRepo := persistence.NewFolderRepository(db)
+	checker := NewChecker(aclRepo, folderRepo)
+
+	ownerID, userID := uuid.New(), uuid.New()
+	parent := &entity.Folder{UserID: ownerID, Name: "Clients"}
+	require.NoError(t, folderRepo.Create(ctx, parent))
+	child := &entity.Folder{UserID: ownerID, ParentID: &parent.ID, Name: "Acme"}
+	require.NoError(t, folderRepo.Create(ctx, child))
+	document := &entity.Document{ID: uuid.New(), UserID: ownerID, FolderID: &child.ID}
+
+	role, err := checker.DocumentRole(ctx, ownerID, document)
+	require.NoError(t, err)
+	require.Equal(t, entity.RoleOwner, role)
+
+	role, err = checker.DocumentRole(ctx, userID, document)
+	require.NoError(t, err)
+	require.Empty(t, role)
+
+	// A role on a folder extends to everything below it.
+	require.NoError(t, aclRepo.Upsert(ctx, &entity.ACLEntry{
+		ResourceType: entity.ResourceFolder,
+		ResourceID:   parent.ID,
+		OwnerID:      ownerID,
+		UserID:       userID,
+		Role:         entity.RoleCommenter,
+	}))
+	role, err = checker.DocumentRole(ctx, userID, document)
+	require.NoError(t, err)
+	require.Equal(t, entity.RoleCommenter, role)
+
+	role, err = checker.FolderRole(ctx, userID, child)
+	require.NoError(t, err)
+	require.Equal(t, entity.RoleCommenter, role)
+
+	// The highest role that applies wins.
+	require.NoError(t, aclRepo.Upsert(ctx, &entity.ACLEntry{
+		ResourceType: entity.ResourceDocument,
+		ResourceID:   document.ID,
+		OwnerID:      ownerID,
+		UserID:       userID,
+		Role:         entity.RoleEditor,
+	}))
+	role, err = checker.DocumentRole(ctx, userID, document)
+	require.NoError(t, err)
+	require.Equal(t, entity.RoleEditor, role)
+
+	role, err = checker.FolderRole(ctx, uuid.New(), parent)
+	require.NoError(t, err)
+	require.Empty(t, role)
+
+	role, err = NewChecker(nil, folderRepo).DocumentRole(ctx, userID, document)
+	require.NoError(t, err)
+	require.Empty(t, role)
+}
+
+func TestChecker_SharedWith(t *testing.T) {
+	t.Parallel()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, persistence.AutoMigrate(db))
+
+	ctx := context.Background()
+	aclRepo := persistence.NewACLRepository(db)
+	folderRepo := persistence.NewFolderRepository(db)
+	checker := NewChecker(aclRepo, folderRepo)
+
+	ownerID, userID := uuid.New(), uuid.New()
+	parent := &entity.Folder{UserID: ownerID, Name: "Clients"}
+	require.NoError(t, folderRepo.Create(ctx, parent))
+	child := &entity.Folder{UserID: ownerID, ParentID: &parent.ID, Name: "Acme"}
+	require.NoError(t, folderRepo.Create(ctx, child))
+	other := &entity.Folder{UserID: ownerID, Name: "Private"}
+	require.NoError(t, folderRepo.Create(ctx, other))
+	documentID := uuid.New()
+
+	documentIDs, folderIDs, err := checker.SharedWith(ctx, userID)
+	require.NoError(t, err)
+	require.Empty(t, documentIDs)
+	require.Empty(t, folderIDs)
+
+	for _, entry := range []*entity.ACLEntry{
+		{ResourceType: entity.ResourceFolder, ResourceID: parent.ID, OwnerID: ownerID, UserID: userID, Role: entity.RoleViewer},
+		// A share on a folder below a shared one adds nothing.
+		{ResourceType: entity.ResourceFolder, ResourceID: child.ID, OwnerID: ownerID, UserID: userID, Role: entity.RoleEditor},
+		{ResourceType: entity.ResourceDocument, ResourceID: documentID, OwnerID: ownerID, UserID: userID, Role: entity.RoleViewer},
+	} {
+		require.NoError(t, aclRepo.Upsert(ctx, entry))
+	}
+
+	documentIDs, folderIDs, err = checker.SharedWith(ctx, userID)
+	require.NoError(t, err)
+	require.Equal(t, []uuid.UUID{documentID}, documentIDs)
+	require.ElementsMatch(t, []uuid.UUID{parent.ID, child.ID}, folderIDs)
+
+	documentIDs, folderIDs, err = NewChecker(nil, folderRepo).SharedWith(ctx, userID)
+	require.NoError(t, err)
+	require.Empty(t, documentIDs)
+	require.Empty(t, folderIDs)
+}
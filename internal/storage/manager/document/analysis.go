@@ -0,0 +1,59 @@
+package document
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/a1y/doc-formatter/internal/storage/domain/constant"
+	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
+	"github.com/a1y/doc-formatter/internal/storage/util/docanalysis"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AnalyzeDocument returns the structure of a document userID can view. The
+// analysis is stored and only made again once the content of the document
+// or the analyzer changes.
+func (m *DocumentManager) AnalyzeDocument(ctx context.Context, userID, documentID uuid.UUID) (*entity.DocumentAnalysis, error) {
+	document, err := m.getDocument(ctx, userID, documentID, entity.RoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	cached, err := m.analysisRepo.GetByDocument(ctx, document.ID)
+	switch {
+	case err == nil && cached.IsCurrent(document):
+		return cached, nil
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound):
+		return nil, err
+	}
+
+	if !docanalysis.Supported(document.ContentType) {
+		return nil, fmt.Errorf("%w: %s", constant.ErrUnsupportedFormat, document.ContentType)
+	}
+	content, err := m.openContent(ctx, document)
+	if err != nil {
+		return nil, err
+	}
+	defer content.Close()
+
+	result, err := docanalysis.Analyze(document.ContentType, content)
+	if errors.Is(err, docanalysis.ErrMalformedDocument) || errors.Is(err, docanalysis.ErrDocumentTooLarge) {
+		return nil, fmt.Errorf("%w: %v", constant.ErrMalformedDocument, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	analysis := &entity.DocumentAnalysis{
+		DocumentID: document.ID,
+		ObjectKey:  document.ObjectKey,
+		Version:    docanalysis.Version,
+		Analysis:   result,
+	}
+	if err := m.analysisRepo.Save(ctx, analysis); err != nil {
+		return nil, err
+	}
+	return analysis, nil
+}
@@ -0,0 +1,81 @@
+package document
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/a1y/doc-formatter/internal/storage/domain/constant"
+	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
+	"github.com/a1y/doc-formatter/internal/storage/domain/repository"
+	"github.com/a1y/doc-formatter/internal/storage/infra/persistence"
+	"github.com/a1y/doc-formatter/internal/storage/util/docanalysis"
+	"github.com/a1y/doc-formatter/internal/storage/util/objectstore/memory"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// countingAnalysisRepository counts the analyses saved, that is made.
+type countingAnalysisRepository struct {
+	repository.DocumentAnalysisRepository
+
+	saved int
+}
+
+func (r *countingAnalysisRepository) Save(ctx context.Context, a *entity.DocumentAnalysis) error {
+	r.saved++
+	return r.DocumentAnalysisRepository.Save(ctx, a)
+}
+
+func TestDocumentManager_AnalyzeDocument(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, persistence.AutoMigrate(db))
+
+	analysisRepo := &countingAnalysisRepository{DocumentAnalysisRepository: persistence.NewDocumentAnalysisRepository(db)}
+	manager := NewDocumentManager(persistence.NewDocumentRepository(db), persistence.NewFolderRepository(db), persistence.NewACLRepository(db), memory.NewMemoryStorage())
+	manager.SetAnalysisRepository(analysisRepo)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	doc, err := manager.UploadDocument(ctx, &entity.Document{UserID: userID, FileName: "guide.md"},
+		bytes.NewReader([]byte("# Guide\n\n## Install\n\nRun the tool.\n")))
+	require.NoError(t, err)
+
+	analysis, err := manager.AnalyzeDocument(ctx, userID, doc.ID)
+	require.NoError(t, err)
+	require.Equal(t, doc.ObjectKey, analysis.ObjectKey)
+	require.Equal(t, []docanalysis.Heading{
+		{Level: 1, Text: "Guide", Children: []docanalysis.Heading{{Level: 2, Text: "Install"}}},
+	}, analysis.Analysis.Outline)
+	require.Equal(t, 1, analysisRepo.saved)
+
+	// The stored analysis is returned as long as it is current.
+	cached, err := manager.AnalyzeDocument(ctx, userID, doc.ID)
+	require.NoError(t, err)
+	require.Equal(t, analysis.Analysis, cached.Analysis)
+	require.Equal(t, 1, analysisRepo.saved)
+
+	stale := *cached
+	stale.Version = docanalysis.Version - 1
+	require.NoError(t, analysisRepo.DocumentAnalysisRepository.Save(ctx, &stale))
+	_, err = manager.AnalyzeDocument(ctx, userID, doc.ID)
+	require.NoError(t, err)
+	require.Equal(t, 2, analysisRepo.saved)
+
+	_, err = manager.AnalyzeDocument(ctx, uuid.New(), doc.ID)
+	require.ErrorIs(t, err, constant.ErrDocumentNotFound)
+
+	pdf, err := manager.UploadDocument(ctx, &entity.Document{UserID: userID, FileName: "scan.pdf"}, bytes.NewReader([]byte("%PDF")))
+	require.NoError(t, err)
+	_, err = manager.AnalyzeDocument(ctx, userID, pdf.ID)
+	require.ErrorIs(t, err, constant.ErrUnsupportedFormat)
+
+	broken, err := manager.UploadDocument(ctx, &entity.Document{UserID: userID, FileName: "broken.docx"}, bytes.NewReader([]byte("not a zip")))
+	require.NoError(t, err)
+	_, err = manager.AnalyzeDocument(ctx, userID, broken.ID)
+	require.ErrorIs(t, err, constant.ErrMalformedDocument)
+	require.Equal(t, 2, analysisRepo.saved)
+}
@@ -0,0 +1,509 @@
+package document
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"runtime/debug"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/a1y/doc-formatter/internal/storage/domain/constant"
+	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
+	"github.com/a1y/doc-formatter/internal/storage/util/docstyle"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// BatchItemsPerRun is the number of pending documents of batch jobs loaded
+// per query while running them.
+const BatchItemsPerRun = 50
+
+// BatchTarget selects the documents a batch job formats: those of a folder
+// owned by the user, those of the user carrying a tag, or a list of
+// documents the user can view. Exactly one of them is set.
+type BatchTarget struct {
+	FolderID *uuid.UUID
+	// Recursive includes the documents of the subfolders of FolderID.
+	Recursive   bool
+	Tag         string
+	DocumentIDs []uuid.UUID
+}
+
+// BatchOptions tell where the documents formatted by a batch job go.
+type BatchOptions struct {
+	// OutputFolderID is the folder of the formatted documents. When nil, they
+	// go into the folder formatted, or to the top level for other targets.
+	OutputFolderID *uuid.UUID
+	// Archive asks for a ZIP archive of the formatted documents as well.
+	Archive bool
+}
+
+// CreateBatchJob creates a job formatting the documents of target with
+// profile, for userID. The documents are listed right away and formatted in
+// the background, each into a new document next to the others of the job.
+func (m *DocumentManager) CreateBatchJob(ctx context.Context, userID uuid.UUID, target BatchTarget, profile docstyle.Profile, opts BatchOptions) (*entity.BatchJob, []*entity.BatchJobItem, error) {
+	set := 0
+	for _, ok := range []bool{target.FolderID != nil, target.Tag != "", len(target.DocumentIDs) > 0} {
+		if ok {
+			set++
+		}
+	}
+	if set != 1 {
+		return nil, nil, fmt.Errorf("%w: a folder, a tag or a list of documents is required", constant.ErrInvalidBatchJob)
+	}
+	if profile.Empty() {
+		return nil, nil, fmt.Errorf("%w: the profile changes nothing", constant.ErrInvalidBatchJob)
+	}
+	outputFolderID := opts.OutputFolderID
+	if outputFolderID == nil {
+		outputFolderID = target.FolderID
+	}
+	job := &entity.BatchJob{
+		UserID:         userID,
+		Status:         entity.BatchJobRunning,
+		FolderID:       target.FolderID,
+		Recursive:      target.Recursive,
+		Tag:            target.Tag,
+		Profile:        profile,
+		OutputFolderID: outputFolderID,
+		Archive:        opts.Archive,
+	}
+	if err := job.Validate(); err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", constant.ErrInvalidBatchJob, err)
+	}
+	if _, err := m.checkFolder(ctx, userID, outputFolderID, entity.RoleOwner); err != nil {
+		return nil, nil, err
+	}
+
+	documents, err := m.batchDocuments(ctx, userID, target)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(documents) == 0 {
+		return nil, nil, fmt.Errorf("%w: the target holds no documents", constant.ErrInvalidBatchJob)
+	}
+	items := make([]*entity.BatchJobItem, len(documents))
+	for i, document := range documents {
+		items[i] = &entity.BatchJobItem{
+			DocumentID: document.ID,
+			FileName:   document.FileName,
+			Position:   i,
+			Status:     entity.BatchItemPending,
+		}
+	}
+	if err := m.batchRepo.Create(ctx, job, items); err != nil {
+		return nil, nil, err
+	}
+	return job, items, nil
+}
+
+// GetBatchJob returns a batch job of userID along with its items, by
+// position.
+func (m *DocumentManager) GetBatchJob(ctx context.Context, userID, jobID uuid.UUID) (*entity.BatchJob, []*entity.BatchJobItem, error) {
+	job, err := m.batchRepo.GetByID(ctx, jobID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, constant.ErrBatchJobNotFound
+		}
+		return nil, nil, err
+	}
+	if job.UserID != userID {
+		return nil, nil, constant.ErrBatchJobNotFound
+	}
+	items, err := m.batchRepo.ListItems(ctx, job.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return job, items, nil
+}
+
+// batchDocuments lists the documents of target, at most
+// entity.MaxBatchDocuments of them.
+func (m *DocumentManager) batchDocuments(ctx context.Context, userID uuid.UUID, target BatchTarget) ([]*entity.Document, error) {
+	tooMany := fmt.Errorf("%w: a job formats at most %d documents", constant.ErrInvalidBatchJob, entity.MaxBatchDocuments)
+	var documents []*entity.Document
+	switch {
+	case target.FolderID != nil:
+		if _, err := m.checkFolder(ctx, userID, target.FolderID, entity.RoleOwner); err != nil {
+			return nil, err
+		}
+		folderIDs := []uuid.UUID{*target.FolderID}
+		for len(folderIDs) > 0 {
+			folderID := folderIDs[0]
+			folderIDs = folderIDs[1:]
+			contained, err := m.documentRepo.ListByFolder(ctx, userID, &folderID)
+			if err != nil {
+				return nil, err
+			}
+			if documents = append(documents, contained...); len(documents) > entity.MaxBatchDocuments {
+				return nil, tooMany
+			}
+			if !target.Recursive {
+				continue
+			}
+			children, err := m.folderRepo.ListByParent(ctx, userID, &folderID)
+			if err != nil {
+				return nil, err
+			}
+			for _, child := range children {
+				folderIDs = append(folderIDs, child.ID)
+			}
+		}
+
+	case target.Tag != "":
+		filter := &entity.DocumentFilter{Tags: []string{target.Tag}}
+		page := &entity.DocumentPage{Sort: entity.SortByName, Size: entity.MaxPageSize}
+		for {
+			listed, err := m.documentRepo.List(ctx, userID, filter, page)
+			if err != nil {
+				return nil, err
+			}
+			if documents = append(documents, listed...); len(documents) > entity.MaxBatchDocuments {
+				return nil, tooMany
+			}
+			if len(listed) < page.Size {
+				break
+			}
+			page.After = listed[len(listed)-1]
+		}
+
+	default:
+		if len(target.DocumentIDs) > entity.MaxBatchDocuments {
+			return nil, tooMany
+		}
+		seen := make(map[uuid.UUID]bool, len(target.DocumentIDs))
+		for _, id := range target.DocumentIDs {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			document, err := m.getDocument(ctx, userID, id, entity.RoleViewer)
+			if err != nil {
+				return nil, err
+			}
+			documents = append(documents, document)
+		}
+	}
+	return documents, nil
+}
+
+// RunBatchJobs formats the pending documents of every batch job, up to
+// concurrency at a time, oldest jobs first, and completes the jobs whose
+// documents are all done. Documents left running by an interrupted run are
+// formatted again. It returns the number of documents formatted, whether
+// they succeeded or not. Only one run may go on at a time, which
+// BatchRunner sees to.
+func (m *DocumentManager) RunBatchJobs(ctx context.Context, concurrency int) (int, error) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	if _, err := m.batchRepo.ResetRunningItems(ctx); err != nil {
+		return 0, err
+	}
+	jobs := make(map[uuid.UUID]*entity.BatchJob)
+	formatted := 0
+	for {
+		items, err := m.batchRepo.ListPendingItems(ctx, BatchItemsPerRun)
+		if err != nil {
+			return formatted, err
+		}
+		if len(items) > 0 {
+			n, err := m.runBatchItems(ctx, jobs, items, concurrency)
+			formatted += n
+			if err != nil {
+				return formatted, err
+			}
+		}
+		if err := m.completeBatchJobs(ctx); err != nil {
+			return formatted, err
+		}
+		if len(items) == 0 {
+			return formatted, nil
+		}
+	}
+}
+
+// runBatchItems formats items, up to concurrency at a time, and returns the
+// number formatted. jobs caches the jobs of the items.
+func (m *DocumentManager) runBatchItems(ctx context.Context, jobs map[uuid.UUID]*entity.BatchJob, items []*entity.BatchJobItem, concurrency int) (int, error) {
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		formatted int
+		failure   error
+	)
+	fail := func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if failure == nil {
+			failure = err
+		}
+	}
+	failed := func() error {
+		mu.Lock()
+		defer mu.Unlock()
+		return failure
+	}
+
+	slots := make(chan struct{}, concurrency)
+	for _, item := range items {
+		job, ok := jobs[item.JobID]
+		if !ok {
+			var err error
+			if job, err = m.batchRepo.GetByID(ctx, item.JobID); err != nil {
+				fail(err)
+				break
+			}
+			jobs[item.JobID] = job
+		}
+		slots <- struct{}{}
+		if err := ctx.Err(); err != nil {
+			fail(err)
+			break
+		}
+		if err := failed(); err != nil {
+			break
+		}
+		item.Status = entity.BatchItemRunning
+		if err := m.batchRepo.UpdateItem(ctx, item); err != nil {
+			fail(err)
+			break
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+			defer func() {
+				if r := recover(); r != nil {
+					logrus.Errorf("Panic while running batch job %s: %v\n%s", job.ID, r, debug.Stack())
+					fail(fmt.Errorf("panic while running batch job %s: %v", job.ID, r))
+				}
+			}()
+			m.formatBatchItem(ctx, job, item)
+			// An interrupted item stays running, and is formatted again by
+			// the next run.
+			if ctx.Err() != nil {
+				fail(ctx.Err())
+				return
+			}
+			if err := m.batchRepo.UpdateItem(ctx, item); err != nil {
+				fail(err)
+				return
+			}
+			mu.Lock()
+			formatted++
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	return formatted, failure
+}
+
+// formatBatchItem formats the document of item for job, and records the
+// outcome in item.
+func (m *DocumentManager) formatBatchItem(ctx context.Context, job *entity.BatchJob, item *entity.BatchJobItem) {
+	// Documents come from users, so one that trips up the formatter fails
+	// its item rather than the whole run.
+	defer func() {
+		if r := recover(); r != nil {
+			logrus.Errorf("Panic while formatting document %s of batch job %s: %v\n%s", item.DocumentID, job.ID, r, debug.Stack())
+			item.Status = entity.BatchItemFailed
+			item.Error = constant.ErrMalformedDocument.Error()
+			item.OutputID = nil
+			item.Warnings = nil
+		}
+	}()
+	output, warnings, err := m.formatDocument(ctx, job, item.DocumentID)
+	if err != nil {
+		item.Status = entity.BatchItemFailed
+		item.Error = err.Error()
+		return
+	}
+	item.Status = entity.BatchItemSucceeded
+	item.OutputID = &output.ID
+	item.Warnings = warnings
+}
+
+// formatDocument makes a document of job.UserID of the document documentID
+// formatted with the profile of job, in the output folder of the job.
+func (m *DocumentManager) formatDocument(ctx context.Context, job *entity.BatchJob, documentID uuid.UUID) (*entity.Document, []string, error) {
+	document, err := m.getDocument(ctx, job.UserID, documentID, entity.RoleViewer)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !docstyle.Supported(document.ContentType) {
+		return nil, nil, fmt.Errorf("%w: %s", constant.ErrUnsupportedFormat, document.ContentType)
+	}
+	if document.FileSize > docstyle.MaxSize {
+		return nil, nil, styleError(docstyle.ErrDocumentTooLarge)
+	}
+	content, err := m.openContent(ctx, document)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer content.Close()
+
+	data, err := io.ReadAll(io.LimitReader(content, docstyle.MaxSize+1))
+	if err != nil {
+		return nil, nil, err
+	}
+	formatted, warnings, err := docstyle.Apply(document.ContentType, data, job.Profile)
+	if err != nil {
+		return nil, nil, styleError(err)
+	}
+
+	output, err := m.UploadDocument(ctx, &entity.Document{
+		UserID:   job.UserID,
+		FileName: stem(document.FileName) + "-formatted" + path.Ext(document.FileName),
+		FileSize: int64(len(formatted)),
+		FolderID: job.OutputFolderID,
+	}, bytes.NewReader(formatted))
+	if err != nil {
+		return nil, nil, err
+	}
+	link := &entity.DocumentSource{DocumentID: output.ID, SourceID: document.ID, Operation: entity.OperationFormat}
+	if err := m.recordSources(ctx, []*entity.DocumentSource{link}, []*entity.Document{output}); err != nil {
+		return nil, nil, err
+	}
+	return output, warnings, nil
+}
+
+// completeBatchJobs completes the running jobs whose documents are all done,
+// building the archives asked for.
+func (m *DocumentManager) completeBatchJobs(ctx context.Context) error {
+	jobs, err := m.batchRepo.ListRunning(ctx)
+	if err != nil {
+		return err
+	}
+	for _, job := range jobs {
+		items, err := m.batchRepo.ListItems(ctx, job.ID)
+		if err != nil {
+			return err
+		}
+		progress := entity.NewBatchProgress(items)
+		if progress.Pending > 0 || progress.Running > 0 {
+			continue
+		}
+
+		if job.Archive {
+			if progress.Succeeded == 0 {
+				job.Error = "no document was formatted, so there is no archive"
+			} else if err := m.archiveBatchJob(ctx, job, items); err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				logrus.Warnf("Failed to archive the documents of batch job %s: %v", job.ID, err)
+				job.Error = "failed to archive the formatted documents: " + err.Error()
+			}
+		}
+		now := time.Now()
+		job.Status = entity.BatchJobCompleted
+		job.FinishedAt = &now
+		if err := m.batchRepo.Complete(ctx, job); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// archiveBatchJob stores a ZIP archive of the documents formatted by job as
+// a document next to them. Formatted documents deleted since are left out.
+func (m *DocumentManager) archiveBatchJob(ctx context.Context, job *entity.BatchJob, items []*entity.BatchJobItem) error {
+	// Archives may be large, so they are written to disk rather than kept
+	// in memory.
+	file, err := os.CreateTemp("", "batch-*.zip")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	zw := zip.NewWriter(file)
+	names := make(map[string]bool)
+	for _, item := range items {
+		if item.Status != entity.BatchItemSucceeded || item.OutputID == nil {
+			continue
+		}
+		output, err := m.documentRepo.GetByID(ctx, *item.OutputID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if err := m.addToArchive(ctx, zw, archiveName(names, output.FileName), output); err != nil {
+			return err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+
+	size, err := file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	archive, err := m.UploadDocument(ctx, &entity.Document{
+		UserID:   job.UserID,
+		FileName: "batch-" + job.ID.String()[:8] + ".zip",
+		FileSize: size,
+		FolderID: job.OutputFolderID,
+	}, file)
+	if err != nil {
+		return err
+	}
+	job.ArchiveID = &archive.ID
+	return nil
+}
+
+// addToArchive writes the content of document to zw as name.
+func (m *DocumentManager) addToArchive(ctx context.Context, zw *zip.Writer, name string, document *entity.Document) error {
+	content, err := m.openContent(ctx, document)
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+
+	w, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(w, content)
+	return err
+}
+
+// archiveName returns fileName, numbered when names already holds it, and
+// adds it to names.
+func archiveName(names map[string]bool, fileName string) string {
+	name := fileName
+	for i := 2; names[name]; i++ {
+		name = stem(fileName) + " (" + strconv.Itoa(i) + ")" + path.Ext(fileName)
+	}
+	names[name] = true
+	return name
+}
+
+// styleError maps the errors of docstyle to those of the service.
+func styleError(err error) error {
+	switch {
+	case errors.Is(err, docstyle.ErrUnsupportedFormat):
+		return fmt.Errorf("%w: %v", constant.ErrUnsupportedFormat, err)
+	case errors.Is(err, docstyle.ErrInvalidProfile):
+		return fmt.Errorf("%w: %v", constant.ErrInvalidBatchJob, err)
+	case errors.Is(err, docstyle.ErrMalformedDocument), errors.Is(err, docstyle.ErrDocumentTooLarge):
+		return fmt.Errorf("%w: %v", constant.ErrMalformedDocument, err)
+	}
+	return err
+}
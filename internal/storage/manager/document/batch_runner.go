@@ -0,0 +1,76 @@
+package document
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/a1y/doc-formatter/internal/storage/domain/repository"
+	"github.com/sirupsen/logrus"
+)
+
+// batchJobLock names the lock that keeps instances of the storage service
+// from running the same batch jobs at the same time.
+const batchJobLock = "storage.batch-jobs"
+
+// BatchRunner periodically formats the pending documents of batch jobs.
+type BatchRunner struct {
+	manager     *DocumentManager
+	locker      repository.Locker
+	concurrency int
+	interval    time.Duration
+}
+
+// NewBatchRunner returns a runner formatting up to concurrency documents at a
+// time, that looks for new jobs every interval.
+func NewBatchRunner(manager *DocumentManager, locker repository.Locker, concurrency int, interval time.Duration) *BatchRunner {
+	return &BatchRunner{
+		manager:     manager,
+		locker:      locker,
+		concurrency: concurrency,
+		interval:    interval,
+	}
+}
+
+// Run runs the pending batch jobs right away, then every interval until ctx
+// is done.
+func (r *BatchRunner) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		if formatted, err := r.RunOnce(ctx); err != nil {
+			logrus.Errorf("Failed to run batch jobs after %d documents: %v", formatted, err)
+		} else if formatted > 0 {
+			logrus.Infof("Formatted %d documents of batch jobs", formatted)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce formats the pending documents of batch jobs until none is left,
+// unless another instance of the service is already doing so. It returns
+// the number of documents formatted. A panic while running them is returned
+// as an error, so that it does not take the service down.
+func (r *BatchRunner) RunOnce(ctx context.Context) (formatted int, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			logrus.Errorf("Panic while running batch jobs: %v\n%s", p, debug.Stack())
+			err = fmt.Errorf("panic while running batch jobs: %v", p)
+		}
+	}()
+	acquired, err := r.locker.TryLock(ctx, batchJobLock, func(ctx context.Context) error {
+		var err error
+		formatted, err = r.manager.RunBatchJobs(ctx, r.concurrency)
+		return err
+	})
+	if err == nil && !acquired {
+		logrus.Debug("Skipping batch jobs, another instance is running them")
+	}
+	return formatted, err
+}
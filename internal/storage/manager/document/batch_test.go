@@ -0,0 +1,266 @@
+package document
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/a1y/doc-formatter/internal/storage/domain/constant"
+	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
+	"github.com/a1y/doc-formatter/internal/storage/infra/persistence"
+	"github.com/a1y/doc-formatter/internal/storage/util/docstyle"
+	"github.com/a1y/doc-formatter/internal/storage/util/objectstore/memory"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// testBatchDOCX returns a DOCX document of a paragraph of text, without
+// styles.
+func testBatchDOCX(t *testing.T, text string) []byte {
+	t.Helper()
+
+	files := map[string]string{
+		"[Content_Types].xml": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/word/document.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"/></Types>`,
+		"_rels/.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="word/document.xml"/></Relationships>`,
+		"word/document.xml": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body><w:p><w:r><w:t>` + text + `</w:t></w:r></w:p></w:body></w:document>`,
+	}
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, content := range files {
+		f, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func TestDocumentManager_CreateBatchJob(t *testing.T) {
+	t.Parallel()
+
+	manager, db := newMergeTestManager(t)
+	ctx := context.Background()
+	userID, otherID := uuid.New(), uuid.New()
+	folders := persistence.NewFolderRepository(db)
+
+	reports := &entity.Folder{UserID: userID, Name: "Reports"}
+	require.NoError(t, folders.Create(ctx, reports))
+	drafts := &entity.Folder{UserID: userID, Name: "Drafts", ParentID: &reports.ID}
+	require.NoError(t, folders.Create(ctx, drafts))
+	upload := func(name string, folderID *uuid.UUID) *entity.Document {
+		document, err := manager.UploadDocument(ctx, &entity.Document{UserID: userID, FileName: name, FolderID: folderID},
+			bytes.NewReader(testBatchDOCX(t, name)))
+		require.NoError(t, err)
+		return document
+	}
+	annual := upload("annual.docx", &reports.ID)
+	draft := upload("draft.docx", &drafts.ID)
+	loose := upload("loose.docx", nil)
+
+	profile := docstyle.Profile{FontFamily: "Georgia"}
+	job, items, err := manager.CreateBatchJob(ctx, userID, BatchTarget{FolderID: &reports.ID}, profile, BatchOptions{})
+	require.NoError(t, err)
+	require.Equal(t, entity.BatchJobRunning, job.Status)
+	require.Equal(t, &reports.ID, job.OutputFolderID)
+	require.Len(t, items, 1)
+	require.Equal(t, annual.ID, items[0].DocumentID)
+
+	_, items, err = manager.CreateBatchJob(ctx, userID, BatchTarget{FolderID: &reports.ID, Recursive: true}, profile, BatchOptions{})
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	require.Equal(t, draft.ID, items[1].DocumentID)
+	require.Equal(t, 1, items[1].Position)
+
+	// Tags are matched by the database, which the tests of the repository
+	// cover.
+	job, items, err = manager.CreateBatchJob(ctx, userID, BatchTarget{DocumentIDs: []uuid.UUID{loose.ID, annual.ID, loose.ID}}, profile,
+		BatchOptions{OutputFolderID: &drafts.ID})
+	require.NoError(t, err)
+	require.Equal(t, &drafts.ID, job.OutputFolderID)
+	require.Len(t, items, 2)
+	require.Equal(t, loose.ID, items[0].DocumentID)
+
+	got, gotItems, err := manager.GetBatchJob(ctx, userID, job.ID)
+	require.NoError(t, err)
+	require.Equal(t, profile, got.Profile)
+	require.Len(t, gotItems, 2)
+	_, _, err = manager.GetBatchJob(ctx, otherID, job.ID)
+	require.ErrorIs(t, err, constant.ErrBatchJobNotFound)
+	_, _, err = manager.GetBatchJob(ctx, userID, uuid.New())
+	require.ErrorIs(t, err, constant.ErrBatchJobNotFound)
+
+	for _, target := range []BatchTarget{
+		{},
+		{FolderID: &reports.ID, Tag: "legal"},
+		{FolderID: &drafts.ID, DocumentIDs: []uuid.UUID{loose.ID}},
+	} {
+		_, _, err = manager.CreateBatchJob(ctx, userID, target, profile, BatchOptions{})
+		require.ErrorIs(t, err, constant.ErrInvalidBatchJob, "%+v", target)
+	}
+	_, _, err = manager.CreateBatchJob(ctx, userID, BatchTarget{DocumentIDs: []uuid.UUID{loose.ID}}, docstyle.Profile{}, BatchOptions{})
+	require.ErrorIs(t, err, constant.ErrInvalidBatchJob)
+	_, _, err = manager.CreateBatchJob(ctx, userID, BatchTarget{DocumentIDs: []uuid.UUID{loose.ID}}, docstyle.Profile{FontSize: 2000}, BatchOptions{})
+	require.ErrorIs(t, err, constant.ErrInvalidBatchJob)
+	empty := &entity.Folder{UserID: userID, Name: "Empty"}
+	require.NoError(t, folders.Create(ctx, empty))
+	_, _, err = manager.CreateBatchJob(ctx, userID, BatchTarget{FolderID: &empty.ID}, profile, BatchOptions{})
+	require.ErrorIs(t, err, constant.ErrInvalidBatchJob)
+	_, _, err = manager.CreateBatchJob(ctx, otherID, BatchTarget{FolderID: &reports.ID}, profile, BatchOptions{})
+	require.ErrorIs(t, err, constant.ErrFolderNotFound)
+	_, _, err = manager.CreateBatchJob(ctx, otherID, BatchTarget{DocumentIDs: []uuid.UUID{loose.ID}}, profile, BatchOptions{})
+	require.ErrorIs(t, err, constant.ErrDocumentNotFound)
+}
+
+func TestDocumentManager_RunBatchJobs(t *testing.T) {
+	t.Parallel()
+
+	manager, db := newMergeTestManager(t)
+	// Every connection to an in-memory database has a database of its own,
+	// so the documents formatted concurrently share one.
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	folder := &entity.Folder{UserID: userID, Name: "Reports"}
+	require.NoError(t, persistence.NewFolderRepository(db).Create(ctx, folder))
+	var ids []uuid.UUID
+	for _, name := range []string{"a.docx", "b.docx", "notes.md"} {
+		data := testBatchDOCX(t, name)
+		if name == "notes.md" {
+			data = []byte("# Notes\n")
+		}
+		document, err := manager.UploadDocument(ctx, &entity.Document{UserID: userID, FileName: name}, bytes.NewReader(data))
+		require.NoError(t, err)
+		ids = append(ids, document.ID)
+	}
+
+	job, _, err := manager.CreateBatchJob(ctx, userID, BatchTarget{DocumentIDs: ids},
+		docstyle.Profile{FontSize: 12, HeadingFontFamily: "Helvetica"}, BatchOptions{OutputFolderID: &folder.ID, Archive: true})
+	require.NoError(t, err)
+
+	formatted, err := manager.RunBatchJobs(ctx, 2)
+	require.NoError(t, err)
+	require.Equal(t, 3, formatted)
+
+	job, items, err := manager.GetBatchJob(ctx, userID, job.ID)
+	require.NoError(t, err)
+	require.Equal(t, entity.BatchJobCompleted, job.Status)
+	require.NotNil(t, job.FinishedAt)
+	require.Empty(t, job.Error)
+	require.Equal(t, entity.BatchProgress{Total: 3, Succeeded: 2, Failed: 1}, entity.NewBatchProgress(items))
+
+	require.Equal(t, entity.BatchItemSucceeded, items[0].Status)
+	require.Equal(t, []string{"the document has no heading styles, so the heading font is not used"}, items[0].Warnings)
+	output, err := manager.getDocument(ctx, userID, *items[0].OutputID, entity.RoleOwner)
+	require.NoError(t, err)
+	require.Equal(t, "a-formatted.docx", output.FileName)
+	require.Equal(t, &folder.ID, output.FolderID)
+	provenance, err := manager.GetProvenance(ctx, userID, output.ID)
+	require.NoError(t, err)
+	require.Len(t, provenance.Sources, 1)
+	require.Equal(t, ids[0], provenance.Sources[0].Document.ID)
+	require.Equal(t, entity.OperationFormat, provenance.Sources[0].Link.Operation)
+
+	require.Equal(t, entity.BatchItemFailed, items[2].Status)
+	require.Contains(t, items[2].Error, constant.ErrUnsupportedFormat.Error())
+	require.Nil(t, items[2].OutputID)
+
+	require.NotNil(t, job.ArchiveID)
+	archive, err := manager.getDocument(ctx, userID, *job.ArchiveID, entity.RoleOwner)
+	require.NoError(t, err)
+	require.Equal(t, &folder.ID, archive.FolderID)
+	data := []byte(readDocument(t, manager, userID, archive))
+	r, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+	var names []string
+	for _, f := range r.File {
+		names = append(names, f.Name)
+	}
+	require.Equal(t, []string{"a-formatted.docx", "b-formatted.docx"}, names)
+	rc, err := r.File[0].Open()
+	require.NoError(t, err)
+	content, err := io.ReadAll(rc)
+	require.NoError(t, err)
+	require.NoError(t, rc.Close())
+	require.Equal(t, readDocument(t, manager, userID, output), string(content))
+
+	// Nothing is left to run.
+	formatted, err = manager.RunBatchJobs(ctx, 2)
+	require.NoError(t, err)
+	require.Zero(t, formatted)
+}
+
+func TestDocumentManager_RunBatchJobs_NoArchive(t *testing.T) {
+	t.Parallel()
+
+	manager, _ := newMergeTestManager(t)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	notes, err := manager.UploadDocument(ctx, &entity.Document{UserID: userID, FileName: "notes.md"}, bytes.NewReader([]byte("# Notes\n")))
+	require.NoError(t, err)
+	job, _, err := manager.CreateBatchJob(ctx, userID, BatchTarget{DocumentIDs: []uuid.UUID{notes.ID}},
+		docstyle.Profile{FontSize: 12}, BatchOptions{Archive: true})
+	require.NoError(t, err)
+
+	formatted, err := manager.RunBatchJobs(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, 1, formatted)
+	job, _, err = manager.GetBatchJob(ctx, userID, job.ID)
+	require.NoError(t, err)
+	require.Equal(t, entity.BatchJobCompleted, job.Status)
+	require.Nil(t, job.ArchiveID)
+	require.Equal(t, "no document was formatted, so there is no archive", job.Error)
+}
+
+// panickingStore panics on reading objects, standing in for a document that
+// trips up the code reading it.
+type panickingStore struct {
+	*memory.MemoryStorage
+}
+
+func (s panickingStore) GetObject(ctx context.Context, objectKey string) (io.ReadCloser, error) {
+	panic("unreadable object " + objectKey)
+}
+
+func TestDocumentManager_RunBatchJobs_Panic(t *testing.T) {
+	t.Parallel()
+
+	manager, _ := newMergeTestManager(t)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	report, err := manager.UploadDocument(ctx, &entity.Document{UserID: userID, FileName: "report.docx"}, bytes.NewReader(testBatchDOCX(t, "report")))
+	require.NoError(t, err)
+	job, _, err := manager.CreateBatchJob(ctx, userID, BatchTarget{DocumentIDs: []uuid.UUID{report.ID}},
+		docstyle.Profile{FontSize: 12}, BatchOptions{})
+	require.NoError(t, err)
+
+	manager.objectStore = panickingStore{MemoryStorage: manager.objectStore.(*memory.MemoryStorage)}
+	formatted, err := manager.RunBatchJobs(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, 1, formatted)
+	job, items, err := manager.GetBatchJob(ctx, userID, job.ID)
+	require.NoError(t, err)
+	require.Equal(t, entity.BatchJobCompleted, job.Status)
+	require.Len(t, items, 1)
+	require.Equal(t, entity.BatchItemFailed, items[0].Status)
+	require.Equal(t, constant.ErrMalformedDocument.Error(), items[0].Error)
+}
+
+func TestArchiveName(t *testing.T) {
+	t.Parallel()
+
+	names := make(map[string]bool)
+	require.Equal(t, "report.docx", archiveName(names, "report.docx"))
+	require.Equal(t, "report (2).docx", archiveName(names, "report.docx"))
+	require.Equal(t, "report (3).docx", archiveName(names, "report.docx"))
+	require.Equal(t, "other.docx", archiveName(names, "other.docx"))
+}
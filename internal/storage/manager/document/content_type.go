@@ -0,0 +1,32 @@
+package document
+
+import (
+	"mime"
+	"path/filepath"
+	"strings"
+)
+
+// documentTypes maps the extensions of the document formats handled by the
+// formatter to their media type, as the system MIME tables often lack them.
+var documentTypes = map[string]string{
+	".doc":  "application/msword",
+	".docx": "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+	".md":   "text/markdown",
+	".odt":  "application/vnd.oasis.opendocument.text",
+	".pdf":  "application/pdf",
+	".rtf":  "application/rtf",
+	".txt":  "text/plain",
+}
+
+// detectContentType returns the media type of a document, without
+// parameters, guessed from the extension of its file name.
+func detectContentType(fileName string) string {
+	ext := strings.ToLower(filepath.Ext(fileName))
+	if contentType, ok := documentTypes[ext]; ok {
+		return contentType
+	}
+	if mediaType, _, err := mime.ParseMediaType(mime.TypeByExtension(ext)); err == nil {
+		return mediaType
+	}
+	return "application/octet-stream"
+}
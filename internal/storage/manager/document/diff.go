@@ -0,0 +1,87 @@
+package document
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/a1y/doc-formatter/internal/storage/domain/constant"
+	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
+	"github.com/a1y/doc-formatter/internal/storage/util/docdiff"
+	"github.com/google/uuid"
+)
+
+// RedlineAuthor is the author of the tracked changes of redline documents.
+const RedlineAuthor = "Doc Formatter"
+
+// DiffDocuments compares two documents userID can view, such as two versions
+// of a document or a document and its formatted copy. Text hunks show
+// contextLines unchanged lines around their changes.
+func (m *DocumentManager) DiffDocuments(ctx context.Context, userID, oldID, newID uuid.UUID, contextLines int) (*docdiff.Diff, error) {
+	if contextLines < 0 || contextLines > docdiff.MaxContextLines {
+		return nil, fmt.Errorf("%w: context lines must be between 0 and %d", constant.ErrInvalidDiff, docdiff.MaxContextLines)
+	}
+	_, from, err := m.parseForDiff(ctx, userID, oldID)
+	if err != nil {
+		return nil, err
+	}
+	_, to, err := m.parseForDiff(ctx, userID, newID)
+	if err != nil {
+		return nil, err
+	}
+	return docdiff.Compare(from, to, contextLines), nil
+}
+
+// RedlineDocuments returns a DOCX document showing the changes from the old
+// document to the new one as tracked changes, which authors can accept or
+// reject in their word processor. The returned document describes the redline
+// document, which is not stored.
+func (m *DocumentManager) RedlineDocuments(ctx context.Context, userID, oldID, newID uuid.UUID) (*entity.Document, []byte, error) {
+	_, from, err := m.parseForDiff(ctx, userID, oldID)
+	if err != nil {
+		return nil, nil, err
+	}
+	document, to, err := m.parseForDiff(ctx, userID, newID)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := docdiff.Redline(from, to, RedlineAuthor, time.Now())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	name := strings.TrimSuffix(document.FileName, path.Ext(document.FileName)) + "-redline.docx"
+	return &entity.Document{
+		FileName:    name,
+		FileSize:    int64(len(data)),
+		ContentType: docdiff.RedlineContentType,
+	}, data, nil
+}
+
+// parseForDiff reads a document userID can view for comparison.
+func (m *DocumentManager) parseForDiff(ctx context.Context, userID, documentID uuid.UUID) (*entity.Document, *docdiff.Document, error) {
+	document, err := m.getDocument(ctx, userID, documentID, entity.RoleViewer)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !docdiff.Supported(document.ContentType) {
+		return nil, nil, fmt.Errorf("%w: %s", constant.ErrUnsupportedFormat, document.ContentType)
+	}
+	content, err := m.openContent(ctx, document)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer content.Close()
+
+	parsed, err := docdiff.Parse(document.ContentType, content)
+	if errors.Is(err, docdiff.ErrMalformedDocument) || errors.Is(err, docdiff.ErrDocumentTooLarge) {
+		return nil, nil, fmt.Errorf("%w: %v", constant.ErrMalformedDocument, err)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return document, parsed, nil
+}
@@ -0,0 +1,54 @@
+package document
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/a1y/doc-formatter/internal/storage/domain/constant"
+	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
+	"github.com/a1y/doc-formatter/internal/storage/util/docdiff"
+	"github.com/a1y/doc-formatter/internal/storage/util/objectstore/memory"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocumentManager_DiffDocuments(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	manager := NewDocumentManager(newFakeDocumentRepository(), nil, nil, memory.NewMemoryStorage())
+	manager.SetKeyring(newTestKeyring(t, "k1", "k1"))
+	userID := uuid.New()
+
+	before, err := manager.UploadDocument(ctx, &entity.Document{UserID: userID, FileName: "guide.md"},
+		bytes.NewReader([]byte("# Guide\n\nRun the tool.\n")))
+	require.NoError(t, err)
+	after, err := manager.UploadDocument(ctx, &entity.Document{UserID: userID, FileName: "guide.md"},
+		bytes.NewReader([]byte("# Guide\n\nRun the formatter.\n\nDone.\n")))
+	require.NoError(t, err)
+
+	diff, err := manager.DiffDocuments(ctx, userID, before.ID, after.ID, docdiff.DefaultContextLines)
+	require.NoError(t, err)
+	require.Equal(t, docdiff.Summary{Inserted: 1, Modified: 1, LinesAdded: 3, LinesRemoved: 1}, diff.Summary)
+	require.Equal(t, "Run the formatter.", diff.Changes[0].New.Text)
+
+	_, err = manager.DiffDocuments(ctx, userID, before.ID, after.ID, docdiff.MaxContextLines+1)
+	require.ErrorIs(t, err, constant.ErrInvalidDiff)
+	_, err = manager.DiffDocuments(ctx, uuid.New(), before.ID, after.ID, 0)
+	require.ErrorIs(t, err, constant.ErrDocumentNotFound)
+
+	pdf, err := manager.UploadDocument(ctx, &entity.Document{UserID: userID, FileName: "scan.pdf"}, bytes.NewReader([]byte("%PDF")))
+	require.NoError(t, err)
+	_, err = manager.DiffDocuments(ctx, userID, before.ID, pdf.ID, 0)
+	require.ErrorIs(t, err, constant.ErrUnsupportedFormat)
+
+	redline, data, err := manager.RedlineDocuments(ctx, userID, before.ID, after.ID)
+	require.NoError(t, err)
+	require.Equal(t, "guide-redline.docx", redline.FileName)
+	require.EqualValues(t, len(data), redline.FileSize)
+	accepted, err := docdiff.Parse(redline.ContentType, bytes.NewReader(data))
+	require.NoError(t, err)
+	require.Len(t, accepted.Blocks, 3)
+	require.Equal(t, "Run the formatter.", accepted.Blocks[1].Text)
+}
@@ -6,8 +6,12 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/a1y/doc-formatter/internal/storage/domain/constant"
 	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
+	"github.com/google/uuid"
 	"github.com/jinzhu/copier"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
 )
 
 func (m *DocumentManager) UploadDocument(ctx context.Context, document *entity.Document, file io.Reader) (*entity.Document, error) {
@@ -16,9 +20,31 @@ func (m *DocumentManager) UploadDocument(ctx context.Context, document *entity.D
 		return nil, err
 	}
 
-	createdEntity.ObjectKey = fmt.Sprintf("%s/%s", createdEntity.UserID.String(), createdEntity.FileName)
+	folder, err := m.checkFolder(ctx, createdEntity.UserID, createdEntity.FolderID, entity.RoleEditor)
+	if err != nil {
+		return nil, err
+	}
+	// Documents uploaded by an editor of a shared folder belong to the owner
+	// of the folder, so that they are listed with the rest of its content.
+	// The uploader keeps access through the share on the folder.
+	if folder != nil {
+		createdEntity.UserID = folder.UserID
+	}
+
+	// Documents with the same name may live in different folders, so every
+	// upload gets its own object.
+	createdEntity.ObjectKey = fmt.Sprintf("%s/%s/%s", createdEntity.UserID.String(), uuid.NewString(), createdEntity.FileName)
+	createdEntity.ContentType = detectContentType(createdEntity.FileName)
+
+	if m.keyring != nil {
+		encrypted, err := m.encrypt(&createdEntity, file)
+		if err != nil {
+			return nil, err
+		}
+		file = encrypted
+	}
 
-	ok, err := m.s3Storage.PutObject(ctx, createdEntity.ObjectKey, file)
+	ok, err := m.objectStore.PutObject(ctx, createdEntity.ObjectKey, file)
 	if err != nil {
 		return nil, err
 	}
@@ -27,7 +53,113 @@ func (m *DocumentManager) UploadDocument(ctx context.Context, document *entity.D
 	}
 
 	if err := m.documentRepo.Create(ctx, &createdEntity); err != nil {
+		// No document points at the object, so nothing would ever delete it.
+		if _, deleteErr := m.objectStore.DeleteObject(ctx, createdEntity.ObjectKey); deleteErr != nil {
+			logrus.Warnf("Failed to delete object %s of a document that was not created: %v", createdEntity.ObjectKey, deleteErr)
+		}
 		return nil, err
 	}
+	m.queueThumbnail(&createdEntity)
 	return &createdEntity, nil
 }
+
+// MoveDocument moves a document owned by userID into folderID, or to the top
+// level when folderID is nil.
+func (m *DocumentManager) MoveDocument(ctx context.Context, userID, documentID uuid.UUID, folderID *uuid.UUID) (*entity.Document, error) {
+	document, err := m.getDocument(ctx, userID, documentID, entity.RoleOwner)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := m.checkFolder(ctx, userID, folderID, entity.RoleOwner); err != nil {
+		return nil, err
+	}
+	if err := m.documentRepo.UpdateFolder(ctx, document.ID, folderID); err != nil {
+		return nil, err
+	}
+	document.FolderID = folderID
+	return document, nil
+}
+
+// DownloadDocument returns a document userID can view together with a reader
+// over its plaintext content. The caller must close the reader.
+func (m *DocumentManager) DownloadDocument(ctx context.Context, userID, documentID uuid.UUID) (*entity.Document, io.ReadCloser, error) {
+	document, err := m.getDocument(ctx, userID, documentID, entity.RoleViewer)
+	if err != nil {
+		return nil, nil, err
+	}
+	content, err := m.openContent(ctx, document)
+	if err != nil {
+		return nil, nil, err
+	}
+	return document, content, nil
+}
+
+// openContent returns a reader over the plaintext content of document.
+func (m *DocumentManager) openContent(ctx context.Context, document *entity.Document) (io.ReadCloser, error) {
+	object, err := m.objectStore.GetObject(ctx, document.ObjectKey)
+	if err != nil {
+		return nil, err
+	}
+	if !document.IsEncrypted() {
+		return object, nil
+	}
+
+	content, err := m.decrypt(document, object)
+	if err != nil {
+		_ = object.Close()
+		return nil, err
+	}
+	return content, nil
+}
+
+// getDocument returns the document with the given id if userID has at least
+// the required role on it. Documents the user cannot even view are reported
+// as missing so that their ids do not leak.
+func (m *DocumentManager) getDocument(ctx context.Context, userID, documentID uuid.UUID, required entity.Role) (*entity.Document, error) {
+	document, err := m.documentRepo.GetByID(ctx, documentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constant.ErrDocumentNotFound
+		}
+		return nil, err
+	}
+	role, err := m.access.DocumentRole(ctx, userID, document)
+	if err != nil {
+		return nil, err
+	}
+	if !role.Allows(entity.RoleViewer) {
+		return nil, constant.ErrDocumentNotFound
+	}
+	if !role.Allows(required) {
+		return nil, constant.ErrPermissionDenied
+	}
+	return document, nil
+}
+
+// checkFolder returns the folder with the given id if userID has at least
+// the required role on it, directly or through a folder above it. A nil
+// folderID stands for the top level of userID and always passes. Folders the
+// user cannot even view are reported as missing.
+func (m *DocumentManager) checkFolder(ctx context.Context, userID uuid.UUID, folderID *uuid.UUID, required entity.Role) (*entity.Folder, error) {
+	if folderID == nil {
+		return nil, nil
+	}
+	folder, err := m.folderRepo.GetByID(ctx, *folderID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constant.ErrFolderNotFound
+		}
+		return nil, err
+	}
+	role, err := m.access.FolderRole(ctx, userID, folder)
+	if err != nil {
+		return nil, err
+	}
+	if !role.Allows(entity.RoleViewer) {
+		return nil, constant.ErrFolderNotFound
+	}
+	if !role.Allows(required) {
+		return nil, constant.ErrPermissionDenied
+	}
+	return folder, nil
+}
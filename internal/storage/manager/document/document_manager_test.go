@@ -3,13 +3,21 @@ package document
 import (
 	"bytes"
 	"context"
+	"errors"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/a1y/doc-formatter/internal/storage/domain/constant"
 	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
 	"github.com/a1y/doc-formatter/internal/storage/domain/repository"
+	"github.com/a1y/doc-formatter/internal/storage/infra/persistence"
+	"github.com/a1y/doc-formatter/internal/storage/util/objectstore/memory"
 	s3util "github.com/a1y/doc-formatter/internal/storage/util/s3"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
 )
 
 type mockDocumentRepository struct{}
@@ -18,7 +26,11 @@ func (m *mockDocumentRepository) Create(ctx context.Context, d *entity.Document)
 	return nil
 }
 
-func (m *mockDocumentRepository) ListByUserID(ctx context.Context, id uuid.UUID) ([]*entity.Document, error) {
+func (m *mockDocumentRepository) ListByFolder(ctx context.Context, userID uuid.UUID, folderID *uuid.UUID) ([]*entity.Document, error) {
+	return nil, nil
+}
+
+func (m *mockDocumentRepository) List(ctx context.Context, userID uuid.UUID, filter *entity.DocumentFilter, page *entity.DocumentPage) ([]*entity.Document, error) {
 	return nil, nil
 }
 
@@ -30,16 +42,52 @@ func (m *mockDocumentRepository) Delete(ctx context.Context, id uuid.UUID) error
 	return nil
 }
 
+func (m *mockDocumentRepository) GetTrashedByID(ctx context.Context, id uuid.UUID) (*entity.Document, error) {
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (m *mockDocumentRepository) ListTrash(ctx context.Context, userID uuid.UUID, page *entity.DocumentPage) ([]*entity.Document, error) {
+	return nil, nil
+}
+
+func (m *mockDocumentRepository) ListTrashedBefore(ctx context.Context, deletedBefore time.Time, afterID uuid.UUID, limit int) ([]*entity.Document, error) {
+	return nil, nil
+}
+
+func (m *mockDocumentRepository) Restore(ctx context.Context, id uuid.UUID, folderID *uuid.UUID) error {
+	return nil
+}
+
+func (m *mockDocumentRepository) Purge(ctx context.Context, ids []uuid.UUID) error {
+	return nil
+}
+
+func (m *mockDocumentRepository) ListWrappedByOtherKey(ctx context.Context, masterKeyID string, afterID uuid.UUID, limit int) ([]*entity.Document, error) {
+	return nil, nil
+}
+
+func (m *mockDocumentRepository) UpdateWrappedKey(ctx context.Context, id uuid.UUID, masterKeyID string, wrappedKey []byte) error {
+	return nil
+}
+
+func (m *mockDocumentRepository) UpdateFolder(ctx context.Context, id uuid.UUID, folderID *uuid.UUID) error {
+	return nil
+}
+
+func (m *mockDocumentRepository) UpdateLabels(ctx context.Context, id uuid.UUID, tags []string, metadata map[string]string) error {
+	return nil
+}
+
 var _ repository.DocumentRepository = (*mockDocumentRepository)(nil)
 
 func TestNewDocumentManager(t *testing.T) {
 	t.Parallel()
 
-	manager := NewDocumentManager(&mockDocumentRepository{}, &s3util.S3Storage{})
+	manager := NewDocumentManager(&mockDocumentRepository{}, nil, nil, &s3util.S3Storage{})
 	require.NotNil(t, manager)
 }
 
-func TestDocumentManager_UploadDocument_PanicsWithNilS3Storage(t *testing.T) {
+func TestDocumentManager_UploadDocument_PanicsWithNilObjectStore(t *testing.T) {
 	t.Parallel()
 
 	doc := &entity.Document{
@@ -59,3 +107,166 @@ func TestDocumentManager_UploadDocument_PanicsWithNilS3Storage(t *testing.T) {
 		_, _ = manager.UploadDocument(context.Background(), doc, reader)
 	})
 }
+
+func TestDocumentManager_UploadDocument_StoresObjectInMemoryBackend(t *testing.T) {
+	t.Parallel()
+
+	store := memory.NewMemoryStorage()
+	manager := NewDocumentManager(&mockDocumentRepository{}, nil, nil, store)
+
+	userID := uuid.New()
+	doc := &entity.Document{
+		UserID:   userID,
+		FileName: "file.txt",
+		FileSize: 7,
+	}
+
+	created, err := manager.UploadDocument(context.Background(), doc, bytes.NewReader([]byte("content")))
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(created.ObjectKey, userID.String()+"/"))
+	require.True(t, strings.HasSuffix(created.ObjectKey, "/file.txt"))
+
+	info, err := store.HeadObject(context.Background(), created.ObjectKey)
+	require.NoError(t, err)
+	require.EqualValues(t, 7, info.Size)
+}
+
+// failingCreateRepository fails to create documents.
+type failingCreateRepository struct {
+	mockDocumentRepository
+}
+
+func (m *failingCreateRepository) Create(ctx context.Context, d *entity.Document) error {
+	return errors.New("db down")
+}
+
+func TestDocumentManager_UploadDocument_DeletesObjectWhenCreateFails(t *testing.T) {
+	t.Parallel()
+
+	store := memory.NewMemoryStorage()
+	manager := NewDocumentManager(&failingCreateRepository{}, nil, nil, store)
+
+	_, err := manager.UploadDocument(context.Background(), &entity.Document{UserID: uuid.New(), FileName: "file.txt"}, bytes.NewReader([]byte("content")))
+	require.EqualError(t, err, "db down")
+
+	objects, err := store.ListObjects(context.Background(), "")
+	require.NoError(t, err)
+	require.Empty(t, objects)
+}
+
+func TestDocumentManager_MoveDocument(t *testing.T) {
+	t.Parallel()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, persistence.AutoMigrate(db))
+
+	ctx := context.Background()
+	folderRepo := persistence.NewFolderRepository(db)
+	manager := NewDocumentManager(persistence.NewDocumentRepository(db), folderRepo, nil, memory.NewMemoryStorage())
+
+	userID := uuid.New()
+	folder := &entity.Folder{UserID: userID, Name: "Contracts"}
+	require.NoError(t, folderRepo.Create(ctx, folder))
+	foreign := &entity.Folder{UserID: uuid.New(), Name: "Foreign"}
+	require.NoError(t, folderRepo.Create(ctx, foreign))
+
+	created, err := manager.UploadDocument(ctx, &entity.Document{
+		UserID:   userID,
+		FileName: "contract.docx",
+		FolderID: &folder.ID,
+	}, bytes.NewReader([]byte("content")))
+	require.NoError(t, err)
+	require.Equal(t, folder.ID, *created.FolderID)
+
+	// Uploading the same name twice must not overwrite the first object.
+	again, err := manager.UploadDocument(ctx, &entity.Document{UserID: userID, FileName: "contract.docx"}, bytes.NewReader([]byte("other")))
+	require.NoError(t, err)
+	require.NotEqual(t, created.ObjectKey, again.ObjectKey)
+
+	moved, err := manager.MoveDocument(ctx, userID, created.ID, nil)
+	require.NoError(t, err)
+	require.Nil(t, moved.FolderID)
+
+	moved, err = manager.MoveDocument(ctx, userID, created.ID, &folder.ID)
+	require.NoError(t, err)
+	require.Equal(t, folder.ID, *moved.FolderID)
+
+	_, err = manager.MoveDocument(ctx, userID, created.ID, &foreign.ID)
+	require.ErrorIs(t, err, constant.ErrFolderNotFound)
+
+	missing := uuid.New()
+	_, err = manager.MoveDocument(ctx, userID, created.ID, &missing)
+	require.ErrorIs(t, err, constant.ErrFolderNotFound)
+
+	_, err = manager.MoveDocument(ctx, uuid.New(), created.ID, nil)
+	require.ErrorIs(t, err, constant.ErrDocumentNotFound)
+
+	_, err = manager.UploadDocument(ctx, &entity.Document{
+		UserID:   userID,
+		FileName: "intruder.docx",
+		FolderID: &foreign.ID,
+	}, bytes.NewReader([]byte("content")))
+	require.ErrorIs(t, err, constant.ErrFolderNotFound)
+}
+
+func TestDocumentManager_UploadDocument_SharedFolder(t *testing.T) {
+	t.Parallel()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, persistence.AutoMigrate(db))
+
+	ctx := context.Background()
+	folderRepo := persistence.NewFolderRepository(db)
+	documentRepo := persistence.NewDocumentRepository(db)
+	aclRepo := persistence.NewACLRepository(db)
+	manager := NewDocumentManager(documentRepo, folderRepo, aclRepo, memory.NewMemoryStorage())
+
+	ownerID, editorID, viewerID := uuid.New(), uuid.New(), uuid.New()
+	shared := &entity.Folder{UserID: ownerID, Name: "Shared"}
+	require.NoError(t, folderRepo.Create(ctx, shared))
+	nested := &entity.Folder{UserID: ownerID, Name: "Nested", ParentID: &shared.ID}
+	require.NoError(t, folderRepo.Create(ctx, nested))
+	for userID, role := range map[uuid.UUID]entity.Role{editorID: entity.RoleEditor, viewerID: entity.RoleViewer} {
+		require.NoError(t, aclRepo.Upsert(ctx, &entity.ACLEntry{
+			ResourceType: entity.ResourceFolder,
+			ResourceID:   shared.ID,
+			OwnerID:      ownerID,
+			UserID:       userID,
+			Role:         role,
+		}))
+	}
+
+	// The role on the shared folder extends to the folders below it.
+	created, err := manager.UploadDocument(ctx, &entity.Document{
+		UserID:   editorID,
+		FileName: "draft.docx",
+		FolderID: &nested.ID,
+	}, bytes.NewReader([]byte("content")))
+	require.NoError(t, err)
+	require.Equal(t, ownerID, created.UserID)
+	require.True(t, strings.HasPrefix(created.ObjectKey, ownerID.String()+"/"))
+
+	listed, err := documentRepo.ListByFolder(ctx, ownerID, &nested.ID)
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	require.Equal(t, created.ID, listed[0].ID)
+
+	_, reader, err := manager.DownloadDocument(ctx, editorID, created.ID)
+	require.NoError(t, err)
+	require.Equal(t, []byte("content"), readAllAndClose(t, reader))
+
+	_, err = manager.UploadDocument(ctx, &entity.Document{
+		UserID:   viewerID,
+		FileName: "draft.docx",
+		FolderID: &shared.ID,
+	}, bytes.NewReader([]byte("content")))
+	require.ErrorIs(t, err, constant.ErrPermissionDenied)
+
+	// Moving documents still needs the folder to be owned.
+	own, err := manager.UploadDocument(ctx, &entity.Document{UserID: editorID, FileName: "mine.docx"}, bytes.NewReader([]byte("content")))
+	require.NoError(t, err)
+	_, err = manager.MoveDocument(ctx, editorID, own.ID, &shared.ID)
+	require.ErrorIs(t, err, constant.ErrPermissionDenied)
+}
@@ -0,0 +1,153 @@
+package document
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	"github.com/a1y/doc-formatter/internal/storage/domain/constant"
+	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
+	"github.com/a1y/doc-formatter/internal/storage/util/envelope"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// RewrapBatchSize is the number of documents loaded per query while
+// re-wrapping data keys.
+const RewrapBatchSize = 100
+
+// encrypt generates a fresh data key for document, records the wrapped key and
+// nonce on it and returns a reader over the encrypted content.
+func (m *DocumentManager) encrypt(document *entity.Document, content io.Reader) (io.Reader, error) {
+	dataKey, err := envelope.GenerateDataKey()
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := envelope.GenerateNonce()
+	if err != nil {
+		return nil, err
+	}
+	sealed, err := m.keyring.WrapDataKey(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	encrypted, err := envelope.NewEncryptReader(content, dataKey, nonce)
+	if err != nil {
+		return nil, err
+	}
+	document.MasterKeyID = sealed.MasterKeyID
+	document.WrappedKey = sealed.WrappedKey
+	document.Nonce = nonce
+	return encrypted, nil
+}
+
+type decryptReadCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// decrypt unwraps the data key of document and returns a reader over the
+// plaintext of content. Closing the reader closes content.
+func (m *DocumentManager) decrypt(document *entity.Document, content io.ReadCloser) (io.ReadCloser, error) {
+	dataKey, err := m.dataKey(document)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := envelope.NewDecryptReader(content, dataKey, document.Nonce)
+	if err != nil {
+		return nil, err
+	}
+	return decryptReadCloser{Reader: plaintext, Closer: content}, nil
+}
+
+// encryptDerived returns a reader over content derived from document, such as
+// its thumbnail, encrypted with the data key of document. Derived objects have
+// no row to record their nonce, so a fresh one is written ahead of the
+// ciphertext.
+func (m *DocumentManager) encryptDerived(document *entity.Document, content io.Reader) (io.Reader, error) {
+	dataKey, err := m.dataKey(document)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := envelope.GenerateNonce()
+	if err != nil {
+		return nil, err
+	}
+	encrypted, err := envelope.NewEncryptReader(content, dataKey, nonce)
+	if err != nil {
+		return nil, err
+	}
+	return io.MultiReader(bytes.NewReader(nonce), encrypted), nil
+}
+
+// decryptDerived returns a reader over the plaintext of an object written by
+// encryptDerived. Closing the reader closes content.
+func (m *DocumentManager) decryptDerived(document *entity.Document, content io.ReadCloser) (io.ReadCloser, error) {
+	dataKey, err := m.dataKey(document)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, envelope.NonceSize)
+	if _, err := io.ReadFull(content, nonce); err != nil {
+		return nil, err
+	}
+	plaintext, err := envelope.NewDecryptReader(content, dataKey, nonce)
+	if err != nil {
+		return nil, err
+	}
+	return decryptReadCloser{Reader: plaintext, Closer: content}, nil
+}
+
+// dataKey unwraps the data key of document.
+func (m *DocumentManager) dataKey(document *entity.Document) ([]byte, error) {
+	if m.keyring == nil {
+		return nil, constant.ErrKeyringNotConfigured
+	}
+	return m.keyring.UnwrapDataKey(&envelope.SealedKey{
+		MasterKeyID: document.MasterKeyID,
+		WrappedKey:  document.WrappedKey,
+	})
+}
+
+// RewrapDataKeys re-wraps the data key of every document that is not sealed
+// by the primary master key, so that retired master keys can be dropped from
+// the keyring. Document content is left untouched. It returns the number of
+// documents re-wrapped.
+func (m *DocumentManager) RewrapDataKeys(ctx context.Context) (int, error) {
+	if m.keyring == nil {
+		return 0, constant.ErrKeyringNotConfigured
+	}
+
+	primary := m.keyring.PrimaryKeyID()
+	rewrapped := 0
+	afterID := uuid.Nil
+	for {
+		documents, err := m.documentRepo.ListWrappedByOtherKey(ctx, primary, afterID, RewrapBatchSize)
+		if err != nil {
+			return rewrapped, err
+		}
+		if len(documents) == 0 {
+			return rewrapped, nil
+		}
+
+		for _, document := range documents {
+			sealed, changed, err := m.keyring.Rewrap(&envelope.SealedKey{
+				MasterKeyID: document.MasterKeyID,
+				WrappedKey:  document.WrappedKey,
+			})
+			if err != nil {
+				return rewrapped, err
+			}
+			if !changed {
+				continue
+			}
+			if err := m.documentRepo.UpdateWrappedKey(ctx, document.ID, sealed.MasterKeyID, sealed.WrappedKey); err != nil {
+				return rewrapped, err
+			}
+			rewrapped++
+			logrus.Debugf("Re-wrapped data key of document %s from %s to %s", document.ID, document.MasterKeyID, sealed.MasterKeyID)
+		}
+		afterID = documents[len(documents)-1].ID
+	}
+}
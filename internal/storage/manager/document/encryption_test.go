@@ -0,0 +1,262 @@
+package document
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/a1y/doc-formatter/internal/storage/domain/constant"
+	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
+	"github.com/a1y/doc-formatter/internal/storage/domain/repository"
+	"github.com/a1y/doc-formatter/internal/storage/util/envelope"
+	"github.com/a1y/doc-formatter/internal/storage/util/objectstore/memory"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// fakeDocumentRepository keeps documents in memory.
+type fakeDocumentRepository struct {
+	mu        sync.Mutex
+	documents map[uuid.UUID]entity.Document
+}
+
+func newFakeDocumentRepository() *fakeDocumentRepository {
+	return &fakeDocumentRepository{documents: map[uuid.UUID]entity.Document{}}
+}
+
+func (r *fakeDocumentRepository) Create(ctx context.Context, d *entity.Document) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	d.ID = uuid.New()
+	r.documents[d.ID] = *d
+	return nil
+}
+
+func (r *fakeDocumentRepository) ListByFolder(ctx context.Context, userID uuid.UUID, folderID *uuid.UUID) ([]*entity.Document, error) {
+	return nil, nil
+}
+
+func (r *fakeDocumentRepository) List(ctx context.Context, userID uuid.UUID, filter *entity.DocumentFilter, page *entity.DocumentPage) ([]*entity.Document, error) {
+	return nil, nil
+}
+
+func (r *fakeDocumentRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Document, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	d, ok := r.documents[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &d, nil
+}
+
+func (r *fakeDocumentRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return nil
+}
+
+func (r *fakeDocumentRepository) GetTrashedByID(ctx context.Context, id uuid.UUID) (*entity.Document, error) {
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *fakeDocumentRepository) ListTrash(ctx context.Context, userID uuid.UUID, page *entity.DocumentPage) ([]*entity.Document, error) {
+	return nil, nil
+}
+
+func (r *fakeDocumentRepository) ListTrashedBefore(ctx context.Context, deletedBefore time.Time, afterID uuid.UUID, limit int) ([]*entity.Document, error) {
+	return nil, nil
+}
+
+func (r *fakeDocumentRepository) Restore(ctx context.Context, id uuid.UUID, folderID *uuid.UUID) error {
+	return nil
+}
+
+func (r *fakeDocumentRepository) Purge(ctx context.Context, ids []uuid.UUID) error {
+	return nil
+}
+
+func (r *fakeDocumentRepository) ListWrappedByOtherKey(ctx context.Context, masterKeyID string, afterID uuid.UUID, limit int) ([]*entity.Document, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var documents []*entity.Document
+	for _, d := range r.documents {
+		if d.IsEncrypted() && d.MasterKeyID != masterKeyID && d.ID.String() > afterID.String() {
+			documents = append(documents, &d)
+		}
+	}
+	sort.Slice(documents, func(i, j int) bool { return documents[i].ID.String() < documents[j].ID.String() })
+	if len(documents) > limit {
+		documents = documents[:limit]
+	}
+	return documents, nil
+}
+
+func (r *fakeDocumentRepository) UpdateWrappedKey(ctx context.Context, id uuid.UUID, masterKeyID string, wrappedKey []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	d := r.documents[id]
+	d.MasterKeyID = masterKeyID
+	d.WrappedKey = wrappedKey
+	r.documents[id] = d
+	return nil
+}
+
+func (r *fakeDocumentRepository) UpdateFolder(ctx context.Context, id uuid.UUID, folderID *uuid.UUID) error {
+	return nil
+}
+
+func (r *fakeDocumentRepository) UpdateLabels(ctx context.Context, id uuid.UUID, tags []string, metadata map[string]string) error {
+	return nil
+}
+
+var _ repository.DocumentRepository = (*fakeDocumentRepository)(nil)
+
+func newTestKeyring(t *testing.T, primary string, ids ...string) *envelope.Keyring {
+	t.Helper()
+
+	keys := map[string][]byte{}
+	for _, id := range ids {
+		keys[id] = bytes.Repeat([]byte(id), envelope.KeySize)[:envelope.KeySize]
+	}
+	keyring, err := envelope.NewKeyring(primary, keys)
+	require.NoError(t, err)
+	return keyring
+}
+
+func readAllAndClose(t *testing.T, rc io.ReadCloser) []byte {
+	t.Helper()
+
+	defer func() { require.NoError(t, rc.Close()) }()
+	data, err := io.ReadAll(rc)
+	require.NoError(t, err)
+	return data
+}
+
+func TestDocumentManager_EncryptedUploadAndDownload(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo := newFakeDocumentRepository()
+	store := memory.NewMemoryStorage()
+	manager := NewDocumentManager(repo, nil, nil, store)
+	manager.SetKeyring(newTestKeyring(t, "k1", "k1"))
+
+	userID := uuid.New()
+	content := []byte("confidential contract")
+	created, err := manager.UploadDocument(ctx, &entity.Document{
+		UserID:   userID,
+		FileName: "contract.docx",
+		FileSize: int64(len(content)),
+	}, bytes.NewReader(content))
+	require.NoError(t, err)
+	require.True(t, created.IsEncrypted())
+	require.Equal(t, "k1", created.MasterKeyID)
+	require.Len(t, created.Nonce, envelope.NonceSize)
+
+	stored, err := store.GetObject(ctx, created.ObjectKey)
+	require.NoError(t, err)
+	ciphertext := readAllAndClose(t, stored)
+	require.NotContains(t, string(ciphertext), string(content))
+	require.EqualValues(t, envelope.EncryptedSize(int64(len(content))), len(ciphertext))
+
+	document, reader, err := manager.DownloadDocument(ctx, userID, created.ID)
+	require.NoError(t, err)
+	require.Equal(t, "contract.docx", document.FileName)
+	require.Equal(t, content, readAllAndClose(t, reader))
+
+	// Uploading the same name again gets its own object and data key, and
+	// leaves the first document readable.
+	again, err := manager.UploadDocument(ctx, &entity.Document{UserID: userID, FileName: "contract.docx"}, bytes.NewReader([]byte("amended")))
+	require.NoError(t, err)
+	require.NotEqual(t, created.ObjectKey, again.ObjectKey)
+	_, reader, err = manager.DownloadDocument(ctx, userID, created.ID)
+	require.NoError(t, err)
+	require.Equal(t, content, readAllAndClose(t, reader))
+}
+
+func TestDocumentManager_DownloadDocument_PlaintextAndErrors(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo := newFakeDocumentRepository()
+	store := memory.NewMemoryStorage()
+	manager := NewDocumentManager(repo, nil, nil, store)
+
+	userID := uuid.New()
+	created, err := manager.UploadDocument(ctx, &entity.Document{
+		UserID:   userID,
+		FileName: "notes.txt",
+	}, bytes.NewReader([]byte("plain")))
+	require.NoError(t, err)
+	require.False(t, created.IsEncrypted())
+
+	_, reader, err := manager.DownloadDocument(ctx, userID, created.ID)
+	require.NoError(t, err)
+	require.Equal(t, []byte("plain"), readAllAndClose(t, reader))
+
+	_, _, err = manager.DownloadDocument(ctx, uuid.New(), created.ID)
+	require.ErrorIs(t, err, constant.ErrDocumentNotFound)
+
+	_, _, err = manager.DownloadDocument(ctx, userID, uuid.New())
+	require.ErrorIs(t, err, constant.ErrDocumentNotFound)
+
+	// An encrypted document cannot be served once the keyring is gone.
+	encrypting := NewDocumentManager(repo, nil, nil, store)
+	encrypting.SetKeyring(newTestKeyring(t, "k1", "k1"))
+	encrypted, err := encrypting.UploadDocument(ctx, &entity.Document{
+		UserID:   userID,
+		FileName: "secret.txt",
+	}, bytes.NewReader([]byte("secret")))
+	require.NoError(t, err)
+
+	_, _, err = manager.DownloadDocument(ctx, userID, encrypted.ID)
+	require.ErrorIs(t, err, constant.ErrKeyringNotConfigured)
+}
+
+func TestDocumentManager_RewrapDataKeys(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo := newFakeDocumentRepository()
+	store := memory.NewMemoryStorage()
+	userID := uuid.New()
+
+	before := NewDocumentManager(repo, nil, nil, store)
+	before.SetKeyring(newTestKeyring(t, "k1", "k1"))
+	var ids []uuid.UUID
+	for i := 0; i < RewrapBatchSize+5; i++ {
+		created, err := before.UploadDocument(ctx, &entity.Document{
+			UserID:   userID,
+			FileName: uuid.NewString() + ".txt",
+		}, bytes.NewReader([]byte("content")))
+		require.NoError(t, err)
+		ids = append(ids, created.ID)
+	}
+
+	after := NewDocumentManager(repo, nil, nil, store)
+	after.SetKeyring(newTestKeyring(t, "k2", "k1", "k2"))
+	count, err := after.RewrapDataKeys(ctx)
+	require.NoError(t, err)
+	require.Equal(t, len(ids), count)
+
+	count, err = after.RewrapDataKeys(ctx)
+	require.NoError(t, err)
+	require.Zero(t, count)
+
+	// Once every data key is re-wrapped, the retired key can be dropped.
+	rotated := NewDocumentManager(repo, nil, nil, store)
+	rotated.SetKeyring(newTestKeyring(t, "k2", "k2"))
+	for _, id := range ids[:3] {
+		document, reader, err := rotated.DownloadDocument(ctx, userID, id)
+		require.NoError(t, err)
+		require.Equal(t, "k2", document.MasterKeyID)
+		require.Equal(t, []byte("content"), readAllAndClose(t, reader))
+	}
+
+	_, err = NewDocumentManager(repo, nil, nil, store).RewrapDataKeys(ctx)
+	require.ErrorIs(t, err, constant.ErrKeyringNotConfigured)
+}
@@ -0,0 +1,77 @@
+package document
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/a1y/doc-formatter/internal/storage/domain/repository"
+	"github.com/sirupsen/logrus"
+)
+
+// textIndexLock names the lock that keeps instances of the storage service
+// from indexing the same documents at the same time.
+const textIndexLock = "storage.text-index"
+
+// TextIndexer periodically extracts the text of new documents so that they
+// can be searched.
+type TextIndexer struct {
+	manager  *DocumentManager
+	locker   repository.Locker
+	language string
+	interval time.Duration
+}
+
+// NewTextIndexer returns an indexer that indexes documents without a language
+// in their metadata with the text search configuration language.
+func NewTextIndexer(manager *DocumentManager, locker repository.Locker, language string, interval time.Duration) *TextIndexer {
+	return &TextIndexer{
+		manager:  manager,
+		locker:   locker,
+		language: language,
+		interval: interval,
+	}
+}
+
+// Run indexes new documents right away, then every interval until ctx is
+// done.
+func (i *TextIndexer) Run(ctx context.Context) {
+	ticker := time.NewTicker(i.interval)
+	defer ticker.Stop()
+	for {
+		if indexed, err := i.IndexOnce(ctx); err != nil {
+			logrus.Errorf("Failed to index documents after %d documents: %v", indexed, err)
+		} else if indexed > 0 {
+			logrus.Infof("Indexed the text of %d documents", indexed)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// IndexOnce indexes the documents that have no text yet, unless another
+// instance of the service is already doing so. It returns the number of
+// documents indexed. A panic while indexing is returned as an error, so that
+// it does not take the service down.
+func (i *TextIndexer) IndexOnce(ctx context.Context) (indexed int, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			logrus.Errorf("Panic while indexing documents: %v\n%s", r, debug.Stack())
+			err = fmt.Errorf("panic while indexing documents: %v", r)
+		}
+	}()
+	acquired, err := i.locker.TryLock(ctx, textIndexLock, func(ctx context.Context) error {
+		var err error
+		indexed, err = i.manager.IndexDocuments(ctx, i.language)
+		return err
+	})
+	if err == nil && !acquired {
+		logrus.Debug("Skipping text indexing, another instance is indexing")
+	}
+	return indexed, err
+}
@@ -0,0 +1,116 @@
+package document
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/a1y/doc-formatter/internal/storage/domain/constant"
+	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
+	"github.com/google/uuid"
+)
+
+// AddTags adds tags to a document userID can edit. Tags the document already
+// carries are left as they are.
+func (m *DocumentManager) AddTags(ctx context.Context, userID, documentID uuid.UUID, tags []string) (*entity.Document, error) {
+	document, err := m.getDocument(ctx, userID, documentID, entity.RoleEditor)
+	if err != nil {
+		return nil, err
+	}
+	for _, tag := range tags {
+		if !slices.Contains(document.Tags, tag) {
+			document.Tags = append(document.Tags, tag)
+		}
+	}
+	if err := entity.ValidateTags(document.Tags); err != nil {
+		return nil, fmt.Errorf("%w: %v", constant.ErrInvalidTags, err)
+	}
+	return m.updateLabels(ctx, document, document.Metadata)
+}
+
+// RemoveTags removes tags from a document userID can edit. Tags the document
+// does not carry are ignored.
+func (m *DocumentManager) RemoveTags(ctx context.Context, userID, documentID uuid.UUID, tags []string) (*entity.Document, error) {
+	document, err := m.getDocument(ctx, userID, documentID, entity.RoleEditor)
+	if err != nil {
+		return nil, err
+	}
+	document.Tags = slices.DeleteFunc(document.Tags, func(tag string) bool {
+		return slices.Contains(tags, tag)
+	})
+	return m.updateLabels(ctx, document, document.Metadata)
+}
+
+// SetMetadata merges metadata into the metadata of a document userID can
+// edit, replacing the values of existing keys.
+func (m *DocumentManager) SetMetadata(ctx context.Context, userID, documentID uuid.UUID, metadata map[string]string) (*entity.Document, error) {
+	document, err := m.getDocument(ctx, userID, documentID, entity.RoleEditor)
+	if err != nil {
+		return nil, err
+	}
+	previous := maps.Clone(document.Metadata)
+	if document.Metadata == nil {
+		document.Metadata = make(map[string]string, len(metadata))
+	}
+	maps.Copy(document.Metadata, metadata)
+	if err := entity.ValidateMetadata(document.Metadata); err != nil {
+		return nil, fmt.Errorf("%w: %v", constant.ErrInvalidMetadata, err)
+	}
+	return m.updateLabels(ctx, document, previous)
+}
+
+// RemoveMetadata removes keys from the metadata of a document userID can
+// edit. Missing keys are ignored.
+func (m *DocumentManager) RemoveMetadata(ctx context.Context, userID, documentID uuid.UUID, keys []string) (*entity.Document, error) {
+	document, err := m.getDocument(ctx, userID, documentID, entity.RoleEditor)
+	if err != nil {
+		return nil, err
+	}
+	previous := maps.Clone(document.Metadata)
+	for _, key := range keys {
+		delete(document.Metadata, key)
+	}
+	return m.updateLabels(ctx, document, previous)
+}
+
+// ListDocuments returns a page of the documents of userID matching filter,
+// along with the token of the next page, which is empty on the last page.
+func (m *DocumentManager) ListDocuments(
+	ctx context.Context, userID uuid.UUID, filter entity.DocumentFilter, req PageRequest,
+) ([]*entity.Document, string, error) {
+	filter.ContentType = strings.ToLower(strings.TrimSpace(filter.ContentType))
+	if err := filter.Validate(); err != nil {
+		return nil, "", fmt.Errorf("%w: %v", constant.ErrInvalidFilter, err)
+	}
+
+	if req.Sort == entity.SortByDeleted {
+		return nil, "", fmt.Errorf("%w: only the trash is sorted by deletion time", constant.ErrInvalidPage)
+	}
+	page, err := newPage(req)
+	if err != nil {
+		return nil, "", err
+	}
+	if _, err := m.checkFolder(ctx, userID, filter.FolderID, entity.RoleOwner); err != nil {
+		return nil, "", err
+	}
+	return listPage(page, func(page *entity.DocumentPage) ([]*entity.Document, error) {
+		return m.documentRepo.List(ctx, userID, &filter, page)
+	})
+}
+
+// updateLabels stores the tags and metadata of document. When the language
+// set in its metadata differs from the one in previous, the text of the
+// document is dropped so that it is indexed again in the new language.
+func (m *DocumentManager) updateLabels(ctx context.Context, document *entity.Document, previous map[string]string) (*entity.Document, error) {
+	if err := m.documentRepo.UpdateLabels(ctx, document.ID, document.Tags, document.Metadata); err != nil {
+		return nil, err
+	}
+	if m.textRepo != nil && document.Metadata[entity.LanguageMetadataKey] != previous[entity.LanguageMetadataKey] {
+		if err := m.textRepo.DeleteByDocuments(ctx, []uuid.UUID{document.ID}); err != nil {
+			return nil, err
+		}
+	}
+	return document, nil
+}
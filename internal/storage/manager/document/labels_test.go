@@ -0,0 +1,135 @@
+package document
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/a1y/doc-formatter/internal/storage/domain/constant"
+	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
+	"github.com/a1y/doc-formatter/internal/storage/infra/persistence"
+	"github.com/a1y/doc-formatter/internal/storage/util/objectstore/memory"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestDocumentManager_Labels(t *testing.T) {
+	t.Parallel()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, persistence.AutoMigrate(db))
+
+	ctx := context.Background()
+	documentRepo := persistence.NewDocumentRepository(db)
+	manager := NewDocumentManager(documentRepo, persistence.NewFolderRepository(db), nil, memory.NewMemoryStorage())
+
+	userID := uuid.New()
+	created, err := manager.UploadDocument(ctx, &entity.Document{UserID: userID, FileName: "Report.PDF"}, bytes.NewReader([]byte("content")))
+	require.NoError(t, err)
+	require.Equal(t, "application/pdf", created.ContentType)
+
+	doc, err := manager.AddTags(ctx, userID, created.ID, []string{"client:acme", "status:draft", "client:acme"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"client:acme", "status:draft"}, doc.Tags)
+
+	doc, err = manager.RemoveTags(ctx, userID, created.ID, []string{"status:draft", "unknown"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"client:acme"}, doc.Tags)
+
+	_, err = manager.AddTags(ctx, userID, created.ID, []string{" spaced "})
+	require.ErrorIs(t, err, constant.ErrInvalidTags)
+
+	doc, err = manager.SetMetadata(ctx, userID, created.ID, map[string]string{"owner": "legal", "year": "2025"})
+	require.NoError(t, err)
+	doc, err = manager.SetMetadata(ctx, userID, created.ID, map[string]string{"year": "2026"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"owner": "legal", "year": "2026"}, doc.Metadata)
+
+	doc, err = manager.RemoveMetadata(ctx, userID, created.ID, []string{"owner", "unknown"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"year": "2026"}, doc.Metadata)
+
+	_, err = manager.SetMetadata(ctx, userID, created.ID, map[string]string{"": "empty"})
+	require.ErrorIs(t, err, constant.ErrInvalidMetadata)
+
+	stored, err := documentRepo.GetByID(ctx, created.ID)
+	require.NoError(t, err)
+	require.Equal(t, []string{"client:acme"}, stored.Tags)
+	require.Equal(t, map[string]string{"year": "2026"}, stored.Metadata)
+
+	_, err = manager.AddTags(ctx, uuid.New(), created.ID, []string{"intruder"})
+	require.ErrorIs(t, err, constant.ErrDocumentNotFound)
+}
+
+func TestDocumentManager_ListDocuments(t *testing.T) {
+	t.Parallel()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, persistence.AutoMigrate(db))
+
+	ctx := context.Background()
+	folderRepo := persistence.NewFolderRepository(db)
+	manager := NewDocumentManager(persistence.NewDocumentRepository(db), folderRepo, nil, memory.NewMemoryStorage())
+
+	userID := uuid.New()
+	folder := &entity.Folder{UserID: userID, Name: "Scans"}
+	require.NoError(t, folderRepo.Create(ctx, folder))
+
+	upload := func(name string, size int, folderID *uuid.UUID) {
+		_, err := manager.UploadDocument(ctx, &entity.Document{
+			UserID:   userID,
+			FileName: name,
+			FileSize: int64(size),
+			FolderID: folderID,
+		}, bytes.NewReader(make([]byte, size)))
+		require.NoError(t, err)
+	}
+	upload("contract.docx", 300, nil)
+	upload("scan.png", 5000, &folder.ID)
+	upload("photo.jpg", 7000, &folder.ID)
+
+	names := func(documents []*entity.Document) []string {
+		var out []string
+		for _, document := range documents {
+			out = append(out, document.FileName)
+		}
+		return out
+	}
+
+	docs, _, err := manager.ListDocuments(ctx, userID, entity.DocumentFilter{}, PageRequest{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"contract.docx", "photo.jpg", "scan.png"}, names(docs))
+
+	docs, _, err = manager.ListDocuments(ctx, userID, entity.DocumentFilter{ContentType: " Image/* "}, PageRequest{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"photo.jpg", "scan.png"}, names(docs))
+
+	maxSize := int64(6000)
+	docs, _, err = manager.ListDocuments(ctx, userID, entity.DocumentFilter{FolderID: &folder.ID, MaxSize: &maxSize}, PageRequest{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"scan.png"}, names(docs))
+
+	_, _, err = manager.ListDocuments(ctx, userID, entity.DocumentFilter{ContentType: "image"}, PageRequest{})
+	require.ErrorIs(t, err, constant.ErrInvalidFilter)
+
+	missing := uuid.New()
+	_, _, err = manager.ListDocuments(ctx, userID, entity.DocumentFilter{FolderID: &missing}, PageRequest{})
+	require.ErrorIs(t, err, constant.ErrFolderNotFound)
+
+	docs, _, err = manager.ListDocuments(ctx, uuid.New(), entity.DocumentFilter{}, PageRequest{})
+	require.NoError(t, err)
+	require.Empty(t, docs)
+}
+
+func TestDetectContentType(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "application/vnd.openxmlformats-officedocument.wordprocessingml.document", detectContentType("contract.DOCX"))
+	require.Equal(t, "text/plain", detectContentType("notes.txt"))
+	require.Equal(t, "image/png", detectContentType("scan.png"))
+	require.Equal(t, "application/octet-stream", detectContentType("no-extension"))
+}
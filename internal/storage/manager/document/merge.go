@@ -0,0 +1,264 @@
+package document
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/a1y/doc-formatter/internal/storage/domain/constant"
+	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
+	"github.com/a1y/doc-formatter/internal/storage/util/docmerge"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// maxPartTitleLength is the length in bytes of the longest title kept in the
+// file names of the documents a document is split into.
+const maxPartTitleLength = 100
+
+// MergeDocuments makes a document of userID named fileName, in folderID or at
+// the top level when folderID is nil, of the content of sourceIDs in order.
+// The sources are documents userID can view, all of the same format, which
+// the file name must have too; an empty name is derived from the first
+// source. Styles are those of the first source, and pageBreaks starts every
+// other source on a new page.
+func (m *DocumentManager) MergeDocuments(ctx context.Context, userID uuid.UUID, sourceIDs []uuid.UUID, fileName string, folderID *uuid.UUID, pageBreaks bool) (*entity.Document, error) {
+	if len(sourceIDs) < 2 || len(sourceIDs) > docmerge.MaxParts {
+		return nil, fmt.Errorf("%w: between 2 and %d documents can be merged", constant.ErrInvalidMerge, docmerge.MaxParts)
+	}
+	if _, err := m.checkFolder(ctx, userID, folderID, entity.RoleOwner); err != nil {
+		return nil, err
+	}
+
+	var sources []*entity.Document
+	var contents [][]byte
+	for _, id := range sourceIDs {
+		source, content, err := m.readForMerge(ctx, userID, id)
+		if err != nil {
+			return nil, err
+		}
+		if len(sources) > 0 && source.ContentType != sources[0].ContentType {
+			return nil, fmt.Errorf("%w: documents of different formats cannot be merged", constant.ErrInvalidMerge)
+		}
+		sources = append(sources, source)
+		contents = append(contents, content)
+	}
+
+	contentType := sources[0].ContentType
+	if fileName == "" {
+		fileName = stem(sources[0].FileName) + "-merged" + path.Ext(sources[0].FileName)
+	}
+	if detectContentType(fileName) != contentType {
+		return nil, fmt.Errorf("%w: file name %q does not match the format of the documents", constant.ErrInvalidMerge, fileName)
+	}
+
+	data, err := docmerge.Merge(contentType, contents, docmerge.Options{PageBreaks: pageBreaks})
+	if err != nil {
+		return nil, mergeError(err)
+	}
+
+	document, err := m.UploadDocument(ctx, &entity.Document{
+		UserID:   userID,
+		FileName: fileName,
+		FileSize: int64(len(data)),
+		FolderID: folderID,
+	}, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	links := make([]*entity.DocumentSource, len(sources))
+	for i, source := range sources {
+		links[i] = &entity.DocumentSource{DocumentID: document.ID, SourceID: source.ID, Operation: entity.OperationMerge, Position: i}
+	}
+	if err := m.recordSources(ctx, links, []*entity.Document{document}); err != nil {
+		return nil, err
+	}
+	return document, nil
+}
+
+// SplitDocument cuts a document userID can view before each of its headings
+// of level or above, 1 being the top level, into documents of userID in
+// folderID, or at the top level when folderID is nil. The documents are named
+// after their number and their heading, and returned in order; content before
+// the first heading makes a document named after the source.
+func (m *DocumentManager) SplitDocument(ctx context.Context, userID, documentID uuid.UUID, level int, folderID *uuid.UUID) ([]*entity.Document, error) {
+	if level < 1 || level > docmerge.MaxHeadingLevel {
+		return nil, fmt.Errorf("%w: heading level must be between 1 and %d", constant.ErrInvalidSplit, docmerge.MaxHeadingLevel)
+	}
+	if _, err := m.checkFolder(ctx, userID, folderID, entity.RoleOwner); err != nil {
+		return nil, err
+	}
+	source, content, err := m.readForMerge(ctx, userID, documentID)
+	if err != nil {
+		return nil, err
+	}
+	parts, err := docmerge.Split(source.ContentType, content, level)
+	if err != nil {
+		return nil, mergeError(err)
+	}
+
+	ext := path.Ext(source.FileName)
+	var documents []*entity.Document
+	var links []*entity.DocumentSource
+	for i, part := range parts {
+		title := partTitle(part.Title)
+		if title == "" {
+			title = stem(source.FileName)
+		}
+		document, err := m.UploadDocument(ctx, &entity.Document{
+			UserID:   userID,
+			FileName: fmt.Sprintf("%02d %s%s", i+1, title, ext),
+			FileSize: int64(len(part.Content)),
+			FolderID: folderID,
+		}, bytes.NewReader(part.Content))
+		if err != nil {
+			m.trashCreated(ctx, documents)
+			return nil, err
+		}
+		documents = append(documents, document)
+		links = append(links, &entity.DocumentSource{DocumentID: document.ID, SourceID: source.ID, Operation: entity.OperationSplit, Position: i})
+	}
+	if err := m.recordSources(ctx, links, documents); err != nil {
+		return nil, err
+	}
+	return documents, nil
+}
+
+// GetProvenance returns the documents a document userID can view was made
+// from and those made from it. Linked documents that are in the trash, gone
+// or that userID cannot view are left out.
+func (m *DocumentManager) GetProvenance(ctx context.Context, userID, documentID uuid.UUID) (*entity.Provenance, error) {
+	document, err := m.getDocument(ctx, userID, documentID, entity.RoleViewer)
+	if err != nil {
+		return nil, err
+	}
+	provenance := &entity.Provenance{}
+	if m.sourceRepo == nil {
+		return provenance, nil
+	}
+
+	sources, err := m.sourceRepo.ListByDocument(ctx, document.ID)
+	if err != nil {
+		return nil, err
+	}
+	if provenance.Sources, err = m.visibleLinks(ctx, userID, sources, func(l *entity.DocumentSource) uuid.UUID { return l.SourceID }); err != nil {
+		return nil, err
+	}
+	derived, err := m.sourceRepo.ListBySource(ctx, document.ID)
+	if err != nil {
+		return nil, err
+	}
+	if provenance.Derived, err = m.visibleLinks(ctx, userID, derived, func(l *entity.DocumentSource) uuid.UUID { return l.DocumentID }); err != nil {
+		return nil, err
+	}
+	return provenance, nil
+}
+
+// visibleLinks pairs links with the documents other returns the id of,
+// keeping those userID can view.
+func (m *DocumentManager) visibleLinks(ctx context.Context, userID uuid.UUID, links []*entity.DocumentSource, other func(*entity.DocumentSource) uuid.UUID) ([]*entity.ProvenanceLink, error) {
+	var out []*entity.ProvenanceLink
+	for _, link := range links {
+		document, err := m.getDocument(ctx, userID, other(link), entity.RoleViewer)
+		if errors.Is(err, constant.ErrDocumentNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, &entity.ProvenanceLink{Link: link, Document: document})
+	}
+	return out, nil
+}
+
+// readForMerge reads a document userID can view for merging or splitting.
+func (m *DocumentManager) readForMerge(ctx context.Context, userID, documentID uuid.UUID) (*entity.Document, []byte, error) {
+	document, err := m.getDocument(ctx, userID, documentID, entity.RoleViewer)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !docmerge.Supported(document.ContentType) {
+		return nil, nil, fmt.Errorf("%w: %s", constant.ErrUnsupportedFormat, document.ContentType)
+	}
+	content, err := m.openContent(ctx, document)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer content.Close()
+
+	data, err := io.ReadAll(io.LimitReader(content, docmerge.MaxSize+1))
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(data) > docmerge.MaxSize {
+		return nil, nil, fmt.Errorf("%w: %v", constant.ErrMalformedDocument, docmerge.ErrDocumentTooLarge)
+	}
+	return document, data, nil
+}
+
+// recordSources records the provenance of created. When it cannot, the
+// documents are moved into the trash so that none is left without it.
+func (m *DocumentManager) recordSources(ctx context.Context, links []*entity.DocumentSource, created []*entity.Document) error {
+	if m.sourceRepo == nil {
+		return nil
+	}
+	if err := m.sourceRepo.Create(ctx, links); err != nil {
+		m.trashCreated(ctx, created)
+		return err
+	}
+	return nil
+}
+
+// trashCreated moves the documents of a failed merge or split into the trash,
+// from which they are purged in due course.
+func (m *DocumentManager) trashCreated(ctx context.Context, documents []*entity.Document) {
+	for _, document := range documents {
+		if err := m.documentRepo.Delete(ctx, document.ID); err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			logrus.Warnf("Failed to trash document %s of a failed merge or split: %v", document.ID, err)
+		}
+	}
+}
+
+// mergeError maps the errors of docmerge to those of the service.
+func mergeError(err error) error {
+	switch {
+	case errors.Is(err, docmerge.ErrUnsupportedFormat):
+		return fmt.Errorf("%w: %v", constant.ErrUnsupportedFormat, err)
+	case errors.Is(err, docmerge.ErrNoHeadings), errors.Is(err, docmerge.ErrTooManyParts):
+		return fmt.Errorf("%w: %v", constant.ErrInvalidSplit, err)
+	case errors.Is(err, docmerge.ErrIncompatibleDocuments):
+		return fmt.Errorf("%w: %v", constant.ErrInvalidMerge, err)
+	case errors.Is(err, docmerge.ErrMalformedDocument), errors.Is(err, docmerge.ErrDocumentTooLarge):
+		return fmt.Errorf("%w: %v", constant.ErrMalformedDocument, err)
+	}
+	return err
+}
+
+// partTitle turns the heading of a part into something fit for a file name:
+// path separators and control characters are replaced, spaces collapsed and
+// the title shortened on a rune boundary.
+func partTitle(heading string) string {
+	title := strings.Join(strings.Fields(strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || unicode.IsControl(r) {
+			return ' '
+		}
+		return r
+	}, heading)), " ")
+	for len(title) > maxPartTitleLength {
+		_, size := utf8.DecodeLastRuneInString(title)
+		title = title[:len(title)-size]
+	}
+	return strings.Trim(title, " .")
+}
+
+// stem returns fileName without its extension.
+func stem(fileName string) string {
+	return strings.TrimSuffix(fileName, path.Ext(fileName))
+}
@@ -0,0 +1,171 @@
+package document
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/a1y/doc-formatter/internal/storage/domain/constant"
+	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
+	"github.com/a1y/doc-formatter/internal/storage/infra/persistence"
+	"github.com/a1y/doc-formatter/internal/storage/util/objectstore/memory"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func newMergeTestManager(t *testing.T) (*DocumentManager, *gorm.DB) {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, persistence.AutoMigrate(db))
+
+	manager := NewDocumentManager(persistence.NewDocumentRepository(db), persistence.NewFolderRepository(db), persistence.NewACLRepository(db), memory.NewMemoryStorage())
+	manager.SetSourceRepository(persistence.NewDocumentSourceRepository(db))
+	manager.SetBatchJobRepository(persistence.NewBatchJobRepository(db))
+	manager.SetKeyring(newTestKeyring(t, "k1", "k1"))
+	return manager, db
+}
+
+func readDocument(t *testing.T, manager *DocumentManager, userID uuid.UUID, document *entity.Document) string {
+	t.Helper()
+
+	_, content, err := manager.DownloadDocument(context.Background(), userID, document.ID)
+	require.NoError(t, err)
+	defer content.Close()
+	data, err := io.ReadAll(content)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestDocumentManager_MergeDocuments(t *testing.T) {
+	t.Parallel()
+
+	manager, db := newMergeTestManager(t)
+	ctx := context.Background()
+	ownerID, userID := uuid.New(), uuid.New()
+
+	intro, err := manager.UploadDocument(ctx, &entity.Document{UserID: userID, FileName: "intro.md"},
+		bytes.NewReader([]byte("# Intro\n\nHello.\n")))
+	require.NoError(t, err)
+	shared, err := manager.UploadDocument(ctx, &entity.Document{UserID: ownerID, FileName: "methods.md"},
+		bytes.NewReader([]byte("# Methods\n\nWe measured.\n")))
+	require.NoError(t, err)
+	require.NoError(t, persistence.NewACLRepository(db).Upsert(ctx, &entity.ACLEntry{
+		ResourceType: entity.ResourceDocument,
+		ResourceID:   shared.ID,
+		OwnerID:      ownerID,
+		UserID:       userID,
+		Role:         entity.RoleViewer,
+	}))
+	folder := &entity.Folder{UserID: userID, Name: "Reports"}
+	require.NoError(t, persistence.NewFolderRepository(db).Create(ctx, folder))
+
+	merged, err := manager.MergeDocuments(ctx, userID, []uuid.UUID{intro.ID, shared.ID}, "report.md", &folder.ID, false)
+	require.NoError(t, err)
+	require.Equal(t, userID, merged.UserID)
+	require.Equal(t, &folder.ID, merged.FolderID)
+	require.Equal(t, "# Intro\n\nHello.\n\n# Methods\n\nWe measured.\n", readDocument(t, manager, userID, merged))
+
+	merged, err = manager.MergeDocuments(ctx, userID, []uuid.UUID{shared.ID, intro.ID}, "", nil, true)
+	require.NoError(t, err)
+	require.Equal(t, "methods-merged.md", merged.FileName)
+
+	provenance, err := manager.GetProvenance(ctx, userID, merged.ID)
+	require.NoError(t, err)
+	require.Len(t, provenance.Sources, 2)
+	require.Equal(t, shared.ID, provenance.Sources[0].Document.ID)
+	require.Equal(t, intro.ID, provenance.Sources[1].Document.ID)
+	require.Equal(t, entity.OperationMerge, provenance.Sources[1].Link.Operation)
+	require.Equal(t, 1, provenance.Sources[1].Link.Position)
+	require.Empty(t, provenance.Derived)
+
+	// The owner of a source does not see documents made from it by others.
+	provenance, err = manager.GetProvenance(ctx, ownerID, shared.ID)
+	require.NoError(t, err)
+	require.Empty(t, provenance.Derived)
+	provenance, err = manager.GetProvenance(ctx, userID, intro.ID)
+	require.NoError(t, err)
+	require.Len(t, provenance.Derived, 2)
+
+	_, err = manager.MergeDocuments(ctx, userID, []uuid.UUID{intro.ID}, "", nil, false)
+	require.ErrorIs(t, err, constant.ErrInvalidMerge)
+	_, err = manager.MergeDocuments(ctx, userID, []uuid.UUID{intro.ID, shared.ID}, "report.docx", nil, false)
+	require.ErrorIs(t, err, constant.ErrInvalidMerge)
+	_, err = manager.MergeDocuments(ctx, userID, []uuid.UUID{intro.ID, uuid.New()}, "", nil, false)
+	require.ErrorIs(t, err, constant.ErrDocumentNotFound)
+	_, err = manager.MergeDocuments(ctx, ownerID, []uuid.UUID{shared.ID, intro.ID}, "", nil, false)
+	require.ErrorIs(t, err, constant.ErrDocumentNotFound)
+	other := uuid.New()
+	_, err = manager.MergeDocuments(ctx, userID, []uuid.UUID{intro.ID, shared.ID}, "", &other, false)
+	require.ErrorIs(t, err, constant.ErrFolderNotFound)
+
+	text, err := manager.UploadDocument(ctx, &entity.Document{UserID: userID, FileName: "notes.txt"}, bytes.NewReader([]byte("notes")))
+	require.NoError(t, err)
+	_, err = manager.MergeDocuments(ctx, userID, []uuid.UUID{intro.ID, text.ID}, "", nil, false)
+	require.ErrorIs(t, err, constant.ErrUnsupportedFormat)
+}
+
+func TestDocumentManager_SplitDocument(t *testing.T) {
+	t.Parallel()
+
+	manager, db := newMergeTestManager(t)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	guide, err := manager.UploadDocument(ctx, &entity.Document{UserID: userID, FileName: "guide.md"},
+		bytes.NewReader([]byte("Preface.\n\n# Install / Setup\n\nRun it.\n\n## Options\n\nFlags.\n\n# Usage\n\nCall it.\n")))
+	require.NoError(t, err)
+
+	parts, err := manager.SplitDocument(ctx, userID, guide.ID, 1, nil)
+	require.NoError(t, err)
+	require.Len(t, parts, 3)
+	require.Equal(t, "01 guide.md", parts[0].FileName)
+	require.Equal(t, "02 Install Setup.md", parts[1].FileName)
+	require.Equal(t, "03 Usage.md", parts[2].FileName)
+	require.Equal(t, "# Install / Setup\n\nRun it.\n\n## Options\n\nFlags.\n", readDocument(t, manager, userID, parts[1]))
+
+	provenance, err := manager.GetProvenance(ctx, userID, guide.ID)
+	require.NoError(t, err)
+	require.Len(t, provenance.Derived, 3)
+	provenance, err = manager.GetProvenance(ctx, userID, parts[2].ID)
+	require.NoError(t, err)
+	require.Len(t, provenance.Sources, 1)
+	require.Equal(t, guide.ID, provenance.Sources[0].Document.ID)
+	require.Equal(t, entity.OperationSplit, provenance.Sources[0].Link.Operation)
+	require.Equal(t, 2, provenance.Sources[0].Link.Position)
+
+	// Parts in the trash are left out, and purging a part removes its link.
+	_, err = manager.TrashDocument(ctx, userID, parts[0].ID)
+	require.NoError(t, err)
+	provenance, err = manager.GetProvenance(ctx, userID, guide.ID)
+	require.NoError(t, err)
+	require.Len(t, provenance.Derived, 2)
+	_, err = manager.EmptyTrash(ctx, userID)
+	require.NoError(t, err)
+	var count int64
+	require.NoError(t, db.Model(&persistence.DocumentSourceModel{}).Count(&count).Error)
+	require.EqualValues(t, 2, count)
+
+	_, err = manager.SplitDocument(ctx, userID, guide.ID, 0, nil)
+	require.ErrorIs(t, err, constant.ErrInvalidSplit)
+	_, err = manager.SplitDocument(ctx, uuid.New(), guide.ID, 1, nil)
+	require.ErrorIs(t, err, constant.ErrDocumentNotFound)
+	plain, err := manager.UploadDocument(ctx, &entity.Document{UserID: userID, FileName: "plain.md"}, bytes.NewReader([]byte("Just text.\n")))
+	require.NoError(t, err)
+	_, err = manager.SplitDocument(ctx, userID, plain.ID, 1, nil)
+	require.ErrorIs(t, err, constant.ErrInvalidSplit)
+}
+
+func TestPartTitle(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "Install Setup", partTitle(" Install /\tSetup "))
+	require.Equal(t, "Why", partTitle("Why..."))
+	require.Equal(t, "", partTitle(""))
+	long := partTitle(string(bytes.Repeat([]byte("é"), maxPartTitleLength)))
+	require.Len(t, long, maxPartTitleLength)
+}
@@ -0,0 +1,128 @@
+package document
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/a1y/doc-formatter/internal/storage/domain/constant"
+	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
+	"github.com/google/uuid"
+)
+
+// PageRequest asks for a page of a listing of documents.
+type PageRequest struct {
+	// Size is the maximum number of documents of the page. Zero asks for
+	// entity.DefaultPageSize and larger sizes are lowered to
+	// entity.MaxPageSize.
+	Size int
+	// Token is the next page token of the previous page, empty for the
+	// first page.
+	Token string
+	// Sort defaults to entity.SortByName.
+	Sort       entity.DocumentSort
+	Descending bool
+}
+
+// newPage returns the page of a listing asked for by req.
+func newPage(req PageRequest) (*entity.DocumentPage, error) {
+	page := &entity.DocumentPage{
+		Sort:       req.Sort,
+		Descending: req.Descending,
+		Size:       min(req.Size, entity.MaxPageSize),
+	}
+	if page.Sort == "" {
+		page.Sort = entity.SortByName
+	}
+	if page.Size == 0 {
+		page.Size = entity.DefaultPageSize
+	}
+	if err := page.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", constant.ErrInvalidPage, err)
+	}
+	if req.Token != "" {
+		after, err := decodePageToken(req.Token, page)
+		if err != nil {
+			return nil, err
+		}
+		page.After = after
+	}
+	return page, nil
+}
+
+// listPage lists page with list and returns the documents along with the
+// token of the next page, which is empty on the last page.
+func listPage(
+	page *entity.DocumentPage, list func(page *entity.DocumentPage) ([]*entity.Document, error),
+) ([]*entity.Document, string, error) {
+	// One more document than asked for tells whether a next page exists.
+	size := page.Size
+	page.Size++
+	documents, err := list(page)
+	page.Size = size
+	if err != nil {
+		return nil, "", err
+	}
+	if len(documents) <= size {
+		return documents, "", nil
+	}
+	documents = documents[:size]
+	return documents, encodePageToken(page, documents[size-1]), nil
+}
+
+// pageToken is the position of a page in a listing: the sort order and the
+// sort key and ID of the last document of the previous page. It is handed to
+// clients as opaque URL-safe base64 JSON.
+type pageToken struct {
+	Sort       entity.DocumentSort `json:"s"`
+	Descending bool                `json:"d,omitempty"`
+	ID         uuid.UUID           `json:"id"`
+	Name       string              `json:"n,omitempty"`
+	Size       int64               `json:"z,omitempty"`
+	Time       time.Time           `json:"t,omitzero"`
+}
+
+func encodePageToken(page *entity.DocumentPage, last *entity.Document) string {
+	token := pageToken{Sort: page.Sort, Descending: page.Descending, ID: last.ID}
+	switch page.Sort {
+	case entity.SortBySize:
+		token.Size = last.FileSize
+	case entity.SortByCreated:
+		token.Time = last.CreatedAt
+	case entity.SortByUpdated:
+		token.Time = last.UpdatedAt
+	case entity.SortByDeleted:
+		if last.DeletedAt != nil {
+			token.Time = *last.DeletedAt
+		}
+	default:
+		token.Name = last.FileName
+	}
+	data, _ := json.Marshal(token)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodePageToken returns the last document of the previous page encoded in
+// value, which must have been issued for the same sort order as page.
+func decodePageToken(value string, page *entity.DocumentPage) (*entity.Document, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, constant.ErrInvalidPageToken
+	}
+	var token pageToken
+	if err := json.Unmarshal(data, &token); err != nil || token.ID == uuid.Nil {
+		return nil, constant.ErrInvalidPageToken
+	}
+	if token.Sort != page.Sort || token.Descending != page.Descending {
+		return nil, constant.ErrInvalidPageToken
+	}
+	return &entity.Document{
+		ID:        token.ID,
+		FileName:  token.Name,
+		FileSize:  token.Size,
+		CreatedAt: token.Time,
+		UpdatedAt: token.Time,
+		DeletedAt: &token.Time,
+	}, nil
+}
@@ -0,0 +1,123 @@
+package document
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/a1y/doc-formatter/internal/storage/domain/constant"
+	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
+	"github.com/a1y/doc-formatter/internal/storage/infra/persistence"
+	"github.com/a1y/doc-formatter/internal/storage/util/objectstore/memory"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestDocumentManager_ListDocuments_Pages(t *testing.T) {
+	t.Parallel()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, persistence.AutoMigrate(db))
+
+	ctx := context.Background()
+	manager := NewDocumentManager(persistence.NewDocumentRepository(db), persistence.NewFolderRepository(db), nil, memory.NewMemoryStorage())
+
+	userID := uuid.New()
+	// Sizes repeat so that ties are broken by id across page boundaries.
+	for i, size := range []int{30, 10, 20, 10, 30, 20, 10} {
+		_, err := manager.UploadDocument(ctx, &entity.Document{
+			UserID:   userID,
+			FileName: fmt.Sprintf("file-%d.txt", i),
+			FileSize: int64(size),
+		}, bytes.NewReader(make([]byte, size)))
+		require.NoError(t, err)
+	}
+
+	// listAll walks every page of a listing and returns the documents.
+	listAll := func(req PageRequest) []*entity.Document {
+		var out []*entity.Document
+		for pages := 0; ; pages++ {
+			require.Less(t, pages, 10)
+			docs, next, err := manager.ListDocuments(ctx, userID, entity.DocumentFilter{}, req)
+			require.NoError(t, err)
+			require.LessOrEqual(t, len(docs), req.Size)
+			out = append(out, docs...)
+			if next == "" {
+				return out
+			}
+			req.Token = next
+		}
+	}
+
+	byName := listAll(PageRequest{Size: 3})
+	require.Len(t, byName, 7)
+	for i, doc := range byName {
+		require.Equal(t, fmt.Sprintf("file-%d.txt", i), doc.FileName)
+	}
+
+	reversed := listAll(PageRequest{Size: 2, Sort: entity.SortByName, Descending: true})
+	require.Len(t, reversed, 7)
+	require.Equal(t, "file-6.txt", reversed[0].FileName)
+	require.Equal(t, "file-0.txt", reversed[6].FileName)
+
+	bySize := listAll(PageRequest{Size: 2, Sort: entity.SortBySize})
+	require.Len(t, bySize, 7)
+	seen := make(map[uuid.UUID]bool)
+	for i, doc := range bySize {
+		require.False(t, seen[doc.ID], "document listed twice")
+		seen[doc.ID] = true
+		if i > 0 {
+			prev := bySize[i-1]
+			require.True(t, prev.FileSize < doc.FileSize ||
+				(prev.FileSize == doc.FileSize && prev.ID.String() < doc.ID.String()))
+		}
+	}
+
+	byCreated := listAll(PageRequest{Size: 4, Sort: entity.SortByCreated, Descending: true})
+	require.Len(t, byCreated, 7)
+	require.Equal(t, "file-6.txt", byCreated[0].FileName)
+
+	docs, next, err := manager.ListDocuments(ctx, userID, entity.DocumentFilter{}, PageRequest{Size: 7})
+	require.NoError(t, err)
+	require.Len(t, docs, 7)
+	require.Empty(t, next)
+}
+
+func TestDocumentManager_ListDocuments_InvalidPage(t *testing.T) {
+	t.Parallel()
+
+	manager := NewDocumentManager(&mockDocumentRepository{}, nil, nil, memory.NewMemoryStorage())
+	ctx := context.Background()
+	userID := uuid.New()
+
+	_, _, err := manager.ListDocuments(ctx, userID, entity.DocumentFilter{}, PageRequest{Size: -1})
+	require.ErrorIs(t, err, constant.ErrInvalidPage)
+
+	_, _, err = manager.ListDocuments(ctx, userID, entity.DocumentFilter{}, PageRequest{Sort: "rating"})
+	require.ErrorIs(t, err, constant.ErrInvalidPage)
+
+	_, _, err = manager.ListDocuments(ctx, userID, entity.DocumentFilter{}, PageRequest{Token: "not a token"})
+	require.ErrorIs(t, err, constant.ErrInvalidPageToken)
+
+	page := &entity.DocumentPage{Sort: entity.SortBySize}
+	token := encodePageToken(page, &entity.Document{ID: uuid.New(), FileSize: 10})
+	_, _, err = manager.ListDocuments(ctx, userID, entity.DocumentFilter{}, PageRequest{Token: token, Sort: entity.SortByName})
+	require.ErrorIs(t, err, constant.ErrInvalidPageToken)
+}
+
+func TestPageToken_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	last := &entity.Document{ID: uuid.New(), FileName: "a.txt", FileSize: 42}
+	for _, sort := range []entity.DocumentSort{entity.SortByName, entity.SortBySize, entity.SortByCreated} {
+		page := &entity.DocumentPage{Sort: sort, Descending: true}
+		after, err := decodePageToken(encodePageToken(page, last), page)
+		require.NoError(t, err)
+		require.Equal(t, last.ID, after.ID)
+		require.Equal(t, sort.Key(last), sort.Key(after))
+	}
+}
@@ -0,0 +1,66 @@
+package document
+
+import (
+	"context"
+	"time"
+
+	"github.com/a1y/doc-formatter/internal/storage/domain/repository"
+	"github.com/sirupsen/logrus"
+)
+
+// trashPurgeLock names the lock that keeps instances of the storage service
+// from purging the trash at the same time.
+const trashPurgeLock = "storage.trash-purge"
+
+// TrashPurger periodically deletes for good the documents that have been in
+// the trash for longer than the retention window.
+type TrashPurger struct {
+	manager   *DocumentManager
+	locker    repository.Locker
+	retention time.Duration
+	interval  time.Duration
+}
+
+func NewTrashPurger(manager *DocumentManager, locker repository.Locker, retention, interval time.Duration) *TrashPurger {
+	return &TrashPurger{
+		manager:   manager,
+		locker:    locker,
+		retention: retention,
+		interval:  interval,
+	}
+}
+
+// Run purges the trash right away, then every interval until ctx is done.
+func (p *TrashPurger) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		if purged, err := p.PurgeOnce(ctx); err != nil {
+			logrus.Errorf("Failed to purge the trash after %d documents: %v", purged, err)
+		} else if purged > 0 {
+			logrus.Infof("Purged %d expired documents from the trash", purged)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// PurgeOnce purges the expired documents of the trash, unless another
+// instance of the service is already doing so. It returns the number of
+// documents purged.
+func (p *TrashPurger) PurgeOnce(ctx context.Context) (int, error) {
+	purged := 0
+	acquired, err := p.locker.TryLock(ctx, trashPurgeLock, func(ctx context.Context) error {
+		var err error
+		purged, err = p.manager.PurgeTrash(ctx, time.Now().Add(-p.retention))
+		return err
+	})
+	if err == nil && !acquired {
+		logrus.Debug("Skipping trash purge, another instance is purging")
+	}
+	return purged, err
+}
@@ -0,0 +1,112 @@
+package document
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"strings"
+
+	"github.com/a1y/doc-formatter/internal/storage/domain/constant"
+	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
+	"github.com/a1y/doc-formatter/internal/storage/util/textextract"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// IndexBatchSize is the number of documents loaded per query while indexing
+// their text.
+const IndexBatchSize = 50
+
+// SearchDocuments returns the documents userID can read whose text or file
+// name matches text, best matches first. A limit of zero asks for
+// entity.DefaultSearchLimit results and larger limits are lowered to
+// entity.MaxSearchLimit.
+func (m *DocumentManager) SearchDocuments(ctx context.Context, userID uuid.UUID, text string, limit int) ([]*entity.SearchHit, error) {
+	if limit == 0 {
+		limit = entity.DefaultSearchLimit
+	}
+	query := &entity.SearchQuery{
+		Text:   strings.TrimSpace(text),
+		UserID: userID,
+		Limit:  min(limit, entity.MaxSearchLimit),
+	}
+	if err := query.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", constant.ErrInvalidSearch, err)
+	}
+
+	documentIDs, folderIDs, err := m.access.SharedWith(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	query.DocumentIDs = documentIDs
+	query.FolderIDs = folderIDs
+	return m.textRepo.Search(ctx, query)
+}
+
+// IndexDocuments extracts and stores the text of every document that has
+// none yet. Documents without a language in their metadata are indexed with
+// the text search configuration defaultLanguage. It returns the number of
+// documents indexed.
+func (m *DocumentManager) IndexDocuments(ctx context.Context, defaultLanguage string) (int, error) {
+	indexed := 0
+	for {
+		documents, err := m.textRepo.ListUnindexed(ctx, IndexBatchSize)
+		if err != nil {
+			return indexed, err
+		}
+		if len(documents) == 0 {
+			return indexed, nil
+		}
+		for _, document := range documents {
+			text := m.extractText(ctx, document, defaultLanguage)
+			// Do not record an interrupted extraction as a failure.
+			if err := ctx.Err(); err != nil {
+				return indexed, err
+			}
+			if err := m.textRepo.Save(ctx, text); err != nil {
+				return indexed, err
+			}
+			indexed++
+		}
+	}
+}
+
+// extractText extracts the text of document. Failures are recorded in the
+// text rather than returned, so that documents that cannot be read are not
+// tried again on every run. A document that makes the extraction panic is
+// recorded as malformed.
+func (m *DocumentManager) extractText(ctx context.Context, document *entity.Document, defaultLanguage string) (text *entity.DocumentText) {
+	text = &entity.DocumentText{
+		DocumentID: document.ID,
+		Language:   defaultLanguage,
+	}
+	if config, ok := entity.TextSearchConfig(document.Metadata[entity.LanguageMetadataKey]); ok {
+		text.Language = config
+	}
+	if !textextract.Supported(document.ContentType) {
+		text.Error = textextract.ErrUnsupportedFormat.Error()
+		return text
+	}
+	if document.FileSize > textextract.MaxDocumentSize {
+		text.Error = textextract.ErrDocumentTooLarge.Error()
+		return text
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			logrus.Errorf("Panic while extracting the text of document %s: %v\n%s", document.ID, r, debug.Stack())
+			text.Content = ""
+			text.Error = textextract.ErrMalformedDocument.Error()
+		}
+	}()
+
+	content, err := m.openContent(ctx, document)
+	if err != nil {
+		text.Error = err.Error()
+		return text
+	}
+	defer content.Close()
+	if text.Content, err = textextract.Extract(document.ContentType, content); err != nil {
+		text.Error = err.Error()
+	}
+	return text
+}
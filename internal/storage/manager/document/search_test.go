@@ -0,0 +1,173 @@
+package document
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/a1y/doc-formatter/internal/storage/domain/constant"
+	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
+	"github.com/a1y/doc-formatter/internal/storage/domain/repository"
+	"github.com/a1y/doc-formatter/internal/storage/infra/persistence"
+	"github.com/a1y/doc-formatter/internal/storage/util/objectstore/memory"
+	"github.com/a1y/doc-formatter/internal/storage/util/textextract"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// recordingTextRepository records search queries, as searching relies on the
+// text search of PostgreSQL, which SQLite lacks.
+type recordingTextRepository struct {
+	repository.DocumentTextRepository
+
+	lastQuery *entity.SearchQuery
+}
+
+func (r *recordingTextRepository) Search(_ context.Context, q *entity.SearchQuery) ([]*entity.SearchHit, error) {
+	r.lastQuery = q
+	return []*entity.SearchHit{}, nil
+}
+
+func newSearchTestManager(t *testing.T) (*DocumentManager, *recordingTextRepository, *gorm.DB) {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, persistence.AutoMigrate(db))
+
+	textRepo := &recordingTextRepository{DocumentTextRepository: persistence.NewDocumentTextRepository(db)}
+	manager := NewDocumentManager(persistence.NewDocumentRepository(db), persistence.NewFolderRepository(db), persistence.NewACLRepository(db), memory.NewMemoryStorage())
+	manager.SetTextRepository(textRepo)
+	return manager, textRepo, db
+}
+
+func storedText(t *testing.T, db *gorm.DB, documentID uuid.UUID) persistence.DocumentTextModel {
+	t.Helper()
+
+	var text persistence.DocumentTextModel
+	require.NoError(t, db.Where("document_id = ?", documentID).First(&text).Error)
+	return text
+}
+
+func TestDocumentManager_IndexDocuments(t *testing.T) {
+	t.Parallel()
+
+	manager, _, db := newSearchTestManager(t)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	upload := func(name, content string, metadata map[string]string) *entity.Document {
+		doc, err := manager.UploadDocument(ctx, &entity.Document{UserID: userID, FileName: name, Metadata: metadata},
+			bytes.NewReader([]byte(content)))
+		require.NoError(t, err)
+		return doc
+	}
+	notes := upload("notes.md", "# Compte rendu\n\nLe *budget* est voté.", map[string]string{"language": "fr"})
+	page := upload("page.html", "<p>Quarterly <b>revenue</b></p>", nil)
+	scan := upload("scan.pdf", "%PDF-1.7", nil)
+	broken := upload("broken.docx", "not a zip", nil)
+	trashed := upload("old.txt", "obsolete", nil)
+	_, err := manager.TrashDocument(ctx, userID, trashed.ID)
+	require.NoError(t, err)
+
+	indexed, err := manager.IndexDocuments(ctx, "english")
+	require.NoError(t, err)
+	require.Equal(t, 4, indexed)
+
+	text := storedText(t, db, notes.ID)
+	require.Equal(t, "french", text.Language)
+	require.Equal(t, "Compte rendu\n\nLe budget est voté.", text.Content)
+	require.Empty(t, text.Error)
+
+	text = storedText(t, db, page.ID)
+	require.Equal(t, "english", text.Language)
+	require.Equal(t, "Quarterly revenue", text.Content)
+
+	text = storedText(t, db, scan.ID)
+	require.Empty(t, text.Content)
+	require.Equal(t, textextract.ErrUnsupportedFormat.Error(), text.Error)
+
+	text = storedText(t, db, broken.ID)
+	require.Contains(t, text.Error, textextract.ErrMalformedDocument.Error())
+
+	// Documents are indexed once, failures included.
+	indexed, err = manager.IndexDocuments(ctx, "english")
+	require.NoError(t, err)
+	require.Zero(t, indexed)
+
+	// Changing the language indexes the document again; other labels do not.
+	_, err = manager.AddTags(ctx, userID, page.ID, []string{"finance"})
+	require.NoError(t, err)
+	_, err = manager.SetMetadata(ctx, userID, page.ID, map[string]string{"language": "de", "owner": "ops"})
+	require.NoError(t, err)
+	_, err = manager.RemoveMetadata(ctx, userID, notes.ID, []string{"language"})
+	require.NoError(t, err)
+
+	indexer := NewTextIndexer(manager, persistence.NewLocker(db), "simple", 0)
+	indexed, err = indexer.IndexOnce(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, indexed)
+	require.Equal(t, "german", storedText(t, db, page.ID).Language)
+	require.Equal(t, "simple", storedText(t, db, notes.ID).Language)
+}
+
+func TestDocumentManager_SearchDocuments(t *testing.T) {
+	t.Parallel()
+
+	manager, textRepo, db := newSearchTestManager(t)
+	ctx := context.Background()
+	ownerID, userID := uuid.New(), uuid.New()
+
+	folderRepo := persistence.NewFolderRepository(db)
+	folder := &entity.Folder{UserID: ownerID, Name: "Reports"}
+	require.NoError(t, folderRepo.Create(ctx, folder))
+	aclRepo := persistence.NewACLRepository(db)
+	require.NoError(t, aclRepo.Upsert(ctx, &entity.ACLEntry{
+		ResourceType: entity.ResourceFolder,
+		ResourceID:   folder.ID,
+		OwnerID:      ownerID,
+		UserID:       userID,
+		Role:         entity.RoleViewer,
+	}))
+
+	hits, err := manager.SearchDocuments(ctx, userID, "  budget  ", 0)
+	require.NoError(t, err)
+	require.Empty(t, hits)
+	require.Equal(t, &entity.SearchQuery{
+		Text:      "budget",
+		UserID:    userID,
+		FolderIDs: []uuid.UUID{folder.ID},
+		Limit:     entity.DefaultSearchLimit,
+	}, textRepo.lastQuery)
+
+	_, err = manager.SearchDocuments(ctx, ownerID, "budget", 5000)
+	require.NoError(t, err)
+	require.Equal(t, entity.MaxSearchLimit, textRepo.lastQuery.Limit)
+	require.Empty(t, textRepo.lastQuery.FolderIDs)
+
+	_, err = manager.SearchDocuments(ctx, userID, " ", 0)
+	require.ErrorIs(t, err, constant.ErrInvalidSearch)
+	_, err = manager.SearchDocuments(ctx, userID, "budget", -1)
+	require.ErrorIs(t, err, constant.ErrInvalidSearch)
+}
+
+func TestDocumentManager_IndexDocuments_Panic(t *testing.T) {
+	t.Parallel()
+
+	manager, _, db := newSearchTestManager(t)
+	ctx := context.Background()
+
+	notes, err := manager.UploadDocument(ctx, &entity.Document{UserID: uuid.New(), FileName: "notes.md"}, bytes.NewReader([]byte("# Notes")))
+	require.NoError(t, err)
+
+	// A document that makes the extraction panic is recorded as malformed.
+	manager.objectStore = panickingStore{MemoryStorage: manager.objectStore.(*memory.MemoryStorage)}
+	indexed, err := manager.IndexDocuments(ctx, "english")
+	require.NoError(t, err)
+	require.Equal(t, 1, indexed)
+	text := storedText(t, db, notes.ID)
+	require.Empty(t, text.Content)
+	require.Equal(t, textextract.ErrMalformedDocument.Error(), text.Error)
+}
@@ -0,0 +1,274 @@
+package document
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/a1y/doc-formatter/internal/storage/domain/constant"
+	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
+	"github.com/a1y/doc-formatter/pkg/credentials"
+	"github.com/a1y/doc-formatter/pkg/throttle"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// linkTokenSize is the number of random bytes of a share link token.
+const linkTokenSize = 32
+
+// Prefixes of the keys wrong share link passwords are counted by.
+const (
+	linkKeyLink = "link:"
+	linkKeyIP   = "ip:"
+)
+
+// ShareLinkOptions restricts the use of a new share link. The zero value
+// gives a link without password, expiry or download limit.
+type ShareLinkOptions struct {
+	Password     string
+	ExpiresAt    *time.Time
+	MaxDownloads int
+}
+
+// LinkDownload is a request to download a document through a share link,
+// along with the client details recorded for the access.
+type LinkDownload struct {
+	Token      string
+	Password   string
+	RemoteAddr string
+	UserAgent  string
+}
+
+// CreateShareLink creates a link to a document of userID and returns it with
+// its token. The token is not stored and cannot be retrieved later.
+func (m *DocumentManager) CreateShareLink(ctx context.Context, userID, documentID uuid.UUID, opts ShareLinkOptions) (*entity.ShareLink, string, error) {
+	if opts.MaxDownloads < 0 {
+		return nil, "", fmt.Errorf("%w: max downloads must not be negative", constant.ErrInvalidShareLink)
+	}
+	if opts.ExpiresAt != nil && !opts.ExpiresAt.After(time.Now()) {
+		return nil, "", fmt.Errorf("%w: expiry must be in the future", constant.ErrInvalidShareLink)
+	}
+	document, err := m.getDocument(ctx, userID, documentID, entity.RoleOwner)
+	if err != nil {
+		return nil, "", err
+	}
+
+	token, err := newLinkToken()
+	if err != nil {
+		return nil, "", err
+	}
+	link := &entity.ShareLink{
+		DocumentID:   document.ID,
+		OwnerID:      document.UserID,
+		TokenHash:    hashLinkToken(token),
+		ExpiresAt:    opts.ExpiresAt,
+		MaxDownloads: opts.MaxDownloads,
+	}
+	if opts.Password != "" {
+		link.PasswordHash, err = credentials.NewDefaultArgon2idHash().HashPassword(opts.Password, nil)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+	if err := m.linkRepo.Create(ctx, link); err != nil {
+		return nil, "", err
+	}
+	return link, token, nil
+}
+
+// ListShareLinks returns the links to a document of userID, newest first.
+func (m *DocumentManager) ListShareLinks(ctx context.Context, userID, documentID uuid.UUID) ([]*entity.ShareLink, error) {
+	document, err := m.getDocument(ctx, userID, documentID, entity.RoleOwner)
+	if err != nil {
+		return nil, err
+	}
+	return m.linkRepo.ListByDocument(ctx, document.ID)
+}
+
+// RevokeShareLink stops a link of userID from working. Revoking a revoked
+// link does nothing.
+func (m *DocumentManager) RevokeShareLink(ctx context.Context, userID, linkID uuid.UUID) (*entity.ShareLink, error) {
+	link, err := m.linkRepo.GetByID(ctx, linkID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constant.ErrShareLinkNotFound
+		}
+		return nil, err
+	}
+	if link.OwnerID != userID {
+		return nil, constant.ErrShareLinkNotFound
+	}
+	if link.RevokedAt != nil {
+		return link, nil
+	}
+
+	now := time.Now()
+	if err := m.linkRepo.Revoke(ctx, link.ID, now); err != nil {
+		return nil, err
+	}
+	link.RevokedAt = &now
+	return link, nil
+}
+
+// SetLinkThrottle sets the store wrong share link passwords are counted in
+// and how they are throttled. Passwords are not throttled when store is nil.
+func (m *DocumentManager) SetLinkThrottle(store throttle.Store, config LinkThrottleConfig) {
+	m.linkThrottle = throttle.New(store, throttle.Policy{
+		Lockout:    config.Lockout,
+		MaxLockout: config.MaxLockout,
+	})
+	m.linkThrottleConfig = config
+}
+
+// DownloadSharedDocument returns the document of a share link together with
+// a reader over its plaintext content, stamped when share-link downloads
+// carry a stamp. Every attempt on an existing link is recorded, whether it
+// succeeds or not, except those failing because the content cannot be read,
+// which are neither recorded nor counted as downloads. Passwords of a link are not checked while the link or the
+// client address is locked after too many wrong ones, a *throttle.LockedError
+// is returned instead. The caller must close the reader.
+func (m *DocumentManager) DownloadSharedDocument(ctx context.Context, req LinkDownload) (*entity.Document, io.ReadCloser, error) {
+	link, err := m.linkRepo.GetByTokenHash(ctx, hashLinkToken(req.Token))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, constant.ErrShareLinkNotFound
+		}
+		return nil, nil, err
+	}
+	// A link to a document in the trash works again once it is restored.
+	document, err := m.documentRepo.GetByID(ctx, link.DocumentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, constant.ErrShareLinkNotFound
+		}
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	linkKey := linkKeyLink + link.ID.String()
+	var ipKey string
+	if req.RemoteAddr != "" {
+		ipKey = linkKeyIP + req.RemoteAddr
+	}
+	if link.HasPassword() {
+		if err := m.linkThrottle.Check(ctx, now, linkKey, ipKey); err != nil {
+			var lockedErr *throttle.LockedError
+			if !errors.As(err, &lockedErr) {
+				return nil, nil, err
+			}
+			if err := m.recordLinkAccess(ctx, link, req, entity.LinkAccessThrottled); err != nil {
+				return nil, nil, err
+			}
+			return nil, nil, lockedErr
+		}
+	}
+
+	outcome, err := checkLink(link, req.Password)
+	if err != nil {
+		return nil, nil, err
+	}
+	if outcome == entity.LinkAccessInvalidPassword {
+		m.linkThrottle.RecordFailure(ctx, now, linkKey, m.linkThrottleConfig.MaxLinkFailures)
+		m.linkThrottle.RecordFailure(ctx, now, ipKey, m.linkThrottleConfig.MaxIPFailures)
+	}
+	var content io.ReadCloser
+	if outcome == entity.LinkAccessDownloaded {
+		if link.HasPassword() {
+			// Failures from the client address are kept, so that opening
+			// one link does not lift throttling of guesses at others.
+			m.linkThrottle.Reset(ctx, linkKey)
+		}
+		// The download is counted once its content is ready, so that a
+		// download that cannot be served does not use up the link.
+		document, content, err = m.stampLinkDownload(ctx, link, document)
+		if err != nil {
+			return nil, nil, err
+		}
+		ok, err := m.linkRepo.IncrementDownloads(ctx, link.ID)
+		if err != nil {
+			content.Close()
+			return nil, nil, err
+		}
+		if !ok {
+			content.Close()
+			content = nil
+			outcome = entity.LinkAccessLimitReached
+		}
+	}
+	if err := m.recordLinkAccess(ctx, link, req, outcome); err != nil {
+		if content != nil {
+			content.Close()
+		}
+		return nil, nil, err
+	}
+	if err := outcomeError(outcome); err != nil {
+		return nil, nil, err
+	}
+
+	return document, content, nil
+}
+
+func (m *DocumentManager) recordLinkAccess(ctx context.Context, link *entity.ShareLink, req LinkDownload, outcome entity.LinkAccessOutcome) error {
+	return m.linkRepo.RecordAccess(ctx, &entity.ShareLinkAccess{
+		LinkID:     link.ID,
+		RemoteAddr: req.RemoteAddr,
+		UserAgent:  req.UserAgent,
+		Outcome:    outcome,
+	})
+}
+
+// checkLink tells whether link can be used with password, leaving the
+// download limit to be checked when the download is counted.
+func checkLink(link *entity.ShareLink, password string) (entity.LinkAccessOutcome, error) {
+	switch {
+	case link.RevokedAt != nil:
+		return entity.LinkAccessRevoked, nil
+	case link.Expired(time.Now()):
+		return entity.LinkAccessExpired, nil
+	case link.HasPassword():
+		ok, err := credentials.Compare(password, link.PasswordHash)
+		if err != nil {
+			return "", err
+		}
+		if !ok {
+			return entity.LinkAccessInvalidPassword, nil
+		}
+	}
+	return entity.LinkAccessDownloaded, nil
+}
+
+func outcomeError(outcome entity.LinkAccessOutcome) error {
+	switch outcome {
+	case entity.LinkAccessRevoked:
+		return constant.ErrShareLinkRevoked
+	case entity.LinkAccessExpired:
+		return constant.ErrShareLinkExpired
+	case entity.LinkAccessInvalidPassword:
+		return constant.ErrShareLinkPassword
+	case entity.LinkAccessLimitReached:
+		return constant.ErrDownloadLimitReached
+	default:
+		return nil
+	}
+}
+
+func newLinkToken() (string, error) {
+	b := make([]byte, linkTokenSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashLinkToken hashes a token for storage. Tokens are random and long, so a
+// fast unsalted hash is enough and lets links be looked up by token.
+func hashLinkToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
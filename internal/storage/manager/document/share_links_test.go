@@ -0,0 +1,191 @@
+package document
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/a1y/doc-formatter/internal/storage/domain/constant"
+	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
+	"github.com/a1y/doc-formatter/internal/storage/infra/persistence"
+	"github.com/a1y/doc-formatter/pkg/throttle"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocumentManager_ShareLinks(t *testing.T) {
+	t.Parallel()
+
+	manager, _, _, db := newTrashTestManager(t)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	created, err := manager.UploadDocument(ctx, &entity.Document{UserID: userID, FileName: "offer.pdf"}, bytes.NewReader([]byte("offer")))
+	require.NoError(t, err)
+
+	link, token, err := manager.CreateShareLink(ctx, userID, created.ID, ShareLinkOptions{Password: "s3cret", MaxDownloads: 2})
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+	require.NotEqual(t, token, link.TokenHash)
+	require.True(t, link.HasPassword())
+
+	_, _, err = manager.DownloadSharedDocument(ctx, LinkDownload{Token: token})
+	require.ErrorIs(t, err, constant.ErrShareLinkPassword)
+	_, _, err = manager.DownloadSharedDocument(ctx, LinkDownload{Token: token, Password: "wrong"})
+	require.ErrorIs(t, err, constant.ErrShareLinkPassword)
+
+	for range 2 {
+		document, reader, err := manager.DownloadSharedDocument(ctx, LinkDownload{Token: token, Password: "s3cret", RemoteAddr: "192.0.2.1", UserAgent: "curl"})
+		require.NoError(t, err)
+		require.Equal(t, "offer.pdf", document.FileName)
+		require.Equal(t, []byte("offer"), readAllAndClose(t, reader))
+	}
+	_, _, err = manager.DownloadSharedDocument(ctx, LinkDownload{Token: token, Password: "s3cret"})
+	require.ErrorIs(t, err, constant.ErrDownloadLimitReached)
+
+	var outcomes []string
+	require.NoError(t, db.Model(&persistence.ShareLinkAccessModel{}).Order("created_at").Pluck("outcome", &outcomes).Error)
+	require.Equal(t, []string{"invalid_password", "invalid_password", "downloaded", "downloaded", "limit_reached"}, outcomes)
+
+	_, _, err = manager.DownloadSharedDocument(ctx, LinkDownload{Token: "unknown"})
+	require.ErrorIs(t, err, constant.ErrShareLinkNotFound)
+
+	// Only the owner manages the links of a document.
+	_, _, err = manager.CreateShareLink(ctx, uuid.New(), created.ID, ShareLinkOptions{})
+	require.ErrorIs(t, err, constant.ErrDocumentNotFound)
+	_, err = manager.RevokeShareLink(ctx, uuid.New(), link.ID)
+	require.ErrorIs(t, err, constant.ErrShareLinkNotFound)
+
+	open, openToken, err := manager.CreateShareLink(ctx, userID, created.ID, ShareLinkOptions{})
+	require.NoError(t, err)
+	links, err := manager.ListShareLinks(ctx, userID, created.ID)
+	require.NoError(t, err)
+	require.Len(t, links, 2)
+	require.Equal(t, open.ID, links[0].ID)
+
+	_, reader, err := manager.DownloadSharedDocument(ctx, LinkDownload{Token: openToken})
+	require.NoError(t, err)
+	require.NoError(t, reader.Close())
+
+	revoked, err := manager.RevokeShareLink(ctx, userID, open.ID)
+	require.NoError(t, err)
+	require.NotNil(t, revoked.RevokedAt)
+	_, _, err = manager.DownloadSharedDocument(ctx, LinkDownload{Token: openToken})
+	require.ErrorIs(t, err, constant.ErrShareLinkRevoked)
+
+	// Links stop working while the document is in the trash and are deleted
+	// with it.
+	_, trashToken, err := manager.CreateShareLink(ctx, userID, created.ID, ShareLinkOptions{})
+	require.NoError(t, err)
+	_, err = manager.TrashDocument(ctx, userID, created.ID)
+	require.NoError(t, err)
+	_, _, err = manager.DownloadSharedDocument(ctx, LinkDownload{Token: trashToken})
+	require.ErrorIs(t, err, constant.ErrShareLinkNotFound)
+	_, err = manager.EmptyTrash(ctx, userID)
+	require.NoError(t, err)
+	var remaining int64
+	require.NoError(t, db.Model(&persistence.ShareLinkModel{}).Count(&remaining).Error)
+	require.Zero(t, remaining)
+}
+
+func TestDocumentManager_ShareLinks_Throttled(t *testing.T) {
+	t.Parallel()
+
+	manager, _, _, db := newTrashTestManager(t)
+	manager.SetLinkThrottle(throttle.NewMemoryStore(), LinkThrottleConfig{MaxLinkFailures: 3, MaxIPFailures: 5, Lockout: time.Minute})
+	ctx := context.Background()
+	userID := uuid.New()
+
+	created, err := manager.UploadDocument(ctx, &entity.Document{UserID: userID, FileName: "offer.pdf"}, bytes.NewReader([]byte("offer")))
+	require.NoError(t, err)
+	_, token, err := manager.CreateShareLink(ctx, userID, created.ID, ShareLinkOptions{Password: "s3cret"})
+	require.NoError(t, err)
+	_, otherToken, err := manager.CreateShareLink(ctx, userID, created.ID, ShareLinkOptions{Password: "other"})
+	require.NoError(t, err)
+	_, thirdToken, err := manager.CreateShareLink(ctx, userID, created.ID, ShareLinkOptions{Password: "third"})
+	require.NoError(t, err)
+
+	for _, addr := range []string{"192.0.2.1", "192.0.2.2", "192.0.2.3"} {
+		_, _, err := manager.DownloadSharedDocument(ctx, LinkDownload{Token: token, Password: "guess", RemoteAddr: addr})
+		require.ErrorIs(t, err, constant.ErrShareLinkPassword)
+	}
+
+	// Even the right password is refused while the link is locked, from any
+	// address.
+	_, _, err = manager.DownloadSharedDocument(ctx, LinkDownload{Token: token, Password: "s3cret", RemoteAddr: "198.51.100.7"})
+	var lockedErr *throttle.LockedError
+	require.ErrorAs(t, err, &lockedErr)
+	require.InDelta(t, time.Minute, lockedErr.RetryAfter, float64(time.Second))
+
+	var outcomes []string
+	require.NoError(t, db.Model(&persistence.ShareLinkAccessModel{}).Order("created_at").Pluck("outcome", &outcomes).Error)
+	require.Equal(t, []string{"invalid_password", "invalid_password", "invalid_password", "throttled"}, outcomes)
+
+	// An address guessing at several links is locked too, even when no link
+	// is.
+	for _, guessed := range []string{otherToken, thirdToken, otherToken, thirdToken} {
+		_, _, err := manager.DownloadSharedDocument(ctx, LinkDownload{Token: guessed, Password: "guess", RemoteAddr: "192.0.2.1"})
+		require.ErrorIs(t, err, constant.ErrShareLinkPassword)
+	}
+	_, _, err = manager.DownloadSharedDocument(ctx, LinkDownload{Token: otherToken, Password: "other", RemoteAddr: "192.0.2.1"})
+	require.ErrorAs(t, err, &lockedErr)
+	_, reader, err := manager.DownloadSharedDocument(ctx, LinkDownload{Token: otherToken, Password: "other", RemoteAddr: "192.0.2.9"})
+	require.NoError(t, err)
+	require.NoError(t, reader.Close())
+}
+
+func TestDocumentManager_ShareLinks_Expiry(t *testing.T) {
+	t.Parallel()
+
+	manager, _, _, db := newTrashTestManager(t)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	created, err := manager.UploadDocument(ctx, &entity.Document{UserID: userID, FileName: "offer.pdf"}, bytes.NewReader([]byte("offer")))
+	require.NoError(t, err)
+
+	past := time.Now().Add(-time.Minute)
+	_, _, err = manager.CreateShareLink(ctx, userID, created.ID, ShareLinkOptions{ExpiresAt: &past})
+	require.ErrorIs(t, err, constant.ErrInvalidShareLink)
+	_, _, err = manager.CreateShareLink(ctx, userID, created.ID, ShareLinkOptions{MaxDownloads: -1})
+	require.ErrorIs(t, err, constant.ErrInvalidShareLink)
+
+	soon := time.Now().Add(time.Hour)
+	link, token, err := manager.CreateShareLink(ctx, userID, created.ID, ShareLinkOptions{ExpiresAt: &soon})
+	require.NoError(t, err)
+	_, reader, err := manager.DownloadSharedDocument(ctx, LinkDownload{Token: token})
+	require.NoError(t, err)
+	require.NoError(t, reader.Close())
+
+	// Move the expiry into the past instead of waiting for it.
+	require.NoError(t, db.Model(&persistence.ShareLinkModel{}).Where("id = ?", link.ID).Update("expires_at", past).Error)
+	_, _, err = manager.DownloadSharedDocument(ctx, LinkDownload{Token: token})
+	require.ErrorIs(t, err, constant.ErrShareLinkExpired)
+}
+
+func TestDocumentManager_ShareLinks_ContentMissing(t *testing.T) {
+	t.Parallel()
+
+	manager, _, store, db := newTrashTestManager(t)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	created, err := manager.UploadDocument(ctx, &entity.Document{UserID: userID, FileName: "offer.pdf"}, bytes.NewReader([]byte("offer")))
+	require.NoError(t, err)
+	link, token, err := manager.CreateShareLink(ctx, userID, created.ID, ShareLinkOptions{MaxDownloads: 1})
+	require.NoError(t, err)
+
+	// A download that cannot be served leaves the link as it was.
+	_, err = store.DeleteObject(ctx, created.ObjectKey)
+	require.NoError(t, err)
+	_, _, err = manager.DownloadSharedDocument(ctx, LinkDownload{Token: token})
+	require.Error(t, err)
+
+	var stored persistence.ShareLinkModel
+	require.NoError(t, db.First(&stored, "id = ?", link.ID).Error)
+	require.Zero(t, stored.DownloadCount)
+	var accesses int64
+	require.NoError(t, db.Model(&persistence.ShareLinkAccessModel{}).Count(&accesses).Error)
+	require.Zero(t, accesses)
+}
@@ -0,0 +1,148 @@
+package document
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/a1y/doc-formatter/internal/storage/domain/constant"
+	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
+	"github.com/a1y/doc-formatter/internal/storage/util/stamp"
+	"github.com/google/uuid"
+)
+
+// SetShareLinkStamp makes every DOCX and PDF document downloaded through a
+// share link carry s. Documents of other formats are downloaded as they
+// are. An empty stamp, the default, leaves downloads unchanged.
+func (m *DocumentManager) SetShareLinkStamp(s stamp.Stamp) error {
+	if err := s.Validate(); err != nil {
+		return err
+	}
+	m.linkStamp = s
+	return nil
+}
+
+// SetUserDirectory makes stamps name the owner of documents through users.
+// Without a directory, owners other than the user are shown by id.
+func (m *DocumentManager) SetUserDirectory(users UserDirectory) {
+	m.users = users
+}
+
+// StampDocument returns a copy of a DOCX or PDF document userID can view,
+// with s drawn on every page. The placeholders of s are filled for the
+// document, user naming userID, as shown on the copy.
+func (m *DocumentManager) StampDocument(ctx context.Context, userID, documentID uuid.UUID, s stamp.Stamp, user string) (*entity.Document, []byte, error) {
+	if err := s.Validate(); err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", constant.ErrInvalidStamp, err)
+	}
+	if s.Empty() {
+		return nil, nil, fmt.Errorf("%w: a watermark, image, header or footer is required", constant.ErrInvalidStamp)
+	}
+	document, err := m.getDocument(ctx, userID, documentID, entity.RoleViewer)
+	if err != nil {
+		return nil, nil, err
+	}
+	owner := user
+	if document.UserID != userID {
+		if owner, err = m.ownerLabel(ctx, document); err != nil {
+			return nil, nil, err
+		}
+	}
+	return m.stampDocument(ctx, document, s.Expand(stampValues(document, owner, user)))
+}
+
+// stampDocument reads document and returns a copy of it carrying s, whose
+// placeholders are filled.
+func (m *DocumentManager) stampDocument(ctx context.Context, document *entity.Document, s stamp.Stamp) (*entity.Document, []byte, error) {
+	if !stamp.Supported(document.ContentType) {
+		return nil, nil, fmt.Errorf("%w: %s", constant.ErrUnsupportedFormat, document.ContentType)
+	}
+	content, err := m.openContent(ctx, document)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer content.Close()
+
+	data, err := io.ReadAll(io.LimitReader(content, stamp.MaxSize+1))
+	if err != nil {
+		return nil, nil, err
+	}
+	stamped, err := stamp.Apply(document.ContentType, data, s)
+	if err != nil {
+		return nil, nil, stampError(err)
+	}
+	copied := *document
+	copied.FileSize = int64(len(stamped))
+	return &copied, stamped, nil
+}
+
+// stampLinkDownload returns the content of document downloaded through
+// link, carrying the stamp of share-link downloads when one is set.
+func (m *DocumentManager) stampLinkDownload(ctx context.Context, link *entity.ShareLink, document *entity.Document) (*entity.Document, io.ReadCloser, error) {
+	if m.linkStamp.Empty() || !stamp.Supported(document.ContentType) {
+		content, err := m.openContent(ctx, document)
+		if err != nil {
+			return nil, nil, err
+		}
+		return document, content, nil
+	}
+	owner, err := m.ownerLabel(ctx, document)
+	if err != nil {
+		return nil, nil, err
+	}
+	// The person holding the link is unknown, so the link stands for them.
+	values := stampValues(document, owner, "link "+link.ID.String())
+	stamped, data, err := m.stampDocument(ctx, document, m.linkStamp.Expand(values))
+	if err != nil {
+		return nil, nil, err
+	}
+	return stamped, io.NopCloser(bytes.NewReader(data)), nil
+}
+
+// ownerLabel returns the name of the owner of document shown on stamps.
+func (m *DocumentManager) ownerLabel(ctx context.Context, document *entity.Document) (string, error) {
+	if m.users == nil {
+		return document.UserID.String(), nil
+	}
+	label, err := m.users.UserLabel(ctx, document.UserID)
+	if err != nil {
+		return "", fmt.Errorf("failed to look up the owner of document %s: %w", document.ID, err)
+	}
+	return label, nil
+}
+
+// stampValues returns the values of the placeholders of a stamp on
+// document. Its version is the object holding its content, which changes
+// with every upload.
+func stampValues(document *entity.Document, owner, user string) stamp.Values {
+	version := document.ObjectKey
+	if parts := strings.SplitN(document.ObjectKey, "/", 3); len(parts) == 3 {
+		version = parts[1]
+	}
+	return stamp.Values{
+		Name:    document.FileName,
+		Owner:   owner,
+		User:    user,
+		Version: version,
+		Date:    time.Now(),
+	}
+}
+
+// stampError maps the errors of stamp to those of the service.
+func stampError(err error) error {
+	switch {
+	case errors.Is(err, stamp.ErrUnsupportedFormat):
+		return fmt.Errorf("%w: %v", constant.ErrUnsupportedFormat, err)
+	case errors.Is(err, stamp.ErrInvalidStamp):
+		return fmt.Errorf("%w: %v", constant.ErrInvalidStamp, err)
+	case errors.Is(err, stamp.ErrEncryptedDocument):
+		return fmt.Errorf("%w: %v", constant.ErrEncryptedDocument, err)
+	case errors.Is(err, stamp.ErrMalformedDocument), errors.Is(err, stamp.ErrDocumentTooLarge):
+		return fmt.Errorf("%w: %v", constant.ErrMalformedDocument, err)
+	}
+	return err
+}
@@ -0,0 +1,128 @@
+package document
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/a1y/doc-formatter/internal/storage/domain/constant"
+	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
+	"github.com/a1y/doc-formatter/internal/storage/infra/persistence"
+	"github.com/a1y/doc-formatter/internal/storage/util/stamp"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// testStampPDF is a one-page PDF document without a cross-reference table,
+// which is rebuilt when it is read.
+const testStampPDF = "%PDF-1.4\n" +
+	"1 0 obj\n<< /Type /Catalog /Pages 2 0 R >>\nendobj\n" +
+	"2 0 obj\n<< /Type /Pages /Kids [3 0 R] /Count 1 >>\nendobj\n" +
+	"3 0 obj\n<< /Type /Page /Parent 2 0 R /MediaBox [0 0 200 200] >>\nendobj\n" +
+	"trailer\n<< /Root 1 0 R >>\n%%EOF\n"
+
+// pdfText returns text as the stamp writes it in PDF content.
+func pdfText(text string) []byte {
+	return []byte("<" + hex.EncodeToString([]byte(text)) + ">")
+}
+
+// fakeUserDirectory names users after the entries of a map.
+type fakeUserDirectory map[uuid.UUID]string
+
+func (d fakeUserDirectory) UserLabel(ctx context.Context, userID uuid.UUID) (string, error) {
+	label, ok := d[userID]
+	if !ok {
+		return "", errors.New("user not found")
+	}
+	return label, nil
+}
+
+func TestDocumentManager_StampDocument(t *testing.T) {
+	t.Parallel()
+
+	manager, db := newMergeTestManager(t)
+	ctx := context.Background()
+	ownerID, userID := uuid.New(), uuid.New()
+
+	document, err := manager.UploadDocument(ctx, &entity.Document{UserID: ownerID, FileName: "offer.pdf"}, bytes.NewReader([]byte(testStampPDF)))
+	require.NoError(t, err)
+
+	stamped, data, err := manager.StampDocument(ctx, ownerID, document.ID, stamp.Stamp{Header: "{name} of {owner} for {user}"}, "owner@example.com")
+	require.NoError(t, err)
+	require.Equal(t, "offer.pdf", stamped.FileName)
+	require.Equal(t, int64(len(data)), stamped.FileSize)
+	require.True(t, bytes.HasPrefix(data, []byte(testStampPDF)))
+	require.True(t, bytes.Contains(data, pdfText("offer.pdf of owner@example.com for owner@example.com")))
+
+	_, _, err = manager.StampDocument(ctx, userID, document.ID, stamp.Stamp{Watermark: "DRAFT"}, "user@example.com")
+	require.ErrorIs(t, err, constant.ErrDocumentNotFound)
+	require.NoError(t, persistence.NewACLRepository(db).Upsert(ctx, &entity.ACLEntry{
+		ResourceType: entity.ResourceDocument,
+		ResourceID:   document.ID,
+		OwnerID:      ownerID,
+		UserID:       userID,
+		Role:         entity.RoleViewer,
+	}))
+	// Without a directory, others see the owner by id.
+	_, data, err = manager.StampDocument(ctx, userID, document.ID, stamp.Stamp{Footer: "{owner} {user}"}, "user@example.com")
+	require.NoError(t, err)
+	require.True(t, bytes.Contains(data, pdfText(ownerID.String()+" user@example.com")))
+
+	manager.SetUserDirectory(fakeUserDirectory{ownerID: "owner@example.com"})
+	_, data, err = manager.StampDocument(ctx, userID, document.ID, stamp.Stamp{Footer: "{owner} {user}"}, "user@example.com")
+	require.NoError(t, err)
+	require.True(t, bytes.Contains(data, pdfText("owner@example.com user@example.com")))
+
+	manager.SetUserDirectory(fakeUserDirectory{})
+	_, _, err = manager.StampDocument(ctx, userID, document.ID, stamp.Stamp{Footer: "{owner} {user}"}, "user@example.com")
+	require.Error(t, err)
+
+	_, _, err = manager.StampDocument(ctx, ownerID, document.ID, stamp.Stamp{}, "owner@example.com")
+	require.ErrorIs(t, err, constant.ErrInvalidStamp)
+	_, _, err = manager.StampDocument(ctx, ownerID, document.ID, stamp.Stamp{Watermark: "DRAFT", Opacity: 2}, "owner@example.com")
+	require.ErrorIs(t, err, constant.ErrInvalidStamp)
+
+	notes, err := manager.UploadDocument(ctx, &entity.Document{UserID: ownerID, FileName: "notes.md"}, bytes.NewReader([]byte("# Notes\n")))
+	require.NoError(t, err)
+	_, _, err = manager.StampDocument(ctx, ownerID, notes.ID, stamp.Stamp{Watermark: "DRAFT"}, "owner@example.com")
+	require.ErrorIs(t, err, constant.ErrUnsupportedFormat)
+
+	broken, err := manager.UploadDocument(ctx, &entity.Document{UserID: ownerID, FileName: "broken.pdf"}, bytes.NewReader([]byte("%PDF-1.4\n")))
+	require.NoError(t, err)
+	_, _, err = manager.StampDocument(ctx, ownerID, broken.ID, stamp.Stamp{Watermark: "DRAFT"}, "owner@example.com")
+	require.ErrorIs(t, err, constant.ErrMalformedDocument)
+}
+
+func TestDocumentManager_ShareLinkStamp(t *testing.T) {
+	t.Parallel()
+
+	manager, _, _, _ := newTrashTestManager(t)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	require.ErrorIs(t, manager.SetShareLinkStamp(stamp.Stamp{Opacity: -1}), stamp.ErrInvalidStamp)
+	require.NoError(t, manager.SetShareLinkStamp(stamp.Stamp{Watermark: "Shared by {owner} as {user}"}))
+	manager.SetUserDirectory(fakeUserDirectory{userID: "owner@example.com"})
+
+	document, err := manager.UploadDocument(ctx, &entity.Document{UserID: userID, FileName: "offer.pdf"}, bytes.NewReader([]byte(testStampPDF)))
+	require.NoError(t, err)
+	link, token, err := manager.CreateShareLink(ctx, userID, document.ID, ShareLinkOptions{})
+	require.NoError(t, err)
+
+	downloaded, reader, err := manager.DownloadSharedDocument(ctx, LinkDownload{Token: token})
+	require.NoError(t, err)
+	data := readAllAndClose(t, reader)
+	require.Equal(t, int64(len(data)), downloaded.FileSize)
+	require.True(t, bytes.Contains(data, pdfText("Shared by owner@example.com as link "+link.ID.String())))
+
+	// Formats that cannot be stamped are downloaded as they are.
+	notes, err := manager.UploadDocument(ctx, &entity.Document{UserID: userID, FileName: "notes.md"}, bytes.NewReader([]byte("# Notes\n")))
+	require.NoError(t, err)
+	_, token, err = manager.CreateShareLink(ctx, userID, notes.ID, ShareLinkOptions{})
+	require.NoError(t, err)
+	_, reader, err = manager.DownloadSharedDocument(ctx, LinkDownload{Token: token})
+	require.NoError(t, err)
+	require.Equal(t, []byte("# Notes\n"), readAllAndClose(t, reader))
+}
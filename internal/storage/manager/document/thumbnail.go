@@ -0,0 +1,146 @@
+package document
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"runtime/debug"
+
+	"github.com/a1y/doc-formatter/internal/storage/domain/constant"
+	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
+	"github.com/a1y/doc-formatter/internal/storage/util/objectstore"
+	"github.com/a1y/doc-formatter/internal/storage/util/thumbnail"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// ThumbnailQueueSize is the number of uploaded documents that can wait for
+// their thumbnails to be rendered. The thumbnails of documents uploaded
+// while the queue is full are rendered on first request instead.
+const ThumbnailQueueSize = 100
+
+// thumbnailSuffix is appended to the object key of a document to name the
+// object holding its thumbnail. Every version of a document is stored under
+// its own object key, so a new version never shows the thumbnail of the
+// previous one.
+const thumbnailSuffix = ".thumbnail.png"
+
+func thumbnailKey(document *entity.Document) string {
+	return document.ObjectKey + thumbnailSuffix
+}
+
+// GetThumbnail returns the PNG thumbnail of the first page of a document
+// userID can view, as stored when it was rendered after its upload. Documents
+// stored without one, such as those uploaded before thumbnails were
+// introduced, get theirs rendered on first request.
+func (m *DocumentManager) GetThumbnail(ctx context.Context, userID, documentID uuid.UUID) ([]byte, error) {
+	document, err := m.getDocument(ctx, userID, documentID, entity.RoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := m.readThumbnail(ctx, document)
+	if !errors.Is(err, objectstore.ErrObjectNotFound) {
+		return stored, err
+	}
+	return m.generateThumbnail(ctx, document)
+}
+
+// queueThumbnail queues document, just uploaded, for its thumbnail to be
+// rendered by RenderThumbnails, so that the upload does not wait for it.
+func (m *DocumentManager) queueThumbnail(document *entity.Document) {
+	if !thumbnail.Supported(document.ContentType) {
+		return
+	}
+	// The caller keeps the document it was given, so the queue gets a copy.
+	queued := *document
+	select {
+	case m.thumbnails <- &queued:
+	default:
+		logrus.Debugf("Thumbnail queue is full, the thumbnail of document %s is rendered on first request", document.ID)
+	}
+}
+
+// RenderThumbnails renders the thumbnails of uploaded documents as they are
+// queued, until ctx is done.
+func (m *DocumentManager) RenderThumbnails(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case document := <-m.thumbnails:
+			m.renderQueuedThumbnail(ctx, document)
+		}
+	}
+}
+
+// renderQueuedThumbnail renders the thumbnail of document. Failures are
+// logged only, the thumbnail is rendered again on first request.
+func (m *DocumentManager) renderQueuedThumbnail(ctx context.Context, document *entity.Document) {
+	// Documents come from users, so one that trips up the renderer must not
+	// stop the thumbnails of the others.
+	defer func() {
+		if r := recover(); r != nil {
+			logrus.Errorf("Panic while rendering the thumbnail of document %s: %v\n%s", document.ID, r, debug.Stack())
+		}
+	}()
+	if _, err := m.generateThumbnail(ctx, document); err != nil {
+		logrus.Warnf("Failed to render the thumbnail of document %s: %v", document.ID, err)
+	}
+}
+
+// readThumbnail returns the stored thumbnail of document.
+func (m *DocumentManager) readThumbnail(ctx context.Context, document *entity.Document) ([]byte, error) {
+	object, err := m.objectStore.GetObject(ctx, thumbnailKey(document))
+	if err != nil {
+		return nil, err
+	}
+	if document.IsEncrypted() {
+		plaintext, err := m.decryptDerived(document, object)
+		if err != nil {
+			_ = object.Close()
+			return nil, err
+		}
+		object = plaintext
+	}
+	defer object.Close()
+	return io.ReadAll(object)
+}
+
+// generateThumbnail renders the thumbnail of document and stores it next to
+// its content.
+func (m *DocumentManager) generateThumbnail(ctx context.Context, document *entity.Document) ([]byte, error) {
+	if !thumbnail.Supported(document.ContentType) {
+		return nil, fmt.Errorf("%w: %s", constant.ErrUnsupportedFormat, document.ContentType)
+	}
+	content, err := m.openContent(ctx, document)
+	if err != nil {
+		return nil, err
+	}
+	defer content.Close()
+
+	image, err := thumbnail.Render(document.ContentType, content)
+	if errors.Is(err, thumbnail.ErrMalformedDocument) || errors.Is(err, thumbnail.ErrDocumentTooLarge) {
+		return nil, fmt.Errorf("%w: %v", constant.ErrMalformedDocument, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var object io.Reader = bytes.NewReader(image)
+	if document.IsEncrypted() {
+		if object, err = m.encryptDerived(document, object); err != nil {
+			return nil, err
+		}
+	}
+	ok, err := m.objectStore.PutObject(ctx, thumbnailKey(document), object)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.New("failed to store thumbnail")
+	}
+	return image, nil
+}
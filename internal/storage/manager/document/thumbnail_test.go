@@ -0,0 +1,131 @@
+package document
+
+import (
+	"bytes"
+	"context"
+	"image/png"
+	"testing"
+	"time"
+
+	"github.com/a1y/doc-formatter/internal/storage/domain/constant"
+	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
+	"github.com/a1y/doc-formatter/internal/storage/util/objectstore/memory"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// renderQueuedThumbnails renders the thumbnails queued so far, as
+// RenderThumbnails does in the background.
+func renderQueuedThumbnails(ctx context.Context, m *DocumentManager) {
+	for {
+		select {
+		case document := <-m.thumbnails:
+			m.renderQueuedThumbnail(ctx, document)
+		default:
+			return
+		}
+	}
+}
+
+func TestDocumentManager_GetThumbnail(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store := memory.NewMemoryStorage()
+	manager := NewDocumentManager(newFakeDocumentRepository(), nil, nil, store)
+	userID := uuid.New()
+
+	doc, err := manager.UploadDocument(ctx, &entity.Document{UserID: userID, FileName: "notes.md"}, bytes.NewReader([]byte("# Notes\n\nBuy milk.")))
+	require.NoError(t, err)
+	// The thumbnail is rendered after the upload, off the request path.
+	_, err = store.HeadObject(ctx, thumbnailKey(doc))
+	require.Error(t, err)
+	renderQueuedThumbnails(ctx, manager)
+	_, err = store.HeadObject(ctx, thumbnailKey(doc))
+	require.NoError(t, err)
+
+	image, err := manager.GetThumbnail(ctx, userID, doc.ID)
+	require.NoError(t, err)
+	_, err = png.Decode(bytes.NewReader(image))
+	require.NoError(t, err)
+
+	// A missing thumbnail is rendered again.
+	_, err = store.DeleteObject(ctx, thumbnailKey(doc))
+	require.NoError(t, err)
+	rendered, err := manager.GetThumbnail(ctx, userID, doc.ID)
+	require.NoError(t, err)
+	require.Equal(t, image, rendered)
+	_, err = store.HeadObject(ctx, thumbnailKey(doc))
+	require.NoError(t, err)
+
+	_, err = manager.GetThumbnail(ctx, uuid.New(), doc.ID)
+	require.ErrorIs(t, err, constant.ErrDocumentNotFound)
+
+	pdf, err := manager.UploadDocument(ctx, &entity.Document{UserID: userID, FileName: "scan.pdf"}, bytes.NewReader([]byte("%PDF")))
+	require.NoError(t, err)
+	_, err = manager.GetThumbnail(ctx, userID, pdf.ID)
+	require.ErrorIs(t, err, constant.ErrUnsupportedFormat)
+
+	// Uploads of documents that cannot be rendered still succeed.
+	broken, err := manager.UploadDocument(ctx, &entity.Document{UserID: userID, FileName: "broken.docx"}, bytes.NewReader([]byte("not a zip")))
+	require.NoError(t, err)
+	renderQueuedThumbnails(ctx, manager)
+	_, err = manager.GetThumbnail(ctx, userID, broken.ID)
+	require.ErrorIs(t, err, constant.ErrMalformedDocument)
+}
+
+func TestDocumentManager_GetThumbnail_Encrypted(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store := memory.NewMemoryStorage()
+	manager := NewDocumentManager(newFakeDocumentRepository(), nil, nil, store)
+	manager.SetKeyring(newTestKeyring(t, "k1", "k1"))
+	userID := uuid.New()
+
+	doc, err := manager.UploadDocument(ctx, &entity.Document{UserID: userID, FileName: "secret.txt"}, bytes.NewReader([]byte("launch codes")))
+	require.NoError(t, err)
+	require.True(t, doc.IsEncrypted())
+	renderQueuedThumbnails(ctx, manager)
+
+	image, err := manager.GetThumbnail(ctx, userID, doc.ID)
+	require.NoError(t, err)
+	_, err = png.Decode(bytes.NewReader(image))
+	require.NoError(t, err)
+
+	stored, err := store.GetObject(ctx, thumbnailKey(doc))
+	require.NoError(t, err)
+	_, err = png.Decode(stored)
+	require.Error(t, err, "the stored thumbnail must be encrypted")
+
+	// The stored thumbnail is decrypted when read back.
+	cached, err := manager.GetThumbnail(ctx, userID, doc.ID)
+	require.NoError(t, err)
+	require.Equal(t, image, cached)
+}
+
+func TestDocumentManager_RenderThumbnails(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := memory.NewMemoryStorage()
+	manager := NewDocumentManager(newFakeDocumentRepository(), nil, nil, store)
+	go manager.RenderThumbnails(ctx)
+
+	doc, err := manager.UploadDocument(ctx, &entity.Document{UserID: uuid.New(), FileName: "notes.md"}, bytes.NewReader([]byte("# Notes")))
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		_, err := store.HeadObject(ctx, thumbnailKey(doc))
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Uploads do not wait for a full queue, the documents left out get their
+	// thumbnails on first request.
+	idle := NewDocumentManager(newFakeDocumentRepository(), nil, nil, store)
+	for range ThumbnailQueueSize + 1 {
+		_, err := idle.UploadDocument(ctx, &entity.Document{UserID: uuid.New(), FileName: "notes.md"}, bytes.NewReader([]byte("# Notes")))
+		require.NoError(t, err)
+	}
+	require.Len(t, idle.thumbnails, ThumbnailQueueSize)
+}
@@ -0,0 +1,153 @@
+package document
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/a1y/doc-formatter/internal/storage/domain/constant"
+	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// PurgeBatchSize is the number of documents loaded per query while purging
+// the trash.
+const PurgeBatchSize = 100
+
+// TrashDocument moves a document owned by userID into the trash. Its content
+// is kept until the trash is emptied or the document expires.
+func (m *DocumentManager) TrashDocument(ctx context.Context, userID, documentID uuid.UUID) (*entity.Document, error) {
+	document, err := m.getDocument(ctx, userID, documentID, entity.RoleOwner)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.documentRepo.Delete(ctx, document.ID); err != nil {
+		return nil, err
+	}
+	return m.documentRepo.GetTrashedByID(ctx, document.ID)
+}
+
+// ListTrash returns a page of the trash of userID, most recently deleted
+// first, along with the token of the next page.
+func (m *DocumentManager) ListTrash(ctx context.Context, userID uuid.UUID, size int, token string) ([]*entity.Document, string, error) {
+	page, err := newPage(PageRequest{Size: size, Token: token, Sort: entity.SortByDeleted, Descending: true})
+	if err != nil {
+		return nil, "", err
+	}
+	return listPage(page, func(page *entity.DocumentPage) ([]*entity.Document, error) {
+		return m.documentRepo.ListTrash(ctx, userID, page)
+	})
+}
+
+// RestoreDocument takes a document owned by userID out of the trash, back
+// into its folder or to the top level when the folder was deleted since.
+func (m *DocumentManager) RestoreDocument(ctx context.Context, userID, documentID uuid.UUID) (*entity.Document, error) {
+	document, err := m.documentRepo.GetTrashedByID(ctx, documentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constant.ErrDocumentNotFound
+		}
+		return nil, err
+	}
+	if document.UserID != userID {
+		return nil, constant.ErrDocumentNotFound
+	}
+
+	folderID := document.FolderID
+	if _, err := m.checkFolder(ctx, userID, folderID, entity.RoleOwner); err != nil {
+		if !errors.Is(err, constant.ErrFolderNotFound) {
+			return nil, err
+		}
+		folderID = nil
+	}
+	if err := m.documentRepo.Restore(ctx, document.ID, folderID); err != nil {
+		return nil, err
+	}
+	return m.documentRepo.GetByID(ctx, document.ID)
+}
+
+// EmptyTrash deletes every document of the trash of userID for good, along
+// with its content. It returns the number of documents deleted.
+func (m *DocumentManager) EmptyTrash(ctx context.Context, userID uuid.UUID) (int, error) {
+	purged := 0
+	for {
+		documents, err := m.documentRepo.ListTrash(ctx, userID, &entity.DocumentPage{
+			Sort: entity.SortByDeleted,
+			Size: PurgeBatchSize,
+		})
+		if err != nil {
+			return purged, err
+		}
+		if len(documents) == 0 {
+			return purged, nil
+		}
+		if err := m.purge(ctx, documents); err != nil {
+			return purged, err
+		}
+		purged += len(documents)
+	}
+}
+
+// PurgeTrash deletes the documents of every user moved into the trash before
+// deletedBefore for good, along with their content. A document whose content
+// cannot be deleted is logged and left in the trash for the next purge, so
+// that it does not hold back the others. It returns the number of documents
+// deleted.
+func (m *DocumentManager) PurgeTrash(ctx context.Context, deletedBefore time.Time) (int, error) {
+	purged := 0
+	var afterID uuid.UUID
+	for {
+		documents, err := m.documentRepo.ListTrashedBefore(ctx, deletedBefore, afterID, PurgeBatchSize)
+		if err != nil {
+			return purged, err
+		}
+		if len(documents) == 0 {
+			return purged, nil
+		}
+		afterID = documents[len(documents)-1].ID
+
+		ids := make([]uuid.UUID, 0, len(documents))
+		for _, document := range documents {
+			if err := m.deleteObjects(ctx, document); err != nil {
+				logrus.Warnf("Failed to delete content of document %s, leaving it in the trash: %v", document.ID, err)
+				continue
+			}
+			ids = append(ids, document.ID)
+		}
+		if err := m.documentRepo.Purge(ctx, ids); err != nil {
+			return purged, err
+		}
+		purged += len(ids)
+	}
+}
+
+// purge deletes the content of trashed documents, then the documents. A
+// document whose content cannot be deleted is left in the trash for a later
+// attempt.
+func (m *DocumentManager) purge(ctx context.Context, documents []*entity.Document) error {
+	ids := make([]uuid.UUID, 0, len(documents))
+	for _, document := range documents {
+		if err := m.deleteObjects(ctx, document); err != nil {
+			if purgeErr := m.documentRepo.Purge(ctx, ids); purgeErr != nil {
+				return purgeErr
+			}
+			return fmt.Errorf("failed to delete content of document %s: %w", document.ID, err)
+		}
+		ids = append(ids, document.ID)
+	}
+	return m.documentRepo.Purge(ctx, ids)
+}
+
+// deleteObjects deletes the content of document and the objects derived from
+// it. Objects already gone are deleted without error.
+func (m *DocumentManager) deleteObjects(ctx context.Context, document *entity.Document) error {
+	for _, key := range []string{document.ObjectKey, thumbnailKey(document)} {
+		if _, err := m.objectStore.DeleteObject(ctx, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,229 @@
+package document
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/a1y/doc-formatter/internal/storage/domain/constant"
+	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
+	"github.com/a1y/doc-formatter/internal/storage/domain/repository"
+	"github.com/a1y/doc-formatter/internal/storage/infra/persistence"
+	"github.com/a1y/doc-formatter/internal/storage/util/objectstore/memory"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func newTrashTestManager(t *testing.T) (*DocumentManager, repository.FolderRepository, *memory.MemoryStorage, *gorm.DB) {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, persistence.AutoMigrate(db))
+
+	folderRepo := persistence.NewFolderRepository(db)
+	store := memory.NewMemoryStorage()
+	manager := NewDocumentManager(persistence.NewDocumentRepository(db), folderRepo, nil, store)
+	manager.SetShareLinkRepository(persistence.NewShareLinkRepository(db))
+	manager.SetTextRepository(persistence.NewDocumentTextRepository(db))
+	manager.SetAnalysisRepository(persistence.NewDocumentAnalysisRepository(db))
+	manager.SetSourceRepository(persistence.NewDocumentSourceRepository(db))
+	manager.SetBatchJobRepository(persistence.NewBatchJobRepository(db))
+	return manager, folderRepo, store, db
+}
+
+func TestDocumentManager_Trash(t *testing.T) {
+	t.Parallel()
+
+	manager, folderRepo, store, _ := newTrashTestManager(t)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	folder := &entity.Folder{UserID: userID, Name: "Reports"}
+	require.NoError(t, folderRepo.Create(ctx, folder))
+
+	upload := func(name string, folderID *uuid.UUID) *entity.Document {
+		doc, err := manager.UploadDocument(ctx, &entity.Document{UserID: userID, FileName: name, FolderID: folderID},
+			bytes.NewReader([]byte(name)))
+		require.NoError(t, err)
+		return doc
+	}
+	inFolder := upload("q1.pdf", &folder.ID)
+	topLevel := upload("notes.txt", nil)
+
+	trashed, err := manager.TrashDocument(ctx, userID, inFolder.ID)
+	require.NoError(t, err)
+	require.NotNil(t, trashed.DeletedAt)
+	_, err = manager.TrashDocument(ctx, userID, inFolder.ID)
+	require.ErrorIs(t, err, constant.ErrDocumentNotFound)
+	_, err = manager.TrashDocument(ctx, uuid.New(), topLevel.ID)
+	require.ErrorIs(t, err, constant.ErrDocumentNotFound)
+	_, err = manager.TrashDocument(ctx, userID, topLevel.ID)
+	require.NoError(t, err)
+
+	// Trashed documents leave the listings but keep their content.
+	docs, _, err := manager.ListDocuments(ctx, userID, entity.DocumentFilter{}, PageRequest{})
+	require.NoError(t, err)
+	require.Empty(t, docs)
+	_, err = store.HeadObject(ctx, inFolder.ObjectKey)
+	require.NoError(t, err)
+
+	trash, next, err := manager.ListTrash(ctx, userID, 1, "")
+	require.NoError(t, err)
+	require.Len(t, trash, 1)
+	require.Equal(t, topLevel.ID, trash[0].ID)
+	require.NotEmpty(t, next)
+	trash, next, err = manager.ListTrash(ctx, userID, 1, next)
+	require.NoError(t, err)
+	require.Len(t, trash, 1)
+	require.Equal(t, inFolder.ID, trash[0].ID)
+	require.Empty(t, next)
+
+	_, _, err = manager.ListDocuments(ctx, userID, entity.DocumentFilter{}, PageRequest{Sort: entity.SortByDeleted})
+	require.ErrorIs(t, err, constant.ErrInvalidPage)
+
+	// Documents are restored into their folder while it exists.
+	restored, err := manager.RestoreDocument(ctx, userID, inFolder.ID)
+	require.NoError(t, err)
+	require.Nil(t, restored.DeletedAt)
+	require.Equal(t, &folder.ID, restored.FolderID)
+	_, err = manager.RestoreDocument(ctx, userID, inFolder.ID)
+	require.ErrorIs(t, err, constant.ErrDocumentNotFound)
+	_, err = manager.RestoreDocument(ctx, uuid.New(), topLevel.ID)
+	require.ErrorIs(t, err, constant.ErrDocumentNotFound)
+
+	_, err = manager.TrashDocument(ctx, userID, inFolder.ID)
+	require.NoError(t, err)
+	require.NoError(t, folderRepo.Delete(ctx, []uuid.UUID{folder.ID}))
+	restored, err = manager.RestoreDocument(ctx, userID, inFolder.ID)
+	require.NoError(t, err)
+	require.Nil(t, restored.FolderID)
+
+	purged, err := manager.EmptyTrash(ctx, userID)
+	require.NoError(t, err)
+	require.Equal(t, 1, purged)
+	_, err = store.HeadObject(ctx, topLevel.ObjectKey)
+	require.Error(t, err)
+	_, err = manager.RestoreDocument(ctx, userID, topLevel.ID)
+	require.ErrorIs(t, err, constant.ErrDocumentNotFound)
+
+	docs, _, err = manager.ListDocuments(ctx, userID, entity.DocumentFilter{}, PageRequest{})
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	require.Equal(t, inFolder.ID, docs[0].ID)
+}
+
+func TestDocumentManager_PurgeTrash(t *testing.T) {
+	t.Parallel()
+
+	manager, _, store, db := newTrashTestManager(t)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	doc, err := manager.UploadDocument(ctx, &entity.Document{UserID: userID, FileName: "old.md"}, bytes.NewReader([]byte("# old")))
+	require.NoError(t, err)
+	_, err = manager.IndexDocuments(ctx, "english")
+	require.NoError(t, err)
+	_, err = manager.AnalyzeDocument(ctx, userID, doc.ID)
+	require.NoError(t, err)
+	_, err = manager.GetThumbnail(ctx, userID, doc.ID)
+	require.NoError(t, err)
+	_, err = manager.TrashDocument(ctx, userID, doc.ID)
+	require.NoError(t, err)
+
+	purged, err := manager.PurgeTrash(ctx, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	require.Zero(t, purged)
+
+	locker := persistence.NewLocker(db)
+	purger := NewTrashPurger(manager, locker, time.Nanosecond, time.Hour)
+
+	// Nothing is purged while another purge holds the lock.
+	acquired, err := locker.TryLock(ctx, trashPurgeLock, func(ctx context.Context) error {
+		purged, err := purger.PurgeOnce(ctx)
+		require.Zero(t, purged)
+		return err
+	})
+	require.NoError(t, err)
+	require.True(t, acquired)
+	_, err = store.HeadObject(ctx, doc.ObjectKey)
+	require.NoError(t, err)
+	_, err = store.HeadObject(ctx, thumbnailKey(doc))
+	require.NoError(t, err)
+
+	purged, err = purger.PurgeOnce(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, purged)
+	_, err = store.HeadObject(ctx, doc.ObjectKey)
+	require.Error(t, err)
+	_, err = store.HeadObject(ctx, thumbnailKey(doc))
+	require.Error(t, err)
+
+	trash, _, err := manager.ListTrash(ctx, userID, 0, "")
+	require.NoError(t, err)
+	require.Empty(t, trash)
+	var texts int64
+	require.NoError(t, db.Model(&persistence.DocumentTextModel{}).Where("document_id = ?", doc.ID).Count(&texts).Error)
+	require.Zero(t, texts)
+	var analyses int64
+	require.NoError(t, db.Model(&persistence.DocumentAnalysisModel{}).Where("document_id = ?", doc.ID).Count(&analyses).Error)
+	require.Zero(t, analyses)
+}
+
+// undeletableStore fails to delete the object stored under key.
+type undeletableStore struct {
+	*memory.MemoryStorage
+	key string
+}
+
+func (s *undeletableStore) DeleteObject(ctx context.Context, objectKey string) (bool, error) {
+	if objectKey == s.key {
+		return false, errors.New("access denied")
+	}
+	return s.MemoryStorage.DeleteObject(ctx, objectKey)
+}
+
+func TestDocumentManager_PurgeTrash_ContentFailure(t *testing.T) {
+	t.Parallel()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, persistence.AutoMigrate(db))
+	store := &undeletableStore{MemoryStorage: memory.NewMemoryStorage()}
+	manager := NewDocumentManager(persistence.NewDocumentRepository(db), nil, persistence.NewACLRepository(db), store)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	var docs []*entity.Document
+	for _, name := range []string{"a.txt", "b.txt", "c.txt"} {
+		doc, err := manager.UploadDocument(ctx, &entity.Document{UserID: userID, FileName: name}, bytes.NewReader([]byte(name)))
+		require.NoError(t, err)
+		_, err = manager.TrashDocument(ctx, userID, doc.ID)
+		require.NoError(t, err)
+		docs = append(docs, doc)
+	}
+	stuck := docs[0]
+	store.key = stuck.ObjectKey
+
+	// The document whose content cannot be deleted is left in the trash
+	// without holding back the others.
+	purged, err := manager.PurgeTrash(ctx, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, 2, purged)
+	trash, _, err := manager.ListTrash(ctx, userID, 0, "")
+	require.NoError(t, err)
+	require.Len(t, trash, 1)
+	require.Equal(t, stuck.ID, trash[0].ID)
+
+	// It is purged once its content can be deleted.
+	store.key = ""
+	purged, err = manager.PurgeTrash(ctx, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, 1, purged)
+	_, err = store.HeadObject(ctx, stuck.ObjectKey)
+	require.Error(t, err)
+}
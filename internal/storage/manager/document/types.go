@@ -1,21 +1,118 @@
 package document
 
 import (
+	"context"
+	"time"
+
+	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
 	"github.com/a1y/doc-formatter/internal/storage/domain/repository"
-	"github.com/a1y/doc-formatter/internal/storage/util/s3"
+	"github.com/a1y/doc-formatter/internal/storage/manager/access"
+	"github.com/a1y/doc-formatter/internal/storage/util/envelope"
+	"github.com/a1y/doc-formatter/internal/storage/util/objectstore"
+	"github.com/a1y/doc-formatter/internal/storage/util/stamp"
+	"github.com/a1y/doc-formatter/pkg/throttle"
+	"github.com/google/uuid"
 )
 
+// UserDirectory names users the way people know them, such as by email
+// address, where a user id would mean nothing to the reader.
+type UserDirectory interface {
+	UserLabel(ctx context.Context, userID uuid.UUID) (string, error)
+}
+
 type DocumentManager struct {
 	documentRepo repository.DocumentRepository
-	s3Storage    *s3.S3Storage
+	folderRepo   repository.FolderRepository
+	aclRepo      repository.ACLRepository
+	linkRepo     repository.ShareLinkRepository
+	textRepo     repository.DocumentTextRepository
+	analysisRepo repository.DocumentAnalysisRepository
+	sourceRepo   repository.DocumentSourceRepository
+	batchRepo    repository.BatchJobRepository
+	access       *access.Checker
+	objectStore  objectstore.ObjectStore
+	// keyring enables envelope encryption of document content when set.
+	keyring *envelope.Keyring
+	// linkStamp is drawn on documents downloaded through share links.
+	linkStamp stamp.Stamp
+	// users names the owners of documents on stamps.
+	users UserDirectory
+	// linkThrottle locks share links and client addresses after too many
+	// wrong passwords.
+	linkThrottle       *throttle.Throttle
+	linkThrottleConfig LinkThrottleConfig
+	// thumbnails holds the uploaded documents waiting for RenderThumbnails.
+	thumbnails chan *entity.Document
+}
+
+// LinkThrottleConfig controls how wrong share link passwords are throttled.
+type LinkThrottleConfig struct {
+	// MaxLinkFailures is the number of wrong passwords to a share link after
+	// which it is locked. Links are not locked when it is zero.
+	MaxLinkFailures int
+	// MaxIPFailures is the number of wrong passwords from a client address
+	// after which it is locked. Addresses are not locked when it is zero.
+	MaxIPFailures int
+	// Lockout is how long the first lockout lasts. Every further wrong
+	// password doubles it.
+	Lockout time.Duration
+	// MaxLockout caps how long lockouts last. They are not capped when it is
+	// zero.
+	MaxLockout time.Duration
 }
 
+// NewDocumentManager returns a manager of the documents in documentRepo,
+// whose content is kept in objectStore. The repositories of share links,
+// text, analyses, sources and batch jobs are set with their setters before
+// the features relying on them are used.
 func NewDocumentManager(
 	documentRepo repository.DocumentRepository,
-	s3Storage *s3.S3Storage,
+	folderRepo repository.FolderRepository,
+	aclRepo repository.ACLRepository,
+	objectStore objectstore.ObjectStore,
 ) *DocumentManager {
 	return &DocumentManager{
 		documentRepo: documentRepo,
-		s3Storage:    s3Storage,
+		folderRepo:   folderRepo,
+		aclRepo:      aclRepo,
+		access:       access.NewChecker(aclRepo, folderRepo),
+		objectStore:  objectStore,
+		thumbnails:   make(chan *entity.Document, ThumbnailQueueSize),
 	}
 }
+
+// SetKeyring sets the keyring the content of documents is encrypted with.
+// Content is stored as it is uploaded when keyring is nil.
+func (m *DocumentManager) SetKeyring(keyring *envelope.Keyring) {
+	m.keyring = keyring
+}
+
+// SetShareLinkRepository sets the repository share links and their accesses
+// are kept in.
+func (m *DocumentManager) SetShareLinkRepository(repo repository.ShareLinkRepository) {
+	m.linkRepo = repo
+}
+
+// SetTextRepository sets the repository the text of documents is indexed
+// and searched in.
+func (m *DocumentManager) SetTextRepository(repo repository.DocumentTextRepository) {
+	m.textRepo = repo
+}
+
+// SetAnalysisRepository sets the repository the structure analyses of
+// documents are kept in.
+func (m *DocumentManager) SetAnalysisRepository(repo repository.DocumentAnalysisRepository) {
+	m.analysisRepo = repo
+}
+
+// SetSourceRepository sets the repository the provenance of merged, split
+// and formatted documents is recorded in. No provenance is recorded when
+// repo is nil.
+func (m *DocumentManager) SetSourceRepository(repo repository.DocumentSourceRepository) {
+	m.sourceRepo = repo
+}
+
+// SetBatchJobRepository sets the repository batch jobs are kept in.
+func (m *DocumentManager) SetBatchJobRepository(repo repository.BatchJobRepository) {
+	m.batchRepo = repo
+}
@@ -0,0 +1,167 @@
+package folder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/a1y/doc-formatter/internal/storage/domain/constant"
+	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func (m *FolderManager) CreateFolder(ctx context.Context, userID uuid.UUID, parentID *uuid.UUID, name string) (*entity.Folder, error) {
+	if err := entity.ValidateFolderName(name); err != nil {
+		return nil, fmt.Errorf("%w: %v", constant.ErrInvalidFolderName, err)
+	}
+	if parentID != nil {
+		if _, err := m.getFolder(ctx, userID, *parentID, entity.RoleOwner); err != nil {
+			return nil, err
+		}
+	}
+	if err := m.checkNameAvailable(ctx, userID, parentID, name, uuid.Nil); err != nil {
+		return nil, err
+	}
+
+	folder := &entity.Folder{
+		UserID:   userID,
+		ParentID: parentID,
+		Name:     name,
+	}
+	if err := m.folderRepo.Create(ctx, folder); err != nil {
+		return nil, err
+	}
+	return folder, nil
+}
+
+// RenameFolder renames a folder userID can edit.
+func (m *FolderManager) RenameFolder(ctx context.Context, userID, folderID uuid.UUID, name string) (*entity.Folder, error) {
+	if err := entity.ValidateFolderName(name); err != nil {
+		return nil, fmt.Errorf("%w: %v", constant.ErrInvalidFolderName, err)
+	}
+	folder, err := m.getFolder(ctx, userID, folderID, entity.RoleEditor)
+	if err != nil {
+		return nil, err
+	}
+	if folder.Name == name {
+		return folder, nil
+	}
+	if err := m.checkNameAvailable(ctx, folder.UserID, folder.ParentID, name, folder.ID); err != nil {
+		return nil, err
+	}
+
+	folder.Name = name
+	if err := m.folderRepo.Update(ctx, folder); err != nil {
+		return nil, err
+	}
+	return folder, nil
+}
+
+// MoveFolder moves a folder, with everything it holds, under parentID or to
+// the top level when parentID is nil.
+func (m *FolderManager) MoveFolder(ctx context.Context, userID, folderID uuid.UUID, parentID *uuid.UUID) (*entity.Folder, error) {
+	folder, err := m.getFolder(ctx, userID, folderID, entity.RoleOwner)
+	if err != nil {
+		return nil, err
+	}
+	if parentID != nil {
+		// Walk up from the new parent: reaching the moved folder means the
+		// move would detach the subtree into a cycle.
+		for ancestorID := parentID; ancestorID != nil; {
+			if *ancestorID == folder.ID {
+				return nil, constant.ErrFolderCycle
+			}
+			ancestor, err := m.getFolder(ctx, userID, *ancestorID, entity.RoleOwner)
+			if err != nil {
+				return nil, err
+			}
+			ancestorID = ancestor.ParentID
+		}
+	}
+	if err := m.checkNameAvailable(ctx, userID, parentID, folder.Name, folder.ID); err != nil {
+		return nil, err
+	}
+
+	folder.ParentID = parentID
+	if err := m.folderRepo.Update(ctx, folder); err != nil {
+		return nil, err
+	}
+	return folder, nil
+}
+
+// DeleteFolder deletes a folder. A folder holding folders or documents is
+// only deleted when recursive is set, in which case its folders are deleted
+// with it and its documents are moved into the trash. The shares of the
+// deleted folders are revoked. It returns the number of folders deleted and
+// documents trashed.
+func (m *FolderManager) DeleteFolder(ctx context.Context, userID, folderID uuid.UUID, recursive bool) (int, int, error) {
+	folder, err := m.getFolder(ctx, userID, folderID, entity.RoleOwner)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return m.folderRepo.DeleteTree(ctx, userID, folder.ID, recursive)
+}
+
+// ListFolder returns the folders and documents directly inside folderID, or
+// at the top level of userID when folderID is nil. The folder may be one
+// shared with userID.
+func (m *FolderManager) ListFolder(ctx context.Context, userID uuid.UUID, folderID *uuid.UUID) ([]*entity.Folder, []*entity.Document, error) {
+	ownerID := userID
+	if folderID != nil {
+		folder, err := m.getFolder(ctx, userID, *folderID, entity.RoleViewer)
+		if err != nil {
+			return nil, nil, err
+		}
+		ownerID = folder.UserID
+	}
+	folders, err := m.folderRepo.ListByParent(ctx, ownerID, folderID)
+	if err != nil {
+		return nil, nil, err
+	}
+	documents, err := m.documentRepo.ListByFolder(ctx, ownerID, folderID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return folders, documents, nil
+}
+
+// getFolder returns the folder with the given id if userID has at least the
+// required role on it. Folders the user cannot even view are reported as
+// missing.
+func (m *FolderManager) getFolder(ctx context.Context, userID, folderID uuid.UUID, required entity.Role) (*entity.Folder, error) {
+	folder, err := m.folderRepo.GetByID(ctx, folderID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constant.ErrFolderNotFound
+		}
+		return nil, err
+	}
+	role, err := m.access.FolderRole(ctx, userID, folder)
+	if err != nil {
+		return nil, err
+	}
+	if !role.Allows(entity.RoleViewer) {
+		return nil, constant.ErrFolderNotFound
+	}
+	if !role.Allows(required) {
+		return nil, constant.ErrPermissionDenied
+	}
+	return folder, nil
+}
+
+// checkNameAvailable fails when a folder other than exceptID already uses
+// name inside parentID.
+func (m *FolderManager) checkNameAvailable(ctx context.Context, userID uuid.UUID, parentID *uuid.UUID, name string, exceptID uuid.UUID) error {
+	siblings, err := m.folderRepo.ListByParent(ctx, userID, parentID)
+	if err != nil {
+		return err
+	}
+	for _, sibling := range siblings {
+		if sibling.Name == name && sibling.ID != exceptID {
+			return constant.ErrFolderNameConflict
+		}
+	}
+	return nil
+}
@@ -0,0 +1,291 @@
+package folder
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/a1y/doc-formatter/internal/storage/domain/constant"
+	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
+	"github.com/a1y/doc-formatter/internal/storage/domain/repository"
+	"github.com/a1y/doc-formatter/internal/storage/infra/persistence"
+	"github.com/a1y/doc-formatter/internal/storage/util/objectstore/memory"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type testEnv struct {
+	manager      *FolderManager
+	documentRepo repository.DocumentRepository
+	aclRepo      repository.ACLRepository
+	store        *memory.MemoryStorage
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, persistence.AutoMigrate(db))
+
+	documentRepo := persistence.NewDocumentRepository(db)
+	aclRepo := persistence.NewACLRepository(db)
+	store := memory.NewMemoryStorage()
+	return &testEnv{
+		manager:      NewFolderManager(persistence.NewFolderRepository(db), documentRepo, aclRepo),
+		documentRepo: documentRepo,
+		aclRepo:      aclRepo,
+		store:        store,
+	}
+}
+
+func (e *testEnv) addDocument(t *testing.T, userID uuid.UUID, folderID *uuid.UUID, name string) *entity.Document {
+	t.Helper()
+
+	ctx := context.Background()
+	doc := &entity.Document{
+		UserID:    userID,
+		FileName:  name,
+		ObjectKey: userID.String() + "/" + uuid.NewString() + "/" + name,
+		FolderID:  folderID,
+	}
+	_, err := e.store.PutObject(ctx, doc.ObjectKey, bytes.NewReader([]byte(name)))
+	require.NoError(t, err)
+	require.NoError(t, e.documentRepo.Create(ctx, doc))
+	return doc
+}
+
+func TestNewFolderManager(t *testing.T) {
+	t.Parallel()
+
+	manager := NewFolderManager(nil, nil, nil)
+	require.NotNil(t, manager)
+}
+
+func TestFolderManager_CreateFolder(t *testing.T) {
+	t.Parallel()
+
+	env := newTestEnv(t)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	parent, err := env.manager.CreateFolder(ctx, userID, nil, "Clients")
+	require.NoError(t, err)
+	require.NotEqual(t, uuid.Nil, parent.ID)
+
+	child, err := env.manager.CreateFolder(ctx, userID, &parent.ID, "Acme")
+	require.NoError(t, err)
+	require.Equal(t, parent.ID, *child.ParentID)
+
+	// The same name is fine under another parent, not next to a sibling.
+	_, err = env.manager.CreateFolder(ctx, userID, &parent.ID, "Clients")
+	require.NoError(t, err)
+	_, err = env.manager.CreateFolder(ctx, userID, nil, "Clients")
+	require.ErrorIs(t, err, constant.ErrFolderNameConflict)
+
+	_, err = env.manager.CreateFolder(ctx, userID, nil, "")
+	require.ErrorIs(t, err, constant.ErrInvalidFolderName)
+
+	// Folders of other users cannot be used as parents.
+	_, err = env.manager.CreateFolder(ctx, uuid.New(), &parent.ID, "Intruder")
+	require.ErrorIs(t, err, constant.ErrFolderNotFound)
+}
+
+func TestFolderManager_RenameFolder(t *testing.T) {
+	t.Parallel()
+
+	env := newTestEnv(t)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	drafts, err := env.manager.CreateFolder(ctx, userID, nil, "Drafts")
+	require.NoError(t, err)
+	_, err = env.manager.CreateFolder(ctx, userID, nil, "Final")
+	require.NoError(t, err)
+
+	renamed, err := env.manager.RenameFolder(ctx, userID, drafts.ID, "Work in progress")
+	require.NoError(t, err)
+	require.Equal(t, "Work in progress", renamed.Name)
+
+	unchanged, err := env.manager.RenameFolder(ctx, userID, drafts.ID, "Work in progress")
+	require.NoError(t, err)
+	require.Equal(t, drafts.ID, unchanged.ID)
+
+	_, err = env.manager.RenameFolder(ctx, userID, drafts.ID, "Final")
+	require.ErrorIs(t, err, constant.ErrFolderNameConflict)
+
+	_, err = env.manager.RenameFolder(ctx, userID, drafts.ID, "a/b")
+	require.ErrorIs(t, err, constant.ErrInvalidFolderName)
+
+	_, err = env.manager.RenameFolder(ctx, uuid.New(), drafts.ID, "Mine")
+	require.ErrorIs(t, err, constant.ErrFolderNotFound)
+}
+
+func TestFolderManager_MoveFolder(t *testing.T) {
+	t.Parallel()
+
+	env := newTestEnv(t)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	a, err := env.manager.CreateFolder(ctx, userID, nil, "A")
+	require.NoError(t, err)
+	b, err := env.manager.CreateFolder(ctx, userID, &a.ID, "B")
+	require.NoError(t, err)
+	c, err := env.manager.CreateFolder(ctx, userID, &b.ID, "C")
+	require.NoError(t, err)
+
+	_, err = env.manager.MoveFolder(ctx, userID, a.ID, &a.ID)
+	require.ErrorIs(t, err, constant.ErrFolderCycle)
+	_, err = env.manager.MoveFolder(ctx, userID, a.ID, &c.ID)
+	require.ErrorIs(t, err, constant.ErrFolderCycle)
+
+	moved, err := env.manager.MoveFolder(ctx, userID, c.ID, nil)
+	require.NoError(t, err)
+	require.Nil(t, moved.ParentID)
+
+	moved, err = env.manager.MoveFolder(ctx, userID, a.ID, &c.ID)
+	require.NoError(t, err)
+	require.Equal(t, c.ID, *moved.ParentID)
+
+	// A folder named like the moved one already sits at the top level.
+	_, err = env.manager.CreateFolder(ctx, userID, nil, "B")
+	require.NoError(t, err)
+	_, err = env.manager.MoveFolder(ctx, userID, b.ID, nil)
+	require.ErrorIs(t, err, constant.ErrFolderNameConflict)
+}
+
+func TestFolderManager_DeleteFolder(t *testing.T) {
+	t.Parallel()
+
+	env := newTestEnv(t)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	empty, err := env.manager.CreateFolder(ctx, userID, nil, "Empty")
+	require.NoError(t, err)
+	folders, files, err := env.manager.DeleteFolder(ctx, userID, empty.ID, false)
+	require.NoError(t, err)
+	require.Equal(t, 1, folders)
+	require.Zero(t, files)
+
+	parent, err := env.manager.CreateFolder(ctx, userID, nil, "Parent")
+	require.NoError(t, err)
+	child, err := env.manager.CreateFolder(ctx, userID, &parent.ID, "Child")
+	require.NoError(t, err)
+	top := env.addDocument(t, userID, &parent.ID, "top.txt")
+	nested := env.addDocument(t, userID, &child.ID, "nested.txt")
+	kept := env.addDocument(t, userID, nil, "kept.txt")
+
+	_, _, err = env.manager.DeleteFolder(ctx, userID, parent.ID, false)
+	require.ErrorIs(t, err, constant.ErrFolderNotEmpty)
+
+	_, _, err = env.manager.DeleteFolder(ctx, uuid.New(), parent.ID, true)
+	require.ErrorIs(t, err, constant.ErrFolderNotFound)
+
+	folders, files, err = env.manager.DeleteFolder(ctx, userID, parent.ID, true)
+	require.NoError(t, err)
+	require.Equal(t, 2, folders)
+	require.Equal(t, 2, files)
+
+	// Documents are trashed and keep their content until purged.
+	for _, doc := range []*entity.Document{top, nested} {
+		_, err := env.documentRepo.GetByID(ctx, doc.ID)
+		require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+		trashed, err := env.documentRepo.GetTrashedByID(ctx, doc.ID)
+		require.NoError(t, err)
+		require.NotNil(t, trashed.DeletedAt)
+		_, err = env.store.HeadObject(ctx, doc.ObjectKey)
+		require.NoError(t, err)
+	}
+	_, err = env.store.HeadObject(ctx, kept.ObjectKey)
+	require.NoError(t, err)
+
+	_, _, err = env.manager.ListFolder(ctx, userID, &child.ID)
+	require.ErrorIs(t, err, constant.ErrFolderNotFound)
+}
+
+func TestFolderManager_ListFolder(t *testing.T) {
+	t.Parallel()
+
+	env := newTestEnv(t)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	parent, err := env.manager.CreateFolder(ctx, userID, nil, "Parent")
+	require.NoError(t, err)
+	_, err = env.manager.CreateFolder(ctx, userID, &parent.ID, "Child")
+	require.NoError(t, err)
+	env.addDocument(t, userID, &parent.ID, "inside.txt")
+	env.addDocument(t, userID, nil, "outside.txt")
+	env.addDocument(t, uuid.New(), nil, "other-user.txt")
+
+	folders, documents, err := env.manager.ListFolder(ctx, userID, nil)
+	require.NoError(t, err)
+	require.Len(t, folders, 1)
+	require.Len(t, documents, 1)
+	require.Equal(t, "outside.txt", documents[0].FileName)
+
+	folders, documents, err = env.manager.ListFolder(ctx, userID, &parent.ID)
+	require.NoError(t, err)
+	require.Len(t, folders, 1)
+	require.Equal(t, "Child", folders[0].Name)
+	require.Len(t, documents, 1)
+	require.Equal(t, "inside.txt", documents[0].FileName)
+
+	_, _, err = env.manager.ListFolder(ctx, uuid.New(), &parent.ID)
+	require.ErrorIs(t, err, constant.ErrFolderNotFound)
+}
+
+func TestFolderManager_SharedFolder(t *testing.T) {
+	t.Parallel()
+
+	env := newTestEnv(t)
+	ctx := context.Background()
+	ownerID, viewerID, editorID := uuid.New(), uuid.New(), uuid.New()
+
+	parent, err := env.manager.CreateFolder(ctx, ownerID, nil, "Parent")
+	require.NoError(t, err)
+	child, err := env.manager.CreateFolder(ctx, ownerID, &parent.ID, "Child")
+	require.NoError(t, err)
+	env.addDocument(t, ownerID, &child.ID, "inside.txt")
+
+	for userID, role := range map[uuid.UUID]entity.Role{viewerID: entity.RoleViewer, editorID: entity.RoleEditor} {
+		require.NoError(t, env.aclRepo.Upsert(ctx, &entity.ACLEntry{
+			ResourceType: entity.ResourceFolder,
+			ResourceID:   parent.ID,
+			OwnerID:      ownerID,
+			UserID:       userID,
+			Role:         role,
+		}))
+	}
+
+	// The share extends to the subfolders.
+	folders, documents, err := env.manager.ListFolder(ctx, viewerID, &child.ID)
+	require.NoError(t, err)
+	require.Empty(t, folders)
+	require.Len(t, documents, 1)
+
+	_, err = env.manager.RenameFolder(ctx, viewerID, child.ID, "Renamed")
+	require.ErrorIs(t, err, constant.ErrPermissionDenied)
+
+	renamed, err := env.manager.RenameFolder(ctx, editorID, child.ID, "Renamed")
+	require.NoError(t, err)
+	require.Equal(t, "Renamed", renamed.Name)
+
+	_, err = env.manager.CreateFolder(ctx, editorID, &parent.ID, "Intruder")
+	require.ErrorIs(t, err, constant.ErrPermissionDenied)
+	_, err = env.manager.MoveFolder(ctx, editorID, child.ID, nil)
+	require.ErrorIs(t, err, constant.ErrPermissionDenied)
+	_, _, err = env.manager.DeleteFolder(ctx, editorID, parent.ID, true)
+	require.ErrorIs(t, err, constant.ErrPermissionDenied)
+
+	// Deleting the folder revokes its shares.
+	_, _, err = env.manager.DeleteFolder(ctx, ownerID, parent.ID, true)
+	require.NoError(t, err)
+	entries, err := env.aclRepo.ListByUser(ctx, viewerID)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
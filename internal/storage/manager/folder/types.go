@@ -0,0 +1,26 @@
+package folder
+
+import (
+	"github.com/a1y/doc-formatter/internal/storage/domain/repository"
+	"github.com/a1y/doc-formatter/internal/storage/manager/access"
+)
+
+type FolderManager struct {
+	folderRepo   repository.FolderRepository
+	documentRepo repository.DocumentRepository
+	aclRepo      repository.ACLRepository
+	access       *access.Checker
+}
+
+func NewFolderManager(
+	folderRepo repository.FolderRepository,
+	documentRepo repository.DocumentRepository,
+	aclRepo repository.ACLRepository,
+) *FolderManager {
+	return &FolderManager{
+		folderRepo:   folderRepo,
+		documentRepo: documentRepo,
+		aclRepo:      aclRepo,
+		access:       access.NewChecker(aclRepo, folderRepo),
+	}
+}
@@ -0,0 +1,135 @@
+package share
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/a1y/doc-formatter/internal/storage/domain/constant"
+	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Share grants granteeID a role on a document or folder owned by userID,
+// replacing the role the grantee had on it.
+func (m *ShareManager) Share(
+	ctx context.Context, userID uuid.UUID, resourceType entity.ResourceType, resourceID, granteeID uuid.UUID, role entity.Role,
+) (*entity.ACLEntry, error) {
+	entry := &entity.ACLEntry{
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+		OwnerID:      userID,
+		UserID:       granteeID,
+		Role:         role,
+	}
+	if err := entry.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", constant.ErrInvalidShare, err)
+	}
+	if err := m.checkOwner(ctx, userID, resourceType, resourceID); err != nil {
+		return nil, err
+	}
+	if err := m.aclRepo.Upsert(ctx, entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// Unshare revokes the role of granteeID on a document or folder owned by
+// userID.
+func (m *ShareManager) Unshare(ctx context.Context, userID uuid.UUID, resourceType entity.ResourceType, resourceID, granteeID uuid.UUID) error {
+	if err := m.checkOwner(ctx, userID, resourceType, resourceID); err != nil {
+		return err
+	}
+	if err := m.aclRepo.Delete(ctx, resourceID, granteeID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return constant.ErrShareNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// ListShares returns the roles granted on a document or folder owned by
+// userID, oldest first.
+func (m *ShareManager) ListShares(ctx context.Context, userID uuid.UUID, resourceType entity.ResourceType, resourceID uuid.UUID) ([]*entity.ACLEntry, error) {
+	if err := m.checkOwner(ctx, userID, resourceType, resourceID); err != nil {
+		return nil, err
+	}
+	return m.aclRepo.ListByResource(ctx, resourceID)
+}
+
+// ListSharedWithMe returns the documents and folders shared with userID, most
+// recently shared first. Documents in the trash are left out.
+func (m *ShareManager) ListSharedWithMe(ctx context.Context, userID uuid.UUID) ([]*SharedResource, error) {
+	entries, err := m.aclRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	shared := make([]*SharedResource, 0, len(entries))
+	for _, entry := range entries {
+		resource := &SharedResource{Entry: entry}
+		switch entry.ResourceType {
+		case entity.ResourceDocument:
+			resource.Document, err = m.documentRepo.GetByID(ctx, entry.ResourceID)
+		case entity.ResourceFolder:
+			resource.Folder, err = m.folderRepo.GetByID(ctx, entry.ResourceID)
+		default:
+			continue
+		}
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		shared = append(shared, resource)
+	}
+	return shared, nil
+}
+
+// checkOwner fails unless the document or folder resourceID belongs to
+// userID. Resources the user cannot even view are reported as missing.
+func (m *ShareManager) checkOwner(ctx context.Context, userID uuid.UUID, resourceType entity.ResourceType, resourceID uuid.UUID) error {
+	var (
+		role     entity.Role
+		notFound error
+	)
+	switch resourceType {
+	case entity.ResourceDocument:
+		notFound = constant.ErrDocumentNotFound
+		document, err := m.documentRepo.GetByID(ctx, resourceID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return notFound
+			}
+			return err
+		}
+		if role, err = m.access.DocumentRole(ctx, userID, document); err != nil {
+			return err
+		}
+	case entity.ResourceFolder:
+		notFound = constant.ErrFolderNotFound
+		folder, err := m.folderRepo.GetByID(ctx, resourceID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return notFound
+			}
+			return err
+		}
+		if role, err = m.access.FolderRole(ctx, userID, folder); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("%w: unknown resource type", constant.ErrInvalidShare)
+	}
+
+	if !role.Allows(entity.RoleViewer) {
+		return notFound
+	}
+	if role != entity.RoleOwner {
+		return constant.ErrPermissionDenied
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package share
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/a1y/doc-formatter/internal/storage/domain/constant"
+	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
+	"github.com/a1y/doc-formatter/internal/storage/domain/repository"
+	"github.com/a1y/doc-formatter/internal/storage/infra/persistence"
+	"github.com/a1y/doc-formatter/internal/storage/manager/document"
+	"github.com/a1y/doc-formatter/internal/storage/util/objectstore/memory"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type testEnv struct {
+	shares     *ShareManager
+	documents  *document.DocumentManager
+	aclRepo    repository.ACLRepository
+	folderRepo repository.FolderRepository
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, persistence.AutoMigrate(db))
+
+	aclRepo := persistence.NewACLRepository(db)
+	documentRepo := persistence.NewDocumentRepository(db)
+	folderRepo := persistence.NewFolderRepository(db)
+	return &testEnv{
+		shares:     NewShareManager(aclRepo, documentRepo, folderRepo),
+		documents:  document.NewDocumentManager(documentRepo, folderRepo, aclRepo, memory.NewMemoryStorage()),
+		aclRepo:    aclRepo,
+		folderRepo: folderRepo,
+	}
+}
+
+func (e *testEnv) upload(t *testing.T, userID uuid.UUID, folderID *uuid.UUID, name string) *entity.Document {
+	t.Helper()
+
+	doc, err := e.documents.UploadDocument(context.Background(), &entity.Document{
+		UserID:   userID,
+		FileName: name,
+		FolderID: folderID,
+	}, bytes.NewReader([]byte(name)))
+	require.NoError(t, err)
+	return doc
+}
+
+func TestShareManager_Share(t *testing.T) {
+	t.Parallel()
+
+	env := newTestEnv(t)
+	ctx := context.Background()
+	ownerID, granteeID := uuid.New(), uuid.New()
+	doc := env.upload(t, ownerID, nil, "contract.pdf")
+
+	entry, err := env.shares.Share(ctx, ownerID, entity.ResourceDocument, doc.ID, granteeID, entity.RoleViewer)
+	require.NoError(t, err)
+	require.Equal(t, ownerID, entry.OwnerID)
+	require.NotEqual(t, uuid.Nil, entry.ID)
+
+	// Viewers can download but not edit, move or trash.
+	_, reader, err := env.documents.DownloadDocument(ctx, granteeID, doc.ID)
+	require.NoError(t, err)
+	require.NoError(t, reader.Close())
+	_, err = env.documents.AddTags(ctx, granteeID, doc.ID, []string{"signed"})
+	require.ErrorIs(t, err, constant.ErrPermissionDenied)
+
+	// Sharing again replaces the role.
+	_, err = env.shares.Share(ctx, ownerID, entity.ResourceDocument, doc.ID, granteeID, entity.RoleEditor)
+	require.NoError(t, err)
+	tagged, err := env.documents.AddTags(ctx, granteeID, doc.ID, []string{"signed"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"signed"}, tagged.Tags)
+	_, err = env.documents.MoveDocument(ctx, granteeID, doc.ID, nil)
+	require.ErrorIs(t, err, constant.ErrPermissionDenied)
+	_, err = env.documents.TrashDocument(ctx, granteeID, doc.ID)
+	require.ErrorIs(t, err, constant.ErrPermissionDenied)
+
+	entries, err := env.shares.ListShares(ctx, ownerID, entity.ResourceDocument, doc.ID)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, entity.RoleEditor, entries[0].Role)
+
+	// Only the owner manages the shares.
+	_, err = env.shares.Share(ctx, granteeID, entity.ResourceDocument, doc.ID, uuid.New(), entity.RoleViewer)
+	require.ErrorIs(t, err, constant.ErrPermissionDenied)
+	_, err = env.shares.ListShares(ctx, uuid.New(), entity.ResourceDocument, doc.ID)
+	require.ErrorIs(t, err, constant.ErrDocumentNotFound)
+
+	_, err = env.shares.Share(ctx, ownerID, entity.ResourceDocument, doc.ID, ownerID, entity.RoleViewer)
+	require.ErrorIs(t, err, constant.ErrInvalidShare)
+	_, err = env.shares.Share(ctx, ownerID, entity.ResourceDocument, doc.ID, granteeID, entity.RoleOwner)
+	require.ErrorIs(t, err, constant.ErrInvalidShare)
+	_, err = env.shares.Share(ctx, ownerID, entity.ResourceFolder, doc.ID, granteeID, entity.RoleViewer)
+	require.ErrorIs(t, err, constant.ErrFolderNotFound)
+
+	require.NoError(t, env.shares.Unshare(ctx, ownerID, entity.ResourceDocument, doc.ID, granteeID))
+	require.ErrorIs(t, env.shares.Unshare(ctx, ownerID, entity.ResourceDocument, doc.ID, granteeID), constant.ErrShareNotFound)
+	_, _, err = env.documents.DownloadDocument(ctx, granteeID, doc.ID)
+	require.ErrorIs(t, err, constant.ErrDocumentNotFound)
+}
+
+func TestShareManager_ListSharedWithMe(t *testing.T) {
+	t.Parallel()
+
+	env := newTestEnv(t)
+	ctx := context.Background()
+	ownerID, granteeID := uuid.New(), uuid.New()
+
+	folder := &entity.Folder{UserID: ownerID, Name: "Clients"}
+	require.NoError(t, env.folderRepo.Create(ctx, folder))
+	inside := env.upload(t, ownerID, &folder.ID, "inside.txt")
+	trashed := env.upload(t, ownerID, nil, "trashed.txt")
+
+	_, err := env.shares.Share(ctx, ownerID, entity.ResourceFolder, folder.ID, granteeID, entity.RoleCommenter)
+	require.NoError(t, err)
+	_, err = env.shares.Share(ctx, ownerID, entity.ResourceDocument, trashed.ID, granteeID, entity.RoleViewer)
+	require.NoError(t, err)
+
+	// A folder share extends to the documents inside it.
+	_, reader, err := env.documents.DownloadDocument(ctx, granteeID, inside.ID)
+	require.NoError(t, err)
+	require.NoError(t, reader.Close())
+
+	shared, err := env.shares.ListSharedWithMe(ctx, granteeID)
+	require.NoError(t, err)
+	require.Len(t, shared, 2)
+
+	_, err = env.documents.TrashDocument(ctx, ownerID, trashed.ID)
+	require.NoError(t, err)
+
+	shared, err = env.shares.ListSharedWithMe(ctx, granteeID)
+	require.NoError(t, err)
+	require.Len(t, shared, 1)
+	require.Nil(t, shared[0].Document)
+	require.Equal(t, folder.ID, shared[0].Folder.ID)
+	require.Equal(t, entity.RoleCommenter, shared[0].Entry.Role)
+
+	// Purging the trash revokes the shares of the purged documents.
+	_, err = env.documents.EmptyTrash(ctx, ownerID)
+	require.NoError(t, err)
+	entries, err := env.aclRepo.ListByUser(ctx, granteeID)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, folder.ID, entries[0].ResourceID)
+
+	shared, err = env.shares.ListSharedWithMe(ctx, uuid.New())
+	require.NoError(t, err)
+	require.Empty(t, shared)
+}
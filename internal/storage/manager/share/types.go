@@ -0,0 +1,35 @@
+package share
+
+import (
+	"github.com/a1y/doc-formatter/internal/storage/domain/entity"
+	"github.com/a1y/doc-formatter/internal/storage/domain/repository"
+	"github.com/a1y/doc-formatter/internal/storage/manager/access"
+)
+
+type ShareManager struct {
+	aclRepo      repository.ACLRepository
+	documentRepo repository.DocumentRepository
+	folderRepo   repository.FolderRepository
+	access       *access.Checker
+}
+
+func NewShareManager(
+	aclRepo repository.ACLRepository,
+	documentRepo repository.DocumentRepository,
+	folderRepo repository.FolderRepository,
+) *ShareManager {
+	return &ShareManager{
+		aclRepo:      aclRepo,
+		documentRepo: documentRepo,
+		folderRepo:   folderRepo,
+		access:       access.NewChecker(aclRepo, folderRepo),
+	}
+}
+
+// SharedResource is a document or folder shared with a user, along with the
+// entry granting the access. Exactly one of Document and Folder is set.
+type SharedResource struct {
+	Entry    *entity.ACLEntry
+	Document *entity.Document
+	Folder   *entity.Folder
+}
@@ -0,0 +1,264 @@
+// Package docanalysis extracts the structure of documents: their outline,
+// statistics, images, tables, language, fonts and properties.
+package docanalysis
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"mime"
+	"path"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/a1y/doc-formatter/internal/storage/util/docparse"
+	"github.com/a1y/doc-formatter/internal/storage/util/textextract"
+)
+
+// Supported reports whether documents of contentType can be analyzed.
+func Supported(contentType string) bool {
+	switch contentType {
+	case textextract.TypeDOCX, textextract.TypeODT, textextract.TypeMarkdown:
+		return true
+	default:
+		return false
+	}
+}
+
+// Analyze returns the structure of the document of contentType read from r.
+// Documents larger than textextract.MaxDocumentSize are rejected with
+// ErrDocumentTooLarge.
+func Analyze(contentType string, r io.Reader) (*Analysis, error) {
+	if !Supported(contentType) {
+		return nil, ErrUnsupportedFormat
+	}
+	data, err := io.ReadAll(io.LimitReader(r, textextract.MaxDocumentSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > textextract.MaxDocumentSize {
+		return nil, ErrDocumentTooLarge
+	}
+
+	var (
+		doc  *document
+		text string
+	)
+	switch contentType {
+	case textextract.TypeDOCX:
+		doc, err = analyzeDOCX(data)
+	case textextract.TypeODT:
+		doc, err = analyzeODT(data)
+	default:
+		doc, data = analyzeMarkdown(data)
+	}
+	if err != nil {
+		return nil, err
+	}
+	// The front matter of Markdown documents is cut from data, so it does
+	// not count as text.
+	text, err = textextract.Extract(contentType, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	analysis := &Analysis{
+		Outline:    nest(doc.headings),
+		Statistics: statistics(text, doc.pages),
+		Images:     doc.images,
+		Tables:     doc.tables,
+		Language:   detectLanguage(text),
+		Fonts:      doc.fontNames(),
+		Properties: doc.properties,
+	}
+	if analysis.Outline == nil {
+		analysis.Outline = []Heading{}
+	}
+	if analysis.Images == nil {
+		analysis.Images = []Image{}
+	}
+	if analysis.Tables == nil {
+		analysis.Tables = []Table{}
+	}
+	return analysis, nil
+}
+
+// document collects the structure of a document while it is parsed.
+type document struct {
+	// headings are in document order, not nested yet.
+	headings   []Heading
+	images     []Image
+	tables     []Table
+	fonts      map[string]struct{}
+	properties Properties
+	// pages is the recorded page count, zero when unknown.
+	pages int
+}
+
+func (d *document) addHeading(level int, text string) {
+	text = strings.Join(strings.Fields(text), " ")
+	if text == "" || level < 1 || level > 9 {
+		return
+	}
+	if len(text) > MaxHeadingLength {
+		text = strings.ToValidUTF8(text[:MaxHeadingLength], "")
+	}
+	d.headings = append(d.headings, Heading{Level: level, Text: text})
+}
+
+func (d *document) addFont(name string) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return
+	}
+	if d.fonts == nil {
+		d.fonts = make(map[string]struct{})
+	}
+	d.fonts[name] = struct{}{}
+}
+
+func (d *document) fontNames() []string {
+	names := make([]string, 0, len(d.fonts))
+	for name := range d.fonts {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// tableStack tracks the tables being parsed, nested tables on top.
+type tableStack struct {
+	tables *[]Table
+	// open holds the index in tables and the cells of the current row of
+	// the open tables.
+	open []struct{ index, cells int }
+}
+
+func (s *tableStack) startTable() {
+	*s.tables = append(*s.tables, Table{})
+	s.open = append(s.open, struct{ index, cells int }{index: len(*s.tables) - 1})
+}
+
+func (s *tableStack) endTable() {
+	if len(s.open) > 0 {
+		s.open = s.open[:len(s.open)-1]
+	}
+}
+
+func (s *tableStack) startRow(repeat int) {
+	if len(s.open) > 0 {
+		top := &s.open[len(s.open)-1]
+		(*s.tables)[top.index].Rows += repeat
+		top.cells = 0
+	}
+}
+
+func (s *tableStack) addCells(n int) {
+	if len(s.open) > 0 {
+		top := &s.open[len(s.open)-1]
+		top.cells += n
+		table := &(*s.tables)[top.index]
+		table.Columns = max(table.Columns, top.cells)
+	}
+}
+
+// nest turns headings in document order into a tree: each heading holds the
+// deeper headings that follow it.
+func nest(headings []Heading) []Heading {
+	var tree []Heading
+	for i := 0; i < len(headings); {
+		heading := headings[i]
+		j := i + 1
+		for j < len(headings) && headings[j].Level > heading.Level {
+			j++
+		}
+		heading.Children = nest(headings[i+1 : j])
+		tree = append(tree, heading)
+		i = j
+	}
+	return tree
+}
+
+func statistics(text string, pages int) Statistics {
+	stats := Statistics{Words: len(strings.Fields(text))}
+	for line := range strings.SplitSeq(text, "\n") {
+		if strings.TrimSpace(line) != "" {
+			stats.Paragraphs++
+		}
+		stats.Characters += utf8.RuneCountInString(line)
+		for _, r := range line {
+			if !unicode.IsSpace(r) {
+				stats.CharactersNoSpaces++
+			}
+		}
+	}
+	stats.Pages = pages
+	if pages <= 0 {
+		stats.Pages = (stats.Words + WordsPerPage - 1) / WordsPerPage
+		stats.PagesEstimated = true
+	}
+	return stats
+}
+
+// packageImages lists the files of pkg under dir.
+func packageImages(pkg *docparse.Package, dir string) []Image {
+	var images []Image
+	for _, file := range pkg.File {
+		if !strings.HasPrefix(file.Name, dir) || strings.HasSuffix(file.Name, "/") {
+			continue
+		}
+		images = append(images, Image{
+			Name:        file.Name,
+			ContentType: imageType(file.Name),
+			Size:        int64(file.UncompressedSize64),
+		})
+	}
+	return images
+}
+
+// imageType returns the media type of an image from its file name, or ""
+// when it is unknown.
+func imageType(name string) string {
+	mediaType, _, err := mime.ParseMediaType(mime.TypeByExtension(strings.ToLower(path.Ext(name))))
+	if err != nil {
+		return ""
+	}
+	return mediaType
+}
+
+func attr(element xml.StartElement, local string) string {
+	for _, a := range element.Attr {
+		if a.Name.Local == local {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// parseTime parses the dates of document properties. Office documents use
+// RFC 3339, OpenDocument drops the time zone, which is then taken as UTC.
+func parseTime(value string) *time.Time {
+	value = strings.TrimSpace(value)
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05.999999999", time.DateOnly} {
+		if t, err := time.Parse(layout, value); err == nil {
+			t = t.UTC()
+			return &t
+		}
+	}
+	return nil
+}
+
+// splitKeywords splits a list of keywords separated by commas or
+// semicolons.
+func splitKeywords(value string) []string {
+	var keywords []string
+	for _, keyword := range strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ';' }) {
+		if keyword = strings.TrimSpace(keyword); keyword != "" {
+			keywords = append(keywords, keyword)
+		}
+	}
+	return keywords
+}
@@ -0,0 +1,221 @@
+package docanalysis
+
+import (
+	"archive/zip"
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/a1y/doc-formatter/internal/storage/util/textextract"
+	"github.com/stretchr/testify/require"
+)
+
+// zipDocument packages files as a ZIP archive, the container of DOCX and ODT
+// documents.
+func zipDocument(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, content := range files {
+		f, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func date(s string) *time.Time {
+	t, _ := time.Parse(time.RFC3339, s)
+	return &t
+}
+
+func TestAnalyze_DOCX(t *testing.T) {
+	data := zipDocument(t, map[string]string{
+		"word/styles.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<w:styles xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">
+<w:style w:type="paragraph" w:styleId="berschrift1"><w:name w:val="heading 1"/><w:rPr><w:rFonts w:ascii="Calibri Light" w:hAnsi="Calibri Light"/></w:rPr></w:style>
+<w:style w:type="paragraph" w:styleId="Custom"><w:name w:val="Chapter"/><w:pPr><w:outlineLvl w:val="1"/></w:pPr></w:style>
+<w:style w:type="paragraph" w:styleId="Normal"><w:name w:val="Normal"/><w:rPr><w:rFonts w:asciiTheme="minorHAnsi"/></w:rPr></w:style>
+</w:styles>`,
+		"word/document.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>
+<w:p><w:pPr><w:pStyle w:val="berschrift1"/></w:pPr><w:r><w:t>Annual</w:t></w:r><w:r><w:t xml:space="preserve"> report</w:t></w:r></w:p>
+<w:p><w:r><w:rPr><w:rFonts w:ascii="Georgia"/></w:rPr><w:t>The results of the year are good and the outlook is bright for the team.</w:t></w:r></w:p>
+<w:p><w:pPr><w:pStyle w:val="Custom"/></w:pPr><w:r><w:t>Revenue</w:t></w:r></w:p>
+<w:tbl><w:tr><w:tc><w:tcPr><w:gridSpan w:val="2"/></w:tcPr><w:p><w:r><w:t>Total</w:t></w:r></w:p></w:tc></w:tr>
+<w:tr><w:tc><w:p/></w:tc><w:tc><w:tbl><w:tr><w:tc><w:p/></w:tc></w:tr></w:tbl><w:p/></w:tc></w:tr></w:tbl>
+<w:p><w:pPr><w:outlineLvl w:val="0"/></w:pPr><w:r><w:t>Outlook</w:t></w:r></w:p>
+<w:p><w:pPr><w:pStyle w:val="berschrift1"/></w:pPr></w:p>
+</w:body></w:document>`,
+		"word/media/image1.png": "png",
+		"docProps/core.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<cp:coreProperties xmlns:cp="http://schemas.openxmlformats.org/package/2006/metadata/core-properties" xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:dcterms="http://purl.org/dc/terms/">
+<dc:title>Annual report</dc:title><dc:creator>Ada</dc:creator><cp:lastModifiedBy>Grace</cp:lastModifiedBy>
+<cp:keywords>finance; 2025</cp:keywords>
+<dcterms:created>2025-01-02T10:00:00Z</dcterms:created><dcterms:modified>2025-03-04T12:30:00Z</dcterms:modified>
+</cp:coreProperties>`,
+		"docProps/app.xml": `<Properties><Pages>3</Pages><Words>20</Words></Properties>`,
+	})
+
+	analysis, err := Analyze(textextract.TypeDOCX, bytes.NewReader(data))
+	require.NoError(t, err)
+	require.Equal(t, []Heading{
+		{Level: 1, Text: "Annual report", Children: []Heading{{Level: 2, Text: "Revenue"}}},
+		{Level: 1, Text: "Outlook"},
+	}, analysis.Outline)
+	require.Equal(t, []Table{{Rows: 2, Columns: 2}, {Rows: 1, Columns: 1}}, analysis.Tables)
+	require.Equal(t, []Image{{Name: "word/media/image1.png", ContentType: "image/png", Size: 3}}, analysis.Images)
+	require.Equal(t, []string{"Calibri Light", "Georgia"}, analysis.Fonts)
+	require.Equal(t, "en", analysis.Language)
+	require.Equal(t, Properties{
+		Title:          "Annual report",
+		Author:         "Ada",
+		LastModifiedBy: "Grace",
+		Keywords:       []string{"finance", "2025"},
+		Created:        date("2025-01-02T10:00:00Z"),
+		Modified:       date("2025-03-04T12:30:00Z"),
+	}, analysis.Properties)
+	require.Equal(t, 3, analysis.Statistics.Pages)
+	require.False(t, analysis.Statistics.PagesEstimated)
+	require.Equal(t, 20, analysis.Statistics.Words)
+}
+
+func TestAnalyze_ODT(t *testing.T) {
+	data := zipDocument(t, map[string]string{
+		"styles.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<office:document-styles xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" xmlns:style="urn:oasis:names:tc:opendocument:xmlns:style:1.0">
+<office:styles><style:style style:name="Standard"><style:text-properties style:font-name="Liberation Serif"/></style:style></office:styles>
+</office:document-styles>`,
+		"content.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<office:document-content xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0" xmlns:table="urn:oasis:names:tc:opendocument:xmlns:table:1.0" xmlns:style="urn:oasis:names:tc:opendocument:xmlns:style:1.0">
+<office:automatic-styles><style:style style:name="T1"><style:text-properties style:font-name="DejaVu Sans"/></style:style></office:automatic-styles>
+<office:body><office:text>
+<text:h text:outline-level="1">Le <text:span>rapport</text:span></text:h>
+<text:p>Les résultats de la société sont bons et nous sommes contents pour les équipes.</text:p>
+<text:h text:outline-level="3">Détails</text:h>
+<text:h text:outline-level="2">Chiffres</text:h>
+<table:table><table:table-row><table:table-cell/><table:table-cell table:number-columns-repeated="2"/></table:table-row>
+<table:table-row table:number-rows-repeated="2"><table:table-cell/><table:covered-table-cell/></table:table-row></table:table>
+</office:text></office:body></office:document-content>`,
+		"meta.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<office:document-meta xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" xmlns:meta="urn:oasis:names:tc:opendocument:xmlns:meta:1.0" xmlns:dc="http://purl.org/dc/elements/1.1/">
+<office:meta><dc:title>Rapport</dc:title><meta:initial-creator>Marie</meta:initial-creator><dc:creator>Pierre</dc:creator>
+<meta:keyword>bilan</meta:keyword><meta:keyword>2025</meta:keyword>
+<meta:creation-date>2025-01-02T10:00:00.123</meta:creation-date><dc:date>2025-03-04T12:30:00</dc:date>
+<meta:document-statistic meta:page-count="2" meta:word-count="14"/></office:meta>
+</office:document-meta>`,
+		"Pictures/logo.jpg": "jpeg",
+	})
+
+	analysis, err := Analyze(textextract.TypeODT, bytes.NewReader(data))
+	require.NoError(t, err)
+	require.Equal(t, []Heading{
+		{Level: 1, Text: "Le rapport", Children: []Heading{
+			{Level: 3, Text: "Détails"},
+			{Level: 2, Text: "Chiffres"},
+		}},
+	}, analysis.Outline)
+	require.Equal(t, []Table{{Rows: 3, Columns: 3}}, analysis.Tables)
+	require.Equal(t, []Image{{Name: "Pictures/logo.jpg", ContentType: "image/jpeg", Size: 4}}, analysis.Images)
+	require.Equal(t, []string{"DejaVu Sans", "Liberation Serif"}, analysis.Fonts)
+	require.Equal(t, "fr", analysis.Language)
+	require.Equal(t, Properties{
+		Title:          "Rapport",
+		Author:         "Marie",
+		LastModifiedBy: "Pierre",
+		Keywords:       []string{"bilan", "2025"},
+		Created:        date("2025-01-02T10:00:00.123Z"),
+		Modified:       date("2025-03-04T12:30:00Z"),
+	}, analysis.Properties)
+	require.Equal(t, 2, analysis.Statistics.Pages)
+}
+
+func TestAnalyze_Markdown(t *testing.T) {
+	data := []byte(`---
+title: Setup guide
+author: [Ada, Grace]
+tags: [install, ops]
+date: 2025-01-02
+---
+# Setup *guide*
+
+Install the tool and run it from the command line of the machine.
+
+Requirements
+------------
+
+` + "```sh\n# not a heading\n```" + `
+
+| Name | Version |
+|------|:-------:|
+| go   | 1.25    |
+| make | 4       |
+
+![Diagram](images/flow.svg "Flow")
+
+### Troubleshooting ###
+`)
+
+	analysis, err := Analyze(textextract.TypeMarkdown, bytes.NewReader(data))
+	require.NoError(t, err)
+	require.Equal(t, []Heading{
+		{Level: 1, Text: "Setup guide", Children: []Heading{
+			{Level: 2, Text: "Requirements", Children: []Heading{{Level: 3, Text: "Troubleshooting"}}},
+		}},
+	}, analysis.Outline)
+	require.Equal(t, []Table{{Rows: 3, Columns: 2}}, analysis.Tables)
+	require.Equal(t, []Image{{Name: "images/flow.svg", ContentType: "image/svg+xml", Description: "Diagram"}}, analysis.Images)
+	require.Empty(t, analysis.Fonts)
+	require.Equal(t, Properties{
+		Title:    "Setup guide",
+		Author:   "Ada, Grace",
+		Keywords: []string{"install", "ops"},
+		Created:  date("2025-01-02T00:00:00Z"),
+	}, analysis.Properties)
+	require.True(t, analysis.Statistics.PagesEstimated)
+	require.Equal(t, 1, analysis.Statistics.Pages)
+	require.Positive(t, analysis.Statistics.Words)
+	require.Greater(t, analysis.Statistics.Characters, analysis.Statistics.CharactersNoSpaces)
+}
+
+func TestAnalyze_Errors(t *testing.T) {
+	_, err := Analyze("application/pdf", strings.NewReader("%PDF"))
+	require.ErrorIs(t, err, ErrUnsupportedFormat)
+
+	_, err = Analyze(textextract.TypeDOCX, strings.NewReader("not a zip"))
+	require.ErrorIs(t, err, ErrMalformedDocument)
+
+	data := zipDocument(t, map[string]string{"word/styles.xml": "<w:styles/>"})
+	_, err = Analyze(textextract.TypeDOCX, bytes.NewReader(data))
+	require.ErrorIs(t, err, ErrMalformedDocument)
+
+	require.True(t, Supported(textextract.TypeODT))
+	require.False(t, Supported(textextract.TypeHTML))
+}
+
+func TestStatistics(t *testing.T) {
+	stats := statistics("one two\n\nthree", 0)
+	require.Equal(t, Statistics{
+		Words:              3,
+		Characters:         12,
+		CharactersNoSpaces: 11,
+		Paragraphs:         2,
+		Pages:              1,
+		PagesEstimated:     true,
+	}, stats)
+
+	require.Equal(t, 2, statistics(strings.Repeat("word ", WordsPerPage+1), 0).Pages)
+	require.Zero(t, statistics("", 0).Pages)
+}
+
+func TestDetectLanguage(t *testing.T) {
+	require.Equal(t, "en", detectLanguage("The cat is on the mat and it was happy with the food for the day."))
+	require.Equal(t, "de", detectLanguage("Der Hund ist nicht mit dem Ball auf der Wiese, und die Katze auch nicht."))
+	require.Equal(t, "es", detectLanguage("El perro y los gatos están en la casa con una pelota para el niño, pero es muy tarde."))
+	require.Empty(t, detectLanguage("Hello world"))
+	require.Empty(t, detectLanguage("12 345 67"))
+}
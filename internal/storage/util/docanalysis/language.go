@@ -0,0 +1,74 @@
+package docanalysis
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	// languageSample is the length in bytes of the start of the text the
+	// language is detected from.
+	languageSample = 64 << 10
+	// minLanguageMatches is the number of stop words below which the
+	// language is not told.
+	minLanguageMatches = 5
+)
+
+// stopWords lists frequent words of the languages told apart, by ISO 639-1
+// code. Words shared by several languages count for each of them.
+var stopWords = map[string][]string{
+	"da": {"og", "jeg", "det", "ikke", "af", "til", "er", "som", "på", "med", "han", "for", "ved", "mig", "sig", "hvad", "ham", "nu", "blev", "efter"},
+	"de": {"und", "der", "die", "das", "nicht", "ist", "ich", "mit", "sich", "auf", "für", "dem", "den", "ein", "eine", "auch", "es", "zu", "von", "wird"},
+	"en": {"the", "and", "of", "to", "is", "that", "it", "for", "with", "as", "was", "on", "are", "be", "this", "by", "have", "from", "which", "or"},
+	"es": {"el", "los", "las", "del", "que", "y", "en", "por", "con", "una", "para", "es", "se", "su", "como", "pero", "más", "este", "está", "muy"},
+	"fi": {"ja", "on", "ei", "että", "se", "oli", "hän", "ovat", "kun", "mutta", "tai", "myös", "joka", "kuin", "sen", "niin", "ole", "vain", "tämä", "jos"},
+	"fr": {"le", "la", "les", "et", "des", "est", "une", "que", "du", "dans", "pour", "pas", "qui", "sur", "au", "avec", "ce", "sont", "mais", "nous"},
+	"it": {"il", "di", "che", "della", "per", "non", "sono", "gli", "una", "con", "del", "nel", "alla", "anche", "questo", "più", "come", "ma", "essere", "ci"},
+	"nl": {"de", "het", "een", "van", "en", "niet", "dat", "zijn", "op", "voor", "met", "ook", "maar", "aan", "wordt", "bij", "heeft", "deze", "naar", "worden"},
+	"pt": {"o", "os", "não", "uma", "do", "da", "em", "para", "com", "que", "se", "por", "mais", "dos", "das", "ao", "foi", "são", "está", "também"},
+	"ru": {"и", "в", "не", "на", "что", "с", "по", "как", "это", "он", "к", "но", "из", "у", "за", "от", "для", "так", "же", "было"},
+	"sv": {"och", "att", "det", "som", "är", "inte", "för", "med", "på", "av", "till", "den", "har", "jag", "ett", "om", "från", "eller", "när", "hade"},
+}
+
+// stopWordLanguages maps each stop word to the languages it belongs to.
+var stopWordLanguages = func() map[string][]string {
+	index := make(map[string][]string)
+	for language, words := range stopWords {
+		for _, word := range words {
+			index[word] = append(index[word], language)
+		}
+	}
+	return index
+}()
+
+// detectLanguage returns the ISO 639-1 code of the language of text, told
+// by the stop words found at its start, or "" when no language stands out.
+func detectLanguage(text string) string {
+	if len(text) > languageSample {
+		text = text[:languageSample]
+	}
+	counts := make(map[string]int)
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	for _, word := range words {
+		for _, language := range stopWordLanguages[word] {
+			counts[language]++
+		}
+	}
+
+	best, bestCount, runnerUp := "", 0, 0
+	for language, count := range counts {
+		switch {
+		case count > bestCount:
+			best, bestCount, runnerUp = language, count, max(runnerUp, bestCount)
+		case count > runnerUp:
+			runnerUp = count
+		}
+	}
+	// Short texts or texts mixing languages are not told.
+	if bestCount < minLanguageMatches || bestCount < runnerUp*3/2 {
+		return ""
+	}
+	return best
+}
@@ -0,0 +1,153 @@
+package docanalysis
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/a1y/doc-formatter/internal/storage/util/docparse"
+	"gopkg.in/yaml.v3"
+)
+
+// analyzeMarkdown reads the structure of a Markdown document. It returns
+// data without its front matter.
+func analyzeMarkdown(data []byte) (*document, []byte) {
+	doc := &document{}
+	lines := docparse.MarkdownLines(data)
+	if front, rest := docparse.SplitFrontMatter(lines); front != nil && frontMatter(doc, front) {
+		lines = rest
+		data = []byte(strings.Join(rest, "\n"))
+	}
+
+	fence := ""
+	// paragraph is set while the previous line is paragraph text, which a
+	// setext underline turns into a heading.
+	paragraph := ""
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		if fence != "" {
+			if docparse.ClosesFence(line, fence) {
+				fence = ""
+			}
+			continue
+		}
+		if fence = docparse.OpenFence(line); fence != "" {
+			paragraph = ""
+			continue
+		}
+
+		for _, m := range docparse.ImageLink.FindAllStringSubmatch(line, -1) {
+			doc.images = append(doc.images, Image{
+				Name:        m[2],
+				ContentType: imageType(m[2]),
+				Description: m[1],
+			})
+		}
+
+		rows, next, isTable := docparse.Table(lines, i)
+		switch {
+		case strings.TrimSpace(line) == "":
+			paragraph = ""
+		case docparse.ATXHeading.MatchString(line):
+			m := docparse.ATXHeading.FindStringSubmatch(line)
+			doc.addHeading(len(m[1]), docparse.InlineText(m[2]))
+			paragraph = ""
+		case paragraph != "" && docparse.SetextH1.MatchString(line):
+			doc.addHeading(1, docparse.InlineText(paragraph))
+			paragraph = ""
+		case paragraph != "" && docparse.SetextH2.MatchString(line):
+			doc.addHeading(2, docparse.InlineText(paragraph))
+			paragraph = ""
+		case isTable:
+			table := Table{Rows: len(rows)}
+			for _, row := range rows {
+				table.Columns = max(table.Columns, len(row))
+			}
+			doc.tables = append(doc.tables, table)
+			// The loop steps to the line after the table.
+			i = next - 1
+			paragraph = ""
+		case docparse.BlockQuote.MatchString(line), docparse.ListItem.MatchString(line),
+			strings.HasPrefix(strings.TrimSpace(line), "|"):
+			paragraph = ""
+		default:
+			if paragraph == "" {
+				paragraph = line
+			} else {
+				paragraph += " " + line
+			}
+		}
+	}
+	return doc, data
+}
+
+// frontMatter reads the properties of the YAML front matter front, lines of
+// three dashes or dots around it included. It reports false when the front
+// matter is invalid, and is then left in place as text.
+func frontMatter(doc *document, front []string) bool {
+	var fields map[string]any
+	if err := yaml.Unmarshal([]byte(strings.Join(front[1:len(front)-1], "\n")), &fields); err != nil {
+		return false
+	}
+	props := &doc.properties
+	props.Title = stringField(fields["title"])
+	props.Subject = stringField(fields["subject"])
+	props.Description = stringField(fields["description"])
+	props.Author = stringField(fields["author"])
+	if keywords, ok := fields["keywords"]; ok {
+		props.Keywords = listField(keywords)
+	} else {
+		props.Keywords = listField(fields["tags"])
+	}
+	props.Created = timeField(fields["date"])
+	for _, key := range []string{"lastmod", "modified", "updated"} {
+		if modified := timeField(fields[key]); modified != nil {
+			props.Modified = modified
+			break
+		}
+	}
+	return true
+}
+
+func stringField(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return strings.TrimSpace(v)
+	case []any:
+		// Several authors, for instance.
+		return strings.Join(listField(v), ", ")
+	default:
+		return strings.TrimSpace(fmt.Sprint(v))
+	}
+}
+
+func listField(value any) []string {
+	switch v := value.(type) {
+	case string:
+		return splitKeywords(v)
+	case []any:
+		var list []string
+		for _, item := range v {
+			if s := stringField(item); s != "" {
+				list = append(list, s)
+			}
+		}
+		return list
+	default:
+		return nil
+	}
+}
+
+func timeField(value any) *time.Time {
+	switch v := value.(type) {
+	case time.Time:
+		t := v.UTC()
+		return &t
+	case string:
+		return parseTime(v)
+	default:
+		return nil
+	}
+}
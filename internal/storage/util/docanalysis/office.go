@@ -0,0 +1,303 @@
+package docanalysis
+
+import (
+	"encoding/xml"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/a1y/doc-formatter/internal/storage/util/docparse"
+)
+
+// headingStyleName matches the names of the built-in heading styles of
+// Word, which are stored in English whatever the language of the user.
+var headingStyleName = regexp.MustCompile(`^heading ([1-9])$`)
+
+// analyzeDOCX reads the structure of a WordprocessingML document.
+func analyzeDOCX(data []byte) (*document, error) {
+	pkg, err := docparse.OpenPackage(data)
+	if err != nil {
+		return nil, err
+	}
+	doc := &document{images: packageImages(pkg, "word/media/")}
+
+	// Style ids are localized, the heading level of a style is told by its
+	// outline level or its name.
+	levels := make(map[string]int)
+	var (
+		styleID, styleName string
+		styleLevel         int
+	)
+	err = pkg.Walk("word/styles.xml", false, func(token xml.Token) bool {
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "style":
+				styleID, styleName, styleLevel = attr(t, "styleId"), "", 0
+			case "name":
+				styleName = strings.ToLower(attr(t, "val"))
+			case "outlineLvl":
+				styleLevel = outlineLevel(attr(t, "val"))
+			case "rFonts":
+				addRunFonts(doc, t)
+			}
+		case xml.EndElement:
+			if t.Name.Local != "style" {
+				return true
+			}
+			if m := headingStyleName.FindStringSubmatch(styleName); m != nil && styleLevel == 0 {
+				styleLevel, _ = strconv.Atoi(m[1])
+			}
+			if styleLevel > 0 {
+				levels[styleID] = styleLevel
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tables := tableStack{tables: &doc.tables}
+	var (
+		paragraph strings.Builder
+		level     int
+		inText    bool
+	)
+	err = pkg.Walk("word/document.xml", true, func(token xml.Token) bool {
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "p":
+				paragraph.Reset()
+				level = 0
+			case "pStyle":
+				level = levels[attr(t, "val")]
+			case "outlineLvl":
+				level = outlineLevel(attr(t, "val"))
+			case "t":
+				inText = true
+			case "tab":
+				paragraph.WriteByte(' ')
+			case "rFonts":
+				addRunFonts(doc, t)
+			case "tbl":
+				tables.startTable()
+			case "tr":
+				tables.startRow(1)
+			case "tc":
+				tables.addCells(1)
+			case "gridSpan":
+				// The cell spans more columns than the one counted.
+				if span, err := strconv.Atoi(attr(t, "val")); err == nil && span > 1 {
+					tables.addCells(min(span, 64) - 1)
+				}
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "t":
+				inText = false
+			case "p":
+				doc.addHeading(level, paragraph.String())
+			case "tbl":
+				tables.endTable()
+			}
+		case xml.CharData:
+			if inText {
+				paragraph.Write(t)
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = pkg.Walk("docProps/core.xml", false, elementText(func(name, text string) {
+		switch name {
+		case "title":
+			doc.properties.Title = text
+		case "subject":
+			doc.properties.Subject = text
+		case "description":
+			doc.properties.Description = text
+		case "creator":
+			doc.properties.Author = text
+		case "lastModifiedBy":
+			doc.properties.LastModifiedBy = text
+		case "keywords":
+			doc.properties.Keywords = splitKeywords(text)
+		case "created":
+			doc.properties.Created = parseTime(text)
+		case "modified":
+			doc.properties.Modified = parseTime(text)
+		}
+	}))
+	if err != nil {
+		return nil, err
+	}
+	err = pkg.Walk("docProps/app.xml", false, elementText(func(name, text string) {
+		if name == "Pages" {
+			doc.pages, _ = strconv.Atoi(text)
+		}
+	}))
+	if err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// outlineLevel converts a zero-based Word outline level to a heading level.
+// Level 9 marks body text.
+func outlineLevel(value string) int {
+	level, err := strconv.Atoi(value)
+	if err != nil || level < 0 || level > 8 {
+		return 0
+	}
+	return level + 1
+}
+
+// addRunFonts records the fonts of a w:rFonts element. Theme fonts, set by
+// the *Theme attributes, are left out as they are only named in the theme.
+func addRunFonts(doc *document, element xml.StartElement) {
+	for _, a := range element.Attr {
+		switch a.Name.Local {
+		case "ascii", "hAnsi", "eastAsia", "cs":
+			doc.addFont(a.Value)
+		}
+	}
+}
+
+// analyzeODT reads the structure of an OpenDocument text document.
+func analyzeODT(data []byte) (*document, error) {
+	pkg, err := docparse.OpenPackage(data)
+	if err != nil {
+		return nil, err
+	}
+	doc := &document{images: packageImages(pkg, "Pictures/")}
+
+	visitFonts := func(token xml.Token) bool {
+		if t, ok := token.(xml.StartElement); ok && t.Name.Local == "text-properties" {
+			for _, name := range []string{"font-name", "font-name-asian", "font-name-complex"} {
+				doc.addFont(attr(t, name))
+			}
+		}
+		return true
+	}
+	if err := pkg.Walk("styles.xml", false, visitFonts); err != nil {
+		return nil, err
+	}
+
+	tables := tableStack{tables: &doc.tables}
+	var (
+		heading strings.Builder
+		level   int
+		depth   int
+	)
+	err = pkg.Walk("content.xml", true, func(token xml.Token) bool {
+		visitFonts(token)
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "h":
+				heading.Reset()
+				level, _ = strconv.Atoi(attr(t, "outline-level"))
+				level = max(level, 1)
+				depth++
+			case "s", "tab", "line-break":
+				if depth > 0 {
+					heading.WriteByte(' ')
+				}
+			case "table":
+				tables.startTable()
+			case "table-row":
+				tables.startRow(repeated(t, "number-rows-repeated"))
+			case "table-cell", "covered-table-cell":
+				tables.addCells(repeated(t, "number-columns-repeated"))
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "h":
+				depth--
+				doc.addHeading(level, heading.String())
+			case "table":
+				tables.endTable()
+			}
+		case xml.CharData:
+			if depth > 0 {
+				heading.Write(t)
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	visitProperties := elementText(func(name, text string) {
+		switch name {
+		case "title":
+			doc.properties.Title = text
+		case "subject":
+			doc.properties.Subject = text
+		case "description":
+			doc.properties.Description = text
+		case "initial-creator":
+			doc.properties.Author = text
+		case "creator":
+			doc.properties.LastModifiedBy = text
+		case "keyword":
+			doc.properties.Keywords = append(doc.properties.Keywords, splitKeywords(text)...)
+		case "creation-date":
+			doc.properties.Created = parseTime(text)
+		case "date":
+			doc.properties.Modified = parseTime(text)
+		}
+	})
+	err = pkg.Walk("meta.xml", false, func(token xml.Token) bool {
+		if t, ok := token.(xml.StartElement); ok && t.Name.Local == "document-statistic" {
+			doc.pages, _ = strconv.Atoi(attr(t, "page-count"))
+		}
+		return visitProperties(token)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// repeated returns the repeat count of an OpenDocument table row or cell,
+// capped so that filler rows and columns do not inflate the table.
+func repeated(element xml.StartElement, name string) int {
+	n, err := strconv.Atoi(attr(element, name))
+	if err != nil || n < 1 {
+		return 1
+	}
+	return min(n, 1024)
+}
+
+// elementText returns a visitor handing the trimmed text of each element
+// without child elements to fn, with the local name of the element.
+func elementText(fn func(name, text string)) func(xml.Token) bool {
+	var (
+		name string
+		text strings.Builder
+	)
+	return func(token xml.Token) bool {
+		switch t := token.(type) {
+		case xml.StartElement:
+			name = t.Name.Local
+			text.Reset()
+		case xml.CharData:
+			text.Write(t)
+		case xml.EndElement:
+			if t.Name.Local == name {
+				if value := strings.TrimSpace(text.String()); value != "" {
+					fn(name, value)
+				}
+			}
+			name = ""
+		}
+		return true
+	}
+}
@@ -0,0 +1,96 @@
+package docanalysis
+
+import (
+	"errors"
+	"time"
+
+	"github.com/a1y/doc-formatter/internal/storage/util/textextract"
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("document analysis is not supported for this format")
+	// ErrDocumentTooLarge and ErrMalformedDocument are shared with text
+	// extraction, which the analysis relies on.
+	ErrDocumentTooLarge  = textextract.ErrDocumentTooLarge
+	ErrMalformedDocument = textextract.ErrMalformedDocument
+)
+
+const (
+	// Version identifies the analysis made by this package. It is bumped
+	// whenever the analysis changes so that stored results are recomputed.
+	Version = 1
+	// WordsPerPage is the number of words of a page when the document does
+	// not record its page count, a single-spaced page of 12pt text.
+	WordsPerPage = 500
+	// MaxHeadingLength is the length in bytes headings are truncated to.
+	MaxHeadingLength = 256
+)
+
+// Analysis describes the structure of a document.
+type Analysis struct {
+	// Outline is the tree of the headings of the document.
+	Outline    []Heading  `json:"outline"`
+	Statistics Statistics `json:"statistics"`
+	Images     []Image    `json:"images"`
+	Tables     []Table    `json:"tables"`
+	// Language is the ISO 639-1 code of the language the text is written
+	// in, empty when it could not be told.
+	Language string `json:"language,omitempty"`
+	// Fonts are the font families set by the styles and the text of the
+	// document, sorted by name.
+	Fonts      []string   `json:"fonts"`
+	Properties Properties `json:"properties"`
+}
+
+type Heading struct {
+	// Level ranges from 1 for top-level headings to 9.
+	Level    int       `json:"level"`
+	Text     string    `json:"text"`
+	Children []Heading `json:"children,omitempty"`
+}
+
+// Statistics are counted over the extracted text of the document, that is
+// its first textextract.MaxTextSize bytes.
+type Statistics struct {
+	Words int `json:"words"`
+	// Characters counts the characters but line breaks, CharactersNoSpaces
+	// leaves out all white space.
+	Characters         int `json:"characters"`
+	CharactersNoSpaces int `json:"charactersNoSpaces"`
+	Paragraphs         int `json:"paragraphs"`
+	// Pages is the page count recorded by the application that saved the
+	// document, or else estimated from the words at WordsPerPage.
+	Pages          int  `json:"pages"`
+	PagesEstimated bool `json:"pagesEstimated"`
+}
+
+// Image is an image embedded in or, for Markdown, linked from the document.
+type Image struct {
+	// Name is the path of the image in the document package, or the link
+	// target in Markdown.
+	Name        string `json:"name"`
+	ContentType string `json:"contentType,omitempty"`
+	// Size is the size in bytes of embedded images.
+	Size        int64  `json:"size,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// Table gives the size of a table. Rows include header rows and Columns is
+// the number of cells of the widest row.
+type Table struct {
+	Rows    int `json:"rows"`
+	Columns int `json:"columns"`
+}
+
+// Properties are the core properties of the document, or the front matter
+// of Markdown documents.
+type Properties struct {
+	Title          string     `json:"title,omitempty"`
+	Subject        string     `json:"subject,omitempty"`
+	Description    string     `json:"description,omitempty"`
+	Author         string     `json:"author,omitempty"`
+	LastModifiedBy string     `json:"lastModifiedBy,omitempty"`
+	Keywords       []string   `json:"keywords,omitempty"`
+	Created        *time.Time `json:"created,omitempty"`
+	Modified       *time.Time `json:"modified,omitempty"`
+}
@@ -0,0 +1,247 @@
+package docdiff
+
+import (
+	"regexp"
+	"strings"
+)
+
+// wordToken splits text into words, runs of white space and single other
+// characters, which together make up the whole text.
+var wordToken = regexp.MustCompile(`\s+|[\p{L}\p{N}_]+|[^\s\p{L}\p{N}_]`)
+
+// blockEdit is a step of the script turning the blocks of one document into
+// those of another. Blocks with the same text but another kind, level or
+// style are paired as modifications, as are blocks replacing each other.
+type blockEdit struct {
+	op       Op
+	from, to *Block
+	fields   []string
+}
+
+// Compare returns the changes from document from to document to. Text hunks
+// show contextLines unchanged lines around changes.
+func Compare(from, to *Document, contextLines int) *Diff {
+	d := &Diff{Changes: []Change{}, Hunks: []Hunk{}}
+	for _, e := range compareBlocks(from.Blocks, to.Blocks) {
+		switch e.op {
+		case OpEqual:
+			continue
+		case OpInsert:
+			d.Summary.Inserted++
+		case OpDelete:
+			d.Summary.Deleted++
+		case OpModify:
+			d.Summary.Modified++
+		}
+		d.Changes = append(d.Changes, Change{Op: e.op, Old: e.from, New: e.to, Fields: e.fields})
+	}
+
+	d.Hunks = textHunks(textLines(from.Text), textLines(to.Text), contextLines)
+	for _, hunk := range d.Hunks {
+		for _, line := range hunk.Lines {
+			switch line.Op {
+			case OpInsert:
+				d.Summary.LinesAdded++
+			case OpDelete:
+				d.Summary.LinesRemoved++
+			}
+		}
+	}
+	return d
+}
+
+// compareBlocks pairs the blocks of from and to.
+func compareBlocks(from, to []Block) []blockEdit {
+	var (
+		edits    []blockEdit
+		deleted  []*Block
+		inserted []*Block
+	)
+	// flush pairs the blocks deleted and inserted in between unchanged
+	// blocks, in order, as long as they are both tables or both text.
+	flush := func() {
+		i, j := 0, 0
+		for i < len(deleted) && j < len(inserted) {
+			if (deleted[i].Kind == KindTable) != (inserted[j].Kind == KindTable) {
+				edits = append(edits, blockEdit{op: OpDelete, from: deleted[i]})
+				i++
+				continue
+			}
+			edits = append(edits, blockEdit{op: OpModify, from: deleted[i], to: inserted[j], fields: changedFields(deleted[i], inserted[j])})
+			i++
+			j++
+		}
+		for ; i < len(deleted); i++ {
+			edits = append(edits, blockEdit{op: OpDelete, from: deleted[i]})
+		}
+		for ; j < len(inserted); j++ {
+			edits = append(edits, blockEdit{op: OpInsert, to: inserted[j]})
+		}
+		deleted, inserted = nil, nil
+	}
+
+	for _, e := range diff(blockKeys(from), blockKeys(to)) {
+		switch e.op {
+		case OpDelete:
+			deleted = append(deleted, &from[e.oldIndex])
+		case OpInsert:
+			inserted = append(inserted, &to[e.newIndex])
+		default:
+			flush()
+			a, b := &from[e.oldIndex], &to[e.newIndex]
+			if fields := changedFields(a, b); len(fields) > 0 {
+				edits = append(edits, blockEdit{op: OpModify, from: a, to: b, fields: fields})
+			} else {
+				edits = append(edits, blockEdit{op: OpEqual, from: a, to: b})
+			}
+		}
+	}
+	flush()
+	return edits
+}
+
+// blockKeys returns the keys blocks are matched by: their text, kept apart
+// for tables.
+func blockKeys(blocks []Block) []string {
+	keys := make([]string, len(blocks))
+	for i, block := range blocks {
+		keys[i] = block.Text
+		if block.Kind == KindTable {
+			keys[i] = "\x00table\x00" + block.Text
+		}
+	}
+	return keys
+}
+
+func changedFields(a, b *Block) []string {
+	var fields []string
+	if a.Text != b.Text {
+		fields = append(fields, FieldText)
+	}
+	if a.Kind != b.Kind {
+		fields = append(fields, FieldKind)
+	}
+	if a.Level != b.Level {
+		fields = append(fields, FieldLevel)
+	}
+	if a.Style != b.Style {
+		fields = append(fields, FieldStyle)
+	}
+	return fields
+}
+
+// textLines splits text into lines, none for empty text.
+func textLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(text, "\n")
+}
+
+// textHunks returns the hunks of the line diff from a to b, each with
+// contextLines unchanged lines around its changes. Changes closer than twice
+// that share a hunk.
+func textHunks(a, b []string, contextLines int) []Hunk {
+	edits := diff(a, b)
+	// oldPos[i] and newPos[i] count the lines of a and b before edit i.
+	oldPos := make([]int, len(edits)+1)
+	newPos := make([]int, len(edits)+1)
+	var changes []int
+	for i, e := range edits {
+		oldPos[i+1], newPos[i+1] = oldPos[i], newPos[i]
+		if e.op != OpInsert {
+			oldPos[i+1]++
+		}
+		if e.op != OpDelete {
+			newPos[i+1]++
+		}
+		if e.op != OpEqual {
+			changes = append(changes, i)
+		}
+	}
+
+	hunks := []Hunk{}
+	for len(changes) > 0 {
+		last := 0
+		for last+1 < len(changes) && changes[last+1]-changes[last] <= 2*contextLines+1 {
+			last++
+		}
+		start := max(changes[0]-contextLines, 0)
+		end := min(changes[last]+contextLines+1, len(edits))
+		changes = changes[last+1:]
+
+		hunk := Hunk{
+			OldStart: oldPos[start] + 1,
+			OldLines: oldPos[end] - oldPos[start],
+			NewStart: newPos[start] + 1,
+			NewLines: newPos[end] - newPos[start],
+			Lines:    make([]Line, 0, end-start),
+		}
+		for _, e := range edits[start:end] {
+			line := Line{Op: e.op}
+			if e.op == OpInsert {
+				line.Text = b[e.newIndex]
+			} else {
+				line.Text = a[e.oldIndex]
+			}
+			hunk.Lines = append(hunk.Lines, line)
+		}
+		hunks = append(hunks, hunk)
+	}
+	return hunks
+}
+
+// textRun is a run of text of a paragraph, unchanged, inserted or deleted.
+type textRun struct {
+	op   Op
+	text string
+}
+
+// diffWords returns the runs turning text a into text b word by word. The
+// changes of a run are given as the text deleted followed by the text
+// inserted, and white space between two changes joins them, so that a
+// replaced phrase reads as one deletion and one insertion.
+func diffWords(a, b string) []textRun {
+	from := wordToken.FindAllString(a, -1)
+	to := wordToken.FindAllString(b, -1)
+	edits := diff(from, to)
+
+	var (
+		runs              []textRun
+		deleted, inserted strings.Builder
+	)
+	flush := func() {
+		if deleted.Len() > 0 {
+			runs = append(runs, textRun{op: OpDelete, text: deleted.String()})
+		}
+		if inserted.Len() > 0 {
+			runs = append(runs, textRun{op: OpInsert, text: inserted.String()})
+		}
+		deleted.Reset()
+		inserted.Reset()
+	}
+	for i, e := range edits {
+		switch e.op {
+		case OpDelete:
+			deleted.WriteString(from[e.oldIndex])
+		case OpInsert:
+			inserted.WriteString(to[e.newIndex])
+		default:
+			token := from[e.oldIndex]
+			if strings.TrimSpace(token) == "" && i > 0 && edits[i-1].op != OpEqual &&
+				i+1 < len(edits) && edits[i+1].op != OpEqual {
+				deleted.WriteString(token)
+				inserted.WriteString(token)
+				continue
+			}
+			flush()
+			if n := len(runs); n > 0 && runs[n-1].op == OpEqual {
+				runs[n-1].text += token
+			} else {
+				runs = append(runs, textRun{op: OpEqual, text: token})
+			}
+		}
+	}
+	flush()
+	return runs
+}
@@ -0,0 +1,237 @@
+package docdiff
+
+import (
+	"archive/zip"
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/a1y/doc-formatter/internal/storage/util/docparse"
+	"github.com/a1y/doc-formatter/internal/storage/util/textextract"
+	"github.com/stretchr/testify/require"
+)
+
+// zipDocument packages files as a ZIP archive, the container of DOCX and ODT
+// documents.
+func zipDocument(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, content := range files {
+		f, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func parse(t *testing.T, contentType, content string) *Document {
+	t.Helper()
+
+	doc, err := Parse(contentType, strings.NewReader(content))
+	require.NoError(t, err)
+	return doc
+}
+
+// apply replays edits on a and returns the sequence they turn it into.
+func apply(t *testing.T, a, b []string, edits []edit) []string {
+	t.Helper()
+
+	out := []string{}
+	next := 0
+	for _, e := range edits {
+		switch e.op {
+		case OpEqual:
+			require.Equal(t, next, e.oldIndex)
+			require.Equal(t, a[e.oldIndex], b[e.newIndex])
+			out = append(out, a[e.oldIndex])
+			next++
+		case OpDelete:
+			require.Equal(t, next, e.oldIndex)
+			next++
+		case OpInsert:
+			out = append(out, b[e.newIndex])
+		}
+	}
+	require.Equal(t, len(a), next)
+	return out
+}
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		a, b  string
+		edits int
+	}{
+		{"", "", 0},
+		{"abc", "abc", 0},
+		{"", "abc", 3},
+		{"abc", "", 3},
+		{"abcabba", "cbabac", 5},
+		{"the quick brown fox", "the quick red fox jumps", 12},
+	}
+	for _, tt := range tests {
+		a, b := strings.Split(tt.a, ""), strings.Split(tt.b, "")
+		edits := diff(a, b)
+		require.Equal(t, b, apply(t, a, b, edits), "%q -> %q", tt.a, tt.b)
+		changes := 0
+		for _, e := range edits {
+			if e.op != OpEqual {
+				changes++
+			}
+		}
+		require.Equal(t, tt.edits, changes, "%q -> %q", tt.a, tt.b)
+	}
+}
+
+func TestDiff_TooManyEdits(t *testing.T) {
+	a := make([]int, maxEdits)
+	b := make([]int, maxEdits)
+	for i := range a {
+		a[i], b[i] = i, -i-1
+	}
+	a = append([]int{-1}, a...)
+	b = append([]int{-1}, b...)
+
+	edits := diff(a, b)
+	require.Len(t, edits, 1+2*maxEdits)
+	require.Equal(t, OpEqual, edits[0].op)
+	require.Equal(t, OpDelete, edits[1].op)
+	require.Equal(t, OpInsert, edits[len(edits)-1].op)
+}
+
+func TestParse(t *testing.T) {
+	docx := zipDocument(t, map[string]string{
+		"word/styles.xml": `<w:styles xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">
+<w:style w:styleId="Titre1"><w:name w:val="heading 1"/></w:style>
+<w:style w:styleId="Citation"><w:name w:val="Quote"/></w:style>
+</w:styles>`,
+		"word/document.xml": `<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>
+<w:p><w:pPr><w:pStyle w:val="Titre1"/></w:pPr><w:r><w:t>Report</w:t></w:r></w:p>
+<w:p/>
+<w:p><w:pPr><w:pStyle w:val="Citation"/></w:pPr><w:r><w:t>Revenue</w:t><w:tab/><w:t>grew</w:t></w:r><w:del><w:r><w:delText>fell</w:delText></w:r></w:del></w:p>
+<w:p><w:pPr><w:numPr><w:numId w:val="1"/></w:numPr></w:pPr><w:r><w:t>First point</w:t></w:r></w:p>
+<w:tbl><w:tr><w:tc><w:p><w:r><w:t>Q1</w:t></w:r></w:p></w:tc><w:tc><w:p><w:r><w:t>10</w:t></w:r></w:p></w:tc></w:tr>
+<w:tr><w:tc><w:p><w:r><w:t>Q2</w:t></w:r></w:p></w:tc><w:tc><w:tbl><w:tr><w:tc><w:p><w:r><w:t>nested</w:t></w:r></w:p></w:tc></w:tr></w:tbl><w:p/></w:tc></w:tr></w:tbl>
+</w:body></w:document>`,
+	})
+	require.Equal(t, []Block{
+		{Index: 0, Kind: KindHeading, Level: 1, Style: "heading 1", Text: "Report"},
+		{Index: 1, Kind: KindParagraph, Style: "Quote", Text: "Revenue grew"},
+		{Index: 2, Kind: KindList, Text: "First point"},
+		{Index: 3, Kind: KindTable, Text: "Q1\t10\nQ2\tnested", Rows: [][]string{{"Q1", "10"}, {"Q2", "nested"}}},
+	}, parse(t, textextract.TypeDOCX, string(docx)).Blocks)
+
+	odt := zipDocument(t, map[string]string{
+		"content.xml": `<office:document-content xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0" xmlns:table="urn:oasis:names:tc:opendocument:xmlns:table:1.0">
+<office:body><office:text>
+<text:h text:outline-level="2" text:style-name="Heading_20_2">Scope</text:h>
+<text:p text:style-name="P1">Two<text:s text:c="3"/>words</text:p>
+<text:list><text:list-item><text:p>Item</text:p></text:list-item></text:list>
+<table:table><table:table-row><table:table-cell><text:p>a</text:p></table:table-cell><table:table-cell table:number-columns-repeated="1000"/></table:table-row>
+<table:table-row table:number-rows-repeated="2"><table:table-cell><text:p>b</text:p></table:table-cell></table:table-row>
+<table:table-row table:number-rows-repeated="500"><table:table-cell/></table:table-row></table:table>
+</office:text></office:body></office:document-content>`,
+	})
+	require.Equal(t, []Block{
+		{Index: 0, Kind: KindHeading, Level: 2, Style: "Heading_20_2", Text: "Scope"},
+		{Index: 1, Kind: KindParagraph, Style: "P1", Text: "Two words"},
+		{Index: 2, Kind: KindList, Text: "Item"},
+		{Index: 3, Kind: KindTable, Text: "a\nb\nb", Rows: [][]string{{"a"}, {"b"}, {"b"}}},
+	}, parse(t, textextract.TypeODT, string(odt)).Blocks)
+
+	md := "---\ntitle: Notes\n---\nNotes\n=====\n\nSome *emphasis* and a [link](http://x).\nSame paragraph.\n\n" +
+		"- one\n- two\n\n> quoted\n> text\n\n```\ncode\n  indented\n```\n\n---\n\n| A | B |\n|---|---|\n| 1 | 2 |\n"
+	require.Equal(t, []Block{
+		{Index: 0, Kind: KindHeading, Level: 1, Text: "Notes"},
+		{Index: 1, Kind: KindParagraph, Text: "Some emphasis and a link. Same paragraph."},
+		{Index: 2, Kind: KindList, Text: "one"},
+		{Index: 3, Kind: KindList, Text: "two"},
+		{Index: 4, Kind: KindQuote, Text: "quoted text"},
+		{Index: 5, Kind: KindCode, Text: "code\n  indented"},
+		{Index: 6, Kind: KindTable, Text: "A\tB\n1\t2", Rows: [][]string{{"A", "B"}, {"1", "2"}}},
+	}, parse(t, textextract.TypeMarkdown, md).Blocks)
+
+	require.Equal(t, []Block{
+		{Index: 0, Kind: KindParagraph, Text: "first paragraph on two lines"},
+		{Index: 1, Kind: KindParagraph, Text: "second"},
+	}, parse(t, textextract.TypeText, "first paragraph\non two lines\n\n\nsecond\n").Blocks)
+
+	_, err := Parse("application/pdf", strings.NewReader("%PDF"))
+	require.ErrorIs(t, err, ErrUnsupportedFormat)
+	_, err = Parse(textextract.TypeDOCX, strings.NewReader("not a zip"))
+	require.ErrorIs(t, err, ErrMalformedDocument)
+}
+
+func TestCompare(t *testing.T) {
+	from := parse(t, textextract.TypeMarkdown, "# Intro\n\nThe quick brown fox.\n\n## Details\n\nUnchanged text.\n\nRemoved paragraph.\n\n| A | B |\n|---|---|\n| 1 | 2 |\n")
+	to := parse(t, textextract.TypeMarkdown, "# Intro\n\nThe slow brown fox.\n\n# Details\n\nUnchanged text.\n\n| A | B |\n|---|---|\n| 1 | 3 |\n\nAdded paragraph.\n")
+
+	d := Compare(from, to, 1)
+	require.Equal(t, []Change{
+		{Op: OpModify, Old: &from.Blocks[1], New: &to.Blocks[1], Fields: []string{FieldText}},
+		{Op: OpModify, Old: &from.Blocks[2], New: &to.Blocks[2], Fields: []string{FieldLevel}},
+		{Op: OpDelete, Old: &from.Blocks[4]},
+		{Op: OpModify, Old: &from.Blocks[5], New: &to.Blocks[4], Fields: []string{FieldText}},
+		{Op: OpInsert, New: &to.Blocks[5]},
+	}, d.Changes)
+	require.Equal(t, Summary{Inserted: 1, Deleted: 1, Modified: 3, LinesAdded: 4, LinesRemoved: 4}, d.Summary)
+
+	require.Len(t, d.Hunks, 2)
+	// Paragraphs of the text are separated by blank lines.
+	require.Equal(t, Hunk{OldStart: 2, OldLines: 3, NewStart: 2, NewLines: 3, Lines: []Line{
+		{Op: OpEqual, Text: ""},
+		{Op: OpDelete, Text: "The quick brown fox."},
+		{Op: OpInsert, Text: "The slow brown fox."},
+		{Op: OpEqual, Text: ""},
+	}}, d.Hunks[0])
+	require.Equal(t, 8, d.Hunks[1].OldStart)
+	require.Equal(t, 6, d.Hunks[1].OldLines)
+
+	same := Compare(from, from, DefaultContextLines)
+	require.Empty(t, same.Changes)
+	require.Empty(t, same.Hunks)
+}
+
+func TestDiffWords(t *testing.T) {
+	require.Equal(t, []textRun{
+		{op: OpEqual, text: "the "},
+		{op: OpDelete, text: "quick brown"},
+		{op: OpInsert, text: "slow red"},
+		{op: OpEqual, text: " fox"},
+	}, diffWords("the quick brown fox", "the slow red fox"))
+	require.Equal(t, []textRun{{op: OpInsert, text: "new"}}, diffWords("", "new"))
+}
+
+func TestRedline(t *testing.T) {
+	from := parse(t, textextract.TypeMarkdown, "# Title\n\nThe quick fox.\n\nGone <soon> & forgotten.\n\n| A |\n|---|\n| 1 |\n")
+	to := parse(t, textextract.TypeMarkdown, "## Title\n\nThe slow fox.\n\n| A |\n|---|\n| 1 |\n| 2 |\n\nBrand new.\n")
+
+	data, err := Redline(from, to, "Reviewer", time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+	require.NoError(t, err)
+
+	pkg, err := docparse.OpenPackage(data)
+	require.NoError(t, err)
+	document, err := pkg.Part("word/document.xml", true)
+	require.NoError(t, err)
+	body := string(document)
+	require.Contains(t, body, `<w:pStyle w:val="Heading2"/><w:pPrChange w:id="1" w:author="Reviewer" w:date="2026-01-02T03:04:05Z"><w:pPr><w:pStyle w:val="Heading1"/></w:pPr></w:pPrChange>`)
+	require.Contains(t, body, `<w:del w:id="2" w:author="Reviewer" w:date="2026-01-02T03:04:05Z"><w:r><w:delText xml:space="preserve">quick</w:delText></w:r></w:del>`)
+	require.Contains(t, body, `<w:ins w:id="3" w:author="Reviewer" w:date="2026-01-02T03:04:05Z"><w:r><w:t xml:space="preserve">slow</w:t></w:r></w:ins>`)
+	require.Contains(t, body, `<w:delText xml:space="preserve">Gone &lt;soon&gt; &amp; forgotten.</w:delText>`)
+	require.Contains(t, body, `<w:trPr><w:ins `)
+
+	// Accepting every change gives the new document.
+	accepted, err := Parse(RedlineContentType, bytes.NewReader(data))
+	require.NoError(t, err)
+	var texts []string
+	for _, block := range accepted.Blocks {
+		texts = append(texts, block.Text)
+	}
+	require.Equal(t, []string{"Title", "The slow fox.", "A\n1\n2", "Brand new."}, texts)
+	require.Equal(t, KindHeading, accepted.Blocks[0].Kind)
+	require.Equal(t, 2, accepted.Blocks[0].Level)
+}
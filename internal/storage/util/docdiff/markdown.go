@@ -0,0 +1,95 @@
+package docdiff
+
+import (
+	"strings"
+
+	"github.com/a1y/doc-formatter/internal/storage/util/docparse"
+)
+
+// parseMarkdown reads the blocks of a Markdown document. Front matter is
+// left out, as it is not part of the text.
+func parseMarkdown(data []byte) []Block {
+	_, lines := docparse.SplitFrontMatter(docparse.MarkdownLines(data))
+
+	var (
+		blocks []Block
+		// kind and text hold the block being read across lines.
+		kind string
+		text []string
+		// fence closes the code block being read.
+		fence string
+	)
+	flush := func() {
+		if len(text) > 0 {
+			joined := strings.Join(text, " ")
+			if kind == KindCode {
+				joined = strings.Join(text, "\n")
+			}
+			blocks = append(blocks, Block{Kind: kind, Text: joined})
+		}
+		kind, text = "", nil
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		if kind == KindCode {
+			if docparse.ClosesFence(line, fence) {
+				flush()
+			} else {
+				text = append(text, line)
+			}
+			continue
+		}
+
+		trimmed := strings.TrimSpace(line)
+		rows, next, isTable := docparse.Table(lines, i)
+		switch {
+		case docparse.OpenFence(line) != "":
+			flush()
+			kind, fence = KindCode, docparse.OpenFence(line)
+		case trimmed == "":
+			flush()
+		case docparse.ATXHeading.MatchString(line):
+			flush()
+			m := docparse.ATXHeading.FindStringSubmatch(line)
+			blocks = append(blocks, Block{Kind: KindHeading, Level: len(m[1]), Text: docparse.InlineText(m[2])})
+		case kind == KindParagraph && docparse.SetextH1.MatchString(line):
+			blocks = append(blocks, Block{Kind: KindHeading, Level: 1, Text: strings.Join(text, " ")})
+			kind, text = "", nil
+		case kind == KindParagraph && docparse.SetextH2.MatchString(line):
+			blocks = append(blocks, Block{Kind: KindHeading, Level: 2, Text: strings.Join(text, " ")})
+			kind, text = "", nil
+		case docparse.ThematicBreak.MatchString(line):
+			flush()
+		case isTable:
+			flush()
+			for _, row := range rows {
+				for j, cell := range row {
+					row[j] = docparse.InlineText(cell)
+				}
+			}
+			blocks = append(blocks, tableBlock(rows))
+			// The loop steps to the line after the table.
+			i = next - 1
+		case docparse.ListItem.MatchString(line):
+			flush()
+			kind, text = KindList, []string{docparse.InlineText(docparse.ListItem.ReplaceAllString(line, ""))}
+		case docparse.BlockQuote.MatchString(line):
+			if kind != KindQuote {
+				flush()
+				kind = KindQuote
+			}
+			if quoted := docparse.InlineText(docparse.BlockQuote.ReplaceAllString(line, "")); quoted != "" {
+				text = append(text, quoted)
+			}
+		default:
+			// Lines following a list item or a quote continue it.
+			if kind == "" {
+				kind = KindParagraph
+			}
+			text = append(text, docparse.InlineText(trimmed))
+		}
+	}
+	flush()
+	return blocks
+}
@@ -0,0 +1,137 @@
+package docdiff
+
+// edit is a step of the script turning one sequence into another. Equal
+// steps refer to an element of both sequences, deletions to an element of
+// the old one only and insertions to an element of the new one only.
+type edit struct {
+	op       Op
+	oldIndex int
+	newIndex int
+}
+
+// diff returns the shortest edit script turning a into b, found with the
+// Myers algorithm. Sequences needing more than maxEdits edits are reported
+// as their common prefix and suffix around a replaced middle.
+func diff[T comparable](a, b []T) []edit {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	edits := make([]edit, 0, max(len(a), len(b)))
+	for i := 0; i < prefix; i++ {
+		edits = append(edits, edit{op: OpEqual, oldIndex: i, newIndex: i})
+	}
+	middle, ok := myers(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])
+	if !ok {
+		middle = replace(len(a)-prefix-suffix, len(b)-prefix-suffix)
+	}
+	for _, e := range middle {
+		e.oldIndex += prefix
+		e.newIndex += prefix
+		edits = append(edits, e)
+	}
+	for i := suffix; i > 0; i-- {
+		edits = append(edits, edit{op: OpEqual, oldIndex: len(a) - i, newIndex: len(b) - i})
+	}
+	return edits
+}
+
+// replace returns the script deleting n elements, then inserting m.
+func replace(n, m int) []edit {
+	edits := make([]edit, 0, n+m)
+	for i := 0; i < n; i++ {
+		edits = append(edits, edit{op: OpDelete, oldIndex: i, newIndex: -1})
+	}
+	for j := 0; j < m; j++ {
+		edits = append(edits, edit{op: OpInsert, oldIndex: -1, newIndex: j})
+	}
+	return edits
+}
+
+// myers returns the shortest edit script turning a into b, or false when it
+// needs more than maxEdits edits.
+func myers[T comparable](a, b []T) ([]edit, bool) {
+	n, m := len(a), len(b)
+	if n == 0 || m == 0 {
+		return replace(n, m), true
+	}
+
+	limit := min(n+m, maxEdits)
+	// v[k+offset] is the furthest x reached on diagonal k. trace keeps, for
+	// each number of edits d, the diagonals -d+1..d-1 of v before the step,
+	// which is what backtracking the step reads.
+	offset := limit + 1
+	v := make([]int, 2*limit+3)
+	var trace [][]int
+	for d := 0; d <= limit; d++ {
+		if d == 0 {
+			trace = append(trace, nil)
+		} else {
+			trace = append(trace, append([]int(nil), v[offset-d+1:offset+d]...))
+		}
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				return backtrack(trace, n, m), true
+			}
+		}
+	}
+	return nil, false
+}
+
+// backtrack walks the trace of myers back from the end of both sequences.
+func backtrack(trace [][]int, n, m int) []edit {
+	var edits []edit
+	x, y := n, m
+	for d := len(trace) - 1; d > 0; d-- {
+		// at reads diagonal k of v before step d.
+		at := func(k int) int { return trace[d][k+d-1] }
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && at(k-1) < at(k+1)) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := at(prevK)
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			x--
+			y--
+			edits = append(edits, edit{op: OpEqual, oldIndex: x, newIndex: y})
+		}
+		if x == prevX {
+			y--
+			edits = append(edits, edit{op: OpInsert, oldIndex: -1, newIndex: y})
+		} else {
+			x--
+			edits = append(edits, edit{op: OpDelete, oldIndex: x, newIndex: -1})
+		}
+	}
+	for x > 0 && y > 0 {
+		x--
+		y--
+		edits = append(edits, edit{op: OpEqual, oldIndex: x, newIndex: y})
+	}
+
+	for i, j := 0, len(edits)-1; i < j; i, j = i+1, j-1 {
+		edits[i], edits[j] = edits[j], edits[i]
+	}
+	return edits
+}
@@ -0,0 +1,420 @@
+// Package docdiff compares documents block by block and line by line, and
+// renders the changes as a redline DOCX with tracked changes.
+package docdiff
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/a1y/doc-formatter/internal/storage/util/docparse"
+	"github.com/a1y/doc-formatter/internal/storage/util/textextract"
+)
+
+// maxRepeat caps the repeat counts of OpenDocument table rows and cells,
+// which spreadsheet-like tables use to fill whole pages.
+const maxRepeat = 64
+
+// Supported reports whether documents of contentType can be compared.
+func Supported(contentType string) bool {
+	return textextract.Supported(contentType)
+}
+
+// Parse reads the blocks and the text of the document of contentType read
+// from r. Documents larger than textextract.MaxDocumentSize are rejected
+// with ErrDocumentTooLarge.
+func Parse(contentType string, r io.Reader) (*Document, error) {
+	if !Supported(contentType) {
+		return nil, ErrUnsupportedFormat
+	}
+	data, err := io.ReadAll(io.LimitReader(r, textextract.MaxDocumentSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > textextract.MaxDocumentSize {
+		return nil, ErrDocumentTooLarge
+	}
+
+	text, err := textextract.Extract(contentType, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	doc := &Document{Text: text}
+	switch contentType {
+	case textextract.TypeDOCX:
+		doc.Blocks, err = parseDOCX(data)
+	case textextract.TypeODT:
+		doc.Blocks, err = parseODT(data)
+	case textextract.TypeMarkdown:
+		doc.Blocks = parseMarkdown(data)
+	case textextract.TypeText:
+		doc.Blocks = parseText(text)
+	default:
+		// Extracted HTML keeps each block on its own line.
+		doc.Blocks = parseLines(text)
+	}
+	if err != nil {
+		return nil, err
+	}
+	for i := range doc.Blocks {
+		doc.Blocks[i].Index = i
+	}
+	return doc, nil
+}
+
+// blockBuilder collects the blocks of a document along with the table being
+// read, if any. Tables nested in a table are read as text of the cell they
+// are in.
+type blockBuilder struct {
+	blocks []Block
+	text   strings.Builder
+
+	tableDepth int
+	rows       [][]string
+	cell       strings.Builder
+}
+
+// addText adds text to the paragraph or the table cell being read.
+func (b *blockBuilder) addText(text string) {
+	if b.tableDepth > 0 {
+		b.cell.WriteString(text)
+		return
+	}
+	b.text.WriteString(text)
+}
+
+// endParagraph adds the paragraph read unless it is blank, which only
+// spaces the layout. Paragraphs of a table cell are joined by a space.
+func (b *blockBuilder) endParagraph(kind string, level int, style string) {
+	if b.tableDepth > 0 {
+		b.cell.WriteByte(' ')
+		return
+	}
+	text := strings.Join(strings.Fields(b.text.String()), " ")
+	b.text.Reset()
+	if text == "" {
+		return
+	}
+	b.blocks = append(b.blocks, Block{Kind: kind, Level: level, Style: style, Text: text})
+}
+
+func (b *blockBuilder) startTable() {
+	b.tableDepth++
+	if b.tableDepth == 1 {
+		b.rows = nil
+	}
+}
+
+func (b *blockBuilder) startRow() {
+	if b.tableDepth == 1 {
+		b.rows = append(b.rows, nil)
+	}
+}
+
+// endCell ends a cell read repeat times.
+func (b *blockBuilder) endCell(repeat int) {
+	if b.tableDepth != 1 || len(b.rows) == 0 {
+		return
+	}
+	text := strings.Join(strings.Fields(b.cell.String()), " ")
+	b.cell.Reset()
+	row := &b.rows[len(b.rows)-1]
+	for i := 0; i < repeat; i++ {
+		*row = append(*row, text)
+	}
+}
+
+// endRow ends a row read repeat times.
+func (b *blockBuilder) endRow(repeat int) {
+	if b.tableDepth != 1 || len(b.rows) == 0 {
+		return
+	}
+	row := b.rows[len(b.rows)-1]
+	for i := 1; i < repeat; i++ {
+		b.rows = append(b.rows, append([]string(nil), row...))
+	}
+}
+
+func (b *blockBuilder) endTable() {
+	b.tableDepth--
+	if b.tableDepth > 0 {
+		// The nested table is part of the text of the outer cell.
+		b.cell.WriteByte(' ')
+		return
+	}
+	if rows := trimRows(b.rows); len(rows) > 0 {
+		b.blocks = append(b.blocks, tableBlock(rows))
+	}
+	b.rows = nil
+}
+
+// trimRows drops the empty cells ending each row and the empty rows ending
+// the table, which repeated filler cells leave behind.
+func trimRows(rows [][]string) [][]string {
+	for i, row := range rows {
+		for len(row) > 0 && row[len(row)-1] == "" {
+			row = row[:len(row)-1]
+		}
+		rows[i] = row
+	}
+	for len(rows) > 0 && len(rows[len(rows)-1]) == 0 {
+		rows = rows[:len(rows)-1]
+	}
+	return rows
+}
+
+func tableBlock(rows [][]string) Block {
+	lines := make([]string, len(rows))
+	for i, row := range rows {
+		lines[i] = strings.Join(row, "\t")
+	}
+	return Block{Kind: KindTable, Text: strings.Join(lines, "\n"), Rows: rows}
+}
+
+func attr(element xml.StartElement, local string) string {
+	for _, a := range element.Attr {
+		if a.Name.Local == local {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// repeated returns the repeat count held by attribute name of an
+// OpenDocument table row or cell.
+func repeated(element xml.StartElement, name string) int {
+	n, err := strconv.Atoi(attr(element, name))
+	if err != nil || n < 1 {
+		return 1
+	}
+	return min(n, maxRepeat)
+}
+
+// parseDOCX reads the blocks of a WordprocessingML document.
+func parseDOCX(data []byte) ([]Block, error) {
+	pkg, err := docparse.OpenPackage(data)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]string)
+	levels := make(map[string]int)
+	var styleID string
+	err = pkg.Walk("word/styles.xml", false, func(token xml.Token) bool {
+		t, ok := token.(xml.StartElement)
+		if !ok {
+			return true
+		}
+		switch t.Name.Local {
+		case "style":
+			styleID = attr(t, "styleId")
+		case "name":
+			name := attr(t, "val")
+			names[styleID] = name
+			if level, ok := headingLevel(name); ok && levels[styleID] == 0 {
+				levels[styleID] = level
+			}
+		case "outlineLvl":
+			if level, err := strconv.Atoi(attr(t, "val")); err == nil && level >= 0 && level < 9 {
+				levels[styleID] = level + 1
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		b      blockBuilder
+		inText bool
+		style  string
+		list   bool
+		// inChange is set within the former properties of a paragraph
+		// whose formatting change is tracked.
+		inChange bool
+	)
+	err = pkg.Walk("word/document.xml", true, func(token xml.Token) bool {
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "p":
+				style, list = "", false
+			case "pPrChange":
+				inChange = true
+			case "pStyle":
+				if !inChange {
+					style = attr(t, "val")
+				}
+			case "numPr":
+				list = list || !inChange
+			case "t":
+				inText = true
+			case "tab", "br", "cr":
+				b.addText(" ")
+			case "tbl":
+				b.startTable()
+			case "tr":
+				b.startRow()
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "t":
+				inText = false
+			case "pPrChange":
+				inChange = false
+			case "p":
+				kind, level := KindParagraph, levels[style]
+				switch {
+				case level > 0:
+					kind = KindHeading
+				case list:
+					kind = KindList
+				}
+				name := names[style]
+				if name == "" {
+					name = style
+				}
+				b.endParagraph(kind, level, name)
+			case "tc":
+				b.endCell(1)
+			case "tr":
+				b.endRow(1)
+			case "tbl":
+				b.endTable()
+			}
+		case xml.CharData:
+			if inText {
+				b.addText(string(t))
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return b.blocks, nil
+}
+
+// headingLevel returns the level of the built-in heading styles of Word,
+// whose names are stored in English whatever the language of the user.
+func headingLevel(name string) (int, bool) {
+	rest, ok := strings.CutPrefix(strings.ToLower(name), "heading ")
+	if !ok {
+		return 0, false
+	}
+	level, err := strconv.Atoi(rest)
+	if err != nil || level < 1 || level > 9 {
+		return 0, false
+	}
+	return level, true
+}
+
+// parseODT reads the blocks of an OpenDocument text document.
+func parseODT(data []byte) ([]Block, error) {
+	pkg, err := docparse.OpenPackage(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		b blockBuilder
+		// depth counts the paragraphs being read, notes hold paragraphs
+		// within paragraphs.
+		depth     int
+		kind      string
+		level     int
+		style     string
+		listDepth int
+		rowRepeat []int
+		cellRep   []int
+	)
+	err = pkg.Walk("content.xml", true, func(token xml.Token) bool {
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "h", "p":
+				depth++
+				if depth > 1 {
+					return true
+				}
+				kind, level, style = KindParagraph, 0, attr(t, "style-name")
+				if t.Name.Local == "h" {
+					kind = KindHeading
+					level, _ = strconv.Atoi(attr(t, "outline-level"))
+					level = min(max(level, 1), 9)
+				} else if listDepth > 0 {
+					kind = KindList
+				}
+			case "s":
+				count, err := strconv.Atoi(attr(t, "c"))
+				if err != nil || count < 1 {
+					count = 1
+				}
+				b.addText(strings.Repeat(" ", min(count, maxRepeat)))
+			case "tab", "line-break":
+				b.addText(" ")
+			case "list":
+				listDepth++
+			case "table":
+				b.startTable()
+			case "table-row":
+				b.startRow()
+				rowRepeat = append(rowRepeat, repeated(t, "number-rows-repeated"))
+			case "table-cell", "covered-table-cell":
+				cellRep = append(cellRep, repeated(t, "number-columns-repeated"))
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "h", "p":
+				depth--
+				if depth == 0 {
+					b.endParagraph(kind, level, style)
+				}
+			case "list":
+				listDepth--
+			case "table":
+				b.endTable()
+			case "table-row":
+				b.endRow(rowRepeat[len(rowRepeat)-1])
+				rowRepeat = rowRepeat[:len(rowRepeat)-1]
+			case "table-cell", "covered-table-cell":
+				b.endCell(cellRep[len(cellRep)-1])
+				cellRep = cellRep[:len(cellRep)-1]
+			}
+		case xml.CharData:
+			if depth > 0 {
+				b.addText(string(t))
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return b.blocks, nil
+}
+
+// parseText reads the paragraphs of plain text, separated by blank lines.
+func parseText(text string) []Block {
+	var blocks []Block
+	for _, paragraph := range strings.Split(text, "\n\n") {
+		if joined := strings.Join(strings.Fields(paragraph), " "); joined != "" {
+			blocks = append(blocks, Block{Kind: KindParagraph, Text: joined})
+		}
+	}
+	return blocks
+}
+
+// parseLines reads each non-blank line of text as a paragraph.
+func parseLines(text string) []Block {
+	var blocks []Block
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			blocks = append(blocks, Block{Kind: KindParagraph, Text: line})
+		}
+	}
+	return blocks
+}
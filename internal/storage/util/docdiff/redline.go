@@ -0,0 +1,343 @@
+package docdiff
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/a1y/doc-formatter/internal/storage/util/textextract"
+)
+
+// RedlineContentType is the media type of redline documents.
+const RedlineContentType = textextract.TypeDOCX
+
+const redlineContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+	`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+	`<Default Extension="xml" ContentType="application/xml"/>` +
+	`<Override PartName="/word/document.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"/>` +
+	`<Override PartName="/word/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.styles+xml"/>` +
+	`</Types>`
+
+const redlineRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="word/document.xml"/>` +
+	`</Relationships>`
+
+const redlineDocumentRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>` +
+	`</Relationships>`
+
+const wordNamespace = "http://schemas.openxmlformats.org/wordprocessingml/2006/main"
+
+// redlineStyles are the paragraph styles blocks are written with, by id.
+var redlineStyles = func() string {
+	var b bytes.Buffer
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n")
+	b.WriteString(`<w:styles xmlns:w="` + wordNamespace + `">`)
+	b.WriteString(`<w:style w:type="paragraph" w:default="1" w:styleId="Normal"><w:name w:val="Normal"/></w:style>`)
+	for level := 1; level <= 9; level++ {
+		size := max(40-4*level, 22)
+		fmt.Fprintf(&b, `<w:style w:type="paragraph" w:styleId="Heading%d"><w:name w:val="heading %d"/>`+
+			`<w:basedOn w:val="Normal"/><w:next w:val="Normal"/><w:pPr><w:keepNext/><w:spacing w:before="240" w:after="60"/><w:outlineLvl w:val="%d"/></w:pPr>`+
+			`<w:rPr><w:b/><w:sz w:val="%d"/></w:rPr></w:style>`, level, level, level-1, size)
+	}
+	b.WriteString(`<w:style w:type="paragraph" w:styleId="ListParagraph"><w:name w:val="List Paragraph"/>` +
+		`<w:basedOn w:val="Normal"/><w:pPr><w:ind w:left="720"/></w:pPr></w:style>`)
+	b.WriteString(`<w:style w:type="paragraph" w:styleId="Quote"><w:name w:val="Quote"/>` +
+		`<w:basedOn w:val="Normal"/><w:pPr><w:ind w:left="720" w:right="720"/></w:pPr><w:rPr><w:i/></w:rPr></w:style>`)
+	b.WriteString(`<w:style w:type="paragraph" w:styleId="Code"><w:name w:val="Code"/>` +
+		`<w:basedOn w:val="Normal"/><w:rPr><w:rFonts w:ascii="Courier New" w:hAnsi="Courier New"/></w:rPr></w:style>`)
+	b.WriteString(`<w:style w:type="table" w:styleId="TableGrid"><w:name w:val="Table Grid"/><w:tblPr><w:tblBorders>` +
+		`<w:top w:val="single" w:sz="4" w:space="0" w:color="auto"/><w:left w:val="single" w:sz="4" w:space="0" w:color="auto"/>` +
+		`<w:bottom w:val="single" w:sz="4" w:space="0" w:color="auto"/><w:right w:val="single" w:sz="4" w:space="0" w:color="auto"/>` +
+		`<w:insideH w:val="single" w:sz="4" w:space="0" w:color="auto"/><w:insideV w:val="single" w:sz="4" w:space="0" w:color="auto"/>` +
+		`</w:tblBorders></w:tblPr></w:style>`)
+	b.WriteString(`</w:styles>`)
+	return b.String()
+}()
+
+// Redline returns a DOCX document showing the changes from document from to
+// document to as tracked changes made by author at date, which word
+// processors let the reader accept or reject one by one. Blocks are written
+// with the styles of their kind and level, so style changes other than
+// those are not shown.
+func Redline(from, to *Document, author string, date time.Time) ([]byte, error) {
+	w := &redlineWriter{
+		author: author,
+		date:   date.UTC().Format(time.RFC3339),
+	}
+	w.body.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n")
+	w.body.WriteString(`<w:document xmlns:w="` + wordNamespace + `"><w:body>`)
+	for _, e := range compareBlocks(from.Blocks, to.Blocks) {
+		switch e.op {
+		case OpInsert:
+			w.block(e.to, nil, OpInsert)
+		case OpDelete:
+			w.block(e.from, nil, OpDelete)
+		default:
+			w.block(e.to, e.from, OpModify)
+		}
+	}
+	w.body.WriteString(`<w:sectPr/></w:body></w:document>`)
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, part := range []struct{ name, content string }{
+		{"[Content_Types].xml", redlineContentTypes},
+		{"_rels/.rels", redlineRels},
+		{"word/_rels/document.xml.rels", redlineDocumentRels},
+		{"word/styles.xml", redlineStyles},
+		{"word/document.xml", w.body.String()},
+	} {
+		f, err := zw.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := f.Write([]byte(part.content)); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// redlineWriter writes the body of a redline document.
+type redlineWriter struct {
+	body   bytes.Buffer
+	author string
+	date   string
+	// revision numbers the tracked changes, which must have unique ids.
+	revision int
+}
+
+// block writes block. Inserted and deleted blocks are marked as such as a
+// whole; modified blocks show the changes from previous.
+func (w *redlineWriter) block(block, previous *Block, op Op) {
+	if block.Kind == KindTable {
+		w.table(block, previous, op)
+		return
+	}
+
+	var runs []textRun
+	switch op {
+	case OpInsert, OpDelete:
+		runs = []textRun{{op: op, text: block.Text}}
+	default:
+		runs = diffWords(previous.Text, block.Text)
+	}
+	var previousStyle *string
+	if previous != nil {
+		if style := styleID(previous); style != styleID(block) {
+			previousStyle = &style
+		}
+	}
+	w.paragraph(styleID(block), previousStyle, op, runs)
+}
+
+// styleID returns the id of the redline style of block.
+func styleID(block *Block) string {
+	switch block.Kind {
+	case KindHeading:
+		return "Heading" + strconv.Itoa(min(max(block.Level, 1), 9))
+	case KindList:
+		return "ListParagraph"
+	case KindQuote:
+		return "Quote"
+	case KindCode:
+		return "Code"
+	default:
+		return ""
+	}
+}
+
+// paragraph writes a paragraph of style made of runs. The paragraph mark is
+// tracked as inserted or deleted with the paragraph, and a change from
+// previousStyle, if set, is tracked as a formatting change.
+func (w *redlineWriter) paragraph(style string, previousStyle *string, op Op, runs []textRun) {
+	w.body.WriteString(`<w:p><w:pPr>`)
+	if style != "" {
+		w.body.WriteString(`<w:pStyle w:val="` + style + `"/>`)
+	}
+	if op == OpInsert || op == OpDelete {
+		w.body.WriteString(`<w:rPr>`)
+		w.mark(op)
+		w.body.WriteString(`</w:rPr>`)
+	}
+	if previousStyle != nil {
+		w.body.WriteString(`<w:pPrChange ` + w.revisionAttrs() + `><w:pPr>`)
+		if *previousStyle != "" {
+			w.body.WriteString(`<w:pStyle w:val="` + *previousStyle + `"/>`)
+		}
+		w.body.WriteString(`</w:pPr></w:pPrChange>`)
+	}
+	w.body.WriteString(`</w:pPr>`)
+	for _, run := range runs {
+		w.run(run)
+	}
+	w.body.WriteString(`</w:p>`)
+}
+
+// run writes a run of text, wrapped in the tracked change it belongs to.
+func (w *redlineWriter) run(run textRun) {
+	if run.text == "" {
+		return
+	}
+	textElement := "w:t"
+	switch run.op {
+	case OpInsert:
+		w.body.WriteString(`<w:ins ` + w.revisionAttrs() + `>`)
+	case OpDelete:
+		w.body.WriteString(`<w:del ` + w.revisionAttrs() + `>`)
+		textElement = "w:delText"
+	}
+	w.body.WriteString(`<w:r><` + textElement + ` xml:space="preserve">`)
+	_ = xml.EscapeText(&w.body, []byte(run.text))
+	w.body.WriteString(`</` + textElement + `></w:r>`)
+	switch run.op {
+	case OpInsert:
+		w.body.WriteString(`</w:ins>`)
+	case OpDelete:
+		w.body.WriteString(`</w:del>`)
+	}
+}
+
+// mark writes an empty w:ins or w:del element, which marks the paragraph or
+// row it is a property of. Other ops write nothing.
+func (w *redlineWriter) mark(op Op) {
+	switch op {
+	case OpInsert:
+		w.body.WriteString(`<w:ins ` + w.revisionAttrs() + `/>`)
+	case OpDelete:
+		w.body.WriteString(`<w:del ` + w.revisionAttrs() + `/>`)
+	}
+}
+
+func (w *redlineWriter) revisionAttrs() string {
+	w.revision++
+	var author bytes.Buffer
+	_ = xml.EscapeText(&author, []byte(w.author))
+	return fmt.Sprintf(`w:id="%d" w:author="%s" w:date="%s"`, w.revision, author.String(), w.date)
+}
+
+// tableRow is a row of a redline table: its cells and whether the row was
+// inserted, deleted or kept.
+type tableRow struct {
+	op    Op
+	cells [][]textRun
+}
+
+// table writes a table block. Rows of modified tables are matched by their
+// text, and rows replacing each other show the changes of their cells.
+func (w *redlineWriter) table(block, previous *Block, op Op) {
+	var rows []tableRow
+	switch op {
+	case OpInsert, OpDelete:
+		for _, row := range block.Rows {
+			rows = append(rows, rowOf(op, row))
+		}
+	default:
+		rows = diffRows(previous.Rows, block.Rows)
+	}
+
+	columns := 1
+	for _, row := range rows {
+		columns = max(columns, len(row.cells))
+	}
+	w.body.WriteString(`<w:tbl><w:tblPr><w:tblStyle w:val="TableGrid"/><w:tblW w:w="0" w:type="auto"/></w:tblPr><w:tblGrid>`)
+	for i := 0; i < columns; i++ {
+		w.body.WriteString(`<w:gridCol w:w="` + strconv.Itoa(9000/columns) + `"/>`)
+	}
+	w.body.WriteString(`</w:tblGrid>`)
+	for _, row := range rows {
+		w.body.WriteString(`<w:tr>`)
+		if row.op == OpInsert || row.op == OpDelete {
+			w.body.WriteString(`<w:trPr>`)
+			w.mark(row.op)
+			w.body.WriteString(`</w:trPr>`)
+		}
+		for i := 0; i < columns; i++ {
+			w.body.WriteString(`<w:tc><w:p>`)
+			if i < len(row.cells) {
+				for _, run := range row.cells[i] {
+					w.run(run)
+				}
+			}
+			w.body.WriteString(`</w:p></w:tc>`)
+		}
+		w.body.WriteString(`</w:tr>`)
+	}
+	w.body.WriteString(`</w:tbl>`)
+	// Keeps the table apart from a table that follows.
+	w.body.WriteString(`<w:p/>`)
+}
+
+// rowOf returns a row whose cells are all inserted, deleted or kept.
+func rowOf(op Op, cells []string) tableRow {
+	row := tableRow{op: op, cells: make([][]textRun, len(cells))}
+	for i, cell := range cells {
+		row.cells[i] = []textRun{{op: op, text: cell}}
+	}
+	return row
+}
+
+// diffRows returns the rows turning the rows from into the rows to. Rows
+// deleted and inserted in between unchanged rows are paired, and show the
+// changes of their cells.
+func diffRows(from, to [][]string) []tableRow {
+	keys := func(rows [][]string) []string {
+		out := make([]string, len(rows))
+		for i, row := range rows {
+			out[i] = fmt.Sprintf("%q", row)
+		}
+		return out
+	}
+
+	var (
+		rows              []tableRow
+		deleted, inserted [][]string
+	)
+	flush := func() {
+		n := min(len(deleted), len(inserted))
+		for i := 0; i < n; i++ {
+			row := tableRow{op: OpModify}
+			for j := 0; j < max(len(deleted[i]), len(inserted[i])); j++ {
+				var a, b string
+				if j < len(deleted[i]) {
+					a = deleted[i][j]
+				}
+				if j < len(inserted[i]) {
+					b = inserted[i][j]
+				}
+				row.cells = append(row.cells, diffWords(a, b))
+			}
+			rows = append(rows, row)
+		}
+		for _, row := range deleted[n:] {
+			rows = append(rows, rowOf(OpDelete, row))
+		}
+		for _, row := range inserted[n:] {
+			rows = append(rows, rowOf(OpInsert, row))
+		}
+		deleted, inserted = nil, nil
+	}
+	for _, e := range diff(keys(from), keys(to)) {
+		switch e.op {
+		case OpDelete:
+			deleted = append(deleted, from[e.oldIndex])
+		case OpInsert:
+			inserted = append(inserted, to[e.newIndex])
+		default:
+			flush()
+			rows = append(rows, rowOf(OpEqual, to[e.newIndex]))
+		}
+	}
+	flush()
+	return rows
+}
@@ -0,0 +1,120 @@
+package docdiff
+
+import (
+	"errors"
+
+	"github.com/a1y/doc-formatter/internal/storage/util/textextract"
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("document comparison is not supported for this format")
+	// ErrDocumentTooLarge and ErrMalformedDocument are shared with text
+	// extraction, which the text diff relies on.
+	ErrDocumentTooLarge  = textextract.ErrDocumentTooLarge
+	ErrMalformedDocument = textextract.ErrMalformedDocument
+)
+
+const (
+	// DefaultContextLines is the number of unchanged lines shown around the
+	// changes of a text hunk unless asked otherwise.
+	DefaultContextLines = 3
+	// MaxContextLines caps the context lines of text hunks.
+	MaxContextLines = 20
+	// maxEdits is the number of edits past which two sequences are reported
+	// as entirely replaced rather than diffed further, which bounds the time
+	// and memory of comparing documents with little in common.
+	maxEdits = 4096
+)
+
+// Op tells how an element changed from the old document to the new one.
+type Op string
+
+const (
+	OpEqual  Op = "equal"
+	OpInsert Op = "insert"
+	OpDelete Op = "delete"
+	OpModify Op = "modify"
+)
+
+// Kinds of blocks.
+const (
+	KindParagraph = "paragraph"
+	KindHeading   = "heading"
+	KindList      = "list"
+	KindQuote     = "quote"
+	KindCode      = "code"
+	KindTable     = "table"
+)
+
+// Document is a document read for comparison: its block structure and its
+// text.
+type Document struct {
+	Blocks []Block
+	Text   string
+}
+
+// Block is a paragraph-level element of a document.
+type Block struct {
+	// Index is the position of the block in its document.
+	Index int    `json:"index"`
+	Kind  string `json:"kind"`
+	// Level ranges from 1 to 9 for headings and is 0 otherwise.
+	Level int `json:"level,omitempty"`
+	// Style is the name of the paragraph style of DOCX and ODT blocks.
+	Style string `json:"style,omitempty"`
+	// Text is the text of the block, with the cells of tables separated by
+	// tabs and their rows by line breaks.
+	Text string     `json:"text"`
+	Rows [][]string `json:"rows,omitempty"`
+}
+
+// Fields of blocks reported as changed by modifications.
+const (
+	FieldText  = "text"
+	FieldKind  = "kind"
+	FieldLevel = "level"
+	FieldStyle = "style"
+)
+
+// Change is a block inserted, deleted or modified. Old is nil for
+// insertions and New for deletions.
+type Change struct {
+	Op  Op     `json:"op"`
+	Old *Block `json:"old,omitempty"`
+	New *Block `json:"new,omitempty"`
+	// Fields lists what differs between the blocks of a modification.
+	Fields []string `json:"fields,omitempty"`
+}
+
+// Hunk is a run of changed lines of the text of the documents with its
+// context, as in a unified diff. Line numbers start at 1.
+type Hunk struct {
+	OldStart int    `json:"oldStart"`
+	OldLines int    `json:"oldLines"`
+	NewStart int    `json:"newStart"`
+	NewLines int    `json:"newLines"`
+	Lines    []Line `json:"lines"`
+}
+
+// Line is a line of a hunk, unchanged, inserted or deleted.
+type Line struct {
+	Op   Op     `json:"op"`
+	Text string `json:"text"`
+}
+
+// Summary counts the changes between two documents.
+type Summary struct {
+	Inserted     int `json:"inserted"`
+	Deleted      int `json:"deleted"`
+	Modified     int `json:"modified"`
+	LinesAdded   int `json:"linesAdded"`
+	LinesRemoved int `json:"linesRemoved"`
+}
+
+// Diff describes the changes between two documents, block by block and line
+// by line.
+type Diff struct {
+	Changes []Change `json:"changes"`
+	Hunks   []Hunk   `json:"hunks"`
+	Summary Summary  `json:"summary"`
+}
@@ -0,0 +1,58 @@
+// Package docmerge joins documents into one and cuts documents into parts
+// at their headings.
+package docmerge
+
+import (
+	"github.com/a1y/doc-formatter/internal/storage/util/textextract"
+)
+
+// Supported reports whether documents of contentType can be merged and
+// split.
+func Supported(contentType string) bool {
+	return contentType == textextract.TypeDOCX || contentType == textextract.TypeMarkdown
+}
+
+// Merge joins docs, documents of contentType, in order. Up to MaxParts
+// documents of MaxSize bytes in total can be merged.
+func Merge(contentType string, docs [][]byte, opts Options) ([]byte, error) {
+	if !Supported(contentType) {
+		return nil, ErrUnsupportedFormat
+	}
+	if len(docs) > MaxParts {
+		return nil, ErrTooManyParts
+	}
+	total := 0
+	for _, data := range docs {
+		total += len(data)
+	}
+	if total > MaxSize {
+		return nil, ErrDocumentTooLarge
+	}
+	if len(docs) == 0 {
+		return nil, nil
+	}
+
+	if contentType == textextract.TypeDOCX {
+		return mergeDOCX(docs, opts)
+	}
+	return mergeMarkdown(docs, opts), nil
+}
+
+// Split cuts data, a document of contentType, before every heading of level
+// or above, 1 being the top level. Content before the first such heading
+// makes a part of its own. Documents with no such heading are rejected with
+// ErrNoHeadings.
+func Split(contentType string, data []byte, level int) ([]Part, error) {
+	if !Supported(contentType) {
+		return nil, ErrUnsupportedFormat
+	}
+	if len(data) > MaxSize {
+		return nil, ErrDocumentTooLarge
+	}
+	level = min(max(level, 1), MaxHeadingLevel)
+
+	if contentType == textextract.TypeDOCX {
+		return splitDOCX(data, level)
+	}
+	return splitMarkdown(data, level)
+}
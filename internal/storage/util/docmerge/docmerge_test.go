@@ -0,0 +1,280 @@
+package docmerge
+
+import (
+	"archive/zip"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/a1y/doc-formatter/internal/storage/util/textextract"
+	"github.com/stretchr/testify/require"
+
+	"github.com/a1y/doc-formatter/internal/storage/util/ooxml"
+)
+
+const (
+	testNamespaces = `xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships" xmlns:wp="http://schemas.openxmlformats.org/drawingml/2006/wordprocessingDrawing" xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main"`
+	testDrawing    = `<w:r><w:drawing><wp:inline><wp:docPr id="1" name="Picture"/><a:blip r:embed="rIdImage"/></wp:inline></w:drawing></w:r>`
+)
+
+// testDOCX is a DOCX document of tests. Parts left empty are not written.
+type testDOCX struct {
+	body      string
+	styles    string
+	numbering string
+	// rels holds the relationships of the document part besides those of
+	// its styles and numbering.
+	rels  string
+	files map[string]string
+}
+
+func (d testDOCX) bytes(t *testing.T) []byte {
+	t.Helper()
+
+	files := map[string]string{
+		ooxml.ContentTypesName: `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Default Extension="png" ContentType="image/png"/><Override PartName="/word/document.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"/></Types>`,
+		ooxml.PackageRelsName: `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="word/document.xml"/></Relationships>`,
+		"word/document.xml": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:document ` + testNamespaces + `><w:body>` + d.body + `<w:sectPr><w:pgSz w:w="11906" w:h="16838"/></w:sectPr></w:body></w:document>`,
+	}
+	rels := d.rels
+	if d.styles != "" {
+		files["word/styles.xml"] = `<w:styles ` + testNamespaces + `>` + d.styles + `</w:styles>`
+		rels += `<Relationship Id="rIdStyles" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`
+	}
+	if d.numbering != "" {
+		files["word/numbering.xml"] = `<w:numbering ` + testNamespaces + `>` + d.numbering + `</w:numbering>`
+		rels += `<Relationship Id="rIdNumbering" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/numbering" Target="numbering.xml"/>`
+	}
+	files["word/_rels/document.xml.rels"] = `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` + rels + `</Relationships>`
+	for name, content := range d.files {
+		files[name] = content
+	}
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, content := range files {
+		f, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+// paragraphs returns the style and text of the paragraphs of the body of a
+// DOCX document, as "style: text".
+func paragraphs(t *testing.T, doc *docxPackage) []string {
+	t.Helper()
+
+	content, _ := doc.content()
+	var out []string
+	for _, c := range content {
+		n := c.(*ooxml.Node)
+		if !doc.main.Is(n, ooxml.NSMain, "p") {
+			continue
+		}
+		style := ""
+		if pPr := doc.main.Child(n, ooxml.NSMain, "pPr"); pPr != nil {
+			if s := doc.main.Child(pPr, ooxml.NSMain, "pStyle"); s != nil {
+				style = val(doc.main, s)
+			}
+		}
+		text := doc.paragraphText(n)
+		ooxml.Walk(n, func(c *ooxml.Node) bool {
+			if doc.main.Is(c, ooxml.NSMain, "br") && doc.main.Value(c, ooxml.NSMain, "type") == "page" {
+				text += "<page>"
+			}
+			return true
+		})
+		out = append(out, style+": "+text)
+	}
+	return out
+}
+
+func TestMergeDOCX(t *testing.T) {
+	t.Parallel()
+
+	first := testDOCX{
+		body: `<w:p><w:pPr><w:pStyle w:val="Heading1"/></w:pPr><w:bookmarkStart w:id="0" w:name="intro"/><w:r><w:t>Introduction</w:t></w:r><w:bookmarkEnd w:id="0"/></w:p>` + `<w:p>` + testDrawing + `</w:p>`,
+		styles: `<w:style w:type="paragraph" w:styleId="Normal"><w:name w:val="Normal"/></w:style>` +
+			`<w:style w:type="paragraph" w:styleId="Heading1"><w:name w:val="heading 1"/><w:basedOn w:val="Normal"/></w:style>`,
+		rels:  `<Relationship Id="rIdImage" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/image" Target="media/image1.png"/>`,
+		files: map[string]string{"word/media/image1.png": "first image"},
+	}.bytes(t)
+	second := testDOCX{
+		body: `<w:p><w:pPr><w:pStyle w:val="Titre1"/></w:pPr><w:bookmarkStart w:id="0" w:name="methods"/><w:r><w:t>Methods</w:t></w:r><w:bookmarkEnd w:id="0"/></w:p>` +
+			`<w:p><w:pPr><w:pStyle w:val="Aside"/><w:numPr><w:ilvl w:val="0"/><w:numId w:val="1"/></w:numPr></w:pPr><w:r><w:t>Step</w:t></w:r><w:commentReference w:id="1"/></w:p>` +
+			`<w:p>` + testDrawing + `<w:hyperlink r:id="rIdLink"><w:r><w:t>Site</w:t></w:r></w:hyperlink></w:p>`,
+		styles: `<w:style w:type="paragraph" w:styleId="Normal"><w:name w:val="Normal"/></w:style>` +
+			`<w:style w:type="paragraph" w:styleId="Titre1"><w:name w:val="Heading 1"/></w:style>` +
+			`<w:style w:type="paragraph" w:styleId="Aside"><w:name w:val="Aside"/><w:basedOn w:val="Normal"/><w:next w:val="Note"/></w:style>` +
+			`<w:style w:type="paragraph" w:styleId="Note"><w:name w:val="Note"/></w:style>`,
+		numbering: `<w:abstractNum w:abstractNumId="0"><w:nsid w:val="1A2B3C4D"/><w:lvl w:ilvl="0"><w:numFmt w:val="decimal"/></w:lvl></w:abstractNum><w:num w:numId="1"><w:abstractNumId w:val="0"/></w:num>`,
+		rels: `<Relationship Id="rIdImage" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/image" Target="media/image1.png"/>` +
+			`<Relationship Id="rIdLink" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/hyperlink" Target="https://example.com" TargetMode="External"/>`,
+		files: map[string]string{"word/media/image1.png": "second image"},
+	}.bytes(t)
+
+	data, err := Merge(textextract.TypeDOCX, [][]byte{first, second}, Options{PageBreaks: true})
+	require.NoError(t, err)
+	merged, err := openDOCX(data)
+	require.NoError(t, err)
+
+	require.Equal(t, []string{
+		"Heading1: Introduction",
+		": ",
+		": <page>",
+		"Heading1: Methods",
+		"Aside: Step",
+		": Site",
+	}, paragraphs(t, merged))
+	document := string(merged.main.Bytes())
+	require.NotContains(t, document, "commentReference")
+	require.Contains(t, document, `<w:bookmarkStart w:id="1" w:name="methods"/>`)
+	require.Contains(t, document, `<w:bookmarkEnd w:id="1"/>`)
+	require.Contains(t, document, `<wp:docPr id="2" name="Picture"/>`)
+	require.Contains(t, document, `<w:sectPr><w:pgSz w:w="11906" w:h="16838"/></w:sectPr></w:body>`)
+
+	// Styles missing from the first document are copied with those they
+	// refer to, and those it has by name are reused.
+	styles := string(merged.styles.Bytes())
+	require.Contains(t, styles, `w:styleId="Aside"`)
+	require.Contains(t, styles, `w:styleId="Note"`)
+	require.NotContains(t, styles, `w:styleId="Titre1"`)
+
+	// The first document had no lists: the numbering part is added.
+	require.NotNil(t, merged.numbering)
+	numbering := string(merged.numbering.Bytes())
+	require.Contains(t, numbering, `<w:abstractNum w:abstractNumId="1"><w:lvl`)
+	require.Contains(t, numbering, `<w:num w:numId="1"><w:abstractNumId w:val="1"/></w:num>`)
+	require.Equal(t, "application/vnd.openxmlformats-officedocument.wordprocessingml.numbering+xml", ooxml.ContentType(merged.types, merged.numberingName))
+
+	// The image of the second document is copied under a new name.
+	image, _ := merged.pkg.Part("word/media/image1.png")
+	require.Equal(t, "first image", string(image))
+	image, _ = merged.pkg.Part("word/media/image1-2.png")
+	require.Equal(t, "second image", string(image))
+	var targets []string
+	for _, r := range ooxml.Relationships(merged.rels) {
+		targets = append(targets, merged.rels.Value(r, "", "Target"))
+	}
+	require.Contains(t, targets, "media/image1-2.png")
+	require.Contains(t, targets, "https://example.com")
+	require.Contains(t, document, `r:embed="rIdImage"`)
+	require.Equal(t, 2, strings.Count(document, `r:embed="`))
+}
+
+func TestMergeDOCX_Errors(t *testing.T) {
+	t.Parallel()
+
+	doc := testDOCX{body: `<w:p/>`}.bytes(t)
+	_, err := Merge(textextract.TypeDOCX, [][]byte{doc, []byte("not a zip")}, Options{})
+	require.ErrorIs(t, err, ErrMalformedDocument)
+
+	clash := testDOCX{files: map[string]string{
+		"word/document.xml": `<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" xmlns:r="urn:other"><w:body><w:p/></w:body></w:document>`,
+	}}.bytes(t)
+	_, err = Merge(textextract.TypeDOCX, [][]byte{doc, clash}, Options{})
+	require.ErrorIs(t, err, ErrIncompatibleDocuments)
+
+	_, err = Merge(textextract.TypeODT, [][]byte{doc, doc}, Options{})
+	require.ErrorIs(t, err, ErrUnsupportedFormat)
+	_, err = Merge(textextract.TypeDOCX, make([][]byte, MaxParts+1), Options{})
+	require.ErrorIs(t, err, ErrTooManyParts)
+}
+
+func TestSplitDOCX(t *testing.T) {
+	t.Parallel()
+
+	data := testDOCX{
+		body: `<w:p><w:r><w:t>Draft</w:t></w:r><w:bookmarkStart w:id="0" w:name="span"/></w:p>` +
+			`<w:p><w:pPr><w:pStyle w:val="Chapter"/></w:pPr><w:bookmarkEnd w:id="0"/><w:r><w:t>One</w:t></w:r></w:p>` +
+			`<w:p><w:pPr><w:pStyle w:val="Heading2"/></w:pPr><w:r><w:t>One point one</w:t></w:r></w:p>` +
+			`<w:p><w:pPr><w:outlineLvl w:val="0"/></w:pPr><w:r><w:t>Two</w:t></w:r></w:p>` +
+			`<w:p>` + testDrawing + `</w:p>`,
+		styles: `<w:style w:type="paragraph" w:styleId="Heading1"><w:name w:val="heading 1"/></w:style>` +
+			`<w:style w:type="paragraph" w:styleId="Chapter"><w:name w:val="Chapter"/><w:basedOn w:val="Heading1"/></w:style>` +
+			`<w:style w:type="paragraph" w:styleId="Heading2"><w:name w:val="Heading 2"/><w:pPr><w:outlineLvl w:val="1"/></w:pPr></w:style>`,
+		rels:  `<Relationship Id="rIdImage" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/image" Target="media/image1.png"/>`,
+		files: map[string]string{"word/media/image1.png": "image"},
+	}.bytes(t)
+
+	parts, err := Split(textextract.TypeDOCX, data, 1)
+	require.NoError(t, err)
+	require.Len(t, parts, 3)
+	require.Equal(t, []string{"", "One", "Two"}, []string{parts[0].Title, parts[1].Title, parts[2].Title})
+
+	var docs []*docxPackage
+	for _, part := range parts {
+		doc, err := openDOCX(part.Content)
+		require.NoError(t, err)
+		docs = append(docs, doc)
+	}
+	require.Equal(t, []string{": Draft"}, paragraphs(t, docs[0]))
+	require.Equal(t, []string{"Chapter: One", "Heading2: One point one"}, paragraphs(t, docs[1]))
+	require.Equal(t, []string{": Two", ": "}, paragraphs(t, docs[2]))
+	// Bookmarks cut in two are dropped.
+	require.NotContains(t, string(docs[0].main.Bytes()), "bookmark")
+	require.NotContains(t, string(docs[1].main.Bytes()), "bookmark")
+	// Every part keeps the page setup, but only the last keeps the image.
+	for _, doc := range docs {
+		require.Contains(t, string(doc.main.Bytes()), `<w:pgSz w:w="11906" w:h="16838"/>`)
+		require.NotNil(t, doc.styles)
+	}
+	require.NotContains(t, docs[0].pkg.Names(), "word/media/image1.png")
+	require.Nil(t, ooxml.Relationship(docs[0].rels, "rIdImage"))
+	require.Contains(t, docs[2].pkg.Names(), "word/media/image1.png")
+	require.NotNil(t, ooxml.Relationship(docs[2].rels, "rIdImage"))
+
+	parts, err = Split(textextract.TypeDOCX, data, 2)
+	require.NoError(t, err)
+	require.Len(t, parts, 4)
+	require.Equal(t, "One point one", parts[2].Title)
+
+	_, err = Split(textextract.TypeDOCX, testDOCX{body: `<w:p><w:r><w:t>Plain</w:t></w:r></w:p>`}.bytes(t), 1)
+	require.ErrorIs(t, err, ErrNoHeadings)
+}
+
+func TestMergeMarkdown(t *testing.T) {
+	t.Parallel()
+
+	data, err := Merge(textextract.TypeMarkdown, [][]byte{
+		[]byte("---\ntitle: Report\n---\n# Intro\r\n\r\nHello.\n\n```go\nfunc main() {}\n"),
+		[]byte("---\ntitle: Part two\n---\n\n# Methods\n\nWe measured.\n\n\n"),
+	}, Options{PageBreaks: true})
+	require.NoError(t, err)
+	require.Equal(t, "---\ntitle: Report\n---\n# Intro\n\nHello.\n\n```go\nfunc main() {}\n```\n\n"+
+		markdownPageBreak+"\n\n# Methods\n\nWe measured.\n", string(data))
+
+	data, err = Merge(textextract.TypeMarkdown, [][]byte{[]byte("A\n"), []byte("B")}, Options{})
+	require.NoError(t, err)
+	require.Equal(t, "A\n\nB\n", string(data))
+}
+
+func TestSplitMarkdown(t *testing.T) {
+	t.Parallel()
+
+	source := "---\ntitle: Guide\n---\nPreface.\n\n# Install **now**\n\nRun it.\n\n```sh\n# not a heading\n```\n\n## Options\n\nFlags.\n\nUsage\n=====\n\nCall [it](https://example.com).\n"
+	parts, err := Split(textextract.TypeMarkdown, []byte(source), 1)
+	require.NoError(t, err)
+	require.Equal(t, []Part{
+		{Title: "", Content: []byte("---\ntitle: Guide\n---\nPreface.\n")},
+		{Title: "Install now", Content: []byte("# Install **now**\n\nRun it.\n\n```sh\n# not a heading\n```\n\n## Options\n\nFlags.\n")},
+		{Title: "Usage", Content: []byte("Usage\n=====\n\nCall [it](https://example.com).\n")},
+	}, parts)
+
+	parts, err = Split(textextract.TypeMarkdown, []byte("# A\n\nText.\n\n## B\n"), 2)
+	require.NoError(t, err)
+	require.Equal(t, []Part{
+		{Title: "A", Content: []byte("# A\n\nText.\n")},
+		{Title: "B", Content: []byte("## B\n")},
+	}, parts)
+
+	_, err = Split(textextract.TypeMarkdown, []byte("Just text.\n\n## Deep\n"), 1)
+	require.ErrorIs(t, err, ErrNoHeadings)
+	_, err = Split(textextract.TypeMarkdown, []byte(strings.Repeat("# H\n", MaxParts+1)), 1)
+	require.ErrorIs(t, err, ErrTooManyParts)
+}
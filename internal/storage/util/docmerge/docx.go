@@ -0,0 +1,281 @@
+package docmerge
+
+import (
+	"encoding/xml"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/a1y/doc-formatter/internal/storage/util/ooxml"
+)
+
+const typeNumbering = "application/vnd.openxmlformats-officedocument.wordprocessingml.numbering+xml"
+
+// docxPackage is a WordprocessingML document opened for editing. The parts
+// it parses are written back to the package by bytes.
+type docxPackage struct {
+	pkg   *ooxml.Package
+	types *ooxml.Document
+	// main is the document part called mainName, and rels its
+	// relationships.
+	mainName string
+	main     *ooxml.Document
+	rels     *ooxml.Document
+	// styles and numbering are nil when the document has no such part.
+	stylesName    string
+	styles        *ooxml.Document
+	numberingName string
+	numbering     *ooxml.Document
+}
+
+// openDOCX reads the DOCX document data.
+func openDOCX(data []byte) (*docxPackage, error) {
+	pkg, err := ooxml.Open(data)
+	if err != nil {
+		return nil, err
+	}
+	d := &docxPackage{pkg: pkg}
+	if d.types, err = pkg.XML(ooxml.ContentTypesName); err != nil {
+		return nil, err
+	}
+	if d.types == nil {
+		return nil, fmt.Errorf("%w: missing %s", ErrMalformedDocument, ooxml.ContentTypesName)
+	}
+	packageRels, err := pkg.XML(ooxml.PackageRelsName)
+	if err != nil {
+		return nil, err
+	}
+	if d.mainName = ooxml.Target(packageRels, "", ooxml.RelDocument); d.mainName == "" {
+		d.mainName = "word/document.xml"
+	}
+	if d.main, err = pkg.XML(d.mainName); err != nil {
+		return nil, err
+	}
+	if d.main == nil || d.body() == nil {
+		return nil, fmt.Errorf("%w: missing document body", ErrMalformedDocument)
+	}
+	if d.rels, err = pkg.XML(ooxml.RelsName(d.mainName)); err != nil {
+		return nil, err
+	}
+	if d.rels == nil {
+		d.rels = ooxml.NewRelationships()
+	}
+	if d.stylesName = ooxml.Target(d.rels, d.mainName, ooxml.RelStyles); d.stylesName != "" {
+		if d.styles, err = pkg.XML(d.stylesName); err != nil {
+			return nil, err
+		}
+	}
+	if d.numberingName = ooxml.Target(d.rels, d.mainName, ooxml.RelNumbering); d.numberingName != "" {
+		if d.numbering, err = pkg.XML(d.numberingName); err != nil {
+			return nil, err
+		}
+	}
+	return d, nil
+}
+
+// bytes writes the document.
+func (d *docxPackage) bytes() ([]byte, error) {
+	d.pkg.Set(ooxml.ContentTypesName, d.types.Bytes())
+	d.pkg.Set(d.mainName, d.main.Bytes())
+	d.pkg.Set(ooxml.RelsName(d.mainName), d.rels.Bytes())
+	if d.styles != nil {
+		d.pkg.Set(d.stylesName, d.styles.Bytes())
+	}
+	if d.numbering != nil {
+		d.pkg.Set(d.numberingName, d.numbering.Bytes())
+	}
+	return d.pkg.Bytes()
+}
+
+func (d *docxPackage) body() *ooxml.Node {
+	return d.main.Child(d.main.Root, ooxml.NSMain, "body")
+}
+
+// content returns the elements of the body but its last section
+// properties, and those properties.
+func (d *docxPackage) content() ([]any, *ooxml.Node) {
+	children := d.body().Children
+	for i := len(children) - 1; i >= 0; i-- {
+		c, ok := children[i].(*ooxml.Node)
+		if !ok {
+			continue
+		}
+		if d.main.Is(c, ooxml.NSMain, "sectPr") {
+			return append(children[:i:i], children[i+1:]...), c
+		}
+		break
+	}
+	return children, nil
+}
+
+// val returns the w:val attribute of n.
+func val(doc *ooxml.Document, n *ooxml.Node) string {
+	return doc.Value(n, ooxml.NSMain, "val")
+}
+
+// styleElements lists the style elements of the styles part.
+func (d *docxPackage) styleElements() []*ooxml.Node {
+	if d.styles == nil {
+		return nil
+	}
+	var out []*ooxml.Node
+	for _, c := range d.styles.Root.Children {
+		if c, ok := c.(*ooxml.Node); ok && d.styles.Is(c, ooxml.NSMain, "style") {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// styleName returns the type and the name of the style element s.
+func (d *docxPackage) styleName(s *ooxml.Node) (string, string) {
+	name := ""
+	if n := d.styles.Child(s, ooxml.NSMain, "name"); n != nil {
+		name = val(d.styles, n)
+	}
+	return d.styles.Value(s, ooxml.NSMain, "type"), name
+}
+
+// headingLevels returns the outline level, from 1 to 9, of the paragraph
+// styles of headings, by style id. Styles inherit the level of the style
+// they are based on.
+func (d *docxPackage) headingLevels() map[string]int {
+	own := make(map[string]int)
+	basedOn := make(map[string]string)
+	for _, s := range d.styleElements() {
+		id := d.styles.Value(s, ooxml.NSMain, "styleId")
+		if _, name := d.styleName(s); name != "" {
+			if level, ok := headingLevel(name); ok {
+				own[id] = level
+			}
+		}
+		if pPr := d.styles.Child(s, ooxml.NSMain, "pPr"); pPr != nil {
+			if outline := d.styles.Child(pPr, ooxml.NSMain, "outlineLvl"); outline != nil {
+				own[id] = outlineLevel(val(d.styles, outline))
+			}
+		}
+		if b := d.styles.Child(s, ooxml.NSMain, "basedOn"); b != nil {
+			basedOn[id] = val(d.styles, b)
+		}
+	}
+
+	levels := make(map[string]int)
+	for id := range basedOn {
+		// Following at most as many links as there are styles stops at
+		// cycles.
+		for s, i := id, 0; s != "" && i <= len(basedOn); s, i = basedOn[s], i+1 {
+			if level, ok := own[s]; ok {
+				levels[id] = level
+				break
+			}
+		}
+	}
+	for id, level := range own {
+		levels[id] = level
+	}
+	return levels
+}
+
+// paragraphLevel returns the outline level of the paragraph p, 0 for body
+// text.
+func (d *docxPackage) paragraphLevel(p *ooxml.Node, levels map[string]int) int {
+	pPr := d.main.Child(p, ooxml.NSMain, "pPr")
+	if pPr == nil {
+		return 0
+	}
+	if outline := d.main.Child(pPr, ooxml.NSMain, "outlineLvl"); outline != nil {
+		return outlineLevel(val(d.main, outline))
+	}
+	if style := d.main.Child(pPr, ooxml.NSMain, "pStyle"); style != nil {
+		return levels[val(d.main, style)]
+	}
+	return 0
+}
+
+// paragraphText returns the text of the paragraph p.
+func (d *docxPackage) paragraphText(p *ooxml.Node) string {
+	var b strings.Builder
+	ooxml.Walk(p, func(n *ooxml.Node) bool {
+		if d.main.Is(n, ooxml.NSMain, "t") {
+			for _, c := range n.Children {
+				if c, ok := c.(xml.CharData); ok {
+					b.Write(c)
+				}
+			}
+			return false
+		}
+		return true
+	})
+	return strings.TrimSpace(b.String())
+}
+
+// pageBreak returns a paragraph holding a page break.
+func (d *docxPackage) pageBreak() *ooxml.Node {
+	br := d.main.Element(ooxml.NSMain, "br", "type", "page")
+	r := d.main.Element(ooxml.NSMain, "r")
+	r.Children = []any{br}
+	p := d.main.Element(ooxml.NSMain, "p")
+	p.Children = []any{r}
+	return p
+}
+
+// headingLevel returns the level of the built-in heading styles of Word,
+// whose names are stored in English whatever the language of the user.
+func headingLevel(name string) (int, bool) {
+	rest, ok := strings.CutPrefix(strings.ToLower(name), "heading ")
+	if !ok {
+		return 0, false
+	}
+	level, err := strconv.Atoi(rest)
+	if err != nil || level < 1 || level > MaxHeadingLevel {
+		return 0, false
+	}
+	return level, true
+}
+
+// outlineLevel converts the value of w:outlineLvl, from 0 for the top
+// level, to a heading level. Level 9 marks body text.
+func outlineLevel(value string) int {
+	level, err := strconv.Atoi(value)
+	if err != nil || level < 0 || level >= MaxHeadingLevel {
+		return 0
+	}
+	return level + 1
+}
+
+// mergeNamespaces declares on the root of dst the namespace prefixes src
+// declares, and marks ignorable the prefixes src marks ignorable.
+func mergeNamespaces(dst, src *ooxml.Document) error {
+	for _, a := range src.Root.Start.Attr {
+		if a.Name.Space != ooxml.NSXMLNS {
+			continue
+		}
+		switch dst.Namespace(a.Name.Local) {
+		case a.Value:
+		case "":
+			dst.Root.Start.Attr = append(dst.Root.Start.Attr, a)
+		default:
+			return fmt.Errorf("%w: prefix %s is bound to different namespaces", ErrIncompatibleDocuments, a.Name.Local)
+		}
+	}
+
+	ignorable := strings.Fields(src.Value(src.Root, ooxml.NSCompatibility, "Ignorable"))
+	if len(ignorable) == 0 {
+		return nil
+	}
+	i := dst.Attr(dst.Root, ooxml.NSCompatibility, "Ignorable")
+	if i < 0 {
+		prefix, _ := dst.Prefix(ooxml.NSCompatibility)
+		dst.Root.Start.Attr = append(dst.Root.Start.Attr, xml.Attr{Name: xml.Name{Space: prefix, Local: "Ignorable"}})
+		i = len(dst.Root.Start.Attr) - 1
+	}
+	prefixes := strings.Fields(dst.Root.Start.Attr[i].Value)
+	for _, p := range ignorable {
+		if !slices.Contains(prefixes, p) {
+			prefixes = append(prefixes, p)
+		}
+	}
+	dst.Root.Start.Attr[i].Value = strings.Join(prefixes, " ")
+	return nil
+}
@@ -0,0 +1,131 @@
+package docmerge
+
+import (
+	"strings"
+
+	"github.com/a1y/doc-formatter/internal/storage/util/docparse"
+)
+
+// markdownPageBreak forces a page break where Markdown is rendered for
+// print, and shows nothing on screen.
+const markdownPageBreak = `<div style="page-break-after: always;"></div>`
+
+// mergeMarkdown joins the documents with a blank line, or a page break,
+// between them. Only the front matter of the first document is kept, and
+// code blocks left open at the end of a document are closed so that they do
+// not swallow the next one.
+func mergeMarkdown(docs [][]byte, opts Options) []byte {
+	var b strings.Builder
+	for i, data := range docs {
+		lines := docparse.MarkdownLines(data)
+		if i > 0 {
+			_, lines = docparse.SplitFrontMatter(lines)
+			b.WriteString("\n")
+			if opts.PageBreaks {
+				b.WriteString(markdownPageBreak + "\n\n")
+			}
+		}
+		lines = trimBlankLines(lines)
+		if fence := unclosedFence(lines); fence != "" {
+			lines = append(lines, fence)
+		}
+		for _, line := range lines {
+			b.WriteString(line)
+			b.WriteString("\n")
+		}
+	}
+	return []byte(b.String())
+}
+
+// splitMarkdown cuts the document data before every heading of level or
+// above. Front matter stays with the content before the first heading, and
+// is dropped with it when there is no such content.
+func splitMarkdown(data []byte, level int) ([]Part, error) {
+	lines := docparse.MarkdownLines(data)
+	front, lines := docparse.SplitFrontMatter(lines)
+
+	// body holds the lines of each section.
+	sections := []Part{{}}
+	body := [][]string{nil}
+	fence := ""
+	for i, line := range lines {
+		if fence != "" {
+			if docparse.ClosesFence(line, fence) {
+				fence = ""
+			}
+			body[len(body)-1] = append(body[len(body)-1], line)
+			continue
+		}
+		fence = docparse.OpenFence(line)
+		if l, title, ok := markdownHeading(lines, i); fence == "" && ok && l <= level {
+			sections = append(sections, Part{Title: title})
+			body = append(body, nil)
+		}
+		body[len(body)-1] = append(body[len(body)-1], line)
+	}
+	if len(sections) == 1 {
+		return nil, ErrNoHeadings
+	}
+	if len(trimBlankLines(body[0])) == 0 {
+		sections, body = sections[1:], body[1:]
+	} else {
+		body[0] = append(front, body[0]...)
+	}
+	if len(sections) > MaxParts {
+		return nil, ErrTooManyParts
+	}
+
+	for i, lines := range body {
+		lines = trimBlankLines(lines)
+		sections[i].Content = []byte(strings.Join(lines, "\n") + "\n")
+	}
+	return sections, nil
+}
+
+// trimBlankLines drops the blank lines lines starts and ends with.
+func trimBlankLines(lines []string) []string {
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// unclosedFence returns the line closing the code block left open at the
+// end of lines, empty when there is none.
+func unclosedFence(lines []string) string {
+	fence := ""
+	for _, line := range lines {
+		if fence != "" {
+			if docparse.ClosesFence(line, fence) {
+				fence = ""
+			}
+		} else {
+			fence = docparse.OpenFence(line)
+		}
+	}
+	return fence
+}
+
+// markdownHeading reports whether the line i of lines starts a heading, and
+// returns its level and text.
+func markdownHeading(lines []string, i int) (int, string, bool) {
+	if m := docparse.ATXHeading.FindStringSubmatch(lines[i]); m != nil {
+		return len(m[1]), docparse.InlineText(m[2]), true
+	}
+	// Setext headings are told from paragraphs by their underline. Only
+	// one-line headings are recognized, lest a thematic break under a
+	// paragraph be taken for one.
+	if strings.TrimSpace(lines[i]) == "" || i+1 >= len(lines) || (i > 0 && strings.TrimSpace(lines[i-1]) != "") {
+		return 0, "", false
+	}
+	switch {
+	case docparse.SetextH1.MatchString(lines[i+1]):
+		return 1, docparse.InlineText(lines[i]), true
+	case docparse.SetextH2.MatchString(lines[i+1]):
+		return 2, docparse.InlineText(lines[i]), true
+	}
+	return 0, "", false
+}
@@ -0,0 +1,401 @@
+package docmerge
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/a1y/doc-formatter/internal/storage/util/ooxml"
+)
+
+// mergeDOCX appends the bodies of the documents after the first to the body
+// of the first. The first document sets the page setup, headers and footers
+// of the result, and its styles win over the styles of the same id or name
+// of the other documents; styles it lacks are copied over, as are the lists,
+// images, links and other parts the appended content refers to. Comments,
+// footnotes and endnotes of the documents after the first are left out.
+func mergeDOCX(docs [][]byte, opts Options) ([]byte, error) {
+	base, err := openDOCX(docs[0])
+	if err != nil {
+		return nil, err
+	}
+	ids := &idCounters{}
+	ooxml.Walk(base.body(), func(n *ooxml.Node) bool {
+		ids.scan(base.main, n)
+		return true
+	})
+
+	var appended []any
+	for _, data := range docs[1:] {
+		part, err := openDOCX(data)
+		if err != nil {
+			return nil, err
+		}
+		m := newMerger(base, part, ids)
+		content, err := m.merge()
+		if err != nil {
+			return nil, err
+		}
+		if opts.PageBreaks {
+			appended = append(appended, base.pageBreak())
+		}
+		appended = append(appended, content...)
+	}
+
+	content, sectPr := base.content()
+	children := append(content[:len(content):len(content)], appended...)
+	if sectPr != nil {
+		children = append(children, sectPr)
+	}
+	base.body().Children = children
+	return base.bytes()
+}
+
+// idCounters hands out the ids of bookmarks and drawings, which must be
+// unique within a document.
+type idCounters struct {
+	bookmark int
+	drawing  int
+}
+
+// scan accounts for the ids n uses.
+func (c *idCounters) scan(doc *ooxml.Document, n *ooxml.Node) {
+	switch {
+	case doc.Is(n, ooxml.NSMain, "bookmarkStart"):
+		if id, err := strconv.Atoi(doc.Value(n, ooxml.NSMain, "id")); err == nil {
+			c.bookmark = max(c.bookmark, id)
+		}
+	case doc.Is(n, ooxml.NSDrawing, "docPr"):
+		if id, err := strconv.Atoi(doc.Value(n, "", "id")); err == nil {
+			c.drawing = max(c.drawing, id)
+		}
+	}
+}
+
+// merger copies the content of the document part into the document base.
+type merger struct {
+	base, part *docxPackage
+	ids        *idCounters
+	// baseStyles holds the ids of the styles of base, and baseNames their
+	// ids by type and lowercased name.
+	baseStyles map[string]bool
+	baseNames  map[string]string
+	// styles, numbers, rels and parts map the style ids, list ids,
+	// relationship ids and part names of part to those of base.
+	styles  map[string]string
+	numbers map[string]string
+	rels    map[string]string
+	parts   map[string]string
+	err     error
+}
+
+func newMerger(base, part *docxPackage, ids *idCounters) *merger {
+	m := &merger{
+		base:       base,
+		part:       part,
+		ids:        ids,
+		baseStyles: make(map[string]bool),
+		baseNames:  make(map[string]string),
+		styles:     make(map[string]string),
+		numbers:    make(map[string]string),
+		rels:       make(map[string]string),
+		parts:      make(map[string]string),
+	}
+	for _, s := range base.styleElements() {
+		id := base.styles.Value(s, ooxml.NSMain, "styleId")
+		m.baseStyles[id] = true
+		if typ, name := base.styleName(s); name != "" {
+			m.baseNames[styleKey(typ, name)] = id
+		}
+	}
+	return m
+}
+
+func styleKey(typ, name string) string {
+	return typ + "\x00" + strings.ToLower(name)
+}
+
+// merge returns the content of the body of part, ready to be appended to
+// the body of base.
+func (m *merger) merge() ([]any, error) {
+	if err := mergeNamespaces(m.base.main, m.part.main); err != nil {
+		return nil, err
+	}
+
+	content, _ := m.part.content()
+	container := &ooxml.Node{}
+	for _, c := range content {
+		// White space between elements is left behind.
+		if c, ok := c.(*ooxml.Node); ok {
+			container.Children = append(container.Children, ooxml.Clone(c))
+		}
+	}
+	doc := m.part.main
+	ooxml.Filter(container, func(n *ooxml.Node) bool {
+		for _, local := range []string{"commentRangeStart", "commentRangeEnd", "commentReference", "footnoteReference", "endnoteReference"} {
+			if doc.Is(n, ooxml.NSMain, local) {
+				return false
+			}
+		}
+		return true
+	})
+
+	bookmarks := make(map[string]string)
+	ooxml.Walk(container, func(n *ooxml.Node) bool {
+		switch {
+		case doc.Is(n, ooxml.NSMain, "pStyle"), doc.Is(n, ooxml.NSMain, "rStyle"), doc.Is(n, ooxml.NSMain, "tblStyle"):
+			setVal(doc, n, m.style)
+		case doc.Is(n, ooxml.NSMain, "numId"):
+			setVal(doc, n, m.number)
+		case doc.Is(n, ooxml.NSMain, "bookmarkStart"), doc.Is(n, ooxml.NSMain, "bookmarkEnd"):
+			if i := doc.Attr(n, ooxml.NSMain, "id"); i >= 0 {
+				old := n.Start.Attr[i].Value
+				if _, ok := bookmarks[old]; !ok {
+					m.ids.bookmark++
+					bookmarks[old] = strconv.Itoa(m.ids.bookmark)
+				}
+				n.Start.Attr[i].Value = bookmarks[old]
+			}
+		case doc.Is(n, ooxml.NSDrawing, "docPr"):
+			if i := doc.Attr(n, "", "id"); i >= 0 {
+				m.ids.drawing++
+				n.Start.Attr[i].Value = strconv.Itoa(m.ids.drawing)
+			}
+		}
+		for i, a := range n.Start.Attr {
+			if a.Name.Space != "" && doc.Namespace(a.Name.Space) == ooxml.NSRelationships {
+				n.Start.Attr[i].Value = m.rel(a.Value)
+			}
+		}
+		return true
+	})
+	if m.err != nil {
+		return nil, m.err
+	}
+	return container.Children, nil
+}
+
+// setVal replaces the w:val attribute of n with what replace returns for
+// it.
+func setVal(doc *ooxml.Document, n *ooxml.Node, replace func(string) string) {
+	if i := doc.Attr(n, ooxml.NSMain, "val"); i >= 0 {
+		n.Start.Attr[i].Value = replace(n.Start.Attr[i].Value)
+	}
+}
+
+func (m *merger) fail(err error) {
+	if m.err == nil {
+		m.err = err
+	}
+}
+
+// style returns the id in base of the style id of part: the style of base
+// with the same id, or else with the same type and name, or else a copy of
+// the style of part.
+func (m *merger) style(id string) string {
+	if mapped, ok := m.styles[id]; ok {
+		return mapped
+	}
+	m.styles[id] = id
+	if m.baseStyles[id] || m.base.styles == nil || m.part.styles == nil {
+		return id
+	}
+	var src *ooxml.Node
+	for _, s := range m.part.styleElements() {
+		if m.part.styles.Value(s, ooxml.NSMain, "styleId") == id {
+			src = s
+			break
+		}
+	}
+	if src == nil {
+		return id
+	}
+	typ, name := m.part.styleName(src)
+	if other, ok := m.baseNames[styleKey(typ, name)]; ok && name != "" {
+		m.styles[id] = other
+		return other
+	}
+
+	if err := mergeNamespaces(m.base.styles, m.part.styles); err != nil {
+		m.fail(err)
+		return id
+	}
+	copied := ooxml.Clone(src)
+	m.base.styles.Root.Children = append(m.base.styles.Root.Children, copied)
+	m.baseStyles[id] = true
+	doc := m.part.styles
+	ooxml.Walk(copied, func(n *ooxml.Node) bool {
+		switch {
+		case doc.Is(n, ooxml.NSMain, "basedOn"), doc.Is(n, ooxml.NSMain, "next"), doc.Is(n, ooxml.NSMain, "link"):
+			setVal(doc, n, m.style)
+		case doc.Is(n, ooxml.NSMain, "numId"):
+			setVal(doc, n, m.number)
+		}
+		return true
+	})
+	return id
+}
+
+// number returns the id in base of the list id of part, whose definition is
+// copied over under new ids. List 0 stands for no list.
+func (m *merger) number(id string) string {
+	if mapped, ok := m.numbers[id]; ok {
+		return mapped
+	}
+	m.numbers[id] = id
+	if id == "0" || m.part.numbering == nil {
+		return id
+	}
+	doc := m.part.numbering
+	num := numberingElement(doc, "num", "numId", id)
+	if num == nil {
+		return id
+	}
+	abstractRef := doc.Child(num, ooxml.NSMain, "abstractNumId")
+	if abstractRef == nil {
+		return id
+	}
+	abstract := numberingElement(doc, "abstractNum", "abstractNumId", val(doc, abstractRef))
+	if abstract == nil {
+		return id
+	}
+	m.ensureNumbering()
+	if err := mergeNamespaces(m.base.numbering, doc); err != nil {
+		m.fail(err)
+		return id
+	}
+
+	base := m.base.numbering
+	abstractID := strconv.Itoa(maxNumberingID(base, "abstractNum", "abstractNumId") + 1)
+	numID := strconv.Itoa(maxNumberingID(base, "num", "numId") + 1)
+	m.numbers[id] = numID
+
+	copiedAbstract := ooxml.Clone(abstract)
+	copiedAbstract.Start.Attr[doc.Attr(copiedAbstract, ooxml.NSMain, "abstractNumId")].Value = abstractID
+	// Lists sharing an nsid may be joined into one by Word.
+	ooxml.Filter(copiedAbstract, func(n *ooxml.Node) bool { return !doc.Is(n, ooxml.NSMain, "nsid") })
+	copiedNum := ooxml.Clone(num)
+	copiedNum.Start.Attr[doc.Attr(copiedNum, ooxml.NSMain, "numId")].Value = numID
+	setVal(doc, doc.Child(copiedNum, ooxml.NSMain, "abstractNumId"), func(string) string { return abstractID })
+
+	// Abstract definitions come before the lists using them.
+	children := base.Root.Children
+	at := len(children)
+	for i, c := range children {
+		if c, ok := c.(*ooxml.Node); ok && !base.Is(c, ooxml.NSMain, "abstractNum") {
+			at = i
+			break
+		}
+	}
+	children = append(children[:at:at], append([]any{copiedAbstract}, children[at:]...)...)
+	last := len(children)
+	for i, c := range children {
+		if c, ok := c.(*ooxml.Node); ok && base.Is(c, ooxml.NSMain, "num") {
+			last = i + 1
+		}
+	}
+	base.Root.Children = append(children[:last:last], append([]any{copiedNum}, children[last:]...)...)
+
+	ooxml.Walk(copiedAbstract, func(n *ooxml.Node) bool {
+		if doc.Is(n, ooxml.NSMain, "pStyle") {
+			setVal(doc, n, m.style)
+		}
+		return true
+	})
+	return numID
+}
+
+// ensureNumbering adds an empty numbering part to base unless it has one.
+func (m *merger) ensureNumbering() {
+	if m.base.numbering != nil {
+		return
+	}
+	name := m.base.pkg.Unique(ooxml.Resolve(m.base.mainName, "numbering.xml"))
+	m.base.pkg.Set(name, nil)
+	m.base.numberingName = name
+	m.base.numbering = &ooxml.Document{
+		Prolog: m.part.numbering.Prolog,
+		Root:   &ooxml.Node{Start: m.part.numbering.Root.Start.Copy()},
+	}
+	ooxml.AddRelationship(m.base.rels, ooxml.RelNumbering, ooxml.Relative(m.base.mainName, name), "")
+	ooxml.SetContentType(m.base.types, name, typeNumbering)
+}
+
+// numberingElement returns the child local of the numbering part whose
+// attribute attr is id.
+func numberingElement(doc *ooxml.Document, local, attr, id string) *ooxml.Node {
+	for _, c := range doc.Root.Children {
+		if c, ok := c.(*ooxml.Node); ok && doc.Is(c, ooxml.NSMain, local) && doc.Value(c, ooxml.NSMain, attr) == id {
+			return c
+		}
+	}
+	return nil
+}
+
+func maxNumberingID(doc *ooxml.Document, local, attr string) int {
+	highest := 0
+	for _, c := range doc.Root.Children {
+		if c, ok := c.(*ooxml.Node); ok && doc.Is(c, ooxml.NSMain, local) {
+			if id, err := strconv.Atoi(doc.Value(c, ooxml.NSMain, attr)); err == nil {
+				highest = max(highest, id)
+			}
+		}
+	}
+	return highest
+}
+
+// rel returns the id in base of the relationship id of the document part of
+// part, adding the relationship and copying the part it targets.
+func (m *merger) rel(id string) string {
+	if mapped, ok := m.rels[id]; ok {
+		return mapped
+	}
+	m.rels[id] = id
+	r := ooxml.Relationship(m.part.rels, id)
+	if r == nil {
+		return id
+	}
+	rels := m.part.rels
+	typ, target, mode := rels.Value(r, "", "Type"), rels.Value(r, "", "Target"), rels.Value(r, "", "TargetMode")
+	if mode != ooxml.TargetExternal {
+		name := m.copyPart(ooxml.Resolve(m.part.mainName, target))
+		target = ooxml.Relative(m.base.mainName, name)
+	}
+	m.rels[id] = ooxml.AddRelationship(m.base.rels, typ, target, mode)
+	return m.rels[id]
+}
+
+// copyPart copies the part name of part into base, along with the parts it
+// refers to, and returns its name in base.
+func (m *merger) copyPart(name string) string {
+	if copied, ok := m.parts[name]; ok {
+		return copied
+	}
+	data, ok := m.part.pkg.Part(name)
+	if !ok {
+		m.parts[name] = name
+		return name
+	}
+	copied := m.base.pkg.Unique(name)
+	m.parts[name] = copied
+	m.base.pkg.Set(copied, data)
+	ooxml.SetContentType(m.base.types, copied, ooxml.ContentType(m.part.types, name))
+
+	rels, err := m.part.pkg.XML(ooxml.RelsName(name))
+	if err != nil {
+		m.fail(err)
+		return copied
+	}
+	if rels == nil {
+		return copied
+	}
+	for _, r := range ooxml.Relationships(rels) {
+		i := rels.Attr(r, "", "Target")
+		if i < 0 || rels.Value(r, "", "TargetMode") == ooxml.TargetExternal {
+			continue
+		}
+		target := m.copyPart(ooxml.Resolve(name, r.Start.Attr[i].Value))
+		r.Start.Attr[i].Value = ooxml.Relative(copied, target)
+	}
+	m.base.pkg.Set(ooxml.RelsName(copied), rels.Bytes())
+	return copied
+}
@@ -0,0 +1,209 @@
+package docmerge
+
+import "github.com/a1y/doc-formatter/internal/storage/util/ooxml"
+
+// contentRelTypes are the types of the relationships the content of a
+// document body refers to, which a part split from the document only keeps
+// when its own content refers to them.
+var contentRelTypes = map[string]bool{
+	ooxml.RelTypePrefix + "image":             true,
+	ooxml.RelTypePrefix + "hyperlink":         true,
+	ooxml.RelTypePrefix + "chart":             true,
+	ooxml.RelTypePrefix + "oleObject":         true,
+	ooxml.RelTypePrefix + "package":           true,
+	ooxml.RelTypePrefix + "diagramData":       true,
+	ooxml.RelTypePrefix + "diagramLayout":     true,
+	ooxml.RelTypePrefix + "diagramQuickStyle": true,
+	ooxml.RelTypePrefix + "diagramColors":     true,
+	ooxml.RelTypePrefix + "aFChunk":           true,
+}
+
+// splitDOCX cuts the document data before every heading of level or above.
+// The parts keep the styles, page setup, headers and footers of the
+// document.
+func splitDOCX(data []byte, level int) ([]Part, error) {
+	src, err := openDOCX(data)
+	if err != nil {
+		return nil, err
+	}
+	levels := src.headingLevels()
+	content, sectPr := src.content()
+
+	type section struct {
+		title string
+		nodes []any
+	}
+	sections := []section{{}}
+	headings := 0
+	for _, c := range content {
+		n, ok := c.(*ooxml.Node)
+		if !ok {
+			continue
+		}
+		if src.main.Is(n, ooxml.NSMain, "p") {
+			if l := src.paragraphLevel(n, levels); l > 0 && l <= level {
+				headings++
+				sections = append(sections, section{title: src.paragraphText(n)})
+			}
+		}
+		last := &sections[len(sections)-1]
+		last.nodes = append(last.nodes, n)
+	}
+	if headings == 0 {
+		return nil, ErrNoHeadings
+	}
+	if !src.hasContent(sections[0].nodes) {
+		sections = sections[1:]
+	}
+	if len(sections) > MaxParts {
+		return nil, ErrTooManyParts
+	}
+
+	parts := make([]Part, len(sections))
+	for i, s := range sections {
+		out, err := src.withContent(s.nodes, sectPr)
+		if err != nil {
+			return nil, err
+		}
+		if parts[i].Content, err = out.bytes(); err != nil {
+			return nil, err
+		}
+		parts[i].Title = s.title
+	}
+	return parts, nil
+}
+
+// hasContent reports whether nodes hold text, or anything but paragraphs.
+func (d *docxPackage) hasContent(nodes []any) bool {
+	for _, c := range nodes {
+		n := c.(*ooxml.Node)
+		if !d.main.Is(n, ooxml.NSMain, "p") || d.paragraphText(n) != "" {
+			return true
+		}
+		found := false
+		ooxml.Walk(n, func(n *ooxml.Node) bool {
+			found = found || d.main.Is(n, ooxml.NSMain, "drawing") || d.main.Is(n, ooxml.NSMain, "pict") || d.main.Is(n, ooxml.NSMain, "object")
+			return !found
+		})
+		if found {
+			return true
+		}
+	}
+	return false
+}
+
+// withContent returns a copy of d whose body holds nodes, followed by the
+// section properties sectPr. The relationships and parts only other content
+// refers to are left out.
+func (d *docxPackage) withContent(nodes []any, sectPr *ooxml.Node) (*docxPackage, error) {
+	out := *d
+	out.pkg = d.pkg.Clone()
+	var err error
+	if out.types, err = out.pkg.XML(ooxml.ContentTypesName); err != nil {
+		return nil, err
+	}
+	if out.rels, err = ooxml.Parse(d.rels.Bytes()); err != nil {
+		return nil, err
+	}
+
+	body := &ooxml.Node{Start: d.body().Start.Copy()}
+	for _, n := range nodes {
+		body.Children = append(body.Children, ooxml.Clone(n.(*ooxml.Node)))
+	}
+	if sectPr != nil {
+		body.Children = append(body.Children, sectPr)
+	}
+	root := &ooxml.Node{Start: d.main.Root.Start}
+	for _, c := range d.main.Root.Children {
+		if c, ok := c.(*ooxml.Node); ok && d.main.Is(c, ooxml.NSMain, "body") {
+			root.Children = append(root.Children, body)
+		} else {
+			root.Children = append(root.Children, c)
+		}
+	}
+	out.main = &ooxml.Document{Prolog: d.main.Prolog, Root: root}
+
+	// Bookmarks whose other end went to another part are dropped.
+	starts, ends := make(map[string]bool), make(map[string]bool)
+	ooxml.Walk(body, func(n *ooxml.Node) bool {
+		switch {
+		case out.main.Is(n, ooxml.NSMain, "bookmarkStart"):
+			starts[out.main.Value(n, ooxml.NSMain, "id")] = true
+		case out.main.Is(n, ooxml.NSMain, "bookmarkEnd"):
+			ends[out.main.Value(n, ooxml.NSMain, "id")] = true
+		}
+		return true
+	})
+	ooxml.Filter(body, func(n *ooxml.Node) bool {
+		switch {
+		case out.main.Is(n, ooxml.NSMain, "bookmarkStart"):
+			return ends[out.main.Value(n, ooxml.NSMain, "id")]
+		case out.main.Is(n, ooxml.NSMain, "bookmarkEnd"):
+			return starts[out.main.Value(n, ooxml.NSMain, "id")]
+		}
+		return true
+	})
+
+	out.pruneRelationships(body)
+	return &out, nil
+}
+
+// pruneRelationships drops the relationships of content types that nothing
+// in body refers to, along with the parts no relationship targets anymore.
+func (d *docxPackage) pruneRelationships(body *ooxml.Node) {
+	used := make(map[string]bool)
+	ooxml.Walk(body, func(n *ooxml.Node) bool {
+		for _, a := range n.Start.Attr {
+			if a.Name.Space != "" && d.main.Namespace(a.Name.Space) == ooxml.NSRelationships {
+				used[a.Value] = true
+			}
+		}
+		return true
+	})
+
+	var dropped []string
+	ooxml.Filter(d.rels.Root, func(r *ooxml.Node) bool {
+		id := d.rels.Value(r, "", "Id")
+		if used[id] || !contentRelTypes[d.rels.Value(r, "", "Type")] {
+			return true
+		}
+		if d.rels.Value(r, "", "TargetMode") != ooxml.TargetExternal {
+			dropped = append(dropped, ooxml.Resolve(d.mainName, d.rels.Value(r, "", "Target")))
+		}
+		return false
+	})
+	if len(dropped) == 0 {
+		return
+	}
+
+	targeted := make(map[string]bool)
+	for _, r := range ooxml.Relationships(d.rels) {
+		if d.rels.Value(r, "", "TargetMode") != ooxml.TargetExternal {
+			targeted[ooxml.Resolve(d.mainName, d.rels.Value(r, "", "Target"))] = true
+		}
+	}
+	for _, name := range d.pkg.Names() {
+		source, ok := ooxml.RelsSource(name)
+		if !ok || source == d.mainName {
+			continue
+		}
+		rels, err := d.pkg.XML(name)
+		if err != nil {
+			// Parts whose relationships cannot be read are kept, with
+			// whatever they refer to.
+			return
+		}
+		for _, r := range ooxml.Relationships(rels) {
+			if rels.Value(r, "", "TargetMode") != ooxml.TargetExternal {
+				targeted[ooxml.Resolve(source, rels.Value(r, "", "Target"))] = true
+			}
+		}
+	}
+	for _, name := range dropped {
+		if !targeted[name] {
+			d.pkg.Remove(name)
+			d.pkg.Remove(ooxml.RelsName(name))
+			ooxml.RemoveOverride(d.types, name)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package docmerge
+
+import (
+	"errors"
+
+	"github.com/a1y/doc-formatter/internal/storage/util/textextract"
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("merging and splitting are not supported for this format")
+	// ErrDocumentTooLarge and ErrMalformedDocument are shared with text
+	// extraction, which bounds documents the same way.
+	ErrDocumentTooLarge  = textextract.ErrDocumentTooLarge
+	ErrMalformedDocument = textextract.ErrMalformedDocument
+	ErrTooManyParts      = errors.New("too many parts")
+	ErrNoHeadings        = errors.New("document has no headings to split at")
+	// ErrIncompatibleDocuments is returned for documents that declare the
+	// same namespace prefix for different namespaces.
+	ErrIncompatibleDocuments = errors.New("documents cannot be merged")
+)
+
+const (
+	// MaxParts caps the documents merged into one, and the documents one is
+	// split into.
+	MaxParts = 100
+	// MaxSize is the largest total size of the documents merged, and the
+	// largest document split. Package parts are bounded by it once
+	// decompressed.
+	MaxSize = textextract.MaxDocumentSize
+	// MaxHeadingLevel is the deepest heading level documents are split at.
+	MaxHeadingLevel = 9
+)
+
+// Options tune Merge.
+type Options struct {
+	// PageBreaks starts every document merged after the first on a new page.
+	PageBreaks bool
+}
+
+// Part is a document a document was split into.
+type Part struct {
+	// Title is the text of the heading the part starts with, empty for the
+	// content before the first heading.
+	Title   string
+	Content []byte
+}
@@ -0,0 +1,113 @@
+package docparse
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func zipFiles(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, content := range files {
+		f, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func TestPackage(t *testing.T) {
+	pkg, err := OpenPackage(zipFiles(t, map[string]string{
+		"content.xml": `<doc><p>one</p><p>two</p><p>three</p></doc>`,
+		"broken.xml":  `<doc><p>`,
+	}))
+	require.NoError(t, err)
+
+	data, err := pkg.Part("content.xml", true)
+	require.NoError(t, err)
+	require.Contains(t, string(data), "<p>two</p>")
+
+	data, err = pkg.Part("missing.xml", false)
+	require.NoError(t, err)
+	require.Nil(t, data)
+	_, err = pkg.Part("missing.xml", true)
+	require.ErrorIs(t, err, ErrMalformedDocument)
+
+	// The walk stops once visit returns false.
+	var texts []string
+	err = pkg.Walk("content.xml", true, func(token xml.Token) bool {
+		if text, ok := token.(xml.CharData); ok {
+			texts = append(texts, string(text))
+		}
+		return len(texts) < 2
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"one", "two"}, texts)
+
+	require.NoError(t, pkg.Walk("missing.xml", false, func(xml.Token) bool { return true }))
+	err = pkg.Walk("broken.xml", true, func(xml.Token) bool { return true })
+	require.ErrorIs(t, err, ErrMalformedDocument)
+	require.Contains(t, err.Error(), "broken.xml")
+
+	_, err = ReadFile(pkg.File[0], 4)
+	require.ErrorIs(t, err, ErrDocumentTooLarge)
+
+	_, err = OpenPackage([]byte("not a package"))
+	require.ErrorIs(t, err, ErrMalformedDocument)
+}
+
+func TestMarkdownLines(t *testing.T) {
+	require.Equal(t, []string{"# Title", "", "Text"}, MarkdownLines([]byte("\ufeff# Title\r\n\r\nText")))
+}
+
+func TestSplitFrontMatter(t *testing.T) {
+	front, rest := SplitFrontMatter([]string{"---", "title: Notes", "...", "# Notes"})
+	require.Equal(t, []string{"---", "title: Notes", "..."}, front)
+	require.Equal(t, []string{"# Notes"}, rest)
+
+	// Unclosed front matter is text.
+	front, rest = SplitFrontMatter([]string{"---", "title: Notes"})
+	require.Nil(t, front)
+	require.Equal(t, []string{"---", "title: Notes"}, rest)
+}
+
+func TestFences(t *testing.T) {
+	require.Equal(t, "```", OpenFence("```go"))
+	require.Equal(t, "~~~~", OpenFence("   ~~~~"))
+	require.Empty(t, OpenFence("    ```"))
+	require.Empty(t, OpenFence("``"))
+
+	require.True(t, ClosesFence("```", "```"))
+	require.True(t, ClosesFence("`````", "```"))
+	require.False(t, ClosesFence("~~~", "```"))
+	require.False(t, ClosesFence("```go", "```"))
+}
+
+func TestInlineText(t *testing.T) {
+	require.Equal(t, "See the docs and a logo", InlineText(" See **the** [docs](https://example.com) and ![a logo](logo.png) "))
+}
+
+func TestTable(t *testing.T) {
+	lines := []string{"Intro", "| A | B |", "|---|:-:|", "| 1 | 2 |", "| 3 |", "", "After"}
+
+	_, next, ok := Table(lines, 0)
+	require.False(t, ok)
+	require.Equal(t, 0, next)
+
+	rows, next, ok := Table(lines, 1)
+	require.True(t, ok)
+	require.Equal(t, [][]string{{"A", "B"}, {"1", "2"}, {"3"}}, rows)
+	require.Equal(t, 5, next)
+
+	// A divider without dashes does not make a table.
+	_, _, ok = Table([]string{"a | b", "|:|"}, 0)
+	require.False(t, ok)
+}
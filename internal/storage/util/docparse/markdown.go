@@ -0,0 +1,95 @@
+package docparse
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Markdown blocks, matched against a single line.
+var (
+	ATXHeading    = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+(.*?))?(?:[ \t]+#+)?[ \t]*$`)
+	SetextH1      = regexp.MustCompile(`^ {0,3}=+[ \t]*$`)
+	SetextH2      = regexp.MustCompile(`^ {0,3}-+[ \t]*$`)
+	ThematicBreak = regexp.MustCompile(`^ {0,3}(?:-{3,}|\*{3,}|_{3,})[ \t]*$`)
+	ListItem      = regexp.MustCompile(`^ {0,3}(?:[-*+]|\d+[.)])[ \t]+`)
+	BlockQuote    = regexp.MustCompile(`^ {0,3}>[ \t]?`)
+	TableDivider  = regexp.MustCompile(`^[ \t]*\|?[ \t]*:?-+:?[ \t]*(?:\|[ \t]*:?-+:?[ \t]*)*\|?[ \t]*$`)
+	// ImageLink captures the alternative text and the target of images.
+	ImageLink = regexp.MustCompile(`!\[([^\]]*)\]\(\s*<?([^)\s>]+)>?(?:\s+"[^"]*")?\s*\)`)
+
+	codeFence      = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})")
+	inlineLink     = regexp.MustCompile(`!?\[([^\]]*)\]\([^)]*\)`)
+	inlineEmphasis = regexp.MustCompile("[*_~`]+")
+)
+
+// MarkdownLines splits Markdown text into lines, without their ends or a
+// leading byte order mark.
+func MarkdownLines(data []byte) []string {
+	text := strings.TrimPrefix(string(data), "\ufeff")
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	return strings.Split(text, "\n")
+}
+
+// SplitFrontMatter splits the YAML front matter off lines. The front matter
+// keeps the lines of three dashes or dots around it, and is nil when lines
+// do not start with any.
+func SplitFrontMatter(lines []string) ([]string, []string) {
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "---" {
+		return nil, lines
+	}
+	for i := 1; i < len(lines); i++ {
+		if end := strings.TrimSpace(lines[i]); end == "---" || end == "..." {
+			return lines[:i+1], lines[i+1:]
+		}
+	}
+	return nil, lines
+}
+
+// OpenFence returns the fence of the code block opened by line, empty when
+// line opens none.
+func OpenFence(line string) string {
+	if m := codeFence.FindStringSubmatch(line); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// ClosesFence reports whether line closes the code block opened by fence.
+func ClosesFence(line, fence string) bool {
+	trimmed := strings.TrimSpace(line)
+	return strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == ""
+}
+
+// InlineText drops the inline markup of Markdown text, keeping the text of
+// links and the alternative text of images.
+func InlineText(text string) string {
+	text = inlineLink.ReplaceAllString(text, "$1")
+	return strings.TrimSpace(inlineEmphasis.ReplaceAllString(text, ""))
+}
+
+// Table reads the table starting at the line i of lines, if any. It returns
+// the cells of its rows, the header first, and the index of the line after
+// the table.
+func Table(lines []string, i int) ([][]string, int, bool) {
+	if !strings.Contains(lines[i], "|") || i+1 >= len(lines) ||
+		!TableDivider.MatchString(lines[i+1]) || !strings.Contains(lines[i+1], "-") {
+		return nil, i, false
+	}
+	rows := [][]string{tableCells(lines[i])}
+	for i += 2; i < len(lines) && strings.Contains(lines[i], "|") && strings.TrimSpace(lines[i]) != ""; i++ {
+		rows = append(rows, tableCells(lines[i]))
+	}
+	return rows, i, true
+}
+
+// tableCells splits a row of a Markdown table into its cells.
+func tableCells(row string) []string {
+	row = strings.TrimSpace(row)
+	row = strings.TrimPrefix(row, "|")
+	row = strings.TrimSuffix(row, "|")
+	cells := strings.Split(row, "|")
+	for i, cell := range cells {
+		cells[i] = strings.TrimSpace(cell)
+	}
+	return cells
+}
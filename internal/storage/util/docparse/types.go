@@ -0,0 +1,12 @@
+package docparse
+
+import "errors"
+
+var (
+	ErrDocumentTooLarge  = errors.New("document is too large")
+	ErrMalformedDocument = errors.New("document is malformed")
+)
+
+// MaxDocumentSize is the largest document, or document part once
+// decompressed, that is read.
+const MaxDocumentSize = 64 << 20
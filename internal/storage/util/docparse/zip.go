@@ -0,0 +1,94 @@
+// Package docparse reads what the document tools have in common: the parts
+// of the ZIP packages of DOCX and ODT documents, and the blocks of Markdown
+// text.
+package docparse
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Package is a ZIP package, the container of DOCX and ODT documents.
+type Package struct {
+	*zip.Reader
+}
+
+// OpenPackage reads the directory of the ZIP package data.
+func OpenPackage(data []byte) (*Package, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformedDocument, err)
+	}
+	return &Package{Reader: archive}, nil
+}
+
+// Part reads the part name, up to MaxDocumentSize bytes once decompressed.
+// Missing parts read as nil unless they are required.
+func (p *Package) Part(name string, required bool) ([]byte, error) {
+	for _, file := range p.File {
+		if file.Name == name {
+			return ReadFile(file, MaxDocumentSize)
+		}
+	}
+	if required {
+		return nil, fmt.Errorf("%w: missing %s", ErrMalformedDocument, name)
+	}
+	return nil, nil
+}
+
+// Walk hands the XML tokens of the part name to visit, as WalkXML does.
+// Missing parts are skipped unless they are required.
+func (p *Package) Walk(name string, required bool, visit func(xml.Token) bool) error {
+	data, err := p.Part(name, required)
+	if err != nil || data == nil {
+		return err
+	}
+	if err := WalkXML(data, visit); err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return nil
+}
+
+// ReadFile reads the file of a ZIP package, up to limit bytes once
+// decompressed.
+func ReadFile(file *zip.File, limit int) ([]byte, error) {
+	if file.UncompressedSize64 > uint64(limit) {
+		return nil, ErrDocumentTooLarge
+	}
+	rc, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformedDocument, err)
+	}
+	defer rc.Close()
+	// The recorded size may lie, so the content is limited as it is read.
+	data, err := io.ReadAll(io.LimitReader(rc, int64(limit)+1))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformedDocument, err)
+	}
+	if len(data) > limit {
+		return nil, ErrDocumentTooLarge
+	}
+	return data, nil
+}
+
+// WalkXML hands the tokens of the XML document data to visit, until the end
+// of the document or until visit returns false.
+func WalkXML(data []byte, visit func(xml.Token) bool) error {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrMalformedDocument, err)
+		}
+		if !visit(token) {
+			return nil
+		}
+	}
+}
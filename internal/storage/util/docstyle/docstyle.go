@@ -0,0 +1,88 @@
+// Package docstyle applies house styles to documents: fonts, sizes, line
+// spacing and page margins.
+package docstyle
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/a1y/doc-formatter/internal/storage/util/textextract"
+)
+
+// Supported reports whether documents of contentType can be formatted.
+func Supported(contentType string) bool {
+	return contentType == textextract.TypeDOCX
+}
+
+// Empty reports whether p changes nothing.
+func (p Profile) Empty() bool {
+	return p.FontFamily == "" && p.FontSize == 0 && p.HeadingFontFamily == "" && p.LineSpacing == 0 && p.Margin == 0 &&
+		!p.ClearDirectFormatting
+}
+
+// Validate checks that p can be applied.
+func (p Profile) Validate() error {
+	for _, family := range []string{p.FontFamily, p.HeadingFontFamily} {
+		if err := validateFontFamily(family); err != nil {
+			return err
+		}
+	}
+	if p.FontSize != 0 && !(p.FontSize >= MinFontSize && p.FontSize <= MaxFontSize) {
+		return fmt.Errorf("%w: font size must be between %d and %d points", ErrInvalidProfile, MinFontSize, MaxFontSize)
+	}
+	if p.LineSpacing != 0 && !(p.LineSpacing >= MinLineSpacing && p.LineSpacing <= MaxLineSpacing) {
+		return fmt.Errorf("%w: line spacing must be between %v and %v lines", ErrInvalidProfile, MinLineSpacing, MaxLineSpacing)
+	}
+	if !(p.Margin >= 0 && p.Margin <= MaxMargin) {
+		return fmt.Errorf("%w: margin must be between 0 and %d points", ErrInvalidProfile, MaxMargin)
+	}
+	return nil
+}
+
+func validateFontFamily(family string) error {
+	if len(family) > MaxFontFamilyLength {
+		return fmt.Errorf("%w: font names must be at most %d bytes", ErrInvalidProfile, MaxFontFamilyLength)
+	}
+	if strings.TrimSpace(family) != family || strings.ContainsFunc(family, unicode.IsControl) {
+		return fmt.Errorf("%w: invalid font name %q", ErrInvalidProfile, family)
+	}
+	return nil
+}
+
+// Apply formats data, a document of contentType, with p. It returns the
+// formatted document along with warnings about parts of the document the
+// profile could not reach.
+func Apply(contentType string, data []byte, p Profile) ([]byte, []string, error) {
+	if !Supported(contentType) {
+		return nil, nil, ErrUnsupportedFormat
+	}
+	if len(data) > MaxSize {
+		return nil, nil, ErrDocumentTooLarge
+	}
+	if err := p.Validate(); err != nil {
+		return nil, nil, err
+	}
+	if p.Empty() {
+		return data, nil, nil
+	}
+	return formatDOCX(data, p)
+}
+
+// halfPoints returns a size in points in the half points of WordprocessingML.
+func halfPoints(points float64) int {
+	return int(math.Round(points * 2))
+}
+
+// twips returns a length in points in the twentieths of a point of
+// WordprocessingML.
+func twips(points float64) int {
+	return int(math.Round(points * 20))
+}
+
+// lineSpacing returns a spacing in lines in the 240ths of a line of
+// WordprocessingML.
+func lineSpacing(lines float64) int {
+	return int(math.Round(lines * 240))
+}
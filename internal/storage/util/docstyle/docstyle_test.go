@@ -0,0 +1,159 @@
+package docstyle
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/a1y/doc-formatter/internal/storage/util/textextract"
+)
+
+const testNamespaces = `xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"`
+
+// testStyles is a styles part like those of Word, with theme fonts.
+const testStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:styles ` + testNamespaces + `><w:docDefaults><w:rPrDefault><w:rPr><w:rFonts w:asciiTheme="minorHAnsi" w:hAnsiTheme="minorHAnsi" w:eastAsiaTheme="minorEastAsia"/><w:lang w:val="en-US"/></w:rPr></w:rPrDefault></w:docDefaults>` +
+	`<w:style w:type="paragraph" w:default="1" w:styleId="Normal"><w:name w:val="Normal"/><w:qFormat/><w:rPr><w:sz w:val="22"/></w:rPr></w:style>` +
+	`<w:style w:type="paragraph" w:styleId="Heading1"><w:name w:val="heading 1"/><w:basedOn w:val="Normal"/><w:pPr><w:outlineLvl w:val="0"/></w:pPr></w:style>` +
+	`<w:style w:type="paragraph" w:styleId="Code"><w:name w:val="Code"/><w:rPr><w:rFonts w:ascii="Courier New" w:hAnsi="Courier New"/></w:rPr></w:style>` +
+	`</w:styles>`
+
+// testDOCX returns a DOCX document of body, with the styles part styles
+// unless it is empty.
+func testDOCX(t *testing.T, body, styles string) []byte {
+	t.Helper()
+
+	files := map[string]string{
+		"[Content_Types].xml": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/word/document.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"/></Types>`,
+		"_rels/.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="word/document.xml"/></Relationships>`,
+		"word/document.xml": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:document ` + testNamespaces + `><w:body>` + body + `</w:body></w:document>`,
+	}
+	if styles != "" {
+		files["word/styles.xml"] = styles
+		files["word/_rels/document.xml.rels"] = `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/></Relationships>`
+	}
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, content := range files {
+		f, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+// readZip returns the files of a ZIP archive by name.
+func readZip(t *testing.T, data []byte) map[string]string {
+	t.Helper()
+
+	r, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+	files := make(map[string]string)
+	for _, f := range r.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		require.NoError(t, rc.Close())
+		files[f.Name] = string(content)
+	}
+	return files
+}
+
+func TestApply_Styles(t *testing.T) {
+	t.Parallel()
+
+	body := `<w:p><w:pPr><w:pStyle w:val="Heading1"/></w:pPr><w:r><w:t>Title</w:t></w:r></w:p>` +
+		`<w:p><w:r><w:rPr><w:b/><w:rFonts w:ascii="Arial"/></w:rPr><w:t>Body</w:t></w:r></w:p>` +
+		`<w:sectPr><w:pgSz w:w="11906" w:h="16838"/><w:pgMar w:top="1440" w:right="1440" w:bottom="1440" w:left="1440" w:header="708" w:footer="708" w:gutter="0"/><w:cols w:space="708"/></w:sectPr>`
+	profile := Profile{FontFamily: "Georgia", FontSize: 11.5, HeadingFontFamily: "Helvetica", LineSpacing: 1.15, Margin: 54}
+	out, warnings, err := Apply(textextract.TypeDOCX, testDOCX(t, body, testStyles), profile)
+	require.NoError(t, err)
+	require.Equal(t, []string{"1 runs of text set their own font or size, which they keep"}, warnings)
+
+	files := readZip(t, out)
+	styles := files["word/styles.xml"]
+	// Theme fonts give way to the profile, other fonts stay, and added
+	// properties follow the order of the schema.
+	require.Contains(t, styles, `<w:rPrDefault><w:rPr><w:rFonts w:eastAsiaTheme="minorEastAsia" w:ascii="Georgia" w:hAnsi="Georgia"/><w:sz w:val="23"/><w:szCs w:val="23"/><w:lang w:val="en-US"/></w:rPr></w:rPrDefault>`)
+	require.Contains(t, styles, `<w:pPrDefault><w:pPr><w:spacing w:line="276" w:lineRule="auto"/></w:pPr></w:pPrDefault>`)
+	require.Contains(t, styles, `<w:name w:val="Normal"/><w:qFormat/><w:rPr><w:sz w:val="23"/><w:szCs w:val="23"/></w:rPr>`)
+	require.Contains(t, styles, `<w:pPr><w:outlineLvl w:val="0"/></w:pPr><w:rPr><w:rFonts w:ascii="Helvetica" w:hAnsi="Helvetica"/></w:rPr>`)
+	require.Contains(t, styles, `<w:rFonts w:ascii="Courier New" w:hAnsi="Courier New"/>`)
+
+	document := files["word/document.xml"]
+	require.Contains(t, document, `<w:pgMar w:top="1080" w:right="1080" w:bottom="1080" w:left="1080" w:header="708" w:footer="708" w:gutter="0"/>`)
+	require.Contains(t, document, `<w:rFonts w:ascii="Arial"/>`)
+
+	// Cleared runs lose their fonts but keep the rest of their formatting.
+	profile.ClearDirectFormatting = true
+	out, warnings, err = Apply(textextract.TypeDOCX, testDOCX(t, body, testStyles), profile)
+	require.NoError(t, err)
+	require.Empty(t, warnings)
+	require.Contains(t, readZip(t, out)["word/document.xml"], `<w:r><w:rPr><w:b/></w:rPr><w:t>Body</w:t></w:r>`)
+}
+
+func TestApply_MissingParts(t *testing.T) {
+	t.Parallel()
+
+	// A document without styles nor sections gets both.
+	out, warnings, err := Apply(textextract.TypeDOCX, testDOCX(t, `<w:p><w:r><w:t>Body</w:t></w:r></w:p>`, ""),
+		Profile{FontSize: 12, HeadingFontFamily: "Helvetica", Margin: 72})
+	require.NoError(t, err)
+	require.Equal(t, []string{"the document has no heading styles, so the heading font is not used"}, warnings)
+
+	files := readZip(t, out)
+	require.Contains(t, files["word/styles.xml"], `<w:docDefaults><w:rPrDefault><w:rPr><w:sz w:val="24"/><w:szCs w:val="24"/></w:rPr></w:rPrDefault></w:docDefaults>`)
+	require.Contains(t, files["word/_rels/document.xml.rels"], `Target="styles.xml"`)
+	require.Contains(t, files["[Content_Types].xml"], `<Override PartName="/word/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.styles+xml"/>`)
+	require.True(t, strings.HasSuffix(files["word/document.xml"],
+		`<w:sectPr><w:pgMar w:header="708" w:footer="708" w:gutter="0" w:top="1440" w:right="1440" w:bottom="1440" w:left="1440"/></w:sectPr></w:body></w:document>`))
+}
+
+func TestApply_Errors(t *testing.T) {
+	t.Parallel()
+
+	_, _, err := Apply("application/pdf", []byte("%PDF-1.4"), Profile{FontSize: 12})
+	require.ErrorIs(t, err, ErrUnsupportedFormat)
+	_, _, err = Apply(textextract.TypeDOCX, []byte("not a zip"), Profile{FontSize: 12})
+	require.ErrorIs(t, err, ErrMalformedDocument)
+	_, _, err = Apply(textextract.TypeDOCX, testDOCX(t, "", ""), Profile{FontSize: 2000})
+	require.ErrorIs(t, err, ErrInvalidProfile)
+
+	data := testDOCX(t, "", "")
+	out, warnings, err := Apply(textextract.TypeDOCX, data, Profile{})
+	require.NoError(t, err)
+	require.Empty(t, warnings)
+	require.Equal(t, data, out)
+}
+
+func TestProfile_Validate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, Profile{FontFamily: "Times New Roman", FontSize: 12, LineSpacing: 1.5, Margin: 72}.Validate())
+	require.True(t, Profile{}.Empty())
+	require.False(t, Profile{ClearDirectFormatting: true}.Empty())
+
+	for _, p := range []Profile{
+		{FontFamily: " Arial"},
+		{HeadingFontFamily: "Arial\n"},
+		{FontFamily: strings.Repeat("a", MaxFontFamilyLength+1)},
+		{FontSize: 0.5},
+		{FontSize: math.NaN()},
+		{LineSpacing: 11},
+		{Margin: -1},
+		{Margin: MaxMargin + 1},
+	} {
+		require.ErrorIs(t, p.Validate(), ErrInvalidProfile, "%+v", p)
+	}
+}
@@ -0,0 +1,368 @@
+package docstyle
+
+import (
+	"encoding/xml"
+	"fmt"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/a1y/doc-formatter/internal/storage/util/ooxml"
+)
+
+const typeStyles = "application/vnd.openxmlformats-officedocument.wordprocessingml.styles+xml"
+
+// defaultHeaderDistance is the distance in twips of headers and footers from
+// the edge of the page Word gives new sections.
+const defaultHeaderDistance = "708"
+
+// headingStyles names the styles of the title and headings as Word names
+// them, whatever the language of the document.
+var headingStyles = []string{"title", "heading 1", "heading 2", "heading 3", "heading 4", "heading 5",
+	"heading 6", "heading 7", "heading 8", "heading 9"}
+
+// docxFormatter formats a WordprocessingML document. The defaults of the
+// document and its default paragraph style carry the body text style, so
+// that every paragraph not styled otherwise takes it.
+type docxFormatter struct {
+	pkg      *ooxml.Package
+	mainName string
+	main     *ooxml.Document
+	profile  Profile
+	warnings []string
+}
+
+// formatDOCX applies p to the styles and sections of the DOCX document data.
+func formatDOCX(data []byte, p Profile) ([]byte, []string, error) {
+	pkg, err := ooxml.Open(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	f := &docxFormatter{pkg: pkg, profile: p}
+	packageRels, err := pkg.XML(ooxml.PackageRelsName)
+	if err != nil {
+		return nil, nil, err
+	}
+	if f.mainName = ooxml.Target(packageRels, "", ooxml.RelDocument); f.mainName == "" {
+		f.mainName = "word/document.xml"
+	}
+	if f.main, err = pkg.XML(f.mainName); err != nil {
+		return nil, nil, err
+	}
+	if f.main == nil || f.main.Child(f.main.Root, ooxml.NSMain, "body") == nil {
+		return nil, nil, fmt.Errorf("%w: missing document body", ErrMalformedDocument)
+	}
+
+	if p.FontFamily != "" || p.FontSize != 0 || p.HeadingFontFamily != "" || p.LineSpacing != 0 {
+		if err := f.formatStyles(); err != nil {
+			return nil, nil, err
+		}
+	}
+	if p.Margin != 0 {
+		f.formatSections()
+	}
+	f.formatRuns()
+
+	pkg.Set(f.mainName, f.main.Bytes())
+	out, err := pkg.Bytes()
+	if err != nil {
+		return nil, nil, err
+	}
+	return out, f.warnings, nil
+}
+
+// formatStyles sets the fonts, size and spacing of the document defaults,
+// the default paragraph style and the heading styles.
+func (f *docxFormatter) formatStyles() error {
+	name, styles, err := f.styles()
+	if err != nil {
+		return err
+	}
+	e := &editor{doc: styles, prefix: styles.Declare(ooxml.NSMain, "w")}
+
+	var rPr, pPr *ooxml.Node
+	if f.profile.FontFamily != "" || f.profile.FontSize != 0 {
+		docDefaults := e.child(styles.Root, "docDefaults", stylesOrder)
+		rPr = e.child(e.child(docDefaults, "rPrDefault", docDefaultsOrder), "rPr", wrapperOrder)
+	}
+	if f.profile.LineSpacing != 0 {
+		docDefaults := e.child(styles.Root, "docDefaults", stylesOrder)
+		pPr = e.child(e.child(docDefaults, "pPrDefault", docDefaultsOrder), "pPr", wrapperOrder)
+	}
+	f.formatBody(e, rPr, pPr, true)
+
+	headingFont := f.profile.HeadingFontFamily
+	if headingFont == "" {
+		headingFont = f.profile.FontFamily
+	}
+	headings := 0
+	for _, c := range styles.Root.Children {
+		style, ok := c.(*ooxml.Node)
+		if !ok || !styles.Is(style, ooxml.NSMain, "style") || styles.Value(style, ooxml.NSMain, "type") != "paragraph" {
+			continue
+		}
+		switch {
+		case on(styles, style, "default"):
+			// The default paragraph style overrides the document defaults,
+			// so what it sets is set again.
+			f.formatBody(e, styles.Child(style, ooxml.NSMain, "rPr"), styles.Child(style, ooxml.NSMain, "pPr"), false)
+		case isHeading(styles, style):
+			headings++
+			if headingFont != "" {
+				e.setFont(e.child(style, "rPr", styleOrder), headingFont)
+			}
+		}
+	}
+	if headings == 0 && f.profile.HeadingFontFamily != "" {
+		f.warn("the document has no heading styles, so the heading font is not used")
+	}
+	f.pkg.Set(name, styles.Bytes())
+	return nil
+}
+
+// formatBody sets the body text style on the run properties rPr and the
+// paragraph properties pPr. Properties are added when add is set, and only
+// replaced otherwise; nil properties are skipped.
+func (f *docxFormatter) formatBody(e *editor, rPr, pPr *ooxml.Node, add bool) {
+	if rPr != nil {
+		if f.profile.FontFamily != "" && (add || e.has(rPr, "rFonts")) {
+			e.setFont(rPr, f.profile.FontFamily)
+		}
+		if f.profile.FontSize != 0 && (add || e.has(rPr, "sz") || e.has(rPr, "szCs")) {
+			size := strconv.Itoa(halfPoints(f.profile.FontSize))
+			e.set(e.child(rPr, "sz", rPrOrder), "val", size)
+			e.set(e.child(rPr, "szCs", rPrOrder), "val", size)
+		}
+	}
+	if pPr != nil && f.profile.LineSpacing != 0 {
+		spacing := e.doc.Child(pPr, ooxml.NSMain, "spacing")
+		if add || (spacing != nil && e.doc.Attr(spacing, ooxml.NSMain, "line") >= 0) {
+			spacing = e.child(pPr, "spacing", pPrOrder)
+			e.set(spacing, "line", strconv.Itoa(lineSpacing(f.profile.LineSpacing)))
+			e.set(spacing, "lineRule", "auto")
+		}
+	}
+}
+
+// styles returns the styles part of the document, adding an empty one when
+// it has none.
+func (f *docxFormatter) styles() (string, *ooxml.Document, error) {
+	relsName := ooxml.RelsName(f.mainName)
+	rels, err := f.pkg.XML(relsName)
+	if err != nil {
+		return "", nil, err
+	}
+	if name := ooxml.Target(rels, f.mainName, ooxml.RelStyles); name != "" {
+		styles, err := f.pkg.XML(name)
+		if err != nil {
+			return "", nil, err
+		}
+		if styles != nil {
+			return name, styles, nil
+		}
+	}
+
+	types, err := f.pkg.XML(ooxml.ContentTypesName)
+	if err != nil {
+		return "", nil, err
+	}
+	if types == nil {
+		return "", nil, fmt.Errorf("%w: missing %s", ErrMalformedDocument, ooxml.ContentTypesName)
+	}
+	if rels == nil {
+		rels = ooxml.NewRelationships()
+	}
+	name := f.pkg.Unique(path.Join(path.Dir(f.mainName), "styles.xml"))
+	ooxml.AddRelationship(rels, ooxml.RelStyles, ooxml.Relative(f.mainName, name), "")
+	ooxml.SetContentType(types, name, typeStyles)
+	f.pkg.Set(relsName, rels.Bytes())
+	f.pkg.Set(ooxml.ContentTypesName, types.Bytes())
+	styles := &ooxml.Document{
+		Prolog: []xml.Token{ooxml.Declaration()},
+		Root:   &ooxml.Node{Start: xml.StartElement{Name: xml.Name{Space: "w", Local: "styles"}, Attr: []xml.Attr{{Name: xml.Name{Space: ooxml.NSXMLNS, Local: "w"}, Value: ooxml.NSMain}}}},
+	}
+	return name, styles, nil
+}
+
+// formatSections sets the page margins of every section.
+func (f *docxFormatter) formatSections() {
+	e := &editor{doc: f.main, prefix: f.main.Declare(ooxml.NSMain, "w")}
+	margin := strconv.Itoa(twips(f.profile.Margin))
+	for _, sectPr := range f.sections(e) {
+		pgMar := e.doc.Child(sectPr, ooxml.NSMain, "pgMar")
+		if pgMar == nil {
+			// A new margin needs every attribute, which Word requires.
+			pgMar = e.child(sectPr, "pgMar", sectPrOrder)
+			e.set(pgMar, "header", defaultHeaderDistance)
+			e.set(pgMar, "footer", defaultHeaderDistance)
+			e.set(pgMar, "gutter", "0")
+		}
+		for _, side := range []string{"top", "right", "bottom", "left"} {
+			e.set(pgMar, side, margin)
+		}
+	}
+}
+
+// sections returns the section properties of the document in order, adding
+// those of the last section when the body has none.
+func (f *docxFormatter) sections(e *editor) []*ooxml.Node {
+	body := f.main.Child(f.main.Root, ooxml.NSMain, "body")
+	var sections []*ooxml.Node
+	ooxml.Walk(body, func(n *ooxml.Node) bool {
+		// The properties of tracked changes are those of past revisions.
+		if f.main.Is(n, ooxml.NSMain, "sectPrChange") || f.main.Is(n, ooxml.NSMain, "pPrChange") {
+			return false
+		}
+		if f.main.Is(n, ooxml.NSMain, "sectPr") {
+			sections = append(sections, n)
+			return false
+		}
+		return true
+	})
+
+	var last *ooxml.Node
+	for i := len(body.Children) - 1; i >= 0 && last == nil; i-- {
+		last, _ = body.Children[i].(*ooxml.Node)
+	}
+	if last == nil || !f.main.Is(last, ooxml.NSMain, "sectPr") {
+		sectPr := e.element("sectPr")
+		body.Children = append(body.Children, sectPr)
+		sections = append(sections, sectPr)
+	}
+	return sections
+}
+
+// formatRuns clears the fonts and sizes set directly on the text of the
+// body when the profile says so, and otherwise warns about those the
+// profile does not change.
+func (f *docxFormatter) formatRuns() {
+	var locals []string
+	if f.profile.ClearDirectFormatting || f.profile.FontFamily != "" || f.profile.HeadingFontFamily != "" {
+		locals = append(locals, "rFonts")
+	}
+	if f.profile.ClearDirectFormatting || f.profile.FontSize != 0 {
+		locals = append(locals, "sz", "szCs")
+	}
+	if len(locals) == 0 {
+		return
+	}
+
+	runs := 0
+	var visit func(parent *ooxml.Node)
+	visit = func(parent *ooxml.Node) {
+		for _, c := range parent.Children {
+			n, ok := c.(*ooxml.Node)
+			if !ok || f.main.Is(n, ooxml.NSMain, "rPrChange") || f.main.Is(n, ooxml.NSMain, "pPrChange") {
+				continue
+			}
+			if f.main.Is(n, ooxml.NSMain, "rPr") && (f.main.Is(parent, ooxml.NSMain, "r") || f.main.Is(parent, ooxml.NSMain, "pPr")) {
+				if f.formatRun(n, locals) && f.main.Is(parent, ooxml.NSMain, "r") {
+					runs++
+				}
+				continue
+			}
+			visit(n)
+		}
+	}
+	visit(f.main.Child(f.main.Root, ooxml.NSMain, "body"))
+
+	if runs > 0 && !f.profile.ClearDirectFormatting {
+		f.warn(fmt.Sprintf("%d runs of text set their own font or size, which they keep", runs))
+	}
+}
+
+// formatRun reports whether the run properties rPr set any of the
+// properties locals, which it removes when the profile clears direct
+// formatting.
+func (f *docxFormatter) formatRun(rPr *ooxml.Node, locals []string) bool {
+	found := false
+	children := rPr.Children[:0]
+	for _, c := range rPr.Children {
+		if n, ok := c.(*ooxml.Node); ok && slices.ContainsFunc(locals, func(local string) bool { return f.main.Is(n, ooxml.NSMain, local) }) {
+			found = true
+			if f.profile.ClearDirectFormatting {
+				continue
+			}
+		}
+		children = append(children, c)
+	}
+	rPr.Children = children
+	return found
+}
+
+func (f *docxFormatter) warn(warning string) {
+	f.warnings = append(f.warnings, warning)
+}
+
+// isHeading reports whether style is that of the title or of a heading.
+func isHeading(doc *ooxml.Document, style *ooxml.Node) bool {
+	name := doc.Child(style, ooxml.NSMain, "name")
+	return name != nil && slices.Contains(headingStyles, strings.ToLower(doc.Value(name, ooxml.NSMain, "val")))
+}
+
+// on reports whether the on/off attribute local of n is on.
+func on(doc *ooxml.Document, n *ooxml.Node, local string) bool {
+	switch doc.Value(n, ooxml.NSMain, local) {
+	case "1", "true", "on":
+		return true
+	}
+	return false
+}
+
+// editor adds and sets the WordprocessingML elements and attributes of a
+// part, named with the prefix the part binds to their namespace.
+type editor struct {
+	doc    *ooxml.Document
+	prefix string
+}
+
+func (e *editor) element(local string) *ooxml.Node {
+	return &ooxml.Node{Start: xml.StartElement{Name: xml.Name{Space: e.prefix, Local: local}}}
+}
+
+func (e *editor) has(parent *ooxml.Node, local string) bool {
+	return e.doc.Child(parent, ooxml.NSMain, local) != nil
+}
+
+// child returns the child local of parent, adding it where order puts it
+// when parent has none.
+func (e *editor) child(parent *ooxml.Node, local string, order map[string]int) *ooxml.Node {
+	if c := e.doc.Child(parent, ooxml.NSMain, local); c != nil {
+		return c
+	}
+	n := e.element(local)
+	at := len(parent.Children)
+	for i, c := range parent.Children {
+		c, ok := c.(*ooxml.Node)
+		if !ok || e.doc.Namespace(c.Start.Name.Space) != ooxml.NSMain {
+			continue
+		}
+		if position, ok := order[c.Start.Name.Local]; ok && position > order[local] {
+			at = i
+			break
+		}
+	}
+	parent.Children = slices.Insert(parent.Children, at, any(n))
+	return n
+}
+
+// set sets the attribute local of n.
+func (e *editor) set(n *ooxml.Node, local, value string) {
+	if i := e.doc.Attr(n, ooxml.NSMain, local); i >= 0 {
+		n.Start.Attr[i].Value = value
+		return
+	}
+	n.Start.Attr = append(n.Start.Attr, xml.Attr{Name: xml.Name{Space: e.prefix, Local: local}, Value: value})
+}
+
+// setFont makes the run properties rPr use family for Latin text, in place
+// of the fonts of the theme.
+func (e *editor) setFont(rPr *ooxml.Node, family string) {
+	rFonts := e.child(rPr, "rFonts", rPrOrder)
+	rFonts.Start.Attr = slices.DeleteFunc(rFonts.Start.Attr, func(a xml.Attr) bool {
+		return (a.Name.Local == "asciiTheme" || a.Name.Local == "hAnsiTheme") && e.doc.Namespace(a.Name.Space) == ooxml.NSMain
+	})
+	e.set(rFonts, "ascii", family)
+	e.set(rFonts, "hAnsi", family)
+}
@@ -0,0 +1,34 @@
+package docstyle
+
+// The schema of WordprocessingML fixes the order of the children of the
+// elements a profile edits, which Word enforces. Children are added where
+// the order puts them.
+var (
+	stylesOrder      = order("docDefaults", "latentStyles", "style")
+	docDefaultsOrder = order("rPrDefault", "pPrDefault")
+	styleOrder       = order("name", "aliases", "basedOn", "next", "link", "autoRedefine", "hidden", "uiPriority",
+		"semiHidden", "unhideWhenUsed", "qFormat", "locked", "personal", "personalCompose", "personalReply", "rsid",
+		"pPr", "rPr", "tblPr", "trPr", "tcPr", "tblStylePr")
+	rPrOrder = order("rStyle", "rFonts", "b", "bCs", "i", "iCs", "caps", "smallCaps", "strike", "dstrike", "outline",
+		"shadow", "emboss", "imprint", "noProof", "snapToGrid", "vanish", "webHidden", "color", "spacing", "w", "kern",
+		"position", "sz", "szCs", "highlight", "u", "effect", "bdr", "shd", "fitText", "vertAlign", "rtl", "cs", "em",
+		"lang", "eastAsianLayout", "specVanish", "oMath", "rPrChange")
+	pPrOrder = order("pStyle", "keepNext", "keepLines", "pageBreakBefore", "framePr", "widowControl", "numPr",
+		"suppressLineNumbers", "pBdr", "shd", "tabs", "suppressAutoHyphens", "kinsoku", "wordWrap", "overflowPunct",
+		"topLinePunct", "autoSpaceDE", "autoSpaceDN", "bidi", "adjustRightInd", "snapToGrid", "spacing", "ind",
+		"contextualSpacing", "mirrorIndents", "suppressOverlap", "jc", "textDirection", "textAlignment",
+		"textboxTightWrap", "outlineLvl", "divId", "cnfStyle", "rPr", "sectPr", "pPrChange")
+	sectPrOrder = order("headerReference", "footerReference", "footnotePr", "endnotePr", "type", "pgSz", "pgMar",
+		"paperSrc", "pgBorders", "lnNumType", "pgNumType", "cols", "formProt", "vAlign", "noEndnote", "titlePg",
+		"textDirection", "bidi", "rtlGutter", "docGrid", "printerSettings", "sectPrChange")
+	// wrapperOrder is the order of the elements only holding rPr or pPr.
+	wrapperOrder = order("rPr", "pPr")
+)
+
+func order(names ...string) map[string]int {
+	m := make(map[string]int, len(names))
+	for i, name := range names {
+		m[name] = i
+	}
+	return m
+}
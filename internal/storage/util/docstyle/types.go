@@ -0,0 +1,51 @@
+package docstyle
+
+import (
+	"errors"
+
+	"github.com/a1y/doc-formatter/internal/storage/util/textextract"
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("formatting is not supported for this format")
+	// ErrDocumentTooLarge and ErrMalformedDocument are shared with text
+	// extraction, which bounds documents the same way.
+	ErrDocumentTooLarge  = textextract.ErrDocumentTooLarge
+	ErrMalformedDocument = textextract.ErrMalformedDocument
+	ErrInvalidProfile    = errors.New("invalid style profile")
+)
+
+const (
+	// MaxSize is the largest document formatted.
+	MaxSize = textextract.MaxDocumentSize
+	// MaxFontFamilyLength is the length in bytes of the longest font name.
+	MaxFontFamilyLength = 100
+	// MinFontSize and MaxFontSize bound font sizes, in points, as Word does.
+	MinFontSize = 1
+	MaxFontSize = 1638
+	// MinLineSpacing and MaxLineSpacing bound line spacing, in lines.
+	MinLineSpacing = 0.5
+	MaxLineSpacing = 10
+	// MaxMargin is the widest page margin, in points.
+	MaxMargin = 720
+)
+
+// Profile is a house style applied to documents. Zero fields leave the
+// document as it is.
+type Profile struct {
+	// FontFamily is the font of body text, and of headings unless
+	// HeadingFontFamily is set.
+	FontFamily string `yaml:"fontFamily,omitempty" json:"fontFamily,omitempty"`
+	// FontSize is the size of body text in points, rounded to half points.
+	FontSize float64 `yaml:"fontSize,omitempty" json:"fontSize,omitempty"`
+	// HeadingFontFamily is the font of the title and headings.
+	HeadingFontFamily string `yaml:"headingFontFamily,omitempty" json:"headingFontFamily,omitempty"`
+	// LineSpacing is the spacing of the lines of body text, 1 being single
+	// spacing.
+	LineSpacing float64 `yaml:"lineSpacing,omitempty" json:"lineSpacing,omitempty"`
+	// Margin is the width of the four page margins in points.
+	Margin float64 `yaml:"margin,omitempty" json:"margin,omitempty"`
+	// ClearDirectFormatting removes the fonts and sizes set on runs of text
+	// themselves, which would otherwise keep them whatever the profile.
+	ClearDirectFormatting bool `yaml:"clearDirectFormatting,omitempty" json:"clearDirectFormatting,omitempty"`
+}
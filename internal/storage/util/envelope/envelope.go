@@ -0,0 +1,102 @@
+package envelope
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"fmt"
+)
+
+// NonceSize is the size in bytes of the per-document base nonce.
+const NonceSize = 12
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("%w: got %d bytes, want %d", ErrInvalidKeySize, len(key), KeySize)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// GenerateDataKey returns a fresh random AES-256 data key.
+func GenerateDataKey() ([]byte, error) {
+	key := make([]byte, KeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// GenerateNonce returns a fresh random base nonce for content encryption.
+func GenerateNonce() ([]byte, error) {
+	nonce := make([]byte, NonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return nonce, nil
+}
+
+// WrapDataKey seals dataKey with the primary master key.
+func (k *Keyring) WrapDataKey(dataKey []byte) (*SealedKey, error) {
+	return k.wrapWith(k.primary, dataKey)
+}
+
+func (k *Keyring) wrapWith(masterKeyID string, dataKey []byte) (*SealedKey, error) {
+	masterKey, err := k.key(masterKeyID)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newGCM(masterKey)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	// Binding the master key ID as additional data stops a wrapped key from
+	// being relabelled with another key ID.
+	wrapped := aead.Seal(nonce, nonce, dataKey, []byte(masterKeyID))
+	return &SealedKey{MasterKeyID: masterKeyID, WrappedKey: wrapped}, nil
+}
+
+// UnwrapDataKey opens a sealed data key with the master key it names.
+func (k *Keyring) UnwrapDataKey(sealed *SealedKey) ([]byte, error) {
+	masterKey, err := k.key(sealed.MasterKeyID)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newGCM(masterKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed.WrappedKey) < aead.NonceSize()+aead.Overhead() {
+		return nil, ErrInvalidWrappedKey
+	}
+	nonce, ciphertext := sealed.WrappedKey[:aead.NonceSize()], sealed.WrappedKey[aead.NonceSize():]
+	dataKey, err := aead.Open(nil, nonce, ciphertext, []byte(sealed.MasterKeyID))
+	if err != nil {
+		return nil, ErrInvalidWrappedKey
+	}
+	return dataKey, nil
+}
+
+// Rewrap re-seals a data key with the primary master key. It reports false
+// when the key is already wrapped by the primary key and nothing changed.
+func (k *Keyring) Rewrap(sealed *SealedKey) (*SealedKey, bool, error) {
+	if sealed.MasterKeyID == k.primary {
+		return sealed, false, nil
+	}
+	dataKey, err := k.UnwrapDataKey(sealed)
+	if err != nil {
+		return nil, false, err
+	}
+	rewrapped, err := k.WrapDataKey(dataKey)
+	if err != nil {
+		return nil, false, err
+	}
+	return rewrapped, true, nil
+}
@@ -0,0 +1,105 @@
+package envelope
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+)
+
+// KeySize is the size in bytes of master keys and data keys (AES-256).
+const KeySize = 32
+
+// keyringFile is the on-disk layout of a keyring:
+//
+//	primary: 2025-01
+//	keys:
+//	  - id: 2024-06
+//	    key: <base64 encoded 32 byte key>
+//	  - id: 2025-01
+//	    key: <base64 encoded 32 byte key>
+type keyringFile struct {
+	Primary string `yaml:"primary"`
+	Keys    []struct {
+		ID  string `yaml:"id"`
+		Key string `yaml:"key"`
+	} `yaml:"keys"`
+}
+
+// Keyring holds the master keys used to wrap per-document data keys. New data
+// keys are always wrapped with the primary key; older keys are kept so that
+// documents wrapped before a rotation can still be read until they are
+// re-wrapped.
+type Keyring struct {
+	primary string
+	keys    map[string][]byte
+}
+
+// NewKeyring builds a keyring from raw master keys.
+func NewKeyring(primary string, keys map[string][]byte) (*Keyring, error) {
+	if primary == "" {
+		return nil, ErrNoPrimaryKey
+	}
+	k := &Keyring{primary: primary, keys: make(map[string][]byte, len(keys))}
+	for id, key := range keys {
+		if id == "" {
+			return nil, ErrEmptyKeyID
+		}
+		if len(key) != KeySize {
+			return nil, fmt.Errorf("%w: key %q has %d bytes, want %d", ErrInvalidKeySize, id, len(key), KeySize)
+		}
+		k.keys[id] = append([]byte(nil), key...)
+	}
+	if _, ok := k.keys[primary]; !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownKeyID, primary)
+	}
+	return k, nil
+}
+
+// LoadKeyring reads a keyring from a local YAML file.
+func LoadKeyring(path string) (*Keyring, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("stat keyring file %q: %w", path, err)
+	}
+	if info.Mode().Perm()&0o077 != 0 {
+		logrus.Warnf("Keyring file %s is accessible by other users, consider chmod 600", path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read keyring file %q: %w", path, err)
+	}
+	var file keyringFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("parse keyring file %q: %w", path, err)
+	}
+
+	keys := make(map[string][]byte, len(file.Keys))
+	for _, entry := range file.Keys {
+		if _, ok := keys[entry.ID]; ok {
+			return nil, fmt.Errorf("%w: %q", ErrDuplicateKeyID, entry.ID)
+		}
+		key, err := base64.StdEncoding.DecodeString(entry.Key)
+		if err != nil {
+			return nil, fmt.Errorf("decode key %q: %w", entry.ID, err)
+		}
+		keys[entry.ID] = key
+	}
+	return NewKeyring(file.Primary, keys)
+}
+
+// PrimaryKeyID returns the ID of the key used to wrap new data keys.
+func (k *Keyring) PrimaryKeyID() string {
+	return k.primary
+}
+
+func (k *Keyring) key(id string) ([]byte, error) {
+	key, ok := k.keys[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownKeyID, id)
+	}
+	return key, nil
+}
@@ -0,0 +1,161 @@
+package envelope
+
+import (
+	"bytes"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testKey(b byte) []byte {
+	return bytes.Repeat([]byte{b}, KeySize)
+}
+
+func writeKeyringFile(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "keyring.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadKeyring_Success(t *testing.T) {
+	t.Parallel()
+
+	path := writeKeyringFile(t, `primary: k2
+keys:
+  - id: k1
+    key: `+base64.StdEncoding.EncodeToString(testKey(1))+`
+  - id: k2
+    key: `+base64.StdEncoding.EncodeToString(testKey(2))+`
+`)
+
+	keyring, err := LoadKeyring(path)
+	require.NoError(t, err)
+	require.Equal(t, "k2", keyring.PrimaryKeyID())
+
+	key, err := keyring.key("k1")
+	require.NoError(t, err)
+	require.Equal(t, testKey(1), key)
+}
+
+func TestLoadKeyring_Errors(t *testing.T) {
+	t.Parallel()
+
+	validKey := base64.StdEncoding.EncodeToString(testKey(1))
+	tests := []struct {
+		name    string
+		content string
+		wantErr error
+	}{
+		{
+			name:    "missing primary",
+			content: "keys:\n  - id: k1\n    key: " + validKey + "\n",
+			wantErr: ErrNoPrimaryKey,
+		},
+		{
+			name:    "primary not in keys",
+			content: "primary: k9\nkeys:\n  - id: k1\n    key: " + validKey + "\n",
+			wantErr: ErrUnknownKeyID,
+		},
+		{
+			name:    "short key",
+			content: "primary: k1\nkeys:\n  - id: k1\n    key: " + base64.StdEncoding.EncodeToString([]byte("short")) + "\n",
+			wantErr: ErrInvalidKeySize,
+		},
+		{
+			name:    "duplicate id",
+			content: "primary: k1\nkeys:\n  - id: k1\n    key: " + validKey + "\n  - id: k1\n    key: " + validKey + "\n",
+			wantErr: ErrDuplicateKeyID,
+		},
+		{
+			name:    "empty id",
+			content: "primary: k1\nkeys:\n  - id: k1\n    key: " + validKey + "\n  - id: \"\"\n    key: " + validKey + "\n",
+			wantErr: ErrEmptyKeyID,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := LoadKeyring(writeKeyringFile(t, tt.content))
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestLoadKeyring_MissingFileAndInvalidYAML(t *testing.T) {
+	t.Parallel()
+
+	_, err := LoadKeyring(filepath.Join(t.TempDir(), "missing.yaml"))
+	require.Error(t, err)
+
+	_, err = LoadKeyring(writeKeyringFile(t, "primary: [unclosed"))
+	require.Error(t, err)
+
+	_, err = LoadKeyring(writeKeyringFile(t, "primary: k1\nkeys:\n  - id: k1\n    key: '%%%'\n"))
+	require.Error(t, err)
+}
+
+func TestKeyring_WrapUnwrapRewrap(t *testing.T) {
+	t.Parallel()
+
+	oldKeyring, err := NewKeyring("k1", map[string][]byte{"k1": testKey(1)})
+	require.NoError(t, err)
+
+	dataKey, err := GenerateDataKey()
+	require.NoError(t, err)
+
+	sealed, err := oldKeyring.WrapDataKey(dataKey)
+	require.NoError(t, err)
+	require.Equal(t, "k1", sealed.MasterKeyID)
+	require.NotContains(t, string(sealed.WrappedKey), string(dataKey))
+
+	unwrapped, err := oldKeyring.UnwrapDataKey(sealed)
+	require.NoError(t, err)
+	require.Equal(t, dataKey, unwrapped)
+
+	// Rotate: k2 becomes primary while k1 stays available for reading.
+	rotated, err := NewKeyring("k2", map[string][]byte{"k1": testKey(1), "k2": testKey(2)})
+	require.NoError(t, err)
+
+	rewrapped, changed, err := rotated.Rewrap(sealed)
+	require.NoError(t, err)
+	require.True(t, changed)
+	require.Equal(t, "k2", rewrapped.MasterKeyID)
+
+	unwrapped, err = rotated.UnwrapDataKey(rewrapped)
+	require.NoError(t, err)
+	require.Equal(t, dataKey, unwrapped)
+
+	same, changed, err := rotated.Rewrap(rewrapped)
+	require.NoError(t, err)
+	require.False(t, changed)
+	require.Equal(t, rewrapped, same)
+}
+
+func TestKeyring_UnwrapDataKey_Errors(t *testing.T) {
+	t.Parallel()
+
+	keyring, err := NewKeyring("k1", map[string][]byte{"k1": testKey(1), "k2": testKey(2)})
+	require.NoError(t, err)
+
+	dataKey, err := GenerateDataKey()
+	require.NoError(t, err)
+	sealed, err := keyring.WrapDataKey(dataKey)
+	require.NoError(t, err)
+
+	_, err = keyring.UnwrapDataKey(&SealedKey{MasterKeyID: "missing", WrappedKey: sealed.WrappedKey})
+	require.ErrorIs(t, err, ErrUnknownKeyID)
+
+	// Relabelling the wrapped key with another master key ID must fail.
+	_, err = keyring.UnwrapDataKey(&SealedKey{MasterKeyID: "k2", WrappedKey: sealed.WrappedKey})
+	require.ErrorIs(t, err, ErrInvalidWrappedKey)
+
+	_, err = keyring.UnwrapDataKey(&SealedKey{MasterKeyID: "k1", WrappedKey: []byte("short")})
+	require.ErrorIs(t, err, ErrInvalidWrappedKey)
+}
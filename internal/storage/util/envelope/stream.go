@@ -0,0 +1,218 @@
+package envelope
+
+import (
+	"crypto/cipher"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// SegmentSize is the amount of plaintext sealed in a single GCM segment.
+//
+// Content is encrypted as a sequence of independently authenticated segments
+// so that neither side has to buffer a whole document. Each segment uses the
+// document nonce XORed with the segment counter, and the final segment is
+// marked in the additional data so that truncation is detected.
+const SegmentSize = 64 * 1024
+
+const (
+	segmentNotFinal byte = 0
+	segmentFinal    byte = 1
+)
+
+func segmentNonce(base []byte, counter uint64) []byte {
+	nonce := append([]byte(nil), base...)
+	tail := nonce[len(nonce)-8:]
+	binary.BigEndian.PutUint64(tail, binary.BigEndian.Uint64(tail)^counter)
+	return nonce
+}
+
+func newStreamAEAD(dataKey, nonce []byte) (cipher.AEAD, error) {
+	if len(nonce) != NonceSize {
+		return nil, fmt.Errorf("%w: got %d bytes, want %d", ErrInvalidNonceSize, len(nonce), NonceSize)
+	}
+	return newGCM(dataKey)
+}
+
+// EncryptedSize returns the size of the ciphertext produced for plaintextSize
+// bytes of content.
+func EncryptedSize(plaintextSize int64) int64 {
+	segments := max(1, (plaintextSize+SegmentSize-1)/SegmentSize)
+	return plaintextSize + segments*16
+}
+
+type encryptReader struct {
+	src       io.Reader
+	aead      cipher.AEAD
+	nonce     []byte
+	counter   uint64
+	plain     []byte
+	lookahead []byte
+	out       []byte
+	done      bool
+	err       error
+}
+
+// NewEncryptReader returns a reader yielding the encryption of src under
+// dataKey and nonce.
+func NewEncryptReader(src io.Reader, dataKey, nonce []byte) (io.Reader, error) {
+	aead, err := newStreamAEAD(dataKey, nonce)
+	if err != nil {
+		return nil, err
+	}
+	return &encryptReader{
+		src:   src,
+		aead:  aead,
+		nonce: append([]byte(nil), nonce...),
+		plain: make([]byte, SegmentSize),
+	}, nil
+}
+
+func (r *encryptReader) Read(p []byte) (int, error) {
+	for len(r.out) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		if r.done {
+			return 0, io.EOF
+		}
+		r.sealNext()
+	}
+	n := copy(p, r.out)
+	r.out = r.out[n:]
+	return n, nil
+}
+
+// sealNext reads the next plaintext segment and seals it into r.out.
+func (r *encryptReader) sealNext() {
+	n := copy(r.plain, r.lookahead)
+	r.lookahead = r.lookahead[:0]
+	m, err := io.ReadFull(r.src, r.plain[n:])
+	n += m
+
+	final := false
+	switch {
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		final = true
+	case err != nil:
+		r.err = err
+		return
+	default:
+		// The segment is full; peek one byte to learn whether it is the last.
+		var peek [1]byte
+		k, perr := io.ReadFull(r.src, peek[:])
+		switch {
+		case k == 1:
+			r.lookahead = append(r.lookahead, peek[0])
+		case errors.Is(perr, io.EOF):
+			final = true
+		default:
+			r.err = perr
+			return
+		}
+	}
+
+	flag := segmentNotFinal
+	if final {
+		flag = segmentFinal
+		r.done = true
+	}
+	r.out = r.aead.Seal(r.out[:0], segmentNonce(r.nonce, r.counter), r.plain[:n], []byte{flag})
+	r.counter++
+}
+
+type decryptReader struct {
+	src     io.Reader
+	aead    cipher.AEAD
+	nonce   []byte
+	counter uint64
+	sealed  []byte
+	peeked  []byte
+	out     []byte
+	done    bool
+	err     error
+}
+
+// NewDecryptReader returns a reader yielding the plaintext of src, which must
+// have been produced by NewEncryptReader with the same dataKey and nonce.
+func NewDecryptReader(src io.Reader, dataKey, nonce []byte) (io.Reader, error) {
+	aead, err := newStreamAEAD(dataKey, nonce)
+	if err != nil {
+		return nil, err
+	}
+	return &decryptReader{
+		src:    src,
+		aead:   aead,
+		nonce:  append([]byte(nil), nonce...),
+		sealed: make([]byte, SegmentSize+aead.Overhead()),
+	}, nil
+}
+
+func (r *decryptReader) Read(p []byte) (int, error) {
+	for len(r.out) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		if r.done {
+			return 0, io.EOF
+		}
+		r.openNext()
+	}
+	n := copy(p, r.out)
+	r.out = r.out[n:]
+	return n, nil
+}
+
+// openNext reads and authenticates the next sealed segment into r.out.
+func (r *decryptReader) openNext() {
+	n := copy(r.sealed, r.peeked)
+	r.peeked = r.peeked[:0]
+	m, err := io.ReadFull(r.src, r.sealed[n:])
+	n += m
+
+	final := false
+	switch {
+	case errors.Is(err, io.EOF) && n == 0:
+		r.err = ErrTruncatedContent
+		return
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		final = true
+	case err != nil:
+		r.err = err
+		return
+	default:
+		var peek [1]byte
+		k, perr := io.ReadFull(r.src, peek[:])
+		switch {
+		case k == 1:
+			r.peeked = append(r.peeked, peek[0])
+		case errors.Is(perr, io.EOF):
+			final = true
+		default:
+			r.err = perr
+			return
+		}
+	}
+
+	flag := segmentNotFinal
+	if final {
+		flag = segmentFinal
+	}
+	plain, err := r.aead.Open(r.out[:0], segmentNonce(r.nonce, r.counter), r.sealed[:n], []byte{flag})
+	if err != nil {
+		if final {
+			// A segment that fails as final may be a non-final segment whose
+			// successors were cut off.
+			if _, nerr := r.aead.Open(nil, segmentNonce(r.nonce, r.counter), r.sealed[:n], []byte{segmentNotFinal}); nerr == nil {
+				r.err = ErrTruncatedContent
+				return
+			}
+		}
+		r.err = ErrCorruptedSegment
+		return
+	}
+	r.out = plain
+	r.counter++
+	r.done = final
+}
@@ -0,0 +1,114 @@
+package envelope
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestKeyAndNonce(t *testing.T) ([]byte, []byte) {
+	t.Helper()
+
+	key, err := GenerateDataKey()
+	require.NoError(t, err)
+	nonce, err := GenerateNonce()
+	require.NoError(t, err)
+	return key, nonce
+}
+
+func encrypt(t *testing.T, plaintext, key, nonce []byte) []byte {
+	t.Helper()
+
+	reader, err := NewEncryptReader(bytes.NewReader(plaintext), key, nonce)
+	require.NoError(t, err)
+	ciphertext, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	return ciphertext
+}
+
+func TestEncryptDecrypt_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	sizes := []int{0, 1, 100, SegmentSize - 1, SegmentSize, SegmentSize + 1, 3*SegmentSize + 17}
+	for _, size := range sizes {
+		key, nonce := newTestKeyAndNonce(t)
+		plaintext := make([]byte, size)
+		_, err := rand.Read(plaintext)
+		require.NoError(t, err)
+
+		ciphertext := encrypt(t, plaintext, key, nonce)
+		require.EqualValues(t, EncryptedSize(int64(size)), len(ciphertext), "size %d", size)
+		if size > 0 {
+			require.NotContains(t, string(ciphertext), string(plaintext))
+		}
+
+		// One byte at a time exercises the segment boundary handling.
+		reader, err := NewDecryptReader(iotest.OneByteReader(bytes.NewReader(ciphertext)), key, nonce)
+		require.NoError(t, err)
+		decrypted, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		require.Equal(t, plaintext, decrypted, "size %d", size)
+	}
+}
+
+func TestDecrypt_WrongKey(t *testing.T) {
+	t.Parallel()
+
+	key, nonce := newTestKeyAndNonce(t)
+	ciphertext := encrypt(t, []byte("secret contract"), key, nonce)
+
+	otherKey, _ := newTestKeyAndNonce(t)
+	reader, err := NewDecryptReader(bytes.NewReader(ciphertext), otherKey, nonce)
+	require.NoError(t, err)
+	_, err = io.ReadAll(reader)
+	require.ErrorIs(t, err, ErrCorruptedSegment)
+}
+
+func TestDecrypt_TamperedContent(t *testing.T) {
+	t.Parallel()
+
+	key, nonce := newTestKeyAndNonce(t)
+	ciphertext := encrypt(t, []byte("secret contract"), key, nonce)
+	ciphertext[3] ^= 0xff
+
+	reader, err := NewDecryptReader(bytes.NewReader(ciphertext), key, nonce)
+	require.NoError(t, err)
+	_, err = io.ReadAll(reader)
+	require.ErrorIs(t, err, ErrCorruptedSegment)
+}
+
+func TestDecrypt_TruncatedContent(t *testing.T) {
+	t.Parallel()
+
+	key, nonce := newTestKeyAndNonce(t)
+	plaintext := bytes.Repeat([]byte("a"), 2*SegmentSize+10)
+	ciphertext := encrypt(t, plaintext, key, nonce)
+
+	// Dropping whole trailing segments must not go unnoticed.
+	truncated := ciphertext[:SegmentSize+16]
+	reader, err := NewDecryptReader(bytes.NewReader(truncated), key, nonce)
+	require.NoError(t, err)
+	_, err = io.ReadAll(reader)
+	require.ErrorIs(t, err, ErrTruncatedContent)
+
+	reader, err = NewDecryptReader(bytes.NewReader(nil), key, nonce)
+	require.NoError(t, err)
+	_, err = io.ReadAll(reader)
+	require.ErrorIs(t, err, ErrTruncatedContent)
+}
+
+func TestNewEncryptReader_InvalidParameters(t *testing.T) {
+	t.Parallel()
+
+	key, nonce := newTestKeyAndNonce(t)
+
+	_, err := NewEncryptReader(bytes.NewReader(nil), key[:16], nonce)
+	require.ErrorIs(t, err, ErrInvalidKeySize)
+
+	_, err = NewDecryptReader(bytes.NewReader(nil), key, nonce[:8])
+	require.ErrorIs(t, err, ErrInvalidNonceSize)
+}
@@ -0,0 +1,24 @@
+package envelope
+
+import "errors"
+
+var (
+	ErrNoPrimaryKey      = errors.New("keyring has no primary key")
+	ErrEmptyKeyID        = errors.New("keyring contains a key without id")
+	ErrDuplicateKeyID    = errors.New("keyring contains a duplicate key id")
+	ErrUnknownKeyID      = errors.New("unknown master key id")
+	ErrInvalidKeySize    = errors.New("invalid key size")
+	ErrInvalidNonceSize  = errors.New("invalid nonce size")
+	ErrInvalidWrappedKey = errors.New("invalid wrapped data key")
+	ErrCorruptedSegment  = errors.New("encrypted content is corrupted or has been tampered with")
+	ErrTruncatedContent  = errors.New("encrypted content is truncated")
+)
+
+// SealedKey is a data key wrapped by a master key, as persisted next to the
+// document it protects.
+type SealedKey struct {
+	// MasterKeyID identifies the keyring entry that wrapped the data key.
+	MasterKeyID string
+	// WrappedKey is the AES-256-GCM sealed data key, prefixed with its nonce.
+	WrappedKey []byte
+}
@@ -0,0 +1,177 @@
+package local
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/a1y/doc-formatter/internal/storage/util/objectstore"
+	"github.com/google/uuid"
+)
+
+var _ objectstore.ObjectStore = &LocalStorage{}
+
+// LocalStorage keeps objects as regular files below a root directory. Object
+// keys map to slash-separated paths relative to that directory.
+type LocalStorage struct {
+	root *os.Root
+}
+
+// NewLocalStorage opens rootDir, creating it when it does not exist yet.
+func NewLocalStorage(rootDir string) (*LocalStorage, error) {
+	if rootDir == "" {
+		return nil, errors.New("local storage root directory must be specified")
+	}
+	if err := os.MkdirAll(rootDir, 0o750); err != nil {
+		return nil, fmt.Errorf("create local storage root %q: %w", rootDir, err)
+	}
+	root, err := os.OpenRoot(rootDir)
+	if err != nil {
+		return nil, fmt.Errorf("open local storage root %q: %w", rootDir, err)
+	}
+	return &LocalStorage{root: root}, nil
+}
+
+// Close releases the root directory handle.
+func (s *LocalStorage) Close() error {
+	return s.root.Close()
+}
+
+// cleanKey validates objectKey and returns it as a path relative to the root.
+func cleanKey(objectKey string) (string, error) {
+	if objectKey == "" || strings.HasPrefix(objectKey, "/") {
+		return "", objectstore.ErrInvalidObjectKey
+	}
+	cleaned := path.Clean(objectKey)
+	if cleaned == "." || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", objectstore.ErrInvalidObjectKey
+	}
+	return cleaned, nil
+}
+
+func (s *LocalStorage) PutObject(ctx context.Context, objectKey string, file io.Reader) (bool, error) {
+	key, err := cleanKey(objectKey)
+	if err != nil {
+		return false, err
+	}
+	if dir := path.Dir(key); dir != "." {
+		if err := s.root.MkdirAll(dir, 0o750); err != nil {
+			return false, fmt.Errorf("failed to create directory for object %s: %w", objectKey, err)
+		}
+	}
+
+	// Write to a temporary sibling first so readers never observe a partially
+	// written object.
+	tmpKey := key + ".tmp-" + uuid.NewString()
+	tmp, err := s.root.OpenFile(tmpKey, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o640)
+	if err != nil {
+		return false, fmt.Errorf("failed to put object %s: %w", objectKey, err)
+	}
+	if _, err := io.Copy(tmp, file); err != nil {
+		_ = tmp.Close()
+		_ = s.root.Remove(tmpKey)
+		return false, fmt.Errorf("failed to put object %s: %w", objectKey, err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = s.root.Remove(tmpKey)
+		return false, fmt.Errorf("failed to put object %s: %w", objectKey, err)
+	}
+	if err := s.root.Rename(tmpKey, key); err != nil {
+		_ = s.root.Remove(tmpKey)
+		return false, fmt.Errorf("failed to put object %s: %w", objectKey, err)
+	}
+	return true, nil
+}
+
+func (s *LocalStorage) GetObject(ctx context.Context, objectKey string) (io.ReadCloser, error) {
+	key, err := cleanKey(objectKey)
+	if err != nil {
+		return nil, err
+	}
+	f, err := s.root.Open(key)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, objectstore.ErrObjectNotFound
+		}
+		return nil, fmt.Errorf("failed to get object %s: %w", objectKey, err)
+	}
+	return f, nil
+}
+
+func (s *LocalStorage) DeleteObject(ctx context.Context, objectKey string) (bool, error) {
+	key, err := cleanKey(objectKey)
+	if err != nil {
+		return false, err
+	}
+	if err := s.root.Remove(key); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return false, fmt.Errorf("failed to delete object %s: %w", objectKey, err)
+	}
+	return true, nil
+}
+
+func (s *LocalStorage) HeadObject(ctx context.Context, objectKey string) (*objectstore.ObjectInfo, error) {
+	key, err := cleanKey(objectKey)
+	if err != nil {
+		return nil, err
+	}
+	info, err := s.root.Stat(key)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, objectstore.ErrObjectNotFound
+		}
+		return nil, fmt.Errorf("failed to head object %s: %w", objectKey, err)
+	}
+	if info.IsDir() {
+		return nil, objectstore.ErrObjectNotFound
+	}
+	return &objectstore.ObjectInfo{
+		Key:          key,
+		Size:         info.Size(),
+		LastModified: info.ModTime(),
+	}, nil
+}
+
+func (s *LocalStorage) ListObjects(ctx context.Context, prefix string) ([]objectstore.ObjectInfo, error) {
+	infos := make([]objectstore.ObjectInfo, 0)
+	err := fs.WalkDir(s.root.FS(), ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			// Skip subtrees that cannot contain a matching key.
+			if p != "." && !strings.HasPrefix(p+"/", prefix) && !strings.HasPrefix(prefix, p+"/") {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasPrefix(p, prefix) || strings.Contains(path.Base(p), ".tmp-") {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		infos = append(infos, objectstore.ObjectInfo{
+			Key:          p,
+			Size:         info.Size(),
+			LastModified: info.ModTime(),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list objects with prefix %s: %w", prefix, err)
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Key < infos[j].Key })
+	return infos, nil
+}
+
+func (s *LocalStorage) PresignGetObject(ctx context.Context, objectKey string, expires time.Duration) (string, error) {
+	return "", objectstore.ErrPresignNotSupported
+}
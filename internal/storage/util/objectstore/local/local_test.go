@@ -0,0 +1,144 @@
+package local
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/a1y/doc-formatter/internal/storage/util/objectstore"
+	"github.com/a1y/doc-formatter/internal/storage/util/objectstore/objectstoretest"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLocalStorage(t *testing.T) (*LocalStorage, string) {
+	t.Helper()
+
+	dir := filepath.Join(t.TempDir(), "objects")
+	store, err := NewLocalStorage(dir)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = store.Close()
+	})
+	return store, dir
+}
+
+func TestNewLocalStorage_EmptyRoot(t *testing.T) {
+	t.Parallel()
+
+	store, err := NewLocalStorage("")
+	require.Error(t, err)
+	require.Nil(t, store)
+}
+
+func TestLocalStorage_Conformance(t *testing.T) {
+	t.Parallel()
+
+	store, _ := newTestLocalStorage(t)
+	objectstoretest.Run(t, store)
+}
+
+func TestLocalStorage_PutGetHeadDelete(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store, dir := newTestLocalStorage(t)
+
+	ok, err := store.PutObject(ctx, "user/nested/file.txt", bytes.NewReader([]byte("hello")))
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	onDisk, err := os.ReadFile(filepath.Join(dir, "user", "nested", "file.txt"))
+	require.NoError(t, err)
+	require.Equal(t, "hello", string(onDisk))
+
+	reader, err := store.GetObject(ctx, "user/nested/file.txt")
+	require.NoError(t, err)
+	data, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	require.NoError(t, reader.Close())
+	require.Equal(t, "hello", string(data))
+
+	info, err := store.HeadObject(ctx, "user/nested/file.txt")
+	require.NoError(t, err)
+	require.EqualValues(t, 5, info.Size)
+
+	ok, err = store.DeleteObject(ctx, "user/nested/file.txt")
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	_, err = store.GetObject(ctx, "user/nested/file.txt")
+	require.ErrorIs(t, err, objectstore.ErrObjectNotFound)
+}
+
+func TestLocalStorage_MissingObject(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store, _ := newTestLocalStorage(t)
+
+	_, err := store.HeadObject(ctx, "missing.txt")
+	require.ErrorIs(t, err, objectstore.ErrObjectNotFound)
+
+	ok, err := store.DeleteObject(ctx, "missing.txt")
+	require.NoError(t, err)
+	require.True(t, ok)
+}
+
+func TestLocalStorage_RejectsKeysOutsideRoot(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store, _ := newTestLocalStorage(t)
+
+	for _, key := range []string{"", "/etc/passwd", "../escape.txt", "a/../../escape.txt", "."} {
+		ok, err := store.PutObject(ctx, key, bytes.NewReader([]byte("x")))
+		require.ErrorIs(t, err, objectstore.ErrInvalidObjectKey, key)
+		require.False(t, ok)
+
+		_, err = store.GetObject(ctx, key)
+		require.ErrorIs(t, err, objectstore.ErrInvalidObjectKey, key)
+	}
+}
+
+func TestLocalStorage_ListObjects_FiltersByPrefix(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store, _ := newTestLocalStorage(t)
+
+	for _, key := range []string{"u1/b.txt", "u1/a.txt", "u1/sub/c.txt", "u2/d.txt"} {
+		_, err := store.PutObject(ctx, key, bytes.NewReader([]byte(key)))
+		require.NoError(t, err)
+	}
+
+	infos, err := store.ListObjects(ctx, "u1/")
+	require.NoError(t, err)
+	keys := make([]string, 0, len(infos))
+	for _, info := range infos {
+		keys = append(keys, info.Key)
+	}
+	require.Equal(t, []string{"u1/a.txt", "u1/b.txt", "u1/sub/c.txt"}, keys)
+
+	infos, err = store.ListObjects(ctx, "u1/s")
+	require.NoError(t, err)
+	require.Len(t, infos, 1)
+	require.Equal(t, "u1/sub/c.txt", infos[0].Key)
+
+	all, err := store.ListObjects(ctx, "")
+	require.NoError(t, err)
+	require.Len(t, all, 4)
+}
+
+func TestLocalStorage_PresignGetObject_NotSupported(t *testing.T) {
+	t.Parallel()
+
+	store, _ := newTestLocalStorage(t)
+
+	url, err := store.PresignGetObject(context.Background(), "key", time.Minute)
+	require.ErrorIs(t, err, objectstore.ErrPresignNotSupported)
+	require.Empty(t, url)
+}
@@ -0,0 +1,106 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/a1y/doc-formatter/internal/storage/util/objectstore"
+)
+
+var _ objectstore.ObjectStore = &MemoryStorage{}
+
+type object struct {
+	data         []byte
+	lastModified time.Time
+}
+
+// MemoryStorage keeps objects in process memory. Content is lost when the
+// process exits, so it is only meant for tests and local development.
+type MemoryStorage struct {
+	mu      sync.RWMutex
+	objects map[string]object
+}
+
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{
+		objects: make(map[string]object),
+	}
+}
+
+func (s *MemoryStorage) PutObject(ctx context.Context, objectKey string, file io.Reader) (bool, error) {
+	if objectKey == "" {
+		return false, objectstore.ErrInvalidObjectKey
+	}
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return false, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.objects[objectKey] = object{data: data, lastModified: time.Now()}
+	return true, nil
+}
+
+func (s *MemoryStorage) GetObject(ctx context.Context, objectKey string) (io.ReadCloser, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	obj, ok := s.objects[objectKey]
+	if !ok {
+		return nil, objectstore.ErrObjectNotFound
+	}
+	// The stored slice is never mutated in place, so readers can share it.
+	return io.NopCloser(bytes.NewReader(obj.data)), nil
+}
+
+func (s *MemoryStorage) DeleteObject(ctx context.Context, objectKey string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.objects, objectKey)
+	return true, nil
+}
+
+func (s *MemoryStorage) HeadObject(ctx context.Context, objectKey string) (*objectstore.ObjectInfo, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	obj, ok := s.objects[objectKey]
+	if !ok {
+		return nil, objectstore.ErrObjectNotFound
+	}
+	return &objectstore.ObjectInfo{
+		Key:          objectKey,
+		Size:         int64(len(obj.data)),
+		LastModified: obj.lastModified,
+	}, nil
+}
+
+func (s *MemoryStorage) ListObjects(ctx context.Context, prefix string) ([]objectstore.ObjectInfo, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	infos := make([]objectstore.ObjectInfo, 0)
+	for key, obj := range s.objects {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		infos = append(infos, objectstore.ObjectInfo{
+			Key:          key,
+			Size:         int64(len(obj.data)),
+			LastModified: obj.lastModified,
+		})
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Key < infos[j].Key })
+	return infos, nil
+}
+
+func (s *MemoryStorage) PresignGetObject(ctx context.Context, objectKey string, expires time.Duration) (string, error) {
+	return "", objectstore.ErrPresignNotSupported
+}
@@ -0,0 +1,120 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/a1y/doc-formatter/internal/storage/util/objectstore"
+	"github.com/a1y/doc-formatter/internal/storage/util/objectstore/objectstoretest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStorage_Conformance(t *testing.T) {
+	t.Parallel()
+
+	objectstoretest.Run(t, NewMemoryStorage())
+}
+
+func TestMemoryStorage_PutGetHeadDelete(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store := NewMemoryStorage()
+
+	ok, err := store.PutObject(ctx, "user/file.txt", bytes.NewReader([]byte("hello")))
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	reader, err := store.GetObject(ctx, "user/file.txt")
+	require.NoError(t, err)
+	data, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	require.NoError(t, reader.Close())
+	require.Equal(t, "hello", string(data))
+
+	info, err := store.HeadObject(ctx, "user/file.txt")
+	require.NoError(t, err)
+	require.Equal(t, "user/file.txt", info.Key)
+	require.EqualValues(t, 5, info.Size)
+	require.False(t, info.LastModified.IsZero())
+
+	ok, err = store.DeleteObject(ctx, "user/file.txt")
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	_, err = store.GetObject(ctx, "user/file.txt")
+	require.ErrorIs(t, err, objectstore.ErrObjectNotFound)
+}
+
+func TestMemoryStorage_PutObject_Overwrites(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store := NewMemoryStorage()
+
+	_, err := store.PutObject(ctx, "key", bytes.NewReader([]byte("first")))
+	require.NoError(t, err)
+	_, err = store.PutObject(ctx, "key", bytes.NewReader([]byte("second")))
+	require.NoError(t, err)
+
+	reader, err := store.GetObject(ctx, "key")
+	require.NoError(t, err)
+	data, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	require.Equal(t, "second", string(data))
+}
+
+func TestMemoryStorage_PutObject_EmptyKey(t *testing.T) {
+	t.Parallel()
+
+	ok, err := NewMemoryStorage().PutObject(context.Background(), "", bytes.NewReader(nil))
+	require.ErrorIs(t, err, objectstore.ErrInvalidObjectKey)
+	require.False(t, ok)
+}
+
+func TestMemoryStorage_MissingObject(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store := NewMemoryStorage()
+
+	_, err := store.HeadObject(ctx, "missing")
+	require.ErrorIs(t, err, objectstore.ErrObjectNotFound)
+
+	ok, err := store.DeleteObject(ctx, "missing")
+	require.NoError(t, err)
+	require.True(t, ok)
+}
+
+func TestMemoryStorage_ListObjects_FiltersByPrefix(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store := NewMemoryStorage()
+
+	for _, key := range []string{"b/2.txt", "a/1.txt", "b/1.txt"} {
+		_, err := store.PutObject(ctx, key, bytes.NewReader([]byte(key)))
+		require.NoError(t, err)
+	}
+
+	infos, err := store.ListObjects(ctx, "b/")
+	require.NoError(t, err)
+	require.Len(t, infos, 2)
+	require.Equal(t, "b/1.txt", infos[0].Key)
+	require.Equal(t, "b/2.txt", infos[1].Key)
+
+	all, err := store.ListObjects(ctx, "")
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+}
+
+func TestMemoryStorage_PresignGetObject_NotSupported(t *testing.T) {
+	t.Parallel()
+
+	url, err := NewMemoryStorage().PresignGetObject(context.Background(), "key", time.Minute)
+	require.ErrorIs(t, err, objectstore.ErrPresignNotSupported)
+	require.Empty(t, url)
+}
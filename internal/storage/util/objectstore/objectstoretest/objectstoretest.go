@@ -0,0 +1,72 @@
+// Package objectstoretest holds the tests every objectstore.ObjectStore
+// driver must pass.
+package objectstoretest
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/a1y/doc-formatter/internal/storage/util/objectstore"
+	"github.com/stretchr/testify/require"
+)
+
+// Run checks that store honours the ObjectStore contract. The store must be
+// empty.
+func Run(t *testing.T, store objectstore.ObjectStore) {
+	t.Helper()
+
+	t.Run("PutGetHeadDelete", func(t *testing.T) {
+		ctx := context.Background()
+
+		ok, err := store.PutObject(ctx, "conformance/file.txt", bytes.NewReader([]byte("hello")))
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		reader, err := store.GetObject(ctx, "conformance/file.txt")
+		require.NoError(t, err)
+		data, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		require.NoError(t, reader.Close())
+		require.Equal(t, "hello", string(data))
+
+		info, err := store.HeadObject(ctx, "conformance/file.txt")
+		require.NoError(t, err)
+		require.EqualValues(t, 5, info.Size)
+
+		ok, err = store.DeleteObject(ctx, "conformance/file.txt")
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		_, err = store.HeadObject(ctx, "conformance/file.txt")
+		require.ErrorIs(t, err, objectstore.ErrObjectNotFound)
+	})
+
+	t.Run("DeleteMissing", func(t *testing.T) {
+		ctx := context.Background()
+
+		ok, err := store.DeleteObject(ctx, "conformance/missing.txt")
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		// Deleting twice is how a failed purge is retried.
+		_, err = store.PutObject(ctx, "conformance/twice.txt", bytes.NewReader([]byte("x")))
+		require.NoError(t, err)
+		for range 2 {
+			ok, err = store.DeleteObject(ctx, "conformance/twice.txt")
+			require.NoError(t, err)
+			require.True(t, ok)
+		}
+	})
+
+	t.Run("MissingObject", func(t *testing.T) {
+		ctx := context.Background()
+
+		_, err := store.GetObject(ctx, "conformance/missing.txt")
+		require.ErrorIs(t, err, objectstore.ErrObjectNotFound)
+
+		_, err = store.HeadObject(ctx, "conformance/missing.txt")
+		require.ErrorIs(t, err, objectstore.ErrObjectNotFound)
+	})
+}
@@ -0,0 +1,44 @@
+package objectstore
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+var (
+	ErrObjectNotFound        = errors.New("object not found")
+	ErrInvalidObjectKey      = errors.New("invalid object key")
+	ErrPresignNotSupported   = errors.New("presigned urls are not supported by this backend")
+	ErrUnknownStorageBackend = errors.New("unknown storage backend")
+)
+
+// ObjectInfo describes a stored object without its content.
+type ObjectInfo struct {
+	Key          string    `yaml:"key" json:"key"`
+	Size         int64     `yaml:"size" json:"size"`
+	LastModified time.Time `yaml:"lastModified" json:"lastModified"`
+}
+
+// ObjectStore is the blob storage used by the storage service to keep
+// document content. Implementations must be safe for concurrent use.
+type ObjectStore interface {
+	// PutObject stores the content of file under objectKey, replacing any
+	// existing object with the same key.
+	PutObject(ctx context.Context, objectKey string, file io.Reader) (bool, error)
+	// GetObject opens the object stored under objectKey. The caller must
+	// close the returned reader.
+	GetObject(ctx context.Context, objectKey string) (io.ReadCloser, error)
+	// DeleteObject removes the object stored under objectKey. Deleting a key
+	// with no object succeeds, as it does on S3, so that a deletion can be
+	// retried.
+	DeleteObject(ctx context.Context, objectKey string) (bool, error)
+	// HeadObject returns the metadata of the object stored under objectKey.
+	HeadObject(ctx context.Context, objectKey string) (*ObjectInfo, error)
+	// ListObjects returns the metadata of every object whose key starts with prefix.
+	ListObjects(ctx context.Context, prefix string) ([]ObjectInfo, error)
+	// PresignGetObject returns a URL granting temporary read access to the
+	// object. Backends without URL access return ErrPresignNotSupported.
+	PresignGetObject(ctx context.Context, objectKey string, expires time.Duration) (string, error)
+}
@@ -0,0 +1,44 @@
+package ooxml
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/a1y/doc-formatter/internal/storage/util/docparse"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse_WritesBack(t *testing.T) {
+	t.Parallel()
+
+	source := `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:document xmlns:w="urn:w" xmlns:mc="urn:mc" mc:Ignorable="w14"><!-- note --><w:body><w:p w:a="1 &amp; &quot;2&quot;"><w:t xml:space="preserve"> a &lt; b </w:t></w:p><w:p/></w:body></w:document>`
+	doc, err := Parse([]byte(source))
+	require.NoError(t, err)
+	require.Equal(t, "urn:w", doc.Namespace("w"))
+	prefix, ok := doc.Prefix("urn:mc")
+	require.True(t, ok)
+	require.Equal(t, "mc", prefix)
+
+	require.Equal(t, strings.ReplaceAll(source, "\n", "&#xA;"),
+		strings.ReplaceAll(string(doc.Bytes()), "&#34;", "&quot;"))
+
+	_, err = Parse([]byte(`<a><b></a>`))
+	require.ErrorIs(t, err, docparse.ErrMalformedDocument)
+}
+
+func TestRelative(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "media/image1.png", Relative("word/document.xml", "word/media/image1.png"))
+	require.Equal(t, "../embeddings/sheet.xlsx", Relative("word/charts/chart1.xml", "word/embeddings/sheet.xlsx"))
+	require.Equal(t, "word/document.xml", Relative("", "word/document.xml"))
+	require.Equal(t, "word/media/image1.png", Resolve("word/document.xml", "media/image1.png"))
+	require.Equal(t, "word/media/image1.png", Resolve("word/charts/chart1.xml", "/word/media/image1.png"))
+
+	source, ok := RelsSource("word/_rels/document.xml.rels")
+	require.True(t, ok)
+	require.Equal(t, "word/document.xml", source)
+	_, ok = RelsSource("word/document.xml")
+	require.False(t, ok)
+}
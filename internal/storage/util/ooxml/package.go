@@ -0,0 +1,328 @@
+// Package ooxml edits Office Open XML documents: the parts of their
+// packages and the XML of those parts.
+package ooxml
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/a1y/doc-formatter/internal/storage/util/docparse"
+)
+
+const (
+	ContentTypesName = "[Content_Types].xml"
+	PackageRelsName  = "_rels/.rels"
+
+	NSPackageRelationships = "http://schemas.openxmlformats.org/package/2006/relationships"
+	NSContentTypes         = "http://schemas.openxmlformats.org/package/2006/content-types"
+
+	RelTypePrefix  = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/"
+	RelDocument    = RelTypePrefix + "officeDocument"
+	RelStyles      = RelTypePrefix + "styles"
+	RelNumbering   = RelTypePrefix + "numbering"
+	TargetExternal = "External"
+)
+
+// Package is an Open Packaging Conventions package, the ZIP container of
+// Office documents, held in memory.
+type Package struct {
+	// names keeps the order of the parts.
+	names []string
+	parts map[string][]byte
+}
+
+// Open reads the package data. Parts larger than
+// docparse.MaxDocumentSize in total once decompressed are rejected with
+// docparse.ErrDocumentTooLarge.
+func Open(data []byte) (*Package, error) {
+	zp, err := docparse.OpenPackage(data)
+	if err != nil {
+		return nil, err
+	}
+	p := &Package{parts: make(map[string][]byte, len(zp.File))}
+	total := 0
+	for _, f := range zp.File {
+		if strings.HasSuffix(f.Name, "/") {
+			continue
+		}
+		content, err := docparse.ReadFile(f, docparse.MaxDocumentSize-total)
+		if err != nil {
+			return nil, err
+		}
+		total += len(content)
+		p.Set(f.Name, content)
+	}
+	return p, nil
+}
+
+// Clone returns a copy of p sharing the content of its parts, which are
+// only ever replaced.
+func (p *Package) Clone() *Package {
+	c := &Package{names: append([]string(nil), p.names...), parts: make(map[string][]byte, len(p.parts))}
+	for name, data := range p.parts {
+		c.parts[name] = data
+	}
+	return c
+}
+
+// Names lists the parts in order.
+func (p *Package) Names() []string {
+	return p.names
+}
+
+// Part returns the content of the part name.
+func (p *Package) Part(name string) ([]byte, bool) {
+	data, ok := p.parts[name]
+	return data, ok
+}
+
+// Set adds the part name, or replaces its content.
+func (p *Package) Set(name string, data []byte) {
+	if _, ok := p.parts[name]; !ok {
+		p.names = append(p.names, name)
+	}
+	p.parts[name] = data
+}
+
+// Remove drops the part name.
+func (p *Package) Remove(name string) {
+	if _, ok := p.parts[name]; !ok {
+		return
+	}
+	delete(p.parts, name)
+	for i, n := range p.names {
+		if n == name {
+			p.names = append(p.names[:i], p.names[i+1:]...)
+			break
+		}
+	}
+}
+
+// XML parses the part name, nil when the package has none.
+func (p *Package) XML(name string) (*Document, error) {
+	data, ok := p.parts[name]
+	if !ok {
+		return nil, nil
+	}
+	return Parse(data)
+}
+
+// Unique returns name, or a name next to it that no part has.
+func (p *Package) Unique(name string) string {
+	ext := path.Ext(name)
+	stem := strings.TrimSuffix(name, ext)
+	for i := 2; ; i++ {
+		if _, ok := p.parts[name]; !ok {
+			return name
+		}
+		name = stem + "-" + strconv.Itoa(i) + ext
+	}
+}
+
+// Bytes writes the package, with the content types first as the format
+// recommends.
+func (p *Package) Bytes() ([]byte, error) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	names := make([]string, 0, len(p.names))
+	if _, ok := p.parts[ContentTypesName]; ok {
+		names = append(names, ContentTypesName)
+	}
+	for _, name := range p.names {
+		if name != ContentTypesName {
+			names = append(names, name)
+		}
+	}
+	for _, name := range names {
+		w, err := zw.Create(name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write(p.parts[name]); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// RelsName returns the name of the relationships part of the part name.
+func RelsName(name string) string {
+	dir, file := path.Split(name)
+	return dir + "_rels/" + file + ".rels"
+}
+
+// RelsSource returns the name of the part whose relationships the part name
+// holds, empty for the relationships of the package, and false unless name
+// Is a relationships part.
+func RelsSource(name string) (string, bool) {
+	dir, file := path.Split(name)
+	if !strings.HasSuffix(dir, "_rels/") || !strings.HasSuffix(file, ".rels") {
+		return "", false
+	}
+	return strings.TrimSuffix(dir, "_rels/") + strings.TrimSuffix(file, ".rels"), true
+}
+
+// Resolve returns the name of the part target refers to from the part
+// source.
+func Resolve(source, target string) string {
+	if strings.HasPrefix(target, "/") {
+		return path.Clean(target)[1:]
+	}
+	return strings.TrimPrefix(path.Join(path.Dir(source), target), "/")
+}
+
+// Relative returns the target referring to the part name from the part
+// source.
+func Relative(source, name string) string {
+	from := strings.Split(path.Dir(source), "/")
+	if from[0] == "." {
+		from = nil
+	}
+	to := strings.Split(name, "/")
+	common := 0
+	for common < len(from) && common < len(to)-1 && from[common] == to[common] {
+		common++
+	}
+	parts := make([]string, 0, len(from)-common+len(to)-common)
+	for range from[common:] {
+		parts = append(parts, "..")
+	}
+	return strings.Join(append(parts, to[common:]...), "/")
+}
+
+// NewRelationships returns an empty relationships part.
+func NewRelationships() *Document {
+	return &Document{
+		Prolog: []xml.Token{Declaration()},
+		Root:   &Node{Start: StartElement("Relationships", "xmlns", NSPackageRelationships)},
+	}
+}
+
+// Relationships lists the Relationship elements of a relationships part.
+func Relationships(rels *Document) []*Node {
+	var out []*Node
+	for _, c := range rels.Root.Children {
+		if c, ok := c.(*Node); ok && c.Start.Name.Local == "Relationship" {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// Relationship returns the relationship id of rels, nil if none.
+func Relationship(rels *Document, id string) *Node {
+	for _, r := range Relationships(rels) {
+		if rels.Value(r, "", "Id") == id {
+			return r
+		}
+	}
+	return nil
+}
+
+// Target returns the part the first relationship of type typ of rels
+// refers to from the part source, empty if none.
+func Target(rels *Document, source, typ string) string {
+	if rels == nil {
+		return ""
+	}
+	for _, r := range Relationships(rels) {
+		if rels.Value(r, "", "Type") == typ && rels.Value(r, "", "TargetMode") != TargetExternal {
+			return Resolve(source, rels.Value(r, "", "Target"))
+		}
+	}
+	return ""
+}
+
+// AddRelationship adds a relationship to rels and returns its id.
+func AddRelationship(rels *Document, typ, target, mode string) string {
+	used := make(map[string]bool)
+	for _, r := range Relationships(rels) {
+		used[rels.Value(r, "", "Id")] = true
+	}
+	id := ""
+	for i := len(used) + 1; ; i++ {
+		if id = "rId" + strconv.Itoa(i); !used[id] {
+			break
+		}
+	}
+	attrs := []string{"Id", id, "Type", typ, "Target", target}
+	if mode != "" {
+		attrs = append(attrs, "TargetMode", mode)
+	}
+	rels.Root.Children = append(rels.Root.Children, &Node{Start: StartElement("Relationship", attrs...)})
+	return id
+}
+
+// ContentType returns the media type the content types part types gives
+// the part name.
+func ContentType(types *Document, name string) string {
+	ext := strings.TrimPrefix(path.Ext(name), ".")
+	byDefault := ""
+	for _, c := range types.Root.Children {
+		c, ok := c.(*Node)
+		if !ok {
+			continue
+		}
+		switch c.Start.Name.Local {
+		case "Override":
+			if strings.EqualFold(types.Value(c, "", "PartName"), "/"+name) {
+				return types.Value(c, "", "ContentType")
+			}
+		case "Default":
+			if strings.EqualFold(types.Value(c, "", "Extension"), ext) {
+				byDefault = types.Value(c, "", "ContentType")
+			}
+		}
+	}
+	return byDefault
+}
+
+// SetContentType makes the content types part types give the part name the
+// media type typ, through the default of its extension when possible.
+func SetContentType(types *Document, name, typ string) {
+	if typ == "" || ContentType(types, name) == typ {
+		return
+	}
+	ext := strings.TrimPrefix(path.Ext(name), ".")
+	hasDefault := false
+	for _, c := range types.Root.Children {
+		if c, ok := c.(*Node); ok && c.Start.Name.Local == "Default" && strings.EqualFold(types.Value(c, "", "Extension"), ext) {
+			hasDefault = true
+		}
+	}
+	if !hasDefault && ext != "" && ext != "xml" && ext != "rels" {
+		types.Root.Children = append([]any{&Node{Start: StartElement("Default", "Extension", ext, "ContentType", typ)}}, types.Root.Children...)
+		return
+	}
+	types.Root.Children = append(types.Root.Children, &Node{Start: StartElement("Override", "PartName", "/"+name, "ContentType", typ)})
+}
+
+// RemoveOverride drops the media type the content types part types gives
+// the part name specifically.
+func RemoveOverride(types *Document, name string) {
+	Filter(types.Root, func(n *Node) bool {
+		return n.Start.Name.Local != "Override" || !strings.EqualFold(types.Value(n, "", "PartName"), "/"+name)
+	})
+}
+
+// Declaration returns the XML declaration Office writes.
+func Declaration() xml.ProcInst {
+	return xml.ProcInst{Target: "xml", Inst: []byte(`version="1.0" encoding="UTF-8" standalone="yes"`)}
+}
+
+// StartElement returns an unprefixed start element with the attributes given as
+// name and value pairs.
+func StartElement(local string, attrs ...string) xml.StartElement {
+	start := xml.StartElement{Name: xml.Name{Local: local}}
+	for i := 0; i+1 < len(attrs); i += 2 {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: attrs[i]}, Value: attrs[i+1]})
+	}
+	return start
+}
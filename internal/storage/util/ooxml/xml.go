@@ -0,0 +1,287 @@
+package ooxml
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/a1y/doc-formatter/internal/storage/util/docparse"
+)
+
+// Namespaces of the WordprocessingML parts.
+const (
+	NSMain          = "http://schemas.openxmlformats.org/wordprocessingml/2006/main"
+	NSRelationships = "http://schemas.openxmlformats.org/officeDocument/2006/relationships"
+	NSDrawing       = "http://schemas.openxmlformats.org/drawingml/2006/wordprocessingDrawing"
+	NSCompatibility = "http://schemas.openxmlformats.org/markup-compatibility/2006"
+	NSXMLNS         = "xmlns"
+)
+
+// Document is an XML document read with the namespace prefixes of its names
+// kept as they are, so that it can be edited and written back without
+// renaming anything. Namespaces are resolved through the declarations of the
+// root element, where Office documents make them all.
+type Document struct {
+	// Prolog holds the tokens before the root element.
+	Prolog []xml.Token
+	Root   *Node
+}
+
+// Node is an element. Its names hold prefixes rather than namespaces, and
+// its children are elements or copies of the other tokens.
+type Node struct {
+	Start    xml.StartElement
+	Children []any
+}
+
+// Parse reads the XML document data.
+func Parse(data []byte) (*Document, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	doc := &Document{}
+	var stack []*Node
+	for {
+		token, err := decoder.RawToken()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", docparse.ErrMalformedDocument, err)
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			n := &Node{Start: t.Copy()}
+			switch {
+			case len(stack) > 0:
+				parent := stack[len(stack)-1]
+				parent.Children = append(parent.Children, n)
+			case doc.Root == nil:
+				doc.Root = n
+			default:
+				return nil, fmt.Errorf("%w: more than one root element", docparse.ErrMalformedDocument)
+			}
+			stack = append(stack, n)
+		case xml.EndElement:
+			if len(stack) == 0 || stack[len(stack)-1].Start.Name != t.Name {
+				return nil, fmt.Errorf("%w: unexpected end element %s", docparse.ErrMalformedDocument, qname(t.Name))
+			}
+			stack = stack[:len(stack)-1]
+		default:
+			token = xml.CopyToken(token)
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.Children = append(parent.Children, token)
+			} else if doc.Root == nil {
+				doc.Prolog = append(doc.Prolog, token)
+			}
+		}
+	}
+	if doc.Root == nil || len(stack) > 0 {
+		return nil, fmt.Errorf("%w: incomplete XML document", docparse.ErrMalformedDocument)
+	}
+	return doc, nil
+}
+
+// Bytes writes the document back.
+func (d *Document) Bytes() []byte {
+	var buf bytes.Buffer
+	for _, token := range d.Prolog {
+		writeToken(&buf, token)
+	}
+	writeNode(&buf, d.Root)
+	return buf.Bytes()
+}
+
+func writeNode(buf *bytes.Buffer, n *Node) {
+	buf.WriteByte('<')
+	buf.WriteString(qname(n.Start.Name))
+	for _, a := range n.Start.Attr {
+		buf.WriteByte(' ')
+		buf.WriteString(qname(a.Name))
+		buf.WriteString(`="`)
+		_ = xml.EscapeText(buf, []byte(a.Value))
+		buf.WriteByte('"')
+	}
+	if len(n.Children) == 0 {
+		buf.WriteString("/>")
+		return
+	}
+	buf.WriteByte('>')
+	for _, child := range n.Children {
+		if c, ok := child.(*Node); ok {
+			writeNode(buf, c)
+		} else {
+			writeToken(buf, child)
+		}
+	}
+	buf.WriteString("</")
+	buf.WriteString(qname(n.Start.Name))
+	buf.WriteByte('>')
+}
+
+func writeToken(buf *bytes.Buffer, token any) {
+	switch t := token.(type) {
+	case xml.CharData:
+		_ = xml.EscapeText(buf, t)
+	case xml.Comment:
+		buf.WriteString("<!--")
+		buf.Write(t)
+		buf.WriteString("-->")
+	case xml.ProcInst:
+		buf.WriteString("<?")
+		buf.WriteString(t.Target)
+		if len(t.Inst) > 0 {
+			buf.WriteByte(' ')
+			buf.Write(t.Inst)
+		}
+		buf.WriteString("?>")
+	case xml.Directive:
+		buf.WriteString("<!")
+		buf.Write(t)
+		buf.WriteByte('>')
+	}
+}
+
+func qname(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+	return name.Space + ":" + name.Local
+}
+
+// Namespace returns the namespace prefix is bound to by the root element, the
+// default namespace for the empty prefix.
+func (d *Document) Namespace(prefix string) string {
+	for _, a := range d.Root.Start.Attr {
+		if (prefix == "" && a.Name.Space == "" && a.Name.Local == NSXMLNS) ||
+			(prefix != "" && a.Name.Space == NSXMLNS && a.Name.Local == prefix) {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// Prefix returns a prefix the root element binds to namespace.
+func (d *Document) Prefix(namespace string) (string, bool) {
+	for _, a := range d.Root.Start.Attr {
+		if a.Value != namespace {
+			continue
+		}
+		if a.Name.Space == NSXMLNS {
+			return a.Name.Local, true
+		}
+		if a.Name.Space == "" && a.Name.Local == NSXMLNS {
+			return "", true
+		}
+	}
+	return "", false
+}
+
+// Declare returns the prefix the root element binds to namespace, binding
+// preferred, or a prefix made from it, when there is none. Attributes need a
+// prefix, so a default namespace does not do.
+func (d *Document) Declare(namespace, preferred string) string {
+	for _, a := range d.Root.Start.Attr {
+		if a.Name.Space == NSXMLNS && a.Value == namespace {
+			return a.Name.Local
+		}
+	}
+	prefix := preferred
+	for i := 2; d.Namespace(prefix) != ""; i++ {
+		prefix = preferred + strconv.Itoa(i)
+	}
+	d.Root.Start.Attr = append(d.Root.Start.Attr, xml.Attr{Name: xml.Name{Space: NSXMLNS, Local: prefix}, Value: namespace})
+	return prefix
+}
+
+// Is reports whether n is the element local of namespace.
+func (d *Document) Is(n *Node, namespace, local string) bool {
+	return n.Start.Name.Local == local && d.Namespace(n.Start.Name.Space) == namespace
+}
+
+// Attr returns the index of the attribute local of namespace of n, or -1.
+// Unprefixed attributes are in no namespace.
+func (d *Document) Attr(n *Node, namespace, local string) int {
+	for i, a := range n.Start.Attr {
+		if a.Name.Local != local {
+			continue
+		}
+		if (a.Name.Space == "" && namespace == "") || (a.Name.Space != "" && d.Namespace(a.Name.Space) == namespace) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Value returns the value of the attribute local of namespace of n.
+func (d *Document) Value(n *Node, namespace, local string) string {
+	if i := d.Attr(n, namespace, local); i >= 0 {
+		return n.Start.Attr[i].Value
+	}
+	return ""
+}
+
+// Child returns the first child of n that is the element local of
+// namespace.
+func (d *Document) Child(n *Node, namespace, local string) *Node {
+	for _, c := range n.Children {
+		if c, ok := c.(*Node); ok && d.Is(c, namespace, local) {
+			return c
+		}
+	}
+	return nil
+}
+
+// Element returns a new element local of namespace, named with the prefix
+// the root binds to it.
+func (d *Document) Element(namespace, local string, attrs ...string) *Node {
+	prefix, _ := d.Prefix(namespace)
+	n := &Node{Start: xml.StartElement{Name: xml.Name{Space: prefix, Local: local}}}
+	for i := 0; i+1 < len(attrs); i += 2 {
+		n.Start.Attr = append(n.Start.Attr, xml.Attr{Name: xml.Name{Space: prefix, Local: attrs[i]}, Value: attrs[i+1]})
+	}
+	return n
+}
+
+// Walk hands n and every element below it to visit, parents first. Children
+// of elements visit returns false for are skipped.
+func Walk(n *Node, visit func(*Node) bool) {
+	if !visit(n) {
+		return
+	}
+	for _, c := range n.Children {
+		if c, ok := c.(*Node); ok {
+			Walk(c, visit)
+		}
+	}
+}
+
+// Filter removes the elements below n that keep returns false for.
+func Filter(n *Node, keep func(*Node) bool) {
+	children := n.Children[:0]
+	for _, c := range n.Children {
+		if c, ok := c.(*Node); ok {
+			if !keep(c) {
+				continue
+			}
+			Filter(c, keep)
+		}
+		children = append(children, c)
+	}
+	n.Children = children
+}
+
+// Clone returns a deep copy of n.
+func Clone(n *Node) *Node {
+	c := &Node{Start: n.Start.Copy(), Children: make([]any, len(n.Children))}
+	for i, child := range n.Children {
+		if n, ok := child.(*Node); ok {
+			c.Children[i] = Clone(n)
+		} else {
+			c.Children[i] = xml.CopyToken(child.(xml.Token))
+		}
+	}
+	return c
+}
@@ -3,16 +3,22 @@ package s3
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"time"
 
+	"github.com/a1y/doc-formatter/internal/storage/util/objectstore"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/smithy-go"
 )
 
+var _ objectstore.ObjectStore = &S3Storage{}
+
 func (s *S3Storage) PutObject(ctx context.Context, objectKey string, file io.Reader) (bool, error) {
-	_, err := s.s3.PutObject(ctx, &s3.PutObjectInput{
+	// The uploader sends streamed content such as encrypted documents in
+	// parts, so that only a part at a time is held in memory.
+	_, err := s.uploader.Upload(ctx, &s3.PutObjectInput{
 		Bucket: aws.String(s.bucket),
 		Key:    aws.String(objectKey),
 		Body:   file,
@@ -33,7 +39,7 @@ func (s *S3Storage) PutObject(ctx context.Context, objectKey string, file io.Rea
 	return true, nil
 }
 
-func (s *S3Storage) GetObject(ctx context.Context, objectKey string) (io.Reader, error) {
+func (s *S3Storage) GetObject(ctx context.Context, objectKey string) (io.ReadCloser, error) {
 	resp, err := s.s3.GetObject(ctx, &s3.GetObjectInput{
 		Bucket: aws.String(s.bucket),
 		Key:    aws.String(objectKey),
@@ -41,13 +47,14 @@ func (s *S3Storage) GetObject(ctx context.Context, objectKey string) (io.Reader,
 	if err != nil {
 		var apiErr smithy.APIError
 		if errors.As(err, &apiErr) && apiErr.ErrorCode() == "NoSuchKey" {
-			return nil, errors.New("object not found: " + objectKey + " in bucket: " + s.bucket)
+			return nil, fmt.Errorf("%w: %s in bucket: %s", objectstore.ErrObjectNotFound, objectKey, s.bucket)
 		}
 		return nil, errors.New("failed to get object: " + objectKey + " in bucket: " + s.bucket + " with error: " + err.Error())
 	} else {
 		err = s3.NewObjectExistsWaiter(s.s3).Wait(
 			ctx, &s3.HeadObjectInput{Bucket: aws.String(s.bucket), Key: aws.String(objectKey)}, time.Minute)
 		if err != nil {
+			_ = resp.Body.Close()
 			return nil, errors.New("failed to wait for object: " + objectKey + " in bucket: " + s.bucket + " with error: " + err.Error())
 		}
 	}
@@ -60,17 +67,71 @@ func (s *S3Storage) DeleteObject(ctx context.Context, objectKey string) (bool, e
 		Key:    aws.String(objectKey),
 	})
 	if err != nil {
+		// S3 answers a missing key with success, S3 compatible stores may
+		// report it instead.
 		var apiErr smithy.APIError
 		if errors.As(err, &apiErr) && apiErr.ErrorCode() == "NoSuchKey" {
-			return false, errors.New("object not found: " + objectKey + " in bucket: " + s.bucket)
+			return true, nil
 		}
 		return false, errors.New("failed to delete object: " + objectKey + " in bucket: " + s.bucket + " with error: " + err.Error())
 	} else {
-		err = s3.NewObjectExistsWaiter(s.s3).Wait(
+		err = s3.NewObjectNotExistsWaiter(s.s3).Wait(
 			ctx, &s3.HeadObjectInput{Bucket: aws.String(s.bucket), Key: aws.String(objectKey)}, time.Minute)
 		if err != nil {
-			return false, errors.New("failed to wait for object: " + objectKey + " in bucket: " + s.bucket + " with error: " + err.Error())
+			return false, errors.New("failed to wait for deletion of object: " + objectKey + " in bucket: " + s.bucket + " with error: " + err.Error())
 		}
 	}
 	return true, nil
 }
+
+func (s *S3Storage) HeadObject(ctx context.Context, objectKey string) (*objectstore.ObjectInfo, error) {
+	resp, err := s.s3.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(objectKey),
+	})
+	if err != nil {
+		var apiErr smithy.APIError
+		if errors.As(err, &apiErr) && (apiErr.ErrorCode() == "NotFound" || apiErr.ErrorCode() == "NoSuchKey") {
+			return nil, fmt.Errorf("%w: %s in bucket: %s", objectstore.ErrObjectNotFound, objectKey, s.bucket)
+		}
+		return nil, errors.New("failed to head object: " + objectKey + " in bucket: " + s.bucket + " with error: " + err.Error())
+	}
+	return &objectstore.ObjectInfo{
+		Key:          objectKey,
+		Size:         aws.ToInt64(resp.ContentLength),
+		LastModified: aws.ToTime(resp.LastModified),
+	}, nil
+}
+
+func (s *S3Storage) ListObjects(ctx context.Context, prefix string) ([]objectstore.ObjectInfo, error) {
+	infos := make([]objectstore.ObjectInfo, 0)
+	paginator := s3.NewListObjectsV2Paginator(s.s3, &s3.ListObjectsV2Input{
+		Bucket: aws.String(s.bucket),
+		Prefix: aws.String(prefix),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, errors.New("failed to list objects with prefix: " + prefix + " in bucket: " + s.bucket + " with error: " + err.Error())
+		}
+		for _, obj := range page.Contents {
+			infos = append(infos, objectstore.ObjectInfo{
+				Key:          aws.ToString(obj.Key),
+				Size:         aws.ToInt64(obj.Size),
+				LastModified: aws.ToTime(obj.LastModified),
+			})
+		}
+	}
+	return infos, nil
+}
+
+func (s *S3Storage) PresignGetObject(ctx context.Context, objectKey string, expires time.Duration) (string, error) {
+	req, err := s3.NewPresignClient(s.s3).PresignGetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(objectKey),
+	}, s3.WithPresignExpires(expires))
+	if err != nil {
+		return "", errors.New("failed to presign object: " + objectKey + " in bucket: " + s.bucket + " with error: " + err.Error())
+	}
+	return req.URL, nil
+}
@@ -6,13 +6,19 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/a1y/doc-formatter/internal/storage"
+	"github.com/a1y/doc-formatter/internal/storage/util/objectstore"
+	"github.com/a1y/doc-formatter/internal/storage/util/objectstore/objectstoretest"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awsconfig "github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/stretchr/testify/require"
 )
@@ -36,11 +42,93 @@ func newTestS3Storage(t *testing.T, handler http.Handler) *S3Storage {
 	})
 
 	return &S3Storage{
-		s3:     client,
-		bucket: "test-bucket",
+		s3:       client,
+		uploader: manager.NewUploader(client),
+		bucket:   "test-bucket",
 	}
 }
 
+// fakeBucket serves the object requests of the driver from memory, answering
+// a missing key the way S3 does.
+type fakeBucket struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	// parts holds the parts of the multipart upload in progress.
+	parts [][]byte
+}
+
+func (b *fakeBucket) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	data, ok := b.objects[r.URL.Path]
+	query := r.URL.Query()
+	switch r.Method {
+	case http.MethodPost:
+		if query.Has("uploads") {
+			b.parts = nil
+			_, _ = io.WriteString(w, `<?xml version="1.0" encoding="UTF-8"?>
+<InitiateMultipartUploadResult><UploadId>upload</UploadId></InitiateMultipartUploadResult>`)
+			return
+		}
+		b.objects[r.URL.Path] = bytes.Join(b.parts, nil)
+		_, _ = io.WriteString(w, `<?xml version="1.0" encoding="UTF-8"?>
+<CompleteMultipartUploadResult><ETag>"object"</ETag></CompleteMultipartUploadResult>`)
+	case http.MethodPut:
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if query.Has("partNumber") {
+			n, _ := strconv.Atoi(query.Get("partNumber"))
+			for len(b.parts) < n {
+				b.parts = append(b.parts, nil)
+			}
+			b.parts[n-1] = body
+			w.Header().Set("ETag", `"part-`+strconv.Itoa(n)+`"`)
+			return
+		}
+		b.objects[r.URL.Path] = body
+	case http.MethodGet, http.MethodHead:
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			if r.Method == http.MethodGet {
+				_, _ = io.WriteString(w, `<?xml version="1.0" encoding="UTF-8"?>
+<Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message></Error>`)
+			}
+			return
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(data)
+		}
+	case http.MethodDelete:
+		delete(b.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.Error(w, "unexpected method", http.StatusBadRequest)
+	}
+}
+
+func TestS3Storage_Conformance(t *testing.T) {
+	objectstoretest.Run(t, newTestS3Storage(t, &fakeBucket{objects: make(map[string][]byte)}))
+}
+
+func TestS3Storage_PutObject_Multipart(t *testing.T) {
+	bucket := &fakeBucket{objects: make(map[string][]byte)}
+	storage := newTestS3Storage(t, bucket)
+
+	// A streamed body larger than a part is sent in several parts.
+	content := bytes.Repeat([]byte("0123456789"), int(manager.DefaultUploadPartSize/4))
+	ok, err := storage.PutObject(context.Background(), "path/to/large.bin", io.MultiReader(bytes.NewReader(content)))
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Len(t, bucket.parts, 3)
+	require.Equal(t, content, bucket.objects["/test-bucket/path/to/large.bin"])
+}
+
 func TestS3Storage_PutObject_Success(t *testing.T) {
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.Method {
@@ -60,6 +148,28 @@ func TestS3Storage_PutObject_Success(t *testing.T) {
 	require.True(t, ok)
 }
 
+func TestS3Storage_PutObject_UnseekableBody(t *testing.T) {
+	var received []byte
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPut:
+			received, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusOK)
+		case http.MethodHead:
+			w.WriteHeader(http.StatusOK)
+		default:
+			http.Error(w, "unexpected method", http.StatusBadRequest)
+		}
+	})
+
+	storage := newTestS3Storage(t, handler)
+
+	ok, err := storage.PutObject(context.Background(), "path/to/object.txt", io.MultiReader(strings.NewReader("hel"), strings.NewReader("lo")))
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, "hello", string(received))
+}
+
 func TestS3Storage_PutObject_Error(t *testing.T) {
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method == http.MethodPut {
@@ -148,7 +258,8 @@ func TestS3Storage_DeleteObject_Success(t *testing.T) {
 		case http.MethodDelete:
 			w.WriteHeader(http.StatusOK)
 		case http.MethodHead:
-			w.WriteHeader(http.StatusOK)
+			// The object is gone once the delete went through.
+			w.WriteHeader(http.StatusNotFound)
 		default:
 			http.Error(w, "unexpected method", http.StatusBadRequest)
 		}
@@ -164,6 +275,25 @@ func TestS3Storage_DeleteObject_Success(t *testing.T) {
 	require.True(t, ok)
 }
 
+func TestS3Storage_DeleteObject_NoSuchKey(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			// Some S3 compatible stores report the missing key.
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, `<?xml version="1.0" encoding="UTF-8"?>
+<Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message></Error>`)
+			return
+		}
+		http.Error(w, "unexpected method", http.StatusBadRequest)
+	})
+
+	storage := newTestS3Storage(t, handler)
+
+	ok, err := storage.DeleteObject(context.Background(), "missing.txt")
+	require.NoError(t, err)
+	require.True(t, ok)
+}
+
 func TestS3Storage_DeleteObject_Error(t *testing.T) {
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method == http.MethodDelete {
@@ -221,3 +351,90 @@ func TestS3Storage_DeleteObject_WaiterError(t *testing.T) {
 	require.Error(t, err)
 	require.False(t, ok)
 }
+
+func TestS3Storage_HeadObject_Success(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			w.Header().Set("Content-Length", "11")
+			w.Header().Set("Last-Modified", "Mon, 02 Jan 2006 15:04:05 GMT")
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		http.Error(w, "unexpected method", http.StatusBadRequest)
+	})
+
+	storage := newTestS3Storage(t, handler)
+
+	info, err := storage.HeadObject(context.Background(), "path/to/object.txt")
+	require.NoError(t, err)
+	require.Equal(t, "path/to/object.txt", info.Key)
+	require.EqualValues(t, 11, info.Size)
+	require.Equal(t, 2006, info.LastModified.Year())
+}
+
+func TestS3Storage_HeadObject_NotFound(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	storage := newTestS3Storage(t, handler)
+
+	info, err := storage.HeadObject(context.Background(), "missing.txt")
+	require.ErrorIs(t, err, objectstore.ErrObjectNotFound)
+	require.Nil(t, info)
+}
+
+func TestS3Storage_ListObjects_Success(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Query().Get("list-type") != "2" {
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+			return
+		}
+		require.Equal(t, "user/", r.URL.Query().Get("prefix"))
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = io.WriteString(w, `<?xml version="1.0" encoding="UTF-8"?>
+<ListBucketResult>
+  <Name>test-bucket</Name>
+  <Prefix>user/</Prefix>
+  <KeyCount>2</KeyCount>
+  <IsTruncated>false</IsTruncated>
+  <Contents><Key>user/a.txt</Key><Size>3</Size><LastModified>2024-01-01T00:00:00.000Z</LastModified></Contents>
+  <Contents><Key>user/b.txt</Key><Size>5</Size><LastModified>2024-01-02T00:00:00.000Z</LastModified></Contents>
+</ListBucketResult>`)
+	})
+
+	storage := newTestS3Storage(t, handler)
+
+	infos, err := storage.ListObjects(context.Background(), "user/")
+	require.NoError(t, err)
+	require.Len(t, infos, 2)
+	require.Equal(t, "user/a.txt", infos[0].Key)
+	require.EqualValues(t, 3, infos[0].Size)
+	require.Equal(t, "user/b.txt", infos[1].Key)
+	require.EqualValues(t, 5, infos[1].Size)
+}
+
+func TestS3Storage_ListObjects_Error(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "internal error", http.StatusInternalServerError)
+	})
+
+	storage := newTestS3Storage(t, handler)
+
+	infos, err := storage.ListObjects(context.Background(), "user/")
+	require.Error(t, err)
+	require.Nil(t, infos)
+}
+
+func TestS3Storage_PresignGetObject(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "presigning must not call the server", http.StatusBadRequest)
+	})
+
+	storage := newTestS3Storage(t, handler)
+
+	url, err := storage.PresignGetObject(context.Background(), "path/to/object.txt", 10*time.Minute)
+	require.NoError(t, err)
+	require.Contains(t, url, "/test-bucket/path/to/object.txt")
+	require.Contains(t, url, "X-Amz-Expires=600")
+}
@@ -8,12 +8,14 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awsconfig "github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 )
 
 type S3Storage struct {
-	s3     *s3.Client
-	bucket string
+	s3       *s3.Client
+	uploader *manager.Uploader
+	bucket   string
 }
 
 func NewS3Storage(ctx context.Context, config *storage.Config) (*S3Storage, error) {
@@ -30,7 +32,13 @@ func NewS3Storage(ctx context.Context, config *storage.Config) (*S3Storage, erro
 		return nil, err
 	}
 
-	s3Client := s3.NewFromConfig(awsConfig)
+	s3Client := s3.NewFromConfig(awsConfig, func(o *s3.Options) {
+		// Honour custom endpoints such as MinIO, which typically also need path-style addressing.
+		if config.EndPoint != "" {
+			o.BaseEndpoint = aws.String(config.EndPoint)
+		}
+		o.UsePathStyle = config.ForcePathStyle
+	})
 	_, err = s3Client.HeadBucket(ctx, &s3.HeadBucketInput{
 		Bucket: aws.String(config.Bucket),
 	})
@@ -39,7 +47,8 @@ func NewS3Storage(ctx context.Context, config *storage.Config) (*S3Storage, erro
 	}
 
 	return &S3Storage{
-		s3:     s3Client,
-		bucket: config.Bucket,
+		s3:       s3Client,
+		uploader: manager.NewUploader(s3Client),
+		bucket:   config.Bucket,
 	}, nil
 }
@@ -0,0 +1,458 @@
+package stamp
+
+import (
+	"encoding/xml"
+	"fmt"
+	"math"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/a1y/doc-formatter/internal/storage/util/ooxml"
+)
+
+const (
+	nsVML    = "urn:schemas-microsoft-com:vml"
+	nsOffice = "urn:schemas-microsoft-com:office:office"
+	nsWord   = "urn:schemas-microsoft-com:office:word"
+
+	relHeader   = ooxml.RelTypePrefix + "header"
+	relFooter   = ooxml.RelTypePrefix + "footer"
+	relSettings = ooxml.RelTypePrefix + "settings"
+	relImage    = ooxml.RelTypePrefix + "image"
+
+	typeHeader = "application/vnd.openxmlformats-officedocument.wordprocessingml.header+xml"
+	typeFooter = "application/vnd.openxmlformats-officedocument.wordprocessingml.footer+xml"
+)
+
+// Watermarks are laid out like those Word inserts: the text on a 6.5in wide
+// diagonal and images 3.5in wide, centred on the page.
+const (
+	docxWatermarkWidth = 468
+	docxImageWidth     = 252
+	docxImageMaxHeight = 360
+	// docxTextSize is the size of header and footer text in half-points.
+	docxTextSize = 16
+)
+
+// fragmentNamespaces binds the prefixes of the markup added to documents.
+// They are renamed to the prefixes the documents use.
+var fragmentNamespaces = [][2]string{
+	{"w", ooxml.NSMain},
+	{"r", ooxml.NSRelationships},
+	{"v", nsVML},
+	{"o", nsOffice},
+	{"w10", nsWord},
+}
+
+// Word describes its text and picture watermarks with these shape types.
+const (
+	shapeTypeText = `<v:shapetype id="_x0000_t136" coordsize="21600,21600" o:spt="136" adj="10800" path="m@7,l@8,m@5,21600l@6,21600e">` +
+		`<v:formulas><v:f eqn="sum #0 0 10800"/><v:f eqn="prod #0 2 1"/><v:f eqn="sum 21600 0 @1"/><v:f eqn="sum 0 0 @2"/>` +
+		`<v:f eqn="sum 21600 0 @3"/><v:f eqn="if @0 @3 0"/><v:f eqn="if @0 21600 @1"/><v:f eqn="if @0 0 @2"/>` +
+		`<v:f eqn="if @0 @4 21600"/><v:f eqn="mid @5 @6"/><v:f eqn="mid @8 @5"/><v:f eqn="mid @7 @8"/>` +
+		`<v:f eqn="mid @6 @7"/><v:f eqn="sum @6 0 @5"/></v:formulas>` +
+		`<v:path textpathok="t" o:connecttype="custom" o:connectlocs="@9,0;@10,10800;@11,21600;@12,10800" o:connectangles="270,180,90,0"/>` +
+		`<v:textpath on="t" fitshape="t"/><v:handles><v:h position="#0,bottomRight" xrange="6629,14971"/></v:handles>` +
+		`<o:lock v:ext="edit" text="t" shapetype="t"/></v:shapetype>`
+	shapeTypePicture = `<v:shapetype id="_x0000_t75" coordsize="21600,21600" o:spt="75" o:preferrelative="t" path="m@4@5l@4@11@9@11@9@5xe" filled="f" stroked="f">` +
+		`<v:stroke joinstyle="miter"/><v:formulas><v:f eqn="if lineDrawn pixelLineWidth 0"/><v:f eqn="sum @0 1 0"/>` +
+		`<v:f eqn="sum 0 0 @1"/><v:f eqn="prod @2 1 2"/><v:f eqn="prod @3 21600 pixelWidth"/><v:f eqn="prod @3 21600 pixelHeight"/>` +
+		`<v:f eqn="sum @0 0 1"/><v:f eqn="prod @6 1 2"/><v:f eqn="prod @7 21600 pixelWidth"/><v:f eqn="sum @8 21600 0"/>` +
+		`<v:f eqn="prod @7 21600 pixelHeight"/><v:f eqn="sum @10 21600 0"/></v:formulas>` +
+		`<v:path o:extrusionok="f" gradientshapeok="t" o:connecttype="rect"/><o:lock v:ext="edit" aspectratio="t"/></v:shapetype>`
+	// shapeStyle centres a shape on the page, behind the text.
+	shapeStyle = "position:absolute;margin-left:0;margin-top:0;width:%spt;height:%spt;%sz-index:-251657216;" +
+		"mso-position-horizontal:center;mso-position-horizontal-relative:margin;" +
+		"mso-position-vertical:center;mso-position-vertical-relative:margin"
+)
+
+// docxStamper stamps a WordprocessingML document. Watermarks are drawn in
+// the headers, where Word keeps its own, so that they show on every page.
+type docxStamper struct {
+	pkg      *ooxml.Package
+	types    *ooxml.Document
+	mainName string
+	main     *ooxml.Document
+	rels     *ooxml.Document
+	stamp    Stamp
+	image    *picture
+	// imageName is the part of the image, once added.
+	imageName string
+	// stamped holds the header and footer parts stamped.
+	stamped map[string]bool
+	shapes  int
+}
+
+// stampDOCX stamps the headers and footers of every section of the DOCX
+// document data, adding them where sections have none.
+func stampDOCX(data []byte, s Stamp) ([]byte, error) {
+	pkg, err := ooxml.Open(data)
+	if err != nil {
+		return nil, err
+	}
+	d := &docxStamper{pkg: pkg, stamp: s, stamped: make(map[string]bool)}
+	if d.types, err = pkg.XML(ooxml.ContentTypesName); err != nil {
+		return nil, err
+	}
+	if d.types == nil {
+		return nil, fmt.Errorf("%w: missing %s", ErrMalformedDocument, ooxml.ContentTypesName)
+	}
+	packageRels, err := pkg.XML(ooxml.PackageRelsName)
+	if err != nil {
+		return nil, err
+	}
+	if d.mainName = ooxml.Target(packageRels, "", ooxml.RelDocument); d.mainName == "" {
+		d.mainName = "word/document.xml"
+	}
+	if d.main, err = pkg.XML(d.mainName); err != nil {
+		return nil, err
+	}
+	if d.main == nil || d.main.Child(d.main.Root, ooxml.NSMain, "body") == nil {
+		return nil, fmt.Errorf("%w: missing document body", ErrMalformedDocument)
+	}
+	if d.rels, err = pkg.XML(ooxml.RelsName(d.mainName)); err != nil {
+		return nil, err
+	}
+	if d.rels == nil {
+		d.rels = ooxml.NewRelationships()
+	}
+	if len(s.Image) > 0 {
+		if d.image, err = checkImage(s.Image); err != nil {
+			return nil, err
+		}
+	}
+
+	evenAndOdd, err := d.evenAndOddHeaders()
+	if err != nil {
+		return nil, err
+	}
+	sections := d.sections()
+	if watermarkText(s.Watermark) != "" || d.image != nil || len(textLines(s.Header)) > 0 {
+		if err := d.stampSections(sections, true, evenAndOdd); err != nil {
+			return nil, err
+		}
+	}
+	if len(textLines(s.Footer)) > 0 {
+		if err := d.stampSections(sections, false, evenAndOdd); err != nil {
+			return nil, err
+		}
+	}
+
+	pkg.Set(ooxml.ContentTypesName, d.types.Bytes())
+	pkg.Set(d.mainName, d.main.Bytes())
+	pkg.Set(ooxml.RelsName(d.mainName), d.rels.Bytes())
+	return pkg.Bytes()
+}
+
+// sections returns the section properties of the document in order, adding
+// those of the last section when the body has none.
+func (d *docxStamper) sections() []*ooxml.Node {
+	body := d.main.Child(d.main.Root, ooxml.NSMain, "body")
+	var sections []*ooxml.Node
+	ooxml.Walk(body, func(n *ooxml.Node) bool {
+		// The properties of tracked changes are those of past revisions.
+		if d.main.Is(n, ooxml.NSMain, "sectPrChange") || d.main.Is(n, ooxml.NSMain, "pPrChange") {
+			return false
+		}
+		if d.main.Is(n, ooxml.NSMain, "sectPr") {
+			sections = append(sections, n)
+			return false
+		}
+		return true
+	})
+
+	var last *ooxml.Node
+	for i := len(body.Children) - 1; i >= 0 && last == nil; i-- {
+		last, _ = body.Children[i].(*ooxml.Node)
+	}
+	if last == nil || !d.main.Is(last, ooxml.NSMain, "sectPr") {
+		sectPr := d.main.Element(ooxml.NSMain, "sectPr")
+		body.Children = append(body.Children, sectPr)
+		sections = append(sections, sectPr)
+	}
+	return sections
+}
+
+// stampSections stamps the headers, or the footers, of sections. Sections
+// without a header of a kind they show take that of the previous section,
+// so one is only added where no previous section has one.
+func (d *docxStamper) stampSections(sections []*ooxml.Node, header bool, evenAndOdd bool) error {
+	local, rel, typ, root := "footerReference", relFooter, typeFooter, "ftr"
+	if header {
+		local, rel, typ, root = "headerReference", relHeader, typeHeader, "hdr"
+	}
+
+	have := make(map[string]bool)
+	for _, sectPr := range sections {
+		for _, c := range sectPr.Children {
+			ref, ok := c.(*ooxml.Node)
+			if !ok || !d.main.Is(ref, ooxml.NSMain, local) {
+				continue
+			}
+			kind := d.main.Value(ref, ooxml.NSMain, "type")
+			if kind == "" {
+				kind = "default"
+			}
+			have[kind] = true
+			if name := d.target(d.main.Value(ref, ooxml.NSRelationships, "id")); name != "" {
+				if err := d.stampPart(name, header); err != nil {
+					return err
+				}
+			}
+		}
+
+		kinds := []string{"default"}
+		if child := d.main.Child(sectPr, ooxml.NSMain, "titlePg"); child != nil && on(d.main, child) {
+			kinds = append(kinds, "first")
+		}
+		if evenAndOdd {
+			kinds = append(kinds, "even")
+		}
+		for _, kind := range kinds {
+			if have[kind] {
+				continue
+			}
+			id, err := d.addPart(header, rel, typ, root)
+			if err != nil {
+				return err
+			}
+			ref, err := fragment(d.main, fmt.Sprintf(`<w:%s w:type="%s" r:id="%s"/>`, local, kind, id))
+			if err != nil {
+				return err
+			}
+			sectPr.Children = append(ref, sectPr.Children...)
+			have[kind] = true
+		}
+	}
+	return nil
+}
+
+// target returns the part the relationship id of the document part refers
+// to, empty if none.
+func (d *docxStamper) target(id string) string {
+	r := ooxml.Relationship(d.rels, id)
+	if r == nil || d.rels.Value(r, "", "TargetMode") == ooxml.TargetExternal {
+		return ""
+	}
+	return ooxml.Resolve(d.mainName, d.rels.Value(r, "", "Target"))
+}
+
+// addPart adds a header or footer part holding only the stamp and returns
+// the id of the relationship of the document part to it.
+func (d *docxStamper) addPart(header bool, rel, typ, root string) (string, error) {
+	name := d.pkg.Unique(path.Join(path.Dir(d.mainName), root+"1.xml"))
+	start := xml.StartElement{Name: xml.Name{Space: "w", Local: root}}
+	for _, ns := range fragmentNamespaces {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Space: ooxml.NSXMLNS, Local: ns[0]}, Value: ns[1]})
+	}
+	d.pkg.Set(name, (&ooxml.Document{Prolog: []xml.Token{ooxml.Declaration()}, Root: &ooxml.Node{Start: start}}).Bytes())
+	ooxml.SetContentType(d.types, name, typ)
+	if err := d.stampPart(name, header); err != nil {
+		return "", err
+	}
+	return ooxml.AddRelationship(d.rels, rel, ooxml.Relative(d.mainName, name), ""), nil
+}
+
+// stampPart adds the stamp to the header or footer part name.
+func (d *docxStamper) stampPart(name string, header bool) error {
+	if d.stamped[name] {
+		return nil
+	}
+	d.stamped[name] = true
+	doc, err := d.pkg.XML(name)
+	if err != nil || doc == nil {
+		return err
+	}
+
+	var markup strings.Builder
+	if header {
+		markup.WriteString(textParagraph(textLines(d.stamp.Header)))
+		if d.image != nil {
+			id, err := d.addImage(name)
+			if err != nil {
+				return err
+			}
+			markup.WriteString(d.imageParagraph(doc, id))
+		}
+		if text := watermarkText(d.stamp.Watermark); text != "" {
+			markup.WriteString(d.watermarkParagraph(doc, text))
+		}
+	} else {
+		markup.WriteString(textParagraph(textLines(d.stamp.Footer)))
+	}
+	nodes, err := fragment(doc, markup.String())
+	if err != nil {
+		return err
+	}
+	doc.Root.Children = append(doc.Root.Children, nodes...)
+	d.pkg.Set(name, doc.Bytes())
+	return nil
+}
+
+// addImage adds the watermark image to the package, once, and a
+// relationship of the part name to it, whose id it returns.
+func (d *docxStamper) addImage(name string) (string, error) {
+	if d.imageName == "" {
+		ext := "png"
+		if d.image.format == "jpeg" {
+			ext = "jpeg"
+		}
+		d.imageName = d.pkg.Unique(path.Join(path.Dir(d.mainName), "media", "stamp."+ext))
+		d.pkg.Set(d.imageName, d.image.data)
+		ooxml.SetContentType(d.types, d.imageName, d.image.mediaType())
+	}
+	relsName := ooxml.RelsName(name)
+	rels, err := d.pkg.XML(relsName)
+	if err != nil {
+		return "", err
+	}
+	if rels == nil {
+		rels = ooxml.NewRelationships()
+	}
+	id := ooxml.AddRelationship(rels, relImage, ooxml.Relative(name, d.imageName), "")
+	d.pkg.Set(relsName, rels.Bytes())
+	return id, nil
+}
+
+// watermarkParagraph returns a paragraph holding a shape of the watermark
+// text, stretched to fit like the watermarks of Word.
+func (d *docxStamper) watermarkParagraph(doc *ooxml.Document, text string) string {
+	height := docxWatermarkWidth / math.Max(4, 0.55*float64(len([]rune(text))))
+	d.shapes++
+	var b strings.Builder
+	b.WriteString(`<w:p><w:pPr><w:spacing w:after="0"/></w:pPr><w:r><w:rPr><w:noProof/></w:rPr><w:pict>`)
+	if !hasShapeType(doc, "_x0000_t136") {
+		b.WriteString(shapeTypeText)
+	}
+	fmt.Fprintf(&b, `<v:shape id="StampWatermark%d" type="#_x0000_t136" style="%s" o:allowincell="f" fillcolor="silver" stroked="f">`,
+		d.shapes, fmt.Sprintf(shapeStyle, number(docxWatermarkWidth), number(height), "rotation:315;"))
+	fmt.Fprintf(&b, `<v:fill opacity="%s"/><v:textpath style="font-family:&quot;Calibri&quot;;font-size:1pt" string="%s"/>`,
+		number(d.stamp.Opacity), escape(text))
+	b.WriteString(`<w10:wrap anchorx="margin" anchory="margin"/></v:shape></w:pict></w:r></w:p>`)
+	return b.String()
+}
+
+// imageParagraph returns a paragraph holding a shape of the watermark image
+// of the relationship id, washed out as Word does whatever the opacity.
+func (d *docxStamper) imageParagraph(doc *ooxml.Document, id string) string {
+	width := float64(docxImageWidth)
+	height := width * float64(d.image.height) / float64(d.image.width)
+	if height > docxImageMaxHeight {
+		width, height = width*docxImageMaxHeight/height, docxImageMaxHeight
+	}
+	d.shapes++
+	var b strings.Builder
+	b.WriteString(`<w:p><w:pPr><w:spacing w:after="0"/></w:pPr><w:r><w:rPr><w:noProof/></w:rPr><w:pict>`)
+	if !hasShapeType(doc, "_x0000_t75") {
+		b.WriteString(shapeTypePicture)
+	}
+	fmt.Fprintf(&b, `<v:shape id="StampWatermark%d" type="#_x0000_t75" style="%s" o:allowincell="f">`,
+		d.shapes, fmt.Sprintf(shapeStyle, number(width), number(height), ""))
+	fmt.Fprintf(&b, `<v:imagedata r:id="%s" o:title="" gain="19661f" blacklevel="22938f"/>`, escape(id))
+	b.WriteString(`<w10:wrap anchorx="margin" anchory="margin"/></v:shape></w:pict></w:r></w:p>`)
+	return b.String()
+}
+
+// evenAndOddHeaders reports whether the settings of the document give even
+// pages headers and footers of their own.
+func (d *docxStamper) evenAndOddHeaders() (bool, error) {
+	name := ooxml.Target(d.rels, d.mainName, relSettings)
+	if name == "" {
+		return false, nil
+	}
+	settings, err := d.pkg.XML(name)
+	if err != nil || settings == nil {
+		return false, err
+	}
+	child := settings.Child(settings.Root, ooxml.NSMain, "evenAndOddHeaders")
+	return child != nil && on(settings, child), nil
+}
+
+// textParagraph returns a centred paragraph of lines, empty for none.
+func textParagraph(lines []string) string {
+	if len(lines) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, `<w:p><w:pPr><w:jc w:val="center"/></w:pPr><w:r><w:rPr><w:sz w:val="%d"/></w:rPr>`, docxTextSize)
+	for i, line := range lines {
+		if i > 0 {
+			b.WriteString(`<w:br/>`)
+		}
+		fmt.Fprintf(&b, `<w:t xml:space="preserve">%s</w:t>`, escape(line))
+	}
+	b.WriteString(`</w:r></w:p>`)
+	return b.String()
+}
+
+// fragment parses markup written with the prefixes of fragmentNamespaces
+// into elements of doc, declaring on its root the namespaces it lacks.
+func fragment(doc *ooxml.Document, markup string) ([]any, error) {
+	var b strings.Builder
+	b.WriteString("<fragment")
+	for _, ns := range fragmentNamespaces {
+		fmt.Fprintf(&b, ` xmlns:%s="%s"`, ns[0], ns[1])
+	}
+	b.WriteString(">" + markup + "</fragment>")
+	parsed, err := ooxml.Parse([]byte(b.String()))
+	if err != nil {
+		return nil, err
+	}
+
+	prefixes := make(map[string]string, len(fragmentNamespaces))
+	for _, ns := range fragmentNamespaces {
+		prefixes[ns[0]] = doc.Declare(ns[1], ns[0])
+	}
+	for _, c := range parsed.Root.Children {
+		c, ok := c.(*ooxml.Node)
+		if !ok {
+			continue
+		}
+		ooxml.Walk(c, func(n *ooxml.Node) bool {
+			if p, ok := prefixes[n.Start.Name.Space]; ok {
+				n.Start.Name.Space = p
+			}
+			for i, a := range n.Start.Attr {
+				if p, ok := prefixes[a.Name.Space]; ok {
+					n.Start.Attr[i].Name.Space = p
+				}
+			}
+			return true
+		})
+	}
+	return parsed.Root.Children, nil
+}
+
+// hasShapeType reports whether doc defines the VML shape type id.
+func hasShapeType(doc *ooxml.Document, id string) bool {
+	found := false
+	ooxml.Walk(doc.Root, func(n *ooxml.Node) bool {
+		if doc.Is(n, nsVML, "shapetype") && doc.Value(n, "", "id") == id {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// on reports whether the on/off property n of doc is on.
+func on(doc *ooxml.Document, n *ooxml.Node) bool {
+	switch doc.Value(n, ooxml.NSMain, "val") {
+	case "0", "false", "off":
+		return false
+	}
+	return true
+}
+
+// escape escapes text for XML character data and attribute values.
+func escape(text string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(text))
+	return b.String()
+}
+
+// number formats n with up to two decimals.
+func number(n float64) string {
+	return strconv.FormatFloat(math.Round(n*100)/100, 'f', -1, 64)
+}
@@ -0,0 +1,63 @@
+package stamp
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/jpeg" // registers the JPEG decoder
+	_ "image/png"  // registers the PNG decoder
+)
+
+// picture is a watermark image.
+type picture struct {
+	// format is "png" or "jpeg".
+	format        string
+	width, height int
+	data          []byte
+}
+
+// checkImage reads the format and the size of the image data.
+func checkImage(data []byte) (*picture, error) {
+	if len(data) > MaxImageSize {
+		return nil, fmt.Errorf("%w: image must be at most %d bytes", ErrInvalidStamp, MaxImageSize)
+	}
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || (format != "png" && format != "jpeg") {
+		return nil, fmt.Errorf("%w: image must be a PNG or JPEG image", ErrInvalidStamp)
+	}
+	if config.Width <= 0 || config.Height <= 0 || config.Width*config.Height > MaxImagePixels {
+		return nil, fmt.Errorf("%w: image must be at most %d pixels", ErrInvalidStamp, MaxImagePixels)
+	}
+	return &picture{format: format, width: config.Width, height: config.Height, data: data}, nil
+}
+
+// mediaType returns the media type of p.
+func (p *picture) mediaType() string {
+	return "image/" + p.format
+}
+
+// samples returns the pixels of p as 8-bit RGB samples, row by row, and
+// their alpha, nil when p is opaque.
+func (p *picture) samples() ([]byte, []byte, error) {
+	img, _, err := image.Decode(bytes.NewReader(p.data))
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidStamp, err)
+	}
+	bounds := img.Bounds()
+	rgb := make([]byte, 0, 3*bounds.Dx()*bounds.Dy())
+	alpha := make([]byte, 0, bounds.Dx()*bounds.Dy())
+	opaque := true
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+			rgb = append(rgb, c.R, c.G, c.B)
+			alpha = append(alpha, c.A)
+			opaque = opaque && c.A == 0xff
+		}
+	}
+	if opaque {
+		alpha = nil
+	}
+	return rgb, alpha, nil
+}
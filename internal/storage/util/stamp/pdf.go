@@ -0,0 +1,536 @@
+package stamp
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+)
+
+// Layout of the stamps of PDF pages, in points.
+const (
+	pdfTextSize     = 9
+	pdfLineHeight   = 11
+	pdfMargin       = 24
+	pdfMinWatermark = 12
+	pdfMaxWatermark = 144
+	// pdfWatermarkSpan is the share of the diagonal of pages the watermark
+	// text spans, and pdfImageSpan the share of their width and height the
+	// image may take.
+	pdfWatermarkSpan = 0.7
+	pdfImageSpan     = 0.5
+)
+
+// defaultMediaBox is the size of US Letter pages, which readers assume for
+// pages without one.
+var defaultMediaBox = [4]float64{0, 0, 612, 792}
+
+// pdfPage is a page with the attributes it inherits from the page tree.
+type pdfPage struct {
+	ref       pdfRef
+	dict      pdfDict
+	resources pdfDict
+	// box is the visible area of the page, and rotate how many degrees
+	// clockwise it is turned for display.
+	box    [4]float64
+	rotate int
+}
+
+// pdfResources names the resources of the stamp in the resources of pages.
+type pdfResources struct {
+	font, state, image pdfName
+}
+
+// stampPDF stamps every page of the PDF document data. The original is
+// kept as it is and followed by an incremental update replacing the pages,
+// so that its signatures still cover the revision they signed.
+func stampPDF(data []byte, s Stamp) ([]byte, error) {
+	r, err := openPDF(data)
+	if err != nil {
+		return nil, err
+	}
+	pages, err := r.pages()
+	if err != nil {
+		return nil, err
+	}
+	w := &pdfUpdate{r: r, next: r.size(), objects: make(map[int][]byte)}
+
+	names := pickNames(r, pages)
+	resources := pdfDict{}
+	if watermarkText(s.Watermark) != "" || len(textLines(s.Header)) > 0 || len(textLines(s.Footer)) > 0 {
+		resources["Font"] = pdfDict{names.font: w.add(pdfDict{
+			"Type":     pdfName("Font"),
+			"Subtype":  pdfName("Type1"),
+			"BaseFont": pdfName("Helvetica"),
+			"Encoding": pdfName("WinAnsiEncoding"),
+		})}
+	}
+	resources["ExtGState"] = pdfDict{names.state: w.add(pdfDict{
+		"Type": pdfName("ExtGState"),
+		"ca":   s.Opacity,
+		"CA":   s.Opacity,
+	})}
+	var image *picture
+	if len(s.Image) > 0 {
+		if image, err = checkImage(s.Image); err != nil {
+			return nil, err
+		}
+		ref, err := w.addImage(image)
+		if err != nil {
+			return nil, err
+		}
+		resources["XObject"] = pdfDict{names.image: ref}
+	}
+
+	// The original content is wrapped in q and Q so that the state it
+	// leaves does not move or recolour the stamp.
+	save := w.addStream(nil, []byte("q\n"))
+	overlays := make(map[string]pdfRef)
+	for _, page := range pages {
+		key := fmt.Sprint(page.box, page.rotate)
+		overlay, ok := overlays[key]
+		if !ok {
+			overlay = w.addStream(nil, pageOverlay(page, s, names, image))
+			overlays[key] = overlay
+		}
+
+		contents := pdfArray{save}
+		switch c := page.dict["Contents"].(type) {
+		case pdfRef:
+			if array, ok := r.resolve(c).(pdfArray); ok {
+				contents = append(contents, array...)
+			} else {
+				contents = append(contents, c)
+			}
+		case pdfArray:
+			contents = append(contents, c...)
+		}
+		dict := make(pdfDict, len(page.dict)+2)
+		for k, v := range page.dict {
+			dict[k] = v
+		}
+		dict["Contents"] = append(contents, overlay)
+		dict["Resources"] = mergeResources(r, page.resources, resources)
+		w.replace(page.ref, dict)
+	}
+	return w.bytes(), nil
+}
+
+// pages lists the pages of the document in order.
+func (r *pdfReader) pages() ([]*pdfPage, error) {
+	catalog := r.dict(r.trailer["Root"])
+	root, ok := catalog["Pages"].(pdfRef)
+	if !ok {
+		return nil, fmt.Errorf("%w: missing page tree", ErrMalformedDocument)
+	}
+	var pages []*pdfPage
+	seen := make(map[int]bool)
+	var walk func(ref pdfRef, inherited pdfDict, depth int) error
+	walk = func(ref pdfRef, inherited pdfDict, depth int) error {
+		if seen[ref.num] || depth > maxNesting {
+			return fmt.Errorf("%w: page tree has a cycle", ErrMalformedDocument)
+		}
+		seen[ref.num] = true
+		node := r.dict(ref)
+		if node == nil {
+			return nil
+		}
+		attrs := make(pdfDict, len(inherited))
+		for k, v := range inherited {
+			attrs[k] = v
+		}
+		for _, k := range []pdfName{"Resources", "MediaBox", "CropBox", "Rotate"} {
+			if v, ok := node[k]; ok {
+				attrs[k] = v
+			}
+		}
+
+		kids, isTree := r.resolve(node["Kids"]).(pdfArray)
+		if node["Type"] == pdfName("Pages") || (isTree && node["Type"] != pdfName("Page")) {
+			for _, kid := range kids {
+				if kid, ok := kid.(pdfRef); ok {
+					if err := walk(kid, attrs, depth+1); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		}
+
+		page := &pdfPage{ref: ref, dict: node, resources: r.dict(attrs["Resources"]), box: defaultMediaBox}
+		if box, ok := r.box(attrs["MediaBox"]); ok {
+			page.box = box
+		}
+		if box, ok := r.box(attrs["CropBox"]); ok {
+			page.box = box
+		}
+		page.rotate = ((intValue(r.resolve(attrs["Rotate"]), 0)%360 + 360) % 360) / 90 * 90
+		pages = append(pages, page)
+		return nil
+	}
+	if err := walk(root, pdfDict{}, 0); err != nil {
+		return nil, err
+	}
+	if len(pages) == 0 {
+		return nil, fmt.Errorf("%w: document has no pages", ErrMalformedDocument)
+	}
+	return pages, nil
+}
+
+// box reads a rectangle, with its corners in order.
+func (r *pdfReader) box(v any) ([4]float64, bool) {
+	array, ok := r.resolve(v).(pdfArray)
+	if !ok || len(array) != 4 {
+		return [4]float64{}, false
+	}
+	var box [4]float64
+	for i, item := range array {
+		if box[i], ok = floatValue(r.resolve(item)); !ok {
+			return [4]float64{}, false
+		}
+	}
+	box[0], box[2] = min(box[0], box[2]), max(box[0], box[2])
+	box[1], box[3] = min(box[1], box[3]), max(box[1], box[3])
+	if box[2]-box[0] <= 0 || box[3]-box[1] <= 0 {
+		return [4]float64{}, false
+	}
+	return box, true
+}
+
+// pickNames returns names for the resources of the stamp that no page uses.
+func pickNames(r *pdfReader, pages []*pdfPage) pdfResources {
+	for i := 0; ; i++ {
+		suffix := ""
+		if i > 0 {
+			suffix = strconv.Itoa(i)
+		}
+		names := pdfResources{
+			font:  pdfName("StampFont" + suffix),
+			state: pdfName("StampState" + suffix),
+			image: pdfName("StampImage" + suffix),
+		}
+		taken := false
+		for _, page := range pages {
+			for _, sub := range page.resources {
+				dict := r.dict(sub)
+				_, font := dict[names.font]
+				_, state := dict[names.state]
+				_, image := dict[names.image]
+				taken = taken || font || state || image
+			}
+		}
+		if !taken {
+			return names
+		}
+	}
+}
+
+// mergeResources returns the resources of a page with those of the stamp
+// added to their categories.
+func mergeResources(r *pdfReader, page, stamp pdfDict) pdfDict {
+	merged := make(pdfDict, len(page)+len(stamp))
+	for k, v := range page {
+		merged[k] = v
+	}
+	for category, entries := range stamp {
+		dict := pdfDict{}
+		for k, v := range r.dict(page[category]) {
+			dict[k] = v
+		}
+		for k, v := range entries.(pdfDict) {
+			dict[k] = v
+		}
+		merged[category] = dict
+	}
+	return merged
+}
+
+// pageOverlay returns the content drawing the stamp on page. It starts by
+// restoring the state saved before the original content.
+func pageOverlay(page *pdfPage, s Stamp, names pdfResources, image *picture) []byte {
+	x0, y0, x1, y1 := page.box[0], page.box[1], page.box[2], page.box[3]
+	width, height := x1-x0, y1-y0
+	// The stamp is laid out upright on the page as displayed, then turned
+	// into the space of the page.
+	matrix := [6]float64{1, 0, 0, 1, x0, y0}
+	switch page.rotate {
+	case 90:
+		matrix = [6]float64{0, 1, -1, 0, x1, y0}
+		width, height = height, width
+	case 180:
+		matrix = [6]float64{-1, 0, 0, -1, x1, y1}
+	case 270:
+		matrix = [6]float64{0, -1, 1, 0, x0, y1}
+		width, height = height, width
+	}
+
+	var b bytes.Buffer
+	b.WriteString("Q\nq\n")
+	fmt.Fprintf(&b, "%s cm\n", numbers(matrix[:]...))
+
+	if image != nil {
+		w := min(width*pdfImageSpan, height*pdfImageSpan*float64(image.width)/float64(image.height))
+		h := w * float64(image.height) / float64(image.width)
+		fmt.Fprintf(&b, "q /%s gs %s cm /%s Do Q\n", names.state, numbers(w, 0, 0, h, (width-w)/2, (height-h)/2), names.image)
+	}
+
+	if text := watermarkText(s.Watermark); text != "" {
+		encoded := encodeText(text)
+		angle := math.Atan2(height, width)
+		cos, sin := math.Cos(angle), math.Sin(angle)
+		size := pdfWatermarkSpan * math.Hypot(width, height) * 1000 / textWidth(encoded)
+		size = min(max(size, pdfMinWatermark), pdfMaxWatermark)
+		span, rise := textWidth(encoded)*size/1000, helveticaCapHeight*size
+		x := width/2 - cos*span/2 + sin*rise/2
+		y := height/2 - sin*span/2 - cos*rise/2
+		fmt.Fprintf(&b, "q /%s gs 0.5 g BT /%s %s Tf %s Tm ", names.state, names.font, number(size), numbers(cos, sin, -sin, cos, x, y))
+		writeText(&b, encoded)
+		b.WriteString(" Tj ET Q\n")
+	}
+
+	header, footer := textLines(s.Header), textLines(s.Footer)
+	if len(header) > 0 || len(footer) > 0 {
+		fmt.Fprintf(&b, "q 0.25 g BT /%s %d Tf\n", names.font, pdfTextSize)
+		top := height - pdfMargin - helveticaCapHeight*pdfTextSize
+		for i, line := range header {
+			writeLine(&b, line, width, top-float64(i*pdfLineHeight))
+		}
+		for i, line := range footer {
+			writeLine(&b, line, width, pdfMargin+float64((len(footer)-1-i)*pdfLineHeight))
+		}
+		b.WriteString("ET Q\n")
+	}
+	b.WriteString("Q\n")
+	return b.Bytes()
+}
+
+// writeLine shows a line of text centred across width at height y.
+func writeLine(b *bytes.Buffer, line string, width, y float64) {
+	encoded := encodeText(line)
+	x := (width - textWidth(encoded)*pdfTextSize/1000) / 2
+	fmt.Fprintf(b, "1 0 0 1 %s Tm ", numbers(max(x, 0), y))
+	writeText(b, encoded)
+	b.WriteString(" Tj\n")
+}
+
+func writeText(b *bytes.Buffer, encoded []byte) {
+	writeObject(b, pdfString(encoded))
+}
+
+// numbers formats ns with spaces between them.
+func numbers(ns ...float64) string {
+	var b bytes.Buffer
+	for i, n := range ns {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(number(n))
+	}
+	return b.String()
+}
+
+// pdfUpdate is an incremental update of a PDF document.
+type pdfUpdate struct {
+	r *pdfReader
+	// next is the number of the next object added.
+	next    int
+	objects map[int][]byte
+}
+
+// add adds the object v and returns a reference to it.
+func (w *pdfUpdate) add(v any) pdfRef {
+	ref := pdfRef{num: w.next}
+	w.next++
+	w.replace(ref, v)
+	return ref
+}
+
+// replace sets the object ref to v.
+func (w *pdfUpdate) replace(ref pdfRef, v any) {
+	var b bytes.Buffer
+	writeObject(&b, v)
+	w.objects[ref.num] = b.Bytes()
+}
+
+// addStream adds a stream of data, whose dictionary holds dict and its
+// length.
+func (w *pdfUpdate) addStream(dict pdfDict, data []byte) pdfRef {
+	if dict == nil {
+		dict = pdfDict{}
+	}
+	dict["Length"] = int64(len(data))
+	var b bytes.Buffer
+	writeObject(&b, dict)
+	b.WriteString("\nstream\n")
+	b.Write(data)
+	b.WriteString("\nendstream")
+	ref := pdfRef{num: w.next}
+	w.next++
+	w.objects[ref.num] = b.Bytes()
+	return ref
+}
+
+// addImage adds the image p, with a soft mask of its transparency.
+func (w *pdfUpdate) addImage(p *picture) (pdfRef, error) {
+	rgb, alpha, err := p.samples()
+	if err != nil {
+		return pdfRef{}, err
+	}
+	dict := pdfDict{
+		"Type":             pdfName("XObject"),
+		"Subtype":          pdfName("Image"),
+		"Width":            int64(p.width),
+		"Height":           int64(p.height),
+		"ColorSpace":       pdfName("DeviceRGB"),
+		"BitsPerComponent": int64(8),
+		"Filter":           pdfName("FlateDecode"),
+	}
+	if alpha != nil {
+		mask := pdfDict{}
+		for k, v := range dict {
+			mask[k] = v
+		}
+		mask["ColorSpace"] = pdfName("DeviceGray")
+		data, err := deflate(alpha)
+		if err != nil {
+			return pdfRef{}, err
+		}
+		dict["SMask"] = w.addStream(mask, data)
+	}
+	data, err := deflate(rgb)
+	if err != nil {
+		return pdfRef{}, err
+	}
+	return w.addStream(dict, data), nil
+}
+
+func deflate(data []byte) ([]byte, error) {
+	var b bytes.Buffer
+	zw := zlib.NewWriter(&b)
+	if _, err := zw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// bytes returns the document followed by the update. The update ends with
+// a cross-reference table, or a stream when the document uses streams.
+// Documents whose cross-reference was rebuilt get a complete one.
+func (w *pdfUpdate) bytes() []byte {
+	var b bytes.Buffer
+	b.Write(w.r.data)
+	if !bytes.HasSuffix(w.r.data, []byte("\n")) {
+		b.WriteByte('\n')
+	}
+
+	offsets := make(map[int]int, len(w.objects))
+	nums := make([]int, 0, len(w.objects))
+	for num := range w.objects {
+		nums = append(nums, num)
+	}
+	slices.Sort(nums)
+	for _, num := range nums {
+		offsets[num] = b.Len()
+		fmt.Fprintf(&b, "%d 0 obj\n", num)
+		b.Write(w.objects[num])
+		b.WriteString("\nendobj\n")
+	}
+
+	trailer := pdfDict{"Size": int64(w.next), "Root": w.r.trailer["Root"]}
+	for _, k := range []pdfName{"Info", "ID"} {
+		if v, ok := w.r.trailer[k]; ok {
+			trailer[k] = v
+		}
+	}
+	if w.r.startxref >= 0 {
+		trailer["Prev"] = int64(w.r.startxref)
+	}
+
+	start := b.Len()
+	if w.r.startxref >= 0 && !w.r.xrefStream {
+		b.WriteString("xref\n")
+		for _, run := range runs(nums) {
+			fmt.Fprintf(&b, "%d %d\n", run[0], run[1])
+			for num := run[0]; num < run[0]+run[1]; num++ {
+				fmt.Fprintf(&b, "%010d 00000 n\r\n", offsets[num])
+			}
+		}
+		b.WriteString("trailer\n")
+		writeObject(&b, trailer)
+		fmt.Fprintf(&b, "\nstartxref\n%d\n%%%%EOF\n", start)
+		return b.Bytes()
+	}
+
+	// The cross-reference stream lists itself, and for rebuilt documents
+	// every object of the document.
+	self := w.next
+	offsets[self] = start
+	nums = append(nums, self)
+	entries := make(map[int][]byte, len(nums))
+	for _, num := range nums {
+		entries[num] = xrefStreamEntry(1, offsets[num], 0)
+	}
+	if w.r.startxref < 0 {
+		for num, entry := range w.r.xref {
+			if _, ok := entries[num]; ok || num >= self {
+				continue
+			}
+			switch {
+			case entry.compressed:
+				entries[num] = xrefStreamEntry(2, entry.stream, 0)
+			case entry.offset >= 0:
+				entries[num] = xrefStreamEntry(1, entry.offset, 0)
+			}
+		}
+		entries[0] = xrefStreamEntry(0, 0, 0xffff)
+	}
+	all := make([]int, 0, len(entries))
+	for num := range entries {
+		all = append(all, num)
+	}
+	slices.Sort(all)
+	index := pdfArray{}
+	var data []byte
+	for _, run := range runs(all) {
+		index = append(index, int64(run[0]), int64(run[1]))
+		for num := run[0]; num < run[0]+run[1]; num++ {
+			data = append(data, entries[num]...)
+		}
+	}
+	trailer["Type"] = pdfName("XRef")
+	trailer["Size"] = int64(self + 1)
+	trailer["W"] = pdfArray{int64(1), int64(4), int64(2)}
+	trailer["Index"] = index
+	trailer["Length"] = int64(len(data))
+	fmt.Fprintf(&b, "%d 0 obj\n", self)
+	writeObject(&b, trailer)
+	b.WriteString("\nstream\n")
+	b.Write(data)
+	fmt.Fprintf(&b, "\nendstream\nendobj\nstartxref\n%d\n%%%%EOF\n", start)
+	return b.Bytes()
+}
+
+// xrefStreamEntry encodes an entry of a cross-reference stream of widths
+// 1, 4 and 2.
+func xrefStreamEntry(kind, f2, f3 int) []byte {
+	return []byte{byte(kind), byte(f2 >> 24), byte(f2 >> 16), byte(f2 >> 8), byte(f2), byte(f3 >> 8), byte(f3)}
+}
+
+// runs groups sorted object numbers into runs of consecutive numbers, as
+// first number and count.
+func runs(nums []int) [][2]int {
+	var out [][2]int
+	for _, num := range nums {
+		if n := len(out); n > 0 && out[n-1][0]+out[n-1][1] == num {
+			out[n-1][1]++
+			continue
+		}
+		out = append(out, [2]int{num, 1})
+	}
+	return out
+}
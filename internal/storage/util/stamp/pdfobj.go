@@ -0,0 +1,505 @@
+package stamp
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+)
+
+// PDF objects are read into these types, and numbers into int64 and
+// float64. Streams keep their data encoded.
+type (
+	pdfName   string
+	pdfString []byte
+	pdfArray  []any
+	pdfDict   map[pdfName]any
+	pdfRef    struct{ num, gen int }
+	pdfStream struct {
+		dict pdfDict
+		data []byte
+	}
+	// pdfKeyword is a token other than an object: operators such as obj
+	// and R, and the delimiters of arrays and dictionaries.
+	pdfKeyword string
+)
+
+// maxNesting bounds the depth of arrays and dictionaries read, and of the
+// references followed.
+const maxNesting = 64
+
+// pdfLexer reads the tokens and objects of PDF data from pos.
+type pdfLexer struct {
+	data []byte
+	pos  int
+}
+
+func isSpace(c byte) bool {
+	return c == 0 || c == '\t' || c == '\n' || c == '\f' || c == '\r' || c == ' '
+}
+
+func isDelimiter(c byte) bool {
+	switch c {
+	case '(', ')', '<', '>', '[', ']', '{', '}', '/', '%':
+		return true
+	}
+	return false
+}
+
+// skipSpace skips white space and comments.
+func (l *pdfLexer) skipSpace() {
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		if c == '%' {
+			for l.pos < len(l.data) && l.data[l.pos] != '\n' && l.data[l.pos] != '\r' {
+				l.pos++
+			}
+			continue
+		}
+		if !isSpace(c) {
+			return
+		}
+		l.pos++
+	}
+}
+
+// token reads the next token.
+func (l *pdfLexer) token() (any, error) {
+	l.skipSpace()
+	if l.pos >= len(l.data) {
+		return nil, io.ErrUnexpectedEOF
+	}
+	c := l.data[l.pos]
+	switch c {
+	case '(':
+		return l.literalString()
+	case '<':
+		if l.pos+1 < len(l.data) && l.data[l.pos+1] == '<' {
+			l.pos += 2
+			return pdfKeyword("<<"), nil
+		}
+		return l.hexString()
+	case '>':
+		if l.pos+1 < len(l.data) && l.data[l.pos+1] == '>' {
+			l.pos += 2
+			return pdfKeyword(">>"), nil
+		}
+		return nil, fmt.Errorf("unexpected > at %d", l.pos)
+	case '[', ']', '{', '}':
+		l.pos++
+		return pdfKeyword(c), nil
+	case '/':
+		return l.name(), nil
+	case ')':
+		return nil, fmt.Errorf("unexpected ) at %d", l.pos)
+	}
+
+	start := l.pos
+	for l.pos < len(l.data) && !isSpace(l.data[l.pos]) && !isDelimiter(l.data[l.pos]) {
+		l.pos++
+	}
+	word := string(l.data[start:l.pos])
+	if n, err := strconv.ParseInt(word, 10, 64); err == nil {
+		return n, nil
+	}
+	if c == '+' || c == '-' || c == '.' || (c >= '0' && c <= '9') {
+		if f, err := strconv.ParseFloat(word, 64); err == nil {
+			return f, nil
+		}
+	}
+	return pdfKeyword(word), nil
+}
+
+func (l *pdfLexer) name() pdfName {
+	l.pos++
+	var b []byte
+	for l.pos < len(l.data) && !isSpace(l.data[l.pos]) && !isDelimiter(l.data[l.pos]) {
+		c := l.data[l.pos]
+		if c == '#' && l.pos+2 < len(l.data) {
+			if v, err := strconv.ParseUint(string(l.data[l.pos+1:l.pos+3]), 16, 8); err == nil {
+				b = append(b, byte(v))
+				l.pos += 3
+				continue
+			}
+		}
+		b = append(b, c)
+		l.pos++
+	}
+	return pdfName(b)
+}
+
+func (l *pdfLexer) literalString() (pdfString, error) {
+	l.pos++
+	var b []byte
+	depth := 1
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		l.pos++
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			if depth--; depth == 0 {
+				return b, nil
+			}
+		case '\\':
+			if l.pos >= len(l.data) {
+				continue
+			}
+			e := l.data[l.pos]
+			l.pos++
+			switch e {
+			case 'n':
+				c = '\n'
+			case 'r':
+				c = '\r'
+			case 't':
+				c = '\t'
+			case 'b':
+				c = '\b'
+			case 'f':
+				c = '\f'
+			case '\r':
+				if l.pos < len(l.data) && l.data[l.pos] == '\n' {
+					l.pos++
+				}
+				continue
+			case '\n':
+				continue
+			default:
+				if e >= '0' && e <= '7' {
+					v := int(e - '0')
+					for i := 0; i < 2 && l.pos < len(l.data) && l.data[l.pos] >= '0' && l.data[l.pos] <= '7'; i++ {
+						v = v*8 + int(l.data[l.pos]-'0')
+						l.pos++
+					}
+					c = byte(v)
+				} else {
+					c = e
+				}
+			}
+		}
+		b = append(b, c)
+	}
+	return nil, errors.New("unterminated string")
+}
+
+func (l *pdfLexer) hexString() (pdfString, error) {
+	l.pos++
+	var digits []byte
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		l.pos++
+		if c == '>' {
+			if len(digits)%2 == 1 {
+				digits = append(digits, '0')
+			}
+			out := make([]byte, len(digits)/2)
+			if _, err := hex.Decode(out, digits); err != nil {
+				return nil, err
+			}
+			return out, nil
+		}
+		if !isSpace(c) {
+			digits = append(digits, c)
+		}
+	}
+	return nil, errors.New("unterminated hex string")
+}
+
+// object reads the next object. Streams are read by indirectObject.
+func (l *pdfLexer) object(depth int) (any, error) {
+	if depth > maxNesting {
+		return nil, errors.New("objects nested too deeply")
+	}
+	token, err := l.token()
+	if err != nil {
+		return nil, err
+	}
+	switch t := token.(type) {
+	case int64:
+		// Two integers followed by R make a reference.
+		save := l.pos
+		if gen, err := l.token(); err == nil {
+			if gen, ok := gen.(int64); ok {
+				if r, err := l.token(); err == nil && r == pdfKeyword("R") {
+					return pdfRef{num: int(t), gen: int(gen)}, nil
+				}
+			}
+		}
+		l.pos = save
+		return t, nil
+	case pdfKeyword:
+		switch t {
+		case "[":
+			array := pdfArray{}
+			for {
+				l.skipSpace()
+				if l.pos < len(l.data) && l.data[l.pos] == ']' {
+					l.pos++
+					return array, nil
+				}
+				item, err := l.object(depth + 1)
+				if err != nil {
+					return nil, err
+				}
+				array = append(array, item)
+			}
+		case "<<":
+			dict := pdfDict{}
+			for {
+				key, err := l.token()
+				if err != nil {
+					return nil, err
+				}
+				if key == pdfKeyword(">>") {
+					return dict, nil
+				}
+				name, ok := key.(pdfName)
+				if !ok {
+					return nil, fmt.Errorf("dictionary key %v is not a name", key)
+				}
+				value, err := l.object(depth + 1)
+				if err != nil {
+					return nil, err
+				}
+				dict[name] = value
+			}
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		case "null":
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unexpected %q", string(t))
+	}
+	return token, nil
+}
+
+// indirectObject reads the object defined at pos, "num gen obj ... endobj".
+// length resolves the length of streams given by reference.
+func (l *pdfLexer) indirectObject(length func(any) (int, bool)) (int, any, error) {
+	num, err := l.token()
+	if err != nil {
+		return 0, nil, err
+	}
+	gen, err := l.token()
+	if err != nil {
+		return 0, nil, err
+	}
+	keyword, err := l.token()
+	if err != nil {
+		return 0, nil, err
+	}
+	n, ok := num.(int64)
+	if _, isInt := gen.(int64); !ok || !isInt || keyword != pdfKeyword("obj") {
+		return 0, nil, errors.New("missing object header")
+	}
+	value, err := l.object(0)
+	if err != nil {
+		return 0, nil, err
+	}
+	dict, ok := value.(pdfDict)
+	if !ok {
+		return int(n), value, nil
+	}
+	save := l.pos
+	if keyword, err := l.token(); err != nil || keyword != pdfKeyword("stream") {
+		l.pos = save
+		return int(n), value, nil
+	}
+
+	// The data starts after the end of the line of the keyword.
+	if l.pos < len(l.data) && l.data[l.pos] == '\r' {
+		l.pos++
+	}
+	if l.pos < len(l.data) && l.data[l.pos] == '\n' {
+		l.pos++
+	}
+	start := l.pos
+	if size, ok := length(dict["Length"]); ok && size >= 0 && start+size <= len(l.data) {
+		rest := pdfLexer{data: l.data, pos: start + size}
+		if keyword, err := rest.token(); err == nil && keyword == pdfKeyword("endstream") {
+			l.pos = rest.pos
+			return int(n), &pdfStream{dict: dict, data: l.data[start : start+size]}, nil
+		}
+	}
+	// Lengths are often wrong, the data then ends before endstream.
+	end := bytes.Index(l.data[start:], []byte("endstream"))
+	if end < 0 {
+		return 0, nil, errors.New("unterminated stream")
+	}
+	data := bytes.TrimRight(l.data[start:start+end], "\r\n")
+	l.pos = start + end + len("endstream")
+	return int(n), &pdfStream{dict: dict, data: data}, nil
+}
+
+// writeObject writes v in PDF syntax. Dictionary keys are sorted so that
+// the output does not vary.
+func writeObject(b *bytes.Buffer, v any) {
+	switch v := v.(type) {
+	case nil:
+		b.WriteString("null")
+	case bool:
+		b.WriteString(strconv.FormatBool(v))
+	case int:
+		b.WriteString(strconv.Itoa(v))
+	case int64:
+		b.WriteString(strconv.FormatInt(v, 10))
+	case float64:
+		b.WriteString(strconv.FormatFloat(v, 'f', -1, 64))
+	case pdfName:
+		writeName(b, v)
+	case pdfString:
+		b.WriteByte('<')
+		b.WriteString(hex.EncodeToString(v))
+		b.WriteByte('>')
+	case pdfRef:
+		fmt.Fprintf(b, "%d %d R", v.num, v.gen)
+	case pdfArray:
+		b.WriteByte('[')
+		for i, item := range v {
+			if i > 0 {
+				b.WriteByte(' ')
+			}
+			writeObject(b, item)
+		}
+		b.WriteByte(']')
+	case pdfDict:
+		keys := make([]pdfName, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+		b.WriteString("<<")
+		for _, k := range keys {
+			writeName(b, k)
+			b.WriteByte(' ')
+			writeObject(b, v[k])
+		}
+		b.WriteString(">>")
+	}
+}
+
+func writeName(b *bytes.Buffer, name pdfName) {
+	b.WriteByte('/')
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if c <= ' ' || c > '~' || c == '#' || isDelimiter(c) {
+			fmt.Fprintf(b, "#%02X", c)
+			continue
+		}
+		b.WriteByte(c)
+	}
+}
+
+// decodeFlate inflates data, undoing the PNG predictors params sets. Data
+// cut short is kept as far as it goes, as readers do.
+func decodeFlate(data []byte, params pdfDict) ([]byte, error) {
+	zr, err := zlib.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	out, err := io.ReadAll(io.LimitReader(zr, MaxSize+1))
+	if err != nil && len(out) == 0 {
+		return nil, err
+	}
+	if len(out) > MaxSize {
+		return nil, ErrDocumentTooLarge
+	}
+
+	predictor := intValue(params["Predictor"], 1)
+	if predictor < 10 {
+		if predictor != 1 {
+			return nil, fmt.Errorf("unsupported predictor %d", predictor)
+		}
+		return out, nil
+	}
+	colors := intValue(params["Colors"], 1)
+	bits := intValue(params["BitsPerComponent"], 8)
+	columns := intValue(params["Columns"], 1)
+	if colors < 1 || colors > 32 || bits < 1 || bits > 16 || columns < 1 || columns > MaxSize {
+		return nil, errors.New("invalid predictor parameters")
+	}
+	return unpredictPNG(out, (colors*bits*columns+7)/8, max(1, (colors*bits+7)/8))
+}
+
+// unpredictPNG undoes the PNG filters of rows of rowLen bytes, each preceded
+// by its filter type, of pixels of bpp bytes.
+func unpredictPNG(data []byte, rowLen, bpp int) ([]byte, error) {
+	out := make([]byte, 0, len(data))
+	prev := make([]byte, rowLen)
+	for len(data) > rowLen {
+		filter, row := data[0], slices.Clone(data[1:1+rowLen])
+		data = data[1+rowLen:]
+		for i := range row {
+			var left, upLeft byte
+			if i >= bpp {
+				left, upLeft = row[i-bpp], prev[i-bpp]
+			}
+			up := prev[i]
+			switch filter {
+			case 0:
+			case 1:
+				row[i] += left
+			case 2:
+				row[i] += up
+			case 3:
+				row[i] += byte((int(left) + int(up)) / 2)
+			case 4:
+				row[i] += paeth(left, up, upLeft)
+			default:
+				return nil, fmt.Errorf("unknown PNG filter %d", filter)
+			}
+		}
+		out = append(out, row...)
+		prev = row
+	}
+	return out, nil
+}
+
+func paeth(a, b, c byte) byte {
+	p := int(a) + int(b) - int(c)
+	pa, pb, pc := abs(p-int(a)), abs(p-int(b)), abs(p-int(c))
+	switch {
+	case pa <= pb && pa <= pc:
+		return a
+	case pb <= pc:
+		return b
+	}
+	return c
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// intValue returns v as an integer, or def when it is not a number.
+func intValue(v any, def int) int {
+	switch v := v.(type) {
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	}
+	return def
+}
+
+// floatValue returns v as a number.
+func floatValue(v any) (float64, bool) {
+	switch v := v.(type) {
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
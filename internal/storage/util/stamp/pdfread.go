@@ -0,0 +1,434 @@
+package stamp
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+)
+
+// xrefEntry locates an object: at offset in the file, or in the object
+// stream stream, whose header gives its place.
+type xrefEntry struct {
+	offset     int
+	stream     int
+	compressed bool
+}
+
+// pdfReader reads the objects of a PDF document.
+type pdfReader struct {
+	data    []byte
+	xref    map[int]xrefEntry
+	trailer pdfDict
+	// startxref is the offset of the last cross-reference section, which
+	// is a stream when xrefStream is set. It is -1 for documents whose
+	// cross-reference was rebuilt by scanning them.
+	startxref  int
+	xrefStream bool
+	objects    map[int]any
+	streams    map[int]*objectStream
+}
+
+// objectStream is a decoded object stream, holding the offsets of its
+// objects by object number.
+type objectStream struct {
+	data    []byte
+	offsets map[int]int
+}
+
+// openPDF reads the cross-reference of the PDF document data, rebuilding it
+// when it cannot be read.
+func openPDF(data []byte) (*pdfReader, error) {
+	// Readers accept junk before the header within the first kilobyte.
+	if !bytes.Contains(data[:min(len(data), 1024)], []byte("%PDF-")) {
+		return nil, fmt.Errorf("%w: not a PDF document", ErrMalformedDocument)
+	}
+	r := &pdfReader{data: data, objects: make(map[int]any), streams: make(map[int]*objectStream)}
+	if err := r.readXref(); err != nil {
+		if err := r.rebuildXref(); err != nil {
+			return nil, err
+		}
+	}
+	if _, ok := r.trailer["Encrypt"]; ok {
+		return nil, ErrEncryptedDocument
+	}
+	return r, nil
+}
+
+// readXref reads the cross-reference sections from the last one back.
+// Entries of later sections win over those of the sections they update.
+func (r *pdfReader) readXref() error {
+	i := bytes.LastIndex(r.data, []byte("startxref"))
+	if i < 0 {
+		return errors.New("missing startxref")
+	}
+	l := &pdfLexer{data: r.data, pos: i + len("startxref")}
+	token, err := l.token()
+	offset, ok := token.(int64)
+	if err != nil || !ok || offset < 0 || int(offset) >= len(r.data) {
+		return errors.New("invalid startxref")
+	}
+	r.startxref = int(offset)
+	r.xref = make(map[int]xrefEntry)
+
+	seen := make(map[int]bool)
+	for next := r.startxref; next >= 0; {
+		if seen[next] || next >= len(r.data) {
+			return errors.New("invalid cross-reference offset")
+		}
+		seen[next] = true
+		trailer, stream, err := r.readSection(next)
+		if err != nil {
+			return err
+		}
+		if r.trailer == nil {
+			r.trailer, r.xrefStream = trailer, stream
+		}
+		// Hybrid files hold the entries of objects in object streams in
+		// a stream only readers of such streams read.
+		if at, ok := trailer["XRefStm"].(int64); ok && !seen[int(at)] {
+			seen[int(at)] = true
+			if _, _, err := r.readSection(int(at)); err != nil {
+				return err
+			}
+		}
+		next = -1
+		if prev, ok := trailer["Prev"].(int64); ok {
+			next = int(prev)
+		}
+	}
+	if _, ok := r.trailer["Root"].(pdfRef); !ok {
+		return errors.New("missing document catalog")
+	}
+	return nil
+}
+
+// readSection reads the cross-reference section at offset, a table or a
+// stream, and returns its trailer.
+func (r *pdfReader) readSection(offset int) (pdfDict, bool, error) {
+	l := &pdfLexer{data: r.data, pos: offset}
+	l.skipSpace()
+	if !bytes.HasPrefix(r.data[l.pos:], []byte("xref")) {
+		trailer, err := r.readXrefStream(l)
+		return trailer, true, err
+	}
+	l.pos += len("xref")
+	for {
+		token, err := l.token()
+		if err != nil {
+			return nil, false, err
+		}
+		if token == pdfKeyword("trailer") {
+			break
+		}
+		start, ok := token.(int64)
+		count, err := l.token()
+		n, isInt := count.(int64)
+		if err != nil || !ok || !isInt || start < 0 || n < 0 {
+			return nil, false, errors.New("invalid cross-reference table")
+		}
+		for i := range int(n) {
+			offset, err := l.token()
+			if err != nil {
+				return nil, false, err
+			}
+			if _, err := l.token(); err != nil {
+				return nil, false, err
+			}
+			kind, err := l.token()
+			if err != nil {
+				return nil, false, err
+			}
+			num := int(start) + i
+			if _, ok := r.xref[num]; ok {
+				continue
+			}
+			switch kind {
+			case pdfKeyword("n"):
+				r.xref[num] = xrefEntry{offset: intValue(offset, 0)}
+			case pdfKeyword("f"):
+				r.xref[num] = xrefEntry{offset: -1}
+			default:
+				return nil, false, errors.New("invalid cross-reference entry")
+			}
+		}
+	}
+	trailer, err := l.object(0)
+	if err != nil {
+		return nil, false, err
+	}
+	dict, ok := trailer.(pdfDict)
+	if !ok {
+		return nil, false, errors.New("invalid trailer")
+	}
+	return dict, false, nil
+}
+
+// readXrefStream reads the cross-reference stream at the position of l.
+func (r *pdfReader) readXrefStream(l *pdfLexer) (pdfDict, error) {
+	_, object, err := l.indirectObject(r.length)
+	if err != nil {
+		return nil, err
+	}
+	stream, ok := object.(*pdfStream)
+	if !ok || stream.dict["Type"] != pdfName("XRef") {
+		return nil, errors.New("invalid cross-reference stream")
+	}
+	data, err := r.decode(stream)
+	if err != nil {
+		return nil, err
+	}
+
+	widths, _ := stream.dict["W"].(pdfArray)
+	if len(widths) != 3 {
+		return nil, errors.New("invalid cross-reference stream widths")
+	}
+	var w [3]int
+	for i := range w {
+		if w[i] = intValue(widths[i], -1); w[i] < 0 || w[i] > 8 {
+			return nil, errors.New("invalid cross-reference stream widths")
+		}
+	}
+	index, _ := stream.dict["Index"].(pdfArray)
+	if index == nil {
+		index = pdfArray{int64(0), stream.dict["Size"]}
+	}
+	size := w[0] + w[1] + w[2]
+	for i := 0; i+1 < len(index); i += 2 {
+		start, count := intValue(index[i], -1), intValue(index[i+1], -1)
+		if start < 0 || count < 0 {
+			return nil, errors.New("invalid cross-reference stream index")
+		}
+		for j := range count {
+			if len(data) < size {
+				return stream.dict, nil
+			}
+			kind := field(data[:w[0]], 1)
+			f2 := field(data[w[0]:w[0]+w[1]], 0)
+			data = data[size:]
+			num := start + j
+			if _, ok := r.xref[num]; ok {
+				continue
+			}
+			switch kind {
+			case 0:
+				r.xref[num] = xrefEntry{offset: -1}
+			case 1:
+				r.xref[num] = xrefEntry{offset: f2}
+			case 2:
+				r.xref[num] = xrefEntry{stream: f2, compressed: true}
+			}
+		}
+	}
+	return stream.dict, nil
+}
+
+// field reads a big-endian field of a cross-reference stream, def when it
+// is absent.
+func field(b []byte, def int) int {
+	if len(b) == 0 {
+		return def
+	}
+	v := 0
+	for _, c := range b {
+		v = v<<8 | int(c)
+	}
+	return v
+}
+
+// objectHeader finds the definitions of objects.
+var objectHeader = regexp.MustCompile(`(?:^|[\r\n\t\f ])(\d+)[\t\f\r\n ]+(\d+)[\t\f\r\n ]+obj\b`)
+
+// rebuildXref finds the objects of a document whose cross-reference is
+// damaged by scanning it, as readers do. The last definition of an object
+// wins, as it would in an update.
+func (r *pdfReader) rebuildXref() error {
+	r.xref = make(map[int]xrefEntry)
+	r.trailer = nil
+	r.startxref, r.xrefStream = -1, false
+	r.objects = make(map[int]any)
+	r.streams = make(map[int]*objectStream)
+
+	for _, m := range objectHeader.FindAllSubmatchIndex(r.data, -1) {
+		num, err := strconv.Atoi(string(r.data[m[2]:m[3]]))
+		if err != nil {
+			continue
+		}
+		r.xref[num] = xrefEntry{offset: m[2]}
+	}
+	// Objects in object streams are only found through the streams.
+	var streams []int
+	for num := range r.xref {
+		if stream, ok := r.get(num).(*pdfStream); ok && stream.dict["Type"] == pdfName("ObjStm") {
+			streams = append(streams, num)
+		}
+	}
+	slices.Sort(streams)
+	for _, num := range streams {
+		objects, err := r.objectStream(num)
+		if err != nil {
+			continue
+		}
+		for n := range objects.offsets {
+			if _, ok := r.xref[n]; !ok {
+				r.xref[n] = xrefEntry{stream: num, compressed: true}
+			}
+		}
+	}
+
+	if i := bytes.LastIndex(r.data, []byte("trailer")); i >= 0 {
+		l := &pdfLexer{data: r.data, pos: i + len("trailer")}
+		if trailer, err := l.object(0); err == nil {
+			r.trailer, _ = trailer.(pdfDict)
+		}
+	}
+	if r.trailer == nil {
+		r.trailer = pdfDict{}
+	}
+	if _, ok := r.trailer["Root"].(pdfRef); !ok {
+		for num := range r.xref {
+			if dict, ok := r.get(num).(pdfDict); ok && dict["Type"] == pdfName("Catalog") {
+				r.trailer["Root"] = pdfRef{num: num}
+				break
+			}
+		}
+	}
+	if _, ok := r.trailer["Root"].(pdfRef); !ok {
+		return fmt.Errorf("%w: missing document catalog", ErrMalformedDocument)
+	}
+	return nil
+}
+
+// size returns the number of the first object free for new objects.
+func (r *pdfReader) size() int {
+	size := intValue(r.trailer["Size"], 0)
+	for num := range r.xref {
+		size = max(size, num+1)
+	}
+	return size
+}
+
+// get returns the object num, nil when it is missing or cannot be read.
+func (r *pdfReader) get(num int) any {
+	if object, ok := r.objects[num]; ok {
+		return object
+	}
+	// Marking the object first stops cycles through stream lengths.
+	r.objects[num] = nil
+	entry, ok := r.xref[num]
+	if !ok || (!entry.compressed && (entry.offset < 0 || entry.offset >= len(r.data))) {
+		return nil
+	}
+
+	var object any
+	if entry.compressed {
+		stream, err := r.objectStream(entry.stream)
+		if err != nil {
+			return nil
+		}
+		offset, ok := stream.offsets[num]
+		if !ok {
+			return nil
+		}
+		l := &pdfLexer{data: stream.data, pos: offset}
+		if object, err = l.object(0); err != nil {
+			return nil
+		}
+	} else {
+		l := &pdfLexer{data: r.data, pos: entry.offset}
+		n, value, err := l.indirectObject(r.length)
+		if err != nil || n != num {
+			return nil
+		}
+		object = value
+	}
+	r.objects[num] = object
+	return object
+}
+
+// objectStream decodes the object stream num.
+func (r *pdfReader) objectStream(num int) (*objectStream, error) {
+	if stream, ok := r.streams[num]; ok {
+		return stream, nil
+	}
+	stream, ok := r.get(num).(*pdfStream)
+	if !ok {
+		return nil, errors.New("missing object stream")
+	}
+	data, err := r.decode(stream)
+	if err != nil {
+		return nil, err
+	}
+	first := intValue(stream.dict["First"], -1)
+	n := intValue(stream.dict["N"], -1)
+	if first < 0 || first > len(data) || n < 0 {
+		return nil, errors.New("invalid object stream")
+	}
+	objects := &objectStream{data: data, offsets: make(map[int]int, n)}
+	l := &pdfLexer{data: data[:first]}
+	for range n {
+		num, err := l.token()
+		if err != nil {
+			break
+		}
+		offset, err := l.token()
+		if err != nil {
+			break
+		}
+		objects.offsets[intValue(num, -1)] = first + intValue(offset, 0)
+	}
+	r.streams[num] = objects
+	return objects, nil
+}
+
+// resolve follows references from v to an object.
+func (r *pdfReader) resolve(v any) any {
+	for range maxNesting {
+		ref, ok := v.(pdfRef)
+		if !ok {
+			return v
+		}
+		v = r.get(ref.num)
+	}
+	return nil
+}
+
+// dict resolves v to a dictionary, nil when it is not one.
+func (r *pdfReader) dict(v any) pdfDict {
+	dict, _ := r.resolve(v).(pdfDict)
+	return dict
+}
+
+// length resolves the length of a stream.
+func (r *pdfReader) length(v any) (int, bool) {
+	switch v := r.resolve(v).(type) {
+	case int64:
+		return int(v), true
+	}
+	return 0, false
+}
+
+// decode returns the data of stream. Only Flate is supported, which the
+// cross-reference and object streams read here use.
+func (r *pdfReader) decode(stream *pdfStream) ([]byte, error) {
+	var filters pdfArray
+	switch f := r.resolve(stream.dict["Filter"]).(type) {
+	case pdfName:
+		filters = pdfArray{f}
+	case pdfArray:
+		filters = f
+	}
+	if len(filters) == 0 {
+		return stream.data, nil
+	}
+	if len(filters) > 1 || r.resolve(filters[0]) != pdfName("FlateDecode") {
+		return nil, fmt.Errorf("unsupported filter %v", filters)
+	}
+	params := r.dict(stream.dict["DecodeParms"])
+	if array, ok := r.resolve(stream.dict["DecodeParms"]).(pdfArray); ok && len(array) > 0 {
+		params = r.dict(array[0])
+	}
+	return decodeFlate(stream.data, params)
+}
@@ -0,0 +1,117 @@
+// Package stamp draws watermarks, headers and footers on the pages of DOCX
+// and PDF documents.
+package stamp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/a1y/doc-formatter/internal/storage/util/textextract"
+)
+
+// dateLayout is how {date} is written, in UTC.
+const dateLayout = "2006-01-02 15:04 UTC"
+
+// Supported reports whether documents of contentType can be stamped.
+func Supported(contentType string) bool {
+	return contentType == textextract.TypeDOCX || contentType == TypePDF
+}
+
+// Empty reports whether s draws nothing.
+func (s Stamp) Empty() bool {
+	return watermarkText(s.Watermark) == "" && len(s.Image) == 0 && len(textLines(s.Header)) == 0 && len(textLines(s.Footer)) == 0
+}
+
+// Validate checks that s can be applied. The image is only checked to be a
+// PNG or JPEG image of a supported size.
+func (s Stamp) Validate() error {
+	for _, text := range []string{s.Watermark, s.Header, s.Footer} {
+		if len(text) > MaxTextLength {
+			return fmt.Errorf("%w: text must be at most %d bytes", ErrInvalidStamp, MaxTextLength)
+		}
+	}
+	if s.Opacity < 0 || s.Opacity > 1 {
+		return fmt.Errorf("%w: opacity must be between 0 and 1", ErrInvalidStamp)
+	}
+	if len(s.Image) > 0 {
+		if _, err := checkImage(s.Image); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Expand returns s with the placeholders {name}, {owner}, {user}, {version}
+// and {date} of its text replaced by v. Other braces are left as they are.
+func (s Stamp) Expand(v Values) Stamp {
+	r := strings.NewReplacer(
+		"{name}", v.Name,
+		"{owner}", v.Owner,
+		"{user}", v.User,
+		"{version}", v.Version,
+		"{date}", v.Date.UTC().Format(dateLayout),
+	)
+	s.Watermark = r.Replace(s.Watermark)
+	s.Header = r.Replace(s.Header)
+	s.Footer = r.Replace(s.Footer)
+	return s
+}
+
+// Apply draws s on every page of data, a document of contentType, and
+// returns the stamped document. Placeholders are not filled, see Expand.
+func Apply(contentType string, data []byte, s Stamp) (stamped []byte, err error) {
+	// Documents come from users, so one that trips up the DOCX or PDF code
+	// is reported as malformed rather than taking the service down.
+	defer func() {
+		if r := recover(); r != nil {
+			stamped, err = nil, fmt.Errorf("%w: %v", ErrMalformedDocument, r)
+		}
+	}()
+	return apply(contentType, data, s)
+}
+
+// apply is Apply without the recovery from panics, so that fuzzing finds
+// them.
+func apply(contentType string, data []byte, s Stamp) ([]byte, error) {
+	if !Supported(contentType) {
+		return nil, ErrUnsupportedFormat
+	}
+	if len(data) > MaxSize {
+		return nil, ErrDocumentTooLarge
+	}
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	if s.Empty() {
+		return data, nil
+	}
+	if s.Opacity == 0 {
+		s.Opacity = DefaultOpacity
+	}
+
+	if contentType == textextract.TypeDOCX {
+		return stampDOCX(data, s)
+	}
+	return stampPDF(data, s)
+}
+
+// watermarkText returns the watermark text on a single line.
+func watermarkText(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// textLines splits header or footer text into its lines, leaving out those
+// at the ends that are blank.
+func textLines(text string) []string {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	return lines
+}
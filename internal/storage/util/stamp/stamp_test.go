@@ -0,0 +1,324 @@
+package stamp
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/a1y/doc-formatter/internal/storage/util/textextract"
+)
+
+const testNamespaces = `xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"`
+
+// testDOCX returns a DOCX document whose body ends with sectPr and holds
+// files besides its main part.
+func testDOCX(t testing.TB, sectPr, rels string, files map[string]string) []byte {
+	t.Helper()
+
+	all := map[string]string{
+		"[Content_Types].xml": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/word/document.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"/></Types>`,
+		"_rels/.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="word/document.xml"/></Relationships>`,
+		"word/document.xml": `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:document ` + testNamespaces + `><w:body><w:p><w:r><w:t>Body</w:t></w:r></w:p>` + sectPr + `</w:body></w:document>`,
+		"word/_rels/document.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` + rels + `</Relationships>`,
+	}
+	for name, content := range files {
+		all[name] = content
+	}
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, content := range all {
+		f, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+// readZip returns the files of a ZIP archive by name.
+func readZip(t *testing.T, data []byte) map[string]string {
+	t.Helper()
+
+	r, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+	files := make(map[string]string)
+	for _, f := range r.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		require.NoError(t, rc.Close())
+		files[f.Name] = string(content)
+	}
+	return files
+}
+
+func testPNG(t testing.TB, alpha uint8) []byte {
+	t.Helper()
+
+	img := image.NewNRGBA(image.Rect(0, 0, 4, 2))
+	for x := 0; x < 4; x++ {
+		for y := 0; y < 2; y++ {
+			img.Set(x, y, color.NRGBA{R: 200, A: alpha})
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestApply_DOCX(t *testing.T) {
+	t.Run("adds headers and footers", func(t *testing.T) {
+		data := testDOCX(t, `<w:sectPr><w:pgSz w:w="11906" w:h="16838"/><w:titlePg/></w:sectPr>`, "", nil)
+
+		out, err := Apply(textextract.TypeDOCX, data, Stamp{Watermark: "CONFIDENTIAL", Header: "Copy for ann", Footer: "Page\nfooter"})
+		require.NoError(t, err)
+
+		files := readZip(t, out)
+		main := files["word/document.xml"]
+		require.Contains(t, main, `w:type="default"`)
+		require.Contains(t, main, `w:type="first"`)
+		require.Equal(t, 2, strings.Count(main, "<w:headerReference"))
+		require.Equal(t, 2, strings.Count(main, "<w:footerReference"))
+		require.Less(t, strings.Index(main, "<w:headerReference"), strings.Index(main, "<w:pgSz"))
+
+		require.Contains(t, files["word/hdr1.xml"], "CONFIDENTIAL")
+		require.Contains(t, files["word/hdr1.xml"], "Copy for ann")
+		require.Contains(t, files["word/hdr1.xml"], `xmlns:v="urn:schemas-microsoft-com:vml"`)
+		require.Contains(t, files["word/ftr1.xml"], "<w:br/>")
+		require.NotContains(t, files["word/ftr1.xml"], "CONFIDENTIAL")
+		require.Contains(t, files["[Content_Types].xml"], `PartName="/word/hdr1.xml"`)
+		require.Contains(t, files["[Content_Types].xml"], `PartName="/word/ftr1.xml"`)
+		require.Equal(t, 4, strings.Count(files["word/_rels/document.xml.rels"], "<Relationship "))
+	})
+
+	t.Run("stamps existing headers", func(t *testing.T) {
+		data := testDOCX(t,
+			`<w:sectPr><w:headerReference w:type="default" r:id="rIdHeader"/></w:sectPr>`,
+			`<Relationship Id="rIdHeader" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/header" Target="header1.xml"/>`,
+			map[string]string{"word/header1.xml": `<w:hdr ` + testNamespaces + `><w:p><w:r><w:t>Letterhead</w:t></w:r></w:p></w:hdr>`},
+		)
+
+		out, err := Apply(textextract.TypeDOCX, data, Stamp{Watermark: "DRAFT", Image: testPNG(t, 0xff)})
+		require.NoError(t, err)
+
+		files := readZip(t, out)
+		require.Equal(t, 1, strings.Count(files["word/document.xml"], "<w:headerReference"))
+		header := files["word/header1.xml"]
+		require.Contains(t, header, "Letterhead")
+		require.Contains(t, header, "DRAFT")
+		require.Contains(t, header, "<v:imagedata")
+		require.Contains(t, files["word/_rels/header1.xml.rels"], "media/stamp.png")
+		require.Contains(t, files, "word/media/stamp.png")
+		require.NotContains(t, files, "word/hdr1.xml")
+	})
+}
+
+// testPDF returns a PDF document of objects, numbered from 1, with a
+// cross-reference table or stream.
+func testPDF(objects []string, xrefStream bool) []byte {
+	var b bytes.Buffer
+	b.WriteString("%PDF-1.7\n")
+	offsets := make([]int, len(objects)+1)
+	for i, object := range objects {
+		offsets[i+1] = b.Len()
+		fmt.Fprintf(&b, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+	start := b.Len()
+	if !xrefStream {
+		fmt.Fprintf(&b, "xref\n0 %d\n0000000000 65535 f\r\n", len(objects)+1)
+		for _, offset := range offsets[1:] {
+			fmt.Fprintf(&b, "%010d 00000 n\r\n", offset)
+		}
+		fmt.Fprintf(&b, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, start)
+		return b.Bytes()
+	}
+	self := len(objects) + 1
+	var data []byte
+	data = append(data, xrefStreamEntry(0, 0, 0xffff)...)
+	for _, offset := range offsets[1:] {
+		data = append(data, xrefStreamEntry(1, offset, 0)...)
+	}
+	data = append(data, xrefStreamEntry(1, start, 0)...)
+	fmt.Fprintf(&b, "%d 0 obj\n<< /Type /XRef /Size %d /W [1 4 2] /Root 1 0 R /Length %d >>\nstream\n", self, self+1, len(data))
+	b.Write(data)
+	fmt.Fprintf(&b, "\nendstream\nendobj\nstartxref\n%d\n%%%%EOF\n", start)
+	return b.Bytes()
+}
+
+var testPDFObjects = []string{
+	"<< /Type /Catalog /Pages 2 0 R >>",
+	"<< /Type /Pages /Kids [3 0 R 4 0 R] /Count 2 /MediaBox [0 0 595 842] /Resources << /Font << /F1 5 0 R >> >> >>",
+	"<< /Type /Page /Parent 2 0 R /Contents 6 0 R >>",
+	"<< /Type /Page /Parent 2 0 R /Contents [6 0 R] /Rotate 90 >>",
+	"<< /Type /Font /Subtype /Type1 /BaseFont /Times-Roman >>",
+	"<< /Length 35 >>\nstream\nBT /F1 12 Tf 72 720 Td (Hi) Tj ET\nendstream",
+}
+
+// stampedPages reopens a stamped PDF document and returns its pages with
+// the content of their overlays.
+func stampedPages(t *testing.T, data []byte) ([]*pdfPage, []string) {
+	t.Helper()
+
+	r, err := openPDF(data)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, r.startxref, 0)
+	pages, err := r.pages()
+	require.NoError(t, err)
+	var overlays []string
+	for _, page := range pages {
+		contents := page.dict["Contents"].(pdfArray)
+		stream, ok := r.resolve(contents[len(contents)-1]).(*pdfStream)
+		require.True(t, ok)
+		overlays = append(overlays, string(stream.data))
+	}
+	return pages, overlays
+}
+
+func TestApply_PDF(t *testing.T) {
+	for _, xrefStream := range []bool{false, true} {
+		t.Run(fmt.Sprintf("xref stream %v", xrefStream), func(t *testing.T) {
+			data := testPDF(testPDFObjects, xrefStream)
+
+			out, err := Apply(TypePDF, data, Stamp{Watermark: "CONFIDENTIAL", Header: "Copy for ann", Footer: "Footer", Image: testPNG(t, 0x80)})
+			require.NoError(t, err)
+			require.True(t, bytes.HasPrefix(out, data))
+
+			pages, overlays := stampedPages(t, out)
+			require.Len(t, pages, 2)
+			for i, page := range pages {
+				contents := page.dict["Contents"].(pdfArray)
+				require.Len(t, contents, 3)
+				require.Equal(t, pdfRef{num: 6}, contents[1])
+
+				resources := page.dict["Resources"].(pdfDict)
+				fonts := resources["Font"].(pdfDict)
+				require.Contains(t, fonts, pdfName("F1"))
+				require.Contains(t, fonts, pdfName("StampFont"))
+				require.Contains(t, resources["XObject"].(pdfDict), pdfName("StampImage"))
+				require.Equal(t, [4]float64{0, 0, 595, 842}, page.box)
+
+				require.True(t, strings.HasPrefix(overlays[i], "Q\nq\n"))
+				require.Contains(t, overlays[i], "<"+hex.EncodeToString([]byte("CONFIDENTIAL"))+">")
+				require.Contains(t, overlays[i], "<"+hex.EncodeToString([]byte("Copy for ann"))+">")
+				require.Contains(t, overlays[i], "/StampImage Do")
+			}
+			require.Contains(t, overlays[0], "1 0 0 1 0 0 cm")
+			require.Contains(t, overlays[1], "0 1 -1 0 595 0 cm")
+
+			// Stamping again adds another revision.
+			again, err := Apply(TypePDF, out, Stamp{Footer: "Again"})
+			require.NoError(t, err)
+			_, overlays = stampedPages(t, again)
+			require.Contains(t, overlays[0], "<"+hex.EncodeToString([]byte("Again"))+">")
+		})
+	}
+
+	t.Run("rebuilds broken cross-references", func(t *testing.T) {
+		data := testPDF(testPDFObjects, false)
+		data = bytes.Replace(data, []byte("startxref\n"), []byte("startxref\n9"), 1)
+
+		out, err := Apply(TypePDF, data, Stamp{Watermark: "DRAFT"})
+		require.NoError(t, err)
+		_, overlays := stampedPages(t, out)
+		require.Len(t, overlays, 2)
+	})
+
+	t.Run("avoids resource names in use", func(t *testing.T) {
+		objects := append([]string(nil), testPDFObjects...)
+		objects[1] = strings.Replace(objects[1], "/F1 5 0 R", "/F1 5 0 R /StampFont 5 0 R", 1)
+
+		out, err := Apply(TypePDF, testPDF(objects, false), Stamp{Watermark: "DRAFT"})
+		require.NoError(t, err)
+		pages, overlays := stampedPages(t, out)
+		require.Contains(t, pages[0].dict["Resources"].(pdfDict)["Font"].(pdfDict), pdfName("StampFont1"))
+		require.Contains(t, overlays[0], "/StampFont1 ")
+	})
+
+	t.Run("rejects encrypted documents", func(t *testing.T) {
+		data := testPDF(testPDFObjects, false)
+		data = bytes.Replace(data, []byte("/Root 1 0 R"), []byte("/Root 1 0 R /Encrypt 5 0 R"), 1)
+
+		_, err := Apply(TypePDF, data, Stamp{Watermark: "DRAFT"})
+		require.ErrorIs(t, err, ErrEncryptedDocument)
+	})
+
+	t.Run("rejects other documents", func(t *testing.T) {
+		_, err := Apply(TypePDF, []byte("not a document"), Stamp{Watermark: "DRAFT"})
+		require.ErrorIs(t, err, ErrMalformedDocument)
+	})
+}
+
+func FuzzApply(f *testing.F) {
+	header := `<Relationship Id="rId9" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/header" Target="header1.xml"/>`
+	f.Add(testDOCX(f, `<w:sectPr><w:pgSz w:w="11906" w:h="16838"/><w:titlePg/></w:sectPr>`, "", nil), true)
+	f.Add(testDOCX(f, `<w:sectPr><w:headerReference w:type="default" r:id="rId9"/></w:sectPr>`, header, map[string]string{
+		"word/header1.xml": `<w:hdr ` + testNamespaces + `><w:p><w:r><w:t>Existing</w:t></w:r></w:p></w:hdr>`,
+	}), true)
+	f.Add(testPDF(testPDFObjects, false), false)
+	f.Add(testPDF(testPDFObjects, true), false)
+	f.Add([]byte("%PDF-1.4\n1 0 obj\n<< /Type /Catalog /Pages 1 0 R >>\nendobj\ntrailer\n<< /Root 1 0 R >>\n%%EOF\n"), false)
+	s := Stamp{Watermark: "CONFIDENTIAL", Header: "Header", Footer: "Footer", Image: testPNG(f, 0x80)}
+
+	f.Fuzz(func(t *testing.T, data []byte, docx bool) {
+		contentType := TypePDF
+		if docx {
+			contentType = textextract.TypeDOCX
+		}
+		out, err := apply(contentType, data, s)
+		if err == nil && len(out) == 0 {
+			t.Fatal("stamping returned an empty document")
+		}
+	})
+}
+
+func TestApply_Errors(t *testing.T) {
+	_, err := Apply(textextract.TypeMarkdown, []byte("# Title"), Stamp{Watermark: "DRAFT"})
+	require.ErrorIs(t, err, ErrUnsupportedFormat)
+
+	data := testPDF(testPDFObjects, false)
+	out, err := Apply(TypePDF, data, Stamp{Header: "\n \n"})
+	require.NoError(t, err)
+	require.Equal(t, data, out)
+}
+
+func TestStamp_Validate(t *testing.T) {
+	require.NoError(t, Stamp{Watermark: "DRAFT", Image: testPNG(t, 0xff), Opacity: 1}.Validate())
+	require.ErrorIs(t, Stamp{Opacity: 1.5}.Validate(), ErrInvalidStamp)
+	require.ErrorIs(t, Stamp{Header: strings.Repeat("a", MaxTextLength+1)}.Validate(), ErrInvalidStamp)
+	require.ErrorIs(t, Stamp{Image: []byte("GIF89a")}.Validate(), ErrInvalidStamp)
+}
+
+func TestStamp_Expand(t *testing.T) {
+	s := Stamp{Watermark: "{user}", Header: "{name} of {owner} {unknown}", Footer: "{version} {date}"}.Expand(Values{
+		Name:    "report.pdf",
+		Owner:   "owner@example.com",
+		User:    "reader@example.com",
+		Version: "v1",
+		Date:    time.Date(2026, 10, 19, 12, 30, 0, 0, time.FixedZone("CEST", 2*60*60)),
+	})
+	require.Equal(t, Stamp{
+		Watermark: "reader@example.com",
+		Header:    "report.pdf of owner@example.com {unknown}",
+		Footer:    "v1 2026-10-19 10:30 UTC",
+	}, s)
+}
+
+func TestEncodeText(t *testing.T) {
+	require.Equal(t, []byte{'a', ' ', 0xe9, 0x80, '?'}, encodeText("a\té€世"))
+	require.Equal(t, float64(556+278), textWidth([]byte("a ")))
+}
@@ -0,0 +1,66 @@
+package stamp
+
+import (
+	"errors"
+	"time"
+
+	"github.com/a1y/doc-formatter/internal/storage/util/textextract"
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("stamping is not supported for this format")
+	// ErrDocumentTooLarge and ErrMalformedDocument are shared with text
+	// extraction, which bounds documents the same way.
+	ErrDocumentTooLarge  = textextract.ErrDocumentTooLarge
+	ErrMalformedDocument = textextract.ErrMalformedDocument
+	// ErrEncryptedDocument is returned for PDF documents protected by a
+	// password or permissions, which cannot be changed without their keys.
+	ErrEncryptedDocument = errors.New("encrypted documents cannot be stamped")
+	ErrInvalidStamp      = errors.New("invalid stamp")
+)
+
+const (
+	// TypePDF is the media type of PDF documents.
+	TypePDF = "application/pdf"
+	// MaxSize is the largest document stamped.
+	MaxSize = textextract.MaxDocumentSize
+	// MaxImageSize is the size in bytes of the largest watermark image, and
+	// MaxImagePixels the number of pixels of the largest one.
+	MaxImageSize   = 4 << 20
+	MaxImagePixels = 4096 * 4096
+	// MaxTextLength is the length in bytes of the longest watermark, header
+	// or footer.
+	MaxTextLength = 500
+	// DefaultOpacity is the opacity of watermarks when the stamp sets none.
+	DefaultOpacity = 0.3
+)
+
+// Stamp is what is drawn on every page of a document. Its text may hold the
+// placeholders Expand fills.
+type Stamp struct {
+	// Watermark is text set diagonally across the middle of pages, on a
+	// single line.
+	Watermark string
+	// Image is a PNG or JPEG image set in the middle of pages, under the
+	// watermark text.
+	Image []byte
+	// Header and Footer are set centred at the top and the bottom of
+	// pages. They may span lines.
+	Header string
+	Footer string
+	// Opacity of the watermark text and image, from 0 to 1, DefaultOpacity
+	// when zero.
+	Opacity float64
+}
+
+// Values fill the placeholders of the text of a stamp.
+type Values struct {
+	// Name is the file name of the document, Owner the owner and User the
+	// person it is stamped for.
+	Name  string
+	Owner string
+	User  string
+	// Version identifies the content of the document.
+	Version string
+	Date    time.Time
+}
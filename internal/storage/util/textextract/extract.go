@@ -0,0 +1,104 @@
+// Package textextract turns documents into plain text for indexing.
+package textextract
+
+import (
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/a1y/doc-formatter/internal/storage/util/docparse"
+)
+
+// Supported reports whether text can be extracted from documents of
+// contentType.
+func Supported(contentType string) bool {
+	switch contentType {
+	case TypeDOCX, TypeODT, TypeMarkdown, TypeHTML, TypeText:
+		return true
+	default:
+		return false
+	}
+}
+
+// Extract returns the text of the document of contentType read from r,
+// truncated to MaxTextSize bytes. Documents larger than MaxDocumentSize are
+// rejected with ErrDocumentTooLarge.
+func Extract(contentType string, r io.Reader) (string, error) {
+	if !Supported(contentType) {
+		return "", ErrUnsupportedFormat
+	}
+	data, err := readAll(r)
+	if err != nil {
+		return "", err
+	}
+
+	var text string
+	switch contentType {
+	case TypeDOCX:
+		text, err = extractDOCX(data)
+	case TypeODT:
+		text, err = extractODT(data)
+	case TypeHTML:
+		text, err = extractHTML(data)
+	case TypeMarkdown:
+		text = stripMarkdown(data)
+	default:
+		text = string(data)
+	}
+	if err != nil {
+		return "", err
+	}
+	return clean(text), nil
+}
+
+// readAll reads r up to MaxDocumentSize bytes.
+func readAll(r io.Reader) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, MaxDocumentSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > MaxDocumentSize {
+		return nil, ErrDocumentTooLarge
+	}
+	return data, nil
+}
+
+var (
+	blankLines = regexp.MustCompile(`\n{3,}`)
+	spaces     = regexp.MustCompile(`[ \t\r\f\v]+`)
+)
+
+// clean makes text valid UTF-8 without NUL bytes, which PostgreSQL rejects,
+// squeezes runs of blanks and truncates it to MaxTextSize bytes.
+func clean(text string) string {
+	text = strings.ToValidUTF8(text, "")
+	text = strings.ReplaceAll(text, "\x00", "")
+	text = spaces.ReplaceAllString(text, " ")
+	text = strings.ReplaceAll(text, " \n", "\n")
+	text = blankLines.ReplaceAllString(text, "\n\n")
+	text = strings.TrimSpace(text)
+	if len(text) <= MaxTextSize {
+		return text
+	}
+	// Cutting may split the last rune, which is dropped.
+	return strings.ToValidUTF8(text[:MaxTextSize], "")
+}
+
+// stripMarkdown removes the markup of Markdown text, keeping the text of
+// links and the alternative text of images.
+func stripMarkdown(data []byte) string {
+	lines := docparse.MarkdownLines(data)
+	for i, line := range lines {
+		switch {
+		case docparse.ThematicBreak.MatchString(line):
+			line = ""
+		case docparse.ATXHeading.MatchString(line):
+			line = docparse.ATXHeading.FindStringSubmatch(line)[2]
+		default:
+			line = docparse.BlockQuote.ReplaceAllString(line, "")
+			line = docparse.ListItem.ReplaceAllString(line, "")
+		}
+		lines[i] = docparse.InlineText(line)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,115 @@
+package textextract
+
+import (
+	"archive/zip"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// zipDocument packages files as a ZIP archive, the container of DOCX and ODT
+// documents.
+func zipDocument(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, content := range files {
+		f, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func TestExtract(t *testing.T) {
+	docx := zipDocument(t, map[string]string{
+		"word/document.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>
+<w:p><w:r><w:t>Quarterly</w:t></w:r><w:r><w:t xml:space="preserve"> report</w:t></w:r></w:p>
+<w:p><w:r><w:t>Revenue</w:t><w:tab/><w:t>grew</w:t></w:r><w:r><w:delText>fell</w:delText></w:r></w:p>
+</w:body></w:document>`,
+	})
+	odt := zipDocument(t, map[string]string{
+		"content.xml": `<?xml version="1.0" encoding="UTF-8"?>
+<office:document-content xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0">
+<office:body><office:text>
+<text:h>Meeting notes</text:h>
+<text:p>Budget<text:s text:c="3"/>approved<text:line-break/>next week</text:p>
+</office:text></office:body></office:document-content>`,
+	})
+
+	tests := []struct {
+		name        string
+		contentType string
+		data        []byte
+		want        string
+	}{
+		{
+			name:        "docx",
+			contentType: TypeDOCX,
+			data:        docx,
+			want:        "Quarterly report\nRevenue grew",
+		},
+		{
+			name:        "odt",
+			contentType: TypeODT,
+			data:        odt,
+			want:        "Meeting notes\nBudget approved\nnext week",
+		},
+		{
+			name:        "markdown",
+			contentType: TypeMarkdown,
+			data:        []byte("# Release *notes*\n\n- See [the guide](https://example.com) for `setup`\n> ![diagram](d.png)\n\n---\n"),
+			want:        "Release notes\n\nSee the guide for setup\ndiagram",
+		},
+		{
+			name:        "html",
+			contentType: TypeHTML,
+			data:        []byte(`<html><head><title>Ignored</title><style>p{}</style></head><body><h1>Invoice</h1><p>Total &amp; taxes</p><script>alert(1)</script></body></html>`),
+			want:        "Invoice\n\nTotal & taxes",
+		},
+		{
+			name:        "plain text with invalid bytes",
+			contentType: TypeText,
+			data:        []byte("plain\x00 text\xff   here\n\n\n\nend"),
+			want:        "plain text here\n\nend",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Extract(tt.contentType, bytes.NewReader(tt.data))
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestExtract_Errors(t *testing.T) {
+	_, err := Extract("application/pdf", strings.NewReader("%PDF-1.7"))
+	require.ErrorIs(t, err, ErrUnsupportedFormat)
+	require.False(t, Supported("application/pdf"))
+
+	_, err = Extract(TypeDOCX, strings.NewReader("not a zip"))
+	require.ErrorIs(t, err, ErrMalformedDocument)
+
+	_, err = Extract(TypeODT, bytes.NewReader(zipDocument(t, map[string]string{"meta.xml": "<meta/>"})))
+	require.ErrorIs(t, err, ErrMalformedDocument)
+
+	_, err = Extract(TypeText, bytes.NewReader(make([]byte, MaxDocumentSize+1)))
+	require.ErrorIs(t, err, ErrDocumentTooLarge)
+}
+
+func TestExtract_TruncatesText(t *testing.T) {
+	// A two byte rune straddles the limit and must not be split.
+	text := strings.Repeat("a", MaxTextSize-1) + "é" + "tail"
+
+	got, err := Extract(TypeText, strings.NewReader(text))
+	require.NoError(t, err)
+	require.Equal(t, strings.Repeat("a", MaxTextSize-1), got)
+}
@@ -0,0 +1,66 @@
+package textextract
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// blockElements end a line of the extracted text of an HTML document.
+var blockElements = map[string]bool{
+	"address": true, "article": true, "aside": true, "blockquote": true, "br": true,
+	"dd": true, "div": true, "dl": true, "dt": true, "figcaption": true,
+	"footer": true, "h1": true, "h2": true, "h3": true, "h4": true, "h5": true,
+	"h6": true, "header": true, "hr": true, "li": true, "main": true, "nav": true,
+	"ol": true, "p": true, "pre": true, "section": true, "table": true,
+	"td": true, "th": true, "tr": true, "ul": true,
+}
+
+// skippedElements hold no text worth indexing.
+var skippedElements = map[string]bool{
+	"head": true, "script": true, "style": true, "template": true,
+}
+
+// extractHTML returns the text of an HTML document, leaving out scripts,
+// styles and the document head.
+func extractHTML(data []byte) (string, error) {
+	tokenizer := html.NewTokenizer(bytes.NewReader(data))
+	var text strings.Builder
+	skip := 0
+	for text.Len() <= MaxTextSize {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			if err := tokenizer.Err(); !errors.Is(err, io.EOF) {
+				return "", fmt.Errorf("%w: %v", ErrMalformedDocument, err)
+			}
+			return text.String(), nil
+		case html.StartTagToken:
+			name, _ := tokenizer.TagName()
+			if skippedElements[string(name)] {
+				skip++
+			} else if blockElements[string(name)] {
+				text.WriteByte('\n')
+			}
+		case html.SelfClosingTagToken:
+			if name, _ := tokenizer.TagName(); blockElements[string(name)] {
+				text.WriteByte('\n')
+			}
+		case html.EndTagToken:
+			name, _ := tokenizer.TagName()
+			if skippedElements[string(name)] {
+				skip = max(skip-1, 0)
+			} else if blockElements[string(name)] {
+				text.WriteByte('\n')
+			}
+		case html.TextToken:
+			if skip == 0 {
+				text.Write(tokenizer.Text())
+			}
+		}
+	}
+	return text.String(), nil
+}
@@ -0,0 +1,94 @@
+package textextract
+
+import (
+	"encoding/xml"
+	"strconv"
+	"strings"
+
+	"github.com/a1y/doc-formatter/internal/storage/util/docparse"
+)
+
+// extractDOCX returns the text of the body of a WordprocessingML document,
+// one line per paragraph.
+func extractDOCX(data []byte) (string, error) {
+	pkg, err := docparse.OpenPackage(data)
+	if err != nil {
+		return "", err
+	}
+
+	var text strings.Builder
+	inText := false
+	err = pkg.Walk("word/document.xml", true, func(token xml.Token) bool {
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "t":
+				inText = true
+			case "tab":
+				text.WriteByte('\t')
+			case "br", "cr":
+				text.WriteByte('\n')
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "t":
+				inText = false
+			case "p":
+				text.WriteByte('\n')
+			}
+		case xml.CharData:
+			if inText {
+				text.Write(t)
+			}
+		}
+		return text.Len() <= MaxTextSize
+	})
+	return text.String(), err
+}
+
+// extractODT returns the text of an OpenDocument text document, one line
+// per paragraph or heading.
+func extractODT(data []byte) (string, error) {
+	pkg, err := docparse.OpenPackage(data)
+	if err != nil {
+		return "", err
+	}
+
+	var text strings.Builder
+	depth := 0
+	err = pkg.Walk("content.xml", true, func(token xml.Token) bool {
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "p", "h":
+				depth++
+			case "s":
+				// text:s stands for text:c spaces, one by default.
+				count := 1
+				for _, attr := range t.Attr {
+					if attr.Name.Local == "c" {
+						if n, err := strconv.Atoi(attr.Value); err == nil && n > 0 {
+							count = min(n, 64)
+						}
+					}
+				}
+				text.WriteString(strings.Repeat(" ", count))
+			case "tab":
+				text.WriteByte('\t')
+			case "line-break":
+				text.WriteByte('\n')
+			}
+		case xml.EndElement:
+			if t.Name.Local == "p" || t.Name.Local == "h" {
+				depth--
+				text.WriteByte('\n')
+			}
+		case xml.CharData:
+			if depth > 0 {
+				text.Write(t)
+			}
+		}
+		return text.Len() <= MaxTextSize
+	})
+	return text.String(), err
+}
@@ -0,0 +1,31 @@
+package textextract
+
+import (
+	"errors"
+
+	"github.com/a1y/doc-formatter/internal/storage/util/docparse"
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("text extraction is not supported for this format")
+	ErrDocumentTooLarge  = docparse.ErrDocumentTooLarge
+	ErrMalformedDocument = docparse.ErrMalformedDocument
+)
+
+const (
+	// MaxDocumentSize is the largest document, or document part once
+	// decompressed, read to extract text.
+	MaxDocumentSize = docparse.MaxDocumentSize
+	// MaxTextSize is the size the extracted text is truncated to. PostgreSQL
+	// caps a tsvector at 1 MiB, so indexing more text would fail anyway.
+	MaxTextSize = 512 << 10
+)
+
+// Media types text can be extracted from.
+const (
+	TypeDOCX     = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+	TypeODT      = "application/vnd.oasis.opendocument.text"
+	TypeMarkdown = "text/markdown"
+	TypeHTML     = "text/html"
+	TypeText     = "text/plain"
+)
@@ -0,0 +1,147 @@
+// Package thumbnail renders PNG previews of the first page of documents.
+package thumbnail
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/a1y/doc-formatter/internal/storage/util/textextract"
+	xdraw "golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+)
+
+var textColor = color.Gray{Y: 0x20}
+
+// regularFont is parsed once, faces are not safe for concurrent use and are
+// made for each rendering.
+var regularFont = sync.OnceValues(func() (*opentype.Font, error) {
+	return opentype.Parse(goregular.TTF)
+})
+
+// Supported reports whether thumbnails can be rendered for documents of
+// contentType.
+func Supported(contentType string) bool {
+	return textextract.Supported(contentType)
+}
+
+// Render returns a PNG thumbnail of the first page of the document of
+// contentType read from r. The page is laid out from the text of the
+// document, so styling, images and tables are not shown.
+func Render(contentType string, r io.Reader) ([]byte, error) {
+	if !Supported(contentType) {
+		return nil, ErrUnsupportedFormat
+	}
+	text, err := textextract.Extract(contentType, r)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := renderPage(text)
+	if err != nil {
+		return nil, err
+	}
+	thumb := image.NewGray(image.Rect(0, 0, Width, Width*pageHeight/pageWidth))
+	xdraw.CatmullRom.Scale(thumb, thumb.Bounds(), page, page.Bounds(), xdraw.Src, nil)
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, thumb); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// renderPage draws the lines of text that fit on the first page.
+func renderPage(text string) (*image.Gray, error) {
+	face, err := newFace()
+	if err != nil {
+		return nil, err
+	}
+	defer face.Close()
+
+	page := image.NewGray(image.Rect(0, 0, pageWidth, pageHeight))
+	xdraw.Draw(page, page.Bounds(), image.White, image.Point{}, xdraw.Src)
+	drawer := &font.Drawer{Dst: page, Src: image.NewUniform(textColor), Face: face}
+
+	width := fixed.I(pageWidth - 2*pageMargin)
+	y := pageMargin + lineHeight
+	for _, paragraph := range strings.Split(text, "\n") {
+		if strings.TrimSpace(paragraph) == "" {
+			// Blank lines separate paragraphs.
+			y += lineHeight / 2
+			continue
+		}
+		for _, line := range wrap(face, paragraph, width) {
+			if y > pageHeight-pageMargin {
+				return page, nil
+			}
+			drawer.Dot = fixed.P(pageMargin, y)
+			drawer.DrawString(line)
+			y += lineHeight
+		}
+	}
+	return page, nil
+}
+
+// newFace returns a face of the regular font at fontSize pixels.
+func newFace() (font.Face, error) {
+	f, err := regularFont()
+	if err != nil {
+		return nil, err
+	}
+	return opentype.NewFace(f, &opentype.FaceOptions{
+		Size:    fontSize,
+		DPI:     72,
+		Hinting: font.HintingFull,
+	})
+}
+
+// wrap breaks paragraph into lines no wider than width. Words wider than a
+// line are broken between characters.
+func wrap(face font.Face, paragraph string, width fixed.Int26_6) []string {
+	space := font.MeasureString(face, " ")
+	var (
+		lines     []string
+		line      strings.Builder
+		lineWidth fixed.Int26_6
+	)
+	flush := func() {
+		if line.Len() > 0 {
+			lines = append(lines, line.String())
+			line.Reset()
+			lineWidth = 0
+		}
+	}
+	for _, word := range strings.Fields(paragraph) {
+		wordWidth := font.MeasureString(face, word)
+		if line.Len() > 0 && lineWidth+space+wordWidth > width {
+			flush()
+		}
+		if wordWidth > width {
+			for _, r := range word {
+				runeWidth := font.MeasureString(face, string(r))
+				if lineWidth+runeWidth > width {
+					flush()
+				}
+				line.WriteRune(r)
+				lineWidth += runeWidth
+			}
+			continue
+		}
+		if line.Len() > 0 {
+			line.WriteByte(' ')
+			lineWidth += space
+		}
+		line.WriteString(word)
+		lineWidth += wordWidth
+	}
+	flush()
+	return lines
+}
@@ -0,0 +1,81 @@
+package thumbnail
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/image/font"
+)
+
+// inkedRows returns the number of rows of img holding a pixel darker than
+// the page.
+func inkedRows(img image.Image) int {
+	rows := 0
+	bounds := img.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			if r, _, _, _ := img.At(x, y).RGBA(); r < 0xe000 {
+				rows++
+				break
+			}
+		}
+	}
+	return rows
+}
+
+func TestRender(t *testing.T) {
+	data, err := Render("text/markdown", strings.NewReader("# Quarterly report\n\nRevenue grew in every region."))
+	require.NoError(t, err)
+
+	img, err := png.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+	require.Equal(t, image.Rect(0, 0, Width, Width*pageHeight/pageWidth), img.Bounds())
+	require.Positive(t, inkedRows(img))
+
+	blank, err := Render("text/plain", strings.NewReader(""))
+	require.NoError(t, err)
+	img, err = png.Decode(bytes.NewReader(blank))
+	require.NoError(t, err)
+	require.Zero(t, inkedRows(img))
+}
+
+func TestRender_OnlyFirstPage(t *testing.T) {
+	long, err := Render("text/plain", strings.NewReader(strings.Repeat("All work and no play.\n", 500)))
+	require.NoError(t, err)
+	img, err := png.Decode(bytes.NewReader(long))
+	require.NoError(t, err)
+
+	// The bottom margin stays blank.
+	bounds := img.Bounds()
+	margin := bounds.Dy() * pageMargin / pageHeight
+	bottom := img.(interface {
+		SubImage(image.Rectangle) image.Image
+	}).SubImage(image.Rect(0, bounds.Max.Y-margin+2, bounds.Max.X, bounds.Max.Y))
+	require.Zero(t, inkedRows(bottom))
+}
+
+func TestRender_Unsupported(t *testing.T) {
+	_, err := Render("application/pdf", strings.NewReader("%PDF-1.7"))
+	require.ErrorIs(t, err, ErrUnsupportedFormat)
+
+	_, err = Render("application/vnd.openxmlformats-officedocument.wordprocessingml.document", strings.NewReader("not a zip"))
+	require.ErrorIs(t, err, ErrMalformedDocument)
+}
+
+func TestWrap(t *testing.T) {
+	face, err := newFace()
+	require.NoError(t, err)
+	defer face.Close()
+
+	width := font.MeasureString(face, "ipsum lorem ipsum")
+	lines := wrap(face, strings.Repeat("lorem ipsum ", 3)+strings.Repeat("x", 60), width)
+	require.Equal(t, []string{"lorem ipsum lorem", "ipsum lorem ipsum"}, lines[:2])
+	for _, line := range lines {
+		require.LessOrEqual(t, font.MeasureString(face, line), width, line)
+	}
+	require.Equal(t, strings.Repeat("x", 60), strings.Join(lines[2:], ""))
+}
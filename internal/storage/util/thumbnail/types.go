@@ -0,0 +1,33 @@
+package thumbnail
+
+import (
+	"errors"
+
+	"github.com/a1y/doc-formatter/internal/storage/util/textextract"
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("thumbnails are not supported for this format")
+	// ErrDocumentTooLarge and ErrMalformedDocument are shared with text
+	// extraction, which the page is laid out from.
+	ErrDocumentTooLarge  = textextract.ErrDocumentTooLarge
+	ErrMalformedDocument = textextract.ErrMalformedDocument
+)
+
+const (
+	// ContentType is the media type of rendered thumbnails.
+	ContentType = "image/png"
+	// Width is the width in pixels of thumbnails. Their height follows the
+	// proportions of an A4 page.
+	Width = 256
+)
+
+// The first page is laid out as an A4 page at 96 DPI with margins of an inch
+// and 12pt text, then scaled down to Width.
+const (
+	pageWidth  = 794
+	pageHeight = 1123
+	pageMargin = 96
+	fontSize   = 16
+	lineHeight = 22
+)
@@ -20,12 +20,18 @@ type Argon2idHash struct {
 	saltLen uint32
 }
 
+// Default key and salt lengths, in bytes.
+const (
+	DefaultKeyLen  = 32
+	DefaultSaltLen = 16
+)
+
 var DefaultArgon2id = Argon2idHash{
 	time:    1,
 	memory:  64 * 1024,
 	threads: uint8(runtime.NumCPU()),
-	keyLen:  32,
-	saltLen: 16,
+	keyLen:  DefaultKeyLen,
+	saltLen: DefaultSaltLen,
 }
 
 func NewDefaultArgon2idHash() *Argon2idHash {
@@ -121,3 +127,18 @@ func Compare(password, encodedHash string) (bool, error) {
 	}
 	return false, nil
 }
+
+// NeedsRehash reports whether encodedHash was made with weaker parameters
+// than a: fewer iterations, less memory or a shorter key or salt. The number
+// of threads only spreads the same work, so hashes made with a different
+// number of threads do not need to be rehashed.
+func (a *Argon2idHash) NeedsRehash(encodedHash string) (bool, error) {
+	stored, _, _, err := decodeHash(encodedHash)
+	if err != nil {
+		return false, err
+	}
+	return stored.time < a.time ||
+		stored.memory < a.memory ||
+		stored.keyLen < a.keyLen ||
+		stored.saltLen < a.saltLen, nil
+}
@@ -114,3 +114,37 @@ func TestDecodeHash(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestArgon2idHash_NeedsRehash(t *testing.T) {
+	password := "securepassword"
+	current := NewArgon2idHash(2, 8*1024, 2, 32, 16)
+
+	tests := []struct {
+		name   string
+		hasher *Argon2idHash
+		want   bool
+	}{
+		{name: "Same", hasher: current, want: false},
+		{name: "OtherThreads", hasher: NewArgon2idHash(2, 8*1024, 1, 32, 16), want: false},
+		{name: "Stronger", hasher: NewArgon2idHash(3, 16*1024, 2, 64, 24), want: false},
+		{name: "FewerIterations", hasher: NewArgon2idHash(1, 8*1024, 2, 32, 16), want: true},
+		{name: "LessMemory", hasher: NewArgon2idHash(2, 4*1024, 2, 32, 16), want: true},
+		{name: "ShorterKey", hasher: NewArgon2idHash(2, 8*1024, 2, 16, 16), want: true},
+		{name: "ShorterSalt", hasher: NewArgon2idHash(2, 8*1024, 2, 32, 8), want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hash, err := tt.hasher.HashPassword(password, nil)
+			assert.NoError(t, err)
+
+			got, err := current.NeedsRehash(hash)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("InvalidHashFormat", func(t *testing.T) {
+		_, err := current.NeedsRehash("invalid$hash$format")
+		assert.Equal(t, ErrInvalidHashFormat, err)
+	})
+}
@@ -0,0 +1,104 @@
+package throttle
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// sweepEvery is the number of failures recorded between sweeps of counts
+// that have expired.
+const sweepEvery = 1024
+
+var _ Store = &memoryStore{}
+
+type memoryStore struct {
+	mu       sync.Mutex
+	attempts map[string]*Attempt
+	// window is the longest window failures were recorded with, past which
+	// unlocked counts are swept.
+	window   time.Duration
+	recorded int
+}
+
+// NewMemoryStore returns a Store keeping the counts in process memory, for
+// a single instance of a service.
+func NewMemoryStore() Store {
+	return &memoryStore{
+		attempts: make(map[string]*Attempt),
+	}
+}
+
+func (r *memoryStore) Get(ctx context.Context, keys []string) ([]*Attempt, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var attempts []*Attempt
+	for _, key := range keys {
+		if attempt, ok := r.attempts[key]; ok {
+			attempts = append(attempts, copyAttempt(attempt))
+		}
+	}
+	return attempts, nil
+}
+
+func (r *memoryStore) RecordFailure(ctx context.Context, key string, now time.Time, window time.Duration) (*Attempt, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if window > r.window {
+		r.window = window
+	}
+	r.recorded++
+	if r.recorded%sweepEvery == 0 {
+		r.sweep(now)
+	}
+
+	attempt, ok := r.attempts[key]
+	if !ok {
+		attempt = &Attempt{ID: uuid.New(), Key: key, CreatedAt: now}
+		r.attempts[key] = attempt
+	}
+	if attempt.LastFailureAt.After(now.Add(-window)) {
+		attempt.Failures++
+	} else {
+		attempt.Failures = 1
+	}
+	attempt.LastFailureAt = now
+	return copyAttempt(attempt), nil
+}
+
+func (r *memoryStore) Lock(ctx context.Context, key string, until time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if attempt, ok := r.attempts[key]; ok {
+		attempt.LockedUntil = &until
+	}
+	return nil
+}
+
+func (r *memoryStore) Reset(ctx context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.attempts, key)
+	return nil
+}
+
+// sweep drops the counts that would start over and are not locked, so that
+// failures from many keys do not pile up.
+func (r *memoryStore) sweep(now time.Time) {
+	for key, attempt := range r.attempts {
+		if !attempt.LastFailureAt.After(now.Add(-r.window)) && attempt.RetryAfter(now) == 0 {
+			delete(r.attempts, key)
+		}
+	}
+}
+
+func copyAttempt(attempt *Attempt) *Attempt {
+	c := *attempt
+	if attempt.LockedUntil != nil {
+		until := *attempt.LockedUntil
+		c.LockedUntil = &until
+	}
+	return &c
+}
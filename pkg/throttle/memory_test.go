@@ -0,0 +1,61 @@
+package throttle
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore(t *testing.T) {
+	repo := NewMemoryStore()
+	ctx := context.Background()
+	now := time.Now()
+	key := "account:user@example.com"
+
+	attempts, err := repo.Get(ctx, []string{key})
+	require.NoError(t, err)
+	assert.Empty(t, attempts)
+
+	for i := 1; i <= 3; i++ {
+		attempt, err := repo.RecordFailure(ctx, key, now.Add(time.Duration(i)*time.Second), time.Hour)
+		require.NoError(t, err)
+		assert.Equal(t, i, attempt.Failures)
+	}
+
+	until := now.Add(time.Minute)
+	require.NoError(t, repo.Lock(ctx, key, until))
+	attempts, err = repo.Get(ctx, []string{key, "ip:192.0.2.1"})
+	require.NoError(t, err)
+	require.Len(t, attempts, 1)
+	assert.Equal(t, 3, attempts[0].Failures)
+	assert.Equal(t, time.Minute, attempts[0].RetryAfter(now))
+
+	// Failures older than the window are no longer counted.
+	attempt, err := repo.RecordFailure(ctx, key, now.Add(2*time.Hour), time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, 1, attempt.Failures)
+
+	require.NoError(t, repo.Reset(ctx, key))
+	attempts, err = repo.Get(ctx, []string{key})
+	require.NoError(t, err)
+	assert.Empty(t, attempts)
+}
+
+func TestMemoryStore_Sweep(t *testing.T) {
+	repo := NewMemoryStore().(*memoryStore)
+	ctx := context.Background()
+	now := time.Now()
+
+	_, err := repo.RecordFailure(ctx, "ip:192.0.2.1", now, time.Minute)
+	require.NoError(t, err)
+	_, err = repo.RecordFailure(ctx, "ip:192.0.2.2", now, time.Minute)
+	require.NoError(t, err)
+	require.NoError(t, repo.Lock(ctx, "ip:192.0.2.2", now.Add(time.Hour)))
+
+	repo.sweep(now.Add(10 * time.Minute))
+	assert.NotContains(t, repo.attempts, "ip:192.0.2.1")
+	assert.Contains(t, repo.attempts, "ip:192.0.2.2")
+}
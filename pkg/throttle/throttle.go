@@ -0,0 +1,103 @@
+// Package throttle locks keys, such as accounts, share links or client
+// addresses, after too many failed attempts, for a while that grows with
+// every further failure.
+package throttle
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Check returns a *LockedError when any of keys is locked. Empty keys are
+// skipped.
+func (t *Throttle) Check(ctx context.Context, now time.Time, keys ...string) error {
+	if t == nil {
+		return nil
+	}
+	attempts, err := t.store.Get(ctx, nonEmpty(keys))
+	if err != nil {
+		return err
+	}
+	var retryAfter time.Duration
+	for _, attempt := range attempts {
+		retryAfter = max(retryAfter, attempt.RetryAfter(now))
+	}
+	if retryAfter > 0 {
+		return &LockedError{RetryAfter: retryAfter}
+	}
+	return nil
+}
+
+// RecordFailure counts a failure for key, locking it once it reached
+// maxFailures. Keys are never locked when maxFailures is zero. Failing to
+// count is logged, so that callers do not depend on it.
+func (t *Throttle) RecordFailure(ctx context.Context, now time.Time, key string, maxFailures int) {
+	if t == nil || key == "" || maxFailures <= 0 {
+		return
+	}
+	window := t.policy.Window
+	if window <= 0 {
+		window = DefaultWindow
+	}
+	attempt, err := t.store.RecordFailure(ctx, key, now, window)
+	if err != nil {
+		logrus.Warnf("Failed to count failed attempt for %s: %v", key, err)
+		return
+	}
+	lockout := t.policy.LockoutFor(attempt.Failures, maxFailures)
+	if lockout == 0 {
+		return
+	}
+	if err := t.store.Lock(ctx, key, now.Add(lockout)); err != nil {
+		logrus.Warnf("Failed to lock %s: %v", key, err)
+		return
+	}
+	logrus.Warnf("%s locked for %s after %d failed attempts", key, lockout, attempt.Failures)
+}
+
+// Reset forgets the failures for key. Failing to is logged.
+func (t *Throttle) Reset(ctx context.Context, key string) {
+	if t == nil || key == "" {
+		return
+	}
+	if err := t.store.Reset(ctx, key); err != nil {
+		logrus.Warnf("Failed to reset failed attempts for %s: %v", key, err)
+	}
+}
+
+// LockoutFor returns how long a key is locked after failures, zero below
+// maxFailures. The lockout doubles with every failure past maxFailures, up
+// to the maximum lockout.
+func (p Policy) LockoutFor(failures, maxFailures int) time.Duration {
+	if failures < maxFailures {
+		return 0
+	}
+	lockout := p.Lockout
+	if lockout <= 0 {
+		lockout = DefaultLockout
+	}
+	limit := p.MaxLockout
+	if limit <= 0 {
+		limit = time.Duration(math.MaxInt64)
+	}
+	for i := maxFailures; i < failures; i++ {
+		if lockout > limit/2 {
+			return limit
+		}
+		lockout *= 2
+	}
+	return min(lockout, limit)
+}
+
+func nonEmpty(keys []string) []string {
+	out := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if key != "" {
+			out = append(out, key)
+		}
+	}
+	return out
+}
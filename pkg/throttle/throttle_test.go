@@ -0,0 +1,73 @@
+package throttle
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestThrottle(t *testing.T) {
+	throttle := New(NewMemoryStore(), Policy{Lockout: time.Minute})
+	ctx := context.Background()
+	now := time.Now()
+
+	for range 2 {
+		require.NoError(t, throttle.Check(ctx, now, "link:a", "ip:192.0.2.1"))
+		throttle.RecordFailure(ctx, now, "link:a", 3)
+	}
+	throttle.RecordFailure(ctx, now, "link:a", 3)
+
+	// The lock holds for every key checked along with the locked one.
+	err := throttle.Check(ctx, now, "link:a", "ip:192.0.2.1")
+	var lockedErr *LockedError
+	require.ErrorAs(t, err, &lockedErr)
+	assert.ErrorIs(t, err, ErrLocked)
+	assert.Equal(t, time.Minute, lockedErr.RetryAfter)
+	assert.NoError(t, throttle.Check(ctx, now, "link:b", "ip:192.0.2.1"))
+	assert.NoError(t, throttle.Check(ctx, now.Add(time.Minute), "link:a"))
+
+	throttle.Reset(ctx, "link:a")
+	assert.NoError(t, throttle.Check(ctx, now, "link:a"))
+
+	// A zero maximum never locks, and empty keys are skipped.
+	for range 10 {
+		throttle.RecordFailure(ctx, now, "ip:192.0.2.1", 0)
+		throttle.RecordFailure(ctx, now, "", 1)
+	}
+	assert.NoError(t, throttle.Check(ctx, now, "ip:192.0.2.1", ""))
+}
+
+func TestThrottle_Nil(t *testing.T) {
+	throttle := New(nil, Policy{})
+	require.Nil(t, throttle)
+
+	ctx := context.Background()
+	throttle.RecordFailure(ctx, time.Now(), "link:a", 1)
+	throttle.Reset(ctx, "link:a")
+	assert.NoError(t, throttle.Check(ctx, time.Now(), "link:a"))
+}
+
+func TestPolicy_LockoutFor(t *testing.T) {
+	policy := Policy{Lockout: time.Minute, MaxLockout: 10 * time.Minute}
+
+	tests := []struct {
+		failures int
+		want     time.Duration
+	}{
+		{failures: 4, want: 0},
+		{failures: 5, want: time.Minute},
+		{failures: 6, want: 2 * time.Minute},
+		{failures: 8, want: 8 * time.Minute},
+		{failures: 9, want: 10 * time.Minute},
+		{failures: 1000, want: 10 * time.Minute},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, policy.LockoutFor(tt.failures, 5), "failures %d", tt.failures)
+	}
+
+	assert.Equal(t, DefaultLockout, Policy{}.LockoutFor(5, 5))
+	assert.Greater(t, Policy{}.LockoutFor(200, 5), time.Duration(0))
+}
@@ -0,0 +1,94 @@
+package throttle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Defaults used when a Policy sets none: the first lockout lasts a minute,
+// and failures are counted until a day has passed without any.
+const (
+	DefaultLockout = time.Minute
+	DefaultWindow  = 24 * time.Hour
+)
+
+var ErrLocked = errors.New("too many failed attempts")
+
+// LockedError is returned when a key is locked after too many failures.
+type LockedError struct {
+	// RetryAfter is how long the key stays locked.
+	RetryAfter time.Duration
+}
+
+func (e *LockedError) Error() string {
+	return fmt.Sprintf("%s, retry in %s", ErrLocked, e.RetryAfter.Round(time.Second))
+}
+
+func (e *LockedError) Unwrap() error {
+	return ErrLocked
+}
+
+// Attempt counts the failures for a key, such as an account or a client
+// address, and how long the key is locked.
+type Attempt struct {
+	ID            uuid.UUID  `yaml:"id" json:"id"`
+	Key           string     `yaml:"key" json:"key"`
+	Failures      int        `yaml:"failures" json:"failures"`
+	LastFailureAt time.Time  `yaml:"last_failure_at" json:"last_failure_at"`
+	LockedUntil   *time.Time `yaml:"locked_until" json:"locked_until"`
+	CreatedAt     time.Time  `yaml:"created_at" json:"created_at"`
+}
+
+// RetryAfter returns how long the key stays locked after now, or zero when
+// it is not locked.
+func (a *Attempt) RetryAfter(now time.Time) time.Duration {
+	if a.LockedUntil == nil || !a.LockedUntil.After(now) {
+		return 0
+	}
+	return a.LockedUntil.Sub(now)
+}
+
+// Store keeps the failure counts of keys.
+type Store interface {
+	// Get returns the counts of the keys that have any.
+	Get(ctx context.Context, keys []string) ([]*Attempt, error)
+	// RecordFailure counts a failure for key at now. The count starts over
+	// when the previous failure is older than window.
+	RecordFailure(ctx context.Context, key string, now time.Time, window time.Duration) (*Attempt, error)
+	// Lock locks key until the given time.
+	Lock(ctx context.Context, key string, until time.Time) error
+	// Reset forgets the failures for key.
+	Reset(ctx context.Context, key string) error
+}
+
+// Policy controls how long keys are locked for.
+type Policy struct {
+	// Lockout is how long the first lockout lasts. Every further failure
+	// doubles it.
+	Lockout time.Duration
+	// MaxLockout caps how long lockouts last. They are not capped when it is
+	// zero.
+	MaxLockout time.Duration
+	// Window is how long failures are counted for. Counts start over after
+	// a window without failures.
+	Window time.Duration
+}
+
+// Throttle locks keys after too many failures, counting them in a store. A
+// nil *Throttle never locks.
+type Throttle struct {
+	store  Store
+	policy Policy
+}
+
+// New returns a Throttle counting failures in store, nil when store is nil.
+func New(store Store, policy Policy) *Throttle {
+	if store == nil {
+		return nil
+	}
+	return &Throttle{store: store, policy: policy}
+}